@@ -0,0 +1,295 @@
+// Package client is a typed Go client for the DeltaGov API.
+//
+// The types and methods in client_gen.go are generated from the API's
+// OpenAPI document by cmd/genclient; regenerate them with go generate
+// after changing the API. This file is the hand-written runtime they use.
+//
+//	c := client.New("https://api.deltagov.org")
+//	bill, err := c.GetBill(ctx, 42, nil)
+//
+//	for bill, err := range c.SearchBillsAll(ctx, &client.SearchBillsParams{Congress: 119}) {
+//		...
+//	}
+//
+//	stream, err := c.StreamDiff(ctx, 42, 1, 2)
+//	defer stream.Close()
+//	for stream.Next() {
+//		record := stream.Record()
+//		...
+//	}
+package client
+
+//go:generate go run ../cmd/genclient -go client_gen.go -ts ../../frontend/src/app/api/deltagov-client.ts
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client calls the DeltaGov API. It is safe for concurrent use.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	header     http.Header // Sent with every request unless the request sets it
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// WithHTTPClient sends requests with hc instead of a client with a
+// two-minute timeout.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithAPIKey authenticates watchlist requests with a user's API key.
+func WithAPIKey(key string) Option {
+	return WithHeader("X-API-Key", key)
+}
+
+// WithAdminToken authenticates admin requests with the server's
+// ADMIN_TOKEN.
+func WithAdminToken(token string) Option {
+	return WithHeader("Authorization", "Bearer "+token)
+}
+
+// WithSessionToken authenticates requests as a signed-in user, with the
+// session token returned by the login callback.
+func WithSessionToken(token string) Option {
+	return WithHeader("Authorization", "Bearer "+token)
+}
+
+// WithHeader sends a header with every request.
+func WithHeader(key, value string) Option {
+	return func(c *Client) { c.header.Set(key, value) }
+}
+
+// New returns a client of the API at baseURL, its origin, e.g.,
+// "https://api.deltagov.org".
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 2 * time.Minute}, // Uncached diffs of large bills are slow
+		header:     http.Header{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Error is an error response from the API. Code identifies the error, e.g.,
+// "BILL_NOT_FOUND"; see the API's error codes.
+type Error struct {
+	StatusCode int
+	ErrorModel
+}
+
+func (e *Error) Error() string {
+	msg := e.Detail
+	if msg == "" {
+		msg = e.Title
+	}
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.Code != "" {
+		return fmt.Sprintf("deltagov: %d %s: %s", e.StatusCode, e.Code, msg)
+	}
+	return fmt.Sprintf("deltagov: %d: %s", e.StatusCode, msg)
+}
+
+// IsNotModified reports whether err is the API's response to an
+// If-None-Match request whose ETag still matches.
+func IsNotModified(err error) bool {
+	var apiErr *Error
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotModified
+}
+
+// do sends a request with a JSON body, if any, and decodes the JSON
+// response into out, if not nil.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, header http.Header, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("deltagov: failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	resp, err := c.send(ctx, method, path, query, header, reader, "application/json")
+	if err != nil {
+		return err
+	}
+	defer resp.Close()
+
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp)
+		return nil
+	}
+	if err := json.NewDecoder(resp).Decode(out); err != nil {
+		return fmt.Errorf("deltagov: failed to decode %s %s: %w", method, path, err)
+	}
+	return nil
+}
+
+// open sends a request and returns the response body for the caller to
+// read and close.
+func (c *Client) open(ctx context.Context, method, path string, query url.Values, header http.Header, accept string) (io.ReadCloser, error) {
+	return c.send(ctx, method, path, query, header, nil, accept)
+}
+
+// send sends a request, returning the body of a successful response and
+// an *Error for any other.
+func (c *Client) send(ctx context.Context, method, path string, query url.Values, header http.Header, body io.Reader, accept string) (io.ReadCloser, error) {
+	target := c.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("deltagov: %w", err)
+	}
+	for key, values := range c.header {
+		req.Header[key] = values
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("deltagov: %s %s: %w", method, path, err)
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.Body, nil
+	}
+	defer resp.Body.Close()
+
+	apiErr := &Error{StatusCode: resp.StatusCode}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	_ = json.Unmarshal(data, &apiErr.ErrorModel) // Not every error has a problem body, e.g., 304
+	return nil, apiErr
+}
+
+// Stream reads the records of an NDJSON response one at a time:
+//
+//	for stream.Next() {
+//		record := stream.Record()
+//	}
+//	if err := stream.Err(); err != nil {
+//		...
+//	}
+//
+// Close it when done, whether or not it was read to the end.
+type Stream[T any] struct {
+	body    io.ReadCloser
+	scanner *bufio.Scanner
+	record  T
+	err     error
+}
+
+// maxRecordSize bounds one NDJSON record, such as a diff line.
+const maxRecordSize = 16 << 20
+
+func newStream[T any](body io.ReadCloser) *Stream[T] {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), maxRecordSize)
+	return &Stream[T]{body: body, scanner: scanner}
+}
+
+// Next reads the next record, reporting whether there was one.
+func (s *Stream[T]) Next() bool {
+	if s.err != nil {
+		return false
+	}
+	for s.scanner.Scan() {
+		line := bytes.TrimSpace(s.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var record T
+		if err := json.Unmarshal(line, &record); err != nil {
+			s.err = fmt.Errorf("deltagov: invalid stream record: %w", err)
+			return false
+		}
+		s.record = record
+		return true
+	}
+	s.err = s.scanner.Err()
+	return false
+}
+
+// Record returns the record read by the last call to Next.
+func (s *Stream[T]) Record() T {
+	return s.record
+}
+
+// Err returns the error that stopped Next, if any.
+func (s *Stream[T]) Err() error {
+	return s.err
+}
+
+// Close closes the response.
+func (s *Stream[T]) Close() error {
+	return s.body.Close()
+}
+
+// setParam sets a query or header parameter unless value is its zero
+// value, which the API treats as absent. Times are sent in RFC 3339.
+func setParam[T comparable](set func(key, value string), key string, value T) {
+	var zero T
+	if value == zero {
+		return
+	}
+	if t, ok := any(value).(time.Time); ok {
+		set(key, t.Format(time.RFC3339))
+		return
+	}
+	set(key, fmt.Sprint(value))
+}
+
+// pathParam formats a path parameter.
+func pathParam(value any) string {
+	return url.PathEscape(fmt.Sprint(value))
+}
+
+// paginate iterates over the items of every page, fetching them from
+// offset on until a page comes back empty or the total is reached.
+func paginate[T any](offset int, fetch func(offset int) ([]T, int, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			items, total, err := fetch(offset)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			offset += len(items)
+			if len(items) == 0 || offset >= total {
+				return
+			}
+		}
+	}
+}
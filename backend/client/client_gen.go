@@ -0,0 +1,4402 @@
+// Code generated by genclient from the DeltaGov OpenAPI document. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"io"
+	"iter"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// AccountHistoryResponse is the API's AccountHistoryResponse schema.
+type AccountHistoryResponse struct {
+	Account string `json:"account"`
+	Agency  string `json:"agency,omitempty"`
+	Bureau  string `json:"bureau,omitempty"`
+	// Funding by fiscal year, as enacted or else as in the latest version.
+	FiscalYears  []FiscalYearLevel `json:"fiscalYears"`
+	Key          string            `json:"key"`
+	Subcommittee string            `json:"subcommittee,omitempty"`
+	// Every version that funds the account, oldest first.
+	Versions []AccountLevel `json:"versions"`
+}
+
+// AccountLevel is the API's AccountLevel schema.
+type AccountLevel struct {
+	Account string `json:"account"`
+	Agency  string `json:"agency,omitempty"`
+	// Whole dollars.
+	Amount int          `json:"amount"`
+	Bill   BillResponse `json:"bill"`
+	Bureau string       `json:"bureau,omitempty"`
+	// The bill became law.
+	Enacted    bool      `json:"enacted"`
+	FetchedAt  time.Time `json:"fetchedAt"`
+	FiscalYear int       `json:"fiscalYear,omitempty"`
+	// Identifies the account across versions and fiscal years.
+	Key string `json:"key"`
+	// As written.
+	Raw string `json:"raw"`
+	// Which of the twelve bills funds the account.
+	Subcommittee string `json:"subcommittee,omitempty"`
+	Title        string `json:"title,omitempty"`
+	VersionCode  string `json:"versionCode"`
+	VersionID    int    `json:"versionId"`
+}
+
+// ActionResponse is the API's ActionResponse schema.
+type ActionResponse struct {
+	Date string `json:"date"`
+	Text string `json:"text"`
+	Type string `json:"type,omitempty"`
+}
+
+// AddBillAliasInputBody is the API's AddBillAliasInputBody schema.
+type AddBillAliasInputBody struct {
+	// Name the bill is known by.
+	Alias string `json:"alias"`
+}
+
+// AddDraftVersionInputBody is the API's AddDraftVersionInputBody schema.
+type AddDraftVersionInputBody struct {
+	// Bill text: plain text, HTML, or USLM XML.
+	Text string `json:"text"`
+	// Version code, e.g., "V2".
+	VersionCode string `json:"versionCode"`
+}
+
+// AdhocText is the API's AdhocText schema.
+type AdhocText struct {
+	// Name shown as the diff's fromVersion or toVersion (default: "from" or "to").
+	Label string `json:"label,omitempty"`
+	// Bill text: plain text, HTML, or USLM XML.
+	Text string `json:"text"`
+}
+
+// AdminUserResponse is the API's AdminUserResponse schema.
+type AdminUserResponse struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	// One of: reader, editor, admin.
+	Role     string `json:"role"`
+	TenantID int    `json:"tenantId,omitempty"`
+}
+
+// AlertMatchList is the API's AlertMatchList schema.
+type AlertMatchList struct {
+	Limit   int                  `json:"limit"`
+	Matches []AlertMatchResponse `json:"matches"`
+	Offset  int                  `json:"offset"`
+	Total   int                  `json:"total"`
+}
+
+// AlertMatchResponse is the API's AlertMatchResponse schema.
+type AlertMatchResponse struct {
+	AlertID     int            `json:"alertId"`
+	Bill        BillResponse   `json:"bill"`
+	ID          int            `json:"id"`
+	MatchedAt   time.Time      `json:"matchedAt"`
+	Snippets    []AlertSnippet `json:"snippets"`
+	VersionCode string         `json:"versionCode"`
+	VersionID   int            `json:"versionId"`
+}
+
+// AlertSnippet is the API's AlertSnippet schema.
+type AlertSnippet struct {
+	// Section anchor, as in diffs.
+	Anchor string `json:"anchor,omitempty"`
+	// Byte offset of the match in the version's plain text.
+	Offset int `json:"offset"`
+	// Number of the section containing the match.
+	Section string `json:"section,omitempty"`
+	// Query term that matched, as written.
+	Term string `json:"term"`
+	// The match with surrounding text.
+	Text string `json:"text"`
+}
+
+// AppropriationsAccountsResponse is the API's AppropriationsAccountsResponse
+// schema.
+type AppropriationsAccountsResponse struct {
+	Accounts   []AccountLevel `json:"accounts"`
+	FiscalYear int            `json:"fiscalYear"`
+	Limit      int            `json:"limit"`
+	Offset     int            `json:"offset"`
+	// Totals of the matching accounts, by bill.
+	Subcommittees []SubcommitteeTotal `json:"subcommittees"`
+	// Matching accounts.
+	Total int `json:"total"`
+}
+
+// AppropriationsDeadlinesResponse is the API's AppropriationsDeadlinesResponse
+// schema.
+type AppropriationsDeadlinesResponse struct {
+	Congress int `json:"congress"`
+	// Soonest expiration first; resolutions without one last.
+	Deadlines []CRDeadline `json:"deadlines"`
+	// Day countdowns are from (UTC).
+	Today string `json:"today"`
+}
+
+// AsOfResponse is the API's AsOfResponse schema.
+type AsOfResponse struct {
+	Bill            BillResponse    `json:"bill"`
+	Current         bool            `json:"current"`
+	Date            string          `json:"date"`
+	Diff            *DiffResponse   `json:"diff,omitempty"`
+	LatestVersionID int             `json:"latestVersionId"`
+	Text            string          `json:"text"`
+	Version         VersionResponse `json:"version"`
+}
+
+// AuditLogList is the API's AuditLogList schema.
+type AuditLogList struct {
+	Entries []AuditLogResponse `json:"entries"`
+	Limit   int                `json:"limit"`
+	Offset  int                `json:"offset"`
+	Total   int                `json:"total"`
+}
+
+// AuditLogResponse is the API's AuditLogResponse schema.
+type AuditLogResponse struct {
+	// One of: user, admin_token, anonymous.
+	ActorType string         `json:"actorType"`
+	After     map[string]any `json:"after,omitempty"`
+	Before    map[string]any `json:"before,omitempty"`
+	CreatedAt time.Time      `json:"createdAt"`
+	ID        int            `json:"id"`
+	Method    string         `json:"method"`
+	// Operation ID, e.g., set-user-role.
+	Operation  string `json:"operation"`
+	Path       string `json:"path"`
+	Route      string `json:"route"`
+	Status     int    `json:"status"`
+	TargetID   string `json:"targetId,omitempty"`
+	TargetType string `json:"targetType"`
+	UserID     int    `json:"userId,omitempty"`
+}
+
+// BillAliasResponse is the API's BillAliasResponse schema.
+type BillAliasResponse struct {
+	Alias     string    `json:"alias"`
+	CreatedAt time.Time `json:"createdAt"`
+	ID        int       `json:"id"`
+	// short_title, popular_title, or admin.
+	Source string `json:"source"`
+}
+
+// BillAliasesResponse is the API's BillAliasesResponse schema.
+type BillAliasesResponse struct {
+	Aliases []BillAliasResponse `json:"aliases"`
+	BillID  int                 `json:"billId"`
+}
+
+// BillChangeFeed is the API's BillChangeFeed schema.
+type BillChangeFeed struct {
+	BillID  int                  `json:"billId"`
+	Changes []BillChangeResponse `json:"changes"`
+	Limit   int                  `json:"limit"`
+	Offset  int                  `json:"offset"`
+	Total   int                  `json:"total"`
+}
+
+// BillChangeResponse is the API's BillChangeResponse schema.
+type BillChangeResponse struct {
+	Details       map[string]any `json:"details,omitempty"`
+	ID            int            `json:"id"`
+	NewValue      string         `json:"newValue,omitempty"`
+	OccurredAt    time.Time      `json:"occurredAt"`
+	PreviousValue string         `json:"previousValue,omitempty"`
+	Type          string         `json:"type"`
+	Version       *ChangeVersion `json:"version,omitempty"`
+}
+
+// BillResponse is the API's BillResponse schema.
+type BillResponse struct {
+	ActionCount    int               `json:"actionCount,omitempty"`
+	AmendmentCount int               `json:"amendmentCount,omitempty"`
+	BecameLaw      bool              `json:"becameLaw"`
+	BillNumber     int               `json:"billNumber"`
+	BillType       string            `json:"billType"`
+	Congress       int               `json:"congress"`
+	CosponsorCount int               `json:"cosponsorCount,omitempty"`
+	CurrentStatus  string            `json:"currentStatus"`
+	ID             int               `json:"id"`
+	IntroducedDate string            `json:"introducedDate,omitempty"`
+	Jurisdiction   string            `json:"jurisdiction"`
+	LawNumber      string            `json:"lawNumber,omitempty"`
+	Number         string            `json:"number"`
+	OriginChamber  string            `json:"originChamber"`
+	PolicyArea     string            `json:"policyArea,omitempty"`
+	Session        string            `json:"session,omitempty"`
+	Sponsor        string            `json:"sponsor"`
+	State          string            `json:"state,omitempty"`
+	Subjects       []string          `json:"subjects,omitempty"`
+	Title          string            `json:"title"`
+	Titles         []TitleResponse   `json:"titles,omitempty"`
+	UpdateDate     string            `json:"updateDate"`
+	Versions       []VersionResponse `json:"versions,omitempty"`
+}
+
+// BillsByStatusResponse is the API's BillsByStatusResponse schema.
+type BillsByStatusResponse struct {
+	ComputedAt *time.Time             `json:"computedAt,omitempty"`
+	Congresses []CongressStatusCounts `json:"congresses"`
+}
+
+// CRDeadline is the API's CRDeadline schema.
+type CRDeadline struct {
+	Bill BillResponse `json:"bill"`
+	// Days from today to expiresOn; 0 on the last day, negative once expired.
+	DaysRemaining int `json:"daysRemaining,omitempty"`
+	// Last day funding continues; absent when the text names none or isn't fetched
+	// yet.
+	ExpiresOn string `json:"expiresOn,omitempty"`
+	// Fiscal year the resolution funds.
+	FiscalYear int `json:"fiscalYear,omitempty"`
+	// Appropriations bills of the same fiscal year not yet enacted, other CRs
+	// included: what could replace or extend the resolution.
+	PendingReplacements []BillResponse `json:"pendingReplacements"`
+	// One of: active, expired, pending.
+	Status string `json:"status"`
+	// Version of the resolution the expiration was read from.
+	VersionID int `json:"versionId,omitempty"`
+}
+
+// CalendarResponse is the API's CalendarResponse schema.
+type CalendarResponse struct {
+	// Path of the calendar to subscribe to, relative to the API's public origin.
+	Path string `json:"path"`
+	// Secret identifying the calendar; shown only once.
+	Token string `json:"token"`
+}
+
+// ChangeVersion is the API's ChangeVersion schema.
+type ChangeVersion struct {
+	Deletions         int    `json:"deletions,omitempty"`
+	ID                int    `json:"id"`
+	Insertions        int    `json:"insertions,omitempty"`
+	PreviousVersionID int    `json:"previousVersionId,omitempty"`
+	VersionCode       string `json:"versionCode"`
+}
+
+// CompareAdhocInputBody is the API's CompareAdhocInputBody schema.
+type CompareAdhocInputBody struct {
+	// Earlier text.
+	From AdhocText `json:"from"`
+	// Later text.
+	To AdhocText `json:"to"`
+}
+
+// CongressDiagnostics is the API's CongressDiagnostics schema.
+type CongressDiagnostics struct {
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	LatencyMs int        `json:"latencyMs"`
+	Status    string     `json:"status"`
+}
+
+// CongressStatusCounts is the API's CongressStatusCounts schema.
+type CongressStatusCounts struct {
+	Congress int           `json:"congress"`
+	Statuses []StatusCount `json:"statuses"`
+	Total    int           `json:"total"`
+}
+
+// CostEstimateResponse is the API's CostEstimateResponse schema.
+type CostEstimateResponse struct {
+	Description string `json:"description"`
+	PubDate     string `json:"pubDate"`
+	Title       string `json:"title"`
+	URL         string `json:"url"`
+}
+
+// CostEstimatesResponse is the API's CostEstimatesResponse schema.
+type CostEstimatesResponse struct {
+	BillID        int                    `json:"billId"`
+	CostEstimates []CostEstimateResponse `json:"costEstimates"`
+}
+
+// CreateDocumentInputBody is the API's CreateDocumentInputBody schema.
+type CreateDocumentInputBody struct {
+	// What the document is, for your own reference.
+	Description string `json:"description,omitempty"`
+	// Document title.
+	Title string `json:"title"`
+}
+
+// CreateDraftInputBody is the API's CreateDraftInputBody schema.
+type CreateDraftInputBody struct {
+	// Bill text: plain text, HTML, or USLM XML.
+	Text string `json:"text"`
+	// Draft title.
+	Title string `json:"title"`
+	// Code of the first version, e.g., "V1". Default: DRAFT.
+	VersionCode string `json:"versionCode,omitempty"`
+}
+
+// CreateTenantInputBody is the API's CreateTenantInputBody schema.
+type CreateTenantInputBody struct {
+	// Organization name.
+	Name string `json:"name"`
+}
+
+// CreateTenantUserInputBody is the API's CreateTenantUserInputBody schema.
+type CreateTenantUserInputBody struct {
+	// Display name.
+	Name string `json:"name"`
+	// User role; readers can't upload drafts. One of: reader, editor, admin.
+	// Default: editor.
+	Role string `json:"role,omitempty"`
+}
+
+// CreateUserInputBody is the API's CreateUserInputBody schema.
+type CreateUserInputBody struct {
+	// Display name.
+	Name string `json:"name"`
+}
+
+// DatabaseDiagnostics is the API's DatabaseDiagnostics schema.
+type DatabaseDiagnostics struct {
+	Error           string `json:"error,omitempty"`
+	Idle            int    `json:"idle"`
+	InUse           int    `json:"inUse"`
+	LatencyMs       int    `json:"latencyMs"`
+	MaxOpen         int    `json:"maxOpen"`
+	OpenConnections int    `json:"openConnections"`
+	Status          string `json:"status"`
+	WaitCount       int    `json:"waitCount"`
+	WaitDurationMs  int    `json:"waitDurationMs"`
+}
+
+// DatabasePoolResponse is the API's DatabasePoolResponse schema.
+type DatabasePoolResponse struct {
+	Idle  int `json:"idle"`
+	InUse int `json:"inUse"`
+	// Connections closed because the idle pool was full.
+	MaxIdleClosed int `json:"maxIdleClosed"`
+	// Connections closed for being idle too long.
+	MaxIdleTimeClosed int `json:"maxIdleTimeClosed"`
+	// Connections closed for reaching their maximum lifetime.
+	MaxLifetimeClosed int `json:"maxLifetimeClosed"`
+	// Maximum open connections; 0 for no limit.
+	MaxOpen int `json:"maxOpen"`
+	// primary, or replica 1 and so on.
+	Name string `json:"name"`
+	// Open connections, in use or idle.
+	Open int `json:"open"`
+	// Queries that waited for a free connection since startup.
+	WaitCount int `json:"waitCount"`
+	// Total time queries waited for a free connection since startup.
+	WaitDurationMs int `json:"waitDurationMs"`
+}
+
+// DatabaseStatsResponse is the API's DatabaseStatsResponse schema.
+type DatabaseStatsResponse struct {
+	Pools []DatabasePoolResponse `json:"pools"`
+	// Statements slower than this are logged and counted as slow; 0 when disabled.
+	SlowQueryThresholdMs int `json:"slowQueryThresholdMs"`
+}
+
+// DefinitionChange is the API's DefinitionChange schema.
+type DefinitionChange struct {
+	Anchor   string `json:"anchor"`
+	FromText string `json:"fromText,omitempty"`
+	Key      string `json:"key"`
+	Section  string `json:"section"`
+	Status   string `json:"status"`
+	Term     string `json:"term"`
+	ToText   string `json:"toText,omitempty"`
+}
+
+// DefinitionChangesResponse is the API's DefinitionChangesResponse schema.
+type DefinitionChangesResponse struct {
+	BillID         int                `json:"billId"`
+	Changes        []DefinitionChange `json:"changes"`
+	FromVersion    string             `json:"fromVersion"`
+	FromVersionID  int                `json:"fromVersionId"`
+	TermsAdded     int                `json:"termsAdded"`
+	TermsRemoved   int                `json:"termsRemoved"`
+	TermsReworded  int                `json:"termsReworded"`
+	TermsUnchanged int                `json:"termsUnchanged"`
+	ToVersion      string             `json:"toVersion"`
+	ToVersionID    int                `json:"toVersionId"`
+}
+
+// Delta is the API's Delta schema.
+type Delta struct {
+	AvgSentenceLength float64 `json:"avgSentenceLength"`
+	DefinedTerms      int     `json:"definedTerms"`
+	GradeLevel        float64 `json:"gradeLevel"`
+	PageEstimate      int     `json:"pageEstimate"`
+	WordCount         int     `json:"wordCount"`
+}
+
+// DeltaJobResponse is the API's DeltaJobResponse schema.
+type DeltaJobResponse struct {
+	BillID        int        `json:"billId,omitempty"`
+	Computed      int        `json:"computed"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	ErrorMessage  string     `json:"errorMessage,omitempty"`
+	Errors        []string   `json:"errors"`
+	Failed        int        `json:"failed"`
+	FinishedAt    *time.Time `json:"finishedAt,omitempty"`
+	FromVersionID int        `json:"fromVersionId,omitempty"`
+	ID            int        `json:"id"`
+	Scope         string     `json:"scope"`
+	// Pairs over the diff size limit, left as they were.
+	Skipped     int        `json:"skipped"`
+	StartedAt   *time.Time `json:"startedAt,omitempty"`
+	Status      string     `json:"status"`
+	ToVersionID int        `json:"toVersionId,omitempty"`
+	Total       int        `json:"total"`
+}
+
+// DeterminismReport is the API's DeterminismReport schema.
+type DeterminismReport struct {
+	Deterministic       bool     `json:"deterministic"`
+	EngineVersion       string   `json:"engineVersion"`
+	Fingerprints        []string `json:"fingerprints"`
+	FromVersionID       int      `json:"fromVersionId"`
+	HasStored           bool     `json:"hasStored"`
+	MatchesStored       bool     `json:"matchesStored"`
+	Mismatches          []string `json:"mismatches"`
+	StoredEngineVersion string   `json:"storedEngineVersion,omitempty"`
+	StoredFingerprint   string   `json:"storedFingerprint,omitempty"`
+	ToVersionID         int      `json:"toVersionId"`
+}
+
+// DiagnosticHealthOutputBody is the API's DiagnosticHealthOutputBody schema.
+type DiagnosticHealthOutputBody struct {
+	Status string `json:"status"`
+}
+
+// DiagnosticsReport is the API's DiagnosticsReport schema.
+type DiagnosticsReport struct {
+	Congress  CongressDiagnostics   `json:"congress"`
+	Database  DatabaseDiagnostics   `json:"database"`
+	Errors    []string              `json:"errors,omitempty"`
+	Ingestion *IngestionDiagnostics `json:"ingestion,omitempty"`
+	Pending   *PendingJobs          `json:"pending,omitempty"`
+	RateLimit *RateLimitDiagnostics `json:"rateLimit,omitempty"`
+	Status    string                `json:"status"`
+}
+
+// DiffAnchor is the API's DiffAnchor schema.
+type DiffAnchor struct {
+	Heading    string `json:"heading"`
+	Hunk       int    `json:"hunk"`
+	ID         string `json:"id"`
+	LineNumber int    `json:"lineNumber"`
+	Section    string `json:"section"`
+}
+
+// DiffHunk is the API's DiffHunk schema.
+type DiffHunk struct {
+	Index      int `json:"index"`
+	LineCount  int `json:"lineCount"`
+	LineNumber int `json:"lineNumber"`
+	StartA     int `json:"startA"`
+	StartB     int `json:"startB"`
+}
+
+// DiffLine is the API's DiffLine schema.
+type DiffLine struct {
+	Anchor     string `json:"anchor,omitempty"`
+	LineNumber int    `json:"lineNumber"`
+	Text       string `json:"text"`
+	Type       string `json:"type"`
+}
+
+// DiffResponse is the API's DiffResponse schema.
+type DiffResponse struct {
+	Anchors     []DiffAnchor  `json:"anchors,omitempty"`
+	Deletions   int           `json:"deletions"`
+	FromVersion string        `json:"fromVersion"`
+	Hunks       []DiffHunk    `json:"hunks,omitempty"`
+	Insertions  int           `json:"insertions"`
+	Lines       []DiffLine    `json:"lines"`
+	NextHunk    int           `json:"nextHunk,omitempty"`
+	Rows        []SplitRow    `json:"rows,omitempty"`
+	Segments    []DiffSegment `json:"segments"`
+	StatsDelta  *Delta        `json:"statsDelta,omitempty"`
+	Summary     string        `json:"summary,omitempty"`
+	ToVersion   string        `json:"toVersion"`
+	TotalHunks  int           `json:"totalHunks"`
+	View        string        `json:"view,omitempty"`
+}
+
+// DiffSegment is the API's DiffSegment schema.
+type DiffSegment struct {
+	Text string `json:"text"`
+	Type string `json:"type"`
+}
+
+// DiffSizeByType is the API's DiffSizeByType schema.
+type DiffSizeByType struct {
+	AvgDeletions    float64 `json:"avgDeletions"`
+	AvgInsertions   float64 `json:"avgInsertions"`
+	AvgLinesChanged float64 `json:"avgLinesChanged"`
+	BillType        string  `json:"billType"`
+	Diffs           int     `json:"diffs"`
+}
+
+// DiffSizeByTypeResponse is the API's DiffSizeByTypeResponse schema.
+type DiffSizeByTypeResponse struct {
+	BillTypes  []DiffSizeByType `json:"billTypes"`
+	ComputedAt *time.Time       `json:"computedAt,omitempty"`
+}
+
+// DiffStreamRecord is the API's DiffStreamRecord schema.
+type DiffStreamRecord struct {
+	Anchor      string `json:"anchor,omitempty"`
+	Deletions   int    `json:"deletions,omitempty"`
+	Error       string `json:"error,omitempty"`
+	Event       string `json:"event"`
+	FromVersion string `json:"fromVersion,omitempty"`
+	Insertions  int    `json:"insertions,omitempty"`
+	LineNumber  int    `json:"lineNumber"`
+	Text        string `json:"text"`
+	ToVersion   string `json:"toVersion,omitempty"`
+	Type        string `json:"type"`
+}
+
+// DocumentListResponse is the API's DocumentListResponse schema.
+type DocumentListResponse struct {
+	Documents []DocumentResponse `json:"documents"`
+}
+
+// DocumentResponse is the API's DocumentResponse schema.
+type DocumentResponse struct {
+	CreatedAt   time.Time         `json:"createdAt"`
+	Description string            `json:"description,omitempty"`
+	ID          int               `json:"id"`
+	Title       string            `json:"title"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+	Versions    []VersionResponse `json:"versions,omitempty"`
+}
+
+// DocumentVersionUpload is the API's DocumentVersionUpload schema.
+type DocumentVersionUpload struct {
+	// Document text: plain text, HTML, or XML.
+	Text string `json:"text"`
+	// Version code, e.g., "V2" (default: V followed by the version's number).
+	VersionCode string `json:"versionCode,omitempty"`
+}
+
+// DollarAmount is the API's DollarAmount schema.
+type DollarAmount struct {
+	Offset int    `json:"offset"`
+	Raw    string `json:"raw"`
+	Value  int    `json:"value"`
+}
+
+// DraftListResponse is the API's DraftListResponse schema.
+type DraftListResponse struct {
+	Drafts []BillResponse `json:"drafts"`
+}
+
+// Earmark is the API's Earmark schema.
+type Earmark struct {
+	Amount    DollarAmount `json:"amount"`
+	Key       string       `json:"key"`
+	Purpose   string       `json:"purpose"`
+	Recipient string       `json:"recipient"`
+	Section   string       `json:"section"`
+}
+
+// EarmarkChange is the API's EarmarkChange schema.
+type EarmarkChange struct {
+	Change     int    `json:"change"`
+	FromAmount int    `json:"fromAmount"`
+	FromRaw    string `json:"fromRaw,omitempty"`
+	Key        string `json:"key"`
+	Purpose    string `json:"purpose"`
+	Recipient  string `json:"recipient"`
+	Section    string `json:"section"`
+	Status     string `json:"status"`
+	ToAmount   int    `json:"toAmount"`
+	ToRaw      string `json:"toRaw,omitempty"`
+}
+
+// EarmarkChangesResponse is the API's EarmarkChangesResponse schema.
+type EarmarkChangesResponse struct {
+	BillID            int             `json:"billId"`
+	Changes           []EarmarkChange `json:"changes"`
+	EarmarksAdded     int             `json:"earmarksAdded"`
+	EarmarksChanged   int             `json:"earmarksChanged"`
+	EarmarksRemoved   int             `json:"earmarksRemoved"`
+	EarmarksUnchanged int             `json:"earmarksUnchanged"`
+	FromTotal         int             `json:"fromTotal"`
+	FromVersion       string          `json:"fromVersion"`
+	FromVersionID     int             `json:"fromVersionId"`
+	NetChange         int             `json:"netChange"`
+	ToTotal           int             `json:"toTotal"`
+	ToVersion         string          `json:"toVersion"`
+	ToVersionID       int             `json:"toVersionId"`
+}
+
+// ErrorDetail is the API's ErrorDetail schema.
+type ErrorDetail struct {
+	// Where the error occurred, e.g. 'body.items[3].tags' or 'path.thing-id'.
+	Location string `json:"location,omitempty"`
+	// Error message text.
+	Message string `json:"message,omitempty"`
+	// The value at the given location.
+	Value any `json:"value,omitempty"`
+}
+
+// ErrorModel is the API's ErrorModel schema.
+type ErrorModel struct {
+	// Machine-readable error code.
+	Code string `json:"code"`
+	// A human-readable explanation specific to this occurrence of the problem.
+	Detail string `json:"detail,omitempty"`
+	// Optional list of individual error details.
+	Errors []ErrorDetail `json:"errors,omitempty"`
+	// A URI reference that identifies the specific occurrence of the problem.
+	Instance string `json:"instance,omitempty"`
+	// HTTP status code.
+	Status int `json:"status,omitempty"`
+	// A short, human-readable summary of the problem type. This value should not
+	// change between occurrences of the error.
+	Title string `json:"title,omitempty"`
+	// A URI reference to human-readable documentation for the error. Default:
+	// about:blank.
+	Type string `json:"type,omitempty"`
+}
+
+// FacetCount is the API's FacetCount schema.
+type FacetCount struct {
+	Count int    `json:"count"`
+	Value string `json:"value"`
+}
+
+// FiscalYearLevel is the API's FiscalYearLevel schema.
+type FiscalYearLevel struct {
+	// Whole dollars.
+	Amount int `json:"amount"`
+	// Difference from the prior fiscal year; absent when it isn't known.
+	Change     int  `json:"change,omitempty"`
+	Enacted    bool `json:"enacted"`
+	FiscalYear int  `json:"fiscalYear"`
+}
+
+// GetBillVersionsOutputBody is the API's GetBillVersionsOutputBody schema.
+type GetBillVersionsOutputBody struct {
+	BillID   int               `json:"billId"`
+	Versions []VersionResponse `json:"versions"`
+}
+
+// GetDocumentVersionsOutputBody is the API's GetDocumentVersionsOutputBody
+// schema.
+type GetDocumentVersionsOutputBody struct {
+	DocumentID int               `json:"documentId"`
+	Versions   []VersionResponse `json:"versions"`
+}
+
+// GraphVersion is the API's GraphVersion schema.
+type GraphVersion struct {
+	Chamber     string    `json:"chamber,omitempty"`
+	FetchedAt   time.Time `json:"fetchedAt"`
+	ID          int       `json:"id"`
+	Label       string    `json:"label"`
+	ParentID    int       `json:"parentId,omitempty"`
+	Stage       int       `json:"stage"`
+	VersionCode string    `json:"versionCode"`
+}
+
+// HealthOutputBody is the API's HealthOutputBody schema.
+type HealthOutputBody struct {
+	Service string `json:"service"`
+	Status  string `json:"status"`
+}
+
+// IngestFailureList is the API's IngestFailureList schema.
+type IngestFailureList struct {
+	Failures []IngestFailureResponse `json:"failures"`
+	Limit    int                     `json:"limit"`
+	Offset   int                     `json:"offset"`
+	Total    int                     `json:"total"`
+}
+
+// IngestFailureResponse is the API's IngestFailureResponse schema.
+type IngestFailureResponse struct {
+	Attempts      int       `json:"attempts"`
+	BillKey       string    `json:"billKey"`
+	CreatedAt     time.Time `json:"createdAt"`
+	ErrorClass    string    `json:"errorClass"`
+	ID            int       `json:"id"`
+	LastError     string    `json:"lastError"`
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+	// The Congress.gov bill listing as ingested.
+	Payload    map[string]any `json:"payload"`
+	ResolvedAt *time.Time     `json:"resolvedAt,omitempty"`
+	RunID      int            `json:"runId,omitempty"`
+	// bill or versions.
+	Stage string `json:"stage"`
+	// pending, exhausted, resolved, or dismissed.
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// IngestRunList is the API's IngestRunList schema.
+type IngestRunList struct {
+	Limit  int                 `json:"limit"`
+	Offset int                 `json:"offset"`
+	Runs   []IngestRunResponse `json:"runs"`
+	Total  int                 `json:"total"`
+}
+
+// IngestRunResponse is the API's IngestRunResponse schema.
+type IngestRunResponse struct {
+	BillsCreated    int        `json:"billsCreated"`
+	BillsFetched    int        `json:"billsFetched"`
+	BillsSkipped    int        `json:"billsSkipped"`
+	BillsUpdated    int        `json:"billsUpdated"`
+	CursorBill      string     `json:"cursorBill,omitempty"`
+	DurationMs      int        `json:"durationMs"`
+	ErrorCount      int        `json:"errorCount"`
+	ErrorMessage    string     `json:"errorMessage,omitempty"`
+	Errors          []string   `json:"errors"`
+	FinishedAt      *time.Time `json:"finishedAt,omitempty"`
+	ID              int        `json:"id"`
+	Mode            string     `json:"mode"`
+	ResumedFrom     int        `json:"resumedFrom,omitempty"`
+	StartedAt       time.Time  `json:"startedAt"`
+	Status          string     `json:"status"`
+	TriggeredBy     string     `json:"triggeredBy"`
+	VersionsCreated int        `json:"versionsCreated"`
+}
+
+// IngestionDiagnostics is the API's IngestionDiagnostics schema.
+type IngestionDiagnostics struct {
+	LastRunAt     *time.Time `json:"lastRunAt,omitempty"`
+	LastRunStatus string     `json:"lastRunStatus,omitempty"`
+	LastSuccessAt *time.Time `json:"lastSuccessAt,omitempty"`
+}
+
+// KeywordAlertBody is the API's KeywordAlertBody schema.
+type KeywordAlertBody struct {
+	// Name of the alert.
+	Name string `json:"name"`
+	// Words and "quoted phrases" combined with AND, OR, NOT, and parentheses;
+	// adjacent terms are ANDed, and a trailing * matches a word prefix.
+	Query string `json:"query"`
+}
+
+// KeywordAlertResponse is the API's KeywordAlertResponse schema.
+type KeywordAlertResponse struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Query     string    `json:"query"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// KeywordAlertUpdates is the API's KeywordAlertUpdates schema.
+type KeywordAlertUpdates struct {
+	Alert   KeywordAlertResponse `json:"alert"`
+	Matches []AlertMatchResponse `json:"matches"`
+}
+
+// KeywordAlertsResponse is the API's KeywordAlertsResponse schema.
+type KeywordAlertsResponse struct {
+	Alerts []KeywordAlertResponse `json:"alerts"`
+}
+
+// LexSearchResult is the API's LexSearchResult schema.
+type LexSearchResult struct {
+	Bills  []BillResponse `json:"bills"`
+	Facets *SearchFacets  `json:"facets,omitempty"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+	Total  int            `json:"total"`
+}
+
+// LineageLink is the API's LineageLink schema.
+type LineageLink struct {
+	Bill BillResponse `json:"bill"`
+	// The linked bill's latest text version.
+	LatestVersionID int  `json:"latestVersionId,omitempty"`
+	SameSponsor     bool `json:"sameSponsor"`
+	// How closely the bills match, from 0.6 to 1.
+	Score float64 `json:"score"`
+	// Share of sections with the same language; absent when either bill's sections
+	// aren't fingerprinted yet.
+	TextSimilarity float64 `json:"textSimilarity,omitempty"`
+	// Share of title words in common, ignoring years.
+	TitleSimilarity float64 `json:"titleSimilarity"`
+}
+
+// LineageResponse is the API's LineageResponse schema.
+type LineageResponse struct {
+	BillID int `json:"billId"`
+	// The line of bills through each Congress's best match, oldest first, this
+	// bill included.
+	Chain []BillResponse `json:"chain"`
+	// Bills of the previous Congress this bill continues, best match first.
+	Predecessors []LineageLink `json:"predecessors"`
+	// Bills of the next Congress continuing this one, best match first.
+	Successors []LineageLink `json:"successors"`
+}
+
+// ListBillsOutputBody is the API's ListBillsOutputBody schema.
+type ListBillsOutputBody struct {
+	Bills []BillResponse `json:"bills"`
+	Total int            `json:"total"`
+}
+
+// LivenessOutputBody is the API's LivenessOutputBody schema.
+type LivenessOutputBody struct {
+	Status string `json:"status"`
+}
+
+// MatrixVersion is the API's MatrixVersion schema.
+type MatrixVersion struct {
+	ID          int    `json:"id"`
+	Label       string `json:"label"`
+	Lines       int    `json:"lines"`
+	Stage       int    `json:"stage"`
+	VersionCode string `json:"versionCode"`
+}
+
+// MeResponse is the API's MeResponse schema.
+type MeResponse struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Email     string    `json:"email,omitempty"`
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	// One of: reader, editor, admin.
+	Role string `json:"role"`
+	// Identity provider and its user ID, for users who sign in.
+	Subject  string `json:"subject,omitempty"`
+	TenantID int    `json:"tenantId,omitempty"`
+}
+
+// MemberBillImpact is the API's MemberBillImpact schema.
+type MemberBillImpact struct {
+	BillID               int    `json:"billId"`
+	BillNumber           int    `json:"billNumber"`
+	BillType             string `json:"billType"`
+	Congress             int    `json:"congress"`
+	Enacted              bool   `json:"enacted"`
+	EnactedAppropriation int    `json:"enactedAppropriation"`
+	ProvisionsIntroduced int    `json:"provisionsIntroduced"`
+	ProvisionsSurvived   int    `json:"provisionsSurvived"`
+	Role                 string `json:"role"`
+	Title                string `json:"title"`
+}
+
+// MemberCongressStatsItem is the API's MemberCongressStatsItem schema.
+type MemberCongressStatsItem struct {
+	AverageChurn   float64 `json:"averageChurn"`
+	BillsEnacted   int     `json:"billsEnacted"`
+	BillsSponsored int     `json:"billsSponsored"`
+	Congress       int     `json:"congress"`
+	EnactmentRate  float64 `json:"enactmentRate"`
+}
+
+// MemberImpactResponse is the API's MemberImpactResponse schema.
+type MemberImpactResponse struct {
+	Bills                []MemberBillImpact `json:"bills"`
+	BillsCosponsored     int                `json:"billsCosponsored"`
+	BillsEnacted         int                `json:"billsEnacted"`
+	BillsSponsored       int                `json:"billsSponsored"`
+	BioguideID           string             `json:"bioguideId"`
+	EnactedAppropriation int                `json:"enactedAppropriation"`
+	FullName             string             `json:"fullName"`
+	Party                string             `json:"party"`
+	ProvisionsIntroduced int                `json:"provisionsIntroduced"`
+	ProvisionsSurvived   int                `json:"provisionsSurvived"`
+	State                string             `json:"state"`
+	SurvivalRate         float64            `json:"survivalRate"`
+}
+
+// MemberPolicyAreaItem is the API's MemberPolicyAreaItem schema.
+type MemberPolicyAreaItem struct {
+	Bills      int     `json:"bills"`
+	PolicyArea string  `json:"policyArea"`
+	Share      float64 `json:"share"`
+}
+
+// MemberStatsResponse is the API's MemberStatsResponse schema.
+type MemberStatsResponse struct {
+	AverageChurn   float64                   `json:"averageChurn"`
+	BillsEnacted   int                       `json:"billsEnacted"`
+	BillsSponsored int                       `json:"billsSponsored"`
+	BioguideID     string                    `json:"bioguideId"`
+	ComputedAt     *time.Time                `json:"computedAt,omitempty"`
+	Congresses     []MemberCongressStatsItem `json:"congresses"`
+	EnactmentRate  float64                   `json:"enactmentRate"`
+	FullName       string                    `json:"fullName"`
+	Party          string                    `json:"party"`
+	PolicyAreas    []MemberPolicyAreaItem    `json:"policyAreas"`
+	State          string                    `json:"state"`
+}
+
+// NominationListResponse is the API's NominationListResponse schema.
+type NominationListResponse struct {
+	Limit       int                  `json:"limit"`
+	Nominations []NominationResponse `json:"nominations"`
+	Offset      int                  `json:"offset"`
+	Total       int                  `json:"total"`
+}
+
+// NominationResponse is the API's NominationResponse schema.
+type NominationResponse struct {
+	Actions       []ActionResponse `json:"actions,omitempty"`
+	Citation      string           `json:"citation"`
+	Congress      int              `json:"congress"`
+	CurrentStatus string           `json:"currentStatus"`
+	Description   string           `json:"description"`
+	ID            int              `json:"id"`
+	IsMilitary    bool             `json:"isMilitary"`
+	Number        int              `json:"number"`
+	Organization  string           `json:"organization"`
+	PartNumber    string           `json:"partNumber"`
+	ReceivedDate  string           `json:"receivedDate,omitempty"`
+	StatusDate    string           `json:"statusDate,omitempty"`
+	UpdatedAt     time.Time        `json:"updatedAt"`
+}
+
+// PendingJobs is the API's PendingJobs schema.
+type PendingJobs struct {
+	DeltaJobs         int  `json:"deltaJobs"`
+	DiffQueue         int  `json:"diffQueue"`
+	DiffQueueEnabled  bool `json:"diffQueueEnabled"`
+	IngestExhausted   int  `json:"ingestExhausted"`
+	IngestRetries     int  `json:"ingestRetries"`
+	RunningIngestions int  `json:"runningIngestions"`
+}
+
+// ProbeCheck is the API's ProbeCheck schema.
+type ProbeCheck struct {
+	Error     string `json:"error,omitempty"`
+	LatencyMs int    `json:"latencyMs"`
+	Status    string `json:"status"`
+}
+
+// ProvenanceResponse is the API's ProvenanceResponse schema.
+type ProvenanceResponse struct {
+	// SHA-256 of the bytes retrieved, before any processing.
+	ContentHash string `json:"contentHash"`
+	// Bytes retrieved.
+	ContentLength int    `json:"contentLength"`
+	ContentType   string `json:"contentType,omitempty"`
+	Etag          string `json:"etag,omitempty"`
+	// URL that answered, when the source redirected.
+	FinalURL     string    `json:"finalUrl,omitempty"`
+	LastModified string    `json:"lastModified,omitempty"`
+	RetrievedAt  time.Time `json:"retrievedAt"`
+	SourceURL    string    `json:"sourceUrl"`
+	VersionID    int       `json:"versionId"`
+}
+
+// ProvenanceVerification is the API's ProvenanceVerification schema.
+type ProvenanceVerification struct {
+	Provenance ProvenanceResponse `json:"provenance"`
+	// Absent when the source couldn't be reached.
+	Source      *SourceCheck `json:"source,omitempty"`
+	SourceError string       `json:"sourceError,omitempty"`
+	// SHA-256 of the raw text stored now.
+	StoredHash string `json:"storedHash"`
+	// The stored text is the text retrieved.
+	StoredMatches bool `json:"storedMatches"`
+	// Both the stored text and the source match the recorded hash.
+	Verified bool `json:"verified"`
+}
+
+// RateLimitDiagnostics is the API's RateLimitDiagnostics schema.
+type RateLimitDiagnostics struct {
+	ClientLimit int        `json:"clientLimit"`
+	Limit       int        `json:"limit"`
+	ObservedAt  *time.Time `json:"observedAt,omitempty"`
+	PausedUntil *time.Time `json:"pausedUntil,omitempty"`
+	Remaining   int        `json:"remaining"`
+}
+
+// ReadinessReport is the API's ReadinessReport schema.
+type ReadinessReport struct {
+	Checks map[string]ProbeCheck `json:"checks"`
+	Status string                `json:"status"`
+}
+
+// RecomputeDeltasRequest is the API's RecomputeDeltasRequest schema.
+type RecomputeDeltasRequest struct {
+	// Recompute every cached delta.
+	All bool `json:"all,omitempty"`
+	// Recompute every cached delta of this bill and its neighboring version pairs.
+	BillID int `json:"billId,omitempty"`
+	// With toVersionId, recompute one version pair.
+	FromVersionID int `json:"fromVersionId,omitempty"`
+	// With fromVersionId, recompute one version pair.
+	ToVersionID int `json:"toVersionId,omitempty"`
+}
+
+// ReconcileResponse is the API's ReconcileResponse schema.
+type ReconcileResponse struct {
+	BaseVersion     string             `json:"baseVersion"`
+	BaseVersionID   int                `json:"baseVersionId"`
+	BillID          int                `json:"billId"`
+	Both            int                `json:"both"`
+	Dropped         int                `json:"dropped"`
+	House           int                `json:"house"`
+	HouseVersion    string             `json:"houseVersion"`
+	HouseVersionID  int                `json:"houseVersionId"`
+	Neither         int                `json:"neither"`
+	Sections        []ReconcileSection `json:"sections"`
+	Senate          int                `json:"senate"`
+	SenateVersion   string             `json:"senateVersion"`
+	SenateVersionID int                `json:"senateVersionId"`
+}
+
+// ReconcileSection is the API's ReconcileSection schema.
+type ReconcileSection struct {
+	Anchor        string `json:"anchor"`
+	Followed      string `json:"followed"`
+	Heading       string `json:"heading"`
+	HouseChanges  int    `json:"houseChanges"`
+	Section       string `json:"section"`
+	SenateChanges int    `json:"senateChanges"`
+}
+
+// ReingestJobResponse is the API's ReingestJobResponse schema.
+type ReingestJobResponse struct {
+	// The billIds filter, if given.
+	BillIds    []int      `json:"billIds,omitempty"`
+	BillType   string     `json:"billType,omitempty"`
+	Congress   int        `json:"congress,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	Errors     []string   `json:"errors"`
+	Failed     int        `json:"failed"`
+	FinishedAt *time.Time `json:"finishedAt,omitempty"`
+	ID         int        `json:"id"`
+	// Bills refreshed or failed so far.
+	Processed int `json:"processed"`
+	// Processed / total, from 0 to 1.
+	Progress  float64    `json:"progress"`
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	// One of: queued, running, succeeded.
+	Status string `json:"status"`
+	// Bills matching the filter when the job was queued.
+	Total        int    `json:"total"`
+	UpdatedSince string `json:"updatedSince,omitempty"`
+}
+
+// ReingestRequest is the API's ReingestRequest schema.
+type ReingestRequest struct {
+	// Only these bills (database IDs).
+	BillIds []int `json:"billIds,omitempty"`
+	// Only bills of this type, e.g., hr.
+	BillType string `json:"billType,omitempty"`
+	// Only bills of this congress.
+	Congress int `json:"congress,omitempty"`
+	// Only bills Congress.gov last updated on or after this date (YYYY-MM-DD).
+	UpdatedSince string `json:"updatedSince,omitempty"`
+}
+
+// RelatedBillResponse is the API's RelatedBillResponse schema.
+type RelatedBillResponse struct {
+	BillID          int      `json:"billId,omitempty"`
+	BillNumber      int      `json:"billNumber"`
+	BillType        string   `json:"billType"`
+	Congress        int      `json:"congress"`
+	IsCompanion     bool     `json:"isCompanion"`
+	LatestVersionID int      `json:"latestVersionId,omitempty"`
+	Relationships   []string `json:"relationships"`
+	Title           string   `json:"title"`
+}
+
+// RelatedBillsResponse is the API's RelatedBillsResponse schema.
+type RelatedBillsResponse struct {
+	BillID          int                   `json:"billId"`
+	LatestVersionID int                   `json:"latestVersionId,omitempty"`
+	Related         []RelatedBillResponse `json:"related"`
+}
+
+// RuleListResponse is the API's RuleListResponse schema.
+type RuleListResponse struct {
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+	Rules  []RuleResponse `json:"rules"`
+	Total  int            `json:"total"`
+}
+
+// RuleResponse is the API's RuleResponse schema.
+type RuleResponse struct {
+	Abstract        string   `json:"abstract,omitempty"`
+	Agencies        []string `json:"agencies"`
+	CounterpartID   int      `json:"counterpartId,omitempty"`
+	DocketID        string   `json:"docketId,omitempty"`
+	DocumentNumber  string   `json:"documentNumber"`
+	ID              int      `json:"id"`
+	PublicationDate string   `json:"publicationDate"`
+	RIN             string   `json:"rin,omitempty"`
+	RuleType        string   `json:"ruleType"`
+	Title           string   `json:"title"`
+	URL             string   `json:"url"`
+}
+
+// SaveSearchInputBody is the API's SaveSearchInputBody schema.
+type SaveSearchInputBody struct {
+	// Search filters, as accepted by /api/v1/lex.
+	Filters SearchFilters `json:"filters"`
+	// Name of the saved search.
+	Name string `json:"name"`
+}
+
+// SavedSearchResponse is the API's SavedSearchResponse schema.
+type SavedSearchResponse struct {
+	CreatedAt time.Time     `json:"createdAt"`
+	Filters   SearchFilters `json:"filters"`
+	ID        int           `json:"id"`
+	Name      string        `json:"name"`
+}
+
+// SavedSearchUpdates is the API's SavedSearchUpdates schema.
+type SavedSearchUpdates struct {
+	Bills  []BillResponse      `json:"bills"`
+	Search SavedSearchResponse `json:"search"`
+}
+
+// SearchFacets is the API's SearchFacets schema.
+type SearchFacets struct {
+	BillType       []FacetCount `json:"billType"`
+	Congress       []FacetCount `json:"congress"`
+	IsSpendingBill []FacetCount `json:"isSpendingBill"`
+	OriginChamber  []FacetCount `json:"originChamber"`
+	PolicyArea     []FacetCount `json:"policyArea"`
+}
+
+// SearchFilters is the API's SearchFilters schema.
+type SearchFilters struct {
+	// Filter by congress number.
+	Congress int `json:"congress,omitempty"`
+	// Filter to federal or state bills, or the caller's tenant's drafts. One of:
+	// federal, state, draft.
+	Jurisdiction string `json:"jurisdiction,omitempty"`
+	// Filter by CRS policy area.
+	PolicyArea string `json:"policyArea,omitempty"`
+	// Search text in bill title.
+	Query string `json:"query,omitempty"`
+	// Only spending bills.
+	Spending bool `json:"spending,omitempty"`
+	// Filter by sponsor name (partial match).
+	Sponsor string `json:"sponsor,omitempty"`
+	// Filter by two-letter state code of state bills.
+	State string `json:"state,omitempty"`
+	// Filter by CRS legislative subject.
+	Subject string `json:"subject,omitempty"`
+	// Filter by bill type (hr, s, hjres, sjres).
+	Type string `json:"type,omitempty"`
+}
+
+// SectionAppearance is the API's SectionAppearance schema.
+type SectionAppearance struct {
+	// The section's anchor, as in version text and section diffs.
+	Anchor string       `json:"anchor"`
+	Bill   BillResponse `json:"bill"`
+	// Word for word the same, ignoring case, punctuation, and line breaks.
+	Exact   bool   `json:"exact"`
+	Excerpt string `json:"excerpt"`
+	// When the version was fetched; the earliest the language is known to have
+	// appeared in it.
+	FetchedAt time.Time `json:"fetchedAt"`
+	Heading   string    `json:"heading"`
+	Section   string    `json:"section"`
+	// Estimated share of wording in common with the provision, at most 1.
+	Similarity  float64 `json:"similarity"`
+	VersionCode string  `json:"versionCode"`
+	VersionID   int     `json:"versionId"`
+}
+
+// SectionProvenanceResult is the API's SectionProvenanceResult schema.
+type SectionProvenanceResult struct {
+	Appearances []SectionAppearance `json:"appearances"`
+	// The earliest appearance; absent when there is none.
+	Origin *SectionAppearance `json:"origin,omitempty"`
+	// Words in the provision traced.
+	Words int `json:"words"`
+}
+
+// SemanticBill is the API's SemanticBill schema.
+type SemanticBill struct {
+	Bill BillResponse `json:"bill"`
+	// Cosine similarity of the bill's closest summary or section to the query, at
+	// most 1.
+	Similarity float64 `json:"similarity"`
+}
+
+// SemanticMatch is the API's SemanticMatch schema.
+type SemanticMatch struct {
+	// Sections: the section's anchor, as in version text and section diffs.
+	Anchor  string `json:"anchor,omitempty"`
+	BillID  int    `json:"billId"`
+	Excerpt string `json:"excerpt"`
+	// Sections: the heading; summaries: the action summarized.
+	Heading string `json:"heading,omitempty"`
+	// One of: summary, section.
+	Kind string `json:"kind"`
+	// Sections: the section number.
+	Section    string  `json:"section,omitempty"`
+	Similarity float64 `json:"similarity"`
+	// Sections: the version the section is from.
+	VersionID int `json:"versionId,omitempty"`
+}
+
+// SemanticSearchResult is the API's SemanticSearchResult schema.
+type SemanticSearchResult struct {
+	Bills   []SemanticBill  `json:"bills"`
+	Matches []SemanticMatch `json:"matches"`
+	// Embedding provider and model that ranked the results.
+	Model string `json:"model"`
+}
+
+// SessionResponse is the API's SessionResponse schema.
+type SessionResponse struct {
+	ExpiresAt time.Time `json:"expiresAt"`
+	// Send as 'Authorization: Bearer <token>' in place of an API key.
+	Token string     `json:"token"`
+	User  MeResponse `json:"user"`
+}
+
+// SetUserRoleInputBody is the API's SetUserRoleInputBody schema.
+type SetUserRoleInputBody struct {
+	// New role. One of: reader, editor, admin.
+	Role string `json:"role"`
+}
+
+// SlowQueryThresholdRequest is the API's SlowQueryThresholdRequest schema.
+type SlowQueryThresholdRequest struct {
+	// New threshold in milliseconds; 0 disables slow query logging.
+	ThresholdMs int `json:"thresholdMs"`
+}
+
+// SourceCheck is the API's SourceCheck schema.
+type SourceCheck struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	// SHA-256 of the bytes served now; absent unless status is 200.
+	ContentHash  string `json:"contentHash,omitempty"`
+	Etag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	// The source serves the recorded bytes.
+	Matches bool `json:"matches"`
+	// HTTP status the source answered with.
+	Status int `json:"status"`
+}
+
+// SpendingChange is the API's SpendingChange schema.
+type SpendingChange struct {
+	Account    string `json:"account"`
+	Change     int    `json:"change"`
+	Context    string `json:"context"`
+	FromAmount int    `json:"fromAmount"`
+	FromRaw    string `json:"fromRaw,omitempty"`
+	Key        string `json:"key"`
+	Section    string `json:"section"`
+	Status     string `json:"status"`
+	ToAmount   int    `json:"toAmount"`
+	ToRaw      string `json:"toRaw,omitempty"`
+}
+
+// SpendingChangesResponse is the API's SpendingChangesResponse schema.
+type SpendingChangesResponse struct {
+	AmountsAdded     int              `json:"amountsAdded"`
+	AmountsChanged   int              `json:"amountsChanged"`
+	AmountsRemoved   int              `json:"amountsRemoved"`
+	AmountsUnchanged int              `json:"amountsUnchanged"`
+	BillID           int              `json:"billId"`
+	Changes          []SpendingChange `json:"changes"`
+	FromTotal        int              `json:"fromTotal"`
+	FromVersion      string           `json:"fromVersion"`
+	FromVersionID    int              `json:"fromVersionId"`
+	NetChange        int              `json:"netChange"`
+	ToTotal          int              `json:"toTotal"`
+	ToVersion        string           `json:"toVersion"`
+	ToVersionID      int              `json:"toVersionId"`
+}
+
+// SplitCell is the API's SplitCell schema.
+type SplitCell struct {
+	Anchor     string `json:"anchor,omitempty"`
+	LineNumber int    `json:"lineNumber"`
+	Text       string `json:"text"`
+}
+
+// SplitRow is the API's SplitRow schema.
+type SplitRow struct {
+	Left  SplitCell `json:"left"`
+	Right SplitCell `json:"right"`
+	Type  string    `json:"type"`
+}
+
+// Stats is the API's Stats schema.
+type Stats struct {
+	AvgSentenceLength float64 `json:"avgSentenceLength"`
+	DefinedTerms      int     `json:"definedTerms"`
+	GradeLevel        float64 `json:"gradeLevel"`
+	PageEstimate      int     `json:"pageEstimate"`
+	WordCount         int     `json:"wordCount"`
+}
+
+// StatusCount is the API's StatusCount schema.
+type StatusCount struct {
+	Bills int `json:"bills"`
+	Stage int `json:"stage"`
+	// One of: introduced, referred, reported, passed_one_chamber,
+	// amended_by_second_chamber, enrolled, enacted.
+	Status string `json:"status"`
+}
+
+// SubcommitteeTotal is the API's SubcommitteeTotal schema.
+type SubcommitteeTotal struct {
+	Accounts int    `json:"accounts"`
+	Name     string `json:"name"`
+	// Whole dollars.
+	Total int `json:"total"`
+}
+
+// SummariesResponse is the API's SummariesResponse schema.
+type SummariesResponse struct {
+	BillID    int               `json:"billId"`
+	Summaries []SummaryResponse `json:"summaries"`
+}
+
+// SummaryResponse is the API's SummaryResponse schema.
+type SummaryResponse struct {
+	ActionDate  string `json:"actionDate"`
+	ActionDesc  string `json:"actionDesc"`
+	ID          int    `json:"id"`
+	Text        string `json:"text"`
+	VersionCode string `json:"versionCode"`
+}
+
+// TenantListResponse is the API's TenantListResponse schema.
+type TenantListResponse struct {
+	Tenants []TenantResponse `json:"tenants"`
+}
+
+// TenantResponse is the API's TenantResponse schema.
+type TenantResponse struct {
+	CreatedAt time.Time `json:"createdAt"`
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+}
+
+// TextSearchHit is the API's TextSearchHit schema.
+type TextSearchHit struct {
+	BillID     int    `json:"billId"`
+	BillNumber int    `json:"billNumber"`
+	BillType   string `json:"billType"`
+	Congress   int    `json:"congress"`
+	// Actions: the kind of change, as in the bill's events.
+	EventType string `json:"eventType,omitempty"`
+	// Excerpts around the matches, with matched words wrapped in <mark> tags.
+	Highlights []string `json:"highlights,omitempty"`
+	// ID of the bill, version, or bill event.
+	ID int `json:"id"`
+	// One of: bills, versions, actions.
+	Kind  string  `json:"kind"`
+	Score float64 `json:"score"`
+	// The bill's title.
+	Title string `json:"title"`
+	// Versions: the version's code.
+	VersionCode string `json:"versionCode,omitempty"`
+}
+
+// TextSearchResult is the API's TextSearchResult schema.
+type TextSearchResult struct {
+	Hits   []TextSearchHit `json:"hits"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+	Total  int             `json:"total"`
+}
+
+// TitleResponse is the API's TitleResponse schema.
+type TitleResponse struct {
+	Chamber string `json:"chamber,omitempty"`
+	// official, short, popular, or display.
+	Kind        string `json:"kind"`
+	Title       string `json:"title"`
+	TitleType   string `json:"titleType"`
+	UpdateDate  string `json:"updateDate,omitempty"`
+	VersionCode string `json:"versionCode,omitempty"`
+}
+
+// TreatyListResponse is the API's TreatyListResponse schema.
+type TreatyListResponse struct {
+	Limit    int              `json:"limit"`
+	Offset   int              `json:"offset"`
+	Total    int              `json:"total"`
+	Treaties []TreatyResponse `json:"treaties"`
+}
+
+// TreatyResponse is the API's TreatyResponse schema.
+type TreatyResponse struct {
+	Actions            []ActionResponse `json:"actions,omitempty"`
+	Congress           int              `json:"congress"`
+	CongressConsidered int              `json:"congressConsidered,omitempty"`
+	CurrentStatus      string           `json:"currentStatus"`
+	DocumentNumber     string           `json:"documentNumber"`
+	ID                 int              `json:"id"`
+	InForceDate        string           `json:"inForceDate,omitempty"`
+	Number             int              `json:"number"`
+	StatusDate         string           `json:"statusDate,omitempty"`
+	Suffix             string           `json:"suffix,omitempty"`
+	Title              string           `json:"title"`
+	Topic              string           `json:"topic"`
+	TransmittedDate    string           `json:"transmittedDate,omitempty"`
+	UpdatedAt          time.Time        `json:"updatedAt"`
+}
+
+// TrendingBill is the API's TrendingBill schema.
+type TrendingBill struct {
+	Bill         BillResponse `json:"bill"`
+	Events       int          `json:"events"`
+	LinesChanged int          `json:"linesChanged"`
+	Score        float64      `json:"score"`
+	Versions     int          `json:"versions"`
+}
+
+// TrendingResponse is the API's TrendingResponse schema.
+type TrendingResponse struct {
+	Bills       []TrendingBill `json:"bills"`
+	ComputedAt  *time.Time     `json:"computedAt,omitempty"`
+	WindowStart *time.Time     `json:"windowStart,omitempty"`
+}
+
+// UserResponse is the API's UserResponse schema.
+type UserResponse struct {
+	// Send as the X-API-Key header; it is not shown again.
+	APIKey string `json:"apiKey"`
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	// What the key may do: readers read and keep a watchlist, editors also upload,
+	// admins also use the admin endpoints. One of: reader, editor, admin.
+	Role string `json:"role"`
+	// Tenant whose drafts the key can read and upload.
+	TenantID int `json:"tenantId,omitempty"`
+}
+
+// VersionEarmarksResponse is the API's VersionEarmarksResponse schema.
+type VersionEarmarksResponse struct {
+	BillID      int       `json:"billId"`
+	Earmarks    []Earmark `json:"earmarks"`
+	Total       int       `json:"total"`
+	VersionCode string    `json:"versionCode"`
+	VersionID   int       `json:"versionId"`
+}
+
+// VersionGraphEdge is the API's VersionGraphEdge schema.
+type VersionGraphEdge struct {
+	FromVersionID int `json:"fromVersionId"`
+	ToVersionID   int `json:"toVersionId"`
+}
+
+// VersionGraphResponse is the API's VersionGraphResponse schema.
+type VersionGraphResponse struct {
+	BillID   int                `json:"billId"`
+	Edges    []VersionGraphEdge `json:"edges"`
+	Versions []GraphVersion     `json:"versions"`
+}
+
+// VersionMatrixResponse is the API's VersionMatrixResponse schema.
+type VersionMatrixResponse struct {
+	BillID   int                `json:"billId"`
+	Pairs    []VersionPairStats `json:"pairs"`
+	Pending  int                `json:"pending"`
+	Versions []MatrixVersion    `json:"versions"`
+}
+
+// VersionPairStats is the API's VersionPairStats schema.
+type VersionPairStats struct {
+	Deletions      int     `json:"deletions"`
+	FromVersionID  int     `json:"fromVersionId"`
+	Insertions     int     `json:"insertions"`
+	PercentChanged float64 `json:"percentChanged,omitempty"`
+	Status         string  `json:"status"`
+	ToVersionID    int     `json:"toVersionId"`
+}
+
+// VersionResponse is the API's VersionResponse schema.
+type VersionResponse struct {
+	Chamber     string `json:"chamber,omitempty"`
+	ContentHash string `json:"contentHash"`
+	Date        string `json:"date"`
+	ID          int    `json:"id"`
+	Label       string `json:"label"`
+	Stage       int    `json:"stage"`
+	Stats       *Stats `json:"stats,omitempty"`
+	VersionCode string `json:"versionCode"`
+}
+
+// VersionTextResponse is the API's VersionTextResponse schema.
+type VersionTextResponse struct {
+	Format      string `json:"format"`
+	Length      int    `json:"length"`
+	Offset      int    `json:"offset"`
+	Text        string `json:"text"`
+	TotalLength int    `json:"totalLength"`
+	VersionCode string `json:"versionCode"`
+	VersionID   int    `json:"versionId"`
+}
+
+// VersionsPerDay is the API's VersionsPerDay schema.
+type VersionsPerDay struct {
+	Bills    int    `json:"bills"`
+	Date     string `json:"date"`
+	Versions int    `json:"versions"`
+}
+
+// VersionsPerDayResponse is the API's VersionsPerDayResponse schema.
+type VersionsPerDayResponse struct {
+	ComputedAt *time.Time       `json:"computedAt,omitempty"`
+	Days       []VersionsPerDay `json:"days"`
+}
+
+// WatchedBillUpdates is the API's WatchedBillUpdates schema.
+type WatchedBillUpdates struct {
+	Bill    BillResponse         `json:"bill"`
+	Changes []BillChangeResponse `json:"changes"`
+}
+
+// WatchlistResponse is the API's WatchlistResponse schema.
+type WatchlistResponse struct {
+	Bills    []BillResponse        `json:"bills"`
+	Searches []SavedSearchResponse `json:"searches"`
+}
+
+// WatchlistUpdates is the API's WatchlistUpdates schema.
+type WatchlistUpdates struct {
+	// Keyword alert matches recorded since the last check.
+	Alerts []KeywordAlertUpdates `json:"alerts"`
+	Bills  []WatchedBillUpdates  `json:"bills"`
+	// Pass as 'since' to resume from this check.
+	CheckedAt time.Time            `json:"checkedAt"`
+	Searches  []SavedSearchUpdates `json:"searches"`
+	Since     time.Time            `json:"since"`
+}
+
+// AddBillAlias sends POST /api/v1/admin/bills/{id}/aliases: Add an alias to a
+// bill.
+//
+// Adds a name the bill is known by, such as "CR" or "NDAA", for bill search to
+// match. Curated aliases are kept when the bill's titles are synced.
+func (c *Client) AddBillAlias(ctx context.Context, id int, body AddBillAliasInputBody) (*BillAliasResponse, error) {
+	path := "/api/v1/admin/bills/" + pathParam(id) + "/aliases"
+	var out BillAliasResponse
+	if err := c.do(ctx, "POST", path, nil, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AddDocumentVersionParams are the query and header parameters of AddDocumentVersion.
+type AddDocumentVersionParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// AddDocumentVersion sends POST /api/v1/documents/{id}/versions: Upload a
+// document version.
+//
+// Adds a version to one of the caller's documents, sent as JSON or as a
+// multipart form with a file field and an optional versionCode field.
+// Uploading text identical to an earlier version is rejected. Requires the
+// editor role.
+func (c *Client) AddDocumentVersion(ctx context.Context, id int, body DocumentVersionUpload, params *AddDocumentVersionParams) (*VersionResponse, error) {
+	path := "/api/v1/documents/" + pathParam(id) + "/versions"
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	var out VersionResponse
+	if err := c.do(ctx, "POST", path, nil, header, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AddDraftVersionParams are the query and header parameters of AddDraftVersion.
+type AddDraftVersionParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// AddDraftVersion sends POST /api/v1/drafts/{id}/versions: Upload a draft
+// version.
+//
+// Adds a version to one of the caller's tenant's drafts, to diff against its
+// earlier versions. Requires the editor role.
+func (c *Client) AddDraftVersion(ctx context.Context, id int, body AddDraftVersionInputBody, params *AddDraftVersionParams) (*VersionResponse, error) {
+	path := "/api/v1/drafts/" + pathParam(id) + "/versions"
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	var out VersionResponse
+	if err := c.do(ctx, "POST", path, nil, header, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CheckDiffDeterminismParams are the query and header parameters of CheckDiffDeterminism.
+type CheckDiffDeterminismParams struct {
+	// Return 304 Not Modified if the resource ETag matches one of these values.
+	IfNoneMatch string
+	// Ignore changes in indentation, spacing, and blank lines.
+	IgnoreWhitespace bool
+	// Ignore lines re-wrapped within a paragraph; each paragraph is diffed as one
+	// line.
+	IgnoreLineWrap bool
+	// Ignore page markers, running headers, and page numbers of printed text.
+	StripPageArtifacts bool
+	// Index of the first hunk to return; use nextHunk from the previous page.
+	// Default: 0.
+	HunkOffset int
+	// Maximum hunks to return (0 = all). Default: 0.
+	HunkLimit int
+	// Unchanged lines to keep around each change; hunks further apart than twice
+	// this are split. Default: 3.
+	Context int
+	// unified returns interleaved lines; split returns aligned left/right rows.
+	// One of: unified, split. Default: unified.
+	View string
+}
+
+// CheckDiffDeterminism sends GET
+// /api/v1/bills/{billId}/diff/{fromVersion}/{toVersion}/determinism: Check
+// diff determinism.
+//
+// Recomputes the diff between two versions multiple times and reports whether
+// the results agree with each other and with the stored delta.
+func (c *Client) CheckDiffDeterminism(ctx context.Context, billID int, fromVersion int, toVersion int, params *CheckDiffDeterminismParams) (*DeterminismReport, error) {
+	path := "/api/v1/bills/" + pathParam(billID) + "/diff/" + pathParam(fromVersion) + "/" + pathParam(toVersion) + "/determinism"
+	query := url.Values{}
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "If-None-Match", params.IfNoneMatch)
+		setParam(query.Set, "ignoreWhitespace", params.IgnoreWhitespace)
+		setParam(query.Set, "ignoreLineWrap", params.IgnoreLineWrap)
+		setParam(query.Set, "stripPageArtifacts", params.StripPageArtifacts)
+		setParam(query.Set, "hunkOffset", params.HunkOffset)
+		setParam(query.Set, "hunkLimit", params.HunkLimit)
+		setParam(query.Set, "context", params.Context)
+		setParam(query.Set, "view", params.View)
+	}
+	var out DeterminismReport
+	if err := c.do(ctx, "GET", path, query, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CompareAdhocParams are the query and header parameters of CompareAdhoc.
+type CompareAdhocParams struct {
+	// Ignore changes in indentation, spacing, and blank lines.
+	IgnoreWhitespace bool
+	// Ignore lines re-wrapped within a paragraph; each paragraph is diffed as one
+	// line.
+	IgnoreLineWrap bool
+	// Ignore page markers, running headers, and page numbers of printed text.
+	StripPageArtifacts bool
+	// Index of the first hunk to return; use nextHunk from the previous page.
+	// Default: 0.
+	HunkOffset int
+	// Maximum hunks to return (0 = all). Default: 0.
+	HunkLimit int
+	// Unchanged lines to keep around each change; hunks further apart than twice
+	// this are split. Default: 3.
+	Context int
+	// unified returns interleaved lines; split returns aligned left/right rows.
+	// One of: unified, split. Default: unified.
+	View string
+}
+
+// CompareAdhoc sends POST /api/v1/compare/adhoc: Compare two uploaded texts.
+//
+// Diffs two texts sent in the request, such as a discussion draft and the
+// introduced bill, with the same text extraction and diff engine as stored
+// versions. Nothing is stored. Each text may be up to 100KB.
+func (c *Client) CompareAdhoc(ctx context.Context, body CompareAdhocInputBody, params *CompareAdhocParams) (*DiffResponse, error) {
+	path := "/api/v1/compare/adhoc"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "ignoreWhitespace", params.IgnoreWhitespace)
+		setParam(query.Set, "ignoreLineWrap", params.IgnoreLineWrap)
+		setParam(query.Set, "stripPageArtifacts", params.StripPageArtifacts)
+		setParam(query.Set, "hunkOffset", params.HunkOffset)
+		setParam(query.Set, "hunkLimit", params.HunkLimit)
+		setParam(query.Set, "context", params.Context)
+		setParam(query.Set, "view", params.View)
+	}
+	var out DiffResponse
+	if err := c.do(ctx, "POST", path, query, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CompareBillSummariesParams are the query and header parameters of CompareBillSummaries.
+type CompareBillSummariesParams struct {
+	// Action date of the source summary, YYYY-MM-DD (default: second most recent
+	// summary).
+	From string
+	// Action date of the target summary, YYYY-MM-DD (default: most recent
+	// summary).
+	To string
+	// unified returns interleaved lines; split returns aligned left/right rows.
+	// One of: unified, split. Default: unified.
+	View string
+}
+
+// CompareBillSummaries sends GET /api/v1/bills/{id}/summaries/diff: Compare
+// CRS summaries between action dates.
+//
+// Diffs the CRS summaries written for the actions on two dates. Defaults to
+// the two most recent summaries. fromVersion and toVersion in the response are
+// the summaries' version codes.
+func (c *Client) CompareBillSummaries(ctx context.Context, id int, params *CompareBillSummariesParams) (*DiffResponse, error) {
+	path := "/api/v1/bills/" + pathParam(id) + "/summaries/diff"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "from", params.From)
+		setParam(query.Set, "to", params.To)
+		setParam(query.Set, "view", params.View)
+	}
+	var out DiffResponse
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ComputeDiffParams are the query and header parameters of ComputeDiff.
+type ComputeDiffParams struct {
+	// Return 304 Not Modified if the resource ETag matches one of these values.
+	IfNoneMatch string
+	// Ignore changes in indentation, spacing, and blank lines.
+	IgnoreWhitespace bool
+	// Ignore lines re-wrapped within a paragraph; each paragraph is diffed as one
+	// line.
+	IgnoreLineWrap bool
+	// Ignore page markers, running headers, and page numbers of printed text.
+	StripPageArtifacts bool
+	// Index of the first hunk to return; use nextHunk from the previous page.
+	// Default: 0.
+	HunkOffset int
+	// Maximum hunks to return (0 = all). Default: 0.
+	HunkLimit int
+	// Unchanged lines to keep around each change; hunks further apart than twice
+	// this are split. Default: 3.
+	Context int
+	// unified returns interleaved lines; split returns aligned left/right rows.
+	// One of: unified, split. Default: unified.
+	View string
+}
+
+// ComputeDiff sends GET /api/v1/bills/{billId}/diff/{fromVersion}/{toVersion}:
+// Compute diff between two bill versions.
+//
+// Returns a structured diff showing insertions, deletions, and unchanged text
+// between two versions. With view=split, returns aligned left/right rows for
+// side-by-side rendering. Large diffs can be loaded a page of hunks at a time
+// with hunkOffset and hunkLimit, and context narrows the unchanged lines
+// around each change.
+func (c *Client) ComputeDiff(ctx context.Context, billID int, fromVersion int, toVersion int, params *ComputeDiffParams) (*DiffResponse, error) {
+	path := "/api/v1/bills/" + pathParam(billID) + "/diff/" + pathParam(fromVersion) + "/" + pathParam(toVersion)
+	query := url.Values{}
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "If-None-Match", params.IfNoneMatch)
+		setParam(query.Set, "ignoreWhitespace", params.IgnoreWhitespace)
+		setParam(query.Set, "ignoreLineWrap", params.IgnoreLineWrap)
+		setParam(query.Set, "stripPageArtifacts", params.StripPageArtifacts)
+		setParam(query.Set, "hunkOffset", params.HunkOffset)
+		setParam(query.Set, "hunkLimit", params.HunkLimit)
+		setParam(query.Set, "context", params.Context)
+		setParam(query.Set, "view", params.View)
+	}
+	var out DiffResponse
+	if err := c.do(ctx, "GET", path, query, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ComputeLineageDiffParams are the query and header parameters of ComputeLineageDiff.
+type ComputeLineageDiffParams struct {
+	// Return 304 Not Modified if the resource ETag matches one of these values.
+	IfNoneMatch string
+	// Ignore changes in indentation, spacing, and blank lines.
+	IgnoreWhitespace bool
+	// Ignore lines re-wrapped within a paragraph; each paragraph is diffed as one
+	// line.
+	IgnoreLineWrap bool
+	// Ignore page markers, running headers, and page numbers of printed text.
+	StripPageArtifacts bool
+	// Index of the first hunk to return; use nextHunk from the previous page.
+	// Default: 0.
+	HunkOffset int
+	// Maximum hunks to return (0 = all). Default: 0.
+	HunkLimit int
+	// Unchanged lines to keep around each change; hunks further apart than twice
+	// this are split. Default: 3.
+	Context int
+	// Predecessor to diff against, one of the bill's lineage predecessors.
+	// Default: the best match.
+	PredecessorID int
+	// The bill's version to diff. Default: its first version, as reintroduced.
+	ToVersion int
+	// unified returns interleaved lines; split returns aligned left/right rows.
+	// One of: unified, split. Default: unified.
+	View string
+}
+
+// ComputeLineageDiff sends GET /api/v1/bills/{billId}/lineage/diff: Diff a
+// bill against its predecessor.
+//
+// Returns the diff from the latest text of a bill's predecessor in the
+// previous Congress (see get-bill-lineage), where that Congress left it, to
+// the bill's text: what changed on reintroduction. Takes the same options as
+// compute-diff.
+func (c *Client) ComputeLineageDiff(ctx context.Context, billID int, params *ComputeLineageDiffParams) (*DiffResponse, error) {
+	path := "/api/v1/bills/" + pathParam(billID) + "/lineage/diff"
+	query := url.Values{}
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "If-None-Match", params.IfNoneMatch)
+		setParam(query.Set, "ignoreWhitespace", params.IgnoreWhitespace)
+		setParam(query.Set, "ignoreLineWrap", params.IgnoreLineWrap)
+		setParam(query.Set, "stripPageArtifacts", params.StripPageArtifacts)
+		setParam(query.Set, "hunkOffset", params.HunkOffset)
+		setParam(query.Set, "hunkLimit", params.HunkLimit)
+		setParam(query.Set, "context", params.Context)
+		setParam(query.Set, "predecessorId", params.PredecessorID)
+		setParam(query.Set, "toVersion", params.ToVersion)
+		setParam(query.Set, "view", params.View)
+	}
+	var out DiffResponse
+	if err := c.do(ctx, "GET", path, query, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ComputeParentDiffParams are the query and header parameters of ComputeParentDiff.
+type ComputeParentDiffParams struct {
+	// Return 304 Not Modified if the resource ETag matches one of these values.
+	IfNoneMatch string
+	// Ignore changes in indentation, spacing, and blank lines.
+	IgnoreWhitespace bool
+	// Ignore lines re-wrapped within a paragraph; each paragraph is diffed as one
+	// line.
+	IgnoreLineWrap bool
+	// Ignore page markers, running headers, and page numbers of printed text.
+	StripPageArtifacts bool
+	// Index of the first hunk to return; use nextHunk from the previous page.
+	// Default: 0.
+	HunkOffset int
+	// Maximum hunks to return (0 = all). Default: 0.
+	HunkLimit int
+	// Unchanged lines to keep around each change; hunks further apart than twice
+	// this are split. Default: 3.
+	Context int
+	// unified returns interleaved lines; split returns aligned left/right rows.
+	// One of: unified, split. Default: unified.
+	View string
+}
+
+// ComputeParentDiff sends GET /api/v1/bills/{billId}/diff/{toVersion}: Diff a
+// version against its parent.
+//
+// Returns the diff from the version toVersion derives from in the bill's
+// version graph (see get-version-graph) to toVersion, the comparison that
+// shows what that stage changed. Takes the same options as compute-diff.
+func (c *Client) ComputeParentDiff(ctx context.Context, billID int, toVersion int, params *ComputeParentDiffParams) (*DiffResponse, error) {
+	path := "/api/v1/bills/" + pathParam(billID) + "/diff/" + pathParam(toVersion)
+	query := url.Values{}
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "If-None-Match", params.IfNoneMatch)
+		setParam(query.Set, "ignoreWhitespace", params.IgnoreWhitespace)
+		setParam(query.Set, "ignoreLineWrap", params.IgnoreLineWrap)
+		setParam(query.Set, "stripPageArtifacts", params.StripPageArtifacts)
+		setParam(query.Set, "hunkOffset", params.HunkOffset)
+		setParam(query.Set, "hunkLimit", params.HunkLimit)
+		setParam(query.Set, "context", params.Context)
+		setParam(query.Set, "view", params.View)
+	}
+	var out DiffResponse
+	if err := c.do(ctx, "GET", path, query, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateDocumentParams are the query and header parameters of CreateDocument.
+type CreateDocumentParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// CreateDocument sends POST /api/v1/documents: Create a tracked document.
+//
+// Creates a document to track outside the legislative record, such as agency
+// guidance or a contract. Upload its versions with the document versions
+// endpoint, then diff them like bill versions. Requires the editor role.
+func (c *Client) CreateDocument(ctx context.Context, body CreateDocumentInputBody, params *CreateDocumentParams) (*DocumentResponse, error) {
+	path := "/api/v1/documents"
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	var out DocumentResponse
+	if err := c.do(ctx, "POST", path, nil, header, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateDraftParams are the query and header parameters of CreateDraft.
+type CreateDraftParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// CreateDraft sends POST /api/v1/drafts: Upload a draft.
+//
+// Uploads a private working draft with its first version. Only API keys of the
+// caller's tenant can read it; with one, the bill, version, and diff endpoints
+// serve drafts like public bills, and search includes them. Requires the
+// editor role.
+func (c *Client) CreateDraft(ctx context.Context, body CreateDraftInputBody, params *CreateDraftParams) (*BillResponse, error) {
+	path := "/api/v1/drafts"
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	var out BillResponse
+	if err := c.do(ctx, "POST", path, nil, header, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateKeywordAlertParams are the query and header parameters of CreateKeywordAlert.
+type CreateKeywordAlertParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// CreateKeywordAlert sends POST /api/v1/watchlist/alerts: Create a keyword
+// alert.
+//
+// Registers a boolean keyword query evaluated against the text of every newly
+// ingested version; matches, with snippets and section anchors, are reported
+// by the alert's matches and the watchlist updates endpoints.
+func (c *Client) CreateKeywordAlert(ctx context.Context, body KeywordAlertBody, params *CreateKeywordAlertParams) (*KeywordAlertResponse, error) {
+	path := "/api/v1/watchlist/alerts"
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	var out KeywordAlertResponse
+	if err := c.do(ctx, "POST", path, nil, header, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateTenant sends POST /api/v1/admin/tenants: Create a tenant.
+//
+// Creates an organization that can upload private drafts. Create its users'
+// API keys with the tenant users endpoint.
+func (c *Client) CreateTenant(ctx context.Context, body CreateTenantInputBody) (*TenantResponse, error) {
+	path := "/api/v1/admin/tenants"
+	var out TenantResponse
+	if err := c.do(ctx, "POST", path, nil, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateTenantUser sends POST /api/v1/admin/tenants/{id}/users: Create a
+// tenant user.
+//
+// Creates a user in a tenant and returns its API key, which is shown only
+// once. The key reads the tenant's drafts, uploads them unless its role is
+// reader, and works with the watchlist endpoints.
+func (c *Client) CreateTenantUser(ctx context.Context, id int, body CreateTenantUserInputBody) (*UserResponse, error) {
+	path := "/api/v1/admin/tenants/" + pathParam(id) + "/users"
+	var out UserResponse
+	if err := c.do(ctx, "POST", path, nil, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateUser sends POST /api/v1/users: Create a user.
+//
+// Creates a user with the reader role and returns its API key, which is shown
+// only once. An admin can grant it another role.
+func (c *Client) CreateUser(ctx context.Context, body CreateUserInputBody) (*UserResponse, error) {
+	path := "/api/v1/users"
+	var out UserResponse
+	if err := c.do(ctx, "POST", path, nil, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// CreateWatchlistCalendarParams are the query and header parameters of CreateWatchlistCalendar.
+type CreateWatchlistCalendarParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// CreateWatchlistCalendar sends POST /api/v1/watchlist/calendar: Create a
+// watchlist calendar URL.
+//
+// Returns the URL of an iCalendar feed of the milestones of the caller's
+// watched bills, for calendar apps to subscribe to. The URL carries a secret
+// token shown only once; creating another replaces it.
+func (c *Client) CreateWatchlistCalendar(ctx context.Context, params *CreateWatchlistCalendarParams) (*CalendarResponse, error) {
+	path := "/api/v1/watchlist/calendar"
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	var out CalendarResponse
+	if err := c.do(ctx, "POST", path, nil, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DeleteBillAlias sends DELETE /api/v1/admin/bills/{id}/aliases/{aliasId}:
+// Remove an alias from a bill.
+//
+// Removes one of a bill's aliases. Short and popular titles return on the
+// bill's next sync.
+func (c *Client) DeleteBillAlias(ctx context.Context, id int, aliasID int) error {
+	path := "/api/v1/admin/bills/" + pathParam(id) + "/aliases/" + pathParam(aliasID)
+	return c.do(ctx, "DELETE", path, nil, nil, nil, nil)
+}
+
+// DeleteDelta sends DELETE /api/v1/admin/deltas/{id}: Invalidate a cached
+// delta.
+//
+// Deletes a cached delta; the next request for that version pair recomputes
+// it.
+func (c *Client) DeleteDelta(ctx context.Context, id int) error {
+	path := "/api/v1/admin/deltas/" + pathParam(id)
+	return c.do(ctx, "DELETE", path, nil, nil, nil, nil)
+}
+
+// DeleteKeywordAlertParams are the query and header parameters of DeleteKeywordAlert.
+type DeleteKeywordAlertParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// DeleteKeywordAlert sends DELETE /api/v1/watchlist/alerts/{id}: Delete a
+// keyword alert.
+//
+// Deletes a keyword alert and its matches.
+func (c *Client) DeleteKeywordAlert(ctx context.Context, id int, params *DeleteKeywordAlertParams) error {
+	path := "/api/v1/watchlist/alerts/" + pathParam(id)
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	return c.do(ctx, "DELETE", path, nil, header, nil, nil)
+}
+
+// DeleteSavedSearchParams are the query and header parameters of DeleteSavedSearch.
+type DeleteSavedSearchParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// DeleteSavedSearch sends DELETE /api/v1/watchlist/searches/{id}: Delete a
+// saved search.
+func (c *Client) DeleteSavedSearch(ctx context.Context, id int, params *DeleteSavedSearchParams) error {
+	path := "/api/v1/watchlist/searches/" + pathParam(id)
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	return c.do(ctx, "DELETE", path, nil, header, nil, nil)
+}
+
+// DeleteUserKeywordAlert sends DELETE
+// /api/v1/admin/users/{id}/alerts/{alertId}: Delete a user's keyword alert.
+//
+// Deletes one of a user's keyword alerts and its matches.
+func (c *Client) DeleteUserKeywordAlert(ctx context.Context, id int, alertID int) error {
+	path := "/api/v1/admin/users/" + pathParam(id) + "/alerts/" + pathParam(alertID)
+	return c.do(ctx, "DELETE", path, nil, nil, nil, nil)
+}
+
+// DeleteUserSavedSearch sends DELETE
+// /api/v1/admin/users/{id}/watchlist/searches/{searchId}: Delete a user's
+// saved search.
+func (c *Client) DeleteUserSavedSearch(ctx context.Context, id int, searchID int) error {
+	path := "/api/v1/admin/users/" + pathParam(id) + "/watchlist/searches/" + pathParam(searchID)
+	return c.do(ctx, "DELETE", path, nil, nil, nil, nil)
+}
+
+// DeleteWatchlistCalendarParams are the query and header parameters of DeleteWatchlistCalendar.
+type DeleteWatchlistCalendarParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// DeleteWatchlistCalendar sends DELETE /api/v1/watchlist/calendar: Delete the
+// watchlist calendar URL.
+//
+// Stops publishing the caller's watchlist calendar; its URL returns 404.
+func (c *Client) DeleteWatchlistCalendar(ctx context.Context, params *DeleteWatchlistCalendarParams) error {
+	path := "/api/v1/watchlist/calendar"
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	return c.do(ctx, "DELETE", path, nil, header, nil, nil)
+}
+
+// DiffDocumentVersionsParams are the query and header parameters of DiffDocumentVersions.
+type DiffDocumentVersionsParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+	// Ignore changes in indentation, spacing, and blank lines.
+	IgnoreWhitespace bool
+	// Ignore lines re-wrapped within a paragraph; each paragraph is diffed as one
+	// line.
+	IgnoreLineWrap bool
+	// Ignore page markers, running headers, and page numbers of printed text.
+	StripPageArtifacts bool
+	// Index of the first hunk to return; use nextHunk from the previous page.
+	// Default: 0.
+	HunkOffset int
+	// Maximum hunks to return (0 = all). Default: 0.
+	HunkLimit int
+	// Unchanged lines to keep around each change; hunks further apart than twice
+	// this are split. Default: 3.
+	Context int
+	// unified returns interleaved lines; split returns aligned left/right rows.
+	// One of: unified, split. Default: unified.
+	View string
+}
+
+// DiffDocumentVersions sends GET
+// /api/v1/documents/{id}/diff/{fromVersion}/{toVersion}: Compute diff between
+// two document versions.
+//
+// Returns the same structured diff as the bill diff endpoint for two versions
+// of one of the caller's documents. With view=split, returns aligned
+// left/right rows for side-by-side rendering.
+func (c *Client) DiffDocumentVersions(ctx context.Context, id int, fromVersion int, toVersion int, params *DiffDocumentVersionsParams) (*DiffResponse, error) {
+	path := "/api/v1/documents/" + pathParam(id) + "/diff/" + pathParam(fromVersion) + "/" + pathParam(toVersion)
+	query := url.Values{}
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+		setParam(query.Set, "ignoreWhitespace", params.IgnoreWhitespace)
+		setParam(query.Set, "ignoreLineWrap", params.IgnoreLineWrap)
+		setParam(query.Set, "stripPageArtifacts", params.StripPageArtifacts)
+		setParam(query.Set, "hunkOffset", params.HunkOffset)
+		setParam(query.Set, "hunkLimit", params.HunkLimit)
+		setParam(query.Set, "context", params.Context)
+		setParam(query.Set, "view", params.View)
+	}
+	var out DiffResponse
+	if err := c.do(ctx, "GET", path, query, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DiffRule sends GET /api/v1/rules/{id}/diff: Diff proposed and final rule.
+//
+// Diffs the proposed rule and the final rule sharing a RIN (or docket) with
+// this rule, from proposed to final. fromVersion and toVersion are document
+// numbers.
+func (c *Client) DiffRule(ctx context.Context, id int) (*DiffResponse, error) {
+	path := "/api/v1/rules/" + pathParam(id) + "/diff"
+	var out DiffResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// DismissIngestionFailure sends POST
+// /api/v1/admin/ingest-failures/{id}/dismiss: Dismiss an ingestion failure.
+//
+// Closes an open failure so it is no longer retried.
+func (c *Client) DismissIngestionFailure(ctx context.Context, id int) (*IngestFailureResponse, error) {
+	path := "/api/v1/admin/ingest-failures/" + pathParam(id) + "/dismiss"
+	var out IngestFailureResponse
+	if err := c.do(ctx, "POST", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ExportBillsParams are the query and header parameters of ExportBills.
+type ExportBillsParams struct {
+	// jsonl writes one JSON object per line; csv writes a header row, then one row
+	// per record. One of: jsonl, csv. Default: jsonl.
+	Format string
+	// Filter by congress number.
+	Congress int
+	// Earliest date, YYYY-MM-DD: the bill's update date, or the version's date.
+	From string
+	// Latest date, YYYY-MM-DD, inclusive.
+	To string
+	// Only spending bills.
+	Spending bool
+}
+
+// ExportBills sends GET /api/v1/export/bills: Export bills.
+//
+// Streams every bill matching the filters as JSON Lines or CSV, for loading
+// into tools such as pandas or BigQuery.
+func (c *Client) ExportBills(ctx context.Context, params *ExportBillsParams) (io.ReadCloser, error) {
+	path := "/api/v1/export/bills"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "format", params.Format)
+		setParam(query.Set, "congress", params.Congress)
+		setParam(query.Set, "from", params.From)
+		setParam(query.Set, "to", params.To)
+		setParam(query.Set, "spending", params.Spending)
+	}
+	return c.open(ctx, "GET", path, query, nil, "")
+}
+
+// ExportVersionsParams are the query and header parameters of ExportVersions.
+type ExportVersionsParams struct {
+	// jsonl writes one JSON object per line; csv writes a header row, then one row
+	// per record. One of: jsonl, csv. Default: jsonl.
+	Format string
+	// Filter by congress number.
+	Congress int
+	// Earliest date, YYYY-MM-DD: the bill's update date, or the version's date.
+	From string
+	// Latest date, YYYY-MM-DD, inclusive.
+	To string
+	// Only spending bills.
+	Spending bool
+	// Include each version's plain text (large).
+	IncludeText bool
+}
+
+// ExportVersions sends GET /api/v1/export/versions: Export versions.
+//
+// Streams every text version of the bills matching the filters as JSON Lines
+// or CSV, optionally with plain text.
+func (c *Client) ExportVersions(ctx context.Context, params *ExportVersionsParams) (io.ReadCloser, error) {
+	path := "/api/v1/export/versions"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "format", params.Format)
+		setParam(query.Set, "congress", params.Congress)
+		setParam(query.Set, "from", params.From)
+		setParam(query.Set, "to", params.To)
+		setParam(query.Set, "spending", params.Spending)
+		setParam(query.Set, "includeText", params.IncludeText)
+	}
+	return c.open(ctx, "GET", path, query, nil, "")
+}
+
+// FetchHr1 sends POST /api/v1/bills/hr1/fetch: Fetch H.R. 1 (One Big Beautiful
+// Bill).
+//
+// Fetches H.R. 1 (119th Congress) from Congress.gov and stores all versions.
+func (c *Client) FetchHr1(ctx context.Context) (*BillResponse, error) {
+	path := "/api/v1/bills/hr1/fetch"
+	var out BillResponse
+	if err := c.do(ctx, "POST", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAppropriationsAccountHistoryParams are the query and header parameters of GetAppropriationsAccountHistory.
+type GetAppropriationsAccountHistoryParams struct {
+	// Required. Account key, from list-appropriations-accounts.
+	Key string
+}
+
+// GetAppropriationsAccountHistory sends GET
+// /api/v1/appropriations/accounts/history: Appropriations account funding
+// history.
+//
+// Returns an account's amount in every version of every bill that funds it, to
+// compare across a bill's versions, and its level in each fiscal year with the
+// change from the year before.
+func (c *Client) GetAppropriationsAccountHistory(ctx context.Context, params *GetAppropriationsAccountHistoryParams) (*AccountHistoryResponse, error) {
+	path := "/api/v1/appropriations/accounts/history"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "key", params.Key)
+	}
+	var out AccountHistoryResponse
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetAppropriationsDeadlinesParams are the query and header parameters of GetAppropriationsDeadlines.
+type GetAppropriationsDeadlinesParams struct {
+	// Congress number. Default: the latest with federal bills.
+	Congress int
+	// Only resolutions with this status: active (enacted, funding continues),
+	// expired, or pending (not enacted). One of: active, expired, pending.
+	Status string
+}
+
+// GetAppropriationsDeadlines sends GET /api/v1/appropriations/deadlines:
+// Continuing resolution deadlines.
+//
+// Returns a Congress's continuing resolutions, identified by title or CRS
+// subject, with the day each one's funding runs out as read from its latest
+// text, the days remaining, whether it was enacted, and the appropriations
+// bills of the same fiscal year pending that could replace it.
+func (c *Client) GetAppropriationsDeadlines(ctx context.Context, params *GetAppropriationsDeadlinesParams) (*AppropriationsDeadlinesResponse, error) {
+	path := "/api/v1/appropriations/deadlines"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "congress", params.Congress)
+		setParam(query.Set, "status", params.Status)
+	}
+	var out AppropriationsDeadlinesResponse
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBillParams are the query and header parameters of GetBill.
+type GetBillParams struct {
+	// Return 304 Not Modified if the resource ETag matches one of these values.
+	IfNoneMatch string
+}
+
+// GetBill sends GET /api/v1/bills/{id}: Get a bill by ID.
+//
+// Returns detailed information about a specific legislative bill.
+func (c *Client) GetBill(ctx context.Context, id int, params *GetBillParams) (*BillResponse, error) {
+	path := "/api/v1/bills/" + pathParam(id)
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "If-None-Match", params.IfNoneMatch)
+	}
+	var out BillResponse
+	if err := c.do(ctx, "GET", path, nil, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBillAliases sends GET /api/v1/bills/{id}/aliases: List a bill's aliases.
+//
+// Returns the names a bill is known by besides its official title: its short
+// and popular titles from Congress.gov and nicknames curated by operators.
+// Bill search matches aliases as well as titles.
+func (c *Client) GetBillAliases(ctx context.Context, id int) (*BillAliasesResponse, error) {
+	path := "/api/v1/bills/" + pathParam(id) + "/aliases"
+	var out BillAliasesResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBillAsOfParams are the query and header parameters of GetBillAsOf.
+type GetBillAsOfParams struct {
+	// Required. Date, YYYY-MM-DD; the bill as it stood at the end of the day
+	// (UTC).
+	Date string
+	// Include the diff from the version current on the date to the most recent
+	// version.
+	Diff bool
+}
+
+// GetBillAsOf sends GET /api/v1/bills/{id}/as-of: Get a bill as it stood on a
+// date.
+//
+// Returns the version that was current at the end of the date, with its plain
+// text, and the bill's title, status, sponsor, and law status at the time,
+// reconstructed from its change feed. With diff=true, also diffs that version
+// against the most recent one.
+func (c *Client) GetBillAsOf(ctx context.Context, id int, params *GetBillAsOfParams) (*AsOfResponse, error) {
+	path := "/api/v1/bills/" + pathParam(id) + "/as-of"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "date", params.Date)
+		setParam(query.Set, "diff", params.Diff)
+	}
+	var out AsOfResponse
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBillChangesParams are the query and header parameters of GetBillChanges.
+type GetBillChangesParams struct {
+	// Number of changes per page (max 200). Default: 50.
+	Limit int
+	// Pagination offset. Default: 0.
+	Offset int
+}
+
+// GetBillChanges sends GET /api/v1/bills/{id}/changes: Get a bill's change
+// feed.
+//
+// Returns detected changes to a bill in chronological order: new versions
+// (with insert/delete counts from cached diffs), status transitions, title
+// changes, and sponsor changes.
+func (c *Client) GetBillChanges(ctx context.Context, id int, params *GetBillChangesParams) (*BillChangeFeed, error) {
+	path := "/api/v1/bills/" + pathParam(id) + "/changes"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "limit", params.Limit)
+		setParam(query.Set, "offset", params.Offset)
+	}
+	var out BillChangeFeed
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBillChangesAll iterates over the changes of every page of GetBillChanges,
+// starting at params.Offset and fetching params.Limit at a time. Iteration
+// stops at the first error, which is yielded.
+func (c *Client) GetBillChangesAll(ctx context.Context, id int, params *GetBillChangesParams) iter.Seq2[BillChangeResponse, error] {
+	var page GetBillChangesParams
+	if params != nil {
+		page = *params
+	}
+	return paginate(page.Offset, func(offset int) ([]BillChangeResponse, int, error) {
+		page.Offset = offset
+		result, err := c.GetBillChanges(ctx, id, &page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Changes, result.Total, nil
+	})
+}
+
+// GetBillCostEstimates sends GET /api/v1/bills/{id}/cost-estimates: Get a
+// bill's CBO cost estimates.
+//
+// Returns links to the Congressional Budget Office cost estimates published
+// for a bill, newest first.
+func (c *Client) GetBillCostEstimates(ctx context.Context, id int) (*CostEstimatesResponse, error) {
+	path := "/api/v1/bills/" + pathParam(id) + "/cost-estimates"
+	var out CostEstimatesResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBillDefinitionChangesParams are the query and header parameters of GetBillDefinitionChanges.
+type GetBillDefinitionChangesParams struct {
+	// Source version ID (default: second most recent version).
+	From int
+	// Target version ID (default: most recent version).
+	To int
+	// Include definitions that did not change.
+	IncludeUnchanged bool
+}
+
+// GetBillDefinitionChanges sends GET /api/v1/bills/{id}/definition-changes:
+// Compare defined terms between two bill versions.
+//
+// Extracts the terms defined in each version's definitions sections (e.g.,
+// "SEC. 2. DEFINITIONS.") and reports which definitions were added, removed,
+// or reworded. Rewording ignores case and whitespace. Defaults to the two most
+// recent versions.
+func (c *Client) GetBillDefinitionChanges(ctx context.Context, id int, params *GetBillDefinitionChangesParams) (*DefinitionChangesResponse, error) {
+	path := "/api/v1/bills/" + pathParam(id) + "/definition-changes"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "from", params.From)
+		setParam(query.Set, "to", params.To)
+		setParam(query.Set, "includeUnchanged", params.IncludeUnchanged)
+	}
+	var out DefinitionChangesResponse
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBillEarmarkChangesParams are the query and header parameters of GetBillEarmarkChanges.
+type GetBillEarmarkChangesParams struct {
+	// Source version ID (default: second most recent version).
+	From int
+	// Target version ID (default: most recent version).
+	To int
+	// Include earmarks that did not change.
+	IncludeUnchanged bool
+}
+
+// GetBillEarmarkChanges sends GET /api/v1/bills/{id}/earmark-changes: Compare
+// earmarks between two bill versions.
+//
+// Matches earmarks between two versions by recipient and purpose and reports
+// which were added or removed and whose amounts changed. Defaults to the two
+// most recent versions.
+func (c *Client) GetBillEarmarkChanges(ctx context.Context, id int, params *GetBillEarmarkChangesParams) (*EarmarkChangesResponse, error) {
+	path := "/api/v1/bills/" + pathParam(id) + "/earmark-changes"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "from", params.From)
+		setParam(query.Set, "to", params.To)
+		setParam(query.Set, "includeUnchanged", params.IncludeUnchanged)
+	}
+	var out EarmarkChangesResponse
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBillFeed sends GET /feeds/bills/{id}.atom: Atom feed of a bill's changes.
+//
+// Returns an Atom feed of the latest changes detected on a single bill, with
+// diff statistics for new versions.
+func (c *Client) GetBillFeed(ctx context.Context, id int) (io.ReadCloser, error) {
+	path := "/feeds/bills/" + pathParam(id) + ".atom"
+	return c.open(ctx, "GET", path, nil, nil, "application/atom+xml")
+}
+
+// GetBillLineage sends GET /api/v1/bills/{id}/lineage: Get a bill's lineage
+// across Congresses.
+//
+// Returns the bills of the previous Congress a bill reintroduces and those of
+// the next Congress that reintroduce it, matched by the ingestor on title,
+// sponsor, and the share of sections with the same language, and the chain of
+// best matches through every Congress.
+func (c *Client) GetBillLineage(ctx context.Context, id int) (*LineageResponse, error) {
+	path := "/api/v1/bills/" + pathParam(id) + "/lineage"
+	var out LineageResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBillSpendingChangesParams are the query and header parameters of GetBillSpendingChanges.
+type GetBillSpendingChangesParams struct {
+	// Source version ID (default: second most recent version).
+	From int
+	// Target version ID (default: most recent version).
+	To int
+	// Include amounts that did not change.
+	IncludeUnchanged bool
+}
+
+// GetBillSpendingChanges sends GET /api/v1/bills/{id}/spending-changes:
+// Compare dollar amounts between two bill versions.
+//
+// Extracts dollar amounts with their section and account context from two
+// versions and reports which amounts were added, removed, or changed and by
+// how much. Defaults to the two most recent versions.
+func (c *Client) GetBillSpendingChanges(ctx context.Context, id int, params *GetBillSpendingChangesParams) (*SpendingChangesResponse, error) {
+	path := "/api/v1/bills/" + pathParam(id) + "/spending-changes"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "from", params.From)
+		setParam(query.Set, "to", params.To)
+		setParam(query.Set, "includeUnchanged", params.IncludeUnchanged)
+	}
+	var out SpendingChangesResponse
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBillSummaries sends GET /api/v1/bills/{id}/summaries: Get a bill's CRS
+// summaries.
+//
+// Returns the Congressional Research Service summaries of a bill, one per
+// summarized action, oldest first.
+func (c *Client) GetBillSummaries(ctx context.Context, id int) (*SummariesResponse, error) {
+	path := "/api/v1/bills/" + pathParam(id) + "/summaries"
+	var out SummariesResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBillVersionMatrixParams are the query and header parameters of GetBillVersionMatrix.
+type GetBillVersionMatrixParams struct {
+	// Return 304 Not Modified if the resource ETag matches one of these values.
+	IfNoneMatch string
+}
+
+// GetBillVersionMatrix sends GET /api/v1/bills/{id}/version-matrix: Get diff
+// statistics for every pair of a bill's versions.
+//
+// Returns insertions, deletions, and percent of lines changed from each
+// version to each later one, from cached diffs. Pairs not diffed yet are
+// pending and queued; request again to pick them up.
+func (c *Client) GetBillVersionMatrix(ctx context.Context, id int, params *GetBillVersionMatrixParams) (*VersionMatrixResponse, error) {
+	path := "/api/v1/bills/" + pathParam(id) + "/version-matrix"
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "If-None-Match", params.IfNoneMatch)
+	}
+	var out VersionMatrixResponse
+	if err := c.do(ctx, "GET", path, nil, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBillVersionsParams are the query and header parameters of GetBillVersions.
+type GetBillVersionsParams struct {
+	// Return 304 Not Modified if the resource ETag matches one of these values.
+	IfNoneMatch string
+}
+
+// GetBillVersions sends GET /api/v1/bills/{id}/versions: Get all versions of a
+// bill.
+//
+// Returns all tracked versions/snapshots of a bill's text.
+func (c *Client) GetBillVersions(ctx context.Context, id int, params *GetBillVersionsParams) (*GetBillVersionsOutputBody, error) {
+	path := "/api/v1/bills/" + pathParam(id) + "/versions"
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "If-None-Match", params.IfNoneMatch)
+	}
+	var out GetBillVersionsOutputBody
+	if err := c.do(ctx, "GET", path, nil, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBillsByStatusParams are the query and header parameters of GetBillsByStatus.
+type GetBillsByStatusParams struct {
+	// Only this congress (default: every congress).
+	Congress int
+}
+
+// GetBillsByStatus sends GET /api/v1/stats/bills-by-status: Count bills by
+// status per congress.
+//
+// Counts public federal bills by the furthest legislative stage their text has
+// reached, per congress. Bills without published text count as introduced, and
+// bills with a law number as enacted. Refreshed by the ingestor after each
+// run; computedAt says when.
+func (c *Client) GetBillsByStatus(ctx context.Context, params *GetBillsByStatusParams) (*BillsByStatusResponse, error) {
+	path := "/api/v1/stats/bills-by-status"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "congress", params.Congress)
+	}
+	var out BillsByStatusResponse
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetBillsFeed sends GET /feeds/bills.atom: Atom feed of recently changed
+// bills.
+//
+// Returns an Atom feed of the latest changes detected across all bills, with
+// diff statistics for new versions.
+func (c *Client) GetBillsFeed(ctx context.Context) (io.ReadCloser, error) {
+	path := "/feeds/bills.atom"
+	return c.open(ctx, "GET", path, nil, nil, "application/atom+xml")
+}
+
+// GetChangeReport sends GET
+// /api/v1/bills/{billId}/diff/{fromVersion}/{toVersion}/report.docx: Download
+// a change report as a Word document.
+//
+// Returns a Word document (.docx) reporting the changes between two versions:
+// word, page, and section counts, the stored summary of the changes when there
+// is one, and each added, removed, and amended section, its changes marked as
+// tracked insertions and deletions that can be reviewed in Word.
+func (c *Client) GetChangeReport(ctx context.Context, billID int, fromVersion int, toVersion int) (io.ReadCloser, error) {
+	path := "/api/v1/bills/" + pathParam(billID) + "/diff/" + pathParam(fromVersion) + "/" + pathParam(toVersion) + "/report.docx"
+	return c.open(ctx, "GET", path, nil, nil, "application/vnd.openxmlformats-officedocument.wordprocessingml.document")
+}
+
+// GetDatabaseStats sends GET /api/v1/admin/database: Get database pool
+// statistics.
+//
+// Returns the API server's connection pool statistics, for the primary and
+// each read replica, and its slow query threshold. A growing waitCount means
+// queries are queuing for a free connection.
+func (c *Client) GetDatabaseStats(ctx context.Context) (*DatabaseStatsResponse, error) {
+	path := "/api/v1/admin/database"
+	var out DatabaseStatsResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDeltaJob sends GET /api/v1/admin/deltas/jobs/{id}: Get a delta recompute
+// job.
+//
+// Returns a delta recompute job's status and progress.
+func (c *Client) GetDeltaJob(ctx context.Context, id int) (*DeltaJobResponse, error) {
+	path := "/api/v1/admin/deltas/jobs/" + pathParam(id)
+	var out DeltaJobResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDiagnostics sends GET /api/v1/diagnostics: Diagnostics.
+//
+// Pings the database (with connection pool statistics) and Congress.gov (with
+// latency, cached for 30 seconds), and reports the remaining Congress.gov rate
+// limit, the last successful ingestion, and pending delta jobs, ingestion
+// retries, and background diffs. Status is degraded if a check fails.
+func (c *Client) GetDiagnostics(ctx context.Context) (*DiagnosticsReport, error) {
+	path := "/api/v1/diagnostics"
+	var out DiagnosticsReport
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDiffSizeByType sends GET /api/v1/stats/diff-size-by-type: Average diff
+// size by bill type.
+//
+// Returns the number of stored diffs of each federal bill type and their
+// average lines inserted, deleted, and changed. Refreshed by the ingestor
+// after each run; computedAt says when.
+func (c *Client) GetDiffSizeByType(ctx context.Context) (*DiffSizeByTypeResponse, error) {
+	path := "/api/v1/stats/diff-size-by-type"
+	var out DiffSizeByTypeResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDocumentParams are the query and header parameters of GetDocument.
+type GetDocumentParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// GetDocument sends GET /api/v1/documents/{id}: Get a tracked document.
+//
+// Returns one of the caller's documents with its versions and their content
+// hashes.
+func (c *Client) GetDocument(ctx context.Context, id int, params *GetDocumentParams) (*DocumentResponse, error) {
+	path := "/api/v1/documents/" + pathParam(id)
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	var out DocumentResponse
+	if err := c.do(ctx, "GET", path, nil, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetDocumentVersionsParams are the query and header parameters of GetDocumentVersions.
+type GetDocumentVersionsParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// GetDocumentVersions sends GET /api/v1/documents/{id}/versions: Get all
+// versions of a tracked document.
+//
+// Returns the uploaded versions of one of the caller's documents, oldest
+// first.
+func (c *Client) GetDocumentVersions(ctx context.Context, id int, params *GetDocumentVersionsParams) (*GetDocumentVersionsOutputBody, error) {
+	path := "/api/v1/documents/" + pathParam(id) + "/versions"
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	var out GetDocumentVersionsOutputBody
+	if err := c.do(ctx, "GET", path, nil, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetEventsParams are the query and header parameters of GetEvents.
+type GetEventsParams struct {
+	// Only events for this congress (0 = all).
+	Congress int
+	// Only events for this bill type, e.g., hr (case-insensitive).
+	BillType string
+	// Only events for this bill ID (0 = all).
+	BillID int
+}
+
+// GetEvents sends GET /api/v1/events: Stream live bill updates.
+//
+// Server-Sent Events stream that pushes an event whenever the ingestor creates
+// or updates a bill (bill_created, bill_updated) or stores a new version
+// (version_created). Each event's data is a JSON object identifying the bill.
+// Events published while a client is disconnected are not replayed; use the
+// change feed to catch up.
+func (c *Client) GetEvents(ctx context.Context, params *GetEventsParams) (io.ReadCloser, error) {
+	path := "/api/v1/events"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "congress", params.Congress)
+		setParam(query.Set, "billType", params.BillType)
+		setParam(query.Set, "billId", params.BillID)
+	}
+	return c.open(ctx, "GET", path, query, nil, "text/event-stream")
+}
+
+// GetHealth sends GET /health: Health Check.
+//
+// Returns the status of the API and database connection.
+func (c *Client) GetHealth(ctx context.Context) (*DiagnosticHealthOutputBody, error) {
+	path := "/health"
+	var out DiagnosticHealthOutputBody
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetHr1Params are the query and header parameters of GetHr1.
+type GetHr1Params struct {
+	// Return 304 Not Modified if the resource ETag matches one of these values.
+	IfNoneMatch string
+}
+
+// GetHr1 sends GET /api/v1/bills/hr1: Get H.R. 1 (One Big Beautiful Bill).
+//
+// Returns H.R. 1 with all versions. Auto-fetches from Congress.gov if not
+// cached.
+func (c *Client) GetHr1(ctx context.Context, params *GetHr1Params) (*BillResponse, error) {
+	path := "/api/v1/bills/hr1"
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "If-None-Match", params.IfNoneMatch)
+	}
+	var out BillResponse
+	if err := c.do(ctx, "GET", path, nil, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetLatestIngestionRun sends GET /api/v1/admin/ingestions/latest: Get the
+// latest ingestion run.
+//
+// Returns the most recently started ingestion run, including runs still in
+// progress.
+func (c *Client) GetLatestIngestionRun(ctx context.Context) (*IngestRunResponse, error) {
+	path := "/api/v1/admin/ingestions/latest"
+	var out IngestRunResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetLiveness sends GET /healthz: Liveness probe.
+//
+// Returns 200 while the process is running. Does not check dependencies.
+func (c *Client) GetLiveness(ctx context.Context) (*LivenessOutputBody, error) {
+	path := "/healthz"
+	var out LivenessOutputBody
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMeParams are the query and header parameters of GetMe.
+type GetMeParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// GetMe sends GET /api/v1/me: Get the current user.
+//
+// Returns the user a session token or API key belongs to.
+func (c *Client) GetMe(ctx context.Context, params *GetMeParams) (*MeResponse, error) {
+	path := "/api/v1/me"
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	var out MeResponse
+	if err := c.do(ctx, "GET", path, nil, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMemberImpact sends GET /api/v1/members/{id}/impact: Get a member's
+// text-influence scorecard.
+//
+// Returns sponsorship counts, how many provisions from the member's sponsored
+// bills survived into enacted text, and total enacted appropriations.
+func (c *Client) GetMemberImpact(ctx context.Context, id string) (*MemberImpactResponse, error) {
+	path := "/api/v1/members/" + pathParam(id) + "/impact"
+	var out MemberImpactResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetMemberStats sends GET /api/v1/members/{id}/stats: Get a member's
+// sponsorship stats.
+//
+// Returns bills sponsored and enacted per congress, the enactment rate, the
+// average text churn of the member's bills (lines changed between consecutive
+// versions), and the policy areas they sponsor in. Served from aggregates the
+// ingestor refreshes each polling cycle; computedAt says when.
+func (c *Client) GetMemberStats(ctx context.Context, id string) (*MemberStatsResponse, error) {
+	path := "/api/v1/members/" + pathParam(id) + "/stats"
+	var out MemberStatsResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetNomination sends GET /api/v1/nominations/{id}: Get a nomination.
+//
+// Returns a nomination with its actions, from receipt through committee and
+// floor consideration.
+func (c *Client) GetNomination(ctx context.Context, id int) (*NominationResponse, error) {
+	path := "/api/v1/nominations/" + pathParam(id)
+	var out NominationResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetReadiness sends GET /readyz: Readiness probe.
+//
+// Returns 200 when the database (and optionally Congress.gov) is reachable,
+// 503 otherwise or while shutting down.
+func (c *Client) GetReadiness(ctx context.Context) (*ReadinessReport, error) {
+	path := "/readyz"
+	var out ReadinessReport
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetReingestJob sends GET /api/v1/admin/reingest/{id}: Get a reingest job.
+//
+// Returns a re-ingestion job's status and progress.
+func (c *Client) GetReingestJob(ctx context.Context, id int) (*ReingestJobResponse, error) {
+	path := "/api/v1/admin/reingest/" + pathParam(id)
+	var out ReingestJobResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRelatedBills sends GET /api/v1/bills/{id}/related: Get a bill's related
+// bills.
+//
+// Returns bills Congress.gov lists as related, with House/Senate companions
+// (identical bills in the other chamber) first. Ingested related bills include
+// their latest version ID for use with the diff endpoint.
+func (c *Client) GetRelatedBills(ctx context.Context, id int) (*RelatedBillsResponse, error) {
+	path := "/api/v1/bills/" + pathParam(id) + "/related"
+	var out RelatedBillsResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetRule sends GET /api/v1/rules/{id}: Get a rule.
+//
+// Returns a rule and the ID of the proposed or final rule it is diffed
+// against.
+func (c *Client) GetRule(ctx context.Context, id int) (*RuleResponse, error) {
+	path := "/api/v1/rules/" + pathParam(id)
+	var out RuleResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTreaty sends GET /api/v1/treaties/{id}: Get a treaty.
+//
+// Returns a treaty with its actions, from transmittal through committee and
+// floor consideration.
+func (c *Client) GetTreaty(ctx context.Context, id int) (*TreatyResponse, error) {
+	path := "/api/v1/treaties/" + pathParam(id)
+	var out TreatyResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetTrendingBillsParams are the query and header parameters of GetTrendingBills.
+type GetTrendingBillsParams struct {
+	// Return 304 Not Modified if the resource ETag matches one of these values.
+	IfNoneMatch string
+	// Number of bills (max 100). Default: 20.
+	Limit int
+}
+
+// GetTrendingBills sends GET /api/v1/bills/trending: List the most actively
+// changing bills.
+//
+// Ranks bills by activity over a sliding window (a week by default): text
+// versions published, legislative events, and lines changed between versions.
+// Scores are recomputed by the ingestor after each run.
+func (c *Client) GetTrendingBills(ctx context.Context, params *GetTrendingBillsParams) (*TrendingResponse, error) {
+	path := "/api/v1/bills/trending"
+	query := url.Values{}
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "If-None-Match", params.IfNoneMatch)
+		setParam(query.Set, "limit", params.Limit)
+	}
+	var out TrendingResponse
+	if err := c.do(ctx, "GET", path, query, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetUserWatchlist sends GET /api/v1/admin/users/{id}/watchlist: Get a user's
+// watchlist.
+//
+// Returns a user's saved searches and watched bills.
+func (c *Client) GetUserWatchlist(ctx context.Context, id int) (*WatchlistResponse, error) {
+	path := "/api/v1/admin/users/" + pathParam(id) + "/watchlist"
+	var out WatchlistResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetVersionEarmarks sends GET /api/v1/versions/{id}/earmarks: List a
+// version's earmarks.
+//
+// Returns the community project funding entries in a version's text, each with
+// its recipient, purpose, amount, and section: clauses directing an amount as
+// a grant to a named recipient, and rows of community project funding tables.
+func (c *Client) GetVersionEarmarks(ctx context.Context, id int) (*VersionEarmarksResponse, error) {
+	path := "/api/v1/versions/" + pathParam(id) + "/earmarks"
+	var out VersionEarmarksResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetVersionGraph sends GET /api/v1/bills/{id}/version-graph: Get a bill's
+// version graph.
+//
+// Returns which version each of a bill's versions derives from, as parent
+// edges. Versions branch: an engrossed amendment derives from the other
+// chamber's passed text rather than the version fetched before it, and
+// enrolled text from the version the chambers agreed to. Derived from version
+// codes and fetch order; unmapped codes follow the version fetched before
+// them.
+func (c *Client) GetVersionGraph(ctx context.Context, id int) (*VersionGraphResponse, error) {
+	path := "/api/v1/bills/" + pathParam(id) + "/version-graph"
+	var out VersionGraphResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetVersionProvenance sends GET /api/v1/versions/{id}/provenance: Get a
+// version's provenance.
+//
+// Returns where a version's text was retrieved from: the source URL, its
+// Last-Modified and ETag headers, when it was retrieved, and the SHA-256 of
+// the bytes retrieved.
+func (c *Client) GetVersionProvenance(ctx context.Context, id int) (*ProvenanceResponse, error) {
+	path := "/api/v1/versions/" + pathParam(id) + "/provenance"
+	var out ProvenanceResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetVersionTextParams are the query and header parameters of GetVersionText.
+type GetVersionTextParams struct {
+	// Return 304 Not Modified if the resource ETag matches one of these values.
+	IfNoneMatch string
+	// plain is the extracted text diffs are computed over; xml is the original
+	// Formatted XML; html is the original HTML, or the plain text in a <pre>
+	// block. One of: plain, html, xml. Default: plain.
+	Format string
+	// First section number to return (plain and html only).
+	FromSection string
+	// Last section number to return, inclusive (default: fromSection).
+	ToSection string
+	// Byte offset into the selected text. Default: 0.
+	Offset int
+	// Maximum bytes to return (0 = to the end). Default: 0.
+	Length int
+}
+
+// GetVersionText sends GET /api/v1/versions/{id}/text: Get a version's text.
+//
+// Returns a version's text as plain text, HTML, or the original XML.
+// fromSection/toSection select a range of sections, and offset/length a byte
+// range within it, so large bills can be loaded in portions; totalLength is
+// the size of the whole selection.
+func (c *Client) GetVersionText(ctx context.Context, id int, params *GetVersionTextParams) (*VersionTextResponse, error) {
+	path := "/api/v1/versions/" + pathParam(id) + "/text"
+	query := url.Values{}
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "If-None-Match", params.IfNoneMatch)
+		setParam(query.Set, "format", params.Format)
+		setParam(query.Set, "fromSection", params.FromSection)
+		setParam(query.Set, "toSection", params.ToSection)
+		setParam(query.Set, "offset", params.Offset)
+		setParam(query.Set, "length", params.Length)
+	}
+	var out VersionTextResponse
+	if err := c.do(ctx, "GET", path, query, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetVersionsPerDayParams are the query and header parameters of GetVersionsPerDay.
+type GetVersionsPerDayParams struct {
+	// Number of days, ending today (UTC). Default: 30.
+	Days int
+}
+
+// GetVersionsPerDay sends GET /api/v1/stats/versions-per-day: Count versions
+// ingested per day.
+//
+// Counts the bill text versions ingested on each recent day, and the bills
+// they belong to. Refreshed by the ingestor after each run; computedAt says
+// when.
+func (c *Client) GetVersionsPerDay(ctx context.Context, params *GetVersionsPerDayParams) (*VersionsPerDayResponse, error) {
+	path := "/api/v1/stats/versions-per-day"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "days", params.Days)
+	}
+	var out VersionsPerDayResponse
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWatchlistParams are the query and header parameters of GetWatchlist.
+type GetWatchlistParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// GetWatchlist sends GET /api/v1/watchlist: Get watchlist.
+//
+// Returns the caller's saved searches and watched bills.
+func (c *Client) GetWatchlist(ctx context.Context, params *GetWatchlistParams) (*WatchlistResponse, error) {
+	path := "/api/v1/watchlist"
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	var out WatchlistResponse
+	if err := c.do(ctx, "GET", path, nil, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// GetWatchlistCalendar sends GET /feeds/watchlist/{token}.ics: iCalendar feed
+// of watched bills' milestones.
+//
+// Returns an iCalendar feed of all-day events for the bills on a watchlist:
+// committee markups and floor consideration scheduled in their actions, the
+// day continuing resolutions expire, and the start of the fiscal year
+// unenacted appropriations bills fund. Events stay for 90 days after their
+// day.
+func (c *Client) GetWatchlistCalendar(ctx context.Context, token string) (io.ReadCloser, error) {
+	path := "/feeds/watchlist/" + pathParam(token) + ".ics"
+	return c.open(ctx, "GET", path, nil, nil, "text/calendar")
+}
+
+// GetWatchlistUpdatesParams are the query and header parameters of GetWatchlistUpdates.
+type GetWatchlistUpdatesParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+	// Report changes after this time (RFC 3339); defaults to the last check.
+	Since time.Time
+}
+
+// GetWatchlistUpdates sends GET /api/v1/watchlist/updates: Get watchlist
+// updates.
+//
+// Returns changes to watched bills, bills newly matching saved searches, and
+// keyword alert matches since the last check (or 'since'), then records the
+// check.
+func (c *Client) GetWatchlistUpdates(ctx context.Context, params *GetWatchlistUpdatesParams) (*WatchlistUpdates, error) {
+	path := "/api/v1/watchlist/updates"
+	query := url.Values{}
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+		setParam(query.Set, "since", params.Since)
+	}
+	var out WatchlistUpdates
+	if err := c.do(ctx, "GET", path, query, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListAppropriationsAccountsParams are the query and header parameters of ListAppropriationsAccounts.
+type ListAppropriationsAccountsParams struct {
+	// Fiscal year. Default: the latest with parsed accounts.
+	FiscalYear int
+	// Only accounts of this bill. One of: Agriculture-FDA,
+	// Commerce-Justice-Science, Defense, Energy-Water, Financial Services-General
+	// Government, Homeland Security, Interior-Environment, Labor-HHS-Education,
+	// Legislative Branch, Military Construction-VA, State-Foreign Operations,
+	// Transportation-HUD.
+	Subcommittee string
+	// Search in agency, bureau, and account names (case-insensitive partial
+	// match).
+	Query string
+	// Number of results per page (max 200). Default: 50.
+	Limit int
+	// Pagination offset. Default: 0.
+	Offset int
+}
+
+// ListAppropriationsAccounts sends GET /api/v1/appropriations/accounts:
+// Appropriations account funding levels.
+//
+// Returns the accounts the twelve annual appropriations bills fund in a fiscal
+// year, parsed from their titles, agencies, and accounts, with each account's
+// amount as enacted or, until then, as in the latest version, and totals by
+// bill.
+func (c *Client) ListAppropriationsAccounts(ctx context.Context, params *ListAppropriationsAccountsParams) (*AppropriationsAccountsResponse, error) {
+	path := "/api/v1/appropriations/accounts"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "fiscalYear", params.FiscalYear)
+		setParam(query.Set, "subcommittee", params.Subcommittee)
+		setParam(query.Set, "query", params.Query)
+		setParam(query.Set, "limit", params.Limit)
+		setParam(query.Set, "offset", params.Offset)
+	}
+	var out AppropriationsAccountsResponse
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListAuditLogParams are the query and header parameters of ListAuditLog.
+type ListAuditLogParams struct {
+	// Only entries of this user.
+	UserID int
+	// Only entries of this kind of caller. One of: user, admin_token, anonymous.
+	ActorType string
+	// Only entries of this operation ID, e.g., start-reingest.
+	Operation string
+	// Only entries targeting this collection, e.g., users.
+	TargetType string
+	// Only entries targeting this ID.
+	TargetID string
+	// Only entries at or after this time (RFC 3339).
+	Since time.Time
+	// Only entries before this time (RFC 3339).
+	Until time.Time
+	// Number of results per page (max 200). Default: 50.
+	Limit int
+	// Pagination offset. Default: 0.
+	Offset int
+}
+
+// ListAuditLog sends GET /api/v1/admin/audit: List the audit log.
+//
+// Returns recorded requests to mutating operations, newest first: admin
+// actions, watchlist and alert changes, and uploads, with the caller, target,
+// response status, and before/after summaries where recorded.
+func (c *Client) ListAuditLog(ctx context.Context, params *ListAuditLogParams) (*AuditLogList, error) {
+	path := "/api/v1/admin/audit"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "userId", params.UserID)
+		setParam(query.Set, "actorType", params.ActorType)
+		setParam(query.Set, "operation", params.Operation)
+		setParam(query.Set, "targetType", params.TargetType)
+		setParam(query.Set, "targetId", params.TargetID)
+		setParam(query.Set, "since", params.Since)
+		setParam(query.Set, "until", params.Until)
+		setParam(query.Set, "limit", params.Limit)
+		setParam(query.Set, "offset", params.Offset)
+	}
+	var out AuditLogList
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListAuditLogAll iterates over the entries of every page of ListAuditLog,
+// starting at params.Offset and fetching params.Limit at a time. Iteration
+// stops at the first error, which is yielded.
+func (c *Client) ListAuditLogAll(ctx context.Context, params *ListAuditLogParams) iter.Seq2[AuditLogResponse, error] {
+	var page ListAuditLogParams
+	if params != nil {
+		page = *params
+	}
+	return paginate(page.Offset, func(offset int) ([]AuditLogResponse, int, error) {
+		page.Offset = offset
+		result, err := c.ListAuditLog(ctx, &page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Entries, result.Total, nil
+	})
+}
+
+// ListBillsParams are the query and header parameters of ListBills.
+type ListBillsParams struct {
+	// Only return bills that became law.
+	BecameLaw bool
+}
+
+// ListBills sends GET /api/v1/bills: List all bills.
+//
+// Returns all bills stored in the database. With becameLaw=true, returns only
+// bills enacted as public or private law.
+func (c *Client) ListBills(ctx context.Context, params *ListBillsParams) (*ListBillsOutputBody, error) {
+	path := "/api/v1/bills"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "becameLaw", params.BecameLaw)
+	}
+	var out ListBillsOutputBody
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListDocumentsParams are the query and header parameters of ListDocuments.
+type ListDocumentsParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// ListDocuments sends GET /api/v1/documents: List tracked documents.
+//
+// Returns the caller's documents, most recently updated first.
+func (c *Client) ListDocuments(ctx context.Context, params *ListDocumentsParams) (*DocumentListResponse, error) {
+	path := "/api/v1/documents"
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	var out DocumentListResponse
+	if err := c.do(ctx, "GET", path, nil, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListDraftsParams are the query and header parameters of ListDrafts.
+type ListDraftsParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// ListDrafts sends GET /api/v1/drafts: List drafts.
+//
+// Returns the drafts of the caller's tenant, most recently updated first.
+func (c *Client) ListDrafts(ctx context.Context, params *ListDraftsParams) (*DraftListResponse, error) {
+	path := "/api/v1/drafts"
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	var out DraftListResponse
+	if err := c.do(ctx, "GET", path, nil, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListIngestionFailuresParams are the query and header parameters of ListIngestionFailures.
+type ListIngestionFailuresParams struct {
+	// Only failures with this status (default: pending and exhausted). One of:
+	// pending, exhausted, resolved, dismissed.
+	Status string
+	// Number of results per page (max 100). Default: 20.
+	Limit int
+	// Pagination offset. Default: 0.
+	Offset int
+}
+
+// ListIngestionFailures sends GET /api/v1/admin/ingest-failures: List
+// ingestion failures.
+//
+// Returns dead-lettered bills whose ingestion failed, with the error class,
+// attempts, and the listing they were ingested from. The ingestor retries
+// pending failures with backoff after each run.
+func (c *Client) ListIngestionFailures(ctx context.Context, params *ListIngestionFailuresParams) (*IngestFailureList, error) {
+	path := "/api/v1/admin/ingest-failures"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "status", params.Status)
+		setParam(query.Set, "limit", params.Limit)
+		setParam(query.Set, "offset", params.Offset)
+	}
+	var out IngestFailureList
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListIngestionFailuresAll iterates over the failures of every page of
+// ListIngestionFailures, starting at params.Offset and fetching params.Limit
+// at a time. Iteration stops at the first error, which is yielded.
+func (c *Client) ListIngestionFailuresAll(ctx context.Context, params *ListIngestionFailuresParams) iter.Seq2[IngestFailureResponse, error] {
+	var page ListIngestionFailuresParams
+	if params != nil {
+		page = *params
+	}
+	return paginate(page.Offset, func(offset int) ([]IngestFailureResponse, int, error) {
+		page.Offset = offset
+		result, err := c.ListIngestionFailures(ctx, &page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Failures, result.Total, nil
+	})
+}
+
+// ListIngestionRunsParams are the query and header parameters of ListIngestionRuns.
+type ListIngestionRunsParams struct {
+	// Number of results per page (max 100). Default: 20.
+	Limit int
+	// Pagination offset. Default: 0.
+	Offset int
+}
+
+// ListIngestionRuns sends GET /api/v1/admin/ingestions: List ingestion runs.
+//
+// Returns recorded ingestion runs with counts, duration, and errors, newest
+// first.
+func (c *Client) ListIngestionRuns(ctx context.Context, params *ListIngestionRunsParams) (*IngestRunList, error) {
+	path := "/api/v1/admin/ingestions"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "limit", params.Limit)
+		setParam(query.Set, "offset", params.Offset)
+	}
+	var out IngestRunList
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListIngestionRunsAll iterates over the runs of every page of
+// ListIngestionRuns, starting at params.Offset and fetching params.Limit at a
+// time. Iteration stops at the first error, which is yielded.
+func (c *Client) ListIngestionRunsAll(ctx context.Context, params *ListIngestionRunsParams) iter.Seq2[IngestRunResponse, error] {
+	var page ListIngestionRunsParams
+	if params != nil {
+		page = *params
+	}
+	return paginate(page.Offset, func(offset int) ([]IngestRunResponse, int, error) {
+		page.Offset = offset
+		result, err := c.ListIngestionRuns(ctx, &page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Runs, result.Total, nil
+	})
+}
+
+// ListKeywordAlertMatchesParams are the query and header parameters of ListKeywordAlertMatches.
+type ListKeywordAlertMatchesParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+	// Number of matches per page (max 100). Default: 20.
+	Limit int
+	// Pagination offset. Default: 0.
+	Offset int
+}
+
+// ListKeywordAlertMatches sends GET /api/v1/watchlist/alerts/{id}/matches:
+// List keyword alert matches.
+//
+// Returns the versions whose text matched a keyword alert, newest first, with
+// the matching snippets and the anchors of their sections.
+func (c *Client) ListKeywordAlertMatches(ctx context.Context, id int, params *ListKeywordAlertMatchesParams) (*AlertMatchList, error) {
+	path := "/api/v1/watchlist/alerts/" + pathParam(id) + "/matches"
+	query := url.Values{}
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+		setParam(query.Set, "limit", params.Limit)
+		setParam(query.Set, "offset", params.Offset)
+	}
+	var out AlertMatchList
+	if err := c.do(ctx, "GET", path, query, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListKeywordAlertMatchesAll iterates over the matches of every page of
+// ListKeywordAlertMatches, starting at params.Offset and fetching params.Limit
+// at a time. Iteration stops at the first error, which is yielded.
+func (c *Client) ListKeywordAlertMatchesAll(ctx context.Context, id int, params *ListKeywordAlertMatchesParams) iter.Seq2[AlertMatchResponse, error] {
+	var page ListKeywordAlertMatchesParams
+	if params != nil {
+		page = *params
+	}
+	return paginate(page.Offset, func(offset int) ([]AlertMatchResponse, int, error) {
+		page.Offset = offset
+		result, err := c.ListKeywordAlertMatches(ctx, id, &page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Matches, result.Total, nil
+	})
+}
+
+// ListKeywordAlertsParams are the query and header parameters of ListKeywordAlerts.
+type ListKeywordAlertsParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// ListKeywordAlerts sends GET /api/v1/watchlist/alerts: List keyword alerts.
+//
+// Returns the caller's keyword alerts.
+func (c *Client) ListKeywordAlerts(ctx context.Context, params *ListKeywordAlertsParams) (*KeywordAlertsResponse, error) {
+	path := "/api/v1/watchlist/alerts"
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	var out KeywordAlertsResponse
+	if err := c.do(ctx, "GET", path, nil, header, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListNominationsParams are the query and header parameters of ListNominations.
+type ListNominationsParams struct {
+	// Filter by congress.
+	Congress int
+	// Filter by nominating organization (case-insensitive partial match).
+	Organization string
+	// Filter to civilian or military nominations. One of: civilian, military.
+	Type string
+	// Search in nomination description (case-insensitive partial match).
+	Query string
+	// Number of results per page (max 100). Default: 20.
+	Limit int
+	// Pagination offset. Default: 0.
+	Offset int
+}
+
+// ListNominations sends GET /api/v1/nominations: List nominations.
+//
+// Lists presidential nominations sent to the Senate, most recent action first.
+func (c *Client) ListNominations(ctx context.Context, params *ListNominationsParams) (*NominationListResponse, error) {
+	path := "/api/v1/nominations"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "congress", params.Congress)
+		setParam(query.Set, "organization", params.Organization)
+		setParam(query.Set, "type", params.Type)
+		setParam(query.Set, "query", params.Query)
+		setParam(query.Set, "limit", params.Limit)
+		setParam(query.Set, "offset", params.Offset)
+	}
+	var out NominationListResponse
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListNominationsAll iterates over the nominations of every page of
+// ListNominations, starting at params.Offset and fetching params.Limit at a
+// time. Iteration stops at the first error, which is yielded.
+func (c *Client) ListNominationsAll(ctx context.Context, params *ListNominationsParams) iter.Seq2[NominationResponse, error] {
+	var page ListNominationsParams
+	if params != nil {
+		page = *params
+	}
+	return paginate(page.Offset, func(offset int) ([]NominationResponse, int, error) {
+		page.Offset = offset
+		result, err := c.ListNominations(ctx, &page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Nominations, result.Total, nil
+	})
+}
+
+// ListRulesParams are the query and header parameters of ListRules.
+type ListRulesParams struct {
+	// Filter by Federal Register agency slug.
+	Agency string
+	// Filter to proposed or final rules. One of: proposed, final.
+	Type string
+	// Filter by Regulation Identifier Number.
+	RIN string
+	// Search in rule title (case-insensitive partial match).
+	Query string
+	// Number of results per page (max 100). Default: 20.
+	Limit int
+	// Pagination offset. Default: 0.
+	Offset int
+}
+
+// ListRules sends GET /api/v1/rules: List rules.
+//
+// Lists proposed and final Federal Register rules, newest first.
+func (c *Client) ListRules(ctx context.Context, params *ListRulesParams) (*RuleListResponse, error) {
+	path := "/api/v1/rules"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "agency", params.Agency)
+		setParam(query.Set, "type", params.Type)
+		setParam(query.Set, "rin", params.RIN)
+		setParam(query.Set, "query", params.Query)
+		setParam(query.Set, "limit", params.Limit)
+		setParam(query.Set, "offset", params.Offset)
+	}
+	var out RuleListResponse
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListRulesAll iterates over the rules of every page of ListRules, starting at
+// params.Offset and fetching params.Limit at a time. Iteration stops at the
+// first error, which is yielded.
+func (c *Client) ListRulesAll(ctx context.Context, params *ListRulesParams) iter.Seq2[RuleResponse, error] {
+	var page ListRulesParams
+	if params != nil {
+		page = *params
+	}
+	return paginate(page.Offset, func(offset int) ([]RuleResponse, int, error) {
+		page.Offset = offset
+		result, err := c.ListRules(ctx, &page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Rules, result.Total, nil
+	})
+}
+
+// ListTenants sends GET /api/v1/admin/tenants: List tenants.
+func (c *Client) ListTenants(ctx context.Context) (*TenantListResponse, error) {
+	path := "/api/v1/admin/tenants"
+	var out TenantListResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListTreatiesParams are the query and header parameters of ListTreaties.
+type ListTreatiesParams struct {
+	// Filter by the Congress that received the treaty.
+	Congress int
+	// Search in treaty title and topic (case-insensitive partial match).
+	Query string
+	// Number of results per page (max 100). Default: 20.
+	Limit int
+	// Pagination offset. Default: 0.
+	Offset int
+}
+
+// ListTreaties sends GET /api/v1/treaties: List treaties.
+//
+// Lists treaties transmitted to the Senate, most recent action first.
+func (c *Client) ListTreaties(ctx context.Context, params *ListTreatiesParams) (*TreatyListResponse, error) {
+	path := "/api/v1/treaties"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "congress", params.Congress)
+		setParam(query.Set, "query", params.Query)
+		setParam(query.Set, "limit", params.Limit)
+		setParam(query.Set, "offset", params.Offset)
+	}
+	var out TreatyListResponse
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ListTreatiesAll iterates over the treaties of every page of ListTreaties,
+// starting at params.Offset and fetching params.Limit at a time. Iteration
+// stops at the first error, which is yielded.
+func (c *Client) ListTreatiesAll(ctx context.Context, params *ListTreatiesParams) iter.Seq2[TreatyResponse, error] {
+	var page ListTreatiesParams
+	if params != nil {
+		page = *params
+	}
+	return paginate(page.Offset, func(offset int) ([]TreatyResponse, int, error) {
+		page.Offset = offset
+		result, err := c.ListTreaties(ctx, &page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Treaties, result.Total, nil
+	})
+}
+
+// ListUserKeywordAlerts sends GET /api/v1/admin/users/{id}/alerts: List a
+// user's keyword alerts.
+func (c *Client) ListUserKeywordAlerts(ctx context.Context, id int) (*KeywordAlertsResponse, error) {
+	path := "/api/v1/admin/users/" + pathParam(id) + "/alerts"
+	var out KeywordAlertsResponse
+	if err := c.do(ctx, "GET", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// Login sends GET /api/v1/auth/login: Sign in.
+//
+// Redirects to the configured identity provider (Google, GitHub, or any OpenID
+// Connect issuer), which redirects back to the callback endpoint.
+func (c *Client) Login(ctx context.Context) error {
+	path := "/api/v1/auth/login"
+	return c.do(ctx, "GET", path, nil, nil, nil, nil)
+}
+
+// LoginCallbackParams are the query and header parameters of LoginCallback.
+type LoginCallbackParams struct {
+	// Required. Authorization code from the identity provider.
+	Code string
+	// Required. State passed to the identity provider.
+	State string
+}
+
+// LoginCallback sends GET /api/v1/auth/callback: Complete sign-in.
+//
+// Redeems the identity provider's authorization code and returns a session
+// token. Users are created on first sign-in with the reader role; their
+// watchlist, saved searches, and documents are theirs across sessions.
+func (c *Client) LoginCallback(ctx context.Context, params *LoginCallbackParams) (*SessionResponse, error) {
+	path := "/api/v1/auth/callback"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "code", params.Code)
+		setParam(query.Set, "state", params.State)
+	}
+	var out SessionResponse
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RecomputeDeltas sends POST /api/v1/admin/deltas/recompute: Recompute cached
+// deltas.
+//
+// Starts a background job recomputing the cached deltas of one bill, one
+// version pair, or all bills. Poll the returned job for progress.
+func (c *Client) RecomputeDeltas(ctx context.Context, body RecomputeDeltasRequest) (*DeltaJobResponse, error) {
+	path := "/api/v1/admin/deltas/recompute"
+	var out DeltaJobResponse
+	if err := c.do(ctx, "POST", path, nil, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// ReconcileBillVersionsParams are the query and header parameters of ReconcileBillVersions.
+type ReconcileBillVersionsParams struct {
+	// Version ID of the reconciled text, e.g., the conference or enrolled version
+	// (default: most recent version).
+	Base int
+	// Version ID of the House text (default: most recent House version).
+	House int
+	// Version ID of the Senate text (default: most recent Senate version).
+	Senate int
+}
+
+// ReconcileBillVersions sends GET /api/v1/bills/{id}/reconcile: Compare a
+// bill's final text with its House and Senate versions.
+//
+// Matches sections by number across a base version (typically conference or
+// enrolled text) and the House and Senate versions, and reports for each
+// whether the base followed the House, the Senate, both, or neither, or
+// dropped the section.
+func (c *Client) ReconcileBillVersions(ctx context.Context, id int, params *ReconcileBillVersionsParams) (*ReconcileResponse, error) {
+	path := "/api/v1/bills/" + pathParam(id) + "/reconcile"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "base", params.Base)
+		setParam(query.Set, "house", params.House)
+		setParam(query.Set, "senate", params.Senate)
+	}
+	var out ReconcileResponse
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// RequeueIngestionFailure sends POST
+// /api/v1/admin/ingest-failures/{id}/requeue: Requeue an ingestion failure.
+//
+// Makes an open failure due for retry on the ingestor's next run, with its
+// attempts reset.
+func (c *Client) RequeueIngestionFailure(ctx context.Context, id int) (*IngestFailureResponse, error) {
+	path := "/api/v1/admin/ingest-failures/" + pathParam(id) + "/requeue"
+	var out IngestFailureResponse
+	if err := c.do(ctx, "POST", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SaveSearchParams are the query and header parameters of SaveSearch.
+type SaveSearchParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// SaveSearch sends POST /api/v1/watchlist/searches: Save a search.
+//
+// Saves a bill search; bills matching it are reported by the watchlist updates
+// endpoint.
+func (c *Client) SaveSearch(ctx context.Context, body SaveSearchInputBody, params *SaveSearchParams) (*SavedSearchResponse, error) {
+	path := "/api/v1/watchlist/searches"
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	var out SavedSearchResponse
+	if err := c.do(ctx, "POST", path, nil, header, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SearchBillsParams are the query and header parameters of SearchBills.
+type SearchBillsParams struct {
+	// Filter by congress number (e.g., 118, 119). 0 = no filter.
+	Congress int
+	// Filter by sponsor name (case-insensitive partial match).
+	Sponsor string
+	// Search in bill title and aliases, such as short titles and nicknames like
+	// NDAA (case-insensitive partial match).
+	Query string
+	// Filter by bill type (hr, s, hjres, sjres, hconres, sconres, hres, sres).
+	Type string
+	// Filter by bill number as published, such as 1 or a state's 12A
+	// (case-insensitive); with congress or state and type, finds a single bill.
+	Number string
+	// Filter to federal bills, state legislature bills, or the caller's tenant's
+	// drafts. One of: federal, state, draft.
+	Jurisdiction string
+	// Filter by two-letter state code of state bills.
+	State string
+	// Filter to only spending/appropriations bills (classified by CRS subjects).
+	Spending bool
+	// Filter by CRS policy area (case-insensitive exact match).
+	PolicyArea string
+	// Filter by CRS legislative subject term (case-insensitive exact match).
+	Subject string
+	// Sort field. One of: updateDate, introducedDate, title. Default: updateDate.
+	Sort string
+	// Sort order (default: desc for dates, asc for title). One of: asc, desc.
+	Order string
+	// Number of results per page (max 100). Default: 20.
+	Limit int
+	// Pagination offset. Default: 0.
+	Offset int
+}
+
+// SearchBills sends GET /api/v1/lex: Search legislative bills.
+//
+// Search and filter bills by congress, sponsor, title query, bill type,
+// spending classification, CRS policy area, legislative subject, and
+// Congress.gov metadata. Supports pagination via limit/offset. Bills can also
+// be filtered by the Congress.gov bill detail stored as their metadata, with
+// exact-match (case-sensitive) parameters such as metadata.sponsors.party=R.
+// Accepted parameters: metadata.committeeReports.citation,
+// metadata.latestAction.actionDate, metadata.latestAction.text,
+// metadata.laws.number, metadata.laws.type, metadata.originChamber,
+// metadata.originChamberCode, metadata.policyArea,
+// metadata.sponsors.bioguideId, metadata.sponsors.party,
+// metadata.sponsors.state.
+func (c *Client) SearchBills(ctx context.Context, params *SearchBillsParams) (*LexSearchResult, error) {
+	path := "/api/v1/lex"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "congress", params.Congress)
+		setParam(query.Set, "sponsor", params.Sponsor)
+		setParam(query.Set, "query", params.Query)
+		setParam(query.Set, "type", params.Type)
+		setParam(query.Set, "number", params.Number)
+		setParam(query.Set, "jurisdiction", params.Jurisdiction)
+		setParam(query.Set, "state", params.State)
+		setParam(query.Set, "spending", params.Spending)
+		setParam(query.Set, "policyArea", params.PolicyArea)
+		setParam(query.Set, "subject", params.Subject)
+		setParam(query.Set, "sort", params.Sort)
+		setParam(query.Set, "order", params.Order)
+		setParam(query.Set, "limit", params.Limit)
+		setParam(query.Set, "offset", params.Offset)
+	}
+	var out LexSearchResult
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SearchBillsAll iterates over the bills of every page of SearchBills,
+// starting at params.Offset and fetching params.Limit at a time. Iteration
+// stops at the first error, which is yielded.
+func (c *Client) SearchBillsAll(ctx context.Context, params *SearchBillsParams) iter.Seq2[BillResponse, error] {
+	var page SearchBillsParams
+	if params != nil {
+		page = *params
+	}
+	return paginate(page.Offset, func(offset int) ([]BillResponse, int, error) {
+		page.Offset = offset
+		result, err := c.SearchBills(ctx, &page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Bills, result.Total, nil
+	})
+}
+
+// SearchBillsV1Params are the query and header parameters of SearchBillsV1.
+type SearchBillsV1Params struct {
+	// Filter by congress number. 0 = no filter.
+	Congress int
+	// Filter by sponsor name (case-insensitive partial match).
+	Sponsor string
+	// Search in bill title and aliases, such as short titles and nicknames like
+	// NDAA (case-insensitive partial match).
+	Q string
+	// Filter by bill type (hr, s, hjres, sjres, hconres, sconres, hres, sres).
+	BillType string
+	// Only return spending/appropriations bills.
+	SpendingOnly bool
+	// Also return counts of matching bills per congress, bill type, origin
+	// chamber, spending classification, and policy area.
+	Facets bool
+	// Sort field. One of: updateDate, introducedDate, title. Default: updateDate.
+	Sort string
+	// Sort order (default: desc for dates, asc for title). One of: asc, desc.
+	Order string
+	// Number of results per page (max 100). Default: 20.
+	Limit int
+	// Pagination offset. Default: 0.
+	Offset int
+}
+
+// SearchBillsV1 sends GET /api/v1/bills/search: Search bills.
+//
+// Searches bills by congress, sponsor, title, bill type, spending
+// classification, and Congress.gov metadata, sorted by update date, introduced
+// date, or title. Supports pagination via limit/offset. Bills can also be
+// filtered by the Congress.gov bill detail stored as their metadata, with
+// exact-match (case-sensitive) parameters such as metadata.sponsors.party=R.
+// Accepted parameters: metadata.committeeReports.citation,
+// metadata.latestAction.actionDate, metadata.latestAction.text,
+// metadata.laws.number, metadata.laws.type, metadata.originChamber,
+// metadata.originChamberCode, metadata.policyArea,
+// metadata.sponsors.bioguideId, metadata.sponsors.party,
+// metadata.sponsors.state.
+func (c *Client) SearchBillsV1(ctx context.Context, params *SearchBillsV1Params) (*LexSearchResult, error) {
+	path := "/api/v1/bills/search"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "congress", params.Congress)
+		setParam(query.Set, "sponsor", params.Sponsor)
+		setParam(query.Set, "q", params.Q)
+		setParam(query.Set, "billType", params.BillType)
+		setParam(query.Set, "spendingOnly", params.SpendingOnly)
+		setParam(query.Set, "facets", params.Facets)
+		setParam(query.Set, "sort", params.Sort)
+		setParam(query.Set, "order", params.Order)
+		setParam(query.Set, "limit", params.Limit)
+		setParam(query.Set, "offset", params.Offset)
+	}
+	var out LexSearchResult
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SearchBillsV1All iterates over the bills of every page of SearchBillsV1,
+// starting at params.Offset and fetching params.Limit at a time. Iteration
+// stops at the first error, which is yielded.
+func (c *Client) SearchBillsV1All(ctx context.Context, params *SearchBillsV1Params) iter.Seq2[BillResponse, error] {
+	var page SearchBillsV1Params
+	if params != nil {
+		page = *params
+	}
+	return paginate(page.Offset, func(offset int) ([]BillResponse, int, error) {
+		page.Offset = offset
+		result, err := c.SearchBillsV1(ctx, &page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Bills, result.Total, nil
+	})
+}
+
+// SearchSemanticParams are the query and header parameters of SearchSemantic.
+type SearchSemanticParams struct {
+	// Text describing what to find, in plain language.
+	Q string
+	// Find bills like this one instead: bills whose summaries and sections are
+	// closest to its summary.
+	BillID int
+	// Match only CRS summaries or only sections of bill text. Default: both. One
+	// of: summary, section.
+	Kind string
+	// Number of bills, and of matches (max 50). Default: 10.
+	Limit int
+}
+
+// SearchSemantic sends GET /api/v1/search/semantic: Find conceptually similar
+// bills and sections.
+//
+// Ranks bills, CRS summaries, and sections of bill text by similarity in
+// meaning to q, or to the bill given by billId ("find bills like this one"),
+// using the embeddings the ingestor computes of each bill's latest summary and
+// latest text. Returns 501 when no embedding provider is configured.
+func (c *Client) SearchSemantic(ctx context.Context, params *SearchSemanticParams) (*SemanticSearchResult, error) {
+	path := "/api/v1/search/semantic"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "q", params.Q)
+		setParam(query.Set, "billId", params.BillID)
+		setParam(query.Set, "kind", params.Kind)
+		setParam(query.Set, "limit", params.Limit)
+	}
+	var out SemanticSearchResult
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SearchTextParams are the query and header parameters of SearchText.
+type SearchTextParams struct {
+	// Required. Words to search for, all of which must match. Supports "quoted
+	// phrases", "phrase"~N for words up to N positions apart, -word to exclude, a
+	// | b for either, and word* for prefixes.
+	Q string
+	// Match q word for word as a single phrase, without the query syntax.
+	Phrase bool
+	// With phrase, how many positions apart or out of order the phrase's words may
+	// be.
+	Slop int
+	// Search only bills (titles, aliases, sponsors, statuses), version text, or
+	// actions. Default: all. One of: bills, versions, actions.
+	Kind string
+	// Filter by congress number. 0 = no filter.
+	Congress int
+	// Filter by bill type.
+	Type string
+	// Search within one bill. 0 = all bills.
+	BillID int
+	// Number of results per page (max 100). Default: 20.
+	Limit int
+	// Pagination offset. Default: 0.
+	Offset int
+}
+
+// SearchText sends GET /api/v1/lex/text: Search bill text.
+//
+// Full-text search of bills, the text of their versions, and their actions,
+// with phrase and proximity matching and highlighted excerpts. Answered by the
+// configured search backend (OpenSearch or Elasticsearch); returns 501 when
+// there is none. Drafts are not searched.
+func (c *Client) SearchText(ctx context.Context, params *SearchTextParams) (*TextSearchResult, error) {
+	path := "/api/v1/lex/text"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "q", params.Q)
+		setParam(query.Set, "phrase", params.Phrase)
+		setParam(query.Set, "slop", params.Slop)
+		setParam(query.Set, "kind", params.Kind)
+		setParam(query.Set, "congress", params.Congress)
+		setParam(query.Set, "type", params.Type)
+		setParam(query.Set, "billId", params.BillID)
+		setParam(query.Set, "limit", params.Limit)
+		setParam(query.Set, "offset", params.Offset)
+	}
+	var out TextSearchResult
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SearchTextAll iterates over the hits of every page of SearchText, starting
+// at params.Offset and fetching params.Limit at a time. Iteration stops at the
+// first error, which is yielded.
+func (c *Client) SearchTextAll(ctx context.Context, params *SearchTextParams) iter.Seq2[TextSearchHit, error] {
+	var page SearchTextParams
+	if params != nil {
+		page = *params
+	}
+	return paginate(page.Offset, func(offset int) ([]TextSearchHit, int, error) {
+		page.Offset = offset
+		result, err := c.SearchText(ctx, &page)
+		if err != nil {
+			return nil, 0, err
+		}
+		return result.Hits, result.Total, nil
+	})
+}
+
+// SetSlowQueryThreshold sends PUT /api/v1/admin/database/slow-query-threshold:
+// Set the slow query threshold.
+//
+// Sets how long a statement may take before the API server logs it and counts
+// it in deltagov_db_slow_queries_total, until it restarts;
+// DB_SLOW_QUERY_THRESHOLD sets the initial value. Applies to the API server
+// handling the request only, not to other replicas or the ingestor.
+func (c *Client) SetSlowQueryThreshold(ctx context.Context, body SlowQueryThresholdRequest) (*DatabaseStatsResponse, error) {
+	path := "/api/v1/admin/database/slow-query-threshold"
+	var out DatabaseStatsResponse
+	if err := c.do(ctx, "PUT", path, nil, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// SetUserRole sends PUT /api/v1/admin/users/{id}/role: Set a user's role.
+//
+// Changes what a user's API key may do: readers read and keep a watchlist,
+// editors also upload documents and drafts, and admins also use the admin
+// endpoints.
+func (c *Client) SetUserRole(ctx context.Context, id int, body SetUserRoleInputBody) (*AdminUserResponse, error) {
+	path := "/api/v1/admin/users/" + pathParam(id) + "/role"
+	var out AdminUserResponse
+	if err := c.do(ctx, "PUT", path, nil, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// StartReingest sends POST /api/v1/admin/reingest: Re-ingest bills by filter.
+//
+// Queues a job refreshing the stored federal bills matching a filter from
+// Congress.gov: detail, related data, and every text version, as if each had
+// changed. The ingestor works through it a batch per polling cycle; poll the
+// returned job for progress.
+func (c *Client) StartReingest(ctx context.Context, body ReingestRequest) (*ReingestJobResponse, error) {
+	path := "/api/v1/admin/reingest"
+	var out ReingestJobResponse
+	if err := c.do(ctx, "POST", path, nil, nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// StreamDiff sends GET
+// /api/v1/bills/{billId}/diff/{fromVersion}/{toVersion}/stream: Stream diff
+// between two bill versions.
+//
+// Streams the diff as it is computed: a start record, one record per line
+// (lineNumber, type, text), then an end record with totals. Records are
+// NDJSON, or Server-Sent Events with format=sse. A failure mid-stream ends
+// with an error record.
+func (c *Client) StreamDiff(ctx context.Context, billID int, fromVersion int, toVersion int) (*Stream[DiffStreamRecord], error) {
+	path := "/api/v1/bills/" + pathParam(billID) + "/diff/" + pathParam(fromVersion) + "/" + pathParam(toVersion) + "/stream"
+	query := url.Values{}
+	query.Set("format", "ndjson")
+	resp, err := c.open(ctx, "GET", path, query, nil, "application/x-ndjson")
+	if err != nil {
+		return nil, err
+	}
+	return newStream[DiffStreamRecord](resp), nil
+}
+
+// TraceSectionProvenanceParams are the query and header parameters of TraceSectionProvenance.
+type TraceSectionProvenanceParams struct {
+	// Provision text to trace.
+	Text string
+	// Or trace a section of this version, given by anchor, to versions fetched
+	// before it.
+	VersionID int
+	// Section anchor in versionId, as in version text and diffs.
+	Anchor string
+	// Least estimated share of wording in common for a section to count. Default:
+	// 0.8.
+	MinSimilarity float64
+	// Most appearances returned (max 100). Default: 20.
+	Limit int
+}
+
+// TraceSectionProvenance sends GET /api/v1/provenance/section: Trace a
+// provision across bills.
+//
+// Finds the sections of stored bill versions with the same language as a
+// provision — word for word or nearly, regardless of numbering and
+// formatting — earliest first, showing where language in an omnibus or
+// package originated. Give the provision as text, or as versionId and the
+// section's anchor to trace it to earlier versions. Sections are fingerprinted
+// by the ingestor, so recently fetched versions may not appear yet.
+func (c *Client) TraceSectionProvenance(ctx context.Context, params *TraceSectionProvenanceParams) (*SectionProvenanceResult, error) {
+	path := "/api/v1/provenance/section"
+	query := url.Values{}
+	if params != nil {
+		setParam(query.Set, "text", params.Text)
+		setParam(query.Set, "versionId", params.VersionID)
+		setParam(query.Set, "anchor", params.Anchor)
+		setParam(query.Set, "minSimilarity", params.MinSimilarity)
+		setParam(query.Set, "limit", params.Limit)
+	}
+	var out SectionProvenanceResult
+	if err := c.do(ctx, "GET", path, query, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// UnwatchBillParams are the query and header parameters of UnwatchBill.
+type UnwatchBillParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// UnwatchBill sends DELETE /api/v1/watchlist/bills/{id}: Unwatch a bill.
+//
+// Removes a bill from the caller's watchlist.
+func (c *Client) UnwatchBill(ctx context.Context, id int, params *UnwatchBillParams) error {
+	path := "/api/v1/watchlist/bills/" + pathParam(id)
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	return c.do(ctx, "DELETE", path, nil, header, nil, nil)
+}
+
+// UnwatchUserBill sends DELETE
+// /api/v1/admin/users/{id}/watchlist/bills/{billId}: Remove a bill from a
+// user's watchlist.
+func (c *Client) UnwatchUserBill(ctx context.Context, id int, billID int) error {
+	path := "/api/v1/admin/users/" + pathParam(id) + "/watchlist/bills/" + pathParam(billID)
+	return c.do(ctx, "DELETE", path, nil, nil, nil, nil)
+}
+
+// UpdateKeywordAlertParams are the query and header parameters of UpdateKeywordAlert.
+type UpdateKeywordAlertParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// UpdateKeywordAlert sends PUT /api/v1/watchlist/alerts/{id}: Update a keyword
+// alert.
+//
+// Renames a keyword alert and replaces its query; earlier matches are kept.
+func (c *Client) UpdateKeywordAlert(ctx context.Context, id int, body KeywordAlertBody, params *UpdateKeywordAlertParams) (*KeywordAlertResponse, error) {
+	path := "/api/v1/watchlist/alerts/" + pathParam(id)
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	var out KeywordAlertResponse
+	if err := c.do(ctx, "PUT", path, nil, header, body, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// VerifyVersionProvenance sends POST /api/v1/versions/{id}/provenance/verify:
+// Verify a version's provenance.
+//
+// Hashes the version's stored raw text and re-fetches its source URL,
+// comparing both with the hash recorded when it was retrieved. verified is
+// true when the stored text is unchanged and the source still serves the same
+// bytes; a source revised since retrieval shows a different hash and, usually,
+// a newer Last-Modified.
+func (c *Client) VerifyVersionProvenance(ctx context.Context, id int) (*ProvenanceVerification, error) {
+	path := "/api/v1/versions/" + pathParam(id) + "/provenance/verify"
+	var out ProvenanceVerification
+	if err := c.do(ctx, "POST", path, nil, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// WatchBillParams are the query and header parameters of WatchBill.
+type WatchBillParams struct {
+	// API key returned when the user was created; not needed with a session token.
+	APIKey string
+}
+
+// WatchBill sends PUT /api/v1/watchlist/bills/{id}: Watch a bill.
+//
+// Adds a bill to the caller's watchlist.
+func (c *Client) WatchBill(ctx context.Context, id int, params *WatchBillParams) error {
+	path := "/api/v1/watchlist/bills/" + pathParam(id)
+	header := http.Header{}
+	if params != nil {
+		setParam(header.Set, "X-API-Key", params.APIKey)
+	}
+	return c.do(ctx, "PUT", path, nil, header, nil, nil)
+}
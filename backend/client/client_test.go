@@ -0,0 +1,176 @@
+package client_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/client"
+)
+
+// TestClient_GetBill verifies requests carry their path, parameters, and
+// default headers, and that JSON responses are decoded.
+func TestClient_GetBill(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/bills/42" {
+			t.Errorf("path = %s, want /api/v1/bills/42", r.URL.Path)
+		}
+		if got := r.Header.Get("If-None-Match"); got != `"abc"` {
+			t.Errorf("If-None-Match = %q", got)
+		}
+		if got := r.Header.Get("X-API-Key"); got != "key" {
+			t.Errorf("X-API-Key = %q, want the default header", got)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 42, "title": "A bill", "congress": 119})
+	}))
+	defer server.Close()
+
+	c := client.New(server.URL+"/", client.WithAPIKey("key"))
+	bill, err := c.GetBill(context.Background(), 42, &client.GetBillParams{IfNoneMatch: `"abc"`})
+	if err != nil {
+		t.Fatalf("GetBill: %v", err)
+	}
+	if bill.ID != 42 || bill.Title != "A bill" || bill.Congress != 119 {
+		t.Errorf("bill = %+v", bill)
+	}
+}
+
+// TestClient_Errors verifies error responses become *client.Error.
+func TestClient_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/bills/2" {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"status":404,"title":"Not Found","detail":"bill not found","code":"BILL_NOT_FOUND"}`)
+	}))
+	defer server.Close()
+	c := client.New(server.URL)
+
+	_, err := c.GetBill(context.Background(), 1, nil)
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %v, want *client.Error", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound || apiErr.Code != "BILL_NOT_FOUND" {
+		t.Errorf("error = %+v", apiErr)
+	}
+	if got, want := err.Error(), "deltagov: 404 BILL_NOT_FOUND: bill not found"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if client.IsNotModified(err) {
+		t.Error("404 reported as not modified")
+	}
+
+	_, err = c.GetBill(context.Background(), 2, &client.GetBillParams{IfNoneMatch: `"abc"`})
+	if !client.IsNotModified(err) {
+		t.Errorf("err = %v, want not modified", err)
+	}
+}
+
+// TestClient_StreamDiff verifies NDJSON records are read one at a time.
+func TestClient_StreamDiff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("format"); got != "ndjson" {
+			t.Errorf("format = %q, want ndjson", got)
+		}
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		fmt.Fprintln(w, `{"event":"start","fromVersion":"IH","toVersion":"EH"}`)
+		fmt.Fprintln(w, `{"event":"line","lineNumber":1,"type":"insertion","text":"New text"}`)
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, `{"event":"end","insertions":1,"deletions":0}`)
+	}))
+	defer server.Close()
+
+	stream, err := client.New(server.URL).StreamDiff(context.Background(), 1, 2, 3)
+	if err != nil {
+		t.Fatalf("StreamDiff: %v", err)
+	}
+	defer stream.Close()
+
+	var events []string
+	for stream.Next() {
+		record := stream.Record()
+		events = append(events, record.Event)
+		if record.Event == "line" && (record.LineNumber != 1 || record.Text != "New text") {
+			t.Errorf("line record = %+v", record)
+		}
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatalf("stream: %v", err)
+	}
+	if fmt.Sprint(events) != "[start line end]" {
+		t.Errorf("events = %v, want [start line end]", events)
+	}
+}
+
+// TestClient_SearchBillsAll verifies the pagination helper fetches every
+// page, and stops at the first error.
+func TestClient_SearchBillsAll(t *testing.T) {
+	const total = 5
+	failAt := -1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if offset == failAt {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if got := r.URL.Query().Get("congress"); got != "119" {
+			t.Errorf("congress = %q, want 119 on every page", got)
+		}
+		var bills []map[string]any
+		for id := offset; id < min(offset+2, total); id++ {
+			bills = append(bills, map[string]any{"id": id})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"bills": bills, "total": total, "limit": 2, "offset": offset})
+	}))
+	defer server.Close()
+	c := client.New(server.URL)
+
+	var ids []int
+	for bill, err := range c.SearchBillsAll(context.Background(), &client.SearchBillsParams{Congress: 119, Limit: 2}) {
+		if err != nil {
+			t.Fatalf("SearchBillsAll: %v", err)
+		}
+		ids = append(ids, bill.ID)
+	}
+	if fmt.Sprint(ids) != "[0 1 2 3 4]" {
+		t.Errorf("ids = %v, want [0 1 2 3 4]", ids)
+	}
+
+	failAt = 2
+	var errs int
+	for _, err := range c.SearchBillsAll(context.Background(), &client.SearchBillsParams{Congress: 119, Limit: 2}) {
+		if err != nil {
+			errs++
+		}
+	}
+	if errs != 1 {
+		t.Errorf("got %d errors, want iteration to stop at the first", errs)
+	}
+}
+
+// TestClient_TimeParams verifies times are sent in RFC 3339.
+func TestClient_TimeParams(t *testing.T) {
+	since := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("since"); got != "2025-03-01T12:00:00Z" {
+			t.Errorf("since = %q", got)
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	params := &client.GetWatchlistUpdatesParams{Since: since}
+	if _, err := client.New(server.URL).GetWatchlistUpdates(context.Background(), params); err != nil {
+		t.Fatalf("GetWatchlistUpdates: %v", err)
+	}
+}
@@ -1,32 +1,55 @@
 package main
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/cors"
-	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/joho/godotenv"
 	"gorm.io/gorm"
 
 	"github.com/drewjst/deltagov/internal/api"
+	"github.com/drewjst/deltagov/internal/auth"
+	"github.com/drewjst/deltagov/internal/compression"
+	"github.com/drewjst/deltagov/internal/config"
 	"github.com/drewjst/deltagov/internal/congress"
 	"github.com/drewjst/deltagov/internal/database"
+	"github.com/drewjst/deltagov/internal/deltas"
+	"github.com/drewjst/deltagov/internal/embeddings"
+	"github.com/drewjst/deltagov/internal/insights"
+	"github.com/drewjst/deltagov/internal/live"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/metrics"
+	"github.com/drewjst/deltagov/internal/opensearch"
+	"github.com/drewjst/deltagov/internal/scope"
+	"github.com/drewjst/deltagov/internal/searchindex"
+	"github.com/drewjst/deltagov/internal/textstore"
 )
 
 func main() {
 	// Load .env file if present
 	_ = godotenv.Load()
 
-	// Get port from environment or default to 8080
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	// Structured logging (LOG_LEVEL, LOG_FORMAT)
+	logging.Setup()
+
+	// HTTP settings: port, CORS, proxies, limits, timeouts (APP_ENV, CONFIG_FILE)
+	serverConfig, err := config.Load()
+	if err != nil {
+		slog.Error("invalid server configuration", "error", err)
+		os.Exit(1)
 	}
+	port := serverConfig.Port
 
 	// Initialize Congress client
 	congressAPIKey := os.Getenv("CONGRESS_API_KEY")
@@ -35,12 +58,12 @@ func main() {
 		var err error
 		congressClient, err = congress.NewClient(congress.WithAPIKey(congressAPIKey))
 		if err != nil {
-			log.Printf("Warning: Failed to create Congress client: %v", err)
+			slog.Warn("failed to create Congress client", "error", err)
 		} else {
-			log.Println("Congress API client initialized")
+			slog.Info("Congress API client initialized")
 		}
 	} else {
-		log.Println("Warning: CONGRESS_API_KEY not set")
+		slog.Warn("CONGRESS_API_KEY not set")
 	}
 
 	// Initialize database connection
@@ -48,74 +71,221 @@ func main() {
 	databaseURL := os.Getenv("DATABASE_URL")
 	if databaseURL != "" {
 		dbConfig := database.DefaultConfig(databaseURL)
+		dbConfig.ReplicaURLs = database.ParseReplicaURLs(os.Getenv("DATABASE_REPLICA_URLS"))
+		if thresholdStr := os.Getenv("DB_SLOW_QUERY_THRESHOLD"); thresholdStr != "" {
+			if parsed, err := time.ParseDuration(thresholdStr); err == nil {
+				dbConfig.SlowQueryThreshold = parsed
+			}
+		}
 		var err error
 		db, err = database.Connect(dbConfig)
 		if err != nil {
-			log.Printf("Warning: Failed to connect to database: %v", err)
+			slog.Warn("failed to connect to database", "error", err)
 		} else {
 			defer database.Close(db)
-			log.Println("Connected to database")
+			slog.Info("connected to database", "read_replicas", len(dbConfig.ReplicaURLs))
 
 			// Run migrations
 			if err := database.Migrate(db); err != nil {
-				log.Printf("Warning: Failed to run migrations: %v", err)
+				slog.Warn("failed to run migrations", "error", err)
 			} else {
-				log.Println("Database migrations complete")
+				slog.Info("database migrations complete")
 			}
 		}
 	} else {
-		log.Println("Warning: DATABASE_URL not set, running with mock data only")
+		slog.Warn("DATABASE_URL not set, running with mock data only")
+	}
+
+	// Load bill scope rules (allow/deny lists for listings)
+	scopeRules, err := scope.FromEnv()
+	if err != nil {
+		slog.Error("invalid scope configuration", "error", err)
+		os.Exit(1)
 	}
 
 	// Initialize Fiber app
 	app := fiber.New(fiber.Config{
-		AppName: "DeltaGov API",
+		AppName:                 "DeltaGov API",
+		BodyLimit:               serverConfig.BodyLimit,
+		ReadTimeout:             serverConfig.ReadTimeout,
+		WriteTimeout:            serverConfig.WriteTimeout,
+		IdleTimeout:             serverConfig.IdleTimeout,
+		EnableTrustedProxyCheck: len(serverConfig.TrustedProxies) > 0,
+		TrustedProxies:          serverConfig.TrustedProxies,
+		ProxyHeader:             proxyHeader(serverConfig),
 	})
 
 	// Middleware
-	app.Use(logger.New())
+	app.Use(logging.Middleware())
+	app.Use(compression.Middleware(serverConfig.CompressMinSize))
 	app.Use(cors.New(cors.Config{
-		AllowOrigins:     "http://localhost:4200, http://localhost:80, http://localhost",
-		AllowHeaders:     "Origin, Content-Type, Accept, Authorization",
+		AllowOrigins:     serverConfig.Origins(),
+		AllowHeaders:     "Origin, Content-Type, Accept, Authorization, X-Request-ID, X-API-Key",
 		AllowMethods:     "GET, POST, PUT, DELETE, OPTIONS",
-		AllowCredentials: true,
+		AllowCredentials: serverConfig.AllowCredentials,
 	}))
 
 	// Create Huma API with OpenAPI config
-	humaConfig := huma.DefaultConfig("DeltaGov API", "1.0.0")
-	humaConfig.Info.Description = "API for tracking and comparing legislative bill versions"
+	humaConfig := api.NewConfig()
 	humaConfig.Servers = []*huma.Server{
-		{URL: fmt.Sprintf("http://localhost:%s", port), Description: "Local development"},
+		{URL: serverConfig.PublicBaseURL, Description: serverConfig.Mode},
 	}
 
 	humaAPI := humafiber.New(app, humaConfig)
+	// Tag and error documentation, and the versioned routes' prefix (API_PREFIX)
+	api.ConfigureOpenAPI(humaAPI, serverConfig.APIPrefix)
+
+	// Live event broker, closed on shutdown so open streams end
+	var broker *live.Broker
+
+	// Admin service, closed on shutdown so delta jobs stop cleanly
+	var adminService *api.AdminService
+	var diffQueue *deltas.Queue
+	liveCtx, stopLive := context.WithCancel(context.Background())
+	defer stopLive()
 
 	// Register API routes based on available dependencies
 	if db != nil {
 		// Database available - register full routes (Congress client optional)
 		billService := api.NewBillService(db, congressClient)
+		billService.SetVerifyDeterminism(os.Getenv("DIFF_VERIFY_DETERMINISM") == "true")
+		billService.SetScope(scopeRules)
+
+		// Version text kept outside the versions table (TEXT_STORE)
+		texts, err := textstore.FromEnv(db)
+		if err != nil {
+			slog.Error("invalid text store configuration", "error", err)
+			os.Exit(1)
+		}
+		billService.SetTextStore(texts)
+
+		// Plain-language diff summaries (INSIGHTS_SUMMARIZER)
+		summarizer, err := insights.FromEnv()
+		if err != nil {
+			slog.Error("invalid summarizer configuration", "error", err)
+			os.Exit(1)
+		}
+		billService.SetSummarizer(summarizer)
+
+		// In-memory bill search index (SEARCH_INDEX=memory), loaded below
+		// once live events can keep it current
+		var searchIndex *searchindex.Index
+		if os.Getenv("SEARCH_INDEX") == "memory" {
+			searchIndex = searchindex.New()
+			billService.SetSearchIndex(searchIndex)
+		}
+
+		// Full-text search of bill text (OPENSEARCH_URL), indexed by the
+		// ingestor
+		billService.SetSearchBackend(opensearch.FromEnv())
+
+		// Semantic search (EMBEDDINGS_PROVIDER) over the embeddings the
+		// ingestor computes with the same provider
+		embedder, err := embeddings.FromEnv()
+		if err != nil {
+			slog.Error("invalid embeddings configuration", "error", err)
+			os.Exit(1)
+		}
+		if embedder != nil {
+			if err := embeddings.Migrate(db, embedder); err != nil {
+				slog.Warn("failed to migrate embeddings, semantic search disabled", "error", err)
+			} else {
+				billService.SetEmbeddings(embedder)
+			}
+		}
+
+		// Background diffs for pairs missing from version matrices
+		// (DIFF_PRECOMPUTE_WORKERS; 0 disables)
+		diffWorkers := 2
+		if workersStr := os.Getenv("DIFF_PRECOMPUTE_WORKERS"); workersStr != "" {
+			if parsed, err := strconv.Atoi(workersStr); err == nil {
+				diffWorkers = parsed
+			}
+		}
+		if diffWorkers > 0 {
+			diffQueue = deltas.NewQueue(db, diffWorkers)
+			diffQueue.SetSummarizer(summarizer)
+			billService.SetDiffQueue(diffQueue)
+		}
+
+		// Admin endpoints require ADMIN_TOKEN as a bearer token or an
+		// admin's API key
+		adminToken := os.Getenv("ADMIN_TOKEN")
+		if adminToken == "" {
+			slog.Warn("ADMIN_TOKEN not set, admin endpoints accept only admins' API keys")
+		}
+		adminService = api.NewAdminService(db, adminToken)
+
+		// End-user sign-in (OIDC_*) and the session tokens it issues
+		// (SESSION_SECRET, SESSION_TTL)
+		authService, err := newAuthService(db)
+		if err != nil {
+			slog.Error("invalid login configuration", "error", err)
+			os.Exit(1)
+		}
+
+		// Session tokens are resolved to their users, tenants' drafts are
+		// hidden from other callers, mutating requests are audited, and
+		// operations requiring a role reject callers without it; the
+		// middleware must be in place before any route is registered
+		tenantService := api.NewTenantService(db, billService)
+		humaAPI.UseMiddleware(authService.Sessions(humaAPI))
+		humaAPI.UseMiddleware(tenantService.Isolation(humaAPI))
+		humaAPI.UseMiddleware(adminService.Audit(humaAPI))
+		humaAPI.UseMiddleware(adminService.Authorization(humaAPI))
+
 		handler := api.NewRouteHandler(billService)
 		api.RegisterRoutesWithService(humaAPI, handler)
-		log.Println("API routes registered with database support")
+		slog.Info("API routes registered with database support")
+
+		memberService := api.NewMemberService(db)
+		memberService.SetTextStore(texts)
+		api.RegisterMemberRoutes(humaAPI, memberService)
+		api.RegisterAdminRoutes(humaAPI, adminService)
+		api.RegisterAuthRoutes(humaAPI, authService)
+		api.RegisterWatchlistRoutes(humaAPI, api.NewWatchlistService(db, billService))
+		api.RegisterTenantRoutes(humaAPI, tenantService)
+		api.RegisterDocumentRoutes(humaAPI, api.NewDocumentService(db, billService))
+		api.RegisterExportRoutes(humaAPI, billService)
+		api.RegisterRuleRoutes(humaAPI, api.NewRuleService(db))
+		api.RegisterExecutiveRoutes(humaAPI, api.NewExecutiveService(db))
+		api.RegisterStatsRoutes(humaAPI, api.NewStatsService(db))
+
+		// Atom feeds link back to the API, so they need its public origin
+		feedService := api.NewFeedService(billService, serverConfig.PublicBaseURL)
+		feedService.SetAPIPrefix(serverConfig.APIPrefix)
+		api.RegisterFeedRoutes(humaAPI, feedService)
 
-		// Register diagnostic routes if Congress client is available
-		if congressClient != nil {
-			diagnosticSvc := api.NewDiagnosticService(congressClient)
-			api.RegisterDiagnosticRoutes(humaAPI, diagnosticSvc)
-			log.Println("Diagnostic routes registered")
+		// Live updates: the ingestor publishes with NOTIFY; relay them over SSE
+		broker = live.NewBroker()
+		go broker.Listen(liveCtx, databaseURL)
+		api.RegisterEventRoutes(humaAPI, broker)
+
+		if searchIndex != nil {
+			reload, _ := time.ParseDuration(os.Getenv("SEARCH_INDEX_RELOAD"))
+			events, _ := broker.Subscribe(live.Filter{})
+			go searchindex.NewLoader(db, scopeRules.Query).Run(liveCtx, searchIndex, events, reload)
 		}
 	} else {
 		// Fallback to mock data when no database
 		api.RegisterRoutes(humaAPI)
-		log.Println("API routes registered with mock data (database not available)")
+		slog.Info("API routes registered with mock data (database not available)")
+	}
 
-		// Register diagnostic routes if Congress client is available
-		if congressClient != nil {
-			diagnosticSvc := api.NewDiagnosticService(congressClient)
-			api.RegisterDiagnosticRoutes(humaAPI, diagnosticSvc)
-			log.Println("Diagnostic routes registered")
-		}
+	// Diagnostics; checks for a missing database or Congress client are skipped
+	diagnosticSvc := api.NewDiagnosticService(db, congressClient)
+	if diffQueue != nil {
+		diagnosticSvc.SetDiffQueue(diffQueue)
 	}
+	api.RegisterDiagnosticRoutes(humaAPI, diagnosticSvc)
+
+	// Liveness and readiness probes for orchestrators
+	probes := api.NewProbeService(db, congressClient)
+	probes.SetCheckCongress(os.Getenv("READYZ_CHECK_CONGRESS") == "true")
+	api.RegisterProbeRoutes(humaAPI, probes)
+
+	// Prometheus metrics
+	app.Get("/metrics", adaptor.HTTPHandler(metrics.Handler()))
 
 	// Serve Scalar API documentation at /docs
 	app.Get("/docs", func(c *fiber.Ctx) error {
@@ -136,10 +306,85 @@ func main() {
 	})
 
 	// Start server
-	log.Printf("DeltaGov API starting on port %s", port)
-	log.Printf("API docs available at http://localhost:%s/docs", port)
-	log.Printf("OpenAPI spec at http://localhost:%s/openapi.json", port)
-	if err := app.Listen(":" + port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	slog.Info("DeltaGov API starting", "port", port, "mode", serverConfig.Mode,
+		"docs", fmt.Sprintf("http://localhost:%s/docs", port),
+		"openapi", fmt.Sprintf("http://localhost:%s/openapi.json", port))
+
+	// Stop on SIGINT/SIGTERM, draining in-flight requests before exiting
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	listenErr := make(chan error, 1)
+	go func() {
+		listenErr <- app.Listen(":" + port)
+	}()
+
+	select {
+	case err := <-listenErr:
+		if err != nil {
+			slog.Error("failed to start server", "error", err)
+			os.Exit(1)
+		}
+		return
+	case <-ctx.Done():
+	}
+
+	shutdownTimeout := serverConfig.ShutdownTimeout
+	slog.Info("shutdown signal received, draining connections", "timeout", shutdownTimeout.String())
+	probes.MarkShuttingDown()
+	stopLive()
+	if broker != nil {
+		broker.Close()
+	}
+	if err := app.ShutdownWithTimeout(shutdownTimeout); err != nil {
+		slog.Error("graceful shutdown failed", "error", err)
+	}
+	if adminService != nil {
+		adminService.Close()
+	}
+	if diffQueue != nil {
+		diffQueue.Close()
+	}
+	slog.Info("DeltaGov API stopped")
+}
+
+// proxyHeader returns the header Fiber reads the client IP from: the
+// configured one behind trusted proxies, and none otherwise, so clients
+// can't spoof their IP.
+func proxyHeader(cfg config.Server) string {
+	if len(cfg.TrustedProxies) == 0 {
+		return ""
+	}
+	return cfg.ProxyHeader
+}
+
+// newAuthService configures end-user sign-in from the OIDC_* variables and
+// session tokens from SESSION_SECRET and SESSION_TTL (default 24h).
+// Sign-in requires SESSION_SECRET; without OIDC_CLIENT_ID it is disabled.
+func newAuthService(db *gorm.DB) (*api.AuthService, error) {
+	provider, err := auth.FromEnv()
+	if err != nil {
+		return nil, err
+	}
+	secret := os.Getenv("SESSION_SECRET")
+	if secret == "" {
+		if provider != nil {
+			return nil, fmt.Errorf("OIDC login requires SESSION_SECRET")
+		}
+		return api.NewAuthService(db, nil, nil), nil
+	}
+	var ttl time.Duration
+	if ttlStr := os.Getenv("SESSION_TTL"); ttlStr != "" {
+		if ttl, err = time.ParseDuration(ttlStr); err != nil {
+			return nil, fmt.Errorf("invalid SESSION_TTL: %w", err)
+		}
+	}
+	sessions, err := auth.NewSessions(secret, ttl)
+	if err != nil {
+		return nil, err
+	}
+	if provider != nil {
+		slog.Info("end-user login enabled", "issuer", provider.Name())
 	}
+	return api.NewAuthService(db, provider, sessions), nil
 }
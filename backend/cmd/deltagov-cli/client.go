@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/drewjst/deltagov/internal/api"
+)
+
+// client reads bills from the database or the HTTP API.
+type client interface {
+	// SearchBills lists bills matching params.
+	SearchBills(ctx context.Context, params api.LexSearchParams) (*api.LexSearchResult, error)
+
+	// GetBill returns a bill with its versions.
+	GetBill(ctx context.Context, id uint) (*api.BillResponse, error)
+
+	// Diff diffs two versions of a bill.
+	Diff(ctx context.Context, billID, fromVersionID, toVersionID uint) (*api.DiffResponse, error)
+}
+
+// errBillNotFound reports a bill reference that matches no bill.
+var errBillNotFound = errors.New("bill not found")
+
+// billRef identifies a bill as type-congress-number, e.g. hr-119-1.
+type billRef struct {
+	Type     string
+	Congress int
+	Number   int
+}
+
+// parseBillRef parses a bill reference such as "hr-119-1".
+func parseBillRef(s string) (billRef, error) {
+	parts := strings.Split(strings.ToLower(s), "-")
+	if len(parts) != 3 || parts[0] == "" {
+		return billRef{}, fmt.Errorf("%w: bill %q is not type-congress-number, e.g. hr-119-1", errUsage, s)
+	}
+	congressNum, err := strconv.Atoi(parts[1])
+	if err != nil || congressNum <= 0 {
+		return billRef{}, fmt.Errorf("%w: bill %q has an invalid congress", errUsage, s)
+	}
+	number, err := strconv.Atoi(parts[2])
+	if err != nil || number <= 0 {
+		return billRef{}, fmt.Errorf("%w: bill %q has an invalid number", errUsage, s)
+	}
+	return billRef{Type: parts[0], Congress: congressNum, Number: number}, nil
+}
+
+func (r billRef) String() string {
+	return fmt.Sprintf("%s-%d-%d", r.Type, r.Congress, r.Number)
+}
+
+// refOf returns the reference for a bill.
+func refOf(b *api.BillResponse) billRef {
+	return billRef{Type: strings.ToLower(b.BillType), Congress: b.Congress, Number: b.BillNumber}
+}
+
+// findBill resolves a bill reference and returns the bill with its versions.
+func findBill(ctx context.Context, c client, ref billRef) (*api.BillResponse, error) {
+	result, err := c.SearchBills(ctx, api.LexSearchParams{
+		Congress: ref.Congress,
+		BillType: ref.Type,
+		Number:   strconv.Itoa(ref.Number),
+		Limit:    1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Bills) == 0 {
+		return nil, fmt.Errorf("%w: %s", errBillNotFound, ref)
+	}
+	return c.GetBill(ctx, result.Bills[0].ID)
+}
+
+// findVersion returns the bill's version with the given code, e.g. "IH".
+// A code stored more than once resolves to the most recent version.
+func findVersion(bill *api.BillResponse, code string) (*api.VersionResponse, error) {
+	var found *api.VersionResponse
+	for i := range bill.Versions {
+		if strings.EqualFold(bill.Versions[i].VersionCode, code) {
+			found = &bill.Versions[i] // Versions are oldest first
+		}
+	}
+	if found == nil {
+		codes := make([]string, len(bill.Versions))
+		for i, v := range bill.Versions {
+			codes[i] = v.VersionCode
+		}
+		return nil, fmt.Errorf("%s has no version %q (versions: %s)", refOf(bill), code, strings.Join(codes, ", "))
+	}
+	return found, nil
+}
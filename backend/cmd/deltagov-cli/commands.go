@@ -0,0 +1,269 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/drewjst/deltagov/internal/api"
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/database"
+	"github.com/drewjst/deltagov/internal/ingestor"
+	"github.com/drewjst/deltagov/internal/scope"
+	"github.com/drewjst/deltagov/internal/textstore"
+)
+
+// billsList implements "bills list".
+func billsList(ctx context.Context, c client, args []string) error {
+	fs := newFlagSet("bills list")
+	var params api.LexSearchParams
+	fs.IntVar(&params.Congress, "congress", 0, "Filter by congress number")
+	fs.StringVar(&params.BillType, "type", "", "Filter by bill type (hr, s, hjres, ...)")
+	fs.StringVar(&params.Sponsor, "sponsor", "", "Filter by sponsor name (partial match)")
+	fs.StringVar(&params.Query, "query", "", "Search bill titles (partial match)")
+	fs.BoolVar(&params.IsSpendingBill, "spending", false, "Only spending bills")
+	fs.StringVar(&params.PolicyArea, "policy-area", "", "Filter by CRS policy area")
+	fs.StringVar(&params.Subject, "subject", "", "Filter by CRS legislative subject")
+	fs.IntVar(&params.Limit, "limit", 20, "Number of bills (max 100)")
+	fs.IntVar(&params.Offset, "offset", 0, "Pagination offset")
+	asJSON := fs.Bool("json", false, "Print the result as JSON")
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := wantArgs("bills list", rest); err != nil {
+		return err
+	}
+
+	result, err := c.SearchBills(ctx, params)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(os.Stdout, result)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "BILL\tUPDATED\tSTATUS\tTITLE")
+	for i := range result.Bills {
+		b := &result.Bills[i]
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", refOf(b), b.UpdateDate, truncate(b.CurrentStatus, 30), truncate(b.Title, 80))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "%d of %d bills\n", len(result.Bills), result.Total)
+	return nil
+}
+
+// billsShow implements "bills show".
+func billsShow(ctx context.Context, c client, args []string) error {
+	fs := newFlagSet("bills show")
+	asJSON := fs.Bool("json", false, "Print the bill as JSON")
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := wantArgs("bills show", rest, "<bill>"); err != nil {
+		return err
+	}
+	ref, err := parseBillRef(rest[0])
+	if err != nil {
+		return err
+	}
+
+	bill, err := findBill(ctx, c, ref)
+	if err != nil {
+		return err
+	}
+	if *asJSON {
+		return printJSON(os.Stdout, bill)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintf(tw, "Bill:\t%s (ID %d)\n", ref, bill.ID)
+	fmt.Fprintf(tw, "Title:\t%s\n", bill.Title)
+	fmt.Fprintf(tw, "Sponsor:\t%s\n", bill.Sponsor)
+	fmt.Fprintf(tw, "Chamber:\t%s\n", bill.OriginChamber)
+	fmt.Fprintf(tw, "Status:\t%s\n", bill.CurrentStatus)
+	fmt.Fprintf(tw, "Updated:\t%s\n", bill.UpdateDate)
+	if bill.PolicyArea != "" {
+		fmt.Fprintf(tw, "Policy area:\t%s\n", bill.PolicyArea)
+	}
+	if bill.LawNumber != "" {
+		fmt.Fprintf(tw, "Law:\t%s\n", bill.LawNumber)
+	}
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "VERSION\tID\tDATE\tLABEL")
+	for _, v := range bill.Versions {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\n", v.VersionCode, v.ID, v.Date, v.Label)
+	}
+	return tw.Flush()
+}
+
+// Diff output formats.
+const (
+	diffFormatPatch = "patch"
+	diffFormatJSON  = "json"
+)
+
+// diff implements "diff".
+func diff(ctx context.Context, c client, args []string) error {
+	fs := newFlagSet("diff")
+	format := fs.String("format", diffFormatPatch, "Output format: patch (unified diff lines) or json (the API's diff response)")
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := wantArgs("diff", rest, "<bill>", "<from>", "<to>"); err != nil {
+		return err
+	}
+	if *format != diffFormatPatch && *format != diffFormatJSON {
+		return fmt.Errorf("%w: unknown format %q (patch or json)", errUsage, *format)
+	}
+	ref, err := parseBillRef(rest[0])
+	if err != nil {
+		return err
+	}
+
+	bill, err := findBill(ctx, c, ref)
+	if err != nil {
+		return err
+	}
+	from, err := findVersion(bill, rest[1])
+	if err != nil {
+		return err
+	}
+	to, err := findVersion(bill, rest[2])
+	if err != nil {
+		return err
+	}
+
+	d, err := c.Diff(ctx, bill.ID, from.ID, to.ID)
+	if err != nil {
+		return err
+	}
+	if *format == diffFormatJSON {
+		return printJSON(os.Stdout, d)
+	}
+	return writePatch(os.Stdout, ref, d)
+}
+
+// writePatch writes a diff as unified diff lines: a ---/+++ header naming
+// the versions, then each line prefixed with "+", "-", or " ". The API
+// doesn't report hunk positions, so there are no @@ headers; the output
+// is for reading and grepping rather than for patch(1).
+func writePatch(w io.Writer, ref billRef, d *api.DiffResponse) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "--- %s/%s\n", ref, d.FromVersion)
+	fmt.Fprintf(bw, "+++ %s/%s\n", ref, d.ToVersion)
+	for _, line := range d.Lines {
+		prefix := " "
+		switch line.Type {
+		case "insertion":
+			prefix = "+"
+		case "deletion":
+			prefix = "-"
+		}
+		bw.WriteString(prefix)
+		bw.WriteString(line.Text)
+		bw.WriteByte('\n')
+	}
+	fmt.Fprintf(bw, "# %d insertions(+), %d deletions(-)\n", d.Insertions, d.Deletions)
+	return bw.Flush()
+}
+
+// ingest implements "ingest": a one-off search-based ingestion, recorded
+// as an ingest run like the ingestor's.
+func ingest(ctx context.Context, args []string) error {
+	fs := newFlagSet("ingest")
+	var cfg ingestor.SearchIngestConfig
+	fs.IntVar(&cfg.Congress, "congress", 119, "Congress number")
+	fs.StringVar(&cfg.BillType, "type", "", "Bill type filter (hr, s, hjres, ...)")
+	fs.BoolVar(&cfg.IsAppropriations, "appropriations", false, "Only fetch appropriations/spending bills")
+	fs.IntVar(&cfg.Limit, "limit", 50, "Maximum number of bills to fetch")
+	fs.IntVar(&cfg.Concurrency, "concurrency", ingestor.DefaultConcurrency, "Number of bills processed at once (max: 16)")
+	rest, err := parseFlags(fs, args)
+	if err != nil {
+		return err
+	}
+	if err := wantArgs("ingest", rest); err != nil {
+		return err
+	}
+	cfg.BillType = strings.ToLower(cfg.BillType)
+
+	apiKey := os.Getenv("CONGRESS_API_KEY")
+	if apiKey == "" {
+		return errors.New("ingest needs CONGRESS_API_KEY")
+	}
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		return errors.New("ingest needs DATABASE_URL")
+	}
+
+	db, err := database.Connect(database.DefaultConfig(databaseURL))
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer database.Close(db)
+	if err := database.Migrate(db); err != nil {
+		return fmt.Errorf("failed to run migrations: %w", err)
+	}
+
+	congressClient, err := congress.NewClient(congress.WithAPIKey(apiKey))
+	if err != nil {
+		return fmt.Errorf("failed to create Congress client: %w", err)
+	}
+	scopeRules, err := scope.FromEnv()
+	if err != nil {
+		return fmt.Errorf("invalid scope configuration: %w", err)
+	}
+	texts, err := textstore.FromEnv(db)
+	if err != nil {
+		return fmt.Errorf("invalid text store configuration: %w", err)
+	}
+
+	svc := ingestor.NewService(db, congressClient)
+	svc.SetScope(scopeRules)
+	svc.SetConcurrency(cfg.Concurrency)
+	svc.SetTextStore(texts)
+
+	result, err := svc.RecordRun(ctx, "cli", "search", func(ctx context.Context) (*ingestor.IngestResult, error) {
+		return svc.IngestFromSearch(ctx, cfg)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("fetched %d, skipped %d, created %d, updated %d bills; %d new versions\n",
+		result.BillsFetched, result.BillsSkipped, result.BillsCreated, result.BillsUpdated, result.VersionsCreated)
+	for _, e := range result.Errors {
+		fmt.Fprintf(os.Stderr, "error: %v\n", e)
+	}
+	if len(result.Errors) > 0 {
+		return fmt.Errorf("%d bills failed to ingest", len(result.Errors))
+	}
+	return nil
+}
+
+// printJSON writes v as indented JSON.
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// truncate shortens s to at most n runes, marking the cut with "…".
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
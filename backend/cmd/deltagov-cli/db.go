@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/api"
+	"github.com/drewjst/deltagov/internal/database"
+	"github.com/drewjst/deltagov/internal/diff_engine"
+)
+
+// dbClient reads bills straight from the database through BillService, so
+// results match what the API would return. It connects on first use, so
+// commands can reject bad arguments without touching the database.
+type dbClient struct {
+	databaseURL string
+	db          *gorm.DB
+	bills       *api.BillService
+}
+
+func newDBClient(databaseURL string) *dbClient {
+	return &dbClient{databaseURL: databaseURL}
+}
+
+// service connects to the database if needed and returns the BillService.
+func (c *dbClient) service() (*api.BillService, error) {
+	if c.bills != nil {
+		return c.bills, nil
+	}
+	db, err := database.Connect(database.DefaultConfig(c.databaseURL))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	c.db, c.bills = db, api.NewBillService(db, nil)
+	return c.bills, nil
+}
+
+// Close closes the database connection, if one was opened.
+func (c *dbClient) Close() error {
+	if c.db == nil {
+		return nil
+	}
+	return database.Close(c.db)
+}
+
+func (c *dbClient) SearchBills(ctx context.Context, params api.LexSearchParams) (*api.LexSearchResult, error) {
+	bills, err := c.service()
+	if err != nil {
+		return nil, err
+	}
+	return bills.SearchBills(ctx, params)
+}
+
+func (c *dbClient) GetBill(ctx context.Context, id uint) (*api.BillResponse, error) {
+	bills, err := c.service()
+	if err != nil {
+		return nil, err
+	}
+	return bills.GetBillWithVersions(ctx, id)
+}
+
+func (c *dbClient) Diff(ctx context.Context, billID, fromVersionID, toVersionID uint) (*api.DiffResponse, error) {
+	bills, err := c.service()
+	if err != nil {
+		return nil, err
+	}
+	return bills.ComputeDiff(ctx, billID, fromVersionID, toVersionID, diff_engine.Options{})
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/api"
+)
+
+// httpClient reads bills from a running DeltaGov API.
+type httpClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+func newHTTPClient(baseURL string) *httpClient {
+	return &httpClient{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: 2 * time.Minute}, // Uncached diffs of large bills are slow
+	}
+}
+
+func (c *httpClient) SearchBills(ctx context.Context, params api.LexSearchParams) (*api.LexSearchResult, error) {
+	query := url.Values{}
+	setInt := func(key string, v int) {
+		if v > 0 {
+			query.Set(key, strconv.Itoa(v))
+		}
+	}
+	setString := func(key, v string) {
+		if v != "" {
+			query.Set(key, v)
+		}
+	}
+	setInt("congress", params.Congress)
+	setString("type", params.BillType)
+	setString("number", params.Number)
+	setString("sponsor", params.Sponsor)
+	setString("query", params.Query)
+	setString("policyArea", params.PolicyArea)
+	setString("subject", params.Subject)
+	if params.IsSpendingBill {
+		query.Set("spending", "true")
+	}
+	setInt("limit", params.Limit)
+	setInt("offset", params.Offset)
+
+	var result api.LexSearchResult
+	if err := c.get(ctx, "/api/v1/lex?"+query.Encode(), &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (c *httpClient) GetBill(ctx context.Context, id uint) (*api.BillResponse, error) {
+	var bill api.BillResponse
+	if err := c.get(ctx, fmt.Sprintf("/api/v1/bills/%d", id), &bill); err != nil {
+		return nil, err
+	}
+	return &bill, nil
+}
+
+func (c *httpClient) Diff(ctx context.Context, billID, fromVersionID, toVersionID uint) (*api.DiffResponse, error) {
+	var diff api.DiffResponse
+	if err := c.get(ctx, fmt.Sprintf("/api/v1/bills/%d/diff/%d/%d", billID, fromVersionID, toVersionID), &diff); err != nil {
+		return nil, err
+	}
+	return &diff, nil
+}
+
+// get fetches path and decodes the JSON response into out. Error
+// responses are reported with their problem detail.
+func (c *httpClient) get(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		var problem struct {
+			Detail string `json:"detail"`
+		}
+		if json.Unmarshal(body, &problem) == nil && problem.Detail != "" {
+			return fmt.Errorf("GET %s: %s: %s", path, resp.Status, problem.Detail)
+		}
+		return fmt.Errorf("GET %s: %s", path, resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("GET %s: failed to decode response: %w", path, err)
+	}
+	return nil
+}
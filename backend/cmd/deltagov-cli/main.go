@@ -0,0 +1,157 @@
+// Command deltagov-cli queries and diffs bills from the command line,
+// reading either the database directly (DATABASE_URL) or a running API
+// (-api or DELTAGOV_API_URL).
+//
+//	deltagov-cli bills list --congress 119 --type hr
+//	deltagov-cli bills show hr-119-1
+//	deltagov-cli diff hr-119-1 IH EH --format=patch
+//	deltagov-cli ingest --congress 119 --type hr
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/joho/godotenv"
+
+	"github.com/drewjst/deltagov/internal/logging"
+)
+
+const usage = `Usage: deltagov-cli [-api URL] <command> [arguments]
+
+Commands:
+  bills list [flags]                 List bills, most recently updated first
+  bills show <bill>                  Show a bill and its text versions
+  diff <bill> <from> <to> [flags]    Diff two versions of a bill by version code
+  ingest [flags]                     Fetch bills from Congress.gov into the database
+
+Bills are written as type-congress-number, e.g. hr-119-1 or s-118-870.
+
+Reads use the API at -api (or DELTAGOV_API_URL) when set, and the database
+at DATABASE_URL otherwise. ingest always uses the database and needs
+CONGRESS_API_KEY.
+`
+
+// errUsage reports bad arguments; the message is printed with the usage.
+var errUsage = errors.New("usage")
+
+func main() {
+	apiURL := flag.String("api", "", "Base URL of a DeltaGov API to query instead of the database (default: DELTAGOV_API_URL)")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		fmt.Fprintln(os.Stderr, "\nGlobal flags:")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// Load .env file if present
+	_ = godotenv.Load()
+
+	// Logs go to stderr so stdout can be piped
+	slog.SetDefault(logging.New(os.Stderr, os.Getenv("LOG_LEVEL"), "text"))
+
+	if *apiURL == "" {
+		*apiURL = os.Getenv("DELTAGOV_API_URL")
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	err := run(ctx, *apiURL, flag.Args())
+	if errors.Is(err, errUsage) {
+		fmt.Fprintf(os.Stderr, "deltagov-cli: %v\n\n", err)
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "deltagov-cli: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run dispatches a command.
+func run(ctx context.Context, apiURL string, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("%w: no command given", errUsage)
+	}
+
+	switch args[0] {
+	case "bills":
+		if len(args) < 2 {
+			return fmt.Errorf("%w: bills needs a subcommand (list or show)", errUsage)
+		}
+		switch args[1] {
+		case "list":
+			return withClient(ctx, apiURL, func(c client) error { return billsList(ctx, c, args[2:]) })
+		case "show":
+			return withClient(ctx, apiURL, func(c client) error { return billsShow(ctx, c, args[2:]) })
+		default:
+			return fmt.Errorf("%w: unknown bills subcommand %q", errUsage, args[1])
+		}
+	case "diff":
+		return withClient(ctx, apiURL, func(c client) error { return diff(ctx, c, args[1:]) })
+	case "ingest":
+		return ingest(ctx, args[1:])
+	case "help":
+		flag.Usage()
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown command %q", errUsage, args[0])
+	}
+}
+
+// withClient opens an API or database client, runs fn, and closes it.
+func withClient(ctx context.Context, apiURL string, fn func(client) error) error {
+	if apiURL != "" {
+		return fn(newHTTPClient(apiURL))
+	}
+
+	databaseURL := os.Getenv("DATABASE_URL")
+	if databaseURL == "" {
+		return errors.New("set DATABASE_URL, or -api / DELTAGOV_API_URL to use an API")
+	}
+	c := newDBClient(databaseURL)
+	defer c.Close()
+	return fn(c)
+}
+
+// parseFlags parses fs from args, allowing flags after positional
+// arguments (diff hr-119-1 IH EH --format=patch), and returns the
+// positional arguments.
+func parseFlags(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, fmt.Errorf("%w: %v", errUsage, err)
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// newFlagSet returns a flag set for a command that reports errors to run
+// rather than exiting.
+func newFlagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	return fs
+}
+
+// wantArgs checks that a command got exactly the named positional arguments.
+func wantArgs(command string, args []string, names ...string) error {
+	if len(args) != len(names) {
+		return fmt.Errorf("%w: %s takes %d argument(s): %s", errUsage, command, len(names), strings.Join(names, " "))
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Command genclient generates the typed Go and TypeScript clients of the
+// DeltaGov API from its OpenAPI document. By default the document is built
+// from the API's routes, so no server needs to run:
+//
+//	go run ./cmd/genclient -go client/client_gen.go -ts ../frontend/src/app/api/deltagov-client.ts
+//
+// go generate ./client runs it with the paths the repository uses.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/drewjst/deltagov/internal/api"
+	"github.com/drewjst/deltagov/internal/clientgen"
+)
+
+func main() {
+	specPath := flag.String("spec", "", "OpenAPI document (JSON) to generate from (default: built from the API's routes)")
+	prefix := flag.String("prefix", api.VersionPrefix, "API path prefix, as API_PREFIX, when building the document")
+	goOut := flag.String("go", "", "Write the Go client to this file")
+	goPackage := flag.String("go-package", "client", "Package name of the Go client")
+	tsOut := flag.String("ts", "", "Write the TypeScript client to this file")
+	flag.Parse()
+
+	if err := run(*specPath, *prefix, *goOut, *goPackage, *tsOut); err != nil {
+		fmt.Fprintf(os.Stderr, "genclient: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(specPath, prefix, goOut, goPackage, tsOut string) error {
+	if goOut == "" && tsOut == "" {
+		return errors.New("nothing to generate: set -go, -ts, or both")
+	}
+
+	var data []byte
+	var err error
+	if specPath != "" {
+		data, err = os.ReadFile(specPath)
+	} else {
+		data, err = json.Marshal(api.Document(prefix))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read OpenAPI document: %w", err)
+	}
+	spec, err := clientgen.Parse(data)
+	if err != nil {
+		return err
+	}
+
+	if goOut != "" {
+		src, err := clientgen.Go(spec, goPackage)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(goOut, src, 0o644); err != nil {
+			return fmt.Errorf("failed to write Go client: %w", err)
+		}
+	}
+	if tsOut != "" {
+		src, err := clientgen.TypeScript(spec)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(tsOut, src, 0o644); err != nil {
+			return fmt.Errorf("failed to write TypeScript client: %w", err)
+		}
+	}
+	return nil
+}
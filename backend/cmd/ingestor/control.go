@@ -0,0 +1,277 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/schedule"
+)
+
+// runFilters narrows an on-demand run to a search of one congress, like
+// the -search flags. A run without filters is a full polling cycle.
+type runFilters struct {
+	Congress       int    `json:"congress,omitempty"`
+	BillType       string `json:"type,omitempty"`
+	Appropriations bool   `json:"appropriations,omitempty"`
+	Limit          int    `json:"limit,omitempty"`
+}
+
+// runRequest asks the polling loop for a run.
+type runRequest struct {
+	triggeredBy string
+	priority    schedule.Priority // Set for scheduled runs; others are full cycles
+	filters     *runFilters
+}
+
+// runStatus describes a run the controller started.
+type runStatus struct {
+	TriggeredBy string            `json:"triggeredBy"`
+	Priority    schedule.Priority `json:"priority,omitempty"`
+	Filters     *runFilters       `json:"filters,omitempty"`
+	StartedAt   time.Time         `json:"startedAt"`
+	FinishedAt  *time.Time        `json:"finishedAt,omitempty"`
+	Error       string            `json:"error,omitempty"`
+}
+
+// controlConfig is the ingestor configuration reported by /status.
+type controlConfig struct {
+	PollInterval   string   `json:"pollInterval"`
+	Schedule       []string `json:"schedule"`     // Each priority's refresh interval and budget share
+	HourlyBudget   int      `json:"hourlyBudget"` // Congress.gov requests per hour; 0 for no budget
+	Mode           string   `json:"mode"`         // recent or search
+	Congress       int      `json:"congress"`
+	BillType       string   `json:"type,omitempty"`
+	Appropriations bool     `json:"appropriations"`
+	Limit          int      `json:"limit"`
+	Concurrency    int      `json:"concurrency"`
+	Targets        []string `json:"targets,omitempty"`
+	TextSource     string   `json:"textSource"`
+	States         []string `json:"states,omitempty"`
+	Rules          bool     `json:"rules"` // Federal Register rules are ingested each cycle
+}
+
+// controlStatus is the body of /status and of the other endpoints' responses.
+type controlStatus struct {
+	Paused  bool          `json:"paused"`
+	Running *runStatus    `json:"running,omitempty"`
+	Queued  bool          `json:"queued"` // An on-demand run is waiting for the current one
+	LastRun *runStatus    `json:"lastRun,omitempty"`
+	NextRun *time.Time    `json:"nextRun,omitempty"` // Next scheduled run; skipped while paused
+	Config  controlConfig `json:"config"`
+
+	// SchemaDrift lists Congress.gov response fields the client doesn't
+	// decode or didn't get, with CONGRESS_STRICT_DECODING set
+	SchemaDrift []congress.FieldDrift `json:"schemaDrift,omitempty"`
+}
+
+// controller lets operators see and steer the continuous-mode polling
+// loop over HTTP: its status, on-demand runs, and pausing scheduled runs.
+// Runs still execute on the loop's goroutine, one at a time.
+type controller struct {
+	token    string
+	config   controlConfig
+	requests chan runRequest
+	drift    func() []congress.FieldDrift // The client's drift report; nil unless strict
+
+	mu      sync.Mutex
+	paused  bool
+	running *runStatus
+	lastRun *runStatus
+	nextRun time.Time
+}
+
+// newController returns a controller for a loop with the given config.
+// Endpoints require token as a bearer token.
+func newController(token string, config controlConfig) *controller {
+	return &controller{
+		token:    token,
+		config:   config,
+		requests: make(chan runRequest, 1),
+	}
+}
+
+// Requests returns the on-demand runs for the polling loop to perform.
+func (c *controller) Requests() <-chan runRequest {
+	return c.requests
+}
+
+// Paused reports whether scheduled runs are paused.
+func (c *controller) Paused() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.paused
+}
+
+// SetNextRun records when the next scheduled run is due.
+func (c *controller) SetNextRun(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nextRun = t
+}
+
+// Run performs a run with fn, recording it for /status.
+func (c *controller) Run(req runRequest, fn func(runRequest) error) {
+	status := &runStatus{TriggeredBy: req.triggeredBy, Priority: req.priority, Filters: req.filters, StartedAt: time.Now()}
+	c.mu.Lock()
+	c.running = status
+	c.mu.Unlock()
+
+	err := fn(req)
+
+	finished := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	status.FinishedAt = &finished
+	if err != nil {
+		status.Error = err.Error()
+	}
+	c.running = nil
+	c.lastRun = status
+}
+
+// status returns a snapshot of the loop's state.
+func (c *controller) status() controlStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := controlStatus{
+		Paused:  c.paused,
+		Running: copyRun(c.running),
+		Queued:  len(c.requests) > 0,
+		LastRun: copyRun(c.lastRun),
+		Config:  c.config,
+	}
+	if !c.nextRun.IsZero() {
+		next := c.nextRun
+		s.NextRun = &next
+	}
+	if c.drift != nil {
+		s.SchemaDrift = c.drift()
+	}
+	return s
+}
+
+// copyRun returns a copy of run, or nil.
+func copyRun(run *runStatus) *runStatus {
+	if run == nil {
+		return nil
+	}
+	copied := *run
+	return &copied
+}
+
+// Handler returns the admin endpoints:
+//
+//	GET  /status  the loop's state, last and next run, and configuration
+//	POST /run     queue a run now; a JSON body of runFilters narrows it
+//	POST /pause   skip scheduled runs until resumed
+//	POST /resume  resume scheduled runs
+func (c *controller) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, c.status())
+	})
+	mux.HandleFunc("POST /run", c.handleRun)
+	mux.HandleFunc("POST /pause", func(w http.ResponseWriter, r *http.Request) {
+		c.setPaused(true)
+		writeJSON(w, http.StatusOK, c.status())
+	})
+	mux.HandleFunc("POST /resume", func(w http.ResponseWriter, r *http.Request) {
+		c.setPaused(false)
+		writeJSON(w, http.StatusOK, c.status())
+	})
+	return c.authorize(mux)
+}
+
+// billTypePattern matches a bill type filter, e.g., "hr".
+var billTypePattern = regexp.MustCompile(`^[A-Za-z]{1,16}$`)
+
+// handleRun queues an on-demand run. At most one run can be queued; the
+// loop starts it once the current run, if any, finishes.
+func (c *controller) handleRun(w http.ResponseWriter, r *http.Request) {
+	var filters runFilters
+	if err := json.NewDecoder(io.LimitReader(r.Body, 4096)).Decode(&filters); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "invalid run filters: "+err.Error())
+		return
+	}
+	switch {
+	case filters.Congress < 0 || filters.Limit < 0:
+		writeError(w, http.StatusBadRequest, "congress and limit must not be negative")
+		return
+	case filters.BillType != "" && !billTypePattern.MatchString(filters.BillType):
+		writeError(w, http.StatusBadRequest, "invalid bill type")
+		return
+	}
+
+	req := runRequest{triggeredBy: "manual"}
+	if filters != (runFilters{}) {
+		filters.BillType = strings.ToLower(filters.BillType)
+		req.filters = &filters
+	}
+	select {
+	case c.requests <- req:
+		slog.Info("on-demand ingestion run queued", "filters", req.filters)
+		writeJSON(w, http.StatusAccepted, c.status())
+	default:
+		writeError(w, http.StatusConflict, "a run is already queued")
+	}
+}
+
+// setPaused pauses or resumes scheduled runs.
+func (c *controller) setPaused(paused bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.paused != paused {
+		slog.Info("scheduled ingestion runs toggled", "paused", paused)
+	}
+	c.paused = paused
+}
+
+// authorize rejects requests without the controller's bearer token.
+func (c *controller) authorize(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(c.token)) != 1 {
+			writeError(w, http.StatusUnauthorized, "invalid admin token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Serve serves the admin endpoints on addr until ctx is done.
+func (c *controller) Serve(ctx context.Context, addr string) {
+	srv := &http.Server{Addr: addr, Handler: c.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	slog.Info("serving ingestor admin endpoints", "addr", addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Error("ingestor admin server stopped", "error", err)
+	}
+}
+
+// writeJSON writes v as a JSON response.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a JSON error response.
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// serve sends a request to the controller's endpoints with a bearer
+// token, unless token is empty, and returns the response.
+func serve(t *testing.T, c *controller, method, path, token, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	c.Handler().ServeHTTP(rec, req)
+	return rec
+}
+
+// decodeStatus decodes a controller status response.
+func decodeStatus(t *testing.T, rec *httptest.ResponseRecorder) controlStatus {
+	t.Helper()
+	var status controlStatus
+	if err := json.NewDecoder(rec.Body).Decode(&status); err != nil {
+		t.Fatalf("Failed to decode status: %v", err)
+	}
+	return status
+}
+
+// TestControllerAuthorize verifies every endpoint requires the token.
+func TestControllerAuthorize(t *testing.T) {
+	c := newController("s3cret", controlConfig{Mode: "recent"})
+	tests := []struct {
+		name          string
+		authorization string
+		want          int
+	}{
+		{"missing", "", http.StatusUnauthorized},
+		{"wrong", "Bearer wrong!", http.StatusUnauthorized},
+		{"prefix", "Bearer s3c", http.StatusUnauthorized},
+		{"not bearer", "s3cret", http.StatusUnauthorized},
+		{"valid", "Bearer s3cret", http.StatusOK},
+	}
+	for _, tt := range tests {
+		for _, endpoint := range []string{"GET /status", "POST /pause", "POST /resume"} {
+			method, path, _ := strings.Cut(endpoint, " ")
+			req := httptest.NewRequest(method, path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			c.Handler().ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("%s token, %s: status = %d, want %d", tt.name, endpoint, rec.Code, tt.want)
+			}
+		}
+	}
+	if rec := serve(t, c, http.MethodPost, "/run", "wrong!", ""); rec.Code != http.StatusUnauthorized || len(c.Requests()) != 0 {
+		t.Errorf("Unauthorized run: status = %d with %d runs queued, want 401 and none", rec.Code, len(c.Requests()))
+	}
+}
+
+// TestControllerPause verifies pausing and resuming scheduled runs.
+func TestControllerPause(t *testing.T) {
+	c := newController("s3cret", controlConfig{})
+
+	if status := decodeStatus(t, serve(t, c, http.MethodPost, "/pause", "s3cret", "")); !status.Paused || !c.Paused() {
+		t.Errorf("After pause: status paused = %v, controller paused = %v", status.Paused, c.Paused())
+	}
+	// Pausing twice is harmless
+	if status := decodeStatus(t, serve(t, c, http.MethodPost, "/pause", "s3cret", "")); !status.Paused {
+		t.Error("Second pause resumed runs")
+	}
+	if status := decodeStatus(t, serve(t, c, http.MethodPost, "/resume", "s3cret", "")); status.Paused || c.Paused() {
+		t.Errorf("After resume: status paused = %v, controller paused = %v", status.Paused, c.Paused())
+	}
+}
+
+// TestControllerRun verifies on-demand runs are validated and queued one
+// at a time, and that finished runs are reported.
+func TestControllerRun(t *testing.T) {
+	c := newController("s3cret", controlConfig{})
+
+	for _, body := range []string{`{`, `{"congress": -1}`, `{"limit": -5}`, `{"type": "hr; drop"}`} {
+		if rec := serve(t, c, http.MethodPost, "/run", "s3cret", body); rec.Code != http.StatusBadRequest {
+			t.Errorf("Run with %s: status = %d, want 400", body, rec.Code)
+		}
+	}
+
+	rec := serve(t, c, http.MethodPost, "/run", "s3cret", `{"congress": 119, "type": "HR"}`)
+	if rec.Code != http.StatusAccepted || !decodeStatus(t, rec).Queued {
+		t.Fatalf("Run: status = %d, want 202 with the run queued", rec.Code)
+	}
+	if rec := serve(t, c, http.MethodPost, "/run", "s3cret", ""); rec.Code != http.StatusConflict {
+		t.Errorf("Second run while one is queued: status = %d, want 409", rec.Code)
+	}
+
+	req := <-c.Requests()
+	if req.triggeredBy != "manual" || req.filters == nil || req.filters.Congress != 119 || req.filters.BillType != "hr" {
+		t.Errorf("Queued run = %+v, want a manual run of 119 hr", req)
+	}
+	c.Run(req, func(runRequest) error {
+		status := c.status()
+		if status.Running == nil || status.Running.TriggeredBy != "manual" || status.Queued {
+			t.Errorf("While running: status = %+v", status)
+		}
+		return errors.New("rate limited")
+	})
+	status := decodeStatus(t, serve(t, c, http.MethodGet, "/status", "s3cret", ""))
+	if status.Running != nil || status.LastRun == nil || status.LastRun.FinishedAt == nil || status.LastRun.Error != "rate limited" {
+		t.Errorf("After the run: status = %+v, want the failed run as the last", status)
+	}
+
+	// A run without filters is a full cycle
+	if rec := serve(t, c, http.MethodPost, "/run", "s3cret", ""); rec.Code != http.StatusAccepted {
+		t.Fatalf("Run without filters: status = %d, want 202", rec.Code)
+	}
+	if req := <-c.Requests(); req.filters != nil {
+		t.Errorf("Run without filters queued with %+v", req.filters)
+	}
+}
@@ -1,19 +1,46 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"flag"
-	"log"
+	"fmt"
+	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/joho/godotenv"
+	"gorm.io/gorm"
 
+	"github.com/drewjst/deltagov/internal/appropriations"
+	"github.com/drewjst/deltagov/internal/archive"
 	"github.com/drewjst/deltagov/internal/congress"
 	"github.com/drewjst/deltagov/internal/database"
+	"github.com/drewjst/deltagov/internal/deltas"
+	"github.com/drewjst/deltagov/internal/embeddings"
+	"github.com/drewjst/deltagov/internal/events"
+	"github.com/drewjst/deltagov/internal/federalregister"
+	"github.com/drewjst/deltagov/internal/govinfo"
 	"github.com/drewjst/deltagov/internal/ingestor"
+	"github.com/drewjst/deltagov/internal/insights"
+	"github.com/drewjst/deltagov/internal/lineage"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/memberstats"
+	"github.com/drewjst/deltagov/internal/metrics"
+	"github.com/drewjst/deltagov/internal/opensearch"
+	"github.com/drewjst/deltagov/internal/openstates"
+	"github.com/drewjst/deltagov/internal/provisions"
+	"github.com/drewjst/deltagov/internal/regulations"
+	"github.com/drewjst/deltagov/internal/schedule"
+	"github.com/drewjst/deltagov/internal/scope"
+	"github.com/drewjst/deltagov/internal/stats"
+	"github.com/drewjst/deltagov/internal/textstore"
+	"github.com/drewjst/deltagov/internal/trending"
 )
 
 func main() {
@@ -24,26 +51,70 @@ func main() {
 	// Search-based ingestion flags
 	searchMode := flag.Bool("search", false, "Use search-based ingestion instead of recent bills")
 	congressNum := flag.Int("congress", 119, "Congress number to search (e.g., 118, 119)")
-	billType := flag.String("type", "", "Bill type filter (hr, s, hjres, sjres, hconres, sconres, hres, sres)")
+	billType := flag.String("type", "", "Bill type filter ("+strings.Join(ingestor.AllBillTypes, ", ")+")")
 	appropriationsOnly := flag.Bool("appropriations", false, "Only fetch appropriations/spending bills")
-	concurrency := flag.Int("concurrency", 5, "Number of parallel workers for batch processing (max: 10)")
-	parallel := flag.Bool("parallel", false, "Use parallel processing for recent bills mode")
+	concurrency := flag.Int("concurrency", ingestor.DefaultConcurrency, "Number of bills processed at once (max: 16)")
+	// Kept so existing job definitions still parse; recent mode always uses the worker pool
+	_ = flag.Bool("parallel", false, "Deprecated: no effect, recent bills mode always runs in parallel")
+	// Historical backfill flags
+	backfill := flag.Bool("backfill", false, "Walk every bill of -congress (optionally -type), ingest all text versions, and exit")
+	backfillDelay := flag.Duration("backfill-delay", ingestor.DefaultBackfillDelay, "Pause between bills during backfill to stay under API rate limits")
+	backfillMax := flag.Int("backfill-max", 0, "Stop the backfill after this many bills (0 = no limit); rerun to resume")
+	backfillRestart := flag.Bool("backfill-restart", false, "Ignore saved backfill checkpoints and start from the beginning")
+	// GovInfo bulk data flags
+	govinfoMode := flag.Bool("govinfo", false, "Ingest every text version of -congress (optionally -type) from GovInfo bulk data, and exit")
+	// State legislature flags (Open States)
+	stateCode := flag.String("state", "", "Ingest up to -limit recently updated bills of this state (e.g., ca) from Open States, and exit")
+	stateSession := flag.String("session", "", "State legislative session for -state and OPENSTATES_STATES (default: all sessions)")
+	// Federal Register flags
+	rulesMode := flag.Bool("rules", false, "Ingest up to -limit proposed and final rules per FEDERAL_REGISTER_KEYWORDS term from the Federal Register, and exit")
+
+	// Reconciliation flags
+	reconcileMode := flag.Bool("reconcile", false, "Check a random sample of stored bills against Congress.gov, record drift, and exit")
+	reconcileSample := flag.Int("reconcile-sample", ingestor.DefaultReconcileSample, "Bills checked by -reconcile")
+	reconcileThreshold := flag.Float64("reconcile-threshold", ingestor.DefaultReconcileThreshold, "Share of checked bills with drift above which -reconcile alerts")
+
+	// Executive business flags
+	executiveMode := flag.Bool("executive", false, "Sync the treaties and nominations of -congress from Congress.gov, and exit")
+
+	targetsSpec := flag.String("targets", "", "Ingestion targets for recent bills mode (overrides INGEST_TARGETS), e.g. \"congress=119 type=hr limit=50; congress=119 appropriations=true\"")
 
 	flag.Parse()
 
 	// Load .env file if present
 	_ = godotenv.Load()
 
+	// Structured logging (LOG_LEVEL, LOG_FORMAT)
+	logging.Setup()
+	defer runCleanups()
+
 	// Get API key from environment
 	apiKey := os.Getenv("CONGRESS_API_KEY")
 	if apiKey == "" {
-		log.Fatal("CONGRESS_API_KEY environment variable is required")
+		fatal("CONGRESS_API_KEY environment variable is required")
+	}
+
+	// Bill types backfills and GovInfo runs walk: -type, else
+	// INGEST_BILL_TYPES, else every type Congress.gov serves
+	if *billType != "" {
+		bt, ok := congress.LookupBillType(*billType)
+		if !ok {
+			fatal("invalid -type", "type", *billType, "want", strings.Join(ingestor.AllBillTypes, ","))
+		}
+		*billType = bt.Code
+	}
+	billTypes, err := ingestor.ParseBillTypes(os.Getenv("INGEST_BILL_TYPES"))
+	if err != nil {
+		fatal("invalid INGEST_BILL_TYPES", "error", err)
+	}
+	if *billType != "" {
+		billTypes = []string{*billType}
 	}
 
 	// Get database URL from environment
 	databaseURL := os.Getenv("DATABASE_URL")
 	if databaseURL == "" {
-		log.Fatal("DATABASE_URL environment variable is required")
+		fatal("DATABASE_URL environment variable is required")
 	}
 
 	// Get poll interval from environment (default: 1 hour)
@@ -56,27 +127,252 @@ func main() {
 
 	// Connect to database
 	dbConfig := database.DefaultConfig(databaseURL)
+	if thresholdStr := os.Getenv("DB_SLOW_QUERY_THRESHOLD"); thresholdStr != "" {
+		if parsed, err := time.ParseDuration(thresholdStr); err == nil {
+			dbConfig.SlowQueryThreshold = parsed
+		}
+	}
 	db, err := database.Connect(dbConfig)
 	if err != nil {
-		log.Fatalf("Failed to connect to database: %v", err)
+		fatal("failed to connect to database", "error", err)
 	}
-	defer database.Close(db)
-	log.Println("Connected to database")
+	atExit(func() { database.Close(db) })
+	slog.Info("connected to database")
 
 	// Run migrations
 	if err := database.Migrate(db); err != nil {
-		log.Fatalf("Failed to run migrations: %v", err)
+		fatal("failed to run migrations", "error", err)
+	}
+	slog.Info("database migrations complete")
+
+	// Optional client-side cap on Congress.gov requests per hour, shared by all workers
+	rateLimit := 0
+	if limitStr := os.Getenv("CONGRESS_RATE_LIMIT"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil {
+			rateLimit = parsed
+		}
+	}
+
+	// Scheduling of continuous mode: the hourly Congress.gov request budget
+	// shared by watched, appropriations, and recent bill refreshes
+	scheduleCfg := schedule.DefaultConfig(pollInterval)
+	if budgetStr := os.Getenv("CONGRESS_HOURLY_BUDGET"); budgetStr != "" {
+		if parsed, err := strconv.Atoi(budgetStr); err == nil && parsed >= 0 {
+			scheduleCfg.HourlyBudget = parsed
+		}
+	}
+	for env, priority := range map[string]schedule.Priority{
+		"WATCHED_POLL_INTERVAL":        schedule.Watched,
+		"APPROPRIATIONS_POLL_INTERVAL": schedule.Appropriations,
+	} {
+		if intervalStr := os.Getenv(env); intervalStr != "" {
+			if parsed, err := time.ParseDuration(intervalStr); err == nil && parsed > 0 {
+				q := scheduleCfg.Queues[priority]
+				q.Interval = parsed
+				scheduleCfg.Queues[priority] = q
+			}
+		}
+	}
+
+	// Create Congress API client, optionally reporting fields Congress.gov
+	// added, dropped, or renamed
+	congressOpts := []congress.Option{congress.WithAPIKey(apiKey), congress.WithRateLimit(rateLimit)}
+	strictDecoding, _ := strconv.ParseBool(os.Getenv("CONGRESS_STRICT_DECODING"))
+	if strictDecoding {
+		congressOpts = append(congressOpts, congress.WithStrictDecoding())
+	}
+	congressClient, err := congress.NewClient(congressOpts...)
+	if err != nil {
+		fatal("failed to create Congress client", "error", err)
 	}
-	log.Println("Database migrations complete")
 
-	// Create Congress API client
-	congressClient, err := congress.New(apiKey)
+	// Load bill scope rules (allow/deny lists for ingestion)
+	scopeRules, err := scope.FromEnv()
 	if err != nil {
-		log.Fatalf("Failed to create Congress client: %v", err)
+		fatal("invalid scope configuration", "error", err)
 	}
 
 	// Create ingestor service
 	ingestorSvc := ingestor.NewService(db, congressClient)
+	ingestorSvc.SetScope(scopeRules)
+	ingestorSvc.SetConcurrency(*concurrency)
+
+	// Keep new versions' raw text outside the versions table (TEXT_STORE)
+	texts, err := textstore.FromEnv(db)
+	if err != nil {
+		fatal("invalid text store configuration", "error", err)
+	}
+	ingestorSvc.SetTextStore(texts)
+
+	// Optionally publish ingestion events to Pub/Sub or NATS (EVENT_PUBLISHER)
+	publisher, err := events.FromEnv()
+	if err != nil {
+		fatal("invalid event publisher configuration", "error", err)
+	}
+	if publisher != nil {
+		atExit(func() { publisher.Close() })
+	}
+	ingestorSvc.SetEventPublisher(publisher)
+
+	// Optionally take bill text from GovInfo bulk data instead of Congress.gov
+	textSource := os.Getenv("TEXT_SOURCE")
+	switch textSource {
+	case "", "congress":
+	case "govinfo":
+		ingestorSvc.SetGovInfo(govinfo.NewClient())
+	default:
+		fatal("invalid TEXT_SOURCE, want congress or govinfo", "text_source", textSource)
+	}
+	if *govinfoMode {
+		ingestorSvc.SetGovInfo(govinfo.NewClient())
+	}
+	govinfoCfg := ingestor.GovInfoConfig{Congress: *congressNum, BillTypes: billTypes}
+
+	// Optionally track state legislatures through Open States
+	states := splitList(strings.ToLower(os.Getenv("OPENSTATES_STATES")))
+	if *stateCode != "" || len(states) > 0 {
+		openstatesClient, err := openstates.NewClient(openstates.WithAPIKey(os.Getenv("OPENSTATES_API_KEY")))
+		if err != nil {
+			fatal("state ingestion requires OPENSTATES_API_KEY", "error", err)
+		}
+		ingestorSvc.SetOpenStates(openstatesClient)
+	}
+	stateCfg := ingestor.OpenStatesConfig{
+		Session: *stateSession,
+		Query:   os.Getenv("OPENSTATES_QUERY"),
+		Limit:   *billLimit,
+	}
+
+	// Optionally track Federal Register rules of some agencies or keywords
+	rulesCfg := regulations.Config{
+		Agencies: splitList(os.Getenv("FEDERAL_REGISTER_AGENCIES")),
+		Keywords: splitList(os.Getenv("FEDERAL_REGISTER_KEYWORDS")),
+		Limit:    *billLimit,
+	}
+	if *rulesMode && rulesCfg.IsEmpty() {
+		fatal("rule ingestion requires FEDERAL_REGISTER_AGENCIES or FEDERAL_REGISTER_KEYWORDS")
+	}
+	var rulesSvc *regulations.Service
+	if !rulesCfg.IsEmpty() {
+		rulesSvc = regulations.NewService(db, federalregister.NewClient())
+	}
+
+	// Precompute diffs against neighboring versions as versions are stored,
+	// and the most requested diffs each cycle
+	var diffQueue *deltas.Queue
+	diffWorkers := 2
+	if workersStr := os.Getenv("DIFF_PRECOMPUTE_WORKERS"); workersStr != "" {
+		if parsed, err := strconv.Atoi(workersStr); err == nil {
+			diffWorkers = parsed
+		}
+	}
+	if diffWorkers > 0 {
+		summarizer, err := insights.FromEnv()
+		if err != nil {
+			fatal("invalid summarizer configuration", "error", err)
+		}
+		diffQueue = deltas.NewQueue(db, diffWorkers)
+		diffQueue.SetSummarizer(summarizer)
+		diffQueue.SetEventPublisher(publisher)
+		// Drains queued diffs, also when a later step fails
+		atExit(diffQueue.Close)
+		ingestorSvc.SetDiffQueue(diffQueue)
+	}
+
+	// Archive the text of superseded versions once they are old enough
+	archivePolicy := archive.Policy{KeepLatest: archive.DefaultKeepLatest}
+	if afterStr := os.Getenv("ARCHIVE_AFTER"); afterStr != "" {
+		parsed, err := time.ParseDuration(afterStr)
+		if err != nil {
+			fatal("invalid ARCHIVE_AFTER", "error", err)
+		}
+		archivePolicy.After = parsed
+	}
+	if keepStr := os.Getenv("ARCHIVE_KEEP_LATEST"); keepStr != "" {
+		if parsed, err := strconv.Atoi(keepStr); err == nil {
+			archivePolicy.KeepLatest = parsed
+		}
+	}
+
+	// Window of activity the trending scores cover; 0 disables scoring
+	trendingWindow := trending.DefaultWindow
+	if windowStr := os.Getenv("TRENDING_WINDOW"); windowStr != "" {
+		parsed, err := time.ParseDuration(windowStr)
+		if err != nil {
+			fatal("invalid TRENDING_WINDOW", "error", err)
+		}
+		trendingWindow = parsed
+	}
+
+	// Index bills, versions, and actions into the full-text search
+	// backend after each cycle (OPENSEARCH_URL)
+	var searchIndexer *opensearch.Indexer
+	if client := opensearch.FromEnv(); client != nil {
+		searchIndexer = opensearch.NewIndexer(db, client, scopeRules.Query)
+	}
+
+	// Embed bills' summaries and sections for semantic search after each
+	// cycle (EMBEDDINGS_PROVIDER), up to EMBEDDINGS_BILLS_PER_CYCLE bills
+	var embedIndexer *embeddings.Indexer
+	embedLimit := embeddings.DefaultSyncLimit
+	embedder, err := embeddings.FromEnv()
+	if err != nil {
+		fatal("invalid embeddings configuration", "error", err)
+	}
+	if embedder != nil {
+		if err := embeddings.Migrate(db, embedder); err != nil {
+			fatal("failed to migrate embeddings", "error", err)
+		}
+		embedIndexer = embeddings.NewIndexer(db, embedder, scopeRules.Query)
+		if limitStr := os.Getenv("EMBEDDINGS_BILLS_PER_CYCLE"); limitStr != "" {
+			if parsed, err := strconv.Atoi(limitStr); err == nil {
+				embedLimit = parsed
+			}
+		}
+	}
+
+	// Fingerprint the sections of new versions after each cycle, up to
+	// PROVISIONS_VERSIONS_PER_CYCLE versions, for tracing provisions
+	provisionIndexer := provisions.NewIndexer(db, scopeRules.Query)
+	provisionLimit := provisions.DefaultSyncLimit
+	if limitStr := os.Getenv("PROVISIONS_VERSIONS_PER_CYCLE"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil {
+			provisionLimit = parsed
+		}
+	}
+
+	// Link bills to those of the previous Congress they reintroduce after
+	// each cycle, up to LINEAGE_BILLS_PER_CYCLE bills
+	lineageMatcher := lineage.NewMatcher(db, scopeRules.Query)
+	lineageLimit := lineage.DefaultMatchLimit
+	if limitStr := os.Getenv("LINEAGE_BILLS_PER_CYCLE"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil {
+			lineageLimit = parsed
+		}
+	}
+
+	// Parse the accounts of new appropriations bill versions after each
+	// cycle, up to ACCOUNTS_VERSIONS_PER_CYCLE versions
+	accountIndexer := appropriations.NewIndexer(db, scopeRules.Query)
+	accountLimit := appropriations.DefaultSyncLimit
+	if limitStr := os.Getenv("ACCOUNTS_VERSIONS_PER_CYCLE"); limitStr != "" {
+		if parsed, err := strconv.Atoi(limitStr); err == nil {
+			accountLimit = parsed
+		}
+	}
+
+	// Load ingestion targets (which congresses/types/keywords to track)
+	if *targetsSpec == "" {
+		*targetsSpec = os.Getenv("INGEST_TARGETS")
+	}
+	targets, err := ingestor.ParseTargets(*targetsSpec)
+	if err != nil {
+		fatal("invalid ingestion targets", "error", err)
+	}
+	ingestorSvc.SetTargets(targets)
+	for _, t := range targets {
+		slog.Info("tracking ingestion target", "target", t.String())
+	}
 
 	// Create context for graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -88,7 +384,7 @@ func main() {
 
 	go func() {
 		<-sigChan
-		log.Println("Shutdown signal received, stopping ingestor...")
+		slog.Info("shutdown signal received, stopping ingestor")
 		cancel()
 	}()
 
@@ -100,45 +396,307 @@ func main() {
 		appropriationsOnly: *appropriationsOnly,
 		limit:              *billLimit,
 		concurrency:        *concurrency,
-		parallel:           *parallel,
 	}
 
+	// Serve Prometheus metrics when an address is configured (e.g., ":9090")
+	if metricsAddr := os.Getenv("METRICS_ADDR"); metricsAddr != "" {
+		go func() {
+			mux := http.NewServeMux()
+			mux.Handle("/metrics", metrics.Handler())
+			slog.Info("serving metrics", "addr", metricsAddr, "path", "/metrics")
+			if err := http.ListenAndServe(metricsAddr, mux); err != nil {
+				slog.Error("metrics server stopped", "error", err)
+			}
+		}()
+	}
+
+	// Backfill mode: resumable full-congress walk, then exit
+	if *backfill {
+		cfg := ingestor.BackfillConfig{
+			Congress: *congressNum,
+			Delay:    *backfillDelay,
+			MaxBills: *backfillMax,
+			Restart:  *backfillRestart,
+		}
+		cfg.BillTypes = billTypes
+		if err := runBackfill(ctx, ingestorSvc, cfg); err != nil {
+			fatal("backfill failed", "error", err)
+		}
+		slog.Info("backfill complete, exiting")
+		return
+	}
+
+	// GovInfo mode: bulk text walk, then exit
+	if *govinfoMode {
+		if err := runGovInfo(ctx, ingestorSvc, govinfoCfg, "govinfo"); err != nil {
+			fatal("GovInfo ingestion failed", "error", err)
+		}
+		slog.Info("GovInfo ingestion complete, exiting")
+		return
+	}
+
+	// State mode: one state's recent bills, then exit
+	if *stateCode != "" {
+		cfg := stateCfg
+		cfg.State = strings.ToLower(*stateCode)
+		if err := runOpenStates(ctx, ingestorSvc, cfg, "openstates"); err != nil {
+			fatal("state ingestion failed", "error", err)
+		}
+		slog.Info("state ingestion complete, exiting")
+		return
+	}
+
+	// Rules mode: Federal Register rules, then exit
+	if *rulesMode {
+		if err := runRules(ctx, rulesSvc, rulesCfg); err != nil {
+			fatal("rule ingestion failed", "error", err)
+		}
+		slog.Info("rule ingestion complete, exiting")
+		return
+	}
+
+	// Reconcile mode: sample stored bills against Congress.gov, then exit
+	if *reconcileMode {
+		cfg := ingestor.ReconcileConfig{Sample: *reconcileSample, Threshold: *reconcileThreshold}
+		if err := runReconcile(ctx, ingestorSvc, cfg); err != nil {
+			fatal("reconciliation failed", "error", err)
+		}
+		slog.Info("reconciliation complete, exiting")
+		return
+	}
+
+	// Executive mode: treaties and nominations of one congress, then exit
+	if *executiveMode {
+		if err := runExecutive(ctx, ingestorSvc, *congressNum); err != nil {
+			fatal("executive sync failed", "error", err)
+		}
+		slog.Info("executive sync complete, exiting")
+		return
+	}
+
+	// With GovInfo as the text source, each run follows metadata with bulk text
+	textFromGovInfo := textSource == "govinfo"
+
 	// Single-run mode for Cloud Run Jobs
 	if *singleRun {
-		log.Println("DeltaGov Ingestor running in single-run mode...")
-		if err := runIngestion(ctx, ingestorSvc, ingestionCfg); err != nil {
-			log.Fatalf("Ingestion failed: %v", err)
+		slog.Info("DeltaGov Ingestor running in single-run mode")
+		if err := runIngestion(ctx, ingestorSvc, ingestionCfg, "single-run"); err != nil {
+			fatal("ingestion failed", "error", err)
 		}
-		log.Println("Single-run ingestion complete, exiting")
+		runRetries(ctx, ingestorSvc, "single-run")
+		runReingest(ctx, ingestorSvc, "single-run")
+		if textFromGovInfo {
+			if err := runGovInfo(ctx, ingestorSvc, govinfoCfg, "single-run"); err != nil {
+				fatal("GovInfo ingestion failed", "error", err)
+			}
+		}
+		for _, st := range states {
+			cfg := stateCfg
+			cfg.State = st
+			if err := runOpenStates(ctx, ingestorSvc, cfg, "single-run"); err != nil {
+				fatal("state ingestion failed", "state", st, "error", err)
+			}
+		}
+		if rulesSvc != nil {
+			if err := runRules(ctx, rulesSvc, rulesCfg); err != nil {
+				fatal("rule ingestion failed", "error", err)
+			}
+		}
+		runArchive(ctx, db, archivePolicy)
+		runTrending(ctx, db, trendingWindow)
+		runMemberStats(ctx, db)
+		runDashboardStats(ctx, db)
+		slog.Info("single-run ingestion complete, exiting")
 		return
 	}
 
 	// Continuous polling mode
-	log.Println("DeltaGov Ingestor starting in continuous mode...")
-	log.Printf("Polling Congress.gov API every %v", pollInterval)
+	slog.Info("DeltaGov Ingestor starting in continuous mode", "poll_interval", pollInterval.String(),
+		"hourly_budget", scheduleCfg.HourlyBudget, "schedule", scheduleStrings(scheduleCfg))
+	sched := schedule.New(scheduleCfg, congressClient.Requests, time.Now())
 
-	// Run initial poll
-	if err := runIngestion(ctx, ingestorSvc, ingestionCfg); err != nil {
-		log.Printf("Initial ingestion failed: %v", err)
+	// One polling cycle: bills, retries, re-ingestion jobs, popular diffs,
+	// bulk text, states, and rules, then archival, trending, member stats,
+	// dashboard stats, the search backend, embeddings, provision
+	// fingerprints, lineage, and appropriations accounts, ingesting up to
+	// limit recent bills. A run with filters only searches for bills.
+	runCycle := func(req runRequest, limit int) error {
+		if req.filters != nil {
+			cfg := ingestionCfg
+			cfg.searchMode = true
+			cfg.billType = req.filters.BillType
+			cfg.appropriationsOnly = req.filters.Appropriations
+			if req.filters.Congress > 0 {
+				cfg.congressNum = req.filters.Congress
+			}
+			if req.filters.Limit > 0 {
+				cfg.limit = req.filters.Limit
+			}
+			err := runIngestion(ctx, ingestorSvc, cfg, req.triggeredBy)
+			if err != nil {
+				slog.Error("ingestion failed", "triggered_by", req.triggeredBy, "error", err)
+			}
+			return err
+		}
+
+		cfg := ingestionCfg
+		cfg.limit = limit
+		err := runIngestion(ctx, ingestorSvc, cfg, req.triggeredBy)
+		if err != nil {
+			slog.Error("ingestion failed", "triggered_by", req.triggeredBy, "error", err)
+		}
+		runRetries(ctx, ingestorSvc, req.triggeredBy)
+		runReingest(ctx, ingestorSvc, req.triggeredBy)
+		runWarmDiffs(ctx, diffQueue)
+		if textFromGovInfo {
+			if err := runGovInfo(ctx, ingestorSvc, govinfoCfg, req.triggeredBy); err != nil {
+				slog.Error("GovInfo ingestion failed", "triggered_by", req.triggeredBy, "error", err)
+			}
+		}
+		runStates(ctx, ingestorSvc, stateCfg, states, req.triggeredBy)
+		if rulesSvc != nil {
+			if err := runRules(ctx, rulesSvc, rulesCfg); err != nil {
+				slog.Error("rule ingestion failed", "triggered_by", req.triggeredBy, "error", err)
+			}
+		}
+		runArchive(ctx, db, archivePolicy)
+		runTrending(ctx, db, trendingWindow)
+		runMemberStats(ctx, db)
+		runDashboardStats(ctx, db)
+		runSearchSync(ctx, searchIndexer)
+		runEmbeddings(ctx, embedIndexer, embedLimit)
+		runProvisions(ctx, provisionIndexer, provisionLimit)
+		runLineage(ctx, lineageMatcher, lineageLimit)
+		runAccounts(ctx, accountIndexer, accountLimit)
+		return err
+	}
+
+	// A scheduled watched or appropriations run refreshes just those
+	// bills; any other run is a polling cycle charged to recent. Runs
+	// start once the database is reachable.
+	cycle := func(req runRequest) error {
+		// Wait out a database outage, such as a Postgres restart, rather
+		// than failing every step of the run
+		if err := database.WaitHealthy(ctx, db, database.DefaultBackoff); err != nil {
+			return err
+		}
+
+		switch req.priority {
+		case schedule.Watched:
+			return sched.Run(ctx, schedule.Watched, func(ctx context.Context, budget int) error {
+				err := runWatched(ctx, ingestorSvc, billsWithin(budget, 0), req.triggeredBy)
+				if err != nil {
+					slog.Error("watched bill refresh failed", "triggered_by", req.triggeredBy, "error", err)
+				}
+				return err
+			})
+		case schedule.Appropriations:
+			return sched.Run(ctx, schedule.Appropriations, func(ctx context.Context, budget int) error {
+				cfg := ingestionCfg
+				cfg.searchMode, cfg.billType, cfg.appropriationsOnly = true, "", true
+				cfg.limit = billsWithin(budget, cfg.limit)
+				err := runIngestion(ctx, ingestorSvc, cfg, req.triggeredBy)
+				if err != nil {
+					slog.Error("appropriations ingestion failed", "triggered_by", req.triggeredBy, "error", err)
+				}
+				return err
+			})
+		}
+
+		return sched.Run(ctx, schedule.Recent, func(ctx context.Context, budget int) error {
+			return runCycle(req, billsWithin(budget, ingestionCfg.limit))
+		})
 	}
 
-	// Start polling loop
-	ticker := time.NewTicker(pollInterval)
-	defer ticker.Stop()
+	// Optional HTTP admin endpoints for status, on-demand runs, and pausing
+	mode := "recent"
+	if ingestionCfg.searchMode {
+		mode = "search"
+	}
+	control := newController(os.Getenv("INGESTOR_ADMIN_TOKEN"), controlConfig{
+		PollInterval:   pollInterval.String(),
+		Schedule:       scheduleStrings(scheduleCfg),
+		HourlyBudget:   scheduleCfg.HourlyBudget,
+		Mode:           mode,
+		Congress:       ingestionCfg.congressNum,
+		BillType:       ingestionCfg.billType,
+		Appropriations: ingestionCfg.appropriationsOnly,
+		Limit:          ingestionCfg.limit,
+		Concurrency:    ingestionCfg.concurrency,
+		Targets:        targetStrings(targets),
+		TextSource:     cmp.Or(textSource, "congress"),
+		States:         states,
+		Rules:          rulesSvc != nil,
+	})
+	if strictDecoding {
+		control.drift = congressClient.DriftReport
+	}
+	if adminAddr := os.Getenv("INGESTOR_ADMIN_ADDR"); adminAddr != "" {
+		if control.token == "" {
+			fatal("INGESTOR_ADMIN_ADDR requires INGESTOR_ADMIN_TOKEN")
+		}
+		go control.Serve(ctx, adminAddr)
+	}
 
+	// Run initial poll
+	control.Run(runRequest{triggeredBy: "startup"}, cycle)
+
+	// Polling loop: each priority when the scheduler says, and on-demand
+	// runs in between
 	for {
+		priority, at := sched.Next(time.Now())
+		control.SetNextRun(at)
+		timer := time.NewTimer(time.Until(at))
+
 		select {
 		case <-ctx.Done():
-			log.Println("Ingestor stopped")
+			timer.Stop()
+			slog.Info("ingestor stopped")
 			return
-		case <-ticker.C:
-			if err := runIngestion(ctx, ingestorSvc, ingestionCfg); err != nil {
-				log.Printf("Ingestion failed: %v", err)
+		case <-timer.C:
+			if control.Paused() {
+				slog.Info("scheduled ingestion run skipped, ingestor is paused", "priority", priority)
+				sched.Skip(priority, time.Now())
+				continue
 			}
+			control.Run(runRequest{triggeredBy: "schedule", priority: priority}, cycle)
+		case req := <-control.Requests():
+			timer.Stop()
+			control.Run(req, cycle)
 		}
 	}
 }
 
+// requestsPerBill estimates the Congress.gov requests ingesting a changed
+// bill costs: its detail, text versions, related bills, summaries,
+// subjects, titles, and cosponsors, plus its share of listing requests.
+const requestsPerBill = 8
+
+// billsWithin returns how many bills a run may ingest on a budget of
+// Congress.gov requests, at most limit unless limit is 0. A run always
+// gets at least one bill; overspending delays the priority's next run.
+func billsWithin(budget, limit int) int {
+	if budget == schedule.Unlimited {
+		return limit
+	}
+	n := max(budget/requestsPerBill, 1)
+	if limit > 0 {
+		n = min(n, limit)
+	}
+	return n
+}
+
+// scheduleStrings describes each priority's schedule, for logs and /status.
+func scheduleStrings(cfg schedule.Config) []string {
+	var out []string
+	for _, p := range schedule.Priorities {
+		if q, ok := cfg.Queues[p]; ok {
+			out = append(out, fmt.Sprintf("%s every %s, %.0f%% of budget reserved", p, q.Interval, q.Share*100))
+		}
+	}
+	return out
+}
+
 // ingestionConfig holds the configuration for an ingestion run.
 type ingestionConfig struct {
 	searchMode         bool
@@ -147,51 +705,452 @@ type ingestionConfig struct {
 	appropriationsOnly bool
 	limit              int
 	concurrency        int
-	parallel           bool
 }
 
-// runIngestion performs a single ingestion run.
-func runIngestion(ctx context.Context, svc *ingestor.Service, cfg ingestionConfig) error {
-	var result *ingestor.IngestResult
-	var err error
+// runIngestion performs a single ingestion run and records it as an IngestRun.
+// triggeredBy describes what started the run (e.g., "schedule", "single-run").
+func runIngestion(ctx context.Context, svc *ingestor.Service, cfg ingestionConfig, triggeredBy string) error {
+	// Each run gets its own correlation ID so its log lines can be grouped
+	ctx = logging.WithRequestID(ctx, logging.NewRequestID())
+	logger := logging.FromContext(ctx)
+
+	var mode string
+	var run ingestor.RunFunc
 
 	if cfg.searchMode {
 		// Search-based ingestion
-		log.Printf("Starting search-based ingestion (congress=%d, type=%s, appropriations=%v, limit=%d, concurrency=%d)...",
-			cfg.congressNum, cfg.billType, cfg.appropriationsOnly, cfg.limit, cfg.concurrency)
-
-		result, err = svc.IngestFromSearch(ctx, ingestor.SearchIngestConfig{
-			Congress:         cfg.congressNum,
-			BillType:         cfg.billType,
-			IsAppropriations: cfg.appropriationsOnly,
-			Limit:            cfg.limit,
-			Concurrency:      cfg.concurrency,
-		})
-	} else if cfg.parallel {
-		// Recent bills with parallel processing
-		log.Printf("Starting parallel ingestion (limit=%d, concurrency=%d)...", cfg.limit, cfg.concurrency)
-		result, err = svc.IngestRecentBillsParallel(ctx, cfg.limit, cfg.concurrency)
+		logger.Info("starting search-based ingestion",
+			"triggered_by", triggeredBy, "congress", cfg.congressNum, "bill_type", cfg.billType,
+			"appropriations", cfg.appropriationsOnly, "limit", cfg.limit, "concurrency", cfg.concurrency)
+
+		mode = "search"
+		run = func(ctx context.Context) (*ingestor.IngestResult, error) {
+			return svc.IngestFromSearch(ctx, ingestor.SearchIngestConfig{
+				Congress:         cfg.congressNum,
+				BillType:         cfg.billType,
+				IsAppropriations: cfg.appropriationsOnly,
+				Limit:            cfg.limit,
+				Concurrency:      cfg.concurrency,
+			})
+		}
 	} else {
-		// Original sequential recent bills mode
-		log.Printf("Starting ingestion run (limit=%d)...", cfg.limit)
-		result, err = svc.IngestRecentBills(ctx, cfg.limit)
+		logger.Info("starting ingestion run",
+			"triggered_by", triggeredBy, "limit", cfg.limit, "concurrency", cfg.concurrency)
+		mode = "recent"
+		run = func(ctx context.Context) (*ingestor.IngestResult, error) {
+			return svc.IngestRecentBills(ctx, cfg.limit)
+		}
 	}
 
+	result, err := svc.RecordRun(ctx, triggeredBy, mode, run)
+
 	if err != nil {
 		return err
 	}
 
-	log.Printf("Ingestion complete: fetched=%d, created=%d, updated=%d, versions=%d, errors=%d",
-		result.BillsFetched,
-		result.BillsCreated,
-		result.BillsUpdated,
-		result.VersionsCreated,
-		len(result.Errors))
+	logger.Info("ingestion complete",
+		"fetched", result.BillsFetched,
+		"skipped", result.BillsSkipped,
+		"created", result.BillsCreated,
+		"updated", result.BillsUpdated,
+		"versions", result.VersionsCreated,
+		"errors", len(result.Errors))
 
 	// Log any errors
 	for _, e := range result.Errors {
-		log.Printf("  Error: %v", e)
+		logger.Warn("ingestion error", "error", e)
+	}
+
+	return nil
+}
+
+// runRetries retries the dead-lettered bills that are due, recording the
+// pass as an IngestRun when there are any. Failures are logged rather than
+// returned so they don't stop polling.
+func runRetries(ctx context.Context, svc *ingestor.Service, triggeredBy string) {
+	ctx = logging.WithRequestID(ctx, logging.NewRequestID())
+	logger := logging.FromContext(ctx)
+
+	due, err := svc.DueFailures(ctx)
+	if err != nil {
+		logger.Error("failed to check for ingestion retries", "error", err)
+		return
+	}
+	if due == 0 {
+		return
+	}
+
+	logger.Info("retrying failed ingestions", "triggered_by", triggeredBy, "due", due)
+	result, err := svc.RecordRun(ctx, triggeredBy, "retry", func(ctx context.Context) (*ingestor.IngestResult, error) {
+		return svc.RetryFailures(ctx, ingestor.DefaultRetryBatch)
+	})
+	if err != nil {
+		logger.Error("ingestion retries failed", "error", err)
+		return
+	}
+	logger.Info("ingestion retries complete",
+		"retried", result.BillsFetched, "versions", result.VersionsCreated, "errors", len(result.Errors))
+}
+
+// runWatched refreshes up to limit bills on users' watchlists (all of
+// them when limit is 0), recorded as a "watched" run. Unlike the other
+// passes its error is returned, as it is the whole of a scheduled run.
+func runWatched(ctx context.Context, svc *ingestor.Service, limit int, triggeredBy string) error {
+	ctx = logging.WithRequestID(ctx, logging.NewRequestID())
+	logger := logging.FromContext(ctx)
+
+	logger.Info("refreshing watched bills", "triggered_by", triggeredBy, "limit", limit)
+	result, err := svc.RecordRun(ctx, triggeredBy, "watched", func(ctx context.Context) (*ingestor.IngestResult, error) {
+		return svc.RefreshWatchedBills(ctx, limit)
+	})
+	if err != nil {
+		return err
+	}
+	for _, e := range result.Errors {
+		logger.Warn("watched bill refresh error", "error", e)
 	}
+	return nil
+}
+
+// runReingest refreshes the next batch of bills of operator-queued
+// re-ingestion jobs, recorded as a "reingest" run when there are any.
+func runReingest(ctx context.Context, svc *ingestor.Service, triggeredBy string) {
+	ctx = logging.WithRequestID(ctx, logging.NewRequestID())
+	logger := logging.FromContext(ctx)
+
+	pending, err := svc.PendingReingestJobs(ctx)
+	if err != nil {
+		logger.Error("failed to check for reingest jobs", "error", err)
+		return
+	}
+	if pending == 0 {
+		return
+	}
+
+	logger.Info("re-ingesting bills", "triggered_by", triggeredBy, "jobs", pending)
+	result, err := svc.RecordRun(ctx, triggeredBy, "reingest", func(ctx context.Context) (*ingestor.IngestResult, error) {
+		return svc.ReingestBills(ctx, ingestor.DefaultReingestBatch)
+	})
+	if err != nil {
+		logger.Error("re-ingestion failed", "error", err)
+		return
+	}
+	logger.Info("re-ingestion batch complete",
+		"bills", result.BillsFetched, "versions", result.VersionsCreated, "errors", len(result.Errors))
+}
+
+// runWarmDiffs queues the most requested diffs that aren't cached, when
+// diffs are precomputed. Failures are logged.
+func runWarmDiffs(ctx context.Context, q *deltas.Queue) {
+	if q == nil {
+		return
+	}
+	if _, err := q.WarmPopular(ctx, deltas.DefaultWarmLimit); err != nil {
+		slog.Error("failed to queue popular diffs", "error", err)
+	}
+}
+
+// runArchive applies the text archival policy, logging rather than
+// returning failures so they don't stop polling.
+func runArchive(ctx context.Context, db *gorm.DB, policy archive.Policy) {
+	if !policy.Enabled() {
+		return
+	}
+	if _, err := archive.Run(ctx, db, policy); err != nil {
+		slog.Error("archival failed", "error", err)
+	}
+}
+
+// runTrending recomputes bill activity scores over the window, logging
+// rather than returning failures so they don't stop polling.
+func runTrending(ctx context.Context, db *gorm.DB, window time.Duration) {
+	if window <= 0 {
+		return
+	}
+	if _, err := trending.Run(ctx, db, window); err != nil {
+		slog.Error("trending scoring failed", "error", err)
+	}
+}
+
+// runMemberStats rebuilds member sponsorship aggregates, logging rather
+// than returning failures so they don't stop polling.
+func runMemberStats(ctx context.Context, db *gorm.DB) {
+	if _, err := memberstats.Run(ctx, db); err != nil {
+		slog.Error("member stats refresh failed", "error", err)
+	}
+}
+
+// runDashboardStats refreshes the dashboard's materialized views, logging
+// rather than returning failures so they don't stop polling.
+func runDashboardStats(ctx context.Context, db *gorm.DB) {
+	if err := stats.Refresh(ctx, db); err != nil {
+		slog.Error("dashboard stats refresh failed", "error", err)
+	}
+}
+
+// runSearchSync indexes what changed into the search backend, when one is
+// configured, logging rather than returning failures so they don't stop
+// polling. What a failed sync missed is picked up by the next.
+func runSearchSync(ctx context.Context, indexer *opensearch.Indexer) {
+	if indexer == nil {
+		return
+	}
+	if _, err := indexer.Sync(ctx); err != nil {
+		slog.Error("search backend sync failed", "error", err)
+	}
+}
+
+// runEmbeddings embeds bills that changed, when embeddings are enabled,
+// logging rather than returning failures so they don't stop polling.
+func runEmbeddings(ctx context.Context, indexer *embeddings.Indexer, limit int) {
+	if indexer == nil {
+		return
+	}
+	if _, err := indexer.Sync(ctx, limit); err != nil {
+		slog.Error("embedding bills failed", "error", err)
+	}
+}
+
+// runProvisions fingerprints the sections of versions not yet
+// fingerprinted, logging rather than returning failures so they don't
+// stop polling.
+func runProvisions(ctx context.Context, indexer *provisions.Indexer, limit int) {
+	if _, err := indexer.Sync(ctx, limit); err != nil {
+		slog.Error("fingerprinting provisions failed", "error", err)
+	}
+}
+
+// runLineage links bills that changed to their predecessors, after
+// provisions so new text counts, logging rather than returning failures
+// so they don't stop polling.
+func runLineage(ctx context.Context, matcher *lineage.Matcher, limit int) {
+	if _, err := matcher.Match(ctx, limit); err != nil {
+		slog.Error("lineage matching failed", "error", err)
+	}
+}
+
+// runAccounts parses the accounts of appropriations bill versions not yet
+// parsed, logging rather than returning failures so they don't stop
+// polling.
+func runAccounts(ctx context.Context, indexer *appropriations.Indexer, limit int) {
+	if _, err := indexer.Sync(ctx, limit); err != nil {
+		slog.Error("parsing appropriations accounts failed", "error", err)
+	}
+}
+
+// cleanups are run, last registered first, when main returns or fatal
+// exits, which skips deferred calls.
+var cleanups []func()
+
+// atExit registers fn to run when main returns or fatal exits.
+func atExit(fn func()) {
+	cleanups = append(cleanups, fn)
+}
+
+// runCleanups runs the functions registered with atExit, once.
+func runCleanups() {
+	for len(cleanups) > 0 {
+		fn := cleanups[len(cleanups)-1]
+		cleanups = cleanups[:len(cleanups)-1]
+		fn()
+	}
+}
+
+// fatal logs msg at error level, runs the atExit cleanups, and exits with
+// a non-zero status.
+func fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	runCleanups()
+	os.Exit(1)
+}
+
+// runBackfill runs a historical backfill and records it as an IngestRun.
+func runBackfill(ctx context.Context, svc *ingestor.Service, cfg ingestor.BackfillConfig) error {
+	ctx = logging.WithRequestID(ctx, logging.NewRequestID())
+	logger := logging.FromContext(ctx)
+
+	logger.Info("starting backfill",
+		"congress", cfg.Congress, "bill_types", cfg.BillTypes,
+		"delay", cfg.Delay.String(), "max_bills", cfg.MaxBills, "restart", cfg.Restart)
+
+	result, err := svc.RecordRun(ctx, "backfill", "backfill", func(ctx context.Context) (*ingestor.IngestResult, error) {
+		return svc.Backfill(ctx, cfg)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("backfill run finished",
+		"fetched", result.BillsFetched,
+		"skipped", result.BillsSkipped,
+		"created", result.BillsCreated,
+		"updated", result.BillsUpdated,
+		"versions", result.VersionsCreated,
+		"errors", len(result.Errors))
+
+	return nil
+}
+
+// runGovInfo ingests GovInfo bulk text and records it as an IngestRun.
+func runGovInfo(ctx context.Context, svc *ingestor.Service, cfg ingestor.GovInfoConfig, triggeredBy string) error {
+	ctx = logging.WithRequestID(ctx, logging.NewRequestID())
+	logger := logging.FromContext(ctx)
+
+	logger.Info("starting GovInfo ingestion",
+		"triggered_by", triggeredBy, "congress", cfg.Congress, "bill_types", cfg.BillTypes)
+
+	result, err := svc.RecordRun(ctx, triggeredBy, "govinfo", func(ctx context.Context) (*ingestor.IngestResult, error) {
+		return svc.IngestGovInfo(ctx, cfg)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("GovInfo ingestion complete",
+		"bills", result.BillsFetched,
+		"skipped", result.BillsSkipped,
+		"created", result.BillsCreated,
+		"versions", result.VersionsCreated,
+		"errors", len(result.Errors))
+	for _, e := range result.Errors {
+		logger.Warn("ingestion error", "error", e)
+	}
+
+	return nil
+}
+
+// runStates ingests each configured state in turn, logging rather than
+// returning failures so one state doesn't stop the others or polling.
+func runStates(ctx context.Context, svc *ingestor.Service, base ingestor.OpenStatesConfig, states []string, triggeredBy string) {
+	for _, st := range states {
+		cfg := base
+		cfg.State = st
+		if err := runOpenStates(ctx, svc, cfg, triggeredBy); err != nil {
+			slog.Error("state ingestion failed", "state", st, "error", err)
+		}
+	}
+}
+
+// runOpenStates ingests one state's bills from Open States and records it
+// as an IngestRun.
+func runOpenStates(ctx context.Context, svc *ingestor.Service, cfg ingestor.OpenStatesConfig, triggeredBy string) error {
+	ctx = logging.WithRequestID(ctx, logging.NewRequestID())
+	logger := logging.FromContext(ctx)
+
+	logger.Info("starting state ingestion",
+		"triggered_by", triggeredBy, "state", cfg.State, "session", cfg.Session, "query", cfg.Query, "limit", cfg.Limit)
+
+	result, err := svc.RecordRun(ctx, triggeredBy, "openstates", func(ctx context.Context) (*ingestor.IngestResult, error) {
+		return svc.IngestOpenStates(ctx, cfg)
+	})
+	if err != nil {
+		return err
+	}
+
+	logger.Info("state ingestion complete",
+		"state", cfg.State,
+		"fetched", result.BillsFetched,
+		"created", result.BillsCreated,
+		"updated", result.BillsUpdated,
+		"versions", result.VersionsCreated,
+		"errors", len(result.Errors))
+	for _, e := range result.Errors {
+		logger.Warn("ingestion error", "error", e)
+	}
+
+	return nil
+}
+
+// runReconcile checks a sample of stored bills against Congress.gov,
+// logging the outcome.
+func runReconcile(ctx context.Context, svc *ingestor.Service, cfg ingestor.ReconcileConfig) error {
+	ctx = logging.WithRequestID(ctx, logging.NewRequestID())
+	logger := logging.FromContext(ctx)
+
+	logger.Info("starting reconciliation", "sample", cfg.Sample, "threshold", cfg.Threshold)
+
+	run, err := svc.Reconcile(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("reconciliation complete",
+		"run_id", run.ID,
+		"checked", run.BillsChecked,
+		"drifted", run.BillsDrifted,
+		"discrepancies", run.Discrepancies,
+		"drift_rate", run.DriftRate,
+		"alerted", run.Alerted,
+		"errors", len(run.Errors))
+	for _, e := range run.Errors {
+		logger.Warn("reconciliation error", "error", e)
+	}
+
+	return nil
+}
+
+// runExecutive syncs a congress's treaties and nominations, logging the
+// outcome.
+func runExecutive(ctx context.Context, svc *ingestor.Service, congressNum int) error {
+	ctx = logging.WithRequestID(ctx, logging.NewRequestID())
+	logger := logging.FromContext(ctx)
+
+	logger.Info("starting executive sync", "congress", congressNum)
+
+	result, err := svc.SyncExecutive(ctx, congressNum)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("executive sync complete",
+		"congress", congressNum,
+		"treaties", result.Treaties,
+		"nominations", result.Nominations,
+		"failed", result.Failed)
 
 	return nil
 }
+
+// runRules ingests Federal Register rules, logging the outcome.
+func runRules(ctx context.Context, svc *regulations.Service, cfg regulations.Config) error {
+	ctx = logging.WithRequestID(ctx, logging.NewRequestID())
+	logger := logging.FromContext(ctx)
+
+	logger.Info("starting rule ingestion",
+		"agencies", cfg.Agencies, "keywords", cfg.Keywords, "limit", cfg.Limit)
+
+	result, err := svc.Ingest(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("rule ingestion complete",
+		"fetched", result.DocumentsFetched,
+		"created", result.RulesCreated,
+		"errors", len(result.Errors))
+	for _, e := range result.Errors {
+		logger.Warn("rule ingestion error", "error", e)
+	}
+
+	return nil
+}
+
+// targetStrings returns the targets in their INGEST_TARGETS form.
+func targetStrings(targets []ingestor.Target) []string {
+	specs := make([]string, len(targets))
+	for i, t := range targets {
+		specs[i] = t.String()
+	}
+	return specs
+}
+
+// splitList splits a comma-separated list, dropping blank entries.
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
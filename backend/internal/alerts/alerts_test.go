@@ -0,0 +1,134 @@
+package alerts_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/alerts"
+)
+
+const billText = `A BILL
+To expand broadband access.
+SECTION 1. SHORT TITLE.
+This Act may be cited as the Rural Connectivity Act.
+SEC. 2. UNIVERSAL
+SERVICE FUND.
+The universal
+service fund shall support broadband deployment in rural areas.
+SEC. 3. TARIFFS.
+No tariff shall apply to spectrum equipment.
+`
+
+// TestQuery_Match verifies boolean operators, phrases, and wildcards.
+func TestQuery_Match(t *testing.T) {
+	tests := []struct {
+		query string
+		want  bool
+	}{
+		{"broadband", true},
+		{"BROADBAND", true},
+		{"broad", false}, // Whole words only
+		{"broadband AND rural", true},
+		{"broadband rural", true}, // Implicit AND
+		{"broadband AND satellite", false},
+		{"broadband OR satellite", true},
+		{"satellite OR fiber", false},
+		{`"universal service fund"`, true}, // Across a line break
+		{`"service universal"`, false},
+		{"broadband NOT satellite", true},
+		{"broadband NOT rural", false},
+		{"(satellite OR spectrum) AND tariff", true},
+		{"satellite OR spectrum AND fiber", false}, // AND binds tighter
+		{"tarif*", true},
+		{"tariffs*", true},
+		{"and", false}, // Lowercase operators are words
+	}
+	for _, tt := range tests {
+		q, err := alerts.Parse(tt.query)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", tt.query, err)
+			continue
+		}
+		if _, got := q.Match(billText); got != tt.want {
+			t.Errorf("Match(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+// TestQuery_Snippets verifies snippets carry the hit, its section anchor,
+// and no hits of negated terms.
+func TestQuery_Snippets(t *testing.T) {
+	q, err := alerts.Parse(`"universal service" AND rural NOT satellite`)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	snippets, ok := q.Match(billText)
+	if !ok {
+		t.Fatal("expected a match")
+	}
+
+	var terms []string
+	for _, s := range snippets {
+		terms = append(terms, s.Term)
+	}
+	// SEC. 2's heading, its body, "rural" in its body, and "Rural" in SEC. 1
+	if got, want := strings.Join(terms, ","), "rural,universal service,universal service,rural"; got != want {
+		t.Fatalf("snippet terms = %s, want %s", got, want)
+	}
+
+	first := snippets[0]
+	if first.Section != "1" || !strings.HasPrefix(first.Anchor, "sec-1-") {
+		t.Errorf("first snippet section = %q, anchor = %q", first.Section, first.Anchor)
+	}
+	if !strings.Contains(first.Text, "Rural Connectivity Act") {
+		t.Errorf("first snippet = %q", first.Text)
+	}
+	body := snippets[2]
+	if body.Section != "2" || !strings.HasPrefix(body.Anchor, "sec-2-") {
+		t.Errorf("body snippet section = %q, anchor = %q", body.Section, body.Anchor)
+	}
+	if strings.Contains(body.Text, "\n") || !strings.Contains(body.Text, "The universal service fund") {
+		t.Errorf("body snippet = %q, want collapsed whitespace", body.Text)
+	}
+}
+
+// TestQuery_SnippetLimit verifies snippets are capped and cut with ellipses.
+func TestQuery_SnippetLimit(t *testing.T) {
+	text := strings.Repeat("Funds for broadband deployment in every county of the State, as the Secretary determines. ", 20)
+	q, err := alerts.Parse("broadband")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	snippets, ok := q.Match(text)
+	if !ok || len(snippets) != alerts.MaxSnippets {
+		t.Fatalf("got %d snippets, want %d", len(snippets), alerts.MaxSnippets)
+	}
+	if s := snippets[5].Text; !strings.HasPrefix(s, "…") || !strings.HasSuffix(s, "…") {
+		t.Errorf("snippet = %q, want ellipses on both ends", s)
+	}
+	if snippets[0].Section != "" {
+		t.Errorf("snippet outside any section has section %q", snippets[0].Section)
+	}
+}
+
+// TestParse_Invalid verifies malformed queries are rejected.
+func TestParse_Invalid(t *testing.T) {
+	for _, query := range []string{
+		"",
+		"   ",
+		"broadband AND",
+		"(broadband OR rural",
+		"broadband)",
+		`"universal service`,
+		`""`,
+		"NOT satellite", // Matches nearly every bill
+		"broadband OR NOT satellite",
+		"br*",
+		strings.Repeat("word ", alerts.MaxTerms+1),
+		strings.Repeat("x", alerts.MaxQueryLength+1),
+	} {
+		if _, err := alerts.Parse(query); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", query)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package alerts
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/drewjst/deltagov/internal/analysis"
+)
+
+// Snippet limits: how many are reported per match, and how much text
+// surrounds each hit.
+const (
+	MaxSnippets    = 10
+	snippetContext = 80 // Bytes on each side of the hit
+)
+
+// Snippet is one occurrence of a query term in the text.
+type Snippet struct {
+	Term    string `json:"term"`              // The query term, as written
+	Text    string `json:"text"`              // The hit with surrounding text, whitespace collapsed
+	Section string `json:"section,omitempty"` // Section number, when the hit is in a numbered section
+	Anchor  string `json:"anchor,omitempty"`  // Stable section anchor, e.g., "sec-201-3f2a9c1b"
+	Offset  int    `json:"offset"`            // Byte offset of the hit in the text
+}
+
+// Match evaluates the query against plain bill text. When it matches, it
+// returns a snippet per occurrence of the terms that made it match (not
+// those under a NOT), in text order, up to MaxSnippets.
+func (q *Query) Match(text string) ([]Snippet, bool) {
+	words := splitWords(text)
+	hits := make(map[*term][]int, len(q.terms)) // Byte ranges of each term's occurrences, start and end pairs
+	for _, t := range q.terms {
+		hits[t] = t.find(words)
+	}
+	if !q.root.eval(func(t *term) bool { return len(hits[t]) > 0 }) {
+		return nil, false
+	}
+
+	type hit struct {
+		term       *term
+		start, end int
+	}
+	var all []hit
+	for _, t := range q.terms {
+		if t.negated {
+			continue
+		}
+		for i := 0; i < len(hits[t]); i += 2 {
+			all = append(all, hit{t, hits[t][i], hits[t][i+1]})
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].start < all[j].start })
+
+	sections := analysis.SplitSections(text)
+	snippets := make([]Snippet, 0, min(len(all), MaxSnippets))
+	for _, h := range all {
+		if len(snippets) == MaxSnippets {
+			break
+		}
+		snippet := Snippet{Term: h.term.text, Text: snippetText(text, h.start, h.end), Offset: h.start}
+		if s := sectionAt(sections, h.start); s != nil {
+			snippet.Section = s.Number
+			snippet.Anchor = s.Anchor()
+		}
+		snippets = append(snippets, snippet)
+	}
+	return snippets, true
+}
+
+// word is a word of text, lowercased, with its byte range.
+type word struct {
+	text       string
+	start, end int
+}
+
+// splitWords splits text into runs of letters and digits.
+func splitWords(text string) []word {
+	var words []word
+	start := -1
+	for i, r := range text {
+		inWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		switch {
+		case inWord && start < 0:
+			start = i
+		case !inWord && start >= 0:
+			words = append(words, word{strings.ToLower(text[start:i]), start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		words = append(words, word{strings.ToLower(text[start:]), start, len(text)})
+	}
+	return words
+}
+
+// find returns the byte ranges of the term's occurrences in words, as
+// start and end pairs.
+func (t *term) find(words []word) []int {
+	var ranges []int
+	last := len(t.words) - 1
+	for i := 0; i+last < len(words); i++ {
+		matched := true
+		for j, w := range t.words {
+			got := words[i+j].text
+			if got != w && !(t.prefix && j == last && strings.HasPrefix(got, w)) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			ranges = append(ranges, words[i].start, words[i+last].end)
+		}
+	}
+	return ranges
+}
+
+// snippetText returns the text around a hit, cut at spaces, with
+// whitespace collapsed and ellipses where it was cut.
+func snippetText(text string, start, end int) string {
+	from := max(0, start-snippetContext)
+	to := min(len(text), end+snippetContext)
+	if from > 0 {
+		if i := strings.IndexAny(text[from:start], " \t\n"); i >= 0 {
+			from += i + 1
+		}
+	}
+	if to < len(text) {
+		if i := strings.LastIndexAny(text[end:to], " \t\n"); i >= 0 {
+			to = end + i
+		}
+	}
+	snippet := strings.Join(strings.Fields(text[from:to]), " ")
+	if from > 0 {
+		snippet = "…" + snippet
+	}
+	if to < len(text) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// sectionAt returns the section containing a byte offset, or nil if it is
+// before the first section.
+func sectionAt(sections []analysis.Section, offset int) *analysis.Section {
+	i := sort.Search(len(sections), func(i int) bool { return sections[i].End > offset })
+	if i < len(sections) && sections[i].Start <= offset {
+		return &sections[i]
+	}
+	return nil
+}
@@ -0,0 +1,274 @@
+// Package alerts evaluates keyword alert queries against bill text.
+//
+// A query combines words and "quoted phrases" with AND, OR, NOT, and
+// parentheses; adjacent terms are ANDed, and AND binds tighter than OR:
+//
+//	broadband AND rural
+//	"universal service" OR (spectrum NOT auction)
+//
+// Terms match whole words, case-insensitively, and phrases match their
+// words in order regardless of line breaks between them. A trailing * on a
+// word matches any word it prefixes, e.g., tarif*.
+package alerts
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Query limits keep evaluation cheap when every new version is checked
+// against every alert.
+const (
+	MaxQueryLength = 500
+	MaxTerms       = 20
+)
+
+// ErrEmptyQuery is returned for a query without terms.
+var ErrEmptyQuery = errors.New("query has no terms")
+
+// Query is a parsed alert query.
+type Query struct {
+	root  node
+	terms []*term // Every term, in query order
+}
+
+// node is an expression in a query.
+type node interface {
+	eval(present func(*term) bool) bool
+}
+
+type (
+	andNode struct{ left, right node }
+	orNode  struct{ left, right node }
+	notNode struct{ operand node }
+)
+
+func (n andNode) eval(present func(*term) bool) bool {
+	return n.left.eval(present) && n.right.eval(present)
+}
+
+func (n orNode) eval(present func(*term) bool) bool {
+	return n.left.eval(present) || n.right.eval(present)
+}
+
+func (n notNode) eval(present func(*term) bool) bool {
+	return !n.operand.eval(present)
+}
+
+// term is a word or phrase: the words of the text it matches, lowercased.
+// A prefix term's last word matches any word starting with it.
+type term struct {
+	text    string // As written in the query, without quotes
+	words   []string
+	prefix  bool
+	negated bool // Under a NOT; its matches aren't reported
+}
+
+func (t *term) eval(present func(*term) bool) bool {
+	return present(t)
+}
+
+// Parse parses an alert query.
+func Parse(query string) (*Query, error) {
+	if len(query) > MaxQueryLength {
+		return nil, fmt.Errorf("query is longer than %d characters", MaxQueryLength)
+	}
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, ErrEmptyQuery
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.or(false)
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("unexpected %s", p.tokens[p.pos])
+	}
+	if len(p.terms) > MaxTerms {
+		return nil, fmt.Errorf("query has more than %d terms", MaxTerms)
+	}
+
+	q := &Query{root: root, terms: p.terms}
+	if q.root.eval(func(*term) bool { return false }) {
+		return nil, errors.New("query matches text without any of its terms; add a term outside NOT")
+	}
+	return q, nil
+}
+
+// String returns the query's terms, for logging.
+func (q *Query) String() string {
+	texts := make([]string, len(q.terms))
+	for i, t := range q.terms {
+		texts[i] = t.text
+	}
+	return strings.Join(texts, ", ")
+}
+
+// token is a query token: an operator, a parenthesis, or a term.
+type token struct {
+	kind string // "AND", "OR", "NOT", "(", ")", "word", or "phrase"
+	text string
+}
+
+func (t token) String() string {
+	if t.kind == "word" || t.kind == "phrase" {
+		return fmt.Sprintf("%q", t.text)
+	}
+	return t.kind
+}
+
+// tokenize splits a query into tokens. Operators must be uppercase, so
+// "and" and "or" are searched for as words.
+func tokenize(query string) ([]token, error) {
+	var tokens []token
+	runes := []rune(query)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(' || r == ')':
+			tokens = append(tokens, token{kind: string(r)})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, errors.New("unterminated phrase")
+			}
+			tokens = append(tokens, token{kind: "phrase", text: string(runes[i+1 : end])})
+			i = end + 1
+		default:
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && !strings.ContainsRune(`()"`, runes[end]) {
+				end++
+			}
+			text := string(runes[i:end])
+			switch text {
+			case "AND", "OR", "NOT":
+				tokens = append(tokens, token{kind: text})
+			default:
+				tokens = append(tokens, token{kind: "word", text: text})
+			}
+			i = end
+		}
+	}
+	return tokens, nil
+}
+
+// parser is a recursive descent parser over query tokens.
+type parser struct {
+	tokens []token
+	pos    int
+	terms  []*term
+}
+
+func (p *parser) peek() string {
+	if p.pos < len(p.tokens) {
+		return p.tokens[p.pos].kind
+	}
+	return ""
+}
+
+// or parses and-expressions separated by OR.
+func (p *parser) or(negated bool) (node, error) {
+	left, err := p.and(negated)
+	if err != nil {
+		return nil, err
+	}
+	for p.peek() == "OR" {
+		p.pos++
+		right, err := p.and(negated)
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+// and parses unary expressions separated by AND or nothing.
+func (p *parser) and(negated bool) (node, error) {
+	left, err := p.unary(negated)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		switch p.peek() {
+		case "AND":
+			p.pos++
+		case "NOT", "(", "word", "phrase":
+		default:
+			return left, nil
+		}
+		right, err := p.unary(negated)
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+}
+
+// unary parses a NOT, a parenthesized expression, or a term.
+func (p *parser) unary(negated bool) (node, error) {
+	if p.pos == len(p.tokens) {
+		return nil, errors.New("query ends where a term was expected")
+	}
+	tok := p.tokens[p.pos]
+	p.pos++
+	switch tok.kind {
+	case "NOT":
+		operand, err := p.unary(!negated)
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand}, nil
+	case "(":
+		inner, err := p.or(negated)
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ")" {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		p.pos++
+		return inner, nil
+	case "word", "phrase":
+		t, err := newTerm(tok, negated)
+		if err != nil {
+			return nil, err
+		}
+		p.terms = append(p.terms, t)
+		return t, nil
+	}
+	return nil, fmt.Errorf("unexpected %s", tok)
+}
+
+// newTerm builds a term from a word or phrase token.
+func newTerm(tok token, negated bool) (*term, error) {
+	text := tok.text
+	prefix := tok.kind == "word" && strings.HasSuffix(text, "*")
+	if prefix {
+		text = strings.TrimSuffix(text, "*")
+	}
+	t := &term{text: tok.text, prefix: prefix, negated: negated}
+	for _, w := range splitWords(text) {
+		t.words = append(t.words, w.text)
+	}
+	if len(t.words) == 0 {
+		return nil, fmt.Errorf("%s has no words", tok)
+	}
+	if prefix && len(t.words[len(t.words)-1]) < 3 {
+		return nil, fmt.Errorf("%s: a wildcard needs at least 3 letters before it", tok)
+	}
+	return t, nil
+}
@@ -0,0 +1,184 @@
+package analysis_test
+
+import (
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/analysis"
+)
+
+// TestExtractDollarAmounts verifies comma, plain, and multiplier formats.
+func TestExtractDollarAmounts(t *testing.T) {
+	text := "For salaries, $1,500,000; for grants $250000, and $2.5 billion for construction."
+	amounts := analysis.ExtractDollarAmounts(text)
+
+	want := []int64{1_500_000, 250_000, 2_500_000_000}
+	if len(amounts) != len(want) {
+		t.Fatalf("Expected %d amounts, got %d: %+v", len(want), len(amounts), amounts)
+	}
+	for i, a := range amounts {
+		if a.Value != want[i] {
+			t.Errorf("Amount %d: got %d, want %d (raw %q)", i, a.Value, want[i], a.Raw)
+		}
+	}
+
+	if total := analysis.SumDollarAmounts(text); total != 2_501_750_000 {
+		t.Errorf("SumDollarAmounts: got %d, want 2501750000", total)
+	}
+}
+
+// TestSplitSections verifies section headers are detected in plain and XML text.
+func TestSplitSections(t *testing.T) {
+	text := "A BILL\nSECTION 1. SHORT TITLE.\nThis Act may be cited as the Test Act.\nSEC. 2. FUNDING.\nThere is appropriated $5.\n"
+	sections := analysis.SplitSections(text)
+
+	if len(sections) != 2 {
+		t.Fatalf("Expected 2 sections, got %d", len(sections))
+	}
+	if sections[1].Number != "2" || sections[1].Heading != "FUNDING." {
+		t.Errorf("Unexpected section: %+v", sections[1])
+	}
+	if sections[0].Body != "This Act may be cited as the Test Act." {
+		t.Errorf("Unexpected body: %q", sections[0].Body)
+	}
+	if got := text[sections[1].Start:sections[1].End]; got != "SEC. 2. FUNDING.\nThere is appropriated $5.\n" {
+		t.Errorf("Unexpected section span: %q", got)
+	}
+
+	xml := "<section><header>SEC. 3. OVERSIGHT.</header><text>GAO shall audit.</text></section>"
+	if got := analysis.SplitSections(analysis.StripMarkup(xml)); len(got) != 1 || got[0].Number != "3" {
+		t.Errorf("Expected one section numbered 3 from XML, got %+v", got)
+	}
+}
+
+// TestSpendingItemsAndCompare verifies account attribution and change matching.
+func TestSpendingItemsAndCompare(t *testing.T) {
+	from := "TITLE I\nDEPARTMENT OF AGRICULTURE\nOffice of the Secretary\n" +
+		"For necessary expenses of the Office of the Secretary, $5,000,000, to remain available.\n" +
+		"Salaries and Expenses\nFor salaries, $1,000,000, of which $250,000 is for travel.\n" +
+		"SEC. 101. RESCISSION.\nOf the unobligated balances, $300,000 are rescinded.\n"
+	to := "TITLE I\nDEPARTMENT OF AGRICULTURE\nOffice of the Secretary\n" +
+		"For necessary expenses of the Office of the Secretary, $7,500,000, to remain available.\n" +
+		"Salaries and Expenses\nFor salaries, $1,000,000, of which $250,000 is for travel.\n"
+
+	items := analysis.ExtractSpendingItems(from)
+	if len(items) != 4 {
+		t.Fatalf("Expected 4 items, got %d: %+v", len(items), items)
+	}
+	if items[0].Account != "Office of the Secretary" {
+		t.Errorf("Expected account %q, got %q", "Office of the Secretary", items[0].Account)
+	}
+	if items[2].Account != "Salaries and Expenses" || items[1].Key == items[2].Key {
+		t.Errorf("Expected distinct keys under Salaries and Expenses, got %+v and %+v", items[1], items[2])
+	}
+	if items[3].Section != "101" {
+		t.Errorf("Expected section 101, got %q", items[3].Section)
+	}
+
+	status := map[string]int{}
+	var net int64
+	for _, c := range analysis.CompareSpending(items, analysis.ExtractSpendingItems(to)) {
+		status[c.Status]++
+		net += c.Change
+	}
+	if status[analysis.SpendingChanged] != 1 || status[analysis.SpendingUnchanged] != 2 || status[analysis.SpendingRemoved] != 1 {
+		t.Errorf("Unexpected statuses: %v", status)
+	}
+	if net != 2_500_000-300_000 {
+		t.Errorf("Expected net change 2200000, got %d", net)
+	}
+}
+
+// TestDefinitionsAndCompare verifies definitions are extracted only from
+// definitions sections and compared by term.
+func TestDefinitionsAndCompare(t *testing.T) {
+	from := "SECTION 1. SHORT TITLE.\nThe term ``Act'' means nothing here.\n" +
+		"SEC. 2. DEFINITIONS.\nIn this Act:\n" +
+		"(1) Administrator.--The term ``Administrator'' means the Administrator of the\nEnvironmental Protection Agency.\n" +
+		"(2) State.--The term ``State'' means each of the several States.\n" +
+		"(3) Tribe.--The term ``Tribe'' has the meaning given in section 4 of the\nIndian Self-Determination Act.\n" +
+		"SEC. 3. GRANTS.\nThe Administrator shall make grants.\n"
+	to := "SEC. 2. DEFINITIONS.\nIn this Act:\n" +
+		"(1) Administrator.--The term ``Administrator'' means the Administrator of the Environmental Protection Agency.\n" +
+		"(2) State.--The term ``State'' means each of the several States and the District of Columbia.\n" +
+		"(3) Eligible entity.--The term ``eligible entity'' includes a unit of local government.\n"
+
+	definitions := analysis.ExtractDefinitions(from)
+	if len(definitions) != 3 {
+		t.Fatalf("Expected 3 definitions, got %d: %+v", len(definitions), definitions)
+	}
+	if d := definitions[0]; d.Term != "Administrator" || d.Section != "2" || d.Key != "administrator|1" {
+		t.Errorf("Unexpected first definition: %+v", d)
+	}
+	if want := "The term ``Administrator'' means the Administrator of the Environmental Protection Agency."; definitions[0].Text != want {
+		t.Errorf("Expected text %q, got %q", want, definitions[0].Text)
+	}
+	if text := definitions[1].Text; text != "The term ``State'' means each of the several States." {
+		t.Errorf("Expected definition to stop before the next paragraph, got %q", text)
+	}
+
+	status := map[string]string{}
+	for _, c := range analysis.CompareDefinitions(definitions, analysis.ExtractDefinitions(to)) {
+		status[c.Term] = c.Status
+	}
+	want := map[string]string{
+		"Administrator":   analysis.DefinitionUnchanged, // Only rewrapped
+		"State":           analysis.DefinitionReworded,
+		"eligible entity": analysis.DefinitionAdded,
+		"Tribe":           analysis.DefinitionRemoved,
+	}
+	for term, s := range want {
+		if status[term] != s {
+			t.Errorf("Expected %s to be %s, got %q", term, s, status[term])
+		}
+	}
+}
+
+// TestEarmarksAndCompare verifies directing clauses and community project
+// funding table rows are extracted and compared by recipient and purpose.
+func TestEarmarksAndCompare(t *testing.T) {
+	from := "SEC. 101. PROJECTS.\nOf the amounts made available, $500,000 shall be available for a grant to the City of\nSpringfield, Illinois, for water main replacement; and $2,000,000 shall be made available to the Secretary for administration.\n" +
+		"SEC. 102. COMMUNITY PROJECT FUNDING.\nRecipient\tProject\tAmount\n" +
+		"Town of Greenfield\tLibrary roof repair\t$150,000\n" +
+		"County of Marin  Flood control levee  1,200,000\n"
+	to := "SEC. 101. PROJECTS.\nOf the amounts made available, $750,000 shall be available for a grant to the City of Springfield, Illinois, for water main replacement.\n" +
+		"SEC. 102. COMMUNITY PROJECT FUNDING.\nRecipient\tProject\tAmount\n" +
+		"Town of Greenfield\tLibrary roof repair\t$150,000\n" +
+		"Port of Astoria\tDock rehabilitation\t$300,000\n"
+
+	earmarks := analysis.ExtractEarmarks(from)
+	if len(earmarks) != 3 {
+		t.Fatalf("Expected 3 earmarks, got %d: %+v", len(earmarks), earmarks)
+	}
+	if e := earmarks[0]; e.Recipient != "City of Springfield, Illinois" || e.Purpose != "water main replacement" || e.Amount.Value != 500_000 || e.Section != "101" {
+		t.Errorf("Unexpected clause earmark: %+v", e)
+	}
+	if e := earmarks[2]; e.Recipient != "County of Marin" || e.Purpose != "Flood control levee" || e.Amount.Value != 1_200_000 || e.Section != "102" {
+		t.Errorf("Unexpected table earmark: %+v", e)
+	}
+
+	status := map[string]string{}
+	for _, c := range analysis.CompareEarmarks(earmarks, analysis.ExtractEarmarks(to)) {
+		status[c.Recipient] = c.Status
+	}
+	want := map[string]string{
+		"City of Springfield, Illinois": analysis.EarmarkChanged,
+		"Town of Greenfield":            analysis.EarmarkUnchanged,
+		"Port of Astoria":               analysis.EarmarkAdded,
+		"County of Marin":               analysis.EarmarkRemoved,
+	}
+	for recipient, s := range want {
+		if status[recipient] != s {
+			t.Errorf("Expected %s to be %s, got %q", recipient, s, status[recipient])
+		}
+	}
+}
+
+// TestEarmarks_XMLTable verifies rows of XML tables are read cell by cell.
+func TestEarmarks_XMLTable(t *testing.T) {
+	text := "<section><header>Community Project Funding</header><table><row><entry>Recipient</entry><entry>Project</entry><entry>Amount</entry></row>" +
+		"<row><entry>Town of Greenfield</entry><entry>Library roof repair</entry><entry>$150,000</entry></row></table></section>"
+	earmarks := analysis.ExtractEarmarks(text)
+	if len(earmarks) != 1 || earmarks[0].Recipient != "Town of Greenfield" || earmarks[0].Amount.Value != 150_000 {
+		t.Errorf("Unexpected earmarks: %+v", earmarks)
+	}
+}
@@ -0,0 +1,161 @@
+package analysis
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Definition is a term defined in a definitions section, such as
+// "SEC. 2. DEFINITIONS.", with its definition.
+type Definition struct {
+	Key        string `json:"key"`     // Stable identity across versions: normalized term|occurrence
+	Term       string `json:"term"`    // As written, e.g., "Administrator"
+	Text       string `json:"text"`    // The definition, whitespace-normalized, from "The term" on
+	Section    string `json:"section"` // Number of the definitions section
+	Anchor     string `json:"anchor"`  // Section.Anchor of the definitions section
+	Offset     int    `json:"-"`       // Byte offset in the markup-stripped text
+	normalized string
+}
+
+// Definition change statuses for DefinitionChange.Status.
+const (
+	DefinitionAdded     = "added"
+	DefinitionRemoved   = "removed"
+	DefinitionReworded  = "reworded"
+	DefinitionUnchanged = "unchanged"
+)
+
+// DefinitionChange compares one defined term between two versions.
+type DefinitionChange struct {
+	Key      string `json:"key"`
+	Term     string `json:"term"`
+	Status   string `json:"status"`
+	Section  string `json:"section"` // From the newer version when present
+	Anchor   string `json:"anchor"`  // From the newer version when present
+	FromText string `json:"fromText,omitempty"`
+	ToText   string `json:"toText,omitempty"`
+}
+
+var (
+	// definitionsHeadingPattern matches the headings of definitions sections:
+	// "DEFINITIONS.", "DEFINITION OF SMALL BUSINESS.", and the like.
+	definitionsHeadingPattern = regexp.MustCompile(`(?i)\bdefinitions?\b`)
+
+	// termPattern matches the start of a definition: "the term" and a quoted
+	// term followed by a defining verb, in the quote styles of GPO text
+	// (``term'', `term'), typographic quotes, and plain quotes.
+	termPattern = regexp.MustCompile("(?i)\\bthe\\s+terms?\\s+(?:``|`|“|‘|\"|')([A-Za-z][^`\"'“”‘’\\n]{0,99}?)(?:''|'|”|’|\")\\s*,?\\s+(?:means|includes|has\\s+the\\s+meaning|shall\\s+mean)\\b")
+
+	// enumeratorPattern matches the paragraph label a definition may start
+	// with, e.g., "(1) Administrator.--" or "(A) ".
+	enumeratorPattern = regexp.MustCompile(`^\s*\([0-9A-Za-z]+\)`)
+)
+
+// ExtractDefinitions finds the terms defined in the text's definitions
+// sections, in text order. A definition runs from its "the term" to the
+// next definition's paragraph or the end of the section. Markup is stripped
+// first, so both plain text and XML versions work.
+func ExtractDefinitions(text string) []Definition {
+	text = StripMarkup(text)
+
+	var definitions []Definition
+	occurrences := make(map[string]int)
+	for _, section := range SplitSections(text) {
+		if !definitionsHeadingPattern.MatchString(section.Heading) {
+			continue
+		}
+		body := text[section.Start:section.End]
+		matches := termPattern.FindAllStringSubmatchIndex(body, -1)
+		for i, m := range matches {
+			end := len(body)
+			if i+1 < len(matches) {
+				end = paragraphStart(body, matches[i+1][0])
+			}
+			if end < m[0] {
+				end = matches[i+1][0] // Two definitions in one paragraph
+			}
+
+			term := strings.Join(strings.Fields(body[m[2]:m[3]]), " ")
+			normalized := NormalizeForComparison(term)
+			occurrences[normalized]++
+			definitions = append(definitions, Definition{
+				Key:        fmt.Sprintf("%s|%d", normalized, occurrences[normalized]),
+				Term:       term,
+				Text:       strings.Join(strings.Fields(body[m[0]:end]), " "),
+				Section:    section.Number,
+				Anchor:     section.Anchor(),
+				Offset:     section.Start + m[0],
+				normalized: NormalizeForComparison(body[m[0]:end]),
+			})
+		}
+	}
+	return definitions
+}
+
+// paragraphStart returns where the paragraph containing offset starts:
+// the start of its line when the line opens with an enumerator such as
+// "(2)", or offset itself.
+func paragraphStart(text string, offset int) int {
+	lineStart := strings.LastIndexByte(text[:offset], '\n') + 1
+	if enumeratorPattern.MatchString(text[lineStart:offset]) {
+		return lineStart
+	}
+	return offset
+}
+
+// CompareDefinitions matches definitions between two versions by Key and
+// reports which were added, removed, or reworded. Rewording ignores case
+// and whitespace. Results follow the order of the newer version, with
+// removed definitions appended in their original order.
+func CompareDefinitions(from, to []Definition) []DefinitionChange {
+	fromByKey := make(map[string]Definition, len(from))
+	for _, d := range from {
+		fromByKey[d.Key] = d
+	}
+
+	changes := make([]DefinitionChange, 0, len(to))
+	seen := make(map[string]bool, len(to))
+	for _, d := range to {
+		seen[d.Key] = true
+		change := DefinitionChange{Key: d.Key, Term: d.Term, Section: d.Section, Anchor: d.Anchor, ToText: d.Text}
+
+		prev, ok := fromByKey[d.Key]
+		switch {
+		case !ok:
+			change.Status = DefinitionAdded
+		case prev.comparable() != d.comparable():
+			change.Status = DefinitionReworded
+		default:
+			change.Status = DefinitionUnchanged
+		}
+		if ok {
+			change.FromText = prev.Text
+		}
+		changes = append(changes, change)
+	}
+
+	for _, d := range from {
+		if seen[d.Key] {
+			continue
+		}
+		changes = append(changes, DefinitionChange{
+			Key:      d.Key,
+			Term:     d.Term,
+			Status:   DefinitionRemoved,
+			Section:  d.Section,
+			Anchor:   d.Anchor,
+			FromText: d.Text,
+		})
+	}
+	return changes
+}
+
+// comparable returns the definition's text as compared for rewording.
+// Definitions loaded from storage have no cached normalized text.
+func (d Definition) comparable() string {
+	if d.normalized != "" {
+		return d.normalized
+	}
+	return NormalizeForComparison(d.Text)
+}
@@ -0,0 +1,67 @@
+package analysis
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DollarAmount is a dollar figure found in bill text.
+type DollarAmount struct {
+	Raw    string `json:"raw"`    // As written, e.g., "$1,500,000"
+	Value  int64  `json:"value"`  // Whole dollars
+	Offset int    `json:"offset"` // Byte offset of Raw within the scanned text
+}
+
+// dollarPattern matches "$1,500,000", "$1500000", and "$2.5" style figures,
+// optionally followed by a "million"/"billion" multiplier.
+var dollarPattern = regexp.MustCompile(`\$\s?(\d{1,3}(?:,\d{3})+|\d+)(?:\.(\d+))?(?:\s+(million|billion|trillion))?`)
+
+var dollarMultipliers = map[string]int64{
+	"million":  1_000_000,
+	"billion":  1_000_000_000,
+	"trillion": 1_000_000_000_000,
+}
+
+// ExtractDollarAmounts returns every dollar amount in text, in order of appearance.
+func ExtractDollarAmounts(text string) []DollarAmount {
+	matches := dollarPattern.FindAllStringSubmatchIndex(text, -1)
+	amounts := make([]DollarAmount, 0, len(matches))
+
+	for _, m := range matches {
+		whole, err := strconv.ParseInt(strings.ReplaceAll(text[m[2]:m[3]], ",", ""), 10, 64)
+		if err != nil {
+			continue // Overflow; not a realistic appropriation
+		}
+
+		value := whole
+		if m[6] >= 0 {
+			multiplier := dollarMultipliers[strings.ToLower(text[m[6]:m[7]])]
+			value = whole * multiplier
+			if m[4] >= 0 {
+				// Apply the fractional part against the multiplier ("$2.5 billion")
+				frac := text[m[4]:m[5]]
+				if f, err := strconv.ParseFloat("0."+frac, 64); err == nil {
+					value += int64(f * float64(multiplier))
+				}
+			}
+		}
+
+		amounts = append(amounts, DollarAmount{
+			Raw:    text[m[0]:m[1]],
+			Value:  value,
+			Offset: m[0],
+		})
+	}
+
+	return amounts
+}
+
+// SumDollarAmounts returns the total value of all dollar amounts in text.
+func SumDollarAmounts(text string) int64 {
+	var total int64
+	for _, a := range ExtractDollarAmounts(text) {
+		total += a.Value
+	}
+	return total
+}
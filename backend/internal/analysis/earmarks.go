@@ -0,0 +1,223 @@
+package analysis
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Earmark is a community project funding entry: an amount directed to a
+// named recipient for a stated purpose.
+type Earmark struct {
+	Key       string       `json:"key"`       // Stable identity across versions: recipient|purpose|occurrence
+	Recipient string       `json:"recipient"` // e.g., "City of Springfield, Illinois"
+	Purpose   string       `json:"purpose"`   // e.g., "water main replacement"
+	Section   string       `json:"section"`   // Enclosing section number, "" outside numbered sections
+	Amount    DollarAmount `json:"amount"`
+}
+
+// Earmark change statuses for EarmarkChange.Status.
+const (
+	EarmarkAdded     = "added"
+	EarmarkRemoved   = "removed"
+	EarmarkChanged   = "changed"
+	EarmarkUnchanged = "unchanged"
+)
+
+// EarmarkChange compares one earmark between two versions.
+type EarmarkChange struct {
+	Key        string `json:"key"`
+	Recipient  string `json:"recipient"`
+	Purpose    string `json:"purpose"`
+	Section    string `json:"section"` // From the newer version when present
+	Status     string `json:"status"`
+	FromAmount int64  `json:"fromAmount"`
+	ToAmount   int64  `json:"toAmount"`
+	Change     int64  `json:"change"` // ToAmount - FromAmount
+	FromRaw    string `json:"fromRaw,omitempty"`
+	ToRaw      string `json:"toRaw,omitempty"`
+}
+
+var (
+	// earmarkClausePattern matches the directing clause that follows an
+	// amount, as in "$500,000 shall be available for a grant to the City of
+	// Springfield, Illinois, for water main replacement;". Grant, award, or
+	// payment wording is required so that amounts made available to agencies
+	// aren't mistaken for earmarks.
+	earmarkClausePattern = regexp.MustCompile(`^\s*(?:shall|is|are)\s+(?:hereby\s+)?(?:be\s+)?(?:(?:made\s+)?(?:available|provided)\s+for\s+(?:a\s+)?(?:grants?|awards?|payments?)\s+to|(?:awarded|paid)\s+to)\s+(?:the\s+)?([A-Z][^;:]{1,150}?),?\s+(?:for|to)\s+([^;:]{3,400}?)(?:;|:|\.(?:\s|$))`)
+
+	// earmarkTablePattern matches the titles of community project tables.
+	earmarkTablePattern = regexp.MustCompile(`(?i)community\s+project\s+funding|congressionally\s+directed\s+spending|community\s+projects`)
+
+	// tableCellSeparator splits a plain-text table row into cells.
+	tableCellSeparator = regexp.MustCompile(`\t+|\s{2,}`)
+
+	// tableAmountPattern matches an amount cell, with or without "$".
+	tableAmountPattern = regexp.MustCompile(`^\$?\s?(\d{1,3}(?:,\d{3})+|\d+)$`)
+
+	tableEntryOpenPattern  = regexp.MustCompile(`<entry[^>]*>`)
+	tableEntryClosePattern = regexp.MustCompile(`</entry>`)
+)
+
+// ExtractEarmarks finds earmark-style entries in appropriations text in two
+// forms: clauses directing an amount to a named recipient for a purpose,
+// and rows of community project funding tables (recipient, project,
+// amount). Markup is stripped first, with XML table cells kept on one line
+// separated by tabs, so both plain text and XML versions work.
+func ExtractEarmarks(text string) []Earmark {
+	if strings.Contains(text, "<entry") {
+		text = tableEntryOpenPattern.ReplaceAllString(text, "")
+		text = tableEntryClosePattern.ReplaceAllString(text, "\t")
+	}
+	text = StripMarkup(text)
+
+	earmarks := extractEarmarkClauses(text)
+	earmarks = append(earmarks, extractEarmarkTables(text)...)
+	sort.SliceStable(earmarks, func(i, j int) bool { return earmarks[i].Amount.Offset < earmarks[j].Amount.Offset })
+
+	sections := sectionHeaderPattern.FindAllStringSubmatchIndex(text, -1)
+	occurrences := make(map[string]int)
+	for i := range earmarks {
+		e := &earmarks[i]
+		if j := sort.Search(len(sections), func(j int) bool { return sections[j][0] > e.Amount.Offset }); j > 0 {
+			e.Section = text[sections[j-1][2]:sections[j-1][3]]
+		}
+		base := strings.ToLower(e.Recipient + "|" + e.Purpose)
+		occurrences[base]++
+		e.Key = fmt.Sprintf("%s|%d", base, occurrences[base])
+	}
+	return earmarks
+}
+
+// extractEarmarkClauses finds amounts followed by a directing clause.
+func extractEarmarkClauses(text string) []Earmark {
+	var earmarks []Earmark
+	for _, amount := range ExtractDollarAmounts(text) {
+		rest := text[amount.Offset+len(amount.Raw):]
+		m := earmarkClausePattern.FindStringSubmatch(rest)
+		if m == nil {
+			continue
+		}
+		earmarks = append(earmarks, Earmark{
+			Recipient: collapseSpace(m[1]),
+			Purpose:   collapseSpace(m[2]),
+			Amount:    amount,
+		})
+	}
+	return earmarks
+}
+
+// extractEarmarkTables finds the rows of community project funding tables:
+// lines after a table title, up to the next section header, with at least
+// three cells and a final amount cell. Header rows have no amount and are
+// skipped.
+func extractEarmarkTables(text string) []Earmark {
+	var earmarks []Earmark
+	inTable := false
+	offset := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		lineOffset := offset
+		offset += len(line)
+
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case sectionHeaderPattern.MatchString(trimmed):
+			inTable = earmarkTablePattern.MatchString(trimmed)
+			continue
+		case earmarkTablePattern.MatchString(trimmed) && !strings.Contains(trimmed, "$"):
+			inTable = true
+			continue
+		case !inTable:
+			continue
+		}
+
+		cells := tableCellSeparator.Split(trimmed, -1)
+		if len(cells) < 3 {
+			continue
+		}
+		last := cells[len(cells)-1]
+		m := tableAmountPattern.FindStringSubmatch(last)
+		if m == nil {
+			continue
+		}
+		value, err := strconv.ParseInt(strings.ReplaceAll(m[1], ",", ""), 10, 64)
+		if err != nil {
+			continue
+		}
+		earmarks = append(earmarks, Earmark{
+			Recipient: collapseSpace(cells[0]),
+			Purpose:   collapseSpace(strings.Join(cells[1:len(cells)-1], " ")),
+			Amount: DollarAmount{
+				Raw:    last,
+				Value:  value,
+				Offset: lineOffset + strings.LastIndex(line, last),
+			},
+		})
+	}
+	return earmarks
+}
+
+// collapseSpace trims text and collapses its runs of whitespace.
+func collapseSpace(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// CompareEarmarks matches earmarks between two versions by Key and reports
+// which were added or removed and how amounts changed. Results follow the
+// order of the newer version, with removed earmarks appended in their
+// original order.
+func CompareEarmarks(from, to []Earmark) []EarmarkChange {
+	fromByKey := make(map[string]Earmark, len(from))
+	for _, e := range from {
+		fromByKey[e.Key] = e
+	}
+
+	changes := make([]EarmarkChange, 0, len(to))
+	seen := make(map[string]bool, len(to))
+	for _, e := range to {
+		seen[e.Key] = true
+		change := EarmarkChange{
+			Key:       e.Key,
+			Recipient: e.Recipient,
+			Purpose:   e.Purpose,
+			Section:   e.Section,
+			ToAmount:  e.Amount.Value,
+			ToRaw:     e.Amount.Raw,
+		}
+
+		prev, ok := fromByKey[e.Key]
+		switch {
+		case !ok:
+			change.Status = EarmarkAdded
+		case prev.Amount.Value != e.Amount.Value:
+			change.Status = EarmarkChanged
+		default:
+			change.Status = EarmarkUnchanged
+		}
+		if ok {
+			change.FromAmount = prev.Amount.Value
+			change.FromRaw = prev.Amount.Raw
+		}
+		change.Change = change.ToAmount - change.FromAmount
+		changes = append(changes, change)
+	}
+
+	for _, e := range from {
+		if seen[e.Key] {
+			continue
+		}
+		changes = append(changes, EarmarkChange{
+			Key:        e.Key,
+			Recipient:  e.Recipient,
+			Purpose:    e.Purpose,
+			Section:    e.Section,
+			Status:     EarmarkRemoved,
+			FromAmount: e.Amount.Value,
+			FromRaw:    e.Amount.Raw,
+			Change:     -e.Amount.Value,
+		})
+	}
+	return changes
+}
@@ -0,0 +1,76 @@
+// Package analysis provides pure text analysis functions over bill text,
+// such as section splitting and dollar-amount extraction.
+package analysis
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+)
+
+// Section is a numbered section of bill text (e.g., "SEC. 101. FUNDING.").
+type Section struct {
+	Number  string `json:"number"`  // e.g., "101"
+	Heading string `json:"heading"` // e.g., "FUNDING."
+	Body    string `json:"body"`    // Text following the heading line
+	Start   int    `json:"-"`       // Byte offset of the header line in the split text
+	End     int    `json:"-"`       // Byte offset where the next section's header starts
+}
+
+var (
+	// sectionHeaderPattern matches "SECTION 1. SHORT TITLE." and "SEC. 101. HEADING." lines.
+	sectionHeaderPattern = regexp.MustCompile(`(?m)^[ \t]*(?:SECTION|SEC\.)[ \t]+(\d+[A-Za-z]?)\.[ \t]*(.*)$`)
+	markupPattern        = regexp.MustCompile(`<[^>]+>`)
+	blankLinesPattern    = regexp.MustCompile(`\n{3,}`)
+)
+
+// StripMarkup removes XML/HTML tags from bill text, replacing each tag with
+// a newline so that block elements (sections, paragraphs) stay on their own lines.
+func StripMarkup(text string) string {
+	if !strings.Contains(text, "<") {
+		return text
+	}
+	stripped := markupPattern.ReplaceAllString(text, "\n")
+	stripped = strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">", "&quot;", `"`, "&#39;", "'", "&nbsp;", " ").Replace(stripped)
+	return blankLinesPattern.ReplaceAllString(stripped, "\n\n")
+}
+
+// SplitSections splits plain bill text into numbered sections.
+// Text before the first section header is ignored.
+func SplitSections(text string) []Section {
+	matches := sectionHeaderPattern.FindAllStringSubmatchIndex(text, -1)
+	sections := make([]Section, 0, len(matches))
+
+	for i, m := range matches {
+		bodyEnd := len(text)
+		if i+1 < len(matches) {
+			bodyEnd = matches[i+1][0]
+		}
+		sections = append(sections, Section{
+			Number:  text[m[2]:m[3]],
+			Heading: strings.TrimSpace(text[m[4]:m[5]]),
+			Body:    strings.TrimSpace(text[m[1]:bodyEnd]),
+			Start:   m[0],
+			End:     bodyEnd,
+		})
+	}
+
+	return sections
+}
+
+// Anchor returns a stable identifier for the section, e.g.,
+// "sec-201-3f2a9c1b": its number and a hash of its heading. It doesn't
+// depend on where the section falls in the text, so links to it survive
+// re-ingestion and amendments elsewhere in the bill; renumbering or
+// retitling the section changes it.
+func (s Section) Anchor() string {
+	hash := sha256.Sum256([]byte(NormalizeForComparison(s.Heading)))
+	return "sec-" + strings.ToLower(s.Number) + "-" + hex.EncodeToString(hash[:4])
+}
+
+// NormalizeForComparison lowercases text and collapses all whitespace runs
+// to single spaces, so re-wrapped or re-indented text compares equal.
+func NormalizeForComparison(text string) string {
+	return strings.Join(strings.Fields(strings.ToLower(text)), " ")
+}
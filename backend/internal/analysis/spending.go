@@ -0,0 +1,249 @@
+package analysis
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SpendingItem is a dollar amount located within a bill's structure.
+type SpendingItem struct {
+	Key     string       `json:"key"`     // Stable identity across versions: section|account|occurrence
+	Section string       `json:"section"` // Enclosing section number, "" outside numbered sections
+	Account string       `json:"account"` // Nearest preceding heading, e.g., "Salaries and Expenses"
+	Context string       `json:"context"` // Whitespace-normalized text surrounding the amount
+	Amount  DollarAmount `json:"amount"`
+}
+
+// Spending change statuses for SpendingChange.Status.
+const (
+	SpendingAdded     = "added"
+	SpendingRemoved   = "removed"
+	SpendingChanged   = "changed"
+	SpendingUnchanged = "unchanged"
+)
+
+// SpendingChange compares one spending item between two versions.
+type SpendingChange struct {
+	Key        string `json:"key"`
+	Section    string `json:"section"`
+	Account    string `json:"account"`
+	Status     string `json:"status"`
+	FromAmount int64  `json:"fromAmount"`
+	ToAmount   int64  `json:"toAmount"`
+	Change     int64  `json:"change"` // ToAmount - FromAmount
+	FromRaw    string `json:"fromRaw,omitempty"`
+	ToRaw      string `json:"toRaw,omitempty"`
+	Context    string `json:"context"` // From the newer version when present
+}
+
+const (
+	contextBefore = 120
+	contextAfter  = 60
+
+	// maxHeadingWords bounds how long a line can be and still count as an
+	// account heading rather than a wrapped sentence.
+	maxHeadingWords = 12
+)
+
+var lineTerminators = regexp.MustCompile(`[.;,:]\s*$`)
+
+// ExtractSpendingItems finds every dollar amount in bill text along with its
+// enclosing section, nearest account heading, and surrounding context.
+// Markup is stripped first, so both plain text and XML versions work.
+func ExtractSpendingItems(text string) []SpendingItem {
+	text = StripMarkup(text)
+
+	amounts := ExtractDollarAmounts(text)
+	if len(amounts) == 0 {
+		return nil
+	}
+
+	headings := findHeadings(text)
+	sections := sectionHeaderPattern.FindAllStringSubmatchIndex(text, -1)
+
+	occurrences := make(map[string]int)
+	items := make([]SpendingItem, 0, len(amounts))
+
+	for _, amount := range amounts {
+		item := SpendingItem{
+			Amount:  amount,
+			Context: surroundingContext(text, amount.Offset, amount.Offset+len(amount.Raw)),
+		}
+
+		// Latest section header starting before the amount
+		if i := sort.Search(len(sections), func(i int) bool { return sections[i][0] > amount.Offset }); i > 0 {
+			m := sections[i-1]
+			item.Section = text[m[2]:m[3]]
+		}
+
+		// Latest account heading starting before the amount
+		if i := sort.Search(len(headings), func(i int) bool { return headings[i].offset > amount.Offset }); i > 0 {
+			item.Account = headings[i-1].text
+		}
+
+		base := strings.ToLower(item.Section + "|" + item.Account)
+		occurrences[base]++
+		item.Key = fmt.Sprintf("%s|%d", base, occurrences[base])
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// CompareSpending matches items between two versions by Key and reports how
+// each amount changed. Results follow the order of the newer version, with
+// removed items appended in their original order.
+func CompareSpending(from, to []SpendingItem) []SpendingChange {
+	fromByKey := make(map[string]SpendingItem, len(from))
+	for _, item := range from {
+		fromByKey[item.Key] = item
+	}
+
+	changes := make([]SpendingChange, 0, len(to))
+	seen := make(map[string]bool, len(to))
+
+	for _, item := range to {
+		seen[item.Key] = true
+		change := SpendingChange{
+			Key:      item.Key,
+			Section:  item.Section,
+			Account:  item.Account,
+			ToAmount: item.Amount.Value,
+			ToRaw:    item.Amount.Raw,
+			Context:  item.Context,
+		}
+
+		prev, ok := fromByKey[item.Key]
+		switch {
+		case !ok:
+			change.Status = SpendingAdded
+		case prev.Amount.Value != item.Amount.Value:
+			change.Status = SpendingChanged
+		default:
+			change.Status = SpendingUnchanged
+		}
+		if ok {
+			change.FromAmount = prev.Amount.Value
+			change.FromRaw = prev.Amount.Raw
+		}
+		change.Change = change.ToAmount - change.FromAmount
+		changes = append(changes, change)
+	}
+
+	for _, item := range from {
+		if seen[item.Key] {
+			continue
+		}
+		changes = append(changes, SpendingChange{
+			Key:        item.Key,
+			Section:    item.Section,
+			Account:    item.Account,
+			Status:     SpendingRemoved,
+			FromAmount: item.Amount.Value,
+			FromRaw:    item.Amount.Raw,
+			Change:     -item.Amount.Value,
+			Context:    item.Context,
+		})
+	}
+
+	return changes
+}
+
+type heading struct {
+	offset int
+	text   string
+}
+
+// findHeadings returns short standalone lines that read like account or
+// agency headings: no dollar sign, no trailing punctuation, and every
+// significant word capitalized.
+func findHeadings(text string) []heading {
+	var headings []heading
+	offset := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if IsHeadingLine(trimmed) {
+			headings = append(headings, heading{offset: offset, text: trimmed})
+		}
+		offset += len(line)
+	}
+	return headings
+}
+
+// IsHeadingLine reports whether a trimmed line looks like an account or
+// agency heading: short, without a dollar sign or trailing punctuation,
+// and with every significant word capitalized.
+func IsHeadingLine(line string) bool {
+	if line == "" || strings.Contains(line, "$") || lineTerminators.MatchString(line) {
+		return false
+	}
+	if sectionHeaderPattern.MatchString(line) {
+		return false
+	}
+
+	words := strings.Fields(line)
+	if len(words) > maxHeadingWords {
+		return false
+	}
+
+	letters := false
+	for _, w := range words {
+		r, _ := utf8.DecodeRuneInString(w)
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		letters = true
+		if unicode.IsLower(r) && !isMinorWord(w) {
+			return false
+		}
+	}
+	return letters
+}
+
+// isMinorWord reports whether w is a short connective left lowercase in
+// title-cased headings ("Salaries and Expenses").
+func isMinorWord(w string) bool {
+	switch w {
+	case "a", "an", "and", "as", "at", "by", "for", "in", "of", "on", "or", "the", "to", "with":
+		return true
+	}
+	return false
+}
+
+// surroundingContext returns the whitespace-normalized text around [start, end).
+func surroundingContext(text string, start, end int) string {
+	from := start - contextBefore
+	if from < 0 {
+		from = 0
+	}
+	to := end + contextAfter
+	if to > len(text) {
+		to = len(text)
+	}
+	// Don't split a multi-byte character
+	for from > 0 && !utf8.RuneStart(text[from]) {
+		from--
+	}
+	for to < len(text) && !utf8.RuneStart(text[to]) {
+		to++
+	}
+
+	snippet := strings.Join(strings.Fields(text[from:to]), " ")
+	// Trim partial words at the edges of the window
+	if from > 0 {
+		if i := strings.IndexByte(snippet, ' '); i >= 0 {
+			snippet = snippet[i+1:]
+		}
+	}
+	if to < len(text) {
+		if i := strings.LastIndexByte(snippet, ' '); i >= 0 {
+			snippet = snippet[:i]
+		}
+	}
+	return snippet
+}
@@ -0,0 +1,236 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ErrNoIngestRuns is returned when no ingestion run has been recorded yet.
+var ErrNoIngestRuns = errors.New("no ingestion runs recorded")
+
+// adminSecurityScheme names the admin token in the OpenAPI document.
+const adminSecurityScheme = "adminToken"
+
+// AdminService handles operator-facing endpoints.
+type AdminService struct {
+	db *gorm.DB
+
+	// token is the bearer token that authorizes admin requests without an
+	// admin's API key. It is disabled while empty.
+	token string
+
+	// Delta recompute jobs run in the background, one at a time, until Close.
+	jobCtx    context.Context
+	stopJobs  context.CancelFunc
+	jobs      sync.WaitGroup
+	jobRunner sync.Mutex
+}
+
+// NewAdminService creates a new AdminService instance. Admin requests
+// must send token as "Authorization: Bearer <token>" or an admin's API key;
+// an empty token accepts only the latter.
+func NewAdminService(db *gorm.DB, token string) *AdminService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &AdminService{db: db, token: token, jobCtx: ctx, stopJobs: cancel}
+}
+
+// Close interrupts running delta jobs and waits for them to stop.
+func (s *AdminService) Close() {
+	s.stopJobs()
+	s.jobs.Wait()
+}
+
+// adminOperation marks an operation as requiring the admin token or an
+// admin's API key.
+func (s *AdminService) adminOperation(api huma.API, op huma.Operation) huma.Operation {
+	op.Tags = []string{"Admin"}
+	op.Security = []map[string][]string{{adminSecurityScheme: {}}}
+	return requireRole(api, op, models.UserRoleAdmin)
+}
+
+// IngestRunResponse is the API response format for an ingestion run.
+type IngestRunResponse struct {
+	ID              uint       `json:"id"`
+	TriggeredBy     string     `json:"triggeredBy"`
+	Mode            string     `json:"mode"`
+	Status          string     `json:"status"`
+	StartedAt       time.Time  `json:"startedAt"`
+	FinishedAt      *time.Time `json:"finishedAt,omitempty"`
+	DurationMs      int64      `json:"durationMs"`
+	BillsFetched    int        `json:"billsFetched"`
+	BillsSkipped    int        `json:"billsSkipped"`
+	BillsCreated    int        `json:"billsCreated"`
+	BillsUpdated    int        `json:"billsUpdated"`
+	VersionsCreated int        `json:"versionsCreated"`
+	ErrorCount      int        `json:"errorCount"`
+	Errors          []string   `json:"errors"`
+	ErrorMessage    string     `json:"errorMessage,omitempty"`
+	CursorBill      string     `json:"cursorBill,omitempty"`  // Bill the run got through, in list order, for the next run to resume after
+	ResumedFrom     *uint      `json:"resumedFrom,omitempty"` // ID of the interrupted run this one resumed
+}
+
+// IngestRunList is a page of ingestion runs.
+type IngestRunList struct {
+	Runs   []IngestRunResponse `json:"runs"`
+	Total  int64               `json:"total"`
+	Limit  int                 `json:"limit"`
+	Offset int                 `json:"offset"`
+}
+
+// ListIngestRuns returns ingestion runs, newest first.
+func (s *AdminService) ListIngestRuns(ctx context.Context, limit, offset int) (*IngestRunList, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := s.db.WithContext(ctx).Model(&models.IngestRun{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count ingestion runs: %w", err)
+	}
+
+	var runs []models.IngestRun
+	if err := query.Order("started_at DESC").Limit(limit).Offset(offset).Find(&runs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list ingestion runs: %w", err)
+	}
+
+	responses := make([]IngestRunResponse, len(runs))
+	for i := range runs {
+		responses[i] = ingestRunToResponse(&runs[i])
+	}
+
+	return &IngestRunList{
+		Runs:   responses,
+		Total:  total,
+		Limit:  limit,
+		Offset: offset,
+	}, nil
+}
+
+// LatestIngestRun returns the most recently started ingestion run.
+func (s *AdminService) LatestIngestRun(ctx context.Context) (*IngestRunResponse, error) {
+	var run models.IngestRun
+	if err := s.db.WithContext(ctx).Order("started_at DESC").First(&run).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoIngestRuns
+		}
+		return nil, fmt.Errorf("failed to fetch latest ingestion run: %w", err)
+	}
+	resp := ingestRunToResponse(&run)
+	return &resp, nil
+}
+
+// ingestRunToResponse converts an IngestRun model to its API response format.
+func ingestRunToResponse(run *models.IngestRun) IngestRunResponse {
+	errs := []string(run.Errors)
+	if errs == nil {
+		errs = []string{}
+	}
+	return IngestRunResponse{
+		ID:              run.ID,
+		TriggeredBy:     run.TriggeredBy,
+		Mode:            run.Mode,
+		Status:          run.Status,
+		StartedAt:       run.StartedAt,
+		FinishedAt:      run.FinishedAt,
+		DurationMs:      run.DurationMs,
+		BillsFetched:    run.BillsFetched,
+		BillsSkipped:    run.BillsSkipped,
+		BillsCreated:    run.BillsCreated,
+		BillsUpdated:    run.BillsUpdated,
+		VersionsCreated: run.VersionsCreated,
+		ErrorCount:      run.ErrorCount,
+		Errors:          errs,
+		ErrorMessage:    run.ErrorMessage,
+		CursorBill:      run.CursorBill,
+		ResumedFrom:     run.ResumedFrom,
+	}
+}
+
+// ListIngestRunsInput is the request for listing ingestion runs
+type ListIngestRunsInput struct {
+	Limit  int `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"Number of results per page (max 100)"`
+	Offset int `query:"offset" default:"0" minimum:"0" doc:"Pagination offset"`
+}
+
+// ListIngestRunsOutput is the response for listing ingestion runs
+type ListIngestRunsOutput struct {
+	Body IngestRunList
+}
+
+// GetIngestRunOutput is the response for a single ingestion run
+type GetIngestRunOutput struct {
+	Body IngestRunResponse
+}
+
+// RegisterAdminRoutes registers operator endpoints with Huma. All of them
+// require the admin token or an admin's API key.
+func RegisterAdminRoutes(api huma.API, s *AdminService) {
+	components := api.OpenAPI().Components
+	if components.SecuritySchemes == nil {
+		components.SecuritySchemes = map[string]*huma.SecurityScheme{}
+	}
+	components.SecuritySchemes[adminSecurityScheme] = &huma.SecurityScheme{
+		Type:        "http",
+		Scheme:      "bearer",
+		Description: "The ADMIN_TOKEN configured on the API server",
+	}
+
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID: "list-ingestion-runs",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/ingestions",
+		Summary:     "List ingestion runs",
+		Description: "Returns recorded ingestion runs with counts, duration, and errors, newest first",
+		Errors:      []int{http.StatusInternalServerError},
+	}), func(ctx context.Context, input *ListIngestRunsInput) (*ListIngestRunsOutput, error) {
+		runs, err := s.ListIngestRuns(ctx, input.Limit, input.Offset)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list ingestion runs: " + err.Error())
+		}
+		return &ListIngestRunsOutput{Body: *runs}, nil
+	})
+
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID: "get-latest-ingestion-run",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/ingestions/latest",
+		Summary:     "Get the latest ingestion run",
+		Description: "Returns the most recently started ingestion run, including runs still in progress",
+		Errors:      []int{http.StatusNotFound},
+	}), func(ctx context.Context, input *struct{}) (*GetIngestRunOutput, error) {
+		run, err := s.LatestIngestRun(ctx)
+		if errors.Is(err, ErrNoIngestRuns) {
+			return nil, huma.Error404NotFound("no ingestion runs recorded")
+		}
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to get latest ingestion run: " + err.Error())
+		}
+		return &GetIngestRunOutput{Body: *run}, nil
+	})
+
+	registerDeltaAdminRoutes(api, s)
+	registerReingestAdminRoutes(api, s)
+	registerFailureAdminRoutes(api, s)
+	registerAliasAdminRoutes(api, s)
+	registerTenantAdminRoutes(api, s)
+	registerUserAdminRoutes(api, s)
+	registerAuditAdminRoutes(api, s)
+	registerDatabaseAdminRoutes(api, s)
+}
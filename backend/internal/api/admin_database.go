@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/database"
+)
+
+// errNoDatabase is returned by database diagnostics when the service has
+// no connection, as when generating the OpenAPI spec.
+var errNoDatabase = errors.New("no database connection")
+
+// DatabasePoolResponse is a connection pool's statistics.
+type DatabasePoolResponse struct {
+	Name              string `json:"name" doc:"primary, or replica 1 and so on"`
+	MaxOpen           int    `json:"maxOpen" doc:"Maximum open connections; 0 for no limit"`
+	Open              int    `json:"open" doc:"Open connections, in use or idle"`
+	InUse             int    `json:"inUse"`
+	Idle              int    `json:"idle"`
+	WaitCount         int64  `json:"waitCount" doc:"Queries that waited for a free connection since startup"`
+	WaitDurationMs    int64  `json:"waitDurationMs" doc:"Total time queries waited for a free connection since startup"`
+	MaxIdleClosed     int64  `json:"maxIdleClosed" doc:"Connections closed because the idle pool was full"`
+	MaxIdleTimeClosed int64  `json:"maxIdleTimeClosed" doc:"Connections closed for being idle too long"`
+	MaxLifetimeClosed int64  `json:"maxLifetimeClosed" doc:"Connections closed for reaching their maximum lifetime"`
+}
+
+// DatabaseStatsResponse is the API server's database connection state.
+type DatabaseStatsResponse struct {
+	Pools                []DatabasePoolResponse `json:"pools"`
+	SlowQueryThresholdMs int64                  `json:"slowQueryThresholdMs" doc:"Statements slower than this are logged and counted as slow; 0 when disabled"`
+}
+
+// SlowQueryThresholdRequest sets the slow query threshold.
+type SlowQueryThresholdRequest struct {
+	ThresholdMs int64 `json:"thresholdMs" minimum:"0" doc:"New threshold in milliseconds; 0 disables slow query logging"`
+}
+
+// DatabaseStats returns the statistics of the API server's connection
+// pools and its slow query threshold.
+func (s *AdminService) DatabaseStats() (*DatabaseStatsResponse, error) {
+	if s.db == nil {
+		return nil, errNoDatabase
+	}
+	pools, err := database.Pools(s.db)
+	if err != nil {
+		return nil, err
+	}
+	resp := &DatabaseStatsResponse{
+		Pools:                make([]DatabasePoolResponse, 0, len(pools)),
+		SlowQueryThresholdMs: database.SlowQueryThreshold().Milliseconds(),
+	}
+	for _, p := range pools {
+		resp.Pools = append(resp.Pools, DatabasePoolResponse{
+			Name:              p.Name,
+			MaxOpen:           p.Stats.MaxOpenConnections,
+			Open:              p.Stats.OpenConnections,
+			InUse:             p.Stats.InUse,
+			Idle:              p.Stats.Idle,
+			WaitCount:         p.Stats.WaitCount,
+			WaitDurationMs:    p.Stats.WaitDuration.Milliseconds(),
+			MaxIdleClosed:     p.Stats.MaxIdleClosed,
+			MaxIdleTimeClosed: p.Stats.MaxIdleTimeClosed,
+			MaxLifetimeClosed: p.Stats.MaxLifetimeClosed,
+		})
+	}
+	return resp, nil
+}
+
+// DatabaseStatsOutput is the response for database statistics
+type DatabaseStatsOutput struct {
+	Body DatabaseStatsResponse
+}
+
+// SetSlowQueryThresholdInput is the request for setting the slow query
+// threshold
+type SetSlowQueryThresholdInput struct {
+	Body SlowQueryThresholdRequest
+}
+
+// registerDatabaseAdminRoutes registers database diagnostics endpoints.
+func registerDatabaseAdminRoutes(api huma.API, s *AdminService) {
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID: "get-database-stats",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/database",
+		Summary:     "Get database pool statistics",
+		Description: "Returns the API server's connection pool statistics, for the primary and each read replica, and its slow query threshold. A growing waitCount means queries are queuing for a free connection.",
+		Errors:      []int{http.StatusInternalServerError},
+	}), func(ctx context.Context, input *struct{}) (*DatabaseStatsOutput, error) {
+		stats, err := s.DatabaseStats()
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to get database stats: " + err.Error())
+		}
+		return &DatabaseStatsOutput{Body: *stats}, nil
+	})
+
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID: "set-slow-query-threshold",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/admin/database/slow-query-threshold",
+		Summary:     "Set the slow query threshold",
+		Description: "Sets how long a statement may take before the API server logs it and counts it in deltagov_db_slow_queries_total, until it restarts; DB_SLOW_QUERY_THRESHOLD sets the initial value. Applies to the API server handling the request only, not to other replicas or the ingestor.",
+		Errors:      []int{http.StatusInternalServerError},
+	}), func(ctx context.Context, input *SetSlowQueryThresholdInput) (*DatabaseStatsOutput, error) {
+		database.SetSlowQueryThreshold(time.Duration(input.Body.ThresholdMs) * time.Millisecond)
+		stats, err := s.DatabaseStats()
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to get database stats: " + err.Error())
+		}
+		return &DatabaseStatsOutput{Body: *stats}, nil
+	})
+}
@@ -0,0 +1,392 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/deltas"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/metrics"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// deltaJobMaxErrors caps the per-pair errors kept on a delta job.
+const deltaJobMaxErrors = 50
+
+// deltaJobProgressEvery is how many pairs are processed between progress saves.
+const deltaJobProgressEvery = 10
+
+// ErrInvalidDeltaJob is returned when a recompute request doesn't name
+// exactly one scope.
+var ErrInvalidDeltaJob = errors.New("specify exactly one of billId, fromVersionId and toVersionId, or all")
+
+// ErrDeltaJobNotFound is returned when a delta job doesn't exist.
+var ErrDeltaJobNotFound = errors.New("delta job not found")
+
+// ErrDeltaNotFound is returned when a cached delta doesn't exist.
+var ErrDeltaNotFound = errors.New("delta not found")
+
+// DeltaJobResponse is the API response format for a delta recompute job.
+type DeltaJobResponse struct {
+	ID            uint       `json:"id"`
+	Scope         string     `json:"scope"`
+	BillID        *uint      `json:"billId,omitempty"`
+	FromVersionID *uint      `json:"fromVersionId,omitempty"`
+	ToVersionID   *uint      `json:"toVersionId,omitempty"`
+	Status        string     `json:"status"`
+	Total         int        `json:"total"`
+	Computed      int        `json:"computed"`
+	Skipped       int        `json:"skipped" doc:"Pairs over the diff size limit, left as they were"`
+	Failed        int        `json:"failed"`
+	Errors        []string   `json:"errors"`
+	ErrorMessage  string     `json:"errorMessage,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	StartedAt     *time.Time `json:"startedAt,omitempty"`
+	FinishedAt    *time.Time `json:"finishedAt,omitempty"`
+}
+
+// RecomputeDeltasRequest selects the deltas to recompute.
+type RecomputeDeltasRequest struct {
+	BillID        uint `json:"billId,omitempty" doc:"Recompute every cached delta of this bill and its neighboring version pairs"`
+	FromVersionID uint `json:"fromVersionId,omitempty" doc:"With toVersionId, recompute one version pair"`
+	ToVersionID   uint `json:"toVersionId,omitempty" doc:"With fromVersionId, recompute one version pair"`
+	All           bool `json:"all,omitempty" doc:"Recompute every cached delta"`
+}
+
+// StartDeltaRecompute records a recompute job and runs it in the background.
+func (s *AdminService) StartDeltaRecompute(ctx context.Context, req RecomputeDeltasRequest) (*DeltaJobResponse, error) {
+	job := models.DeltaJob{Status: models.DeltaJobQueued}
+
+	scopes := 0
+	if req.BillID != 0 {
+		scopes++
+		job.Scope, job.BillID = models.DeltaJobScopeBill, &req.BillID
+	}
+	if req.FromVersionID != 0 || req.ToVersionID != 0 {
+		if req.FromVersionID == 0 || req.ToVersionID == 0 {
+			return nil, ErrInvalidDeltaJob
+		}
+		scopes++
+		job.Scope, job.FromVersionID, job.ToVersionID = models.DeltaJobScopePair, &req.FromVersionID, &req.ToVersionID
+	}
+	if req.All {
+		scopes++
+		job.Scope = models.DeltaJobScopeAll
+	}
+	if scopes != 1 {
+		return nil, ErrInvalidDeltaJob
+	}
+
+	db := s.db.WithContext(ctx)
+	switch job.Scope {
+	case models.DeltaJobScopeBill:
+		var count int64
+		if err := db.Model(&models.Bill{}).Where("id = ?", req.BillID).Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("failed to fetch bill: %w", err)
+		}
+		if count == 0 {
+			return nil, ErrBillNotFound
+		}
+	case models.DeltaJobScopePair:
+		var count int64
+		if err := db.Model(&models.Version{}).Where("id IN ?", []uint{req.FromVersionID, req.ToVersionID}).
+			Count(&count).Error; err != nil {
+			return nil, fmt.Errorf("failed to fetch versions: %w", err)
+		}
+		if count != 2 {
+			return nil, ErrVersionNotFound
+		}
+	}
+
+	if err := db.Create(&job).Error; err != nil {
+		return nil, fmt.Errorf("failed to record delta job: %w", err)
+	}
+
+	// The job outlives the request; keep its ID for log correlation
+	jobCtx := logging.WithRequestID(s.jobCtx, logging.RequestID(ctx))
+	s.jobs.Add(1)
+	go func() {
+		defer s.jobs.Done()
+		s.runDeltaJob(jobCtx, job)
+	}()
+
+	resp := deltaJobToResponse(&job)
+	return &resp, nil
+}
+
+// GetDeltaJob returns a delta recompute job.
+func (s *AdminService) GetDeltaJob(ctx context.Context, id uint) (*DeltaJobResponse, error) {
+	var job models.DeltaJob
+	if err := s.db.WithContext(ctx).First(&job, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDeltaJobNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch delta job: %w", err)
+	}
+	resp := deltaJobToResponse(&job)
+	return &resp, nil
+}
+
+// DeleteDelta removes a cached delta so the next request recomputes it.
+func (s *AdminService) DeleteDelta(ctx context.Context, id uint) error {
+	result := s.db.WithContext(ctx).Delete(&models.Delta{}, id)
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete delta: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrDeltaNotFound
+	}
+	return nil
+}
+
+// runDeltaJob recomputes the job's version pairs, saving progress as it
+// goes. Jobs wait for any job already running.
+func (s *AdminService) runDeltaJob(ctx context.Context, job models.DeltaJob) {
+	s.jobRunner.Lock()
+	defer s.jobRunner.Unlock()
+
+	logger := logging.FromContext(ctx).With("delta_job", job.ID, "scope", job.Scope)
+	db := s.db.WithContext(ctx)
+
+	started := time.Now()
+	job.Status, job.StartedAt = models.DeltaJobRunning, &started
+	s.saveDeltaJob(ctx, &job)
+
+	pairs, err := s.deltaJobPairs(ctx, &job)
+	if err == nil {
+		job.Total = len(pairs)
+		logger.Info("recomputing deltas", "pairs", job.Total)
+		for i, pair := range pairs {
+			if err = ctx.Err(); err != nil {
+				break
+			}
+			s.recomputePair(ctx, db, &job, pair)
+			if (i+1)%deltaJobProgressEvery == 0 {
+				s.saveDeltaJob(ctx, &job)
+			}
+		}
+	}
+
+	finished := time.Now()
+	job.FinishedAt = &finished
+	job.Status = models.DeltaJobSucceeded
+	switch {
+	case errors.Is(err, context.Canceled):
+		job.Status, job.ErrorMessage = models.DeltaJobFailed, "interrupted by server shutdown"
+	case err != nil:
+		job.Status, job.ErrorMessage = models.DeltaJobFailed, err.Error()
+	}
+	// Saved without the job context so an interrupted job is still recorded
+	s.saveDeltaJob(context.WithoutCancel(ctx), &job)
+
+	logger.Info("delta job finished", "status", job.Status,
+		"computed", job.Computed, "skipped", job.Skipped, "failed", job.Failed,
+		"duration_ms", finished.Sub(started).Milliseconds())
+}
+
+// recomputePair recomputes one delta and tallies the outcome on job.
+func (s *AdminService) recomputePair(ctx context.Context, db *gorm.DB, job *models.DeltaJob, pair [2]uint) {
+	fail := func(err error) {
+		job.Failed++
+		if len(job.Errors) < deltaJobMaxErrors {
+			job.Errors = append(job.Errors, fmt.Sprintf("%d→%d: %v", pair[0], pair[1], err))
+		}
+	}
+
+	var from, to models.Version
+	if err := db.First(&from, pair[0]).Error; err != nil {
+		fail(fmt.Errorf("failed to fetch version: %w", err))
+		return
+	}
+	if err := db.First(&to, pair[1]).Error; err != nil {
+		fail(fmt.Errorf("failed to fetch version: %w", err))
+		return
+	}
+	if err := rehydrate(&from, &to); err != nil {
+		fail(err)
+		return
+	}
+	if deltas.TooLarge(&from, &to) {
+		job.Skipped++
+		return
+	}
+	if _, err := deltas.Compute(ctx, s.db, &from, &to, false); err != nil {
+		fail(err)
+		return
+	}
+	metrics.DiffComputations.WithLabelValues("recomputed").Inc()
+	job.Computed++
+}
+
+// deltaJobPairs returns the version pairs a job recomputes.
+func (s *AdminService) deltaJobPairs(ctx context.Context, job *models.DeltaJob) ([][2]uint, error) {
+	db := s.db.WithContext(ctx)
+
+	type pairRow struct {
+		VersionAID uint
+		VersionBID uint
+	}
+	var rows []pairRow
+
+	switch job.Scope {
+	case models.DeltaJobScopePair:
+		return [][2]uint{{*job.FromVersionID, *job.ToVersionID}}, nil
+
+	case models.DeltaJobScopeAll:
+		if err := db.Model(&models.Delta{}).Select("version_a_id", "version_b_id").
+			Order("id ASC").Scan(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to list deltas: %w", err)
+		}
+
+	case models.DeltaJobScopeBill:
+		if err := db.Model(&models.Delta{}).Select("deltas.version_a_id", "deltas.version_b_id").
+			Joins("JOIN versions ON versions.id = deltas.version_a_id").
+			Where("versions.bill_id = ?", *job.BillID).
+			Order("deltas.id ASC").Scan(&rows).Error; err != nil {
+			return nil, fmt.Errorf("failed to list deltas: %w", err)
+		}
+		// Neighboring versions too, so diffs that were never cached are filled in
+		var versions []models.Version
+		if err := db.Select("id").Where("bill_id = ?", *job.BillID).
+			Order("fetched_at ASC, id ASC").Find(&versions).Error; err != nil {
+			return nil, fmt.Errorf("failed to fetch versions: %w", err)
+		}
+		for i := 1; i < len(versions); i++ {
+			rows = append(rows, pairRow{VersionAID: versions[i-1].ID, VersionBID: versions[i].ID})
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown delta job scope %q", job.Scope)
+	}
+
+	seen := make(map[[2]uint]bool, len(rows))
+	pairs := make([][2]uint, 0, len(rows))
+	for _, r := range rows {
+		pair := [2]uint{r.VersionAID, r.VersionBID}
+		if !seen[pair] {
+			seen[pair] = true
+			pairs = append(pairs, pair)
+		}
+	}
+	return pairs, nil
+}
+
+// saveDeltaJob persists a job's progress, logging rather than failing the
+// job when the write fails.
+func (s *AdminService) saveDeltaJob(ctx context.Context, job *models.DeltaJob) {
+	if err := s.db.WithContext(ctx).Save(job).Error; err != nil {
+		logging.FromContext(ctx).Warn("failed to save delta job", "delta_job", job.ID, "error", err)
+	}
+}
+
+// deltaJobToResponse converts a DeltaJob model to its API response format.
+func deltaJobToResponse(job *models.DeltaJob) DeltaJobResponse {
+	errs := []string(job.Errors)
+	if errs == nil {
+		errs = []string{}
+	}
+	return DeltaJobResponse{
+		ID:            job.ID,
+		Scope:         job.Scope,
+		BillID:        job.BillID,
+		FromVersionID: job.FromVersionID,
+		ToVersionID:   job.ToVersionID,
+		Status:        job.Status,
+		Total:         job.Total,
+		Computed:      job.Computed,
+		Skipped:       job.Skipped,
+		Failed:        job.Failed,
+		Errors:        errs,
+		ErrorMessage:  job.ErrorMessage,
+		CreatedAt:     job.CreatedAt,
+		StartedAt:     job.StartedAt,
+		FinishedAt:    job.FinishedAt,
+	}
+}
+
+// RecomputeDeltasInput is the request for starting a delta recompute job
+type RecomputeDeltasInput struct {
+	Body RecomputeDeltasRequest
+}
+
+// DeltaJobOutput is the response for a delta recompute job
+type DeltaJobOutput struct {
+	Status int
+	Body   DeltaJobResponse
+}
+
+// GetDeltaJobInput is the request for a delta recompute job
+type GetDeltaJobInput struct {
+	ID uint `path:"id" minimum:"1" doc:"Delta job ID"`
+}
+
+// DeleteDeltaInput is the request for invalidating a cached delta
+type DeleteDeltaInput struct {
+	ID uint `path:"id" minimum:"1" doc:"Delta ID"`
+}
+
+// registerDeltaAdminRoutes registers delta cache maintenance endpoints.
+func registerDeltaAdminRoutes(api huma.API, s *AdminService) {
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID:   "recompute-deltas",
+		Method:        http.MethodPost,
+		Path:          "/api/v1/admin/deltas/recompute",
+		Summary:       "Recompute cached deltas",
+		Description:   "Starts a background job recomputing the cached deltas of one bill, one version pair, or all bills. Poll the returned job for progress.",
+		Errors:        []int{http.StatusNotFound, http.StatusUnprocessableEntity},
+		DefaultStatus: http.StatusAccepted,
+	}), func(ctx context.Context, input *RecomputeDeltasInput) (*DeltaJobOutput, error) {
+		job, err := s.StartDeltaRecompute(ctx, input.Body)
+		switch {
+		case errors.Is(err, ErrInvalidDeltaJob):
+			return nil, huma.Error422UnprocessableEntity(err.Error())
+		case err != nil:
+			return nil, serviceError(err, "failed to start delta job")
+		}
+		auditTarget(ctx, job.ID)
+		return &DeltaJobOutput{Status: http.StatusAccepted, Body: *job}, nil
+	})
+
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID: "get-delta-job",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/deltas/jobs/{id}",
+		Summary:     "Get a delta recompute job",
+		Description: "Returns a delta recompute job's status and progress",
+		Errors:      []int{http.StatusNotFound},
+	}), func(ctx context.Context, input *GetDeltaJobInput) (*DeltaJobOutput, error) {
+		job, err := s.GetDeltaJob(ctx, input.ID)
+		if errors.Is(err, ErrDeltaJobNotFound) {
+			return nil, huma.Error404NotFound("delta job not found")
+		}
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to get delta job: " + err.Error())
+		}
+		return &DeltaJobOutput{Status: http.StatusOK, Body: *job}, nil
+	})
+
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID:   "delete-delta",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/admin/deltas/{id}",
+		Summary:       "Invalidate a cached delta",
+		Description:   "Deletes a cached delta; the next request for that version pair recomputes it",
+		Errors:        []int{http.StatusNotFound},
+		DefaultStatus: http.StatusNoContent,
+	}), func(ctx context.Context, input *DeleteDeltaInput) (*struct{}, error) {
+		err := s.DeleteDelta(ctx, input.ID)
+		if errors.Is(err, ErrDeltaNotFound) {
+			return nil, huma.Error404NotFound("delta not found")
+		}
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to delete delta: " + err.Error())
+		}
+		return nil, nil
+	})
+}
@@ -0,0 +1,217 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ErrIngestFailureNotFound is returned when an ingestion failure doesn't exist.
+var ErrIngestFailureNotFound = errors.New("ingestion failure not found")
+
+// ErrIngestFailureClosed is returned when requeueing or dismissing a
+// failure that is already resolved or dismissed.
+var ErrIngestFailureClosed = errors.New("ingestion failure is already resolved or dismissed")
+
+// IngestFailureResponse is the API response format for a dead-lettered bill.
+type IngestFailureResponse struct {
+	ID            uint           `json:"id"`
+	BillKey       string         `json:"billKey"`
+	Stage         string         `json:"stage" doc:"bill or versions"`
+	Status        string         `json:"status" doc:"pending, exhausted, resolved, or dismissed"`
+	ErrorClass    string         `json:"errorClass"`
+	LastError     string         `json:"lastError"`
+	Attempts      int            `json:"attempts"`
+	NextAttemptAt time.Time      `json:"nextAttemptAt"`
+	Payload       map[string]any `json:"payload" doc:"The Congress.gov bill listing as ingested"`
+	RunID         *uint          `json:"runId,omitempty"`
+	ResolvedAt    *time.Time     `json:"resolvedAt,omitempty"`
+	CreatedAt     time.Time      `json:"createdAt"`
+	UpdatedAt     time.Time      `json:"updatedAt"`
+}
+
+// IngestFailureList is a page of ingestion failures.
+type IngestFailureList struct {
+	Failures []IngestFailureResponse `json:"failures"`
+	Total    int64                   `json:"total"`
+	Limit    int                     `json:"limit"`
+	Offset   int                     `json:"offset"`
+}
+
+// ListIngestFailures returns ingestion failures with the given status, or
+// open (pending and exhausted) ones when status is empty, most recently
+// updated first.
+func (s *AdminService) ListIngestFailures(ctx context.Context, status string, limit, offset int) (*IngestFailureList, error) {
+	query := s.db.WithContext(ctx).Model(&models.IngestFailure{})
+	if status == "" {
+		query = query.Where("status IN ?", []string{models.IngestFailurePending, models.IngestFailureExhausted})
+	} else {
+		query = query.Where("status = ?", status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count ingestion failures: %w", err)
+	}
+
+	var failures []models.IngestFailure
+	if err := query.Order("updated_at DESC, id DESC").Limit(limit).Offset(offset).Find(&failures).Error; err != nil {
+		return nil, fmt.Errorf("failed to list ingestion failures: %w", err)
+	}
+
+	responses := make([]IngestFailureResponse, len(failures))
+	for i := range failures {
+		responses[i] = ingestFailureToResponse(&failures[i])
+	}
+	return &IngestFailureList{Failures: responses, Total: total, Limit: limit, Offset: offset}, nil
+}
+
+// RequeueIngestFailure makes an open failure due for retry now, giving an
+// exhausted one a fresh set of attempts.
+func (s *AdminService) RequeueIngestFailure(ctx context.Context, id uint) (*IngestFailureResponse, error) {
+	return s.updateIngestFailure(ctx, id, map[string]any{
+		"status":          models.IngestFailurePending,
+		"attempts":        0,
+		"next_attempt_at": time.Now(),
+	})
+}
+
+// DismissIngestFailure closes an open failure without retrying it.
+func (s *AdminService) DismissIngestFailure(ctx context.Context, id uint) (*IngestFailureResponse, error) {
+	return s.updateIngestFailure(ctx, id, map[string]any{
+		"status":      models.IngestFailureDismissed,
+		"resolved_at": time.Now(),
+	})
+}
+
+// updateIngestFailure applies updates to an open failure and returns it.
+func (s *AdminService) updateIngestFailure(ctx context.Context, id uint, updates map[string]any) (*IngestFailureResponse, error) {
+	result := s.db.WithContext(ctx).Model(&models.IngestFailure{}).
+		Where("id = ? AND status IN ?", id, []string{models.IngestFailurePending, models.IngestFailureExhausted}).
+		Updates(updates)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to update ingestion failure: %w", result.Error)
+	}
+
+	var failure models.IngestFailure
+	if err := s.db.WithContext(ctx).Limit(1).Find(&failure, id).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch ingestion failure: %w", err)
+	}
+	if failure.ID == 0 {
+		return nil, ErrIngestFailureNotFound
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrIngestFailureClosed
+	}
+	resp := ingestFailureToResponse(&failure)
+	return &resp, nil
+}
+
+// ingestFailureToResponse converts an IngestFailure model to its API response format.
+func ingestFailureToResponse(f *models.IngestFailure) IngestFailureResponse {
+	var payload map[string]any
+	_ = json.Unmarshal(f.Payload, &payload) // Written by the ingestor; left empty if unreadable
+	return IngestFailureResponse{
+		ID:            f.ID,
+		BillKey:       f.BillKey,
+		Stage:         f.Stage,
+		Status:        f.Status,
+		ErrorClass:    f.ErrorClass,
+		LastError:     f.LastError,
+		Attempts:      f.Attempts,
+		NextAttemptAt: f.NextAttemptAt,
+		Payload:       payload,
+		RunID:         f.RunID,
+		ResolvedAt:    f.ResolvedAt,
+		CreatedAt:     f.CreatedAt,
+		UpdatedAt:     f.UpdatedAt,
+	}
+}
+
+// ListIngestFailuresInput is the request for listing ingestion failures
+type ListIngestFailuresInput struct {
+	Status string `query:"status" enum:"pending,exhausted,resolved,dismissed" doc:"Only failures with this status (default: pending and exhausted)"`
+	Limit  int    `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"Number of results per page (max 100)"`
+	Offset int    `query:"offset" default:"0" minimum:"0" doc:"Pagination offset"`
+}
+
+// ListIngestFailuresOutput is the response for listing ingestion failures
+type ListIngestFailuresOutput struct {
+	Body IngestFailureList
+}
+
+// IngestFailureInput is the request for acting on an ingestion failure
+type IngestFailureInput struct {
+	ID uint `path:"id" minimum:"1" doc:"Ingestion failure ID"`
+}
+
+// IngestFailureOutput is the response for acting on an ingestion failure
+type IngestFailureOutput struct {
+	Body IngestFailureResponse
+}
+
+// registerFailureAdminRoutes registers the ingestion dead-letter endpoints.
+func registerFailureAdminRoutes(api huma.API, s *AdminService) {
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID: "list-ingestion-failures",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/ingest-failures",
+		Summary:     "List ingestion failures",
+		Description: "Returns dead-lettered bills whose ingestion failed, with the error class, attempts, and the listing they were ingested from. The ingestor retries pending failures with backoff after each run.",
+		Errors:      []int{http.StatusInternalServerError},
+	}), func(ctx context.Context, input *ListIngestFailuresInput) (*ListIngestFailuresOutput, error) {
+		failures, err := s.ListIngestFailures(ctx, input.Status, input.Limit, input.Offset)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list ingestion failures: " + err.Error())
+		}
+		return &ListIngestFailuresOutput{Body: *failures}, nil
+	})
+
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID: "requeue-ingestion-failure",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/admin/ingest-failures/{id}/requeue",
+		Summary:     "Requeue an ingestion failure",
+		Description: "Makes an open failure due for retry on the ingestor's next run, with its attempts reset",
+		Errors:      []int{http.StatusNotFound, http.StatusConflict},
+	}), func(ctx context.Context, input *IngestFailureInput) (*IngestFailureOutput, error) {
+		failure, err := s.RequeueIngestFailure(ctx, input.ID)
+		if err != nil {
+			return nil, ingestFailureError(err)
+		}
+		return &IngestFailureOutput{Body: *failure}, nil
+	})
+
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID: "dismiss-ingestion-failure",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/admin/ingest-failures/{id}/dismiss",
+		Summary:     "Dismiss an ingestion failure",
+		Description: "Closes an open failure so it is no longer retried",
+		Errors:      []int{http.StatusNotFound, http.StatusConflict},
+	}), func(ctx context.Context, input *IngestFailureInput) (*IngestFailureOutput, error) {
+		failure, err := s.DismissIngestFailure(ctx, input.ID)
+		if err != nil {
+			return nil, ingestFailureError(err)
+		}
+		return &IngestFailureOutput{Body: *failure}, nil
+	})
+}
+
+// ingestFailureError converts an error from updating a failure to its response.
+func ingestFailureError(err error) error {
+	switch {
+	case errors.Is(err, ErrIngestFailureNotFound):
+		return huma.Error404NotFound(err.Error())
+	case errors.Is(err, ErrIngestFailureClosed):
+		return huma.Error409Conflict(err.Error())
+	}
+	return huma.Error500InternalServerError("failed to update ingestion failure: " + err.Error())
+}
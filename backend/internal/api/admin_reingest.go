@@ -0,0 +1,198 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ErrInvalidReingestJob is returned when a re-ingestion request has no
+// filter, which would refresh every bill.
+var ErrInvalidReingestJob = errors.New("specify at least one of congress, billType, updatedSince, or billIds")
+
+// ErrReingestJobNotFound is returned when a re-ingestion job doesn't
+// exist.
+var ErrReingestJobNotFound = errors.New("reingest job not found")
+
+// ReingestRequest selects the bills to re-ingest. Filters combine with
+// AND.
+type ReingestRequest struct {
+	Congress     int    `json:"congress,omitempty" minimum:"0" doc:"Only bills of this congress"`
+	BillType     string `json:"billType,omitempty" doc:"Only bills of this type, e.g., hr"`
+	UpdatedSince string `json:"updatedSince,omitempty" format:"date" doc:"Only bills Congress.gov last updated on or after this date (YYYY-MM-DD)"`
+	BillIDs      []uint `json:"billIds,omitempty" maxItems:"1000" doc:"Only these bills (database IDs)"`
+}
+
+// ReingestJobResponse is the API response format for a re-ingestion job.
+type ReingestJobResponse struct {
+	ID           uint       `json:"id"`
+	Congress     int        `json:"congress,omitempty"`
+	BillType     string     `json:"billType,omitempty"`
+	UpdatedSince string     `json:"updatedSince,omitempty"`
+	BillIDs      []uint     `json:"billIds,omitempty" doc:"The billIds filter, if given"`
+	Status       string     `json:"status" enum:"queued,running,succeeded"`
+	Total        int        `json:"total" doc:"Bills matching the filter when the job was queued"`
+	Processed    int        `json:"processed" doc:"Bills refreshed or failed so far"`
+	Failed       int        `json:"failed"`
+	Progress     float64    `json:"progress" doc:"Processed / total, from 0 to 1"`
+	Errors       []string   `json:"errors"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	StartedAt    *time.Time `json:"startedAt,omitempty"`
+	FinishedAt   *time.Time `json:"finishedAt,omitempty"`
+}
+
+// StartReingest queues a job refreshing the public federal bills matching
+// req, for the ingestor to work through. A filter matching no bills
+// yields a job that has already succeeded.
+func (s *AdminService) StartReingest(ctx context.Context, req ReingestRequest) (*ReingestJobResponse, error) {
+	if req.Congress == 0 && req.BillType == "" && req.UpdatedSince == "" && len(req.BillIDs) == 0 {
+		return nil, ErrInvalidReingestJob
+	}
+
+	query := s.db.WithContext(ctx).Model(&models.Bill{}).
+		Where("jurisdiction = ? AND tenant_id = 0", models.JurisdictionFederal)
+	if req.Congress > 0 {
+		query = query.Where("congress = ?", req.Congress)
+	}
+	if req.BillType != "" {
+		query = query.Where("LOWER(bill_type) = ?", strings.ToLower(req.BillType))
+	}
+	if req.UpdatedSince != "" {
+		query = query.Where("update_date >= ?", req.UpdatedSince)
+	}
+	if len(req.BillIDs) > 0 {
+		query = query.Where("id IN ?", req.BillIDs)
+	}
+	var billIDs []uint
+	if err := query.Order("id ASC").Pluck("id", &billIDs).Error; err != nil {
+		return nil, fmt.Errorf("failed to find bills: %w", err)
+	}
+
+	job := models.ReingestJob{
+		Congress:     req.Congress,
+		BillType:     req.BillType,
+		UpdatedSince: req.UpdatedSince,
+		RequestedIDs: req.BillIDs,
+		BillIDs:      billIDs,
+		Status:       models.ReingestJobQueued,
+		Total:        len(billIDs),
+	}
+	if len(billIDs) == 0 {
+		now := time.Now()
+		job.Status, job.FinishedAt = models.ReingestJobSucceeded, &now
+	}
+	if err := s.db.WithContext(ctx).Create(&job).Error; err != nil {
+		return nil, fmt.Errorf("failed to record reingest job: %w", err)
+	}
+
+	resp := reingestJobToResponse(&job)
+	return &resp, nil
+}
+
+// GetReingestJob returns a re-ingestion job.
+func (s *AdminService) GetReingestJob(ctx context.Context, id uint) (*ReingestJobResponse, error) {
+	var job models.ReingestJob
+	err := s.db.WithContext(ctx).Omit("bill_ids").First(&job, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrReingestJobNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reingest job: %w", err)
+	}
+	resp := reingestJobToResponse(&job)
+	return &resp, nil
+}
+
+// reingestJobToResponse converts a ReingestJob model to its API response
+// format.
+func reingestJobToResponse(job *models.ReingestJob) ReingestJobResponse {
+	errs := []string(job.Errors)
+	if errs == nil {
+		errs = []string{}
+	}
+	resp := ReingestJobResponse{
+		ID:           job.ID,
+		Congress:     job.Congress,
+		BillType:     job.BillType,
+		UpdatedSince: job.UpdatedSince,
+		BillIDs:      job.RequestedIDs,
+		Status:       job.Status,
+		Total:        job.Total,
+		Processed:    job.Processed,
+		Failed:       job.Failed,
+		Progress:     1,
+		Errors:       errs,
+		CreatedAt:    job.CreatedAt,
+		StartedAt:    job.StartedAt,
+		FinishedAt:   job.FinishedAt,
+	}
+	if job.Total > 0 {
+		resp.Progress = float64(job.Processed) / float64(job.Total)
+	}
+	return resp
+}
+
+// StartReingestInput is the request for queueing a re-ingestion job
+type StartReingestInput struct {
+	Body ReingestRequest
+}
+
+// ReingestJobOutput is the response for a re-ingestion job
+type ReingestJobOutput struct {
+	Status int
+	Body   ReingestJobResponse
+}
+
+// GetReingestJobInput is the request for a re-ingestion job
+type GetReingestJobInput struct {
+	ID uint `path:"id" minimum:"1" doc:"Reingest job ID"`
+}
+
+// registerReingestAdminRoutes registers bulk re-ingestion endpoints.
+func registerReingestAdminRoutes(api huma.API, s *AdminService) {
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID:   "start-reingest",
+		Method:        http.MethodPost,
+		Path:          "/api/v1/admin/reingest",
+		Summary:       "Re-ingest bills by filter",
+		Description:   "Queues a job refreshing the stored federal bills matching a filter from Congress.gov: detail, related data, and every text version, as if each had changed. The ingestor works through it a batch per polling cycle; poll the returned job for progress.",
+		Errors:        []int{http.StatusUnprocessableEntity},
+		DefaultStatus: http.StatusAccepted,
+	}), func(ctx context.Context, input *StartReingestInput) (*ReingestJobOutput, error) {
+		job, err := s.StartReingest(ctx, input.Body)
+		switch {
+		case errors.Is(err, ErrInvalidReingestJob):
+			return nil, huma.Error422UnprocessableEntity(err.Error())
+		case err != nil:
+			return nil, huma.Error500InternalServerError("failed to queue reingest job: " + err.Error())
+		}
+		auditTarget(ctx, job.ID)
+		return &ReingestJobOutput{Status: http.StatusAccepted, Body: *job}, nil
+	})
+
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID: "get-reingest-job",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/reingest/{id}",
+		Summary:     "Get a reingest job",
+		Description: "Returns a re-ingestion job's status and progress",
+		Errors:      []int{http.StatusNotFound},
+	}), func(ctx context.Context, input *GetReingestJobInput) (*ReingestJobOutput, error) {
+		job, err := s.GetReingestJob(ctx, input.ID)
+		if errors.Is(err, ErrReingestJobNotFound) {
+			return nil, huma.Error404NotFound("reingest job not found")
+		}
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to get reingest job: " + err.Error())
+		}
+		return &ReingestJobOutput{Status: http.StatusOK, Body: *job}, nil
+	})
+}
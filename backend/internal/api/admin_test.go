@@ -0,0 +1,145 @@
+package api_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/api"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// createVersion stores a version of a bill with the given text.
+func createVersion(t *testing.T, db *gorm.DB, bill *models.Bill, code, text string, fetchedAt time.Time) *models.Version {
+	t.Helper()
+	version := models.Version{BillID: bill.ID, VersionCode: code, ContentHash: code + "-" + text,
+		TextContent: text, PlainText: text, TextSize: len(text), FetchedAt: fetchedAt}
+	if err := db.Create(&version).Error; err != nil {
+		t.Fatalf("Failed to create version: %v", err)
+	}
+	return &version
+}
+
+// TestAdminToken verifies admin endpoints require the admin token, or an
+// admin's API key, and that the token is refused while unset.
+func TestAdminToken(t *testing.T) {
+	const path = "/api/v1/admin/ingestions"
+
+	t.Run("set", func(t *testing.T) {
+		ts := newTestServer(t, "s3cret")
+		tests := []struct {
+			name   string
+			header http.Header
+			want   int
+		}{
+			{"missing", nil, http.StatusUnauthorized},
+			{"wrong", bearer("wrong!"), http.StatusUnauthorized},
+			{"prefix", bearer("s3c"), http.StatusUnauthorized},
+			{"longer", bearer("s3cret2"), http.StatusUnauthorized},
+			{"not bearer", http.Header{"Authorization": {"s3cret"}}, http.StatusUnauthorized},
+			{"valid", bearer("s3cret"), http.StatusOK},
+		}
+		for _, tt := range tests {
+			if status, body := ts.request(t, http.MethodGet, path, tt.header, nil); status != tt.want {
+				t.Errorf("%s token: status = %d, want %d: %s", tt.name, status, tt.want, body)
+			}
+		}
+	})
+
+	t.Run("unset", func(t *testing.T) {
+		ts := newTestServer(t, "")
+		for _, token := range []string{"s3cret", "anything"} {
+			if status, body := ts.request(t, http.MethodGet, path, bearer(token), nil); status != http.StatusForbidden {
+				t.Errorf("Token %q with the admin token unset: status = %d, want 403: %s", token, status, body)
+			}
+		}
+		if status, body := ts.request(t, http.MethodGet, path, apiKey(ts.addUser(t, models.UserRoleAdmin, nil)), nil); status != http.StatusOK {
+			t.Errorf("Admin API key with the admin token unset: status = %d, want 200: %s", status, body)
+		}
+	})
+}
+
+// TestDeltaJobs verifies recompute requests are validated, that a job
+// recomputes its pair in the background, and that cached deltas can be
+// invalidated.
+func TestDeltaJobs(t *testing.T) {
+	ts := newTestServer(t, "s3cret")
+	auth := bearer("s3cret")
+	bill := createBill(t, ts.db, 1)
+	from := createVersion(t, ts.db, bill, "IH", "SEC. 1. FUNDING.\nThere is appropriated $500.\n", time.Now().Add(-time.Hour))
+	to := createVersion(t, ts.db, bill, "EH", "SEC. 1. FUNDING.\nThere is appropriated $750.\n", time.Now())
+
+	invalid := []api.RecomputeDeltasRequest{
+		{},
+		{FromVersionID: from.ID},
+		{BillID: bill.ID, All: true},
+	}
+	for _, req := range invalid {
+		if status, body := ts.request(t, http.MethodPost, "/api/v1/admin/deltas/recompute", auth, req); status != http.StatusUnprocessableEntity {
+			t.Errorf("Recompute %+v: status = %d, want 422: %s", req, status, body)
+		}
+	}
+	if status, _ := ts.request(t, http.MethodPost, "/api/v1/admin/deltas/recompute", auth,
+		api.RecomputeDeltasRequest{BillID: 999}); status != http.StatusNotFound {
+		t.Errorf("Recompute of a missing bill: status = %d, want 404", status)
+	}
+	if status, _ := ts.request(t, http.MethodPost, "/api/v1/admin/deltas/recompute", auth,
+		api.RecomputeDeltasRequest{FromVersionID: from.ID, ToVersionID: 999}); status != http.StatusNotFound {
+		t.Errorf("Recompute of a missing version: status = %d, want 404", status)
+	}
+
+	status, body := ts.request(t, http.MethodPost, "/api/v1/admin/deltas/recompute", auth,
+		api.RecomputeDeltasRequest{FromVersionID: from.ID, ToVersionID: to.ID})
+	if status != http.StatusAccepted {
+		t.Fatalf("Recompute: status = %d, want 202: %s", status, body)
+	}
+	var job api.DeltaJobResponse
+	if err := json.Unmarshal(body, &job); err != nil {
+		t.Fatalf("Failed to decode job: %v", err)
+	}
+	if job.Scope != models.DeltaJobScopePair || job.Status != models.DeltaJobQueued {
+		t.Errorf("New job = %+v, want a queued pair job", job)
+	}
+
+	jobPath := fmt.Sprintf("/api/v1/admin/deltas/jobs/%d", job.ID)
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		status, body := ts.request(t, http.MethodGet, jobPath, auth, nil)
+		if status != http.StatusOK {
+			t.Fatalf("Get job: status = %d, want 200: %s", status, body)
+		}
+		if err := json.Unmarshal(body, &job); err != nil {
+			t.Fatalf("Failed to decode job: %v", err)
+		}
+		if job.FinishedAt != nil || time.Now().After(deadline) {
+			break
+		}
+	}
+	if job.Status != models.DeltaJobSucceeded || job.Total != 1 || job.Computed != 1 || job.Failed != 0 {
+		t.Fatalf("Finished job = %+v, want one pair computed", job)
+	}
+	if status, _ := ts.request(t, http.MethodGet, "/api/v1/admin/deltas/jobs/999", auth, nil); status != http.StatusNotFound {
+		t.Errorf("Get missing job: status = %d, want 404", status)
+	}
+
+	var delta models.Delta
+	if err := ts.db.Where("version_a_id = ? AND version_b_id = ?", from.ID, to.ID).First(&delta).Error; err != nil {
+		t.Fatalf("Recomputed delta not stored: %v", err)
+	}
+	deltaPath := fmt.Sprintf("/api/v1/admin/deltas/%d", delta.ID)
+	if status, body := ts.request(t, http.MethodDelete, deltaPath, nil, nil); status != http.StatusUnauthorized {
+		t.Errorf("Delete without the token: status = %d, want 401: %s", status, body)
+	}
+	if status, body := ts.request(t, http.MethodDelete, deltaPath, auth, nil); status != http.StatusNoContent {
+		t.Errorf("Delete: status = %d, want 204: %s", status, body)
+	}
+	if err := ts.db.First(&models.Delta{}, delta.ID).Error; err != gorm.ErrRecordNotFound {
+		t.Errorf("Deleted delta still stored: %v", err)
+	}
+	if status, _ := ts.request(t, http.MethodDelete, deltaPath, auth, nil); status != http.StatusNotFound {
+		t.Errorf("Second delete: status = %d, want 404", status)
+	}
+}
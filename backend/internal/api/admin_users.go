@@ -0,0 +1,233 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ErrUserNotFound is returned when a user does not exist.
+var ErrUserNotFound = errors.New("user not found")
+
+// AdminUserResponse is a user as admins see it. The API key is never
+// returned again.
+type AdminUserResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Role      string    `json:"role" enum:"reader,editor,admin"`
+	TenantID  *uint     `json:"tenantId,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// user returns a user by ID.
+func (s *AdminService) user(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	if err := s.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	return &user, nil
+}
+
+// watchlists returns a WatchlistService for managing other users'
+// watchlists and keyword alerts.
+func (s *AdminService) watchlists() *WatchlistService {
+	return &WatchlistService{db: s.db}
+}
+
+// SetUserRole changes a user's role.
+func (s *AdminService) SetUserRole(ctx context.Context, id uint, role string) (*AdminUserResponse, error) {
+	if !models.ValidUserRole(role) {
+		return nil, fmt.Errorf("unknown role %q", role)
+	}
+	user, err := s.user(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	// Update sets user.Role, so the old role is kept first
+	before := map[string]any{"role": user.Role}
+	if err := s.db.WithContext(ctx).Model(user).Update("role", role).Error; err != nil {
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+	auditChange(ctx, before, map[string]any{"role": role})
+	return &AdminUserResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Role:      user.Role,
+		TenantID:  user.TenantID,
+		CreatedAt: user.CreatedAt,
+	}, nil
+}
+
+// SetUserRoleInput is the request for changing a user's role
+type SetUserRoleInput struct {
+	ID   uint `path:"id" minimum:"1" doc:"User ID"`
+	Body struct {
+		Role string `json:"role" enum:"reader,editor,admin" doc:"New role"`
+	}
+}
+
+// AdminUserOutput is the response for a user
+type AdminUserOutput struct {
+	Body AdminUserResponse
+}
+
+// UserInput names a user
+type UserInput struct {
+	ID uint `path:"id" minimum:"1" doc:"User ID"`
+}
+
+// DeleteUserSearchInput is the request for deleting a user's saved search
+type DeleteUserSearchInput struct {
+	UserInput
+	SearchID uint `path:"searchId" minimum:"1" doc:"Saved search ID"`
+}
+
+// UnwatchUserBillInput is the request for removing a bill from a user's
+// watchlist
+type UnwatchUserBillInput struct {
+	UserInput
+	BillID uint `path:"billId" minimum:"1" doc:"Bill ID"`
+}
+
+// DeleteUserAlertInput is the request for deleting a user's keyword alert
+type DeleteUserAlertInput struct {
+	UserInput
+	AlertID uint `path:"alertId" minimum:"1" doc:"Keyword alert ID"`
+}
+
+// userError converts a user lookup error to an HTTP error.
+func userError(err error, action string) error {
+	if errors.Is(err, ErrUserNotFound) {
+		return apiError(http.StatusNotFound, CodeUserNotFound, err.Error())
+	}
+	return huma.Error500InternalServerError(action + ": " + err.Error())
+}
+
+// registerUserAdminRoutes registers the endpoints for managing users' roles
+// and their watchlists and keyword alerts.
+func registerUserAdminRoutes(api huma.API, s *AdminService) {
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID: "set-user-role",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/admin/users/{id}/role",
+		Summary:     "Set a user's role",
+		Description: "Changes what a user's API key may do: readers read and keep a watchlist, editors also upload documents and drafts, and admins also use the admin endpoints",
+		Errors:      []int{http.StatusNotFound},
+	}), func(ctx context.Context, input *SetUserRoleInput) (*AdminUserOutput, error) {
+		user, err := s.SetUserRole(ctx, input.ID, input.Body.Role)
+		if err != nil {
+			return nil, userError(err, "failed to set role")
+		}
+		return &AdminUserOutput{Body: *user}, nil
+	})
+
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID: "get-user-watchlist",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/users/{id}/watchlist",
+		Summary:     "Get a user's watchlist",
+		Description: "Returns a user's saved searches and watched bills",
+		Errors:      []int{http.StatusNotFound},
+	}), func(ctx context.Context, input *UserInput) (*GetWatchlistOutput, error) {
+		user, err := s.user(ctx, input.ID)
+		if err != nil {
+			return nil, userError(err, "failed to get watchlist")
+		}
+		watchlist, err := s.watchlists().GetWatchlist(ctx, user)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to get watchlist: " + err.Error())
+		}
+		return &GetWatchlistOutput{Body: *watchlist}, nil
+	})
+
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID:   "delete-user-saved-search",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/admin/users/{id}/watchlist/searches/{searchId}",
+		Summary:       "Delete a user's saved search",
+		Errors:        []int{http.StatusNotFound},
+		DefaultStatus: http.StatusNoContent,
+	}), func(ctx context.Context, input *DeleteUserSearchInput) (*struct{}, error) {
+		user, err := s.user(ctx, input.ID)
+		if err != nil {
+			return nil, userError(err, "failed to delete saved search")
+		}
+		err = s.watchlists().DeleteSearch(ctx, user, input.SearchID)
+		switch {
+		case errors.Is(err, ErrSavedSearchNotFound):
+			return nil, huma.Error404NotFound("saved search not found")
+		case err != nil:
+			return nil, huma.Error500InternalServerError("failed to delete saved search: " + err.Error())
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID:   "unwatch-user-bill",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/admin/users/{id}/watchlist/bills/{billId}",
+		Summary:       "Remove a bill from a user's watchlist",
+		Errors:        []int{http.StatusNotFound},
+		DefaultStatus: http.StatusNoContent,
+	}), func(ctx context.Context, input *UnwatchUserBillInput) (*struct{}, error) {
+		user, err := s.user(ctx, input.ID)
+		if err != nil {
+			return nil, userError(err, "failed to unwatch bill")
+		}
+		err = s.watchlists().UnwatchBill(ctx, user, input.BillID)
+		switch {
+		case errors.Is(err, ErrBillNotFound):
+			return nil, apiError(http.StatusNotFound, CodeBillNotFound, "bill is not on the watchlist")
+		case err != nil:
+			return nil, huma.Error500InternalServerError("failed to unwatch bill: " + err.Error())
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID: "list-user-keyword-alerts",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/users/{id}/alerts",
+		Summary:     "List a user's keyword alerts",
+		Errors:      []int{http.StatusNotFound},
+	}), func(ctx context.Context, input *UserInput) (*ListAlertsOutput, error) {
+		user, err := s.user(ctx, input.ID)
+		if err != nil {
+			return nil, userError(err, "failed to list keyword alerts")
+		}
+		list, err := s.watchlists().ListAlerts(ctx, user)
+		if err != nil {
+			return nil, alertError(err, "failed to list keyword alerts")
+		}
+		return &ListAlertsOutput{Body: *list}, nil
+	})
+
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID:   "delete-user-keyword-alert",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/admin/users/{id}/alerts/{alertId}",
+		Summary:       "Delete a user's keyword alert",
+		Description:   "Deletes one of a user's keyword alerts and its matches",
+		Errors:        []int{http.StatusNotFound},
+		DefaultStatus: http.StatusNoContent,
+	}), func(ctx context.Context, input *DeleteUserAlertInput) (*struct{}, error) {
+		user, err := s.user(ctx, input.ID)
+		if err != nil {
+			return nil, userError(err, "failed to delete keyword alert")
+		}
+		if err := s.watchlists().DeleteAlert(ctx, user, input.AlertID); err != nil {
+			return nil, alertError(err, "failed to delete keyword alert")
+		}
+		return nil, nil
+	})
+}
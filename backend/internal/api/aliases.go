@@ -0,0 +1,202 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ErrAliasNotFound is returned when an alias doesn't exist or belongs to another bill.
+var ErrAliasNotFound = errors.New("alias not found")
+
+// ErrAliasExists is returned when adding an alias a bill already has.
+var ErrAliasExists = errors.New("bill already has this alias")
+
+// BillAliasResponse is another name a bill is known by.
+type BillAliasResponse struct {
+	ID        uint      `json:"id"`
+	Alias     string    `json:"alias"`
+	Source    string    `json:"source" doc:"short_title, popular_title, or admin"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// BillAliasesResponse lists a bill's aliases.
+type BillAliasesResponse struct {
+	BillID  uint                `json:"billId"`
+	Aliases []BillAliasResponse `json:"aliases"`
+}
+
+// GetBillAliases returns the names a bill is known by besides its title,
+// in the order they were added.
+func (s *BillService) GetBillAliases(ctx context.Context, billID uint) (*BillAliasesResponse, error) {
+	if err := s.requireBill(ctx, billID); err != nil {
+		return nil, err
+	}
+
+	var aliases []models.BillAlias
+	if err := s.db.WithContext(ctx).Where("bill_id = ?", billID).Order("id ASC").Find(&aliases).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch aliases: %w", err)
+	}
+
+	response := &BillAliasesResponse{BillID: billID, Aliases: make([]BillAliasResponse, len(aliases))}
+	for i := range aliases {
+		response.Aliases[i] = aliasToResponse(&aliases[i])
+	}
+	return response, nil
+}
+
+// AddBillAlias adds an operator-curated alias, such as "CR", to a bill.
+// Aliases are unique per bill regardless of case.
+func (s *AdminService) AddBillAlias(ctx context.Context, billID uint, alias string) (*BillAliasResponse, error) {
+	alias = strings.Join(strings.Fields(alias), " ")
+	db := s.db.WithContext(ctx)
+
+	var bill models.Bill
+	if err := db.Select("id").First(&bill, billID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBillNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch bill: %w", err)
+	}
+
+	var existing int64
+	if err := db.Model(&models.BillAlias{}).Where("bill_id = ? AND LOWER(alias) = LOWER(?)", billID, alias).
+		Count(&existing).Error; err != nil {
+		return nil, fmt.Errorf("failed to check aliases: %w", err)
+	}
+	if existing > 0 {
+		return nil, ErrAliasExists
+	}
+
+	row := models.BillAlias{BillID: billID, Alias: alias, Source: models.AliasSourceAdmin}
+	result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&row)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to add alias: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrAliasExists // Added concurrently
+	}
+	resp := aliasToResponse(&row)
+	return &resp, nil
+}
+
+// DeleteBillAlias removes one of a bill's aliases. Aliases from Congress.gov
+// titles return on the bill's next sync.
+func (s *AdminService) DeleteBillAlias(ctx context.Context, billID, aliasID uint) error {
+	result := s.db.WithContext(ctx).Where("id = ? AND bill_id = ?", aliasID, billID).Delete(&models.BillAlias{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete alias: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrAliasNotFound
+	}
+	return nil
+}
+
+// aliasToResponse converts a BillAlias model to its API response format.
+func aliasToResponse(a *models.BillAlias) BillAliasResponse {
+	return BillAliasResponse{ID: a.ID, Alias: a.Alias, Source: a.Source, CreatedAt: a.CreatedAt}
+}
+
+// GetBillAliasesInput is the request for a bill's aliases
+type GetBillAliasesInput struct {
+	ID uint `path:"id" minimum:"1" doc:"Bill ID"`
+}
+
+// GetBillAliasesOutput is the response for a bill's aliases
+type GetBillAliasesOutput struct {
+	Body BillAliasesResponse
+}
+
+// AddBillAliasInput is the request for adding an alias to a bill
+type AddBillAliasInput struct {
+	ID   uint `path:"id" minimum:"1" doc:"Bill ID"`
+	Body struct {
+		Alias string `json:"alias" minLength:"1" maxLength:"200" pattern:"\\S" example:"CR" doc:"Name the bill is known by"`
+	}
+}
+
+// AddBillAliasOutput is the response for adding an alias to a bill
+type AddBillAliasOutput struct {
+	Body BillAliasResponse
+}
+
+// DeleteBillAliasInput is the request for removing an alias from a bill
+type DeleteBillAliasInput struct {
+	ID      uint `path:"id" minimum:"1" doc:"Bill ID"`
+	AliasID uint `path:"aliasId" minimum:"1" doc:"Alias ID"`
+}
+
+// registerAliasRoute registers the endpoint listing a bill's aliases.
+func registerAliasRoute(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-bill-aliases",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{id}/aliases",
+		Summary:     "List a bill's aliases",
+		Description: "Returns the names a bill is known by besides its official title: its short and popular titles from Congress.gov and nicknames curated by operators. Bill search matches aliases as well as titles.",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *GetBillAliasesInput) (*GetBillAliasesOutput, error) {
+		aliases, err := s.GetBillAliases(ctx, input.ID)
+		if err != nil {
+			return nil, serviceError(err, "failed to list aliases")
+		}
+		return &GetBillAliasesOutput{Body: *aliases}, nil
+	})
+}
+
+// registerAliasAdminRoutes registers the endpoints for curating aliases.
+func registerAliasAdminRoutes(api huma.API, s *AdminService) {
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID:   "add-bill-alias",
+		Method:        http.MethodPost,
+		Path:          "/api/v1/admin/bills/{id}/aliases",
+		Summary:       "Add an alias to a bill",
+		Description:   "Adds a name the bill is known by, such as \"CR\" or \"NDAA\", for bill search to match. Curated aliases are kept when the bill's titles are synced.",
+		Errors:        []int{http.StatusNotFound, http.StatusConflict},
+		DefaultStatus: http.StatusCreated,
+	}), func(ctx context.Context, input *AddBillAliasInput) (*AddBillAliasOutput, error) {
+		alias, err := s.AddBillAlias(ctx, input.ID, input.Body.Alias)
+		if err != nil {
+			return nil, aliasError(err, "failed to add alias")
+		}
+		auditChange(ctx, nil, map[string]any{"alias_id": alias.ID, "alias": alias.Alias})
+		return &AddBillAliasOutput{Body: *alias}, nil
+	})
+
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID:   "delete-bill-alias",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/admin/bills/{id}/aliases/{aliasId}",
+		Summary:       "Remove an alias from a bill",
+		Description:   "Removes one of a bill's aliases. Short and popular titles return on the bill's next sync.",
+		Errors:        []int{http.StatusNotFound},
+		DefaultStatus: http.StatusNoContent,
+	}), func(ctx context.Context, input *DeleteBillAliasInput) (*struct{}, error) {
+		if err := s.DeleteBillAlias(ctx, input.ID, input.AliasID); err != nil {
+			return nil, aliasError(err, "failed to delete alias")
+		}
+		return nil, nil
+	})
+}
+
+// aliasError converts an alias service error to an HTTP error.
+func aliasError(err error, action string) error {
+	switch {
+	case errors.Is(err, ErrAliasNotFound):
+		return apiError(http.StatusNotFound, CodeAliasNotFound, err.Error())
+	case errors.Is(err, ErrAliasExists):
+		return apiError(http.StatusConflict, CodeAliasExists, err.Error())
+	}
+	return serviceError(err, action)
+}
@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/appropriations"
+	"github.com/drewjst/deltagov/internal/database"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// Statuses of a continuing resolution's deadline.
+const (
+	DeadlineActive  = "active"  // Enacted, and funding hasn't run out
+	DeadlineExpired = "expired" // Enacted, and funding ran out
+	DeadlinePending = "pending" // Not enacted
+)
+
+// CRDeadline is a continuing resolution and the day its funding runs out.
+type CRDeadline struct {
+	Bill                BillResponse   `json:"bill"`
+	VersionID           uint           `json:"versionId,omitempty" doc:"Version of the resolution the expiration was read from"`
+	FiscalYear          int            `json:"fiscalYear,omitempty" doc:"Fiscal year the resolution funds"`
+	ExpiresOn           string         `json:"expiresOn,omitempty" example:"2025-11-21" doc:"Last day funding continues; absent when the text names none or isn't fetched yet"`
+	DaysRemaining       *int           `json:"daysRemaining,omitempty" doc:"Days from today to expiresOn; 0 on the last day, negative once expired"`
+	Status              string         `json:"status" enum:"active,expired,pending"`
+	PendingReplacements []BillResponse `json:"pendingReplacements" doc:"Appropriations bills of the same fiscal year not yet enacted, other CRs included: what could replace or extend the resolution"`
+}
+
+// AppropriationsDeadlinesResponse lists a Congress's continuing
+// resolutions by when their funding runs out.
+type AppropriationsDeadlinesResponse struct {
+	Congress  int          `json:"congress"`
+	Today     string       `json:"today" example:"2025-11-03" doc:"Day countdowns are from (UTC)"`
+	Deadlines []CRDeadline `json:"deadlines" doc:"Soonest expiration first; resolutions without one last"`
+}
+
+// GetAppropriationsDeadlines returns the continuing resolutions of a
+// Congress (0 for the latest with federal bills) with the day each one's
+// funding runs out, read from its latest text, the days left until then,
+// and the appropriations bills pending that could replace it. status, if
+// set, keeps only resolutions with that status.
+func (s *BillService) GetAppropriationsDeadlines(ctx context.Context, congress int, status string) (*AppropriationsDeadlinesResponse, error) {
+	db := database.ReadReplica(s.db.WithContext(ctx))
+	if congress == 0 {
+		if err := db.Model(&models.Bill{}).Scopes(s.scope.Query, visibleBills(ctx)).
+			Where("bills.jurisdiction = ?", models.JurisdictionFederal).
+			Select("COALESCE(MAX(bills.congress), 0)").Scan(&congress).Error; err != nil {
+			return nil, fmt.Errorf("failed to find latest congress: %w", err)
+		}
+	}
+
+	var spending []models.Bill
+	if err := db.Scopes(s.scope.Query, visibleBills(ctx)).
+		Where("bills.jurisdiction = ? AND bills.congress = ? AND bills.is_spending_bill", models.JurisdictionFederal, congress).
+		Order("bills.id ASC").Find(&spending).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch appropriations bills: %w", err)
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	response := &AppropriationsDeadlinesResponse{Congress: congress, Today: today.Format(time.DateOnly), Deadlines: []CRDeadline{}}
+	for i := range spending {
+		bill := &spending[i]
+		if !appropriations.IsContinuing(bill.Title, bill.Subjects) {
+			continue
+		}
+		deadline, err := s.crDeadline(ctx, bill, today)
+		if err != nil {
+			return nil, err
+		}
+		if status != "" && deadline.Status != status {
+			continue
+		}
+		deadline.PendingReplacements = pendingReplacements(bill, deadline.FiscalYear, spending)
+		response.Deadlines = append(response.Deadlines, deadline)
+	}
+
+	sort.SliceStable(response.Deadlines, func(i, j int) bool {
+		a, b := response.Deadlines[i].ExpiresOn, response.Deadlines[j].ExpiresOn
+		if (a == "") != (b == "") {
+			return b == ""
+		}
+		return a < b
+	})
+	return response, nil
+}
+
+// crDeadline reads a continuing resolution's expiration from its latest
+// version.
+func (s *BillService) crDeadline(ctx context.Context, bill *models.Bill, today time.Time) (CRDeadline, error) {
+	deadline := CRDeadline{
+		Bill:       billListResponse(bill),
+		FiscalYear: appropriations.FiscalYear(bill.Title),
+		Status:     DeadlinePending,
+	}
+
+	var latest models.Version
+	if err := database.ReadReplica(s.db.WithContext(ctx)).
+		Select("id", "plain_text", "archived_plain_text", "archived_at").Where("bill_id = ?", bill.ID).
+		Order("fetched_at DESC, id DESC").Limit(1).Find(&latest).Error; err != nil {
+		return CRDeadline{}, fmt.Errorf("failed to fetch version: %w", err)
+	}
+	if err := rehydrate(&latest); err != nil {
+		return CRDeadline{}, err
+	}
+	expires, ok := appropriations.Expiration(latest.PlainText)
+	if latest.ID == 0 || !ok {
+		return deadline, nil
+	}
+
+	days := int(expires.Sub(today).Hours() / 24)
+	deadline.VersionID = latest.ID
+	deadline.ExpiresOn = expires.Format(time.DateOnly)
+	deadline.DaysRemaining = &days
+	if deadline.FiscalYear == 0 {
+		deadline.FiscalYear = appropriations.FiscalYearOf(expires)
+	}
+	if bill.PublicLawNumber != "" {
+		deadline.Status = DeadlineActive
+		if days < 0 {
+			deadline.Status = DeadlineExpired
+		}
+	}
+	return deadline, nil
+}
+
+// pendingReplacements returns the appropriations bills among spending,
+// other than cr, funding fiscal year fy and not yet enacted.
+func pendingReplacements(cr *models.Bill, fy int, spending []models.Bill) []BillResponse {
+	out := []BillResponse{}
+	if fy == 0 {
+		return out
+	}
+	for i := range spending {
+		b := &spending[i]
+		if b.ID != cr.ID && b.PublicLawNumber == "" && appropriations.FiscalYear(b.Title) == fy {
+			out = append(out, billListResponse(b))
+		}
+	}
+	return out
+}
+
+// AppropriationsDeadlinesInput is the request for continuing resolution
+// deadlines
+type AppropriationsDeadlinesInput struct {
+	Congress int    `query:"congress" minimum:"0" doc:"Congress number. Default: the latest with federal bills"`
+	Status   string `query:"status" enum:"active,expired,pending" doc:"Only resolutions with this status: active (enacted, funding continues), expired, or pending (not enacted)"`
+}
+
+// AppropriationsDeadlinesOutput is the response for continuing resolution
+// deadlines
+type AppropriationsDeadlinesOutput struct {
+	Body AppropriationsDeadlinesResponse
+}
+
+// registerAppropriationsDeadlinesRoute registers the continuing resolution
+// deadline tracker.
+func registerAppropriationsDeadlinesRoute(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-appropriations-deadlines",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/appropriations/deadlines",
+		Summary:     "Continuing resolution deadlines",
+		Description: "Returns a Congress's continuing resolutions, identified by title or CRS subject, with the day each one's funding runs out as read from its latest text, the days remaining, whether it was enacted, and the appropriations bills of the same fiscal year pending that could replace it.",
+		Errors:      []int{http.StatusInternalServerError},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *AppropriationsDeadlinesInput) (*AppropriationsDeadlinesOutput, error) {
+		deadlines, err := s.GetAppropriationsDeadlines(ctx, input.Congress, input.Status)
+		if err != nil {
+			return nil, serviceError(err, "failed to get appropriations deadlines")
+		}
+		return &AppropriationsDeadlinesOutput{Body: *deadlines}, nil
+	})
+}
@@ -0,0 +1,340 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/database"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ErrAccountNotFound is returned for an appropriations account key no
+// visible bill has.
+var ErrAccountNotFound = errors.New("appropriations account not found")
+
+// AccountLevel is an appropriations account's funding in a version of a
+// bill.
+type AccountLevel struct {
+	Key          string       `json:"key" doc:"Identifies the account across versions and fiscal years"`
+	FiscalYear   int          `json:"fiscalYear,omitempty"`
+	Subcommittee string       `json:"subcommittee,omitempty" example:"Agriculture-FDA" doc:"Which of the twelve bills funds the account"`
+	Title        string       `json:"title,omitempty" example:"TITLE I"`
+	Agency       string       `json:"agency,omitempty" example:"DEPARTMENT OF AGRICULTURE"`
+	Bureau       string       `json:"bureau,omitempty" example:"Agricultural Research Service"`
+	Account      string       `json:"account" example:"Salaries and Expenses"`
+	Amount       int64        `json:"amount" doc:"Whole dollars"`
+	Raw          string       `json:"raw" example:"$1,800,000,000" doc:"As written"`
+	Bill         BillResponse `json:"bill"`
+	VersionID    uint         `json:"versionId"`
+	VersionCode  string       `json:"versionCode" example:"enr"`
+	FetchedAt    time.Time    `json:"fetchedAt"`
+	Enacted      bool         `json:"enacted" doc:"The bill became law"`
+}
+
+// SubcommitteeTotal sums the accounts of one of the twelve bills.
+type SubcommitteeTotal struct {
+	Name     string `json:"name" example:"Agriculture-FDA"`
+	Accounts int    `json:"accounts"`
+	Total    int64  `json:"total" doc:"Whole dollars"`
+}
+
+// AppropriationsAccountsResponse is a fiscal year's account funding
+// levels.
+type AppropriationsAccountsResponse struct {
+	FiscalYear    int                 `json:"fiscalYear"`
+	Subcommittees []SubcommitteeTotal `json:"subcommittees" doc:"Totals of the matching accounts, by bill"`
+	Accounts      []AccountLevel      `json:"accounts"`
+	Total         int64               `json:"total" doc:"Matching accounts"`
+	Limit         int                 `json:"limit"`
+	Offset        int                 `json:"offset"`
+}
+
+// FiscalYearLevel is an account's funding in a fiscal year.
+type FiscalYearLevel struct {
+	FiscalYear int    `json:"fiscalYear"`
+	Amount     int64  `json:"amount" doc:"Whole dollars"`
+	Enacted    bool   `json:"enacted"`
+	Change     *int64 `json:"change,omitempty" doc:"Difference from the prior fiscal year; absent when it isn't known"`
+}
+
+// AccountHistoryResponse is an appropriations account's funding across
+// versions and fiscal years.
+type AccountHistoryResponse struct {
+	Key          string            `json:"key"`
+	Subcommittee string            `json:"subcommittee,omitempty"`
+	Agency       string            `json:"agency,omitempty"`
+	Bureau       string            `json:"bureau,omitempty"`
+	Account      string            `json:"account"`
+	Versions     []AccountLevel    `json:"versions" doc:"Every version that funds the account, oldest first"`
+	FiscalYears  []FiscalYearLevel `json:"fiscalYears" doc:"Funding by fiscal year, as enacted or else as in the latest version"`
+}
+
+// AccountListParams filters account funding levels.
+type AccountListParams struct {
+	FiscalYear   int    // 0 for the latest parsed
+	Subcommittee string // One of appropriations.Subcommittees
+	Query        string // Case-insensitive partial match of the agency, bureau, or account
+	Limit        int
+	Offset       int
+}
+
+// accountRow is a stored account with its version and bill.
+type accountRow struct {
+	models.AppropriationsAccount
+	VersionCode string
+	Enacted     bool
+}
+
+// accountQuery returns a query of the stored accounts of visible bills,
+// with their versions and whether their bill was enacted.
+func (s *BillService) accountQuery(ctx context.Context) *gorm.DB {
+	return database.ReadReplica(s.db.WithContext(ctx)).Model(&models.AppropriationsAccount{}).
+		Joins("JOIN bills ON bills.id = appropriations_accounts.bill_id").
+		Joins("JOIN versions ON versions.id = appropriations_accounts.version_id").
+		Scopes(s.scope.Query, visibleBills(ctx))
+}
+
+// ListAppropriationsAccounts returns the funding level of each account in
+// a fiscal year: the amount in the latest version of the enacted bill
+// funding it, or of the latest version of any bill while none is enacted.
+// Subcommittee totals cover every matching account, not just the page.
+func (s *BillService) ListAppropriationsAccounts(ctx context.Context, params AccountListParams) (*AppropriationsAccountsResponse, error) {
+	if params.FiscalYear == 0 {
+		if err := s.accountQuery(ctx).Select("COALESCE(MAX(appropriations_accounts.fiscal_year), 0)").
+			Scan(&params.FiscalYear).Error; err != nil {
+			return nil, fmt.Errorf("failed to find latest fiscal year: %w", err)
+		}
+	}
+
+	query := s.accountQuery(ctx).Where("appropriations_accounts.fiscal_year = ?", params.FiscalYear)
+	if params.Subcommittee != "" {
+		query = query.Where("appropriations_accounts.subcommittee = ?", params.Subcommittee)
+	}
+	if params.Query != "" {
+		pattern := "%" + params.Query + "%"
+		query = query.Where("appropriations_accounts.agency ILIKE ? OR appropriations_accounts.bureau ILIKE ? OR appropriations_accounts.account ILIKE ?",
+			pattern, pattern, pattern)
+	}
+	var rows []accountRow
+	if err := query.
+		Select("DISTINCT ON (appropriations_accounts.key) appropriations_accounts.*, versions.version_code, bills.public_law_number <> '' AS enacted").
+		Order("appropriations_accounts.key, bills.public_law_number <> '' DESC, appropriations_accounts.fetched_at DESC, appropriations_accounts.id DESC").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	totals := map[string]*SubcommitteeTotal{}
+	for _, r := range rows {
+		t, ok := totals[r.Subcommittee]
+		if !ok {
+			t = &SubcommitteeTotal{Name: r.Subcommittee}
+			totals[r.Subcommittee] = t
+		}
+		t.Accounts++
+		t.Total += r.Amount
+	}
+	response := &AppropriationsAccountsResponse{
+		FiscalYear:    params.FiscalYear,
+		Subcommittees: make([]SubcommitteeTotal, 0, len(totals)),
+		Accounts:      []AccountLevel{},
+		Total:         int64(len(rows)),
+		Limit:         params.Limit,
+		Offset:        params.Offset,
+	}
+	for _, t := range totals {
+		response.Subcommittees = append(response.Subcommittees, *t)
+	}
+	sort.Slice(response.Subcommittees, func(i, j int) bool { return response.Subcommittees[i].Name < response.Subcommittees[j].Name })
+
+	// In the order of the bills' text
+	sort.SliceStable(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.Subcommittee != b.Subcommittee {
+			return a.Subcommittee < b.Subcommittee
+		}
+		if a.VersionID != b.VersionID {
+			return a.VersionID < b.VersionID
+		}
+		return a.Offset < b.Offset
+	})
+	if params.Offset >= len(rows) {
+		return response, nil
+	}
+	rows = rows[params.Offset:]
+	if params.Limit > 0 && len(rows) > params.Limit {
+		rows = rows[:params.Limit]
+	}
+	levels, err := s.accountLevels(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	response.Accounts = levels
+	return response, nil
+}
+
+// GetAccountHistory returns an account's funding in every version of every
+// bill that funds it, and its level in each fiscal year with the change
+// from the year before. It returns ErrAccountNotFound for an unknown key.
+func (s *BillService) GetAccountHistory(ctx context.Context, key string) (*AccountHistoryResponse, error) {
+	var rows []accountRow
+	if err := s.accountQuery(ctx).Where("appropriations_accounts.key = ?", key).
+		Select("appropriations_accounts.*, versions.version_code, bills.public_law_number <> '' AS enacted").
+		Order("appropriations_accounts.fetched_at ASC, appropriations_accounts.id ASC").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch account: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, ErrAccountNotFound
+	}
+
+	levels, err := s.accountLevels(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+	latest := rows[len(rows)-1]
+	response := &AccountHistoryResponse{
+		Key:          key,
+		Subcommittee: latest.Subcommittee,
+		Agency:       latest.Agency,
+		Bureau:       latest.Bureau,
+		Account:      latest.Account,
+		Versions:     levels,
+		FiscalYears:  []FiscalYearLevel{},
+	}
+
+	// Rows are oldest first, so a later row of the same standing wins
+	byYear := map[int]accountRow{}
+	for _, r := range rows {
+		if cur, ok := byYear[r.FiscalYear]; !ok || r.Enacted || !cur.Enacted {
+			byYear[r.FiscalYear] = r
+		}
+	}
+	for fy, r := range byYear {
+		response.FiscalYears = append(response.FiscalYears, FiscalYearLevel{FiscalYear: fy, Amount: r.Amount, Enacted: r.Enacted})
+	}
+	sort.Slice(response.FiscalYears, func(i, j int) bool { return response.FiscalYears[i].FiscalYear < response.FiscalYears[j].FiscalYear })
+	for i := 1; i < len(response.FiscalYears); i++ {
+		prev, cur := response.FiscalYears[i-1], &response.FiscalYears[i]
+		if prev.FiscalYear == cur.FiscalYear-1 {
+			change := cur.Amount - prev.Amount
+			cur.Change = &change
+		}
+	}
+	return response, nil
+}
+
+// accountLevels converts stored accounts to their API form.
+func (s *BillService) accountLevels(ctx context.Context, rows []accountRow) ([]AccountLevel, error) {
+	ids := make([]uint, 0, len(rows))
+	for _, r := range rows {
+		ids = append(ids, r.BillID)
+	}
+	var bills []models.Bill
+	if err := database.ReadReplica(s.db.WithContext(ctx)).Where("id IN ?", ids).Find(&bills).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch bills: %w", err)
+	}
+	byID := make(map[uint]*models.Bill, len(bills))
+	for i := range bills {
+		byID[bills[i].ID] = &bills[i]
+	}
+
+	levels := make([]AccountLevel, 0, len(rows))
+	for _, r := range rows {
+		level := AccountLevel{
+			Key:          r.Key,
+			FiscalYear:   r.FiscalYear,
+			Subcommittee: r.Subcommittee,
+			Title:        r.Title,
+			Agency:       r.Agency,
+			Bureau:       r.Bureau,
+			Account:      r.Account,
+			Amount:       r.Amount,
+			Raw:          r.Raw,
+			VersionID:    r.VersionID,
+			VersionCode:  r.VersionCode,
+			FetchedAt:    r.FetchedAt,
+			Enacted:      r.Enacted,
+		}
+		if bill, ok := byID[r.BillID]; ok {
+			level.Bill = billListResponse(bill)
+		}
+		levels = append(levels, level)
+	}
+	return levels, nil
+}
+
+// ListAppropriationsAccountsInput is the request for account funding
+// levels
+type ListAppropriationsAccountsInput struct {
+	FiscalYear   int    `query:"fiscalYear" minimum:"0" doc:"Fiscal year. Default: the latest with parsed accounts" example:"2026"`
+	Subcommittee string `query:"subcommittee" enum:"Agriculture-FDA,Commerce-Justice-Science,Defense,Energy-Water,Financial Services-General Government,Homeland Security,Interior-Environment,Labor-HHS-Education,Legislative Branch,Military Construction-VA,State-Foreign Operations,Transportation-HUD" doc:"Only accounts of this bill"`
+	Query        string `query:"query" doc:"Search in agency, bureau, and account names (case-insensitive partial match)"`
+	Limit        int    `query:"limit" default:"50" minimum:"1" maximum:"200" doc:"Number of results per page (max 200)"`
+	Offset       int    `query:"offset" default:"0" minimum:"0" doc:"Pagination offset"`
+}
+
+// ListAppropriationsAccountsOutput is the response for account funding
+// levels
+type ListAppropriationsAccountsOutput struct {
+	Body AppropriationsAccountsResponse
+}
+
+// AccountHistoryInput is the request for an account's funding history
+type AccountHistoryInput struct {
+	Key string `query:"key" required:"true" doc:"Account key, from list-appropriations-accounts"`
+}
+
+// AccountHistoryOutput is the response for an account's funding history
+type AccountHistoryOutput struct {
+	Body AccountHistoryResponse
+}
+
+// registerAppropriationsAccountRoutes registers the appropriations account
+// endpoints.
+func registerAppropriationsAccountRoutes(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-appropriations-accounts",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/appropriations/accounts",
+		Summary:     "Appropriations account funding levels",
+		Description: "Returns the accounts the twelve annual appropriations bills fund in a fiscal year, parsed from their titles, agencies, and accounts, with each account's amount as enacted or, until then, as in the latest version, and totals by bill.",
+		Errors:      []int{http.StatusInternalServerError},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *ListAppropriationsAccountsInput) (*ListAppropriationsAccountsOutput, error) {
+		accounts, err := s.ListAppropriationsAccounts(ctx, AccountListParams{
+			FiscalYear:   input.FiscalYear,
+			Subcommittee: input.Subcommittee,
+			Query:        strings.TrimSpace(input.Query),
+			Limit:        input.Limit,
+			Offset:       input.Offset,
+		})
+		if err != nil {
+			return nil, serviceError(err, "failed to list appropriations accounts")
+		}
+		return &ListAppropriationsAccountsOutput{Body: *accounts}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-appropriations-account-history",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/appropriations/accounts/history",
+		Summary:     "Appropriations account funding history",
+		Description: "Returns an account's amount in every version of every bill that funds it, to compare across a bill's versions, and its level in each fiscal year with the change from the year before.",
+		Errors:      []int{http.StatusNotFound, http.StatusInternalServerError},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *AccountHistoryInput) (*AccountHistoryOutput, error) {
+		history, err := s.GetAccountHistory(ctx, input.Key)
+		if err != nil {
+			return nil, serviceError(err, "failed to get account history")
+		}
+		return &AccountHistoryOutput{Body: *history}, nil
+	})
+}
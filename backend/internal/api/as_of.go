@@ -0,0 +1,158 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/deltas"
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ErrNoVersionAsOf is returned when a bill had no text version yet on the
+// requested date.
+var ErrNoVersionAsOf = errors.New("bill had no text version on that date")
+
+// AsOfResponse is a bill as it stood at the end of a date.
+type AsOfResponse struct {
+	Date            string          `json:"date"`            // YYYY-MM-DD, as requested
+	Bill            BillResponse    `json:"bill"`            // Title, status, sponsor, and law status as of the date, with the versions fetched by then
+	Version         VersionResponse `json:"version"`         // The version current on the date
+	Text            string          `json:"text"`            // Plain text of Version
+	LatestVersionID uint            `json:"latestVersionId"` // The bill's most recent version today
+	Current         bool            `json:"current"`         // Version is still the most recent
+	Diff            *DiffResponse   `json:"diff,omitempty"`  // With diff=true: Version against the most recent version
+}
+
+// GetBillAsOf reconstructs a bill as of the end of date (UTC): the most
+// recent version fetched by then, with its text, and the metadata that
+// title, status, sponsor, and enactment events recorded since then
+// changed, rolled back. Other metadata, such as subjects, is as currently
+// stored. With withDiff, the response includes the diff from that version
+// to the bill's most recent one.
+func (s *BillService) GetBillAsOf(ctx context.Context, billID uint, date time.Time, withDiff bool) (*AsOfResponse, error) {
+	var bill models.Bill
+	if err := s.db.WithContext(ctx).First(&bill, billID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBillNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch bill: %w", err)
+	}
+	end := time.Date(date.Year(), date.Month(), date.Day()+1, 0, 0, 0, 0, time.UTC)
+
+	var versions []models.Version
+	if err := s.db.WithContext(ctx).Select("id", "bill_id", "version_code", "content_hash", "fetched_at",
+		"word_count", "page_estimate", "avg_sentence_length", "grade_level", "defined_terms").
+		Where("bill_id = ?", billID).Order("fetched_at ASC, id ASC").Find(&versions).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch versions: %w", err)
+	}
+	current := -1
+	for i := range versions {
+		if versions[i].FetchedAt.Before(end) {
+			current = i
+		}
+	}
+	if current < 0 {
+		return nil, ErrNoVersionAsOf
+	}
+
+	var events []models.BillEvent
+	if err := s.db.WithContext(ctx).
+		Where("bill_id = ? AND occurred_at >= ?", billID, end).
+		Order("occurred_at DESC, id DESC").Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch bill events: %w", err)
+	}
+	revertEvents(&bill, events)
+
+	var version models.Version
+	if err := s.db.WithContext(ctx).First(&version, versions[current].ID).Error; err != nil {
+		return nil, versionLookupError(err)
+	}
+	if err := rehydrate(&version); err != nil {
+		return nil, err
+	}
+
+	latest := versions[len(versions)-1].ID
+	response := &AsOfResponse{
+		Date:            date.Format("2006-01-02"),
+		Bill:            billListResponse(&bill),
+		Version:         versionResponse(&versions[current]),
+		Text:            deltas.Text(&version),
+		LatestVersionID: latest,
+		Current:         latest == version.ID,
+	}
+	response.Bill.Versions = make([]VersionResponse, current+1)
+	for i := range response.Bill.Versions {
+		response.Bill.Versions[i] = versionResponse(&versions[i])
+	}
+
+	if withDiff && !response.Current {
+		diff, err := s.ComputeDiff(ctx, billID, version.ID, latest, diff_engine.Options{})
+		if err != nil {
+			return nil, err
+		}
+		response.Diff = diff
+	}
+	return response, nil
+}
+
+// revertEvents undoes on bill the changes recorded by events, newest
+// first. Sponsor events that only added cosponsors carry no values and
+// change nothing.
+func revertEvents(bill *models.Bill, events []models.BillEvent) {
+	for _, e := range events {
+		switch e.EventType {
+		case models.BillEventTitleChanged:
+			bill.Title = e.PreviousValue
+		case models.BillEventStatusChanged:
+			bill.CurrentStatus = e.PreviousValue
+		case models.BillEventSponsorChanged:
+			if e.PreviousValue != "" {
+				bill.Sponsor = e.PreviousValue
+			}
+		case models.BillEventBecameLaw:
+			bill.PublicLawNumber, bill.LawType = "", ""
+		}
+	}
+}
+
+// GetBillAsOfInput is the request for a bill as of a date.
+type GetBillAsOfInput struct {
+	ID   uint   `path:"id" minimum:"1" doc:"Bill ID"`
+	Date string `query:"date" format:"date" required:"true" doc:"Date, YYYY-MM-DD; the bill as it stood at the end of the day (UTC)"`
+	Diff bool   `query:"diff" doc:"Include the diff from the version current on the date to the most recent version"`
+}
+
+// GetBillAsOfOutput is the response for a bill as of a date.
+type GetBillAsOfOutput struct {
+	Body AsOfResponse
+}
+
+// registerAsOfRoute registers the historical snapshot endpoint.
+func registerAsOfRoute(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-bill-as-of",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{id}/as-of",
+		Summary:     "Get a bill as it stood on a date",
+		Description: "Returns the version that was current at the end of the date, with its plain text, and the bill's title, status, sponsor, and law status at the time, reconstructed from its change feed. With diff=true, also diffs that version against the most recent one.",
+		Errors:      []int{http.StatusBadRequest, http.StatusNotFound},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *GetBillAsOfInput) (*GetBillAsOfOutput, error) {
+		date, err := time.Parse("2006-01-02", input.Date)
+		if err != nil {
+			return nil, huma.Error400BadRequest("invalid date: " + err.Error())
+		}
+		snapshot, err := s.GetBillAsOf(ctx, input.ID, date, input.Diff)
+		if err != nil {
+			return nil, serviceError(err, "failed to get bill as of date")
+		}
+		return &GetBillAsOfOutput{Body: *snapshot}, nil
+	})
+}
@@ -0,0 +1,280 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// auditMetadataKey is the operation metadata entry that, set to false,
+// keeps a read-only operation sent with POST out of the audit log.
+const auditMetadataKey = "audit"
+
+// auditEntryKey is the context key of the audit log entry of a request
+// being recorded.
+type auditEntryKey struct{}
+
+// notAudited marks an operation that changes nothing, despite its method,
+// as not recorded in the audit log.
+func notAudited(op huma.Operation) huma.Operation {
+	if op.Metadata == nil {
+		op.Metadata = map[string]any{}
+	}
+	op.Metadata[auditMetadataKey] = false
+	return op
+}
+
+// audited reports whether requests to an operation are recorded: those
+// with methods other than GET and HEAD unless marked notAudited.
+func audited(op *huma.Operation) bool {
+	if op.Method == http.MethodGet || op.Method == http.MethodHead {
+		return false
+	}
+	record, ok := op.Metadata[auditMetadataKey].(bool)
+	return !ok || record
+}
+
+// Audit is Huma middleware that records each request to a mutating
+// operation in the audit log once it completes: the caller, the operation,
+// the entity it targeted, and the response status. Rejected requests are
+// recorded too. The target is the last path parameter and the collection
+// before it, e.g., users 7 for /api/v1/admin/users/7/role; handlers of
+// operations that create an entity record its ID with auditTarget, and
+// handlers may record before and after summaries with auditChange. It must
+// be installed after AuthService.Sessions and before routes are
+// registered.
+func (s *AdminService) Audit(api huma.API) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		op := ctx.Operation()
+		if !audited(op) {
+			next(ctx)
+			return
+		}
+
+		u := ctx.URL()
+		entry := &models.AuditLog{
+			Operation: op.OperationID,
+			Method:    op.Method,
+			Route:     op.Path,
+			Path:      u.Path,
+		}
+		entry.TargetType, entry.TargetID = routeTarget(op.Path, ctx.Param)
+		s.auditActor(ctx, entry)
+
+		next(huma.WithValue(ctx, auditEntryKey{}, entry))
+
+		entry.Status = ctx.Status()
+		if entry.Status == 0 {
+			entry.Status = http.StatusOK
+		}
+		// Recorded even if the client went away mid-request
+		if err := s.db.WithContext(context.WithoutCancel(ctx.Context())).Create(entry).Error; err != nil {
+			logging.FromContext(ctx.Context()).Warn("failed to record audit log",
+				"operation", entry.Operation, "path", entry.Path, "error", err)
+		}
+	}
+}
+
+// auditActor records who made a request: the user of its session token or
+// API key, the admin token, or no one.
+func (s *AdminService) auditActor(ctx huma.Context, entry *models.AuditLog) {
+	entry.ActorType = models.AuditActorAnonymous
+	if user, err := authenticate(ctx.Context(), s.db, ctx.Header("X-API-Key")); err == nil {
+		entry.ActorType = models.AuditActorUser
+		entry.UserID = &user.ID
+		return
+	}
+	token, ok := strings.CutPrefix(ctx.Header("Authorization"), "Bearer ")
+	if ok && s.token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1 {
+		entry.ActorType = models.AuditActorAdminToken
+	}
+}
+
+// routeTarget returns the entity a route targets: the value of its last
+// path parameter and the literal segment before it, or the last segment
+// and no ID for routes without parameters, such as creation endpoints.
+func routeTarget(route string, param func(string) string) (kind, id string) {
+	segments := strings.Split(strings.Trim(route, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		name, ok := strings.CutPrefix(segments[i], "{")
+		if !ok {
+			continue
+		}
+		id = param(strings.TrimSuffix(name, "}"))
+		for j := i - 1; j >= 0; j-- {
+			if !strings.HasPrefix(segments[j], "{") {
+				return segments[j], id
+			}
+		}
+		return "", id
+	}
+	return segments[len(segments)-1], ""
+}
+
+// auditTarget records the ID of the entity a request created, for the
+// audit log.
+func auditTarget(ctx context.Context, id uint) {
+	if entry, ok := ctx.Value(auditEntryKey{}).(*models.AuditLog); ok {
+		entry.TargetID = fmt.Sprint(id)
+	}
+}
+
+// auditChange records summaries of the entity a request changed, before
+// and after the change, for the audit log. Either may be nil.
+func auditChange(ctx context.Context, before, after map[string]any) {
+	if entry, ok := ctx.Value(auditEntryKey{}).(*models.AuditLog); ok {
+		entry.Before, entry.After = before, after
+	}
+}
+
+// AuditLogResponse is an audit log entry in API responses.
+type AuditLogResponse struct {
+	ID         uint           `json:"id"`
+	ActorType  string         `json:"actorType" enum:"user,admin_token,anonymous"`
+	UserID     *uint          `json:"userId,omitempty"`
+	Operation  string         `json:"operation" doc:"Operation ID, e.g., set-user-role"`
+	Method     string         `json:"method"`
+	Route      string         `json:"route"`
+	Path       string         `json:"path"`
+	TargetType string         `json:"targetType"`
+	TargetID   string         `json:"targetId,omitempty"`
+	Status     int            `json:"status"`
+	Before     map[string]any `json:"before,omitempty"`
+	After      map[string]any `json:"after,omitempty"`
+	CreatedAt  time.Time      `json:"createdAt"`
+}
+
+// AuditLogList is a page of audit log entries.
+type AuditLogList struct {
+	Entries []AuditLogResponse `json:"entries"`
+	Total   int64              `json:"total"`
+	Limit   int                `json:"limit"`
+	Offset  int                `json:"offset"`
+}
+
+// AuditLogFilter selects audit log entries; zero fields match everything.
+type AuditLogFilter struct {
+	UserID     uint
+	ActorType  string
+	Operation  string
+	TargetType string
+	TargetID   string
+	Since      time.Time
+	Until      time.Time
+}
+
+// ListAuditLog returns audit log entries matching a filter, newest first.
+func (s *AdminService) ListAuditLog(ctx context.Context, filter AuditLogFilter, limit, offset int) (*AuditLogList, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	query := s.db.WithContext(ctx).Model(&models.AuditLog{})
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.ActorType != "" {
+		query = query.Where("actor_type = ?", filter.ActorType)
+	}
+	if filter.Operation != "" {
+		query = query.Where("operation = ?", filter.Operation)
+	}
+	if filter.TargetType != "" {
+		query = query.Where("target_type = ?", filter.TargetType)
+	}
+	if filter.TargetID != "" {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if !filter.Since.IsZero() {
+		query = query.Where("created_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		query = query.Where("created_at < ?", filter.Until)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count audit log entries: %w", err)
+	}
+	var entries []models.AuditLog
+	if err := query.Order("created_at DESC, id DESC").Limit(limit).Offset(offset).Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to list audit log entries: %w", err)
+	}
+
+	resp := &AuditLogList{Entries: make([]AuditLogResponse, len(entries)), Total: total, Limit: limit, Offset: offset}
+	for i, e := range entries {
+		resp.Entries[i] = AuditLogResponse{
+			ID:         e.ID,
+			ActorType:  e.ActorType,
+			UserID:     e.UserID,
+			Operation:  e.Operation,
+			Method:     e.Method,
+			Route:      e.Route,
+			Path:       e.Path,
+			TargetType: e.TargetType,
+			TargetID:   e.TargetID,
+			Status:     e.Status,
+			Before:     e.Before,
+			After:      e.After,
+			CreatedAt:  e.CreatedAt,
+		}
+	}
+	return resp, nil
+}
+
+// ListAuditLogInput is the request for listing audit log entries
+type ListAuditLogInput struct {
+	UserID     uint      `query:"userId" doc:"Only entries of this user"`
+	ActorType  string    `query:"actorType" enum:"user,admin_token,anonymous" doc:"Only entries of this kind of caller"`
+	Operation  string    `query:"operation" doc:"Only entries of this operation ID, e.g., start-reingest"`
+	TargetType string    `query:"targetType" doc:"Only entries targeting this collection, e.g., users"`
+	TargetID   string    `query:"targetId" doc:"Only entries targeting this ID"`
+	Since      time.Time `query:"since" doc:"Only entries at or after this time (RFC 3339)"`
+	Until      time.Time `query:"until" doc:"Only entries before this time (RFC 3339)"`
+	Limit      int       `query:"limit" default:"50" minimum:"1" maximum:"200" doc:"Number of results per page (max 200)"`
+	Offset     int       `query:"offset" default:"0" minimum:"0" doc:"Pagination offset"`
+}
+
+// ListAuditLogOutput is the response for listing audit log entries
+type ListAuditLogOutput struct {
+	Body AuditLogList
+}
+
+// registerAuditAdminRoutes registers the audit log endpoint.
+func registerAuditAdminRoutes(api huma.API, s *AdminService) {
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID: "list-audit-log",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/audit",
+		Summary:     "List the audit log",
+		Description: "Returns recorded requests to mutating operations, newest first: admin actions, watchlist and alert changes, and uploads, with the caller, target, response status, and before/after summaries where recorded",
+	}), func(ctx context.Context, input *ListAuditLogInput) (*ListAuditLogOutput, error) {
+		list, err := s.ListAuditLog(ctx, AuditLogFilter{
+			UserID:     input.UserID,
+			ActorType:  input.ActorType,
+			Operation:  input.Operation,
+			TargetType: input.TargetType,
+			TargetID:   input.TargetID,
+			Since:      input.Since,
+			Until:      input.Until,
+		}, input.Limit, input.Offset)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list audit log: " + err.Error())
+		}
+		return &ListAuditLogOutput{Body: *list}, nil
+	})
+}
@@ -0,0 +1,30 @@
+package api
+
+import "testing"
+
+// TestRouteTarget verifies the target of a route is its last path
+// parameter and the collection before it, or its last segment for routes
+// without parameters.
+func TestRouteTarget(t *testing.T) {
+	params := map[string]string{"id": "7", "searchId": "42"}
+	tests := []struct {
+		route    string
+		wantKind string
+		wantID   string
+	}{
+		{"/api/v1/admin/users/{id}/role", "users", "7"},
+		{"/api/v1/admin/users/{id}/watchlist/searches/{searchId}", "searches", "42"},
+		{"/api/v1/admin/deltas/{id}", "deltas", "7"},
+		{"/api/v1/admin/tenants", "tenants", ""},
+		{"/api/v1/documents/{id}/versions", "documents", "7"},
+		{"/api/v1/watchlist/bills/{id}", "bills", "7"},
+		{"/{id}", "", "7"},
+		{"/api/v1/admin/reingest/", "reingest", ""},
+	}
+	for _, tt := range tests {
+		kind, id := routeTarget(tt.route, func(name string) string { return params[name] })
+		if kind != tt.wantKind || id != tt.wantID {
+			t.Errorf("routeTarget(%q) = %q, %q; want %q, %q", tt.route, kind, id, tt.wantKind, tt.wantID)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/api"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// TestAudit verifies requests to mutating operations are recorded with
+// their caller, target, and status, including those rejected for missing
+// credentials or an insufficient role, and that reads aren't recorded.
+func TestAudit(t *testing.T) {
+	ts := newTestServer(t, "s3cret")
+	reader := ts.addUser(t, models.UserRoleReader, nil)
+	editor := ts.addUser(t, models.UserRoleEditor, nil)
+	var readerUser, editorUser models.User
+	ts.db.Where("name = ?", models.UserRoleReader).First(&readerUser)
+	ts.db.Where("name = ?", models.UserRoleEditor).First(&editorUser)
+	create := map[string]string{"title": "Guidance"}
+
+	ts.request(t, http.MethodPost, "/api/v1/admin/tenants", nil, map[string]string{"name": "Agency"})
+	ts.request(t, http.MethodPost, "/api/v1/documents", apiKey(reader), create)
+	_, body := ts.request(t, http.MethodPost, "/api/v1/documents", apiKey(editor), create)
+	var doc api.DocumentResponse
+	decodeJSON(t, body, &doc)
+	rolePath := fmt.Sprintf("/api/v1/admin/users/%d/role", readerUser.ID)
+	ts.request(t, http.MethodPut, rolePath, bearer("s3cret"), map[string]string{"role": models.UserRoleEditor})
+
+	// Reads and operations marked as not changing anything aren't recorded
+	ts.request(t, http.MethodGet, "/api/v1/documents", apiKey(editor), nil)
+	ts.request(t, http.MethodGet, "/api/v1/admin/ingestions", bearer("s3cret"), nil)
+	ts.request(t, http.MethodPost, "/api/v1/compare/adhoc", nil, map[string]string{"from": "a", "to": "b"})
+
+	var entries []models.AuditLog
+	if err := ts.db.Order("id").Find(&entries).Error; err != nil {
+		t.Fatalf("Failed to read audit log: %v", err)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 audit log entries, got %d: %+v", len(entries), entries)
+	}
+
+	want := []struct {
+		actor      string
+		userID     uint
+		operation  string
+		targetType string
+		targetID   string
+		status     int
+	}{
+		{models.AuditActorAnonymous, 0, "create-tenant", "tenants", "", http.StatusUnauthorized},
+		{models.AuditActorUser, readerUser.ID, "create-document", "documents", "", http.StatusForbidden},
+		{models.AuditActorUser, editorUser.ID, "create-document", "documents", fmt.Sprint(doc.ID), http.StatusCreated},
+		{models.AuditActorAdminToken, 0, "set-user-role", "users", fmt.Sprint(readerUser.ID), http.StatusOK},
+	}
+	for i, w := range want {
+		e := entries[i]
+		var userID uint
+		if e.UserID != nil {
+			userID = *e.UserID
+		}
+		if e.ActorType != w.actor || userID != w.userID || e.Operation != w.operation ||
+			e.TargetType != w.targetType || e.TargetID != w.targetID || e.Status != w.status {
+			t.Errorf("Entry %d = %+v, want %+v", i, e, w)
+		}
+	}
+	if role := entries[3]; role.Route != "/api/v1/admin/users/{id}/role" || role.Path != rolePath ||
+		role.Before["role"] != models.UserRoleReader || role.After["role"] != models.UserRoleEditor {
+		t.Errorf("Role change entry = %+v, want its route, path, and before and after roles", role)
+	}
+
+	var list api.AuditLogList
+	status, body := ts.request(t, http.MethodGet, "/api/v1/admin/audit?targetType=documents", bearer("s3cret"), nil)
+	if status != http.StatusOK {
+		t.Fatalf("List audit log: status = %d, want 200: %s", status, body)
+	}
+	decodeJSON(t, body, &list)
+	if list.Total != 2 || list.Entries[0].Status != http.StatusCreated {
+		t.Errorf("Document entries = %+v, want both, newest first", list)
+	}
+}
@@ -0,0 +1,311 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/auth"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ErrLoginDisabled is returned when no identity provider is configured.
+var ErrLoginDisabled = errors.New("login is not configured")
+
+// loginStateCookie holds the state of a sign-in in progress, which the
+// callback must be given back.
+const loginStateCookie = "deltagov_login_state"
+
+// loginStateTTL is how long a user has to complete a sign-in.
+const loginStateTTL = 10 * time.Minute
+
+// sessionUserKey is the context key of the user a session token belongs to.
+type sessionUserKey struct{}
+
+// AuthService signs end users in with an identity provider and issues the
+// session tokens that authenticate them afterwards.
+type AuthService struct {
+	db       *gorm.DB
+	provider *auth.Provider
+	sessions *auth.Sessions
+}
+
+// NewAuthService creates a new AuthService. Without a provider, sign-in is
+// disabled; without sessions, session tokens are not accepted either.
+func NewAuthService(db *gorm.DB, provider *auth.Provider, sessions *auth.Sessions) *AuthService {
+	return &AuthService{db: db, provider: provider, sessions: sessions}
+}
+
+// MeResponse is the caller's user.
+type MeResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email,omitempty"`
+	Role      string    `json:"role" enum:"reader,editor,admin"`
+	Subject   string    `json:"subject,omitempty" doc:"Identity provider and its user ID, for users who sign in"`
+	TenantID  *uint     `json:"tenantId,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// SessionResponse is a session token issued on sign-in.
+type SessionResponse struct {
+	Token     string     `json:"token" doc:"Send as 'Authorization: Bearer <token>' in place of an API key"`
+	ExpiresAt time.Time  `json:"expiresAt"`
+	User      MeResponse `json:"user"`
+}
+
+// Sessions is Huma middleware that resolves a session token sent as a
+// bearer token to its user, which authenticate then returns for requests
+// without an API key. Other bearer tokens, such as the admin token, are
+// passed through. It must be installed before the middleware that
+// authenticates callers.
+func (s *AuthService) Sessions(api huma.API) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		token, ok := strings.CutPrefix(ctx.Header("Authorization"), "Bearer ")
+		if !ok || s.sessions == nil {
+			next(ctx)
+			return
+		}
+		id, err := s.sessions.Verify(token)
+		if err != nil {
+			next(ctx)
+			return
+		}
+		var user models.User
+		if err := s.db.WithContext(ctx.Context()).First(&user, id).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				huma.WriteErr(api, ctx, http.StatusUnauthorized, "session user no longer exists")
+				return
+			}
+			huma.WriteErr(api, ctx, http.StatusInternalServerError, "failed to authenticate: "+err.Error())
+			return
+		}
+		// Responses depend on who signed in; shared caches must not serve them to others
+		ctx.AppendHeader("Vary", "Authorization")
+		next(huma.WithValue(ctx, sessionUserKey{}, &user))
+	}
+}
+
+// sessionUser returns the user whose session token authenticated the
+// request, if any.
+func sessionUser(ctx context.Context) (*models.User, bool) {
+	user, ok := ctx.Value(sessionUserKey{}).(*models.User)
+	return user, ok
+}
+
+// LoginURL starts a sign-in, returning the identity provider's URL to send
+// the user to and the state the callback must be given back.
+func (s *AuthService) LoginURL(ctx context.Context) (loginURL, state string, err error) {
+	if s.provider == nil {
+		return "", "", ErrLoginDisabled
+	}
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", "", fmt.Errorf("failed to generate state: %w", err)
+	}
+	state = hex.EncodeToString(raw)
+	// The state doubles as the ID token nonce: both are bound to the cookie
+	loginURL, err = s.provider.AuthCodeURL(ctx, state, state)
+	if err != nil {
+		return "", "", err
+	}
+	return loginURL, state, nil
+}
+
+// Callback completes a sign-in: it redeems the provider's code, creates
+// or updates the user the provider vouches for, and issues a session
+// token. New users get the reader role.
+func (s *AuthService) Callback(ctx context.Context, code, state string) (*SessionResponse, error) {
+	if s.provider == nil || s.sessions == nil {
+		return nil, ErrLoginDisabled
+	}
+	identity, err := s.provider.Exchange(ctx, code, state)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.signIn(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	token, expires, err := s.sessions.Issue(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &SessionResponse{Token: token, ExpiresAt: expires, User: meResponse(user)}, nil
+}
+
+// signIn returns the user with an identity's subject, creating it on first
+// sign-in and refreshing its name and email after.
+func (s *AuthService) signIn(ctx context.Context, identity *auth.Identity) (*models.User, error) {
+	db := s.db.WithContext(ctx)
+	name := identity.Name
+	if name == "" {
+		name = identity.Email
+	}
+
+	var user models.User
+	err := db.Where("subject = ?", identity.Subject).First(&user).Error
+	if err == nil {
+		if err := db.Model(&user).Updates(map[string]any{"name": name, "email": identity.Email}).Error; err != nil {
+			return nil, fmt.Errorf("failed to update user: %w", err)
+		}
+		return &user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	// API key hashes are unique, so signed-in users get one for a key
+	// that is never shown
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	subject := identity.Subject
+	user = models.User{
+		Name:       name,
+		Email:      identity.Email,
+		Subject:    &subject,
+		APIKeyHash: hashAPIKey(hex.EncodeToString(raw)),
+		Role:       models.UserRoleReader,
+	}
+	if err := db.Create(&user).Error; err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	return &user, nil
+}
+
+// meResponse converts a User model to the caller's view of it.
+func meResponse(user *models.User) MeResponse {
+	resp := MeResponse{
+		ID:        user.ID,
+		Name:      user.Name,
+		Email:     user.Email,
+		Role:      user.Role,
+		TenantID:  user.TenantID,
+		CreatedAt: user.CreatedAt,
+	}
+	if user.Subject != nil {
+		resp.Subject = *user.Subject
+	}
+	return resp
+}
+
+// LoginOutput redirects to the identity provider
+type LoginOutput struct {
+	Status   int
+	Location string      `header:"Location"`
+	Cookie   http.Cookie `header:"Set-Cookie"`
+}
+
+// LoginCallbackInput is the identity provider's redirect back after sign-in
+type LoginCallbackInput struct {
+	Code        string `query:"code" required:"true" doc:"Authorization code from the identity provider"`
+	State       string `query:"state" required:"true" doc:"State passed to the identity provider"`
+	CookieState string `cookie:"deltagov_login_state" doc:"State set by the login endpoint"`
+}
+
+// LoginCallbackOutput is the response for a completed sign-in
+type LoginCallbackOutput struct {
+	Cookie http.Cookie `header:"Set-Cookie"`
+	Body   SessionResponse
+}
+
+// GetMeOutput is the response for the caller's user
+type GetMeOutput struct {
+	Body MeResponse
+}
+
+// loginError converts a sign-in failure to an HTTP error.
+func loginError(err error) error {
+	switch {
+	case errors.Is(err, ErrLoginDisabled):
+		return huma.Error501NotImplemented(err.Error())
+	case errors.Is(err, auth.ErrInvalidToken):
+		return huma.Error401Unauthorized("sign-in failed: " + err.Error())
+	}
+	return huma.Error502BadGateway("sign-in failed: " + err.Error())
+}
+
+// stateCookie returns the cookie carrying a sign-in's state, or one
+// clearing it when state is empty.
+func stateCookie(state string) http.Cookie {
+	cookie := http.Cookie{
+		Name:     loginStateCookie,
+		Value:    state,
+		Path:     "/",
+		MaxAge:   int(loginStateTTL / time.Second),
+		Secure:   true,
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if state == "" {
+		cookie.MaxAge = -1
+	}
+	return cookie
+}
+
+// RegisterAuthRoutes registers the sign-in and current-user endpoints with
+// Huma.
+func RegisterAuthRoutes(api huma.API, s *AuthService) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "login",
+		Method:        http.MethodGet,
+		Path:          "/api/v1/auth/login",
+		Summary:       "Sign in",
+		Description:   "Redirects to the configured identity provider (Google, GitHub, or any OpenID Connect issuer), which redirects back to the callback endpoint",
+		Errors:        []int{http.StatusNotImplemented, http.StatusBadGateway},
+		Tags:          []string{"Auth"},
+		DefaultStatus: http.StatusFound,
+	}, func(ctx context.Context, input *struct{}) (*LoginOutput, error) {
+		loginURL, state, err := s.LoginURL(ctx)
+		if err != nil {
+			return nil, loginError(err)
+		}
+		return &LoginOutput{Status: http.StatusFound, Location: loginURL, Cookie: stateCookie(state)}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "login-callback",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/auth/callback",
+		Summary:     "Complete sign-in",
+		Description: "Redeems the identity provider's authorization code and returns a session token. Users are created on first sign-in with the reader role; their watchlist, saved searches, and documents are theirs across sessions.",
+		Errors:      []int{http.StatusUnauthorized, http.StatusNotImplemented, http.StatusBadGateway},
+		Tags:        []string{"Auth"},
+	}, func(ctx context.Context, input *LoginCallbackInput) (*LoginCallbackOutput, error) {
+		if input.CookieState == "" || input.State != input.CookieState {
+			return nil, huma.Error401Unauthorized("sign-in failed: state does not match; start again from the login endpoint")
+		}
+		session, err := s.Callback(ctx, input.Code, input.State)
+		if err != nil {
+			return nil, loginError(err)
+		}
+		return &LoginCallbackOutput{Cookie: stateCookie(""), Body: *session}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-me",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/me",
+		Summary:     "Get the current user",
+		Description: "Returns the user a session token or API key belongs to",
+		Errors:      []int{http.StatusUnauthorized},
+		Tags:        []string{"Auth"},
+	}, func(ctx context.Context, input *APIKeyInput) (*GetMeOutput, error) {
+		user, err := authenticate(ctx, s.db, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		return &GetMeOutput{Body: meResponse(user)}, nil
+	})
+}
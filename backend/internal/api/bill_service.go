@@ -4,20 +4,67 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"time"
 
+	"github.com/drewjst/deltagov/internal/archive"
 	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/database"
+	"github.com/drewjst/deltagov/internal/deltas"
 	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/embeddings"
+	"github.com/drewjst/deltagov/internal/insights"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/metrics"
 	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/opensearch"
+	"github.com/drewjst/deltagov/internal/scope"
+	"github.com/drewjst/deltagov/internal/searchindex"
+	"github.com/drewjst/deltagov/internal/textextract"
+	"github.com/drewjst/deltagov/internal/textnorm"
+	"github.com/drewjst/deltagov/internal/textstats"
+	"github.com/drewjst/deltagov/internal/textstore"
+	"github.com/drewjst/deltagov/internal/versioncode"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // BillService handles bill-related business logic.
 type BillService struct {
 	db             *gorm.DB
 	congressClient *congress.Client
+
+	// verifyDeterminism makes ComputeDiff run the engine twice and skip
+	// caching when the two results disagree.
+	verifyDeterminism bool
+
+	// scope restricts which bills appear in listings and search results.
+	scope *scope.Rules
+
+	// texts holds version text offloaded from the versions table; nil
+	// keeps text inline.
+	texts textstore.Store
+
+	// summarizer writes the plain-language summary of each diff; nil
+	// leaves diffs unsummarized.
+	summarizer insights.Summarizer
+
+	// diffQueue precomputes diffs requested through the version matrix;
+	// nil leaves them to be computed on first request.
+	diffQueue *deltas.Queue
+
+	// searchIndex answers bill searches from memory when it can; nil
+	// searches the database.
+	searchIndex *searchindex.Index
+
+	// searchBackend answers full-text searches; nil disables them.
+	searchBackend *opensearch.Client
+
+	// embeddings embeds semantic search queries; nil disables semantic
+	// search.
+	embeddings embeddings.Provider
 }
 
 // NewBillService creates a new BillService instance.
@@ -28,64 +75,155 @@ func NewBillService(db *gorm.DB, congressClient *congress.Client) *BillService {
 	}
 }
 
+// SetVerifyDeterminism enables or disables double-computation of diffs
+// before they are cached.
+func (s *BillService) SetVerifyDeterminism(enabled bool) {
+	s.verifyDeterminism = enabled
+}
+
+// SetScope restricts bill listings and search results to bills allowed by
+// the given rules. A nil value lists every bill.
+func (s *BillService) SetScope(rules *scope.Rules) {
+	s.scope = rules
+}
+
+// SetSummarizer sets how diffs are summarized. Summaries are stored with
+// the delta, so each pair is summarized once. A nil value disables them.
+func (s *BillService) SetSummarizer(summarizer insights.Summarizer) {
+	s.summarizer = summarizer
+}
+
+// SetDiffQueue sets the queue that precomputes diffs the version matrix
+// is missing. A nil value disables precomputation.
+func (s *BillService) SetDiffQueue(q *deltas.Queue) {
+	s.diffQueue = q
+}
+
+// SetSearchIndex sets the in-memory index SearchBills answers from once it
+// is ready, for searches it supports. A nil value searches the database.
+func (s *BillService) SetSearchIndex(x *searchindex.Index) {
+	s.searchIndex = x
+}
+
+// SetSearchBackend sets the cluster SearchText queries. A nil value
+// disables full-text search.
+func (s *BillService) SetSearchBackend(c *opensearch.Client) {
+	s.searchBackend = c
+}
+
+// SetEmbeddings sets the provider semantic search queries are embedded
+// with, which must be the one the ingestor embeds bills with. A nil value
+// disables semantic search.
+func (s *BillService) SetEmbeddings(p embeddings.Provider) {
+	s.embeddings = p
+}
+
+// SetTextStore sets where version text is written to and read back from.
+func (s *BillService) SetTextStore(store textstore.Store) {
+	s.texts = store
+}
+
 // BillResponse is the API response format for a bill.
 type BillResponse struct {
-	ID            uint              `json:"id"`
-	Congress      int               `json:"congress"`
-	BillNumber    int               `json:"billNumber"`
-	BillType      string            `json:"billType"`
-	Title         string            `json:"title"`
-	Sponsor       string            `json:"sponsor"`
-	OriginChamber string            `json:"originChamber"`
-	CurrentStatus string            `json:"currentStatus"`
-	UpdateDate    string            `json:"updateDate"`
-	Versions      []VersionResponse `json:"versions,omitempty"`
+	ID             uint              `json:"id" example:"42"`
+	Jurisdiction   string            `json:"jurisdiction" example:"federal"` // "federal", "state", or "draft"
+	State          string            `json:"state,omitempty"`                // State bills: lower-case state code
+	Session        string            `json:"session,omitempty"`              // State bills: legislative session
+	Congress       int               `json:"congress" example:"119"`         // Federal bills; 0 for state bills
+	BillNumber     int               `json:"billNumber" example:"1"`         // 0 when Number isn't numeric
+	Number         string            `json:"number" example:"1"`             // As published
+	BillType       string            `json:"billType" example:"hr"`
+	Title          string            `json:"title" example:"One Big Beautiful Bill Act"`
+	Sponsor        string            `json:"sponsor" example:"Rep. Arrington, Jodey C. [R-TX-19]"`
+	OriginChamber  string            `json:"originChamber" example:"House"`
+	CurrentStatus  string            `json:"currentStatus" example:"Became Public Law No: 119-21."`
+	UpdateDate     string            `json:"updateDate" example:"2025-07-08"`
+	IntroducedDate string            `json:"introducedDate,omitempty" example:"2025-05-20"`               // YYYY-MM-DD
+	PolicyArea     string            `json:"policyArea,omitempty" example:"Economics and Public Finance"` // CRS policy area
+	CosponsorCount int               `json:"cosponsorCount,omitempty" example:"12"`                       // Federal bills, once their detail is fetched
+	ActionCount    int               `json:"actionCount,omitempty" example:"73"`
+	AmendmentCount int               `json:"amendmentCount,omitempty"`
+	Subjects       []string          `json:"subjects,omitempty"` // CRS legislative subjects; single-bill responses only
+	BecameLaw      bool              `json:"becameLaw"`
+	LawNumber      string            `json:"lawNumber,omitempty" example:"Public Law 119-21"` // e.g., "Public Law 118-5"
+	Versions       []VersionResponse `json:"versions,omitempty"`
+	Titles         []TitleResponse   `json:"titles,omitempty"` // Title history, oldest first; single-bill responses only
+}
+
+// TitleResponse is one of a bill's titles as of a text version or action.
+type TitleResponse struct {
+	Title       string `json:"title" example:"One Big Beautiful Bill Act"`
+	Kind        string `json:"kind" example:"short" doc:"official, short, popular, or display"`
+	TitleType   string `json:"titleType" example:"Short Titles as Introduced"`
+	VersionCode string `json:"versionCode,omitempty" example:"IH"` // Text version the title is from
+	Chamber     string `json:"chamber,omitempty" example:"House"`
+	UpdateDate  string `json:"updateDate,omitempty"`
 }
 
 // VersionResponse is the API response format for a version.
 type VersionResponse struct {
-	ID          uint   `json:"id"`
-	VersionCode string `json:"versionCode"`
-	Date        string `json:"date"`
-	ContentHash string `json:"contentHash"`
-	Label       string `json:"label"`
+	ID          uint             `json:"id" example:"108"`
+	VersionCode string           `json:"versionCode" example:"EH"`
+	Date        string           `json:"date" example:"2025-05-22"`
+	ContentHash string           `json:"contentHash" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	Label       string           `json:"label" example:"Engrossed in House"`
+	Stage       int              `json:"stage" example:"4"`                 // Legislative stage; 0 when the code is unknown
+	Chamber     string           `json:"chamber,omitempty" example:"House"` // "House" or "Senate"; empty for enrolled/public law
+	Stats       *textstats.Stats `json:"stats,omitempty"`                   // Readability metrics; absent for versions stored before they were computed
 }
 
 // DiffResponse is the API response format for a diff.
 type DiffResponse struct {
-	FromVersion string        `json:"fromVersion"`
-	ToVersion   string        `json:"toVersion"`
-	Insertions  int           `json:"insertions"`
-	Deletions   int           `json:"deletions"`
-	Lines       []DiffLine    `json:"lines"`
-	Segments    []DiffSegment `json:"segments"`
+	FromVersion string           `json:"fromVersion" example:"IH"`
+	ToVersion   string           `json:"toVersion" example:"EH"`
+	Insertions  int              `json:"insertions" example:"214"`
+	Deletions   int              `json:"deletions" example:"187"`
+	Lines       []DiffLine       `json:"lines"`
+	Segments    []DiffSegment    `json:"segments"`
+	Anchors     []DiffAnchor     `json:"anchors,omitempty"`    // Sections with changes, in order; see DiffLine.Anchor
+	Hunks       []DiffHunk       `json:"hunks,omitempty"`      // Blocks of Lines, in order; see DiffWindow
+	TotalHunks  int              `json:"totalHunks"`           // Hunks in the whole diff, including any not returned
+	NextHunk    int              `json:"nextHunk,omitempty"`   // hunkOffset of the next page; absent on the last
+	View        string           `json:"view,omitempty"`       // DiffViewSplit when Rows is populated
+	Rows        []SplitRow       `json:"rows,omitempty"`       // Aligned rows for view=split
+	StatsDelta  *textstats.Delta `json:"statsDelta,omitempty"` // Change in readability metrics; absent when either version lacks them
+	Summary     string           `json:"summary,omitempty"`    // Plain-language summary of the change; see package insights
 }
 
 // DiffLine represents a single line in the diff output.
 type DiffLine struct {
-	LineNumber int    `json:"lineNumber"`
-	Type       string `json:"type"` // "insertion", "deletion", "unchanged"
-	Text       string `json:"text"`
+	LineNumber int    `json:"lineNumber" example:"1207"`
+	Type       string `json:"type" example:"insertion"` // "insertion", "deletion", "unchanged"
+	Text       string `json:"text" example:"SEC. 201. FUNDING."`
+	Anchor     string `json:"anchor,omitempty"` // ID of the bill section containing the line, e.g., "sec-201-3f2a9c1b"
 }
 
-// DiffSegment represents a segment in the diff output (word-level).
-type DiffSegment struct {
-	Type string `json:"type"` // "insertion", "deletion", "unchanged"
-	Text string `json:"text"`
+// DiffAnchor is a bill section changed by a diff. Its ID is derived from
+// the section's number and heading, so it is stable across versions and
+// re-ingestion, unlike line numbers; link to a section's changes with it.
+type DiffAnchor struct {
+	ID         string `json:"id"`
+	Section    string `json:"section"`    // e.g., "201"
+	Heading    string `json:"heading"`    // e.g., "FUNDING."
+	LineNumber int    `json:"lineNumber"` // First changed line in the section
+	Hunk       int    `json:"hunk"`       // Index of the hunk containing LineNumber
+}
+
+// DiffHunk is a block of a diff's lines: changed lines with up to three
+// unchanged lines of context around them. Lines between hunks are
+// unchanged and omitted.
+type DiffHunk struct {
+	Index      int `json:"index"`
+	StartA     int `json:"startA"`     // Line of the old text the hunk starts at
+	StartB     int `json:"startB"`     // Line of the new text the hunk starts at
+	LineNumber int `json:"lineNumber"` // DiffLine.LineNumber of the hunk's first line
+	LineCount  int `json:"lineCount"`
 }
 
-// versionCodeLabels maps version codes to human-readable labels.
-var versionCodeLabels = map[string]string{
-	"IH":  "Introduced in House",
-	"RH":  "Reported in House",
-	"EH":  "Engrossed in House",
-	"IS":  "Introduced in Senate",
-	"RS":  "Reported in Senate",
-	"ES":  "Engrossed in Senate",
-	"PCS": "Placed on Calendar Senate",
-	"EAS": "Engrossed Amendment Senate",
-	"ENR": "Enrolled",
-	"PL":  "Public Law",
+// DiffSegment represents a segment in the diff output (word-level).
+type DiffSegment struct {
+	Type string `json:"type" example:"deletion"` // "insertion", "deletion", "unchanged"
+	Text string `json:"text" example:"$1,000,000"`
 }
 
 // FetchAndStoreHR1 fetches H.R. 1 (119th Congress) and stores it in the database.
@@ -99,6 +237,7 @@ func (s *BillService) FetchAndStoreHR1(ctx context.Context) (*BillResponse, erro
 	const (
 		congressNum = 119
 		billType    = "hr"
+		number      = "1"
 		billNumber  = 1
 	)
 
@@ -119,25 +258,47 @@ func (s *BillService) FetchAndStoreHR1(ctx context.Context) (*BillResponse, erro
 	}
 
 	// Fetch bill details from Congress.gov
-	log.Printf("Fetching H.R. 1 (119th Congress) from Congress.gov...")
-	billDetail, err := s.congressClient.GetBillDetail(ctx, congressNum, billType, billNumber)
+	logger := logging.FromContext(ctx)
+	logger.Info("fetching H.R. 1 from Congress.gov", "congress", congressNum)
+	billDetail, err := s.congressClient.GetBillDetail(ctx, congressNum, billType, number)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch bill details: %w", err)
 	}
 
 	// Create or update the bill record
 	bill := models.Bill{
-		Congress:      congressNum,
-		BillNumber:    billNumber,
-		BillType:      billType,
-		Title:         billDetail.Title,
-		OriginChamber: billDetail.OriginChamber,
-		UpdateDate:    billDetail.UpdateDate,
+		Congress:       congressNum,
+		Number:         number,
+		BillNumber:     billNumber,
+		BillType:       billType,
+		Title:          billDetail.Title,
+		OriginChamber:  billDetail.OriginChamber,
+		UpdateDate:     billDetail.UpdateDate,
+		IntroducedDate: billDetail.IntroducedDate,
 	}
 
 	if billDetail.LatestAction != nil {
 		bill.CurrentStatus = billDetail.LatestAction.Text
 	}
+	if len(billDetail.Sponsors) > 0 {
+		bill.Sponsor = billDetail.Sponsors[0].FullName
+	}
+	if billDetail.PolicyArea != nil {
+		bill.PolicyArea = billDetail.PolicyArea.Name
+	}
+	if billDetail.Cosponsors != nil {
+		bill.CosponsorCount = billDetail.Cosponsors.Count
+	}
+	if billDetail.Actions != nil {
+		bill.ActionCount = billDetail.Actions.Count
+	}
+	if billDetail.Amendments != nil {
+		bill.AmendmentCount = billDetail.Amendments.Count
+	}
+	// Keep the full detail, as the ingestor does
+	if data, err := json.Marshal(billDetail); err == nil {
+		_ = json.Unmarshal(data, &bill.Metadata)
+	}
 
 	// Upsert the bill
 	if result.Error != nil {
@@ -154,22 +315,22 @@ func (s *BillService) FetchAndStoreHR1(ctx context.Context) (*BillResponse, erro
 	}
 
 	// Fetch all text versions with content
-	log.Printf("Fetching text versions for H.R. 1...")
-	textVersions, err := s.congressClient.GetBillTextWithContent(ctx, congressNum, billType, billNumber)
+	logger.Info("fetching text versions for H.R. 1")
+	textVersions, err := s.congressClient.GetBillTextWithContent(ctx, congressNum, billType, number)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch text versions: %w", err)
 	}
 
-	log.Printf("Found %d text versions", len(textVersions))
+	logger.Info("found text versions", "count", len(textVersions))
 
 	// Store each version
 	for _, tv := range textVersions {
-		// Compute content hash
-		hash := sha256.Sum256([]byte(tv.Content))
-		contentHash := hex.EncodeToString(hash[:])
+		// Hash normalized text so format differences don't count as changes
+		contentHash := textnorm.Hash(tv.Content)
+		rawHash := sha256.Sum256([]byte(tv.Content))
 
 		// Extract version code from type (e.g., "Introduced in House" -> "IH")
-		versionCode := extractVersionCode(tv.Type)
+		versionCode := versioncode.FromType(tv.Type)
 
 		// Check if version already exists
 		var existingVersion models.Version
@@ -191,162 +352,334 @@ func (s *BillService) FetchAndStoreHR1(ctx context.Context) (*BillResponse, erro
 			BillID:      bill.ID,
 			VersionCode: versionCode,
 			ContentHash: contentHash,
+			RawHash:     hex.EncodeToString(rawHash[:]),
 			TextContent: tv.Content,
+			PlainText:   textextract.Extract(tv.Content),
 			FetchedAt:   fetchedAt,
 		}
+		textstats.Fill(&version)
+		if err := textstore.Offload(ctx, s.texts, &bill, &version); err != nil {
+			logger.Warn("failed to store version text", "version_code", versionCode, "error", err)
+			continue
+		}
 
 		if err := s.db.Create(&version).Error; err != nil {
-			log.Printf("Warning: failed to create version %s: %v", versionCode, err)
+			logger.Warn("failed to create version", "version_code", versionCode, "error", err)
 			continue
 		}
-		log.Printf("Stored version: %s (%s)", versionCode, tv.Type)
+		logger.Info("stored version", "version_code", versionCode, "type", tv.Type)
 	}
 
 	return s.GetBillWithVersions(ctx, bill.ID)
 }
 
-// GetBillWithVersions retrieves a bill with all its versions.
+// GetBillWithVersions retrieves a bill with all its versions and its title
+// history.
 func (s *BillService) GetBillWithVersions(ctx context.Context, billID uint) (*BillResponse, error) {
 	var bill models.Bill
-	if err := s.db.First(&bill, billID).Error; err != nil {
-		return nil, fmt.Errorf("bill not found: %w", err)
+	if err := s.db.WithContext(ctx).First(&bill, billID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBillNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch bill: %w", err)
 	}
 
 	var versions []models.Version
 	// Select specific fields to avoid fetching large text_content
-	if err := s.db.Select("id", "bill_id", "version_code", "content_hash", "fetched_at").
+	if err := s.db.Select("id", "bill_id", "version_code", "content_hash", "fetched_at",
+		"word_count", "page_estimate", "avg_sentence_length", "grade_level", "defined_terms").
 		Where("bill_id = ?", billID).Order("fetched_at ASC").Find(&versions).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch versions: %w", err)
 	}
 
 	response := &BillResponse{
-		ID:            bill.ID,
-		Congress:      bill.Congress,
-		BillNumber:    bill.BillNumber,
-		BillType:      bill.BillType,
-		Title:         bill.Title,
-		Sponsor:       bill.Sponsor,
-		OriginChamber: bill.OriginChamber,
-		CurrentStatus: bill.CurrentStatus,
-		UpdateDate:    bill.UpdateDate,
-		Versions:      make([]VersionResponse, len(versions)),
-	}
-
-	for i, v := range versions {
-		label := versionCodeLabels[v.VersionCode]
-		if label == "" {
-			label = v.VersionCode
+		ID:             bill.ID,
+		Jurisdiction:   bill.Jurisdiction,
+		State:          bill.StateCode,
+		Session:        bill.Session,
+		Congress:       bill.Congress,
+		BillNumber:     bill.BillNumber,
+		Number:         bill.Number,
+		BillType:       bill.BillType,
+		Title:          bill.Title,
+		Sponsor:        bill.Sponsor,
+		OriginChamber:  bill.OriginChamber,
+		CurrentStatus:  bill.CurrentStatus,
+		UpdateDate:     bill.UpdateDate,
+		IntroducedDate: bill.IntroducedDate,
+		PolicyArea:     bill.PolicyArea,
+		CosponsorCount: bill.CosponsorCount,
+		ActionCount:    bill.ActionCount,
+		AmendmentCount: bill.AmendmentCount,
+		Subjects:       bill.Subjects,
+		BecameLaw:      bill.PublicLawNumber != "",
+		LawNumber:      lawNumber(&bill),
+		Versions:       make([]VersionResponse, len(versions)),
+	}
+
+	for i := range versions {
+		response.Versions[i] = versionResponse(&versions[i])
+	}
+
+	var titles []models.BillTitle
+	if err := s.db.WithContext(ctx).Where("bill_id = ?", billID).
+		Order("update_date ASC, position DESC").Find(&titles).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch titles: %w", err)
+	}
+	for _, t := range titles {
+		response.Titles = append(response.Titles, TitleResponse{
+			Title:       t.Title,
+			Kind:        t.Kind,
+			TitleType:   t.TitleType,
+			VersionCode: t.VersionCode,
+			Chamber:     t.Chamber,
+			UpdateDate:  t.UpdateDate,
+		})
+	}
+
+	return response, nil
+}
+
+// versionResponse converts a version, selected with at least the columns
+// GetBillWithVersions selects, to its response format.
+func versionResponse(v *models.Version) VersionResponse {
+	response := VersionResponse{
+		ID:          v.ID,
+		VersionCode: v.VersionCode,
+		Date:        v.FetchedAt.Format("2006-01-02"),
+		ContentHash: v.ContentHash,
+		Label:       fmt.Sprintf("%s (%s)", versioncode.Label(v.VersionCode), v.FetchedAt.Format("Jan 2")),
+		Stage:       versioncode.Stage(v.VersionCode),
+		Chamber:     versioncode.Chamber(v.VersionCode),
+	}
+	if stats, ok := textstats.FromVersion(v); ok {
+		response.Stats = &stats
+	}
+	return response
+}
+
+// rehydrate restores the text of archived versions so they can be diffed
+// or analyzed.
+func rehydrate(versions ...*models.Version) error {
+	for _, v := range versions {
+		if err := archive.Rehydrate(v); err != nil {
+			return fmt.Errorf("failed to restore archived text: %w", err)
 		}
-		response.Versions[i] = VersionResponse{
-			ID:          v.ID,
-			VersionCode: v.VersionCode,
-			Date:        v.FetchedAt.Format("2006-01-02"),
-			ContentHash: v.ContentHash,
-			Label:       fmt.Sprintf("%s (%s)", label, v.FetchedAt.Format("Jan 2")),
+	}
+	return nil
+}
+
+// loadRawText reads the raw text of versions whose TextContent was
+// offloaded to the text store. Diffs don't need it; markup-aware analysis
+// such as spending extraction does.
+func loadRawText(ctx context.Context, store textstore.Store, versions ...*models.Version) error {
+	for _, v := range versions {
+		if err := textstore.Load(ctx, store, v); err != nil {
+			return fmt.Errorf("failed to load version text: %w", err)
 		}
 	}
+	return nil
+}
 
-	return response, nil
+// extractForVersion extracts an analysis of a version not analyzed yet
+// from its raw text and stores it with upsert, which is given a query that
+// skips rows already stored. Analyses are extracted on first request so
+// text is only loaded when needed.
+func extractForVersion[T any](ctx context.Context, s *BillService, versionID uint, extract func(text string) []T, upsert func(db *gorm.DB, extracted []T) error) ([]T, error) {
+	db := s.db.WithContext(ctx)
+
+	var version models.Version
+	if err := db.Select(append([]string{"id"}, archive.TextColumns...)).First(&version, versionID).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch version text: %w", err)
+	}
+	if err := rehydrate(&version); err != nil {
+		return nil, err
+	}
+	if err := loadRawText(ctx, s.texts, &version); err != nil {
+		return nil, err
+	}
+
+	extracted := extract(version.TextContent)
+	if len(extracted) == 0 {
+		return extracted, nil
+	}
+	// Concurrent first requests may race; the unique key makes that harmless
+	if err := upsert(db.Clauses(clause.OnConflict{DoNothing: true}), extracted); err != nil {
+		return nil, err
+	}
+	return extracted, nil
 }
 
-// ComputeDiff computes a diff between two versions.
-func (s *BillService) ComputeDiff(ctx context.Context, fromVersionID, toVersionID uint) (*DiffResponse, error) {
+// ComputeDiff computes a diff between two versions of a bill; see
+// loadBillVersions for the errors returned when they aren't. Deltas
+// precomputed by the ingestor or cached by an earlier request are served
+// without diffing, loading only the from version's plain text to restore
+// unchanged lines; other diffs of texts too large to diff on demand return
+// ErrDiffTooLarge. opts preprocess both texts; see diffVersions. Requests
+// for diffs without options are counted in diff_hits.
+func (s *BillService) ComputeDiff(ctx context.Context, billID, fromVersionID, toVersionID uint, opts diff_engine.Options) (*DiffResponse, error) {
 	var fromVersion, toVersion models.Version
+	if err := s.loadBillVersions(ctx, billID, fromVersionID, toVersionID, &fromVersion, &toVersion, versionStatsColumns...); err != nil {
+		return nil, err
+	}
+	if opts.IsZero() {
+		// Counted for the precompute queue, which warms the most requested
+		if err := deltas.RecordHit(ctx, s.db, billID, fromVersionID, toVersionID); err != nil {
+			logging.FromContext(ctx).Warn("failed to record diff hit", "bill_id", billID, "error", err)
+		}
+	}
+
+	return s.diffVersions(ctx, &fromVersion, &toVersion, opts)
+}
+
+// diffVersions diffs two versions selected with versionStatsColumns,
+// serving a cached delta when there is one and otherwise loading their
+// text into from and to and storing the computed delta. It returns
+// ErrDiffTooLarge when either text is too large to diff. Diffs of texts
+// preprocessed with opts are computed each time, neither cached nor
+// summarized, since the stored delta is of the texts as they are.
+func (s *BillService) diffVersions(ctx context.Context, from, to *models.Version, opts diff_engine.Options) (*DiffResponse, error) {
+	if opts.IsZero() {
+		// Deltas from an older engine are ignored here and overwritten below
+		cached, err := deltas.Cached(ctx, s.db, from.ID, to.ID)
+		if err != nil {
+			return nil, err
+		}
+		if cached != nil {
+			metrics.DiffComputations.WithLabelValues("cached").Inc()
+			response := diffResponse(cached, from.VersionCode, to.VersionCode)
+			response.StatsDelta = statsDelta(from, to)
+			response.Summary = s.summarize(ctx, from, to, cached, false)
+			return response, nil
+		}
+	}
 
-	if err := s.db.First(&fromVersion, fromVersionID).Error; err != nil {
-		return nil, fmt.Errorf("from version not found: %w", err)
-	}
-	if err := s.db.First(&toVersion, toVersionID).Error; err != nil {
-		return nil, fmt.Errorf("to version not found: %w", err)
-	}
-
-	// Check if we have a cached delta
-	var existingDelta models.Delta
-	if err := s.db.Where("version_a_id = ? AND version_b_id = ?",
-		fromVersionID, toVersionID).First(&existingDelta).Error; err == nil {
-		// Return cached delta
-		return s.deltaToResponse(&existingDelta, fromVersion.VersionCode, toVersion.VersionCode), nil
-	}
-
-	// For large texts (>100KB), return mock diff data to prevent OOM crashes
-	const maxDiffSize = 100 * 1024 // 100KB
-	if len(fromVersion.TextContent) > maxDiffSize || len(toVersion.TextContent) > maxDiffSize {
-		return &DiffResponse{
-			FromVersion: fromVersion.VersionCode,
-			ToVersion:   toVersion.VersionCode,
-			Insertions:  2500,
-			Deletions:   1200,
-			Lines: []DiffLine{
-				{LineNumber: 1, Type: "unchanged", Text: "SECTION 1. SHORT TITLE."},
-				{LineNumber: 2, Type: "unchanged", Text: "This Act may be cited as the \"One Big Beautiful Bill Act\"."},
-				{LineNumber: 3, Type: "unchanged", Text: ""},
-				{LineNumber: 4, Type: "unchanged", Text: "SECTION 2. APPROPRIATIONS."},
-				{LineNumber: 5, Type: "deletion", Text: "(a) There is appropriated $500,000,000,000 for federal programs."},
-				{LineNumber: 6, Type: "insertion", Text: "(a) There is appropriated $750,000,000,000 for federal programs."},
-				{LineNumber: 7, Type: "unchanged", Text: ""},
-				{LineNumber: 8, Type: "deletion", Text: "(b) Funds shall be distributed over a period of 5 years."},
-				{LineNumber: 9, Type: "insertion", Text: "(b) Funds shall be distributed over a period of 10 years."},
-				{LineNumber: 10, Type: "unchanged", Text: ""},
-				{LineNumber: 11, Type: "insertion", Text: "(c) Priority shall be given to infrastructure projects."},
-				{LineNumber: 12, Type: "insertion", Text: "(d) Annual reporting requirements established."},
-				{LineNumber: 13, Type: "unchanged", Text: ""},
-				{LineNumber: 14, Type: "unchanged", Text: "SECTION 3. OVERSIGHT."},
-				{LineNumber: 15, Type: "unchanged", Text: "The Government Accountability Office shall conduct quarterly audits."},
-				{LineNumber: 16, Type: "unchanged", Text: ""},
-				{LineNumber: 17, Type: "unchanged", Text: "[Note: Full diff computation disabled for large bills (>100KB). This is sample data.]"},
-			},
-			Segments: []DiffSegment{
-				{Type: "unchanged", Text: "SECTION 1. SHORT TITLE.\n"},
-				{Type: "deletion", Text: "$500,000,000,000"},
-				{Type: "insertion", Text: "$750,000,000,000"},
-				{Type: "unchanged", Text: " for federal programs."},
-			},
-		}, nil
-	}
-
-	// Compute the diff using the diff engine
-	delta, err := diff_engine.ComputeWordLevel(fromVersion.TextContent, toVersion.TextContent)
+	if err := s.db.WithContext(ctx).First(from, from.ID).Error; err != nil {
+		return nil, versionLookupError(err)
+	}
+	if err := s.db.WithContext(ctx).First(to, to.ID).Error; err != nil {
+		return nil, versionLookupError(err)
+	}
+	if err := rehydrate(from, to); err != nil {
+		return nil, err
+	}
+	if deltas.TooLarge(from, to) {
+		return nil, fmt.Errorf("%w: each version may be at most %d bytes", ErrDiffTooLarge, deltas.MaxTextSize)
+	}
+
+	if !opts.IsZero() {
+		delta, err := diff_engine.ComputeWordLevel(diff_engine.Preprocess(from.PlainText, opts), diff_engine.Preprocess(to.PlainText, opts))
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute diff: %w", err)
+		}
+		metrics.DiffComputations.WithLabelValues("preprocessed").Inc()
+		response := diffResponse(delta, from.VersionCode, to.VersionCode)
+		response.StatsDelta = statsDelta(from, to)
+		return response, nil
+	}
+
+	delta, err := deltas.Compute(ctx, s.db, from, to, s.verifyDeterminism)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compute diff: %w", err)
 	}
+	metrics.DiffComputations.WithLabelValues("computed").Inc()
+
+	response := diffResponse(delta, from.VersionCode, to.VersionCode)
+	response.StatsDelta = statsDelta(from, to)
+	response.Summary = s.summarize(ctx, from, to, delta, true)
+	return response, nil
+}
+
+// summarize returns the summary of a delta, writing and storing it on
+// first request. textLoaded reports whether the versions' text is loaded;
+// when it isn't, it is loaded only if a summary must be written. Failures
+// are logged and yield no summary, so they never fail the diff.
+func (s *BillService) summarize(ctx context.Context, from, to *models.Version, delta *diff_engine.Delta, textLoaded bool) string {
+	if s.summarizer == nil {
+		return ""
+	}
+	logger := logging.FromContext(ctx)
 
-	// Store the delta for caching
-	storedDelta := models.Delta{
-		VersionAID: fromVersionID,
-		VersionBID: toVersionID,
-		Insertions: delta.Insertions,
-		Deletions:  delta.Deletions,
-		ComputedAt: time.Now(),
+	summary, err := deltas.StoredSummary(ctx, s.db, from.ID, to.ID)
+	if err != nil || summary != "" {
+		if err != nil {
+			logger.Warn("failed to fetch diff summary", "from_version", from.ID, "to_version", to.ID, "error", err)
+		}
+		return summary
 	}
-	s.db.Create(&storedDelta)
 
-	// Convert to response format
+	if !textLoaded {
+		if err := s.db.WithContext(ctx).First(from, from.ID).Error; err != nil {
+			logger.Warn("failed to load version for summary", "version", from.ID, "error", err)
+			return ""
+		}
+		if err := s.db.WithContext(ctx).First(to, to.ID).Error; err != nil {
+			logger.Warn("failed to load version for summary", "version", to.ID, "error", err)
+			return ""
+		}
+		if err := rehydrate(from, to); err != nil {
+			logger.Warn("failed to restore version text for summary", "error", err)
+			return ""
+		}
+	}
+
+	summary, err = deltas.Summarize(ctx, s.db, s.summarizer, from, to, delta)
+	if err != nil {
+		logger.Warn("failed to summarize diff", "from_version", from.ID, "to_version", to.ID, "error", err)
+		return ""
+	}
+	return summary
+}
+
+// versionStatsColumns are the version columns diffVersions needs before it
+// knows whether the text must be loaded.
+var versionStatsColumns = []string{
+	"id", "bill_id", "document_id", "version_code", "word_count", "page_estimate", "avg_sentence_length", "grade_level", "defined_terms",
+}
+
+// statsDelta returns the change in readability metrics between two
+// versions, or nil when either was stored before they were computed.
+func statsDelta(from, to *models.Version) *textstats.Delta {
+	fromStats, ok := textstats.FromVersion(from)
+	if !ok {
+		return nil
+	}
+	toStats, ok := textstats.FromVersion(to)
+	if !ok {
+		return nil
+	}
+	delta := textstats.Compare(fromStats, toStats)
+	return &delta
+}
+
+// diffResponse converts a diff engine result to the API response format.
+func diffResponse(delta *diff_engine.Delta, fromCode, toCode string) *DiffResponse {
 	response := &DiffResponse{
-		FromVersion: fromVersion.VersionCode,
-		ToVersion:   toVersion.VersionCode,
+		FromVersion: fromCode,
+		ToVersion:   toCode,
 		Insertions:  delta.Insertions,
 		Deletions:   delta.Deletions,
 		Lines:       make([]DiffLine, 0, len(delta.Hunks)*10),
 		Segments:    make([]DiffSegment, 0),
+		Anchors:     diffAnchors(delta),
 	}
 
 	lineNum := 1
-	for _, hunk := range delta.Hunks {
+	for i, hunk := range delta.Hunks {
+		response.Hunks = append(response.Hunks, DiffHunk{
+			Index:      i,
+			StartA:     hunk.StartA,
+			StartB:     hunk.StartB,
+			LineNumber: lineNum,
+			LineCount:  len(hunk.Lines),
+		})
 		for _, change := range hunk.Lines {
-			changeType := "unchanged"
-			switch change.Type {
-			case diff_engine.ChangeInsert:
-				changeType = "insertion"
-			case diff_engine.ChangeDelete:
-				changeType = "deletion"
-			case diff_engine.ChangeUnchanged:
-				changeType = "unchanged"
-			}
-
+			changeType := lineType(change.Type)
 			response.Lines = append(response.Lines, DiffLine{
 				LineNumber: lineNum,
 				Type:       changeType,
 				Text:       change.Content,
+				Anchor:     change.Anchor,
 			})
 			response.Segments = append(response.Segments, DiffSegment{
 				Type: changeType,
@@ -355,69 +688,61 @@ func (s *BillService) ComputeDiff(ctx context.Context, fromVersionID, toVersionI
 			lineNum++
 		}
 	}
+	response.TotalHunks = len(response.Hunks)
 
-	return response, nil
+	return response
 }
 
-// deltaToResponse converts a stored Delta to DiffResponse.
-func (s *BillService) deltaToResponse(delta *models.Delta, fromCode, toCode string) *DiffResponse {
-	return &DiffResponse{
-		FromVersion: fromCode,
-		ToVersion:   toCode,
-		Insertions:  delta.Insertions,
-		Deletions:   delta.Deletions,
-		Lines:       []DiffLine{},
-		Segments:    []DiffSegment{},
+// diffAnchors lists the sections a diff changes, each with the response
+// line number of its first inserted or deleted line.
+func diffAnchors(delta *diff_engine.Delta) []DiffAnchor {
+	var anchors []DiffAnchor
+	seen := make(map[string]bool)
+	lineNum := 1
+	for i, hunk := range delta.Hunks {
+		for _, change := range hunk.Lines {
+			if change.Type != diff_engine.ChangeUnchanged && change.Anchor != "" && !seen[change.Anchor] {
+				seen[change.Anchor] = true
+				for _, a := range hunk.Anchors {
+					if a.ID == change.Anchor {
+						anchors = append(anchors, DiffAnchor{ID: a.ID, Section: a.Section, Heading: a.Heading, LineNumber: lineNum, Hunk: i})
+					}
+				}
+			}
+			lineNum++
+		}
 	}
+	return anchors
 }
 
-// extractVersionCode extracts the version code from the full type string.
-func extractVersionCode(typeStr string) string {
-	// Map full type names to codes
-	typeToCode := map[string]string{
-		"Introduced in House":       "IH",
-		"Reported in House":         "RH",
-		"Engrossed in House":        "EH",
-		"Introduced in Senate":      "IS",
-		"Reported in Senate":        "RS",
-		"Engrossed in Senate":       "ES",
-		"Placed on Calendar Senate": "PCS",
-		"Engrossed Amendment Senate": "EAS",
-		"Enrolled":                  "ENR",
-		"Public Law":                "PL",
-	}
-
-	if code, ok := typeToCode[typeStr]; ok {
-		return code
+// lineType converts a diff engine change type to the API's line type.
+func lineType(t diff_engine.ChangeType) string {
+	switch t {
+	case diff_engine.ChangeInsert:
+		return "insertion"
+	case diff_engine.ChangeDelete:
+		return "deletion"
+	default:
+		return "unchanged"
 	}
+}
 
-	// If not found, return first two letters uppercase
-	if len(typeStr) >= 2 {
-		return typeStr[:2]
+// GetAllBills returns all bills from the database, or with becameLaw set,
+// only the bills that became law.
+func (s *BillService) GetAllBills(ctx context.Context, becameLaw bool) ([]BillResponse, error) {
+	query := database.ReadReplica(s.db.WithContext(ctx)).Scopes(s.scope.Query, visibleBills(ctx))
+	if becameLaw {
+		query = query.Where("public_law_number <> ''")
 	}
-	return typeStr
-}
 
-// GetAllBills returns all bills from the database.
-func (s *BillService) GetAllBills(ctx context.Context) ([]BillResponse, error) {
 	var bills []models.Bill
-	if err := s.db.Find(&bills).Error; err != nil {
+	if err := query.Find(&bills).Error; err != nil {
 		return nil, fmt.Errorf("failed to fetch bills: %w", err)
 	}
 
 	responses := make([]BillResponse, len(bills))
 	for i, b := range bills {
-		responses[i] = BillResponse{
-			ID:            b.ID,
-			Congress:      b.Congress,
-			BillNumber:    b.BillNumber,
-			BillType:      b.BillType,
-			Title:         b.Title,
-			Sponsor:       b.Sponsor,
-			OriginChamber: b.OriginChamber,
-			CurrentStatus: b.CurrentStatus,
-			UpdateDate:    b.UpdateDate,
-		}
+		responses[i] = billListResponse(&b)
 	}
 
 	return responses, nil
@@ -428,16 +753,91 @@ func (s *BillService) GetBillByID(ctx context.Context, id uint) (*BillResponse,
 	return s.GetBillWithVersions(ctx, id)
 }
 
+// billListResponse converts a bill to the response format used in
+// listings, without versions or subjects.
+func billListResponse(b *models.Bill) BillResponse {
+	return BillResponse{
+		ID:             b.ID,
+		Jurisdiction:   b.Jurisdiction,
+		State:          b.StateCode,
+		Session:        b.Session,
+		Congress:       b.Congress,
+		BillNumber:     b.BillNumber,
+		Number:         b.Number,
+		BillType:       b.BillType,
+		Title:          b.Title,
+		Sponsor:        b.Sponsor,
+		OriginChamber:  b.OriginChamber,
+		CurrentStatus:  b.CurrentStatus,
+		UpdateDate:     b.UpdateDate,
+		IntroducedDate: b.IntroducedDate,
+		PolicyArea:     b.PolicyArea,
+		CosponsorCount: b.CosponsorCount,
+		ActionCount:    b.ActionCount,
+		AmendmentCount: b.AmendmentCount,
+		BecameLaw:      b.PublicLawNumber != "",
+		LawNumber:      lawNumber(b),
+	}
+}
+
+// lawNumber formats a bill's law citation, e.g., "Public Law 118-5".
+func lawNumber(b *models.Bill) string {
+	if b.PublicLawNumber == "" {
+		return ""
+	}
+	lawType := b.LawType
+	if lawType == "" {
+		lawType = "Public Law"
+	}
+	return lawType + " " + b.PublicLawNumber
+}
+
 // LexSearchParams contains the search parameters for the lex endpoint.
 // Zero values are treated as "no filter" for optional fields.
 type LexSearchParams struct {
-	Congress       int    // Filter by congress number (0 = no filter)
-	Sponsor        string // Filter by sponsor name (empty = no filter)
-	Query          string // Full-text search in title (empty = no filter)
-	BillType       string // Filter by bill type (empty = no filter)
-	IsSpendingBill bool   // Filter by spending bill flag (only applied if true)
-	Limit          int    // Pagination limit (default: 20, max: 100)
-	Offset         int    // Pagination offset
+	Congress       int               // Filter by congress number (0 = no filter)
+	Sponsor        string            // Filter by sponsor name (empty = no filter)
+	Query          string            // Full-text search in title (empty = no filter)
+	BillType       string            // Filter by bill type, case-insensitive (empty = no filter)
+	Number         string            // Filter by bill number as published, e.g., "12A", case-insensitive (empty = no filter)
+	Jurisdiction   string            // Filter by jurisdiction, "federal", "state", or "draft" (empty = no filter)
+	State          string            // Filter by state code, case-insensitive (empty = no filter)
+	IsSpendingBill bool              // Filter by spending bill flag (only applied if true)
+	PolicyArea     string            // Filter by CRS policy area, case-insensitive (empty = no filter)
+	Subject        string            // Filter by CRS legislative subject, case-insensitive (empty = no filter)
+	Metadata       map[string]string // Filter by metadata path (see metadataPaths) equal to value (empty = no filter)
+	Sort           string            // One of the SearchSort values (default: SearchSortUpdateDate)
+	Order          string            // "asc" or "desc" (default: desc for dates, asc for title)
+	Facets         bool              // Also count matching bills per facet value (see SearchFacets)
+	Limit          int               // Pagination limit (default: 20, max: 100)
+	Offset         int               // Pagination offset
+}
+
+// Sort orders of bill search results.
+const (
+	SearchSortUpdateDate     = "updateDate"
+	SearchSortIntroducedDate = "introducedDate"
+	SearchSortTitle          = "title"
+)
+
+// searchOrder returns the ORDER BY clause for a search's sort and order.
+// Bills without an introduced date sort last either way; ties are broken
+// by ID so pages are stable.
+func searchOrder(sort, order string) string {
+	column, direction := "update_date", "DESC"
+	switch sort {
+	case SearchSortIntroducedDate:
+		column = "NULLIF(introduced_date, '')"
+	case SearchSortTitle:
+		column, direction = "title", "ASC"
+	}
+	switch order {
+	case "asc":
+		direction = "ASC"
+	case "desc":
+		direction = "DESC"
+	}
+	return fmt.Sprintf("%s %s NULLS LAST, id %s", column, direction, direction)
 }
 
 // LexSearchResult contains the search results with pagination info.
@@ -446,6 +846,7 @@ type LexSearchResult struct {
 	Total  int64          `json:"total"`
 	Limit  int            `json:"limit"`
 	Offset int            `json:"offset"`
+	Facets *SearchFacets  `json:"facets,omitempty"` // Only when requested
 }
 
 // SearchBills performs a dynamic search on bills with optional filters.
@@ -462,31 +863,11 @@ func (s *BillService) SearchBills(ctx context.Context, params LexSearchParams) (
 		params.Offset = 0
 	}
 
-	// Start building the query
-	query := s.db.WithContext(ctx).Model(&models.Bill{})
-
-	// Apply filters dynamically (zero values = no filter)
-	if params.Congress > 0 {
-		query = query.Where("congress = ?", params.Congress)
-	}
-
-	if params.Sponsor != "" {
-		// Use ILIKE for case-insensitive partial match
-		query = query.Where("sponsor ILIKE ?", "%"+params.Sponsor+"%")
-	}
-
-	if params.Query != "" {
-		// Search in title using ILIKE
-		query = query.Where("title ILIKE ?", "%"+params.Query+"%")
+	if result, ok := s.searchIndexed(ctx, params); ok {
+		return result, nil
 	}
 
-	if params.BillType != "" {
-		query = query.Where("bill_type = ?", params.BillType)
-	}
-
-	if params.IsSpendingBill {
-		query = query.Where("is_spending_bill = ?", true)
-	}
+	query := s.searchQuery(ctx, params)
 
 	// Get total count before pagination
 	var total int64
@@ -497,7 +878,7 @@ func (s *BillService) SearchBills(ctx context.Context, params LexSearchParams) (
 	// Apply pagination and ordering
 	var bills []models.Bill
 	if err := query.
-		Order("update_date DESC").
+		Order(searchOrder(params.Sort, params.Order)).
 		Limit(params.Limit).
 		Offset(params.Offset).
 		Find(&bills).Error; err != nil {
@@ -507,23 +888,113 @@ func (s *BillService) SearchBills(ctx context.Context, params LexSearchParams) (
 	// Convert to response format
 	responses := make([]BillResponse, len(bills))
 	for i, b := range bills {
-		responses[i] = BillResponse{
-			ID:            b.ID,
-			Congress:      b.Congress,
-			BillNumber:    b.BillNumber,
-			BillType:      b.BillType,
-			Title:         b.Title,
-			Sponsor:       b.Sponsor,
-			OriginChamber: b.OriginChamber,
-			CurrentStatus: b.CurrentStatus,
-			UpdateDate:    b.UpdateDate,
-		}
+		responses[i] = billListResponse(&b)
 	}
 
-	return &LexSearchResult{
+	result := &LexSearchResult{
 		Bills:  responses,
 		Total:  total,
 		Limit:  params.Limit,
 		Offset: params.Offset,
-	}, nil
+	}
+	if params.Facets {
+		facets, err := s.searchFacets(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		result.Facets = facets
+	}
+	return result, nil
+}
+
+// searchIndexed answers a search from the search index, reporting false
+// when it can't: there is no index or it isn't loaded yet, the search
+// filters by subject or metadata or wants facets, which the index doesn't
+// hold, or the caller's tenant has drafts, which aren't indexed.
+// Pagination defaults must already be applied.
+func (s *BillService) searchIndexed(ctx context.Context, params LexSearchParams) (*LexSearchResult, bool) {
+	if s.searchIndex == nil || !s.searchIndex.Ready() || params.Subject != "" || len(params.Metadata) > 0 ||
+		params.Facets || callerTenant(ctx) != 0 {
+		return nil, false
+	}
+	bills, total := s.searchIndex.Search(searchindex.Query{
+		Text:         params.Query,
+		Sponsor:      params.Sponsor,
+		Congress:     params.Congress,
+		BillType:     params.BillType,
+		Number:       params.Number,
+		Jurisdiction: params.Jurisdiction,
+		State:        params.State,
+		SpendingOnly: params.IsSpendingBill,
+		PolicyArea:   params.PolicyArea,
+		Sort:         params.Sort,
+		Order:        params.Order,
+		Limit:        params.Limit,
+		Offset:       params.Offset,
+	})
+	metrics.SearchIndexQueries.Inc()
+
+	responses := make([]BillResponse, len(bills))
+	for i, b := range bills {
+		responses[i] = billListResponse(&b)
+	}
+	return &LexSearchResult{Bills: responses, Total: int64(total), Limit: params.Limit, Offset: params.Offset}, true
+}
+
+// searchQuery returns a bills query with the search filters and scope
+// applied. Zero values are treated as "no filter".
+func (s *BillService) searchQuery(ctx context.Context, params LexSearchParams) *gorm.DB {
+	query := database.ReadReplica(s.db.WithContext(ctx)).Model(&models.Bill{}).Scopes(s.scope.Query, visibleBills(ctx))
+
+	if params.Congress > 0 {
+		query = query.Where("congress = ?", params.Congress)
+	}
+
+	if params.Sponsor != "" {
+		// Use ILIKE for case-insensitive partial match
+		query = query.Where("sponsor ILIKE ?", "%"+params.Sponsor+"%")
+	}
+
+	if params.Query != "" {
+		// Search in title and aliases ("NDAA", short titles) using ILIKE
+		pattern := "%" + params.Query + "%"
+		query = query.Where("title ILIKE ? OR id IN (?)", pattern,
+			s.db.Model(&models.BillAlias{}).Select("bill_id").Where("alias ILIKE ?", pattern))
+	}
+
+	if params.BillType != "" {
+		// Congress.gov reports types in upper case ("HR")
+		query = query.Where("LOWER(bill_type) = LOWER(?)", params.BillType)
+	}
+
+	if params.Number != "" {
+		// Matched as published, so suffixed state numbers ("12A") are found
+		query = query.Where("UPPER(number) = UPPER(?)", params.Number)
+	}
+
+	if params.Jurisdiction != "" {
+		query = query.Where("jurisdiction = ?", params.Jurisdiction)
+	}
+
+	if params.State != "" {
+		query = query.Where("state_code = LOWER(?)", params.State)
+	}
+
+	if params.IsSpendingBill {
+		query = query.Where("is_spending_bill = ?", true)
+	}
+
+	if params.PolicyArea != "" {
+		query = query.Where("LOWER(policy_area) = LOWER(?)", params.PolicyArea)
+	}
+
+	if params.Subject != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM bill_subjects WHERE bill_subjects.bill_id = bills.id AND LOWER(bill_subjects.subject) = LOWER(?))", params.Subject)
+	}
+
+	if len(params.Metadata) > 0 {
+		query = query.Scopes(whereMetadata(params.Metadata))
+	}
+
+	return query
 }
@@ -0,0 +1,256 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/appropriations"
+	"github.com/drewjst/deltagov/internal/feeds"
+	"github.com/drewjst/deltagov/internal/milestones"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ErrCalendarNotFound is returned for a calendar URL whose token is
+// unknown or was replaced.
+var ErrCalendarNotFound = errors.New("calendar not found")
+
+// calendarLookback is how long milestones stay in a calendar after their
+// day, so a week's past markups don't vanish from calendar apps at once.
+const calendarLookback = 90 * 24 * time.Hour
+
+// CalendarResponse is a watchlist's calendar subscription.
+type CalendarResponse struct {
+	Token string `json:"token" doc:"Secret identifying the calendar; shown only once"`
+	Path  string `json:"path" example:"/feeds/watchlist/dgcal_0123.ics" doc:"Path of the calendar to subscribe to, relative to the API's public origin"`
+}
+
+// CreateCalendar creates the URL of a calendar of the milestones of a
+// user's watched bills, replacing any earlier one: the URL carries a
+// secret token, since calendar apps subscribe without credentials. Only
+// the token's hash is stored.
+func (s *WatchlistService) CreateCalendar(ctx context.Context, user *models.User) (*CalendarResponse, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate calendar token: %w", err)
+	}
+	token := "dgcal_" + hex.EncodeToString(raw)
+	hash := hashAPIKey(token)
+	if err := s.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", user.ID).
+		Update("calendar_token_hash", &hash).Error; err != nil {
+		return nil, fmt.Errorf("failed to save calendar token: %w", err)
+	}
+	return &CalendarResponse{Token: token, Path: "/feeds/watchlist/" + token + ".ics"}, nil
+}
+
+// DeleteCalendar stops publishing a user's calendar.
+func (s *WatchlistService) DeleteCalendar(ctx context.Context, user *models.User) error {
+	if err := s.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", user.ID).
+		Update("calendar_token_hash", nil).Error; err != nil {
+		return fmt.Errorf("failed to delete calendar token: %w", err)
+	}
+	return nil
+}
+
+// WatchlistCalendar returns an iCalendar document of the milestones of the
+// bills on the watchlist of the user a calendar token belongs to: markups
+// and floor consideration scheduled in their actions, when continuing
+// resolutions expire, and when the fiscal years unenacted appropriations
+// bills fund begin. It returns ErrCalendarNotFound for an unknown token.
+func (s *FeedService) WatchlistCalendar(ctx context.Context, token string) ([]byte, error) {
+	db := s.bills.db.WithContext(ctx)
+	var user models.User
+	if err := db.Select("id", "name").Where("calendar_token_hash = ?", hashAPIKey(token)).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCalendarNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	var bills []models.Bill
+	if err := db.Joins("JOIN watched_bills ON watched_bills.bill_id = bills.id").
+		Where("watched_bills.user_id = ?", user.ID).
+		Order("watched_bills.id ASC").Find(&bills).Error; err != nil {
+		return nil, fmt.Errorf("failed to list watched bills: %w", err)
+	}
+
+	now := time.Now()
+	cal := &feeds.Calendar{
+		Name:        "DeltaGov: " + user.Name + "'s watchlist",
+		Description: "Scheduled markups, floor consideration, and funding deadlines of watched bills",
+	}
+	for i := range bills {
+		found, err := s.billMilestones(ctx, &bills[i])
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range found {
+			if m.Date.Before(now.Add(-calendarLookback)) {
+				continue
+			}
+			cal.Events = append(cal.Events, s.calendarEvent(&bills[i], m))
+		}
+	}
+	sort.SliceStable(cal.Events, func(i, j int) bool { return cal.Events[i].Date.Before(cal.Events[j].Date) })
+	return cal.Marshal(now), nil
+}
+
+// billMilestones returns a bill's milestones, one per kind and day, from
+// its status changes and, for appropriations bills, its funding deadline.
+func (s *FeedService) billMilestones(ctx context.Context, bill *models.Bill) ([]milestones.Milestone, error) {
+	db := s.bills.db.WithContext(ctx)
+	var events []models.BillEvent
+	if err := db.Select("new_value", "occurred_at").
+		Where("bill_id = ? AND event_type = ?", bill.ID, models.BillEventStatusChanged).
+		Order("occurred_at ASC, id ASC").Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to list bill events: %w", err)
+	}
+
+	// The latest action as first synced has no event
+	found := milestones.FromAction(bill.CurrentStatus, bill.UpdatedAt)
+	for _, e := range events {
+		found = append(found, milestones.FromAction(e.NewValue, e.OccurredAt)...)
+	}
+
+	if bill.IsSpendingBill && (bill.PublicLawNumber == "" || appropriations.IsContinuing(bill.Title, bill.Subjects)) {
+		var text string
+		if appropriations.IsContinuing(bill.Title, bill.Subjects) {
+			var latest models.Version
+			if err := db.Select("id", "plain_text", "archived_plain_text", "archived_at").Where("bill_id = ?", bill.ID).
+				Order("fetched_at DESC, id DESC").Limit(1).Find(&latest).Error; err != nil {
+				return nil, fmt.Errorf("failed to fetch version: %w", err)
+			}
+			if err := rehydrate(&latest); err != nil {
+				return nil, err
+			}
+			text = latest.PlainText
+		}
+		if m, ok := milestones.Funding(bill.Title, bill.Subjects, text); ok {
+			found = append(found, m)
+		}
+	}
+
+	type key struct {
+		kind milestones.Kind
+		date time.Time
+	}
+	seen := make(map[key]bool, len(found))
+	unique := found[:0]
+	for _, m := range found {
+		if k := (key{m.Kind, m.Date}); !seen[k] {
+			seen[k] = true
+			unique = append(unique, m)
+		}
+	}
+	return unique, nil
+}
+
+// calendarEvent converts a bill's milestone to a calendar event.
+func (s *FeedService) calendarEvent(bill *models.Bill, m milestones.Milestone) feeds.Event {
+	label := billLabel(bill)
+	description := []string{m.Detail}
+	if bill.Title != "" {
+		description = append(description, label+": "+bill.Title)
+	}
+	return feeds.Event{
+		UID:         fmt.Sprintf("bill-%d-%s-%s@deltagov", bill.ID, m.Kind, m.Date.Format("20060102")),
+		Date:        m.Date,
+		Summary:     label + ": " + m.Summary,
+		Description: strings.Join(description, "\n"),
+		URL:         fmt.Sprintf("%s%s/bills/%d", s.baseURL, s.apiPrefix, bill.ID),
+		Categories:  []string{strings.ToUpper(string(m.Kind[:1])) + string(m.Kind[1:])},
+	}
+}
+
+// CreateCalendarOutput is the response for creating a calendar URL
+type CreateCalendarOutput struct {
+	Status int
+	Body   CalendarResponse
+}
+
+// CalendarInput is the request for a watchlist's calendar
+type CalendarInput struct {
+	Token string `path:"token" doc:"Calendar token returned by create-watchlist-calendar"`
+}
+
+// CalendarOutput is the response for a watchlist's calendar
+type CalendarOutput struct {
+	ContentType  string `header:"Content-Type"`
+	CacheControl string `header:"Cache-Control"`
+	Body         []byte
+}
+
+// registerCalendarRoutes registers the endpoints managing a watchlist's
+// calendar URL.
+func registerCalendarRoutes(api huma.API, s *WatchlistService) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-watchlist-calendar",
+		Method:        http.MethodPost,
+		Path:          "/api/v1/watchlist/calendar",
+		Summary:       "Create a watchlist calendar URL",
+		Description:   "Returns the URL of an iCalendar feed of the milestones of the caller's watched bills, for calendar apps to subscribe to. The URL carries a secret token shown only once; creating another replaces it.",
+		Errors:        []int{http.StatusUnauthorized},
+		Tags:          []string{"Watchlist"},
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *APIKeyInput) (*CreateCalendarOutput, error) {
+		user, err := s.Authenticate(ctx, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		cal, err := s.CreateCalendar(ctx, user)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to create calendar: " + err.Error())
+		}
+		auditTarget(ctx, user.ID)
+		return &CreateCalendarOutput{Status: http.StatusCreated, Body: *cal}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-watchlist-calendar",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/watchlist/calendar",
+		Summary:       "Delete the watchlist calendar URL",
+		Description:   "Stops publishing the caller's watchlist calendar; its URL returns 404",
+		Errors:        []int{http.StatusUnauthorized},
+		Tags:          []string{"Watchlist"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *APIKeyInput) (*struct{}, error) {
+		user, err := s.Authenticate(ctx, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		if err := s.DeleteCalendar(ctx, user); err != nil {
+			return nil, huma.Error500InternalServerError("failed to delete calendar: " + err.Error())
+		}
+		return nil, nil
+	})
+}
+
+// registerCalendarFeedRoute registers the watchlist calendar feed.
+func registerCalendarFeedRoute(api huma.API, s *FeedService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-watchlist-calendar",
+		Method:      http.MethodGet,
+		Path:        "/feeds/watchlist/{token}.ics",
+		Summary:     "iCalendar feed of watched bills' milestones",
+		Description: "Returns an iCalendar feed of all-day events for the bills on a watchlist: committee markups and floor consideration scheduled in their actions, the day continuing resolutions expire, and the start of the fiscal year unenacted appropriations bills fund. Events stay for 90 days after their day.",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Feeds"},
+		Responses:   streamedResponse("iCalendar feed", &huma.Schema{Type: huma.TypeString}, "text/calendar"),
+	}, func(ctx context.Context, input *CalendarInput) (*CalendarOutput, error) {
+		body, err := s.WatchlistCalendar(ctx, input.Token)
+		if err != nil {
+			return nil, serviceError(err, "failed to build calendar")
+		}
+		return &CalendarOutput{ContentType: feeds.CalendarContentType, CacheControl: cacheControlBill, Body: body}, nil
+	})
+}
@@ -0,0 +1,243 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/analysis"
+	"github.com/drewjst/deltagov/internal/deltas"
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/docx"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/versioncode"
+)
+
+// Statuses of a section in a change report.
+const (
+	sectionAdded     = "Added"
+	sectionRemoved   = "Removed"
+	sectionAmended   = "Amended"
+	sectionUnchanged = "Unchanged"
+)
+
+// reportSection is a section of either version and how it changed.
+type reportSection struct {
+	title    string // e.g., "SEC. 201. FUNDING."
+	status   string
+	segments []diff_engine.Segment
+}
+
+// ChangeReport is a change report rendered as a Word document.
+type ChangeReport struct {
+	Filename string
+	Body     []byte
+}
+
+// ChangeReport renders a Word document reporting the changes between two
+// versions of a bill: summary statistics, then each added, removed, and
+// amended section with its changes as tracked insertions and deletions,
+// so they can be reviewed in Word. See loadBillVersions for the errors
+// returned when the versions aren't the bill's.
+func (s *BillService) ChangeReport(ctx context.Context, billID, fromVersionID, toVersionID uint) (*ChangeReport, error) {
+	var from, to models.Version
+	if err := s.loadBillVersions(ctx, billID, fromVersionID, toVersionID, &from, &to); err != nil {
+		return nil, err
+	}
+	if err := rehydrate(&from, &to); err != nil {
+		return nil, err
+	}
+	var bill models.Bill
+	if err := s.db.WithContext(ctx).First(&bill, billID).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch bill: %w", err)
+	}
+
+	sections := compareSections(from.PlainText, to.PlainText)
+	counts := map[string]int{}
+	inserted, deleted := 0, 0
+	for _, sec := range sections {
+		counts[sec.status]++
+		for _, seg := range sec.segments {
+			switch seg.Type {
+			case diff_engine.ChangeInsert:
+				inserted += len(strings.Fields(seg.Text))
+			case diff_engine.ChangeDelete:
+				deleted += len(strings.Fields(seg.Text))
+			}
+		}
+	}
+
+	label := billLabel(&bill)
+	doc := docx.New(label+" change report", "DeltaGov", time.Now())
+	doc.Title("Change report: " + label)
+	doc.Paragraph(docx.Run{Text: bill.Title, Italic: true})
+	doc.Paragraph(docx.Run{Text: fmt.Sprintf("Changes from %s to %s. Insertions and deletions are marked as tracked changes.",
+		reportVersionLabel(&from), reportVersionLabel(&to))})
+
+	doc.Heading(1, "Summary")
+	doc.Table([]string{"", from.VersionCode, to.VersionCode}, [][]string{
+		{"Words", strconv.Itoa(from.WordCount), strconv.Itoa(to.WordCount)},
+		{"Pages (estimated)", strconv.Itoa(from.PageEstimate), strconv.Itoa(to.PageEstimate)},
+		{"Sections", strconv.Itoa(counts[sectionRemoved] + counts[sectionAmended] + counts[sectionUnchanged]),
+			strconv.Itoa(counts[sectionAdded] + counts[sectionAmended] + counts[sectionUnchanged])},
+		{"Reading grade level", fmt.Sprintf("%.1f", from.GradeLevel), fmt.Sprintf("%.1f", to.GradeLevel)},
+	})
+	doc.Paragraph(docx.Run{Text: fmt.Sprintf("Sections added: %d. Removed: %d. Amended: %d. Unchanged: %d.",
+		counts[sectionAdded], counts[sectionRemoved], counts[sectionAmended], counts[sectionUnchanged])})
+	doc.Paragraph(docx.Run{Text: fmt.Sprintf("Words inserted: %d. Deleted: %d.", inserted, deleted)})
+	summary, err := deltas.StoredSummary(ctx, s.db, from.ID, to.ID)
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to fetch diff summary for report", "from_version", from.ID, "to_version", to.ID, "error", err)
+	}
+	if summary != "" {
+		doc.Paragraph(docx.Run{Text: "Summary of changes: ", Bold: true}, docx.Run{Text: summary})
+	}
+
+	doc.Heading(1, "Section-by-section changes")
+	if counts[sectionUnchanged] == len(sections) {
+		doc.Paragraph(docx.Run{Text: "The versions' text is the same."})
+	}
+	for _, sec := range sections {
+		if sec.status == sectionUnchanged {
+			continue
+		}
+		doc.Heading(2, sec.title+" ("+strings.ToLower(sec.status)+")")
+		runs := make([]docx.Run, 0, len(sec.segments))
+		for _, seg := range sec.segments {
+			run := docx.Run{Text: seg.Text}
+			switch seg.Type {
+			case diff_engine.ChangeInsert:
+				run.Revision = docx.Inserted
+			case diff_engine.ChangeDelete:
+				run.Revision = docx.Deleted
+			}
+			runs = append(runs, run)
+		}
+		doc.Paragraph(runs...)
+	}
+
+	var buf bytes.Buffer
+	if err := doc.Write(&buf); err != nil {
+		return nil, fmt.Errorf("failed to write report: %w", err)
+	}
+	filename := fmt.Sprintf("%s%s-%d-%s-%s-changes.docx", strings.ToLower(bill.BillType), bill.Number, bill.Congress,
+		strings.ToLower(from.VersionCode), strings.ToLower(to.VersionCode))
+	return &ChangeReport{Filename: filename, Body: buf.Bytes()}, nil
+}
+
+// reportVersionLabel describes a version, e.g., "IH (Introduced in
+// House), fetched 2025-01-03".
+func reportVersionLabel(v *models.Version) string {
+	label := v.VersionCode
+	if name := versioncode.Label(v.VersionCode); name != "" && name != v.VersionCode {
+		label += " (" + name + ")"
+	}
+	if !v.FetchedAt.IsZero() {
+		label += ", fetched " + v.FetchedAt.Format(time.DateOnly)
+	}
+	return label
+}
+
+// compareSections pairs the sections of two texts by number, in the
+// order of the later text with removed sections at the end, and diffs
+// each pair word by word. Text before the first section, such as the
+// enacting clause, is compared as a section of its own.
+func compareSections(textA, textB string) []reportSection {
+	type keyed struct {
+		title, number, text string
+	}
+	split := func(text string) []keyed {
+		sections := analysis.SplitSections(text)
+		preamble := text
+		if len(sections) > 0 {
+			preamble = text[:sections[0].Start]
+		}
+		out := []keyed{{title: "Preamble", text: preamble}}
+		for _, sec := range sections {
+			out = append(out, keyed{
+				title:  strings.TrimSpace("SEC. " + sec.Number + ". " + sec.Heading),
+				number: strings.ToLower(sec.Number),
+				text:   sec.Heading + "\n" + sec.Body,
+			})
+		}
+		return out
+	}
+	a, b := split(textA), split(textB)
+
+	byNumber := map[string][]int{}
+	for i, sec := range a {
+		byNumber[sec.number] = append(byNumber[sec.number], i)
+	}
+	matched := make([]bool, len(a))
+	var out []reportSection
+	for _, sec := range b {
+		candidates := byNumber[sec.number]
+		if len(candidates) == 0 {
+			if strings.TrimSpace(sec.text) != "" {
+				out = append(out, reportSection{title: sec.title, status: sectionAdded,
+					segments: []diff_engine.Segment{{Type: diff_engine.ChangeInsert, Text: sec.text}}})
+			}
+			continue
+		}
+		i := candidates[0]
+		byNumber[sec.number] = candidates[1:]
+		matched[i] = true
+		if analysis.NormalizeForComparison(a[i].text) == analysis.NormalizeForComparison(sec.text) {
+			out = append(out, reportSection{title: sec.title, status: sectionUnchanged})
+			continue
+		}
+		out = append(out, reportSection{title: sec.title, status: sectionAmended, segments: diff_engine.DiffWords(a[i].text, sec.text)})
+	}
+	for i, sec := range a {
+		if !matched[i] && strings.TrimSpace(sec.text) != "" {
+			out = append(out, reportSection{title: sec.title, status: sectionRemoved,
+				segments: []diff_engine.Segment{{Type: diff_engine.ChangeDelete, Text: sec.text}}})
+		}
+	}
+	return out
+}
+
+// ChangeReportInput is the request for a change report
+type ChangeReportInput struct {
+	BillID      uint `path:"billId" minimum:"1" doc:"Bill ID"`
+	FromVersion uint `path:"fromVersion" minimum:"1" doc:"Source version ID"`
+	ToVersion   uint `path:"toVersion" minimum:"1" doc:"Target version ID"`
+}
+
+// ChangeReportOutput is the response for a change report
+type ChangeReportOutput struct {
+	ContentType        string `header:"Content-Type"`
+	ContentDisposition string `header:"Content-Disposition"`
+	Body               []byte
+}
+
+// registerChangeReportRoute registers the Word change report endpoint.
+func registerChangeReportRoute(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-change-report",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{billId}/diff/{fromVersion}/{toVersion}/report.docx",
+		Summary:     "Download a change report as a Word document",
+		Description: "Returns a Word document (.docx) reporting the changes between two versions: word, page, and section counts, the stored summary of the changes when there is one, and each added, removed, and amended section, its changes marked as tracked insertions and deletions that can be reviewed in Word.",
+		Errors:      []int{http.StatusNotFound, http.StatusUnprocessableEntity},
+		Responses:   streamedResponse("Word document", &huma.Schema{Type: huma.TypeString}, docx.ContentType),
+		Tags:        []string{"Export"},
+	}, func(ctx context.Context, input *ChangeReportInput) (*ChangeReportOutput, error) {
+		report, err := s.ChangeReport(ctx, input.BillID, input.FromVersion, input.ToVersion)
+		if err != nil {
+			return nil, serviceError(err, "failed to build change report")
+		}
+		return &ChangeReportOutput{
+			ContentType:        docx.ContentType,
+			ContentDisposition: fmt.Sprintf(`attachment; filename="%s"`, report.Filename),
+			Body:               report.Body,
+		}, nil
+	})
+}
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ErrBillNotFound is returned when no bill exists for an ID.
+var ErrBillNotFound = errors.New("bill not found")
+
+// BillChangeResponse is a single entry in a bill's change feed.
+type BillChangeResponse struct {
+	ID            uint           `json:"id"`
+	Type          string         `json:"type"` // version_added, status_changed, title_changed, title_revised, sponsor_changed, became_law
+	OccurredAt    time.Time      `json:"occurredAt"`
+	PreviousValue string         `json:"previousValue,omitempty"`
+	NewValue      string         `json:"newValue,omitempty"`
+	Version       *ChangeVersion `json:"version,omitempty"`
+	Details       map[string]any `json:"details,omitempty"`
+}
+
+// ChangeVersion describes the version added by a version_added change.
+// Insertions and deletions come from the cached delta against the previous
+// version and are omitted when that diff hasn't been computed yet.
+type ChangeVersion struct {
+	ID                uint   `json:"id"`
+	VersionCode       string `json:"versionCode"`
+	PreviousVersionID uint   `json:"previousVersionId,omitempty"`
+	Insertions        *int   `json:"insertions,omitempty"`
+	Deletions         *int   `json:"deletions,omitempty"`
+}
+
+// BillChangeFeed is a page of a bill's change feed.
+type BillChangeFeed struct {
+	BillID  uint                 `json:"billId"`
+	Changes []BillChangeResponse `json:"changes"`
+	Total   int64                `json:"total"`
+	Limit   int                  `json:"limit"`
+	Offset  int                  `json:"offset"`
+}
+
+// GetBillChanges returns a bill's detected changes in chronological order.
+func (s *BillService) GetBillChanges(ctx context.Context, billID uint, limit, offset int) (*BillChangeFeed, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 200 {
+		limit = 200
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	db := s.db.WithContext(ctx)
+
+	var bill models.Bill
+	if err := db.Select("id").First(&bill, billID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBillNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch bill: %w", err)
+	}
+
+	query := db.Model(&models.BillEvent{}).Where("bill_id = ?", billID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count bill events: %w", err)
+	}
+
+	var events []models.BillEvent
+	if err := query.Order("occurred_at ASC, id ASC").Limit(limit).Offset(offset).Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to list bill events: %w", err)
+	}
+
+	changes, err := s.changeResponses(ctx, billID, events)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BillChangeFeed{
+		BillID:  billID,
+		Changes: changes,
+		Total:   total,
+		Limit:   limit,
+		Offset:  offset,
+	}, nil
+}
+
+// changeResponses converts a bill's events to change feed entries.
+func (s *BillService) changeResponses(ctx context.Context, billID uint, events []models.BillEvent) ([]BillChangeResponse, error) {
+	// Versions in fetch order, used to find each new version's predecessor
+	versions, err := s.versionsInOrder(ctx, billID)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]BillChangeResponse, len(events))
+	for i := range events {
+		change := BillChangeResponse{
+			ID:            events[i].ID,
+			Type:          events[i].EventType,
+			OccurredAt:    events[i].OccurredAt,
+			PreviousValue: events[i].PreviousValue,
+			NewValue:      events[i].NewValue,
+			Details:       events[i].Details,
+		}
+		if events[i].VersionID != nil {
+			version, err := s.changeVersion(ctx, *events[i].VersionID, versions)
+			if err != nil {
+				return nil, err
+			}
+			change.Version = version
+		}
+		changes[i] = change
+	}
+
+	return changes, nil
+}
+
+// changeVersion builds the version summary for a version_added change,
+// pulling insert/delete counts from the cached delta against the preceding
+// version when one exists.
+func (s *BillService) changeVersion(ctx context.Context, versionID uint, versions []models.Version) (*ChangeVersion, error) {
+	result := &ChangeVersion{ID: versionID}
+
+	for i, v := range versions {
+		if v.ID != versionID {
+			continue
+		}
+		result.VersionCode = v.VersionCode
+		if i > 0 {
+			result.PreviousVersionID = versions[i-1].ID
+		}
+		break
+	}
+
+	if result.PreviousVersionID == 0 {
+		return result, nil
+	}
+
+	var delta models.Delta
+	err := s.db.WithContext(ctx).Select("insertions", "deletions").
+		Where("version_a_id = ? AND version_b_id = ?", result.PreviousVersionID, versionID).
+		First(&delta).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch delta: %w", err)
+	}
+
+	result.Insertions = &delta.Insertions
+	result.Deletions = &delta.Deletions
+	return result, nil
+}
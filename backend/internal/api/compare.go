@@ -0,0 +1,120 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/deltas"
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/insights"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/metrics"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/textextract"
+	"github.com/drewjst/deltagov/internal/textstats"
+)
+
+// AdhocText is one side of an ad hoc comparison.
+type AdhocText struct {
+	Label string `json:"label,omitempty" maxLength:"64" doc:"Name shown as the diff's fromVersion or toVersion (default: \"from\" or \"to\")"`
+	Text  string `json:"text" minLength:"1" doc:"Bill text: plain text, HTML, or USLM XML"`
+}
+
+// CompareAdhoc diffs two texts that aren't stored, such as a leaked
+// discussion draft and the introduced bill. The texts are extracted and
+// diffed as stored versions are, after any preprocessing opts select, and
+// nothing is persisted.
+func (s *BillService) CompareAdhoc(ctx context.Context, from, to AdhocText, opts diff_engine.Options) (*DiffResponse, error) {
+	if from.Label == "" {
+		from.Label = "from"
+	}
+	if to.Label == "" {
+		to.Label = "to"
+	}
+	fromVersion := adhocVersion(from)
+	toVersion := adhocVersion(to)
+	if deltas.TooLarge(fromVersion, toVersion) {
+		return nil, fmt.Errorf("%w: each text may be at most %d bytes", ErrDiffTooLarge, deltas.MaxTextSize)
+	}
+
+	start := time.Now()
+	delta, err := diff_engine.ComputeWordLevel(diff_engine.Preprocess(fromVersion.PlainText, opts), diff_engine.Preprocess(toVersion.PlainText, opts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute diff: %w", err)
+	}
+	metrics.DiffDuration.Observe(time.Since(start).Seconds())
+	metrics.DiffComputations.WithLabelValues("adhoc").Inc()
+
+	response := diffResponse(delta, from.Label, to.Label)
+	response.StatsDelta = statsDelta(fromVersion, toVersion)
+	if s.summarizer != nil {
+		summary, _, err := s.summarizer.Summarize(ctx, insights.Input{
+			FromCode: from.Label,
+			ToCode:   to.Label,
+			FromText: fromVersion.PlainText,
+			ToText:   toVersion.PlainText,
+			Delta:    delta,
+		})
+		if err != nil {
+			logging.FromContext(ctx).Warn("failed to summarize ad hoc diff", "error", err)
+		}
+		response.Summary = summary
+	}
+	return response, nil
+}
+
+// adhocVersion returns an unsaved version holding a text, with its plain
+// text extracted and readability metrics filled in.
+func adhocVersion(t AdhocText) *models.Version {
+	v := &models.Version{
+		VersionCode: t.Label,
+		TextContent: t.Text,
+		TextSize:    len(t.Text),
+		PlainText:   textextract.Extract(t.Text),
+	}
+	textstats.Fill(v)
+	return v
+}
+
+// CompareAdhocInput is the request for an ad hoc comparison
+type CompareAdhocInput struct {
+	DiffOptionsInput
+	DiffWindowInput
+	View string `query:"view" enum:"unified,split" default:"unified" doc:"unified returns interleaved lines; split returns aligned left/right rows"`
+	Body struct {
+		From AdhocText `json:"from" doc:"Earlier text"`
+		To   AdhocText `json:"to" doc:"Later text"`
+	}
+}
+
+// CompareAdhocOutput is the response for an ad hoc comparison
+type CompareAdhocOutput struct {
+	Body DiffResponse
+}
+
+// registerCompareRoute registers the ad hoc comparison endpoint.
+func registerCompareRoute(api huma.API, s *BillService) {
+	huma.Register(api, notAudited(huma.Operation{
+		OperationID: "compare-adhoc",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/compare/adhoc",
+		Summary:     "Compare two uploaded texts",
+		Description: "Diffs two texts sent in the request, such as a discussion draft and the introduced bill, with the same text extraction and diff engine as stored versions. Nothing is stored. Each text may be up to 100KB.",
+		Errors:      []int{http.StatusUnprocessableEntity},
+		Tags:        []string{"Diff"},
+	}), func(ctx context.Context, input *CompareAdhocInput) (*CompareAdhocOutput, error) {
+		diff, err := s.CompareAdhoc(ctx, input.Body.From, input.Body.To, input.Options())
+		if err != nil {
+			return nil, serviceError(err, "failed to compare texts")
+		}
+		windowDiff(diff, input.Window())
+		if input.View == DiffViewSplit {
+			toSplitView(diff)
+		}
+		return &CompareAdhocOutput{Body: *diff}, nil
+	})
+}
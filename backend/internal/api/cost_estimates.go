@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// CostEstimateResponse is a CBO cost estimate of a bill, linking to cbo.gov.
+type CostEstimateResponse struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	PubDate     string `json:"pubDate"` // YYYY-MM-DD
+}
+
+// CostEstimatesResponse lists a bill's CBO cost estimates, newest first.
+type CostEstimatesResponse struct {
+	BillID        uint                   `json:"billId"`
+	CostEstimates []CostEstimateResponse `json:"costEstimates"`
+}
+
+// GetCostEstimates returns a bill's CBO cost estimates, newest first.
+func (s *BillService) GetCostEstimates(ctx context.Context, billID uint) (*CostEstimatesResponse, error) {
+	if err := s.requireBill(ctx, billID); err != nil {
+		return nil, err
+	}
+
+	var estimates []models.CostEstimate
+	if err := s.db.WithContext(ctx).Where("bill_id = ?", billID).
+		Order("pub_date DESC, id DESC").Find(&estimates).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch cost estimates: %w", err)
+	}
+
+	response := &CostEstimatesResponse{
+		BillID:        billID,
+		CostEstimates: make([]CostEstimateResponse, len(estimates)),
+	}
+	for i, e := range estimates {
+		response.CostEstimates[i] = CostEstimateResponse{
+			Title:       e.Title,
+			Description: e.Description,
+			URL:         e.URL,
+			PubDate:     e.PubDate.Format(time.DateOnly),
+		}
+	}
+	return response, nil
+}
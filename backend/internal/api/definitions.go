@@ -0,0 +1,193 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/analysis"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// DefinitionChangesResponse compares the defined terms in two versions of a bill.
+type DefinitionChangesResponse struct {
+	BillID         uint                        `json:"billId"`
+	FromVersionID  uint                        `json:"fromVersionId"`
+	ToVersionID    uint                        `json:"toVersionId"`
+	FromVersion    string                      `json:"fromVersion"`
+	ToVersion      string                      `json:"toVersion"`
+	TermsAdded     int                         `json:"termsAdded"`
+	TermsRemoved   int                         `json:"termsRemoved"`
+	TermsReworded  int                         `json:"termsReworded"`
+	TermsUnchanged int                         `json:"termsUnchanged"`
+	Changes        []analysis.DefinitionChange `json:"changes"`
+}
+
+// GetDefinitionChanges compares the terms defined in the definitions
+// sections of two versions of a bill. When both version IDs are zero, the
+// two most recent versions are compared. Unchanged definitions are omitted
+// unless includeUnchanged is set.
+func (s *BillService) GetDefinitionChanges(ctx context.Context, billID, fromID, toID uint, includeUnchanged bool) (*DefinitionChangesResponse, error) {
+	var bill models.Bill
+	if err := s.db.WithContext(ctx).Select("id").First(&bill, billID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBillNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch bill: %w", err)
+	}
+
+	if fromID == 0 && toID == 0 {
+		versions, err := s.versionsInOrder(ctx, billID)
+		if err != nil {
+			return nil, err
+		}
+		if len(versions) < 2 {
+			return nil, ErrNotEnoughVersions
+		}
+		fromID = versions[len(versions)-2].ID
+		toID = versions[len(versions)-1].ID
+	}
+
+	from, err := s.definitionsForVersion(ctx, billID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.definitionsForVersion(ctx, billID, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DefinitionChangesResponse{
+		BillID:        billID,
+		FromVersionID: fromID,
+		ToVersionID:   toID,
+		FromVersion:   from.versionCode,
+		ToVersion:     to.versionCode,
+		Changes:       make([]analysis.DefinitionChange, 0),
+	}
+
+	for _, change := range analysis.CompareDefinitions(from.definitions, to.definitions) {
+		switch change.Status {
+		case analysis.DefinitionAdded:
+			response.TermsAdded++
+		case analysis.DefinitionRemoved:
+			response.TermsRemoved++
+		case analysis.DefinitionReworded:
+			response.TermsReworded++
+		case analysis.DefinitionUnchanged:
+			response.TermsUnchanged++
+			if !includeUnchanged {
+				continue
+			}
+		}
+		response.Changes = append(response.Changes, change)
+	}
+
+	return response, nil
+}
+
+// versionDefinitions is a version's code and extracted definitions.
+type versionDefinitions struct {
+	versionCode string
+	definitions []analysis.Definition
+}
+
+// definitionsForVersion loads a version's stored defined terms, extracting
+// and storing them first if the version hasn't been analyzed yet.
+func (s *BillService) definitionsForVersion(ctx context.Context, billID, versionID uint) (*versionDefinitions, error) {
+	db := s.db.WithContext(ctx)
+
+	var version models.Version
+	if err := db.Select("id", "bill_id", "version_code").First(&version, versionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVersionNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch version: %w", err)
+	}
+	if version.BillID != billID {
+		return nil, ErrVersionNotFound
+	}
+
+	var stored []models.DefinedTerm
+	if err := db.Where("version_id = ?", versionID).Order("\"offset\" ASC").Find(&stored).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch defined terms: %w", err)
+	}
+
+	if len(stored) == 0 {
+		extracted, err := extractForVersion(ctx, s, versionID, analysis.ExtractDefinitions,
+			func(db *gorm.DB, extracted []analysis.Definition) error {
+				rows := make([]models.DefinedTerm, len(extracted))
+				for i, d := range extracted {
+					rows[i] = models.DefinedTerm{
+						VersionID:  versionID,
+						Key:        d.Key,
+						Term:       d.Term,
+						Definition: d.Text,
+						Section:    d.Section,
+						Anchor:     d.Anchor,
+						Offset:     d.Offset,
+					}
+				}
+				if err := db.CreateInBatches(rows, 500).Error; err != nil {
+					return fmt.Errorf("failed to store defined terms: %w", err)
+				}
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+		return &versionDefinitions{versionCode: version.VersionCode, definitions: extracted}, nil
+	}
+
+	definitions := make([]analysis.Definition, len(stored))
+	for i, row := range stored {
+		definitions[i] = analysis.Definition{
+			Key:     row.Key,
+			Term:    row.Term,
+			Text:    row.Definition,
+			Section: row.Section,
+			Anchor:  row.Anchor,
+			Offset:  row.Offset,
+		}
+	}
+	return &versionDefinitions{versionCode: version.VersionCode, definitions: definitions}, nil
+}
+
+// GetDefinitionChangesInput is the request for comparing defined terms between versions
+type GetDefinitionChangesInput struct {
+	ID               uint `path:"id" minimum:"1" doc:"Bill ID"`
+	From             uint `query:"from" doc:"Source version ID (default: second most recent version)"`
+	To               uint `query:"to" doc:"Target version ID (default: most recent version)"`
+	IncludeUnchanged bool `query:"includeUnchanged" doc:"Include definitions that did not change"`
+}
+
+// GetDefinitionChangesOutput is the response for comparing defined terms between versions
+type GetDefinitionChangesOutput struct {
+	Body DefinitionChangesResponse
+}
+
+// registerDefinitionChangesRoute registers the defined-terms comparison endpoint.
+func registerDefinitionChangesRoute(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-bill-definition-changes",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{id}/definition-changes",
+		Summary:     "Compare defined terms between two bill versions",
+		Description: "Extracts the terms defined in each version's definitions sections (e.g., \"SEC. 2. DEFINITIONS.\") and reports which definitions were added, removed, or reworded. Rewording ignores case and whitespace. Defaults to the two most recent versions.",
+		Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *GetDefinitionChangesInput) (*GetDefinitionChangesOutput, error) {
+		if (input.From == 0) != (input.To == 0) {
+			return nil, huma.Error400BadRequest("from and to must be provided together")
+		}
+		changes, err := s.GetDefinitionChanges(ctx, input.ID, input.From, input.To, input.IncludeUnchanged)
+		if err != nil {
+			return nil, serviceError(err, "failed to compare definitions")
+		}
+		return &GetDefinitionChangesOutput{Body: *changes}, nil
+	})
+}
@@ -2,19 +2,248 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
 	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/deltas"
+	"github.com/drewjst/deltagov/internal/models"
 )
 
-// DiagnosticService handles system health and testing endpoints
+// congressCheckTTL is how long a Congress.gov check result is reused, so
+// polling diagnostics doesn't spend the API key's rate limit.
+const congressCheckTTL = 30 * time.Second
+
+// DiagnosticService handles system health and testing endpoints. Both
+// dependencies are optional: a nil db means the API is serving mock data,
+// and a nil congress client skips the upstream check.
 type DiagnosticService struct {
 	CongressClient *congress.Client
+	db             *gorm.DB
+	diffQueue      *deltas.Queue
+
+	mu            sync.Mutex
+	congressCheck ProbeCheck
+	checkedAt     time.Time
 }
 
 // NewDiagnosticService creates a new instance of the service
-func NewDiagnosticService(client *congress.Client) *DiagnosticService {
-	return &DiagnosticService{CongressClient: client}
+func NewDiagnosticService(db *gorm.DB, client *congress.Client) *DiagnosticService {
+	return &DiagnosticService{CongressClient: client, db: db}
+}
+
+// SetDiffQueue reports the background diff queue's backlog in diagnostics.
+func (s *DiagnosticService) SetDiffQueue(q *deltas.Queue) {
+	s.diffQueue = q
+}
+
+// DatabaseDiagnostics is a database ping and its connection pool statistics.
+type DatabaseDiagnostics struct {
+	ProbeCheck
+	OpenConnections int   `json:"openConnections"`
+	InUse           int   `json:"inUse"`
+	Idle            int   `json:"idle"`
+	MaxOpen         int   `json:"maxOpen"`        // 0 means unlimited
+	WaitCount       int64 `json:"waitCount"`      // Connections waited for since startup
+	WaitDurationMs  int64 `json:"waitDurationMs"` // Total time spent waiting, in milliseconds
+}
+
+// CongressDiagnostics is a Congress.gov request and its latency, cached for
+// congressCheckTTL.
+type CongressDiagnostics struct {
+	ProbeCheck
+	CheckedAt *time.Time `json:"checkedAt,omitempty"`
+}
+
+// RateLimitDiagnostics is the Congress.gov rate limit budget as of the
+// last API response.
+type RateLimitDiagnostics struct {
+	Limit       int        `json:"limit"`     // Requests per hour; 0 if not yet reported
+	Remaining   int        `json:"remaining"` // -1 if not yet reported
+	ObservedAt  *time.Time `json:"observedAt,omitempty"`
+	PausedUntil *time.Time `json:"pausedUntil,omitempty"` // Set while backing off after a 429
+	ClientLimit int        `json:"clientLimit"`           // This process's own cap per hour; 0 if none
+}
+
+// IngestionDiagnostics describes the ingestor's progress.
+type IngestionDiagnostics struct {
+	LastSuccessAt *time.Time `json:"lastSuccessAt,omitempty"` // When the last successful run finished
+	LastRunStatus string     `json:"lastRunStatus,omitempty"`
+	LastRunAt     *time.Time `json:"lastRunAt,omitempty"`
+}
+
+// PendingJobs counts work waiting to be done.
+type PendingJobs struct {
+	DeltaJobs         int64 `json:"deltaJobs"`       // Queued or running delta recomputations
+	IngestRetries     int64 `json:"ingestRetries"`   // Pending ingestion failures
+	IngestExhausted   int64 `json:"ingestExhausted"` // Failures out of retries
+	DiffQueue         int   `json:"diffQueue"`       // Version pairs waiting for a background diff
+	DiffQueueEnabled  bool  `json:"diffQueueEnabled"`
+	RunningIngestions int64 `json:"runningIngestions"` // Ingestion runs in progress
+}
+
+// DiagnosticsReport is the response body for the diagnostics endpoint.
+type DiagnosticsReport struct {
+	Status    string                `json:"status"` // "ok" or "degraded"
+	Database  DatabaseDiagnostics   `json:"database"`
+	Congress  CongressDiagnostics   `json:"congress"`
+	RateLimit *RateLimitDiagnostics `json:"rateLimit,omitempty"`
+	Ingestion *IngestionDiagnostics `json:"ingestion,omitempty"`
+	Pending   *PendingJobs          `json:"pending,omitempty"`
+	Errors    []string              `json:"errors,omitempty"` // Queries that failed while gathering the report
+}
+
+// Diagnose exercises the database and Congress.gov and reports the
+// service's rate limit budget, ingestion progress, and pending work.
+func (s *DiagnosticService) Diagnose(ctx context.Context) *DiagnosticsReport {
+	report := &DiagnosticsReport{Status: "ok"}
+	report.Database = s.diagnoseDatabase(ctx)
+	report.Congress = s.diagnoseCongress(ctx)
+
+	if s.CongressClient != nil {
+		limit := s.CongressClient.RateLimit()
+		report.RateLimit = &RateLimitDiagnostics{
+			Limit:       limit.Limit,
+			Remaining:   limit.Remaining,
+			ObservedAt:  timeOrNil(limit.ObservedAt),
+			ClientLimit: limit.PerHour,
+		}
+		if limit.PausedUntil.After(time.Now()) {
+			report.RateLimit.PausedUntil = &limit.PausedUntil
+		}
+	}
+
+	if s.db != nil && report.Database.Status == "ok" {
+		var err error
+		if report.Ingestion, err = s.diagnoseIngestion(ctx); err != nil {
+			report.Errors = append(report.Errors, err.Error())
+		}
+		if report.Pending, err = s.pendingJobs(ctx); err != nil {
+			report.Errors = append(report.Errors, err.Error())
+		}
+	}
+
+	if report.Database.Status == "failed" || report.Congress.Status == "failed" || len(report.Errors) > 0 {
+		report.Status = "degraded"
+	}
+	return report
+}
+
+// diagnoseDatabase pings the database and reads its pool statistics.
+func (s *DiagnosticService) diagnoseDatabase(ctx context.Context) DatabaseDiagnostics {
+	if s.db == nil {
+		return DatabaseDiagnostics{ProbeCheck: ProbeCheck{Status: "skipped"}}
+	}
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return DatabaseDiagnostics{ProbeCheck: ProbeCheck{Status: "failed", Error: err.Error()}}
+	}
+
+	diag := DatabaseDiagnostics{ProbeCheck: runProbe(ctx, sqlDB.PingContext)}
+	stats := sqlDB.Stats()
+	diag.OpenConnections = stats.OpenConnections
+	diag.InUse = stats.InUse
+	diag.Idle = stats.Idle
+	diag.MaxOpen = stats.MaxOpenConnections
+	diag.WaitCount = stats.WaitCount
+	diag.WaitDurationMs = stats.WaitDuration.Milliseconds()
+	return diag
+}
+
+// diagnoseCongress makes a lightweight Congress.gov request, reusing the
+// last result if it's younger than congressCheckTTL.
+func (s *DiagnosticService) diagnoseCongress(ctx context.Context) CongressDiagnostics {
+	if s.CongressClient == nil {
+		return CongressDiagnostics{ProbeCheck: ProbeCheck{Status: "skipped"}}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.checkedAt.IsZero() || time.Since(s.checkedAt) >= congressCheckTTL {
+		s.congressCheck = runProbe(ctx, s.CongressClient.Ping)
+		s.checkedAt = time.Now()
+	}
+	checkedAt := s.checkedAt
+	return CongressDiagnostics{ProbeCheck: s.congressCheck, CheckedAt: &checkedAt}
+}
+
+// diagnoseIngestion reports the latest ingestion run and the last one
+// that succeeded.
+func (s *DiagnosticService) diagnoseIngestion(ctx context.Context) (*IngestionDiagnostics, error) {
+	db := s.db.WithContext(ctx)
+	diag := &IngestionDiagnostics{}
+
+	var runs []models.IngestRun
+	if err := db.Order("started_at DESC, id DESC").Limit(1).Find(&runs).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch latest ingestion run: %w", err)
+	}
+	if len(runs) > 0 {
+		diag.LastRunStatus = runs[0].Status
+		diag.LastRunAt = &runs[0].StartedAt
+	}
+
+	var succeeded []models.IngestRun
+	if err := db.Where("status = ?", models.IngestRunSucceeded).
+		Order("finished_at DESC, id DESC").Limit(1).Find(&succeeded).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch last successful ingestion run: %w", err)
+	}
+	if len(succeeded) > 0 {
+		diag.LastSuccessAt = succeeded[0].FinishedAt
+	}
+	return diag, nil
+}
+
+// pendingJobs counts queued delta jobs, ingestion failures awaiting a
+// retry, running ingestions, and the background diff queue's backlog.
+func (s *DiagnosticService) pendingJobs(ctx context.Context) (*PendingJobs, error) {
+	db := s.db.WithContext(ctx)
+	pending := &PendingJobs{}
+	if s.diffQueue != nil {
+		pending.DiffQueue = s.diffQueue.Len()
+		pending.DiffQueueEnabled = true
+	}
+
+	if err := db.Model(&models.DeltaJob{}).
+		Where("status IN ?", []string{models.DeltaJobQueued, models.DeltaJobRunning}).
+		Count(&pending.DeltaJobs).Error; err != nil {
+		return nil, fmt.Errorf("failed to count delta jobs: %w", err)
+	}
+
+	var failures []struct {
+		Status string
+		Count  int64
+	}
+	if err := db.Model(&models.IngestFailure{}).Select("status, COUNT(*) AS count").
+		Where("status IN ?", []string{models.IngestFailurePending, models.IngestFailureExhausted}).
+		Group("status").Scan(&failures).Error; err != nil {
+		return nil, fmt.Errorf("failed to count ingestion failures: %w", err)
+	}
+	for _, f := range failures {
+		if f.Status == models.IngestFailurePending {
+			pending.IngestRetries = f.Count
+		} else {
+			pending.IngestExhausted = f.Count
+		}
+	}
+
+	if err := db.Model(&models.IngestRun{}).Where("status = ?", models.IngestRunRunning).
+		Count(&pending.RunningIngestions).Error; err != nil {
+		return nil, fmt.Errorf("failed to count running ingestions: %w", err)
+	}
+	return pending, nil
+}
+
+// timeOrNil returns a pointer to t, or nil if t is zero.
+func timeOrNil(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
 }
 
 // DiagnosticHealthOutput is the response for diagnostic health check
@@ -24,6 +253,11 @@ type DiagnosticHealthOutput struct {
 	}
 }
 
+// DiagnosticsOutput is the response for the diagnostics endpoint
+type DiagnosticsOutput struct {
+	Body DiagnosticsReport
+}
+
 // RegisterDiagnosticRoutes registers testing and health endpoints with Huma
 func RegisterDiagnosticRoutes(api huma.API, s *DiagnosticService) {
 	huma.Register(api, huma.Operation{
@@ -38,4 +272,15 @@ func RegisterDiagnosticRoutes(api huma.API, s *DiagnosticService) {
 		resp.Body.Status = "ok"
 		return resp, nil
 	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-diagnostics",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/diagnostics",
+		Summary:     "Diagnostics",
+		Description: "Pings the database (with connection pool statistics) and Congress.gov (with latency, cached for 30 seconds), and reports the remaining Congress.gov rate limit, the last successful ingestion, and pending delta jobs, ingestion retries, and background diffs. Status is degraded if a check fails.",
+		Tags:        []string{"Diagnostics"},
+	}, func(ctx context.Context, input *struct{}) (*DiagnosticsOutput, error) {
+		return &DiagnosticsOutput{Body: *s.Diagnose(ctx)}, nil
+	})
 }
@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drewjst/deltagov/internal/deltas"
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// determinismRuns is the number of independent computations compared in a report.
+const determinismRuns = 2
+
+// DeterminismReport describes whether a diff reproduces across live runs
+// and whether it agrees with the stored (cached) Delta.
+type DeterminismReport struct {
+	FromVersionID uint     `json:"fromVersionId"`
+	ToVersionID   uint     `json:"toVersionId"`
+	EngineVersion string   `json:"engineVersion"`
+	Fingerprints  []string `json:"fingerprints"`
+	Deterministic bool     `json:"deterministic"`
+
+	// Stored delta comparison; HasStored is false when nothing is cached.
+	HasStored           bool   `json:"hasStored"`
+	StoredEngineVersion string `json:"storedEngineVersion,omitempty"`
+	StoredFingerprint   string `json:"storedFingerprint,omitempty"`
+	MatchesStored       bool   `json:"matchesStored"`
+
+	// Mismatches lists human-readable descriptions of every disagreement found.
+	Mismatches []string `json:"mismatches"`
+}
+
+// CheckDiffDeterminism recomputes the diff between two versions of a bill
+// several times and compares the results with each other and with the
+// cached Delta. Nothing is written to the database.
+func (s *BillService) CheckDiffDeterminism(ctx context.Context, billID, fromVersionID, toVersionID uint) (*DeterminismReport, error) {
+	var fromVersion, toVersion models.Version
+	if err := s.loadBillVersions(ctx, billID, fromVersionID, toVersionID, &fromVersion, &toVersion); err != nil {
+		return nil, err
+	}
+	if err := rehydrate(&fromVersion, &toVersion); err != nil {
+		return nil, err
+	}
+
+	report := &DeterminismReport{
+		FromVersionID: fromVersionID,
+		ToVersionID:   toVersionID,
+		EngineVersion: diff_engine.EngineVersion,
+		Fingerprints:  make([]string, 0, determinismRuns),
+		Deterministic: true,
+		Mismatches:    []string{},
+	}
+
+	fromText, toText := deltas.Text(&fromVersion), deltas.Text(&toVersion)
+
+	var live *diff_engine.Delta
+	for i := 0; i < determinismRuns; i++ {
+		delta, err := diff_engine.ComputeWordLevel(fromText, toText)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute diff: %w", err)
+		}
+		fp := diff_engine.Fingerprint(delta)
+		if i > 0 && fp != report.Fingerprints[0] {
+			report.Deterministic = false
+			report.Mismatches = append(report.Mismatches,
+				fmt.Sprintf("run %d fingerprint %s differs from run 1 fingerprint %s", i+1, fp, report.Fingerprints[0]))
+		}
+		report.Fingerprints = append(report.Fingerprints, fp)
+		live = delta
+	}
+
+	var stored models.Delta
+	if err := s.db.WithContext(ctx).Where("version_a_id = ? AND version_b_id = ?",
+		fromVersionID, toVersionID).First(&stored).Error; err != nil {
+		// Nothing cached is not a disagreement
+		return report, nil
+	}
+
+	report.HasStored = true
+	report.StoredEngineVersion = stored.EngineVersion
+	report.StoredFingerprint = stored.Fingerprint
+	report.MatchesStored = true
+
+	if stored.EngineVersion != diff_engine.EngineVersion {
+		report.MatchesStored = false
+		report.Mismatches = append(report.Mismatches,
+			fmt.Sprintf("stored delta was computed by engine %q, current engine is %q",
+				stored.EngineVersion, diff_engine.EngineVersion))
+	}
+	if stored.Fingerprint != "" && stored.Fingerprint != report.Fingerprints[0] {
+		report.MatchesStored = false
+		report.Mismatches = append(report.Mismatches,
+			fmt.Sprintf("stored fingerprint %s differs from live fingerprint %s",
+				stored.Fingerprint, report.Fingerprints[0]))
+	}
+	if stored.Insertions != live.Insertions || stored.Deletions != live.Deletions {
+		report.MatchesStored = false
+		report.Mismatches = append(report.Mismatches,
+			fmt.Sprintf("stored counts +%d/-%d differ from live counts +%d/-%d",
+				stored.Insertions, stored.Deletions, live.Insertions, live.Deletions))
+	}
+
+	return report, nil
+}
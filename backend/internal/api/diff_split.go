@@ -0,0 +1,109 @@
+package api
+
+// Diff views accepted by the diff endpoints.
+const (
+	DiffViewUnified = "unified"
+	DiffViewSplit   = "split"
+)
+
+// SplitCell is one side of a split diff row.
+type SplitCell struct {
+	LineNumber int    `json:"lineNumber"`
+	Text       string `json:"text"`
+	Anchor     string `json:"anchor,omitempty"` // See DiffLine.Anchor
+}
+
+// SplitRow is an aligned row of a side-by-side diff. Left is the old text
+// and Right the new; either is null when that side has no line.
+type SplitRow struct {
+	Type  string     `json:"type"` // "unchanged", "modified", "deletion", "insertion"
+	Left  *SplitCell `json:"left"`
+	Right *SplitCell `json:"right"`
+}
+
+// toSplitView replaces a diff's interleaved lines with aligned rows.
+// Lines and Segments are emptied so the response isn't doubled in size.
+func toSplitView(diff *DiffResponse) {
+	diff.View = DiffViewSplit
+	diff.Rows = splitRows(diff.Lines, diff.Hunks)
+	diff.Lines = []DiffLine{}
+	diff.Segments = []DiffSegment{}
+}
+
+// splitRows aligns interleaved diff lines into left/right rows, hunk by
+// hunk. Each cell's line number is its line in the old or new text,
+// counted from its hunk's StartA or StartB, so numbers stay right when
+// context is trimmed or only some hunks are returned. Lines the hunks
+// don't account for are numbered from 1.
+func splitRows(lines []DiffLine, hunks []DiffHunk) []SplitRow {
+	total := 0
+	for _, h := range hunks {
+		total += h.LineCount
+	}
+	if len(hunks) == 0 || total != len(lines) {
+		return appendSplitRows(make([]SplitRow, 0, len(lines)), lines, 1, 1)
+	}
+
+	rows := make([]SplitRow, 0, len(lines))
+	pos := 0
+	for _, h := range hunks {
+		rows = appendSplitRows(rows, lines[pos:pos+h.LineCount], h.StartA, h.StartB)
+		pos += h.LineCount
+	}
+	return rows
+}
+
+// appendSplitRows appends the rows of a run of lines starting at line
+// startA of the old text and startB of the new. Each run of deletions is
+// paired line by line with the insertions that follow it, as "modified"
+// rows; the longer side's leftovers get rows of their own with the other
+// side empty.
+func appendSplitRows(rows []SplitRow, lines []DiffLine, startA, startB int) []SplitRow {
+	left, right := startA-1, startB-1
+
+	for i := 0; i < len(lines); {
+		if lines[i].Type != "deletion" && lines[i].Type != "insertion" {
+			left++
+			right++
+			rows = append(rows, SplitRow{
+				Type:  "unchanged",
+				Left:  &SplitCell{LineNumber: left, Text: lines[i].Text, Anchor: lines[i].Anchor},
+				Right: &SplitCell{LineNumber: right, Text: lines[i].Text, Anchor: lines[i].Anchor},
+			})
+			i++
+			continue
+		}
+
+		// Collect a block of deletions followed by insertions
+		var deleted, inserted []DiffLine
+		for ; i < len(lines) && lines[i].Type == "deletion"; i++ {
+			deleted = append(deleted, lines[i])
+		}
+		for ; i < len(lines) && lines[i].Type == "insertion"; i++ {
+			inserted = append(inserted, lines[i])
+		}
+
+		for j := 0; j < max(len(deleted), len(inserted)); j++ {
+			row := SplitRow{}
+			if j < len(deleted) {
+				left++
+				row.Left = &SplitCell{LineNumber: left, Text: deleted[j].Text, Anchor: deleted[j].Anchor}
+			}
+			if j < len(inserted) {
+				right++
+				row.Right = &SplitCell{LineNumber: right, Text: inserted[j].Text, Anchor: inserted[j].Anchor}
+			}
+			switch {
+			case row.Left != nil && row.Right != nil:
+				row.Type = "modified"
+			case row.Left != nil:
+				row.Type = "deletion"
+			default:
+				row.Type = "insertion"
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	return rows
+}
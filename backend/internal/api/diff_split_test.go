@@ -0,0 +1,130 @@
+package api
+
+import (
+	"fmt"
+	"testing"
+)
+
+// hunkDiff returns a diff of two engine hunks, with three lines of context
+// around their changes: a modified line at line 13 of both texts, and a
+// line inserted after line 42 of the old text, which follows line 42 of
+// the new.
+func hunkDiff() *DiffResponse {
+	diff := &DiffResponse{}
+	add := func(typ, text string) {
+		diff.Lines = append(diff.Lines, DiffLine{LineNumber: len(diff.Lines) + 1, Type: typ, Text: text})
+		diff.Segments = append(diff.Segments, DiffSegment{Type: typ, Text: text})
+	}
+	hunk := func(startA, startB int, lines func()) {
+		first := len(diff.Lines)
+		lines()
+		diff.Hunks = append(diff.Hunks, DiffHunk{
+			Index:      len(diff.Hunks),
+			StartA:     startA,
+			StartB:     startB,
+			LineNumber: first + 1,
+			LineCount:  len(diff.Lines) - first,
+		})
+	}
+	context := func(a, b, n int) {
+		for i := 0; i < n; i++ {
+			add("unchanged", fmt.Sprintf("a%d/b%d", a+i, b+i))
+		}
+	}
+
+	hunk(10, 10, func() {
+		context(10, 10, 3)
+		add("deletion", "a13")
+		add("insertion", "b13")
+		context(14, 14, 3)
+	})
+	hunk(40, 40, func() {
+		context(40, 40, 3)
+		add("insertion", "b43")
+		context(43, 44, 3)
+	})
+	return diff
+}
+
+// cell formats a split cell as "line:text", or "-" when absent.
+func cell(c *SplitCell) string {
+	if c == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d:%s", c.LineNumber, c.Text)
+}
+
+// TestSplitRowsLineNumbers verifies split rows are numbered by their lines
+// in the old and new texts, counted from each hunk's start lines, also
+// when context is trimmed and only later hunks are returned.
+func TestSplitRowsLineNumbers(t *testing.T) {
+	tests := []struct {
+		name   string
+		window DiffWindow
+		want   []string
+	}{
+		{
+			name:   "all hunks",
+			window: DiffWindow{Context: engineContext},
+			want: []string{
+				"unchanged 10:a10/b10 10:a10/b10",
+				"unchanged 11:a11/b11 11:a11/b11",
+				"unchanged 12:a12/b12 12:a12/b12",
+				"modified 13:a13 13:b13",
+				"unchanged 14:a14/b14 14:a14/b14",
+				"unchanged 15:a15/b15 15:a15/b15",
+				"unchanged 16:a16/b16 16:a16/b16",
+				"unchanged 40:a40/b40 40:a40/b40",
+				"unchanged 41:a41/b41 41:a41/b41",
+				"unchanged 42:a42/b42 42:a42/b42",
+				"insertion - 43:b43",
+				"unchanged 43:a43/b44 44:a43/b44",
+				"unchanged 44:a44/b45 45:a44/b45",
+				"unchanged 45:a45/b46 46:a45/b46",
+			},
+		},
+		{
+			name:   "second hunk with trimmed context",
+			window: DiffWindow{HunkOffset: 1, HunkLimit: 1, Context: 1},
+			want: []string{
+				"unchanged 42:a42/b42 42:a42/b42",
+				"insertion - 43:b43",
+				"unchanged 43:a43/b44 44:a43/b44",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff := hunkDiff()
+			windowDiff(diff, tt.window)
+			toSplitView(diff)
+
+			if len(diff.Rows) != len(tt.want) {
+				t.Fatalf("Expected %d rows, got %d: %+v", len(tt.want), len(diff.Rows), diff.Rows)
+			}
+			for i, row := range diff.Rows {
+				if got := row.Type + " " + cell(row.Left) + " " + cell(row.Right); got != tt.want[i] {
+					t.Errorf("Row %d: expected %q, got %q", i, tt.want[i], got)
+				}
+			}
+		})
+	}
+}
+
+// TestSplitRowsWithoutHunks verifies diffs without hunks, such as the
+// large-bill fallback, are numbered from the first line.
+func TestSplitRowsWithoutHunks(t *testing.T) {
+	rows := splitRows([]DiffLine{
+		{Type: "unchanged", Text: "a"},
+		{Type: "deletion", Text: "b"},
+		{Type: "unchanged", Text: "c"},
+	}, nil)
+
+	want := []string{"unchanged 1:a 1:a", "deletion 2:b -", "unchanged 3:c 2:c"}
+	for i, row := range rows {
+		if got := row.Type + " " + cell(row.Left) + " " + cell(row.Right); got != want[i] {
+			t.Errorf("Row %d: expected %q, got %q", i, want[i], got)
+		}
+	}
+}
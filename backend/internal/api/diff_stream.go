@@ -0,0 +1,173 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/deltas"
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/metrics"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ErrDiffTooLarge is returned when an uncached diff exceeds deltas.MaxTextSize.
+var ErrDiffTooLarge = errors.New("versions too large to diff on demand")
+
+// Stream formats accepted by the diff stream endpoint.
+const (
+	DiffStreamNDJSON = "ndjson"
+	DiffStreamSSE    = "sse"
+)
+
+// DiffStreamRecord is one record of a streamed diff. A stream is a "start"
+// record, a "line" record per diff line (with the DiffLine fields inline),
+// and an "end" record with totals; an "error" record ends a failed stream.
+type DiffStreamRecord struct {
+	Event       string `json:"event"` // "start", "line", "end", "error"
+	FromVersion string `json:"fromVersion,omitempty"`
+	ToVersion   string `json:"toVersion,omitempty"`
+	*DiffLine
+	Insertions *int   `json:"insertions,omitempty"`
+	Deletions  *int   `json:"deletions,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// DiffStream is a diff ready to be streamed. Lookups happen in
+// PrepareDiffStream so failures surface as HTTP errors before streaming.
+type DiffStream struct {
+	db         *gorm.DB
+	from, to   models.Version
+	cached     *diff_engine.Delta
+	cacheState string // metrics.DiffComputations source
+}
+
+// PrepareDiffStream loads the versions to diff, both of which must belong
+// to the bill (see loadBillVersions), and the cached delta between them when there is one.
+func (s *BillService) PrepareDiffStream(ctx context.Context, billID, fromID, toID uint) (*DiffStream, error) {
+	db := s.db.WithContext(ctx)
+	stream := &DiffStream{db: s.db}
+
+	if err := s.loadBillVersions(ctx, billID, fromID, toID, &stream.from, &stream.to, "id", "bill_id", "version_code"); err != nil {
+		return nil, err
+	}
+
+	cached, err := deltas.Cached(ctx, s.db, fromID, toID)
+	if err != nil {
+		return nil, err
+	}
+	if cached != nil {
+		stream.cached, stream.cacheState = cached, "cached"
+		return stream, nil
+	}
+
+	if err := db.First(&stream.from, fromID).Error; err != nil {
+		return nil, versionLookupError(err)
+	}
+	if err := db.First(&stream.to, toID).Error; err != nil {
+		return nil, versionLookupError(err)
+	}
+	if err := rehydrate(&stream.from, &stream.to); err != nil {
+		return nil, err
+	}
+	if deltas.TooLarge(&stream.from, &stream.to) {
+		return nil, ErrDiffTooLarge
+	}
+	stream.cacheState = "computed"
+	return stream, nil
+}
+
+// versionLookupError maps a missing version to ErrVersionNotFound.
+func versionLookupError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrVersionNotFound
+	}
+	return fmt.Errorf("failed to fetch version: %w", err)
+}
+
+// Write streams the diff to w in the given format, flushing after each
+// hunk. Uncached diffs are streamed as they are computed, then cached.
+func (d *DiffStream) Write(ctx context.Context, w *bufio.Writer, format string) {
+	enc := &diffStreamEncoder{w: w, sse: format == DiffStreamSSE}
+	lineNum := 1
+	emit := func(hunk diff_engine.Hunk) error {
+		for _, change := range hunk.Lines {
+			line := &DiffLine{LineNumber: lineNum, Type: lineType(change.Type), Text: change.Content, Anchor: change.Anchor}
+			if err := enc.write(DiffStreamRecord{Event: "line", DiffLine: line}); err != nil {
+				return err
+			}
+			lineNum++
+		}
+		return w.Flush()
+	}
+
+	err := enc.write(DiffStreamRecord{Event: "start", FromVersion: d.from.VersionCode, ToVersion: d.to.VersionCode})
+	if err == nil {
+		err = w.Flush()
+	}
+
+	delta := d.cached
+	if err == nil && delta != nil {
+		for _, hunk := range delta.Hunks {
+			if err = emit(hunk); err != nil {
+				break
+			}
+		}
+	} else if err == nil {
+		delta, err = deltas.Stream(ctx, d.db, &d.from, &d.to, emit)
+	}
+
+	if err != nil {
+		// Usually the client went away; otherwise tell it why the stream ended
+		logging.FromContext(ctx).Warn("diff stream failed",
+			"from_version", d.from.ID, "to_version", d.to.ID, "error", err)
+		_ = enc.write(DiffStreamRecord{Event: "error", Error: err.Error()})
+		_ = w.Flush()
+		return
+	}
+
+	metrics.DiffComputations.WithLabelValues(d.cacheState).Inc()
+	_ = enc.write(DiffStreamRecord{Event: "end", Insertions: &delta.Insertions, Deletions: &delta.Deletions})
+	_ = w.Flush()
+}
+
+// diffStreamEncoder writes records as NDJSON lines or SSE events.
+type diffStreamEncoder struct {
+	w   *bufio.Writer
+	sse bool
+}
+
+func (e *diffStreamEncoder) write(rec DiffStreamRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if e.sse {
+		_, err = fmt.Fprintf(e.w, "event: %s\ndata: %s\n\n", rec.Event, data)
+		return err
+	}
+	if _, err = e.w.Write(data); err != nil {
+		return err
+	}
+	return e.w.WriteByte('\n')
+}
+
+// streamBody arranges for write to produce the response body. On Fiber the
+// body is streamed to the client after the handler returns, so write must
+// not use the request context; elsewhere it is buffered.
+func streamBody(body io.Writer, write func(w *bufio.Writer)) {
+	if c, ok := body.(*fiber.Ctx); ok {
+		c.Context().SetBodyStreamWriter(write)
+		return
+	}
+	w := bufio.NewWriter(body)
+	write(w)
+	_ = w.Flush()
+}
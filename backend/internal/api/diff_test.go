@@ -0,0 +1,123 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/api"
+	"github.com/drewjst/deltagov/internal/congresstest"
+	"github.com/drewjst/deltagov/internal/deltas"
+	"github.com/drewjst/deltagov/internal/diff_engine"
+)
+
+// TestComputeDiff verifies versions are diffed, and that versions too
+// large to diff on demand are refused rather than diffed.
+func TestComputeDiff(t *testing.T) {
+	db := congresstest.OpenDB(t)
+	s := api.NewBillService(db, nil)
+	ctx := context.Background()
+	bill := createBill(t, db, 1)
+	now := time.Now()
+	from := createVersion(t, db, bill, "IH", "The fee is $500.\n", now.Add(-time.Hour))
+	to := createVersion(t, db, bill, "EH", "The fee is $750.\n", now)
+
+	diff, err := s.ComputeDiff(ctx, bill.ID, from.ID, to.ID, diff_engine.Options{})
+	if err != nil {
+		t.Fatalf("ComputeDiff failed: %v", err)
+	}
+	if diff.FromVersion != "IH" || diff.ToVersion != "EH" || diff.Insertions == 0 || diff.Deletions == 0 {
+		t.Errorf("Diff = %+v, want the fee change from IH to EH", diff)
+	}
+
+	large := createVersion(t, db, bill, "ENR", strings.Repeat("The fee is $900.\n", deltas.MaxTextSize/16), now.Add(time.Hour))
+	diff, err = s.ComputeDiff(ctx, bill.ID, to.ID, large.ID, diff_engine.Options{})
+	if !errors.Is(err, api.ErrDiffTooLarge) {
+		t.Errorf("ComputeDiff of a large version = %+v, %v; want ErrDiffTooLarge", diff, err)
+	}
+}
+
+// TestComputeDiffErrors verifies the diff endpoint reports missing bills,
+// versions of another bill, and versions too large to diff with their
+// error codes.
+func TestComputeDiffErrors(t *testing.T) {
+	ts := newTestServer(t, "")
+	now := time.Now()
+	bill := createBill(t, ts.db, 1)
+	from := createVersion(t, ts.db, bill, "IH", "The fee is $500.\n", now.Add(-time.Hour))
+	to := createVersion(t, ts.db, bill, "EH", "The fee is $750.\n", now)
+	large := createVersion(t, ts.db, bill, "ENR", strings.Repeat("The fee is $900.\n", deltas.MaxTextSize/16), now.Add(time.Hour))
+	other := createBill(t, ts.db, 2)
+	otherVersion := createVersion(t, ts.db, other, "IH", "The fee is $100.\n", now)
+
+	diffPath := func(billID, fromID, toID uint) string {
+		return fmt.Sprintf("/api/v1/bills/%d/diff/%d/%d", billID, fromID, toID)
+	}
+	if status, body := ts.request(t, http.MethodGet, diffPath(bill.ID, from.ID, to.ID), nil, nil); status != http.StatusOK {
+		t.Fatalf("Diff: status = %d, want 200: %s", status, body)
+	}
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+		code   string
+	}{
+		{"missing bill", diffPath(999, from.ID, to.ID), http.StatusNotFound, api.CodeBillNotFound},
+		{"missing version", diffPath(bill.ID, from.ID, 999), http.StatusNotFound, api.CodeVersionNotFound},
+		{"version of another bill", diffPath(bill.ID, from.ID, otherVersion.ID), http.StatusUnprocessableEntity, api.CodeVersionMismatch},
+		{"too large", diffPath(bill.ID, to.ID, large.ID), http.StatusUnprocessableEntity, api.CodeDiffTooLarge},
+		{"too large, split", diffPath(bill.ID, to.ID, large.ID) + "?view=split", http.StatusUnprocessableEntity, api.CodeDiffTooLarge},
+	}
+	for _, tt := range tests {
+		if status, body := ts.request(t, http.MethodGet, tt.path, nil, nil); status != tt.status || errorCode(t, body) != tt.code {
+			t.Errorf("Diff of %s: status = %d, want %d %s: %s", tt.name, status, tt.status, tt.code, body)
+		}
+	}
+}
+
+// TestDiffConditional verifies diffs are sent with a weak ETag, shared by
+// every encoding the compression middleware may send, and that either
+// form of it in If-None-Match gets 304 Not Modified.
+func TestDiffConditional(t *testing.T) {
+	ts := newTestServer(t, "")
+	now := time.Now()
+	bill := createBill(t, ts.db, 1)
+	from := createVersion(t, ts.db, bill, "IH", "The fee is $500.\n", now.Add(-time.Hour))
+	to := createVersion(t, ts.db, bill, "EH", "The fee is $750.\n", now)
+	path := fmt.Sprintf("/api/v1/bills/%d/diff/%d/%d", bill.ID, from.ID, to.ID)
+
+	get := func(ifNoneMatch string) *http.Response {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		resp, err := ts.app.Test(req, -1)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", path, err)
+		}
+		resp.Body.Close()
+		return resp
+	}
+
+	resp := get("")
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(etag, `W/"`) {
+		t.Fatalf("Diff: status = %d, ETag = %q; want 200 with a weak ETag", resp.StatusCode, etag)
+	}
+	for _, ifNoneMatch := range []string{etag, strings.TrimPrefix(etag, "W/"), `"other", ` + etag} {
+		if resp := get(ifNoneMatch); resp.StatusCode != http.StatusNotModified || resp.Header.Get("ETag") != etag {
+			t.Errorf("If-None-Match %s: status = %d, ETag = %q; want 304 with %s",
+				ifNoneMatch, resp.StatusCode, resp.Header.Get("ETag"), etag)
+		}
+	}
+	if resp := get(`W/"other"`); resp.StatusCode != http.StatusOK {
+		t.Errorf("Stale If-None-Match: status = %d, want 200", resp.StatusCode)
+	}
+}
@@ -0,0 +1,146 @@
+package api
+
+// engineContext is the unchanged lines the diff engine keeps around each
+// change; narrower context is trimmed from its hunks.
+const engineContext = 3
+
+// DiffWindow selects part of a diff so large diffs can be loaded lazily:
+// HunkLimit hunks starting at HunkOffset (all when HunkLimit is 0), with at
+// most Context unchanged lines around each change. Line numbers and
+// anchors stay those of the whole diff, and anchors are always returned
+// for every changed section, so a client can jump to any section's hunk.
+type DiffWindow struct {
+	HunkOffset int
+	HunkLimit  int
+	Context    int
+}
+
+// DiffWindowInput is embedded in inputs of diff endpoints to return part
+// of a large diff.
+type DiffWindowInput struct {
+	HunkOffset int `query:"hunkOffset" default:"0" minimum:"0" doc:"Index of the first hunk to return; use nextHunk from the previous page"`
+	HunkLimit  int `query:"hunkLimit" default:"0" minimum:"0" maximum:"1000" doc:"Maximum hunks to return (0 = all)"`
+	Context    int `query:"context" default:"3" minimum:"0" maximum:"3" doc:"Unchanged lines to keep around each change; hunks further apart than twice this are split"`
+}
+
+// Window returns the window the input selects.
+func (in DiffWindowInput) Window() DiffWindow {
+	return DiffWindow{HunkOffset: in.HunkOffset, HunkLimit: in.HunkLimit, Context: in.Context}
+}
+
+// windowDiff reduces a diff to the window's hunks. It must be applied
+// before toSplitView. Diffs without hunks, of identical texts, are left
+// as they are.
+func windowDiff(diff *DiffResponse, w DiffWindow) {
+	if len(diff.Hunks) == 0 {
+		return
+	}
+	if w.Context < engineContext {
+		trimContext(diff, max(w.Context, 0))
+	}
+	diff.TotalHunks = len(diff.Hunks)
+
+	start := min(w.HunkOffset, len(diff.Hunks))
+	end := len(diff.Hunks)
+	if w.HunkLimit > 0 {
+		end = min(start+w.HunkLimit, end)
+	}
+	if start == 0 && end == len(diff.Hunks) {
+		return
+	}
+
+	first := 0
+	for _, h := range diff.Hunks[:start] {
+		first += h.LineCount
+	}
+	last := first
+	for _, h := range diff.Hunks[start:end] {
+		last += h.LineCount
+	}
+	diff.Lines = diff.Lines[first:last]
+	diff.Segments = diff.Segments[first:last]
+	diff.Hunks = diff.Hunks[start:end]
+	if end < diff.TotalHunks {
+		diff.NextHunk = end
+	}
+}
+
+// trimContext keeps only the unchanged lines within context lines of a
+// change, splitting hunks where a longer unchanged run is dropped. Diffs
+// without changes are left with no hunks.
+func trimContext(diff *DiffResponse, context int) {
+	lines := make([]DiffLine, 0, len(diff.Lines))
+	segments := make([]DiffSegment, 0, len(diff.Segments))
+	var hunks []DiffHunk
+
+	pos := 0
+	for _, h := range diff.Hunks {
+		hunkLines := diff.Lines[pos : pos+h.LineCount]
+		keep := nearChanges(hunkLines, context)
+		lineA, lineB := h.StartA, h.StartB
+		open := false
+		for i, line := range hunkLines {
+			if keep[i] {
+				if !open {
+					hunks = append(hunks, DiffHunk{Index: len(hunks), StartA: lineA, StartB: lineB, LineNumber: line.LineNumber})
+					open = true
+				}
+				hunks[len(hunks)-1].LineCount++
+				lines = append(lines, line)
+				segments = append(segments, diff.Segments[pos+i])
+			} else {
+				open = false
+			}
+			switch line.Type {
+			case "deletion":
+				lineA++
+			case "insertion":
+				lineB++
+			default:
+				lineA++
+				lineB++
+			}
+		}
+		pos += h.LineCount
+	}
+
+	diff.Lines, diff.Segments, diff.Hunks = lines, segments, hunks
+	for i := range diff.Anchors {
+		diff.Anchors[i].Hunk = hunkAt(hunks, diff.Anchors[i].LineNumber)
+	}
+}
+
+// nearChanges reports, for each line, whether it is within context lines
+// of an inserted or deleted line.
+func nearChanges(lines []DiffLine, context int) []bool {
+	keep := make([]bool, len(lines))
+	since := context + 1 // Lines since the last change
+	for i, line := range lines {
+		if line.Type != "unchanged" {
+			since = 0
+		} else {
+			since++
+		}
+		keep[i] = since <= context
+	}
+	since = context + 1
+	for i := len(lines) - 1; i >= 0; i-- {
+		if lines[i].Type != "unchanged" {
+			since = 0
+		} else {
+			since++
+		}
+		keep[i] = keep[i] || since <= context
+	}
+	return keep
+}
+
+// hunkAt returns the index of the hunk containing a line number, or -1.
+func hunkAt(hunks []DiffHunk, lineNumber int) int {
+	for _, h := range hunks {
+		if lineNumber >= h.LineNumber && lineNumber < h.LineNumber+h.LineCount {
+			return h.Index
+		}
+	}
+	return -1
+}
@@ -0,0 +1,523 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/textextract"
+	"github.com/drewjst/deltagov/internal/textnorm"
+	"github.com/drewjst/deltagov/internal/textstats"
+)
+
+// maxUploadMemory is how much of a multipart upload is held in memory;
+// larger files spill to temporary files.
+const maxUploadMemory = 1 << 20
+
+// ErrDocumentNotFound is returned when a document doesn't exist or belongs
+// to another user.
+var ErrDocumentNotFound = errors.New("document not found")
+
+// ErrDocumentVersionExists is returned when uploading a document version
+// whose text matches one the document already has.
+var ErrDocumentVersionExists = errors.New("document already has a version with this text")
+
+// ErrDocumentVersionMismatch is returned when a version exists but belongs
+// to a bill or a different document than the one requested.
+var ErrDocumentVersionMismatch = errors.New("version does not belong to this document")
+
+// ErrInvalidUpload is returned when an uploaded version can't be read.
+var ErrInvalidUpload = errors.New("invalid upload")
+
+// DocumentService tracks custom documents users upload. Their versions
+// are stored and diffed like bill versions.
+type DocumentService struct {
+	db    *gorm.DB
+	bills *BillService
+}
+
+// NewDocumentService creates a new DocumentService.
+func NewDocumentService(db *gorm.DB, bills *BillService) *DocumentService {
+	return &DocumentService{db: db, bills: bills}
+}
+
+// DocumentResponse is a tracked document in API responses.
+type DocumentResponse struct {
+	ID          uint              `json:"id"`
+	Title       string            `json:"title"`
+	Description string            `json:"description,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+	Versions    []VersionResponse `json:"versions,omitempty"` // Oldest first; only when fetching one document
+}
+
+// DocumentListResponse lists a user's documents.
+type DocumentListResponse struct {
+	Documents []DocumentResponse `json:"documents"`
+}
+
+// DocumentVersionUpload is a document version sent as JSON.
+type DocumentVersionUpload struct {
+	VersionCode string `json:"versionCode,omitempty" maxLength:"16" pattern:"^[A-Za-z0-9]*$" doc:"Version code, e.g., \"V2\" (default: V followed by the version's number)"`
+	Text        string `json:"text" minLength:"1" doc:"Document text: plain text, HTML, or XML"`
+}
+
+// CreateDocument creates a document owned by a user, without versions.
+func (s *DocumentService) CreateDocument(ctx context.Context, userID uint, title, description string) (*DocumentResponse, error) {
+	doc := models.Document{UserID: userID, Title: strings.TrimSpace(title), Description: description}
+	if err := s.db.WithContext(ctx).Create(&doc).Error; err != nil {
+		return nil, fmt.Errorf("failed to create document: %w", err)
+	}
+	resp := documentToResponse(&doc)
+	return &resp, nil
+}
+
+// ListDocuments returns a user's documents, most recently updated first.
+func (s *DocumentService) ListDocuments(ctx context.Context, userID uint) (*DocumentListResponse, error) {
+	var docs []models.Document
+	if err := s.db.WithContext(ctx).Where("user_id = ?", userID).
+		Order("updated_at DESC, id DESC").Find(&docs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list documents: %w", err)
+	}
+	response := &DocumentListResponse{Documents: make([]DocumentResponse, len(docs))}
+	for i := range docs {
+		response.Documents[i] = documentToResponse(&docs[i])
+	}
+	return response, nil
+}
+
+// GetDocument returns one of a user's documents with its versions.
+func (s *DocumentService) GetDocument(ctx context.Context, userID, documentID uint) (*DocumentResponse, error) {
+	doc, err := s.userDocument(ctx, userID, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []models.Version
+	// Select specific fields to avoid fetching large text_content
+	if err := s.db.WithContext(ctx).Select("id", "document_id", "version_code", "content_hash", "fetched_at",
+		"word_count", "page_estimate", "avg_sentence_length", "grade_level", "defined_terms").
+		Where("document_id = ?", doc.ID).Order("fetched_at ASC, id ASC").Find(&versions).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch versions: %w", err)
+	}
+
+	response := documentToResponse(doc)
+	response.Versions = make([]VersionResponse, len(versions))
+	for i := range versions {
+		response.Versions[i] = versionResponse(&versions[i])
+	}
+	return &response, nil
+}
+
+// AddDocumentVersion stores a new version of one of a user's documents.
+// An empty versionCode is numbered after the existing versions: V1, V2,
+// and so on.
+func (s *DocumentService) AddDocumentVersion(ctx context.Context, userID, documentID uint, versionCode, text string) (*VersionResponse, error) {
+	doc, err := s.userDocument(ctx, userID, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	rawHash := sha256.Sum256([]byte(text))
+	version := models.Version{
+		DocumentID:  &doc.ID,
+		VersionCode: strings.ToUpper(versionCode),
+		ContentHash: textnorm.Hash(text),
+		RawHash:     hex.EncodeToString(rawHash[:]),
+		TextContent: text,
+		TextSize:    len(text),
+		PlainText:   textextract.Extract(text),
+		FetchedAt:   now,
+	}
+	textstats.Fill(&version)
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing []string
+		if err := tx.Model(&models.Version{}).Where("document_id = ?", doc.ID).
+			Pluck("content_hash", &existing).Error; err != nil {
+			return fmt.Errorf("failed to query versions: %w", err)
+		}
+		for _, hash := range existing {
+			if hash == version.ContentHash {
+				return ErrDocumentVersionExists
+			}
+		}
+		if version.VersionCode == "" {
+			version.VersionCode = fmt.Sprintf("V%d", len(existing)+1)
+		}
+		if err := tx.Create(&version).Error; err != nil {
+			return fmt.Errorf("failed to create version: %w", err)
+		}
+		return tx.Model(doc).Update("updated_at", now).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := versionResponse(&version)
+	return &resp, nil
+}
+
+// DiffVersions diffs two versions of one of a user's documents. Deltas are
+// cached as for bills, and opts preprocess both texts as for bills.
+// Versions of other documents are reported as
+// ErrDocumentVersionMismatch and texts too large to diff as ErrDiffTooLarge.
+func (s *DocumentService) DiffVersions(ctx context.Context, userID, documentID, fromVersionID, toVersionID uint, opts diff_engine.Options) (*DiffResponse, error) {
+	doc, err := s.userDocument(ctx, userID, documentID)
+	if err != nil {
+		return nil, err
+	}
+	var from, to models.Version
+	for _, v := range []struct {
+		id      uint
+		version *models.Version
+	}{{fromVersionID, &from}, {toVersionID, &to}} {
+		if err := s.db.WithContext(ctx).Select(versionStatsColumns).
+			First(v.version, v.id).Error; err != nil {
+			return nil, versionLookupError(err)
+		}
+		if v.version.DocumentID == nil || *v.version.DocumentID != doc.ID {
+			return nil, ErrDocumentVersionMismatch
+		}
+	}
+	return s.bills.diffVersions(ctx, &from, &to, opts)
+}
+
+// userDocument returns a document owned by a user, or ErrDocumentNotFound.
+func (s *DocumentService) userDocument(ctx context.Context, userID, documentID uint) (*models.Document, error) {
+	var doc models.Document
+	if err := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", documentID, userID).First(&doc).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrDocumentNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch document: %w", err)
+	}
+	return &doc, nil
+}
+
+// documentToResponse converts a Document model to its API response format.
+func documentToResponse(d *models.Document) DocumentResponse {
+	return DocumentResponse{
+		ID:          d.ID,
+		Title:       d.Title,
+		Description: d.Description,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+}
+
+// parseVersionUpload reads a version uploaded as JSON or as a multipart
+// form with a "file" (or "text") field and an optional "versionCode"
+// field.
+func parseVersionUpload(contentType string, body []byte) (*DocumentVersionUpload, error) {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = "application/json"
+	}
+
+	var upload DocumentVersionUpload
+	if mediaType == "multipart/form-data" {
+		form, err := multipart.NewReader(bytes.NewReader(body), params["boundary"]).ReadForm(maxUploadMemory)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidUpload, err)
+		}
+		defer form.RemoveAll()
+		upload.VersionCode = formValue(form, "versionCode")
+		upload.Text = formValue(form, "text")
+		if files := form.File["file"]; len(files) > 0 {
+			f, err := files[0].Open()
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidUpload, err)
+			}
+			text, err := io.ReadAll(f)
+			f.Close()
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidUpload, err)
+			}
+			upload.Text = string(text)
+		}
+	} else if err := json.Unmarshal(body, &upload); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidUpload, err)
+	}
+
+	switch {
+	case strings.TrimSpace(upload.Text) == "":
+		return nil, fmt.Errorf("%w: text is required", ErrInvalidUpload)
+	case len(upload.VersionCode) > 16 || strings.IndexFunc(upload.VersionCode, notAlphanumeric) >= 0:
+		return nil, fmt.Errorf("%w: versionCode must be at most 16 letters and digits", ErrInvalidUpload)
+	}
+	return &upload, nil
+}
+
+// formValue returns the first value of a multipart form field, or "".
+func formValue(form *multipart.Form, name string) string {
+	if values := form.Value[name]; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// notAlphanumeric reports whether r isn't an ASCII letter or digit.
+func notAlphanumeric(r rune) bool {
+	return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9')
+}
+
+// DocumentInput is the request for one of the caller's documents
+type DocumentInput struct {
+	APIKeyInput
+	ID uint `path:"id" minimum:"1" doc:"Document ID"`
+}
+
+// CreateDocumentInput is the request for creating a document
+type CreateDocumentInput struct {
+	APIKeyInput
+	Body struct {
+		Title       string `json:"title" minLength:"1" maxLength:"1000" pattern:"\\S" doc:"Document title"`
+		Description string `json:"description,omitempty" maxLength:"5000" doc:"What the document is, for your own reference"`
+	}
+}
+
+// DocumentOutput is the response for one document
+type DocumentOutput struct {
+	Body DocumentResponse
+}
+
+// ListDocumentsOutput is the response for listing documents
+type ListDocumentsOutput struct {
+	Body DocumentListResponse
+}
+
+// GetDocumentVersionsOutput is the response for a document's versions
+type GetDocumentVersionsOutput struct {
+	Body struct {
+		DocumentID uint              `json:"documentId"`
+		Versions   []VersionResponse `json:"versions"`
+	}
+}
+
+// AddDocumentVersionInput is the request for uploading a document version.
+// The body is read raw so that it may be JSON or a multipart form.
+type AddDocumentVersionInput struct {
+	APIKeyInput
+	ID          uint   `path:"id" minimum:"1" doc:"Document ID"`
+	ContentType string `header:"Content-Type" hidden:"true"`
+	RawBody     []byte `contentType:"multipart/form-data"`
+}
+
+// AddDocumentVersionOutput is the response for uploading a document version
+type AddDocumentVersionOutput struct {
+	Body VersionResponse
+}
+
+// DocumentDiffInput is the request for diffing two document versions
+type DocumentDiffInput struct {
+	APIKeyInput
+	DiffOptionsInput
+	DiffWindowInput
+	ID          uint   `path:"id" minimum:"1" doc:"Document ID"`
+	FromVersion uint   `path:"fromVersion" minimum:"1" doc:"Source version ID"`
+	ToVersion   uint   `path:"toVersion" minimum:"1" doc:"Target version ID"`
+	View        string `query:"view" enum:"unified,split" default:"unified" doc:"unified returns interleaved lines; split returns aligned left/right rows"`
+}
+
+// DocumentDiffOutput is the response for diffing two document versions
+type DocumentDiffOutput struct {
+	Body DiffResponse
+}
+
+// RegisterDocumentRoutes registers the custom document endpoints with
+// Huma. Documents are private to the API key that created them.
+func RegisterDocumentRoutes(api huma.API, s *DocumentService) {
+	huma.Register(api, requireRole(api, huma.Operation{
+		OperationID:   "create-document",
+		Method:        http.MethodPost,
+		Path:          "/api/v1/documents",
+		Summary:       "Create a tracked document",
+		Description:   "Creates a document to track outside the legislative record, such as agency guidance or a contract. Upload its versions with the document versions endpoint, then diff them like bill versions. Requires the editor role.",
+		Tags:          []string{"Documents"},
+		DefaultStatus: http.StatusCreated,
+	}, models.UserRoleEditor), func(ctx context.Context, input *CreateDocumentInput) (*DocumentOutput, error) {
+		user, err := authenticate(ctx, s.db, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		doc, err := s.CreateDocument(ctx, user.ID, input.Body.Title, input.Body.Description)
+		if err != nil {
+			return nil, documentError(err, "failed to create document")
+		}
+		auditTarget(ctx, doc.ID)
+		return &DocumentOutput{Body: *doc}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-documents",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/documents",
+		Summary:     "List tracked documents",
+		Description: "Returns the caller's documents, most recently updated first",
+		Errors:      []int{http.StatusUnauthorized},
+		Tags:        []string{"Documents"},
+	}, func(ctx context.Context, input *APIKeyInput) (*ListDocumentsOutput, error) {
+		user, err := authenticate(ctx, s.db, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		docs, err := s.ListDocuments(ctx, user.ID)
+		if err != nil {
+			return nil, documentError(err, "failed to list documents")
+		}
+		return &ListDocumentsOutput{Body: *docs}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-document",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/documents/{id}",
+		Summary:     "Get a tracked document",
+		Description: "Returns one of the caller's documents with its versions and their content hashes",
+		Errors:      []int{http.StatusUnauthorized, http.StatusNotFound},
+		Tags:        []string{"Documents"},
+	}, func(ctx context.Context, input *DocumentInput) (*DocumentOutput, error) {
+		user, err := authenticate(ctx, s.db, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		doc, err := s.GetDocument(ctx, user.ID, input.ID)
+		if err != nil {
+			return nil, documentError(err, "failed to get document")
+		}
+		return &DocumentOutput{Body: *doc}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-document-versions",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/documents/{id}/versions",
+		Summary:     "Get all versions of a tracked document",
+		Description: "Returns the uploaded versions of one of the caller's documents, oldest first",
+		Errors:      []int{http.StatusUnauthorized, http.StatusNotFound},
+		Tags:        []string{"Documents"},
+	}, func(ctx context.Context, input *DocumentInput) (*GetDocumentVersionsOutput, error) {
+		user, err := authenticate(ctx, s.db, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		doc, err := s.GetDocument(ctx, user.ID, input.ID)
+		if err != nil {
+			return nil, documentError(err, "failed to get document versions")
+		}
+		resp := &GetDocumentVersionsOutput{}
+		resp.Body.DocumentID = doc.ID
+		resp.Body.Versions = doc.Versions
+		return resp, nil
+	})
+
+	huma.Register(api, requireRole(api, documentUploadOperation(api, huma.Operation{
+		OperationID:   "add-document-version",
+		Method:        http.MethodPost,
+		Path:          "/api/v1/documents/{id}/versions",
+		Summary:       "Upload a document version",
+		Description:   "Adds a version to one of the caller's documents, sent as JSON or as a multipart form with a file field and an optional versionCode field. Uploading text identical to an earlier version is rejected. Requires the editor role.",
+		Errors:        []int{http.StatusBadRequest, http.StatusNotFound, http.StatusConflict},
+		Tags:          []string{"Documents"},
+		DefaultStatus: http.StatusCreated,
+	}), models.UserRoleEditor), func(ctx context.Context, input *AddDocumentVersionInput) (*AddDocumentVersionOutput, error) {
+		user, err := authenticate(ctx, s.db, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		upload, err := parseVersionUpload(input.ContentType, input.RawBody)
+		if err != nil {
+			return nil, documentError(err, "failed to add document version")
+		}
+		version, err := s.AddDocumentVersion(ctx, user.ID, input.ID, upload.VersionCode, upload.Text)
+		if err != nil {
+			return nil, documentError(err, "failed to add document version")
+		}
+		auditChange(ctx, nil, map[string]any{"version_id": version.ID, "version_code": version.VersionCode, "content_hash": version.ContentHash})
+		return &AddDocumentVersionOutput{Body: *version}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "diff-document-versions",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/documents/{id}/diff/{fromVersion}/{toVersion}",
+		Summary:     "Compute diff between two document versions",
+		Description: "Returns the same structured diff as the bill diff endpoint for two versions of one of the caller's documents. With view=split, returns aligned left/right rows for side-by-side rendering.",
+		Errors:      []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusUnprocessableEntity},
+		Tags:        []string{"Documents"},
+	}, func(ctx context.Context, input *DocumentDiffInput) (*DocumentDiffOutput, error) {
+		user, err := authenticate(ctx, s.db, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		diff, err := s.DiffVersions(ctx, user.ID, input.ID, input.FromVersion, input.ToVersion, input.Options())
+		if err != nil {
+			return nil, documentError(err, "failed to compute diff")
+		}
+		windowDiff(diff, input.Window())
+		if input.View == DiffViewSplit {
+			toSplitView(diff)
+		}
+		return &DocumentDiffOutput{Body: *diff}, nil
+	})
+}
+
+// documentUploadOperation documents both forms a document version can be
+// uploaded in, which Huma can't infer from the raw body. Huma would parse
+// every body as JSON to validate it against the JSON schema, so validation
+// is left to parseVersionUpload.
+func documentUploadOperation(api huma.API, op huma.Operation) huma.Operation {
+	registry := api.OpenAPI().Components.Schemas
+	op.SkipValidateBody = true
+	op.RequestBody = &huma.RequestBody{
+		Required: true,
+		Content: map[string]*huma.MediaType{
+			"application/json": {
+				Schema: registry.Schema(reflect.TypeOf(DocumentVersionUpload{}), true, "DocumentVersionUpload"),
+			},
+			"multipart/form-data": {
+				Schema: &huma.Schema{
+					Type: huma.TypeObject,
+					Properties: map[string]*huma.Schema{
+						"file":        {Type: huma.TypeString, ContentEncoding: "binary", Description: "Document text file: plain text, HTML, or XML"},
+						"versionCode": {Type: huma.TypeString, Description: "Version code of up to 16 letters and digits (default: V followed by the version's number)"},
+					},
+					Required: []string{"file"},
+				},
+			},
+		},
+	}
+	return op
+}
+
+// documentError converts a document service error to an HTTP error.
+func documentError(err error, action string) error {
+	switch {
+	case errors.Is(err, ErrDocumentNotFound):
+		return apiError(http.StatusNotFound, CodeDocumentNotFound, err.Error())
+	case errors.Is(err, ErrDocumentVersionMismatch):
+		return apiError(http.StatusUnprocessableEntity, CodeVersionMismatch, err.Error())
+	case errors.Is(err, ErrDocumentVersionExists):
+		return apiError(http.StatusConflict, CodeDocumentVersionExists, err.Error())
+	case errors.Is(err, ErrInvalidUpload):
+		return apiError(http.StatusBadRequest, CodeInvalidRequest, err.Error())
+	}
+	return serviceError(err, action)
+}
@@ -0,0 +1,205 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/api"
+	"github.com/drewjst/deltagov/internal/congresstest"
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// TestDocumentVersions verifies document versions are numbered, that
+// identical text is rejected, and that documents are private to their
+// owner.
+func TestDocumentVersions(t *testing.T) {
+	db := congresstest.OpenDB(t)
+	s := api.NewDocumentService(db, api.NewBillService(db, nil))
+	ctx := context.Background()
+	const owner, other = 1, 2
+
+	doc, err := s.CreateDocument(ctx, owner, "  Agency Guidance  ", "Internal memo")
+	if err != nil {
+		t.Fatalf("CreateDocument failed: %v", err)
+	}
+	if doc.Title != "Agency Guidance" {
+		t.Errorf("Title = %q, want it trimmed", doc.Title)
+	}
+
+	first, err := s.AddDocumentVersion(ctx, owner, doc.ID, "", "Section 1. Staff may telework.\n")
+	if err != nil {
+		t.Fatalf("AddDocumentVersion failed: %v", err)
+	}
+	second, err := s.AddDocumentVersion(ctx, owner, doc.ID, "", "Section 1. Staff may telework two days a week.\n")
+	if err != nil {
+		t.Fatalf("AddDocumentVersion failed: %v", err)
+	}
+	final, err := s.AddDocumentVersion(ctx, owner, doc.ID, "final", "Section 1. Staff may telework three days a week.\n")
+	if err != nil {
+		t.Fatalf("AddDocumentVersion failed: %v", err)
+	}
+	if first.VersionCode != "V1" || second.VersionCode != "V2" || final.VersionCode != "FINAL" {
+		t.Errorf("Version codes = %q, %q, %q; want V1, V2, FINAL", first.VersionCode, second.VersionCode, final.VersionCode)
+	}
+	// Whitespace differences normalize to the same text
+	if _, err := s.AddDocumentVersion(ctx, owner, doc.ID, "", "Section 1.  Staff may telework.\n\n"); !errors.Is(err, api.ErrDocumentVersionExists) {
+		t.Errorf("AddDocumentVersion with identical text: error = %v, want ErrDocumentVersionExists", err)
+	}
+
+	got, err := s.GetDocument(ctx, owner, doc.ID)
+	if err != nil {
+		t.Fatalf("GetDocument failed: %v", err)
+	}
+	if len(got.Versions) != 3 || got.Versions[0].ID != first.ID || got.Versions[2].ID != final.ID {
+		t.Errorf("Versions = %+v, want the three oldest first", got.Versions)
+	}
+
+	if _, err := s.GetDocument(ctx, other, doc.ID); !errors.Is(err, api.ErrDocumentNotFound) {
+		t.Errorf("GetDocument by another user: error = %v, want ErrDocumentNotFound", err)
+	}
+	if _, err := s.AddDocumentVersion(ctx, other, doc.ID, "", "Section 1. Nobody may telework.\n"); !errors.Is(err, api.ErrDocumentNotFound) {
+		t.Errorf("AddDocumentVersion by another user: error = %v, want ErrDocumentNotFound", err)
+	}
+	if _, err := s.DiffVersions(ctx, other, doc.ID, first.ID, second.ID, diff_engine.Options{}); !errors.Is(err, api.ErrDocumentNotFound) {
+		t.Errorf("DiffVersions by another user: error = %v, want ErrDocumentNotFound", err)
+	}
+	if list, err := s.ListDocuments(ctx, other); err != nil || len(list.Documents) != 0 {
+		t.Errorf("ListDocuments by another user = %+v, %v; want none", list, err)
+	}
+}
+
+// TestDocumentDiff verifies document versions diff like bill versions, and
+// that versions of other documents are refused.
+func TestDocumentDiff(t *testing.T) {
+	db := congresstest.OpenDB(t)
+	s := api.NewDocumentService(db, api.NewBillService(db, nil))
+	ctx := context.Background()
+
+	doc, err := s.CreateDocument(ctx, 1, "Contract", "")
+	if err != nil {
+		t.Fatalf("CreateDocument failed: %v", err)
+	}
+	from, err := s.AddDocumentVersion(ctx, 1, doc.ID, "", "The fee is $500.\n")
+	if err != nil {
+		t.Fatalf("AddDocumentVersion failed: %v", err)
+	}
+	to, err := s.AddDocumentVersion(ctx, 1, doc.ID, "", "The fee is $750.\n")
+	if err != nil {
+		t.Fatalf("AddDocumentVersion failed: %v", err)
+	}
+
+	diff, err := s.DiffVersions(ctx, 1, doc.ID, from.ID, to.ID, diff_engine.Options{})
+	if err != nil {
+		t.Fatalf("DiffVersions failed: %v", err)
+	}
+	if diff.Insertions == 0 || diff.Deletions == 0 {
+		t.Errorf("Diff = %+v, want the fee change", diff)
+	}
+
+	otherDoc, err := s.CreateDocument(ctx, 1, "Other contract", "")
+	if err != nil {
+		t.Fatalf("CreateDocument failed: %v", err)
+	}
+	if _, err := s.DiffVersions(ctx, 1, otherDoc.ID, from.ID, to.ID, diff_engine.Options{}); !errors.Is(err, api.ErrDocumentVersionMismatch) {
+		t.Errorf("DiffVersions of another document's versions: error = %v, want ErrDocumentVersionMismatch", err)
+	}
+	bill := createBill(t, db, 1)
+	version := createVersion(t, db, bill, "IH", "The fee is $900.\n", time.Now())
+	if _, err := s.DiffVersions(ctx, 1, doc.ID, from.ID, version.ID, diff_engine.Options{}); !errors.Is(err, api.ErrDocumentVersionMismatch) {
+		t.Errorf("DiffVersions with a bill version: error = %v, want ErrDocumentVersionMismatch", err)
+	}
+}
+
+// TestDocumentEndpoints verifies the document endpoints require an API
+// key, that creating and uploading require the editor role, and that
+// versions may be uploaded as JSON or as a multipart form.
+func TestDocumentEndpoints(t *testing.T) {
+	ts := newTestServer(t, "")
+	editor := apiKey(ts.addUser(t, models.UserRoleEditor, nil))
+	reader := apiKey(ts.addUser(t, models.UserRoleReader, nil))
+	other := apiKey(ts.addUser(t, models.UserRoleEditor, nil))
+	create := map[string]string{"title": "Agency Guidance"}
+
+	if status, _ := ts.request(t, http.MethodGet, "/api/v1/documents", nil, nil); status != http.StatusUnauthorized {
+		t.Errorf("List without a key: status = %d, want 401", status)
+	}
+	if status, body := ts.request(t, http.MethodPost, "/api/v1/documents", reader, create); status != http.StatusForbidden {
+		t.Errorf("Create by a reader: status = %d, want 403: %s", status, body)
+	}
+	status, body := ts.request(t, http.MethodPost, "/api/v1/documents", editor, create)
+	if status != http.StatusCreated {
+		t.Fatalf("Create: status = %d, want 201: %s", status, body)
+	}
+	var doc api.DocumentResponse
+	decodeJSON(t, body, &doc)
+	versionsPath := fmt.Sprintf("/api/v1/documents/%d/versions", doc.ID)
+
+	uploads := []struct {
+		name   string
+		header http.Header
+		body   any
+		want   int
+	}{
+		{"JSON", editor, api.DocumentVersionUpload{Text: "Staff may telework.\n"}, http.StatusCreated},
+		{"duplicate", editor, api.DocumentVersionUpload{Text: "Staff may telework.\n"}, http.StatusConflict},
+		{"empty", editor, api.DocumentVersionUpload{Text: "  "}, http.StatusBadRequest},
+		{"bad code", editor, api.DocumentVersionUpload{VersionCode: "v-2", Text: "Staff may not telework.\n"}, http.StatusBadRequest},
+		{"reader", reader, api.DocumentVersionUpload{Text: "Staff may not telework.\n"}, http.StatusForbidden},
+		{"other user", other, api.DocumentVersionUpload{Text: "Staff may not telework.\n"}, http.StatusNotFound},
+	}
+	for _, tt := range uploads {
+		if status, body := ts.request(t, http.MethodPost, versionsPath, tt.header, tt.body); status != tt.want {
+			t.Errorf("Upload %s: status = %d, want %d: %s", tt.name, status, tt.want, body)
+		}
+	}
+
+	var form bytes.Buffer
+	w := multipart.NewWriter(&form)
+	w.WriteField("versionCode", "v2")
+	file, _ := w.CreateFormFile("file", "guidance.txt")
+	io.WriteString(file, "Staff may telework two days a week.\n")
+	w.Close()
+	req := httptest.NewRequest(http.MethodPost, versionsPath, &form)
+	req.Header = editor.Clone()
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	resp, err := ts.app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("Multipart upload failed: %v", err)
+	}
+	data, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("Multipart upload: status = %d, want 201: %s", resp.StatusCode, data)
+	}
+	var version api.VersionResponse
+	decodeJSON(t, data, &version)
+	if version.VersionCode != "V2" {
+		t.Errorf("Multipart upload stored as %q, want V2", version.VersionCode)
+	}
+
+	status, body = ts.request(t, http.MethodGet, versionsPath, editor, nil)
+	var versions api.GetDocumentVersionsOutput
+	decodeJSON(t, body, &versions.Body)
+	if status != http.StatusOK || len(versions.Body.Versions) != 2 {
+		t.Fatalf("Versions: status = %d, want 200 with both versions: %s", status, body)
+	}
+	diffPath := fmt.Sprintf("/api/v1/documents/%d/diff/%d/%d", doc.ID, versions.Body.Versions[0].ID, version.ID)
+	if status, body := ts.request(t, http.MethodGet, diffPath+"?view=split", editor, nil); status != http.StatusOK {
+		t.Errorf("Diff: status = %d, want 200: %s", status, body)
+	}
+	if status, body := ts.request(t, http.MethodGet, diffPath, other, nil); status != http.StatusNotFound || errorCode(t, body) != api.CodeDocumentNotFound {
+		t.Errorf("Diff by another user: status = %d, want 404: %s", status, body)
+	}
+	if status, body := ts.request(t, http.MethodGet, "/api/v1/documents", other, nil); status != http.StatusOK || bytes.Contains(body, []byte("Agency Guidance")) {
+		t.Errorf("List by another user: status = %d, want 200 without the document: %s", status, body)
+	}
+}
@@ -0,0 +1,255 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/analysis"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// VersionEarmarksResponse lists the earmarks in a version of a bill.
+type VersionEarmarksResponse struct {
+	BillID      uint               `json:"billId"`
+	VersionID   uint               `json:"versionId"`
+	VersionCode string             `json:"versionCode"`
+	Total       int64              `json:"total"` // Sum of the earmarked amounts
+	Earmarks    []analysis.Earmark `json:"earmarks"`
+}
+
+// EarmarkChangesResponse compares the earmarks in two versions of a bill.
+type EarmarkChangesResponse struct {
+	BillID            uint                     `json:"billId"`
+	FromVersionID     uint                     `json:"fromVersionId"`
+	ToVersionID       uint                     `json:"toVersionId"`
+	FromVersion       string                   `json:"fromVersion"`
+	ToVersion         string                   `json:"toVersion"`
+	FromTotal         int64                    `json:"fromTotal"`
+	ToTotal           int64                    `json:"toTotal"`
+	NetChange         int64                    `json:"netChange"`
+	EarmarksAdded     int                      `json:"earmarksAdded"`
+	EarmarksRemoved   int                      `json:"earmarksRemoved"`
+	EarmarksChanged   int                      `json:"earmarksChanged"`
+	EarmarksUnchanged int                      `json:"earmarksUnchanged"`
+	Changes           []analysis.EarmarkChange `json:"changes"`
+}
+
+// GetVersionEarmarks lists the earmarks in a version's text, in text order.
+func (s *BillService) GetVersionEarmarks(ctx context.Context, versionID uint) (*VersionEarmarksResponse, error) {
+	version, err := s.earmarksForVersion(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &VersionEarmarksResponse{
+		BillID:      version.billID,
+		VersionID:   versionID,
+		VersionCode: version.versionCode,
+		Earmarks:    version.earmarks,
+	}
+	if response.Earmarks == nil {
+		response.Earmarks = make([]analysis.Earmark, 0)
+	}
+	for _, e := range version.earmarks {
+		response.Total += e.Amount.Value
+	}
+	return response, nil
+}
+
+// GetEarmarkChanges compares the earmarks in two versions of a bill. When
+// both version IDs are zero, the two most recent versions are compared.
+// Unchanged earmarks are omitted unless includeUnchanged is set.
+func (s *BillService) GetEarmarkChanges(ctx context.Context, billID, fromID, toID uint, includeUnchanged bool) (*EarmarkChangesResponse, error) {
+	var bill models.Bill
+	if err := s.db.WithContext(ctx).Select("id").First(&bill, billID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBillNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch bill: %w", err)
+	}
+
+	if fromID == 0 && toID == 0 {
+		versions, err := s.versionsInOrder(ctx, billID)
+		if err != nil {
+			return nil, err
+		}
+		if len(versions) < 2 {
+			return nil, ErrNotEnoughVersions
+		}
+		fromID = versions[len(versions)-2].ID
+		toID = versions[len(versions)-1].ID
+	}
+
+	from, err := s.earmarksForVersion(ctx, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.earmarksForVersion(ctx, toID)
+	if err != nil {
+		return nil, err
+	}
+	if from.billID != billID || to.billID != billID {
+		return nil, ErrVersionNotFound
+	}
+
+	response := &EarmarkChangesResponse{
+		BillID:        billID,
+		FromVersionID: fromID,
+		ToVersionID:   toID,
+		FromVersion:   from.versionCode,
+		ToVersion:     to.versionCode,
+		Changes:       make([]analysis.EarmarkChange, 0),
+	}
+
+	for _, change := range analysis.CompareEarmarks(from.earmarks, to.earmarks) {
+		response.FromTotal += change.FromAmount
+		response.ToTotal += change.ToAmount
+
+		switch change.Status {
+		case analysis.EarmarkAdded:
+			response.EarmarksAdded++
+		case analysis.EarmarkRemoved:
+			response.EarmarksRemoved++
+		case analysis.EarmarkChanged:
+			response.EarmarksChanged++
+		case analysis.EarmarkUnchanged:
+			response.EarmarksUnchanged++
+			if !includeUnchanged {
+				continue
+			}
+		}
+		response.Changes = append(response.Changes, change)
+	}
+	response.NetChange = response.ToTotal - response.FromTotal
+
+	return response, nil
+}
+
+// versionEarmarks is a version's bill, code, and extracted earmarks.
+type versionEarmarks struct {
+	billID      uint
+	versionCode string
+	earmarks    []analysis.Earmark
+}
+
+// earmarksForVersion loads a version's stored earmarks, extracting and
+// storing them first if the version hasn't been analyzed yet.
+func (s *BillService) earmarksForVersion(ctx context.Context, versionID uint) (*versionEarmarks, error) {
+	db := s.db.WithContext(ctx)
+
+	var version models.Version
+	if err := db.Select("id", "bill_id", "version_code").First(&version, versionID).Error; err != nil {
+		return nil, versionLookupError(err)
+	}
+
+	var stored []models.Earmark
+	if err := db.Where("version_id = ?", versionID).Order("\"offset\" ASC").Find(&stored).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch earmarks: %w", err)
+	}
+
+	if len(stored) == 0 {
+		extracted, err := extractForVersion(ctx, s, versionID, analysis.ExtractEarmarks,
+			func(db *gorm.DB, extracted []analysis.Earmark) error {
+				rows := make([]models.Earmark, len(extracted))
+				for i, e := range extracted {
+					rows[i] = models.Earmark{
+						VersionID: versionID,
+						Key:       e.Key,
+						Recipient: e.Recipient,
+						Purpose:   e.Purpose,
+						Section:   e.Section,
+						Raw:       e.Amount.Raw,
+						Amount:    e.Amount.Value,
+						Offset:    e.Amount.Offset,
+					}
+				}
+				if err := db.CreateInBatches(rows, 500).Error; err != nil {
+					return fmt.Errorf("failed to store earmarks: %w", err)
+				}
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+		return &versionEarmarks{billID: version.BillID, versionCode: version.VersionCode, earmarks: extracted}, nil
+	}
+
+	earmarks := make([]analysis.Earmark, len(stored))
+	for i, row := range stored {
+		earmarks[i] = analysis.Earmark{
+			Key:       row.Key,
+			Recipient: row.Recipient,
+			Purpose:   row.Purpose,
+			Section:   row.Section,
+			Amount:    analysis.DollarAmount{Raw: row.Raw, Value: row.Amount, Offset: row.Offset},
+		}
+	}
+	return &versionEarmarks{billID: version.BillID, versionCode: version.VersionCode, earmarks: earmarks}, nil
+}
+
+// GetVersionEarmarksInput is the request for a version's earmarks
+type GetVersionEarmarksInput struct {
+	ID uint `path:"id" minimum:"1" doc:"Version ID"`
+}
+
+// GetVersionEarmarksOutput is the response for a version's earmarks
+type GetVersionEarmarksOutput struct {
+	Body VersionEarmarksResponse
+}
+
+// GetEarmarkChangesInput is the request for comparing earmarks between versions
+type GetEarmarkChangesInput struct {
+	ID               uint `path:"id" minimum:"1" doc:"Bill ID"`
+	From             uint `query:"from" doc:"Source version ID (default: second most recent version)"`
+	To               uint `query:"to" doc:"Target version ID (default: most recent version)"`
+	IncludeUnchanged bool `query:"includeUnchanged" doc:"Include earmarks that did not change"`
+}
+
+// GetEarmarkChangesOutput is the response for comparing earmarks between versions
+type GetEarmarkChangesOutput struct {
+	Body EarmarkChangesResponse
+}
+
+// registerEarmarkRoutes registers the per-version earmark list and the
+// earmark comparison endpoints.
+func registerEarmarkRoutes(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-version-earmarks",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/versions/{id}/earmarks",
+		Summary:     "List a version's earmarks",
+		Description: "Returns the community project funding entries in a version's text, each with its recipient, purpose, amount, and section: clauses directing an amount as a grant to a named recipient, and rows of community project funding tables.",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *GetVersionEarmarksInput) (*GetVersionEarmarksOutput, error) {
+		earmarks, err := s.GetVersionEarmarks(ctx, input.ID)
+		if err != nil {
+			return nil, serviceError(err, "failed to list earmarks")
+		}
+		return &GetVersionEarmarksOutput{Body: *earmarks}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-bill-earmark-changes",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{id}/earmark-changes",
+		Summary:     "Compare earmarks between two bill versions",
+		Description: "Matches earmarks between two versions by recipient and purpose and reports which were added or removed and whose amounts changed. Defaults to the two most recent versions.",
+		Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *GetEarmarkChangesInput) (*GetEarmarkChangesOutput, error) {
+		if (input.From == 0) != (input.To == 0) {
+			return nil, huma.Error400BadRequest("from and to must be provided together")
+		}
+		changes, err := s.GetEarmarkChanges(ctx, input.ID, input.From, input.To, input.IncludeUnchanged)
+		if err != nil {
+			return nil, serviceError(err, "failed to compare earmarks")
+		}
+		return &GetEarmarkChangesOutput{Body: *changes}, nil
+	})
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/regulations"
+)
+
+// ErrVersionMismatch is returned when a version exists but belongs to a
+// different bill than the one requested.
+var ErrVersionMismatch = errors.New("version does not belong to this bill")
+
+// Error codes identify API errors for clients. They are stable across
+// releases, unlike messages. Errors without a specific code are coded by
+// status: INVALID_REQUEST (400), VALIDATION_FAILED (422), or the status
+// text, e.g., NOT_FOUND or INTERNAL_SERVER_ERROR.
+const (
+	CodeBillNotFound          = "BILL_NOT_FOUND"
+	CodeVersionNotFound       = "VERSION_NOT_FOUND"
+	CodeVersionMismatch       = "VERSION_MISMATCH"
+	CodeDiffTooLarge          = "DIFF_TOO_LARGE"
+	CodeNotEnoughVersions     = "NOT_ENOUGH_VERSIONS"
+	CodeSummaryNotFound       = "SUMMARY_NOT_FOUND"
+	CodeNotEnoughSummaries    = "NOT_ENOUGH_SUMMARIES"
+	CodeMemberNotFound        = "MEMBER_NOT_FOUND"
+	CodeRuleNotFound          = "RULE_NOT_FOUND"
+	CodeTreatyNotFound        = "TREATY_NOT_FOUND"
+	CodeNominationNotFound    = "NOMINATION_NOT_FOUND"
+	CodeNoCounterpart         = "NO_COUNTERPART"
+	CodeFormatUnavailable     = "FORMAT_UNAVAILABLE"
+	CodeSectionNotFound       = "SECTION_NOT_FOUND"
+	CodeRangeNotSatisfiable   = "RANGE_NOT_SATISFIABLE"
+	CodeNoChamberVersions     = "NO_CHAMBER_VERSIONS"
+	CodeNoVersionAsOf         = "NO_VERSION_AS_OF"
+	CodeAlertNotFound         = "ALERT_NOT_FOUND"
+	CodeUserNotFound          = "USER_NOT_FOUND"
+	CodeInvalidQuery          = "INVALID_QUERY"
+	CodeAliasNotFound         = "ALIAS_NOT_FOUND"
+	CodeAliasExists           = "ALIAS_EXISTS"
+	CodeTenantNotFound        = "TENANT_NOT_FOUND"
+	CodeTenantExists          = "TENANT_EXISTS"
+	CodeNotTenantKey          = "NOT_TENANT_KEY"
+	CodeDraftVersionExists    = "DRAFT_VERSION_EXISTS"
+	CodeDocumentNotFound      = "DOCUMENT_NOT_FOUND"
+	CodeDocumentVersionExists = "DOCUMENT_VERSION_EXISTS"
+	CodeNoProvenance          = "NO_PROVENANCE"
+	CodeNoParentVersion       = "NO_PARENT_VERSION"
+	CodeInvalidRequest        = "INVALID_REQUEST"
+	CodeValidationFailed      = "VALIDATION_FAILED"
+	CodeInsufficientRole      = "INSUFFICIENT_ROLE"
+	CodeSearchNotConfigured   = "SEARCH_NOT_CONFIGURED"
+	CodeSearchBackendFailed   = "SEARCH_BACKEND_FAILED"
+	CodeSemanticNotConfigured = "SEMANTIC_SEARCH_NOT_CONFIGURED"
+	CodeEmbeddingsFailed      = "EMBEDDINGS_FAILED"
+	CodeBillNotEmbedded       = "BILL_NOT_EMBEDDED"
+	CodeProvisionTooShort     = "PROVISION_TOO_SHORT"
+	CodeNoPredecessor         = "NO_PREDECESSOR"
+	CodeNoVersions            = "NO_VERSIONS"
+	CodeCalendarNotFound      = "CALENDAR_NOT_FOUND"
+	CodeAccountNotFound       = "ACCOUNT_NOT_FOUND"
+)
+
+// ErrorModel is the body of every error response: an RFC 9457 problem
+// detail with a machine-readable code. Validation failures list each
+// invalid parameter in Errors.
+type ErrorModel struct {
+	huma.ErrorModel
+	Code string `json:"code" example:"BILL_NOT_FOUND" doc:"Machine-readable error code"`
+}
+
+// humaNewError is Huma's default error constructor, wrapped by newError.
+var humaNewError = huma.NewError
+
+// Huma builds every error response, including its own validation errors,
+// with huma.NewError, and documents the error schema from it at
+// registration, so it is replaced before any route is registered.
+func init() {
+	huma.NewError = newError
+}
+
+// newError is huma.NewError with the status's default code.
+func newError(status int, msg string, errs ...error) huma.StatusError {
+	model := &ErrorModel{Code: statusCode(status)}
+	if base, ok := humaNewError(status, msg, errs...).(*huma.ErrorModel); ok {
+		model.ErrorModel = *base
+	}
+	return model
+}
+
+// statusCode returns the default error code of an HTTP status.
+func statusCode(status int) string {
+	switch status {
+	case 0:
+		return ""
+	case http.StatusBadRequest:
+		return CodeInvalidRequest
+	case http.StatusUnprocessableEntity:
+		return CodeValidationFailed
+	}
+	return strings.ToUpper(strings.ReplaceAll(http.StatusText(status), " ", "_"))
+}
+
+// apiError returns an error response with a specific code.
+func apiError(status int, code, msg string) error {
+	return &ErrorModel{
+		ErrorModel: huma.ErrorModel{Status: status, Title: http.StatusText(status), Detail: msg},
+		Code:       code,
+	}
+}
+
+// serviceErrors are the service errors with their own status and code.
+var serviceErrors = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{ErrBillNotFound, http.StatusNotFound, CodeBillNotFound},
+	{ErrVersionNotFound, http.StatusNotFound, CodeVersionNotFound},
+	{ErrVersionMismatch, http.StatusUnprocessableEntity, CodeVersionMismatch},
+	{ErrDiffTooLarge, http.StatusUnprocessableEntity, CodeDiffTooLarge},
+	{ErrNotEnoughVersions, http.StatusUnprocessableEntity, CodeNotEnoughVersions},
+	{ErrSummaryNotFound, http.StatusNotFound, CodeSummaryNotFound},
+	{ErrNotEnoughSummaries, http.StatusUnprocessableEntity, CodeNotEnoughSummaries},
+	{ErrMemberNotFound, http.StatusNotFound, CodeMemberNotFound},
+	{ErrRuleNotFound, http.StatusNotFound, CodeRuleNotFound},
+	{ErrTreatyNotFound, http.StatusNotFound, CodeTreatyNotFound},
+	{ErrNominationNotFound, http.StatusNotFound, CodeNominationNotFound},
+	{regulations.ErrNoCounterpart, http.StatusNotFound, CodeNoCounterpart},
+	{regulations.ErrTooLarge, http.StatusUnprocessableEntity, CodeDiffTooLarge},
+	{ErrTextFormatUnavailable, http.StatusNotFound, CodeFormatUnavailable},
+	{ErrSectionNotFound, http.StatusNotFound, CodeSectionNotFound},
+	{ErrSectionRangeFormat, http.StatusBadRequest, CodeInvalidRequest},
+	{ErrRangeNotSatisfiable, http.StatusRequestedRangeNotSatisfiable, CodeRangeNotSatisfiable},
+	{ErrNoChamberVersions, http.StatusUnprocessableEntity, CodeNoChamberVersions},
+	{ErrNoVersionAsOf, http.StatusNotFound, CodeNoVersionAsOf},
+	{ErrNoProvenance, http.StatusNotFound, CodeNoProvenance},
+	{ErrNoParentVersion, http.StatusUnprocessableEntity, CodeNoParentVersion},
+	{ErrSearchBackendDisabled, http.StatusNotImplemented, CodeSearchNotConfigured},
+	{ErrSearchBackendFailed, http.StatusBadGateway, CodeSearchBackendFailed},
+	{ErrEmbeddingsDisabled, http.StatusNotImplemented, CodeSemanticNotConfigured},
+	{ErrEmbeddingsFailed, http.StatusBadGateway, CodeEmbeddingsFailed},
+	{ErrSemanticQueryMissing, http.StatusBadRequest, CodeInvalidRequest},
+	{ErrBillNotEmbedded, http.StatusUnprocessableEntity, CodeBillNotEmbedded},
+	{ErrProvisionQueryMissing, http.StatusBadRequest, CodeInvalidRequest},
+	{ErrProvisionTooShort, http.StatusUnprocessableEntity, CodeProvisionTooShort},
+	{ErrNoPredecessor, http.StatusNotFound, CodeNoPredecessor},
+	{ErrNoVersions, http.StatusUnprocessableEntity, CodeNoVersions},
+	{ErrCalendarNotFound, http.StatusNotFound, CodeCalendarNotFound},
+	{ErrAccountNotFound, http.StatusNotFound, CodeAccountNotFound},
+}
+
+// serviceError converts an error returned by a service to its response:
+// known service errors get their status and code, and anything else is a
+// 500 prefixed with action, e.g., "failed to compute diff".
+func serviceError(err error, action string) error {
+	for _, known := range serviceErrors {
+		if errors.Is(err, known.err) {
+			return apiError(known.status, known.code, err.Error())
+		}
+	}
+	return huma.Error500InternalServerError(action + ": " + err.Error())
+}
+
+// loadBillVersions loads two versions of a bill, selecting only columns
+// (which must include bill_id) when any are given. It returns
+// ErrBillNotFound if the bill doesn't exist, ErrVersionNotFound if a
+// version doesn't, and ErrVersionMismatch if a version belongs to another
+// bill.
+func (s *BillService) loadBillVersions(ctx context.Context, billID, fromID, toID uint, from, to *models.Version, columns ...string) error {
+	if err := s.requireBill(ctx, billID); err != nil {
+		return err
+	}
+	for _, v := range []struct {
+		id      uint
+		version *models.Version
+	}{{fromID, from}, {toID, to}} {
+		query := s.db.WithContext(ctx)
+		if len(columns) > 0 {
+			query = query.Select(columns)
+		}
+		if err := query.First(v.version, v.id).Error; err != nil {
+			return versionLookupError(err)
+		}
+		if v.version.BillID != billID {
+			return ErrVersionMismatch
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// Cache-Control policies for read endpoints.
+// Bill metadata changes on every ingestion run, so it is only cached briefly.
+// Diffs between two stored versions only change when the engine changes.
+const (
+	cacheControlBill = "public, max-age=60, must-revalidate"
+	cacheControlDiff = "public, max-age=3600"
+)
+
+// ConditionalInput is embedded in inputs of cacheable read endpoints.
+type ConditionalInput struct {
+	IfNoneMatch string `header:"If-None-Match" doc:"Return 304 Not Modified if the resource ETag matches one of these values"`
+}
+
+// CacheHeaders is embedded in outputs of cacheable read endpoints.
+type CacheHeaders struct {
+	ETag         string `header:"ETag"`
+	CacheControl string `header:"Cache-Control"`
+}
+
+// computeETag returns a weak ETag derived from the SHA-256 of the JSON body.
+// It's weak because the compression middleware may send the body gzip- or
+// brotli-encoded, and a strong ETag must differ between encodings.
+func computeETag(body any) (string, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return `W/"` + hex.EncodeToString(hash[:16]) + `"`, nil
+}
+
+// etagMatches reports whether an If-None-Match header value matches the ETag,
+// using the weak comparison RFC 9110 requires for If-None-Match.
+// Handles comma-separated lists, weak validators, and the "*" wildcard.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		candidate = strings.TrimPrefix(candidate, "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// conditionalHeaders computes cache headers for a response body and returns
+// a 304 Not Modified error when the client already has the current version.
+func conditionalHeaders(input ConditionalInput, body any, cacheControl string) (CacheHeaders, error) {
+	etag, err := computeETag(body)
+	if err != nil {
+		// An ETag is an optimization; never fail the request over it
+		return CacheHeaders{CacheControl: cacheControl}, nil
+	}
+	if etagMatches(input.IfNoneMatch, etag) {
+		// RFC 9110 requires the validator to be repeated on 304 responses
+		return CacheHeaders{}, huma.ErrorWithHeaders(huma.Status304NotModified(), http.Header{
+			"ETag":          {etag},
+			"Cache-Control": {cacheControl},
+		})
+	}
+	return CacheHeaders{ETag: etag, CacheControl: cacheControl}, nil
+}
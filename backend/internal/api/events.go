@@ -0,0 +1,82 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/live"
+)
+
+// eventsHeartbeat is how often an idle event stream sends a comment, so
+// proxies and load balancers don't close it.
+const eventsHeartbeat = 15 * time.Second
+
+// GetEventsInput is the request for the live event stream
+type GetEventsInput struct {
+	Congress int    `query:"congress" doc:"Only events for this congress (0 = all)" example:"119"`
+	BillType string `query:"billType" doc:"Only events for this bill type, e.g., hr (case-insensitive)"`
+	BillID   uint   `query:"billId" doc:"Only events for this bill ID (0 = all)"`
+}
+
+// RegisterEventRoutes registers the live event stream with Huma
+func RegisterEventRoutes(api huma.API, broker *live.Broker) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-events",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/events",
+		Summary:     "Stream live bill updates",
+		Description: "Server-Sent Events stream that pushes an event whenever the ingestor creates or updates a bill (bill_created, bill_updated) or stores a new version (version_created). Each event's data is a JSON object identifying the bill. Events published while a client is disconnected are not replayed; use the change feed to catch up.",
+		Tags:        []string{"Events"},
+		Responses:   streamedResponse("Event stream", nil, "text/event-stream"),
+	}, func(ctx context.Context, input *GetEventsInput) (*huma.StreamResponse, error) {
+		filter := live.Filter{Congress: input.Congress, BillType: input.BillType, BillID: input.BillID}
+		return &huma.StreamResponse{Body: func(hctx huma.Context) {
+			hctx.SetHeader("Content-Type", "text/event-stream")
+			hctx.SetHeader("Cache-Control", "no-cache")
+			hctx.SetHeader("X-Accel-Buffering", "no") // Keep proxies from buffering the stream
+			streamBody(hctx.BodyWriter(), func(w *bufio.Writer) {
+				events, unsubscribe := broker.Subscribe(filter)
+				defer unsubscribe()
+				writeEvents(w, events)
+			})
+		}}, nil
+	})
+}
+
+// writeEvents writes events as SSE until the channel closes or the client
+// goes away, sending heartbeats while idle.
+func writeEvents(w *bufio.Writer, events <-chan live.Event) {
+	heartbeat := time.NewTicker(eventsHeartbeat)
+	defer heartbeat.Stop()
+
+	// Ask clients to reconnect after 5s, and open the stream right away
+	fmt.Fprint(w, "retry: 5000\n: connected\n\n")
+	if err := w.Flush(); err != nil {
+		return
+	}
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": keepalive\n\n")
+		}
+		if err := w.Flush(); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,385 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ErrTreatyNotFound is returned when no treaty exists for an ID.
+var ErrTreatyNotFound = errors.New("treaty not found")
+
+// ErrNominationNotFound is returned when no nomination exists for an ID.
+var ErrNominationNotFound = errors.New("nomination not found")
+
+// ExecutiveService serves the Senate's executive business: treaties and
+// nominations, as synced from Congress.gov by the ingestor.
+type ExecutiveService struct {
+	db *gorm.DB
+}
+
+// NewExecutiveService creates a new ExecutiveService instance.
+func NewExecutiveService(db *gorm.DB) *ExecutiveService {
+	return &ExecutiveService{db: db}
+}
+
+// ActionResponse is a step in a treaty's or nomination's history.
+type ActionResponse struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	Text string `json:"text"`
+	Type string `json:"type,omitempty"`
+}
+
+// TreatyResponse is a treaty in API responses.
+type TreatyResponse struct {
+	ID                 uint             `json:"id"`
+	Congress           int              `json:"congress"` // Congress that received it
+	Number             int              `json:"number"`
+	Suffix             string           `json:"suffix,omitempty"`
+	DocumentNumber     string           `json:"documentNumber"` // Treaty Document number, e.g., "118-1A"
+	CongressConsidered int              `json:"congressConsidered,omitempty"`
+	Topic              string           `json:"topic"`
+	Title              string           `json:"title"`
+	TransmittedDate    string           `json:"transmittedDate,omitempty"`
+	InForceDate        string           `json:"inForceDate,omitempty"`
+	CurrentStatus      string           `json:"currentStatus"`
+	StatusDate         string           `json:"statusDate,omitempty"`
+	UpdatedAt          time.Time        `json:"updatedAt"`
+	Actions            []ActionResponse `json:"actions,omitempty"` // Oldest first; only when fetching one treaty
+}
+
+// TreatyListResponse is a page of treaties, most recent action first.
+type TreatyListResponse struct {
+	Treaties []TreatyResponse `json:"treaties"`
+	Total    int64            `json:"total"`
+	Limit    int              `json:"limit"`
+	Offset   int              `json:"offset"`
+}
+
+// NominationResponse is a nomination in API responses.
+type NominationResponse struct {
+	ID            uint             `json:"id"`
+	Congress      int              `json:"congress"`
+	Number        int              `json:"number"`
+	PartNumber    string           `json:"partNumber"`
+	Citation      string           `json:"citation"` // e.g., "PN123" or "PN123-1"
+	Description   string           `json:"description"`
+	Organization  string           `json:"organization"`
+	IsMilitary    bool             `json:"isMilitary"`
+	ReceivedDate  string           `json:"receivedDate,omitempty"`
+	CurrentStatus string           `json:"currentStatus"`
+	StatusDate    string           `json:"statusDate,omitempty"`
+	UpdatedAt     time.Time        `json:"updatedAt"`
+	Actions       []ActionResponse `json:"actions,omitempty"` // Oldest first; only when fetching one nomination
+}
+
+// NominationListResponse is a page of nominations, most recent action
+// first.
+type NominationListResponse struct {
+	Nominations []NominationResponse `json:"nominations"`
+	Total       int64                `json:"total"`
+	Limit       int                  `json:"limit"`
+	Offset      int                  `json:"offset"`
+}
+
+// ExecutiveListParams filters the treaty and nomination lists.
+type ExecutiveListParams struct {
+	Congress     int
+	Query        string // Title or description search
+	Organization string // Nominations only
+	Military     *bool  // Nominations only
+	Limit        int
+	Offset       int
+}
+
+// ListTreaties returns treaties matching params, most recent action first.
+func (s *ExecutiveService) ListTreaties(ctx context.Context, params ExecutiveListParams) (*TreatyListResponse, error) {
+	query := s.db.WithContext(ctx).Model(&models.Treaty{})
+	if params.Congress > 0 {
+		query = query.Where("congress = ?", params.Congress)
+	}
+	if params.Query != "" {
+		query = query.Where("title ILIKE ? OR topic ILIKE ?", "%"+params.Query+"%", "%"+params.Query+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count treaties: %w", err)
+	}
+
+	var treaties []models.Treaty
+	if err := query.Omit("actions").
+		Order("status_date DESC, id DESC").
+		Limit(params.Limit).Offset(params.Offset).
+		Find(&treaties).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch treaties: %w", err)
+	}
+
+	response := &TreatyListResponse{
+		Treaties: make([]TreatyResponse, len(treaties)),
+		Total:    total,
+		Limit:    params.Limit,
+		Offset:   params.Offset,
+	}
+	for i := range treaties {
+		response.Treaties[i] = treatyResponse(&treaties[i])
+	}
+	return response, nil
+}
+
+// GetTreaty returns a treaty with its actions.
+func (s *ExecutiveService) GetTreaty(ctx context.Context, id uint) (*TreatyResponse, error) {
+	var treaty models.Treaty
+	err := s.db.WithContext(ctx).First(&treaty, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrTreatyNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch treaty: %w", err)
+	}
+	response := treatyResponse(&treaty)
+	response.Actions = actionResponses(treaty.Actions)
+	return &response, nil
+}
+
+// ListNominations returns nominations matching params, most recent action
+// first.
+func (s *ExecutiveService) ListNominations(ctx context.Context, params ExecutiveListParams) (*NominationListResponse, error) {
+	query := s.db.WithContext(ctx).Model(&models.Nomination{})
+	if params.Congress > 0 {
+		query = query.Where("congress = ?", params.Congress)
+	}
+	if params.Organization != "" {
+		query = query.Where("organization ILIKE ?", "%"+params.Organization+"%")
+	}
+	if params.Military != nil {
+		query = query.Where("is_military = ?", *params.Military)
+	}
+	if params.Query != "" {
+		query = query.Where("description ILIKE ?", "%"+params.Query+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count nominations: %w", err)
+	}
+
+	var nominations []models.Nomination
+	if err := query.Omit("actions").
+		Order("status_date DESC, id DESC").
+		Limit(params.Limit).Offset(params.Offset).
+		Find(&nominations).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch nominations: %w", err)
+	}
+
+	response := &NominationListResponse{
+		Nominations: make([]NominationResponse, len(nominations)),
+		Total:       total,
+		Limit:       params.Limit,
+		Offset:      params.Offset,
+	}
+	for i := range nominations {
+		response.Nominations[i] = nominationResponse(&nominations[i])
+	}
+	return response, nil
+}
+
+// GetNomination returns a nomination with its actions.
+func (s *ExecutiveService) GetNomination(ctx context.Context, id uint) (*NominationResponse, error) {
+	var nomination models.Nomination
+	err := s.db.WithContext(ctx).First(&nomination, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNominationNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch nomination: %w", err)
+	}
+	response := nominationResponse(&nomination)
+	response.Actions = actionResponses(nomination.Actions)
+	return &response, nil
+}
+
+// treatyResponse converts a Treaty to its API form, without actions.
+func treatyResponse(t *models.Treaty) TreatyResponse {
+	return TreatyResponse{
+		ID:                 t.ID,
+		Congress:           t.Congress,
+		Number:             t.Number,
+		Suffix:             t.Suffix,
+		DocumentNumber:     fmt.Sprintf("%d-%d%s", t.Congress, t.Number, t.Suffix),
+		CongressConsidered: t.CongressConsidered,
+		Topic:              t.Topic,
+		Title:              t.Title,
+		TransmittedDate:    t.TransmittedDate,
+		InForceDate:        t.InForceDate,
+		CurrentStatus:      t.CurrentStatus,
+		StatusDate:         t.StatusDate,
+		UpdatedAt:          t.UpdatedAt,
+	}
+}
+
+// nominationResponse converts a Nomination to its API form, without
+// actions.
+func nominationResponse(n *models.Nomination) NominationResponse {
+	return NominationResponse{
+		ID:            n.ID,
+		Congress:      n.Congress,
+		Number:        n.Number,
+		PartNumber:    n.PartNumber,
+		Citation:      n.Citation,
+		Description:   n.Description,
+		Organization:  n.Organization,
+		IsMilitary:    n.IsMilitary,
+		ReceivedDate:  n.ReceivedDate,
+		CurrentStatus: n.CurrentStatus,
+		StatusDate:    n.StatusDate,
+		UpdatedAt:     n.UpdatedAt,
+	}
+}
+
+// actionResponses converts stored actions to their API form.
+func actionResponses(actions []models.LegislativeAction) []ActionResponse {
+	out := make([]ActionResponse, len(actions))
+	for i, a := range actions {
+		out[i] = ActionResponse{Date: a.Date, Text: a.Text, Type: a.Type}
+	}
+	return out
+}
+
+// ListTreatiesInput is the request for listing treaties.
+type ListTreatiesInput struct {
+	Congress int    `query:"congress" minimum:"0" doc:"Filter by the Congress that received the treaty" example:"118"`
+	Query    string `query:"query" doc:"Search in treaty title and topic (case-insensitive partial match)"`
+	Limit    int    `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"Number of results per page (max 100)"`
+	Offset   int    `query:"offset" default:"0" minimum:"0" doc:"Pagination offset"`
+}
+
+// ListTreatiesOutput is the response for listing treaties.
+type ListTreatiesOutput struct {
+	Body TreatyListResponse
+}
+
+// GetTreatyInput is the request for a single treaty.
+type GetTreatyInput struct {
+	ID uint `path:"id" minimum:"1" doc:"Treaty ID (database ID)"`
+}
+
+// GetTreatyOutput is the response for a single treaty.
+type GetTreatyOutput struct {
+	Body TreatyResponse
+}
+
+// ListNominationsInput is the request for listing nominations.
+type ListNominationsInput struct {
+	Congress     int    `query:"congress" minimum:"0" doc:"Filter by congress" example:"119"`
+	Organization string `query:"organization" doc:"Filter by nominating organization (case-insensitive partial match)" example:"Department of State"`
+	Type         string `query:"type" enum:"civilian,military" doc:"Filter to civilian or military nominations"`
+	Query        string `query:"query" doc:"Search in nomination description (case-insensitive partial match)"`
+	Limit        int    `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"Number of results per page (max 100)"`
+	Offset       int    `query:"offset" default:"0" minimum:"0" doc:"Pagination offset"`
+}
+
+// ListNominationsOutput is the response for listing nominations.
+type ListNominationsOutput struct {
+	Body NominationListResponse
+}
+
+// GetNominationInput is the request for a single nomination.
+type GetNominationInput struct {
+	ID uint `path:"id" minimum:"1" doc:"Nomination ID (database ID)"`
+}
+
+// GetNominationOutput is the response for a single nomination.
+type GetNominationOutput struct {
+	Body NominationResponse
+}
+
+// RegisterExecutiveRoutes registers the treaty and nomination endpoints.
+func RegisterExecutiveRoutes(api huma.API, s *ExecutiveService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-treaties",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/treaties",
+		Summary:     "List treaties",
+		Description: "Lists treaties transmitted to the Senate, most recent action first",
+		Errors:      []int{http.StatusInternalServerError},
+		Tags:        []string{"Treaties"},
+	}, func(ctx context.Context, input *ListTreatiesInput) (*ListTreatiesOutput, error) {
+		treaties, err := s.ListTreaties(ctx, ExecutiveListParams{
+			Congress: input.Congress,
+			Query:    input.Query,
+			Limit:    input.Limit,
+			Offset:   input.Offset,
+		})
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list treaties: " + err.Error())
+		}
+		return &ListTreatiesOutput{Body: *treaties}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-treaty",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/treaties/{id}",
+		Summary:     "Get a treaty",
+		Description: "Returns a treaty with its actions, from transmittal through committee and floor consideration",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Treaties"},
+	}, func(ctx context.Context, input *GetTreatyInput) (*GetTreatyOutput, error) {
+		treaty, err := s.GetTreaty(ctx, input.ID)
+		if err != nil {
+			return nil, serviceError(err, "failed to get treaty")
+		}
+		return &GetTreatyOutput{Body: *treaty}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-nominations",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/nominations",
+		Summary:     "List nominations",
+		Description: "Lists presidential nominations sent to the Senate, most recent action first",
+		Errors:      []int{http.StatusInternalServerError},
+		Tags:        []string{"Nominations"},
+	}, func(ctx context.Context, input *ListNominationsInput) (*ListNominationsOutput, error) {
+		params := ExecutiveListParams{
+			Congress:     input.Congress,
+			Query:        input.Query,
+			Organization: input.Organization,
+			Limit:        input.Limit,
+			Offset:       input.Offset,
+		}
+		if input.Type != "" {
+			military := input.Type == "military"
+			params.Military = &military
+		}
+		nominations, err := s.ListNominations(ctx, params)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list nominations: " + err.Error())
+		}
+		return &ListNominationsOutput{Body: *nominations}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-nomination",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/nominations/{id}",
+		Summary:     "Get a nomination",
+		Description: "Returns a nomination with its actions, from receipt through committee and floor consideration",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Nominations"},
+	}, func(ctx context.Context, input *GetNominationInput) (*GetNominationOutput, error) {
+		nomination, err := s.GetNomination(ctx, input.ID)
+		if err != nil {
+			return nil, serviceError(err, "failed to get nomination")
+		}
+		return &GetNominationOutput{Body: *nomination}, nil
+	})
+}
@@ -0,0 +1,162 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/api"
+	"github.com/drewjst/deltagov/internal/congresstest"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// createExecutiveBusiness stores two treaties and a civilian and a
+// military nomination, each with its latest action as its status.
+func createExecutiveBusiness(t *testing.T, db *gorm.DB) ([]models.Treaty, []models.Nomination) {
+	t.Helper()
+	treaties := []models.Treaty{
+		{Congress: 117, Number: 5, Topic: "Tax", Title: "Tax Convention with Chile", CurrentStatus: "Received in the Senate.",
+			StatusDate: "2022-02-01", Actions: []models.LegislativeAction{{Date: "2022-02-01", Text: "Received in the Senate."}}},
+		{Congress: 118, Number: 1, Suffix: "A", Topic: "Extradition", Title: "Extradition Treaty with Peru",
+			CurrentStatus: "Reported favorably.", StatusDate: "2023-06-01", Actions: []models.LegislativeAction{
+				{Date: "2023-05-01", Text: "Received in the Senate.", Type: "IntroReferral"},
+				{Date: "2023-06-01", Text: "Reported favorably.", Type: "Committee"},
+			}},
+	}
+	nominations := []models.Nomination{
+		{Congress: 118, Number: 123, PartNumber: "00", Citation: "PN123", Description: "Jane Doe, to be an Assistant Secretary of State.",
+			Organization: "Department of State", CurrentStatus: "Confirmed.", StatusDate: "2023-03-01",
+			Actions: []models.LegislativeAction{{Date: "2023-01-03", Text: "Received."}, {Date: "2023-03-01", Text: "Confirmed."}}},
+		{Congress: 118, Number: 200, PartNumber: "00", Citation: "PN200", Description: "Army nominations beginning with John Roe.",
+			Organization: "Army", IsMilitary: true, CurrentStatus: "Received.", StatusDate: "2023-04-01"},
+	}
+	if err := db.Create(&treaties).Error; err != nil {
+		t.Fatalf("Failed to create treaties: %v", err)
+	}
+	if err := db.Create(&nominations).Error; err != nil {
+		t.Fatalf("Failed to create nominations: %v", err)
+	}
+	return treaties, nominations
+}
+
+// TestExecutiveService verifies treaties and nominations are listed most
+// recent action first without their actions, filtered, and fetched with
+// their actions.
+func TestExecutiveService(t *testing.T) {
+	db := congresstest.OpenDB(t)
+	s := api.NewExecutiveService(db)
+	ctx := context.Background()
+	treaties, nominations := createExecutiveBusiness(t, db)
+
+	list, err := s.ListTreaties(ctx, api.ExecutiveListParams{Limit: 20})
+	if err != nil {
+		t.Fatalf("ListTreaties failed: %v", err)
+	}
+	if list.Total != 2 || len(list.Treaties) != 2 || list.Treaties[0].ID != treaties[1].ID {
+		t.Fatalf("Treaties = %+v, want both, most recent action first", list)
+	}
+	if got := list.Treaties[0]; got.DocumentNumber != "118-1A" || got.Actions != nil {
+		t.Errorf("Listed treaty = %+v, want document number 118-1A without actions", got)
+	}
+	list, err = s.ListTreaties(ctx, api.ExecutiveListParams{Congress: 117, Limit: 20})
+	if err != nil || list.Total != 1 || list.Treaties[0].ID != treaties[0].ID {
+		t.Errorf("Treaties of the 117th = %+v, %v; want the tax convention", list, err)
+	}
+	list, err = s.ListTreaties(ctx, api.ExecutiveListParams{Limit: 1, Offset: 1})
+	if err != nil || list.Total != 2 || len(list.Treaties) != 1 || list.Treaties[0].ID != treaties[0].ID {
+		t.Errorf("Second page of treaties = %+v, %v; want the older treaty", list, err)
+	}
+
+	treaty, err := s.GetTreaty(ctx, treaties[1].ID)
+	if err != nil {
+		t.Fatalf("GetTreaty failed: %v", err)
+	}
+	if len(treaty.Actions) != 2 || treaty.Actions[1].Type != "Committee" {
+		t.Errorf("Treaty actions = %+v, want both oldest first", treaty.Actions)
+	}
+	if _, err := s.GetTreaty(ctx, 999); !errors.Is(err, api.ErrTreatyNotFound) {
+		t.Errorf("GetTreaty(999) error = %v, want ErrTreatyNotFound", err)
+	}
+
+	military := true
+	noms, err := s.ListNominations(ctx, api.ExecutiveListParams{Military: &military, Limit: 20})
+	if err != nil || noms.Total != 1 || noms.Nominations[0].Citation != "PN200" {
+		t.Errorf("Military nominations = %+v, %v; want PN200", noms, err)
+	}
+	military = false
+	noms, err = s.ListNominations(ctx, api.ExecutiveListParams{Military: &military, Limit: 20})
+	if err != nil || noms.Total != 1 || noms.Nominations[0].Citation != "PN123" {
+		t.Errorf("Civilian nominations = %+v, %v; want PN123", noms, err)
+	}
+
+	nomination, err := s.GetNomination(ctx, nominations[0].ID)
+	if err != nil {
+		t.Fatalf("GetNomination failed: %v", err)
+	}
+	if nomination.Organization != "Department of State" || len(nomination.Actions) != 2 || nomination.Actions[1].Text != "Confirmed." {
+		t.Errorf("Nomination = %+v, want its actions oldest first", nomination)
+	}
+	if _, err := s.GetNomination(ctx, 999); !errors.Is(err, api.ErrNominationNotFound) {
+		t.Errorf("GetNomination(999) error = %v, want ErrNominationNotFound", err)
+	}
+}
+
+// TestExecutiveEndpoints verifies the treaty and nomination endpoints are
+// public and report missing ones as 404s.
+func TestExecutiveEndpoints(t *testing.T) {
+	ts := newTestServer(t, "")
+	treaties, nominations := createExecutiveBusiness(t, ts.db)
+
+	var treatyList api.TreatyListResponse
+	status, body := ts.request(t, http.MethodGet, "/api/v1/treaties?congress=118", nil, nil)
+	if status != http.StatusOK {
+		t.Fatalf("List treaties: status = %d, want 200: %s", status, body)
+	}
+	decodeJSON(t, body, &treatyList)
+	if treatyList.Total != 1 || treatyList.Limit != 20 || treatyList.Treaties[0].ID != treaties[1].ID {
+		t.Errorf("Treaties of the 118th = %+v", treatyList)
+	}
+
+	var nominationList api.NominationListResponse
+	status, body = ts.request(t, http.MethodGet, "/api/v1/nominations?type=military", nil, nil)
+	if status != http.StatusOK {
+		t.Fatalf("List nominations: status = %d, want 200: %s", status, body)
+	}
+	decodeJSON(t, body, &nominationList)
+	if nominationList.Total != 1 || nominationList.Nominations[0].ID != nominations[1].ID {
+		t.Errorf("Military nominations = %+v", nominationList)
+	}
+	if status, body := ts.request(t, http.MethodGet, "/api/v1/nominations?type=judicial", nil, nil); status != http.StatusUnprocessableEntity {
+		t.Errorf("Unknown nomination type: status = %d, want 422: %s", status, body)
+	}
+
+	var treaty api.TreatyResponse
+	status, body = ts.request(t, http.MethodGet, fmt.Sprintf("/api/v1/treaties/%d", treaties[1].ID), nil, nil)
+	if status != http.StatusOK {
+		t.Fatalf("Get treaty: status = %d, want 200: %s", status, body)
+	}
+	decodeJSON(t, body, &treaty)
+	if treaty.DocumentNumber != "118-1A" || len(treaty.Actions) != 2 {
+		t.Errorf("Treaty = %+v, want 118-1A with its actions", treaty)
+	}
+	if status, body := ts.request(t, http.MethodGet, fmt.Sprintf("/api/v1/nominations/%d", nominations[0].ID), nil, nil); status != http.StatusOK {
+		t.Errorf("Get nomination: status = %d, want 200: %s", status, body)
+	}
+
+	missing := []struct {
+		path string
+		code string
+	}{
+		{"/api/v1/treaties/999", api.CodeTreatyNotFound},
+		{"/api/v1/nominations/999", api.CodeNominationNotFound},
+	}
+	for _, tt := range missing {
+		if status, body := ts.request(t, http.MethodGet, tt.path, nil, nil); status != http.StatusNotFound || errorCode(t, body) != tt.code {
+			t.Errorf("GET %s: status = %d, want 404 %s: %s", tt.path, status, tt.code, body)
+		}
+	}
+}
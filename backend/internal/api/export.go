@@ -0,0 +1,394 @@
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/archive"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// Export formats.
+const (
+	ExportJSONL = "jsonl"
+	ExportCSV   = "csv"
+)
+
+// exportBatchSize is the number of rows read per query while exporting.
+const exportBatchSize = 500
+
+// ExportFilter selects the rows included in an export.
+type ExportFilter struct {
+	Congress     int       // Filter by congress number (0 = no filter)
+	From         time.Time // Earliest bill update date or version date, inclusive (zero = no filter)
+	To           time.Time // Latest bill update date or version date, inclusive (zero = no filter)
+	SpendingOnly bool      // Only spending bills
+}
+
+// ExportBill is one row of the bills export.
+type ExportBill struct {
+	ID             uint     `json:"id"`
+	Jurisdiction   string   `json:"jurisdiction"`
+	State          string   `json:"state"`
+	Session        string   `json:"session"`
+	Congress       int      `json:"congress"`
+	BillType       string   `json:"billType"`
+	Number         string   `json:"number"`
+	BillNumber     int      `json:"billNumber"`
+	Title          string   `json:"title"`
+	Sponsor        string   `json:"sponsor"`
+	OriginChamber  string   `json:"originChamber"`
+	CurrentStatus  string   `json:"currentStatus"`
+	UpdateDate     string   `json:"updateDate"`
+	IsSpendingBill bool     `json:"isSpendingBill"`
+	PolicyArea     string   `json:"policyArea"`
+	Subjects       []string `json:"subjects"`
+	LawNumber      string   `json:"lawNumber"`
+}
+
+// exportBillHeader is the CSV header of the bills export.
+var exportBillHeader = []string{
+	"id", "jurisdiction", "state", "session", "congress", "bill_type", "number", "bill_number", "title", "sponsor",
+	"origin_chamber", "current_status", "update_date", "is_spending_bill", "policy_area", "subjects", "law_number",
+}
+
+// csv returns the bill as a CSV record. Subjects are joined with "; ".
+func (b *ExportBill) csv() []string {
+	return []string{
+		strconv.FormatUint(uint64(b.ID), 10), b.Jurisdiction, b.State, b.Session, strconv.Itoa(b.Congress),
+		b.BillType, b.Number, strconv.Itoa(b.BillNumber), b.Title, b.Sponsor, b.OriginChamber, b.CurrentStatus, b.UpdateDate,
+		strconv.FormatBool(b.IsSpendingBill), b.PolicyArea, strings.Join(b.Subjects, "; "), b.LawNumber,
+	}
+}
+
+// ExportVersion is one row of the versions export.
+type ExportVersion struct {
+	ID          uint   `json:"id"`
+	BillID      uint   `json:"billId"`
+	Congress    int    `json:"congress"`
+	BillType    string `json:"billType"`
+	Number      string `json:"number"`
+	BillNumber  int    `json:"billNumber"`
+	VersionCode string `json:"versionCode"`
+	Date        string `json:"date"`
+	ContentHash string `json:"contentHash"`
+	Text        string `json:"text,omitempty"` // Plain text, when requested
+}
+
+// exportVersionHeader is the CSV header of the versions export.
+var exportVersionHeader = []string{
+	"id", "bill_id", "congress", "bill_type", "number", "bill_number", "version_code", "date", "content_hash",
+}
+
+// csv returns the version as a CSV record, with its text last when requested.
+func (v *ExportVersion) csv(withText bool) []string {
+	rec := []string{
+		strconv.FormatUint(uint64(v.ID), 10), strconv.FormatUint(uint64(v.BillID), 10), strconv.Itoa(v.Congress),
+		v.BillType, v.Number, strconv.Itoa(v.BillNumber), v.VersionCode, v.Date, v.ContentHash,
+	}
+	if withText {
+		rec = append(rec, v.Text)
+	}
+	return rec
+}
+
+// exportWriter writes export rows as JSON Lines or CSV.
+type exportWriter struct {
+	w    *bufio.Writer
+	csv  *csv.Writer
+	json *json.Encoder
+}
+
+func newExportWriter(w *bufio.Writer, format string, header []string) (*exportWriter, error) {
+	if format == ExportCSV {
+		cw := csv.NewWriter(w)
+		if err := cw.Write(header); err != nil {
+			return nil, err
+		}
+		return &exportWriter{w: w, csv: cw}, nil
+	}
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &exportWriter{w: w, json: enc}, nil
+}
+
+// write writes one row; record is only called for CSV.
+func (e *exportWriter) write(row any, record func() []string) error {
+	if e.csv != nil {
+		return e.csv.Write(record())
+	}
+	return e.json.Encode(row)
+}
+
+// flush sends buffered rows to the client.
+func (e *exportWriter) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+	return e.w.Flush()
+}
+
+// exportBillsQuery returns the bills matching the filter, within scope.
+func (s *BillService) exportBillsQuery(ctx context.Context, filter ExportFilter) *gorm.DB {
+	query := s.db.WithContext(ctx).Model(&models.Bill{}).Scopes(s.scope.Query, visibleBills(ctx))
+	if filter.Congress > 0 {
+		query = query.Where("bills.congress = ?", filter.Congress)
+	}
+	if filter.SpendingOnly {
+		query = query.Where("bills.is_spending_bill = ?", true)
+	}
+	return query
+}
+
+// ExportBills writes every bill matching the filter to w, a batch at a time.
+// The date range applies to the bill's Congress.gov update date.
+func (s *BillService) ExportBills(ctx context.Context, w *bufio.Writer, filter ExportFilter, format string) error {
+	out, err := newExportWriter(w, format, exportBillHeader)
+	if err != nil {
+		return err
+	}
+
+	// updateDate is an ISO 8601 string, so ranges compare lexically
+	query := s.exportBillsQuery(ctx, filter)
+	if !filter.From.IsZero() {
+		query = query.Where("bills.update_date >= ?", filter.From.Format(time.DateOnly))
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("bills.update_date < ?", filter.To.AddDate(0, 0, 1).Format(time.DateOnly))
+	}
+
+	var bills []models.Bill
+	err = query.Order("bills.id ASC").FindInBatches(&bills, exportBatchSize, func(_ *gorm.DB, _ int) error {
+		for i := range bills {
+			b := &bills[i]
+			row := ExportBill{
+				ID:             b.ID,
+				Jurisdiction:   b.Jurisdiction,
+				State:          b.StateCode,
+				Session:        b.Session,
+				Congress:       b.Congress,
+				BillType:       b.BillType,
+				Number:         b.Number,
+				BillNumber:     b.BillNumber,
+				Title:          b.Title,
+				Sponsor:        b.Sponsor,
+				OriginChamber:  b.OriginChamber,
+				CurrentStatus:  b.CurrentStatus,
+				UpdateDate:     b.UpdateDate,
+				IsSpendingBill: b.IsSpendingBill,
+				PolicyArea:     b.PolicyArea,
+				Subjects:       []string(b.Subjects),
+				LawNumber:      lawNumber(b),
+			}
+			if row.Subjects == nil {
+				row.Subjects = []string{}
+			}
+			if err := out.write(&row, row.csv); err != nil {
+				return err
+			}
+		}
+		return out.flush()
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to export bills: %w", err)
+	}
+	return out.flush()
+}
+
+// ExportVersions writes every version of the bills matching the filter to
+// w, a batch at a time, optionally with each version's plain text. The date
+// range applies to the version date.
+func (s *BillService) ExportVersions(ctx context.Context, w *bufio.Writer, filter ExportFilter, format string, withText bool) error {
+	header := exportVersionHeader
+	if withText {
+		header = append(header[:len(header):len(header)], "text")
+	}
+	out, err := newExportWriter(w, format, header)
+	if err != nil {
+		return err
+	}
+
+	columns := []string{"versions.id", "versions.bill_id", "versions.version_code", "versions.fetched_at", "versions.content_hash"}
+	if withText {
+		for _, c := range archive.TextColumns {
+			columns = append(columns, "versions."+c)
+		}
+	}
+	query := s.db.WithContext(ctx).Model(&models.Version{}).Select(columns).
+		Where("versions.bill_id IN (?)", s.exportBillsQuery(ctx, filter).Select("bills.id"))
+	if !filter.From.IsZero() {
+		query = query.Where("versions.fetched_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("versions.fetched_at < ?", filter.To.AddDate(0, 0, 1))
+	}
+
+	bills := make(map[uint]*models.Bill)
+	var versions []models.Version
+	err = query.Order("versions.id ASC").FindInBatches(&versions, exportBatchSize, func(_ *gorm.DB, _ int) error {
+		if err := s.loadExportBills(ctx, versions, bills); err != nil {
+			return err
+		}
+		for i := range versions {
+			v := &versions[i]
+			bill := bills[v.BillID]
+			row := ExportVersion{
+				ID:          v.ID,
+				BillID:      v.BillID,
+				Congress:    bill.Congress,
+				BillType:    bill.BillType,
+				Number:      bill.Number,
+				BillNumber:  bill.BillNumber,
+				VersionCode: v.VersionCode,
+				Date:        v.FetchedAt.Format(time.DateOnly),
+				ContentHash: v.ContentHash,
+			}
+			if withText {
+				if err := rehydrate(v); err != nil {
+					return err
+				}
+				row.Text = v.PlainText
+			}
+			if err := out.write(&row, func() []string { return row.csv(withText) }); err != nil {
+				return err
+			}
+		}
+		return out.flush()
+	}).Error
+	if err != nil {
+		return fmt.Errorf("failed to export versions: %w", err)
+	}
+	return out.flush()
+}
+
+// loadExportBills adds the bills of versions not yet in bills to the map.
+func (s *BillService) loadExportBills(ctx context.Context, versions []models.Version, bills map[uint]*models.Bill) error {
+	var missing []uint
+	for _, v := range versions {
+		if _, ok := bills[v.BillID]; !ok {
+			missing = append(missing, v.BillID)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	var found []models.Bill
+	if err := s.db.WithContext(ctx).Select("id", "congress", "bill_type", "number", "bill_number").
+		Where("id IN ?", missing).Find(&found).Error; err != nil {
+		return fmt.Errorf("failed to fetch bills: %w", err)
+	}
+	for i := range found {
+		bills[found[i].ID] = &found[i]
+	}
+	return nil
+}
+
+// ExportInput is the request shared by the export endpoints
+type ExportInput struct {
+	Format   string `query:"format" enum:"jsonl,csv" default:"jsonl" doc:"jsonl writes one JSON object per line; csv writes a header row, then one row per record"`
+	Congress int    `query:"congress" doc:"Filter by congress number"`
+	From     string `query:"from" format:"date" doc:"Earliest date, YYYY-MM-DD: the bill's update date, or the version's date"`
+	To       string `query:"to" format:"date" doc:"Latest date, YYYY-MM-DD, inclusive"`
+	Spending bool   `query:"spending" doc:"Only spending bills"`
+}
+
+// filter parses the input's filter parameters.
+func (in *ExportInput) filter() (ExportFilter, error) {
+	filter := ExportFilter{Congress: in.Congress, SpendingOnly: in.Spending}
+	var err error
+	if in.From != "" {
+		if filter.From, err = time.Parse(time.DateOnly, in.From); err != nil {
+			return filter, huma.Error400BadRequest("invalid from date")
+		}
+	}
+	if in.To != "" {
+		if filter.To, err = time.Parse(time.DateOnly, in.To); err != nil {
+			return filter, huma.Error400BadRequest("invalid to date")
+		}
+	}
+	return filter, nil
+}
+
+// ExportVersionsInput is the request for exporting versions
+type ExportVersionsInput struct {
+	ExportInput
+	IncludeText bool `query:"includeText" doc:"Include each version's plain text (large)"`
+}
+
+// exportResponse streams an export with the given filename. The body is
+// written after the handler returns, so write gets a context detached from
+// the request, carrying its ID and the caller's tenant.
+func exportResponse(ctx context.Context, format, name string, write func(ctx context.Context, w *bufio.Writer) error) *huma.StreamResponse {
+	exportCtx := logging.WithRequestID(context.Background(), logging.RequestID(ctx))
+	exportCtx = context.WithValue(exportCtx, tenantKey{}, callerTenant(ctx))
+	return &huma.StreamResponse{Body: func(hctx huma.Context) {
+		contentType := "application/x-ndjson"
+		if format == ExportCSV {
+			contentType = "text/csv; charset=utf-8"
+		}
+		hctx.SetHeader("Content-Type", contentType)
+		hctx.SetHeader("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, format))
+		hctx.SetHeader("X-Accel-Buffering", "no")
+		streamBody(hctx.BodyWriter(), func(w *bufio.Writer) {
+			if err := write(exportCtx, w); err != nil {
+				// Headers are already sent; the client sees a truncated file
+				logging.FromContext(exportCtx).Warn("export failed", "export", name, "error", err)
+			}
+		})
+	}}
+}
+
+// RegisterExportRoutes registers bulk export endpoints with Huma
+func RegisterExportRoutes(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "export-bills",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/export/bills",
+		Summary:     "Export bills",
+		Description: "Streams every bill matching the filters as JSON Lines or CSV, for loading into tools such as pandas or BigQuery",
+		Errors:      []int{http.StatusBadRequest},
+		Responses:   streamedResponse("Exported bills", nil, "application/x-ndjson", "text/csv"),
+		Tags:        []string{"Export"},
+	}, func(ctx context.Context, input *ExportInput) (*huma.StreamResponse, error) {
+		filter, err := input.filter()
+		if err != nil {
+			return nil, err
+		}
+		return exportResponse(ctx, input.Format, "bills", func(ctx context.Context, w *bufio.Writer) error {
+			return s.ExportBills(ctx, w, filter, input.Format)
+		}), nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "export-versions",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/export/versions",
+		Summary:     "Export versions",
+		Description: "Streams every text version of the bills matching the filters as JSON Lines or CSV, optionally with plain text",
+		Errors:      []int{http.StatusBadRequest},
+		Responses:   streamedResponse("Exported versions", nil, "application/x-ndjson", "text/csv"),
+		Tags:        []string{"Export"},
+	}, func(ctx context.Context, input *ExportVersionsInput) (*huma.StreamResponse, error) {
+		filter, err := input.filter()
+		if err != nil {
+			return nil, err
+		}
+		return exportResponse(ctx, input.Format, "versions", func(ctx context.Context, w *bufio.Writer) error {
+			return s.ExportVersions(ctx, w, filter, input.Format, input.IncludeText)
+		}), nil
+	})
+}
@@ -0,0 +1,75 @@
+package api_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/api"
+	"github.com/drewjst/deltagov/internal/congresstest"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// TestExportNumber verifies bills and versions are exported with their
+// number as published, so state bills with suffixed numbers, whose
+// BillNumber is 0, can be told apart.
+func TestExportNumber(t *testing.T) {
+	db := congresstest.OpenDB(t)
+	s := api.NewBillService(db, nil)
+	ctx := context.Background()
+	bill := models.Bill{BillType: "AB", Number: "12A", StateCode: "ca", Session: "20252026",
+		Title: "Housing Act", Jurisdiction: models.JurisdictionState}
+	if err := db.Create(&bill).Error; err != nil {
+		t.Fatalf("Failed to create bill: %v", err)
+	}
+	createVersion(t, db, &bill, "IH", "Text.\n", time.Now())
+
+	export := func(format string, write func(w *bufio.Writer) error) []byte {
+		t.Helper()
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		if err := write(w); err != nil {
+			t.Fatalf("Export as %s failed: %v", format, err)
+		}
+		return buf.Bytes()
+	}
+
+	var exportedBill api.ExportBill
+	decodeJSON(t, export(api.ExportJSONL, func(w *bufio.Writer) error {
+		return s.ExportBills(ctx, w, api.ExportFilter{}, api.ExportJSONL)
+	}), &exportedBill)
+	if exportedBill.Number != "12A" || exportedBill.BillNumber != 0 {
+		t.Errorf("Exported bill = %+v, want number 12A", exportedBill)
+	}
+	var exportedVersion api.ExportVersion
+	decodeJSON(t, export(api.ExportJSONL, func(w *bufio.Writer) error {
+		return s.ExportVersions(ctx, w, api.ExportFilter{}, api.ExportJSONL, false)
+	}), &exportedVersion)
+	if exportedVersion.Number != "12A" {
+		t.Errorf("Exported version = %+v, want number 12A", exportedVersion)
+	}
+
+	for name, body := range map[string][]byte{
+		"bills": export(api.ExportCSV, func(w *bufio.Writer) error {
+			return s.ExportBills(ctx, w, api.ExportFilter{}, api.ExportCSV)
+		}),
+		"versions": export(api.ExportCSV, func(w *bufio.Writer) error {
+			return s.ExportVersions(ctx, w, api.ExportFilter{}, api.ExportCSV, false)
+		}),
+	} {
+		records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+		if err != nil || len(records) != 2 {
+			t.Fatalf("CSV %s export = %q, %v; want a header and one row", name, body, err)
+		}
+		row := make(map[string]string)
+		for i, column := range records[0] {
+			row[column] = records[1][i]
+		}
+		if row["number"] != "12A" || row["bill_number"] != "0" {
+			t.Errorf("CSV %s row = %v, want number 12A", name, row)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"context"
+	"fmt"
+)
+
+// FacetCount is the number of matching bills with one value of a field.
+type FacetCount struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// SearchFacets are the counts of matching bills per value of each filter
+// field, most common first, for rendering filter chips. Values are
+// strings; isSpendingBill counts "true" and "false".
+type SearchFacets struct {
+	Congress       []FacetCount `json:"congress"`
+	BillType       []FacetCount `json:"billType"` // Lower case
+	OriginChamber  []FacetCount `json:"originChamber"`
+	IsSpendingBill []FacetCount `json:"isSpendingBill"`
+	PolicyArea     []FacetCount `json:"policyArea"`
+}
+
+// searchFacets counts the bills matching params per value of each facet
+// field, one grouped query per field. Empty values, and the congress of
+// state bills, aren't counted.
+func (s *BillService) searchFacets(ctx context.Context, params LexSearchParams) (*SearchFacets, error) {
+	facets := &SearchFacets{}
+	for _, f := range []struct {
+		expr  string
+		where string // Excludes empty values
+		dst   *[]FacetCount
+	}{
+		{"CAST(congress AS TEXT)", "congress > 0", &facets.Congress},
+		{"LOWER(bill_type)", "bill_type <> ''", &facets.BillType},
+		{"origin_chamber", "origin_chamber <> ''", &facets.OriginChamber},
+		{"CAST(is_spending_bill AS TEXT)", "is_spending_bill IS NOT NULL", &facets.IsSpendingBill},
+		{"policy_area", "policy_area <> ''", &facets.PolicyArea},
+	} {
+		counts := make([]FacetCount, 0)
+		if err := s.searchQuery(ctx, params).
+			Select(f.expr + " AS value, COUNT(*) AS count").
+			Where(f.where).
+			Group(f.expr).
+			Order("count DESC, value ASC").
+			Scan(&counts).Error; err != nil {
+			return nil, fmt.Errorf("failed to count facet %s: %w", f.expr, err)
+		}
+		*f.dst = counts
+	}
+	return facets, nil
+}
@@ -0,0 +1,284 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/feeds"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// feedEntryLimit is the number of entries included in each Atom feed.
+const feedEntryLimit = 50
+
+// FeedService renders Atom feeds of recently changed bills from ingestion
+// events, and iCalendar feeds of watched bills' milestones.
+type FeedService struct {
+	bills     *BillService
+	baseURL   string
+	apiPrefix string
+}
+
+// NewFeedService creates a new FeedService. baseURL is the public origin of
+// the API (e.g., "https://api.deltagov.org") used for absolute feed links.
+func NewFeedService(bills *BillService, baseURL string) *FeedService {
+	return &FeedService{bills: bills, baseURL: strings.TrimSuffix(baseURL, "/"), apiPrefix: VersionPrefix}
+}
+
+// SetAPIPrefix sets the path prefix of the API routes feed entries link
+// to, when ConfigureOpenAPI serves them under another prefix.
+func (s *FeedService) SetAPIPrefix(prefix string) {
+	s.apiPrefix = strings.TrimSuffix(prefix, "/")
+}
+
+// RecentChangesFeed returns an Atom feed of the latest changes across all bills.
+func (s *FeedService) RecentChangesFeed(ctx context.Context) ([]byte, error) {
+	var events []models.BillEvent
+	if err := s.bills.db.WithContext(ctx).
+		Order("occurred_at DESC, id DESC").Limit(feedEntryLimit).Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to list bill events: %w", err)
+	}
+
+	feed := &feeds.Feed{
+		ID:       s.baseURL + "/feeds/bills.atom",
+		Title:    "DeltaGov: recently changed bills",
+		Subtitle: "New versions, status changes, title changes, and sponsor changes detected by DeltaGov",
+		Author:   &feeds.Person{Name: "DeltaGov"},
+		Links:    []feeds.Link{{Href: s.baseURL + "/feeds/bills.atom", Rel: "self", Type: feeds.ContentType}},
+		Entries:  make([]feeds.Entry, 0, len(events)),
+	}
+
+	bills := make(map[uint]*models.Bill)
+	versions := make(map[uint][]models.Version)
+	for i := range events {
+		bill, ok := bills[events[i].BillID]
+		if !ok {
+			bill = &models.Bill{}
+			if err := s.bills.db.WithContext(ctx).First(bill, events[i].BillID).Error; err != nil {
+				continue // Event for a bill that was removed
+			}
+			bills[bill.ID] = bill
+
+			vs, err := s.bills.versionsInOrder(ctx, bill.ID)
+			if err != nil {
+				return nil, err
+			}
+			versions[bill.ID] = vs
+		}
+
+		entry, err := s.entry(ctx, bill, &events[i], versions[bill.ID])
+		if err != nil {
+			return nil, err
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return feed.Marshal()
+}
+
+// BillChangesFeed returns an Atom feed of the latest changes to one bill.
+func (s *FeedService) BillChangesFeed(ctx context.Context, billID uint) ([]byte, error) {
+	db := s.bills.db.WithContext(ctx)
+
+	var bill models.Bill
+	if err := db.First(&bill, billID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBillNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch bill: %w", err)
+	}
+
+	var events []models.BillEvent
+	if err := db.Where("bill_id = ?", billID).
+		Order("occurred_at DESC, id DESC").Limit(feedEntryLimit).Find(&events).Error; err != nil {
+		return nil, fmt.Errorf("failed to list bill events: %w", err)
+	}
+
+	versions, err := s.bills.versionsInOrder(ctx, billID)
+	if err != nil {
+		return nil, err
+	}
+
+	self := fmt.Sprintf("%s/feeds/bills/%d.atom", s.baseURL, billID)
+	feed := &feeds.Feed{
+		ID:      self,
+		Title:   fmt.Sprintf("DeltaGov: %s", billLabel(&bill)),
+		Author:  &feeds.Person{Name: "DeltaGov"},
+		Links:   []feeds.Link{{Href: self, Rel: "self", Type: feeds.ContentType}},
+		Entries: make([]feeds.Entry, 0, len(events)),
+	}
+	if bill.Title != "" {
+		feed.Subtitle = bill.Title
+	}
+
+	for i := range events {
+		entry, err := s.entry(ctx, &bill, &events[i], versions)
+		if err != nil {
+			return nil, err
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return feed.Marshal()
+}
+
+// entry converts a bill event to an Atom entry, including diff statistics
+// for new versions when the diff has been computed.
+func (s *FeedService) entry(ctx context.Context, bill *models.Bill, event *models.BillEvent, versions []models.Version) (feeds.Entry, error) {
+	label := billLabel(bill)
+	changesURL := fmt.Sprintf("%s%s/bills/%d/changes", s.baseURL, s.apiPrefix, bill.ID)
+
+	entry := feeds.Entry{
+		ID:      fmt.Sprintf("%s#event-%d", changesURL, event.ID),
+		Updated: feeds.Timestamp(event.OccurredAt),
+		Links:   []feeds.Link{{Href: changesURL, Rel: "alternate", Type: "application/json"}},
+	}
+
+	var content []string
+	switch event.EventType {
+	case models.BillEventVersionAdded:
+		entry.Title = fmt.Sprintf("%s: new version %s", label, event.NewValue)
+		content = append(content, fmt.Sprintf("A new text version (%s) of %s was published.", event.NewValue, label))
+		if event.VersionID != nil {
+			version, err := s.bills.changeVersion(ctx, *event.VersionID, versions)
+			if err != nil {
+				return feeds.Entry{}, err
+			}
+			if version.Insertions != nil && version.Deletions != nil {
+				content = append(content, fmt.Sprintf("Compared with the previous version: %d insertions, %d deletions.",
+					*version.Insertions, *version.Deletions))
+			}
+			if version.PreviousVersionID != 0 {
+				entry.Links = append(entry.Links, feeds.Link{
+					Href: fmt.Sprintf("%s%s/bills/%d/diff/%d/%d",
+						s.baseURL, s.apiPrefix, bill.ID, version.PreviousVersionID, version.ID),
+					Rel:  "related",
+					Type: "application/json",
+				})
+			}
+		}
+	case models.BillEventStatusChanged:
+		entry.Title = fmt.Sprintf("%s: status changed", label)
+		content = append(content, fmt.Sprintf("Latest action: %s", event.NewValue))
+		if event.PreviousValue != "" {
+			content = append(content, fmt.Sprintf("Previously: %s", event.PreviousValue))
+		}
+	case models.BillEventTitleChanged:
+		entry.Title = fmt.Sprintf("%s: title changed", label)
+		content = append(content, fmt.Sprintf("New title: %s", event.NewValue), fmt.Sprintf("Previous title: %s", event.PreviousValue))
+	case models.BillEventSponsorChanged:
+		entry.Title = fmt.Sprintf("%s: sponsors changed", label)
+		if event.NewValue != "" {
+			content = append(content, fmt.Sprintf("Sponsor: %s (previously %s)", event.NewValue, event.PreviousValue))
+		}
+		if added, ok := event.Details["cosponsorsAdded"].([]any); ok && len(added) > 0 {
+			names := make([]string, 0, len(added))
+			for _, name := range added {
+				names = append(names, fmt.Sprint(name))
+			}
+			content = append(content, fmt.Sprintf("Cosponsors added: %s", strings.Join(names, ", ")))
+		}
+	case models.BillEventBecameLaw:
+		entry.Title = fmt.Sprintf("%s: became %s", label, event.NewValue)
+		content = append(content, fmt.Sprintf("%s was enacted as %s.", label, event.NewValue))
+	default:
+		entry.Title = fmt.Sprintf("%s: %s", label, event.EventType)
+	}
+
+	if bill.Title != "" {
+		entry.Summary = bill.Title
+	}
+	entry.Content = &feeds.Text{Type: "text", Body: strings.Join(content, "\n")}
+
+	return entry, nil
+}
+
+// versionsInOrder returns a bill's version IDs and codes in fetch order.
+func (s *BillService) versionsInOrder(ctx context.Context, billID uint) ([]models.Version, error) {
+	var versions []models.Version
+	if err := s.db.WithContext(ctx).Select("id", "version_code", "fetched_at").
+		Where("bill_id = ?", billID).Order("fetched_at ASC, id ASC").Find(&versions).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch versions: %w", err)
+	}
+	return versions, nil
+}
+
+// billLabel returns a short citation such as "HR 1 (119th)".
+func billLabel(bill *models.Bill) string {
+	return fmt.Sprintf("%s %s (%s)", strings.ToUpper(bill.BillType), bill.Number, ordinal(bill.Congress))
+}
+
+// ordinal formats n with its English ordinal suffix.
+func ordinal(n int) string {
+	suffix := "th"
+	switch n % 100 {
+	case 11, 12, 13:
+	default:
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// AtomFeedOutput is the response for an Atom feed
+type AtomFeedOutput struct {
+	ContentType  string `header:"Content-Type"`
+	CacheControl string `header:"Cache-Control"`
+	Body         []byte
+}
+
+// GetBillFeedInput is the request for a single bill's Atom feed
+type GetBillFeedInput struct {
+	ID uint `path:"id" minimum:"1" doc:"Bill ID"`
+}
+
+// RegisterFeedRoutes registers Atom and iCalendar feed endpoints with Huma
+func RegisterFeedRoutes(api huma.API, s *FeedService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-bills-feed",
+		Method:      http.MethodGet,
+		Path:        "/feeds/bills.atom",
+		Summary:     "Atom feed of recently changed bills",
+		Description: "Returns an Atom feed of the latest changes detected across all bills, with diff statistics for new versions",
+		Errors:      []int{http.StatusInternalServerError},
+		Tags:        []string{"Feeds"},
+		Responses:   streamedResponse("Atom feed", &huma.Schema{Type: huma.TypeString}, "application/atom+xml"),
+	}, func(ctx context.Context, input *struct{}) (*AtomFeedOutput, error) {
+		body, err := s.RecentChangesFeed(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to build feed: " + err.Error())
+		}
+		return &AtomFeedOutput{ContentType: feeds.ContentType, CacheControl: cacheControlBill, Body: body}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-bill-feed",
+		Method:      http.MethodGet,
+		Path:        "/feeds/bills/{id}.atom",
+		Summary:     "Atom feed of a bill's changes",
+		Description: "Returns an Atom feed of the latest changes detected on a single bill, with diff statistics for new versions",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Feeds"},
+		Responses:   streamedResponse("Atom feed", &huma.Schema{Type: huma.TypeString}, "application/atom+xml"),
+	}, func(ctx context.Context, input *GetBillFeedInput) (*AtomFeedOutput, error) {
+		body, err := s.BillChangesFeed(ctx, input.ID)
+		if err != nil {
+			return nil, serviceError(err, "failed to build feed")
+		}
+		return &AtomFeedOutput{ContentType: feeds.ContentType, CacheControl: cacheControlBill, Body: body}, nil
+	})
+
+	registerCalendarFeedRoute(api, s)
+}
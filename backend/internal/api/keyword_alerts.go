@@ -0,0 +1,424 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/alerts"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ErrAlertNotFound is returned when a keyword alert doesn't exist or
+// belongs to another user.
+var ErrAlertNotFound = errors.New("keyword alert not found")
+
+// ErrInvalidAlertQuery is returned when a keyword alert's query doesn't
+// parse; the parser's message is wrapped.
+var ErrInvalidAlertQuery = errors.New("invalid alert query")
+
+// KeywordAlertResponse is a keyword alert in API responses.
+type KeywordAlertResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Query     string    `json:"query" example:"broadband AND rural"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// KeywordAlertsResponse lists a user's keyword alerts.
+type KeywordAlertsResponse struct {
+	Alerts []KeywordAlertResponse `json:"alerts"`
+}
+
+// AlertSnippet is one occurrence of a query term in matched text.
+type AlertSnippet struct {
+	Term    string `json:"term" doc:"Query term that matched, as written"`
+	Text    string `json:"text" doc:"The match with surrounding text"`
+	Section string `json:"section,omitempty" doc:"Number of the section containing the match"`
+	Anchor  string `json:"anchor,omitempty" doc:"Section anchor, as in diffs"`
+	Offset  int    `json:"offset" doc:"Byte offset of the match in the version's plain text"`
+}
+
+// AlertMatchResponse is a version whose text matched a keyword alert.
+type AlertMatchResponse struct {
+	ID          uint           `json:"id"`
+	AlertID     uint           `json:"alertId"`
+	Bill        BillResponse   `json:"bill"`
+	VersionID   uint           `json:"versionId"`
+	VersionCode string         `json:"versionCode"`
+	Snippets    []AlertSnippet `json:"snippets"`
+	MatchedAt   time.Time      `json:"matchedAt"`
+}
+
+// AlertMatchList is a page of a keyword alert's matches, newest first.
+type AlertMatchList struct {
+	Matches []AlertMatchResponse `json:"matches"`
+	Total   int64                `json:"total"`
+	Limit   int                  `json:"limit"`
+	Offset  int                  `json:"offset"`
+}
+
+// KeywordAlertUpdates is a keyword alert's matches since the last check.
+type KeywordAlertUpdates struct {
+	Alert   KeywordAlertResponse `json:"alert"`
+	Matches []AlertMatchResponse `json:"matches"`
+}
+
+// ListAlerts returns a user's keyword alerts, oldest first.
+func (s *WatchlistService) ListAlerts(ctx context.Context, user *models.User) (*KeywordAlertsResponse, error) {
+	var keywordAlerts []models.KeywordAlert
+	if err := s.db.WithContext(ctx).Where("user_id = ?", user.ID).Order("id ASC").Find(&keywordAlerts).Error; err != nil {
+		return nil, fmt.Errorf("failed to list keyword alerts: %w", err)
+	}
+	resp := &KeywordAlertsResponse{Alerts: make([]KeywordAlertResponse, len(keywordAlerts))}
+	for i := range keywordAlerts {
+		resp.Alerts[i] = keywordAlertResponse(&keywordAlerts[i])
+	}
+	return resp, nil
+}
+
+// CreateAlert registers a keyword alert, which is evaluated against the
+// text of every version ingested from now on.
+func (s *WatchlistService) CreateAlert(ctx context.Context, user *models.User, name, query string) (*KeywordAlertResponse, error) {
+	if _, err := alerts.Parse(query); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAlertQuery, err)
+	}
+	alert := models.KeywordAlert{UserID: user.ID, Name: name, Query: query}
+	if err := s.db.WithContext(ctx).Create(&alert).Error; err != nil {
+		return nil, fmt.Errorf("failed to create keyword alert: %w", err)
+	}
+	resp := keywordAlertResponse(&alert)
+	return &resp, nil
+}
+
+// UpdateAlert renames a keyword alert and replaces its query. Earlier
+// matches are kept.
+func (s *WatchlistService) UpdateAlert(ctx context.Context, user *models.User, alertID uint, name, query string) (*KeywordAlertResponse, error) {
+	if _, err := alerts.Parse(query); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAlertQuery, err)
+	}
+	alert, err := s.alert(ctx, user, alertID)
+	if err != nil {
+		return nil, err
+	}
+	before := map[string]any{"name": alert.Name, "query": alert.Query}
+	alert.Name, alert.Query = name, query
+	if err := s.db.WithContext(ctx).Save(alert).Error; err != nil {
+		return nil, fmt.Errorf("failed to update keyword alert: %w", err)
+	}
+	auditChange(ctx, before, map[string]any{"name": name, "query": query})
+	resp := keywordAlertResponse(alert)
+	return &resp, nil
+}
+
+// DeleteAlert removes one of a user's keyword alerts and its matches.
+func (s *WatchlistService) DeleteAlert(ctx context.Context, user *models.User, alertID uint) error {
+	result := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", alertID, user.ID).Delete(&models.KeywordAlert{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete keyword alert: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrAlertNotFound
+	}
+	if err := s.db.WithContext(ctx).Where("alert_id = ?", alertID).Delete(&models.KeywordAlertMatch{}).Error; err != nil {
+		return fmt.Errorf("failed to delete keyword alert matches: %w", err)
+	}
+	return nil
+}
+
+// ListAlertMatches returns a page of a keyword alert's matches, newest
+// first.
+func (s *WatchlistService) ListAlertMatches(ctx context.Context, user *models.User, alertID uint, limit, offset int) (*AlertMatchList, error) {
+	if _, err := s.alert(ctx, user, alertID); err != nil {
+		return nil, err
+	}
+	query := s.db.WithContext(ctx).Model(&models.KeywordAlertMatch{}).Where("alert_id = ?", alertID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count keyword alert matches: %w", err)
+	}
+	var matches []models.KeywordAlertMatch
+	if err := query.Order("id DESC").Limit(limit).Offset(offset).Find(&matches).Error; err != nil {
+		return nil, fmt.Errorf("failed to list keyword alert matches: %w", err)
+	}
+	responses, err := s.alertMatchResponses(ctx, matches)
+	if err != nil {
+		return nil, err
+	}
+	return &AlertMatchList{Matches: responses, Total: total, Limit: limit, Offset: offset}, nil
+}
+
+// alertUpdates returns the matches of a user's keyword alerts recorded in
+// (from, to], for the watchlist updates.
+func (s *WatchlistService) alertUpdates(ctx context.Context, userID uint, from, to time.Time) ([]KeywordAlertUpdates, error) {
+	db := s.db.WithContext(ctx)
+	var keywordAlerts []models.KeywordAlert
+	if err := db.Where("user_id = ?", userID).Order("id ASC").Find(&keywordAlerts).Error; err != nil {
+		return nil, fmt.Errorf("failed to list keyword alerts: %w", err)
+	}
+
+	updates := []KeywordAlertUpdates{}
+	for i := range keywordAlerts {
+		var matches []models.KeywordAlertMatch
+		if err := db.Where("alert_id = ? AND created_at > ? AND created_at <= ?", keywordAlerts[i].ID, from, to).
+			Order("id DESC").Limit(savedSearchMatchLimit).Find(&matches).Error; err != nil {
+			return nil, fmt.Errorf("failed to list keyword alert matches: %w", err)
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		responses, err := s.alertMatchResponses(ctx, matches)
+		if err != nil {
+			return nil, err
+		}
+		updates = append(updates, KeywordAlertUpdates{Alert: keywordAlertResponse(&keywordAlerts[i]), Matches: responses})
+	}
+	return updates, nil
+}
+
+// alert returns one of a user's keyword alerts.
+func (s *WatchlistService) alert(ctx context.Context, user *models.User, alertID uint) (*models.KeywordAlert, error) {
+	var alert models.KeywordAlert
+	result := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", alertID, user.ID).Limit(1).Find(&alert)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to fetch keyword alert: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrAlertNotFound
+	}
+	return &alert, nil
+}
+
+// alertMatchResponses converts matches to their API response format,
+// loading their bills and version codes.
+func (s *WatchlistService) alertMatchResponses(ctx context.Context, matches []models.KeywordAlertMatch) ([]AlertMatchResponse, error) {
+	billIDs := make([]uint, 0, len(matches))
+	versionIDs := make([]uint, 0, len(matches))
+	for _, m := range matches {
+		billIDs = append(billIDs, m.BillID)
+		versionIDs = append(versionIDs, m.VersionID)
+	}
+
+	db := s.db.WithContext(ctx)
+	var bills []models.Bill
+	if err := db.Where("id IN ?", billIDs).Find(&bills).Error; err != nil {
+		return nil, fmt.Errorf("failed to load matched bills: %w", err)
+	}
+	billsByID := make(map[uint]*models.Bill, len(bills))
+	for i := range bills {
+		billsByID[bills[i].ID] = &bills[i]
+	}
+	var versions []models.Version
+	if err := db.Select("id", "version_code").Where("id IN ?", versionIDs).Find(&versions).Error; err != nil {
+		return nil, fmt.Errorf("failed to load matched versions: %w", err)
+	}
+	codes := make(map[uint]string, len(versions))
+	for _, v := range versions {
+		codes[v.ID] = v.VersionCode
+	}
+
+	responses := make([]AlertMatchResponse, 0, len(matches))
+	for _, m := range matches {
+		bill, ok := billsByID[m.BillID]
+		if !ok {
+			continue // Bill deleted since it matched
+		}
+		resp := AlertMatchResponse{
+			ID:          m.ID,
+			AlertID:     m.AlertID,
+			Bill:        billListResponse(bill),
+			VersionID:   m.VersionID,
+			VersionCode: codes[m.VersionID],
+			Snippets:    make([]AlertSnippet, len(m.Snippets)),
+			MatchedAt:   m.CreatedAt,
+		}
+		for i, snippet := range m.Snippets {
+			resp.Snippets[i] = AlertSnippet(snippet)
+		}
+		responses = append(responses, resp)
+	}
+	return responses, nil
+}
+
+// keywordAlertResponse converts a KeywordAlert model to its API response
+// format.
+func keywordAlertResponse(alert *models.KeywordAlert) KeywordAlertResponse {
+	return KeywordAlertResponse{
+		ID:        alert.ID,
+		Name:      alert.Name,
+		Query:     alert.Query,
+		CreatedAt: alert.CreatedAt,
+		UpdatedAt: alert.UpdatedAt,
+	}
+}
+
+// KeywordAlertBody is a keyword alert as created or updated.
+type KeywordAlertBody struct {
+	Name  string `json:"name" minLength:"1" maxLength:"100" doc:"Name of the alert"`
+	Query string `json:"query" minLength:"1" maxLength:"500" example:"\"universal service\" AND (broadband OR rural)" doc:"Words and \"quoted phrases\" combined with AND, OR, NOT, and parentheses; adjacent terms are ANDed, and a trailing * matches a word prefix"`
+}
+
+// CreateAlertInput is the request for creating a keyword alert
+type CreateAlertInput struct {
+	APIKeyInput
+	Body KeywordAlertBody
+}
+
+// UpdateAlertInput is the request for updating a keyword alert
+type UpdateAlertInput struct {
+	APIKeyInput
+	ID   uint `path:"id" minimum:"1" doc:"Keyword alert ID"`
+	Body KeywordAlertBody
+}
+
+// AlertOutput is the response for creating or updating a keyword alert
+type AlertOutput struct {
+	Status int
+	Body   KeywordAlertResponse
+}
+
+// ListAlertsOutput is the response for listing keyword alerts
+type ListAlertsOutput struct {
+	Body KeywordAlertsResponse
+}
+
+// DeleteAlertInput is the request for deleting a keyword alert
+type DeleteAlertInput struct {
+	APIKeyInput
+	ID uint `path:"id" minimum:"1" doc:"Keyword alert ID"`
+}
+
+// ListAlertMatchesInput is the request for a keyword alert's matches
+type ListAlertMatchesInput struct {
+	APIKeyInput
+	ID     uint `path:"id" minimum:"1" doc:"Keyword alert ID"`
+	Limit  int  `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"Number of matches per page (max 100)"`
+	Offset int  `query:"offset" default:"0" minimum:"0" doc:"Pagination offset"`
+}
+
+// ListAlertMatchesOutput is the response for a keyword alert's matches
+type ListAlertMatchesOutput struct {
+	Body AlertMatchList
+}
+
+// alertError converts a keyword alert service error to an HTTP error.
+func alertError(err error, action string) error {
+	switch {
+	case errors.Is(err, ErrAlertNotFound):
+		return apiError(http.StatusNotFound, CodeAlertNotFound, err.Error())
+	case errors.Is(err, ErrInvalidAlertQuery):
+		return apiError(http.StatusUnprocessableEntity, CodeInvalidQuery, err.Error())
+	}
+	return huma.Error500InternalServerError(action + ": " + err.Error())
+}
+
+// registerKeywordAlertRoutes registers the keyword alert endpoints.
+func registerKeywordAlertRoutes(api huma.API, s *WatchlistService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-keyword-alerts",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/watchlist/alerts",
+		Summary:     "List keyword alerts",
+		Description: "Returns the caller's keyword alerts",
+		Errors:      []int{http.StatusUnauthorized},
+		Tags:        []string{"Watchlist"},
+	}, func(ctx context.Context, input *APIKeyInput) (*ListAlertsOutput, error) {
+		user, err := s.Authenticate(ctx, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		resp, err := s.ListAlerts(ctx, user)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list keyword alerts: " + err.Error())
+		}
+		return &ListAlertsOutput{Body: *resp}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-keyword-alert",
+		Method:        http.MethodPost,
+		Path:          "/api/v1/watchlist/alerts",
+		Summary:       "Create a keyword alert",
+		Description:   "Registers a boolean keyword query evaluated against the text of every newly ingested version; matches, with snippets and section anchors, are reported by the alert's matches and the watchlist updates endpoints",
+		Errors:        []int{http.StatusUnauthorized, http.StatusUnprocessableEntity},
+		Tags:          []string{"Watchlist"},
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *CreateAlertInput) (*AlertOutput, error) {
+		user, err := s.Authenticate(ctx, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		alert, err := s.CreateAlert(ctx, user, input.Body.Name, input.Body.Query)
+		if err != nil {
+			return nil, alertError(err, "failed to create keyword alert")
+		}
+		auditTarget(ctx, alert.ID)
+		return &AlertOutput{Status: http.StatusCreated, Body: *alert}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-keyword-alert",
+		Method:      http.MethodPut,
+		Path:        "/api/v1/watchlist/alerts/{id}",
+		Summary:     "Update a keyword alert",
+		Description: "Renames a keyword alert and replaces its query; earlier matches are kept",
+		Errors:      []int{http.StatusUnauthorized, http.StatusNotFound, http.StatusUnprocessableEntity},
+		Tags:        []string{"Watchlist"},
+	}, func(ctx context.Context, input *UpdateAlertInput) (*AlertOutput, error) {
+		user, err := s.Authenticate(ctx, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		alert, err := s.UpdateAlert(ctx, user, input.ID, input.Body.Name, input.Body.Query)
+		if err != nil {
+			return nil, alertError(err, "failed to update keyword alert")
+		}
+		return &AlertOutput{Status: http.StatusOK, Body: *alert}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-keyword-alert",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/watchlist/alerts/{id}",
+		Summary:       "Delete a keyword alert",
+		Description:   "Deletes a keyword alert and its matches",
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound},
+		Tags:          []string{"Watchlist"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *DeleteAlertInput) (*struct{}, error) {
+		user, err := s.Authenticate(ctx, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		if err := s.DeleteAlert(ctx, user, input.ID); err != nil {
+			return nil, alertError(err, "failed to delete keyword alert")
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "list-keyword-alert-matches",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/watchlist/alerts/{id}/matches",
+		Summary:     "List keyword alert matches",
+		Description: "Returns the versions whose text matched a keyword alert, newest first, with the matching snippets and the anchors of their sections",
+		Errors:      []int{http.StatusUnauthorized, http.StatusNotFound},
+		Tags:        []string{"Watchlist"},
+	}, func(ctx context.Context, input *ListAlertMatchesInput) (*ListAlertMatchesOutput, error) {
+		user, err := s.Authenticate(ctx, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		matches, err := s.ListAlertMatches(ctx, user, input.ID, input.Limit, input.Offset)
+		if err != nil {
+			return nil, alertError(err, "failed to list keyword alert matches")
+		}
+		return &ListAlertMatchesOutput{Body: *matches}, nil
+	})
+}
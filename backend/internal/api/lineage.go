@@ -0,0 +1,301 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// Errors returned by the lineage endpoints.
+var (
+	ErrNoPredecessor = errors.New("bill has no linked predecessor in the previous Congress")
+	ErrNoVersions    = errors.New("bill has no text versions")
+)
+
+// maxLineageChain bounds the Congresses a lineage chain is followed
+// through in each direction.
+const maxLineageChain = 20
+
+// LineageLink is a bill linked to another as its predecessor or successor.
+type LineageLink struct {
+	Bill            BillResponse `json:"bill"`
+	Score           float64      `json:"score" doc:"How closely the bills match, from 0.6 to 1"`
+	TitleSimilarity float64      `json:"titleSimilarity" doc:"Share of title words in common, ignoring years"`
+	TextSimilarity  *float64     `json:"textSimilarity,omitempty" doc:"Share of sections with the same language; absent when either bill's sections aren't fingerprinted yet"`
+	SameSponsor     bool         `json:"sameSponsor"`
+	LatestVersionID *uint        `json:"latestVersionId,omitempty" doc:"The linked bill's latest text version"`
+}
+
+// LineageResponse is a bill's place in a line of bills reintroduced from
+// Congress to Congress.
+type LineageResponse struct {
+	BillID       uint           `json:"billId"`
+	Predecessors []LineageLink  `json:"predecessors" doc:"Bills of the previous Congress this bill continues, best match first"`
+	Successors   []LineageLink  `json:"successors" doc:"Bills of the next Congress continuing this one, best match first"`
+	Chain        []BillResponse `json:"chain" doc:"The line of bills through each Congress's best match, oldest first, this bill included"`
+}
+
+// GetLineage returns a bill's predecessors, successors, and the chain of
+// best matches through every Congress, as linked by the ingestor's
+// lineage matching.
+func (s *BillService) GetLineage(ctx context.Context, billID uint) (*LineageResponse, error) {
+	if err := s.requireBill(ctx, billID); err != nil {
+		return nil, err
+	}
+	predecessors, err := s.lineageLinks(ctx, billID, false)
+	if err != nil {
+		return nil, err
+	}
+	successors, err := s.lineageLinks(ctx, billID, true)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LineageResponse{
+		BillID:       billID,
+		Predecessors: make([]LineageLink, 0, len(predecessors)),
+		Successors:   make([]LineageLink, 0, len(successors)),
+	}
+	for _, links := range []struct {
+		rows []lineageRow
+		out  *[]LineageLink
+	}{{predecessors, &response.Predecessors}, {successors, &response.Successors}} {
+		for _, row := range links.rows {
+			link := LineageLink{
+				Bill:            billListResponse(&row.bill),
+				Score:           row.link.Score,
+				TitleSimilarity: row.link.TitleSimilarity,
+				TextSimilarity:  row.link.TextSimilarity,
+				SameSponsor:     row.link.SameSponsor,
+			}
+			if id, err := s.latestVersionID(ctx, row.bill.ID); err != nil {
+				return nil, err
+			} else if id != 0 {
+				link.LatestVersionID = &id
+			}
+			*links.out = append(*links.out, link)
+		}
+	}
+
+	chain, err := s.lineageChain(ctx, billID)
+	if err != nil {
+		return nil, err
+	}
+	response.Chain = chain
+	return response, nil
+}
+
+// lineageRow is a lineage link with the bill at its other end.
+type lineageRow struct {
+	link models.BillLineage
+	bill models.Bill
+}
+
+// lineageLinks returns a bill's links to its predecessors, or to its
+// successors, with the visible bills at their other end, best match
+// first.
+func (s *BillService) lineageLinks(ctx context.Context, billID uint, successors bool) ([]lineageRow, error) {
+	db := s.db.WithContext(ctx)
+	column := "bill_id"
+	if successors {
+		column = "predecessor_id"
+	}
+	var links []models.BillLineage
+	if err := db.Where(column+" = ?", billID).Order("score DESC, id ASC").Find(&links).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch lineage: %w", err)
+	}
+	if len(links) == 0 {
+		return nil, nil
+	}
+	ids := make([]uint, len(links))
+	for i, l := range links {
+		ids[i] = l.PredecessorID
+		if successors {
+			ids[i] = l.BillID
+		}
+	}
+	var bills []models.Bill
+	if err := db.Scopes(visibleBills(ctx), s.scope.Query).Where("bills.id IN ?", ids).Find(&bills).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch bills: %w", err)
+	}
+	byID := make(map[uint]models.Bill, len(bills))
+	for _, b := range bills {
+		byID[b.ID] = b
+	}
+	rows := make([]lineageRow, 0, len(links))
+	for i, l := range links {
+		if bill, ok := byID[ids[i]]; ok {
+			rows = append(rows, lineageRow{link: l, bill: bill})
+		}
+	}
+	return rows, nil
+}
+
+// lineageChain follows a bill's best predecessor back and its best
+// successor forward, returning the bills oldest first.
+func (s *BillService) lineageChain(ctx context.Context, billID uint) ([]BillResponse, error) {
+	var bill models.Bill
+	if err := s.db.WithContext(ctx).First(&bill, billID).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch bill: %w", err)
+	}
+	seen := map[uint]bool{billID: true}
+	var back, forward []BillResponse
+	for _, dir := range []struct {
+		successors bool
+		out        *[]BillResponse
+	}{{false, &back}, {true, &forward}} {
+		id := billID
+		for range maxLineageChain {
+			rows, err := s.lineageLinks(ctx, id, dir.successors)
+			if err != nil {
+				return nil, err
+			}
+			if len(rows) == 0 || seen[rows[0].bill.ID] {
+				break
+			}
+			next := rows[0].bill
+			seen[next.ID] = true
+			*dir.out = append(*dir.out, billListResponse(&next))
+			id = next.ID
+		}
+	}
+
+	chain := make([]BillResponse, 0, len(back)+1+len(forward))
+	for i := len(back) - 1; i >= 0; i-- {
+		chain = append(chain, back[i])
+	}
+	chain = append(chain, billListResponse(&bill))
+	return append(chain, forward...), nil
+}
+
+// LineageDiff diffs a bill's text against the latest text of a
+// predecessor, the last Congress's final version: what changed when the
+// bill was reintroduced. predecessorID 0 selects the best-matching
+// predecessor, and toVersionID 0 the bill's first version. It returns
+// ErrNoPredecessor when the predecessor isn't linked to the bill,
+// ErrNoVersions when either has no text, and ErrVersionMismatch when
+// toVersionID isn't the bill's.
+func (s *BillService) LineageDiff(ctx context.Context, billID, predecessorID, toVersionID uint, opts diff_engine.Options) (*DiffResponse, error) {
+	if err := s.requireBill(ctx, billID); err != nil {
+		return nil, err
+	}
+	links, err := s.lineageLinks(ctx, billID, false)
+	if err != nil {
+		return nil, err
+	}
+	var predecessor *models.Bill
+	for i := range links {
+		if predecessorID == 0 || links[i].bill.ID == predecessorID {
+			predecessor = &links[i].bill
+			break
+		}
+	}
+	if predecessor == nil {
+		return nil, ErrNoPredecessor
+	}
+
+	db := s.db.WithContext(ctx)
+	var from, to models.Version
+	if err := db.Select(versionStatsColumns).Where("bill_id = ?", predecessor.ID).
+		Order("fetched_at DESC, id DESC").Limit(1).Find(&from).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch predecessor's version: %w", err)
+	}
+	if toVersionID != 0 {
+		if err := db.Select(versionStatsColumns).First(&to, toVersionID).Error; err != nil {
+			return nil, versionLookupError(err)
+		}
+		if to.BillID != billID {
+			return nil, ErrVersionMismatch
+		}
+	} else if err := db.Select(versionStatsColumns).Where("bill_id = ?", billID).
+		Order("fetched_at ASC, id ASC").Limit(1).Find(&to).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch version: %w", err)
+	}
+	if from.ID == 0 || to.ID == 0 {
+		return nil, ErrNoVersions
+	}
+
+	var bill models.Bill
+	if err := db.Select("id", "congress", "bill_type", "number", "bill_number").First(&bill, billID).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch bill: %w", err)
+	}
+	response, err := s.diffVersions(ctx, &from, &to, opts)
+	if err != nil {
+		return nil, err
+	}
+	response.FromVersion = billLabel(predecessor) + " " + from.VersionCode
+	response.ToVersion = billLabel(&bill) + " " + to.VersionCode
+	return response, nil
+}
+
+// LineageInput is the request for a bill's lineage
+type LineageInput struct {
+	ID uint `path:"id" minimum:"1" doc:"Bill ID"`
+}
+
+// LineageOutput is the response for a bill's lineage
+type LineageOutput struct {
+	Body LineageResponse
+}
+
+// LineageDiffInput is the request for a diff against a bill's
+// predecessor
+type LineageDiffInput struct {
+	ConditionalInput
+	DiffOptionsInput
+	DiffWindowInput
+	BillID        uint   `path:"billId" minimum:"1" doc:"Bill ID"`
+	PredecessorID uint   `query:"predecessorId" doc:"Predecessor to diff against, one of the bill's lineage predecessors. Default: the best match"`
+	ToVersion     uint   `query:"toVersion" doc:"The bill's version to diff. Default: its first version, as reintroduced"`
+	View          string `query:"view" enum:"unified,split" default:"unified" doc:"unified returns interleaved lines; split returns aligned left/right rows"`
+}
+
+// registerLineageRoutes registers a bill's lineage and the diff against
+// its predecessor.
+func registerLineageRoutes(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-bill-lineage",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{id}/lineage",
+		Summary:     "Get a bill's lineage across Congresses",
+		Description: "Returns the bills of the previous Congress a bill reintroduces and those of the next Congress that reintroduce it, matched by the ingestor on title, sponsor, and the share of sections with the same language, and the chain of best matches through every Congress.",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *LineageInput) (*LineageOutput, error) {
+		lineage, err := s.GetLineage(ctx, input.ID)
+		if err != nil {
+			return nil, serviceError(err, "failed to get lineage")
+		}
+		return &LineageOutput{Body: *lineage}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "compute-lineage-diff",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{billId}/lineage/diff",
+		Summary:     "Diff a bill against its predecessor",
+		Description: "Returns the diff from the latest text of a bill's predecessor in the previous Congress (see get-bill-lineage), where that Congress left it, to the bill's text: what changed on reintroduction. Takes the same options as compute-diff.",
+		Errors:      []int{http.StatusNotFound, http.StatusUnprocessableEntity},
+		Tags:        []string{"Diff"},
+	}, func(ctx context.Context, input *LineageDiffInput) (*ComputeDiffOutput, error) {
+		diff, err := s.LineageDiff(ctx, input.BillID, input.PredecessorID, input.ToVersion, input.Options())
+		if err != nil {
+			return nil, serviceError(err, "failed to compute diff")
+		}
+		windowDiff(diff, input.Window())
+		if input.View == DiffViewSplit {
+			toSplitView(diff)
+		}
+		headers, err := conditionalHeaders(input.ConditionalInput, diff, cacheControlDiff)
+		if err != nil {
+			return nil, err
+		}
+		return &ComputeDiffOutput{CacheHeaders: headers, Body: *diff}, nil
+	})
+}
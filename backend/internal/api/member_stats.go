@@ -0,0 +1,145 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// MemberStatsResponse is a member's record as sponsor, from aggregates
+// the ingestor refreshes periodically (see memberstats.Run).
+type MemberStatsResponse struct {
+	BioguideID     string                    `json:"bioguideId"`
+	FullName       string                    `json:"fullName"`
+	Party          string                    `json:"party"`
+	State          string                    `json:"state"`
+	BillsSponsored int                       `json:"billsSponsored"`
+	BillsEnacted   int                       `json:"billsEnacted"`         // Sponsored bills that became law or have enrolled text
+	EnactmentRate  float64                   `json:"enactmentRate"`        // BillsEnacted / BillsSponsored
+	AverageChurn   float64                   `json:"averageChurn"`         // Mean lines changed per sponsored bill between its versions, over bills with more than one version diffed
+	Congresses     []MemberCongressStatsItem `json:"congresses"`           // Newest first
+	PolicyAreas    []MemberPolicyAreaItem    `json:"policyAreas"`          // Most bills first
+	ComputedAt     *time.Time                `json:"computedAt,omitempty"` // When the aggregates were last refreshed; absent before the first refresh
+}
+
+// MemberCongressStatsItem is a member's record as sponsor in one Congress.
+type MemberCongressStatsItem struct {
+	Congress       int     `json:"congress"`
+	BillsSponsored int     `json:"billsSponsored"`
+	BillsEnacted   int     `json:"billsEnacted"`
+	EnactmentRate  float64 `json:"enactmentRate"`
+	AverageChurn   float64 `json:"averageChurn"`
+}
+
+// MemberPolicyAreaItem counts a member's sponsored bills in a policy area.
+type MemberPolicyAreaItem struct {
+	PolicyArea string  `json:"policyArea"`
+	Bills      int     `json:"bills"`
+	Share      float64 `json:"share"` // Of the member's sponsored bills with a policy area
+}
+
+// GetMemberStats returns the sponsorship aggregates of the member with the
+// given Bioguide ID.
+func (s *MemberService) GetMemberStats(ctx context.Context, bioguideID string) (*MemberStatsResponse, error) {
+	db := s.db.WithContext(ctx)
+
+	var member models.Member
+	if err := db.First(&member, "bioguide_id = ?", bioguideID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch member: %w", err)
+	}
+
+	var congresses []models.MemberCongressStats
+	if err := db.Where("bioguide_id = ?", bioguideID).Order("congress DESC").Find(&congresses).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch member stats: %w", err)
+	}
+	var areas []models.MemberPolicyArea
+	if err := db.Where("bioguide_id = ?", bioguideID).Order("bills DESC, policy_area ASC").Find(&areas).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch member policy areas: %w", err)
+	}
+
+	response := &MemberStatsResponse{
+		BioguideID:  member.BioguideID,
+		FullName:    member.FullName,
+		Party:       member.Party,
+		State:       member.State,
+		Congresses:  make([]MemberCongressStatsItem, len(congresses)),
+		PolicyAreas: make([]MemberPolicyAreaItem, len(areas)),
+	}
+	var withChanges, linesChanged int
+	for i, c := range congresses {
+		response.Congresses[i] = MemberCongressStatsItem{
+			Congress:       c.Congress,
+			BillsSponsored: c.BillsSponsored,
+			BillsEnacted:   c.BillsEnacted,
+			EnactmentRate:  ratio(c.BillsEnacted, c.BillsSponsored),
+			AverageChurn:   ratio(c.LinesChanged, c.BillsWithChanges),
+		}
+		response.BillsSponsored += c.BillsSponsored
+		response.BillsEnacted += c.BillsEnacted
+		withChanges += c.BillsWithChanges
+		linesChanged += c.LinesChanged
+		if response.ComputedAt == nil || c.ComputedAt.After(*response.ComputedAt) {
+			computedAt := c.ComputedAt
+			response.ComputedAt = &computedAt
+		}
+	}
+	response.EnactmentRate = ratio(response.BillsEnacted, response.BillsSponsored)
+	response.AverageChurn = ratio(linesChanged, withChanges)
+
+	var classified int
+	for _, a := range areas {
+		classified += a.Bills
+	}
+	for i, a := range areas {
+		response.PolicyAreas[i] = MemberPolicyAreaItem{PolicyArea: a.PolicyArea, Bills: a.Bills, Share: ratio(a.Bills, classified)}
+	}
+
+	return response, nil
+}
+
+// ratio returns n / d, or 0 when d is 0.
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
+
+// GetMemberStatsInput is the request for a member's sponsorship stats
+type GetMemberStatsInput struct {
+	ID string `path:"id" pattern:"^[A-Za-z][0-9]{6}$" doc:"Member Bioguide ID" example:"J000299"`
+}
+
+// GetMemberStatsOutput is the response for a member's sponsorship stats
+type GetMemberStatsOutput struct {
+	Body MemberStatsResponse
+}
+
+// registerMemberStatsRoute registers the member sponsorship stats endpoint.
+func registerMemberStatsRoute(api huma.API, s *MemberService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-member-stats",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/members/{id}/stats",
+		Summary:     "Get a member's sponsorship stats",
+		Description: "Returns bills sponsored and enacted per congress, the enactment rate, the average text churn of the member's bills (lines changed between consecutive versions), and the policy areas they sponsor in. Served from aggregates the ingestor refreshes each polling cycle; computedAt says when.",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Members"},
+	}, func(ctx context.Context, input *GetMemberStatsInput) (*GetMemberStatsOutput, error) {
+		stats, err := s.GetMemberStats(ctx, input.ID)
+		if err != nil {
+			return nil, serviceError(err, "failed to get member stats")
+		}
+		return &GetMemberStatsOutput{Body: *stats}, nil
+	})
+}
@@ -0,0 +1,228 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/analysis"
+	"github.com/drewjst/deltagov/internal/archive"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/textstore"
+	"github.com/drewjst/deltagov/internal/versioncode"
+)
+
+// ErrMemberNotFound is returned when no member exists for a Bioguide ID.
+var ErrMemberNotFound = errors.New("member not found")
+
+// MemberService computes member-centric analytics.
+type MemberService struct {
+	db    *gorm.DB
+	texts textstore.Store
+}
+
+// NewMemberService creates a new MemberService instance.
+func NewMemberService(db *gorm.DB) *MemberService {
+	return &MemberService{db: db}
+}
+
+// SetTextStore sets where offloaded version text is read from.
+func (s *MemberService) SetTextStore(store textstore.Store) {
+	s.texts = store
+}
+
+// MemberImpactResponse is the text-influence scorecard for a member.
+type MemberImpactResponse struct {
+	BioguideID           string             `json:"bioguideId"`
+	FullName             string             `json:"fullName"`
+	Party                string             `json:"party"`
+	State                string             `json:"state"`
+	BillsSponsored       int                `json:"billsSponsored"`
+	BillsCosponsored     int                `json:"billsCosponsored"`
+	BillsEnacted         int                `json:"billsEnacted"`         // Sponsored bills with an enrolled or public-law version
+	ProvisionsIntroduced int                `json:"provisionsIntroduced"` // Sections in the first version of enacted sponsored bills
+	ProvisionsSurvived   int                `json:"provisionsSurvived"`   // Of those, sections whose text appears in the enacted version
+	SurvivalRate         float64            `json:"survivalRate"`         // ProvisionsSurvived / ProvisionsIntroduced
+	EnactedAppropriation int64              `json:"enactedAppropriation"` // Total dollars in enacted sponsored spending bills
+	Bills                []MemberBillImpact `json:"bills"`
+}
+
+// MemberBillImpact is the per-bill breakdown of a member's scorecard.
+type MemberBillImpact struct {
+	BillID               uint   `json:"billId"`
+	Congress             int    `json:"congress"`
+	BillType             string `json:"billType"`
+	BillNumber           int    `json:"billNumber"`
+	Title                string `json:"title"`
+	Role                 string `json:"role"`
+	Enacted              bool   `json:"enacted"`
+	ProvisionsIntroduced int    `json:"provisionsIntroduced"`
+	ProvisionsSurvived   int    `json:"provisionsSurvived"`
+	EnactedAppropriation int64  `json:"enactedAppropriation"`
+}
+
+// GetMemberImpact computes the scorecard for the member with the given Bioguide ID.
+// Provision survival is measured per sponsored bill: each section of the
+// bill's first version counts as surviving if its normalized text appears
+// in the bill's enacted version.
+func (s *MemberService) GetMemberImpact(ctx context.Context, bioguideID string) (*MemberImpactResponse, error) {
+	db := s.db.WithContext(ctx)
+
+	var member models.Member
+	if err := db.First(&member, "bioguide_id = ?", bioguideID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrMemberNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch member: %w", err)
+	}
+
+	var sponsorships []models.BillSponsorship
+	if err := db.Where("bioguide_id = ?", bioguideID).Find(&sponsorships).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch sponsorships: %w", err)
+	}
+
+	response := &MemberImpactResponse{
+		BioguideID: member.BioguideID,
+		FullName:   member.FullName,
+		Party:      member.Party,
+		State:      member.State,
+		Bills:      make([]MemberBillImpact, 0, len(sponsorships)),
+	}
+
+	for _, sp := range sponsorships {
+		var bill models.Bill
+		if err := db.First(&bill, sp.BillID).Error; err != nil {
+			continue // Sponsorship for a bill that was removed
+		}
+
+		impact := MemberBillImpact{
+			BillID:     bill.ID,
+			Congress:   bill.Congress,
+			BillType:   bill.BillType,
+			BillNumber: bill.BillNumber,
+			Title:      bill.Title,
+			Role:       sp.Role,
+		}
+
+		if sp.Role == models.SponsorshipRoleCosponsor {
+			response.BillsCosponsored++
+			response.Bills = append(response.Bills, impact)
+			continue
+		}
+
+		response.BillsSponsored++
+		if err := s.scoreSponsoredBill(ctx, &bill, &impact); err != nil {
+			return nil, err
+		}
+		if impact.Enacted {
+			response.BillsEnacted++
+			response.ProvisionsIntroduced += impact.ProvisionsIntroduced
+			response.ProvisionsSurvived += impact.ProvisionsSurvived
+			response.EnactedAppropriation += impact.EnactedAppropriation
+		}
+		response.Bills = append(response.Bills, impact)
+	}
+
+	if response.ProvisionsIntroduced > 0 {
+		response.SurvivalRate = float64(response.ProvisionsSurvived) / float64(response.ProvisionsIntroduced)
+	}
+
+	return response, nil
+}
+
+// scoreSponsoredBill fills in enactment, provision survival, and dollar
+// figures for a single sponsored bill.
+func (s *MemberService) scoreSponsoredBill(ctx context.Context, bill *models.Bill, impact *MemberBillImpact) error {
+	db := s.db.WithContext(ctx)
+
+	var versions []models.Version
+	if err := db.Select("id", "version_code", "fetched_at").
+		Where("bill_id = ?", bill.ID).Order("fetched_at ASC").Find(&versions).Error; err != nil {
+		return fmt.Errorf("failed to fetch versions: %w", err)
+	}
+	if len(versions) == 0 {
+		return nil
+	}
+
+	enactedID := uint(0)
+	for _, v := range versions {
+		if versioncode.IsEnacted(v.VersionCode) {
+			enactedID = v.ID
+		}
+	}
+	if enactedID == 0 {
+		return nil
+	}
+	impact.Enacted = true
+
+	var first, enacted models.Version
+	if err := db.Select(append([]string{"id"}, archive.TextColumns...)).First(&first, versions[0].ID).Error; err != nil {
+		return fmt.Errorf("failed to fetch first version: %w", err)
+	}
+	if err := db.Select(append([]string{"id"}, archive.TextColumns...)).First(&enacted, enactedID).Error; err != nil {
+		return fmt.Errorf("failed to fetch enacted version: %w", err)
+	}
+	if err := rehydrate(&first, &enacted); err != nil {
+		return err
+	}
+	if err := loadRawText(ctx, s.texts, &first, &enacted); err != nil {
+		return err
+	}
+
+	enactedText := analysis.StripMarkup(enacted.TextContent)
+	enactedNormalized := analysis.NormalizeForComparison(enactedText)
+
+	for _, section := range analysis.SplitSections(analysis.StripMarkup(first.TextContent)) {
+		body := analysis.NormalizeForComparison(section.Body)
+		if body == "" {
+			continue
+		}
+		impact.ProvisionsIntroduced++
+		if strings.Contains(enactedNormalized, body) {
+			impact.ProvisionsSurvived++
+		}
+	}
+
+	if bill.IsSpendingBill {
+		impact.EnactedAppropriation = analysis.SumDollarAmounts(enactedText)
+	}
+
+	return nil
+}
+
+// GetMemberImpactInput is the request for a member's impact scorecard
+type GetMemberImpactInput struct {
+	ID string `path:"id" pattern:"^[A-Za-z][0-9]{6}$" doc:"Member Bioguide ID" example:"J000299"`
+}
+
+// GetMemberImpactOutput is the response for a member's impact scorecard
+type GetMemberImpactOutput struct {
+	Body MemberImpactResponse
+}
+
+// RegisterMemberRoutes registers member analytics endpoints with Huma
+func RegisterMemberRoutes(api huma.API, s *MemberService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-member-impact",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/members/{id}/impact",
+		Summary:     "Get a member's text-influence scorecard",
+		Description: "Returns sponsorship counts, how many provisions from the member's sponsored bills survived into enacted text, and total enacted appropriations",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Members"},
+	}, func(ctx context.Context, input *GetMemberImpactInput) (*GetMemberImpactOutput, error) {
+		impact, err := s.GetMemberImpact(ctx, input.ID)
+		if err != nil {
+			return nil, serviceError(err, "failed to compute member impact")
+		}
+		return &GetMemberImpactOutput{Body: *impact}, nil
+	})
+
+	// Sponsorship aggregates per congress and policy area
+	registerMemberStatsRoute(api, s)
+}
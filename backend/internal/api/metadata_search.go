@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+)
+
+// metadataParamPrefix prefixes the query parameters that filter bills by
+// their metadata, e.g., metadata.sponsors.party=R.
+const metadataParamPrefix = "metadata."
+
+// metadataPaths are the bill metadata paths searches may filter on, by
+// query parameter name without metadataParamPrefix. Paths name keys of
+// the stored Congress.gov bill detail, with [] for array elements; each is
+// matched exactly by JSONB containment, which idx_bills_metadata_gin
+// answers. Only string-valued paths are listed.
+var metadataPaths = map[string]string{
+	"policyArea":                "policyArea.name",
+	"originChamber":             "originChamber",
+	"originChamberCode":         "originChamberCode",
+	"latestAction.actionDate":   "latestAction.actionDate",
+	"latestAction.text":         "latestAction.text",
+	"sponsors.bioguideId":       "sponsors[].bioguideId",
+	"sponsors.party":            "sponsors[].party",
+	"sponsors.state":            "sponsors[].state",
+	"laws.number":               "laws[].number",
+	"laws.type":                 "laws[].type",
+	"committeeReports.citation": "committeeReports[].citation",
+}
+
+// metadataFiltersDoc documents the metadata query parameters in the
+// descriptions of the operations accepting them.
+var metadataFiltersDoc = "Bills can also be filtered by the Congress.gov bill detail stored as their metadata, " +
+	"with exact-match (case-sensitive) parameters such as metadata.sponsors.party=R. Accepted parameters: " +
+	strings.Join(metadataParamNames(), ", ") + "."
+
+// MetadataFilters reads the metadata.* query parameters of a search. It
+// is embedded in search inputs rather than declared field by field, as
+// the parameters are named by metadataPaths.
+type MetadataFilters struct {
+	filters map[string]string // Value by metadataPaths key
+}
+
+// Resolve implements huma.Resolver, rejecting metadata parameters whose
+// path isn't in metadataPaths.
+func (m *MetadataFilters) Resolve(ctx huma.Context) []error {
+	u := ctx.URL()
+	var errs []error
+	for name, values := range u.Query() {
+		key, ok := strings.CutPrefix(name, metadataParamPrefix)
+		if !ok || len(values) == 0 {
+			continue
+		}
+		if _, ok := metadataPaths[key]; !ok {
+			errs = append(errs, &huma.ErrorDetail{
+				Location: "query." + name,
+				Message:  "unknown metadata path; expected one of " + strings.Join(metadataParamNames(), ", "),
+				Value:    values[0],
+			})
+			continue
+		}
+		if m.filters == nil {
+			m.filters = map[string]string{}
+		}
+		m.filters[key] = values[0]
+	}
+	return errs
+}
+
+// metadataParamNames returns the metadata query parameters, sorted.
+func metadataParamNames() []string {
+	names := make([]string, 0, len(metadataPaths))
+	for key := range metadataPaths {
+		names = append(names, metadataParamPrefix+key)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// metadataContainment returns the JSONB document bills' metadata must
+// contain for path to equal value, e.g., {"sponsors":[{"party":"R"}]}
+// for "sponsors[].party".
+func metadataContainment(path, value string) (string, error) {
+	var doc any = value
+	keys := strings.Split(path, ".")
+	for i := len(keys) - 1; i >= 0; i-- {
+		if key, ok := strings.CutSuffix(keys[i], "[]"); ok {
+			doc = map[string]any{key: []any{doc}}
+		} else {
+			doc = map[string]any{keys[i]: doc}
+		}
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// whereMetadata returns a scope narrowing bills to those whose metadata
+// matches every filter, keyed as metadataPaths. The metadata @> ?::jsonb
+// condition is PostgreSQL-only, so searches with metadata filters fail on
+// the SQLite test database; test them against PostgreSQL.
+func whereMetadata(filters map[string]string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		keys := make([]string, 0, len(filters))
+		for key := range filters {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			path, ok := metadataPaths[key]
+			if !ok {
+				db.AddError(fmt.Errorf("unknown metadata path %q", key))
+				return db
+			}
+			doc, err := metadataContainment(path, filters[key])
+			if err != nil {
+				db.AddError(err)
+				return db
+			}
+			db = db.Where("metadata @> ?::jsonb", doc)
+		}
+		return db
+	}
+}
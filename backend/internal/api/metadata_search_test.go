@@ -0,0 +1,63 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/humatest"
+)
+
+// TestMetadataContainment verifies metadata paths become the JSONB
+// documents containing their value, with arrays for [] keys.
+func TestMetadataContainment(t *testing.T) {
+	tests := []struct {
+		path  string
+		value string
+		want  string
+	}{
+		{"sponsors[].party", "R", `{"sponsors":[{"party":"R"}]}`},
+		{"policyArea.name", "Taxation", `{"policyArea":{"name":"Taxation"}}`},
+		{"originChamber", "House", `{"originChamber":"House"}`},
+		{"latestAction.text", `Became "Public Law"`, `{"latestAction":{"text":"Became \"Public Law\""}}`},
+	}
+	for _, tt := range tests {
+		got, err := metadataContainment(tt.path, tt.value)
+		if err != nil || got != tt.want {
+			t.Errorf("metadataContainment(%q, %q) = %s, %v; want %s", tt.path, tt.value, got, err, tt.want)
+		}
+	}
+}
+
+// TestMetadataFiltersResolve verifies metadata parameters are read by
+// their metadataPaths key, other parameters are ignored, and unknown
+// metadata paths are rejected.
+func TestMetadataFiltersResolve(t *testing.T) {
+	resolve := func(query string) (*MetadataFilters, []error) {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/lex?"+query, nil)
+		ctx := humatest.NewContext(&huma.Operation{}, req, httptest.NewRecorder())
+		var m MetadataFilters
+		return &m, m.Resolve(ctx)
+	}
+
+	m, errs := resolve("metadata.sponsors.party=R&metadata.policyArea=Taxation&congress=119")
+	if len(errs) != 0 {
+		t.Fatalf("Resolve failed: %v", errs)
+	}
+	if len(m.filters) != 2 || m.filters["sponsors.party"] != "R" || m.filters["policyArea"] != "Taxation" {
+		t.Errorf("Filters = %v, want the party and policy area", m.filters)
+	}
+
+	_, errs = resolve("metadata.sponsors.party=R&metadata.sponsors.nickname=Joe")
+	if len(errs) != 1 {
+		t.Fatalf("Resolve with an unknown path: errors = %v, want one", errs)
+	}
+	if detail, ok := errs[0].(*huma.ErrorDetail); !ok || detail.Location != "query.metadata.sponsors.nickname" || detail.Value != "Joe" {
+		t.Errorf("Error = %v, want one located at the unknown parameter", errs[0])
+	}
+
+	if m, errs := resolve("sponsor=Smith"); len(errs) != 0 || m.filters != nil {
+		t.Errorf("Resolve without metadata parameters = %v, %v; want no filters", m.filters, errs)
+	}
+}
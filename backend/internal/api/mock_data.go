@@ -1,6 +1,10 @@
 package api
 
-import "time"
+import (
+	"time"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
 
 // MockBill represents bill data for API responses
 type MockBill struct {
@@ -163,8 +167,10 @@ func GetMockDelta(fromVersion, toVersion string) MockDelta {
 func GetMockHR1() BillResponse {
 	return BillResponse{
 		ID:            1,
+		Jurisdiction:  models.JurisdictionFederal,
 		Congress:      119,
 		BillNumber:    1,
+		Number:        "1",
 		BillType:      "hr",
 		Title:         "One Big Beautiful Bill Act",
 		Sponsor:       "Rep. Jason Smith (R-MO)",
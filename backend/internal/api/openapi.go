@@ -0,0 +1,209 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// NewConfig returns the Huma configuration the API is served with.
+func NewConfig() huma.Config {
+	config := huma.DefaultConfig("DeltaGov API", "1.0.0")
+	config.Info.Description = "API for tracking and comparing legislative bill versions"
+	return config
+}
+
+// Document returns the OpenAPI document of the API as served with a
+// database, with its versioned routes under prefix. Client generators
+// build from it without a running server.
+func Document(prefix string) *huma.OpenAPI {
+	humaAPI := huma.NewAPI(NewConfig(), documentAdapter{})
+	ConfigureOpenAPI(humaAPI, prefix)
+
+	// Handlers are never called, so the services need no dependencies
+	bills := NewBillService(nil, nil)
+	RegisterRoutesWithService(humaAPI, NewRouteHandler(bills))
+	RegisterMemberRoutes(humaAPI, NewMemberService(nil))
+	RegisterAdminRoutes(humaAPI, NewAdminService(nil, ""))
+	RegisterAuthRoutes(humaAPI, NewAuthService(nil, nil, nil))
+	RegisterWatchlistRoutes(humaAPI, NewWatchlistService(nil, bills))
+	RegisterTenantRoutes(humaAPI, NewTenantService(nil, bills))
+	RegisterDocumentRoutes(humaAPI, NewDocumentService(nil, bills))
+	RegisterExportRoutes(humaAPI, bills)
+	RegisterRuleRoutes(humaAPI, NewRuleService(nil))
+	RegisterExecutiveRoutes(humaAPI, NewExecutiveService(nil))
+	RegisterStatsRoutes(humaAPI, NewStatsService(nil))
+	RegisterFeedRoutes(humaAPI, NewFeedService(bills, ""))
+	RegisterEventRoutes(humaAPI, nil)
+	RegisterDiagnosticRoutes(humaAPI, NewDiagnosticService(nil, nil))
+	RegisterProbeRoutes(humaAPI, NewProbeService(nil, nil))
+	return humaAPI.OpenAPI()
+}
+
+// documentAdapter is a router that serves nothing, for building the
+// OpenAPI document alone.
+type documentAdapter struct{}
+
+func (documentAdapter) Handle(*huma.Operation, func(huma.Context)) {}
+
+func (documentAdapter) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.WriteHeader(http.StatusNotFound)
+}
+
+// VersionPrefix is the path prefix routes are registered with. Routes are
+// served under it unless ConfigureOpenAPI is given another prefix, so a
+// later /api/v2 can be registered alongside them.
+const VersionPrefix = "/api/v1"
+
+// apiTags describe the operation tags, one per subsystem, in the order
+// the documentation lists them.
+var apiTags = []*huma.Tag{
+	{Name: "Bills", Description: "Tracked bills, their text versions, and their change history"},
+	{Name: "Diff", Description: "Comparisons between versions of a bill and between its summaries"},
+	{Name: "Search", Description: "Bill search with filters and facets"},
+	{Name: "Members", Description: "Members of Congress and the bills they sponsor"},
+	{Name: "Rules", Description: "Federal Register rules and the differences between their documents"},
+	{Name: "Treaties", Description: "Treaties before the Senate and their action histories"},
+	{Name: "Nominations", Description: "Presidential nominations before the Senate and their action histories"},
+	{Name: "Stats", Description: "Dashboard aggregates, refreshed by the ingestor"},
+	{Name: "Auth", Description: "Sign-in with an identity provider and the current user"},
+	{Name: "Watchlist", Description: "Users, saved searches, and watched bills, authenticated with an API key or session token"},
+	{Name: "Events", Description: "Live bill updates over server-sent events"},
+	{Name: "Export", Description: "Bulk exports of bills and versions"},
+	{Name: "Feeds", Description: "Atom feeds of bill changes"},
+	{Name: "Diagnostics", Description: "Health, readiness, and dependency checks"},
+	{Name: "Admin", Description: "Ingestion, delta cache, and user operations, authenticated with ADMIN_TOKEN or an admin's API key"},
+}
+
+// ConfigureOpenAPI documents the API's tags and adds an example body to
+// every documented error response. Routes registered under VersionPrefix
+// are served and documented under prefix instead. It must be called
+// before any route is registered.
+func ConfigureOpenAPI(api huma.API, prefix string) {
+	oapi := api.OpenAPI()
+	oapi.Tags = append(oapi.Tags, apiTags...)
+	oapi.OnAddOperation = append(oapi.OnAddOperation, addErrorExamples)
+
+	prefix = strings.TrimSuffix(prefix, "/")
+	if prefix != "" && prefix != VersionPrefix {
+		oapi.OnAddOperation = append(oapi.OnAddOperation, func(oapi *huma.OpenAPI, op *huma.Operation) {
+			movePrefix(oapi, op, prefix)
+		})
+	}
+}
+
+// streamedResponse documents the 200 response of an operation that writes
+// its own body, such as a stream or a feed, which Huma can't infer: each
+// of contentTypes with schema, or with none for an opaque body.
+func streamedResponse(description string, schema *huma.Schema, contentTypes ...string) map[string]*huma.Response {
+	content := make(map[string]*huma.MediaType, len(contentTypes))
+	for _, contentType := range contentTypes {
+		content[contentType] = &huma.MediaType{Schema: schema}
+	}
+	return map[string]*huma.Response{"200": {Description: description, Content: content}}
+}
+
+// movePrefix moves an operation registered under VersionPrefix to the
+// same path under prefix. Huma registers the operation's route after
+// adding it to the OpenAPI, so the route follows.
+func movePrefix(oapi *huma.OpenAPI, op *huma.Operation, prefix string) {
+	rest, ok := strings.CutPrefix(op.Path, VersionPrefix)
+	if !ok || (rest != "" && !strings.HasPrefix(rest, "/")) {
+		return
+	}
+
+	item := oapi.Paths[op.Path]
+	if slot := operationSlot(item, op.Method); slot != nil && *slot == op {
+		*slot = nil
+		if isEmptyPathItem(item) {
+			delete(oapi.Paths, op.Path)
+		}
+	}
+
+	op.Path = prefix + rest
+	moved := oapi.Paths[op.Path]
+	if moved == nil {
+		moved = &huma.PathItem{}
+		oapi.Paths[op.Path] = moved
+	}
+	if slot := operationSlot(moved, op.Method); slot != nil {
+		*slot = op
+	}
+}
+
+// operationSlot returns the field of item holding the operation for
+// method, or nil for an unknown method or a nil item.
+func operationSlot(item *huma.PathItem, method string) **huma.Operation {
+	if item == nil {
+		return nil
+	}
+	switch method {
+	case http.MethodGet:
+		return &item.Get
+	case http.MethodPost:
+		return &item.Post
+	case http.MethodPut:
+		return &item.Put
+	case http.MethodPatch:
+		return &item.Patch
+	case http.MethodDelete:
+		return &item.Delete
+	case http.MethodHead:
+		return &item.Head
+	case http.MethodOptions:
+		return &item.Options
+	case http.MethodTrace:
+		return &item.Trace
+	}
+	return nil
+}
+
+// isEmptyPathItem reports whether item has no operations left.
+func isEmptyPathItem(item *huma.PathItem) bool {
+	return item.Get == nil && item.Post == nil && item.Put == nil && item.Patch == nil &&
+		item.Delete == nil && item.Head == nil && item.Options == nil && item.Trace == nil
+}
+
+// addErrorExamples gives each of an operation's error responses an
+// example body with the status's default code. Operations list their
+// errors in Operation.Errors; Huma adds 422 for operations with
+// parameters, and 500.
+func addErrorExamples(_ *huma.OpenAPI, op *huma.Operation) {
+	for key, resp := range op.Responses {
+		status, err := strconv.Atoi(key)
+		if err != nil || status < 400 {
+			continue
+		}
+		for _, media := range resp.Content {
+			if media.Example == nil && len(media.Examples) == 0 {
+				media.Example = errorExample(status)
+			}
+		}
+	}
+}
+
+// errorExample returns an example error body for status.
+func errorExample(status int) *ErrorModel {
+	return &ErrorModel{
+		ErrorModel: huma.ErrorModel{
+			Title:  http.StatusText(status),
+			Status: status,
+			Detail: errorExampleDetails[status],
+		},
+		Code: statusCode(status),
+	}
+}
+
+// errorExampleDetails are example messages for common error statuses.
+var errorExampleDetails = map[int]string{
+	http.StatusBadRequest:                   "invalid date",
+	http.StatusUnauthorized:                 "invalid API key",
+	http.StatusForbidden:                    "admin endpoints are disabled: ADMIN_TOKEN is not set",
+	http.StatusNotFound:                     "bill not found",
+	http.StatusConflict:                     "ingestion failure is already resolved or dismissed",
+	http.StatusRequestedRangeNotSatisfiable: "range starts past the end of the text",
+	http.StatusUnprocessableEntity:          "validation failed",
+	http.StatusInternalServerError:          "failed to fetch bill: connection refused",
+}
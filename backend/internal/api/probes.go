@@ -0,0 +1,165 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/congress"
+)
+
+// probeTimeout bounds each dependency check so a hung dependency can't
+// stall the orchestrator's probe.
+const probeTimeout = 3 * time.Second
+
+// ProbeService serves liveness and readiness probes for orchestrators.
+// Both dependencies are optional: a nil db means the API is serving mock
+// data, and a nil congress client skips the upstream check.
+type ProbeService struct {
+	db            *gorm.DB
+	congress      *congress.Client
+	checkCongress bool
+	shuttingDown  atomic.Bool
+}
+
+// NewProbeService creates a new ProbeService instance.
+func NewProbeService(db *gorm.DB, client *congress.Client) *ProbeService {
+	return &ProbeService{db: db, congress: client}
+}
+
+// SetCheckCongress enables the Congress.gov reachability check in readiness.
+// Off by default so an upstream outage doesn't pull every replica out of
+// rotation while cached data can still be served.
+func (s *ProbeService) SetCheckCongress(enabled bool) {
+	s.checkCongress = enabled
+}
+
+// MarkShuttingDown makes readiness fail so load balancers stop routing new
+// requests while in-flight ones drain.
+func (s *ProbeService) MarkShuttingDown() {
+	s.shuttingDown.Store(true)
+}
+
+// ProbeCheck is the result of a single dependency check.
+type ProbeCheck struct {
+	Status    string `json:"status"` // "ok", "failed", or "skipped"
+	LatencyMs int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ReadinessReport is the response body for the readiness probe.
+type ReadinessReport struct {
+	Status string                `json:"status"` // "ready" or "not_ready"
+	Checks map[string]ProbeCheck `json:"checks"`
+}
+
+// Ready runs all enabled dependency checks and reports whether the server
+// can accept traffic.
+func (s *ProbeService) Ready(ctx context.Context) *ReadinessReport {
+	report := &ReadinessReport{
+		Status: "ready",
+		Checks: make(map[string]ProbeCheck, 3),
+	}
+
+	if s.shuttingDown.Load() {
+		report.Status = "not_ready"
+		report.Checks["server"] = ProbeCheck{Status: "failed", Error: "shutting down"}
+		return report
+	}
+
+	if s.db != nil {
+		report.Checks["database"] = runProbe(ctx, s.pingDatabase)
+	} else {
+		report.Checks["database"] = ProbeCheck{Status: "skipped"}
+	}
+
+	if s.congress != nil && s.checkCongress {
+		report.Checks["congress"] = runProbe(ctx, s.congress.Ping)
+	} else {
+		report.Checks["congress"] = ProbeCheck{Status: "skipped"}
+	}
+
+	for _, check := range report.Checks {
+		if check.Status == "failed" {
+			report.Status = "not_ready"
+		}
+	}
+
+	return report
+}
+
+// pingDatabase verifies the connection pool can reach PostgreSQL.
+func (s *ProbeService) pingDatabase(ctx context.Context) error {
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// runProbe executes check with probeTimeout and records its outcome.
+func runProbe(ctx context.Context, check func(context.Context) error) ProbeCheck {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check(ctx)
+	result := ProbeCheck{Status: "ok", LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		result.Status = "failed"
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// LivenessOutput is the response for the liveness probe
+type LivenessOutput struct {
+	Body struct {
+		Status string `json:"status"`
+	}
+}
+
+// ReadinessOutput is the response for the readiness probe
+type ReadinessOutput struct {
+	Status int
+	Body   ReadinessReport
+}
+
+// RegisterProbeRoutes registers liveness and readiness probes with Huma
+func RegisterProbeRoutes(api huma.API, s *ProbeService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-liveness",
+		Method:      http.MethodGet,
+		Path:        "/healthz",
+		Summary:     "Liveness probe",
+		Description: "Returns 200 while the process is running. Does not check dependencies.",
+		Tags:        []string{"Diagnostics"},
+	}, func(ctx context.Context, input *struct{}) (*LivenessOutput, error) {
+		resp := &LivenessOutput{}
+		resp.Body.Status = "ok"
+		return resp, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-readiness",
+		Method:      http.MethodGet,
+		Path:        "/readyz",
+		Summary:     "Readiness probe",
+		Description: "Returns 200 when the database (and optionally Congress.gov) is reachable, 503 otherwise or while shutting down",
+		Tags:        []string{"Diagnostics"},
+		Responses: map[string]*huma.Response{
+			"503": {Description: "Not ready"},
+		},
+	}, func(ctx context.Context, input *struct{}) (*ReadinessOutput, error) {
+		report := s.Ready(ctx)
+		status := http.StatusOK
+		if report.Status != "ready" {
+			status = http.StatusServiceUnavailable
+		}
+		return &ReadinessOutput{Status: status, Body: *report}, nil
+	})
+}
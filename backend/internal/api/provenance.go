@@ -0,0 +1,193 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/provenance"
+)
+
+// ErrNoProvenance is returned when a version has no provenance record:
+// it was stored before provenance was recorded, or uploaded rather than
+// fetched.
+var ErrNoProvenance = errors.New("no provenance recorded for this version")
+
+// ProvenanceResponse is where a version's text was retrieved from and
+// what the source served.
+type ProvenanceResponse struct {
+	VersionID     uint      `json:"versionId" example:"108"`
+	SourceURL     string    `json:"sourceUrl" example:"https://www.congress.gov/119/bills/hr1/BILLS-119hr1eh.htm"`
+	FinalURL      string    `json:"finalUrl,omitempty" doc:"URL that answered, when the source redirected"`
+	LastModified  string    `json:"lastModified,omitempty" example:"Thu, 22 May 2025 14:03:11 GMT"`
+	ETag          string    `json:"etag,omitempty"`
+	ContentType   string    `json:"contentType,omitempty" example:"text/html"`
+	ContentLength int       `json:"contentLength" doc:"Bytes retrieved"`
+	ContentHash   string    `json:"contentHash" doc:"SHA-256 of the bytes retrieved, before any processing"`
+	RetrievedAt   time.Time `json:"retrievedAt"`
+}
+
+// SourceCheck is the result of re-fetching a version's source.
+type SourceCheck struct {
+	Status       int       `json:"status" example:"200" doc:"HTTP status the source answered with"`
+	ContentHash  string    `json:"contentHash,omitempty" doc:"SHA-256 of the bytes served now; absent unless status is 200"`
+	LastModified string    `json:"lastModified,omitempty"`
+	ETag         string    `json:"etag,omitempty"`
+	Matches      bool      `json:"matches" doc:"The source serves the recorded bytes"`
+	CheckedAt    time.Time `json:"checkedAt"`
+}
+
+// ProvenanceVerification compares a version's stored text, and what its
+// source serves now, with what was retrieved.
+type ProvenanceVerification struct {
+	Provenance    ProvenanceResponse `json:"provenance"`
+	StoredHash    string             `json:"storedHash" doc:"SHA-256 of the raw text stored now"`
+	StoredMatches bool               `json:"storedMatches" doc:"The stored text is the text retrieved"`
+	Source        *SourceCheck       `json:"source,omitempty" doc:"Absent when the source couldn't be reached"`
+	SourceError   string             `json:"sourceError,omitempty"`
+	Verified      bool               `json:"verified" doc:"Both the stored text and the source match the recorded hash"`
+}
+
+// GetProvenance returns a version's provenance record.
+func (s *BillService) GetProvenance(ctx context.Context, versionID uint) (*ProvenanceResponse, error) {
+	record, err := s.provenance(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+	resp := provenanceToResponse(record)
+	return &resp, nil
+}
+
+// VerifyProvenance hashes a version's stored raw text and re-fetches its
+// source, comparing both with the hash recorded when it was retrieved. A
+// source that can't be reached is reported in the result, not as an
+// error, so the stored text is still checked.
+func (s *BillService) VerifyProvenance(ctx context.Context, versionID uint) (*ProvenanceVerification, error) {
+	record, err := s.provenance(ctx, versionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var version models.Version
+	if err := s.db.WithContext(ctx).First(&version, versionID).Error; err != nil {
+		return nil, versionLookupError(err)
+	}
+	if err := rehydrate(&version); err != nil {
+		return nil, err
+	}
+	if err := loadRawText(ctx, s.texts, &version); err != nil {
+		return nil, err
+	}
+
+	result := &ProvenanceVerification{
+		Provenance: provenanceToResponse(record),
+		StoredHash: provenance.Hash([]byte(version.TextContent)),
+	}
+	result.StoredMatches = result.StoredHash == record.ContentHash
+
+	check, err := provenance.Verify(ctx, nil, record)
+	if err != nil {
+		result.SourceError = err.Error()
+		return result, nil
+	}
+	result.Source = &SourceCheck{
+		Status:       check.Status,
+		ContentHash:  check.ContentHash,
+		LastModified: check.LastModified,
+		ETag:         check.ETag,
+		Matches:      check.Matches,
+		CheckedAt:    check.CheckedAt,
+	}
+	result.Verified = result.StoredMatches && check.Matches
+	return result, nil
+}
+
+// provenance returns a version's provenance record, or ErrVersionNotFound
+// or ErrNoProvenance.
+func (s *BillService) provenance(ctx context.Context, versionID uint) (*models.VersionProvenance, error) {
+	var version models.Version
+	if err := s.db.WithContext(ctx).Select("id").First(&version, versionID).Error; err != nil {
+		return nil, versionLookupError(err)
+	}
+	var record models.VersionProvenance
+	if err := s.db.WithContext(ctx).Where("version_id = ?", versionID).First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrNoProvenance
+		}
+		return nil, fmt.Errorf("failed to fetch provenance: %w", err)
+	}
+	return &record, nil
+}
+
+// provenanceToResponse converts a provenance record to its API response
+// format.
+func provenanceToResponse(p *models.VersionProvenance) ProvenanceResponse {
+	return ProvenanceResponse{
+		VersionID:     p.VersionID,
+		SourceURL:     p.SourceURL,
+		FinalURL:      p.FinalURL,
+		LastModified:  p.LastModified,
+		ETag:          p.ETag,
+		ContentType:   p.ContentType,
+		ContentLength: p.ContentLength,
+		ContentHash:   p.ContentHash,
+		RetrievedAt:   p.RetrievedAt,
+	}
+}
+
+// VersionProvenanceInput is the request for a version's provenance
+type VersionProvenanceInput struct {
+	ID uint `path:"id" minimum:"1" doc:"Version ID"`
+}
+
+// GetProvenanceOutput is the response for a version's provenance
+type GetProvenanceOutput struct {
+	Body ProvenanceResponse
+}
+
+// VerifyProvenanceOutput is the response for verifying a version's
+// provenance
+type VerifyProvenanceOutput struct {
+	Body ProvenanceVerification
+}
+
+// registerProvenanceRoutes registers the version provenance endpoints.
+func registerProvenanceRoutes(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-version-provenance",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/versions/{id}/provenance",
+		Summary:     "Get a version's provenance",
+		Description: "Returns where a version's text was retrieved from: the source URL, its Last-Modified and ETag headers, when it was retrieved, and the SHA-256 of the bytes retrieved.",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *VersionProvenanceInput) (*GetProvenanceOutput, error) {
+		record, err := s.GetProvenance(ctx, input.ID)
+		if err != nil {
+			return nil, serviceError(err, "failed to get provenance")
+		}
+		return &GetProvenanceOutput{Body: *record}, nil
+	})
+
+	huma.Register(api, notAudited(huma.Operation{
+		OperationID: "verify-version-provenance",
+		Method:      http.MethodPost,
+		Path:        "/api/v1/versions/{id}/provenance/verify",
+		Summary:     "Verify a version's provenance",
+		Description: "Hashes the version's stored raw text and re-fetches its source URL, comparing both with the hash recorded when it was retrieved. verified is true when the stored text is unchanged and the source still serves the same bytes; a source revised since retrieval shows a different hash and, usually, a newer Last-Modified.",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Bills"},
+	}), func(ctx context.Context, input *VersionProvenanceInput) (*VerifyProvenanceOutput, error) {
+		result, err := s.VerifyProvenance(ctx, input.ID)
+		if err != nil {
+			return nil, serviceError(err, "failed to verify provenance")
+		}
+		return &VerifyProvenanceOutput{Body: *result}, nil
+	})
+}
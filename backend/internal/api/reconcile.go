@@ -0,0 +1,165 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/deltas"
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/versioncode"
+)
+
+// ErrNoChamberVersions is returned when reconciliation defaults can't find
+// both a House and a Senate version of the bill.
+var ErrNoChamberVersions = errors.New("bill has no House and Senate versions to reconcile")
+
+// ReconcileResponse reports, section by section, whether a base version
+// followed the House or the Senate version of a bill.
+type ReconcileResponse struct {
+	BillID          uint               `json:"billId"`
+	BaseVersionID   uint               `json:"baseVersionId"`
+	BaseVersion     string             `json:"baseVersion"`
+	HouseVersionID  uint               `json:"houseVersionId"`
+	HouseVersion    string             `json:"houseVersion"`
+	SenateVersionID uint               `json:"senateVersionId"`
+	SenateVersion   string             `json:"senateVersion"`
+	Both            int                `json:"both"`
+	House           int                `json:"house"`
+	Senate          int                `json:"senate"`
+	Neither         int                `json:"neither"`
+	Dropped         int                `json:"dropped"`
+	Sections        []ReconcileSection `json:"sections"`
+}
+
+// ReconcileSection is one section's outcome; see diff_engine.ThreeWaySection.
+type ReconcileSection struct {
+	Section       string `json:"section"`
+	Heading       string `json:"heading"`
+	Anchor        string `json:"anchor"`
+	Followed      string `json:"followed"`      // "both", "house", "senate", "neither", or "dropped"
+	HouseChanges  int    `json:"houseChanges"`  // Lines changed from the House section; -1 when the House version lacks it
+	SenateChanges int    `json:"senateChanges"` // Lines changed from the Senate section; -1 when the Senate version lacks it
+}
+
+// Reconcile compares a base version of a bill, typically the conference or
+// enrolled text, with a House and a Senate version. A zero ID defaults to
+// the bill's most recent version for the base, and to its most recent
+// version from that chamber, other than the base, for the House and Senate.
+func (s *BillService) Reconcile(ctx context.Context, billID, baseID, houseID, senateID uint) (*ReconcileResponse, error) {
+	if baseID == 0 || houseID == 0 || senateID == 0 {
+		if err := s.requireBill(ctx, billID); err != nil {
+			return nil, err
+		}
+		versions, err := s.versionsInOrder(ctx, billID)
+		if err != nil {
+			return nil, err
+		}
+		if baseID == 0 && len(versions) > 0 {
+			baseID = versions[len(versions)-1].ID
+		}
+		for i := len(versions) - 1; i >= 0; i-- {
+			v := versions[i]
+			if v.ID == baseID {
+				continue
+			}
+			switch versioncode.Chamber(v.VersionCode) {
+			case versioncode.ChamberHouse:
+				if houseID == 0 {
+					houseID = v.ID
+				}
+			case versioncode.ChamberSenate:
+				if senateID == 0 {
+					senateID = v.ID
+				}
+			}
+		}
+		if baseID == 0 || houseID == 0 || senateID == 0 {
+			return nil, ErrNoChamberVersions
+		}
+	}
+
+	var base, house, senate models.Version
+	if err := s.loadBillVersions(ctx, billID, houseID, senateID, &house, &senate); err != nil {
+		return nil, err
+	}
+	if err := s.db.WithContext(ctx).First(&base, baseID).Error; err != nil {
+		return nil, versionLookupError(err)
+	}
+	if base.BillID != billID {
+		return nil, ErrVersionMismatch
+	}
+	if err := rehydrate(&base, &house, &senate); err != nil {
+		return nil, err
+	}
+	if deltas.TooLarge(&base, &house, &senate) {
+		return nil, ErrDiffTooLarge
+	}
+
+	threeWay, err := diff_engine.ComputeThreeWay(deltas.Text(&base), deltas.Text(&house), deltas.Text(&senate))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReconcileResponse{
+		BillID:          billID,
+		BaseVersionID:   base.ID,
+		BaseVersion:     base.VersionCode,
+		HouseVersionID:  house.ID,
+		HouseVersion:    house.VersionCode,
+		SenateVersionID: senate.ID,
+		SenateVersion:   senate.VersionCode,
+		Both:            threeWay.Both,
+		House:           threeWay.House,
+		Senate:          threeWay.Senate,
+		Neither:         threeWay.Neither,
+		Dropped:         threeWay.Dropped,
+		Sections:        make([]ReconcileSection, len(threeWay.Sections)),
+	}
+	for i, sec := range threeWay.Sections {
+		response.Sections[i] = ReconcileSection{
+			Section:       sec.Section,
+			Heading:       sec.Heading,
+			Anchor:        sec.Anchor,
+			Followed:      string(sec.Followed),
+			HouseChanges:  sec.HouseChanges,
+			SenateChanges: sec.SenateChanges,
+		}
+	}
+	return response, nil
+}
+
+// ReconcileInput is the request for reconciling a bill's versions.
+type ReconcileInput struct {
+	ID     uint `path:"id" minimum:"1" doc:"Bill ID"`
+	Base   uint `query:"base" doc:"Version ID of the reconciled text, e.g., the conference or enrolled version (default: most recent version)"`
+	House  uint `query:"house" doc:"Version ID of the House text (default: most recent House version)"`
+	Senate uint `query:"senate" doc:"Version ID of the Senate text (default: most recent Senate version)"`
+}
+
+// ReconcileOutput is the response for reconciling a bill's versions.
+type ReconcileOutput struct {
+	Body ReconcileResponse
+}
+
+// registerReconcileRoute registers the three-way reconciliation endpoint.
+func registerReconcileRoute(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "reconcile-bill-versions",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{id}/reconcile",
+		Summary:     "Compare a bill's final text with its House and Senate versions",
+		Description: "Matches sections by number across a base version (typically conference or enrolled text) and the House and Senate versions, and reports for each whether the base followed the House, the Senate, both, or neither, or dropped the section.",
+		Errors:      []int{http.StatusNotFound, http.StatusUnprocessableEntity},
+		Tags:        []string{"Diff"},
+	}, func(ctx context.Context, input *ReconcileInput) (*ReconcileOutput, error) {
+		result, err := s.Reconcile(ctx, input.ID, input.Base, input.House, input.Senate)
+		if err != nil {
+			return nil, serviceError(err, "failed to reconcile versions")
+		}
+		return &ReconcileOutput{Body: *result}, nil
+	})
+}
@@ -0,0 +1,110 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// RelatedBillResponse is a bill related to another bill. BillID and
+// LatestVersionID are set when the related bill has been ingested, so its
+// text can be compared with the diff endpoint.
+type RelatedBillResponse struct {
+	Congress        int      `json:"congress"`
+	BillType        string   `json:"billType"`
+	BillNumber      int      `json:"billNumber"`
+	Title           string   `json:"title"`
+	Relationships   []string `json:"relationships"` // e.g., "Identical bill (House)"
+	IsCompanion     bool     `json:"isCompanion"`
+	BillID          *uint    `json:"billId,omitempty"`
+	LatestVersionID *uint    `json:"latestVersionId,omitempty"`
+}
+
+// RelatedBillsResponse lists a bill's related bills, companions first.
+type RelatedBillsResponse struct {
+	BillID          uint                  `json:"billId"`
+	LatestVersionID *uint                 `json:"latestVersionId,omitempty"`
+	Related         []RelatedBillResponse `json:"related"`
+}
+
+// GetRelatedBills returns the bills related to a bill, resolving those that
+// have been ingested to their IDs and latest versions.
+func (s *BillService) GetRelatedBills(ctx context.Context, billID uint) (*RelatedBillsResponse, error) {
+	db := s.db.WithContext(ctx)
+
+	var bill models.Bill
+	if err := db.Select("id").First(&bill, billID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBillNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch bill: %w", err)
+	}
+
+	var rows []models.RelatedBill
+	if err := db.Where("bill_id = ?", billID).
+		Order("is_companion DESC, related_congress DESC, related_type ASC, related_number ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch related bills: %w", err)
+	}
+
+	response := &RelatedBillsResponse{
+		BillID:  billID,
+		Related: make([]RelatedBillResponse, len(rows)),
+	}
+	if id, err := s.latestVersionID(ctx, billID); err != nil {
+		return nil, err
+	} else if id != 0 {
+		response.LatestVersionID = &id
+	}
+
+	for i, r := range rows {
+		related := RelatedBillResponse{
+			Congress:      r.RelatedCongress,
+			BillType:      r.RelatedType,
+			BillNumber:    r.RelatedNumber,
+			Title:         r.Title,
+			Relationships: r.Relationships,
+			IsCompanion:   r.IsCompanion,
+		}
+		if related.Relationships == nil {
+			related.Relationships = []string{}
+		}
+
+		var tracked models.Bill
+		err := db.Select("id").
+			Where("congress = ? AND LOWER(bill_type) = ? AND number = ?",
+				r.RelatedCongress, strings.ToLower(r.RelatedType), strconv.Itoa(r.RelatedNumber)).
+			Limit(1).Find(&tracked).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve related bill: %w", err)
+		}
+		if tracked.ID != 0 {
+			related.BillID = &tracked.ID
+			if id, err := s.latestVersionID(ctx, tracked.ID); err != nil {
+				return nil, err
+			} else if id != 0 {
+				related.LatestVersionID = &id
+			}
+		}
+
+		response.Related[i] = related
+	}
+
+	return response, nil
+}
+
+// latestVersionID returns the ID of a bill's most recent version, or 0.
+func (s *BillService) latestVersionID(ctx context.Context, billID uint) (uint, error) {
+	var version models.Version
+	if err := s.db.WithContext(ctx).Select("id").Where("bill_id = ?", billID).
+		Order("fetched_at DESC, id DESC").Limit(1).Find(&version).Error; err != nil {
+		return 0, fmt.Errorf("failed to fetch latest version: %w", err)
+	}
+	return version.ID, nil
+}
@@ -0,0 +1,125 @@
+package api
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ErrInsufficientRole is returned when an API key's role doesn't allow an
+// operation.
+var ErrInsufficientRole = errors.New("API key's role does not allow this operation")
+
+// Security schemes naming users' API keys and session tokens in the
+// OpenAPI document.
+const (
+	apiKeySecurityScheme  = "apiKey"
+	sessionSecurityScheme = "session"
+)
+
+// roleMetadataKey is the operation metadata entry naming the role an
+// operation requires.
+const roleMetadataKey = "role"
+
+// requireRole marks an operation as requiring an API key whose user has at
+// least role. The Authorization middleware enforces it; admin operations
+// also accept the admin token.
+func requireRole(api huma.API, op huma.Operation, role string) huma.Operation {
+	components := api.OpenAPI().Components
+	if components.SecuritySchemes == nil {
+		components.SecuritySchemes = map[string]*huma.SecurityScheme{}
+	}
+	components.SecuritySchemes[apiKeySecurityScheme] = &huma.SecurityScheme{
+		Type:        "apiKey",
+		In:          "header",
+		Name:        "X-API-Key",
+		Description: "A user's API key; its role decides which operations it may call",
+	}
+	components.SecuritySchemes[sessionSecurityScheme] = &huma.SecurityScheme{
+		Type:         "http",
+		Scheme:       "bearer",
+		BearerFormat: "JWT",
+		Description:  "A session token from signing in; its user's role decides which operations it may call",
+	}
+
+	if op.Metadata == nil {
+		op.Metadata = map[string]any{}
+	}
+	op.Metadata[roleMetadataKey] = role
+	op.Security = append(op.Security, map[string][]string{apiKeySecurityScheme: {}}, map[string][]string{sessionSecurityScheme: {}})
+	op.Errors = append(op.Errors, http.StatusUnauthorized, http.StatusForbidden)
+	return op
+}
+
+// operationRole returns the role an operation requires, or "" if anyone
+// may call it.
+func operationRole(op *huma.Operation) string {
+	role, _ := op.Metadata[roleMetadataKey].(string)
+	return role
+}
+
+// Authorization is Huma middleware that enforces the role each operation
+// requires (see requireRole). Requests without a valid API key or session
+// token get a 401, and users whose role is too low get a 403 coded
+// INSUFFICIENT_ROLE. Admin operations also accept the admin token as a
+// bearer token. It must be installed after AuthService.Sessions and before
+// routes are registered.
+func (s *AdminService) Authorization(api huma.API) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		role := operationRole(ctx.Operation())
+		if role == "" {
+			next(ctx)
+			return
+		}
+
+		_, signedIn := sessionUser(ctx.Context())
+		if token, ok := strings.CutPrefix(ctx.Header("Authorization"), "Bearer "); ok && role == models.UserRoleAdmin && !signedIn {
+			if s.token == "" {
+				huma.WriteErr(api, ctx, http.StatusForbidden, "admin token is disabled: ADMIN_TOKEN is not set")
+				return
+			}
+			if subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+				huma.WriteErr(api, ctx, http.StatusUnauthorized, "invalid admin token")
+				return
+			}
+			next(ctx)
+			return
+		}
+
+		user, err := authenticate(ctx.Context(), s.db, ctx.Header("X-API-Key"))
+		switch {
+		case errors.Is(err, ErrInvalidAPIKey):
+			huma.WriteErr(api, ctx, http.StatusUnauthorized, "missing or invalid API key or session token")
+			return
+		case err != nil:
+			huma.WriteErr(api, ctx, http.StatusInternalServerError, "failed to authenticate: "+err.Error())
+			return
+		}
+		if !user.HasRole(role) {
+			writeError(api, ctx, apiError(http.StatusForbidden, CodeInsufficientRole,
+				fmt.Sprintf("%s: requires the %s role, API key has %s", ErrInsufficientRole, role, user.Role)))
+			return
+		}
+		next(ctx)
+	}
+}
+
+// writeError writes an error response from middleware. Unlike
+// huma.WriteErr, it keeps an ErrorModel's code rather than deriving one
+// from the status; other errors are written as 500s.
+func writeError(api huma.API, ctx huma.Context, err error) {
+	var model *ErrorModel
+	if !errors.As(err, &model) {
+		huma.WriteErr(api, ctx, http.StatusInternalServerError, err.Error())
+		return
+	}
+	ctx.SetHeader("Content-Type", "application/problem+json")
+	ctx.SetStatus(model.Status)
+	_ = api.Marshal(ctx.BodyWriter(), "application/problem+json", model)
+}
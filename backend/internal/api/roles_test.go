@@ -0,0 +1,127 @@
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/api"
+	"github.com/drewjst/deltagov/internal/congresstest"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// TestAuthorization verifies each operation's required role is enforced:
+// callers without a valid API key get a 401, and users whose role is too
+// low a 403 coded INSUFFICIENT_ROLE.
+func TestAuthorization(t *testing.T) {
+	ts := newTestServer(t, "")
+	reader := apiKey(ts.addUser(t, models.UserRoleReader, nil))
+	editor := apiKey(ts.addUser(t, models.UserRoleEditor, nil))
+	admin := apiKey(ts.addUser(t, models.UserRoleAdmin, nil))
+
+	routes := []struct {
+		method string
+		path   string
+		body   any
+		role   string
+	}{
+		{http.MethodPost, "/api/v1/documents", map[string]string{"title": "Guidance"}, models.UserRoleEditor},
+		{http.MethodPost, "/api/v1/documents/1/versions", api.DocumentVersionUpload{Text: "Text."}, models.UserRoleEditor},
+		{http.MethodPost, "/api/v1/drafts", map[string]string{"title": "Draft", "text": "Text."}, models.UserRoleEditor},
+		{http.MethodPost, "/api/v1/drafts/1/versions", map[string]string{"text": "Text."}, models.UserRoleEditor},
+		{http.MethodGet, "/api/v1/admin/ingestions", nil, models.UserRoleAdmin},
+		{http.MethodPost, "/api/v1/admin/tenants", map[string]string{"name": "Agency"}, models.UserRoleAdmin},
+		{http.MethodPut, "/api/v1/admin/users/1/role", map[string]string{"role": models.UserRoleReader}, models.UserRoleAdmin},
+	}
+	callers := []struct {
+		name   string
+		header http.Header
+		role   string
+	}{
+		{"anonymous", nil, ""},
+		{"invalid key", apiKey("dg_unknown"), ""},
+		{"reader", reader, models.UserRoleReader},
+		{"editor", editor, models.UserRoleEditor},
+		{"admin", admin, models.UserRoleAdmin},
+	}
+	for _, route := range routes {
+		for _, caller := range callers {
+			status, body := ts.request(t, route.method, route.path, caller.header, route.body)
+			switch {
+			case caller.role == "":
+				if status != http.StatusUnauthorized {
+					t.Errorf("%s, %s %s: status = %d, want 401: %s", caller.name, route.method, route.path, status, body)
+				}
+			case !(models.User{Role: caller.role}).HasRole(route.role):
+				if status != http.StatusForbidden || errorCode(t, body) != api.CodeInsufficientRole {
+					t.Errorf("%s, %s %s: status = %d, want 403 %s: %s", caller.name, route.method, route.path, status, api.CodeInsufficientRole, body)
+				}
+			default:
+				if status == http.StatusUnauthorized || status == http.StatusForbidden && errorCode(t, body) == api.CodeInsufficientRole {
+					t.Errorf("%s, %s %s: status = %d, want the role accepted: %s", caller.name, route.method, route.path, status, body)
+				}
+			}
+		}
+	}
+
+	// Operations without a role are open to anyone
+	if status, body := ts.request(t, http.MethodGet, "/api/v1/lex", nil, nil); status != http.StatusOK {
+		t.Errorf("Anonymous, GET /api/v1/lex: status = %d, want 200: %s", status, body)
+	}
+}
+
+// TestSetUserRole verifies a role change takes effect on the user's next
+// request, and that unknown roles are refused.
+func TestSetUserRole(t *testing.T) {
+	ts := newTestServer(t, "s3cret")
+	key := ts.addUser(t, models.UserRoleReader, nil)
+	var user models.User
+	if err := ts.db.Where("name = ?", models.UserRoleReader).First(&user).Error; err != nil {
+		t.Fatalf("Failed to read user: %v", err)
+	}
+	rolePath := fmt.Sprintf("/api/v1/admin/users/%d/role", user.ID)
+	create := map[string]string{"title": "Guidance"}
+
+	if status, _ := ts.request(t, http.MethodPost, "/api/v1/documents", apiKey(key), create); status != http.StatusForbidden {
+		t.Fatalf("Create by a reader: status = %d, want 403", status)
+	}
+	if status, body := ts.request(t, http.MethodPut, rolePath, bearer("s3cret"), map[string]string{"role": models.UserRoleEditor}); status != http.StatusOK {
+		t.Fatalf("Set role: status = %d, want 200: %s", status, body)
+	}
+	if status, body := ts.request(t, http.MethodPost, "/api/v1/documents", apiKey(key), create); status != http.StatusCreated {
+		t.Errorf("Create after promotion to editor: status = %d, want 201: %s", status, body)
+	}
+
+	if status, _ := ts.request(t, http.MethodPut, rolePath, bearer("s3cret"), map[string]string{"role": "owner"}); status != http.StatusUnprocessableEntity {
+		t.Errorf("Set unknown role: status = %d, want 422", status)
+	}
+	if status, _ := ts.request(t, http.MethodPut, "/api/v1/admin/users/999/role", bearer("s3cret"), map[string]string{"role": models.UserRoleAdmin}); status != http.StatusNotFound {
+		t.Errorf("Set role of a missing user: status = %d, want 404", status)
+	}
+}
+
+// TestUserRoleDefault verifies users created before roles existed become
+// editors, keeping the write access they had, when the role column is
+// added.
+func TestUserRoleDefault(t *testing.T) {
+	db := congresstest.OpenDB(t)
+	// The users table as it was before roles
+	if err := db.Migrator().DropColumn(&models.User{}, "role"); err != nil {
+		t.Fatalf("Failed to drop the role column: %v", err)
+	}
+	if err := db.Exec("INSERT INTO users (name, api_key_hash, created_at, updated_at) VALUES (?, ?, ?, ?)",
+		"Legacy", "legacy-hash", "2024-01-01 00:00:00", "2024-01-01 00:00:00").Error; err != nil {
+		t.Fatalf("Failed to create legacy user: %v", err)
+	}
+
+	if err := db.AutoMigrate(&models.User{}); err != nil {
+		t.Fatalf("AutoMigrate failed: %v", err)
+	}
+	var user models.User
+	if err := db.Where("name = ?", "Legacy").First(&user).Error; err != nil {
+		t.Fatalf("Failed to read user: %v", err)
+	}
+	if user.Role != models.UserRoleEditor {
+		t.Errorf("Legacy user's role = %q, want %q", user.Role, models.UserRoleEditor)
+	}
+}
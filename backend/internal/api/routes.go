@@ -1,11 +1,18 @@
 package api
 
 import (
+	"bufio"
 	"context"
 	"net/http"
+	"reflect"
 	"strconv"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
 )
 
 // --- Request/Response Types ---
@@ -18,41 +25,154 @@ type ListBillsOutput struct {
 	}
 }
 
+// ListBillsInput is the request for listing bills
+type ListBillsInput struct {
+	BecameLaw bool `query:"becameLaw" doc:"Only return bills that became law"`
+}
+
 // GetBillInput is the request for getting a single bill
 type GetBillInput struct {
-	ID uint `path:"id" doc:"Bill ID (database ID)"`
+	ConditionalInput
+	ID uint `path:"id" minimum:"1" doc:"Bill ID (database ID)"`
 }
 
 // GetBillOutput is the response for getting a single bill
 type GetBillOutput struct {
+	CacheHeaders
 	Body BillResponse
 }
 
 // GetBillVersionsInput is the request for getting bill versions
 type GetBillVersionsInput struct {
-	ID uint `path:"id" doc:"Bill ID"`
+	ConditionalInput
+	ID uint `path:"id" minimum:"1" doc:"Bill ID"`
 }
 
 // GetBillVersionsOutput is the response for getting bill versions
 type GetBillVersionsOutput struct {
+	CacheHeaders
 	Body struct {
 		BillID   uint              `json:"billId"`
 		Versions []VersionResponse `json:"versions"`
 	}
 }
 
+// GetBillChangesInput is the request for a bill's change feed
+type GetBillChangesInput struct {
+	ID     uint `path:"id" minimum:"1" doc:"Bill ID"`
+	Limit  int  `query:"limit" default:"50" minimum:"1" maximum:"200" doc:"Number of changes per page (max 200)"`
+	Offset int  `query:"offset" default:"0" minimum:"0" doc:"Pagination offset"`
+}
+
+// GetBillChangesOutput is the response for a bill's change feed
+type GetBillChangesOutput struct {
+	Body BillChangeFeed
+}
+
+// GetSpendingChangesInput is the request for comparing dollar amounts between versions
+type GetSpendingChangesInput struct {
+	ID               uint `path:"id" minimum:"1" doc:"Bill ID"`
+	From             uint `query:"from" doc:"Source version ID (default: second most recent version)"`
+	To               uint `query:"to" doc:"Target version ID (default: most recent version)"`
+	IncludeUnchanged bool `query:"includeUnchanged" doc:"Include amounts that did not change"`
+}
+
+// GetSpendingChangesOutput is the response for comparing dollar amounts between versions
+type GetSpendingChangesOutput struct {
+	Body SpendingChangesResponse
+}
+
+// GetRelatedBillsInput is the request for a bill's related bills
+type GetRelatedBillsInput struct {
+	ID uint `path:"id" minimum:"1" doc:"Bill ID"`
+}
+
+// GetRelatedBillsOutput is the response for a bill's related bills
+type GetRelatedBillsOutput struct {
+	Body RelatedBillsResponse
+}
+
+// GetBillSummariesInput is the request for a bill's CRS summaries
+type GetBillSummariesInput struct {
+	ID uint `path:"id" minimum:"1" doc:"Bill ID"`
+}
+
+// GetBillSummariesOutput is the response for a bill's CRS summaries
+type GetBillSummariesOutput struct {
+	Body SummariesResponse
+}
+
+// CompareSummariesInput is the request for diffing two CRS summaries
+type CompareSummariesInput struct {
+	ID   uint   `path:"id" minimum:"1" doc:"Bill ID"`
+	From string `query:"from" format:"date" doc:"Action date of the source summary, YYYY-MM-DD (default: second most recent summary)"`
+	To   string `query:"to" format:"date" doc:"Action date of the target summary, YYYY-MM-DD (default: most recent summary)"`
+	View string `query:"view" enum:"unified,split" default:"unified" doc:"unified returns interleaved lines; split returns aligned left/right rows"`
+}
+
+// CompareSummariesOutput is the response for diffing two CRS summaries
+type CompareSummariesOutput struct {
+	Body DiffResponse
+}
+
+// GetCostEstimatesInput is the request for a bill's CBO cost estimates
+type GetCostEstimatesInput struct {
+	ID uint `path:"id" minimum:"1" doc:"Bill ID"`
+}
+
+// GetCostEstimatesOutput is the response for a bill's CBO cost estimates
+type GetCostEstimatesOutput struct {
+	Body CostEstimatesResponse
+}
+
+// DiffOptionsInput is embedded in inputs of diff endpoints to select
+// preprocessing that hides changes in formatting rather than text. Diffs
+// with any option are computed on request and not cached.
+type DiffOptionsInput struct {
+	IgnoreWhitespace   bool `query:"ignoreWhitespace" doc:"Ignore changes in indentation, spacing, and blank lines"`
+	IgnoreLineWrap     bool `query:"ignoreLineWrap" doc:"Ignore lines re-wrapped within a paragraph; each paragraph is diffed as one line"`
+	StripPageArtifacts bool `query:"stripPageArtifacts" doc:"Ignore page markers, running headers, and page numbers of printed text"`
+}
+
+// Options returns the diff engine options the input selects.
+func (in DiffOptionsInput) Options() diff_engine.Options {
+	return diff_engine.Options{
+		IgnoreWhitespace:   in.IgnoreWhitespace,
+		IgnoreLineWrap:     in.IgnoreLineWrap,
+		StripPageArtifacts: in.StripPageArtifacts,
+	}
+}
+
 // ComputeDiffInput is the request for computing a diff
 type ComputeDiffInput struct {
-	BillID      uint `path:"billId" doc:"Bill ID"`
-	FromVersion uint `path:"fromVersion" doc:"Source version ID"`
-	ToVersion   uint `path:"toVersion" doc:"Target version ID"`
+	ConditionalInput
+	DiffOptionsInput
+	DiffWindowInput
+	BillID      uint   `path:"billId" minimum:"1" doc:"Bill ID"`
+	FromVersion uint   `path:"fromVersion" minimum:"1" doc:"Source version ID"`
+	ToVersion   uint   `path:"toVersion" minimum:"1" doc:"Target version ID"`
+	View        string `query:"view" enum:"unified,split" default:"unified" doc:"unified returns interleaved lines; split returns aligned left/right rows"`
 }
 
 // ComputeDiffOutput is the response for computing a diff
 type ComputeDiffOutput struct {
+	CacheHeaders
 	Body DiffResponse
 }
 
+// StreamDiffInput is the request for streaming a diff
+type StreamDiffInput struct {
+	BillID      uint   `path:"billId" minimum:"1" doc:"Bill ID"`
+	FromVersion uint   `path:"fromVersion" minimum:"1" doc:"Source version ID"`
+	ToVersion   uint   `path:"toVersion" minimum:"1" doc:"Target version ID"`
+	Format      string `query:"format" enum:"ndjson,sse" default:"ndjson" doc:"ndjson writes one JSON record per line; sse writes Server-Sent Events"`
+}
+
+// DiffDeterminismOutput is the response for a diff determinism check
+type DiffDeterminismOutput struct {
+	Body DeterminismReport
+}
+
 // HealthOutput is the response for health check
 type HealthOutput struct {
 	Body struct {
@@ -70,13 +190,36 @@ type FetchHR1Output struct {
 // Note: Using non-pointer types as Huma doesn't support pointers for query params.
 // Zero values (0, "", false) are treated as "not provided" in the handler.
 type LexSearchInput struct {
-	Congress       int    `query:"congress" doc:"Filter by congress number (e.g., 118, 119). 0 = no filter" example:"119"`
+	Congress       int    `query:"congress" minimum:"0" doc:"Filter by congress number (e.g., 118, 119). 0 = no filter" example:"119"`
 	Sponsor        string `query:"sponsor" doc:"Filter by sponsor name (case-insensitive partial match)" example:"Johnson"`
-	Query          string `query:"query" doc:"Search in bill title (case-insensitive partial match)" example:"appropriation"`
-	BillType       string `query:"type" doc:"Filter by bill type (hr, s, hjres, sjres, hconres, sconres, hres, sres)" example:"hr"`
-	IsSpendingBill bool   `query:"spending" doc:"Filter to only spending/appropriations bills"`
-	Limit          int    `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"Number of results per page (max 100)"`
-	Offset         int    `query:"offset" default:"0" minimum:"0" doc:"Pagination offset"`
+	Query          string `query:"query" doc:"Search in bill title and aliases, such as short titles and nicknames like NDAA (case-insensitive partial match)" example:"appropriation"`
+	BillType       string `query:"type" pattern:"^[A-Za-z]+$" maxLength:"16" doc:"Filter by bill type (hr, s, hjres, sjres, hconres, sconres, hres, sres)" example:"hr"`
+	Number         string `query:"number" pattern:"^[0-9A-Za-z]+$" maxLength:"16" doc:"Filter by bill number as published, such as 1 or a state's 12A (case-insensitive); with congress or state and type, finds a single bill" example:"1"`
+	Jurisdiction   string `query:"jurisdiction" enum:"federal,state,draft" doc:"Filter to federal bills, state legislature bills, or the caller's tenant's drafts"`
+	State          string `query:"state" pattern:"^[A-Za-z]{2}$" doc:"Filter by two-letter state code of state bills" example:"ca"`
+	IsSpendingBill bool   `query:"spending" doc:"Filter to only spending/appropriations bills (classified by CRS subjects)"`
+	PolicyArea     string `query:"policyArea" doc:"Filter by CRS policy area (case-insensitive exact match)" example:"Economics and Public Finance"`
+	Subject        string `query:"subject" doc:"Filter by CRS legislative subject term (case-insensitive exact match)" example:"Appropriations"`
+	MetadataFilters
+	Sort   string `query:"sort" enum:"updateDate,introducedDate,title" default:"updateDate" doc:"Sort field"`
+	Order  string `query:"order" enum:"asc,desc" doc:"Sort order (default: desc for dates, asc for title)"`
+	Limit  int    `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"Number of results per page (max 100)"`
+	Offset int    `query:"offset" default:"0" minimum:"0" doc:"Pagination offset"`
+}
+
+// SearchBillsInput is the request for searching bills via /api/v1/bills/search
+type SearchBillsInput struct {
+	Congress     int    `query:"congress" minimum:"0" doc:"Filter by congress number. 0 = no filter" example:"119"`
+	Sponsor      string `query:"sponsor" doc:"Filter by sponsor name (case-insensitive partial match)" example:"Johnson"`
+	Query        string `query:"q" doc:"Search in bill title and aliases, such as short titles and nicknames like NDAA (case-insensitive partial match)" example:"appropriation"`
+	BillType     string `query:"billType" pattern:"^[A-Za-z]+$" maxLength:"16" doc:"Filter by bill type (hr, s, hjres, sjres, hconres, sconres, hres, sres)" example:"hr"`
+	SpendingOnly bool   `query:"spendingOnly" doc:"Only return spending/appropriations bills"`
+	Facets       bool   `query:"facets" doc:"Also return counts of matching bills per congress, bill type, origin chamber, spending classification, and policy area"`
+	MetadataFilters
+	Sort   string `query:"sort" enum:"updateDate,introducedDate,title" default:"updateDate" doc:"Sort field"`
+	Order  string `query:"order" enum:"asc,desc" doc:"Sort order (default: desc for dates, asc for title)"`
+	Limit  int    `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"Number of results per page (max 100)"`
+	Offset int    `query:"offset" default:"0" minimum:"0" doc:"Pagination offset"`
 }
 
 // LexSearchOutput is the response for searching bills
@@ -123,8 +266,13 @@ func RegisterRoutes(api huma.API) {
 		Summary:     "Get H.R. 1 (Mock Data)",
 		Description: "Returns mock H.R. 1 data for demo/testing purposes",
 		Tags:        []string{"Bills"},
-	}, func(ctx context.Context, input *struct{}) (*GetBillOutput, error) {
-		return &GetBillOutput{Body: GetMockHR1()}, nil
+	}, func(ctx context.Context, input *ConditionalInput) (*GetBillOutput, error) {
+		bill := GetMockHR1()
+		headers, err := conditionalHeaders(*input, bill, cacheControlBill)
+		if err != nil {
+			return nil, err
+		}
+		return &GetBillOutput{CacheHeaders: headers, Body: bill}, nil
 	})
 
 	// Mock diff computation (for demo/testing)
@@ -136,7 +284,15 @@ func RegisterRoutes(api huma.API) {
 		Description: "Returns mock diff data for demo/testing purposes",
 		Tags:        []string{"Diff"},
 	}, func(ctx context.Context, input *ComputeDiffInput) (*ComputeDiffOutput, error) {
-		return &ComputeDiffOutput{Body: GetMockDiff()}, nil
+		diff := GetMockDiff()
+		if input.View == DiffViewSplit {
+			toSplitView(&diff)
+		}
+		headers, err := conditionalHeaders(input.ConditionalInput, diff, cacheControlDiff)
+		if err != nil {
+			return nil, err
+		}
+		return &ComputeDiffOutput{CacheHeaders: headers, Body: diff}, nil
 	})
 }
 
@@ -157,6 +313,7 @@ func RegisterRoutesWithService(api huma.API, handler *RouteHandler) {
 		Path:        "/api/v1/bills/hr1/fetch",
 		Summary:     "Fetch H.R. 1 (One Big Beautiful Bill)",
 		Description: "Fetches H.R. 1 (119th Congress) from Congress.gov and stores all versions",
+		Errors:      []int{http.StatusInternalServerError},
 		Tags:        []string{"Bills"},
 	}, func(ctx context.Context, input *struct{}) (*FetchHR1Output, error) {
 		bill, err := handler.billService.FetchAndStoreHR1(ctx)
@@ -173,13 +330,18 @@ func RegisterRoutesWithService(api huma.API, handler *RouteHandler) {
 		Path:        "/api/v1/bills/hr1",
 		Summary:     "Get H.R. 1 (One Big Beautiful Bill)",
 		Description: "Returns H.R. 1 with all versions. Auto-fetches from Congress.gov if not cached.",
+		Errors:      []int{http.StatusInternalServerError},
 		Tags:        []string{"Bills"},
-	}, func(ctx context.Context, input *struct{}) (*GetBillOutput, error) {
+	}, func(ctx context.Context, input *ConditionalInput) (*GetBillOutput, error) {
 		bill, err := handler.billService.FetchAndStoreHR1(ctx)
 		if err != nil {
 			return nil, huma.Error500InternalServerError("failed to get H.R. 1: " + err.Error())
 		}
-		return &GetBillOutput{Body: *bill}, nil
+		headers, err := conditionalHeaders(*input, bill, cacheControlBill)
+		if err != nil {
+			return nil, err
+		}
+		return &GetBillOutput{CacheHeaders: headers, Body: *bill}, nil
 	})
 
 	// List all bills
@@ -188,10 +350,11 @@ func RegisterRoutesWithService(api huma.API, handler *RouteHandler) {
 		Method:      http.MethodGet,
 		Path:        "/api/v1/bills",
 		Summary:     "List all bills",
-		Description: "Returns all bills stored in the database",
+		Description: "Returns all bills stored in the database. With becameLaw=true, returns only bills enacted as public or private law.",
+		Errors:      []int{http.StatusInternalServerError},
 		Tags:        []string{"Bills"},
-	}, func(ctx context.Context, input *struct{}) (*ListBillsOutput, error) {
-		bills, err := handler.billService.GetAllBills(ctx)
+	}, func(ctx context.Context, input *ListBillsInput) (*ListBillsOutput, error) {
+		bills, err := handler.billService.GetAllBills(ctx, input.BecameLaw)
 		if err != nil {
 			return nil, huma.Error500InternalServerError("failed to list bills: " + err.Error())
 		}
@@ -201,6 +364,38 @@ func RegisterRoutesWithService(api huma.API, handler *RouteHandler) {
 		return resp, nil
 	})
 
+	// Search bills; registered before /bills/{id} so "search" isn't taken as an ID
+	huma.Register(api, huma.Operation{
+		OperationID: "search-bills-v1",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/search",
+		Summary:     "Search bills",
+		Description: "Searches bills by congress, sponsor, title, bill type, spending classification, and Congress.gov metadata, sorted by update date, introduced date, or title. Supports pagination via limit/offset.\n\n" + metadataFiltersDoc,
+		Errors:      []int{http.StatusInternalServerError},
+		Tags:        []string{"Search"},
+	}, func(ctx context.Context, input *SearchBillsInput) (*LexSearchOutput, error) {
+		result, err := handler.billService.SearchBills(ctx, LexSearchParams{
+			Congress:       input.Congress,
+			Sponsor:        input.Sponsor,
+			Query:          input.Query,
+			BillType:       input.BillType,
+			IsSpendingBill: input.SpendingOnly,
+			Metadata:       input.filters,
+			Facets:         input.Facets,
+			Sort:           input.Sort,
+			Order:          input.Order,
+			Limit:          input.Limit,
+			Offset:         input.Offset,
+		})
+		if err != nil {
+			return nil, huma.Error500InternalServerError("search failed: " + err.Error())
+		}
+		return &LexSearchOutput{Body: *result}, nil
+	})
+
+	// Registered before /bills/{id} too
+	registerTrendingRoute(api, handler.billService)
+
 	// Get single bill
 	huma.Register(api, huma.Operation{
 		OperationID: "get-bill",
@@ -208,13 +403,18 @@ func RegisterRoutesWithService(api huma.API, handler *RouteHandler) {
 		Path:        "/api/v1/bills/{id}",
 		Summary:     "Get a bill by ID",
 		Description: "Returns detailed information about a specific legislative bill",
+		Errors:      []int{http.StatusNotFound},
 		Tags:        []string{"Bills"},
 	}, func(ctx context.Context, input *GetBillInput) (*GetBillOutput, error) {
 		bill, err := handler.billService.GetBillByID(ctx, input.ID)
 		if err != nil {
-			return nil, huma.Error404NotFound("bill not found")
+			return nil, serviceError(err, "failed to get bill")
+		}
+		headers, err := conditionalHeaders(input.ConditionalInput, bill, cacheControlBill)
+		if err != nil {
+			return nil, err
 		}
-		return &GetBillOutput{Body: *bill}, nil
+		return &GetBillOutput{CacheHeaders: headers, Body: *bill}, nil
 	})
 
 	// Get bill versions
@@ -224,32 +424,264 @@ func RegisterRoutesWithService(api huma.API, handler *RouteHandler) {
 		Path:        "/api/v1/bills/{id}/versions",
 		Summary:     "Get all versions of a bill",
 		Description: "Returns all tracked versions/snapshots of a bill's text",
+		Errors:      []int{http.StatusNotFound},
 		Tags:        []string{"Bills"},
 	}, func(ctx context.Context, input *GetBillVersionsInput) (*GetBillVersionsOutput, error) {
 		bill, err := handler.billService.GetBillWithVersions(ctx, input.ID)
 		if err != nil {
-			return nil, huma.Error404NotFound("bill not found")
+			return nil, serviceError(err, "failed to get bill versions")
 		}
 		resp := &GetBillVersionsOutput{}
 		resp.Body.BillID = bill.ID
 		resp.Body.Versions = bill.Versions
+		headers, err := conditionalHeaders(input.ConditionalInput, resp.Body, cacheControlBill)
+		if err != nil {
+			return nil, err
+		}
+		resp.CacheHeaders = headers
 		return resp, nil
 	})
 
+	// Bill change feed
+	huma.Register(api, huma.Operation{
+		OperationID: "get-bill-changes",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{id}/changes",
+		Summary:     "Get a bill's change feed",
+		Description: "Returns detected changes to a bill in chronological order: new versions (with insert/delete counts from cached diffs), status transitions, title changes, and sponsor changes",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *GetBillChangesInput) (*GetBillChangesOutput, error) {
+		feed, err := handler.billService.GetBillChanges(ctx, input.ID, input.Limit, input.Offset)
+		if err != nil {
+			return nil, serviceError(err, "failed to get bill changes")
+		}
+		return &GetBillChangesOutput{Body: *feed}, nil
+	})
+
+	// Related and companion bills
+	huma.Register(api, huma.Operation{
+		OperationID: "get-related-bills",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{id}/related",
+		Summary:     "Get a bill's related bills",
+		Description: "Returns bills Congress.gov lists as related, with House/Senate companions (identical bills in the other chamber) first. Ingested related bills include their latest version ID for use with the diff endpoint.",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *GetRelatedBillsInput) (*GetRelatedBillsOutput, error) {
+		related, err := handler.billService.GetRelatedBills(ctx, input.ID)
+		if err != nil {
+			return nil, serviceError(err, "failed to get related bills")
+		}
+		return &GetRelatedBillsOutput{Body: *related}, nil
+	})
+
+	// CRS summaries
+	huma.Register(api, huma.Operation{
+		OperationID: "get-bill-summaries",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{id}/summaries",
+		Summary:     "Get a bill's CRS summaries",
+		Description: "Returns the Congressional Research Service summaries of a bill, one per summarized action, oldest first",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *GetBillSummariesInput) (*GetBillSummariesOutput, error) {
+		summaries, err := handler.billService.GetBillSummaries(ctx, input.ID)
+		if err != nil {
+			return nil, serviceError(err, "failed to get summaries")
+		}
+		return &GetBillSummariesOutput{Body: *summaries}, nil
+	})
+
+	// Diff between CRS summaries
+	huma.Register(api, huma.Operation{
+		OperationID: "compare-bill-summaries",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{id}/summaries/diff",
+		Summary:     "Compare CRS summaries between action dates",
+		Description: "Diffs the CRS summaries written for the actions on two dates. Defaults to the two most recent summaries. fromVersion and toVersion in the response are the summaries' version codes.",
+		Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+		Tags:        []string{"Diff"},
+	}, func(ctx context.Context, input *CompareSummariesInput) (*CompareSummariesOutput, error) {
+		if (input.From == "") != (input.To == "") {
+			return nil, huma.Error400BadRequest("from and to must be provided together")
+		}
+		var from, to time.Time
+		if input.From != "" {
+			var err error
+			if from, err = time.Parse(time.DateOnly, input.From); err != nil {
+				return nil, huma.Error400BadRequest("invalid from date")
+			}
+			if to, err = time.Parse(time.DateOnly, input.To); err != nil {
+				return nil, huma.Error400BadRequest("invalid to date")
+			}
+		}
+		diff, err := handler.billService.CompareSummaries(ctx, input.ID, from, to)
+		if err != nil {
+			return nil, serviceError(err, "failed to compare summaries")
+		}
+		if input.View == DiffViewSplit {
+			toSplitView(diff)
+		}
+		return &CompareSummariesOutput{Body: *diff}, nil
+	})
+
+	// CBO cost estimates
+	huma.Register(api, huma.Operation{
+		OperationID: "get-bill-cost-estimates",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{id}/cost-estimates",
+		Summary:     "Get a bill's CBO cost estimates",
+		Description: "Returns links to the Congressional Budget Office cost estimates published for a bill, newest first",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *GetCostEstimatesInput) (*GetCostEstimatesOutput, error) {
+		estimates, err := handler.billService.GetCostEstimates(ctx, input.ID)
+		if err != nil {
+			return nil, serviceError(err, "failed to get cost estimates")
+		}
+		return &GetCostEstimatesOutput{Body: *estimates}, nil
+	})
+
+	// Spending changes between versions
+	huma.Register(api, huma.Operation{
+		OperationID: "get-bill-spending-changes",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{id}/spending-changes",
+		Summary:     "Compare dollar amounts between two bill versions",
+		Description: "Extracts dollar amounts with their section and account context from two versions and reports which amounts were added, removed, or changed and by how much. Defaults to the two most recent versions.",
+		Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *GetSpendingChangesInput) (*GetSpendingChangesOutput, error) {
+		if (input.From == 0) != (input.To == 0) {
+			return nil, huma.Error400BadRequest("from and to must be provided together")
+		}
+		changes, err := handler.billService.GetSpendingChanges(ctx, input.ID, input.From, input.To, input.IncludeUnchanged)
+		if err != nil {
+			return nil, serviceError(err, "failed to compare spending")
+		}
+		return &GetSpendingChangesOutput{Body: *changes}, nil
+	})
+
+	// Defined-term changes between versions
+	registerDefinitionChangesRoute(api, handler.billService)
+
+	// Per-version earmarks and earmark changes between versions
+	registerEarmarkRoutes(api, handler.billService)
+
+	// When continuing resolutions run out, and what's pending to replace them
+	registerAppropriationsDeadlinesRoute(api, handler.billService)
+
+	// Account funding levels across bill versions and fiscal years
+	registerAppropriationsAccountRoutes(api, handler.billService)
+
+	// Short titles, popular titles, and curated nicknames
+	registerAliasRoute(api, handler.billService)
+
 	// Compute diff between versions
 	huma.Register(api, huma.Operation{
 		OperationID: "compute-diff",
 		Method:      http.MethodGet,
 		Path:        "/api/v1/bills/{billId}/diff/{fromVersion}/{toVersion}",
 		Summary:     "Compute diff between two bill versions",
-		Description: "Returns a structured diff showing insertions, deletions, and unchanged text between two versions",
+		Description: "Returns a structured diff showing insertions, deletions, and unchanged text between two versions. With view=split, returns aligned left/right rows for side-by-side rendering. Large diffs can be loaded a page of hunks at a time with hunkOffset and hunkLimit, and context narrows the unchanged lines around each change.",
+		Errors:      []int{http.StatusNotFound, http.StatusUnprocessableEntity},
 		Tags:        []string{"Diff"},
 	}, func(ctx context.Context, input *ComputeDiffInput) (*ComputeDiffOutput, error) {
-		diff, err := handler.billService.ComputeDiff(ctx, input.FromVersion, input.ToVersion)
+		diff, err := handler.billService.ComputeDiff(ctx, input.BillID, input.FromVersion, input.ToVersion, input.Options())
+		if err != nil {
+			return nil, serviceError(err, "failed to compute diff")
+		}
+		windowDiff(diff, input.Window())
+		if input.View == DiffViewSplit {
+			toSplitView(diff)
+		}
+		headers, err := conditionalHeaders(input.ConditionalInput, diff, cacheControlDiff)
+		if err != nil {
+			return nil, err
+		}
+		return &ComputeDiffOutput{CacheHeaders: headers, Body: *diff}, nil
+	})
+
+	// Word change report with tracked changes for a version pair
+	registerChangeReportRoute(api, handler.billService)
+
+	// Diff of two texts sent in the request, stored nowhere
+	registerCompareRoute(api, handler.billService)
+
+	// Diff statistics for every version pair
+	registerVersionMatrixRoute(api, handler.billService)
+
+	// Which version each version derives from, and diffs against it
+	registerVersionGraphRoutes(api, handler.billService)
+
+	// Bills reintroduced across Congresses, and diffs against the last
+	registerLineageRoutes(api, handler.billService)
+
+	// Section-by-section reconciliation of House, Senate, and final text
+	registerReconcileRoute(api, handler.billService)
+
+	// Version text, whole or in portions
+	registerVersionTextRoute(api, handler.billService)
+
+	// Where version text was retrieved from, and re-checking it
+	registerProvenanceRoutes(api, handler.billService)
+
+	// Earlier appearances of a provision's language in other bills and versions
+	registerSectionProvenanceRoute(api, handler.billService)
+
+	// A bill as it stood on a past date
+	registerAsOfRoute(api, handler.billService)
+
+	// Streamed diff
+	huma.Register(api, huma.Operation{
+		OperationID: "stream-diff",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{billId}/diff/{fromVersion}/{toVersion}/stream",
+		Summary:     "Stream diff between two bill versions",
+		Description: "Streams the diff as it is computed: a start record, one record per line (lineNumber, type, text), then an end record with totals. Records are NDJSON, or Server-Sent Events with format=sse. A failure mid-stream ends with an error record.",
+		Errors:      []int{http.StatusNotFound, http.StatusUnprocessableEntity},
+		Tags:        []string{"Diff"},
+		Responses: streamedResponse("Diff records",
+			api.OpenAPI().Components.Schemas.Schema(reflect.TypeOf(DiffStreamRecord{}), true, "DiffStreamRecord"),
+			"application/x-ndjson", "text/event-stream"),
+	}, func(ctx context.Context, input *StreamDiffInput) (*huma.StreamResponse, error) {
+		stream, err := handler.billService.PrepareDiffStream(ctx, input.BillID, input.FromVersion, input.ToVersion)
+		if err != nil {
+			return nil, serviceError(err, "failed to prepare diff")
+		}
+
+		// The body is written after this handler returns, outliving ctx
+		streamCtx := logging.WithRequestID(context.Background(), logging.RequestID(ctx))
+		return &huma.StreamResponse{Body: func(hctx huma.Context) {
+			contentType := "application/x-ndjson"
+			if input.Format == DiffStreamSSE {
+				contentType = "text/event-stream"
+			}
+			hctx.SetHeader("Content-Type", contentType)
+			hctx.SetHeader("Cache-Control", "no-cache")
+			hctx.SetHeader("X-Accel-Buffering", "no") // Keep proxies from buffering the stream
+			streamBody(hctx.BodyWriter(), func(w *bufio.Writer) {
+				stream.Write(streamCtx, w, input.Format)
+			})
+		}}, nil
+	})
+
+	// Diff determinism report
+	huma.Register(api, huma.Operation{
+		OperationID: "check-diff-determinism",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{billId}/diff/{fromVersion}/{toVersion}/determinism",
+		Summary:     "Check diff determinism",
+		Description: "Recomputes the diff between two versions multiple times and reports whether the results agree with each other and with the stored delta",
+		Errors:      []int{http.StatusNotFound, http.StatusUnprocessableEntity},
+		Tags:        []string{"Diff"},
+	}, func(ctx context.Context, input *ComputeDiffInput) (*DiffDeterminismOutput, error) {
+		report, err := handler.billService.CheckDiffDeterminism(ctx, input.BillID, input.FromVersion, input.ToVersion)
 		if err != nil {
-			return nil, huma.Error500InternalServerError("failed to compute diff: " + err.Error())
+			return nil, serviceError(err, "failed to check determinism")
 		}
-		return &ComputeDiffOutput{Body: *diff}, nil
+		return &DiffDeterminismOutput{Body: *report}, nil
 	})
 
 	// Search bills - /api/v1/lex
@@ -258,7 +690,8 @@ func RegisterRoutesWithService(api huma.API, handler *RouteHandler) {
 		Method:      http.MethodGet,
 		Path:        "/api/v1/lex",
 		Summary:     "Search legislative bills",
-		Description: "Search and filter bills by congress, sponsor, title query, bill type, and spending classification. Supports pagination via limit/offset.",
+		Description: "Search and filter bills by congress, sponsor, title query, bill type, spending classification, CRS policy area, legislative subject, and Congress.gov metadata. Supports pagination via limit/offset.\n\n" + metadataFiltersDoc,
+		Errors:      []int{http.StatusInternalServerError},
 		Tags:        []string{"Search"},
 	}, func(ctx context.Context, input *LexSearchInput) (*LexSearchOutput, error) {
 		// Convert Huma input to service params
@@ -267,7 +700,15 @@ func RegisterRoutesWithService(api huma.API, handler *RouteHandler) {
 			Sponsor:        input.Sponsor,
 			Query:          input.Query,
 			BillType:       input.BillType,
+			Number:         input.Number,
+			Jurisdiction:   input.Jurisdiction,
+			State:          input.State,
 			IsSpendingBill: input.IsSpendingBill,
+			PolicyArea:     input.PolicyArea,
+			Subject:        input.Subject,
+			Metadata:       input.filters,
+			Sort:           input.Sort,
+			Order:          input.Order,
 			Limit:          input.Limit,
 			Offset:         input.Offset,
 		}
@@ -286,6 +727,12 @@ func RegisterRoutesWithService(api huma.API, handler *RouteHandler) {
 			},
 		}, nil
 	})
+
+	// Full-text search of bill text in the search backend
+	registerTextSearchRoute(api, handler.billService)
+
+	// Bills and sections similar in meaning to a query or a bill
+	registerSemanticSearchRoute(api, handler.billService)
 }
 
 // mockBillsToBillResponses converts mock bills to BillResponse format
@@ -295,6 +742,7 @@ func mockBillsToBillResponses(mocks []MockBill) []BillResponse {
 		id, _ := strconv.ParseUint(m.ID, 10, 32)
 		responses[i] = BillResponse{
 			ID:            uint(id),
+			Jurisdiction:  models.JurisdictionFederal,
 			Title:         m.Title,
 			Sponsor:       m.Sponsor,
 			CurrentStatus: m.CurrentStatus,
@@ -0,0 +1,265 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/regulations"
+)
+
+// ErrRuleNotFound is returned when no rule exists for an ID.
+var ErrRuleNotFound = errors.New("rule not found")
+
+// RuleService serves Federal Register rules and their proposed-to-final
+// diffs.
+type RuleService struct {
+	db *gorm.DB
+}
+
+// NewRuleService creates a new RuleService instance.
+func NewRuleService(db *gorm.DB) *RuleService {
+	return &RuleService{db: db}
+}
+
+// RuleResponse is a proposed or final rule, without its text.
+type RuleResponse struct {
+	ID              uint     `json:"id"`
+	DocumentNumber  string   `json:"documentNumber"`
+	RuleType        string   `json:"ruleType"` // "proposed" or "final"
+	Title           string   `json:"title"`
+	Abstract        string   `json:"abstract,omitempty"`
+	Agencies        []string `json:"agencies"`
+	RIN             string   `json:"rin,omitempty"`
+	DocketID        string   `json:"docketId,omitempty"`
+	PublicationDate string   `json:"publicationDate"` // YYYY-MM-DD
+	URL             string   `json:"url"`
+	CounterpartID   *uint    `json:"counterpartId,omitempty"` // The proposed or final rule it is diffed against
+}
+
+// RuleListResponse is a page of rules, newest first.
+type RuleListResponse struct {
+	Rules  []RuleResponse `json:"rules"`
+	Total  int64          `json:"total"`
+	Limit  int            `json:"limit"`
+	Offset int            `json:"offset"`
+}
+
+// RuleListParams filters the rule list.
+type RuleListParams struct {
+	Agency   string // Agency slug
+	RuleType string // "proposed" or "final"
+	RIN      string
+	Query    string // Title search
+	Limit    int
+	Offset   int
+}
+
+// ListRules returns rules matching params, newest first.
+func (s *RuleService) ListRules(ctx context.Context, params RuleListParams) (*RuleListResponse, error) {
+	query := s.db.WithContext(ctx).Model(&models.Rule{})
+	if params.Agency != "" {
+		slugs, _ := json.Marshal([]string{params.Agency})
+		query = query.Where("agency_slugs @> ?::jsonb", string(slugs))
+	}
+	if params.RuleType != "" {
+		query = query.Where("rule_type = ?", params.RuleType)
+	}
+	if params.RIN != "" {
+		query = query.Where("rin = ?", params.RIN)
+	}
+	if params.Query != "" {
+		query = query.Where("title ILIKE ?", "%"+params.Query+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, fmt.Errorf("failed to count rules: %w", err)
+	}
+
+	var rules []models.Rule
+	if err := query.Omit("text_content", "plain_text").
+		Order("publication_date DESC, id DESC").
+		Limit(params.Limit).Offset(params.Offset).
+		Find(&rules).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	response := &RuleListResponse{
+		Rules:  make([]RuleResponse, len(rules)),
+		Total:  total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	}
+	for i := range rules {
+		response.Rules[i] = ruleResponse(&rules[i])
+	}
+	return response, nil
+}
+
+// GetRule returns a rule and the ID of its counterpart, if stored.
+func (s *RuleService) GetRule(ctx context.Context, id uint) (*RuleResponse, error) {
+	rule, err := s.loadRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	response := ruleResponse(rule)
+
+	counterpart, err := regulations.Counterpart(ctx, s.db, rule)
+	switch {
+	case errors.Is(err, regulations.ErrNoCounterpart):
+	case err != nil:
+		return nil, err
+	default:
+		response.CounterpartID = &counterpart.ID
+	}
+	return &response, nil
+}
+
+// DiffRule diffs a rule against its counterpart, always from the proposed
+// rule to the final rule. Versions in the response are document numbers.
+func (s *RuleService) DiffRule(ctx context.Context, id uint) (*DiffResponse, error) {
+	rule, err := s.loadRule(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	counterpart, err := regulations.Counterpart(ctx, s.db, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	proposed, final := counterpart, rule
+	if rule.RuleType == models.RuleTypeProposed {
+		proposed, final = rule, counterpart
+	}
+	delta, err := regulations.Diff(proposed, final)
+	if err != nil {
+		return nil, err
+	}
+	return diffResponse(delta, proposed.DocumentNumber, final.DocumentNumber), nil
+}
+
+// loadRule returns the rule with the given ID, or ErrRuleNotFound.
+func (s *RuleService) loadRule(ctx context.Context, id uint) (*models.Rule, error) {
+	var rule models.Rule
+	err := s.db.WithContext(ctx).First(&rule, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRuleNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rule: %w", err)
+	}
+	return &rule, nil
+}
+
+// ruleResponse converts a Rule to its API form.
+func ruleResponse(r *models.Rule) RuleResponse {
+	return RuleResponse{
+		ID:              r.ID,
+		DocumentNumber:  r.DocumentNumber,
+		RuleType:        r.RuleType,
+		Title:           r.Title,
+		Abstract:        r.Abstract,
+		Agencies:        append([]string{}, r.Agencies...),
+		RIN:             r.RIN,
+		DocketID:        r.DocketID,
+		PublicationDate: r.PublicationDate.Format(time.DateOnly),
+		URL:             r.HTMLURL,
+	}
+}
+
+// ListRulesInput is the request for listing rules.
+type ListRulesInput struct {
+	Agency   string `query:"agency" doc:"Filter by Federal Register agency slug" example:"environmental-protection-agency"`
+	RuleType string `query:"type" enum:"proposed,final" doc:"Filter to proposed or final rules"`
+	RIN      string `query:"rin" doc:"Filter by Regulation Identifier Number" example:"2060-AV09"`
+	Query    string `query:"query" doc:"Search in rule title (case-insensitive partial match)"`
+	Limit    int    `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"Number of results per page (max 100)"`
+	Offset   int    `query:"offset" default:"0" minimum:"0" doc:"Pagination offset"`
+}
+
+// ListRulesOutput is the response for listing rules.
+type ListRulesOutput struct {
+	Body RuleListResponse
+}
+
+// GetRuleInput is the request for a single rule.
+type GetRuleInput struct {
+	ID uint `path:"id" minimum:"1" doc:"Rule ID (database ID)"`
+}
+
+// GetRuleOutput is the response for a single rule.
+type GetRuleOutput struct {
+	Body RuleResponse
+}
+
+// RuleDiffOutput is the response for a rule diff.
+type RuleDiffOutput struct {
+	Body DiffResponse
+}
+
+// RegisterRuleRoutes registers the Federal Register rule endpoints.
+func RegisterRuleRoutes(api huma.API, s *RuleService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-rules",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/rules",
+		Summary:     "List rules",
+		Description: "Lists proposed and final Federal Register rules, newest first",
+		Errors:      []int{http.StatusInternalServerError},
+		Tags:        []string{"Rules"},
+	}, func(ctx context.Context, input *ListRulesInput) (*ListRulesOutput, error) {
+		rules, err := s.ListRules(ctx, RuleListParams{
+			Agency:   input.Agency,
+			RuleType: input.RuleType,
+			RIN:      input.RIN,
+			Query:    input.Query,
+			Limit:    input.Limit,
+			Offset:   input.Offset,
+		})
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list rules: " + err.Error())
+		}
+		return &ListRulesOutput{Body: *rules}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-rule",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/rules/{id}",
+		Summary:     "Get a rule",
+		Description: "Returns a rule and the ID of the proposed or final rule it is diffed against",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Rules"},
+	}, func(ctx context.Context, input *GetRuleInput) (*GetRuleOutput, error) {
+		rule, err := s.GetRule(ctx, input.ID)
+		if err != nil {
+			return nil, serviceError(err, "failed to get rule")
+		}
+		return &GetRuleOutput{Body: *rule}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "diff-rule",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/rules/{id}/diff",
+		Summary:     "Diff proposed and final rule",
+		Description: "Diffs the proposed rule and the final rule sharing a RIN (or docket) with this rule, " +
+			"from proposed to final. fromVersion and toVersion are document numbers.",
+		Errors: []int{http.StatusNotFound, http.StatusUnprocessableEntity},
+		Tags:   []string{"Rules"},
+	}, func(ctx context.Context, input *GetRuleInput) (*RuleDiffOutput, error) {
+		diff, err := s.DiffRule(ctx, input.ID)
+		if err != nil {
+			return nil, serviceError(err, "failed to diff rule")
+		}
+		return &RuleDiffOutput{Body: *diff}, nil
+	})
+}
@@ -0,0 +1,90 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/api"
+	"github.com/drewjst/deltagov/internal/congresstest"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// TestSearchNumber verifies bills are found by their number as published,
+// including state bills with suffixed numbers, whose BillNumber is 0.
+func TestSearchNumber(t *testing.T) {
+	ts := newTestServer(t, "")
+	federal := createBill(t, ts.db, 12)
+	lettered := models.Bill{BillType: "AB", Number: "12A", StateCode: "ca", Session: "20252026",
+		Title: "Water Act", Jurisdiction: models.JurisdictionState}
+	if err := ts.db.Create(&lettered).Error; err != nil {
+		t.Fatalf("Failed to create bill: %v", err)
+	}
+
+	tests := []struct {
+		query string
+		want  []uint
+	}{
+		{"number=12", []uint{federal.ID}},
+		{"number=12A", []uint{lettered.ID}},
+		{"number=12a&type=ab&state=CA", []uint{lettered.ID}},
+		{"number=12B", nil},
+	}
+	for _, tt := range tests {
+		status, body := ts.request(t, http.MethodGet, "/api/v1/lex?"+tt.query, nil, nil)
+		if status != http.StatusOK {
+			t.Fatalf("GET /api/v1/lex?%s: status = %d, want 200: %s", tt.query, status, body)
+		}
+		var result api.LexSearchResult
+		decodeJSON(t, body, &result)
+		var got []uint
+		for _, b := range result.Bills {
+			got = append(got, b.ID)
+		}
+		if len(got) != len(tt.want) || len(got) > 0 && got[0] != tt.want[0] {
+			t.Errorf("GET /api/v1/lex?%s = bills %v, want %v", tt.query, got, tt.want)
+		}
+	}
+	if status, body := ts.request(t, http.MethodGet, "/api/v1/lex?number=12-A", nil, nil); status != http.StatusUnprocessableEntity {
+		t.Errorf("Malformed number: status = %d, want 422: %s", status, body)
+	}
+}
+
+// TestRelatedBills verifies related bills that have been ingested are
+// resolved to their IDs and latest versions by their number, and that
+// state bills sharing a number aren't mistaken for them.
+func TestRelatedBills(t *testing.T) {
+	db := congresstest.OpenDB(t)
+	s := api.NewBillService(db, nil)
+	bill := createBill(t, db, 1)
+	companion := models.Bill{Congress: 119, BillType: "S", Number: "5", BillNumber: 5,
+		Title: "Companion Act", Jurisdiction: models.JurisdictionFederal}
+	state := models.Bill{BillType: "S", Number: "7", BillNumber: 7, StateCode: "ca", Session: "20252026",
+		Title: "State Act", Jurisdiction: models.JurisdictionState}
+	for _, b := range []*models.Bill{&companion, &state} {
+		if err := db.Create(b).Error; err != nil {
+			t.Fatalf("Failed to create bill: %v", err)
+		}
+	}
+	related := []models.RelatedBill{
+		{BillID: bill.ID, RelatedCongress: 119, RelatedType: "s", RelatedNumber: 5, IsCompanion: true},
+		{BillID: bill.ID, RelatedCongress: 119, RelatedType: "s", RelatedNumber: 7},
+	}
+	if err := db.Create(&related).Error; err != nil {
+		t.Fatalf("Failed to create related bills: %v", err)
+	}
+
+	got, err := s.GetRelatedBills(context.Background(), bill.ID)
+	if err != nil {
+		t.Fatalf("GetRelatedBills failed: %v", err)
+	}
+	if len(got.Related) != 2 {
+		t.Fatalf("Related = %+v, want both", got.Related)
+	}
+	if r := got.Related[0]; r.BillID == nil || *r.BillID != companion.ID {
+		t.Errorf("Companion = %+v, want it resolved to bill %d", r, companion.ID)
+	}
+	if r := got.Related[1]; r.BillID != nil {
+		t.Errorf("S. 7 = %+v, want it unresolved, not the state bill", r)
+	}
+}
@@ -0,0 +1,220 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/analysis"
+	"github.com/drewjst/deltagov/internal/archive"
+	"github.com/drewjst/deltagov/internal/database"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/provisions"
+)
+
+// Errors returned by TraceSection.
+var (
+	ErrProvisionQueryMissing = errors.New("either text, or versionId and anchor, is required")
+	ErrProvisionTooShort     = fmt.Errorf("provision is too short to trace; it needs at least %d words", provisions.MinWords)
+)
+
+// SectionProvenanceParams is the provision to trace: a text, or a section
+// of a stored version.
+type SectionProvenanceParams struct {
+	Text          string
+	VersionID     uint
+	Anchor        string  // Section anchor in the version, as in version text and diffs
+	MinSimilarity float64 // provisions.DefaultThreshold if zero
+	Limit         int
+}
+
+// SectionAppearance is a section of a stored version with the traced
+// provision's language.
+type SectionAppearance struct {
+	Bill        BillResponse `json:"bill"`
+	VersionID   uint         `json:"versionId"`
+	VersionCode string       `json:"versionCode" example:"IH"`
+	FetchedAt   time.Time    `json:"fetchedAt" doc:"When the version was fetched; the earliest the language is known to have appeared in it"`
+	Section     string       `json:"section" example:"201"`
+	Anchor      string       `json:"anchor" doc:"The section's anchor, as in version text and section diffs"`
+	Heading     string       `json:"heading"`
+	Excerpt     string       `json:"excerpt"`
+	Similarity  float64      `json:"similarity" doc:"Estimated share of wording in common with the provision, at most 1"`
+	Exact       bool         `json:"exact" doc:"Word for word the same, ignoring case, punctuation, and line breaks"`
+}
+
+// SectionProvenanceResult lists where a provision's language appears,
+// earliest first.
+type SectionProvenanceResult struct {
+	Words       int                 `json:"words" doc:"Words in the provision traced"`
+	Origin      *SectionAppearance  `json:"origin,omitempty" doc:"The earliest appearance; absent when there is none"`
+	Appearances []SectionAppearance `json:"appearances"`
+}
+
+// TraceSection finds the sections of stored bill versions with the same
+// language as a provision, earliest first, so omnibus language can be
+// traced to the bill it came from. A provision given by version and
+// anchor is traced to versions fetched before that one. It returns
+// ErrProvisionQueryMissing without a provision, ErrVersionNotFound and
+// ErrSectionNotFound for an unknown version or section, and
+// ErrProvisionTooShort for a provision too short to tell apart from
+// boilerplate.
+func (s *BillService) TraceSection(ctx context.Context, params SectionProvenanceParams) (*SectionProvenanceResult, error) {
+	db := database.ReadReplica(s.db.WithContext(ctx))
+	opts := provisions.TraceOptions{
+		Threshold: params.MinSimilarity,
+		Limit:     params.Limit,
+		Scopes:    []func(*gorm.DB) *gorm.DB{s.scope.Query, visibleBills(ctx)},
+	}
+
+	var text string
+	switch {
+	case strings.TrimSpace(params.Text) != "":
+		text = params.Text
+	case params.VersionID != 0 && params.Anchor != "":
+		version, err := s.visibleVersion(ctx, db, params.VersionID)
+		if err != nil {
+			return nil, err
+		}
+		for _, sec := range analysis.SplitSections(version.PlainText) {
+			if sec.Anchor() == params.Anchor {
+				text = sec.Body
+				break
+			}
+		}
+		if text == "" {
+			return nil, ErrSectionNotFound
+		}
+		opts.Before = &version.FetchedAt
+	default:
+		return nil, ErrProvisionQueryMissing
+	}
+
+	fp := provisions.NewFingerprint(text)
+	if fp.Words < provisions.MinWords {
+		return nil, ErrProvisionTooShort
+	}
+	matches, err := provisions.Trace(ctx, db, fp, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SectionProvenanceResult{Words: fp.Words, Appearances: make([]SectionAppearance, 0, len(matches))}
+	if len(matches) == 0 {
+		return result, nil
+	}
+	billIDs := make([]uint, 0, len(matches))
+	versionIDs := make([]uint, 0, len(matches))
+	for _, m := range matches {
+		billIDs = append(billIDs, m.BillID)
+		versionIDs = append(versionIDs, m.VersionID)
+	}
+	var bills []models.Bill
+	if err := db.Where("id IN ?", billIDs).Find(&bills).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch bills: %w", err)
+	}
+	byID := make(map[uint]*models.Bill, len(bills))
+	for i := range bills {
+		byID[bills[i].ID] = &bills[i]
+	}
+	var versions []models.Version
+	if err := db.Select("id", "version_code").Where("id IN ?", versionIDs).Find(&versions).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch versions: %w", err)
+	}
+	codes := make(map[uint]string, len(versions))
+	for _, v := range versions {
+		codes[v.ID] = v.VersionCode
+	}
+
+	for _, m := range matches {
+		bill, ok := byID[m.BillID]
+		if !ok {
+			continue
+		}
+		result.Appearances = append(result.Appearances, SectionAppearance{
+			Bill:        billListResponse(bill),
+			VersionID:   m.VersionID,
+			VersionCode: codes[m.VersionID],
+			FetchedAt:   m.FetchedAt,
+			Section:     m.Section,
+			Anchor:      m.Anchor,
+			Heading:     m.Heading,
+			Excerpt:     m.Excerpt,
+			Similarity:  m.Similarity,
+			Exact:       m.Exact,
+		})
+	}
+	if len(result.Appearances) > 0 {
+		result.Origin = &result.Appearances[0]
+	}
+	return result, nil
+}
+
+// visibleVersion loads a bill version's text, returning
+// ErrVersionNotFound for documents' versions and versions of bills the
+// caller can't see.
+func (s *BillService) visibleVersion(ctx context.Context, db *gorm.DB, versionID uint) (*models.Version, error) {
+	var version models.Version
+	if err := db.Select(append([]string{"id", "bill_id", "fetched_at"}, archive.TextColumns...)).
+		First(&version, versionID).Error; err != nil {
+		return nil, versionLookupError(err)
+	}
+	var visible int64
+	if err := db.Model(&models.Bill{}).Where("bills.id = ?", version.BillID).
+		Scopes(visibleBills(ctx)).Count(&visible).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch bill: %w", err)
+	}
+	if visible == 0 {
+		return nil, ErrVersionNotFound
+	}
+	if err := archive.Rehydrate(&version); err != nil {
+		return nil, err
+	}
+	return &version, nil
+}
+
+// SectionProvenanceInput is the request to trace a provision.
+type SectionProvenanceInput struct {
+	Text          string  `query:"text" maxLength:"20000" doc:"Provision text to trace"`
+	VersionID     uint    `query:"versionId" doc:"Or trace a section of this version, given by anchor, to versions fetched before it"`
+	Anchor        string  `query:"anchor" doc:"Section anchor in versionId, as in version text and diffs" example:"sec-201-3f2a9c1b"`
+	MinSimilarity float64 `query:"minSimilarity" default:"0.8" minimum:"0.5" maximum:"1" doc:"Least estimated share of wording in common for a section to count"`
+	Limit         int     `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"Most appearances returned (max 100)"`
+}
+
+// SectionProvenanceOutput is the response to tracing a provision.
+type SectionProvenanceOutput struct {
+	Body SectionProvenanceResult
+}
+
+// registerSectionProvenanceRoute registers the provision tracing
+// endpoint.
+func registerSectionProvenanceRoute(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "trace-section-provenance",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/provenance/section",
+		Summary:     "Trace a provision across bills",
+		Description: "Finds the sections of stored bill versions with the same language as a provision — word for word or nearly, regardless of numbering and formatting — earliest first, showing where language in an omnibus or package originated. Give the provision as text, or as versionId and the section's anchor to trace it to earlier versions. Sections are fingerprinted by the ingestor, so recently fetched versions may not appear yet.",
+		Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *SectionProvenanceInput) (*SectionProvenanceOutput, error) {
+		result, err := s.TraceSection(ctx, SectionProvenanceParams{
+			Text:          input.Text,
+			VersionID:     input.VersionID,
+			Anchor:        input.Anchor,
+			MinSimilarity: input.MinSimilarity,
+			Limit:         input.Limit,
+		})
+		if err != nil {
+			return nil, serviceError(err, "failed to trace provision")
+		}
+		return &SectionProvenanceOutput{Body: *result}, nil
+	})
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/database"
+	"github.com/drewjst/deltagov/internal/embeddings"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// Errors returned by SemanticSearch.
+var (
+	ErrEmbeddingsDisabled   = errors.New("semantic search is not configured")
+	ErrEmbeddingsFailed     = errors.New("failed to embed query")
+	ErrSemanticQueryMissing = errors.New("either q or billId is required")
+	ErrBillNotEmbedded      = errors.New("bill has not been embedded yet")
+)
+
+// semanticCandidates is how many nearest summaries and sections are
+// fetched per result requested, before they are grouped by bill.
+const semanticCandidates = 4
+
+// SemanticSearchParams is a semantic search by text or by bill.
+type SemanticSearchParams struct {
+	Query  string // Text to find bills and sections about
+	BillID uint   // Or a bill to find others like; its own results are excluded
+	Kind   string // models.EmbeddingKindSummary or EmbeddingKindSection; "" searches both
+	Limit  int
+}
+
+// SemanticBill is a bill conceptually similar to the query.
+type SemanticBill struct {
+	Bill       BillResponse `json:"bill"`
+	Similarity float64      `json:"similarity" doc:"Cosine similarity of the bill's closest summary or section to the query, at most 1"`
+}
+
+// SemanticMatch is a summary or section conceptually similar to the query.
+type SemanticMatch struct {
+	BillID     uint    `json:"billId"`
+	Kind       string  `json:"kind" enum:"summary,section"`
+	VersionID  *uint   `json:"versionId,omitempty" doc:"Sections: the version the section is from"`
+	Section    string  `json:"section,omitempty" doc:"Sections: the section number"`
+	Anchor     string  `json:"anchor,omitempty" doc:"Sections: the section's anchor, as in version text and section diffs"`
+	Heading    string  `json:"heading,omitempty" doc:"Sections: the heading; summaries: the action summarized"`
+	Excerpt    string  `json:"excerpt"`
+	Similarity float64 `json:"similarity"`
+}
+
+// SemanticSearchResult lists the bills and the summaries and sections most
+// similar to a query, most similar first.
+type SemanticSearchResult struct {
+	Model   string          `json:"model" doc:"Embedding provider and model that ranked the results"`
+	Bills   []SemanticBill  `json:"bills"`
+	Matches []SemanticMatch `json:"matches"`
+}
+
+// SemanticSearch finds the bills, summaries, and sections closest in
+// meaning to a text, or to a bill. It returns ErrEmbeddingsDisabled when
+// no provider is configured, ErrEmbeddingsFailed when the provider fails,
+// ErrBillNotFound for an unknown bill, and ErrBillNotEmbedded for a bill
+// the ingestor hasn't embedded yet.
+func (s *BillService) SemanticSearch(ctx context.Context, params SemanticSearchParams) (*SemanticSearchResult, error) {
+	if s.embeddings == nil {
+		return nil, ErrEmbeddingsDisabled
+	}
+	query := strings.TrimSpace(params.Query)
+	if query == "" && params.BillID == 0 {
+		return nil, ErrSemanticQueryMissing
+	}
+	db := database.ReadReplica(s.db.WithContext(ctx))
+
+	var vector models.Vector
+	if query != "" {
+		vectors, err := s.embeddings.Embed(ctx, []string{query})
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrEmbeddingsFailed, err)
+		}
+		vector = vectors[0]
+	} else {
+		// Other tenants' drafts are missing to the caller, as elsewhere
+		var visible int64
+		if err := db.Model(&models.Bill{}).Scopes(visibleBills(ctx)).
+			Where("bills.id = ?", params.BillID).Count(&visible).Error; err != nil {
+			return nil, fmt.Errorf("failed to fetch bill: %w", err)
+		}
+		if visible == 0 {
+			return nil, ErrBillNotFound
+		}
+		v, err := embeddings.BillVector(ctx, db, s.embeddings, params.BillID)
+		if errors.Is(err, embeddings.ErrNotEmbedded) {
+			return nil, ErrBillNotEmbedded
+		}
+		if err != nil {
+			return nil, err
+		}
+		vector = v
+	}
+
+	filters := []func(*gorm.DB) *gorm.DB{s.scope.Query, visibleBills(ctx)}
+	if params.Kind != "" {
+		filters = append(filters, func(db *gorm.DB) *gorm.DB { return db.Where("embeddings.kind = ?", params.Kind) })
+	}
+	if params.BillID != 0 && query == "" {
+		filters = append(filters, func(db *gorm.DB) *gorm.DB { return db.Where("embeddings.bill_id <> ?", params.BillID) })
+	}
+	matches, err := embeddings.Nearest(ctx, db, s.embeddings, vector, params.Limit*semanticCandidates, filters...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SemanticSearchResult{
+		Model:   s.embeddings.Name(),
+		Bills:   []SemanticBill{},
+		Matches: make([]SemanticMatch, 0, min(len(matches), params.Limit)),
+	}
+	var billIDs []uint
+	best := map[uint]float64{}
+	for _, m := range matches {
+		if _, seen := best[m.BillID]; !seen {
+			if len(billIDs) < params.Limit {
+				billIDs = append(billIDs, m.BillID)
+			}
+			best[m.BillID] = m.Similarity
+		}
+		if len(result.Matches) < params.Limit {
+			match := SemanticMatch{
+				BillID:     m.BillID,
+				Kind:       m.Kind,
+				VersionID:  m.VersionID,
+				Section:    m.Section,
+				Heading:    m.Heading,
+				Excerpt:    m.Excerpt,
+				Similarity: m.Similarity,
+			}
+			if m.Kind == models.EmbeddingKindSection {
+				match.Anchor = m.Ref
+			}
+			result.Matches = append(result.Matches, match)
+		}
+	}
+	if len(billIDs) == 0 {
+		return result, nil
+	}
+
+	var bills []models.Bill
+	if err := db.Where("id IN ?", billIDs).Find(&bills).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch bills: %w", err)
+	}
+	byID := make(map[uint]*models.Bill, len(bills))
+	for i := range bills {
+		byID[bills[i].ID] = &bills[i]
+	}
+	for _, id := range billIDs {
+		if bill, ok := byID[id]; ok {
+			result.Bills = append(result.Bills, SemanticBill{Bill: billListResponse(bill), Similarity: best[id]})
+		}
+	}
+	return result, nil
+}
+
+// SemanticSearchInput is the request for a semantic search.
+type SemanticSearchInput struct {
+	Query  string `query:"q" maxLength:"2000" doc:"Text describing what to find, in plain language" example:"housing assistance for veterans"`
+	BillID uint   `query:"billId" doc:"Find bills like this one instead: bills whose summaries and sections are closest to its summary"`
+	Kind   string `query:"kind" enum:"summary,section" doc:"Match only CRS summaries or only sections of bill text. Default: both"`
+	Limit  int    `query:"limit" default:"10" minimum:"1" maximum:"50" doc:"Number of bills, and of matches (max 50)"`
+}
+
+// SemanticSearchOutput is the response for a semantic search.
+type SemanticSearchOutput struct {
+	Body SemanticSearchResult
+}
+
+// registerSemanticSearchRoute registers the semantic search endpoint.
+func registerSemanticSearchRoute(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "search-semantic",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/search/semantic",
+		Summary:     "Find conceptually similar bills and sections",
+		Description: "Ranks bills, CRS summaries, and sections of bill text by similarity in meaning to q, or to the bill given by billId (\"find bills like this one\"), using the embeddings the ingestor computes of each bill's latest summary and latest text. Returns 501 when no embedding provider is configured.",
+		Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusNotImplemented, http.StatusBadGateway},
+		Tags:        []string{"Search"},
+	}, func(ctx context.Context, input *SemanticSearchInput) (*SemanticSearchOutput, error) {
+		result, err := s.SemanticSearch(ctx, SemanticSearchParams{
+			Query:  input.Query,
+			BillID: input.BillID,
+			Kind:   input.Kind,
+			Limit:  input.Limit,
+		})
+		if err != nil {
+			return nil, serviceError(err, "semantic search failed")
+		}
+		return &SemanticSearchOutput{Body: *result}, nil
+	})
+}
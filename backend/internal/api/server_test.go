@@ -0,0 +1,146 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/adapters/humafiber"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/api"
+	"github.com/drewjst/deltagov/internal/congresstest"
+	"github.com/drewjst/deltagov/internal/embeddings"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// testServer is the API wired as cmd/api wires it, over a SQLite database,
+// without a Congress client, sign-in, or live events. Semantic search uses
+// the hashing provider, though searching by text needs pgvector.
+type testServer struct {
+	app *fiber.App
+	db  *gorm.DB
+}
+
+// newTestServer starts a test server whose admin endpoints accept
+// adminToken; an empty token disables it.
+func newTestServer(t *testing.T, adminToken string) *testServer {
+	t.Helper()
+	db := congresstest.OpenDB(t)
+	app := fiber.New()
+	humaAPI := humafiber.New(app, api.NewConfig())
+	api.ConfigureOpenAPI(humaAPI, "")
+
+	billService := api.NewBillService(db, nil)
+	billService.SetEmbeddings(embeddings.NewHashing(16))
+	adminService := api.NewAdminService(db, adminToken)
+	t.Cleanup(adminService.Close)
+	authService := api.NewAuthService(db, nil, nil)
+	tenantService := api.NewTenantService(db, billService)
+	// Requests made with app.Test have no server, so their contexts report
+	// themselves canceled; handlers get one that can't be
+	humaAPI.UseMiddleware(func(ctx huma.Context, next func(huma.Context)) {
+		next(huma.WithContext(ctx, context.WithoutCancel(ctx.Context())))
+	})
+	humaAPI.UseMiddleware(authService.Sessions(humaAPI))
+	humaAPI.UseMiddleware(tenantService.Isolation(humaAPI))
+	humaAPI.UseMiddleware(adminService.Audit(humaAPI))
+	humaAPI.UseMiddleware(adminService.Authorization(humaAPI))
+
+	api.RegisterRoutesWithService(humaAPI, api.NewRouteHandler(billService))
+	api.RegisterAdminRoutes(humaAPI, adminService)
+	api.RegisterAuthRoutes(humaAPI, authService)
+	api.RegisterWatchlistRoutes(humaAPI, api.NewWatchlistService(db, billService))
+	api.RegisterTenantRoutes(humaAPI, tenantService)
+	api.RegisterDocumentRoutes(humaAPI, api.NewDocumentService(db, billService))
+	api.RegisterExportRoutes(humaAPI, billService)
+	api.RegisterExecutiveRoutes(humaAPI, api.NewExecutiveService(db))
+
+	return &testServer{app: app, db: db}
+}
+
+// request sends a request with a JSON body, unless body is nil, and
+// returns the response status and body.
+func (ts *testServer) request(t *testing.T, method, path string, header http.Header, body any) (int, []byte) {
+	t.Helper()
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("Failed to encode request body: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	req := httptest.NewRequest(method, path, reader)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := ts.app.Test(req, -1)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read %s %s response: %v", method, path, err)
+	}
+	return resp.StatusCode, data
+}
+
+// addUser stores a user with a role, optionally in a tenant, and returns
+// its API key.
+func (ts *testServer) addUser(t *testing.T, role string, tenantID *uint) string {
+	t.Helper()
+	var count int64
+	ts.db.Model(&models.User{}).Count(&count)
+	key := fmt.Sprintf("dg_test_%s_%d", role, count+1)
+	sum := sha256.Sum256([]byte(key))
+	user := models.User{Name: role, APIKeyHash: hex.EncodeToString(sum[:]), Role: role, TenantID: tenantID}
+	if err := ts.db.Create(&user).Error; err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	return key
+}
+
+// apiKey returns a header sending an API key.
+func apiKey(key string) http.Header {
+	return http.Header{"X-Api-Key": {key}}
+}
+
+// bearer returns a header sending a bearer token.
+func bearer(token string) http.Header {
+	return http.Header{"Authorization": {"Bearer " + token}}
+}
+
+// errorCode returns the code of an error response body.
+func errorCode(t *testing.T, body []byte) string {
+	t.Helper()
+	var model struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(body, &model); err != nil {
+		t.Fatalf("Failed to decode error %s: %v", body, err)
+	}
+	return model.Code
+}
+
+// decodeJSON decodes a JSON response body into v.
+func decodeJSON(t *testing.T, body []byte, v any) {
+	t.Helper()
+	if err := json.Unmarshal(body, v); err != nil {
+		t.Fatalf("Failed to decode %s: %v", body, err)
+	}
+}
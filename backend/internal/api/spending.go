@@ -0,0 +1,170 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/analysis"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ErrVersionNotFound is returned when a version doesn't exist or belongs to another bill.
+var ErrVersionNotFound = errors.New("version not found")
+
+// ErrNotEnoughVersions is returned when a comparison needs two versions but the bill has fewer.
+var ErrNotEnoughVersions = errors.New("bill has fewer than two versions")
+
+// SpendingChangesResponse compares the dollar amounts in two versions of a bill.
+type SpendingChangesResponse struct {
+	BillID           uint                      `json:"billId"`
+	FromVersionID    uint                      `json:"fromVersionId"`
+	ToVersionID      uint                      `json:"toVersionId"`
+	FromVersion      string                    `json:"fromVersion"`
+	ToVersion        string                    `json:"toVersion"`
+	FromTotal        int64                     `json:"fromTotal"`
+	ToTotal          int64                     `json:"toTotal"`
+	NetChange        int64                     `json:"netChange"`
+	AmountsAdded     int                       `json:"amountsAdded"`
+	AmountsRemoved   int                       `json:"amountsRemoved"`
+	AmountsChanged   int                       `json:"amountsChanged"`
+	AmountsUnchanged int                       `json:"amountsUnchanged"`
+	Changes          []analysis.SpendingChange `json:"changes"`
+}
+
+// GetSpendingChanges compares the dollar amounts in two versions of a bill.
+// When both version IDs are zero, the two most recent versions are compared.
+// Unchanged amounts are omitted unless includeUnchanged is set.
+func (s *BillService) GetSpendingChanges(ctx context.Context, billID, fromID, toID uint, includeUnchanged bool) (*SpendingChangesResponse, error) {
+	db := s.db.WithContext(ctx)
+
+	var bill models.Bill
+	if err := db.Select("id").First(&bill, billID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBillNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch bill: %w", err)
+	}
+
+	if fromID == 0 && toID == 0 {
+		versions, err := s.versionsInOrder(ctx, billID)
+		if err != nil {
+			return nil, err
+		}
+		if len(versions) < 2 {
+			return nil, ErrNotEnoughVersions
+		}
+		fromID = versions[len(versions)-2].ID
+		toID = versions[len(versions)-1].ID
+	}
+
+	from, err := s.spendingItemsForVersion(ctx, billID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.spendingItemsForVersion(ctx, billID, toID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SpendingChangesResponse{
+		BillID:        billID,
+		FromVersionID: fromID,
+		ToVersionID:   toID,
+		FromVersion:   from.versionCode,
+		ToVersion:     to.versionCode,
+		Changes:       make([]analysis.SpendingChange, 0),
+	}
+
+	for _, change := range analysis.CompareSpending(from.items, to.items) {
+		response.FromTotal += change.FromAmount
+		response.ToTotal += change.ToAmount
+
+		switch change.Status {
+		case analysis.SpendingAdded:
+			response.AmountsAdded++
+		case analysis.SpendingRemoved:
+			response.AmountsRemoved++
+		case analysis.SpendingChanged:
+			response.AmountsChanged++
+		case analysis.SpendingUnchanged:
+			response.AmountsUnchanged++
+			if !includeUnchanged {
+				continue
+			}
+		}
+		response.Changes = append(response.Changes, change)
+	}
+	response.NetChange = response.ToTotal - response.FromTotal
+
+	return response, nil
+}
+
+// versionSpending is a version's code and extracted spending items.
+type versionSpending struct {
+	versionCode string
+	items       []analysis.SpendingItem
+}
+
+// spendingItemsForVersion loads a version's stored spending items, extracting
+// and storing them first if the version hasn't been analyzed yet.
+func (s *BillService) spendingItemsForVersion(ctx context.Context, billID, versionID uint) (*versionSpending, error) {
+	db := s.db.WithContext(ctx)
+
+	var version models.Version
+	if err := db.Select("id", "bill_id", "version_code").First(&version, versionID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrVersionNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch version: %w", err)
+	}
+	if version.BillID != billID {
+		return nil, ErrVersionNotFound
+	}
+
+	var stored []models.SpendingItem
+	if err := db.Where("version_id = ?", versionID).Order("\"offset\" ASC").Find(&stored).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch spending items: %w", err)
+	}
+
+	if len(stored) == 0 {
+		extracted, err := extractForVersion(ctx, s, versionID, analysis.ExtractSpendingItems,
+			func(db *gorm.DB, extracted []analysis.SpendingItem) error {
+				rows := make([]models.SpendingItem, len(extracted))
+				for i, item := range extracted {
+					rows[i] = models.SpendingItem{
+						VersionID: versionID,
+						Key:       item.Key,
+						Section:   item.Section,
+						Account:   item.Account,
+						Context:   item.Context,
+						Raw:       item.Amount.Raw,
+						Amount:    item.Amount.Value,
+						Offset:    item.Amount.Offset,
+					}
+				}
+				if err := db.CreateInBatches(rows, 500).Error; err != nil {
+					return fmt.Errorf("failed to store spending items: %w", err)
+				}
+				return nil
+			})
+		if err != nil {
+			return nil, err
+		}
+		return &versionSpending{versionCode: version.VersionCode, items: extracted}, nil
+	}
+
+	items := make([]analysis.SpendingItem, len(stored))
+	for i, row := range stored {
+		items[i] = analysis.SpendingItem{
+			Key:     row.Key,
+			Section: row.Section,
+			Account: row.Account,
+			Context: row.Context,
+			Amount:  analysis.DollarAmount{Raw: row.Raw, Value: row.Amount, Offset: row.Offset},
+		}
+	}
+	return &versionSpending{versionCode: version.VersionCode, items: items}, nil
+}
@@ -0,0 +1,248 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/stats"
+	"github.com/drewjst/deltagov/internal/versioncode"
+)
+
+// stageNames are the status names of the legislative stages in
+// BillsByStatusResponse.
+var stageNames = map[int]string{
+	versioncode.StageIntroduced: "introduced",
+	versioncode.StageReferred:   "referred",
+	versioncode.StageReported:   "reported",
+	versioncode.StagePassed:     "passed_one_chamber",
+	versioncode.StageAmended:    "amended_by_second_chamber",
+	versioncode.StageEnrolled:   "enrolled",
+	versioncode.StageEnacted:    "enacted",
+}
+
+// StatsService serves the dashboard aggregates, read from the
+// materialized views package stats maintains.
+type StatsService struct {
+	db *gorm.DB
+}
+
+// NewStatsService creates a new StatsService instance.
+func NewStatsService(db *gorm.DB) *StatsService {
+	return &StatsService{db: db}
+}
+
+// BillsByStatusResponse counts public federal bills by legislative stage
+// in each congress.
+type BillsByStatusResponse struct {
+	Congresses []CongressStatusCounts `json:"congresses"` // Newest first
+	ComputedAt *time.Time             `json:"computedAt,omitempty"`
+}
+
+// CongressStatusCounts counts one congress's bills by stage.
+type CongressStatusCounts struct {
+	Congress int           `json:"congress"`
+	Total    int           `json:"total"`
+	Statuses []StatusCount `json:"statuses"` // In legislative order
+}
+
+// StatusCount is the number of bills whose furthest stage is Status.
+type StatusCount struct {
+	Status string `json:"status" enum:"introduced,referred,reported,passed_one_chamber,amended_by_second_chamber,enrolled,enacted"`
+	Stage  int    `json:"stage"`
+	Bills  int    `json:"bills"`
+}
+
+// VersionsPerDayResponse counts bill versions ingested per day.
+type VersionsPerDayResponse struct {
+	Days       []VersionsPerDay `json:"days"` // Oldest first; days without versions are omitted
+	ComputedAt *time.Time       `json:"computedAt,omitempty"`
+}
+
+// VersionsPerDay is the number of versions ingested on one day.
+type VersionsPerDay struct {
+	Date     string `json:"date"` // YYYY-MM-DD
+	Versions int    `json:"versions"`
+	Bills    int    `json:"bills"` // Distinct bills those versions belong to
+}
+
+// DiffSizeByTypeResponse is the average size of stored diffs by bill
+// type.
+type DiffSizeByTypeResponse struct {
+	BillTypes  []DiffSizeByType `json:"billTypes"` // Largest average first
+	ComputedAt *time.Time       `json:"computedAt,omitempty"`
+}
+
+// DiffSizeByType is the average size of the stored diffs of one bill
+// type's versions.
+type DiffSizeByType struct {
+	BillType        string  `json:"billType"`
+	Diffs           int     `json:"diffs"`
+	AvgLinesChanged float64 `json:"avgLinesChanged"`
+	AvgInsertions   float64 `json:"avgInsertions"`
+	AvgDeletions    float64 `json:"avgDeletions"`
+}
+
+// BillsByStatus returns bill counts by stage per congress, for one
+// congress when congress is positive.
+func (s *StatsService) BillsByStatus(ctx context.Context, congress int) (*BillsByStatusResponse, error) {
+	var rows []struct {
+		Congress   int
+		Stage      int
+		Bills      int
+		ComputedAt time.Time
+	}
+	query := s.db.WithContext(ctx).Table(stats.ViewBillsByStatus)
+	if congress > 0 {
+		query = query.Where("congress = ?", congress)
+	}
+	if err := query.Order("congress DESC, stage ASC").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch bills by status: %w", err)
+	}
+
+	response := &BillsByStatusResponse{Congresses: []CongressStatusCounts{}}
+	for _, r := range rows {
+		n := len(response.Congresses)
+		if n == 0 || response.Congresses[n-1].Congress != r.Congress {
+			response.Congresses = append(response.Congresses, CongressStatusCounts{Congress: r.Congress})
+			n++
+		}
+		c := &response.Congresses[n-1]
+		c.Total += r.Bills
+		c.Statuses = append(c.Statuses, StatusCount{Status: stageNames[r.Stage], Stage: r.Stage, Bills: r.Bills})
+		response.ComputedAt = &r.ComputedAt
+	}
+	return response, nil
+}
+
+// VersionsPerDay returns the versions ingested on each of the last days
+// days.
+func (s *StatsService) VersionsPerDay(ctx context.Context, days int) (*VersionsPerDayResponse, error) {
+	var rows []struct {
+		Day        time.Time
+		Versions   int
+		Bills      int
+		ComputedAt time.Time
+	}
+	since := time.Now().UTC().AddDate(0, 0, -days+1).Format(time.DateOnly)
+	if err := s.db.WithContext(ctx).Table(stats.ViewVersionsPerDay).
+		Where("day >= ?", since).Order("day ASC").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch versions per day: %w", err)
+	}
+
+	response := &VersionsPerDayResponse{Days: make([]VersionsPerDay, len(rows))}
+	for i, r := range rows {
+		response.Days[i] = VersionsPerDay{Date: r.Day.Format(time.DateOnly), Versions: r.Versions, Bills: r.Bills}
+		response.ComputedAt = &rows[i].ComputedAt
+	}
+	return response, nil
+}
+
+// DiffSizeByType returns the average stored diff size of each bill type.
+func (s *StatsService) DiffSizeByType(ctx context.Context) (*DiffSizeByTypeResponse, error) {
+	var rows []struct {
+		BillType        string
+		Diffs           int
+		AvgLinesChanged float64
+		AvgInsertions   float64
+		AvgDeletions    float64
+		ComputedAt      time.Time
+	}
+	if err := s.db.WithContext(ctx).Table(stats.ViewDiffSizeByType).
+		Order("avg_lines_changed DESC, bill_type ASC").Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch diff sizes: %w", err)
+	}
+
+	response := &DiffSizeByTypeResponse{BillTypes: make([]DiffSizeByType, len(rows))}
+	for i, r := range rows {
+		response.BillTypes[i] = DiffSizeByType{
+			BillType:        r.BillType,
+			Diffs:           r.Diffs,
+			AvgLinesChanged: r.AvgLinesChanged,
+			AvgInsertions:   r.AvgInsertions,
+			AvgDeletions:    r.AvgDeletions,
+		}
+		response.ComputedAt = &rows[i].ComputedAt
+	}
+	return response, nil
+}
+
+// BillsByStatusInput is the request for bill counts by status
+type BillsByStatusInput struct {
+	Congress int `query:"congress" minimum:"0" doc:"Only this congress (default: every congress)" example:"119"`
+}
+
+// BillsByStatusOutput is the response for bill counts by status
+type BillsByStatusOutput struct {
+	Body BillsByStatusResponse
+}
+
+// VersionsPerDayInput is the request for versions ingested per day
+type VersionsPerDayInput struct {
+	Days int `query:"days" default:"30" minimum:"1" maximum:"365" doc:"Number of days, ending today (UTC)"`
+}
+
+// VersionsPerDayOutput is the response for versions ingested per day
+type VersionsPerDayOutput struct {
+	Body VersionsPerDayResponse
+}
+
+// DiffSizeByTypeOutput is the response for diff sizes by bill type
+type DiffSizeByTypeOutput struct {
+	Body DiffSizeByTypeResponse
+}
+
+// RegisterStatsRoutes registers the dashboard aggregate endpoints.
+func RegisterStatsRoutes(api huma.API, s *StatsService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-bills-by-status",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/stats/bills-by-status",
+		Summary:     "Count bills by status per congress",
+		Description: "Counts public federal bills by the furthest legislative stage their text has reached, per congress. Bills without published text count as introduced, and bills with a law number as enacted. Refreshed by the ingestor after each run; computedAt says when.",
+		Errors:      []int{http.StatusInternalServerError},
+		Tags:        []string{"Stats"},
+	}, func(ctx context.Context, input *BillsByStatusInput) (*BillsByStatusOutput, error) {
+		counts, err := s.BillsByStatus(ctx, input.Congress)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to count bills: " + err.Error())
+		}
+		return &BillsByStatusOutput{Body: *counts}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-versions-per-day",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/stats/versions-per-day",
+		Summary:     "Count versions ingested per day",
+		Description: "Counts the bill text versions ingested on each recent day, and the bills they belong to. Refreshed by the ingestor after each run; computedAt says when.",
+		Errors:      []int{http.StatusInternalServerError},
+		Tags:        []string{"Stats"},
+	}, func(ctx context.Context, input *VersionsPerDayInput) (*VersionsPerDayOutput, error) {
+		days, err := s.VersionsPerDay(ctx, input.Days)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to count versions: " + err.Error())
+		}
+		return &VersionsPerDayOutput{Body: *days}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-diff-size-by-type",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/stats/diff-size-by-type",
+		Summary:     "Average diff size by bill type",
+		Description: "Returns the number of stored diffs of each federal bill type and their average lines inserted, deleted, and changed. Refreshed by the ingestor after each run; computedAt says when.",
+		Errors:      []int{http.StatusInternalServerError},
+		Tags:        []string{"Stats"},
+	}, func(ctx context.Context, input *struct{}) (*DiffSizeByTypeOutput, error) {
+		sizes, err := s.DiffSizeByType(ctx)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to compute diff sizes: " + err.Error())
+		}
+		return &DiffSizeByTypeOutput{Body: *sizes}, nil
+	})
+}
@@ -0,0 +1,130 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ErrSummaryNotFound is returned when a bill has no summary for an action date.
+var ErrSummaryNotFound = errors.New("summary not found")
+
+// ErrNotEnoughSummaries is returned when a comparison needs two summaries but the bill has fewer.
+var ErrNotEnoughSummaries = errors.New("bill has fewer than two summaries")
+
+// SummaryResponse is a CRS summary of a bill as of one legislative action.
+type SummaryResponse struct {
+	ID          uint   `json:"id"`
+	VersionCode string `json:"versionCode"` // CRS summary code, e.g., "00"
+	ActionDate  string `json:"actionDate"`  // YYYY-MM-DD
+	ActionDesc  string `json:"actionDesc"`
+	Text        string `json:"text"` // Plain text
+}
+
+// SummariesResponse lists a bill's CRS summaries, oldest first.
+type SummariesResponse struct {
+	BillID    uint              `json:"billId"`
+	Summaries []SummaryResponse `json:"summaries"`
+}
+
+// GetBillSummaries returns a bill's CRS summaries ordered by action date.
+func (s *BillService) GetBillSummaries(ctx context.Context, billID uint) (*SummariesResponse, error) {
+	if err := s.requireBill(ctx, billID); err != nil {
+		return nil, err
+	}
+
+	summaries, err := s.summariesInOrder(ctx, billID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SummariesResponse{
+		BillID:    billID,
+		Summaries: make([]SummaryResponse, len(summaries)),
+	}
+	for i, sum := range summaries {
+		response.Summaries[i] = SummaryResponse{
+			ID:          sum.ID,
+			VersionCode: sum.VersionCode,
+			ActionDate:  sum.ActionDate.Format(time.DateOnly),
+			ActionDesc:  sum.ActionDesc,
+			Text:        sum.PlainText,
+		}
+	}
+	return response, nil
+}
+
+// CompareSummaries diffs the summaries written for the actions on two dates.
+// When both dates are zero, the two most recent summaries are compared. The
+// response's FromVersion and ToVersion are the summaries' version codes.
+func (s *BillService) CompareSummaries(ctx context.Context, billID uint, fromDate, toDate time.Time) (*DiffResponse, error) {
+	if err := s.requireBill(ctx, billID); err != nil {
+		return nil, err
+	}
+
+	summaries, err := s.summariesInOrder(ctx, billID)
+	if err != nil {
+		return nil, err
+	}
+
+	var from, to *models.Summary
+	if fromDate.IsZero() && toDate.IsZero() {
+		if len(summaries) < 2 {
+			return nil, ErrNotEnoughSummaries
+		}
+		from, to = &summaries[len(summaries)-2], &summaries[len(summaries)-1]
+	} else {
+		if from = summaryOn(summaries, fromDate); from == nil {
+			return nil, ErrSummaryNotFound
+		}
+		if to = summaryOn(summaries, toDate); to == nil {
+			return nil, ErrSummaryNotFound
+		}
+	}
+
+	delta, err := diff_engine.ComputeWordLevel(from.PlainText, to.PlainText)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute diff: %w", err)
+	}
+	return diffResponse(delta, from.VersionCode, to.VersionCode), nil
+}
+
+// requireBill returns ErrBillNotFound unless the bill exists.
+func (s *BillService) requireBill(ctx context.Context, billID uint) error {
+	var bill models.Bill
+	if err := s.db.WithContext(ctx).Select("id").First(&bill, billID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrBillNotFound
+		}
+		return fmt.Errorf("failed to fetch bill: %w", err)
+	}
+	return nil
+}
+
+// summariesInOrder returns a bill's summaries, oldest action first.
+func (s *BillService) summariesInOrder(ctx context.Context, billID uint) ([]models.Summary, error) {
+	var summaries []models.Summary
+	if err := s.db.WithContext(ctx).Where("bill_id = ?", billID).
+		Order("action_date ASC, version_code ASC, id ASC").
+		Find(&summaries).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch summaries: %w", err)
+	}
+	return summaries, nil
+}
+
+// summaryOn returns the last summary for an action on date, or nil.
+func summaryOn(summaries []models.Summary, date time.Time) *models.Summary {
+	want := date.Format(time.DateOnly)
+	for i := len(summaries) - 1; i >= 0; i-- {
+		if summaries[i].ActionDate.Format(time.DateOnly) == want {
+			return &summaries[i]
+		}
+	}
+	return nil
+}
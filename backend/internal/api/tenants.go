@@ -0,0 +1,521 @@
+package api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/textextract"
+	"github.com/drewjst/deltagov/internal/textnorm"
+	"github.com/drewjst/deltagov/internal/textstats"
+	"github.com/drewjst/deltagov/internal/textstore"
+)
+
+// draftBillType is the bill type of uploaded drafts.
+const draftBillType = "draft"
+
+// ErrTenantNotFound is returned when a tenant doesn't exist.
+var ErrTenantNotFound = errors.New("tenant not found")
+
+// ErrTenantExists is returned when creating a tenant with a taken name.
+var ErrTenantExists = errors.New("a tenant with this name already exists")
+
+// ErrNotTenantKey is returned when an API key that doesn't belong to a
+// tenant is used to upload drafts.
+var ErrNotTenantKey = errors.New("API key does not belong to a tenant")
+
+// ErrDraftVersionExists is returned when uploading a draft version whose
+// text matches one the draft already has.
+var ErrDraftVersionExists = errors.New("draft already has a version with this text")
+
+// tenantKey is the context key of the caller's tenant ID.
+type tenantKey struct{}
+
+// callerTenant returns the tenant of the request's API key, or 0 for
+// callers without one.
+func callerTenant(ctx context.Context) uint {
+	id, _ := ctx.Value(tenantKey{}).(uint)
+	return id
+}
+
+// visibleBills returns a GORM scope that restricts a query on the bills
+// table to public bills and the caller's tenant's drafts.
+func visibleBills(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	tenant := callerTenant(ctx)
+	return func(db *gorm.DB) *gorm.DB {
+		if tenant == 0 {
+			return db.Where("bills.tenant_id = 0")
+		}
+		return db.Where("bills.tenant_id IN ?", []uint{0, tenant})
+	}
+}
+
+// TenantService handles tenants' draft uploads and isolates their drafts
+// from other callers.
+type TenantService struct {
+	db    *gorm.DB
+	bills *BillService
+}
+
+// NewTenantService creates a new TenantService.
+func NewTenantService(db *gorm.DB, bills *BillService) *TenantService {
+	return &TenantService{db: db, bills: bills}
+}
+
+// TenantResponse is a tenant in API responses.
+type TenantResponse struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// TenantListResponse lists the tenants.
+type TenantListResponse struct {
+	Tenants []TenantResponse `json:"tenants"`
+}
+
+// DraftListResponse lists a tenant's drafts.
+type DraftListResponse struct {
+	Drafts []BillResponse `json:"drafts"`
+}
+
+// Isolation is Huma middleware that resolves the caller's tenant from the
+// X-API-Key header or session token and hides other tenants' drafts: requests for a bill or
+// version by ID get the same 404 as for one that doesn't exist unless it is
+// public or the caller's tenant's. Versions of tracked documents are hidden
+// the same way. Invalid keys are treated as no key; endpoints that require
+// one reject them. It must be installed after AuthService.Sessions and
+// before routes are registered.
+func (s *TenantService) Isolation(api huma.API) func(huma.Context, func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		var tenant uint
+		if user, err := authenticate(ctx.Context(), s.db, ctx.Header("X-API-Key")); err == nil && user.TenantID != nil {
+			tenant = *user.TenantID
+			// Responses may include drafts; shared caches must not serve them to others
+			ctx.AppendHeader("Vary", "X-API-Key")
+		}
+		ctx = huma.WithValue(ctx, tenantKey{}, tenant)
+
+		if err := s.authorizePath(ctx, tenant); err != nil {
+			writeError(api, ctx, err)
+			return
+		}
+		next(ctx)
+	}
+}
+
+// authorizePath checks that the caller may read the bill or version the
+// request's path names, if any, returning ErrBillNotFound or
+// ErrVersionNotFound if not. Admin endpoints may read every bill. Missing
+// bills and versions are left for the endpoint to report.
+func (s *TenantService) authorizePath(ctx huma.Context, tenant uint) error {
+	path := ctx.Operation().Path
+	if strings.HasPrefix(path, "/api/v1/admin/") {
+		return nil
+	}
+
+	db := s.db.WithContext(ctx.Context())
+	hidden := ErrBillNotFound
+	var query *gorm.DB
+	switch {
+	case strings.HasPrefix(path, "/api/v1/versions/{id}"):
+		// Documents' versions are served only by the document endpoints
+		var documents int64
+		if err := db.Model(&models.Version{}).Where("id = ? AND document_id IS NOT NULL", ctx.Param("id")).
+			Count(&documents).Error; err != nil {
+			return fmt.Errorf("failed to authorize request: %w", err)
+		}
+		if documents > 0 {
+			return serviceError(ErrVersionNotFound, "")
+		}
+		hidden = ErrVersionNotFound
+		query = db.Model(&models.Version{}).Joins("JOIN bills ON bills.id = versions.bill_id").
+			Where("versions.id = ?", ctx.Param("id"))
+	case strings.Contains(path, "/bills/{billId}"):
+		query = db.Model(&models.Bill{}).Where("bills.id = ?", ctx.Param("billId"))
+	case strings.Contains(path, "/bills/{id}"):
+		query = db.Model(&models.Bill{}).Where("bills.id = ?", ctx.Param("id"))
+	default:
+		return nil
+	}
+
+	var owners []uint
+	if err := query.Limit(1).Pluck("bills.tenant_id", &owners).Error; err != nil {
+		return fmt.Errorf("failed to authorize request: %w", err)
+	}
+	if len(owners) == 0 || owners[0] == 0 || owners[0] == tenant {
+		return nil
+	}
+	return serviceError(hidden, "")
+}
+
+// tenantUser returns the user owning an API key, which must belong to a
+// tenant.
+func (s *TenantService) tenantUser(ctx context.Context, key string) (*models.User, error) {
+	user, err := authenticate(ctx, s.db, key)
+	if err != nil {
+		return nil, err
+	}
+	if user.TenantID == nil {
+		return nil, ErrNotTenantKey
+	}
+	return user, nil
+}
+
+// ListDrafts returns a tenant's drafts, most recently updated first.
+func (s *TenantService) ListDrafts(ctx context.Context, tenantID uint) (*DraftListResponse, error) {
+	var bills []models.Bill
+	if err := s.db.WithContext(ctx).Where("tenant_id = ?", tenantID).
+		Order("updated_at DESC, id DESC").Find(&bills).Error; err != nil {
+		return nil, fmt.Errorf("failed to list drafts: %w", err)
+	}
+	response := &DraftListResponse{Drafts: make([]BillResponse, len(bills))}
+	for i := range bills {
+		response.Drafts[i] = billListResponse(&bills[i])
+	}
+	return response, nil
+}
+
+// CreateDraft uploads a new draft with its first version. Drafts are
+// numbered per tenant, starting at 1.
+func (s *TenantService) CreateDraft(ctx context.Context, tenantID uint, title, versionCode, text string) (*BillResponse, error) {
+	now := time.Now()
+	bill := models.Bill{
+		TenantID:      tenantID,
+		Jurisdiction:  models.JurisdictionDraft,
+		BillType:      draftBillType,
+		Title:         title,
+		CurrentStatus: "Draft",
+		UpdateDate:    now.Format(time.DateOnly),
+	}
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Locking the tenant serializes numbering of its drafts
+		var tenant models.Tenant
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&tenant, tenantID).Error; err != nil {
+			return fmt.Errorf("failed to lock tenant: %w", err)
+		}
+		if err := tx.Model(&models.Bill{}).Where("tenant_id = ?", tenantID).
+			Select("COALESCE(MAX(bill_number), 0) + 1").Scan(&bill.BillNumber).Error; err != nil {
+			return fmt.Errorf("failed to number draft: %w", err)
+		}
+		bill.Number = strconv.Itoa(bill.BillNumber)
+		if err := tx.Create(&bill).Error; err != nil {
+			return fmt.Errorf("failed to create draft: %w", err)
+		}
+		_, err := s.storeDraftVersion(ctx, tx, &bill, versionCode, text, now)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.bills.GetBillWithVersions(ctx, bill.ID)
+}
+
+// AddDraftVersion uploads a new version of one of a tenant's drafts.
+func (s *TenantService) AddDraftVersion(ctx context.Context, tenantID, billID uint, versionCode, text string) (*VersionResponse, error) {
+	var bill models.Bill
+	if err := s.db.WithContext(ctx).Where("id = ? AND tenant_id = ?", billID, tenantID).First(&bill).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBillNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch draft: %w", err)
+	}
+
+	var version *models.Version
+	now := time.Now()
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var exists int64
+		if err := tx.Model(&models.Version{}).Where("bill_id = ? AND content_hash = ?", bill.ID, textnorm.Hash(text)).
+			Count(&exists).Error; err != nil {
+			return fmt.Errorf("failed to query versions: %w", err)
+		}
+		if exists > 0 {
+			return ErrDraftVersionExists
+		}
+		var err error
+		if version, err = s.storeDraftVersion(ctx, tx, &bill, versionCode, text, now); err != nil {
+			return err
+		}
+		return tx.Model(&bill).Updates(map[string]any{"update_date": now.Format(time.DateOnly), "updated_at": now}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp := versionResponse(version)
+	return &resp, nil
+}
+
+// storeDraftVersion stores a version of a draft, as the ingestor stores
+// fetched text.
+func (s *TenantService) storeDraftVersion(ctx context.Context, tx *gorm.DB, bill *models.Bill, versionCode, text string, at time.Time) (*models.Version, error) {
+	rawHash := sha256.Sum256([]byte(text))
+	version := models.Version{
+		BillID:      bill.ID,
+		VersionCode: strings.ToUpper(versionCode),
+		ContentHash: textnorm.Hash(text),
+		RawHash:     hex.EncodeToString(rawHash[:]),
+		TextContent: text,
+		PlainText:   textextract.Extract(text),
+		FetchedAt:   at,
+	}
+	textstats.Fill(&version)
+	if err := textstore.Offload(ctx, s.bills.texts, bill, &version); err != nil {
+		return nil, fmt.Errorf("failed to store version text: %w", err)
+	}
+	if err := tx.Create(&version).Error; err != nil {
+		return nil, fmt.Errorf("failed to create version: %w", err)
+	}
+	return &version, nil
+}
+
+// CreateTenant creates a tenant.
+func (s *AdminService) CreateTenant(ctx context.Context, name string) (*TenantResponse, error) {
+	tenant := models.Tenant{Name: strings.TrimSpace(name)}
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&tenant)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to create tenant: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrTenantExists
+	}
+	resp := tenantToResponse(&tenant)
+	return &resp, nil
+}
+
+// ListTenants returns every tenant, oldest first.
+func (s *AdminService) ListTenants(ctx context.Context) (*TenantListResponse, error) {
+	var tenants []models.Tenant
+	if err := s.db.WithContext(ctx).Order("id ASC").Find(&tenants).Error; err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	response := &TenantListResponse{Tenants: make([]TenantResponse, len(tenants))}
+	for i := range tenants {
+		response.Tenants[i] = tenantToResponse(&tenants[i])
+	}
+	return response, nil
+}
+
+// CreateTenantUser creates a user with a role in a tenant and returns its
+// API key, which can read the tenant's drafts and, as an editor or admin,
+// upload them.
+func (s *AdminService) CreateTenantUser(ctx context.Context, tenantID uint, name, role string) (*UserResponse, error) {
+	var tenant models.Tenant
+	if err := s.db.WithContext(ctx).Select("id").First(&tenant, tenantID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTenantNotFound
+		}
+		return nil, fmt.Errorf("failed to fetch tenant: %w", err)
+	}
+	return createUser(ctx, s.db, name, role, &tenant.ID)
+}
+
+// tenantToResponse converts a Tenant model to its API response format.
+func tenantToResponse(t *models.Tenant) TenantResponse {
+	return TenantResponse{ID: t.ID, Name: t.Name, CreatedAt: t.CreatedAt}
+}
+
+// ListDraftsOutput is the response for listing drafts
+type ListDraftsOutput struct {
+	Body DraftListResponse
+}
+
+// CreateDraftInput is the request for uploading a draft
+type CreateDraftInput struct {
+	APIKeyInput
+	Body struct {
+		Title       string `json:"title" minLength:"1" maxLength:"1000" doc:"Draft title"`
+		VersionCode string `json:"versionCode,omitempty" maxLength:"16" pattern:"^[A-Za-z0-9]*$" default:"DRAFT" doc:"Code of the first version, e.g., \"V1\""`
+		Text        string `json:"text" minLength:"1" doc:"Bill text: plain text, HTML, or USLM XML"`
+	}
+}
+
+// CreateDraftOutput is the response for uploading a draft
+type CreateDraftOutput struct {
+	Body BillResponse
+}
+
+// AddDraftVersionInput is the request for uploading a draft version
+type AddDraftVersionInput struct {
+	APIKeyInput
+	ID   uint `path:"id" minimum:"1" doc:"Draft (bill) ID"`
+	Body struct {
+		VersionCode string `json:"versionCode" minLength:"1" maxLength:"16" pattern:"^[A-Za-z0-9]+$" doc:"Version code, e.g., \"V2\""`
+		Text        string `json:"text" minLength:"1" doc:"Bill text: plain text, HTML, or USLM XML"`
+	}
+}
+
+// AddDraftVersionOutput is the response for uploading a draft version
+type AddDraftVersionOutput struct {
+	Body VersionResponse
+}
+
+// CreateTenantInput is the request for creating a tenant
+type CreateTenantInput struct {
+	Body struct {
+		Name string `json:"name" minLength:"1" maxLength:"100" pattern:"\\S" doc:"Organization name"`
+	}
+}
+
+// CreateTenantOutput is the response for creating a tenant
+type CreateTenantOutput struct {
+	Body TenantResponse
+}
+
+// ListTenantsOutput is the response for listing tenants
+type ListTenantsOutput struct {
+	Body TenantListResponse
+}
+
+// CreateTenantUserInput is the request for creating a tenant's user
+type CreateTenantUserInput struct {
+	ID   uint `path:"id" minimum:"1" doc:"Tenant ID"`
+	Body struct {
+		Name string `json:"name" minLength:"1" maxLength:"100" doc:"Display name"`
+		Role string `json:"role,omitempty" enum:"reader,editor,admin" default:"editor" doc:"User role; readers can't upload drafts"`
+	}
+}
+
+// RegisterTenantRoutes registers the draft upload endpoints with Huma.
+// Reading drafts uses the bill endpoints with a tenant's API key.
+func RegisterTenantRoutes(api huma.API, s *TenantService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "list-drafts",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/drafts",
+		Summary:     "List drafts",
+		Description: "Returns the drafts of the caller's tenant, most recently updated first",
+		Errors:      []int{http.StatusUnauthorized, http.StatusForbidden},
+		Tags:        []string{"Drafts"},
+	}, func(ctx context.Context, input *APIKeyInput) (*ListDraftsOutput, error) {
+		user, err := s.tenantUser(ctx, input.APIKey)
+		if err != nil {
+			return nil, tenantError(err, "failed to list drafts")
+		}
+		drafts, err := s.ListDrafts(ctx, *user.TenantID)
+		if err != nil {
+			return nil, tenantError(err, "failed to list drafts")
+		}
+		return &ListDraftsOutput{Body: *drafts}, nil
+	})
+
+	huma.Register(api, requireRole(api, huma.Operation{
+		OperationID:   "create-draft",
+		Method:        http.MethodPost,
+		Path:          "/api/v1/drafts",
+		Summary:       "Upload a draft",
+		Description:   "Uploads a private working draft with its first version. Only API keys of the caller's tenant can read it; with one, the bill, version, and diff endpoints serve drafts like public bills, and search includes them. Requires the editor role.",
+		Tags:          []string{"Drafts"},
+		DefaultStatus: http.StatusCreated,
+	}, models.UserRoleEditor), func(ctx context.Context, input *CreateDraftInput) (*CreateDraftOutput, error) {
+		user, err := s.tenantUser(ctx, input.APIKey)
+		if err != nil {
+			return nil, tenantError(err, "failed to create draft")
+		}
+		draft, err := s.CreateDraft(ctx, *user.TenantID, input.Body.Title, input.Body.VersionCode, input.Body.Text)
+		if err != nil {
+			return nil, tenantError(err, "failed to create draft")
+		}
+		auditTarget(ctx, draft.ID)
+		return &CreateDraftOutput{Body: *draft}, nil
+	})
+
+	huma.Register(api, requireRole(api, huma.Operation{
+		OperationID:   "add-draft-version",
+		Method:        http.MethodPost,
+		Path:          "/api/v1/drafts/{id}/versions",
+		Summary:       "Upload a draft version",
+		Description:   "Adds a version to one of the caller's tenant's drafts, to diff against its earlier versions. Requires the editor role.",
+		Errors:        []int{http.StatusNotFound, http.StatusConflict},
+		Tags:          []string{"Drafts"},
+		DefaultStatus: http.StatusCreated,
+	}, models.UserRoleEditor), func(ctx context.Context, input *AddDraftVersionInput) (*AddDraftVersionOutput, error) {
+		user, err := s.tenantUser(ctx, input.APIKey)
+		if err != nil {
+			return nil, tenantError(err, "failed to add draft version")
+		}
+		version, err := s.AddDraftVersion(ctx, *user.TenantID, input.ID, input.Body.VersionCode, input.Body.Text)
+		if err != nil {
+			return nil, tenantError(err, "failed to add draft version")
+		}
+		auditChange(ctx, nil, map[string]any{"version_id": version.ID, "version_code": version.VersionCode, "content_hash": version.ContentHash})
+		return &AddDraftVersionOutput{Body: *version}, nil
+	})
+}
+
+// registerTenantAdminRoutes registers the endpoints for managing tenants.
+func registerTenantAdminRoutes(api huma.API, s *AdminService) {
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID:   "create-tenant",
+		Method:        http.MethodPost,
+		Path:          "/api/v1/admin/tenants",
+		Summary:       "Create a tenant",
+		Description:   "Creates an organization that can upload private drafts. Create its users' API keys with the tenant users endpoint.",
+		Errors:        []int{http.StatusConflict},
+		DefaultStatus: http.StatusCreated,
+	}), func(ctx context.Context, input *CreateTenantInput) (*CreateTenantOutput, error) {
+		tenant, err := s.CreateTenant(ctx, input.Body.Name)
+		if err != nil {
+			return nil, tenantError(err, "failed to create tenant")
+		}
+		auditTarget(ctx, tenant.ID)
+		return &CreateTenantOutput{Body: *tenant}, nil
+	})
+
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID: "list-tenants",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/admin/tenants",
+		Summary:     "List tenants",
+	}), func(ctx context.Context, input *struct{}) (*ListTenantsOutput, error) {
+		tenants, err := s.ListTenants(ctx)
+		if err != nil {
+			return nil, tenantError(err, "failed to list tenants")
+		}
+		return &ListTenantsOutput{Body: *tenants}, nil
+	})
+
+	huma.Register(api, s.adminOperation(api, huma.Operation{
+		OperationID:   "create-tenant-user",
+		Method:        http.MethodPost,
+		Path:          "/api/v1/admin/tenants/{id}/users",
+		Summary:       "Create a tenant user",
+		Description:   "Creates a user in a tenant and returns its API key, which is shown only once. The key reads the tenant's drafts, uploads them unless its role is reader, and works with the watchlist endpoints.",
+		Errors:        []int{http.StatusNotFound},
+		DefaultStatus: http.StatusCreated,
+	}), func(ctx context.Context, input *CreateTenantUserInput) (*CreateUserOutput, error) {
+		user, err := s.CreateTenantUser(ctx, input.ID, input.Body.Name, input.Body.Role)
+		if err != nil {
+			return nil, tenantError(err, "failed to create tenant user")
+		}
+		auditChange(ctx, nil, map[string]any{"user_id": user.ID, "role": user.Role})
+		return &CreateUserOutput{Status: http.StatusCreated, Body: *user}, nil
+	})
+}
+
+// tenantError converts a tenant or draft service error to an HTTP error.
+func tenantError(err error, action string) error {
+	switch {
+	case errors.Is(err, ErrInvalidAPIKey):
+		return authError(err)
+	case errors.Is(err, ErrNotTenantKey):
+		return apiError(http.StatusForbidden, CodeNotTenantKey, err.Error())
+	case errors.Is(err, ErrTenantNotFound):
+		return apiError(http.StatusNotFound, CodeTenantNotFound, err.Error())
+	case errors.Is(err, ErrTenantExists):
+		return apiError(http.StatusConflict, CodeTenantExists, err.Error())
+	case errors.Is(err, ErrDraftVersionExists):
+		return apiError(http.StatusConflict, CodeDraftVersionExists, err.Error())
+	}
+	return serviceError(err, action)
+}
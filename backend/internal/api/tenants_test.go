@@ -0,0 +1,133 @@
+package api_test
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/api"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// createDraft stores a tenant's draft with two versions.
+func createDraft(t *testing.T, ts *testServer, tenantID uint, title string) (*models.Bill, *models.Version, *models.Version) {
+	t.Helper()
+	var count int64
+	ts.db.Model(&models.Bill{}).Where("tenant_id = ?", tenantID).Count(&count)
+	bill := models.Bill{TenantID: tenantID, Jurisdiction: models.JurisdictionDraft, BillType: "draft",
+		Number: fmt.Sprint(count + 1), BillNumber: int(count + 1), Title: title, CurrentStatus: "Draft"}
+	if err := ts.db.Create(&bill).Error; err != nil {
+		t.Fatalf("Failed to create draft: %v", err)
+	}
+	first := createVersion(t, ts.db, &bill, "DRAFT", "SEC. 1. PURPOSE.\nTo fund parks.\n", time.Now().Add(-time.Hour))
+	second := createVersion(t, ts.db, &bill, "DRAFT2", "SEC. 1. PURPOSE.\nTo fund parks and trails.\n", time.Now())
+	return &bill, first, second
+}
+
+// createTenant stores a tenant and returns the API key of an editor in it.
+func createTenant(t *testing.T, ts *testServer, name string) (uint, string) {
+	t.Helper()
+	tenant := models.Tenant{Name: name}
+	if err := ts.db.Create(&tenant).Error; err != nil {
+		t.Fatalf("Failed to create tenant: %v", err)
+	}
+	return tenant.ID, ts.addUser(t, models.UserRoleEditor, &tenant.ID)
+}
+
+// TestTenantIsolation verifies a tenant's drafts are missing to other
+// tenants and to anonymous callers, both by ID, where they get the same 404
+// as for a bill or version that doesn't exist, and in listings.
+func TestTenantIsolation(t *testing.T) {
+	ts := newTestServer(t, "")
+	_, keyA := createTenant(t, ts, "Agency A")
+	tenantB, keyB := createTenant(t, ts, "Agency B")
+	draft, first, second := createDraft(t, ts, tenantB, "Secret Parks Draft")
+	createBill(t, ts.db, 1)
+
+	callers := []struct {
+		name   string
+		header http.Header
+	}{
+		{"other tenant", apiKey(keyA)},
+		{"anonymous", nil},
+		{"invalid key", apiKey("dg_unknown")},
+	}
+
+	byID := []struct {
+		path string
+		code string
+	}{
+		{fmt.Sprintf("/api/v1/bills/%d", draft.ID), "BILL_NOT_FOUND"},
+		{fmt.Sprintf("/api/v1/bills/%d/versions", draft.ID), "BILL_NOT_FOUND"},
+		{fmt.Sprintf("/api/v1/bills/%d/diff/%d/%d", draft.ID, first.ID, second.ID), "BILL_NOT_FOUND"},
+		{fmt.Sprintf("/api/v1/versions/%d/text", second.ID), "VERSION_NOT_FOUND"},
+		{fmt.Sprintf("/api/v1/search/semantic?billId=%d", draft.ID), "BILL_NOT_FOUND"},
+	}
+	for _, tt := range byID {
+		for _, caller := range callers {
+			status, body := ts.request(t, http.MethodGet, tt.path, caller.header, nil)
+			if status != http.StatusNotFound || errorCode(t, body) != tt.code {
+				t.Errorf("%s, GET %s: status = %d, want 404 %s: %s", caller.name, tt.path, status, tt.code, body)
+			}
+		}
+	}
+	// The owner reads them; searching its draft's embeddings needs pgvector
+	for _, tt := range byID {
+		status, body := ts.request(t, http.MethodGet, tt.path, apiKey(keyB), nil)
+		if strings.Contains(tt.path, "semantic") {
+			if status == http.StatusNotFound {
+				t.Errorf("Owner, GET %s: status = 404: %s", tt.path, body)
+			}
+		} else if status != http.StatusOK {
+			t.Errorf("Owner, GET %s: status = %d, want 200: %s", tt.path, status, body)
+		}
+	}
+
+	listings := []string{
+		"/api/v1/lex",
+		"/api/v1/lex?jurisdiction=draft",
+		"/api/v1/bills/search",
+		"/api/v1/export/bills",
+		"/api/v1/export/versions",
+	}
+	for _, path := range listings {
+		for _, caller := range callers {
+			status, body := ts.request(t, http.MethodGet, path, caller.header, nil)
+			if status != http.StatusOK || strings.Contains(string(body), draft.Title) {
+				t.Errorf("%s, GET %s: status = %d, want 200 without the draft: %s", caller.name, path, status, body)
+			}
+		}
+		if path == "/api/v1/export/versions" {
+			continue // Versions are exported without their bill's title
+		}
+		if status, body := ts.request(t, http.MethodGet, path, apiKey(keyB), nil); status != http.StatusOK || !strings.Contains(string(body), draft.Title) {
+			t.Errorf("Owner, GET %s: status = %d, want 200 with the draft: %s", path, status, body)
+		}
+	}
+}
+
+// TestTenantIsolationMissing verifies a hidden draft is reported exactly as
+// a bill that doesn't exist, so its existence isn't revealed.
+func TestTenantIsolationMissing(t *testing.T) {
+	ts := newTestServer(t, "")
+	tenantB, _ := createTenant(t, ts, "Agency B")
+	draft, _, _ := createDraft(t, ts, tenantB, "Secret Parks Draft")
+
+	_, hidden := ts.request(t, http.MethodGet, fmt.Sprintf("/api/v1/bills/%d", draft.ID), nil, nil)
+	_, missing := ts.request(t, http.MethodGet, fmt.Sprintf("/api/v1/bills/%d", draft.ID+100), nil, nil)
+	if errorCode(t, hidden) != errorCode(t, missing) {
+		t.Errorf("Hidden draft reported as %s, missing bill as %s", hidden, missing)
+	}
+
+	var result api.LexSearchResult
+	status, body := ts.request(t, http.MethodGet, "/api/v1/lex?jurisdiction=draft", nil, nil)
+	if status != http.StatusOK {
+		t.Fatalf("Anonymous draft search: status = %d: %s", status, body)
+	}
+	decodeJSON(t, body, &result)
+	if result.Total != 0 {
+		t.Errorf("Anonymous draft search found %d drafts", result.Total)
+	}
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/opensearch"
+)
+
+// Errors returned by SearchText.
+var (
+	ErrSearchBackendDisabled = errors.New("full-text search is not configured")
+	ErrSearchBackendFailed   = errors.New("full-text search failed")
+)
+
+// TextSearchParams is a full-text search; see opensearch.Query.
+type TextSearchParams struct {
+	Query    string
+	Phrase   bool
+	Slop     int
+	Kind     string // One of the opensearch kinds; "" searches all
+	Congress int
+	BillType string
+	BillID   uint
+	Limit    int
+	Offset   int
+}
+
+// TextSearchHit is a bill, version, or action matching a full-text search.
+type TextSearchHit struct {
+	Kind        string   `json:"kind" enum:"bills,versions,actions"`
+	ID          uint     `json:"id" doc:"ID of the bill, version, or bill event"`
+	BillID      uint     `json:"billId"`
+	Congress    int      `json:"congress"`
+	BillType    string   `json:"billType"`
+	BillNumber  int      `json:"billNumber"`
+	Title       string   `json:"title" doc:"The bill's title"`
+	VersionCode string   `json:"versionCode,omitempty" doc:"Versions: the version's code"`
+	EventType   string   `json:"eventType,omitempty" doc:"Actions: the kind of change, as in the bill's events"`
+	Score       float64  `json:"score"`
+	Highlights  []string `json:"highlights,omitempty" doc:"Excerpts around the matches, with matched words wrapped in <mark> tags"`
+}
+
+// TextSearchResult is a page of full-text search hits, best first.
+type TextSearchResult struct {
+	Hits   []TextSearchHit `json:"hits"`
+	Total  int             `json:"total"`
+	Limit  int             `json:"limit"`
+	Offset int             `json:"offset"`
+}
+
+// SearchText searches bills, version text, and actions in the search
+// backend. It returns ErrSearchBackendDisabled when no backend is
+// configured and ErrSearchBackendFailed when the backend fails.
+func (s *BillService) SearchText(ctx context.Context, params TextSearchParams) (*TextSearchResult, error) {
+	if s.searchBackend == nil {
+		return nil, ErrSearchBackendDisabled
+	}
+	q := opensearch.Query{
+		Text:     params.Query,
+		Phrase:   params.Phrase,
+		Slop:     params.Slop,
+		Congress: params.Congress,
+		BillType: params.BillType,
+		BillID:   params.BillID,
+		Limit:    params.Limit,
+		Offset:   params.Offset,
+	}
+	if params.Kind != "" {
+		q.Kinds = []string{params.Kind}
+	}
+	results, err := s.searchBackend.Search(ctx, q)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSearchBackendFailed, err)
+	}
+
+	response := &TextSearchResult{
+		Hits:   make([]TextSearchHit, len(results.Hits)),
+		Total:  results.Total,
+		Limit:  params.Limit,
+		Offset: params.Offset,
+	}
+	for i, h := range results.Hits {
+		response.Hits[i] = TextSearchHit{
+			Kind:        h.Kind,
+			ID:          h.ID,
+			BillID:      h.BillID,
+			Congress:    h.Congress,
+			BillType:    h.BillType,
+			BillNumber:  h.BillNumber,
+			Title:       h.Title,
+			VersionCode: h.VersionCode,
+			EventType:   h.EventType,
+			Score:       h.Score,
+			Highlights:  h.Highlights,
+		}
+	}
+	return response, nil
+}
+
+// TextSearchInput is the request for a full-text search.
+type TextSearchInput struct {
+	Query    string `query:"q" required:"true" minLength:"1" maxLength:"500" doc:"Words to search for, all of which must match. Supports \"quoted phrases\", \"phrase\"~N for words up to N positions apart, -word to exclude, a | b for either, and word* for prefixes" example:"\"shall not be used\""`
+	Phrase   bool   `query:"phrase" doc:"Match q word for word as a single phrase, without the query syntax"`
+	Slop     int    `query:"slop" minimum:"0" maximum:"50" doc:"With phrase, how many positions apart or out of order the phrase's words may be"`
+	Kind     string `query:"kind" enum:"bills,versions,actions" doc:"Search only bills (titles, aliases, sponsors, statuses), version text, or actions. Default: all"`
+	Congress int    `query:"congress" minimum:"0" doc:"Filter by congress number. 0 = no filter" example:"119"`
+	BillType string `query:"type" pattern:"^[A-Za-z]+$" maxLength:"16" doc:"Filter by bill type" example:"hr"`
+	BillID   uint   `query:"billId" doc:"Search within one bill. 0 = all bills"`
+	Limit    int    `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"Number of results per page (max 100)"`
+	Offset   int    `query:"offset" default:"0" minimum:"0" maximum:"10000" doc:"Pagination offset"`
+}
+
+// TextSearchOutput is the response for a full-text search.
+type TextSearchOutput struct {
+	Body TextSearchResult
+}
+
+// registerTextSearchRoute registers the full-text search endpoint.
+func registerTextSearchRoute(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "search-text",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/lex/text",
+		Summary:     "Search bill text",
+		Description: "Full-text search of bills, the text of their versions, and their actions, with phrase and proximity matching and highlighted excerpts. Answered by the configured search backend (OpenSearch or Elasticsearch); returns 501 when there is none. Drafts are not searched.",
+		Errors:      []int{http.StatusNotImplemented, http.StatusBadGateway},
+		Tags:        []string{"Search"},
+	}, func(ctx context.Context, input *TextSearchInput) (*TextSearchOutput, error) {
+		result, err := s.SearchText(ctx, TextSearchParams{
+			Query:    input.Query,
+			Phrase:   input.Phrase,
+			Slop:     input.Slop,
+			Kind:     input.Kind,
+			Congress: input.Congress,
+			BillType: input.BillType,
+			BillID:   input.BillID,
+			Limit:    input.Limit,
+			Offset:   input.Offset,
+		})
+		if err != nil {
+			return nil, serviceError(err, "search failed")
+		}
+		return &TextSearchOutput{Body: *result}, nil
+	})
+}
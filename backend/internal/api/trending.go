@@ -0,0 +1,115 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/database"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// TrendingResponse ranks bills by recent activity.
+type TrendingResponse struct {
+	WindowStart *time.Time     `json:"windowStart,omitempty"` // Start of the activity window; absent until scores are computed
+	ComputedAt  *time.Time     `json:"computedAt,omitempty"`
+	Bills       []TrendingBill `json:"bills"` // Highest score first
+}
+
+// TrendingBill is a bill and its activity in the window; see
+// models.BillActivity.
+type TrendingBill struct {
+	Bill         BillResponse `json:"bill"`
+	Score        float64      `json:"score"`
+	Versions     int          `json:"versions"`
+	Events       int          `json:"events"`
+	LinesChanged int          `json:"linesChanged"`
+}
+
+// GetTrendingBills returns up to limit bills in scope with the highest
+// activity scores, as last computed by the ingestor (see package trending).
+func (s *BillService) GetTrendingBills(ctx context.Context, limit int) (*TrendingResponse, error) {
+	db := database.ReadReplica(s.db.WithContext(ctx))
+	var ranked []models.BillActivity
+	if err := db.Model(&models.BillActivity{}).
+		Joins("JOIN bills ON bills.id = bill_activity.bill_id").Scopes(s.scope.Query, visibleBills(ctx)).
+		Order("bill_activity.score DESC, bill_activity.bill_id DESC").
+		Limit(limit).
+		Find(&ranked).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch trending bills: %w", err)
+	}
+
+	response := &TrendingResponse{Bills: make([]TrendingBill, 0, len(ranked))}
+	if len(ranked) == 0 {
+		return response, nil
+	}
+	response.WindowStart = &ranked[0].WindowStart
+	response.ComputedAt = &ranked[0].ComputedAt
+
+	ids := make([]uint, len(ranked))
+	for i, a := range ranked {
+		ids[i] = a.BillID
+	}
+	var bills []models.Bill
+	if err := db.Where("id IN ?", ids).Find(&bills).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch bills: %w", err)
+	}
+	byID := make(map[uint]*models.Bill, len(bills))
+	for i := range bills {
+		byID[bills[i].ID] = &bills[i]
+	}
+
+	for _, a := range ranked {
+		bill, ok := byID[a.BillID]
+		if !ok {
+			continue // Deleted since the scores were computed
+		}
+		response.Bills = append(response.Bills, TrendingBill{
+			Bill:         billListResponse(bill),
+			Score:        a.Score,
+			Versions:     a.Versions,
+			Events:       a.Events,
+			LinesChanged: a.LinesChanged,
+		})
+	}
+	return response, nil
+}
+
+// GetTrendingBillsInput is the request for trending bills.
+type GetTrendingBillsInput struct {
+	ConditionalInput
+	Limit int `query:"limit" default:"20" minimum:"1" maximum:"100" doc:"Number of bills (max 100)"`
+}
+
+// GetTrendingBillsOutput is the response for trending bills.
+type GetTrendingBillsOutput struct {
+	CacheHeaders
+	Body TrendingResponse
+}
+
+// registerTrendingRoute registers the trending bills endpoint. It must be
+// registered before /bills/{id} so "trending" isn't taken as an ID.
+func registerTrendingRoute(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-trending-bills",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/trending",
+		Summary:     "List the most actively changing bills",
+		Description: "Ranks bills by activity over a sliding window (a week by default): text versions published, legislative events, and lines changed between versions. Scores are recomputed by the ingestor after each run.",
+		Errors:      []int{http.StatusInternalServerError},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *GetTrendingBillsInput) (*GetTrendingBillsOutput, error) {
+		trending, err := s.GetTrendingBills(ctx, input.Limit)
+		if err != nil {
+			return nil, serviceError(err, "failed to get trending bills")
+		}
+		headers, err := conditionalHeaders(input.ConditionalInput, trending, cacheControlBill)
+		if err != nil {
+			return nil, err
+		}
+		return &GetTrendingBillsOutput{CacheHeaders: headers, Body: *trending}, nil
+	})
+}
@@ -0,0 +1,184 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/versioncode"
+)
+
+// ErrNoParentVersion is returned when a version is the root of its bill's
+// version graph, so there is nothing to diff it against.
+var ErrNoParentVersion = errors.New("version has no parent version")
+
+// VersionGraphResponse is how a bill's versions derive from one another.
+// Each version has at most one parent, but a version can have several
+// children, e.g., the Senate's reported text and its engrossed amendment
+// both follow the House's engrossed text.
+type VersionGraphResponse struct {
+	BillID   uint               `json:"billId"`
+	Versions []GraphVersion     `json:"versions"` // Oldest first
+	Edges    []VersionGraphEdge `json:"edges"`    // Parent to child
+}
+
+// GraphVersion is a version in a VersionGraphResponse.
+type GraphVersion struct {
+	ID          uint      `json:"id"`
+	VersionCode string    `json:"versionCode"`
+	Label       string    `json:"label"`
+	Stage       int       `json:"stage"`
+	Chamber     string    `json:"chamber,omitempty"`
+	FetchedAt   time.Time `json:"fetchedAt"`
+	ParentID    uint      `json:"parentId,omitempty"` // Absent for the root
+}
+
+// VersionGraphEdge links a version to one derived from it.
+type VersionGraphEdge struct {
+	FromVersionID uint `json:"fromVersionId"`
+	ToVersionID   uint `json:"toVersionId"`
+}
+
+// GetVersionGraph returns a bill's version graph, derived from version
+// codes and fetch order; see versioncode.Parents.
+func (s *BillService) GetVersionGraph(ctx context.Context, billID uint) (*VersionGraphResponse, error) {
+	if err := s.requireBill(ctx, billID); err != nil {
+		return nil, err
+	}
+	versions, err := s.versionsInOrder(ctx, billID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &VersionGraphResponse{
+		BillID:   billID,
+		Versions: make([]GraphVersion, len(versions)),
+		Edges:    []VersionGraphEdge{},
+	}
+	for i, parent := range versionParents(versions) {
+		v := versions[i]
+		response.Versions[i] = GraphVersion{
+			ID:          v.ID,
+			VersionCode: v.VersionCode,
+			Label:       versioncode.Label(v.VersionCode),
+			Stage:       versioncode.Stage(v.VersionCode),
+			Chamber:     versioncode.Chamber(v.VersionCode),
+			FetchedAt:   v.FetchedAt,
+		}
+		if parent >= 0 {
+			response.Versions[i].ParentID = versions[parent].ID
+			response.Edges = append(response.Edges, VersionGraphEdge{FromVersionID: versions[parent].ID, ToVersionID: v.ID})
+		}
+	}
+	return response, nil
+}
+
+// ParentVersion returns the ID of the version a bill's version derives
+// from in its version graph, or ErrNoParentVersion for the root.
+func (s *BillService) ParentVersion(ctx context.Context, billID, versionID uint) (uint, error) {
+	if err := s.requireBill(ctx, billID); err != nil {
+		return 0, err
+	}
+	versions, err := s.versionsInOrder(ctx, billID)
+	if err != nil {
+		return 0, err
+	}
+	for i, parent := range versionParents(versions) {
+		if versions[i].ID != versionID {
+			continue
+		}
+		if parent < 0 {
+			return 0, ErrNoParentVersion
+		}
+		return versions[parent].ID, nil
+	}
+
+	// Not one of the bill's versions
+	var version models.Version
+	if err := s.db.WithContext(ctx).Select("id").First(&version, versionID).Error; err != nil {
+		return 0, versionLookupError(err)
+	}
+	return 0, ErrVersionMismatch
+}
+
+// versionParents returns the parent index of each of versions, in fetch
+// order.
+func versionParents(versions []models.Version) []int {
+	codes := make([]string, len(versions))
+	for i, v := range versions {
+		codes[i] = v.VersionCode
+	}
+	return versioncode.Parents(codes)
+}
+
+// VersionGraphInput is the request for a bill's version graph
+type VersionGraphInput struct {
+	ID uint `path:"id" minimum:"1" doc:"Bill ID"`
+}
+
+// VersionGraphOutput is the response for a bill's version graph
+type VersionGraphOutput struct {
+	Body VersionGraphResponse
+}
+
+// ParentDiffInput is the request for a diff against a version's parent
+type ParentDiffInput struct {
+	ConditionalInput
+	DiffOptionsInput
+	DiffWindowInput
+	BillID    uint   `path:"billId" minimum:"1" doc:"Bill ID"`
+	ToVersion uint   `path:"toVersion" minimum:"1" doc:"Target version ID; diffed against the version it derives from"`
+	View      string `query:"view" enum:"unified,split" default:"unified" doc:"unified returns interleaved lines; split returns aligned left/right rows"`
+}
+
+// registerVersionGraphRoutes registers the version graph and the diff of
+// a version against its parent in it.
+func registerVersionGraphRoutes(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-version-graph",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{id}/version-graph",
+		Summary:     "Get a bill's version graph",
+		Description: "Returns which version each of a bill's versions derives from, as parent edges. Versions branch: an engrossed amendment derives from the other chamber's passed text rather than the version fetched before it, and enrolled text from the version the chambers agreed to. Derived from version codes and fetch order; unmapped codes follow the version fetched before them.",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *VersionGraphInput) (*VersionGraphOutput, error) {
+		graph, err := s.GetVersionGraph(ctx, input.ID)
+		if err != nil {
+			return nil, serviceError(err, "failed to get version graph")
+		}
+		return &VersionGraphOutput{Body: *graph}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "compute-parent-diff",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{billId}/diff/{toVersion}",
+		Summary:     "Diff a version against its parent",
+		Description: "Returns the diff from the version toVersion derives from in the bill's version graph (see get-version-graph) to toVersion, the comparison that shows what that stage changed. Takes the same options as compute-diff.",
+		Errors:      []int{http.StatusNotFound, http.StatusUnprocessableEntity},
+		Tags:        []string{"Diff"},
+	}, func(ctx context.Context, input *ParentDiffInput) (*ComputeDiffOutput, error) {
+		parent, err := s.ParentVersion(ctx, input.BillID, input.ToVersion)
+		if err != nil {
+			return nil, serviceError(err, "failed to find parent version")
+		}
+		diff, err := s.ComputeDiff(ctx, input.BillID, parent, input.ToVersion, input.Options())
+		if err != nil {
+			return nil, serviceError(err, "failed to compute diff")
+		}
+		windowDiff(diff, input.Window())
+		if input.View == DiffViewSplit {
+			toSplitView(diff)
+		}
+		headers, err := conditionalHeaders(input.ConditionalInput, diff, cacheControlDiff)
+		if err != nil {
+			return nil, err
+		}
+		return &ComputeDiffOutput{CacheHeaders: headers, Body: *diff}, nil
+	})
+}
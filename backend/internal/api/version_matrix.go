@@ -0,0 +1,179 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/database"
+	"github.com/drewjst/deltagov/internal/deltas"
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/versioncode"
+)
+
+// Statuses of a VersionPairStats.
+const (
+	PairCached   = "cached"   // Statistics are from the stored delta
+	PairPending  = "pending"  // Not diffed yet; queued, or computed on first diff request
+	PairTooLarge = "tooLarge" // A version is too large to diff
+)
+
+// VersionMatrixResponse has diff statistics for every pair of a bill's
+// versions, for rendering how much each stage changed.
+type VersionMatrixResponse struct {
+	BillID   uint               `json:"billId"`
+	Versions []MatrixVersion    `json:"versions"` // Oldest first
+	Pairs    []VersionPairStats `json:"pairs"`    // Each earlier version against each later one
+	Pending  int                `json:"pending"`  // Pairs without statistics yet
+}
+
+// MatrixVersion is a version in a VersionMatrixResponse.
+type MatrixVersion struct {
+	ID          uint   `json:"id"`
+	VersionCode string `json:"versionCode"`
+	Label       string `json:"label"`
+	Stage       int    `json:"stage"`
+	Lines       int    `json:"lines"` // Lines of plain text; 0 for archived versions
+}
+
+// VersionPairStats is the size of the diff from one version to another.
+type VersionPairStats struct {
+	FromVersionID  uint     `json:"fromVersionId"`
+	ToVersionID    uint     `json:"toVersionId"`
+	Status         string   `json:"status"` // PairCached, PairPending, or PairTooLarge
+	Insertions     int      `json:"insertions"`
+	Deletions      int      `json:"deletions"`
+	PercentChanged *float64 `json:"percentChanged,omitempty"` // Changed lines as a percentage of both versions' lines; absent until cached, or when line counts are unknown
+}
+
+// GetVersionMatrix returns diff statistics for every pair of a bill's
+// versions. Statistics come from stored deltas only; pairs without a
+// current one are reported pending and queued for precomputation when a
+// diff queue is set (see SetDiffQueue), so a later request fills them in.
+func (s *BillService) GetVersionMatrix(ctx context.Context, billID uint) (*VersionMatrixResponse, error) {
+	if err := s.requireBill(ctx, billID); err != nil {
+		return nil, err
+	}
+
+	db := database.ReadReplica(s.db.WithContext(ctx))
+	var versions []struct {
+		ID          uint
+		VersionCode string
+		FetchedAt   time.Time
+		TextSize    int
+		Lines       int
+	}
+	// Lines are counted in the database so the text isn't transferred
+	if err := db.Model(&models.Version{}).
+		Select("id, version_code, fetched_at, text_size, "+
+			"CASE WHEN plain_text = '' THEN 0 ELSE length(plain_text) - length(replace(plain_text, chr(10), '')) + 1 END AS lines").
+		Where("bill_id = ?", billID).Order("fetched_at ASC, id ASC").
+		Scan(&versions).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch versions: %w", err)
+	}
+
+	response := &VersionMatrixResponse{
+		BillID:   billID,
+		Versions: make([]MatrixVersion, len(versions)),
+		Pairs:    []VersionPairStats{},
+	}
+	ids := make([]uint, len(versions))
+	for i, v := range versions {
+		ids[i] = v.ID
+		response.Versions[i] = MatrixVersion{
+			ID:          v.ID,
+			VersionCode: v.VersionCode,
+			Label:       versioncode.Label(v.VersionCode),
+			Stage:       versioncode.Stage(v.VersionCode),
+			Lines:       v.Lines,
+		}
+	}
+	if len(versions) < 2 {
+		return response, nil
+	}
+
+	var stored []struct {
+		VersionAID uint
+		VersionBID uint
+		Insertions int
+		Deletions  int
+	}
+	if err := db.Model(&models.Delta{}).
+		Select("version_a_id, version_b_id, insertions, deletions").
+		Where("version_a_id IN ? AND version_b_id IN ? AND engine_version = ? AND (delta_data IS NOT NULL OR delta_json IS NOT NULL)",
+			ids, ids, diff_engine.EngineVersion).
+		Scan(&stored).Error; err != nil {
+		return nil, fmt.Errorf("failed to fetch deltas: %w", err)
+	}
+	type pair struct{ from, to uint }
+	cached := make(map[pair]int, len(stored))
+	for i, d := range stored {
+		cached[pair{d.VersionAID, d.VersionBID}] = i
+	}
+
+	for i, from := range versions {
+		for _, to := range versions[i+1:] {
+			stats := VersionPairStats{FromVersionID: from.ID, ToVersionID: to.ID}
+			if j, ok := cached[pair{from.ID, to.ID}]; ok {
+				stats.Status = PairCached
+				stats.Insertions = stored[j].Insertions
+				stats.Deletions = stored[j].Deletions
+				if lines := from.Lines + to.Lines; lines > 0 {
+					percent := math.Round(float64(stats.Insertions+stats.Deletions)/float64(lines)*1000) / 10
+					stats.PercentChanged = &percent
+				}
+			} else if deltas.TooLarge(&models.Version{TextSize: from.TextSize}, &models.Version{TextSize: to.TextSize}) {
+				stats.Status = PairTooLarge
+			} else {
+				stats.Status = PairPending
+				response.Pending++
+				if s.diffQueue != nil {
+					s.diffQueue.Enqueue(ctx, from.ID, to.ID)
+				}
+			}
+			response.Pairs = append(response.Pairs, stats)
+		}
+	}
+
+	return response, nil
+}
+
+// GetVersionMatrixInput is the request for a bill's version matrix.
+type GetVersionMatrixInput struct {
+	ConditionalInput
+	ID uint `path:"id" minimum:"1" doc:"Bill ID"`
+}
+
+// GetVersionMatrixOutput is the response for a bill's version matrix.
+type GetVersionMatrixOutput struct {
+	CacheHeaders
+	Body VersionMatrixResponse
+}
+
+// registerVersionMatrixRoute registers the version matrix endpoint.
+func registerVersionMatrixRoute(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-bill-version-matrix",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/bills/{id}/version-matrix",
+		Summary:     "Get diff statistics for every pair of a bill's versions",
+		Description: "Returns insertions, deletions, and percent of lines changed from each version to each later one, from cached diffs. Pairs not diffed yet are pending and queued; request again to pick them up.",
+		Errors:      []int{http.StatusNotFound},
+		Tags:        []string{"Diff"},
+	}, func(ctx context.Context, input *GetVersionMatrixInput) (*GetVersionMatrixOutput, error) {
+		matrix, err := s.GetVersionMatrix(ctx, input.ID)
+		if err != nil {
+			return nil, serviceError(err, "failed to get version matrix")
+		}
+		headers, err := conditionalHeaders(input.ConditionalInput, matrix, cacheControlBill)
+		if err != nil {
+			return nil, err
+		}
+		return &GetVersionMatrixOutput{CacheHeaders: headers, Body: *matrix}, nil
+	})
+}
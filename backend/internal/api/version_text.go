@@ -0,0 +1,224 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"unicode/utf8"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	"github.com/drewjst/deltagov/internal/analysis"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/textextract"
+	"github.com/drewjst/deltagov/internal/textstore"
+)
+
+// Errors returned by GetVersionText.
+var (
+	ErrTextFormatUnavailable = errors.New("version text is not available in that format")
+	ErrSectionNotFound       = errors.New("section not found in version text")
+	ErrRangeNotSatisfiable   = errors.New("offset is beyond the end of the text")
+	ErrSectionRangeFormat    = errors.New("section ranges are only available in plain and html formats")
+)
+
+// Formats of version text.
+const (
+	TextFormatPlain = "plain"
+	TextFormatHTML  = "html"
+	TextFormatXML   = "xml"
+)
+
+// VersionTextParams selects the text returned by GetVersionText. A section
+// range is applied first, then the byte range within it.
+type VersionTextParams struct {
+	Format      string // TextFormatPlain (default), TextFormatHTML, or TextFormatXML
+	FromSection string // First section number, e.g., "101" (empty = start of text)
+	ToSection   string // Last section number, inclusive (empty = FromSection, or end of text)
+	Offset      int    // Byte offset into the selected text
+	Length      int    // Maximum bytes returned (0 = to the end)
+}
+
+// VersionTextResponse is a version's text, or a portion of it.
+type VersionTextResponse struct {
+	VersionID   uint   `json:"versionId"`
+	VersionCode string `json:"versionCode"`
+	Format      string `json:"format"`
+	Offset      int    `json:"offset"`      // Byte offset of Text within the selected text
+	Length      int    `json:"length"`      // Bytes in Text
+	TotalLength int    `json:"totalLength"` // Bytes in the selected text; more remain when offset+length is less
+	Text        string `json:"text"`
+}
+
+// GetVersionText returns a version's text in the requested format: plain
+// is the extracted text that diffs are computed over, xml is the original
+// Formatted XML, and html is the original HTML, or the plain text in a
+// <pre> block for versions fetched in another format. Byte ranges are
+// widened to whole UTF-8 characters.
+func (s *BillService) GetVersionText(ctx context.Context, versionID uint, params VersionTextParams) (*VersionTextResponse, error) {
+	var version models.Version
+	if err := s.db.WithContext(ctx).First(&version, versionID).Error; err != nil {
+		return nil, versionLookupError(err)
+	}
+	if err := rehydrate(&version); err != nil {
+		return nil, err
+	}
+	if params.Format != TextFormatPlain && params.Format != "" {
+		if err := textstore.Load(ctx, s.texts, &version); err != nil {
+			return nil, fmt.Errorf("failed to load version text: %w", err)
+		}
+	}
+
+	text, err := versionText(&version, params)
+	if err != nil {
+		return nil, err
+	}
+	total := len(text)
+	if params.Offset > total || (params.Offset == total && total > 0) {
+		return nil, ErrRangeNotSatisfiable
+	}
+
+	start := runeStart(text, params.Offset)
+	end := total
+	if params.Length > 0 && params.Offset+params.Length < total {
+		end = runeEnd(text, params.Offset+params.Length)
+	}
+
+	format := params.Format
+	if format == "" {
+		format = TextFormatPlain
+	}
+	return &VersionTextResponse{
+		VersionID:   version.ID,
+		VersionCode: version.VersionCode,
+		Format:      format,
+		Offset:      start,
+		Length:      end - start,
+		TotalLength: total,
+		Text:        text[start:end],
+	}, nil
+}
+
+// versionText returns the text of a version selected by format and
+// section range, before any byte range.
+func versionText(v *models.Version, params VersionTextParams) (string, error) {
+	kind := textextract.Kind(v.TextContent)
+	sectioned := params.FromSection != "" || params.ToSection != ""
+
+	switch params.Format {
+	case TextFormatXML:
+		if sectioned {
+			return "", ErrSectionRangeFormat
+		}
+		if kind != textextract.KindXML {
+			return "", ErrTextFormatUnavailable
+		}
+		return v.TextContent, nil
+	case TextFormatHTML:
+		if kind == textextract.KindHTML && !sectioned {
+			return v.TextContent, nil
+		}
+		plain, err := sectionRange(v.PlainText, params.FromSection, params.ToSection)
+		if err != nil {
+			return "", err
+		}
+		return "<pre>" + html.EscapeString(plain) + "</pre>", nil
+	default:
+		return sectionRange(v.PlainText, params.FromSection, params.ToSection)
+	}
+}
+
+// sectionRange returns the text from the header of section from through
+// the end of section to. An empty from starts at the first section; an
+// empty to ends with section from, or at the end of the text when from is
+// empty too.
+func sectionRange(text, from, to string) (string, error) {
+	if from == "" && to == "" {
+		return text, nil
+	}
+	if to == "" {
+		to = from
+	}
+
+	sections := analysis.SplitSections(text)
+	start, end := -1, -1
+	for i, sec := range sections {
+		if start < 0 && (sec.Number == from || (from == "" && i == 0)) {
+			start = sec.Start
+		}
+		if start >= 0 && sec.Number == to {
+			end = sec.End
+			break
+		}
+	}
+	if start < 0 || end < 0 {
+		return "", ErrSectionNotFound
+	}
+	return text[start:end], nil
+}
+
+// runeStart moves a byte offset back to the start of the UTF-8 character
+// containing it.
+func runeStart(s string, offset int) int {
+	for offset > 0 && offset < len(s) && !utf8.RuneStart(s[offset]) {
+		offset--
+	}
+	return offset
+}
+
+// runeEnd moves a byte offset forward to the end of the UTF-8 character
+// containing it, so a range never splits a character.
+func runeEnd(s string, offset int) int {
+	for offset < len(s) && !utf8.RuneStart(s[offset]) {
+		offset++
+	}
+	return offset
+}
+
+// GetVersionTextInput is the request for a version's text.
+type GetVersionTextInput struct {
+	ConditionalInput
+	ID          uint   `path:"id" minimum:"1" doc:"Version ID"`
+	Format      string `query:"format" enum:"plain,html,xml" default:"plain" doc:"plain is the extracted text diffs are computed over; xml is the original Formatted XML; html is the original HTML, or the plain text in a <pre> block"`
+	FromSection string `query:"fromSection" maxLength:"16" doc:"First section number to return (plain and html only)" example:"101"`
+	ToSection   string `query:"toSection" maxLength:"16" doc:"Last section number to return, inclusive (default: fromSection)" example:"105"`
+	Offset      int    `query:"offset" default:"0" minimum:"0" doc:"Byte offset into the selected text"`
+	Length      int    `query:"length" default:"0" minimum:"0" doc:"Maximum bytes to return (0 = to the end)"`
+}
+
+// GetVersionTextOutput is the response for a version's text.
+type GetVersionTextOutput struct {
+	CacheHeaders
+	Body VersionTextResponse
+}
+
+// registerVersionTextRoute registers the version text endpoint.
+func registerVersionTextRoute(api huma.API, s *BillService) {
+	huma.Register(api, huma.Operation{
+		OperationID: "get-version-text",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/versions/{id}/text",
+		Summary:     "Get a version's text",
+		Description: "Returns a version's text as plain text, HTML, or the original XML. fromSection/toSection select a range of sections, and offset/length a byte range within it, so large bills can be loaded in portions; totalLength is the size of the whole selection.",
+		Errors:      []int{http.StatusBadRequest, http.StatusNotFound, http.StatusRequestedRangeNotSatisfiable},
+		Tags:        []string{"Bills"},
+	}, func(ctx context.Context, input *GetVersionTextInput) (*GetVersionTextOutput, error) {
+		text, err := s.GetVersionText(ctx, input.ID, VersionTextParams{
+			Format:      input.Format,
+			FromSection: input.FromSection,
+			ToSection:   input.ToSection,
+			Offset:      input.Offset,
+			Length:      input.Length,
+		})
+		if err != nil {
+			return nil, serviceError(err, "failed to get version text")
+		}
+		headers, err := conditionalHeaders(input.ConditionalInput, text, cacheControlDiff)
+		if err != nil {
+			return nil, err
+		}
+		return &GetVersionTextOutput{CacheHeaders: headers, Body: *text}, nil
+	})
+}
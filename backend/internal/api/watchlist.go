@@ -0,0 +1,607 @@
+package api
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// savedSearchMatchLimit caps the new matches reported per saved search in
+// one updates response.
+const savedSearchMatchLimit = 50
+
+// ErrInvalidAPIKey is returned when an API key is missing or unknown.
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+// ErrSavedSearchNotFound is returned when a saved search doesn't exist or
+// belongs to another user.
+var ErrSavedSearchNotFound = errors.New("saved search not found")
+
+// ErrEmptySearch is returned when a saved search has no filters, which would
+// match every bill.
+var ErrEmptySearch = errors.New("saved search needs at least one filter")
+
+// WatchlistService manages users' saved searches and watched bills.
+type WatchlistService struct {
+	db    *gorm.DB
+	bills *BillService
+}
+
+// NewWatchlistService creates a new WatchlistService.
+func NewWatchlistService(db *gorm.DB, bills *BillService) *WatchlistService {
+	return &WatchlistService{db: db, bills: bills}
+}
+
+// SearchFilters is a saved bill search, in the shape of the /api/v1/lex query.
+type SearchFilters struct {
+	Congress       int    `json:"congress,omitempty" doc:"Filter by congress number"`
+	Sponsor        string `json:"sponsor,omitempty" doc:"Filter by sponsor name (partial match)"`
+	Query          string `json:"query,omitempty" doc:"Search text in bill title"`
+	BillType       string `json:"type,omitempty" doc:"Filter by bill type (hr, s, hjres, sjres)"`
+	Jurisdiction   string `json:"jurisdiction,omitempty" enum:"federal,state,draft" doc:"Filter to federal or state bills, or the caller's tenant's drafts"`
+	State          string `json:"state,omitempty" doc:"Filter by two-letter state code of state bills"`
+	IsSpendingBill bool   `json:"spending,omitempty" doc:"Only spending bills"`
+	PolicyArea     string `json:"policyArea,omitempty" doc:"Filter by CRS policy area"`
+	Subject        string `json:"subject,omitempty" doc:"Filter by CRS legislative subject"`
+}
+
+// empty reports whether no filter is set.
+func (f SearchFilters) empty() bool {
+	return f == SearchFilters{}
+}
+
+// params converts the filters to search parameters.
+func (f SearchFilters) params() LexSearchParams {
+	return LexSearchParams{
+		Congress:       f.Congress,
+		Sponsor:        f.Sponsor,
+		Query:          f.Query,
+		BillType:       f.BillType,
+		Jurisdiction:   f.Jurisdiction,
+		State:          f.State,
+		IsSpendingBill: f.IsSpendingBill,
+		PolicyArea:     f.PolicyArea,
+		Subject:        f.Subject,
+	}
+}
+
+// UserResponse is a newly created user. APIKey is only ever returned here.
+type UserResponse struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Role     string `json:"role" enum:"reader,editor,admin" doc:"What the key may do: readers read and keep a watchlist, editors also upload, admins also use the admin endpoints"`
+	TenantID *uint  `json:"tenantId,omitempty" doc:"Tenant whose drafts the key can read and upload"`
+	APIKey   string `json:"apiKey" doc:"Send as the X-API-Key header; it is not shown again"`
+}
+
+// SavedSearchResponse is a saved search in API responses.
+type SavedSearchResponse struct {
+	ID        uint          `json:"id"`
+	Name      string        `json:"name"`
+	Filters   SearchFilters `json:"filters"`
+	CreatedAt time.Time     `json:"createdAt"`
+}
+
+// WatchlistResponse lists a user's saved searches and watched bills.
+type WatchlistResponse struct {
+	Searches []SavedSearchResponse `json:"searches"`
+	Bills    []BillResponse        `json:"bills"`
+}
+
+// WatchedBillUpdates is the changes to one watched bill since the last check.
+type WatchedBillUpdates struct {
+	Bill    BillResponse         `json:"bill"`
+	Changes []BillChangeResponse `json:"changes"`
+}
+
+// SavedSearchUpdates is the bills matching a saved search that changed since
+// the last check.
+type SavedSearchUpdates struct {
+	Search SavedSearchResponse `json:"search"`
+	Bills  []BillResponse      `json:"bills"`
+}
+
+// WatchlistUpdates is what changed on a user's watchlist since the last check.
+type WatchlistUpdates struct {
+	Since     time.Time             `json:"since"`
+	CheckedAt time.Time             `json:"checkedAt" doc:"Pass as 'since' to resume from this check"`
+	Bills     []WatchedBillUpdates  `json:"bills"`
+	Searches  []SavedSearchUpdates  `json:"searches"`
+	Alerts    []KeywordAlertUpdates `json:"alerts" doc:"Keyword alert matches recorded since the last check"`
+}
+
+// CreateUser creates a user and returns its API key. Only the key's hash is
+// stored.
+func (s *WatchlistService) CreateUser(ctx context.Context, name string) (*UserResponse, error) {
+	return createUser(ctx, s.db, name, models.UserRoleReader, nil)
+}
+
+// Authenticate returns the user owning an API key.
+func (s *WatchlistService) Authenticate(ctx context.Context, key string) (*models.User, error) {
+	return authenticate(ctx, s.db, key)
+}
+
+// createUser creates a user with a role, optionally in a tenant, and returns
+// its API key.
+func createUser(ctx context.Context, db *gorm.DB, name, role string, tenantID *uint) (*UserResponse, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+	key := "dg_" + hex.EncodeToString(raw)
+
+	user := models.User{Name: name, APIKeyHash: hashAPIKey(key), Role: role, TenantID: tenantID}
+	if err := db.WithContext(ctx).Create(&user).Error; err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+	return &UserResponse{ID: user.ID, Name: user.Name, Role: user.Role, TenantID: user.TenantID, APIKey: key}, nil
+}
+
+// authenticate returns the user owning an API key or, without one, the
+// user whose session token authenticated the request.
+func authenticate(ctx context.Context, db *gorm.DB, key string) (*models.User, error) {
+	if key == "" {
+		if user, ok := sessionUser(ctx); ok {
+			return user, nil
+		}
+		return nil, ErrInvalidAPIKey
+	}
+	var user models.User
+	if err := db.WithContext(ctx).Where("api_key_hash = ?", hashAPIKey(key)).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	return &user, nil
+}
+
+// GetWatchlist returns a user's saved searches and watched bills.
+func (s *WatchlistService) GetWatchlist(ctx context.Context, user *models.User) (*WatchlistResponse, error) {
+	db := s.db.WithContext(ctx)
+
+	var searches []models.SavedSearch
+	if err := db.Where("user_id = ?", user.ID).Order("id ASC").Find(&searches).Error; err != nil {
+		return nil, fmt.Errorf("failed to list saved searches: %w", err)
+	}
+
+	bills, err := s.watchedBills(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &WatchlistResponse{
+		Searches: make([]SavedSearchResponse, len(searches)),
+		Bills:    make([]BillResponse, len(bills)),
+	}
+	for i := range searches {
+		resp.Searches[i] = savedSearchResponse(&searches[i])
+	}
+	for i := range bills {
+		resp.Bills[i] = billListResponse(&bills[i])
+	}
+	return resp, nil
+}
+
+// SaveSearch saves a bill search for a user.
+func (s *WatchlistService) SaveSearch(ctx context.Context, user *models.User, name string, filters SearchFilters) (*SavedSearchResponse, error) {
+	if filters.empty() {
+		return nil, ErrEmptySearch
+	}
+
+	params, err := filtersToParams(filters)
+	if err != nil {
+		return nil, err
+	}
+	search := models.SavedSearch{UserID: user.ID, Name: name, Params: params}
+	if err := s.db.WithContext(ctx).Create(&search).Error; err != nil {
+		return nil, fmt.Errorf("failed to save search: %w", err)
+	}
+
+	resp := savedSearchResponse(&search)
+	return &resp, nil
+}
+
+// DeleteSearch removes one of a user's saved searches.
+func (s *WatchlistService) DeleteSearch(ctx context.Context, user *models.User, searchID uint) error {
+	result := s.db.WithContext(ctx).Where("id = ? AND user_id = ?", searchID, user.ID).Delete(&models.SavedSearch{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete saved search: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrSavedSearchNotFound
+	}
+	return nil
+}
+
+// WatchBill adds a bill to a user's watchlist. Watching a bill twice is a no-op.
+func (s *WatchlistService) WatchBill(ctx context.Context, user *models.User, billID uint) error {
+	if err := s.bills.requireBill(ctx, billID); err != nil {
+		return err
+	}
+	watched := models.WatchedBill{UserID: user.ID, BillID: billID}
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "bill_id"}},
+		DoNothing: true,
+	}).Create(&watched).Error; err != nil {
+		return fmt.Errorf("failed to watch bill: %w", err)
+	}
+	return nil
+}
+
+// UnwatchBill removes a bill from a user's watchlist.
+func (s *WatchlistService) UnwatchBill(ctx context.Context, user *models.User, billID uint) error {
+	result := s.db.WithContext(ctx).Where("user_id = ? AND bill_id = ?", user.ID, billID).Delete(&models.WatchedBill{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to unwatch bill: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return ErrBillNotFound
+	}
+	return nil
+}
+
+// GetUpdates returns changes to watched bills, new saved-search matches, and
+// keyword alert matches since the given time, or since the user's last check when since is nil, and
+// records this check.
+func (s *WatchlistService) GetUpdates(ctx context.Context, user *models.User, since *time.Time) (*WatchlistUpdates, error) {
+	// Captured before querying so changes made during the check are reported next time
+	checkedAt := time.Now().UTC()
+
+	from := user.CreatedAt
+	if user.LastCheckedAt != nil {
+		from = *user.LastCheckedAt
+	}
+	if since != nil {
+		from = *since
+	}
+
+	db := s.db.WithContext(ctx)
+	updates := &WatchlistUpdates{
+		Since:     from,
+		CheckedAt: checkedAt,
+		Bills:     []WatchedBillUpdates{},
+		Searches:  []SavedSearchUpdates{},
+	}
+
+	bills, err := s.watchedBills(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range bills {
+		var events []models.BillEvent
+		if err := db.Where("bill_id = ? AND created_at > ? AND created_at <= ?", bills[i].ID, from, checkedAt).
+			Order("occurred_at ASC, id ASC").Find(&events).Error; err != nil {
+			return nil, fmt.Errorf("failed to list bill events: %w", err)
+		}
+		if len(events) == 0 {
+			continue
+		}
+		changes, err := s.bills.changeResponses(ctx, bills[i].ID, events)
+		if err != nil {
+			return nil, err
+		}
+		updates.Bills = append(updates.Bills, WatchedBillUpdates{Bill: billListResponse(&bills[i]), Changes: changes})
+	}
+
+	var searches []models.SavedSearch
+	if err := db.Where("user_id = ?", user.ID).Order("id ASC").Find(&searches).Error; err != nil {
+		return nil, fmt.Errorf("failed to list saved searches: %w", err)
+	}
+	for i := range searches {
+		search := savedSearchResponse(&searches[i])
+
+		var matches []models.Bill
+		if err := s.bills.searchQuery(ctx, search.Filters.params()).
+			Where("updated_at > ? AND updated_at <= ?", from, checkedAt).
+			Order("updated_at DESC").Limit(savedSearchMatchLimit).Find(&matches).Error; err != nil {
+			return nil, fmt.Errorf("failed to run saved search: %w", err)
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		result := SavedSearchUpdates{Search: search, Bills: make([]BillResponse, len(matches))}
+		for j := range matches {
+			result.Bills[j] = billListResponse(&matches[j])
+		}
+		updates.Searches = append(updates.Searches, result)
+	}
+
+	if updates.Alerts, err = s.alertUpdates(ctx, user.ID, from, checkedAt); err != nil {
+		return nil, err
+	}
+
+	if err := db.Model(&models.User{}).Where("id = ?", user.ID).Update("last_checked_at", checkedAt).Error; err != nil {
+		return nil, fmt.Errorf("failed to record watchlist check: %w", err)
+	}
+
+	return updates, nil
+}
+
+// watchedBills returns the bills on a user's watchlist, oldest watch first.
+func (s *WatchlistService) watchedBills(ctx context.Context, userID uint) ([]models.Bill, error) {
+	var bills []models.Bill
+	if err := s.db.WithContext(ctx).
+		Joins("JOIN watched_bills ON watched_bills.bill_id = bills.id").
+		Where("watched_bills.user_id = ?", userID).
+		Order("watched_bills.id ASC").Find(&bills).Error; err != nil {
+		return nil, fmt.Errorf("failed to list watched bills: %w", err)
+	}
+	return bills, nil
+}
+
+// savedSearchResponse converts a SavedSearch model to its API response format.
+func savedSearchResponse(search *models.SavedSearch) SavedSearchResponse {
+	var filters SearchFilters
+	if raw, err := json.Marshal(search.Params); err == nil {
+		_ = json.Unmarshal(raw, &filters) // Unknown or malformed keys are left unset
+	}
+	return SavedSearchResponse{
+		ID:        search.ID,
+		Name:      search.Name,
+		Filters:   filters,
+		CreatedAt: search.CreatedAt,
+	}
+}
+
+// filtersToParams stores search filters under their lex query names.
+func filtersToParams(filters SearchFilters) (datatypes.JSONMap, error) {
+	raw, err := json.Marshal(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode search filters: %w", err)
+	}
+	var params datatypes.JSONMap
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, fmt.Errorf("failed to encode search filters: %w", err)
+	}
+	return params, nil
+}
+
+// hashAPIKey returns the hex SHA-256 of an API key.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// APIKeyInput authenticates a watchlist request. Signed-in users send
+// their session token as a bearer token instead.
+type APIKeyInput struct {
+	APIKey string `header:"X-API-Key" doc:"API key returned when the user was created; not needed with a session token"`
+}
+
+// CreateUserInput is the request for creating a user
+type CreateUserInput struct {
+	Body struct {
+		Name string `json:"name" minLength:"1" maxLength:"100" doc:"Display name"`
+	}
+}
+
+// CreateUserOutput is the response for creating a user
+type CreateUserOutput struct {
+	Status int
+	Body   UserResponse
+}
+
+// GetWatchlistOutput is the response for a user's watchlist
+type GetWatchlistOutput struct {
+	Body WatchlistResponse
+}
+
+// SaveSearchInput is the request for saving a search
+type SaveSearchInput struct {
+	APIKeyInput
+	Body struct {
+		Name    string        `json:"name" minLength:"1" maxLength:"100" doc:"Name of the saved search"`
+		Filters SearchFilters `json:"filters" doc:"Search filters, as accepted by /api/v1/lex"`
+	}
+}
+
+// SaveSearchOutput is the response for saving a search
+type SaveSearchOutput struct {
+	Status int
+	Body   SavedSearchResponse
+}
+
+// DeleteSearchInput is the request for deleting a saved search
+type DeleteSearchInput struct {
+	APIKeyInput
+	ID uint `path:"id" minimum:"1" doc:"Saved search ID"`
+}
+
+// WatchBillInput is the request for watching or unwatching a bill
+type WatchBillInput struct {
+	APIKeyInput
+	ID uint `path:"id" minimum:"1" doc:"Bill ID"`
+}
+
+// GetWatchlistUpdatesInput is the request for watchlist updates
+type GetWatchlistUpdatesInput struct {
+	APIKeyInput
+	Since time.Time `query:"since" doc:"Report changes after this time (RFC 3339); defaults to the last check"`
+}
+
+// GetWatchlistUpdatesOutput is the response for watchlist updates
+type GetWatchlistUpdatesOutput struct {
+	Body WatchlistUpdates
+}
+
+// authError converts an authentication failure to an HTTP error.
+func authError(err error) error {
+	if errors.Is(err, ErrInvalidAPIKey) {
+		return huma.Error401Unauthorized("missing or invalid API key or session token")
+	}
+	return huma.Error500InternalServerError("failed to authenticate: " + err.Error())
+}
+
+// RegisterWatchlistRoutes registers user, saved search, watchlist, keyword alert, and calendar endpoints with Huma
+func RegisterWatchlistRoutes(api huma.API, s *WatchlistService) {
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-user",
+		Method:        http.MethodPost,
+		Path:          "/api/v1/users",
+		Summary:       "Create a user",
+		Description:   "Creates a user with the reader role and returns its API key, which is shown only once. An admin can grant it another role.",
+		Errors:        []int{http.StatusInternalServerError},
+		Tags:          []string{"Watchlist"},
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *CreateUserInput) (*CreateUserOutput, error) {
+		user, err := s.CreateUser(ctx, input.Body.Name)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to create user: " + err.Error())
+		}
+		auditTarget(ctx, user.ID)
+		return &CreateUserOutput{Status: http.StatusCreated, Body: *user}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-watchlist",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/watchlist",
+		Summary:     "Get watchlist",
+		Description: "Returns the caller's saved searches and watched bills",
+		Errors:      []int{http.StatusUnauthorized},
+		Tags:        []string{"Watchlist"},
+	}, func(ctx context.Context, input *APIKeyInput) (*GetWatchlistOutput, error) {
+		user, err := s.Authenticate(ctx, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		watchlist, err := s.GetWatchlist(ctx, user)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to get watchlist: " + err.Error())
+		}
+		return &GetWatchlistOutput{Body: *watchlist}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "save-search",
+		Method:        http.MethodPost,
+		Path:          "/api/v1/watchlist/searches",
+		Summary:       "Save a search",
+		Description:   "Saves a bill search; bills matching it are reported by the watchlist updates endpoint",
+		Errors:        []int{http.StatusUnauthorized, http.StatusUnprocessableEntity},
+		Tags:          []string{"Watchlist"},
+		DefaultStatus: http.StatusCreated,
+	}, func(ctx context.Context, input *SaveSearchInput) (*SaveSearchOutput, error) {
+		user, err := s.Authenticate(ctx, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		search, err := s.SaveSearch(ctx, user, input.Body.Name, input.Body.Filters)
+		switch {
+		case errors.Is(err, ErrEmptySearch):
+			return nil, huma.Error422UnprocessableEntity(err.Error())
+		case err != nil:
+			return nil, huma.Error500InternalServerError("failed to save search: " + err.Error())
+		}
+		auditTarget(ctx, search.ID)
+		return &SaveSearchOutput{Status: http.StatusCreated, Body: *search}, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-saved-search",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/watchlist/searches/{id}",
+		Summary:       "Delete a saved search",
+		Tags:          []string{"Watchlist"},
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *DeleteSearchInput) (*struct{}, error) {
+		user, err := s.Authenticate(ctx, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		err = s.DeleteSearch(ctx, user, input.ID)
+		switch {
+		case errors.Is(err, ErrSavedSearchNotFound):
+			return nil, huma.Error404NotFound("saved search not found")
+		case err != nil:
+			return nil, huma.Error500InternalServerError("failed to delete saved search: " + err.Error())
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "watch-bill",
+		Method:        http.MethodPut,
+		Path:          "/api/v1/watchlist/bills/{id}",
+		Summary:       "Watch a bill",
+		Description:   "Adds a bill to the caller's watchlist",
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound},
+		Tags:          []string{"Watchlist"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *WatchBillInput) (*struct{}, error) {
+		user, err := s.Authenticate(ctx, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		if err := s.WatchBill(ctx, user, input.ID); err != nil {
+			return nil, serviceError(err, "failed to watch bill")
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "unwatch-bill",
+		Method:        http.MethodDelete,
+		Path:          "/api/v1/watchlist/bills/{id}",
+		Summary:       "Unwatch a bill",
+		Description:   "Removes a bill from the caller's watchlist",
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound},
+		Tags:          []string{"Watchlist"},
+		DefaultStatus: http.StatusNoContent,
+	}, func(ctx context.Context, input *WatchBillInput) (*struct{}, error) {
+		user, err := s.Authenticate(ctx, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		err = s.UnwatchBill(ctx, user, input.ID)
+		switch {
+		case errors.Is(err, ErrBillNotFound):
+			return nil, apiError(http.StatusNotFound, CodeBillNotFound, "bill is not on the watchlist")
+		case err != nil:
+			return nil, huma.Error500InternalServerError("failed to unwatch bill: " + err.Error())
+		}
+		return nil, nil
+	})
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-watchlist-updates",
+		Method:      http.MethodGet,
+		Path:        "/api/v1/watchlist/updates",
+		Summary:     "Get watchlist updates",
+		Description: "Returns changes to watched bills, bills newly matching saved searches, and keyword alert matches since the last check (or 'since'), then records the check",
+		Errors:      []int{http.StatusUnauthorized},
+		Tags:        []string{"Watchlist"},
+	}, func(ctx context.Context, input *GetWatchlistUpdatesInput) (*GetWatchlistUpdatesOutput, error) {
+		user, err := s.Authenticate(ctx, input.APIKey)
+		if err != nil {
+			return nil, authError(err)
+		}
+		var since *time.Time
+		if !input.Since.IsZero() {
+			since = &input.Since
+		}
+		updates, err := s.GetUpdates(ctx, user, since)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to get watchlist updates: " + err.Error())
+		}
+		return &GetWatchlistUpdatesOutput{Body: *updates}, nil
+	})
+
+	registerKeywordAlertRoutes(api, s)
+	registerCalendarRoutes(api, s)
+}
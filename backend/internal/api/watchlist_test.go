@@ -0,0 +1,209 @@
+package api_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/api"
+	"github.com/drewjst/deltagov/internal/congresstest"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// newWatchlist returns a watchlist service over a fresh database.
+func newWatchlist(t *testing.T) (*api.WatchlistService, *gorm.DB) {
+	t.Helper()
+	db := congresstest.OpenDB(t)
+	return api.NewWatchlistService(db, api.NewBillService(db, nil)), db
+}
+
+// createUser creates a user and returns it as authenticated by its key.
+func createUser(t *testing.T, s *api.WatchlistService, name string) *models.User {
+	t.Helper()
+	ctx := context.Background()
+	created, err := s.CreateUser(ctx, name)
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	user, err := s.Authenticate(ctx, created.APIKey)
+	if err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+	return user
+}
+
+// createBill stores a federal bill.
+func createBill(t *testing.T, db *gorm.DB, number int) *models.Bill {
+	t.Helper()
+	bill := models.Bill{Congress: 119, BillType: "HR", Number: strconv.Itoa(number), BillNumber: number,
+		Title: "Test Act", Jurisdiction: models.JurisdictionFederal}
+	if err := db.Create(&bill).Error; err != nil {
+		t.Fatalf("Failed to create bill: %v", err)
+	}
+	return &bill
+}
+
+// TestAPIKeys verifies API keys are stored only as their SHA-256 and
+// authenticate their user.
+func TestAPIKeys(t *testing.T) {
+	s, db := newWatchlist(t)
+	ctx := context.Background()
+
+	created, err := s.CreateUser(ctx, "Analyst")
+	if err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	if !strings.HasPrefix(created.APIKey, "dg_") || len(created.APIKey) != 3+64 {
+		t.Errorf("Unexpected API key format: %q", created.APIKey)
+	}
+	if created.Role != models.UserRoleReader {
+		t.Errorf("Expected a reader, got %q", created.Role)
+	}
+
+	var stored models.User
+	if err := db.First(&stored, created.ID).Error; err != nil {
+		t.Fatalf("Failed to read user: %v", err)
+	}
+	sum := sha256.Sum256([]byte(created.APIKey))
+	if stored.APIKeyHash != hex.EncodeToString(sum[:]) {
+		t.Errorf("Expected the key's SHA-256 to be stored, got %q", stored.APIKeyHash)
+	}
+
+	user, err := s.Authenticate(ctx, created.APIKey)
+	if err != nil || user.ID != created.ID {
+		t.Fatalf("Authenticate(key) = %+v, %v; want user %d", user, err, created.ID)
+	}
+	for _, key := range []string{"", "dg_unknown", stored.APIKeyHash} {
+		if _, err := s.Authenticate(ctx, key); !errors.Is(err, api.ErrInvalidAPIKey) {
+			t.Errorf("Authenticate(%q) error = %v, want ErrInvalidAPIKey", key, err)
+		}
+	}
+}
+
+// TestWatchlistIsolation verifies users can't remove each other's saved
+// searches or watched bills.
+func TestWatchlistIsolation(t *testing.T) {
+	s, db := newWatchlist(t)
+	ctx := context.Background()
+	owner, other := createUser(t, s, "Owner"), createUser(t, s, "Other")
+	bill := createBill(t, db, 1)
+
+	search, err := s.SaveSearch(ctx, owner, "This congress", api.SearchFilters{Congress: 119})
+	if err != nil {
+		t.Fatalf("SaveSearch failed: %v", err)
+	}
+	if err := s.WatchBill(ctx, owner, bill.ID); err != nil {
+		t.Fatalf("WatchBill failed: %v", err)
+	}
+
+	if err := s.DeleteSearch(ctx, other, search.ID); !errors.Is(err, api.ErrSavedSearchNotFound) {
+		t.Errorf("DeleteSearch by another user: error = %v, want ErrSavedSearchNotFound", err)
+	}
+	if err := s.UnwatchBill(ctx, other, bill.ID); !errors.Is(err, api.ErrBillNotFound) {
+		t.Errorf("UnwatchBill by another user: error = %v, want ErrBillNotFound", err)
+	}
+	watchlist, err := s.GetWatchlist(ctx, owner)
+	if err != nil {
+		t.Fatalf("GetWatchlist failed: %v", err)
+	}
+	if len(watchlist.Searches) != 1 || len(watchlist.Bills) != 1 {
+		t.Fatalf("Owner's watchlist changed: %+v", watchlist)
+	}
+	if watchlist, err := s.GetWatchlist(ctx, other); err != nil || len(watchlist.Searches) != 0 || len(watchlist.Bills) != 0 {
+		t.Errorf("Other user's watchlist = %+v, %v; want empty", watchlist, err)
+	}
+
+	if err := s.DeleteSearch(ctx, owner, search.ID); err != nil {
+		t.Errorf("DeleteSearch by owner failed: %v", err)
+	}
+	if err := s.UnwatchBill(ctx, owner, bill.ID); err != nil {
+		t.Errorf("UnwatchBill by owner failed: %v", err)
+	}
+	if _, err := s.SaveSearch(ctx, owner, "Everything", api.SearchFilters{}); !errors.Is(err, api.ErrEmptySearch) {
+		t.Errorf("SaveSearch without filters: error = %v, want ErrEmptySearch", err)
+	}
+}
+
+// TestGetUpdates verifies updates are reported since the user's last
+// check, or since its creation before the first, unless since is given,
+// and that each check is recorded.
+func TestGetUpdates(t *testing.T) {
+	s, db := newWatchlist(t)
+	ctx := context.Background()
+	user := createUser(t, s, "Analyst")
+	bill := createBill(t, db, 1)
+	if err := s.WatchBill(ctx, user, bill.ID); err != nil {
+		t.Fatalf("WatchBill failed: %v", err)
+	}
+	if _, err := s.SaveSearch(ctx, user, "This congress", api.SearchFilters{Congress: 119}); err != nil {
+		t.Fatalf("SaveSearch failed: %v", err)
+	}
+	addEvent := func(value string) {
+		t.Helper()
+		if err := db.Create(&models.BillEvent{BillID: bill.ID, EventType: models.BillEventStatusChanged,
+			OccurredAt: time.Now(), NewValue: value}).Error; err != nil {
+			t.Fatalf("Failed to create event: %v", err)
+		}
+	}
+	reload := func() {
+		t.Helper()
+		if err := db.First(user, user.ID).Error; err != nil {
+			t.Fatalf("Failed to read user: %v", err)
+		}
+	}
+
+	addEvent("Passed House")
+	first, err := s.GetUpdates(ctx, user, nil)
+	if err != nil {
+		t.Fatalf("GetUpdates failed: %v", err)
+	}
+	if !first.Since.Equal(user.CreatedAt) {
+		t.Errorf("First check: since = %v, want the user's creation %v", first.Since, user.CreatedAt)
+	}
+	if len(first.Bills) != 1 || len(first.Bills[0].Changes) != 1 || first.Bills[0].Changes[0].NewValue != "Passed House" {
+		t.Errorf("First check: bills = %+v, want the one change", first.Bills)
+	}
+	if len(first.Searches) != 1 || len(first.Searches[0].Bills) != 1 {
+		t.Errorf("First check: searches = %+v, want the bill matched", first.Searches)
+	}
+
+	reload()
+	if user.LastCheckedAt == nil || !user.LastCheckedAt.Equal(first.CheckedAt) {
+		t.Fatalf("Check not recorded: last checked at %v, want %v", user.LastCheckedAt, first.CheckedAt)
+	}
+	second, err := s.GetUpdates(ctx, user, nil)
+	if err != nil {
+		t.Fatalf("GetUpdates failed: %v", err)
+	}
+	if !second.Since.Equal(first.CheckedAt) || len(second.Bills) != 0 || len(second.Searches) != 0 {
+		t.Errorf("Second check: got %+v, want nothing since %v", second, first.CheckedAt)
+	}
+
+	addEvent("Passed Senate")
+	reload()
+	third, err := s.GetUpdates(ctx, user, nil)
+	if err != nil {
+		t.Fatalf("GetUpdates failed: %v", err)
+	}
+	if len(third.Bills) != 1 || len(third.Bills[0].Changes) != 1 || third.Bills[0].Changes[0].NewValue != "Passed Senate" {
+		t.Errorf("Third check: bills = %+v, want only the new change", third.Bills)
+	}
+
+	// An explicit since reports changes already checked
+	reload()
+	since := user.CreatedAt
+	replay, err := s.GetUpdates(ctx, user, &since)
+	if err != nil {
+		t.Fatalf("GetUpdates failed: %v", err)
+	}
+	if len(replay.Bills) != 1 || len(replay.Bills[0].Changes) != 2 {
+		t.Errorf("Check since creation: bills = %+v, want both changes", replay.Bills)
+	}
+}
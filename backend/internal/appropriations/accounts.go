@@ -0,0 +1,183 @@
+package appropriations
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/drewjst/deltagov/internal/analysis"
+)
+
+// The twelve regular appropriations bills, by the subcommittees of the
+// House and Senate Appropriations Committees that write them.
+const (
+	AgricultureFDA         = "Agriculture-FDA"
+	CommerceJusticeScience = "Commerce-Justice-Science"
+	Defense                = "Defense"
+	EnergyWater            = "Energy-Water"
+	FinancialServices      = "Financial Services-General Government"
+	HomelandSecurity       = "Homeland Security"
+	InteriorEnvironment    = "Interior-Environment"
+	LaborHHSEducation      = "Labor-HHS-Education"
+	LegislativeBranch      = "Legislative Branch"
+	MilitaryConstructionVA = "Military Construction-VA"
+	StateForeignOperations = "State-Foreign Operations"
+	TransportationHUD      = "Transportation-HUD"
+)
+
+// Subcommittees lists the twelve bills.
+var Subcommittees = []string{
+	AgricultureFDA, CommerceJusticeScience, Defense, EnergyWater, FinancialServices, HomelandSecurity,
+	InteriorEnvironment, LaborHHSEducation, LegislativeBranch, MilitaryConstructionVA, StateForeignOperations, TransportationHUD,
+}
+
+// subcommitteeNames recognizes each bill by words of its title, checked in
+// order so "Military Construction" isn't taken for defense.
+var subcommitteeNames = []struct {
+	subcommittee string
+	words        []string
+}{
+	{MilitaryConstructionVA, []string{"military construction"}},
+	{AgricultureFDA, []string{"agriculture, rural development"}},
+	{CommerceJusticeScience, []string{"commerce, justice, science"}},
+	{EnergyWater, []string{"energy and water"}},
+	{FinancialServices, []string{"financial services and general government"}},
+	{HomelandSecurity, []string{"homeland security appropriations", "department of homeland security"}},
+	{InteriorEnvironment, []string{"interior, environment", "department of the interior, environment"}},
+	{LaborHHSEducation, []string{"departments of labor, health and human services", "labor, health and human services"}},
+	{LegislativeBranch, []string{"legislative branch"}},
+	{StateForeignOperations, []string{"state, foreign operations", "national security, department of state", "department of state, foreign operations"}},
+	{TransportationHUD, []string{"transportation, housing and urban development", "transportation, and housing and urban development"}},
+	{Defense, []string{"department of defense appropriations", "defense appropriations act", "for the department of defense"}},
+}
+
+// Subcommittee returns which of the twelve bills a title names, such as a
+// bill's title or the heading of an omnibus's division, or "" for none.
+func Subcommittee(title string) string {
+	lower := strings.Join(strings.Fields(strings.ToLower(title)), " ")
+	for _, s := range subcommitteeNames {
+		for _, w := range s.words {
+			if strings.Contains(lower, w) {
+				return s.subcommittee
+			}
+		}
+	}
+	return ""
+}
+
+// Account is an appropriations account and the amount a version of a bill
+// provides it: the first amount after its heading, before any later
+// heading or section, as in "For necessary expenses of the Office of the
+// Secretary, $63,000,000, of which ...", where later amounts earmark parts
+// of that total.
+type Account struct {
+	// Key identifies the account across versions and fiscal years:
+	// subcommittee, agency, bureau, and account, lower-cased, with the
+	// occurrence among accounts so named
+	Key          string
+	Subcommittee string // One of Subcommittees, "" when unrecognized
+	FiscalYear   int    // From the enclosing division's heading, else the bill's title; 0 if neither names one
+	Title        string // e.g., "TITLE I"
+	Agency       string // e.g., "DEPARTMENT OF AGRICULTURE"
+	Bureau       string // e.g., "Food and Nutrition Service"; "" when the account follows the agency
+	Name         string // e.g., "Child Nutrition Programs"
+	Amount       analysis.DollarAmount
+}
+
+var (
+	divisionRe = regexp.MustCompile(`^DIVISION\s+[A-Z]+\b`)
+	titleRe    = regexp.MustCompile(`^TITLE\s+[IVXLC]+\b`)
+	sectionRe  = regexp.MustCompile(`^(?:SECTION|SEC\.)\s+\d+`)
+)
+
+// ParseAccounts parses the accounts of an appropriations bill's plain
+// text: within each title (and each division of an omnibus), agencies
+// are headed by all-capitals lines and accounts by title-case lines, with
+// a heading directly followed by another read as the bureau the account
+// belongs to. billTitle identifies the bill, and its fiscal year, where
+// the text has no divisions naming them. Amounts in numbered sections,
+// the general provisions, aren't accounts.
+func ParseAccounts(text, billTitle string) []Account {
+	amounts := analysis.ExtractDollarAmounts(text)
+	next := 0
+
+	subcommittee, fiscalYear := Subcommittee(billTitle), FiscalYear(billTitle)
+	var title, agency, bureau, name string
+	awaiting, inSection := false, false
+
+	occurrences := map[string]int{}
+	var accounts []Account
+	offset := 0
+	for _, line := range strings.SplitAfter(text, "\n") {
+		start, end := offset, offset+len(line)
+		offset = end
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case divisionRe.MatchString(trimmed):
+			if s := Subcommittee(trimmed); s != "" {
+				subcommittee = s
+			}
+			if fy := FiscalYear(trimmed); fy != 0 {
+				fiscalYear = fy
+			}
+			title, agency, bureau, name, awaiting, inSection = "", "", "", "", false, false
+			continue
+		case titleRe.MatchString(trimmed):
+			title = titleRe.FindString(trimmed)
+			// "TITLE I--AGRICULTURAL PROGRAMS" names the agency too
+			agency = strings.Trim(strings.TrimPrefix(trimmed, title), " -\u2014:")
+			bureau, name, awaiting, inSection = "", "", false, false
+			continue
+		case sectionRe.MatchString(trimmed):
+			inSection, awaiting = true, false
+		case analysis.IsHeadingLine(trimmed) && isCapitals(trimmed):
+			agency, bureau, name, awaiting, inSection = trimmed, "", "", false, false
+			continue
+		case analysis.IsHeadingLine(trimmed) && !inSection:
+			if awaiting {
+				bureau = name // A heading with no amount heads the accounts after it
+			}
+			name, awaiting = trimmed, true
+			continue
+		}
+
+		// The account's amount is the first in the lines after its heading
+		for next < len(amounts) && amounts[next].Offset < end {
+			amount := amounts[next]
+			next++
+			if amount.Offset < start || !awaiting || name == "" {
+				continue
+			}
+			base := strings.ToLower(strings.Join([]string{subcommittee, agency, bureau, name}, "|"))
+			occurrences[base]++
+			accounts = append(accounts, Account{
+				Key:          fmt.Sprintf("%s|%d", base, occurrences[base]),
+				Subcommittee: subcommittee,
+				FiscalYear:   fiscalYear,
+				Title:        title,
+				Agency:       agency,
+				Bureau:       bureau,
+				Name:         name,
+				Amount:       amount,
+			})
+			awaiting = false
+		}
+	}
+	return accounts
+}
+
+// isCapitals reports whether a line's letters are all capitals.
+func isCapitals(line string) bool {
+	letters := false
+	for _, r := range line {
+		if unicode.IsLetter(r) {
+			if !unicode.IsUpper(r) {
+				return false
+			}
+			letters = true
+		}
+	}
+	return letters
+}
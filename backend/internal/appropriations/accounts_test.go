@@ -0,0 +1,68 @@
+package appropriations_test
+
+import (
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/appropriations"
+)
+
+func TestSubcommittee(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Military Construction, Veterans Affairs, and Related Agencies Appropriations Act, 2026", appropriations.MilitaryConstructionVA},
+		{"Department of Defense Appropriations Act, 2026", appropriations.Defense},
+		{"DIVISION B—COMMERCE, JUSTICE, SCIENCE, AND RELATED AGENCIES APPROPRIATIONS ACT, 2024", appropriations.CommerceJusticeScience},
+		{"Continuing Appropriations and Extensions Act, 2026", ""},
+	}
+	for _, tt := range tests {
+		if got := appropriations.Subcommittee(tt.title); got != tt.want {
+			t.Errorf("Subcommittee(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
+
+func TestParseAccounts(t *testing.T) {
+	text := "DIVISION A—AGRICULTURE, RURAL DEVELOPMENT, FOOD AND DRUG ADMINISTRATION, AND RELATED AGENCIES APPROPRIATIONS ACT, 2024\n" +
+		"TITLE I\n" +
+		"AGRICULTURAL PROGRAMS\n" +
+		"Office of the Secretary\n" +
+		"For necessary expenses of the Office of the Secretary, $63,000,000, of which not to exceed $5,000,000 shall be available for the immediate Office of the Secretary.\n" +
+		"Agricultural Research Service\n" +
+		"Salaries and Expenses\n" +
+		"For necessary expenses of the Agricultural Research Service, $1,800,000,000.\n" +
+		"Buildings and Facilities\n" +
+		"For the acquisition of land, $50,000,000, to remain available until expended.\n" +
+		"TITLE VII\n" +
+		"GENERAL PROVISIONS\n" +
+		"SEC. 701. Of the unobligated balances, $10,000,000 are hereby rescinded.\n" +
+		"DIVISION B—COMMERCE, JUSTICE, SCIENCE, AND RELATED AGENCIES APPROPRIATIONS ACT, 2024\n" +
+		"TITLE I\n" +
+		"DEPARTMENT OF COMMERCE\n" +
+		"Salaries and Expenses\n" +
+		"For necessary expenses, $80,000,000.\n"
+
+	got := appropriations.ParseAccounts(text, "Consolidated Appropriations Act, 2024")
+	want := []struct {
+		key    string
+		sub    string
+		bureau string
+		name   string
+		amount int64
+	}{
+		{"agriculture-fda|agricultural programs||office of the secretary|1", appropriations.AgricultureFDA, "", "Office of the Secretary", 63_000_000},
+		{"agriculture-fda|agricultural programs|agricultural research service|salaries and expenses|1", appropriations.AgricultureFDA, "Agricultural Research Service", "Salaries and Expenses", 1_800_000_000},
+		{"agriculture-fda|agricultural programs|agricultural research service|buildings and facilities|1", appropriations.AgricultureFDA, "Agricultural Research Service", "Buildings and Facilities", 50_000_000},
+		{"commerce-justice-science|department of commerce||salaries and expenses|1", appropriations.CommerceJusticeScience, "", "Salaries and Expenses", 80_000_000},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ParseAccounts returned %d accounts, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Key != w.key || g.Subcommittee != w.sub || g.Bureau != w.bureau || g.Name != w.name || g.Amount.Value != w.amount || g.FiscalYear != 2024 || g.Title != "TITLE I" {
+			t.Errorf("account %d = %+v, want %+v", i, g, w)
+		}
+	}
+}
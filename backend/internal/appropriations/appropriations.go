@@ -0,0 +1,125 @@
+// Package appropriations reads the funding calendar of appropriations
+// bills: which are continuing resolutions (CRs), the date a CR's funding
+// runs out, and the fiscal year a bill funds.
+package appropriations
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// continuingSubject is the CRS legislative subject of continuing
+// resolutions.
+const continuingSubject = "continuing appropriations"
+
+// datePattern matches a date such as "November 21, 2025" or "Nov. 21, 2025".
+const datePattern = `(Jan(?:uary|\.)?|Feb(?:ruary|\.)?|Mar(?:ch|\.)?|Apr(?:il|\.)?|May|June?|July?|Aug(?:ust|\.)?|Sept?(?:ember|\.)?|Oct(?:ober|\.)?|Nov(?:ember|\.)?|Dec(?:ember|\.)?)\s+(\d{1,2}),\s+(\d{4})`
+
+var (
+	// DateRe matches a date such as "November 21, 2025"; ParseDate parses
+	// its submatches.
+	DateRe = regexp.MustCompile(datePattern)
+
+	// expiresRe matches the date a CR's funding lasts until, e.g., "until
+	// November 21, 2025" or, ending a list of conditions of which
+	// whichever first occurs, "or (3) November 21, 2025"
+	expiresRe = regexp.MustCompile(`(?i)\b(?:until|through|or)\s+(?:\(\d+\)\s+)?` + datePattern)
+
+	// fiscalYearRe matches "fiscal year ending September 30, 2026",
+	// "fiscal year 2026", and the year of an "Appropriations Act, 2026"
+	fiscalYearRe = regexp.MustCompile(`(?i)fiscal\s+year\s+(?:ending\s+September\s+30,\s+)?(\d{4})|appropriations(?:\s+and\s+\w+)?\s+act,\s+(\d{4})`)
+
+	continuingRe = regexp.MustCompile(`(?i)continuing\s+appropriations|continuing\s+resolution`)
+)
+
+// months maps the first three letters of a month's name to the month.
+var months = map[string]time.Month{
+	"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+	"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+	"sep": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+}
+
+// IsContinuing reports whether a bill is a continuing resolution, by its
+// title or its CRS subjects.
+func IsContinuing(title string, subjects []string) bool {
+	if continuingRe.MatchString(title) {
+		return true
+	}
+	for _, s := range subjects {
+		if strings.EqualFold(s, continuingSubject) {
+			return true
+		}
+	}
+	return false
+}
+
+// Expiration returns the day a CR's funding runs out: the date its text
+// most often continues funding until, the latest of those tied, since a
+// CR repeats its end date in every section extending it while exceptions
+// name dates of their own. It reports false when the text names none.
+func Expiration(text string) (time.Time, bool) {
+	counts := map[time.Time]int{}
+	for _, m := range expiresRe.FindAllStringSubmatch(text, -1) {
+		if date, ok := ParseDate(m[1:]); ok {
+			counts[date]++
+		}
+	}
+	if len(counts) == 0 {
+		return time.Time{}, false
+	}
+	dates := make([]time.Time, 0, len(counts))
+	for d := range counts {
+		dates = append(dates, d)
+	}
+	sort.Slice(dates, func(i, j int) bool {
+		if counts[dates[i]] != counts[dates[j]] {
+			return counts[dates[i]] > counts[dates[j]]
+		}
+		return dates[i].After(dates[j])
+	})
+	return dates[0], true
+}
+
+// FiscalYear returns the fiscal year a bill's title funds, or 0 when it
+// names none.
+func FiscalYear(title string) int {
+	m := fiscalYearRe.FindStringSubmatch(title)
+	if m == nil {
+		return 0
+	}
+	year, _ := strconv.Atoi(m[1] + m[2])
+	return year
+}
+
+// FiscalYearOf returns the federal fiscal year a day falls in, which
+// begins on October 1 of the year before.
+func FiscalYearOf(t time.Time) int {
+	if t.Month() >= time.October {
+		return t.Year() + 1
+	}
+	return t.Year()
+}
+
+// FiscalYearStart returns the first day of a fiscal year.
+func FiscalYearStart(year int) time.Time {
+	return time.Date(year-1, time.October, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// ParseDate parses the month, day, and year submatches of DateRe, at
+// midnight UTC. It reports false for a day the month doesn't have.
+func ParseDate(m []string) (time.Time, bool) {
+	month, ok := months[strings.ToLower(m[0][:3])]
+	if !ok {
+		return time.Time{}, false
+	}
+	day, _ := strconv.Atoi(m[1])
+	year, _ := strconv.Atoi(m[2])
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if date.Day() != day { // e.g., February 30
+		return time.Time{}, false
+	}
+	return date, true
+}
@@ -0,0 +1,62 @@
+package appropriations_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/appropriations"
+)
+
+func TestIsContinuing(t *testing.T) {
+	tests := []struct {
+		title    string
+		subjects []string
+		want     bool
+	}{
+		{"Continuing Appropriations and Extensions Act, 2026", nil, true},
+		{"Making further continuing appropriations for fiscal year 2025, and for other purposes.", nil, true},
+		{"Full-Year Funding Act", []string{"Appropriations", "Continuing appropriations"}, true},
+		{"Department of Defense Appropriations Act, 2026", []string{"Appropriations"}, false},
+	}
+	for _, tt := range tests {
+		if got := appropriations.IsContinuing(tt.title, tt.subjects); got != tt.want {
+			t.Errorf("IsContinuing(%q, %v) = %v, want %v", tt.title, tt.subjects, got, tt.want)
+		}
+	}
+}
+
+func TestExpiration(t *testing.T) {
+	text := "SEC. 106. Unless otherwise provided for in this Act, appropriations and funds made available shall be available until whichever of the following first occurs: (1) the enactment into law of an appropriation for any project or activity provided for in this Act; (2) the enactment into law of the applicable appropriations Act for fiscal year 2026 without any provision for such project or activity; or (3) November 21, 2025.\n" +
+		"SEC. 120. Amounts made available by section 101 may be apportioned through November 21, 2025, notwithstanding section 1513 of title 31, or until December 31, 2025 for the census."
+	got, ok := appropriations.Expiration(text)
+	if want := time.Date(2025, 11, 21, 0, 0, 0, 0, time.UTC); !ok || !got.Equal(want) {
+		t.Errorf("Expiration = %v, %v; want %v", got, ok, want)
+	}
+	if _, ok := appropriations.Expiration("SEC. 2. Funds are appropriated for fiscal year 2026."); ok {
+		t.Error("Expiration of text without a date reported one")
+	}
+}
+
+func TestFiscalYear(t *testing.T) {
+	tests := []struct {
+		title string
+		want  int
+	}{
+		{"Making appropriations for the Department of Defense for the fiscal year ending September 30, 2026, and for other purposes.", 2026},
+		{"Making continuing appropriations for fiscal year 2025", 2025},
+		{"Continuing Appropriations and Extensions Act, 2026", 2026},
+		{"Clean Water Act Amendments of 2025", 0},
+	}
+	for _, tt := range tests {
+		if got := appropriations.FiscalYear(tt.title); got != tt.want {
+			t.Errorf("FiscalYear(%q) = %d, want %d", tt.title, got, tt.want)
+		}
+	}
+
+	if got := appropriations.FiscalYearOf(time.Date(2025, 11, 21, 0, 0, 0, 0, time.UTC)); got != 2026 {
+		t.Errorf("FiscalYearOf(2025-11-21) = %d, want 2026", got)
+	}
+	if got := appropriations.FiscalYearOf(time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC)); got != 2025 {
+		t.Errorf("FiscalYearOf(2025-09-30) = %d, want 2025", got)
+	}
+}
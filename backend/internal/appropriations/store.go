@@ -0,0 +1,119 @@
+package appropriations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/archive"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// DefaultSyncLimit is the number of versions Sync parses per call when no
+// limit is given.
+const DefaultSyncLimit = 100
+
+// Indexer parses the accounts of the versions of public federal
+// appropriations bills (not tenants' drafts) within scope.
+type Indexer struct {
+	db    *gorm.DB
+	scope func(*gorm.DB) *gorm.DB
+}
+
+// NewIndexer creates an Indexer. scope restricts the bills indexed, such
+// as scope.Rules.Query; nil indexes every public bill.
+func NewIndexer(db *gorm.DB, scope func(*gorm.DB) *gorm.DB) *Indexer {
+	if scope == nil {
+		scope = func(db *gorm.DB) *gorm.DB { return db }
+	}
+	return &Indexer{db: db, scope: scope}
+}
+
+// Sync parses the accounts of up to limit versions (DefaultSyncLimit if
+// zero or less) not yet parsed, oldest first. Continuing resolutions,
+// which fund at the prior year's rate rather than by account, are
+// recorded as parsed with no accounts. It returns how many versions it
+// parsed.
+func (ix *Indexer) Sync(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		limit = DefaultSyncLimit
+	}
+	db := ix.db.WithContext(ctx)
+
+	var ids []uint
+	if err := db.Model(&models.Version{}).
+		Joins("JOIN bills ON bills.id = versions.bill_id").
+		Joins("LEFT JOIN appropriations_account_states ON appropriations_account_states.version_id = versions.id").
+		Scopes(ix.scope).
+		Where("bills.tenant_id = 0 AND bills.jurisdiction = ? AND bills.is_spending_bill", models.JurisdictionFederal).
+		Where("appropriations_account_states.version_id IS NULL").
+		Order("versions.fetched_at ASC, versions.id ASC").Limit(limit).
+		Pluck("versions.id", &ids).Error; err != nil {
+		return 0, fmt.Errorf("appropriations: failed to list versions to parse: %w", err)
+	}
+
+	for i, id := range ids {
+		if err := ix.parseVersion(ctx, id); err != nil {
+			return i, err
+		}
+	}
+	if len(ids) > 0 {
+		logging.FromContext(ctx).Info("parsed appropriations accounts", "versions", len(ids))
+	}
+	return len(ids), nil
+}
+
+// parseVersion stores the accounts of a version and records it as
+// parsed, in one transaction.
+func (ix *Indexer) parseVersion(ctx context.Context, versionID uint) error {
+	db := ix.db.WithContext(ctx)
+	var version models.Version
+	if err := db.Select(append([]string{"id", "bill_id", "fetched_at"}, archive.TextColumns...)).
+		First(&version, versionID).Error; err != nil {
+		return fmt.Errorf("appropriations: failed to load version %d: %w", versionID, err)
+	}
+	var bill models.Bill
+	if err := db.Select("id", "title", "subjects").First(&bill, version.BillID).Error; err != nil {
+		return fmt.Errorf("appropriations: failed to load bill %d: %w", version.BillID, err)
+	}
+
+	var rows []models.AppropriationsAccount
+	if !IsContinuing(bill.Title, bill.Subjects) {
+		if err := archive.Rehydrate(&version); err != nil {
+			return err
+		}
+		for _, a := range ParseAccounts(version.PlainText, bill.Title) {
+			rows = append(rows, models.AppropriationsAccount{
+				VersionID:    version.ID,
+				BillID:       version.BillID,
+				Key:          a.Key,
+				FiscalYear:   a.FiscalYear,
+				Subcommittee: a.Subcommittee,
+				Title:        a.Title,
+				Agency:       a.Agency,
+				Bureau:       a.Bureau,
+				Account:      a.Name,
+				Raw:          a.Amount.Raw,
+				Amount:       a.Amount.Value,
+				Offset:       a.Amount.Offset,
+				FetchedAt:    version.FetchedAt,
+			})
+		}
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if len(rows) > 0 {
+			if err := tx.CreateInBatches(&rows, 500).Error; err != nil {
+				return fmt.Errorf("appropriations: failed to store accounts of version %d: %w", versionID, err)
+			}
+		}
+		state := models.AppropriationsAccountState{VersionID: versionID, Accounts: len(rows), ParsedAt: time.Now()}
+		if err := tx.Create(&state).Error; err != nil {
+			return fmt.Errorf("appropriations: failed to record version %d as parsed: %w", versionID, err)
+		}
+		return nil
+	})
+}
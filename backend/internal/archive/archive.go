@@ -0,0 +1,206 @@
+// Package archive moves the text of superseded versions out of the text
+// columns into gzip-compressed bytea columns, keeping hashes and metadata,
+// and restores it in memory when a diff or analysis needs it again.
+package archive
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/metrics"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// DefaultKeepLatest is the number of newest versions per bill kept
+// unarchived when a policy doesn't say.
+const DefaultKeepLatest = 2
+
+// batchSize is the number of versions archived per transaction.
+const batchSize = 50
+
+// TextColumns are the version columns Rehydrate and textstore.Load need.
+// Queries that select a version's text should select these rather than
+// text_content alone.
+var TextColumns = []string{"text_content", "plain_text", "text_size", "storage_ref", "archived_text", "archived_plain_text", "archived_at"}
+
+// Policy decides which versions are archived.
+type Policy struct {
+	// After is how long after it was fetched a version becomes eligible.
+	// Zero disables archival.
+	After time.Duration
+
+	// KeepLatest is the number of newest versions of each bill or document
+	// that are never archived, so current text is always served from the
+	// text columns.
+	KeepLatest int
+}
+
+// Enabled reports whether the policy archives anything.
+func (p Policy) Enabled() bool {
+	return p.After > 0
+}
+
+// Run archives every version the policy selects and returns how many were
+// archived. It is safe to run repeatedly; archived versions are skipped.
+func Run(ctx context.Context, db *gorm.DB, policy Policy) (int, error) {
+	if !policy.Enabled() {
+		return 0, nil
+	}
+	keep := policy.KeepLatest
+	if keep < 1 {
+		keep = DefaultKeepLatest
+	}
+	cutoff := time.Now().Add(-policy.After)
+
+	db = db.WithContext(ctx)
+
+	var ids []uint
+	if err := db.Raw(`
+		SELECT id FROM (
+			SELECT id, fetched_at, archived_at,
+				ROW_NUMBER() OVER (PARTITION BY bill_id, document_id ORDER BY fetched_at DESC, id DESC) AS rank
+			FROM versions
+		) ranked
+		WHERE rank > ? AND archived_at IS NULL AND fetched_at < ?
+		ORDER BY id`, keep, cutoff).Scan(&ids).Error; err != nil {
+		return 0, fmt.Errorf("archive: failed to select versions: %w", err)
+	}
+
+	archived := 0
+	for start := 0; start < len(ids); start += batchSize {
+		if err := ctx.Err(); err != nil {
+			return archived, err
+		}
+		end := min(start+batchSize, len(ids))
+		n, err := archiveBatch(db, ids[start:end])
+		archived += n
+		if err != nil {
+			return archived, err
+		}
+	}
+
+	if archived > 0 {
+		logging.FromContext(ctx).Info("archived version text", "versions", archived, "cutoff", cutoff)
+	}
+	return archived, nil
+}
+
+// archiveBatch compresses and archives the given versions in one transaction.
+func archiveBatch(db *gorm.DB, ids []uint) (int, error) {
+	var versions []models.Version
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Select(append([]string{"id"}, TextColumns...)).
+			Where("id IN ? AND archived_at IS NULL", ids).Find(&versions).Error; err != nil {
+			return fmt.Errorf("archive: failed to load versions: %w", err)
+		}
+		for i := range versions {
+			if err := Archive(&versions[i]); err != nil {
+				return err
+			}
+			v := &versions[i]
+			if err := tx.Model(&models.Version{}).Where("id = ?", v.ID).Updates(map[string]any{
+				"text_content":        "",
+				"plain_text":          "",
+				"text_size":           v.TextSize,
+				"archived_text":       v.ArchivedText,
+				"archived_plain_text": v.ArchivedPlainText,
+				"archived_at":         v.ArchivedAt,
+			}).Error; err != nil {
+				return fmt.Errorf("archive: failed to archive version %d: %w", v.ID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	metrics.VersionsArchived.Add(float64(len(versions)))
+	return len(versions), nil
+}
+
+// Archive compresses a version's text into its archive fields and empties
+// the text fields, in memory, recording TextSize if it isn't already.
+// Archived versions are left unchanged.
+func Archive(v *models.Version) error {
+	if v.ArchivedAt != nil {
+		return nil
+	}
+	text, err := compress(v.TextContent)
+	if err != nil {
+		return fmt.Errorf("archive: failed to compress version %d: %w", v.ID, err)
+	}
+	plain, err := compress(v.PlainText)
+	if err != nil {
+		return fmt.Errorf("archive: failed to compress version %d: %w", v.ID, err)
+	}
+	if v.TextSize == 0 {
+		v.TextSize = len(v.TextContent)
+	}
+	now := time.Now()
+	v.ArchivedText, v.ArchivedPlainText, v.ArchivedAt = text, plain, &now
+	v.TextContent, v.PlainText = "", ""
+	return nil
+}
+
+// Rehydrate restores an archived version's text fields from its archive
+// fields, in memory, so it can be diffed like any other version. It does
+// nothing for versions that aren't archived; the version must have been
+// loaded with TextColumns.
+func Rehydrate(v *models.Version) error {
+	if v.ArchivedAt == nil {
+		return nil
+	}
+	text, err := decompress(v.ArchivedText)
+	if err != nil {
+		return fmt.Errorf("archive: failed to decompress version %d: %w", v.ID, err)
+	}
+	plain, err := decompress(v.ArchivedPlainText)
+	if err != nil {
+		return fmt.Errorf("archive: failed to decompress version %d: %w", v.ID, err)
+	}
+	v.TextContent, v.PlainText = text, plain
+	return nil
+}
+
+// compress gzips s. Empty text compresses to nil.
+func compress(s string) ([]byte, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var buf bytes.Buffer
+	zw, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(zw, s); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompress reverses compress.
+func decompress(b []byte) (string, error) {
+	if len(b) == 0 {
+		return "", nil
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return "", err
+	}
+	defer zr.Close()
+	out, err := io.ReadAll(zr)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
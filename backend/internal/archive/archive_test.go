@@ -0,0 +1,60 @@
+package archive_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/archive"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+func TestArchiveRehydrateRoundTrip(t *testing.T) {
+	raw := "<bill><section>" + strings.Repeat("Funds are appropriated. ", 500) + "</section></bill>"
+	plain := strings.Repeat("Funds are appropriated. ", 500)
+	v := &models.Version{ID: 7, ContentHash: "abc", TextContent: raw, PlainText: plain}
+
+	if err := archive.Archive(v); err != nil {
+		t.Fatalf("Archive: %v", err)
+	}
+	if v.ArchivedAt == nil {
+		t.Fatal("ArchivedAt not set")
+	}
+	if v.TextContent != "" || v.PlainText != "" {
+		t.Error("text fields not emptied")
+	}
+	if len(v.ArchivedText) >= len(raw) {
+		t.Errorf("archived text is %d bytes, want less than %d", len(v.ArchivedText), len(raw))
+	}
+	if v.ContentHash != "abc" {
+		t.Error("content hash changed")
+	}
+
+	if err := archive.Rehydrate(v); err != nil {
+		t.Fatalf("Rehydrate: %v", err)
+	}
+	if v.TextContent != raw {
+		t.Error("TextContent not restored")
+	}
+	if v.PlainText != plain {
+		t.Error("PlainText not restored")
+	}
+}
+
+func TestRehydrateUnarchived(t *testing.T) {
+	v := &models.Version{TextContent: "text", PlainText: "text"}
+	if err := archive.Rehydrate(v); err != nil {
+		t.Fatalf("Rehydrate: %v", err)
+	}
+	if v.TextContent != "text" || v.PlainText != "text" {
+		t.Error("unarchived version changed")
+	}
+}
+
+func TestPolicyEnabled(t *testing.T) {
+	if (archive.Policy{}).Enabled() {
+		t.Error("zero policy should be disabled")
+	}
+	if !(archive.Policy{After: 1}).Enabled() {
+		t.Error("policy with After should be enabled")
+	}
+}
@@ -0,0 +1,174 @@
+package auth_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/auth"
+)
+
+const secret = "0123456789abcdef0123456789abcdef"
+
+func TestSessions(t *testing.T) {
+	if _, err := auth.NewSessions("short", 0); err == nil {
+		t.Error("NewSessions accepted a short secret")
+	}
+	sessions, err := auth.NewSessions(secret, time.Hour)
+	if err != nil {
+		t.Fatalf("NewSessions: %v", err)
+	}
+	token, expires, err := sessions.Issue(42)
+	if err != nil {
+		t.Fatalf("Issue: %v", err)
+	}
+	if d := time.Until(expires); d < 59*time.Minute || d > time.Hour {
+		t.Errorf("expires in %v, want 1h", d)
+	}
+	if id, err := sessions.Verify(token); err != nil || id != 42 {
+		t.Errorf("Verify = %d, %v, want 42", id, err)
+	}
+
+	other, _ := auth.NewSessions(strings.Repeat("x", 32), time.Hour)
+	parts := strings.Split(token, ".")
+	tampered := parts[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"deltagov","sub":"1","exp":9999999999}`)) + "." + parts[2]
+	for name, bad := range map[string]func() (uint, error){
+		"other secret": func() (uint, error) { return other.Verify(token) },
+		"tampered":     func() (uint, error) { return sessions.Verify(tampered) },
+		"garbage":      func() (uint, error) { return sessions.Verify("not-a-token") },
+	} {
+		if _, err := bad(); !errors.Is(err, auth.ErrInvalidToken) {
+			t.Errorf("%s: Verify error = %v, want ErrInvalidToken", name, err)
+		}
+	}
+
+	expired, _ := auth.NewSessions(secret, time.Nanosecond)
+	token, _, _ = expired.Issue(42)
+	time.Sleep(time.Millisecond)
+	if _, err := expired.Verify(token); !errors.Is(err, auth.ErrInvalidToken) {
+		t.Errorf("expired: Verify error = %v, want ErrInvalidToken", err)
+	}
+}
+
+// issuer is a fake OpenID Connect issuer signing ID tokens with key.
+type issuer struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]any // ID token claims; iss is filled in
+}
+
+func newIssuer(t *testing.T) *issuer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	iss := &issuer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 iss.URL,
+			"authorization_endpoint": iss.URL + "/authorize",
+			"token_endpoint":         iss.URL + "/token",
+			"jwks_uri":               iss.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA", "kid": "k1", "use": "sig",
+			"n": base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e": base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("code") != "good-code" || r.FormValue("client_secret") != "s3cret" {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		iss.claims["iss"] = iss.URL
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": iss.sign(t, iss.claims)})
+	})
+	iss.Server = httptest.NewServer(mux)
+	t.Cleanup(iss.Close)
+	return iss
+}
+
+func (iss *issuer) sign(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "k1", "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, iss.key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestProviderOIDC(t *testing.T) {
+	iss := newIssuer(t)
+	provider, err := auth.NewProvider(auth.Config{
+		Issuer:       iss.URL,
+		ClientID:     "client",
+		ClientSecret: "s3cret",
+		RedirectURL:  "https://deltagov.example/api/v1/auth/callback",
+	})
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+	ctx := context.Background()
+
+	loginURL, err := provider.AuthCodeURL(ctx, "state1", "nonce1")
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+	u, _ := url.Parse(loginURL)
+	if q := u.Query(); u.Path != "/authorize" || q.Get("state") != "state1" || q.Get("nonce") != "nonce1" ||
+		q.Get("client_id") != "client" || q.Get("scope") != "openid email profile" {
+		t.Errorf("AuthCodeURL = %s", loginURL)
+	}
+
+	valid := func() map[string]any {
+		return map[string]any{
+			"sub": "1234", "aud": "client", "exp": time.Now().Add(time.Hour).Unix(),
+			"nonce": "nonce1", "email": "ada@example.com", "name": "Ada",
+		}
+	}
+	iss.claims = valid()
+	id, err := provider.Exchange(ctx, "good-code", "nonce1")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if want := (auth.Identity{Subject: iss.URL + "|1234", Email: "ada@example.com", Name: "Ada"}); *id != want {
+		t.Errorf("Exchange = %+v, want %+v", *id, want)
+	}
+
+	for name, mutate := range map[string]func(map[string]any){
+		"wrong audience": func(c map[string]any) { c["aud"] = []string{"someone-else"} },
+		"expired":        func(c map[string]any) { c["exp"] = time.Now().Add(-time.Hour).Unix() },
+		"wrong nonce":    func(c map[string]any) { c["nonce"] = "replayed" },
+	} {
+		iss.claims = valid()
+		mutate(iss.claims)
+		if _, err := provider.Exchange(ctx, "good-code", "nonce1"); !errors.Is(err, auth.ErrInvalidToken) {
+			t.Errorf("%s: Exchange error = %v, want ErrInvalidToken", name, err)
+		}
+	}
+
+	if _, err := provider.Exchange(ctx, "bad-code", "nonce1"); err == nil {
+		t.Error("Exchange accepted a rejected code")
+	}
+}
@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrInvalidToken is returned for tokens that are malformed, badly signed,
+// expired, or issued for someone else.
+var ErrInvalidToken = errors.New("invalid token")
+
+// jwtHeader is the JOSE header of a JWT.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid,omitempty"`
+	Typ string `json:"typ,omitempty"`
+}
+
+// jwt is a JWT split into its parts, with the signature not yet checked.
+type jwt struct {
+	header    jwtHeader
+	payload   []byte
+	signed    string // header.payload, as signed
+	signature []byte
+}
+
+// parseJWT splits a compact JWT and decodes its header, payload, and
+// signature.
+func parseJWT(token string) (*jwt, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: want 3 segments, got %d", ErrInvalidToken, len(parts))
+	}
+	rawHeader, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+	t := &jwt{signed: parts[0] + "." + parts[1]}
+	if err := json.Unmarshal(rawHeader, &t.header); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+	if t.payload, err = base64.RawURLEncoding.DecodeString(parts[1]); err != nil {
+		return nil, fmt.Errorf("%w: payload: %v", ErrInvalidToken, err)
+	}
+	if t.signature, err = base64.RawURLEncoding.DecodeString(parts[2]); err != nil {
+		return nil, fmt.Errorf("%w: signature: %v", ErrInvalidToken, err)
+	}
+	return t, nil
+}
+
+// signHS256 returns a compact HS256 JWT carrying claims.
+func signHS256(secret []byte, claims any) (string, error) {
+	header, err := json.Marshal(jwtHeader{Alg: "HS256", Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(hs256(secret, signed)), nil
+}
+
+// hs256 returns the HMAC-SHA256 of s.
+func hs256(secret []byte, s string) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(s))
+	return mac.Sum(nil)
+}
+
+// verifyHS256 checks an HS256 signature.
+func (t *jwt) verifyHS256(secret []byte) error {
+	if t.header.Alg != "HS256" {
+		return fmt.Errorf("%w: unexpected algorithm %q", ErrInvalidToken, t.header.Alg)
+	}
+	if !hmac.Equal(t.signature, hs256(secret, t.signed)) {
+		return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+	}
+	return nil
+}
+
+// verifyRS256 checks an RS256 signature.
+func (t *jwt) verifyRS256(key *rsa.PublicKey) error {
+	if t.header.Alg != "RS256" {
+		return fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidToken, t.header.Alg)
+	}
+	digest := sha256.Sum256([]byte(t.signed))
+	if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], t.signature); err != nil {
+		return fmt.Errorf("%w: bad signature", ErrInvalidToken)
+	}
+	return nil
+}
+
+// jwk is an RSA key in a JSON Web Key Set.
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// publicKey decodes an RSA JWK.
+func (k jwk) publicKey() (*rsa.PublicKey, error) {
+	if k.Kty != "RSA" {
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("modulus: %w", err)
+	}
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("exponent: %w", err)
+	}
+	exponent := new(big.Int).SetBytes(e)
+	if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+		return nil, errors.New("exponent out of range")
+	}
+	return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+}
+
+// audience is a JWT "aud" claim, which may be a string or a list.
+type audience []string
+
+// UnmarshalJSON accepts both forms of the claim.
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+// contains reports whether the audience includes id.
+func (a audience) contains(id string) bool {
+	for _, aud := range a {
+		if aud == id {
+			return true
+		}
+	}
+	return false
+}
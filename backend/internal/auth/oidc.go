@@ -0,0 +1,358 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Providers. Google and other OpenID Connect issuers use ProviderOIDC;
+// GitHub's OAuth apps don't issue ID tokens, so they have their own flow.
+const (
+	ProviderOIDC   = "oidc"
+	ProviderGitHub = "github"
+)
+
+const (
+	defaultHTTPTimeout = 15 * time.Second
+
+	// maxResponseBytes bounds responses read from a provider.
+	maxResponseBytes = 1 << 20
+
+	// clockSkew is the leeway allowed on ID token expiry.
+	clockSkew = time.Minute
+)
+
+// GitHub's OAuth endpoints.
+const (
+	githubIssuer   = "https://github.com"
+	githubAuthURL  = "https://github.com/login/oauth/authorize"
+	githubTokenURL = "https://github.com/login/oauth/access_token"
+	githubUserURL  = "https://api.github.com/user"
+)
+
+// Config configures an identity provider.
+type Config struct {
+	Provider     string       // ProviderOIDC (the default) or ProviderGitHub
+	Issuer       string       // OIDC issuer URL, e.g., "https://accounts.google.com"; required for ProviderOIDC
+	ClientID     string       // OAuth client ID (required)
+	ClientSecret string       // OAuth client secret (required)
+	RedirectURL  string       // The API's callback URL, registered with the provider (required)
+	Scopes       []string     // Requested scopes (default: openid, email, profile; read:user, user:email for GitHub)
+	HTTPClient   *http.Client // Optional; defaults to one with a 15s timeout
+}
+
+// Identity is an authenticated end user.
+type Identity struct {
+	// Subject identifies the user across providers: the issuer and the
+	// provider's stable user ID, e.g., "https://accounts.google.com|1234".
+	Subject string
+	Email   string
+	Name    string
+}
+
+// Provider signs end users in with an OAuth 2.0 authorization code flow,
+// verifying OpenID Connect ID tokens against the issuer's published keys.
+// Its endpoints are discovered on first use. It is safe for concurrent use.
+type Provider struct {
+	cfg Config
+
+	mu        sync.Mutex
+	endpoints *endpoints
+	keys      map[string]*rsa.PublicKey // By key ID
+}
+
+// endpoints are a provider's OAuth endpoints.
+type endpoints struct {
+	Issuer   string `json:"issuer"`
+	AuthURL  string `json:"authorization_endpoint"`
+	TokenURL string `json:"token_endpoint"`
+	JWKSURL  string `json:"jwks_uri"`
+}
+
+// FromEnv returns the provider configured by OIDC_PROVIDER ("oidc", the
+// default, or "github"), OIDC_ISSUER, OIDC_CLIENT_ID, OIDC_CLIENT_SECRET,
+// OIDC_REDIRECT_URL, and OIDC_SCOPES (space-separated), or nil when
+// OIDC_CLIENT_ID is unset.
+func FromEnv() (*Provider, error) {
+	if os.Getenv("OIDC_CLIENT_ID") == "" {
+		return nil, nil
+	}
+	return NewProvider(Config{
+		Provider:     os.Getenv("OIDC_PROVIDER"),
+		Issuer:       os.Getenv("OIDC_ISSUER"),
+		ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
+		Scopes:       strings.Fields(os.Getenv("OIDC_SCOPES")),
+	})
+}
+
+// NewProvider creates a provider.
+func NewProvider(cfg Config) (*Provider, error) {
+	if cfg.Provider == "" {
+		cfg.Provider = ProviderOIDC
+	}
+	if cfg.ClientID == "" || cfg.ClientSecret == "" || cfg.RedirectURL == "" {
+		return nil, errors.New("auth: a client ID, client secret, and redirect URL are required")
+	}
+	p := &Provider{cfg: cfg}
+	switch cfg.Provider {
+	case ProviderOIDC:
+		if cfg.Issuer == "" {
+			return nil, errors.New("auth: an issuer URL is required")
+		}
+		p.cfg.Issuer = strings.TrimSuffix(cfg.Issuer, "/")
+		if len(cfg.Scopes) == 0 {
+			p.cfg.Scopes = []string{"openid", "email", "profile"}
+		}
+	case ProviderGitHub:
+		p.endpoints = &endpoints{Issuer: githubIssuer, AuthURL: githubAuthURL, TokenURL: githubTokenURL}
+		if len(cfg.Scopes) == 0 {
+			p.cfg.Scopes = []string{"read:user", "user:email"}
+		}
+	default:
+		return nil, fmt.Errorf("auth: unknown provider %q, want oidc or github", cfg.Provider)
+	}
+	if p.cfg.HTTPClient == nil {
+		p.cfg.HTTPClient = &http.Client{Timeout: defaultHTTPTimeout}
+	}
+	return p, nil
+}
+
+// Name returns the provider's issuer, e.g., "https://accounts.google.com".
+func (p *Provider) Name() string {
+	if p.cfg.Provider == ProviderGitHub {
+		return githubIssuer
+	}
+	return p.cfg.Issuer
+}
+
+// AuthCodeURL returns the URL to send the user to to sign in. state is
+// returned to the callback unchanged; nonce is bound into the ID token.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce string) (string, error) {
+	ep, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	params := url.Values{
+		"response_type": {"code"},
+		"client_id":     {p.cfg.ClientID},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"scope":         {strings.Join(p.cfg.Scopes, " ")},
+		"state":         {state},
+	}
+	if p.cfg.Provider == ProviderOIDC {
+		params.Set("nonce", nonce)
+	}
+	sep := "?"
+	if strings.Contains(ep.AuthURL, "?") {
+		sep = "&"
+	}
+	return ep.AuthURL + sep + params.Encode(), nil
+}
+
+// Exchange redeems an authorization code and returns who signed in. For
+// OIDC providers, the ID token must be signed by the issuer, be for this
+// client, be unexpired, and carry nonce.
+func (p *Provider) Exchange(ctx context.Context, code, nonce string) (*Identity, error) {
+	ep, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"client_id":     {p.cfg.ClientID},
+		"client_secret": {p.cfg.ClientSecret},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ep.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	var tokens struct {
+		AccessToken string `json:"access_token"`
+		IDToken     string `json:"id_token"`
+		Error       string `json:"error"`
+		Description string `json:"error_description"`
+	}
+	if err := p.doJSON(req, &tokens); err != nil {
+		return nil, fmt.Errorf("auth: token exchange failed: %w", err)
+	}
+	// GitHub reports errors with a 200
+	if tokens.Error != "" {
+		return nil, fmt.Errorf("auth: token exchange failed: %s: %s", tokens.Error, tokens.Description)
+	}
+
+	if p.cfg.Provider == ProviderGitHub {
+		return p.githubIdentity(ctx, tokens.AccessToken)
+	}
+	if tokens.IDToken == "" {
+		return nil, errors.New("auth: token response has no ID token")
+	}
+	return p.verifyIDToken(ctx, ep, tokens.IDToken, nonce)
+}
+
+// verifyIDToken checks an ID token and returns the identity it asserts.
+func (p *Provider) verifyIDToken(ctx context.Context, ep *endpoints, token, nonce string) (*Identity, error) {
+	t, err := parseJWT(token)
+	if err != nil {
+		return nil, err
+	}
+	key, err := p.key(ctx, ep, t.header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.verifyRS256(key); err != nil {
+		return nil, err
+	}
+
+	var claims struct {
+		Issuer    string   `json:"iss"`
+		Subject   string   `json:"sub"`
+		Audience  audience `json:"aud"`
+		ExpiresAt int64    `json:"exp"`
+		Nonce     string   `json:"nonce"`
+		Email     string   `json:"email"`
+		Name      string   `json:"name"`
+	}
+	if err := json.Unmarshal(t.payload, &claims); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+	switch {
+	case claims.Issuer != ep.Issuer:
+		return nil, fmt.Errorf("%w: issuer %q", ErrInvalidToken, claims.Issuer)
+	case !claims.Audience.contains(p.cfg.ClientID):
+		return nil, fmt.Errorf("%w: not issued for this client", ErrInvalidToken)
+	case time.Now().Add(-clockSkew).Unix() >= claims.ExpiresAt:
+		return nil, fmt.Errorf("%w: expired", ErrInvalidToken)
+	case claims.Nonce != nonce:
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrInvalidToken)
+	case claims.Subject == "":
+		return nil, fmt.Errorf("%w: no subject", ErrInvalidToken)
+	}
+	return &Identity{Subject: claims.Issuer + "|" + claims.Subject, Email: claims.Email, Name: claims.Name}, nil
+}
+
+// githubIdentity fetches the GitHub user an access token belongs to.
+func (p *Provider) githubIdentity(ctx context.Context, accessToken string) (*Identity, error) {
+	if accessToken == "" {
+		return nil, errors.New("auth: token response has no access token")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, githubUserURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to build user request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	var user struct {
+		ID    int64  `json:"id"`
+		Login string `json:"login"`
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	}
+	if err := p.doJSON(req, &user); err != nil {
+		return nil, fmt.Errorf("auth: failed to fetch GitHub user: %w", err)
+	}
+	if user.ID == 0 {
+		return nil, errors.New("auth: GitHub user has no ID")
+	}
+	name := user.Name
+	if name == "" {
+		name = user.Login
+	}
+	return &Identity{Subject: githubIssuer + "|" + strconv.FormatInt(user.ID, 10), Email: user.Email, Name: name}, nil
+}
+
+// discover returns the provider's endpoints, fetching the issuer's OpenID
+// configuration the first time.
+func (p *Provider) discover(ctx context.Context) (*endpoints, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.endpoints != nil {
+		return p.endpoints, nil
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.cfg.Issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to build discovery request: %w", err)
+	}
+	var ep endpoints
+	if err := p.doJSON(req, &ep); err != nil {
+		return nil, fmt.Errorf("auth: discovery failed: %w", err)
+	}
+	if ep.Issuer != p.cfg.Issuer {
+		return nil, fmt.Errorf("auth: discovery returned issuer %q, want %q", ep.Issuer, p.cfg.Issuer)
+	}
+	if ep.AuthURL == "" || ep.TokenURL == "" || ep.JWKSURL == "" {
+		return nil, errors.New("auth: discovery document is missing endpoints")
+	}
+	p.endpoints = &ep
+	return p.endpoints, nil
+}
+
+// key returns the issuer's signing key with an ID, refetching the key set
+// when the ID is unknown, as issuers rotate keys.
+func (p *Provider) key(ctx context.Context, ep *endpoints, kid string) (*rsa.PublicKey, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep.JWKSURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("auth: failed to build key set request: %w", err)
+	}
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := p.doJSON(req, &set); err != nil {
+		return nil, fmt.Errorf("auth: failed to fetch key set: %w", err)
+	}
+	keys := make(map[string]*rsa.PublicKey, len(set.Keys))
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		// Keys of other types are skipped; only RS256 tokens are accepted
+		if key, err := k.publicKey(); err == nil {
+			keys[k.Kid] = key
+		}
+	}
+	p.keys = keys
+
+	key, ok := keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown signing key %q", ErrInvalidToken, kid)
+	}
+	return key, nil
+}
+
+// doJSON sends a request and decodes its JSON response into v.
+func (p *Provider) doJSON(req *http.Request, v any) error {
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: status %d: %s", req.URL.Host, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, v)
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// DefaultSessionTTL is how long session tokens are valid by default.
+const DefaultSessionTTL = 24 * time.Hour
+
+// sessionIssuer is the "iss" claim of session tokens.
+const sessionIssuer = "deltagov"
+
+// minSecretLength is the shortest session secret accepted, in bytes.
+const minSecretLength = 32
+
+// Sessions issues and verifies DeltaGov session tokens: HS256 JWTs whose
+// subject is a user ID.
+type Sessions struct {
+	secret []byte
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// sessionClaims are the claims of a session token.
+type sessionClaims struct {
+	Issuer    string `json:"iss"`
+	Subject   string `json:"sub"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// NewSessions creates a session issuer signing with secret, which must be
+// at least 32 bytes. Tokens are valid for ttl (default: DefaultSessionTTL).
+func NewSessions(secret string, ttl time.Duration) (*Sessions, error) {
+	if len(secret) < minSecretLength {
+		return nil, fmt.Errorf("auth: the session secret must be at least %d bytes", minSecretLength)
+	}
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+	return &Sessions{secret: []byte(secret), ttl: ttl, now: time.Now}, nil
+}
+
+// Issue returns a session token for a user and when it expires.
+func (s *Sessions) Issue(userID uint) (string, time.Time, error) {
+	now := s.now()
+	expires := now.Add(s.ttl)
+	token, err := signHS256(s.secret, sessionClaims{
+		Issuer:    sessionIssuer,
+		Subject:   strconv.FormatUint(uint64(userID), 10),
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expires.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("auth: failed to sign session: %w", err)
+	}
+	return token, expires, nil
+}
+
+// Verify returns the user ID of a session token, or an error wrapping
+// ErrInvalidToken if it isn't a valid, unexpired session token.
+func (s *Sessions) Verify(token string) (uint, error) {
+	t, err := parseJWT(token)
+	if err != nil {
+		return 0, err
+	}
+	if err := t.verifyHS256(s.secret); err != nil {
+		return 0, err
+	}
+	var claims sessionClaims
+	if err := json.Unmarshal(t.payload, &claims); err != nil {
+		return 0, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+	if claims.Issuer != sessionIssuer {
+		return 0, fmt.Errorf("%w: issuer %q", ErrInvalidToken, claims.Issuer)
+	}
+	if s.now().Unix() >= claims.ExpiresAt {
+		return 0, fmt.Errorf("%w: expired", ErrInvalidToken)
+	}
+	id, err := strconv.ParseUint(claims.Subject, 10, 0)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("%w: subject %q", ErrInvalidToken, claims.Subject)
+	}
+	return uint(id), nil
+}
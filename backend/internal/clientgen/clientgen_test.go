@@ -0,0 +1,116 @@
+package clientgen_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/api"
+	"github.com/drewjst/deltagov/internal/clientgen"
+)
+
+// spec returns the API's OpenAPI document as the generators read it.
+func spec(t *testing.T) *clientgen.Spec {
+	t.Helper()
+	data, err := json.Marshal(api.Document(api.VersionPrefix))
+	if err != nil {
+		t.Fatalf("failed to encode OpenAPI document: %v", err)
+	}
+	spec, err := clientgen.Parse(data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	return spec
+}
+
+// TestGeneratedClientsUpToDate verifies the checked-in clients match the
+// API; run go generate ./client after changing it.
+func TestGeneratedClientsUpToDate(t *testing.T) {
+	spec := spec(t)
+
+	goSrc, err := clientgen.Go(spec, "client")
+	if err != nil {
+		t.Fatalf("Go: %v", err)
+	}
+	checked, err := os.ReadFile("../../client/client_gen.go")
+	if err != nil {
+		t.Fatalf("failed to read Go client: %v", err)
+	}
+	if !bytes.Equal(goSrc, checked) {
+		t.Error("client/client_gen.go is out of date: run go generate ./client")
+	}
+
+	tsSrc, err := clientgen.TypeScript(spec)
+	if err != nil {
+		t.Fatalf("TypeScript: %v", err)
+	}
+	checked, err = os.ReadFile("../../../frontend/src/app/api/deltagov-client.ts")
+	if os.IsNotExist(err) {
+		t.Skip("frontend is not checked out")
+	}
+	if err != nil {
+		t.Fatalf("failed to read TypeScript client: %v", err)
+	}
+	if !bytes.Equal(tsSrc, checked) {
+		t.Error("frontend/src/app/api/deltagov-client.ts is out of date: run go generate ./client")
+	}
+}
+
+// TestGo_Methods verifies the Go client's pagination and streaming
+// helpers and how operation and parameter names are written.
+func TestGo_Methods(t *testing.T) {
+	src, err := clientgen.Go(spec(t), "client")
+	if err != nil {
+		t.Fatalf("Go: %v", err)
+	}
+	for _, want := range []string{
+		"func (c *Client) GetBill(ctx context.Context, id int, params *GetBillParams) (*BillResponse, error)",
+		"func (c *Client) SearchBillsAll(ctx context.Context, params *SearchBillsParams) iter.Seq2[BillResponse, error]",
+		"func (c *Client) StreamDiff(ctx context.Context, billID int, fromVersion int, toVersion int) (*Stream[DiffStreamRecord], error)",
+		`query.Set("format", "ndjson")`,
+		"func (c *Client) ExportBills(ctx context.Context, params *ExportBillsParams) (io.ReadCloser, error)",
+		"func (c *Client) DeleteDelta(ctx context.Context, id int) error",
+		`setParam(header.Set, "X-API-Key", params.APIKey)`,
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("Go client is missing %q", want)
+		}
+	}
+	if strings.Contains(string(src), "func (c *Client) GetBillAll(") {
+		t.Error("unpaginated GetBill has a pagination helper")
+	}
+}
+
+// TestTypeScript_Methods verifies the TypeScript client's pagination and
+// streaming helpers.
+func TestTypeScript_Methods(t *testing.T) {
+	src, err := clientgen.TypeScript(spec(t))
+	if err != nil {
+		t.Fatalf("TypeScript: %v", err)
+	}
+	for _, want := range []string{
+		"export class DeltaGovClient {",
+		"export interface BillResponse {",
+		"  versions?: VersionResponse[] | null;",
+		"  ): AsyncGenerator<BillResponse> {",
+		"  async *streamDiff(",
+		"    yield* readNdjson<DiffStreamRecord>(response);",
+		"`/api/v1/bills/${path(id)}`",
+		"'X-API-Key': params.apiKey",
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("TypeScript client is missing %q", want)
+		}
+	}
+}
+
+// TestParse_Invalid verifies documents without operations are rejected.
+func TestParse_Invalid(t *testing.T) {
+	for _, doc := range []string{`not json`, `{"paths": {}}`, `{"paths": {"/x": {"get": {"parameters": [{"schema": {"type": 1}}]}}}}`} {
+		if _, err := clientgen.Parse([]byte(doc)); err == nil {
+			t.Errorf("Parse(%s) succeeded, want an error", doc)
+		}
+	}
+}
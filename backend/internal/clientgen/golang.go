@@ -0,0 +1,329 @@
+package clientgen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Header is the first line of generated files.
+const Header = "Code generated by genclient from the DeltaGov OpenAPI document. DO NOT EDIT."
+
+// Go returns the source of a Go client for spec, in package pkg. It
+// declares the types and methods; the package's hand-written runtime
+// provides Client, Stream, and the request helpers they call.
+func Go(spec *Spec, pkg string) ([]byte, error) {
+	methods, err := spec.methods()
+	if err != nil {
+		return nil, err
+	}
+	g := &goWriter{spec: spec, imports: map[string]bool{}}
+	for _, name := range sortedKeys(spec.Components.Schemas) {
+		g.typeDecl(name, spec.Components.Schemas[name])
+	}
+	for _, m := range methods {
+		g.method(m)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// %s\n\npackage %s\n\n", Header, pkg)
+	if len(g.imports) > 0 {
+		out.WriteString("import (\n")
+		for _, path := range sortedKeys(g.imports) {
+			fmt.Fprintf(&out, "\t%q\n", path)
+		}
+		out.WriteString(")\n\n")
+	}
+	out.Write(g.buf.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("clientgen: generated invalid Go: %w", err)
+	}
+	return src, nil
+}
+
+// goWriter accumulates Go declarations and the imports they need.
+type goWriter struct {
+	spec    *Spec
+	buf     bytes.Buffer
+	imports map[string]bool
+}
+
+func (g *goWriter) printf(format string, args ...any) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// comment writes text as a comment, wrapped, with indent before each line.
+func (g *goWriter) comment(indent, text string) {
+	for _, line := range wrap(text, 76) {
+		g.printf("%s// %s\n", indent, line)
+	}
+}
+
+// typeDecl declares a component schema as a struct, or as a named type
+// for other schemas.
+func (g *goWriter) typeDecl(name string, s *Schema) {
+	doc := s.Description
+	if doc == "" {
+		doc = fmt.Sprintf("%s is the API's %s schema.", name, name)
+	}
+	g.comment("", doc)
+	if !s.Type.Is("object") || len(s.Properties) == 0 {
+		g.printf("type %s %s\n\n", name, g.typeExpr(s, true))
+		return
+	}
+
+	g.printf("type %s struct {\n", name)
+	for _, prop := range properties(s) {
+		schema := s.Properties[prop]
+		required := slices.Contains(s.Required, prop)
+		if doc := describe(schema.Description, schema); doc != "" {
+			g.comment("\t", doc)
+		}
+		tag := prop
+		if !required {
+			tag += ",omitempty"
+		}
+		g.printf("\t%s %s `json:%q`\n", exportedName(prop), g.typeExpr(schema, required), tag)
+	}
+	g.printf("}\n\n")
+}
+
+// describe documents a property or parameter: its description, allowed
+// values, and default.
+func describe(description string, s *Schema) string {
+	doc := sentence(description)
+	if len(s.Enum) > 0 {
+		values := make([]string, len(s.Enum))
+		for i, v := range s.Enum {
+			values[i] = fmt.Sprint(v)
+		}
+		doc += " One of: " + strings.Join(values, ", ") + "."
+	}
+	if s.Default != nil {
+		doc += fmt.Sprintf(" Default: %v.", s.Default)
+	}
+	return strings.TrimSpace(doc)
+}
+
+// paramDescription returns a parameter's description, which Huma puts
+// on the parameter or its schema.
+func paramDescription(p *Parameter) string {
+	if p.Description != "" {
+		return p.Description
+	}
+	return p.Schema.Description
+}
+
+// typeExpr returns the Go type of a schema. Optional objects and times
+// are pointers so that absent values are omitted.
+func (g *goWriter) typeExpr(s *Schema, required bool) string {
+	switch {
+	case s == nil:
+		return "any"
+	case s.Ref != "":
+		target := g.spec.resolve(s)
+		if !required && target != s && target.Type.Is("object") && len(target.Properties) > 0 {
+			return "*" + refName(s.Ref)
+		}
+		return refName(s.Ref)
+	case s.Type.Is("array"):
+		return "[]" + g.typeExpr(s.Items, true)
+	case s.Type.Is("object"):
+		if values, _ := mapValues(s); values != nil {
+			return "map[string]" + g.typeExpr(values, true)
+		}
+		return "map[string]any"
+	case s.Type.Is("string"):
+		switch {
+		case s.Format == "date-time":
+			g.imports["time"] = true
+			if !required {
+				return "*time.Time"
+			}
+			return "time.Time"
+		case s.ContentEncoding == "base64":
+			return "[]byte"
+		}
+		return "string"
+	case s.Type.Is("integer"):
+		return "int"
+	case s.Type.Is("number"):
+		return "float64"
+	case s.Type.Is("boolean"):
+		return "bool"
+	}
+	return "any"
+}
+
+// method declares an operation's parameters type, its method, and, for a
+// paginated listing, its iterator over every page.
+func (g *goWriter) method(m *method) {
+	g.imports["context"] = true
+	op := m.op
+	paramsType := m.name + "Params"
+
+	if len(m.params) > 0 {
+		g.printf("// %s are the query and header parameters of %s.\n", paramsType, m.name)
+		g.printf("type %s struct {\n", paramsType)
+		for _, p := range m.params {
+			doc := describe(paramDescription(p), p.Schema)
+			if p.Required {
+				doc = strings.TrimSpace("Required. " + doc)
+			}
+			if doc != "" {
+				g.comment("\t", doc)
+			}
+			g.printf("\t%s %s\n", exportedName(paramName(p)), g.typeExpr(p.Schema, true))
+		}
+		g.printf("}\n\n")
+	}
+
+	// Doc comment and signature
+	g.comment("", fmt.Sprintf("%s sends %s %s: %s", m.name, op.method, op.path, sentence(op.summary())))
+	if op.Description != "" && op.Description != op.Summary {
+		g.printf("//\n")
+		g.comment("", sentence(op.Description))
+	}
+	if op.Deprecated {
+		g.printf("//\n// Deprecated: the operation is deprecated.\n")
+	}
+
+	args := []string{"ctx context.Context"}
+	for _, p := range m.pathParams {
+		args = append(args, unexportedName(p.Name)+" "+g.typeExpr(p.Schema, true))
+	}
+	if m.body != nil {
+		args = append(args, "body "+g.typeExpr(m.body, true))
+	}
+	if len(m.params) > 0 {
+		args = append(args, "params *"+paramsType)
+	}
+
+	var result, zero string
+	switch m.result {
+	case resultJSON:
+		result = g.typeExpr(m.schema, true)
+		if m.schema.Ref != "" {
+			result = "*" + result
+		}
+		zero = "nil"
+	case resultStream:
+		result = "*Stream[" + g.typeExpr(m.schema, true) + "]"
+		zero = "nil"
+	case resultRaw:
+		g.imports["io"] = true
+		result = "io.ReadCloser"
+		zero = "nil"
+	}
+	if result != "" {
+		g.printf("func (c *Client) %s(%s) (%s, error) {\n", m.name, strings.Join(args, ", "), result)
+	} else {
+		g.printf("func (c *Client) %s(%s) error {\n", m.name, strings.Join(args, ", "))
+	}
+
+	// Request
+	g.printf("\tpath := %s\n", g.pathExpr(m))
+	query, header := "nil", "nil"
+	if len(m.params) > 0 || len(m.fixed) > 0 {
+		if slices.ContainsFunc(m.params, func(p *Parameter) bool { return p.In == "query" }) || len(m.fixed) > 0 {
+			g.imports["net/url"] = true
+			g.printf("\tquery := url.Values{}\n")
+			query = "query"
+		}
+		if slices.ContainsFunc(m.params, func(p *Parameter) bool { return p.In == "header" }) {
+			g.imports["net/http"] = true
+			g.printf("\theader := http.Header{}\n")
+			header = "header"
+		}
+		for _, name := range sortedKeys(m.fixed) {
+			g.printf("\tquery.Set(%q, %q)\n", name, m.fixed[name])
+		}
+		if len(m.params) > 0 {
+			g.printf("\tif params != nil {\n")
+			for _, p := range m.params {
+				g.printf("\t\tsetParam(%s.Set, %q, params.%s)\n", p.In, p.Name, exportedName(paramName(p)))
+			}
+			g.printf("\t}\n")
+		}
+	}
+	body := "nil"
+	if m.body != nil {
+		body = "body"
+	}
+
+	switch m.result {
+	case resultJSON:
+		out := "out"
+		if m.schema.Ref != "" {
+			out = "&out"
+		}
+		g.printf("\tvar out %s\n", g.typeExpr(m.schema, true))
+		g.printf("\tif err := c.do(ctx, %q, path, %s, %s, %s, &out); err != nil {\n", op.method, query, header, body)
+		g.printf("\t\treturn %s, err\n\t}\n", zero)
+		g.printf("\treturn %s, nil\n}\n\n", out)
+	case resultStream:
+		g.printf("\tresp, err := c.open(ctx, %q, path, %s, %s, %q)\n", op.method, query, header, streamFormat)
+		g.printf("\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+		g.printf("\treturn newStream[%s](resp), nil\n}\n\n", g.typeExpr(m.schema, true))
+	case resultRaw:
+		g.printf("\treturn c.open(ctx, %q, path, %s, %s, %q)\n}\n\n", op.method, query, header, m.accept)
+	default:
+		g.printf("\treturn c.do(ctx, %q, path, %s, %s, %s, nil)\n}\n\n", op.method, query, header, body)
+	}
+
+	if m.pages != nil {
+		g.pages(m, args, paramsType)
+	}
+}
+
+// pathExpr returns a Go expression building an operation's path.
+func (g *goWriter) pathExpr(m *method) string {
+	segments := pathSegments(m.op.path)
+	parts := []string{fmt.Sprintf("%q", segments[0])}
+	for i := 1; i < len(segments); i += 2 {
+		parts = append(parts, "pathParam("+unexportedName(segments[i])+")")
+		if segments[i+1] != "" {
+			parts = append(parts, fmt.Sprintf("%q", segments[i+1]))
+		}
+	}
+	return strings.Join(parts, " + ")
+}
+
+// pages declares the iterator over every page of a paginated listing.
+func (g *goWriter) pages(m *method, args []string, paramsType string) {
+	g.imports["iter"] = true
+	item := g.typeExpr(m.pages.item, true)
+	items := exportedName(m.pages.items)
+
+	g.comment("", fmt.Sprintf("%sAll iterates over the %s of every page of %s, "+
+		"starting at params.Offset and fetching params.Limit at a time. "+
+		"Iteration stops at the first error, which is yielded.", m.name, m.pages.items, m.name))
+	g.printf("func (c *Client) %sAll(%s) iter.Seq2[%s, error] {\n", m.name, strings.Join(args, ", "), item)
+	g.printf("\tvar page %s\n\tif params != nil {\n\t\tpage = *params\n\t}\n", paramsType)
+	g.printf("\treturn paginate(page.Offset, func(offset int) ([]%s, int, error) {\n", item)
+	g.printf("\t\tpage.Offset = offset\n")
+
+	call := []string{"ctx"}
+	for _, p := range m.pathParams {
+		call = append(call, unexportedName(p.Name))
+	}
+	call = append(call, "&page")
+	g.printf("\t\tresult, err := c.%s(%s)\n", m.name, strings.Join(call, ", "))
+	g.printf("\t\tif err != nil {\n\t\t\treturn nil, 0, err\n\t\t}\n")
+	g.printf("\t\treturn result.%s, result.Total, nil\n\t})\n}\n\n", items)
+}
+
+// sortedKeys returns a map's keys in order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
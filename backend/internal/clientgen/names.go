@@ -0,0 +1,136 @@
+package clientgen
+
+import (
+	"strings"
+	"unicode"
+)
+
+// initialisms are words written in capitals in Go names.
+var initialisms = map[string]bool{
+	"api": true, "csv": true, "html": true, "http": true, "id": true, "json": true,
+	"rin": true, "sse": true, "uri": true, "url": true, "xml": true,
+}
+
+// words splits a name at punctuation and lower-to-upper case changes,
+// e.g., "billId" and "bill-id" into "bill" and "id".
+func words(name string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(name)
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			if len(word) > 0 {
+				words = append(words, strings.ToLower(string(word)))
+			}
+			word = word[:0]
+			continue
+		case unicode.IsUpper(r) && i > 0 && len(word) > 0 &&
+			(unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))):
+			words = append(words, strings.ToLower(string(word)))
+			word = word[:0]
+		}
+		word = append(word, r)
+	}
+	if len(word) > 0 {
+		words = append(words, strings.ToLower(string(word)))
+	}
+	return words
+}
+
+// exportedName returns the Go name of an OpenAPI name, e.g., "BillID" for
+// "billId" and "GetBill" for "get-bill".
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, w := range words(name) {
+		if initialisms[w] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	return b.String()
+}
+
+// unexportedName returns the Go name of a local variable or parameter,
+// e.g., "billID" for "billId".
+func unexportedName(name string) string {
+	ws := words(name)
+	if len(ws) == 0 {
+		return "_"
+	}
+	rest := exportedName(strings.Join(ws[1:], "-"))
+	if goKeywords[ws[0]] && rest == "" {
+		return ws[0] + "_"
+	}
+	return ws[0] + rest
+}
+
+// camelName returns the TypeScript name of an OpenAPI name, e.g.,
+// "getBill" for "get-bill".
+func camelName(name string) string {
+	ws := words(name)
+	for i := 1; i < len(ws); i++ {
+		ws[i] = strings.ToUpper(ws[i][:1]) + ws[i][1:]
+	}
+	return strings.Join(ws, "")
+}
+
+// paramName returns the name of a parameter in a generated params type,
+// dropping the X- of custom headers, e.g., "APIKey" for "X-API-Key".
+func paramName(p *Parameter) string {
+	if p.In == "header" {
+		if rest, ok := strings.CutPrefix(p.Name, "X-"); ok {
+			return rest
+		}
+	}
+	return p.Name
+}
+
+// goKeywords are the Go keywords a parameter name could collide with.
+var goKeywords = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true, "default": true,
+	"defer": true, "else": true, "fallthrough": true, "for": true, "func": true, "go": true,
+	"goto": true, "if": true, "import": true, "interface": true, "map": true, "package": true,
+	"range": true, "return": true, "select": true, "struct": true, "switch": true, "type": true, "var": true,
+}
+
+// isIdentifier reports whether name is usable unquoted as a TypeScript
+// property name.
+func isIdentifier(name string) bool {
+	for i, r := range name {
+		if !(r == '_' || r == '$' || unicode.IsLetter(r) || (i > 0 && unicode.IsDigit(r))) {
+			return false
+		}
+	}
+	return name != ""
+}
+
+// wrap breaks text into lines of at most width characters, at spaces.
+func wrap(text string, width int) []string {
+	var lines []string
+	var line strings.Builder
+	for _, word := range strings.Fields(text) {
+		if line.Len() > 0 && line.Len()+1+len(word) > width {
+			lines = append(lines, line.String())
+			line.Reset()
+		}
+		if line.Len() > 0 {
+			line.WriteByte(' ')
+		}
+		line.WriteString(word)
+	}
+	if line.Len() > 0 {
+		lines = append(lines, line.String())
+	}
+	return lines
+}
+
+// sentence returns text ending with a period.
+func sentence(text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.HasSuffix(text, ".") {
+		return text
+	}
+	return text + "."
+}
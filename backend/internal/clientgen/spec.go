@@ -0,0 +1,347 @@
+// Package clientgen generates typed Go and TypeScript clients for the
+// DeltaGov API from its OpenAPI document: a type per component schema and
+// a method per operation, with iterators over every page of paginated
+// listings and typed readers for NDJSON streams such as the streamed diff.
+package clientgen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Spec is the subset of an OpenAPI 3.1 document the generators use.
+type Spec struct {
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components struct {
+		Schemas map[string]*Schema `json:"schemas"`
+	} `json:"components"`
+}
+
+// Operation is an OpenAPI operation.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary"`
+	Description string               `json:"description"`
+	Deprecated  bool                 `json:"deprecated"`
+	Parameters  []*Parameter         `json:"parameters"`
+	RequestBody *RequestBody         `json:"requestBody"`
+	Responses   map[string]*Response `json:"responses"`
+
+	method, path string
+}
+
+// Parameter is an OpenAPI path, query, or header parameter.
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is an OpenAPI request body.
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response is an OpenAPI response.
+type Response struct {
+	Content map[string]*MediaType `json:"content"`
+}
+
+// MediaType is the schema of a request or response content type.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a JSON Schema, as Huma generates them.
+type Schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 Types              `json:"type"`
+	Format               string             `json:"format"`
+	ContentEncoding      string             `json:"contentEncoding"`
+	Description          string             `json:"description"`
+	Enum                 []any              `json:"enum"`
+	Default              any                `json:"default"`
+	Items                *Schema            `json:"items"`
+	Properties           map[string]*Schema `json:"properties"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	Required             []string           `json:"required"`
+	ReadOnly             bool               `json:"readOnly"`
+}
+
+// Types is a schema's type: one name, or several such as ["array", "null"].
+type Types []string
+
+// UnmarshalJSON accepts a single type name or a list of them.
+func (t *Types) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*t = Types{name}
+		return nil
+	}
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return fmt.Errorf("clientgen: invalid schema type %s", data)
+	}
+	*t = names
+	return nil
+}
+
+// Is reports whether the schema has type name.
+func (t Types) Is(name string) bool {
+	return slices.Contains(t, name)
+}
+
+// Parse reads an OpenAPI document in JSON.
+func Parse(data []byte) (*Spec, error) {
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("clientgen: invalid OpenAPI document: %w", err)
+	}
+	if len(spec.Paths) == 0 {
+		return nil, errors.New("clientgen: OpenAPI document has no paths")
+	}
+	return &spec, nil
+}
+
+// refName returns the component schema a $ref points to.
+func refName(ref string) string {
+	return strings.TrimPrefix(ref, "#/components/schemas/")
+}
+
+// resolve returns the component schema s refers to, or s itself.
+func (spec *Spec) resolve(s *Schema) *Schema {
+	if s != nil && s.Ref != "" {
+		if target, ok := spec.Components.Schemas[refName(s.Ref)]; ok {
+			return target
+		}
+	}
+	return s
+}
+
+// mapValues returns the schema of a map's values and whether s is a map:
+// an object without properties. A nil schema means any value.
+func mapValues(s *Schema) (*Schema, bool) {
+	if !s.Type.Is("object") || len(s.Properties) > 0 {
+		return nil, false
+	}
+	var values Schema
+	if len(s.AdditionalProperties) == 0 || json.Unmarshal(s.AdditionalProperties, &values) != nil {
+		return nil, true // true, or absent
+	}
+	if values.Ref == "" && len(values.Type) == 0 {
+		return nil, true // {}
+	}
+	return &values, true
+}
+
+// properties returns an object schema's properties in name order, without
+// the read-only $schema link Huma adds to response bodies.
+func properties(s *Schema) []string {
+	names := make([]string, 0, len(s.Properties))
+	for name, prop := range s.Properties {
+		if name == "$schema" && prop.ReadOnly {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Result kinds: how an operation's successful response is read.
+const (
+	resultNone   = iota // No body, e.g., 204
+	resultJSON          // A JSON document of Result's type
+	resultStream        // NDJSON records of Result's type
+	resultRaw           // An opaque body, such as a CSV export or Atom feed
+)
+
+// method is an operation as the generators emit it.
+type method struct {
+	op         *Operation
+	name       string       // Go name, e.g., "GetBill"
+	pathParams []*Parameter // In path order
+	params     []*Parameter // Query and header parameters
+	fixed      map[string]string
+	body       *Schema
+	result     int
+	schema     *Schema // Result schema for resultJSON and resultStream
+	accept     string  // Content type of a resultRaw body, if it has one
+	pages      *pagination
+}
+
+// pagination describes an offset-paginated listing: one whose limit and
+// offset parameters page through Items, out of Total.
+type pagination struct {
+	items string  // JSON name of the page's array property
+	item  *Schema // Schema of one item
+}
+
+// streamFormat is the content type of typed streams. An enum query
+// parameter offering it (such as format=ndjson|sse) is fixed to select it.
+const streamFormat = "application/x-ndjson"
+
+// methods returns the spec's operations, sorted by operation ID.
+func (spec *Spec) methods() ([]*method, error) {
+	var methods []*method
+	for path, item := range spec.Paths {
+		for verb, op := range item {
+			op.method, op.path = strings.ToUpper(verb), path
+			m, err := spec.method(op)
+			if err != nil {
+				return nil, err
+			}
+			methods = append(methods, m)
+		}
+	}
+	sort.Slice(methods, func(i, j int) bool { return methods[i].op.OperationID < methods[j].op.OperationID })
+	for i := 1; i < len(methods); i++ {
+		if methods[i].name == methods[i-1].name {
+			return nil, fmt.Errorf("clientgen: operations %s and %s have the same name",
+				methods[i-1].op.OperationID, methods[i].op.OperationID)
+		}
+	}
+	return methods, nil
+}
+
+// method classifies an operation's parameters, body, and result.
+func (spec *Spec) method(op *Operation) (*method, error) {
+	if op.OperationID == "" {
+		return nil, fmt.Errorf("clientgen: %s %s has no operation ID", op.method, op.path)
+	}
+	m := &method{op: op, name: exportedName(op.OperationID), fixed: map[string]string{}}
+
+	for _, name := range pathParamNames(op.path) {
+		i := slices.IndexFunc(op.Parameters, func(p *Parameter) bool { return p.In == "path" && p.Name == name })
+		if i < 0 {
+			return nil, fmt.Errorf("clientgen: %s: path parameter %s is undocumented", op.OperationID, name)
+		}
+		m.pathParams = append(m.pathParams, op.Parameters[i])
+	}
+	for _, p := range op.Parameters {
+		if p.In == "query" || p.In == "header" {
+			m.params = append(m.params, p)
+		}
+	}
+
+	if op.RequestBody != nil {
+		if media := op.RequestBody.Content["application/json"]; media != nil {
+			m.body = media.Schema
+		}
+	}
+
+	status := successStatus(op)
+	if status == "" {
+		return m, nil
+	}
+	content := op.Responses[status].Content
+	switch {
+	case content["application/json"] != nil && content["application/json"].Schema != nil:
+		m.result, m.schema = resultJSON, content["application/json"].Schema
+		m.pages = spec.paginate(m)
+	case content[streamFormat] != nil && content[streamFormat].Schema != nil:
+		m.result, m.schema = resultStream, content[streamFormat].Schema
+		m.params = slices.DeleteFunc(m.params, func(p *Parameter) bool {
+			if p.In == "query" && slices.Contains(p.Schema.Enum, any("ndjson")) {
+				m.fixed[p.Name] = "ndjson"
+				return true
+			}
+			return false
+		})
+	case len(content) > 0:
+		m.result = resultRaw
+		if len(content) == 1 {
+			for contentType := range content {
+				m.accept = contentType
+			}
+		}
+	}
+	return m, nil
+}
+
+// successStatus returns the lowest 2xx status an operation documents.
+func successStatus(op *Operation) string {
+	var statuses []string
+	for status := range op.Responses {
+		if strings.HasPrefix(status, "2") {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Strings(statuses)
+	if len(statuses) == 0 {
+		return ""
+	}
+	return statuses[0]
+}
+
+// paginate returns how a JSON method pages, or nil if it doesn't: it must
+// take limit and offset and return an integer total with one array.
+func (spec *Spec) paginate(m *method) *pagination {
+	hasParam := func(name string) bool {
+		return slices.ContainsFunc(m.params, func(p *Parameter) bool { return p.In == "query" && p.Name == name })
+	}
+	if !hasParam("limit") || !hasParam("offset") {
+		return nil
+	}
+	result := spec.resolve(m.schema)
+	if total := result.Properties["total"]; total == nil || !total.Type.Is("integer") {
+		return nil
+	}
+	var pages *pagination
+	for _, name := range properties(result) {
+		if prop := result.Properties[name]; prop.Type.Is("array") {
+			if pages != nil {
+				return nil
+			}
+			pages = &pagination{items: name, item: prop.Items}
+		}
+	}
+	return pages
+}
+
+// pathParamNames returns the {names} in a path template, in order.
+func pathParamNames(path string) []string {
+	var names []string
+	for {
+		start := strings.IndexByte(path, '{')
+		if start < 0 {
+			return names
+		}
+		end := strings.IndexByte(path[start:], '}')
+		if end < 0 {
+			return names
+		}
+		names = append(names, path[start+1:start+end])
+		path = path[start+end+1:]
+	}
+}
+
+// pathSegments splits a path template into literal text and parameter
+// names, alternating, starting with literal text.
+func pathSegments(path string) []string {
+	var segments []string
+	for {
+		start := strings.IndexByte(path, '{')
+		if start < 0 {
+			return append(segments, path)
+		}
+		end := strings.IndexByte(path[start:], '}')
+		segments = append(segments, path[:start], path[start+1:start+end])
+		path = path[start+end+1:]
+	}
+}
+
+// summary returns the first line of an operation's documentation.
+func (op *Operation) summary() string {
+	if op.Summary != "" {
+		return op.Summary
+	}
+	return fmt.Sprintf("%s %s", op.method, op.path)
+}
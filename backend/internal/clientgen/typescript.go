@@ -0,0 +1,525 @@
+package clientgen
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// tsWidth is the frontend's Prettier print width.
+const tsWidth = 100
+
+// TypeScript returns the source of a dependency-free TypeScript client for
+// spec: an interface per component schema and a DeltaGovClient class with
+// a method per operation, using fetch.
+func TypeScript(spec *Spec) ([]byte, error) {
+	methods, err := spec.methods()
+	if err != nil {
+		return nil, err
+	}
+	t := &tsWriter{spec: spec}
+	t.printf("// %s\n\n", Header)
+	for _, name := range sortedKeys(spec.Components.Schemas) {
+		t.typeDecl(name, spec.Components.Schemas[name])
+	}
+	for _, m := range methods {
+		t.paramsDecl(m)
+	}
+	t.printf("%s", tsRuntime)
+	for _, m := range methods {
+		t.method(m)
+	}
+	t.printf("%s", tsHelpers)
+	return bytes.ReplaceAll(t.buf.Bytes(), []byte("\n\n\n"), []byte("\n\n")), nil
+}
+
+// tsWriter accumulates TypeScript declarations.
+type tsWriter struct {
+	spec *Spec
+	buf  bytes.Buffer
+}
+
+func (t *tsWriter) printf(format string, args ...any) {
+	fmt.Fprintf(&t.buf, format, args...)
+}
+
+// comment writes text as a JSDoc comment with indent before each line.
+func (t *tsWriter) comment(indent, text string) {
+	if text == "" {
+		return
+	}
+	if len(indent)+len(text)+7 <= tsWidth && !strings.Contains(text, "\n") {
+		t.printf("%s/** %s */\n", indent, text)
+		return
+	}
+	t.printf("%s/**\n", indent)
+	for _, paragraph := range strings.Split(text, "\n") {
+		lines := wrap(paragraph, tsWidth-len(indent)-3)
+		if len(lines) == 0 {
+			t.printf("%s *\n", indent)
+		}
+		for _, line := range lines {
+			t.printf("%s * %s\n", indent, line)
+		}
+	}
+	t.printf("%s */\n", indent)
+}
+
+// typeDecl declares a component schema as an interface, or as a type
+// alias for other schemas.
+func (t *tsWriter) typeDecl(name string, s *Schema) {
+	t.comment("", s.Description)
+	if !s.Type.Is("object") || len(s.Properties) == 0 {
+		t.printf("export type %s = %s;\n\n", name, t.typeExpr(s))
+		return
+	}
+	t.printf("export interface %s {\n", name)
+	for _, prop := range properties(s) {
+		schema := s.Properties[prop]
+		t.comment("  ", describe(schema.Description, schema))
+		optional := ""
+		if !slices.Contains(s.Required, prop) {
+			optional = "?"
+		}
+		t.printf("  %s%s: %s;\n", tsProperty(prop), optional, t.typeExpr(schema))
+	}
+	t.printf("}\n\n")
+}
+
+// tsProperty returns a property name, quoted if it isn't an identifier.
+func tsProperty(name string) string {
+	if isIdentifier(name) {
+		return name
+	}
+	return fmt.Sprintf("'%s'", name)
+}
+
+// typeExpr returns the TypeScript type of a schema.
+func (t *tsWriter) typeExpr(s *Schema) string {
+	var expr string
+	switch {
+	case s == nil:
+		return "unknown"
+	case s.Ref != "":
+		expr = refName(s.Ref)
+	case len(s.Enum) > 0:
+		values := make([]string, len(s.Enum))
+		for i, v := range s.Enum {
+			if str, ok := v.(string); ok {
+				values[i] = fmt.Sprintf("'%s'", str)
+			} else {
+				values[i] = fmt.Sprint(v)
+			}
+		}
+		expr = strings.Join(values, " | ")
+	case s.Type.Is("array"):
+		item := t.typeExpr(s.Items)
+		if strings.Contains(item, " ") {
+			item = "(" + item + ")"
+		}
+		expr = item + "[]"
+	case s.Type.Is("object"):
+		values, _ := mapValues(s)
+		expr = "Record<string, " + t.typeExpr(values) + ">"
+	case s.Type.Is("string"):
+		expr = "string"
+	case s.Type.Is("integer"), s.Type.Is("number"):
+		expr = "number"
+	case s.Type.Is("boolean"):
+		expr = "boolean"
+	default:
+		return "unknown"
+	}
+	if s.Type.Is("null") {
+		expr += " | null"
+	}
+	return expr
+}
+
+// paramsDecl declares an operation's query and header parameters.
+func (t *tsWriter) paramsDecl(m *method) {
+	if len(m.params) == 0 {
+		return
+	}
+	t.printf("/** Query and header parameters of %s. */\n", camelName(m.op.OperationID))
+	t.printf("export interface %sParams {\n", m.name)
+	for _, p := range m.params {
+		doc := describe(paramDescription(p), p.Schema)
+		if p.Required {
+			doc = strings.TrimSpace("Required. " + doc)
+		}
+		t.comment("  ", doc)
+		t.printf("  %s?: %s;\n", camelName(paramName(p)), t.typeExpr(p.Schema))
+	}
+	t.printf("}\n\n")
+}
+
+// method declares an operation's method and, for a paginated listing, its
+// generator over every page.
+func (t *tsWriter) method(m *method) {
+	op := m.op
+	name := camelName(op.OperationID)
+
+	doc := fmt.Sprintf("%s %s: %s", op.method, op.path, sentence(op.summary()))
+	if op.Description != "" && op.Description != op.Summary {
+		doc += "\n\n" + sentence(op.Description)
+	}
+	if op.Deprecated {
+		doc += "\n\n@deprecated"
+	}
+	t.comment("  ", doc)
+
+	var args []string
+	for _, p := range m.pathParams {
+		args = append(args, camelName(p.Name)+": "+t.typeExpr(p.Schema))
+	}
+	if m.body != nil {
+		args = append(args, "body: "+t.typeExpr(m.body))
+	}
+	if len(m.params) > 0 {
+		args = append(args, "params: "+m.name+"Params = {}")
+	}
+	args = append(args, "options: RequestOptions = {}")
+
+	var result string
+	switch m.result {
+	case resultJSON:
+		result = "Promise<" + t.typeExpr(m.schema) + ">"
+	case resultStream:
+		result = "AsyncGenerator<" + t.typeExpr(m.schema) + ">"
+	case resultRaw:
+		result = "Promise<Response>"
+	default:
+		result = "Promise<void>"
+	}
+	prefix := "  async " + name
+	if m.result == resultStream {
+		prefix = "  async *" + name
+	}
+	t.signature(prefix, args, ": "+result+" {")
+
+	var query, header tsObject
+	for _, p := range m.params {
+		field := tsEntry{tsProperty(p.Name), "params." + camelName(paramName(p))}
+		if p.In == "query" {
+			query = append(query, field)
+		} else {
+			header = append(header, field)
+		}
+	}
+	for _, key := range sortedKeys(m.fixed) {
+		query = append(query, tsEntry{tsProperty(key), fmt.Sprintf("'%s'", m.fixed[key])})
+	}
+	var request tsObject
+	if len(query) > 0 {
+		request = append(request, tsEntry{"query", query})
+	}
+	if len(header) > 0 {
+		request = append(request, tsEntry{"headers", header})
+	}
+	if m.body != nil {
+		request = append(request, tsEntry{value: "body"})
+	}
+	switch m.result {
+	case resultStream:
+		request = append(request, tsEntry{"accept", fmt.Sprintf("'%s'", streamFormat)})
+	case resultRaw:
+		accept := m.accept
+		if accept == "" {
+			accept = "*/*"
+		}
+		request = append(request, tsEntry{"accept", fmt.Sprintf("'%s'", accept)})
+	}
+	call := []any{fmt.Sprintf("'%s'", op.method), t.pathExpr(m), "options"}
+	if len(request) > 0 {
+		call[2] = append(request, tsEntry{value: "...options"})
+	}
+
+	switch m.result {
+	case resultJSON:
+		t.call("    return this.request(", call, ");")
+	case resultStream:
+		t.call("    const response = await this.send(", call, ");")
+		t.printf("    yield* readNdjson<%s>(response);\n", t.typeExpr(m.schema))
+	case resultRaw:
+		t.call("    return this.send(", call, ");")
+	default:
+		t.call("    await this.send(", call, ");")
+	}
+	t.printf("  }\n\n")
+
+	if m.pages != nil {
+		t.pages(m, name, args)
+	}
+}
+
+// pathExpr returns a template literal building an operation's path.
+func (t *tsWriter) pathExpr(m *method) string {
+	segments := pathSegments(m.op.path)
+	if len(segments) == 1 {
+		return fmt.Sprintf("'%s'", segments[0])
+	}
+	var b strings.Builder
+	b.WriteString("`" + segments[0])
+	for i := 1; i < len(segments); i += 2 {
+		fmt.Fprintf(&b, "${path(%s)}%s", camelName(segments[i]), segments[i+1])
+	}
+	b.WriteString("`")
+	return b.String()
+}
+
+// signature writes a method signature on one line, or with one argument
+// per line if it is too long.
+func (t *tsWriter) signature(prefix string, args []string, suffix string) {
+	line := prefix + "(" + strings.Join(args, ", ") + ")" + suffix
+	if len(line) <= tsWidth {
+		t.printf("%s\n", line)
+		return
+	}
+	t.printf("%s(\n", prefix)
+	for _, arg := range args {
+		t.printf("    %s,\n", arg)
+	}
+	t.printf("  )%s\n", suffix)
+}
+
+// call writes a call on one line, or with one argument per line if it is
+// too long. Arguments are expressions or object literals.
+func (t *tsWriter) call(prefix string, args []any, suffix string) {
+	inline := make([]string, len(args))
+	for i, arg := range args {
+		inline[i] = tsInline(arg)
+	}
+	if line := prefix + strings.Join(inline, ", ") + suffix; len(line) <= tsWidth {
+		t.printf("%s\n", line)
+		return
+	}
+	indent := prefix[:len(prefix)-len(strings.TrimLeft(prefix, " "))]
+	t.printf("%s\n", prefix)
+	for _, arg := range args {
+		t.lines(indent+"  ", "", arg, ",")
+	}
+	t.printf("%s%s\n", indent, suffix)
+}
+
+// tsObject is an object literal, written on one line if it fits.
+type tsObject []tsEntry
+
+// tsEntry is a property of an object literal. Without a key, the value is
+// written alone, as for a spread or shorthand property.
+type tsEntry struct {
+	key   string
+	value any // string or tsObject
+}
+
+// tsInline returns an expression or object literal on one line.
+func tsInline(value any) string {
+	obj, ok := value.(tsObject)
+	if !ok {
+		return value.(string)
+	}
+	entries := make([]string, len(obj))
+	for i, entry := range obj {
+		entries[i] = tsInline(entry.value)
+		if entry.key != "" {
+			entries[i] = entry.key + ": " + entries[i]
+		}
+	}
+	return "{ " + strings.Join(entries, ", ") + " }"
+}
+
+// lines writes an expression or object literal after indent and prefix,
+// breaking object literals that don't fit one per property.
+func (t *tsWriter) lines(indent, prefix string, value any, suffix string) {
+	line := indent + prefix + tsInline(value) + suffix
+	obj, ok := value.(tsObject)
+	if !ok || len(line) <= tsWidth {
+		t.printf("%s\n", line)
+		return
+	}
+	t.printf("%s%s{\n", indent, prefix)
+	for _, entry := range obj {
+		key := ""
+		if entry.key != "" {
+			key = entry.key + ": "
+		}
+		t.lines(indent+"  ", key, entry.value, ",")
+	}
+	t.printf("%s}%s\n", indent, suffix)
+}
+
+// pages declares the generator over every page of a paginated listing.
+func (t *tsWriter) pages(m *method, name string, args []string) {
+	item := t.typeExpr(m.pages.item)
+	t.comment("  ", fmt.Sprintf("Yields the %s of every page of %s, starting at params.offset and "+
+		"fetching params.limit at a time.", m.pages.items, name))
+	t.signature("  async *"+name+"All", args, ": AsyncGenerator<"+item+"> {")
+
+	call := []string{}
+	for _, p := range m.pathParams {
+		call = append(call, camelName(p.Name))
+	}
+	call = append(call, "{ ...params, offset }", "options")
+	t.printf("    let offset = params.offset ?? 0;\n")
+	t.printf("    for (;;) {\n")
+	t.printf("      const page = await this.%s(%s);\n", name, strings.Join(call, ", "))
+	t.printf("      const items = page.%s ?? [];\n", m.pages.items)
+	t.printf("      yield* items;\n")
+	t.printf("      offset += items.length;\n")
+	t.printf("      if (items.length === 0 || offset >= page.total) {\n")
+	t.printf("        return;\n")
+	t.printf("      }\n")
+	t.printf("    }\n")
+	t.printf("  }\n\n")
+}
+
+// tsRuntime opens the client class: its options, errors, and transport.
+const tsRuntime = `/** Options of a DeltaGovClient. */
+export interface ClientOptions {
+  /** Origin of the API, e.g., 'https://api.deltagov.org'. */
+  baseUrl: string;
+  /** Sends requests; defaults to the global fetch. */
+  fetch?: typeof fetch;
+  /** Headers sent with every request. */
+  headers?: Record<string, string>;
+  /** Authenticates watchlist requests. */
+  apiKey?: string;
+  /** Authenticates admin requests with the server's ADMIN_TOKEN. */
+  adminToken?: string;
+  /** Authenticates a signed-in user with a session token from the login callback. */
+  sessionToken?: string;
+}
+
+/** Options of a single request. */
+export interface RequestOptions {
+  /** Aborts the request, and a stream being read. */
+  signal?: AbortSignal;
+}
+
+/** An error response from the API. */
+export class ApiError extends Error {
+  constructor(
+    readonly status: number,
+    readonly body: ErrorModel | null,
+  ) {
+    const code = body?.code ? ' ' + body.code : '';
+    super(` + "`deltagov: ${status}${code}: ${body?.detail || body?.title || 'request failed'}`" + `);
+    this.name = 'ApiError';
+  }
+
+  /** The API's error code, e.g., 'BILL_NOT_FOUND'. */
+  get code(): string | undefined {
+    return this.body?.code;
+  }
+}
+
+type QueryValue = string | number | boolean | null | undefined;
+
+interface ApiRequest extends RequestOptions {
+  query?: Record<string, QueryValue>;
+  headers?: Record<string, QueryValue>;
+  body?: unknown;
+  accept?: string;
+}
+
+/** A typed client of the DeltaGov API. */
+export class DeltaGovClient {
+  private readonly baseUrl: string;
+  private readonly fetch: typeof fetch;
+  private readonly headers: Record<string, string>;
+
+  constructor(options: ClientOptions) {
+    this.baseUrl = options.baseUrl.replace(/\/+$/, '');
+    this.fetch = options.fetch ?? globalThis.fetch.bind(globalThis);
+    this.headers = { ...options.headers };
+    if (options.apiKey) {
+      this.headers['X-API-Key'] = options.apiKey;
+    }
+    if (options.adminToken) {
+      this.headers['Authorization'] = ` + "`Bearer ${options.adminToken}`" + `;
+    }
+    if (options.sessionToken) {
+      this.headers['Authorization'] = ` + "`Bearer ${options.sessionToken}`" + `;
+    }
+  }
+
+  private async send(method: string, path: string, request: ApiRequest): Promise<Response> {
+    const url = new URL(this.baseUrl + path);
+    for (const [key, value] of Object.entries(request.query ?? {})) {
+      if (value !== undefined && value !== null && value !== '') {
+        url.searchParams.set(key, String(value));
+      }
+    }
+    const headers: Record<string, string> = {
+      ...this.headers,
+      Accept: request.accept ?? 'application/json',
+    };
+    for (const [key, value] of Object.entries(request.headers ?? {})) {
+      if (value !== undefined && value !== null && value !== '') {
+        headers[key] = String(value);
+      }
+    }
+    let body: string | undefined;
+    if (request.body !== undefined) {
+      headers['Content-Type'] = 'application/json';
+      body = JSON.stringify(request.body);
+    }
+
+    const response = await this.fetch(url, { method, headers, body, signal: request.signal });
+    if (!response.ok) {
+      const error = (await response.json().catch(() => null)) as ErrorModel | null;
+      throw new ApiError(response.status, error);
+    }
+    return response;
+  }
+
+  private async request<T>(method: string, path: string, request: ApiRequest): Promise<T> {
+    const response = await this.send(method, path, request);
+    return (await response.json()) as T;
+  }
+
+`
+
+// tsHelpers closes the client class and defines the helpers its methods
+// use.
+const tsHelpers = `}
+
+/** Formats a path parameter. */
+function path(value: string | number): string {
+  return encodeURIComponent(String(value));
+}
+
+/** Yields the records of an NDJSON response as they arrive. */
+async function* readNdjson<T>(response: Response): AsyncGenerator<T> {
+  if (!response.body) {
+    return;
+  }
+  const reader = response.body.pipeThrough(new TextDecoderStream()).getReader();
+  let buffer = '';
+  try {
+    for (;;) {
+      const { done, value } = await reader.read();
+      if (value) {
+        buffer += value;
+      }
+      let newline: number;
+      while ((newline = buffer.indexOf('\n')) >= 0) {
+        const line = buffer.slice(0, newline).trim();
+        buffer = buffer.slice(newline + 1);
+        if (line) {
+          yield JSON.parse(line) as T;
+        }
+      }
+      if (done) {
+        break;
+      }
+    }
+    if (buffer.trim()) {
+      yield JSON.parse(buffer) as T;
+    }
+  } finally {
+    reader.releaseLock();
+  }
+}
+`
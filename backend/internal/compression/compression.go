@@ -0,0 +1,44 @@
+// Package compression provides response compression middleware. Unlike
+// fiber's compress middleware, small responses are sent as-is, since
+// compressing them costs more CPU than it saves in bandwidth.
+package compression
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// DefaultMinSize is the smallest response body compressed by default.
+const DefaultMinSize = 1024
+
+// Middleware compresses response bodies of at least minSize bytes with
+// brotli, gzip, or deflate, whichever the client prefers per its
+// Accept-Encoding header, adding Vary: Accept-Encoding so caches keep the
+// encodings apart. ETags set by handlers must be weak, since they're sent
+// unchanged whatever the encoding. Streamed bodies are left alone so they
+// keep flushing incrementally. A negative minSize disables compression.
+func Middleware(minSize int) fiber.Handler {
+	if minSize < 0 {
+		return func(c *fiber.Ctx) error {
+			return c.Next()
+		}
+	}
+
+	compressor := fasthttp.CompressHandlerBrotliLevel(func(*fasthttp.RequestCtx) {},
+		fasthttp.CompressBrotliDefaultCompression,
+		fasthttp.CompressDefaultCompression,
+	)
+
+	return func(c *fiber.Ctx) error {
+		if err := c.Next(); err != nil {
+			return err
+		}
+
+		resp := c.Response()
+		if resp.IsBodyStream() || len(resp.Body()) < minSize {
+			return nil
+		}
+		compressor(c.Context())
+		return nil
+	}
+}
@@ -0,0 +1,101 @@
+package compression_test
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/compression"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TestMiddleware verifies bodies are compressed per the request's
+// Accept-Encoding, that small bodies are sent as-is, and that compressed
+// responses vary on Accept-Encoding so caches keep encodings apart.
+func TestMiddleware(t *testing.T) {
+	large := strings.Repeat("The fee is $500.\n", 100)
+	small := "The fee is $500.\n"
+
+	app := fiber.New()
+	app.Use(compression.Middleware(compression.DefaultMinSize))
+	app.Get("/large", func(c *fiber.Ctx) error { return c.SendString(large) })
+	app.Get("/small", func(c *fiber.Ctx) error { return c.SendString(small) })
+
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+		wantEncoding   string
+	}{
+		{"gzip", "/large", "gzip", "gzip"},
+		{"brotli preferred", "/large", "gzip, br", "br"},
+		{"identity", "/large", "identity", ""},
+		{"no Accept-Encoding", "/large", "", ""},
+		{"below minimum size", "/small", "gzip, br", ""},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(fiber.MethodGet, tt.path, nil)
+		if tt.acceptEncoding != "" {
+			req.Header.Set(fiber.HeaderAcceptEncoding, tt.acceptEncoding)
+		}
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("%s: request failed: %v", tt.name, err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("%s: reading body failed: %v", tt.name, err)
+		}
+
+		encoding := resp.Header.Get(fiber.HeaderContentEncoding)
+		if encoding != tt.wantEncoding {
+			t.Errorf("%s: Content-Encoding = %q, want %q", tt.name, encoding, tt.wantEncoding)
+		}
+		vary := resp.Header.Get(fiber.HeaderVary)
+		if tt.wantEncoding != "" && !strings.Contains(vary, fiber.HeaderAcceptEncoding) {
+			t.Errorf("%s: Vary = %q, want Accept-Encoding", tt.name, vary)
+		}
+		if tt.wantEncoding == "" {
+			want := large
+			if tt.path == "/small" {
+				want = small
+			}
+			if string(body) != want {
+				t.Errorf("%s: body = %q, want it uncompressed", tt.name, body)
+			}
+		}
+		if tt.wantEncoding == "gzip" {
+			zr, err := gzip.NewReader(strings.NewReader(string(body)))
+			if err != nil {
+				t.Fatalf("%s: gzip.NewReader failed: %v", tt.name, err)
+			}
+			if got, err := io.ReadAll(zr); err != nil || string(got) != large {
+				t.Errorf("%s: decompressed body = %d bytes, %v; want the original", tt.name, len(got), err)
+			}
+		}
+	}
+}
+
+// TestMiddlewareDisabled verifies a negative minimum size disables
+// compression.
+func TestMiddlewareDisabled(t *testing.T) {
+	app := fiber.New()
+	app.Use(compression.Middleware(-1))
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(strings.Repeat("The fee is $500.\n", 100))
+	})
+
+	req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+	req.Header.Set(fiber.HeaderAcceptEncoding, "gzip, br")
+	resp, err := app.Test(req)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	resp.Body.Close()
+	if encoding := resp.Header.Get(fiber.HeaderContentEncoding); encoding != "" {
+		t.Errorf("Content-Encoding = %q, want none", encoding)
+	}
+}
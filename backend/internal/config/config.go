@@ -0,0 +1,214 @@
+// Package config loads the API server's HTTP settings — listen port, API
+// path prefix, CORS origins, trusted proxies, body limit, and timeouts — from environment
+// variables and an optional file, so a deployment behind its own domain is
+// configured without code changes.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"github.com/drewjst/deltagov/internal/compression"
+)
+
+// Modes select the defaults that Load starts from.
+const (
+	ModeDevelopment = "development"
+	ModeProduction  = "production"
+)
+
+// DefaultAPIPrefix is the path prefix of the API's versioned routes.
+const DefaultAPIPrefix = "/api/v1"
+
+// DefaultOrigins are the CORS origins allowed in development: the Angular
+// dev server and the nginx frontend container.
+var DefaultOrigins = []string{"http://localhost:4200", "http://localhost:80", "http://localhost"}
+
+// Server holds the API server's HTTP settings.
+type Server struct {
+	Mode          string
+	Port          string
+	PublicBaseURL string // Public origin of the API (default: http://localhost:$PORT)
+	APIPrefix     string // Path prefix of the versioned routes, e.g., "/api/v1"
+
+	AllowOrigins     []string
+	AllowCredentials bool
+
+	// TrustedProxies are the IPs or CIDR ranges whose ProxyHeader is
+	// believed for the client IP. Empty trusts no proxy.
+	TrustedProxies []string
+	ProxyHeader    string
+
+	BodyLimit       int           // Maximum request body, in bytes
+	ReadTimeout     time.Duration // Zero means no timeout
+	WriteTimeout    time.Duration // Zero means no timeout; event streams need none
+	IdleTimeout     time.Duration // Zero means ReadTimeout
+	ShutdownTimeout time.Duration
+	CompressMinSize int // Negative disables compression
+}
+
+// Defaults returns the settings of a mode before overrides. Production
+// allows no CORS origin by default, so the origins must be configured,
+// limits request bodies to 1 MiB, and times out slow clients.
+func Defaults(mode string) Server {
+	s := Server{
+		Mode:             ModeDevelopment,
+		Port:             "8080",
+		APIPrefix:        DefaultAPIPrefix,
+		AllowOrigins:     append([]string(nil), DefaultOrigins...),
+		AllowCredentials: true,
+		ProxyHeader:      "X-Forwarded-For",
+		BodyLimit:        4 * 1024 * 1024,
+		ShutdownTimeout:  30 * time.Second,
+		CompressMinSize:  compression.DefaultMinSize,
+	}
+	if mode == ModeProduction {
+		s.Mode = ModeProduction
+		s.AllowOrigins = nil
+		s.BodyLimit = 1024 * 1024
+		s.ReadTimeout = 30 * time.Second
+		s.IdleTimeout = 120 * time.Second
+	}
+	return s
+}
+
+// Load returns the server settings. APP_ENV selects the mode's defaults
+// ("development", the default, or "production"); the file named by
+// CONFIG_FILE, in .env format, overrides them; and environment variables
+// override the file:
+//
+//	PORT                     listen port
+//	PUBLIC_BASE_URL          public origin of the API
+//	API_PREFIX               path prefix of the versioned routes
+//	CORS_ALLOW_ORIGINS       comma-separated origins, or "*"
+//	CORS_ALLOW_CREDENTIALS   true or false
+//	TRUSTED_PROXIES          comma-separated IPs or CIDR ranges
+//	PROXY_HEADER             header carrying the client IP behind a proxy
+//	BODY_LIMIT               maximum request body, in bytes
+//	READ_TIMEOUT, WRITE_TIMEOUT, IDLE_TIMEOUT, SHUTDOWN_TIMEOUT  durations
+//	COMPRESS_MIN_SIZE        smallest compressed response, in bytes
+func Load() (Server, error) {
+	values := map[string]string{}
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		file, err := godotenv.Read(path)
+		if err != nil {
+			return Server{}, fmt.Errorf("config: failed to read CONFIG_FILE: %w", err)
+		}
+		values = file
+	}
+	get := func(key string) string {
+		if v, ok := os.LookupEnv(key); ok {
+			return v
+		}
+		return values[key]
+	}
+
+	mode := strings.ToLower(strings.TrimSpace(get("APP_ENV")))
+	switch mode {
+	case "", ModeDevelopment, ModeProduction:
+	default:
+		return Server{}, fmt.Errorf("config: unknown APP_ENV %q, want development or production", mode)
+	}
+	s := Defaults(mode)
+
+	if v := get("PORT"); v != "" {
+		s.Port = v
+	}
+	if v := get("PUBLIC_BASE_URL"); v != "" {
+		s.PublicBaseURL = strings.TrimSuffix(v, "/")
+	}
+	if v := get("API_PREFIX"); v != "" {
+		s.APIPrefix = strings.TrimSuffix(v, "/")
+	}
+	if v := get("CORS_ALLOW_ORIGINS"); v != "" {
+		s.AllowOrigins = splitList(v)
+	}
+	if v := get("TRUSTED_PROXIES"); v != "" {
+		s.TrustedProxies = splitList(v)
+	}
+	if v := get("PROXY_HEADER"); v != "" {
+		s.ProxyHeader = v
+	}
+
+	var err error
+	if v := get("CORS_ALLOW_CREDENTIALS"); v != "" {
+		if s.AllowCredentials, err = strconv.ParseBool(v); err != nil {
+			return Server{}, fmt.Errorf("config: invalid CORS_ALLOW_CREDENTIALS: %w", err)
+		}
+	}
+	for _, setting := range []struct {
+		key string
+		dst *int
+	}{
+		{"BODY_LIMIT", &s.BodyLimit},
+		{"COMPRESS_MIN_SIZE", &s.CompressMinSize},
+	} {
+		if v := get(setting.key); v != "" {
+			if *setting.dst, err = strconv.Atoi(v); err != nil {
+				return Server{}, fmt.Errorf("config: invalid %s: %w", setting.key, err)
+			}
+		}
+	}
+	for _, setting := range []struct {
+		key string
+		dst *time.Duration
+	}{
+		{"READ_TIMEOUT", &s.ReadTimeout},
+		{"WRITE_TIMEOUT", &s.WriteTimeout},
+		{"IDLE_TIMEOUT", &s.IdleTimeout},
+		{"SHUTDOWN_TIMEOUT", &s.ShutdownTimeout},
+	} {
+		if v := get(setting.key); v != "" {
+			if *setting.dst, err = time.ParseDuration(v); err != nil {
+				return Server{}, fmt.Errorf("config: invalid %s: %w", setting.key, err)
+			}
+		}
+	}
+
+	if s.PublicBaseURL == "" {
+		s.PublicBaseURL = "http://localhost:" + s.Port
+	}
+	return s, s.Validate()
+}
+
+// Validate reports settings the server can't run with.
+func (s Server) Validate() error {
+	if len(s.AllowOrigins) == 0 && s.Mode == ModeProduction {
+		return errors.New("config: CORS_ALLOW_ORIGINS is required in production")
+	}
+	for _, o := range s.AllowOrigins {
+		if o == "*" && s.AllowCredentials {
+			return errors.New("config: CORS_ALLOW_ORIGINS=* requires CORS_ALLOW_CREDENTIALS=false")
+		}
+	}
+	if !strings.HasPrefix(s.APIPrefix, "/") || strings.ContainsAny(s.APIPrefix, "{}?# ") {
+		return fmt.Errorf("config: API_PREFIX %q must be a path starting with /", s.APIPrefix)
+	}
+	if s.BodyLimit <= 0 {
+		return errors.New("config: BODY_LIMIT must be positive")
+	}
+	return nil
+}
+
+// Origins returns AllowOrigins in the comma-separated form the CORS
+// middleware takes.
+func (s Server) Origins() string {
+	return strings.Join(s.AllowOrigins, ", ")
+}
+
+// splitList splits a comma-separated list, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, p := range strings.Split(value, ",") {
+		if p = strings.TrimSpace(p); p != "" {
+			items = append(items, p)
+		}
+	}
+	return items
+}
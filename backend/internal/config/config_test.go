@@ -0,0 +1,96 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/config"
+)
+
+func TestLoadDevelopmentDefaults(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Mode != config.ModeDevelopment || cfg.Port != "8080" {
+		t.Errorf("Mode, Port = %q, %q", cfg.Mode, cfg.Port)
+	}
+	if !slices.Equal(cfg.AllowOrigins, config.DefaultOrigins) || !cfg.AllowCredentials {
+		t.Errorf("AllowOrigins = %v, AllowCredentials = %v", cfg.AllowOrigins, cfg.AllowCredentials)
+	}
+	if cfg.PublicBaseURL != "http://localhost:8080" {
+		t.Errorf("PublicBaseURL = %q", cfg.PublicBaseURL)
+	}
+	if cfg.APIPrefix != config.DefaultAPIPrefix {
+		t.Errorf("APIPrefix = %q", cfg.APIPrefix)
+	}
+}
+
+func TestLoadProduction(t *testing.T) {
+	t.Setenv("APP_ENV", "production")
+	if _, err := config.Load(); err == nil {
+		t.Fatal("Load without CORS_ALLOW_ORIGINS in production succeeded")
+	}
+
+	t.Setenv("CORS_ALLOW_ORIGINS", "https://deltagov.org, https://www.deltagov.org")
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8")
+	t.Setenv("PUBLIC_BASE_URL", "https://api.deltagov.org/")
+	t.Setenv("API_PREFIX", "/api/v2/")
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Origins() != "https://deltagov.org, https://www.deltagov.org" {
+		t.Errorf("Origins = %q", cfg.Origins())
+	}
+	if !slices.Equal(cfg.TrustedProxies, []string{"10.0.0.0/8"}) {
+		t.Errorf("TrustedProxies = %v", cfg.TrustedProxies)
+	}
+	if cfg.PublicBaseURL != "https://api.deltagov.org" {
+		t.Errorf("PublicBaseURL = %q", cfg.PublicBaseURL)
+	}
+	if cfg.APIPrefix != "/api/v2" {
+		t.Errorf("APIPrefix = %q", cfg.APIPrefix)
+	}
+	if cfg.BodyLimit != 1024*1024 || cfg.ReadTimeout != 30*time.Second {
+		t.Errorf("BodyLimit, ReadTimeout = %d, %v", cfg.BodyLimit, cfg.ReadTimeout)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "server.env")
+	if err := os.WriteFile(path, []byte("PORT=9000\nREAD_TIMEOUT=5s\nBODY_LIMIT=2048\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("BODY_LIMIT", "4096") // The environment overrides the file
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Port != "9000" || cfg.ReadTimeout != 5*time.Second || cfg.BodyLimit != 4096 {
+		t.Errorf("Port, ReadTimeout, BodyLimit = %q, %v, %d", cfg.Port, cfg.ReadTimeout, cfg.BodyLimit)
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	for name, env := range map[string][2]string{
+		"mode":                {"APP_ENV", "staging"},
+		"timeout":             {"READ_TIMEOUT", "soon"},
+		"body limit":          {"BODY_LIMIT", "0"},
+		"api prefix":          {"API_PREFIX", "api/v2"},
+		"wildcard with creds": {"CORS_ALLOW_ORIGINS", "*"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(env[0], env[1])
+			if _, err := config.Load(); err == nil {
+				t.Errorf("Load with %s=%s succeeded", env[0], env[1])
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package congress
+
+import (
+	"context"
+	neturl "net/url"
+	"strconv"
+)
+
+// Action is a step in the history of a treaty or nomination, such as
+// referral to committee, a committee report, or a floor vote.
+type Action struct {
+	ActionCode string `json:"actionCode,omitempty"`
+	ActionDate string `json:"actionDate"` // YYYY-MM-DD
+	Text       string `json:"text"`
+	Type       string `json:"type"` // e.g., "Committee", "Floor", "IntroReferral"
+}
+
+// getActions fetches the actions listed at path, following pagination.
+// Congress.gov lists them newest first.
+func (c *Client) getActions(ctx context.Context, path string) ([]Action, error) {
+	actions := make([]Action, 0, 16)
+	for offset := 0; ; offset += defaultLimit {
+		var page struct {
+			Actions    []Action   `json:"actions"`
+			Pagination Pagination `json:"pagination"`
+		}
+		query := neturl.Values{
+			"limit":  {strconv.Itoa(defaultLimit)},
+			"offset": {strconv.Itoa(offset)},
+		}
+		if err := c.getJSON(ctx, path, query, &page); err != nil {
+			return nil, err
+		}
+
+		actions = append(actions, page.Actions...)
+		if page.Pagination.Next == "" || len(page.Actions) == 0 {
+			break
+		}
+	}
+
+	return actions, nil
+}
@@ -0,0 +1,72 @@
+package congress
+
+import "strings"
+
+// Kinds of legislation, by what they do once adopted.
+const (
+	KindBill                 = "bill"                  // Becomes law when enacted
+	KindJointResolution      = "joint resolution"      // Becomes law when enacted, as do continuing resolutions; or proposes a constitutional amendment
+	KindConcurrentResolution = "concurrent resolution" // Adopted by both chambers without becoming law, e.g., a budget resolution
+	KindSimpleResolution     = "simple resolution"     // Adopted by one chamber, e.g., a rule for considering a bill
+)
+
+// BillType is a type of legislation the Congress.gov bill endpoint serves.
+type BillType struct {
+	Code     string // Lower-case code, as in Congress.gov paths, e.g., "hconres"
+	Citation string // Citation prefix, e.g., "H.Con.Res."
+	Chamber  string // Chamber of origin, "House" or "Senate"
+	Kind     string // One of the Kind constants
+}
+
+// BecomesLaw reports whether legislation of the type becomes law when
+// enacted: bills and joint resolutions do; concurrent and simple
+// resolutions, which only express or govern Congress, don't.
+func (t BillType) BecomesLaw() bool {
+	return t.Kind == KindBill || t.Kind == KindJointResolution
+}
+
+// BillTypes lists every type the Congress.gov bill endpoint serves.
+var BillTypes = []BillType{
+	{"hr", "H.R.", "House", KindBill},
+	{"s", "S.", "Senate", KindBill},
+	{"hjres", "H.J.Res.", "House", KindJointResolution},
+	{"sjres", "S.J.Res.", "Senate", KindJointResolution},
+	{"hconres", "H.Con.Res.", "House", KindConcurrentResolution},
+	{"sconres", "S.Con.Res.", "Senate", KindConcurrentResolution},
+	{"hres", "H.Res.", "House", KindSimpleResolution},
+	{"sres", "S.Res.", "Senate", KindSimpleResolution},
+}
+
+// BillTypeCodes returns the codes of BillTypes.
+func BillTypeCodes() []string {
+	codes := make([]string, len(BillTypes))
+	for i, t := range BillTypes {
+		codes[i] = t.Code
+	}
+	return codes
+}
+
+// LookupBillType returns the type with a code, in any case ("HRES" as the
+// bill list returns it, or "hres"). It reports false for codes
+// Congress.gov doesn't serve, such as state bill types.
+func LookupBillType(code string) (BillType, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	for _, t := range BillTypes {
+		if t.Code == code {
+			return t, true
+		}
+	}
+	return BillType{}, false
+}
+
+// IsSpendingBillOfType is IsSpendingBill for federal legislation of a type:
+// only legislation that becomes law appropriates, so concurrent and simple
+// resolutions (a budget resolution, or a rule for considering an
+// appropriations bill) never count, whatever their subjects. Unknown
+// types are classified by title and subjects alone.
+func IsSpendingBillOfType(billType, title string, subjects []string) bool {
+	if t, ok := LookupBillType(billType); ok && !t.BecomesLaw() {
+		return false
+	}
+	return IsSpendingBill(title, subjects)
+}
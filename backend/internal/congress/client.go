@@ -7,9 +7,15 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	neturl "net/url"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/metrics"
 )
 
 const (
@@ -34,8 +40,25 @@ type Client struct {
 	httpClient *http.Client
 	baseURL    string
 
-	// mu protects any future mutable state (e.g., rate limit tracking)
-	mu sync.RWMutex
+	// interval is the minimum spacing between API requests; 0 for none
+	interval time.Duration
+
+	// sent counts API requests, for callers budgeting them
+	sent atomic.Int64
+
+	// mu protects the rate limit state below
+	mu          sync.Mutex
+	next        time.Time // Earliest time the next API request may start
+	pausedUntil time.Time // Set after a 429 so all callers back off together
+	limit       int       // X-RateLimit-Limit of the last API response; 0 if not seen
+	remaining   int       // X-RateLimit-Remaining of the last API response
+	observedAt  time.Time // When limit and remaining were seen
+
+	// strict compares responses with the types they decode into; see
+	// WithStrictDecoding
+	strict  bool
+	driftMu sync.Mutex
+	drift   map[string]*FieldDrift // By endpoint, kind, and field
 }
 
 // Option is a functional option for configuring the Client.
@@ -103,7 +126,8 @@ func NewClient(opts ...Option) (*Client, error) {
 }
 
 // Bill represents a legislative bill from Congress.gov API V3.
-// Fields map to the /bill/{congress}/{billType} endpoint response.
+// Fields map to the /bill/{congress}/{billType} endpoint response; see
+// BillDetail for the detail endpoint's.
 // Note: Number is a string because some bill types use non-numeric identifiers.
 type Bill struct {
 	Congress                int           `json:"congress"`
@@ -116,6 +140,64 @@ type Bill struct {
 	UpdateDateIncludingText string        `json:"updateDateIncludingText,omitempty"`
 	URL                     string        `json:"url"`
 	LatestAction            *LatestAction `json:"latestAction,omitempty"`
+	Laws                    []Law         `json:"laws,omitempty"` // Only present on detail and GetLaws responses, once enacted
+}
+
+// BillDetail is a bill as the /bill/{congress}/{billType}/{billNumber}
+// endpoint returns it: its list fields plus sponsors, policy area, cost
+// estimates, and the counts of its subresources (actions, cosponsors,
+// titles, and so on), each fetched separately.
+type BillDetail struct {
+	Bill
+	IntroducedDate                       string            `json:"introducedDate"` // YYYY-MM-DD
+	Sponsors                             []Sponsor         `json:"sponsors,omitempty"`
+	PolicyArea                           *PolicyArea       `json:"policyArea,omitempty"` // Nil until CRS assigns one
+	CBOCostEstimates                     []CostEstimate    `json:"cboCostEstimates,omitempty"`
+	CommitteeReports                     []CommitteeReport `json:"committeeReports,omitempty"`
+	ConstitutionalAuthorityStatementText string            `json:"constitutionalAuthorityStatementText,omitempty"` // HTML; House bills only
+	Actions                              *ResourceCount    `json:"actions,omitempty"`
+	Amendments                           *ResourceCount    `json:"amendments,omitempty"`
+	Committees                           *ResourceCount    `json:"committees,omitempty"`
+	Cosponsors                           *CosponsorCount   `json:"cosponsors,omitempty"`
+	RelatedBills                         *ResourceCount    `json:"relatedBills,omitempty"`
+	Subjects                             *ResourceCount    `json:"subjects,omitempty"`
+	Summaries                            *ResourceCount    `json:"summaries,omitempty"`
+	TextVersions                         *ResourceCount    `json:"textVersions,omitempty"`
+	Titles                               *ResourceCount    `json:"titles,omitempty"`
+}
+
+// ResourceCount is how many items a bill subresource has, and where to
+// fetch them.
+type ResourceCount struct {
+	Count int    `json:"count"`
+	URL   string `json:"url"`
+}
+
+// CosponsorCount is a bill's cosponsor count, which excludes withdrawn
+// cosponsors.
+type CosponsorCount struct {
+	ResourceCount
+	CountIncludingWithdrawnCosponsors int `json:"countIncludingWithdrawnCosponsors"`
+}
+
+// CommitteeReport is a committee report on a bill.
+type CommitteeReport struct {
+	Citation string `json:"citation"` // e.g., "H. Rept. 119-106"
+	URL      string `json:"url"`
+}
+
+// Law identifies the law a bill became.
+type Law struct {
+	Number string `json:"number"` // e.g., "118-5"
+	Type   string `json:"type"`   // "Public Law" or "Private Law"
+}
+
+// CostEstimate is a Congressional Budget Office cost estimate of a bill.
+type CostEstimate struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	PubDate     string `json:"pubDate"` // RFC 3339
+	URL         string `json:"url"`
 }
 
 // LatestAction represents the most recent action on a bill.
@@ -164,7 +246,7 @@ func (c *Client) FetchBills(ctx context.Context, congress int, billType string,
 
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("congress: failed to fetch bills: %w", err)
 	}
@@ -180,7 +262,11 @@ func (c *Client) FetchBills(ctx context.Context, congress int, billType string,
 		Bills: make([]Bill, 0, defaultPreallocCap),
 	}
 
-	decoder := json.NewDecoder(resp.Body)
+	body, err := c.responseBody(resp, &BillsResponse{})
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(body)
 
 	// Parse the opening brace
 	if _, err := decoder.Token(); err != nil {
@@ -243,6 +329,45 @@ func (c *Client) decodeBillsArray(decoder *json.Decoder, result *FetchBillsResul
 	return nil
 }
 
+// do sends an HTTP request and records request metrics for it.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	// Text downloads live outside the API with unbounded paths; group them
+	endpoint := "text_content"
+	if strings.HasPrefix(req.URL.String(), c.baseURL) {
+		endpoint = metrics.EndpointLabel(strings.TrimPrefix(req.URL.Path, "/v3"))
+		if err := c.wait(req.Context()); err != nil {
+			return nil, err
+		}
+		c.sent.Add(1)
+	}
+	// Propagate the caller's correlation ID so upstream logs can be matched
+	if id := logging.RequestID(req.Context()); id != "" {
+		req.Header.Set(logging.RequestIDHeader, id)
+	}
+	start := time.Now()
+
+	resp, err := c.httpClient.Do(req)
+	elapsed := time.Since(start)
+	metrics.CongressRequestDuration.WithLabelValues(endpoint).Observe(elapsed.Seconds())
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+		if endpoint != "text_content" {
+			c.observeRateLimit(resp)
+		}
+		if resp.StatusCode == http.StatusTooManyRequests {
+			metrics.CongressRateLimited.Inc()
+			c.pause(req.Context(), resp)
+		}
+	}
+	metrics.CongressRequests.WithLabelValues(endpoint, status).Inc()
+	logging.FromContext(req.Context()).Debug("congress api request",
+		"endpoint", endpoint, "status", status, "latency_ms", elapsed.Milliseconds())
+
+	return resp, err
+}
+
 // checkResponse validates the HTTP response status code.
 func (c *Client) checkResponse(resp *http.Response) error {
 	switch resp.StatusCode {
@@ -257,10 +382,56 @@ func (c *Client) checkResponse(resp *http.Response) error {
 	}
 }
 
+// getJSON performs a GET request against a Congress.gov API path and decodes
+// the JSON response body into out. The API key and format are added to query.
+func (c *Client) getJSON(ctx context.Context, path string, query neturl.Values, out any) error {
+	if query == nil {
+		query = neturl.Values{}
+	}
+	query.Set("api_key", c.apiKey)
+	query.Set("format", "json")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("congress: failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("congress: failed to fetch %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if err := c.checkResponse(resp); err != nil {
+		return err
+	}
+
+	body, err := c.responseBody(resp, out)
+	if err != nil {
+		return err
+	}
+	if err := json.NewDecoder(body).Decode(out); err != nil {
+		return fmt.Errorf("congress: failed to decode %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Ping verifies the Congress.gov API is reachable and accepts the API key by
+// requesting a single bill from the bill list.
+func (c *Client) Ping(ctx context.Context) error {
+	var page struct {
+		Bills []json.RawMessage `json:"bills"`
+	}
+	return c.getJSON(ctx, "/bill", neturl.Values{"limit": {"1"}}, &page)
+}
+
 // GetBillDetail fetches detailed information for a specific bill.
-func (c *Client) GetBillDetail(ctx context.Context, congress int, billType string, billNumber int) (*Bill, error) {
-	url := fmt.Sprintf("%s/bill/%d/%s/%d?api_key=%s&format=json",
-		c.baseURL, congress, strings.ToLower(billType), billNumber, c.apiKey)
+func (c *Client) GetBillDetail(ctx context.Context, congress int, billType string, billNumber string) (*BillDetail, error) {
+	url := fmt.Sprintf("%s/bill/%d/%s/%s?api_key=%s&format=json",
+		c.baseURL, congress, strings.ToLower(billType), neturl.PathEscape(billNumber), c.apiKey)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -269,7 +440,7 @@ func (c *Client) GetBillDetail(ctx context.Context, congress int, billType strin
 
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("congress: failed to fetch bill detail: %w", err)
 	}
@@ -281,9 +452,13 @@ func (c *Client) GetBillDetail(ctx context.Context, congress int, billType strin
 
 	// Response wraps bill in a "bill" key
 	var wrapper struct {
-		Bill Bill `json:"bill"`
+		Bill BillDetail `json:"bill"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&wrapper); err != nil {
+	body, err := c.responseBody(resp, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.NewDecoder(body).Decode(&wrapper); err != nil {
 		return nil, fmt.Errorf("congress: failed to decode bill detail: %w", err)
 	}
 
@@ -291,9 +466,9 @@ func (c *Client) GetBillDetail(ctx context.Context, congress int, billType strin
 }
 
 // GetBillText fetches the text versions available for a bill.
-func (c *Client) GetBillText(ctx context.Context, congress int, billType string, billNumber int) ([]TextVersion, error) {
-	url := fmt.Sprintf("%s/bill/%d/%s/%d/text?api_key=%s&format=json",
-		c.baseURL, congress, strings.ToLower(billType), billNumber, c.apiKey)
+func (c *Client) GetBillText(ctx context.Context, congress int, billType string, billNumber string) ([]TextVersion, error) {
+	url := fmt.Sprintf("%s/bill/%d/%s/%s/text?api_key=%s&format=json",
+		c.baseURL, congress, strings.ToLower(billType), neturl.PathEscape(billNumber), c.apiKey)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -302,7 +477,7 @@ func (c *Client) GetBillText(ctx context.Context, congress int, billType string,
 
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("congress: failed to fetch bill text: %w", err)
 	}
@@ -314,8 +489,13 @@ func (c *Client) GetBillText(ctx context.Context, congress int, billType string,
 
 	var wrapper struct {
 		TextVersions []TextVersion `json:"textVersions"`
+		Pagination   Pagination    `json:"pagination"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&wrapper); err != nil {
+	body, err := c.responseBody(resp, &wrapper)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.NewDecoder(body).Decode(&wrapper); err != nil {
 		return nil, fmt.Errorf("congress: failed to decode text versions: %w", err)
 	}
 
@@ -348,7 +528,7 @@ func (c *Client) FetchTextContent(ctx context.Context, url string) (string, erro
 	req.Header.Set("Accept", "text/xml, text/html, text/plain")
 	req.Header.Set("User-Agent", "DeltaGov/1.0")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return "", fmt.Errorf("congress: failed to fetch text content: %w", err)
 	}
@@ -376,7 +556,7 @@ func (c *Client) FetchTextContent(ctx context.Context, url string) (string, erro
 
 // GetBillTextWithContent fetches all text versions and downloads the content for each.
 // Returns text versions with their content populated.
-func (c *Client) GetBillTextWithContent(ctx context.Context, congress int, billType string, billNumber int) ([]TextVersionWithContent, error) {
+func (c *Client) GetBillTextWithContent(ctx context.Context, congress int, billType string, billNumber string) ([]TextVersionWithContent, error) {
 	// First, get the list of text versions
 	versions, err := c.GetBillText(ctx, congress, billType, billNumber)
 	if err != nil {
@@ -485,6 +665,7 @@ type SearchFilters struct {
 	BillType         string // Filter by bill type (hr, s, hjres, sjres, etc.)
 	Limit            int    // Maximum results (1-250, default 250)
 	Offset           int    // Pagination offset
+	Sort             string // Optional sort order, e.g., "updateDate+desc"
 }
 
 // SearchBills searches for bills using the Congress.gov API with optional filters.
@@ -526,6 +707,9 @@ func (c *Client) SearchBills(ctx context.Context, filters SearchFilters) (*Fetch
 
 	fmt.Fprintf(&urlBuilder, "?api_key=%s&format=json&limit=%d&offset=%d",
 		c.apiKey, limit, filters.Offset)
+	if filters.Sort != "" {
+		fmt.Fprintf(&urlBuilder, "&sort=%s", filters.Sort)
+	}
 
 	url := urlBuilder.String()
 
@@ -536,7 +720,7 @@ func (c *Client) SearchBills(ctx context.Context, filters SearchFilters) (*Fetch
 
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("congress: failed to search bills: %w", err)
 	}
@@ -551,7 +735,11 @@ func (c *Client) SearchBills(ctx context.Context, filters SearchFilters) (*Fetch
 		Bills: make([]Bill, 0, limit),
 	}
 
-	decoder := json.NewDecoder(resp.Body)
+	body, err := c.responseBody(resp, &BillsResponse{})
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(body)
 
 	if _, err := decoder.Token(); err != nil {
 		return nil, fmt.Errorf("congress: failed to parse response start: %w", err)
@@ -660,7 +848,7 @@ func (c *Client) FetchRecentBills(ctx context.Context, limit int) (*FetchBillsRe
 
 	req.Header.Set("Accept", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("congress: failed to fetch recent bills: %w", err)
 	}
@@ -675,7 +863,11 @@ func (c *Client) FetchRecentBills(ctx context.Context, limit int) (*FetchBillsRe
 		Bills: make([]Bill, 0, limit),
 	}
 
-	decoder := json.NewDecoder(resp.Body)
+	body, err := c.responseBody(resp, &BillsResponse{})
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(body)
 
 	// Parse the opening brace
 	if _, err := decoder.Token(); err != nil {
@@ -0,0 +1,140 @@
+package congress_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/congresstest"
+)
+
+// TestFetchBillsPagination verifies a bill list is read a page at a time
+// until the last page.
+func TestFetchBillsPagination(t *testing.T) {
+	srv := congresstest.NewServer(t)
+	for i := 1; i <= 260; i++ {
+		srv.AddBill(congress.Bill{Congress: 119, Type: "HR", Number: strconv.Itoa(i), UpdateDate: "2025-01-03"})
+	}
+	srv.AddBill(congress.Bill{Congress: 119, Type: "S", Number: "1", UpdateDate: "2025-01-03"})
+	c := srv.Client(t)
+	ctx := context.Background()
+
+	first, err := c.FetchBills(ctx, 119, "hr", 0)
+	if err != nil {
+		t.Fatalf("FetchBills failed: %v", err)
+	}
+	if len(first.Bills) != 250 || first.TotalCount != 260 || !first.HasMore {
+		t.Errorf("First page: %d bills of %d, hasMore %t", len(first.Bills), first.TotalCount, first.HasMore)
+	}
+
+	last, err := c.FetchBills(ctx, 119, "hr", len(first.Bills))
+	if err != nil {
+		t.Fatalf("FetchBills failed: %v", err)
+	}
+	if len(last.Bills) != 10 || last.HasMore {
+		t.Errorf("Last page: %d bills, hasMore %t", len(last.Bills), last.HasMore)
+	}
+}
+
+// TestRateLimited verifies a 429 is reported as ErrRateLimited and holds
+// back the next request until Retry-After has passed.
+func TestRateLimited(t *testing.T) {
+	srv := congresstest.NewServer(t)
+	srv.AddBill(congress.Bill{Congress: 119, Type: "HR", Number: "1", UpdateDate: "2025-01-03"})
+	c := srv.Client(t)
+	ctx := context.Background()
+
+	srv.RateLimitNext(1)
+	if _, err := c.FetchRecentBills(ctx, 10); !errors.Is(err, congress.ErrRateLimited) {
+		t.Fatalf("Expected ErrRateLimited, got %v", err)
+	}
+	if status := c.RateLimit(); !status.PausedUntil.After(time.Now()) || status.Remaining != 0 {
+		t.Errorf("Expected a pause with none remaining, got %+v", status)
+	}
+
+	start := time.Now()
+	result, err := c.FetchRecentBills(ctx, 10)
+	if err != nil {
+		t.Fatalf("FetchRecentBills after the pause failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 500*time.Millisecond {
+		t.Errorf("Request after a 429 wasn't held back (took %s)", elapsed)
+	}
+	if len(result.Bills) != 1 {
+		t.Errorf("Expected 1 bill, got %d", len(result.Bills))
+	}
+	if status := c.RateLimit(); status.Limit != congresstest.RateLimit || status.Remaining <= 0 {
+		t.Errorf("Expected the reported limit, got %+v", status)
+	}
+}
+
+// TestGetBillTextWithContent verifies text versions are listed and their
+// content downloaded, and that unknown bills are ErrNotFound.
+func TestGetBillTextWithContent(t *testing.T) {
+	srv := congresstest.NewServer(t)
+	srv.AddBill(congress.Bill{Congress: 118, Type: "HJRES", Number: "7", UpdateDate: "2024-03-01"},
+		congresstest.Text{Type: "Introduced in House", Date: "2024-01-10T05:00:00Z", Content: "introduced text"},
+		congresstest.Text{Type: "Enrolled Bill", Date: "2024-03-01T05:00:00Z", Content: "enrolled text"})
+	c := srv.Client(t)
+	ctx := context.Background()
+
+	versions, err := c.GetBillTextWithContent(ctx, 118, "hjres", "7")
+	if err != nil {
+		t.Fatalf("GetBillTextWithContent failed: %v", err)
+	}
+	// Newest first, as Congress.gov lists them
+	if len(versions) != 2 || versions[0].Content != "enrolled text" || versions[1].Type != "Introduced in House" {
+		t.Errorf("Unexpected versions: %+v", versions)
+	}
+
+	if _, err := c.GetBillDetail(ctx, 118, "hjres", "8"); !errors.Is(err, congress.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an unknown bill, got %v", err)
+	}
+}
+
+// TestStrictDecoding verifies strict mode reports new and missing fields
+// once per endpoint and field, and still decodes the response.
+func TestStrictDecoding(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"bill":{"congress":119,"type":"HR","number":"1","originChamber":"House",
+			"originChamberCode":"H","updateDate":"2025-01-03","introducedDate":"2025-01-03","url":"u","policyArea":{"name":"Taxation"},
+			"fundingSources":[{"name":"Treasury"}]},"request":{"format":"json"}}`)
+	}))
+	defer srv.Close()
+
+	c, err := congress.NewClient(congress.WithAPIKey("key"), congress.WithBaseURL(srv.URL+"/v3"),
+		congress.WithHTTPClient(srv.Client()), congress.WithStrictDecoding())
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	for range 2 {
+		bill, err := c.GetBillDetail(context.Background(), 119, "hr", "1")
+		if err != nil || bill.Number != "1" {
+			t.Fatalf("GetBillDetail = %+v, %v", bill, err)
+		}
+	}
+
+	// request is ignored; title is required
+	report := c.DriftReport()
+	if len(report) != 2 {
+		t.Fatalf("Expected 2 drifted fields, got %+v", report)
+	}
+	if d := report[0]; d.Endpoint != "/bill/:n/hr/:n" || d.Kind != congress.DriftMissing || d.Field != "bill.title" || d.Count != 2 {
+		t.Errorf("Unexpected missing field: %+v", d)
+	}
+	if d := report[1]; d.Kind != congress.DriftUnknown || d.Field != "bill.fundingSources" {
+		t.Errorf("Unexpected unknown field: %+v", d)
+	}
+
+	c, _ = congress.NewClient(congress.WithAPIKey("key"), congress.WithBaseURL(srv.URL+"/v3"), congress.WithHTTPClient(srv.Client()))
+	if _, err := c.GetBillDetail(context.Background(), 119, "hr", "1"); err != nil || len(c.DriftReport()) != 0 {
+		t.Errorf("Without strict mode drift shouldn't be checked: %v, %+v", err, c.DriftReport())
+	}
+}
@@ -0,0 +1,103 @@
+package congress
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Congress is a two-year Congress and its sessions.
+type Congress struct {
+	Name      string    `json:"name"` // e.g., "118th Congress"
+	Number    int       `json:"number"`
+	StartYear string    `json:"startYear"`
+	EndYear   string    `json:"endYear"`
+	Sessions  []Session `json:"sessions"`
+	URL       string    `json:"url"`
+}
+
+// Session is one session of a Congress in one chamber.
+type Session struct {
+	Chamber   string `json:"chamber"` // "House of Representatives" or "Senate"
+	Number    int    `json:"number"`
+	Type      string `json:"type"`              // "R" (regular) or "S" (special)
+	StartDate string `json:"startDate"`         // YYYY-MM-DD
+	EndDate   string `json:"endDate,omitempty"` // YYYY-MM-DD; absent while the session is in progress
+}
+
+// InSession reports whether date falls within the session, treating a
+// session without an end date as ongoing.
+func (s Session) InSession(date time.Time) bool {
+	day := date.Format(time.DateOnly)
+	if s.StartDate == "" || day < s.StartDate {
+		return false
+	}
+	return s.EndDate == "" || day <= s.EndDate
+}
+
+// GetCongresses fetches every Congress Congress.gov knows, newest first,
+// following pagination.
+func (c *Client) GetCongresses(ctx context.Context) ([]Congress, error) {
+	congresses := make([]Congress, 0, defaultLimit)
+	for offset := 0; ; offset += defaultLimit {
+		var page struct {
+			Congresses []Congress `json:"congresses"`
+			Pagination Pagination `json:"pagination"`
+		}
+		query := neturl.Values{
+			"limit":  {strconv.Itoa(defaultLimit)},
+			"offset": {strconv.Itoa(offset)},
+		}
+		if err := c.getJSON(ctx, "/congress", query, &page); err != nil {
+			return nil, err
+		}
+
+		for _, cg := range page.Congresses {
+			congresses = append(congresses, cg.withNumber())
+		}
+		if page.Pagination.Next == "" || len(page.Congresses) == 0 {
+			break
+		}
+	}
+
+	return congresses, nil
+}
+
+// GetCongress fetches one Congress and its session dates.
+func (c *Client) GetCongress(ctx context.Context, congress int) (*Congress, error) {
+	return c.getCongress(ctx, fmt.Sprintf("/congress/%d", congress))
+}
+
+// GetCurrentCongress fetches the Congress now sitting and its session
+// dates.
+func (c *Client) GetCurrentCongress(ctx context.Context) (*Congress, error) {
+	return c.getCongress(ctx, "/congress/current")
+}
+
+// getCongress fetches a single Congress response from path.
+func (c *Client) getCongress(ctx context.Context, path string) (*Congress, error) {
+	var resp struct {
+		Congress Congress `json:"congress"`
+	}
+	if err := c.getJSON(ctx, path, nil, &resp); err != nil {
+		return nil, err
+	}
+	cg := resp.Congress.withNumber()
+	return &cg, nil
+}
+
+// withNumber fills Number from Name for responses that omit it, as the
+// /congress listing does.
+func (cg Congress) withNumber() Congress {
+	if cg.Number == 0 {
+		digits := strings.TrimLeft(cg.Name, " ")
+		end := strings.IndexFunc(digits, func(r rune) bool { return r < '0' || r > '9' })
+		if end > 0 {
+			cg.Number, _ = strconv.Atoi(digits[:end])
+		}
+	}
+	return cg
+}
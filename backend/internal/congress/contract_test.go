@@ -0,0 +1,194 @@
+package congress_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/congress"
+)
+
+// Contract tests replay recorded Congress.gov responses through a client
+// in strict mode, so upstream schema drift fails here rather than in
+// production.
+//
+// Re-record against the live API with:
+// CONGRESS_RECORD=1 CONGRESS_API_KEY=... go test ./internal/congress/ -run TestContract
+
+// newContractClient returns a client replaying the named cassette, or
+// recording it from Congress.gov when CONGRESS_RECORD is set.
+func newContractClient(t *testing.T, name string) (*congress.Client, *congress.Recorder) {
+	t.Helper()
+	path := filepath.Join("testdata", "cassettes", name+".json")
+	mode, key := congress.ModeReplay, "test-key"
+	if os.Getenv("CONGRESS_RECORD") != "" {
+		if key = os.Getenv("CONGRESS_API_KEY"); key == "" {
+			t.Fatal("CONGRESS_RECORD requires CONGRESS_API_KEY")
+		}
+		mode = congress.ModeRecord
+	}
+
+	rec, err := congress.NewRecorder(path, mode, key)
+	if err != nil {
+		t.Fatalf("NewRecorder failed: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := rec.Save(); err != nil {
+			t.Errorf("Save failed: %v", err)
+		}
+	})
+	c, err := congress.NewClient(congress.WithAPIKey(key), congress.WithStrictDecoding(),
+		congress.WithHTTPClient(&http.Client{Transport: rec}))
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	return c, rec
+}
+
+// TestContractBill replays a bill's list entry, detail, text versions,
+// and text, checking the client reads each and the responses match the
+// client's types.
+func TestContractBill(t *testing.T) {
+	c, rec := newContractClient(t, "bill")
+	ctx := context.Background()
+
+	list, err := c.FetchBills(ctx, 119, "hr", 0)
+	if err != nil {
+		t.Fatalf("FetchBills failed: %v", err)
+	}
+	if len(list.Bills) == 0 || list.TotalCount < len(list.Bills) {
+		t.Fatalf("Unexpected bill list: %d bills of %d", len(list.Bills), list.TotalCount)
+	}
+	for _, b := range list.Bills {
+		if b.Congress != 119 || b.Number == "" || b.Title == "" || b.UpdateDate == "" {
+			t.Errorf("Incomplete list entry: %+v", b)
+		}
+		if _, ok := congress.LookupBillType(b.Type); !ok {
+			t.Errorf("Unknown bill type %q", b.Type)
+		}
+	}
+
+	detail, err := c.GetBillDetail(ctx, 119, "hr", "1")
+	if err != nil {
+		t.Fatalf("GetBillDetail failed: %v", err)
+	}
+	if detail.IntroducedDate == "" || len(detail.Sponsors) == 0 || detail.Sponsors[0].BioguideID == "" || detail.LatestAction == nil {
+		t.Errorf("Incomplete bill detail: %+v", detail)
+	}
+
+	versions, err := c.GetBillText(ctx, 119, "hr", "1")
+	if err != nil {
+		t.Fatalf("GetBillText failed: %v", err)
+	}
+	if len(versions) == 0 || versions[0].Type == "" || len(versions[0].Formats) == 0 {
+		t.Fatalf("Unexpected text versions: %+v", versions)
+	}
+	var textURL string
+	for _, f := range versions[0].Formats {
+		if f.Type == "Formatted Text" {
+			textURL = f.URL
+		}
+	}
+	if textURL == "" {
+		t.Fatalf("Latest version has no formatted text: %+v", versions[0].Formats)
+	}
+	content, err := c.FetchTextContent(ctx, textURL)
+	if err != nil {
+		t.Fatalf("FetchTextContent failed: %v", err)
+	}
+	if !strings.Contains(content, "SECTION 1.") {
+		t.Errorf("Text content doesn't look like bill text: %.200q", content)
+	}
+
+	checkContracts(t, c, rec)
+}
+
+// checkContracts fails on drift strict mode found in the responses, and
+// on recordings that weren't sanitized.
+func checkContracts(t *testing.T, c *congress.Client, rec *congress.Recorder) {
+	t.Helper()
+	for _, d := range c.DriftReport() {
+		switch d.Kind {
+		case congress.DriftUnknown:
+			t.Errorf("%s: new field %q; decode it or add it to congress.IgnoredFields", d.Endpoint, d.Field)
+		case congress.DriftMissing:
+			t.Errorf("%s: field %q is gone; was it renamed?", d.Endpoint, d.Field)
+		}
+	}
+	for _, in := range rec.Interactions() {
+		if strings.Contains(in.Request.URL, "api_key") {
+			t.Errorf("Recording of %s isn't sanitized", in.Request.URL)
+		}
+	}
+}
+
+// TestRecorder verifies a recording is sanitized of the API key and
+// replays without the network.
+func TestRecorder(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=abc")
+		fmt.Fprintf(w, `{"bill":{"congress":119,"type":"HR","number":"1","url":"%s?api_key=%s"}}`,
+			r.URL.Path, r.URL.Query().Get("api_key"))
+	}))
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	rec, err := congress.NewRecorder(path, congress.ModeRecord, "secret-key")
+	if err != nil {
+		t.Fatalf("NewRecorder failed: %v", err)
+	}
+	c, _ := congress.NewClient(congress.WithAPIKey("secret-key"), congress.WithBaseURL(srv.URL),
+		congress.WithHTTPClient(&http.Client{Transport: rec}))
+	if _, err := c.GetBillDetail(context.Background(), 119, "hr", "1"); err != nil {
+		t.Fatalf("GetBillDetail while recording failed: %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "secret-key") || strings.Contains(string(data), "session=abc") {
+		t.Errorf("Cassette leaks secrets: %s", data)
+	}
+
+	srv.Close()
+	replay, err := congress.NewRecorder(path, congress.ModeReplay)
+	if err != nil {
+		t.Fatalf("NewRecorder failed: %v", err)
+	}
+	c, _ = congress.NewClient(congress.WithAPIKey("other-key"), congress.WithBaseURL(srv.URL),
+		congress.WithHTTPClient(&http.Client{Transport: replay}))
+	bill, err := c.GetBillDetail(context.Background(), 119, "hr", "1")
+	if err != nil || bill.Number != "1" {
+		t.Fatalf("Replay = %+v, %v", bill, err)
+	}
+	if _, err := c.GetBillDetail(context.Background(), 119, "hr", "2"); !errors.Is(err, congress.ErrNoRecording) {
+		t.Errorf("Expected ErrNoRecording for an unrecorded request, got %v", err)
+	}
+}
+
+// TestSchemaDrift verifies new and renamed keys are reported.
+func TestSchemaDrift(t *testing.T) {
+	body := `{"bills":[{"congress":119,"type":"HR","billNumber":"1","title":"T","originChamber":"House",
+		"originChamberCode":"H","updateDate":"2025-01-01","url":"u","latestAction":{"actionDate":"2025-01-01","text":"x","actionTime":"12:00"}}],
+		"pagination":{"count":1}}`
+	drift, err := congress.SchemaDrift([]byte(body), congress.BillsResponse{})
+	if err != nil {
+		t.Fatalf("SchemaDrift failed: %v", err)
+	}
+	wantUnknown := []string{"bills[].billNumber", "bills[].latestAction.actionTime"}
+	if strings.Join(drift.Unknown, ",") != strings.Join(wantUnknown, ",") {
+		t.Errorf("Unknown = %v, want %v", drift.Unknown, wantUnknown)
+	}
+	// number was renamed billNumber
+	wantMissing := []string{"bills[].number"}
+	if strings.Join(drift.Missing, ",") != strings.Join(wantMissing, ",") {
+		t.Errorf("Missing = %v, want %v", drift.Missing, wantMissing)
+	}
+}
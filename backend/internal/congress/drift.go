@@ -0,0 +1,132 @@
+package congress
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Drift is how a JSON response differs from the Go type it decodes into.
+// Paths name keys from the top of the response, with [] for array
+// elements, e.g., "bills[].latestAction.text".
+type Drift struct {
+	// Unknown are keys in the response the type has no field for: new
+	// fields, or the new names of renamed ones.
+	Unknown []string
+	// Missing are fields of the type, other than omitempty ones, the
+	// response lacks: removed fields, or the old names of renamed ones.
+	Missing []string
+}
+
+// SchemaDrift compares a JSON response with the type of v, a struct or a
+// pointer to one, field by field through nested objects and arrays.
+// Fields decoded into maps or json.RawMessage are not compared further.
+func SchemaDrift(data []byte, v any) (Drift, error) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return Drift{}, fmt.Errorf("congress: failed to decode response: %w", err)
+	}
+	unknown, missing := map[string]bool{}, map[string]bool{}
+	compareSchema(doc, reflect.TypeOf(v), "", unknown, missing)
+
+	drift := Drift{}
+	for path := range unknown {
+		drift.Unknown = append(drift.Unknown, path)
+	}
+	for path := range missing {
+		drift.Missing = append(drift.Missing, path)
+	}
+	slices.Sort(drift.Unknown)
+	slices.Sort(drift.Missing)
+	return drift, nil
+}
+
+// compareSchema records the keys of value unknown to t, and t's required
+// fields missing from value, under path.
+func compareSchema(value any, t reflect.Type, path string, unknown, missing map[string]bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == reflect.TypeOf(json.RawMessage(nil)) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return
+		}
+		fields := jsonFields(t)
+		for key, child := range obj {
+			field, ok := fields[key]
+			if !ok {
+				unknown[joinPath(path, key)] = true
+				continue
+			}
+			compareSchema(child, field.Type, joinPath(path, key), unknown, missing)
+		}
+		for name, field := range fields {
+			if _, ok := obj[name]; !ok && !field.omitEmpty {
+				missing[joinPath(path, name)] = true
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		items, ok := value.([]any)
+		if !ok {
+			return
+		}
+		for _, item := range items {
+			compareSchema(item, t.Elem(), path+"[]", unknown, missing)
+		}
+	}
+}
+
+// jsonField is a struct field as encoding/json sees it.
+type jsonField struct {
+	Type      reflect.Type
+	omitEmpty bool
+}
+
+// jsonFields returns the JSON fields of a struct type by key, including
+// those of embedded structs.
+func jsonFields(t reflect.Type) map[string]jsonField {
+	fields := map[string]jsonField{}
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" || (!f.IsExported() && !f.Anonymous) {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			embedded := f.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				for k, v := range jsonFields(embedded) {
+					if _, ok := fields[k]; !ok {
+						fields[k] = v
+					}
+				}
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = jsonField{Type: f.Type, omitEmpty: strings.Contains(opts, "omitempty")}
+	}
+	return fields
+}
+
+// joinPath appends key to a drift path.
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
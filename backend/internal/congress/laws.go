@@ -0,0 +1,44 @@
+package congress
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+)
+
+// Law types accepted by GetLaws.
+const (
+	LawTypePublic  = "pub"
+	LawTypePrivate = "priv"
+)
+
+// GetLaws fetches the bills of a congress that became law, following
+// pagination. lawType narrows the listing to LawTypePublic or
+// LawTypePrivate laws; "" lists both. Each bill's Laws holds the law
+// numbers it was enacted as.
+func (c *Client) GetLaws(ctx context.Context, congress int, lawType string) ([]Bill, error) {
+	path := fmt.Sprintf("/law/%d", congress)
+	if lawType != "" {
+		path += "/" + lawType
+	}
+
+	laws := make([]Bill, 0, defaultLimit)
+	for offset := 0; ; offset += defaultLimit {
+		var page BillsResponse
+		query := neturl.Values{
+			"limit":  {strconv.Itoa(defaultLimit)},
+			"offset": {strconv.Itoa(offset)},
+		}
+		if err := c.getJSON(ctx, path, query, &page); err != nil {
+			return nil, err
+		}
+
+		laws = append(laws, page.Bills...)
+		if page.Pagination.Next == "" || len(page.Bills) == 0 {
+			break
+		}
+	}
+
+	return laws, nil
+}
@@ -0,0 +1,76 @@
+package congress
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+)
+
+// Nomination is a presidential nomination sent to the Senate, cited as
+// "PN" and its number (e.g., "PN123"). A nomination the Senate divided
+// has one Nomination per part, cited with the part number ("PN123-1").
+type Nomination struct {
+	Citation       string          `json:"citation"`
+	Congress       int             `json:"congress"`
+	Number         int             `json:"number"`
+	PartNumber     string          `json:"partNumber"` // "00" for an undivided nomination
+	Description    string          `json:"description"`
+	Organization   string          `json:"organization"`
+	ReceivedDate   string          `json:"receivedDate"`
+	UpdateDate     string          `json:"updateDate"`
+	NominationType *NominationType `json:"nominationType,omitempty"`
+	LatestAction   *LatestAction   `json:"latestAction,omitempty"`
+	URL            string          `json:"url"`
+}
+
+// NominationType says whether a nomination is for a civilian or military
+// position.
+type NominationType struct {
+	IsCivilian bool `json:"isCivilian"`
+	IsMilitary bool `json:"isMilitary"`
+}
+
+// GetNominations fetches the nominations of a congress, following
+// pagination.
+func (c *Client) GetNominations(ctx context.Context, congress int) ([]Nomination, error) {
+	path := fmt.Sprintf("/nomination/%d", congress)
+
+	nominations := make([]Nomination, 0, defaultLimit)
+	for offset := 0; ; offset += defaultLimit {
+		var page struct {
+			Nominations []Nomination `json:"nominations"`
+			Pagination  Pagination   `json:"pagination"`
+		}
+		query := neturl.Values{
+			"limit":  {strconv.Itoa(defaultLimit)},
+			"offset": {strconv.Itoa(offset)},
+		}
+		if err := c.getJSON(ctx, path, query, &page); err != nil {
+			return nil, err
+		}
+
+		nominations = append(nominations, page.Nominations...)
+		if page.Pagination.Next == "" || len(page.Nominations) == 0 {
+			break
+		}
+	}
+
+	return nominations, nil
+}
+
+// GetNomination fetches a nomination's detail.
+func (c *Client) GetNomination(ctx context.Context, congress, number int) (*Nomination, error) {
+	var resp struct {
+		Nomination Nomination `json:"nomination"`
+	}
+	if err := c.getJSON(ctx, fmt.Sprintf("/nomination/%d/%d", congress, number), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Nomination, nil
+}
+
+// GetNominationActions fetches a nomination's actions, newest first.
+func (c *Client) GetNominationActions(ctx context.Context, congress, number int) ([]Action, error) {
+	return c.getActions(ctx, fmt.Sprintf("/nomination/%d/%d/actions", congress, number))
+}
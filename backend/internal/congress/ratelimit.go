@@ -0,0 +1,121 @@
+package congress
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/logging"
+)
+
+// defaultRateLimitPause is how long all callers pause after a 429 without
+// a usable Retry-After header.
+const defaultRateLimitPause = time.Minute
+
+// WithRateLimit caps API requests at perHour, spread evenly across the hour.
+// The limit is shared by every goroutine using the client, so concurrent
+// workers don't need to coordinate among themselves. Zero disables it.
+// Text downloads, which are served outside the API, are not limited.
+func WithRateLimit(perHour int) Option {
+	return func(c *Client) {
+		if perHour > 0 {
+			c.interval = time.Hour / time.Duration(perHour)
+		}
+	}
+}
+
+// RateLimitStatus is the client's view of its Congress.gov rate limit.
+type RateLimitStatus struct {
+	Limit       int       // Requests per hour Congress.gov allows, per the last API response; 0 if unknown
+	Remaining   int       // Requests left in the hour per the last API response; -1 if unknown
+	ObservedAt  time.Time // When Limit and Remaining were reported; zero if never
+	PausedUntil time.Time // While in the future, requests are held back after a 429
+	PerHour     int       // Client-side cap set by WithRateLimit; 0 if none
+}
+
+// RateLimit returns the rate limit Congress.gov last reported and the
+// client's own limits.
+func (c *Client) RateLimit() RateLimitStatus {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	status := RateLimitStatus{Limit: c.limit, Remaining: -1, PausedUntil: c.pausedUntil}
+	if !c.observedAt.IsZero() {
+		status.Remaining, status.ObservedAt = c.remaining, c.observedAt
+	}
+	if c.interval > 0 {
+		status.PerHour = int(time.Hour / c.interval)
+	}
+	return status
+}
+
+// Requests returns the number of API requests the client has sent, so
+// callers can measure what a piece of work cost against the hourly limit.
+// Text downloads are not counted.
+func (c *Client) Requests() int64 {
+	return c.sent.Load()
+}
+
+// observeRateLimit records the rate limit headers of an API response
+// (X-RateLimit-Limit and X-RateLimit-Remaining), when it has them.
+func (c *Client) observeRateLimit(resp *http.Response) {
+	remaining, err := strconv.Atoi(resp.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	limit, _ := strconv.Atoi(resp.Header.Get("X-RateLimit-Limit"))
+
+	c.mu.Lock()
+	c.limit, c.remaining, c.observedAt = limit, remaining, time.Now()
+	c.mu.Unlock()
+}
+
+// wait blocks until the client may send its next API request: after the
+// configured interval since the previous one, and after any pause set by a
+// rate-limited response.
+func (c *Client) wait(ctx context.Context) error {
+	c.mu.Lock()
+	now := time.Now()
+	slot := now
+	if c.interval > 0 && c.next.After(slot) {
+		slot = c.next
+	}
+	if c.pausedUntil.After(slot) {
+		slot = c.pausedUntil
+	}
+	if c.interval > 0 {
+		c.next = slot.Add(c.interval)
+	}
+	c.mu.Unlock()
+
+	if !slot.After(now) {
+		return nil
+	}
+	timer := time.NewTimer(slot.Sub(now))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// pause holds back every caller after a 429, honoring Retry-After when the
+// server sends it in seconds.
+func (c *Client) pause(ctx context.Context, resp *http.Response) {
+	d := defaultRateLimitPause
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+		d = time.Duration(secs) * time.Second
+	}
+
+	c.mu.Lock()
+	until := time.Now().Add(d)
+	if until.After(c.pausedUntil) {
+		c.pausedUntil = until
+	}
+	c.mu.Unlock()
+
+	logging.FromContext(ctx).Warn("congress api rate limited, pausing requests", "pause", d.String())
+}
@@ -0,0 +1,217 @@
+package congress
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ErrNoRecording is returned by a replaying Recorder for a request its
+// cassette has no response to.
+var ErrNoRecording = errors.New("congress: no recorded response")
+
+// redacted replaces secrets in recorded responses.
+const redacted = "REDACTED"
+
+// recordedHeaders are the response headers a Recorder keeps; the rest
+// (cookies, request IDs, dates) vary between recordings.
+var recordedHeaders = []string{"Content-Type", "Retry-After", "X-RateLimit-Limit", "X-RateLimit-Remaining"}
+
+// RecorderMode selects whether a Recorder records or replays.
+type RecorderMode int
+
+const (
+	// ModeReplay answers requests from the cassette without the network.
+	ModeReplay RecorderMode = iota
+	// ModeRecord sends requests on and records their responses.
+	ModeRecord
+)
+
+// Cassette is a recording of API requests and their responses.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest identifies a recorded request. The URL has no api_key.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+}
+
+// RecordedResponse is a recorded response, with secrets redacted.
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+}
+
+// Recorder is an http.RoundTripper that records Congress.gov responses to
+// a cassette file, or replays them from one, for contract tests. Pass it
+// to the client with WithHTTPClient. Recording removes the api_key
+// parameter from URLs and replaces the given secrets wherever they appear
+// in responses, so cassettes can be committed.
+//
+// Replay matches requests by method and URL, ignoring api_key. Each
+// recorded response is replayed once, in order; when they're used up, the
+// last is repeated.
+type Recorder struct {
+	path    string
+	mode    RecorderMode
+	next    http.RoundTripper
+	secrets []string
+
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool // Per interaction, whether it has been replayed
+}
+
+// NewRecorder creates a Recorder of the cassette at path. Replaying loads
+// the cassette; recording starts an empty one, written by Save, and sends
+// requests on with http.DefaultTransport. secrets, typically the API key,
+// are redacted from recordings.
+func NewRecorder(path string, mode RecorderMode, secrets ...string) (*Recorder, error) {
+	r := &Recorder{path: path, mode: mode, next: http.DefaultTransport}
+	for _, s := range secrets {
+		if s != "" {
+			r.secrets = append(r.secrets, s)
+		}
+	}
+	if mode == ModeRecord {
+		return r, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("congress: failed to read cassette: %w", err)
+	}
+	if err := json.Unmarshal(data, &r.cassette); err != nil {
+		return nil, fmt.Errorf("congress: failed to decode cassette %s: %w", path, err)
+	}
+	r.used = make([]bool, len(r.cassette.Interactions))
+	return r, nil
+}
+
+// Interactions returns the cassette's interactions: those loaded for
+// replay, or those recorded so far.
+func (r *Recorder) Interactions() []Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Interaction(nil), r.cassette.Interactions...)
+}
+
+// RoundTrip records or replays one request.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := RecordedRequest{Method: req.Method, URL: sanitizeURL(req.URL)}
+	if r.mode == ModeReplay {
+		return r.replay(req, key)
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("congress: failed to read response to record: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	recorded := RecordedResponse{Status: resp.StatusCode, Header: http.Header{}, Body: r.redact(string(body))}
+	for _, name := range recordedHeaders {
+		if v := resp.Header.Get(name); v != "" {
+			recorded.Header.Set(name, r.redact(v))
+		}
+	}
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{Request: key, Response: recorded})
+	r.mu.Unlock()
+	return resp, nil
+}
+
+// replay answers a request from the cassette.
+func (r *Recorder) replay(req *http.Request, key RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	match := -1
+	for i, in := range r.cassette.Interactions {
+		if in.Request != key {
+			continue
+		}
+		match = i
+		if !r.used[i] {
+			break
+		}
+	}
+	if match < 0 {
+		return nil, fmt.Errorf("%w for %s %s", ErrNoRecording, key.Method, key.URL)
+	}
+	r.used[match] = true
+
+	recorded := r.cassette.Interactions[match].Response
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.Status, http.StatusText(recorded.Status)),
+		StatusCode:    recorded.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Save writes the recorded cassette to its path, creating its directory.
+// It does nothing when replaying.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("congress: failed to encode cassette: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("congress: failed to create cassette directory: %w", err)
+	}
+	if err := os.WriteFile(r.path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("congress: failed to write cassette: %w", err)
+	}
+	return nil
+}
+
+// redact replaces the recorder's secrets in s.
+func (r *Recorder) redact(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return s
+}
+
+// sanitizeURL returns u without its api_key parameter, with the rest of
+// the query in a canonical order.
+func sanitizeURL(u *neturl.URL) string {
+	clean := *u
+	query := clean.Query()
+	query.Del("api_key")
+	clean.RawQuery = query.Encode()
+	return clean.String()
+}
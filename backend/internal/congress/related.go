@@ -0,0 +1,83 @@
+package congress
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+	"strings"
+)
+
+// RelatedBill is a bill Congress.gov links to another bill, such as an
+// identical bill introduced in the other chamber.
+type RelatedBill struct {
+	Congress            int                  `json:"congress"`
+	Type                string               `json:"type"`
+	Number              int                  `json:"number"`
+	Title               string               `json:"title"`
+	URL                 string               `json:"url"`
+	LatestAction        *LatestAction        `json:"latestAction,omitempty"`
+	RelationshipDetails []RelationshipDetail `json:"relationshipDetails"`
+}
+
+// RelationshipDetail describes one way two bills are related and who
+// identified the relationship (e.g., "House", "Senate", "CRS").
+type RelationshipDetail struct {
+	Type         string `json:"type"` // e.g., "Identical bill", "Related bill", "Procedurally-related"
+	IdentifiedBy string `json:"identifiedBy"`
+}
+
+// GetRelatedBills fetches the bills related to a bill, following pagination.
+func (c *Client) GetRelatedBills(ctx context.Context, congress int, billType string, billNumber string) ([]RelatedBill, error) {
+	path := fmt.Sprintf("/bill/%d/%s/%s/relatedbills", congress, strings.ToLower(billType), neturl.PathEscape(billNumber))
+
+	related := make([]RelatedBill, 0, 8)
+	for offset := 0; ; offset += defaultLimit {
+		var page struct {
+			RelatedBills []RelatedBill `json:"relatedBills"`
+			Pagination   Pagination    `json:"pagination"`
+		}
+		query := neturl.Values{
+			"limit":  {strconv.Itoa(defaultLimit)},
+			"offset": {strconv.Itoa(offset)},
+		}
+		if err := c.getJSON(ctx, path, query, &page); err != nil {
+			return nil, err
+		}
+
+		related = append(related, page.RelatedBills...)
+		if page.Pagination.Next == "" || len(page.RelatedBills) == 0 {
+			break
+		}
+	}
+
+	return related, nil
+}
+
+// BillTypeChamber returns "House" or "Senate" for a bill type such as "hr"
+// or "sjres", or "" when the type is unrecognized.
+func BillTypeChamber(billType string) string {
+	switch t := strings.ToLower(billType); {
+	case strings.HasPrefix(t, "h"):
+		return "House"
+	case strings.HasPrefix(t, "s"):
+		return "Senate"
+	}
+	return ""
+}
+
+// IsCompanionOf reports whether r is the companion of a bill of the given
+// type: a bill in the other chamber that either chamber identified as
+// identical.
+func (r RelatedBill) IsCompanionOf(billType string) bool {
+	chamber, other := BillTypeChamber(billType), BillTypeChamber(r.Type)
+	if chamber == "" || other == "" || chamber == other {
+		return false
+	}
+	for _, d := range r.RelationshipDetails {
+		if strings.EqualFold(d.Type, "Identical bill") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package congress
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+	"strings"
+)
+
+// Sponsor represents a member of Congress who sponsored a bill.
+// Returned in the "sponsors" array of the bill detail response.
+type Sponsor struct {
+	BioguideID  string `json:"bioguideId"`
+	FullName    string `json:"fullName"`
+	FirstName   string `json:"firstName,omitempty"`
+	LastName    string `json:"lastName,omitempty"`
+	Party       string `json:"party,omitempty"`
+	State       string `json:"state,omitempty"`
+	District    int    `json:"district,omitempty"`
+	MiddleName  string `json:"middleName,omitempty"`
+	URL         string `json:"url,omitempty"`         // Member endpoint
+	IsByRequest string `json:"isByRequest,omitempty"` // "Y" when introduced on behalf of another, e.g., the President; sponsors only
+}
+
+// Cosponsor represents a member of Congress who cosponsored a bill.
+type Cosponsor struct {
+	Sponsor
+	SponsorshipDate     string `json:"sponsorshipDate,omitempty"`
+	IsOriginalCosponsor bool   `json:"isOriginalCosponsor,omitempty"`
+}
+
+// GetBillCosponsors fetches all cosponsors of a bill, following pagination.
+func (c *Client) GetBillCosponsors(ctx context.Context, congress int, billType string, billNumber string) ([]Cosponsor, error) {
+	path := fmt.Sprintf("/bill/%d/%s/%s/cosponsors", congress, strings.ToLower(billType), neturl.PathEscape(billNumber))
+
+	cosponsors := make([]Cosponsor, 0, 16)
+	for offset := 0; ; offset += defaultLimit {
+		var page struct {
+			Cosponsors []Cosponsor `json:"cosponsors"`
+			Pagination Pagination  `json:"pagination"`
+		}
+		query := neturl.Values{
+			"limit":  {strconv.Itoa(defaultLimit)},
+			"offset": {strconv.Itoa(offset)},
+		}
+		if err := c.getJSON(ctx, path, query, &page); err != nil {
+			return nil, err
+		}
+
+		cosponsors = append(cosponsors, page.Cosponsors...)
+		if page.Pagination.Next == "" || len(page.Cosponsors) == 0 {
+			break
+		}
+	}
+
+	return cosponsors, nil
+}
@@ -0,0 +1,133 @@
+package congress
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/metrics"
+)
+
+// Kinds of FieldDrift.
+const (
+	DriftUnknown = "unknown" // In responses, but not decoded
+	DriftMissing = "missing" // Decoded, but not in responses
+)
+
+// IgnoredFields are response keys, as drift paths (see Drift), that the
+// client knowingly doesn't decode. Strict mode and the contract tests
+// don't report them. "request", Congress.gov's echo of the request, is
+// in every response.
+var IgnoredFields = map[string]bool{
+	"request":                         true,
+	"bill.latestAction.actionTime":    true,
+	"bills[].latestAction.actionTime": true,
+	"pagination.prev":                 true,
+}
+
+// FieldDrift is a response field strict mode found unknown to or missing
+// for the client.
+type FieldDrift struct {
+	Endpoint  string    `json:"endpoint"` // e.g., "/bill/:n/hr/:n"
+	Kind      string    `json:"kind"`     // DriftUnknown or DriftMissing
+	Field     string    `json:"field"`    // Drift path, e.g., "bill.sponsors[].party"
+	Count     int64     `json:"count"`    // Responses it drifted in
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+}
+
+// WithStrictDecoding makes the client compare each API response with the
+// type it decodes into, so operators learn when Congress.gov adds data
+// worth ingesting or drops or renames a field the client reads. Drifted
+// fields not in IgnoredFields are counted in
+// deltagov_congress_schema_drift_total, logged the first time each is
+// seen, and listed by DriftReport. Responses are still decoded leniently.
+// Strict mode reads each response whole rather than streaming it.
+func WithStrictDecoding() Option {
+	return func(c *Client) {
+		c.strict = true
+	}
+}
+
+// DriftReport returns the fields strict mode has found drifted, by
+// endpoint, kind, and field. It is empty unless WithStrictDecoding is set.
+func (c *Client) DriftReport() []FieldDrift {
+	c.driftMu.Lock()
+	defer c.driftMu.Unlock()
+	report := make([]FieldDrift, 0, len(c.drift))
+	for _, d := range c.drift {
+		report = append(report, *d)
+	}
+	slices.SortFunc(report, func(a, b FieldDrift) int {
+		return strings.Compare(a.Endpoint+" "+a.Kind+" "+a.Field, b.Endpoint+" "+b.Kind+" "+b.Field)
+	})
+	return report
+}
+
+// responseBody returns the reader to decode an API response into target
+// from: its body, or in strict mode the body read whole, after comparing
+// it with target.
+func (c *Client) responseBody(resp *http.Response, target any) (io.Reader, error) {
+	if !c.strict {
+		return resp.Body, nil
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("congress: failed to read response: %w", err)
+	}
+
+	endpoint := "unknown"
+	if resp.Request != nil {
+		endpoint = metrics.EndpointLabel(strings.TrimPrefix(resp.Request.URL.Path, "/v3"))
+	}
+	drift, err := SchemaDrift(data, target)
+	if err != nil {
+		// Left for the decoder to report
+		return bytes.NewReader(data), nil
+	}
+	for _, field := range drift.Unknown {
+		c.recordDrift(resp, endpoint, DriftUnknown, field)
+	}
+	for _, field := range drift.Missing {
+		c.recordDrift(resp, endpoint, DriftMissing, field)
+	}
+	return bytes.NewReader(data), nil
+}
+
+// recordDrift counts a drifted field, logging it the first time.
+func (c *Client) recordDrift(resp *http.Response, endpoint, kind, field string) {
+	if IgnoredFields[field] {
+		return
+	}
+	metrics.CongressSchemaDrift.WithLabelValues(endpoint, kind, field).Inc()
+
+	now := time.Now()
+	key := endpoint + " " + kind + " " + field
+	c.driftMu.Lock()
+	d, seen := c.drift[key]
+	if !seen {
+		if c.drift == nil {
+			c.drift = map[string]*FieldDrift{}
+		}
+		d = &FieldDrift{Endpoint: endpoint, Kind: kind, Field: field, FirstSeen: now}
+		c.drift[key] = d
+	}
+	d.Count++
+	d.LastSeen = now
+	c.driftMu.Unlock()
+
+	if !seen {
+		ctx := context.Background()
+		if resp.Request != nil {
+			ctx = resp.Request.Context()
+		}
+		logging.FromContext(ctx).Warn("congress api schema drift",
+			"endpoint", endpoint, "kind", kind, "field", field)
+	}
+}
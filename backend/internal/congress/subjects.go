@@ -0,0 +1,88 @@
+package congress
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+	"strings"
+)
+
+// PolicyArea is the single CRS policy area term assigned to a bill,
+// e.g., "Economics and Public Finance".
+type PolicyArea struct {
+	Name string `json:"name"`
+}
+
+// LegislativeSubject is a CRS legislative subject term assigned to a bill.
+type LegislativeSubject struct {
+	Name       string `json:"name"`
+	UpdateDate string `json:"updateDate,omitempty"`
+}
+
+// BillSubjects contains a bill's policy area and legislative subject terms.
+// PolicyArea is nil until CRS assigns one, typically weeks after introduction.
+type BillSubjects struct {
+	PolicyArea          *PolicyArea          `json:"policyArea,omitempty"`
+	LegislativeSubjects []LegislativeSubject `json:"legislativeSubjects"`
+}
+
+// GetBillSubjects fetches a bill's policy area and all of its legislative
+// subjects, following pagination.
+func (c *Client) GetBillSubjects(ctx context.Context, congress int, billType string, billNumber string) (*BillSubjects, error) {
+	path := fmt.Sprintf("/bill/%d/%s/%s/subjects", congress, strings.ToLower(billType), neturl.PathEscape(billNumber))
+
+	subjects := &BillSubjects{LegislativeSubjects: make([]LegislativeSubject, 0, 16)}
+	for offset := 0; ; offset += defaultLimit {
+		var page struct {
+			Subjects   BillSubjects `json:"subjects"`
+			Pagination Pagination   `json:"pagination"`
+		}
+		query := neturl.Values{
+			"limit":  {strconv.Itoa(defaultLimit)},
+			"offset": {strconv.Itoa(offset)},
+		}
+		if err := c.getJSON(ctx, path, query, &page); err != nil {
+			return nil, err
+		}
+
+		if page.Subjects.PolicyArea != nil {
+			subjects.PolicyArea = page.Subjects.PolicyArea
+		}
+		subjects.LegislativeSubjects = append(subjects.LegislativeSubjects, page.Subjects.LegislativeSubjects...)
+		if page.Pagination.Next == "" || len(page.Subjects.LegislativeSubjects) == 0 {
+			break
+		}
+	}
+
+	return subjects, nil
+}
+
+// Names returns the legislative subject names.
+func (s *BillSubjects) Names() []string {
+	names := make([]string, 0, len(s.LegislativeSubjects))
+	for _, subject := range s.LegislativeSubjects {
+		if subject.Name != "" {
+			names = append(names, subject.Name)
+		}
+	}
+	return names
+}
+
+// IsSpendingBill classifies a bill as an appropriations/spending bill from
+// its CRS legislative subjects: any "Appropriations" term (including
+// continuing and supplemental appropriations) or "Budget process". CRS
+// indexes bills some time after introduction, so a bill without subjects
+// falls back to the IsAppropriation title heuristic.
+func IsSpendingBill(title string, subjects []string) bool {
+	if len(subjects) == 0 {
+		return IsAppropriation(title)
+	}
+	for _, subject := range subjects {
+		lower := strings.ToLower(subject)
+		if strings.Contains(lower, "appropriations") || lower == "budget process" {
+			return true
+		}
+	}
+	return false
+}
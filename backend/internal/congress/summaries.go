@@ -0,0 +1,46 @@
+package congress
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+	"strings"
+)
+
+// BillSummary is a CRS (Congressional Research Service) summary of a bill
+// as of one legislative action. Text is HTML.
+type BillSummary struct {
+	ActionDate  string `json:"actionDate"` // e.g., "2025-01-03"
+	ActionDesc  string `json:"actionDesc"` // e.g., "Introduced in House"
+	Text        string `json:"text"`
+	UpdateDate  string `json:"updateDate"`
+	VersionCode string `json:"versionCode"` // CRS summary code, e.g., "00" (introduced), "49" (public law)
+}
+
+// GetBillSummaries fetches all CRS summaries of a bill, following pagination.
+func (c *Client) GetBillSummaries(ctx context.Context, congress int, billType string, billNumber string) ([]BillSummary, error) {
+	path := fmt.Sprintf("/bill/%d/%s/%s/summaries", congress, strings.ToLower(billType), neturl.PathEscape(billNumber))
+
+	summaries := make([]BillSummary, 0, 4)
+	for offset := 0; ; offset += defaultLimit {
+		var page struct {
+			Summaries  []BillSummary `json:"summaries"`
+			Pagination Pagination    `json:"pagination"`
+		}
+		query := neturl.Values{
+			"limit":  {strconv.Itoa(defaultLimit)},
+			"offset": {strconv.Itoa(offset)},
+		}
+		if err := c.getJSON(ctx, path, query, &page); err != nil {
+			return nil, err
+		}
+
+		summaries = append(summaries, page.Summaries...)
+		if page.Pagination.Next == "" || len(page.Summaries) == 0 {
+			break
+		}
+	}
+
+	return summaries, nil
+}
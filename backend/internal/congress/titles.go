@@ -0,0 +1,65 @@
+package congress
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+	"strings"
+)
+
+// BillTitle is one of a bill's titles: official, short, or popular, as of a
+// text version or chamber action.
+type BillTitle struct {
+	Title               string `json:"title"`
+	TitleType           string `json:"titleType"`     // e.g., "Short Title(s) as Introduced", "Popular Title"
+	TitleTypeCode       int    `json:"titleTypeCode"` // e.g., 104
+	UpdateDate          string `json:"updateDate"`
+	BillTextVersionCode string `json:"billTextVersionCode,omitempty"`
+	ChamberName         string `json:"chamberName,omitempty"`
+}
+
+// IsOfficialTitle reports whether the title is an official title, the
+// long "To provide for..." form, as of introduction or an amendment.
+func (t BillTitle) IsOfficialTitle() bool {
+	return strings.Contains(strings.ToLower(t.TitleType), "official title")
+}
+
+// IsShortTitle reports whether the title is a short title, e.g., "One Big
+// Beautiful Bill Act", as opposed to an official title.
+func (t BillTitle) IsShortTitle() bool {
+	return strings.Contains(strings.ToLower(t.TitleType), "short title")
+}
+
+// IsPopularTitle reports whether the title is a popular name CRS assigned,
+// such as "NDAA", rather than one written in the bill.
+func (t BillTitle) IsPopularTitle() bool {
+	return strings.Contains(strings.ToLower(t.TitleType), "popular title")
+}
+
+// GetBillTitles fetches all of a bill's titles, following pagination.
+func (c *Client) GetBillTitles(ctx context.Context, congress int, billType string, billNumber string) ([]BillTitle, error) {
+	path := fmt.Sprintf("/bill/%d/%s/%s/titles", congress, strings.ToLower(billType), neturl.PathEscape(billNumber))
+
+	titles := make([]BillTitle, 0, 8)
+	for offset := 0; ; offset += defaultLimit {
+		var page struct {
+			Titles     []BillTitle `json:"titles"`
+			Pagination Pagination  `json:"pagination"`
+		}
+		query := neturl.Values{
+			"limit":  {strconv.Itoa(defaultLimit)},
+			"offset": {strconv.Itoa(offset)},
+		}
+		if err := c.getJSON(ctx, path, query, &page); err != nil {
+			return nil, err
+		}
+
+		titles = append(titles, page.Titles...)
+		if page.Pagination.Next == "" || len(page.Titles) == 0 {
+			break
+		}
+	}
+
+	return titles, nil
+}
@@ -0,0 +1,95 @@
+package congress
+
+import (
+	"context"
+	"fmt"
+	neturl "net/url"
+	"strconv"
+	"strings"
+)
+
+// Treaty is a treaty the President transmitted to the Senate, numbered by
+// the Congress that received it (e.g., Treaty Document 118-1). A treaty
+// transmitted in parts has one Treaty per part, told apart by Suffix.
+type Treaty struct {
+	CongressReceived   int           `json:"congressReceived"`
+	CongressConsidered int           `json:"congressConsidered"`
+	Number             int           `json:"number"`
+	Suffix             string        `json:"suffix"` // e.g., "A"; "" for an undivided treaty
+	Topic              string        `json:"topic"`
+	TransmittedDate    string        `json:"transmittedDate"`
+	InForceDate        string        `json:"inForceDate,omitempty"`
+	UpdateDate         string        `json:"updateDate"`
+	Titles             []TreatyTitle `json:"titles,omitempty"` // Only present on detail responses
+	URL                string        `json:"url"`
+}
+
+// TreatyTitle is a title of a treaty.
+type TreatyTitle struct {
+	Title     string `json:"title"`
+	TitleType string `json:"titleType"` // "Formal Title" or "Short Title"
+}
+
+// FormalTitle returns the treaty's formal title, or its topic when
+// Congress.gov has none.
+func (t *Treaty) FormalTitle() string {
+	for _, title := range t.Titles {
+		if title.TitleType == "Formal Title" {
+			return title.Title
+		}
+	}
+	return t.Topic
+}
+
+// GetTreaties fetches the treaties received by a congress, following
+// pagination.
+func (c *Client) GetTreaties(ctx context.Context, congress int) ([]Treaty, error) {
+	path := fmt.Sprintf("/treaty/%d", congress)
+
+	treaties := make([]Treaty, 0, 16)
+	for offset := 0; ; offset += defaultLimit {
+		var page struct {
+			Treaties   []Treaty   `json:"treaties"`
+			Pagination Pagination `json:"pagination"`
+		}
+		query := neturl.Values{
+			"limit":  {strconv.Itoa(defaultLimit)},
+			"offset": {strconv.Itoa(offset)},
+		}
+		if err := c.getJSON(ctx, path, query, &page); err != nil {
+			return nil, err
+		}
+
+		treaties = append(treaties, page.Treaties...)
+		if page.Pagination.Next == "" || len(page.Treaties) == 0 {
+			break
+		}
+	}
+
+	return treaties, nil
+}
+
+// GetTreaty fetches a treaty's detail, including its titles.
+func (c *Client) GetTreaty(ctx context.Context, congress, number int, suffix string) (*Treaty, error) {
+	var resp struct {
+		Treaty Treaty `json:"treaty"`
+	}
+	if err := c.getJSON(ctx, treatyPath(congress, number, suffix), nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.Treaty, nil
+}
+
+// GetTreatyActions fetches a treaty's actions, newest first.
+func (c *Client) GetTreatyActions(ctx context.Context, congress, number int, suffix string) ([]Action, error) {
+	return c.getActions(ctx, treatyPath(congress, number, suffix)+"/actions")
+}
+
+// treatyPath returns the API path of a treaty, or of one part of it.
+func treatyPath(congress, number int, suffix string) string {
+	path := fmt.Sprintf("/treaty/%d/%d", congress, number)
+	if suffix != "" {
+		path += "/" + strings.ToUpper(suffix)
+	}
+	return path
+}
@@ -0,0 +1,43 @@
+package congresstest
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/drewjst/deltagov/internal/database"
+)
+
+// OpenDB opens a fresh SQLite database with every model's table, removed
+// when the test ends. It skips the test where SQLite is unavailable, as in
+// builds without cgo.
+//
+// The database is a file in WAL mode rather than in memory, so the
+// ingestor's concurrent workers each get a connection; a writer waits for
+// another rather than failing. PostgreSQL-only features (advisory locks,
+// full-text search, JSONB operators) aren't available, so code using them
+// still needs a PostgreSQL integration test.
+func OpenDB(t testing.TB) *gorm.DB {
+	t.Helper()
+	dsn := "file:" + filepath.Join(t.TempDir(), "deltagov.db") +
+		"?_journal_mode=WAL&_busy_timeout=10000&_txlock=immediate&_foreign_keys=on"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Skipf("congresstest: SQLite unavailable: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("congresstest: failed to get underlying DB: %v", err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.AutoMigrate(database.Models()...); err != nil {
+		t.Fatalf("congresstest: failed to migrate: %v", err)
+	}
+	return db
+}
@@ -0,0 +1,447 @@
+// Package congresstest provides test fixtures for code that talks to
+// Congress.gov: a fake API server serving canned bills, their text
+// versions and content, treaties, and nominations, and a SQLite database
+// to ingest them into, so client, ingestor, and service tests run without
+// the network or PostgreSQL.
+package congresstest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/congress"
+)
+
+const (
+	// APIKey is the API key Client configures; the server accepts any.
+	APIKey = "test-key"
+
+	// RateLimit is the hourly limit the server reports in its
+	// X-RateLimit-Limit header.
+	RateLimit = 5000
+
+	// defaultPageSize is the page size of bill lists requested without a
+	// limit, as on Congress.gov.
+	defaultPageSize = 20
+)
+
+// Text is a canned text version of a bill.
+type Text struct {
+	Type    string // Version type as Congress.gov names it, e.g., "Introduced in House"
+	Date    string // RFC 3339 date, e.g., "2025-01-03T05:00:00Z"
+	Content string // Served as the version's formatted text
+}
+
+// Server is a fake Congress.gov API. It serves the bill lists
+// (/bill, /bill/{congress}, and /bill/{congress}/{type}) with offset and
+// limit pagination, newest update first; bill details; text version lists;
+// and the text content they link to. Other endpoints of a known bill
+// (actions, cosponsors, subjects, and so on) return an empty object, which
+// the client reads as no data. Unknown bills are 404s. Treaties and
+// nominations are served likewise: their lists, details, and actions.
+//
+// A Server is safe for concurrent use; bills may be added while it serves.
+type Server struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	bills       []congress.BillDetail
+	texts       map[string][]Text // By billKey
+	treaties    []congress.Treaty
+	nominations []congress.Nomination
+	actions     map[string][]congress.Action // By treatyKey or nominationKey, newest first
+	rateLimited int                          // API requests left to answer with 429
+	textLimited int                          // Text downloads left to answer with 429
+	apiRequests int                          // API requests answered, for X-RateLimit-Remaining
+	requests    []string                     // Paths requested, in order
+}
+
+// NewServer starts a Server with no bills, closed when the test ends.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+	s := &Server{texts: map[string][]Text{}, actions: map[string][]congress.Action{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// BaseURL returns the API base URL, for congress.WithBaseURL.
+func (s *Server) BaseURL() string {
+	return s.URL + "/v3"
+}
+
+// Client returns a Congress.gov client of the server.
+func (s *Server) Client(t testing.TB) *congress.Client {
+	t.Helper()
+	c, err := congress.NewClient(
+		congress.WithAPIKey(APIKey),
+		congress.WithBaseURL(s.BaseURL()),
+		congress.WithHTTPClient(s.Server.Client()),
+	)
+	if err != nil {
+		t.Fatalf("congresstest: failed to create client: %v", err)
+	}
+	return c
+}
+
+// AddBill adds a bill with only its list fields; see AddBillDetail.
+func (s *Server) AddBill(bill congress.Bill, texts ...Text) {
+	s.AddBillDetail(congress.BillDetail{Bill: bill}, texts...)
+}
+
+// AddBillDetail adds a bill, replacing any with its congress, type, and
+// number, and sets its text versions, oldest first. The bill is served as
+// given by its detail endpoint; lists serve its Bill without laws. Its
+// URL is set to its detail endpoint when empty.
+func (s *Server) AddBillDetail(bill congress.BillDetail, texts ...Text) {
+	if bill.URL == "" {
+		bill.URL = fmt.Sprintf("%s/bill/%d/%s/%s?format=json",
+			s.BaseURL(), bill.Congress, strings.ToLower(bill.Type), bill.Number)
+	}
+	key := billKey(bill.Congress, bill.Type, bill.Number)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bills = slices.DeleteFunc(s.bills, func(b congress.BillDetail) bool {
+		return billKey(b.Congress, b.Type, b.Number) == key
+	})
+	s.bills = append(s.bills, bill)
+	s.texts[key] = texts
+}
+
+// AddTreaty adds a treaty, replacing any with its congress, number, and
+// suffix, and sets its actions, newest first as Congress.gov lists them.
+// The treaty is served as given by its detail endpoint; lists serve it
+// without titles.
+func (s *Server) AddTreaty(treaty congress.Treaty, actions ...congress.Action) {
+	key := treatyKey(treaty.CongressReceived, strconv.Itoa(treaty.Number), treaty.Suffix)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.treaties = slices.DeleteFunc(s.treaties, func(t congress.Treaty) bool {
+		return treatyKey(t.CongressReceived, strconv.Itoa(t.Number), t.Suffix) == key
+	})
+	s.treaties = append(s.treaties, treaty)
+	s.actions[key] = actions
+}
+
+// AddNomination adds a nomination, replacing any with its congress and
+// citation, and sets its actions, newest first. Its parts share their
+// number, so the actions of a nomination's number are those last set for
+// any of its parts.
+func (s *Server) AddNomination(nomination congress.Nomination, actions ...congress.Action) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nominations = slices.DeleteFunc(s.nominations, func(n congress.Nomination) bool {
+		return n.Congress == nomination.Congress && n.Citation == nomination.Citation
+	})
+	s.nominations = append(s.nominations, nomination)
+	s.actions[nominationKey(nomination.Congress, strconv.Itoa(nomination.Number))] = actions
+}
+
+// RateLimitNext answers the next n API requests with 429 Too Many Requests
+// and a Retry-After of one second. Text downloads aren't rate limited; see
+// RateLimitTextNext.
+func (s *Server) RateLimitNext(n int) {
+	s.mu.Lock()
+	s.rateLimited = n
+	s.mu.Unlock()
+}
+
+// RateLimitTextNext answers the next n text downloads with 429 Too Many
+// Requests and a Retry-After of one second.
+func (s *Server) RateLimitTextNext(n int) {
+	s.mu.Lock()
+	s.textLimited = n
+	s.mu.Unlock()
+}
+
+// Requests returns the paths requested so far, in order, without the /v3
+// prefix of API paths.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return slices.Clone(s.requests)
+}
+
+// serveHTTP routes a request to the API or text handlers.
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path, isAPI := strings.CutPrefix(r.URL.Path, "/v3")
+	s.requests = append(s.requests, path)
+	if !isAPI {
+		s.serveText(w, r)
+		return
+	}
+
+	if s.rateLimited > 0 {
+		s.rateLimited--
+		w.Header().Set("Retry-After", "1")
+		w.Header().Set("X-RateLimit-Limit", strconv.Itoa(RateLimit))
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		http.Error(w, `{"error":{"code":"OVER_RATE_LIMIT"}}`, http.StatusTooManyRequests)
+		return
+	}
+	s.apiRequests++
+	w.Header().Set("X-RateLimit-Limit", strconv.Itoa(RateLimit))
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(RateLimit-s.apiRequests))
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch parts[0] {
+	case "bill":
+	case "treaty":
+		s.serveTreaty(w, r, parts[1:])
+		return
+	case "nomination":
+		s.serveNomination(w, r, parts[1:])
+		return
+	default:
+		http.NotFound(w, r)
+		return
+	}
+	switch len(parts) {
+	case 1, 2, 3:
+		s.serveList(w, r, parts[1:])
+	case 4:
+		bill, ok := s.findBill(parts[1], parts[2], parts[3])
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		writeJSON(w, map[string]any{"bill": bill})
+	case 5:
+		bill, ok := s.findBill(parts[1], parts[2], parts[3])
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if parts[4] != "text" {
+			writeJSON(w, map[string]any{})
+			return
+		}
+		s.serveTextVersions(w, bill.Bill)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// serveList serves a page of the bills matching an optional congress and
+// type, newest update first.
+func (s *Server) serveList(w http.ResponseWriter, r *http.Request, filter []string) {
+	var matched []congress.Bill
+	for _, b := range s.bills {
+		if len(filter) > 0 && filter[0] != strconv.Itoa(b.Congress) {
+			continue
+		}
+		if len(filter) > 1 && !strings.EqualFold(filter[1], b.Type) {
+			continue
+		}
+		// Lists carry only summary fields
+		bill := b.Bill
+		bill.Laws = nil
+		matched = append(matched, bill)
+	}
+	slices.SortStableFunc(matched, func(a, b congress.Bill) int {
+		return strings.Compare(b.UpdateDate, a.UpdateDate)
+	})
+
+	query := r.URL.Query()
+	offset, _ := strconv.Atoi(query.Get("offset"))
+	limit, err := strconv.Atoi(query.Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultPageSize
+	}
+	start := min(max(offset, 0), len(matched))
+	end := min(start+limit, len(matched))
+
+	pagination := congress.Pagination{Count: len(matched)}
+	if end < len(matched) {
+		next := *r.URL
+		query.Set("offset", strconv.Itoa(end))
+		next.RawQuery = query.Encode()
+		pagination.Next = s.URL + next.RequestURI()
+	}
+	page := matched[start:end]
+	if page == nil {
+		page = []congress.Bill{}
+	}
+	writeJSON(w, congress.BillsResponse{Bills: page, Pagination: pagination})
+}
+
+// serveTreaty serves the treaty list of a congress, a treaty's detail, or
+// its actions: /treaty/{congress}[/{number}[/{suffix}][/actions]].
+func (s *Server) serveTreaty(w http.ResponseWriter, r *http.Request, parts []string) {
+	congressNum, err := strconv.Atoi(parts[0])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if len(parts) == 1 {
+		treaties := []congress.Treaty{}
+		for _, t := range s.treaties {
+			if t.CongressReceived == congressNum {
+				t.Titles = nil
+				treaties = append(treaties, t)
+			}
+		}
+		writeJSON(w, map[string]any{"treaties": treaties, "pagination": congress.Pagination{Count: len(treaties)}})
+		return
+	}
+
+	actions := parts[len(parts)-1] == "actions"
+	if actions {
+		parts = parts[:len(parts)-1]
+	}
+	if len(parts) > 3 {
+		http.NotFound(w, r)
+		return
+	}
+	suffix := ""
+	if len(parts) == 3 {
+		suffix = parts[2]
+	}
+	key := treatyKey(congressNum, parts[1], suffix)
+	for _, t := range s.treaties {
+		if treatyKey(t.CongressReceived, strconv.Itoa(t.Number), t.Suffix) != key {
+			continue
+		}
+		if actions {
+			writeJSON(w, map[string]any{"actions": s.actions[key]})
+		} else {
+			writeJSON(w, map[string]any{"treaty": t})
+		}
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// serveNomination serves the nomination list of a congress, a
+// nomination's detail, or its actions:
+// /nomination/{congress}[/{number}[/actions]].
+func (s *Server) serveNomination(w http.ResponseWriter, r *http.Request, parts []string) {
+	congressNum, err := strconv.Atoi(parts[0])
+	if err != nil || len(parts) > 3 || len(parts) == 3 && parts[2] != "actions" {
+		http.NotFound(w, r)
+		return
+	}
+	if len(parts) == 1 {
+		nominations := []congress.Nomination{}
+		for _, n := range s.nominations {
+			if n.Congress == congressNum {
+				nominations = append(nominations, n)
+			}
+		}
+		writeJSON(w, map[string]any{"nominations": nominations, "pagination": congress.Pagination{Count: len(nominations)}})
+		return
+	}
+
+	key := nominationKey(congressNum, parts[1])
+	for _, n := range s.nominations {
+		if nominationKey(n.Congress, strconv.Itoa(n.Number)) != key {
+			continue
+		}
+		if len(parts) == 3 {
+			writeJSON(w, map[string]any{"actions": s.actions[key]})
+		} else {
+			writeJSON(w, map[string]any{"nomination": n})
+		}
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// serveTextVersions serves a bill's text version list, newest first as
+// Congress.gov lists them, each linking to its content.
+func (s *Server) serveTextVersions(w http.ResponseWriter, bill congress.Bill) {
+	texts := s.texts[billKey(bill.Congress, bill.Type, bill.Number)]
+	versions := make([]congress.TextVersion, 0, len(texts))
+	for i := len(texts) - 1; i >= 0; i-- {
+		versions = append(versions, congress.TextVersion{
+			Date: texts[i].Date,
+			Type: texts[i].Type,
+			Formats: []congress.TextFormat{{
+				Type: "Formatted Text",
+				URL:  s.textURL(bill, i),
+			}},
+		})
+	}
+	writeJSON(w, map[string]any{"textVersions": versions})
+}
+
+// serveText serves a text version's content at the path textURL gives it.
+func (s *Server) serveText(w http.ResponseWriter, r *http.Request) {
+	// /text/{congress}/{type}/{number}/{index}.htm
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) != 5 || parts[0] != "text" {
+		http.NotFound(w, r)
+		return
+	}
+	if s.textLimited > 0 {
+		s.textLimited--
+		w.Header().Set("Retry-After", "1")
+		http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+		return
+	}
+	congressNum, _ := strconv.Atoi(parts[1])
+	texts := s.texts[billKey(congressNum, parts[2], parts[3])]
+	i, err := strconv.Atoi(strings.TrimSuffix(parts[4], ".htm"))
+	if err != nil || i < 0 || i >= len(texts) {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprint(w, texts[i].Content)
+}
+
+// textURL returns the URL of a bill's i'th text version's content.
+func (s *Server) textURL(bill congress.Bill, i int) string {
+	return fmt.Sprintf("%s/text/%d/%s/%s/%d.htm",
+		s.URL, bill.Congress, strings.ToLower(bill.Type), url.PathEscape(bill.Number), i)
+}
+
+// findBill returns the bill with a congress, type, and number from a path.
+func (s *Server) findBill(congressNum, billType, number string) (congress.BillDetail, bool) {
+	n, err := strconv.Atoi(congressNum)
+	if err != nil {
+		return congress.BillDetail{}, false
+	}
+	key := billKey(n, billType, number)
+	for _, b := range s.bills {
+		if billKey(b.Congress, b.Type, b.Number) == key {
+			return b, true
+		}
+	}
+	return congress.BillDetail{}, false
+}
+
+// billKey identifies a bill regardless of the case of its type.
+func billKey(congressNum int, billType, number string) string {
+	return fmt.Sprintf("%d/%s/%s", congressNum, strings.ToLower(billType), number)
+}
+
+// treatyKey identifies a treaty, or a part of one, regardless of the case
+// of its suffix.
+func treatyKey(congressNum int, number, suffix string) string {
+	return fmt.Sprintf("treaty/%d/%s/%s", congressNum, number, strings.ToUpper(suffix))
+}
+
+// nominationKey identifies a nomination with all its parts.
+func nominationKey(congressNum int, number string) string {
+	return fmt.Sprintf("nomination/%d/%s", congressNum, number)
+}
+
+// writeJSON writes v as a JSON response.
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -8,7 +9,13 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
 
+	"github.com/drewjst/deltagov/internal/metrics"
 	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/stats"
+	"github.com/drewjst/deltagov/internal/textextract"
+	"github.com/drewjst/deltagov/internal/textnorm"
+	"github.com/drewjst/deltagov/internal/textstats"
+	"github.com/drewjst/deltagov/internal/versioncode"
 )
 
 // Config holds database connection configuration.
@@ -16,6 +23,11 @@ type Config struct {
 	// URL is the PostgreSQL connection string
 	URL string
 
+	// ReplicaURLs are connection strings of read replicas of URL. Queries
+	// marked with ReadReplica are spread across them; everything else,
+	// and everything when empty, goes to URL.
+	ReplicaURLs []string
+
 	// MaxOpenConns sets the maximum number of open connections
 	MaxOpenConns int
 
@@ -25,6 +37,16 @@ type Config struct {
 	// ConnMaxLifetime sets the maximum lifetime of a connection
 	ConnMaxLifetime time.Duration
 
+	// ConnMaxIdleTime closes connections idle this long, so connections
+	// left over from before a database restart are retired rather than
+	// failing the next statement that picks them up
+	ConnMaxIdleTime time.Duration
+
+	// SlowQueryThreshold is how long a statement may take before it is
+	// logged as slow; 0 disables. It can be changed while running with
+	// SetSlowQueryThreshold.
+	SlowQueryThreshold time.Duration
+
 	// LogLevel sets the GORM logger level
 	LogLevel logger.LogLevel
 }
@@ -32,11 +54,13 @@ type Config struct {
 // DefaultConfig returns a Config with sensible defaults.
 func DefaultConfig(url string) *Config {
 	return &Config{
-		URL:             url,
-		MaxOpenConns:    25,
-		MaxIdleConns:    5,
-		ConnMaxLifetime: 5 * time.Minute,
-		LogLevel:        logger.Warn,
+		URL:                url,
+		MaxOpenConns:       25,
+		MaxIdleConns:       5,
+		ConnMaxLifetime:    5 * time.Minute,
+		ConnMaxIdleTime:    2 * time.Minute,
+		SlowQueryThreshold: DefaultSlowQueryThreshold,
+		LogLevel:           logger.Warn,
 	}
 }
 
@@ -47,8 +71,43 @@ func Connect(cfg *Config) (*gorm.DB, error) {
 		return nil, fmt.Errorf("database: DATABASE_URL is required")
 	}
 
-	db, err := gorm.Open(postgres.Open(cfg.URL), &gorm.Config{
-		Logger: logger.Default.LogMode(cfg.LogLevel),
+	db, err := open(cfg, cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	SetSlowQueryThreshold(cfg.SlowQueryThreshold)
+
+	// Route marked reads to replicas, each with its own pool
+	if len(cfg.ReplicaURLs) > 0 {
+		var replicas []gorm.Dialector
+		for i, url := range cfg.ReplicaURLs {
+			replica, err := open(cfg, url)
+			if err != nil {
+				return nil, fmt.Errorf("database: replica %d: %w", i+1, err)
+			}
+			sqlDB, err := replica.DB()
+			if err != nil {
+				return nil, fmt.Errorf("database: failed to get underlying DB: %w", err)
+			}
+			replicas = append(replicas, postgres.New(postgres.Config{Conn: sqlDB}))
+		}
+		if err := useReplicas(db, replicas); err != nil {
+			return nil, fmt.Errorf("database: failed to register replica routing: %w", err)
+		}
+	}
+
+	// Record statement latency for the /metrics endpoint
+	if err := metrics.InstrumentGORM(db); err != nil {
+		return nil, fmt.Errorf("database: failed to register metrics callbacks: %w", err)
+	}
+
+	return db, nil
+}
+
+// open connects to url with cfg's logging and pool settings.
+func open(cfg *Config, url string) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(url), &gorm.Config{
+		Logger: newQueryLogger(cfg.LogLevel),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("database: failed to connect: %w", err)
@@ -63,21 +122,91 @@ func Connect(cfg *Config) (*gorm.DB, error) {
 	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
 	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
 	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
-
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 	return db, nil
 }
 
-// Migrate runs auto-migration for all models and creates custom indexes.
-func Migrate(db *gorm.DB) error {
-	// Run GORM auto-migration
-	if err := db.AutoMigrate(
+// Models returns every model Migrate creates a table for, in migration
+// order. The tables migrate on SQLite too, for tests; the indexes Migrate
+// adds after them are PostgreSQL's.
+func Models() []any {
+	return []any{
 		&models.Bill{},
 		&models.Version{},
+		&models.VersionProvenance{},
+		&models.TextObject{},
 		&models.Delta{},
-	); err != nil {
+		&models.DiffHit{},
+		&models.Member{},
+		&models.BillSponsorship{},
+		&models.IngestRun{},
+		&models.IngestFailure{},
+		&models.ReconciliationRun{},
+		&models.ReconciliationDiscrepancy{},
+		&models.DeltaJob{},
+		&models.ReingestJob{},
+		&models.BillEvent{},
+		&models.SpendingItem{},
+		&models.DefinedTerm{},
+		&models.Earmark{},
+		&models.BackfillCheckpoint{},
+		&models.RelatedBill{},
+		&models.Summary{},
+		&models.BillSubject{},
+		&models.BillTitle{},
+		&models.BillAlias{},
+		&models.CostEstimate{},
+		&models.Tenant{},
+		&models.Document{},
+		&models.User{},
+		&models.SavedSearch{},
+		&models.WatchedBill{},
+		&models.KeywordAlert{},
+		&models.KeywordAlertMatch{},
+		&models.Rule{},
+		&models.Treaty{},
+		&models.Nomination{},
+		&models.AuditLog{},
+		&models.BillActivity{},
+		&models.MemberCongressStats{},
+		&models.MemberPolicyArea{},
+		&models.Provision{},
+		&models.ProvisionBand{},
+		&models.ProvisionState{},
+		&models.BillLineage{},
+		&models.LineageState{},
+		&models.AppropriationsAccount{},
+		&models.AppropriationsAccountState{},
+	}
+}
+
+// Migrate runs auto-migration for all models and creates custom indexes.
+func Migrate(db *gorm.DB) error {
+	if err := addBillNumbers(db); err != nil {
+		return err
+	}
+
+	// Run GORM auto-migration
+	if err := db.AutoMigrate(Models()...); err != nil {
 		return fmt.Errorf("database: auto-migration failed: %w", err)
 	}
 
+	// The bill key gained state_code and session (idx_bill_key) for
+	// state bills; the federal-only key it replaces would reject them
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_bill_unique`).Error; err != nil {
+		return fmt.Errorf("database: failed to drop idx_bill_unique: %w", err)
+	}
+	// ...and later tenant_id (idx_bill_tenant_key), so tenants' drafts can
+	// be numbered independently
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_bill_key`).Error; err != nil {
+		return fmt.Errorf("database: failed to drop idx_bill_key: %w", err)
+	}
+	// ...and later the number as published (idx_bill_number_key), so bills
+	// whose numbers aren't numeric aren't all keyed as number 0
+	if err := db.Exec(`DROP INDEX IF EXISTS idx_bill_tenant_key`).Error; err != nil {
+		return fmt.Errorf("database: failed to drop idx_bill_tenant_key: %w", err)
+	}
+
 	// Create GIN index on bills.metadata JSONB column for fast querying
 	// Using IF NOT EXISTS to make it idempotent
 	if err := db.Exec(`
@@ -95,6 +224,70 @@ func Migrate(db *gorm.DB) error {
 		return fmt.Errorf("database: failed to create GIN index on delta_json: %w", err)
 	}
 
+	// Compressed deltas are stored as they are, rather than recompressed
+	// by TOAST
+	if err := db.Exec(`ALTER TABLE deltas ALTER COLUMN delta_data SET STORAGE EXTERNAL`).Error; err != nil {
+		return fmt.Errorf("database: failed to set storage of delta_data: %w", err)
+	}
+
+	// Dashboard aggregates, refreshed by the ingestor
+	if err := stats.CreateViews(db); err != nil {
+		return fmt.Errorf("database: %w", err)
+	}
+
+	if err := normalizeVersionCodes(db); err != nil {
+		return err
+	}
+
+	if err := rehashVersions(db); err != nil {
+		return err
+	}
+
+	if err := extractPlainText(db); err != nil {
+		return err
+	}
+
+	if err := computeTextStats(db); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// addBillNumbers adds bills.number to a database created before it,
+// filled from bill_number, ahead of auto-migration: the unique key
+// auto-migration creates on it would reject the empty numbers of existing
+// rows. It does nothing once the column exists.
+func addBillNumbers(db *gorm.DB) error {
+	m := db.Migrator()
+	if !m.HasTable(&models.Bill{}) || m.HasColumn(&models.Bill{}, "Number") {
+		return nil
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`ALTER TABLE bills ADD COLUMN number varchar(32) NOT NULL DEFAULT ''`).Error; err != nil {
+			return fmt.Errorf("database: failed to add bills.number: %w", err)
+		}
+		if err := tx.Exec(`UPDATE bills SET number = CAST(bill_number AS text)`).Error; err != nil {
+			return fmt.Errorf("database: failed to fill bills.number: %w", err)
+		}
+		return nil
+	})
+}
+
+// normalizeVersionCodes rewrites version codes stored as full type strings
+// ("Engrossed in House") by earlier ingestor releases, or truncated to two
+// letters by the first, to their short codes. Truncated codes that could
+// be more than one code ("En") are left as stored; see
+// versioncode.LegacyTypes. Rows that are already normalized are
+// untouched, so this is idempotent.
+func normalizeVersionCodes(db *gorm.DB) error {
+	for typeStr, code := range versioncode.LegacyTypes() {
+		if err := db.Model(&models.Version{}).
+			Where("version_code = ?", typeStr).
+			Update("version_code", code).Error; err != nil {
+			return fmt.Errorf("database: failed to normalize version code %q: %w", typeStr, err)
+		}
+	}
 	return nil
 }
 
@@ -104,5 +297,78 @@ func Close(db *gorm.DB) error {
 	if err != nil {
 		return err
 	}
-	return sqlDB.Close()
+	var errs []error
+	for _, pool := range replicaPools(db) {
+		errs = append(errs, pool.Close())
+	}
+	return errors.Join(append(errs, sqlDB.Close())...)
+}
+
+// rehashVersions moves content hashes computed over raw text by earlier
+// releases into raw_hash and replaces them with hashes of the normalized
+// text. Rows with a raw_hash are already done, so this is idempotent.
+func rehashVersions(db *gorm.DB) error {
+	var versions []models.Version
+	err := db.Select("id", "content_hash", "text_content").
+		Where("raw_hash IS NULL OR raw_hash = ''").
+		FindInBatches(&versions, 100, func(tx *gorm.DB, _ int) error {
+			for _, v := range versions {
+				if err := tx.Model(&models.Version{}).Where("id = ?", v.ID).Updates(map[string]any{
+					"raw_hash":     v.ContentHash,
+					"content_hash": textnorm.Hash(v.TextContent),
+				}).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return fmt.Errorf("database: failed to rehash versions: %w", err)
+	}
+	return nil
+}
+
+// extractPlainText fills plain_text for versions stored before text
+// extraction was added.
+func extractPlainText(db *gorm.DB) error {
+	var versions []models.Version
+	err := db.Select("id", "text_content").
+		Where("(plain_text IS NULL OR plain_text = '') AND text_content <> ''").
+		FindInBatches(&versions, 100, func(tx *gorm.DB, _ int) error {
+			for _, v := range versions {
+				if err := tx.Model(&models.Version{}).Where("id = ?", v.ID).
+					Update("plain_text", textextract.Extract(v.TextContent)).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return fmt.Errorf("database: failed to extract plain text: %w", err)
+	}
+	return nil
+}
+
+// computeTextStats fills the readability metrics of versions stored before
+// they were added. Archived versions, whose plain text has been moved
+// aside, are left without them.
+func computeTextStats(db *gorm.DB) error {
+	var versions []models.Version
+	err := db.Select("id", "plain_text").
+		Where("word_count = 0 AND plain_text <> ''").
+		FindInBatches(&versions, 100, func(tx *gorm.DB, _ int) error {
+			for _, v := range versions {
+				textstats.Fill(&v)
+				if err := tx.Model(&models.Version{}).Where("id = ?", v.ID).
+					Select("word_count", "page_estimate", "avg_sentence_length", "grade_level", "defined_terms").
+					Updates(&v).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}).Error
+	if err != nil {
+		return fmt.Errorf("database: failed to compute text stats: %w", err)
+	}
+	return nil
 }
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// TestAddBillNumbers verifies bills.number is added to a database created
+// before it and filled from bill_number, so the unique key on it can be
+// created, and that it's left alone once it exists.
+func TestAddBillNumbers(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "deltagov.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Skipf("SQLite unavailable: %v", err)
+	}
+	// The bills table as it was before bills.number. Columns are quoted,
+	// as SQLite's HasColumn matches "number " within "bill_number integer"
+	if err := db.Exec(`CREATE TABLE bills ("id" integer PRIMARY KEY, "congress" integer, "bill_type" text, "bill_number" integer)`).Error; err != nil {
+		t.Fatalf("Failed to create bills: %v", err)
+	}
+	if err := db.Exec(`INSERT INTO bills (congress, bill_type, bill_number) VALUES (119, 'hr', 1), (119, 's', 42)`).Error; err != nil {
+		t.Fatalf("Failed to create bills: %v", err)
+	}
+
+	if err := addBillNumbers(db); err != nil {
+		t.Fatalf("addBillNumbers failed: %v", err)
+	}
+	var numbers []string
+	if err := db.Table("bills").Order("id").Pluck("number", &numbers).Error; err != nil {
+		t.Fatalf("Failed to read numbers: %v", err)
+	}
+	if len(numbers) != 2 || numbers[0] != "1" || numbers[1] != "42" {
+		t.Fatalf("Numbers = %v, want [1 42]", numbers)
+	}
+	if err := db.AutoMigrate(&models.Bill{}); err != nil {
+		t.Fatalf("AutoMigrate after addBillNumbers failed: %v", err)
+	}
+
+	// Once the column exists, numbers are kept
+	if err := db.Exec(`UPDATE bills SET number = '42A' WHERE bill_number = 42`).Error; err != nil {
+		t.Fatalf("Failed to update bill: %v", err)
+	}
+	if err := addBillNumbers(db); err != nil {
+		t.Fatalf("Second addBillNumbers failed: %v", err)
+	}
+	var number string
+	db.Table("bills").Where("bill_number = ?", 42).Pluck("number", &number)
+	if number != "42A" {
+		t.Errorf("Number after a second run = %q, want it kept", number)
+	}
+}
@@ -0,0 +1,97 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/metrics"
+)
+
+// pingTimeout bounds each attempt to reach the database.
+const pingTimeout = 5 * time.Second
+
+// Backoff bounds the waits between attempts to reach the database: the
+// first wait is Initial, doubling after each failed attempt up to Max.
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+}
+
+// DefaultBackoff retries after 1s, 2s, 4s, and so on, at most a minute
+// apart.
+var DefaultBackoff = Backoff{Initial: time.Second, Max: time.Minute}
+
+// WaitHealthy pings db's primary until it answers, waiting between failed
+// attempts as b says, and returns nil once it does, at once when it's
+// already reachable. It returns ctx's error if ctx ends first. A ping
+// that fails discards the connection it used, so once Postgres is back
+// after a restart the pool holds only new connections. Outages are logged
+// when first seen and when they end, not on every attempt.
+func WaitHealthy(ctx context.Context, db *gorm.DB, b Backoff) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("database: failed to get underlying DB: %w", err)
+	}
+	logger := logging.FromContext(ctx)
+
+	var down time.Time
+	wait := b.Initial
+	for attempt := 1; ; attempt++ {
+		err := ping(ctx, sqlDB)
+		if err == nil {
+			if !down.IsZero() {
+				metrics.DBReconnects.Inc()
+				logger.Info("database reachable again", "attempts", attempt, "down_for", time.Since(down).Round(time.Second).String())
+			}
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if down.IsZero() {
+			down = time.Now()
+			logger.Warn("database unreachable, waiting for it", "error", err, "retry_in", wait.String())
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+		wait = min(wait*2, b.Max)
+	}
+}
+
+// ping checks the database answers, within pingTimeout.
+func ping(ctx context.Context, sqlDB *sql.DB) error {
+	ctx, cancel := context.WithTimeout(ctx, pingTimeout)
+	defer cancel()
+	return sqlDB.PingContext(ctx)
+}
+
+// Pool is a connection pool's statistics.
+type Pool struct {
+	Name  string // "primary", or "replica 1" and so on
+	Stats sql.DBStats
+}
+
+// Pools returns the statistics of db's connection pools: the primary's,
+// then each read replica's.
+func Pools(db *gorm.DB) ([]Pool, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("database: failed to get underlying DB: %w", err)
+	}
+	pools := []Pool{{Name: "primary", Stats: sqlDB.Stats()}}
+	for i, pool := range replicaPools(db) {
+		pools = append(pools, Pool{Name: fmt.Sprintf("replica %d", i+1), Stats: pool.Stats()})
+	}
+	return pools, nil
+}
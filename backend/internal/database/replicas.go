@@ -0,0 +1,65 @@
+package database
+
+import (
+	"database/sql"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/plugin/dbresolver"
+)
+
+// replicaResolver names the dbresolver resolver for read replicas. Being
+// named rather than global, it only routes statements that ask for it, so
+// reads that must see a write just made stay on the primary by default.
+const replicaResolver = "deltagov:replicas"
+
+// ReadReplica returns db with its queries routed to a read replica when
+// any are configured (see Config.ReplicaURLs); without replicas they run
+// on db as before. Replicas lag the primary, so use it only for reads that
+// needn't see a write just made: listings, search, and cached diffs.
+// Writes, queries in a transaction, and queries with a locking clause stay
+// on the primary. Like one from WithContext, the returned db can be reused
+// for several queries.
+func ReadReplica(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Use(replicaResolver)).Session(&gorm.Session{})
+}
+
+// ParseReplicaURLs splits a comma-separated list of connection strings,
+// such as DATABASE_REPLICA_URLS, skipping empty entries.
+func ParseReplicaURLs(value string) []string {
+	var urls []string
+	for _, url := range strings.Split(value, ",") {
+		if url = strings.TrimSpace(url); url != "" {
+			urls = append(urls, url)
+		}
+	}
+	return urls
+}
+
+// useReplicas registers dbresolver with replicas as the read replicas
+// ReadReplica routes to, taken in turn.
+func useReplicas(db *gorm.DB, replicas []gorm.Dialector) error {
+	return db.Use(dbresolver.Register(dbresolver.Config{
+		Replicas: replicas,
+		Policy:   dbresolver.StrictRoundRobinPolicy(),
+	}, replicaResolver))
+}
+
+// replicaPools returns the pools of the replicas registered by
+// useReplicas, in order; none without replicas.
+func replicaPools(db *gorm.DB) []*sql.DB {
+	resolver, ok := db.Config.Plugins[(&dbresolver.DBResolver{}).Name()].(*dbresolver.DBResolver)
+	if !ok {
+		return nil
+	}
+	// The resolver's pools include the primary's, as the replicas' source
+	primary, _ := db.DB()
+	var pools []*sql.DB
+	resolver.Call(func(pool gorm.ConnPool) error {
+		if sqlDB, ok := pool.(*sql.DB); ok && sqlDB != primary {
+			pools = append(pools, sqlDB)
+		}
+		return nil
+	})
+	return pools
+}
@@ -0,0 +1,144 @@
+package database
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+// replicaRow is a row whose name tells which database it was read from.
+type replicaRow struct {
+	ID   uint
+	Name string
+}
+
+// openReplicated opens a primary and a replica SQLite database, each with
+// a row 1 named after it, with the replica registered for ReadReplica.
+func openReplicated(t *testing.T) *gorm.DB {
+	t.Helper()
+	dir := t.TempDir()
+	config := &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)}
+	for _, name := range []string{"primary", "replica"} {
+		db, err := gorm.Open(sqlite.Open(filepath.Join(dir, name+".db")), config)
+		if err != nil {
+			t.Skipf("SQLite unavailable: %v", err)
+		}
+		if err := db.AutoMigrate(&replicaRow{}); err != nil {
+			t.Fatalf("Failed to migrate %s: %v", name, err)
+		}
+		if err := db.Create(&replicaRow{ID: 1, Name: name}).Error; err != nil {
+			t.Fatalf("Failed to create %s row: %v", name, err)
+		}
+		sqlDB, _ := db.DB()
+		sqlDB.Close()
+	}
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(dir, "primary.db")), config)
+	if err != nil {
+		t.Fatalf("Failed to open primary: %v", err)
+	}
+	if err := useReplicas(db, []gorm.Dialector{sqlite.Open(filepath.Join(dir, "replica.db"))}); err != nil {
+		t.Fatalf("useReplicas failed: %v", err)
+	}
+	t.Cleanup(func() { Close(db) })
+	return db
+}
+
+// readName returns the name of row 1 as read through db.
+func readName(t *testing.T, db *gorm.DB) string {
+	t.Helper()
+	var row replicaRow
+	if err := db.First(&row, 1).Error; err != nil {
+		t.Fatalf("Failed to read row: %v", err)
+	}
+	return row.Name
+}
+
+// TestReadReplica verifies reads marked with ReadReplica go to a replica,
+// and that unmarked reads, writes, reads in a transaction, and locking
+// reads go to the primary.
+func TestReadReplica(t *testing.T) {
+	db := openReplicated(t)
+
+	if got := readName(t, db); got != "primary" {
+		t.Errorf("Unmarked read from %s, want primary", got)
+	}
+	if got := readName(t, ReadReplica(db)); got != "replica" {
+		t.Errorf("Marked read from %s, want replica", got)
+	}
+	var name string
+	if err := ReadReplica(db).Model(&replicaRow{}).Where("id = ?", 1).Select("name").Scan(&name).Error; err != nil || name != "replica" {
+		t.Errorf("Marked scan from %q, %v; want replica", name, err)
+	}
+	if got := readName(t, ReadReplica(db).Clauses(clause.Locking{Strength: "UPDATE"})); got != "primary" {
+		t.Errorf("Locking read from %s, want primary", got)
+	}
+
+	if err := ReadReplica(db).Create(&replicaRow{ID: 2, Name: "written"}).Error; err != nil {
+		t.Fatalf("Marked write failed: %v", err)
+	}
+	var count int64
+	db.Model(&replicaRow{}).Where("id = ?", 2).Count(&count)
+	if count != 1 {
+		t.Errorf("Marked write reached the primary %d times, want once", count)
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if got := readName(t, ReadReplica(tx)); got != "primary" {
+			t.Errorf("Marked read in a transaction from %s, want primary", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Transaction failed: %v", err)
+	}
+
+	if pools := replicaPools(db); len(pools) != 1 {
+		t.Errorf("Replica pools = %d, want 1", len(pools))
+	}
+}
+
+// TestReadReplicaReuse verifies a db from ReadReplica can run several
+// queries, each without the previous one's table or conditions.
+func TestReadReplicaReuse(t *testing.T) {
+	db := ReadReplica(openReplicated(t))
+
+	var rows []replicaRow
+	if err := db.Where("name = ?", "primary").Find(&rows).Error; err != nil || len(rows) != 0 {
+		t.Fatalf("First query = %v, %v; want no rows on the replica", rows, err)
+	}
+	var count int64
+	if err := db.Model(&replicaRow{}).Count(&count).Error; err != nil || count != 1 {
+		t.Errorf("Second query = %d, %v; want 1 row, without the first's condition", count, err)
+	}
+	if got := readName(t, db); got != "replica" {
+		t.Errorf("Third query read from %s, want replica", got)
+	}
+}
+
+// TestReadReplicaWithoutReplicas verifies marked reads go to the primary
+// when no replicas are configured.
+func TestReadReplicaWithoutReplicas(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "deltagov.db")), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Skipf("SQLite unavailable: %v", err)
+	}
+	if err := db.AutoMigrate(&replicaRow{}); err != nil {
+		t.Fatalf("Failed to migrate: %v", err)
+	}
+	if err := db.Create(&replicaRow{ID: 1, Name: "primary"}).Error; err != nil {
+		t.Fatalf("Failed to create row: %v", err)
+	}
+	if got := readName(t, ReadReplica(db)); got != "primary" {
+		t.Errorf("Marked read from %s, want primary", got)
+	}
+	if pools := replicaPools(db); len(pools) != 0 {
+		t.Errorf("Replica pools = %d, want none", len(pools))
+	}
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/metrics"
+)
+
+const (
+	// DefaultSlowQueryThreshold is the slow query threshold until
+	// configured, GORM's own default.
+	DefaultSlowQueryThreshold = 200 * time.Millisecond
+
+	// maxLoggedSQL bounds the statement text logged with a slow query, so
+	// a bulk insert doesn't flood the logs.
+	maxLoggedSQL = 2000
+)
+
+// slowQueryThreshold is the current threshold in nanoseconds; 0 disables
+// slow query logging.
+var slowQueryThreshold atomic.Int64
+
+func init() {
+	SetSlowQueryThreshold(DefaultSlowQueryThreshold)
+}
+
+// SetSlowQueryThreshold sets how long a statement may take before it is
+// logged and counted as slow, in every connection of the process. It may
+// be called at any time; 0 disables slow query logging.
+func SetSlowQueryThreshold(d time.Duration) {
+	slowQueryThreshold.Store(int64(max(d, 0)))
+}
+
+// SlowQueryThreshold returns the slow query threshold; 0 when disabled.
+func SlowQueryThreshold() time.Duration {
+	return time.Duration(slowQueryThreshold.Load())
+}
+
+// queryLogger is GORM's logger with slow queries logged through slog,
+// against a threshold that can change while running, rather than against
+// the fixed threshold GORM's logger is created with.
+type queryLogger struct {
+	logger.Interface
+}
+
+// newQueryLogger returns the logger for connections at level.
+func newQueryLogger(level logger.LogLevel) logger.Interface {
+	base := logger.New(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{
+		LogLevel:                  level,
+		IgnoreRecordNotFoundError: true,
+		Colorful:                  true,
+	})
+	return queryLogger{base}
+}
+
+// LogMode implements logger.Interface.
+func (l queryLogger) LogMode(level logger.LogLevel) logger.Interface {
+	return queryLogger{l.Interface.LogMode(level)}
+}
+
+// Trace implements logger.Interface, logging statements slower than the
+// threshold as warnings with the request's logger.
+func (l queryLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	elapsed := time.Since(begin)
+	if threshold := SlowQueryThreshold(); threshold > 0 && elapsed >= threshold &&
+		(err == nil || errors.Is(err, gorm.ErrRecordNotFound)) {
+		metrics.DBSlowQueries.Inc()
+		sql, rows := fc()
+		if len(sql) > maxLoggedSQL {
+			sql = sql[:maxLoggedSQL] + "..."
+		}
+		logging.FromContext(ctx).Warn("slow query",
+			"elapsed_ms", elapsed.Milliseconds(), "threshold_ms", threshold.Milliseconds(), "rows", rows, "sql", sql)
+		return
+	}
+	l.Interface.Trace(ctx, begin, fc, err)
+}
@@ -0,0 +1,102 @@
+package deltas
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gorm.io/datatypes"
+
+	"github.com/drewjst/deltagov/internal/diff_engine"
+)
+
+// CodecGzipRef stores a delta as gzipped JSON whose unchanged lines omit
+// their content, which is restored from the from version's text by line
+// number. Unchanged context is most of a big bill's delta and is already
+// in the version text, so it isn't copied.
+const CodecGzipRef = "gzip+ref/1"
+
+// encode converts a diff from fromText to the compressed form stored in
+// Delta.DeltaData, in CodecGzipRef.
+func encode(delta *diff_engine.Delta, fromText string) ([]byte, error) {
+	linesA := strings.Split(fromText, "\n")
+
+	compact := *delta
+	compact.Hunks = make([]diff_engine.Hunk, len(delta.Hunks))
+	for i, hunk := range delta.Hunks {
+		lines := make([]diff_engine.Change, len(hunk.Lines))
+		for j, change := range hunk.Lines {
+			if change.Type == diff_engine.ChangeUnchanged && lineAt(linesA, change.LineA) == change.Content {
+				change.Content = ""
+			}
+			lines[j] = change
+		}
+		hunk.Lines = lines
+		compact.Hunks[i] = hunk
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if err := json.NewEncoder(zw).Encode(&compact); err != nil {
+		return nil, fmt.Errorf("deltas: failed to encode delta: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("deltas: failed to encode delta: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// decode converts Delta.DeltaData in codec back into a diff, restoring
+// unchanged lines from fromText. Text that changed since the delta was
+// stored restores the wrong lines; callers check the result against the
+// stored fingerprint.
+func decode(codec string, data []byte, fromText string) (*diff_engine.Delta, error) {
+	if codec != CodecGzipRef {
+		return nil, fmt.Errorf("deltas: unknown codec %q", codec)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("deltas: failed to decode delta: %w", err)
+	}
+	defer zr.Close()
+
+	var delta diff_engine.Delta
+	if err := json.NewDecoder(zr).Decode(&delta); err != nil {
+		return nil, fmt.Errorf("deltas: failed to decode delta: %w", err)
+	}
+
+	linesA := strings.Split(fromText, "\n")
+	for i := range delta.Hunks {
+		for j := range delta.Hunks[i].Lines {
+			change := &delta.Hunks[i].Lines[j]
+			if change.Type == diff_engine.ChangeUnchanged && change.Content == "" {
+				change.Content = lineAt(linesA, change.LineA)
+			}
+		}
+	}
+	return &delta, nil
+}
+
+// decodeJSON converts Delta.DeltaJSON, as stored before deltas were
+// compressed, back into a diff.
+func decodeJSON(m datatypes.JSONMap) (*diff_engine.Delta, error) {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return nil, fmt.Errorf("deltas: failed to decode delta: %w", err)
+	}
+	var delta diff_engine.Delta
+	if err := json.Unmarshal(data, &delta); err != nil {
+		return nil, fmt.Errorf("deltas: failed to decode delta: %w", err)
+	}
+	return &delta, nil
+}
+
+// lineAt returns a 1-based line, or "" past either end.
+func lineAt(lines []string, n int) string {
+	if n < 1 || n > len(lines) {
+		return ""
+	}
+	return lines[n-1]
+}
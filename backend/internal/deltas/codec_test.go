@@ -0,0 +1,107 @@
+package deltas
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/congresstest"
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+const (
+	codecFromText = "SECTION 1. SHORT TITLE.\nThis Act may be cited as the Test Act.\n" +
+		"SEC. 2. FEES.\nThe fee is $500.\nThe fee is due yearly.\nSEC. 3. EFFECTIVE DATE.\nThis Act takes effect on enactment.\n"
+	codecToText = "SECTION 1. SHORT TITLE.\nThis Act may be cited as the Test Act.\n" +
+		"SEC. 2. FEES.\nThe fee is $750.\nThe fee is due yearly.\nSEC. 3. EFFECTIVE DATE.\nThis Act takes effect 90 days after enactment.\n"
+)
+
+// TestCodecRoundTrip verifies a delta decodes to the diff it was encoded
+// from, unchanged lines restored from the from text they were left out for.
+func TestCodecRoundTrip(t *testing.T) {
+	delta, err := diff_engine.ComputeWordLevel(codecFromText, codecToText)
+	if err != nil {
+		t.Fatalf("ComputeWordLevel failed: %v", err)
+	}
+	unchanged := 0
+	for _, hunk := range delta.Hunks {
+		for _, change := range hunk.Lines {
+			if change.Type == diff_engine.ChangeUnchanged {
+				unchanged++
+			}
+		}
+	}
+	if unchanged == 0 {
+		t.Fatalf("Delta has no unchanged lines to leave out: %+v", delta)
+	}
+
+	data, err := encode(delta, codecFromText)
+	if err != nil {
+		t.Fatalf("encode failed: %v", err)
+	}
+	if strings.Contains(string(data), "due yearly") {
+		t.Errorf("Encoded delta isn't compressed")
+	}
+	decoded, err := decode(CodecGzipRef, data, codecFromText)
+	if err != nil {
+		t.Fatalf("decode failed: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, delta) {
+		t.Errorf("Decoded delta = %+v, want %+v", decoded, delta)
+	}
+
+	// Restored from other text, the delta no longer matches its fingerprint
+	changed := strings.Replace(codecFromText, "due yearly", "due monthly", 1)
+	decoded, err = decode(CodecGzipRef, data, changed)
+	if err != nil {
+		t.Fatalf("decode of changed text failed: %v", err)
+	}
+	if diff_engine.Fingerprint(decoded) == diff_engine.Fingerprint(delta) {
+		t.Errorf("Delta restored from changed text matches its fingerprint")
+	}
+
+	if _, err := decode("gzip/2", data, codecFromText); err == nil {
+		t.Errorf("decode of an unknown codec succeeded")
+	}
+}
+
+// TestCachedRoundTrip verifies a computed delta is read back from the
+// cache as computed, and isn't once the from version's text changes.
+func TestCachedRoundTrip(t *testing.T) {
+	db := congresstest.OpenDB(t)
+	ctx := context.Background()
+	bill := models.Bill{Congress: 119, BillType: "hr", Number: "1", BillNumber: 1, Title: "Test Act"}
+	if err := db.Create(&bill).Error; err != nil {
+		t.Fatalf("Failed to create bill: %v", err)
+	}
+	now := time.Now()
+	from := models.Version{BillID: bill.ID, VersionCode: "IH", ContentHash: "from", PlainText: codecFromText, FetchedAt: now}
+	to := models.Version{BillID: bill.ID, VersionCode: "EH", ContentHash: "to", PlainText: codecToText, FetchedAt: now}
+	for _, v := range []*models.Version{&from, &to} {
+		if err := db.Create(v).Error; err != nil {
+			t.Fatalf("Failed to create version: %v", err)
+		}
+	}
+
+	delta, err := Compute(ctx, db, &from, &to, true)
+	if err != nil {
+		t.Fatalf("Compute failed: %v", err)
+	}
+	cached, err := Cached(ctx, db, from.ID, to.ID)
+	if err != nil {
+		t.Fatalf("Cached failed: %v", err)
+	}
+	if !reflect.DeepEqual(cached, delta) {
+		t.Errorf("Cached delta = %+v, want %+v", cached, delta)
+	}
+
+	if err := db.Model(&from).Update("plain_text", strings.Replace(codecFromText, "due yearly", "due monthly", 1)).Error; err != nil {
+		t.Fatalf("Failed to update version: %v", err)
+	}
+	if cached, err := Cached(ctx, db, from.ID, to.ID); err != nil || cached != nil {
+		t.Errorf("Cached after the text changed = %+v, %v; want none", cached, err)
+	}
+}
@@ -0,0 +1,191 @@
+// Package deltas computes diffs between stored versions and caches them in
+// the deltas table, hunks included, so serving a diff is a cache read.
+package deltas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/archive"
+	"github.com/drewjst/deltagov/internal/database"
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/metrics"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/textextract"
+)
+
+// MaxTextSize is the largest version text that is diffed; larger texts
+// can exhaust memory in the diff engine.
+const MaxTextSize = 100 * 1024 // 100KB
+
+// Cached returns the stored delta between two versions, or nil when none is
+// usable: missing, computed by an older engine, or stored without hunks.
+// Compressed deltas need the from version's text to restore unchanged
+// lines (see CodecGzipRef); one that doesn't restore to its fingerprint,
+// because the text changed since, isn't usable either.
+func Cached(ctx context.Context, db *gorm.DB, fromID, toID uint) (*diff_engine.Delta, error) {
+	db = database.ReadReplica(db.WithContext(ctx))
+
+	var stored models.Delta
+	err := db.Where("version_a_id = ? AND version_b_id = ?", fromID, toID).First(&stored).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("deltas: failed to fetch delta: %w", err)
+	}
+	if stored.EngineVersion != diff_engine.EngineVersion {
+		return nil, nil
+	}
+
+	switch {
+	case stored.DeltaData != nil:
+		var from models.Version
+		if err := db.Select(append([]string{"id"}, archive.TextColumns...)).First(&from, fromID).Error; err != nil {
+			return nil, fmt.Errorf("deltas: failed to fetch version text: %w", err)
+		}
+		if err := archive.Rehydrate(&from); err != nil {
+			return nil, fmt.Errorf("deltas: failed to restore archived text: %w", err)
+		}
+		delta, err := decode(stored.Codec, stored.DeltaData, Text(&from))
+		if err != nil {
+			return nil, err
+		}
+		if diff_engine.Fingerprint(delta) != stored.Fingerprint {
+			logging.FromContext(ctx).Warn("stored delta doesn't match version text, recomputing",
+				"from_version", fromID, "to_version", toID)
+			return nil, nil
+		}
+		return delta, nil
+	case stored.DeltaJSON != nil:
+		return decodeJSON(stored.DeltaJSON)
+	}
+	return nil, nil
+}
+
+// Compute diffs two versions and stores the result, replacing any stale
+// delta for the pair. Diffs are computed over each version's PlainText,
+// so both versions must have it (or TextContent, for older rows) loaded.
+//
+// With verify set, the diff is computed twice and a result that doesn't
+// reproduce is returned but not stored, rather than caching one of two
+// answers.
+func Compute(ctx context.Context, db *gorm.DB, from, to *models.Version, verify bool) (*diff_engine.Delta, error) {
+	fromText, toText := Text(from), Text(to)
+
+	start := time.Now()
+	delta, err := diff_engine.ComputeWordLevel(fromText, toText)
+	if err != nil {
+		return nil, fmt.Errorf("deltas: failed to compute diff: %w", err)
+	}
+	metrics.DiffDuration.Observe(time.Since(start).Seconds())
+
+	fingerprint := diff_engine.Fingerprint(delta)
+
+	if verify {
+		again, err := diff_engine.ComputeWordLevel(fromText, toText)
+		if err != nil {
+			return nil, fmt.Errorf("deltas: failed to recompute diff: %w", err)
+		}
+		if again := diff_engine.Fingerprint(again); again != fingerprint {
+			logging.FromContext(ctx).Warn("non-deterministic diff, not caching",
+				"from_version", from.ID, "to_version", to.ID,
+				"fingerprint", fingerprint, "recomputed_fingerprint", again)
+			return delta, nil
+		}
+	}
+
+	if err := store(ctx, db, from.ID, to.ID, delta, fingerprint, fromText); err != nil {
+		return nil, err
+	}
+	return delta, nil
+}
+
+// Stream is Compute without verification, passing each hunk to emit as
+// soon as it is diffed (see diff_engine.StreamWordLevel). The delta is
+// stored once complete.
+func Stream(ctx context.Context, db *gorm.DB, from, to *models.Version, emit func(diff_engine.Hunk) error) (*diff_engine.Delta, error) {
+	fromText := Text(from)
+
+	start := time.Now()
+	delta, err := diff_engine.StreamWordLevel(fromText, Text(to), emit)
+	if err != nil {
+		return nil, fmt.Errorf("deltas: failed to compute diff: %w", err)
+	}
+	metrics.DiffDuration.Observe(time.Since(start).Seconds())
+
+	if err := store(ctx, db, from.ID, to.ID, delta, diff_engine.Fingerprint(delta), fromText); err != nil {
+		return nil, err
+	}
+	return delta, nil
+}
+
+// store upserts the delta between two versions, compressed with
+// CodecGzipRef against fromText. A delta stored before compression is
+// replaced, its DeltaJSON cleared.
+func store(ctx context.Context, db *gorm.DB, fromID, toID uint, delta *diff_engine.Delta, fingerprint, fromText string) error {
+	encoded, err := encode(delta, fromText)
+	if err != nil {
+		return err
+	}
+
+	row := models.Delta{
+		VersionAID:    fromID,
+		VersionBID:    toID,
+		Insertions:    delta.Insertions,
+		Deletions:     delta.Deletions,
+		DeltaData:     encoded,
+		Codec:         CodecGzipRef,
+		EngineVersion: diff_engine.EngineVersion,
+		Fingerprint:   fingerprint,
+		ComputedAt:    time.Now(),
+	}
+
+	db = db.WithContext(ctx)
+	var existing models.Delta
+	err = db.Select("id", "created_at").
+		Where("version_a_id = ? AND version_b_id = ?", fromID, toID).
+		First(&existing).Error
+	switch {
+	case err == nil:
+		row.ID = existing.ID
+		row.CreatedAt = existing.CreatedAt
+		err = db.Save(&row).Error
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		err = db.Create(&row).Error
+	}
+	if err != nil {
+		return fmt.Errorf("deltas: failed to store delta: %w", err)
+	}
+	return nil
+}
+
+// TooLarge reports whether any of the versions' raw text exceeds
+// MaxTextSize. TextSize is used when recorded, so offloaded text needn't be
+// fetched to check.
+func TooLarge(versions ...*models.Version) bool {
+	for _, v := range versions {
+		size := v.TextSize
+		if size == 0 {
+			size = len(v.TextContent)
+		}
+		if size > MaxTextSize {
+			return true
+		}
+	}
+	return false
+}
+
+// Text returns the text a version is diffed over: its extracted plain
+// text, or for rows stored before extraction, the text extracted now.
+func Text(v *models.Version) string {
+	if v.PlainText != "" {
+		return v.PlainText
+	}
+	return textextract.Extract(v.TextContent)
+}
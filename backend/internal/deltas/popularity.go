@@ -0,0 +1,75 @@
+package deltas
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// PopularWindow is how recently a diff must have been requested to count
+// as popular.
+const PopularWindow = 7 * 24 * time.Hour
+
+// DefaultWarmLimit is the number of popular diffs WarmPopular queues per
+// pass when no limit is given.
+const DefaultWarmLimit = 100
+
+// RecordHit counts a request for the diff between two versions of a bill.
+func RecordHit(ctx context.Context, db *gorm.DB, billID, fromID, toID uint) error {
+	hit := models.DiffHit{BillID: billID, VersionAID: fromID, VersionBID: toID, Hits: 1, LastHitAt: time.Now()}
+	if err := db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "version_a_id"}, {Name: "version_b_id"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"hits":        gorm.Expr("diff_hits.hits + 1"),
+			"last_hit_at": hit.LastHitAt,
+		}),
+	}).Create(&hit).Error; err != nil {
+		return fmt.Errorf("deltas: failed to record diff hit: %w", err)
+	}
+	return nil
+}
+
+// Popular reports whether any diff of a bill was requested within
+// PopularWindow.
+func Popular(ctx context.Context, db *gorm.DB, billID uint) (bool, error) {
+	var count int64
+	if err := db.WithContext(ctx).Model(&models.DiffHit{}).
+		Where("bill_id = ? AND last_hit_at >= ?", billID, time.Now().Add(-PopularWindow)).
+		Count(&count).Error; err != nil {
+		return false, fmt.Errorf("deltas: failed to count diff hits: %w", err)
+	}
+	return count > 0, nil
+}
+
+// WarmPopular queues up to limit of the diffs requested most within
+// PopularWindow that have no delta from the current engine, most requested
+// first, returning how many it queued. Diffs may have lost their delta to
+// a new engine version or never been cached, e.g., because their first
+// request failed. A limit of zero or less uses DefaultWarmLimit.
+func (q *Queue) WarmPopular(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		limit = DefaultWarmLimit
+	}
+	var pairs []models.DiffHit
+	if err := q.db.WithContext(ctx).Select("version_a_id", "version_b_id").
+		Where("last_hit_at >= ?", time.Now().Add(-PopularWindow)).
+		Where("NOT EXISTS (SELECT 1 FROM deltas WHERE deltas.version_a_id = diff_hits.version_a_id AND deltas.version_b_id = diff_hits.version_b_id AND deltas.engine_version = ?)",
+			diff_engine.EngineVersion).
+		Order("hits DESC, last_hit_at DESC").Limit(limit).Find(&pairs).Error; err != nil {
+		return 0, fmt.Errorf("deltas: failed to list popular diffs: %w", err)
+	}
+	for _, p := range pairs {
+		q.Enqueue(ctx, p.VersionAID, p.VersionBID)
+	}
+	if len(pairs) > 0 {
+		logging.FromContext(ctx).Info("queued popular diffs", "count", len(pairs))
+	}
+	return len(pairs), nil
+}
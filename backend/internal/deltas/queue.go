@@ -0,0 +1,167 @@
+package deltas
+
+import (
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/archive"
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/events"
+	"github.com/drewjst/deltagov/internal/insights"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/metrics"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// queueSize bounds pending jobs; beyond it, pairs are left for the API to
+// compute on first request.
+const queueSize = 1024
+
+// job is a pair of versions to diff. ctx carries the enqueuer's logger.
+type job struct {
+	ctx          context.Context
+	fromID, toID uint
+}
+
+// Queue precomputes deltas in the background so ingestion isn't held up by
+// diffing. It is safe for concurrent use.
+type Queue struct {
+	db         *gorm.DB
+	summarizer insights.Summarizer
+	publisher  events.Publisher
+	jobs       chan job
+	wg         sync.WaitGroup
+}
+
+// NewQueue starts a queue with the given number of workers (at least one).
+func NewQueue(db *gorm.DB, workers int) *Queue {
+	q := &Queue{
+		db:   db,
+		jobs: make(chan job, queueSize),
+	}
+	for range max(workers, 1) {
+		q.wg.Add(1)
+		go q.work()
+	}
+	return q
+}
+
+// SetSummarizer makes the queue summarize each delta it computes (see
+// Summarize). It must be called before the first Enqueue. A nil value
+// leaves summaries to be written on demand.
+func (q *Queue) SetSummarizer(s insights.Summarizer) {
+	q.summarizer = s
+}
+
+// SetEventPublisher makes the queue publish a delta.computed event for
+// each delta it computes. It must be called before the first Enqueue.
+func (q *Queue) SetEventPublisher(p events.Publisher) {
+	q.publisher = p
+}
+
+// Enqueue schedules the delta from one version to another. It never blocks:
+// when the queue is full the pair is dropped and computed on demand instead.
+func (q *Queue) Enqueue(ctx context.Context, fromID, toID uint) {
+	select {
+	case q.jobs <- job{ctx: context.WithoutCancel(ctx), fromID: fromID, toID: toID}:
+	default:
+		logging.FromContext(ctx).Warn("delta queue full, skipping precompute",
+			"from_version", fromID, "to_version", toID)
+	}
+}
+
+// Len returns the number of pairs waiting for a worker.
+func (q *Queue) Len() int {
+	return len(q.jobs)
+}
+
+// Close stops accepting jobs and waits for queued ones to finish.
+// Enqueue must not be called after Close.
+func (q *Queue) Close() {
+	close(q.jobs)
+	q.wg.Wait()
+}
+
+func (q *Queue) work() {
+	defer q.wg.Done()
+	for j := range q.jobs {
+		if err := q.precompute(j); err != nil {
+			logging.FromContext(j.ctx).Warn("failed to precompute delta",
+				"from_version", j.fromID, "to_version", j.toID, "error", err)
+		}
+	}
+}
+
+// precompute computes and stores one delta unless a current one exists.
+func (q *Queue) precompute(j job) error {
+	if cached, err := Cached(j.ctx, q.db, j.fromID, j.toID); err != nil || cached != nil {
+		return err
+	}
+
+	var from, to models.Version
+	db := q.db.WithContext(j.ctx)
+	if err := db.First(&from, j.fromID).Error; err != nil {
+		return err
+	}
+	if err := db.First(&to, j.toID).Error; err != nil {
+		return err
+	}
+	if err := archive.Rehydrate(&from); err != nil {
+		return err
+	}
+	if err := archive.Rehydrate(&to); err != nil {
+		return err
+	}
+	if TooLarge(&from, &to) {
+		return nil
+	}
+
+	delta, err := Compute(j.ctx, q.db, &from, &to, false)
+	if err != nil {
+		return err
+	}
+	metrics.DiffComputations.WithLabelValues("precomputed").Inc()
+	if q.summarizer != nil {
+		if _, err := Summarize(j.ctx, q.db, q.summarizer, &from, &to, delta); err != nil {
+			return err
+		}
+	}
+	logging.FromContext(j.ctx).Debug("precomputed delta", "from_version", j.fromID, "to_version", j.toID)
+	q.publishComputed(j.ctx, &from, &to, delta)
+	return nil
+}
+
+// publishComputed publishes a delta.computed event, if a publisher is set.
+// Failures are logged: the delta is stored either way.
+func (q *Queue) publishComputed(ctx context.Context, from, to *models.Version, delta *diff_engine.Delta) {
+	if q.publisher == nil {
+		return
+	}
+	var bill models.Bill
+	if err := q.db.WithContext(ctx).Select("id", "congress", "bill_type", "number", "bill_number", "title").
+		First(&bill, to.BillID).Error; err != nil {
+		logging.FromContext(ctx).Warn("failed to load bill for delta event", "bill_id", to.BillID, "error", err)
+		return
+	}
+	err := q.publisher.Publish(ctx, events.Event{
+		Type:          events.TypeDeltaComputed,
+		BillID:        bill.ID,
+		Congress:      bill.Congress,
+		BillType:      bill.BillType,
+		Number:        bill.Number,
+		BillNumber:    bill.BillNumber,
+		Title:         bill.Title,
+		FromVersionID: from.ID,
+		ToVersionID:   to.ID,
+		FromVersion:   from.VersionCode,
+		ToVersion:     to.VersionCode,
+		Insertions:    delta.Insertions,
+		Deletions:     delta.Deletions,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to publish event",
+			"from_version", from.ID, "to_version", to.ID, "event_type", events.TypeDeltaComputed, "error", err)
+	}
+}
@@ -0,0 +1,53 @@
+package deltas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/insights"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// StoredSummary returns the summary stored with the delta between two
+// versions, or "" when there is none yet.
+func StoredSummary(ctx context.Context, db *gorm.DB, fromID, toID uint) (string, error) {
+	var stored models.Delta
+	err := db.WithContext(ctx).Select("summary").
+		Where("version_a_id = ? AND version_b_id = ?", fromID, toID).
+		First(&stored).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("deltas: failed to fetch summary: %w", err)
+	}
+	return stored.Summary, nil
+}
+
+// Summarize summarizes the delta between two versions and stores the
+// summary with it. Both versions' text must be loaded, as for Compute.
+// A delta that wasn't stored (see Compute's verify) is summarized but the
+// summary isn't kept.
+func Summarize(ctx context.Context, db *gorm.DB, s insights.Summarizer, from, to *models.Version, delta *diff_engine.Delta) (string, error) {
+	summary, by, err := s.Summarize(ctx, insights.Input{
+		FromCode: from.VersionCode,
+		ToCode:   to.VersionCode,
+		FromText: Text(from),
+		ToText:   Text(to),
+		Delta:    delta,
+	})
+	if err != nil {
+		return "", fmt.Errorf("deltas: failed to summarize: %w", err)
+	}
+
+	if err := db.WithContext(ctx).Model(&models.Delta{}).
+		Where("version_a_id = ? AND version_b_id = ?", from.ID, to.ID).
+		Updates(map[string]any{"summary": summary, "summary_by": by}).Error; err != nil {
+		return "", fmt.Errorf("deltas: failed to store summary: %w", err)
+	}
+	return summary, nil
+}
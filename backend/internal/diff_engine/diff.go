@@ -3,27 +3,36 @@ package diff_engine
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
+	"strconv"
 	"strings"
 
 	"github.com/aymanbagabas/go-udiff"
-	"github.com/aymanbagabas/go-udiff/myers"
+
+	"github.com/drewjst/deltagov/internal/analysis"
 )
 
+// EngineVersion identifies the diff algorithm and output format.
+// Bump this whenever a change to the engine could alter the output for the
+// same inputs, so stored Deltas computed by an older engine are recomputed.
+const EngineVersion = "myers-udiff/5"
+
 // Delta represents the structured diff between two text versions
 type Delta struct {
-	VersionA    string   `json:"version_a"`
-	VersionB    string   `json:"version_b"`
-	Hunks       []Hunk   `json:"hunks"`
-	Insertions  int      `json:"insertions"`
-	Deletions   int      `json:"deletions"`
-	Unchanged   int      `json:"unchanged"`
+	VersionA   string `json:"version_a"`
+	VersionB   string `json:"version_b"`
+	Hunks      []Hunk `json:"hunks"`
+	Insertions int    `json:"insertions"`
+	Deletions  int    `json:"deletions"`
+	Unchanged  int    `json:"unchanged"`
 }
 
 // Hunk represents a contiguous block of changes
 type Hunk struct {
-	StartA int      `json:"start_a"`
-	StartB int      `json:"start_b"`
-	Lines  []Change `json:"lines"`
+	StartA  int      `json:"start_a"`
+	StartB  int      `json:"start_b"`
+	Lines   []Change `json:"lines"`
+	Anchors []Anchor `json:"anchors,omitempty"` // Sections containing the hunk's inserted or deleted lines
 }
 
 // Change represents a single line change
@@ -32,6 +41,15 @@ type Change struct {
 	Content string     `json:"content"`
 	LineA   int        `json:"line_a,omitempty"`
 	LineB   int        `json:"line_b,omitempty"`
+	Anchor  string     `json:"anchor,omitempty"` // ID of the section containing the line; empty before the first section
+}
+
+// Anchor identifies a bill section by its number and heading rather than
+// its line numbers, which shift between versions.
+type Anchor struct {
+	ID      string `json:"id"`      // See analysis.Section.Anchor, e.g., "sec-201-3f2a9c1b"
+	Section string `json:"section"` // e.g., "201"
+	Heading string `json:"heading"` // e.g., "FUNDING."
 }
 
 // ChangeType indicates the type of change
@@ -49,10 +67,33 @@ func ComputeHash(content string) string {
 	return hex.EncodeToString(hash[:])
 }
 
+// Fingerprint returns a SHA-256 hash over the semantic content of a delta
+// (hunks and counts). Two computations over the same inputs with the same
+// EngineVersion must produce the same fingerprint.
+func Fingerprint(delta *Delta) string {
+	if delta == nil {
+		return ""
+	}
+	// Version labels are caller-supplied and not part of the diff result
+	canonical := struct {
+		Hunks      []Hunk `json:"hunks"`
+		Insertions int    `json:"insertions"`
+		Deletions  int    `json:"deletions"`
+		Unchanged  int    `json:"unchanged"`
+	}{delta.Hunks, delta.Insertions, delta.Deletions, delta.Unchanged}
+
+	data, err := json.Marshal(canonical)
+	if err != nil {
+		return ""
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
 // Compute calculates the diff between two text versions using Myers algorithm
 func Compute(textA, textB, versionA, versionB string) (*Delta, error) {
-	// Use go-udiff with Myers algorithm
-	edits := myers.ComputeEdits(textA, textB)
+	// Use go-udiff with Myers algorithm, section by section
+	edits := computeEdits(textA, textB)
 	// 3 lines of context around changes (standard unified diff format)
 	unifiedDiff, err := udiff.ToUnified("version_a", "version_b", textA, edits, 3)
 	if err != nil {
@@ -98,12 +139,24 @@ func Compute(textA, textB, versionA, versionB string) (*Delta, error) {
 
 // ComputeWordLevel performs word-level diffing for more granular changes
 func ComputeWordLevel(textA, textB string) (*Delta, error) {
+	return StreamWordLevel(textA, textB, nil)
+}
+
+// StreamWordLevel is ComputeWordLevel, additionally passing each hunk to
+// emit as soon as it is complete so callers can send it before the rest of
+// the diff is parsed. An error from emit stops the diff and is returned.
+// A nil emit is allowed.
+func StreamWordLevel(textA, textB string, emit func(Hunk) error) (*Delta, error) {
 	// Split by lines for line-level diffing with word context
 	linesA := strings.Split(textA, "\n")
 	linesB := strings.Split(textB, "\n")
 
-	// Compute diff on lines
-	edits := myers.ComputeEdits(textA, textB)
+	// Sections containing each line, for anchors
+	anchorsA := lineAnchors(textA)
+	anchorsB := lineAnchors(textB)
+
+	// Compute diff on lines, section by section
+	edits := computeEdits(textA, textB)
 
 	delta := &Delta{
 		Hunks: []Hunk{},
@@ -117,6 +170,13 @@ func ComputeWordLevel(textA, textB string) (*Delta, error) {
 
 	// Parse the unified diff to extract changes
 	var currentHunk *Hunk
+	flush := func() error {
+		delta.Hunks = append(delta.Hunks, *currentHunk)
+		if emit == nil {
+			return nil
+		}
+		return emit(*currentHunk)
+	}
 	lineNumA := 1
 	lineNumB := 1
 
@@ -133,8 +193,11 @@ func ComputeWordLevel(textA, textB string) (*Delta, error) {
 		// Parse hunk header
 		if strings.HasPrefix(line, "@@") {
 			if currentHunk != nil {
-				delta.Hunks = append(delta.Hunks, *currentHunk)
+				if err := flush(); err != nil {
+					return nil, err
+				}
 			}
+			lineNumA, lineNumB = hunkStart(line)
 			currentHunk = &Hunk{
 				StartA: lineNumA,
 				StartB: lineNumB,
@@ -153,11 +216,14 @@ func ComputeWordLevel(textA, textB string) (*Delta, error) {
 			if len(line) > 1 {
 				content = line[1:]
 			}
+			anchor := anchorAt(anchorsB, lineNumB)
 			currentHunk.Lines = append(currentHunk.Lines, Change{
 				Type:    ChangeInsert,
 				Content: content,
 				LineB:   lineNumB,
+				Anchor:  anchor.id(),
 			})
+			currentHunk.addAnchor(anchor)
 			delta.Insertions++
 			lineNumB++
 		case '-':
@@ -165,11 +231,14 @@ func ComputeWordLevel(textA, textB string) (*Delta, error) {
 			if len(line) > 1 {
 				content = line[1:]
 			}
+			anchor := anchorAt(anchorsA, lineNumA)
 			currentHunk.Lines = append(currentHunk.Lines, Change{
 				Type:    ChangeDelete,
 				Content: content,
 				LineA:   lineNumA,
+				Anchor:  anchor.id(),
 			})
+			currentHunk.addAnchor(anchor)
 			delta.Deletions++
 			lineNumA++
 		case ' ':
@@ -182,6 +251,7 @@ func ComputeWordLevel(textA, textB string) (*Delta, error) {
 				Content: content,
 				LineA:   lineNumA,
 				LineB:   lineNumB,
+				Anchor:  anchorAt(anchorsB, lineNumB).id(),
 			})
 			delta.Unchanged++
 			lineNumA++
@@ -191,7 +261,9 @@ func ComputeWordLevel(textA, textB string) (*Delta, error) {
 
 	// Add the last hunk
 	if currentHunk != nil && len(currentHunk.Lines) > 0 {
-		delta.Hunks = append(delta.Hunks, *currentHunk)
+		if err := flush(); err != nil {
+			return nil, err
+		}
 	}
 
 	// If no changes detected, add all lines as unchanged
@@ -199,26 +271,105 @@ func ComputeWordLevel(textA, textB string) (*Delta, error) {
 		hunk := Hunk{StartA: 1, StartB: 1, Lines: []Change{}}
 		maxLines := max(len(linesA), len(linesB))
 		for i := 0; i < maxLines; i++ {
-			content := ""
+			content, anchors := "", anchorsA
 			if i < len(linesA) {
 				content = linesA[i]
 			} else if i < len(linesB) {
-				content = linesB[i]
+				content, anchors = linesB[i], anchorsB
 			}
 			hunk.Lines = append(hunk.Lines, Change{
 				Type:    ChangeUnchanged,
 				Content: content,
 				LineA:   i + 1,
 				LineB:   i + 1,
+				Anchor:  anchorAt(anchors, i+1).id(),
 			})
 			delta.Unchanged++
 		}
-		delta.Hunks = append(delta.Hunks, hunk)
+		currentHunk = &hunk
+		if err := flush(); err != nil {
+			return nil, err
+		}
 	}
 
 	return delta, nil
 }
 
+// hunkStart returns the first line numbers of a unified diff hunk from its
+// header, e.g., "@@ -12,7 +14,8 @@".
+func hunkStart(header string) (int, int) {
+	startA, startB := 1, 1
+	for _, field := range strings.Fields(header) {
+		if len(field) < 2 || (field[0] != '-' && field[0] != '+') {
+			continue
+		}
+		num, _, _ := strings.Cut(field[1:], ",")
+		// An empty side is numbered from 0; its next line is 1
+		n, err := strconv.Atoi(num)
+		if err != nil || n < 1 {
+			n = 1
+		}
+		if field[0] == '-' {
+			startA = n
+		} else {
+			startB = n
+		}
+	}
+	return startA, startB
+}
+
+// lineAnchors returns the anchor of the section containing each line of
+// text, indexed from 0; lines before the first section have none.
+func lineAnchors(text string) []*Anchor {
+	anchors := make([]*Anchor, strings.Count(text, "\n")+1)
+	sections := analysis.SplitSections(text)
+	if len(sections) == 0 {
+		return anchors
+	}
+	// Each section ends where the next begins
+	line := strings.Count(text[:sections[0].Start], "\n")
+	for _, sec := range sections {
+		anchor := &Anchor{ID: sec.Anchor(), Section: sec.Number, Heading: sec.Heading}
+		end := line + strings.Count(text[sec.Start:sec.End], "\n")
+		if sec.End == len(text) {
+			end = len(anchors)
+		}
+		for ; line < end; line++ {
+			anchors[line] = anchor
+		}
+	}
+	return anchors
+}
+
+// anchorAt returns the anchor of a 1-based line number, or nil.
+func anchorAt(anchors []*Anchor, line int) *Anchor {
+	if line < 1 || line > len(anchors) {
+		return nil
+	}
+	return anchors[line-1]
+}
+
+// id returns the anchor's ID, or "" for a nil anchor.
+func (a *Anchor) id() string {
+	if a == nil {
+		return ""
+	}
+	return a.ID
+}
+
+// addAnchor records that the hunk changes a line of the anchor's section.
+func (h *Hunk) addAnchor(anchor *Anchor) {
+	if anchor == nil {
+		return
+	}
+	for _, existing := range h.Anchors {
+		if existing.ID == anchor.ID {
+			return
+		}
+	}
+	h.Anchors = append(h.Anchors, *anchor)
+}
+
 // tokenize splits text into word tokens
 func tokenize(text string) []string {
 	var tokens []string
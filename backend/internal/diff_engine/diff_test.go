@@ -0,0 +1,243 @@
+package diff_engine_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/diff_engine"
+)
+
+const (
+	textA = "SECTION 1. SHORT TITLE.\nThis Act may be cited as the Test Act.\n\nSEC. 2. FUNDING.\nThere is appropriated $500,000,000.\n"
+	textB = "SECTION 1. SHORT TITLE.\nThis Act may be cited as the Test Act.\n\nSEC. 2. FUNDING.\nThere is appropriated $750,000,000.\n"
+)
+
+// TestFingerprint_Deterministic verifies repeated computations fingerprint identically.
+func TestFingerprint_Deterministic(t *testing.T) {
+	first, err := diff_engine.ComputeWordLevel(textA, textB)
+	if err != nil {
+		t.Fatalf("ComputeWordLevel failed: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		again, err := diff_engine.ComputeWordLevel(textA, textB)
+		if err != nil {
+			t.Fatalf("ComputeWordLevel failed: %v", err)
+		}
+		if diff_engine.Fingerprint(first) != diff_engine.Fingerprint(again) {
+			t.Fatalf("Run %d produced a different fingerprint", i+2)
+		}
+	}
+}
+
+// TestFingerprint_DetectsChanges verifies different results fingerprint differently.
+func TestFingerprint_DetectsChanges(t *testing.T) {
+	changed, err := diff_engine.ComputeWordLevel(textA, textB)
+	if err != nil {
+		t.Fatalf("ComputeWordLevel failed: %v", err)
+	}
+	same, err := diff_engine.ComputeWordLevel(textA, textA)
+	if err != nil {
+		t.Fatalf("ComputeWordLevel failed: %v", err)
+	}
+
+	if diff_engine.Fingerprint(changed) == diff_engine.Fingerprint(same) {
+		t.Error("Different diffs should produce different fingerprints")
+	}
+
+	if changed.Insertions != 1 || changed.Deletions != 1 {
+		t.Errorf("Expected 1 insertion and 1 deletion, got +%d/-%d", changed.Insertions, changed.Deletions)
+	}
+
+	if diff_engine.Fingerprint(nil) != "" {
+		t.Error("Fingerprint of nil delta should be empty")
+	}
+}
+
+// TestAnchors verifies changed lines are anchored to their section, by
+// number and heading rather than line number.
+func TestAnchors(t *testing.T) {
+	// Shift section 2 down two lines; its anchor must not change
+	shifted := "SECTION 1. SHORT TITLE.\nThis Act may be cited as the Test Act.\nIt is a test.\n\n\nSEC. 2. FUNDING.\nThere is appropriated $750,000,000.\n"
+	first, err := diff_engine.ComputeWordLevel(textA, textB)
+	if err != nil {
+		t.Fatalf("ComputeWordLevel failed: %v", err)
+	}
+	second, err := diff_engine.ComputeWordLevel(textA, shifted)
+	if err != nil {
+		t.Fatalf("ComputeWordLevel failed: %v", err)
+	}
+
+	if len(first.Hunks) != 1 || len(first.Hunks[0].Anchors) != 1 {
+		t.Fatalf("Expected one hunk anchored to one section, got %+v", first.Hunks)
+	}
+	anchor := first.Hunks[0].Anchors[0]
+	if anchor.Section != "2" || anchor.Heading != "FUNDING." {
+		t.Errorf("Unexpected anchor: %+v", anchor)
+	}
+
+	var found bool
+	for _, hunk := range second.Hunks {
+		for _, change := range hunk.Lines {
+			if change.Type == diff_engine.ChangeInsert && change.Content == "There is appropriated $750,000,000." {
+				found = true
+				if change.Anchor != anchor.ID {
+					t.Errorf("Shifted line anchored to %q, want %q", change.Anchor, anchor.ID)
+				}
+				if change.LineB != 7 {
+					t.Errorf("Shifted line numbered %d, want 7", change.LineB)
+				}
+			}
+		}
+	}
+	if !found {
+		t.Error("Changed line not found in shifted diff")
+	}
+}
+
+// TestComputeThreeWay verifies each section is attributed to the chamber
+// whose text the base kept.
+func TestComputeThreeWay(t *testing.T) {
+	house := "SEC. 1. SHORT TITLE.\nThe Test Act.\nSEC. 2. FUNDING.\n$500.\nSEC. 3. REPORTS.\nAnnual.\nSEC. 5. STUDY.\nGAO.\n"
+	senate := "SEC. 1. SHORT TITLE.\nThe Test Act.\nSEC. 2. FUNDING.\n$750.\nSEC. 3. REPORTS.\nQuarterly.\n"
+	base := "SEC. 1. SHORT TITLE.\nThe  test Act.\nSEC. 2. FUNDING.\n$750.\nSEC. 3. REPORTS.\nAnnual.\nSEC. 4. OVERSIGHT.\nIG.\n"
+
+	result, err := diff_engine.ComputeThreeWay(base, house, senate)
+	if err != nil {
+		t.Fatalf("ComputeThreeWay failed: %v", err)
+	}
+
+	want := map[string]diff_engine.Source{
+		"1": diff_engine.FollowedBoth,
+		"2": diff_engine.FollowedSenate,
+		"3": diff_engine.FollowedHouse,
+		"4": diff_engine.FollowedNeither,
+		"5": diff_engine.FollowedDropped,
+	}
+	if len(result.Sections) != len(want) {
+		t.Fatalf("Expected %d sections, got %+v", len(want), result.Sections)
+	}
+	for _, sec := range result.Sections {
+		if sec.Followed != want[sec.Section] {
+			t.Errorf("Section %s followed %q, want %q", sec.Section, sec.Followed, want[sec.Section])
+		}
+	}
+	if result.Sections[3].HouseChanges != -1 || result.Sections[4].SenateChanges != -1 {
+		t.Errorf("Missing sections should have -1 changes: %+v", result.Sections)
+	}
+	if result.Both != 1 || result.House != 1 || result.Senate != 1 || result.Neither != 1 || result.Dropped != 1 {
+		t.Errorf("Unexpected counts: %+v", result)
+	}
+}
+
+func TestPreprocess(t *testing.T) {
+	printed := "\f                                                  [Page 2]\n•HR 1 EH\nSEC. 2. FUNDING.\n  (a) In General.--There is appropriated\n$500,000,000 for\nprograms.\n2\n\n\n\n(b) Period.--Over   5 years.\n<all>"
+	reflowed := "SEC. 2. FUNDING.\n(a) In General.--There is appropriated $500,000,000\nfor programs.\n\n(b) Period.--Over 5 years."
+
+	tests := []struct {
+		name string
+		opts diff_engine.Options
+		want string
+	}{
+		{"none", diff_engine.Options{}, printed},
+		{"page artifacts", diff_engine.Options{StripPageArtifacts: true},
+			"SEC. 2. FUNDING.\n  (a) In General.--There is appropriated\n$500,000,000 for\nprograms.\n\n\n\n(b) Period.--Over   5 years."},
+		{"all", diff_engine.Options{IgnoreWhitespace: true, IgnoreLineWrap: true, StripPageArtifacts: true},
+			"SEC. 2. FUNDING.\n(a) In General.--There is appropriated $500,000,000 for programs.\n\n(b) Period.--Over 5 years."},
+	}
+	for _, tt := range tests {
+		if got := diff_engine.Preprocess(printed, tt.opts); got != tt.want {
+			t.Errorf("%s: Preprocess =\n%q\nwant\n%q", tt.name, got, tt.want)
+		}
+	}
+
+	// With every option, the printed and reflowed renderings are identical
+	all := diff_engine.Options{IgnoreWhitespace: true, IgnoreLineWrap: true, StripPageArtifacts: true}
+	delta, err := diff_engine.ComputeWordLevel(diff_engine.Preprocess(printed, all), diff_engine.Preprocess(reflowed, all))
+	if err != nil {
+		t.Fatalf("ComputeWordLevel failed: %v", err)
+	}
+	if delta.Insertions != 0 || delta.Deletions != 0 {
+		t.Errorf("preprocessed diff has %d insertions and %d deletions, want none", delta.Insertions, delta.Deletions)
+	}
+}
+
+// TestComputeWordLevel_Sectioned verifies a diff computed section by
+// section numbers changes by their place in the whole text, including
+// sections inserted and removed, and is the same on every run.
+func TestComputeWordLevel_Sectioned(t *testing.T) {
+	var a, b strings.Builder
+	for i := 1; i <= 200; i++ {
+		fmt.Fprintf(&a, "SEC. %d. HEADING %d.\nText of section %d.\nMore text.\n\n", i, i, i)
+		switch {
+		case i == 50:
+			// Removed from b
+		case i%40 == 0:
+			fmt.Fprintf(&b, "SEC. %d. HEADING %d.\nAmended text of section %d.\nMore text.\n\n", i, i, i)
+		default:
+			fmt.Fprintf(&b, "SEC. %d. HEADING %d.\nText of section %d.\nMore text.\n\n", i, i, i)
+		}
+		if i == 100 {
+			b.WriteString("SEC. 100A. NEW.\nInserted.\n\n")
+		}
+	}
+
+	delta, err := diff_engine.ComputeWordLevel(a.String(), b.String())
+	if err != nil {
+		t.Fatalf("ComputeWordLevel failed: %v", err)
+	}
+	// Five amended lines, four removed, three inserted
+	if delta.Insertions != 8 || delta.Deletions != 9 {
+		t.Errorf("Expected +8/-9, got +%d/-%d", delta.Insertions, delta.Deletions)
+	}
+
+	linesA := strings.Split(a.String(), "\n")
+	linesB := strings.Split(b.String(), "\n")
+	for _, hunk := range delta.Hunks {
+		for _, change := range hunk.Lines {
+			if change.Type != diff_engine.ChangeInsert && linesA[change.LineA-1] != change.Content {
+				t.Errorf("Line %d of a is %q, diff has %q", change.LineA, linesA[change.LineA-1], change.Content)
+			}
+			if change.Type != diff_engine.ChangeDelete && linesB[change.LineB-1] != change.Content {
+				t.Errorf("Line %d of b is %q, diff has %q", change.LineB, linesB[change.LineB-1], change.Content)
+			}
+		}
+	}
+
+	for i := 0; i < 5; i++ {
+		again, err := diff_engine.ComputeWordLevel(a.String(), b.String())
+		if err != nil {
+			t.Fatalf("ComputeWordLevel failed: %v", err)
+		}
+		if diff_engine.Fingerprint(again) != diff_engine.Fingerprint(delta) {
+			t.Fatalf("Run %d produced a different fingerprint", i+2)
+		}
+	}
+}
+
+func TestDiffWords(t *testing.T) {
+	segments := diff_engine.DiffWords(
+		"There is appropriated\n  $500,000,000 for grants\nto States.",
+		"There is appropriated $750,000,000\nfor grants to States and tribes.",
+	)
+	var got strings.Builder
+	for _, s := range segments {
+		switch s.Type {
+		case diff_engine.ChangeDelete:
+			fmt.Fprintf(&got, "[-%s-]", s.Text)
+		case diff_engine.ChangeInsert:
+			fmt.Fprintf(&got, "{+%s+}", s.Text)
+		default:
+			got.WriteString(s.Text)
+		}
+	}
+	want := "There is appropriated[-\n$500,000,000-]{+ $750,000,000+}\nfor grants to[- States.-]{+ States and tribes.+}"
+	if got.String() != want {
+		t.Errorf("DiffWords =\n%q\nwant\n%q", got.String(), want)
+	}
+
+	if s := diff_engine.DiffWords("Same  text\n\nhere.", "Same text\nhere."); len(s) != 1 || s[0].Type != diff_engine.ChangeUnchanged {
+		t.Errorf("DiffWords of reformatted text = %+v, want one unchanged run", s)
+	}
+}
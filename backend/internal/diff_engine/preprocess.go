@@ -0,0 +1,110 @@
+package diff_engine
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Options selects preprocessing applied to both texts before diffing, to
+// suppress differences in how a text was rendered rather than what it
+// says. Line numbers in the resulting delta refer to the preprocessed
+// texts.
+type Options struct {
+	IgnoreWhitespace   bool // Trim lines, collapse runs of spaces and tabs, and collapse runs of blank lines
+	IgnoreLineWrap     bool // Join lines re-wrapped within a paragraph, so each paragraph is one line
+	StripPageArtifacts bool // Drop form feeds, page markers, running headers, and bare page numbers
+}
+
+// IsZero reports whether no preprocessing is selected.
+func (o Options) IsZero() bool {
+	return o == Options{}
+}
+
+var (
+	// Page markers of GPO text, e.g., "[Page 12]" or "[[Page H1234]]"
+	pageMarkerPattern = regexp.MustCompile(`^\[{1,2}Page [A-Z]?\d+\]{1,2}$`)
+
+	// Running headers of printed bills, e.g., "•HR 1 EH" or "S.J. Res. 5 IS"
+	runningHeaderPattern = regexp.MustCompile(`^[•·]?\s*(?i:H\.?\s?R|S|H\.?\s?J\.?\s?RES|S\.?\s?J\.?\s?RES|H\.?\s?CON\.?\s?RES|S\.?\s?CON\.?\s?RES|H\.?\s?RES|S\.?\s?RES)\.?\s*\d+\s+[A-Z]{2,4}$`)
+
+	// Printing plant slugs of GPO PDFs, e.g., "VerDate Sep 11 2014 ..."
+	printSlugPattern = regexp.MustCompile(`^(VerDate|Jkt|PO \d+ Frm) `)
+
+	// Bare page numbers
+	pageNumberPattern = regexp.MustCompile(`^\d{1,4}$`)
+
+	// Lines that start a new structural unit rather than continue the
+	// previous one: section and division headings and enumerated
+	// paragraphs, e.g., "SEC. 2.", "TITLE I", "(a)", "(1)", "(A)", "(i)"
+	unitStartPattern = regexp.MustCompile(`^(SECTION|SEC\.|Sec\.|TITLE|Title|Subtitle|SUBTITLE|DIVISION|Division|CHAPTER|Chapter|PART|Part|\([0-9A-Za-z]{1,6}\)|"|“)`)
+
+	spaceRunPattern = regexp.MustCompile(`[ \t\x{00A0}]+`)
+)
+
+// Preprocess returns text with the preprocessing opts select applied.
+// Page artifacts are stripped first, then wrapped lines joined, then
+// whitespace normalized.
+func Preprocess(text string, opts Options) string {
+	if opts.IsZero() {
+		return text
+	}
+	lines := strings.Split(text, "\n")
+	if opts.StripPageArtifacts {
+		lines = stripPageArtifacts(lines)
+	}
+	if opts.IgnoreLineWrap {
+		lines = joinWrappedLines(lines)
+	}
+	if opts.IgnoreWhitespace {
+		lines = normalizeWhitespace(lines)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// stripPageArtifacts drops lines that are page furniture rather than text.
+func stripPageArtifacts(lines []string) []string {
+	kept := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.ReplaceAll(line, "\f", "")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "<all>" || pageMarkerPattern.MatchString(trimmed) ||
+			runningHeaderPattern.MatchString(trimmed) || printSlugPattern.MatchString(trimmed) ||
+			pageNumberPattern.MatchString(trimmed) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return kept
+}
+
+// joinWrappedLines joins each line onto the previous one unless it is
+// blank, follows a blank line, or starts a new structural unit.
+func joinWrappedLines(lines []string) []string {
+	var joined []string
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		continues := i > 0 && trimmed != "" && strings.TrimSpace(lines[i-1]) != "" &&
+			!unitStartPattern.MatchString(trimmed)
+		if !continues {
+			joined = append(joined, line)
+			continue
+		}
+		last := len(joined) - 1
+		joined[last] = strings.TrimRight(joined[last], " \t") + " " + trimmed
+	}
+	return joined
+}
+
+// normalizeWhitespace trims each line, collapses runs of spaces and tabs
+// within it, and collapses runs of blank lines to one.
+func normalizeWhitespace(lines []string) []string {
+	var normalized []string
+	for _, line := range lines {
+		line = strings.TrimSpace(spaceRunPattern.ReplaceAllString(line, " "))
+		if line == "" && len(normalized) > 0 && normalized[len(normalized)-1] == "" {
+			continue
+		}
+		normalized = append(normalized, line)
+	}
+	return normalized
+}
@@ -0,0 +1,107 @@
+package diff_engine
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/aymanbagabas/go-udiff"
+	"github.com/aymanbagabas/go-udiff/myers"
+
+	"github.com/drewjst/deltagov/internal/analysis"
+)
+
+// chunk is a span of each text, as byte offsets, diffed on its own.
+type chunk struct {
+	startA, endA int
+	startB, endB int
+}
+
+// computeEdits returns the line edits turning textA into textB. Texts are
+// split into chunks at the sections they share (see sectionChunks), and
+// chunks are diffed concurrently by a pool of GOMAXPROCS workers, since
+// Myers' cost grows with the square of the differences and an omnibus
+// bill's are spread across thousands of sections. Edits are reassembled
+// in text order, so the result doesn't depend on scheduling; it can
+// differ from diffing the whole texts at once only where a section's text
+// would otherwise align with a different section.
+func computeEdits(textA, textB string) []udiff.Edit {
+	chunks := sectionChunks(textA, textB)
+	if len(chunks) < 2 {
+		return myers.ComputeEdits(textA, textB)
+	}
+
+	queue := make(chan int)
+	results := make([][]udiff.Edit, len(chunks))
+
+	var wg sync.WaitGroup
+	for range min(runtime.GOMAXPROCS(0), len(chunks)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				c := chunks[i]
+				a, b := textA[c.startA:c.endA], textB[c.startB:c.endB]
+				if a == b {
+					continue
+				}
+				edits := myers.ComputeEdits(a, b)
+				for k := range edits {
+					edits[k].Start += c.startA
+					edits[k].End += c.startA
+				}
+				results[i] = edits
+			}
+		}()
+	}
+	for i := range chunks {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+
+	var edits []udiff.Edit
+	for _, r := range results {
+		edits = append(edits, r...)
+	}
+	return edits
+}
+
+// sectionChunks splits textA and textB before each section they share,
+// matched by number as in ComputeThreeWay. Sections are taken in textA's
+// order, skipping any whose match in textB comes before the previous
+// one's, so chunks never cross; a section moved far from its place just
+// leaves its neighbours in a larger chunk. Chunk boundaries fall at line
+// starts, so each chunk's edits are whole lines.
+func sectionChunks(textA, textB string) []chunk {
+	sectionsA := analysis.SplitSections(textA)
+	sectionsB := analysis.SplitSections(textB)
+	if len(sectionsA) == 0 || len(sectionsB) == 0 {
+		return nil
+	}
+
+	keysB := sectionKeys(sectionsB)
+	indexB := make(map[string]int, len(keysB))
+	for i, key := range keysB {
+		indexB[key] = i
+	}
+
+	var chunks []chunk
+	current := chunk{}
+	lastB := -1
+	for i, key := range sectionKeys(sectionsA) {
+		j, ok := indexB[key]
+		if !ok || j <= lastB {
+			continue
+		}
+		lastB = j
+		startA, startB := sectionsA[i].Start, sectionsB[j].Start
+		if startA == current.startA && startB == current.startB {
+			continue // Both texts open with this section
+		}
+		current.endA, current.endB = startA, startB
+		chunks = append(chunks, current)
+		current = chunk{startA: startA, startB: startB}
+	}
+	current.endA, current.endB = len(textA), len(textB)
+	return append(chunks, current)
+}
@@ -0,0 +1,180 @@
+package diff_engine
+
+import (
+	"strconv"
+
+	"github.com/drewjst/deltagov/internal/analysis"
+)
+
+// Source records which chamber's text a reconciled section followed.
+type Source string
+
+const (
+	FollowedBoth    Source = "both"    // The House and Senate agree, and the base kept their text
+	FollowedHouse   Source = "house"   // The base matches the House text only
+	FollowedSenate  Source = "senate"  // The base matches the Senate text only
+	FollowedNeither Source = "neither" // The base differs from both, or only it has the section
+	FollowedDropped Source = "dropped" // A chamber has the section but the base doesn't
+)
+
+// ThreeWaySection compares one section of the base text with the same
+// section of each chamber's version.
+type ThreeWaySection struct {
+	Section       string `json:"section"` // e.g., "201"; empty for text without section headers
+	Heading       string `json:"heading"`
+	Anchor        string `json:"anchor"` // See analysis.Section.Anchor
+	Followed      Source `json:"followed"`
+	HouseChanges  int    `json:"house_changes"`  // Lines inserted or deleted from the House section; -1 when the House version lacks it
+	SenateChanges int    `json:"senate_changes"` // Lines inserted or deleted from the Senate section; -1 when the Senate version lacks it
+}
+
+// ThreeWay is the section-by-section reconciliation of a base text against
+// House and Senate versions, with a count of sections per Source.
+type ThreeWay struct {
+	Sections []ThreeWaySection `json:"sections"`
+	Both     int               `json:"both"`
+	House    int               `json:"house"`
+	Senate   int               `json:"senate"`
+	Neither  int               `json:"neither"`
+	Dropped  int               `json:"dropped"`
+}
+
+// ComputeThreeWay reports, for each section of base (typically conference
+// or enacted text), whether it followed the House version, the Senate
+// version, both, or neither. Sections are matched by number, so the nth
+// "SEC. 101." in one text matches the nth in another; text without section
+// headers is compared as a single section. Whitespace and case differences
+// are ignored. Sections that only the chambers have are listed last, as
+// dropped.
+func ComputeThreeWay(base, houseVersion, senateVersion string) (*ThreeWay, error) {
+	baseSections := keyedSections(base)
+	house := keyedSections(houseVersion)
+	senate := keyedSections(senateVersion)
+
+	result := &ThreeWay{Sections: []ThreeWaySection{}}
+	listed := make(map[string]bool, len(baseSections.keys))
+
+	for _, key := range baseSections.keys {
+		sec := baseSections.byKey[key]
+		houseChanges, err := sectionChanges(sec, house.byKey[key])
+		if err != nil {
+			return nil, err
+		}
+		senateChanges, err := sectionChanges(sec, senate.byKey[key])
+		if err != nil {
+			return nil, err
+		}
+
+		followed := FollowedNeither
+		switch {
+		case houseChanges == 0 && senateChanges == 0:
+			followed = FollowedBoth
+		case houseChanges == 0:
+			followed = FollowedHouse
+		case senateChanges == 0:
+			followed = FollowedSenate
+		}
+		result.add(sec, followed, houseChanges, senateChanges)
+		listed[key] = true
+	}
+
+	for _, chamber := range []keyed{house, senate} {
+		for _, key := range chamber.keys {
+			if listed[key] {
+				continue
+			}
+			listed[key] = true
+			houseChanges, senateChanges := -1, -1
+			if _, ok := house.byKey[key]; ok {
+				houseChanges = 0
+			}
+			if _, ok := senate.byKey[key]; ok {
+				senateChanges = 0
+			}
+			result.add(chamber.byKey[key], FollowedDropped, houseChanges, senateChanges)
+		}
+	}
+
+	return result, nil
+}
+
+// add appends a section and counts it under followed.
+func (t *ThreeWay) add(sec *analysis.Section, followed Source, houseChanges, senateChanges int) {
+	t.Sections = append(t.Sections, ThreeWaySection{
+		Section:       sec.Number,
+		Heading:       sec.Heading,
+		Anchor:        sec.Anchor(),
+		Followed:      followed,
+		HouseChanges:  houseChanges,
+		SenateChanges: senateChanges,
+	})
+	switch followed {
+	case FollowedBoth:
+		t.Both++
+	case FollowedHouse:
+		t.House++
+	case FollowedSenate:
+		t.Senate++
+	case FollowedNeither:
+		t.Neither++
+	case FollowedDropped:
+		t.Dropped++
+	}
+}
+
+// keyed is a text's sections by match key, in text order.
+type keyed struct {
+	keys  []string
+	byKey map[string]*analysis.Section
+}
+
+// keyedSections splits text into sections keyed by sectionKeys.
+func keyedSections(text string) keyed {
+	sections := analysis.SplitSections(text)
+	if len(sections) == 0 {
+		sections = []analysis.Section{{Body: text}}
+	}
+
+	k := keyed{keys: sectionKeys(sections), byKey: make(map[string]*analysis.Section, len(sections))}
+	for i, key := range k.keys {
+		k.byKey[key] = &sections[i]
+	}
+	return k
+}
+
+// sectionKeys returns the match key of each section: its number and
+// occurrence, e.g., "101" then "101#2" for a number repeated in another
+// division.
+func sectionKeys(sections []analysis.Section) []string {
+	keys := make([]string, len(sections))
+	seen := make(map[string]int, len(sections))
+	for i, sec := range sections {
+		key := sec.Number
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			key += "#" + strconv.Itoa(n)
+		}
+		keys[i] = key
+	}
+	return keys
+}
+
+// sectionChanges returns the number of lines inserted or deleted between
+// two versions of a section, 0 when they differ only in whitespace or case,
+// or -1 when other is missing.
+func sectionChanges(sec, other *analysis.Section) (int, error) {
+	if other == nil {
+		return -1, nil
+	}
+	a := sec.Heading + "\n" + sec.Body
+	b := other.Heading + "\n" + other.Body
+	if analysis.NormalizeForComparison(a) == analysis.NormalizeForComparison(b) {
+		return 0, nil
+	}
+	delta, err := Compute(b, a, "", "")
+	if err != nil {
+		return 0, err
+	}
+	// Guard against a zero count, which would read as a match
+	return max(delta.Insertions+delta.Deletions, 1), nil
+}
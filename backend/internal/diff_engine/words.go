@@ -0,0 +1,101 @@
+package diff_engine
+
+import (
+	"strings"
+
+	"github.com/aymanbagabas/go-udiff/myers"
+)
+
+// Segment is a run of text a word diff keeps, deletes, or inserts.
+type Segment struct {
+	Type ChangeType
+	Text string
+}
+
+// DiffWords diffs two texts word by word, returning runs of unchanged,
+// deleted, and inserted text in order, for redlines where a line diff
+// would mark a whole paragraph for one changed word. Only words are
+// compared: in the runs, words are separated by single spaces, or by
+// "\n" where a line broke, following textB's lines except in deleted
+// runs, which follow textA's.
+func DiffWords(textA, textB string) []Segment {
+	a, b := splitWords(textA), splitWords(textB)
+
+	// Each word becomes a line, so the line diff is a word diff, and
+	// offsets of edits in joined map back to word indexes
+	offsets := make(map[int]int, len(a)+1)
+	var joined, joinedB strings.Builder
+	for i, w := range a {
+		offsets[joined.Len()] = i
+		joined.WriteString(w.text)
+		joined.WriteByte('\n')
+	}
+	offsets[joined.Len()] = len(a)
+	for _, w := range b {
+		joinedB.WriteString(w.text)
+		joinedB.WriteByte('\n')
+	}
+
+	var out wordWriter
+	nextA, nextB := 0, 0
+	for _, edit := range myers.ComputeEdits(joined.String(), joinedB.String()) {
+		start, end := offsets[edit.Start], offsets[edit.End]
+		kept := start - nextA
+		out.write(ChangeUnchanged, b[nextB:nextB+kept])
+		nextB += kept
+		out.write(ChangeDelete, a[start:end])
+		if edit.New != "" {
+			inserted := strings.Count(edit.New, "\n")
+			out.write(ChangeInsert, b[nextB:nextB+inserted])
+			nextB += inserted
+		}
+		nextA = end
+	}
+	out.write(ChangeUnchanged, b[nextB:])
+	return out.segments
+}
+
+// word is a word of a text diffed by DiffWords.
+type word struct {
+	text      string
+	lineStart bool // First word of a line other than the first
+}
+
+// splitWords splits text into words.
+func splitWords(text string) []word {
+	var words []word
+	for _, line := range strings.Split(text, "\n") {
+		for i, f := range strings.Fields(line) {
+			words = append(words, word{text: f, lineStart: i == 0 && len(words) > 0})
+		}
+	}
+	return words
+}
+
+// wordWriter assembles words into segments, merging runs of a type.
+type wordWriter struct {
+	segments []Segment
+	started  bool
+}
+
+func (w *wordWriter) write(t ChangeType, words []word) {
+	if len(words) == 0 {
+		return
+	}
+	var b strings.Builder
+	for _, wd := range words {
+		switch {
+		case wd.lineStart && w.started:
+			b.WriteByte('\n')
+		case w.started:
+			b.WriteByte(' ')
+		}
+		b.WriteString(wd.text)
+		w.started = true
+	}
+	if n := len(w.segments); n > 0 && w.segments[n-1].Type == t {
+		w.segments[n-1].Text += b.String()
+		return
+	}
+	w.segments = append(w.segments, Segment{Type: t, Text: b.String()})
+}
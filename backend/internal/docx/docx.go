@@ -0,0 +1,215 @@
+// Package docx writes Word documents (Office Open XML WordprocessingML,
+// ECMA-376): headings, paragraphs, simple tables, and text marked as
+// tracked insertions and deletions, which Word shows as redlines its
+// users can review, accept, or reject.
+package docx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ContentType is the media type for Word documents.
+const ContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+
+// Revision marks a run as a tracked change.
+type Revision int
+
+// Revisions of a run.
+const (
+	Unchanged Revision = iota
+	Inserted
+	Deleted
+)
+
+// Run is text with the same formatting.
+type Run struct {
+	Text     string // Line breaks ("\n") become breaks within the paragraph
+	Bold     bool
+	Italic   bool
+	Revision Revision
+}
+
+// Document is a Word document being built. Its zero value isn't usable;
+// create one with New.
+type Document struct {
+	title  string
+	author string
+	date   time.Time
+	body   strings.Builder
+	nextID int
+}
+
+// New creates an empty document. author and date are recorded as its
+// creator and as the author and date of its tracked changes.
+func New(title, author string, date time.Time) *Document {
+	return &Document{title: title, author: author, date: date.UTC().Truncate(time.Second)}
+}
+
+// Title adds the document's title paragraph.
+func (d *Document) Title(text string) {
+	d.paragraph("Title", []Run{{Text: text}})
+}
+
+// Heading adds a heading of level 1 or 2.
+func (d *Document) Heading(level int, text string) {
+	d.paragraph(fmt.Sprintf("Heading%d", min(max(level, 1), 2)), []Run{{Text: text}})
+}
+
+// Paragraph adds a paragraph of runs.
+func (d *Document) Paragraph(runs ...Run) {
+	d.paragraph("", runs)
+}
+
+// Table adds a table with a bold header row.
+func (d *Document) Table(header []string, rows [][]string) {
+	b := &d.body
+	b.WriteString(`<w:tbl><w:tblPr><w:tblStyle w:val="TableGrid"/><w:tblW w:w="0" w:type="auto"/></w:tblPr>`)
+	for i, row := range append([][]string{header}, rows...) {
+		b.WriteString(`<w:tr>`)
+		for _, cell := range row {
+			b.WriteString(`<w:tc><w:tcPr><w:tcW w:w="0" w:type="auto"/></w:tcPr><w:p>`)
+			d.run(Run{Text: cell, Bold: i == 0})
+			b.WriteString(`</w:p></w:tc>`)
+		}
+		b.WriteString(`</w:tr>`)
+	}
+	b.WriteString(`</w:tbl>`)
+}
+
+func (d *Document) paragraph(style string, runs []Run) {
+	b := &d.body
+	b.WriteString(`<w:p>`)
+	if style != "" {
+		fmt.Fprintf(b, `<w:pPr><w:pStyle w:val="%s"/></w:pPr>`, style)
+	}
+	for _, r := range runs {
+		d.run(r)
+	}
+	b.WriteString(`</w:p>`)
+}
+
+// run writes a run, wrapped in an insertion or deletion when it is one.
+func (d *Document) run(r Run) {
+	if r.Text == "" {
+		return
+	}
+	b := &d.body
+	if r.Revision != Unchanged {
+		tag := "w:ins"
+		if r.Revision == Deleted {
+			tag = "w:del"
+		}
+		d.nextID++
+		fmt.Fprintf(b, `<%s w:id="%d" w:author="%s" w:date="%s">`, tag, d.nextID, escape(d.author), d.date.Format(time.RFC3339))
+		defer fmt.Fprintf(b, `</%s>`, tag)
+	}
+
+	b.WriteString(`<w:r>`)
+	if r.Bold || r.Italic {
+		b.WriteString(`<w:rPr>`)
+		if r.Bold {
+			b.WriteString(`<w:b/>`)
+		}
+		if r.Italic {
+			b.WriteString(`<w:i/>`)
+		}
+		b.WriteString(`</w:rPr>`)
+	}
+	text := "w:t"
+	if r.Revision == Deleted {
+		text = "w:delText"
+	}
+	for i, line := range strings.Split(r.Text, "\n") {
+		if i > 0 {
+			b.WriteString(`<w:br/>`)
+		}
+		if line != "" {
+			fmt.Fprintf(b, `<%s xml:space="preserve">%s</%s>`, text, escape(line), text)
+		}
+	}
+	b.WriteString(`</w:r>`)
+}
+
+// Write writes the document as a .docx package.
+func (d *Document) Write(w io.Writer) error {
+	z := zip.NewWriter(w)
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", packageRels},
+		{"docProps/core.xml", fmt.Sprintf(coreProps, escape(d.title), escape(d.author), d.date.Format(time.RFC3339), d.date.Format(time.RFC3339))},
+		{"word/_rels/document.xml.rels", documentRels},
+		{"word/styles.xml", styles},
+		{"word/document.xml", xml.Header + `<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` +
+			d.body.String() + `<w:sectPr><w:pgSz w:w="12240" w:h="15840"/><w:pgMar w:top="1440" w:right="1440" w:bottom="1440" w:left="1440" w:header="720" w:footer="720" w:gutter="0"/></w:sectPr></w:body></w:document>`},
+	}
+	for _, p := range parts {
+		f, err := z.Create(p.name)
+		if err != nil {
+			return fmt.Errorf("docx: failed to add %s: %w", p.name, err)
+		}
+		if _, err := io.WriteString(f, p.content); err != nil {
+			return fmt.Errorf("docx: failed to write %s: %w", p.name, err)
+		}
+	}
+	return z.Close()
+}
+
+// escape escapes text for XML, dropping characters XML can't hold, such
+// as the form feeds between pages of printed bill text.
+func escape(s string) string {
+	clean := strings.Map(func(r rune) rune {
+		if r == '\t' || r == '\n' || r == '\r' || (r >= 0x20 && r != utf8.RuneError && r != 0xFFFE && r != 0xFFFF) {
+			return r
+		}
+		return -1
+	}, s)
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(clean))
+	return b.String()
+}
+
+const contentTypes = xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+	`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+	`<Default Extension="xml" ContentType="application/xml"/>` +
+	`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/>` +
+	`<Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/>` +
+	`<Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/>` +
+	`</Types>`
+
+const packageRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/>` +
+	`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/>` +
+	`</Relationships>`
+
+const documentRels = xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+	`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+	`</Relationships>`
+
+const coreProps = xml.Header + `<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" ` +
+	`xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance">` +
+	`<dc:title>%s</dc:title><dc:creator>%s</dc:creator>` +
+	`<dcterms:created xsi:type="dcterms:W3CDTF">%s</dcterms:created><dcterms:modified xsi:type="dcterms:W3CDTF">%s</dcterms:modified>` +
+	`</cp:coreProperties>`
+
+const styles = xml.Header + `<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+	`<w:docDefaults><w:rPrDefault><w:rPr><w:rFonts w:ascii="Calibri" w:hAnsi="Calibri" w:cs="Calibri"/><w:sz w:val="22"/></w:rPr></w:rPrDefault>` +
+	`<w:pPrDefault><w:pPr><w:spacing w:after="120"/></w:pPr></w:pPrDefault></w:docDefaults>` +
+	`<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/>` +
+	`<w:pPr><w:spacing w:after="240"/></w:pPr><w:rPr><w:b/><w:sz w:val="40"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/>` +
+	`<w:pPr><w:keepNext/><w:spacing w:before="360" w:after="120"/><w:outlineLvl w:val="0"/></w:pPr><w:rPr><w:b/><w:sz w:val="32"/></w:rPr></w:style>` +
+	`<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/>` +
+	`<w:pPr><w:keepNext/><w:spacing w:before="240" w:after="80"/><w:outlineLvl w:val="1"/></w:pPr><w:rPr><w:b/><w:sz w:val="26"/></w:rPr></w:style>` +
+	`<w:style w:type="table" w:styleId="TableGrid"><w:name w:val="Table Grid"/><w:tblPr><w:tblBorders>` +
+	`<w:top w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:left w:val="single" w:sz="4" w:space="0" w:color="auto"/>` +
+	`<w:bottom w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:right w:val="single" w:sz="4" w:space="0" w:color="auto"/>` +
+	`<w:insideH w:val="single" w:sz="4" w:space="0" w:color="auto"/><w:insideV w:val="single" w:sz="4" w:space="0" w:color="auto"/>` +
+	`</w:tblBorders><w:tblCellMar><w:left w:w="108" w:type="dxa"/><w:right w:w="108" w:type="dxa"/></w:tblCellMar></w:tblPr></w:style>` +
+	`</w:styles>`
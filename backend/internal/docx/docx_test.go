@@ -0,0 +1,81 @@
+package docx_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/docx"
+)
+
+func TestWrite(t *testing.T) {
+	doc := docx.New("Change report", "DeltaGov", time.Date(2025, 6, 3, 14, 0, 0, 0, time.UTC))
+	doc.Title("Change report: HR 1 (119th)")
+	doc.Heading(1, "Summary")
+	doc.Table([]string{"", "From", "To"}, [][]string{{"Words", "1,200", "1,350"}})
+	doc.Heading(2, "SEC. 2. FUNDING.")
+	doc.Paragraph(
+		docx.Run{Text: "There is appropriated "},
+		docx.Run{Text: "$500,000,000", Revision: docx.Deleted},
+		docx.Run{Text: "$750,000,000", Revision: docx.Inserted},
+		docx.Run{Text: " for grants & loans\n\fto States <and tribes>.", Italic: true},
+	)
+
+	var buf bytes.Buffer
+	if err := doc.Write(&buf); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	z, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("not a zip package: %v", err)
+	}
+
+	parts := map[string]string{}
+	for _, f := range z.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		content, _ := io.ReadAll(r)
+		r.Close()
+		parts[f.Name] = string(content)
+
+		// Every part is well-formed XML
+		dec := xml.NewDecoder(bytes.NewReader(content))
+		for {
+			if _, err := dec.Token(); err != nil {
+				if !errors.Is(err, io.EOF) {
+					t.Errorf("%s is not well-formed: %v", f.Name, err)
+				}
+				break
+			}
+		}
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "word/document.xml", "word/styles.xml", "word/_rels/document.xml.rels", "docProps/core.xml"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("package is missing %s", name)
+		}
+	}
+
+	body := parts["word/document.xml"]
+	for _, want := range []string{
+		`<w:pStyle w:val="Title"/>`,
+		`<w:pStyle w:val="Heading2"/>`,
+		`<w:del w:id="1" w:author="DeltaGov" w:date="2025-06-03T14:00:00Z"><w:r><w:delText xml:space="preserve">$500,000,000</w:delText></w:r></w:del>`,
+		`<w:ins w:id="2" w:author="DeltaGov" w:date="2025-06-03T14:00:00Z"><w:r><w:t xml:space="preserve">$750,000,000</w:t></w:r></w:ins>`,
+		`grants &amp; loans</w:t><w:br/><w:t xml:space="preserve">to States &lt;and tribes&gt;.`,
+		`<w:tblStyle w:val="TableGrid"/>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("document.xml is missing %s", want)
+		}
+	}
+	if !strings.Contains(parts["docProps/core.xml"], "<dc:title>Change report</dc:title>") {
+		t.Error("core properties are missing the title")
+	}
+}
@@ -0,0 +1,278 @@
+// Package embeddings computes vector embeddings of bills' CRS summaries and
+// the sections of their latest text, stores them with pgvector, and finds
+// the summaries and sections nearest a query, for semantic search and
+// "bills like this one".
+//
+// Embeddings come from a pluggable Provider: an OpenAI-compatible
+// embeddings API, or the built-in hashing provider, which needs no
+// service but only captures shared vocabulary.
+package embeddings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Provider computes embeddings of texts.
+type Provider interface {
+	// Name identifies the provider and model, e.g., "hashing:512".
+	// Embeddings from different providers are kept apart.
+	Name() string
+
+	// Dimensions is the length of the provider's vectors.
+	Dimensions() int
+
+	// Embed returns an embedding of each text, in order.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// FromEnv returns the provider selected by EMBEDDINGS_PROVIDER: "hashing"
+// (see Hashing; EMBEDDINGS_DIMENSIONS sets its size), "openai"
+// (configured by the EMBEDDINGS_URL, EMBEDDINGS_API_KEY, EMBEDDINGS_MODEL,
+// and EMBEDDINGS_DIMENSIONS variables; see NewOpenAI), or "" or "none",
+// which returns nil to disable embeddings.
+func FromEnv() (Provider, error) {
+	dims := 0
+	if s := os.Getenv("EMBEDDINGS_DIMENSIONS"); s != "" {
+		parsed, err := strconv.Atoi(s)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("embeddings: invalid EMBEDDINGS_DIMENSIONS %q", s)
+		}
+		dims = parsed
+	}
+
+	switch kind := os.Getenv("EMBEDDINGS_PROVIDER"); kind {
+	case "", "none":
+		return nil, nil
+	case "hashing":
+		return NewHashing(dims), nil
+	case "openai":
+		return NewOpenAI(OpenAIConfig{
+			BaseURL:    os.Getenv("EMBEDDINGS_URL"),
+			APIKey:     os.Getenv("EMBEDDINGS_API_KEY"),
+			Model:      os.Getenv("EMBEDDINGS_MODEL"),
+			Dimensions: dims,
+		})
+	default:
+		return nil, fmt.Errorf("embeddings: unknown EMBEDDINGS_PROVIDER %q, want hashing, openai, or none", kind)
+	}
+}
+
+// DefaultHashingDimensions is the size of Hashing vectors when none is
+// given.
+const DefaultHashingDimensions = 512
+
+// Hashing embeds text by feature hashing: each word and pair of adjacent
+// words adds to a signed bucket, weighted sublinearly by count, and the
+// vector is normalized. Texts sharing distinctive vocabulary come out
+// close; synonyms don't. It needs no service, so it suits development and
+// deployments without a model.
+type Hashing struct {
+	dims int
+}
+
+// NewHashing creates a hashing provider with dims dimensions
+// (DefaultHashingDimensions if zero or less).
+func NewHashing(dims int) *Hashing {
+	if dims <= 0 {
+		dims = DefaultHashingDimensions
+	}
+	return &Hashing{dims: dims}
+}
+
+// Name returns "hashing:" and the dimensions.
+func (h *Hashing) Name() string {
+	return "hashing:" + strconv.Itoa(h.dims)
+}
+
+// Dimensions returns the vector size.
+func (h *Hashing) Dimensions() int {
+	return h.dims
+}
+
+// Embed hashes each text.
+func (h *Hashing) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, len(texts))
+	for i, t := range texts {
+		out[i] = h.embed(t)
+	}
+	return out, nil
+}
+
+func (h *Hashing) embed(text string) []float32 {
+	counts := map[string]int{}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	prev := ""
+	for _, w := range words {
+		if len(w) < 2 || stopWords[w] {
+			prev = ""
+			continue
+		}
+		counts[w]++
+		if prev != "" {
+			counts[prev+" "+w]++
+		}
+		prev = w
+	}
+
+	v := make([]float32, h.dims)
+	for feature, n := range counts {
+		f := fnv.New64a()
+		f.Write([]byte(feature))
+		sum := f.Sum64()
+		weight := float32(1 + math.Log(float64(n)))
+		if sum&(1<<63) != 0 {
+			weight = -weight
+		}
+		v[sum%uint64(h.dims)] += weight
+	}
+	normalize(v)
+	return v
+}
+
+// stopWords are words too common in bills to say what one is about.
+var stopWords = map[string]bool{
+	"the": true, "of": true, "and": true, "to": true, "in": true, "a": true, "for": true, "or": true,
+	"by": true, "be": true, "is": true, "as": true, "on": true, "that": true, "such": true, "this": true,
+	"with": true, "any": true, "an": true, "under": true, "shall": true, "section": true, "sec": true,
+	"act": true, "subsection": true, "paragraph": true, "which": true, "from": true, "at": true,
+}
+
+// normalize scales v to unit length, leaving zero vectors as they are.
+func normalize(v []float32) {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return
+	}
+	scale := float32(1 / math.Sqrt(sum))
+	for i := range v {
+		v[i] *= scale
+	}
+}
+
+const (
+	defaultOpenAIURL        = "https://api.openai.com/v1"
+	defaultOpenAIModel      = "text-embedding-3-small"
+	defaultOpenAIDimensions = 1536
+	defaultOpenAITimeout    = 60 * time.Second
+)
+
+// OpenAIConfig configures an OpenAI-compatible embeddings provider.
+type OpenAIConfig struct {
+	BaseURL    string       // API base URL (default: OpenAI's)
+	APIKey     string       // Bearer token (required)
+	Model      string       // Model name (default: text-embedding-3-small)
+	Dimensions int          // Vector size (default: 1536); sent to the API when not the default
+	HTTPClient *http.Client // Optional; defaults to one with a 60s timeout
+}
+
+// OpenAI computes embeddings with an OpenAI-compatible embeddings API.
+type OpenAI struct {
+	cfg OpenAIConfig
+}
+
+// NewOpenAI creates an OpenAI-compatible provider.
+func NewOpenAI(cfg OpenAIConfig) (*OpenAI, error) {
+	if cfg.APIKey == "" {
+		return nil, errors.New("embeddings: the openai provider requires an API key")
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = defaultOpenAIURL
+	}
+	cfg.BaseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+	if cfg.Model == "" {
+		cfg.Model = defaultOpenAIModel
+	}
+	if cfg.Dimensions <= 0 {
+		cfg.Dimensions = defaultOpenAIDimensions
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: defaultOpenAITimeout}
+	}
+	return &OpenAI{cfg: cfg}, nil
+}
+
+// Name returns "openai:" and the model name, and the dimensions when not
+// the default.
+func (o *OpenAI) Name() string {
+	name := "openai:" + o.cfg.Model
+	if o.cfg.Dimensions != defaultOpenAIDimensions {
+		name += ":" + strconv.Itoa(o.cfg.Dimensions)
+	}
+	return name
+}
+
+// Dimensions returns the vector size.
+func (o *OpenAI) Dimensions() int {
+	return o.cfg.Dimensions
+}
+
+// Embed requests embeddings of texts in one request.
+func (o *OpenAI) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	request := map[string]any{"model": o.cfg.Model, "input": texts}
+	if o.cfg.Dimensions != defaultOpenAIDimensions {
+		request["dimensions"] = o.cfg.Dimensions
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, o.cfg.BaseURL+"/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("embeddings: failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+o.cfg.APIKey)
+
+	resp, err := o.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embeddings: request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("embeddings: API returned status %d", resp.StatusCode)
+	}
+
+	var reply struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float32 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return nil, fmt.Errorf("embeddings: failed to decode reply: %w", err)
+	}
+	out := make([][]float32, len(texts))
+	for _, d := range reply.Data {
+		if d.Index < 0 || d.Index >= len(out) {
+			return nil, fmt.Errorf("embeddings: reply has index %d for %d inputs", d.Index, len(texts))
+		}
+		if len(d.Embedding) != o.cfg.Dimensions {
+			return nil, fmt.Errorf("embeddings: reply has %d dimensions, want %d", len(d.Embedding), o.cfg.Dimensions)
+		}
+		out[d.Index] = d.Embedding
+	}
+	for i, v := range out {
+		if v == nil {
+			return nil, fmt.Errorf("embeddings: reply is missing input %d", i)
+		}
+	}
+	return out, nil
+}
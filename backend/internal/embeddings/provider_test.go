@@ -0,0 +1,117 @@
+package embeddings_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/embeddings"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+func cosine(a, b []float32) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	return dot / math.Sqrt(na*nb)
+}
+
+func TestHashing(t *testing.T) {
+	h := embeddings.NewHashing(0)
+	if h.Dimensions() != embeddings.DefaultHashingDimensions || h.Name() != "hashing:512" {
+		t.Fatalf("NewHashing(0) = %s with %d dimensions", h.Name(), h.Dimensions())
+	}
+
+	vectors, err := h.Embed(context.Background(), []string{
+		"Amounts appropriated for military construction of Army family housing.",
+		"Funds appropriated for the construction of family housing for the Army.",
+		"Expands eligibility for the child tax credit and the earned income credit.",
+		"",
+	})
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if len(vectors) != 4 || len(vectors[0]) != 512 {
+		t.Fatalf("Embed returned %d vectors of %d dimensions", len(vectors), len(vectors[0]))
+	}
+	similar, unrelated := cosine(vectors[0], vectors[1]), cosine(vectors[0], vectors[2])
+	if similar <= unrelated {
+		t.Errorf("similar texts scored %.3f, unrelated %.3f", similar, unrelated)
+	}
+	if n := cosine(vectors[0], vectors[0]); math.Abs(n-1) > 1e-5 {
+		t.Errorf("self-similarity = %.6f, want 1", n)
+	}
+	for _, x := range vectors[3] {
+		if x != 0 {
+			t.Fatal("empty text embedded as a non-zero vector")
+		}
+	}
+}
+
+func TestOpenAI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/embeddings" || r.Header.Get("Authorization") != "Bearer key" {
+			t.Errorf("unexpected request %s (%s)", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		var req struct {
+			Model      string   `json:"model"`
+			Input      []string `json:"input"`
+			Dimensions int      `json:"dimensions"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Model != "embed-small" || len(req.Input) != 2 || req.Dimensions != 3 {
+			t.Errorf("unexpected request body %+v", req)
+		}
+		// Out of order, as the API allows
+		io.WriteString(w, `{"data":[{"index":1,"embedding":[0,1,0]},{"index":0,"embedding":[1,0,0]}]}`)
+	}))
+	defer srv.Close()
+
+	p, err := embeddings.NewOpenAI(embeddings.OpenAIConfig{BaseURL: srv.URL + "/v1/", APIKey: "key", Model: "embed-small", Dimensions: 3})
+	if err != nil {
+		t.Fatalf("NewOpenAI: %v", err)
+	}
+	if p.Name() != "openai:embed-small:3" {
+		t.Errorf("Name = %q", p.Name())
+	}
+	vectors, err := p.Embed(context.Background(), []string{"first", "second"})
+	if err != nil {
+		t.Fatalf("Embed: %v", err)
+	}
+	if vectors[0][0] != 1 || vectors[1][1] != 1 {
+		t.Errorf("Embed = %v, want inputs in order", vectors)
+	}
+
+	if _, err := embeddings.NewOpenAI(embeddings.OpenAIConfig{}); err == nil {
+		t.Error("NewOpenAI without an API key succeeded")
+	}
+}
+
+func TestVectorRoundTrip(t *testing.T) {
+	v := models.Vector{1, -0.25, 3.5e-7}
+	value, err := v.Value()
+	if err != nil {
+		t.Fatalf("Value: %v", err)
+	}
+	if value != "[1,-0.25,3.5e-07]" {
+		t.Errorf("Value = %v", value)
+	}
+
+	var got models.Vector
+	if err := got.Scan([]byte(value.(string))); err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if len(got) != 3 || got[0] != v[0] || got[1] != v[1] || got[2] != v[2] {
+		t.Errorf("Scan = %v, want %v", got, v)
+	}
+	if err := got.Scan("1,2"); err == nil {
+		t.Error("Scan accepted a vector without brackets")
+	}
+}
@@ -0,0 +1,360 @@
+package embeddings
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/drewjst/deltagov/internal/analysis"
+	"github.com/drewjst/deltagov/internal/archive"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+const (
+	// DefaultSyncLimit is the number of bills Sync embeds per call when no
+	// limit is given.
+	DefaultSyncLimit = 50
+
+	// maxSections bounds the sections embedded per bill, so an omnibus
+	// doesn't use up a cycle; the rest of its sections aren't searchable.
+	maxSections = 500
+
+	// maxTextChars bounds the text embedded per summary or section, within
+	// the input limits of common embedding models.
+	maxTextChars = 8000
+
+	// excerptChars is the length of the excerpt stored for display.
+	excerptChars = 300
+
+	// embedBatch is the number of texts sent to the provider at once.
+	embedBatch = 64
+
+	// maxIndexedDimensions is the most dimensions pgvector's HNSW index
+	// supports; larger vectors are searched without an index.
+	maxIndexedDimensions = 2000
+)
+
+// ErrNotEmbedded is returned by BillVector for a bill with no embeddings.
+var ErrNotEmbedded = errors.New("embeddings: bill has no embeddings")
+
+// Migrate enables pgvector and creates the embeddings tables, and an HNSW
+// index of the provider's vectors for cosine distance.
+func Migrate(db *gorm.DB, p Provider) error {
+	if err := db.Exec(`CREATE EXTENSION IF NOT EXISTS vector`).Error; err != nil {
+		return fmt.Errorf("embeddings: failed to enable pgvector: %w", err)
+	}
+	if err := db.AutoMigrate(&models.Embedding{}, &models.EmbeddingState{}); err != nil {
+		return fmt.Errorf("embeddings: auto-migration failed: %w", err)
+	}
+	if p.Dimensions() > maxIndexedDimensions {
+		return nil
+	}
+
+	// Vectors of every model share a column, so each model is indexed
+	// separately, cast to its dimensions
+	hash := sha256.Sum256([]byte(p.Name()))
+	if err := db.Exec(fmt.Sprintf(`
+		CREATE INDEX IF NOT EXISTS idx_embeddings_%s
+		ON embeddings USING hnsw ((vector::vector(%d)) vector_cosine_ops)
+		WHERE model = '%s'`,
+		hex.EncodeToString(hash[:4]), p.Dimensions(), strings.ReplaceAll(p.Name(), "'", "''")),
+	).Error; err != nil {
+		return fmt.Errorf("embeddings: failed to create vector index: %w", err)
+	}
+	return nil
+}
+
+// Indexer keeps the embeddings of public bills (not tenants' drafts)
+// within scope up to date: their latest CRS summary and the sections of
+// their latest text version.
+type Indexer struct {
+	db       *gorm.DB
+	provider Provider
+	scope    func(*gorm.DB) *gorm.DB
+}
+
+// NewIndexer creates an Indexer. scope restricts the bills embedded, such
+// as scope.Rules.Query; nil embeds every public bill.
+func NewIndexer(db *gorm.DB, p Provider, scope func(*gorm.DB) *gorm.DB) *Indexer {
+	if scope == nil {
+		scope = func(db *gorm.DB) *gorm.DB { return db }
+	}
+	return &Indexer{db: db, provider: p, scope: scope}
+}
+
+// Sync embeds up to limit bills (DefaultSyncLimit if zero or less) that
+// were never embedded or have changed since, most recently updated first,
+// returning how many it synced. Only summaries and sections whose text
+// changed are sent to the provider.
+func (ix *Indexer) Sync(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		limit = DefaultSyncLimit
+	}
+	model := ix.provider.Name()
+
+	var bills []models.Bill
+	if err := ix.db.WithContext(ctx).Model(&models.Bill{}).Select("bills.id", "bills.title").
+		Joins("LEFT JOIN embedding_states ON embedding_states.bill_id = bills.id AND embedding_states.model = ?", model).
+		Scopes(ix.scope).
+		Where("bills.tenant_id = 0").
+		Where(`(embedding_states.bill_id IS NULL OR embedding_states.synced_at < bills.updated_at
+			OR EXISTS (SELECT 1 FROM versions WHERE versions.bill_id = bills.id AND versions.created_at > embedding_states.synced_at)
+			OR EXISTS (SELECT 1 FROM summaries WHERE summaries.bill_id = bills.id AND summaries.updated_at > embedding_states.synced_at))`).
+		Order("bills.updated_at DESC").Limit(limit).Find(&bills).Error; err != nil {
+		return 0, fmt.Errorf("embeddings: failed to list bills to embed: %w", err)
+	}
+
+	for i := range bills {
+		if err := ix.syncBill(ctx, &bills[i]); err != nil {
+			return i, err
+		}
+	}
+	if len(bills) > 0 {
+		logging.FromContext(ctx).Info("embedded bills", "bills", len(bills), "model", model)
+	}
+	return len(bills), nil
+}
+
+// chunk is a text to embed and where it came from.
+type chunk struct {
+	kind, ref string
+	versionID *uint
+	section   string
+	heading   string
+	text      string
+	hash      string
+}
+
+// chunks returns the texts of a bill to embed: its latest summary and the
+// sections of its latest version.
+func (ix *Indexer) chunks(ctx context.Context, bill *models.Bill) ([]chunk, error) {
+	db := ix.db.WithContext(ctx)
+	var chunks []chunk
+
+	var summary models.Summary
+	if err := db.Where("bill_id = ?", bill.ID).Order("action_date DESC, id DESC").Limit(1).Find(&summary).Error; err != nil {
+		return nil, fmt.Errorf("embeddings: failed to load summary of bill %d: %w", bill.ID, err)
+	}
+	if summary.ID != 0 && strings.TrimSpace(summary.PlainText) != "" {
+		chunks = append(chunks, chunk{
+			kind:    models.EmbeddingKindSummary,
+			ref:     summary.VersionCode,
+			heading: summary.ActionDesc,
+			text:    bill.Title + "\n\n" + summary.PlainText,
+		})
+	}
+
+	var version models.Version
+	if err := db.Select(append([]string{"id"}, archive.TextColumns...)).Where("bill_id = ?", bill.ID).
+		Order("fetched_at DESC, id DESC").Limit(1).Find(&version).Error; err != nil {
+		return nil, fmt.Errorf("embeddings: failed to load latest version of bill %d: %w", bill.ID, err)
+	}
+	if version.ID != 0 {
+		if err := archive.Rehydrate(&version); err != nil {
+			return nil, err
+		}
+		seen := map[string]bool{}
+		for _, sec := range analysis.SplitSections(version.PlainText) {
+			ref := sec.Anchor()
+			if seen[ref] || len(seen) == maxSections {
+				continue
+			}
+			seen[ref] = true
+			chunks = append(chunks, chunk{
+				kind:      models.EmbeddingKindSection,
+				ref:       ref,
+				versionID: &version.ID,
+				section:   sec.Number,
+				heading:   sec.Heading,
+				text:      "SEC. " + sec.Number + ". " + sec.Heading + "\n" + sec.Body,
+			})
+		}
+	}
+
+	for i := range chunks {
+		chunks[i].text = truncate(chunks[i].text, maxTextChars)
+		sum := sha256.Sum256([]byte(chunks[i].text))
+		chunks[i].hash = hex.EncodeToString(sum[:])
+	}
+	return chunks, nil
+}
+
+// syncBill brings a bill's embeddings up to date: it embeds new and
+// changed texts and drops embeddings of texts the bill no longer has.
+func (ix *Indexer) syncBill(ctx context.Context, bill *models.Bill) error {
+	started := time.Now()
+	model := ix.provider.Name()
+	db := ix.db.WithContext(ctx)
+
+	chunks, err := ix.chunks(ctx, bill)
+	if err != nil {
+		return err
+	}
+
+	var existing []models.Embedding
+	if err := db.Select("id", "kind", "ref", "content_hash").
+		Where("model = ? AND bill_id = ?", model, bill.ID).Find(&existing).Error; err != nil {
+		return fmt.Errorf("embeddings: failed to load embeddings of bill %d: %w", bill.ID, err)
+	}
+	hashes := make(map[string]string, len(existing))
+	for _, e := range existing {
+		hashes[e.Kind+"/"+e.Ref] = e.ContentHash
+	}
+
+	keep := make(map[string]bool, len(chunks))
+	var changed []chunk
+	for _, c := range chunks {
+		key := c.kind + "/" + c.ref
+		keep[key] = true
+		if hashes[key] != c.hash {
+			changed = append(changed, c)
+		}
+	}
+
+	for start := 0; start < len(changed); start += embedBatch {
+		batch := changed[start:min(start+embedBatch, len(changed))]
+		texts := make([]string, len(batch))
+		for i, c := range batch {
+			texts[i] = c.text
+		}
+		vectors, err := ix.provider.Embed(ctx, texts)
+		if err != nil {
+			return fmt.Errorf("embeddings: failed to embed bill %d: %w", bill.ID, err)
+		}
+
+		rows := make([]models.Embedding, len(batch))
+		for i, c := range batch {
+			rows[i] = models.Embedding{
+				Model:       model,
+				BillID:      bill.ID,
+				Kind:        c.kind,
+				Ref:         c.ref,
+				VersionID:   c.versionID,
+				Section:     c.section,
+				Heading:     c.heading,
+				Excerpt:     truncate(c.text, excerptChars),
+				ContentHash: c.hash,
+				Vector:      vectors[i],
+			}
+		}
+		if err := db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "model"}, {Name: "bill_id"}, {Name: "kind"}, {Name: "ref"}},
+			DoUpdates: clause.AssignmentColumns([]string{"version_id", "section", "heading", "excerpt", "content_hash", "vector", "updated_at"}),
+		}).Create(&rows).Error; err != nil {
+			return fmt.Errorf("embeddings: failed to store embeddings of bill %d: %w", bill.ID, err)
+		}
+	}
+
+	// Sections whose text is unchanged now come from the latest version
+	for _, c := range chunks {
+		if c.versionID != nil {
+			if err := db.Model(&models.Embedding{}).
+				Where("model = ? AND bill_id = ? AND kind = ?", model, bill.ID, models.EmbeddingKindSection).
+				Update("version_id", *c.versionID).Error; err != nil {
+				return fmt.Errorf("embeddings: failed to update embeddings of bill %d: %w", bill.ID, err)
+			}
+			break
+		}
+	}
+
+	var stale []uint
+	for _, e := range existing {
+		if !keep[e.Kind+"/"+e.Ref] {
+			stale = append(stale, e.ID)
+		}
+	}
+	if len(stale) > 0 {
+		if err := db.Delete(&models.Embedding{}, stale).Error; err != nil {
+			return fmt.Errorf("embeddings: failed to delete stale embeddings of bill %d: %w", bill.ID, err)
+		}
+	}
+
+	state := models.EmbeddingState{BillID: bill.ID, Model: model, SyncedAt: started}
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "bill_id"}, {Name: "model"}},
+		DoUpdates: clause.AssignmentColumns([]string{"synced_at"}),
+	}).Create(&state).Error; err != nil {
+		return fmt.Errorf("embeddings: failed to record sync of bill %d: %w", bill.ID, err)
+	}
+	return nil
+}
+
+// Match is an embedded summary or section near a query vector.
+type Match struct {
+	ID         uint
+	BillID     uint
+	Kind       string
+	Ref        string
+	VersionID  *uint
+	Section    string
+	Heading    string
+	Excerpt    string
+	Similarity float64 // Cosine similarity to the query, 1 at best
+}
+
+// Nearest returns up to limit of the provider's embeddings most similar to
+// v, most similar first. scopes restrict the embeddings searched, and may
+// refer to the bills table, which is joined.
+func Nearest(ctx context.Context, db *gorm.DB, p Provider, v models.Vector, limit int, scopes ...func(*gorm.DB) *gorm.DB) ([]Match, error) {
+	distance := fmt.Sprintf("embeddings.vector::vector(%d) <=> ?::vector(%d)", p.Dimensions(), p.Dimensions())
+	var matches []Match
+	if err := db.WithContext(ctx).Table("embeddings").
+		Select("embeddings.id, embeddings.bill_id, embeddings.kind, embeddings.ref, embeddings.version_id, "+
+			"embeddings.section, embeddings.heading, embeddings.excerpt, 1 - ("+distance+") AS similarity", v).
+		Joins("JOIN bills ON bills.id = embeddings.bill_id").
+		Where("embeddings.model = ?", p.Name()).
+		Scopes(scopes...).
+		Clauses(clause.OrderBy{Expression: clause.Expr{SQL: distance, Vars: []any{v}}}).
+		Limit(limit).
+		Scan(&matches).Error; err != nil {
+		return nil, fmt.Errorf("embeddings: failed to search embeddings: %w", err)
+	}
+	return matches, nil
+}
+
+// BillVector returns a vector standing for a whole bill: its summary's
+// embedding, or the normalized mean of its sections' when it has no
+// summary. It returns ErrNotEmbedded when the bill has neither.
+func BillVector(ctx context.Context, db *gorm.DB, p Provider, billID uint) (models.Vector, error) {
+	var rows []models.Embedding
+	if err := db.WithContext(ctx).Select("kind", "vector").
+		Where("model = ? AND bill_id = ?", p.Name(), billID).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("embeddings: failed to load embeddings of bill %d: %w", billID, err)
+	}
+	if len(rows) == 0 {
+		return nil, ErrNotEmbedded
+	}
+	for _, r := range rows {
+		if r.Kind == models.EmbeddingKindSummary {
+			return r.Vector, nil
+		}
+	}
+	mean := make(models.Vector, p.Dimensions())
+	for _, r := range rows {
+		for i := range min(len(r.Vector), len(mean)) {
+			mean[i] += r.Vector[i]
+		}
+	}
+	normalize(mean)
+	return mean, nil
+}
+
+// truncate cuts s to at most n bytes, at a rune boundary.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
@@ -0,0 +1,116 @@
+// Package events publishes ingestion events to a message broker, so
+// downstream services such as alerting and analytics can react to new
+// bills, versions, and deltas without polling the database. Publishers
+// exist for Google Cloud Pub/Sub and NATS; see FromEnv.
+//
+// Unlike the live package, which notifies API instances over Postgres,
+// events leave the deployment and carry a unique ID for consumers to drop
+// redeliveries.
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/textstore"
+)
+
+// Event types.
+const (
+	TypeBillCreated    = "bill.created"
+	TypeBillUpdated    = "bill.updated"
+	TypeVersionCreated = "version.created"
+	TypeDeltaComputed  = "delta.computed"
+)
+
+// Event is a change the ingestor made.
+type Event struct {
+	ID          string    `json:"id"`
+	Type        string    `json:"type"`
+	OccurredAt  time.Time `json:"occurredAt"`
+	BillID      uint      `json:"billId"`
+	Congress    int       `json:"congress"`
+	BillType    string    `json:"billType"`
+	Number      string    `json:"number"`     // As published, e.g., "12A"
+	BillNumber  int       `json:"billNumber"` // 0 when Number isn't numeric
+	Title       string    `json:"title,omitempty"`
+	VersionID   uint      `json:"versionId,omitempty"`   // Set for version.created
+	VersionCode string    `json:"versionCode,omitempty"` // Set for version.created
+
+	// Set for delta.computed
+	FromVersionID uint   `json:"fromVersionId,omitempty"`
+	ToVersionID   uint   `json:"toVersionId,omitempty"`
+	FromVersion   string `json:"fromVersion,omitempty"`
+	ToVersion     string `json:"toVersion,omitempty"`
+	Insertions    int    `json:"insertions,omitempty"`
+	Deletions     int    `json:"deletions,omitempty"`
+}
+
+// Publisher sends events to a broker. Implementations must be safe for
+// concurrent use.
+type Publisher interface {
+	// Publish sends one event, filling in its ID and time if unset.
+	Publish(ctx context.Context, event Event) error
+	// Close releases the publisher's connections.
+	Close() error
+}
+
+// prepare fills in an event's ID and time.
+func prepare(event *Event) {
+	if event.ID == "" {
+		var b [16]byte
+		_, _ = rand.Read(b[:])
+		event.ID = hex.EncodeToString(b[:])
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+}
+
+// FromEnv builds the publisher configured by environment variables:
+//
+//	EVENT_PUBLISHER        "pubsub" or "nats"; empty disables publishing
+//	PUBSUB_PROJECT         Google Cloud project of the topic (pubsub)
+//	PUBSUB_TOPIC           topic ID (pubsub)
+//	PUBSUB_ACCESS_TOKEN    static OAuth token (default: from the GCE/Cloud Run metadata server)
+//	PUBSUB_EMULATOR_HOST   host:port of a Pub/Sub emulator; disables authentication
+//	NATS_URL               server URL (default: nats://localhost:4222); tls:// connects over TLS
+//	NATS_SUBJECT_PREFIX    prefix of event subjects (default: deltagov)
+//
+// Returns nil when EVENT_PUBLISHER is unset.
+func FromEnv() (Publisher, error) {
+	switch kind := strings.ToLower(os.Getenv("EVENT_PUBLISHER")); kind {
+	case "":
+		return nil, nil
+	case "pubsub":
+		project, topic := os.Getenv("PUBSUB_PROJECT"), os.Getenv("PUBSUB_TOPIC")
+		if project == "" || topic == "" {
+			return nil, errors.New("events: PUBSUB_PROJECT and PUBSUB_TOPIC are required for pubsub")
+		}
+		client := &http.Client{Timeout: 10 * time.Second}
+		p := &PubSub{Project: project, Topic: topic, Token: textstore.MetadataToken(client), Client: client}
+		if static := os.Getenv("PUBSUB_ACCESS_TOKEN"); static != "" {
+			p.Token = textstore.StaticToken(static)
+		}
+		if emulator := os.Getenv("PUBSUB_EMULATOR_HOST"); emulator != "" {
+			p.Endpoint = "http://" + emulator
+			p.Token = nil
+		}
+		return p, nil
+	case "nats":
+		url := os.Getenv("NATS_URL")
+		if url == "" {
+			url = "nats://localhost:4222"
+		}
+		return &NATS{URL: url, SubjectPrefix: os.Getenv("NATS_SUBJECT_PREFIX")}, nil
+	default:
+		return nil, fmt.Errorf("events: unknown EVENT_PUBLISHER %q (want pubsub or nats)", kind)
+	}
+}
@@ -0,0 +1,203 @@
+package events_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/events"
+	"github.com/drewjst/deltagov/internal/textstore"
+)
+
+func TestPubSub_Publish(t *testing.T) {
+	var (
+		path, auth string
+		body       struct {
+			Messages []struct {
+				Data       string            `json:"data"`
+				Attributes map[string]string `json:"attributes"`
+			} `json:"messages"`
+		}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, auth = r.URL.Path, r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.Write([]byte(`{"messageIds":["1"]}`))
+	}))
+	defer srv.Close()
+
+	p := &events.PubSub{Endpoint: srv.URL, Project: "proj", Topic: "ingest", Token: textstore.StaticToken("tok")}
+	err := p.Publish(context.Background(), events.Event{
+		Type: events.TypeVersionCreated, BillID: 7, Congress: 119, BillType: "hr", BillNumber: 1, VersionID: 3,
+	})
+	if err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	if path != "/v1/projects/proj/topics/ingest:publish" {
+		t.Errorf("path = %q", path)
+	}
+	if auth != "Bearer tok" {
+		t.Errorf("Authorization = %q", auth)
+	}
+	if len(body.Messages) != 1 {
+		t.Fatalf("got %d messages, want 1", len(body.Messages))
+	}
+	msg := body.Messages[0]
+	if msg.Attributes["type"] != "version.created" || msg.Attributes["congress"] != "119" || msg.Attributes["billType"] != "hr" {
+		t.Errorf("attributes = %v", msg.Attributes)
+	}
+	data, _ := base64.StdEncoding.DecodeString(msg.Data)
+	var event events.Event
+	if err := json.Unmarshal(data, &event); err != nil {
+		t.Fatalf("decode data: %v", err)
+	}
+	if event.ID == "" || event.ID != msg.Attributes["id"] || event.OccurredAt.IsZero() || event.VersionID != 3 {
+		t.Errorf("event = %+v", event)
+	}
+}
+
+func TestPubSub_PublishError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "topic not found", http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	p := &events.PubSub{Endpoint: srv.URL, Project: "proj", Topic: "missing"}
+	err := p.Publish(context.Background(), events.Event{Type: events.TypeBillCreated})
+	if err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Publish error = %v, want status 404", err)
+	}
+}
+
+// natsServer is a fake NATS server that records published messages.
+// Each connection is closed after serving one PUB, so every publish
+// after the first must reconnect.
+type natsServer struct {
+	ln       net.Listener
+	connects chan string
+	messages chan string
+}
+
+func newNATSServer(t *testing.T) *natsServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &natsServer{ln: ln, connects: make(chan string, 10), messages: make(chan string, 10)}
+	go s.serve()
+	t.Cleanup(func() { ln.Close() })
+	return s
+}
+
+func (s *natsServer) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *natsServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	conn.Write([]byte("INFO {\"server_id\":\"test\"}\r\n"))
+	published := false
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case strings.HasPrefix(line, "CONNECT "):
+			s.connects <- strings.TrimPrefix(line, "CONNECT ")
+		case line == "PING":
+			conn.Write([]byte("PONG\r\n"))
+			if published {
+				return
+			}
+		case strings.HasPrefix(line, "PUB "):
+			fields := strings.Fields(line)
+			size, _ := strconv.Atoi(fields[2])
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			s.messages <- fields[1] + " " + string(payload[:size])
+			published = true
+		}
+	}
+}
+
+func TestNATS_Publish(t *testing.T) {
+	srv := newNATSServer(t)
+	n := &events.NATS{URL: "nats://secret@" + srv.ln.Addr().String()}
+	defer n.Close()
+
+	ctx := context.Background()
+	if err := n.Publish(ctx, events.Event{Type: events.TypeBillCreated, BillID: 1}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	// The server closed the connection; the next publish reconnects
+	if err := n.Publish(ctx, events.Event{Type: events.TypeDeltaComputed, BillID: 1, FromVersionID: 2, ToVersionID: 3}); err != nil {
+		t.Fatalf("second Publish: %v", err)
+	}
+
+	connect := <-srv.connects
+	if !strings.Contains(connect, `"auth_token":"secret"`) {
+		t.Errorf("CONNECT = %s, want auth token", connect)
+	}
+
+	first, second := <-srv.messages, <-srv.messages
+	if !strings.HasPrefix(first, "deltagov.bill.created {") {
+		t.Errorf("first message = %q", first)
+	}
+	subject, payload, _ := strings.Cut(second, " ")
+	if subject != "deltagov.delta.computed" {
+		t.Errorf("second subject = %q", subject)
+	}
+	var event events.Event
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		t.Fatalf("decode payload: %v", err)
+	}
+	if event.FromVersionID != 2 || event.ToVersionID != 3 || event.ID == "" {
+		t.Errorf("event = %+v", event)
+	}
+}
+
+func TestNATS_ServerError(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.Write([]byte("INFO {}\r\n"))
+		bufio.NewReader(conn).ReadString('\n')
+		conn.Write([]byte("-ERR 'Authorization Violation'\r\n"))
+	}()
+
+	n := &events.NATS{URL: "nats://" + ln.Addr().String()}
+	err = n.Publish(context.Background(), events.Event{Type: events.TypeBillUpdated})
+	if err == nil || !strings.Contains(err.Error(), "Authorization Violation") {
+		t.Errorf("Publish error = %v, want authorization violation", err)
+	}
+}
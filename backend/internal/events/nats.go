@@ -0,0 +1,210 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// natsTimeout bounds a publish, including connecting, when the context
+// has no deadline.
+const natsTimeout = 10 * time.Second
+
+// errNATSServer is wrapped by errors the server reports with -ERR.
+var errNATSServer = errors.New("nats server error")
+
+// NATS publishes events to a NATS server on the subject
+// "<prefix>.<type>", e.g., "deltagov.version.created", speaking the core
+// text protocol directly. Each publish is followed by a PING and waits for
+// the PONG, so a returned nil means the server accepted the message.
+// The connection is opened on first use and reopened after failures.
+type NATS struct {
+	URL           string // nats://[user:pass@ or token@]host:port; tls:// connects over TLS
+	SubjectPrefix string // Default: "deltagov"
+
+	mu   sync.Mutex
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+// Publish sends an event, reconnecting once if the connection was lost.
+func (n *NATS) Publish(ctx context.Context, event Event) error {
+	prepare(&event)
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("events: failed to encode event: %w", err)
+	}
+	subject := n.prefix() + "." + event.Type
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	reused := n.conn != nil
+	err = n.publish(ctx, subject, payload)
+	if err != nil && reused && !errors.Is(err, errNATSServer) && ctx.Err() == nil {
+		// The server may have closed an idle connection
+		err = n.publish(ctx, subject, payload)
+	}
+	if err != nil {
+		return fmt.Errorf("events: publish %s: %w", subject, err)
+	}
+	return nil
+}
+
+// Close closes the connection, if open.
+func (n *NATS) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.disconnect()
+}
+
+// publish sends one message and waits for the server to confirm it,
+// connecting first if needed. Any failure closes the connection.
+func (n *NATS) publish(ctx context.Context, subject string, payload []byte) error {
+	if n.conn == nil {
+		if err := n.connect(ctx); err != nil {
+			return err
+		}
+	}
+	n.conn.SetDeadline(deadline(ctx))
+
+	msg := fmt.Sprintf("PUB %s %d\r\n%s\r\nPING\r\n", subject, len(payload), payload)
+	if _, err := n.conn.Write([]byte(msg)); err != nil {
+		n.disconnect()
+		return err
+	}
+	if err := n.awaitPong(); err != nil {
+		n.disconnect()
+		return err
+	}
+	return nil
+}
+
+// connect dials the server, reads its INFO, and sends CONNECT with the
+// URL's credentials.
+func (n *NATS) connect(ctx context.Context) error {
+	u, err := url.Parse(n.URL)
+	if err != nil {
+		return fmt.Errorf("invalid NATS_URL: %w", err)
+	}
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "4222")
+	}
+
+	dialer := &net.Dialer{Deadline: deadline(ctx)}
+	var conn net.Conn
+	switch u.Scheme {
+	case "nats", "":
+		conn, err = dialer.DialContext(ctx, "tcp", host)
+	case "tls":
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: u.Hostname()}}).
+			DialContext(ctx, "tcp", host)
+	default:
+		return fmt.Errorf("unsupported NATS_URL scheme %q (want nats or tls)", u.Scheme)
+	}
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(deadline(ctx))
+	n.conn, n.r = conn, bufio.NewReader(conn)
+
+	line, err := n.readLine()
+	if err != nil {
+		n.disconnect()
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		n.disconnect()
+		return fmt.Errorf("unexpected greeting %q", line)
+	}
+
+	options := map[string]any{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "deltagov-ingestor",
+		"lang":     "go",
+		"version":  "1.0.0",
+		"protocol": 0,
+	}
+	if user := u.User; user != nil {
+		if pass, ok := user.Password(); ok {
+			options["user"], options["pass"] = user.Username(), pass
+		} else {
+			options["auth_token"] = user.Username()
+		}
+	}
+	connect, _ := json.Marshal(options)
+	if _, err := fmt.Fprintf(conn, "CONNECT %s\r\nPING\r\n", connect); err != nil {
+		n.disconnect()
+		return err
+	}
+	if err := n.awaitPong(); err != nil {
+		n.disconnect()
+		return err
+	}
+	return nil
+}
+
+// awaitPong reads until the server's PONG, answering its PINGs.
+func (n *NATS) awaitPong() error {
+	for {
+		line, err := n.readLine()
+		if err != nil {
+			return err
+		}
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err := n.conn.Write([]byte("PONG\r\n")); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("%w: %s", errNATSServer, strings.Trim(strings.TrimPrefix(line, "-ERR"), " '"))
+		}
+		// +OK and INFO updates need no reply
+	}
+}
+
+// readLine reads one protocol line without its CRLF.
+func (n *NATS) readLine() (string, error) {
+	line, err := n.r.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// disconnect closes the connection, if open.
+func (n *NATS) disconnect() error {
+	if n.conn == nil {
+		return nil
+	}
+	err := n.conn.Close()
+	n.conn, n.r = nil, nil
+	return err
+}
+
+// prefix returns the subject prefix.
+func (n *NATS) prefix() string {
+	if n.SubjectPrefix == "" {
+		return "deltagov"
+	}
+	return strings.TrimSuffix(n.SubjectPrefix, ".")
+}
+
+// deadline returns the context's deadline, or natsTimeout from now.
+func deadline(ctx context.Context) time.Time {
+	if d, ok := ctx.Deadline(); ok {
+		return d
+	}
+	return time.Now().Add(natsTimeout)
+}
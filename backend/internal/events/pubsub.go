@@ -0,0 +1,101 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/drewjst/deltagov/internal/textstore"
+)
+
+// PubSub publishes events to a Google Cloud Pub/Sub topic through the REST
+// API. Each message's data is the JSON event; its attributes carry the
+// type, congress, and bill type for subscription filters.
+type PubSub struct {
+	Endpoint string // Default: "https://pubsub.googleapis.com"
+	Project  string
+	Topic    string
+	Token    textstore.TokenSource // Nil sends unauthenticated requests, e.g., to the emulator
+	Client   *http.Client
+}
+
+// pubsubMessage is a message in a topics.publish request.
+type pubsubMessage struct {
+	Data       string            `json:"data"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// Publish sends an event to the topic.
+func (p *PubSub) Publish(ctx context.Context, event Event) error {
+	prepare(&event)
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("events: failed to encode event: %w", err)
+	}
+	body, err := json.Marshal(map[string][]pubsubMessage{
+		"messages": {{
+			Data: base64.StdEncoding.EncodeToString(data),
+			Attributes: map[string]string{
+				"id":       event.ID,
+				"type":     event.Type,
+				"billId":   strconv.FormatUint(uint64(event.BillID), 10),
+				"congress": strconv.Itoa(event.Congress),
+				"billType": event.BillType,
+			},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("events: failed to encode message: %w", err)
+	}
+
+	u := fmt.Sprintf("%s/v1/projects/%s/topics/%s:publish",
+		p.endpoint(), url.PathEscape(p.Project), url.PathEscape(p.Topic))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.Token != nil {
+		token, err := p.Token(ctx)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("events: publish %s: %w", event.Type, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("events: publish %s returned status %d: %s",
+			event.Type, resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// Close does nothing; requests don't hold connections open.
+func (p *PubSub) Close() error {
+	return nil
+}
+
+// endpoint returns the API origin.
+func (p *PubSub) endpoint() string {
+	if p.Endpoint == "" {
+		return "https://pubsub.googleapis.com"
+	}
+	return strings.TrimSuffix(p.Endpoint, "/")
+}
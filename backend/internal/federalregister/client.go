@@ -0,0 +1,252 @@
+// Package federalregister is a client for the Federal Register API
+// (https://www.federalregister.gov/developers/documentation/api/v1), which
+// publishes every proposed and final rule of the federal agencies with its
+// full text. The API needs no key.
+package federalregister
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/logging"
+)
+
+const (
+	baseURL        = "https://www.federalregister.gov/api/v1"
+	defaultTimeout = 30 * time.Second
+
+	// MaxPerPage is the largest page size the documents endpoint accepts.
+	MaxPerPage = 1000
+
+	// maxTextSize bounds a downloaded rule text, matching the ingestor's
+	// limit for bill text.
+	maxTextSize = 10 * 1024 * 1024
+)
+
+// Document types, as reported in Document.Type.
+const (
+	TypeProposedRule = "Proposed Rule"
+	TypeRule         = "Rule"
+)
+
+// Errors returned by the client.
+var (
+	ErrInvalidStatus = errors.New("federalregister: unexpected status code")
+	ErrRateLimited   = errors.New("federalregister: rate limit exceeded")
+	ErrNotFound      = errors.New("federalregister: resource not found")
+)
+
+// Client is a Federal Register API client. It is safe for concurrent use.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// Option is a functional option for configuring the Client.
+type Option func(*Client)
+
+// WithHTTPClient sets a custom HTTP client for API requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		if client != nil {
+			c.httpClient = client
+		}
+	}
+}
+
+// WithBaseURL overrides the default API base URL.
+// Useful for testing with mock servers.
+func WithBaseURL(url string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// NewClient creates a new Federal Register client with the given options.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		httpClient: &http.Client{
+			Timeout: defaultTimeout,
+		},
+		baseURL: baseURL,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Document is a rule document from the /documents endpoint.
+type Document struct {
+	DocumentNumber      string   `json:"document_number"` // e.g., "2025-01234"
+	Type                string   `json:"type"`            // TypeProposedRule or TypeRule
+	Title               string   `json:"title"`
+	Abstract            string   `json:"abstract"`
+	Agencies            []Agency `json:"agencies"`
+	PublicationDate     string   `json:"publication_date"` // YYYY-MM-DD
+	HTMLURL             string   `json:"html_url"`
+	RawTextURL          string   `json:"raw_text_url"`
+	RegulationIDNumbers []string `json:"regulation_id_numbers"` // RINs, e.g., "2060-AV09"
+	DocketIDs           []string `json:"docket_ids"`            // e.g., "EPA-HQ-OAR-2023-0234"
+}
+
+// Agency is an agency that issued a document.
+type Agency struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"` // e.g., "environmental-protection-agency"
+}
+
+// ParsedPublicationDate returns the document's publication date.
+func (d *Document) ParsedPublicationDate() (time.Time, bool) {
+	t, err := time.Parse(time.DateOnly, d.PublicationDate)
+	return t, err == nil
+}
+
+// AgencyNames returns the names of the issuing agencies.
+func (d *Document) AgencyNames() []string {
+	names := make([]string, 0, len(d.Agencies))
+	for _, a := range d.Agencies {
+		if a.Name != "" {
+			names = append(names, a.Name)
+		}
+	}
+	return names
+}
+
+// documentFields are the fields requested for each document.
+var documentFields = []string{
+	"document_number", "type", "title", "abstract", "agencies", "publication_date",
+	"html_url", "raw_text_url", "regulation_id_numbers", "docket_ids",
+}
+
+// DocumentsQuery selects proposed and final rules from the /documents
+// endpoint. Conditions are combined with AND.
+type DocumentsQuery struct {
+	Agencies []string  // Agency slugs (empty = all agencies)
+	Term     string    // Full-text search (empty = no filter)
+	Since    time.Time // Earliest publication date (zero = no limit)
+	Page     int       // 1-based page (default: 1)
+	PerPage  int       // Documents per page (default: 100, max: MaxPerPage)
+}
+
+// DocumentsPage is one page of documents.
+type DocumentsPage struct {
+	Documents  []Document
+	Count      int
+	TotalPages int
+	Page       int
+}
+
+// HasMore reports whether later pages exist.
+func (p *DocumentsPage) HasMore() bool {
+	return p.Page < p.TotalPages
+}
+
+// SearchRules fetches a page of proposed and final rules, newest first.
+func (c *Client) SearchRules(ctx context.Context, q DocumentsQuery) (*DocumentsPage, error) {
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.PerPage < 1 {
+		q.PerPage = 100
+	}
+	q.PerPage = min(q.PerPage, MaxPerPage)
+
+	query := neturl.Values{}
+	query.Add("conditions[type][]", "PRORULE")
+	query.Add("conditions[type][]", "RULE")
+	for _, agency := range q.Agencies {
+		query.Add("conditions[agencies][]", agency)
+	}
+	if q.Term != "" {
+		query.Set("conditions[term]", q.Term)
+	}
+	if !q.Since.IsZero() {
+		query.Set("conditions[publication_date][gte]", q.Since.Format(time.DateOnly))
+	}
+	for _, field := range documentFields {
+		query.Add("fields[]", field)
+	}
+	query.Set("order", "newest")
+	query.Set("page", strconv.Itoa(q.Page))
+	query.Set("per_page", strconv.Itoa(q.PerPage))
+
+	resp, err := c.get(ctx, c.baseURL+"/documents.json?"+query.Encode(), "application/json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Count      int        `json:"count"`
+		TotalPages int        `json:"total_pages"`
+		Results    []Document `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("federalregister: failed to decode documents: %w", err)
+	}
+	return &DocumentsPage{
+		Documents:  body.Results,
+		Count:      body.Count,
+		TotalPages: body.TotalPages,
+		Page:       q.Page,
+	}, nil
+}
+
+// FetchText downloads a document's raw text from its RawTextURL.
+func (c *Client) FetchText(ctx context.Context, rawTextURL string) (string, error) {
+	resp, err := c.get(ctx, rawTextURL, "text/plain, text/html")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxTextSize))
+	if err != nil {
+		return "", fmt.Errorf("federalregister: failed to read %s: %w", rawTextURL, err)
+	}
+	return string(content), nil
+}
+
+// get sends a GET request and checks the response status. The caller
+// closes the body.
+func (c *Client) get(ctx context.Context, url, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("federalregister: failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", accept)
+	if id := logging.RequestID(ctx); id != "" {
+		req.Header.Set(logging.RequestIDHeader, id)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("federalregister: failed to fetch %s: %w", req.URL.Path, err)
+	}
+	logging.FromContext(ctx).Debug("federal register request",
+		"path", req.URL.Path, "status", resp.StatusCode, "latency_ms", time.Since(start).Milliseconds())
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	case http.StatusTooManyRequests:
+		resp.Body.Close()
+		return nil, ErrRateLimited
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %d", ErrInvalidStatus, resp.StatusCode)
+	}
+}
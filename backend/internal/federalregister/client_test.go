@@ -0,0 +1,77 @@
+package federalregister_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/federalregister"
+)
+
+func TestSearchRules(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/documents.json":
+			q := r.URL.Query()
+			if len(q["conditions[type][]"]) != 2 || q.Get("conditions[agencies][]") != "environmental-protection-agency" ||
+				q.Get("conditions[term]") != "methane" || q.Get("conditions[publication_date][gte]") != "2024-01-01" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			io.WriteString(w, `{
+				"count": 2,
+				"total_pages": 1,
+				"results": [{
+					"document_number": "2024-05678",
+					"type": "Rule",
+					"title": "Methane Emissions Standards",
+					"agencies": [{"name": "Environmental Protection Agency", "slug": "environmental-protection-agency"}],
+					"publication_date": "2024-03-08",
+					"raw_text_url": "`+"http://"+r.Host+`/full_text/2024-05678.txt",
+					"regulation_id_numbers": ["2060-AV16"],
+					"docket_ids": ["EPA-HQ-OAR-2021-0317"]
+				}]
+			}`)
+		case "/full_text/2024-05678.txt":
+			io.WriteString(w, "Sec. 60.5360b Applicability.")
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := federalregister.NewClient(federalregister.WithBaseURL(srv.URL), federalregister.WithHTTPClient(srv.Client()))
+	page, err := c.SearchRules(context.Background(), federalregister.DocumentsQuery{
+		Agencies: []string{"environmental-protection-agency"},
+		Term:     "methane",
+		Since:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatalf("SearchRules: %v", err)
+	}
+	if len(page.Documents) != 1 || page.Count != 2 || page.HasMore() {
+		t.Fatalf("SearchRules = %+v", page)
+	}
+
+	doc := page.Documents[0]
+	if doc.Type != federalregister.TypeRule || doc.RegulationIDNumbers[0] != "2060-AV16" {
+		t.Errorf("document = %+v", doc)
+	}
+	if names := doc.AgencyNames(); len(names) != 1 || names[0] != "Environmental Protection Agency" {
+		t.Errorf("AgencyNames = %v", names)
+	}
+	if d, ok := doc.ParsedPublicationDate(); !ok || d.Format(time.DateOnly) != "2024-03-08" {
+		t.Errorf("ParsedPublicationDate = %v, %v", d, ok)
+	}
+
+	text, err := c.FetchText(context.Background(), doc.RawTextURL)
+	if err != nil || text != "Sec. 60.5360b Applicability." {
+		t.Errorf("FetchText = %q, %v", text, err)
+	}
+	if _, err := c.FetchText(context.Background(), srv.URL+"/full_text/missing.txt"); !errors.Is(err, federalregister.ErrNotFound) {
+		t.Errorf("FetchText of a missing document: err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,77 @@
+// Package feeds renders Atom 1.0 (RFC 4287) syndication documents and
+// iCalendar (RFC 5545) calendars to subscribe to.
+package feeds
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+// ContentType is the media type for Atom documents.
+const ContentType = "application/atom+xml; charset=utf-8"
+
+// Feed is an Atom feed document.
+type Feed struct {
+	XMLName  xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+	ID       string   `xml:"id"`
+	Title    string   `xml:"title"`
+	Subtitle string   `xml:"subtitle,omitempty"`
+	Updated  string   `xml:"updated"`
+	Author   *Person  `xml:"author,omitempty"`
+	Links    []Link   `xml:"link"`
+	Entries  []Entry  `xml:"entry"`
+}
+
+// Entry is a single item in an Atom feed.
+type Entry struct {
+	ID      string  `xml:"id"`
+	Title   string  `xml:"title"`
+	Updated string  `xml:"updated"`
+	Links   []Link  `xml:"link"`
+	Summary string  `xml:"summary,omitempty"`
+	Content *Text   `xml:"content,omitempty"`
+	Author  *Person `xml:"author,omitempty"`
+}
+
+// Link is an Atom link element.
+type Link struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// Person is an Atom person construct.
+type Person struct {
+	Name string `xml:"name"`
+}
+
+// Text is an Atom text construct. Type is "text" or "html".
+type Text struct {
+	Type string `xml:"type,attr,omitempty"`
+	Body string `xml:",chardata"`
+}
+
+// Timestamp formats t as an RFC 3339 date, as Atom requires.
+func Timestamp(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// Marshal encodes the feed as an indented XML document with declaration.
+// When Updated is unset it is derived from the newest entry, or the zero
+// time for an empty feed.
+func (f *Feed) Marshal() ([]byte, error) {
+	if f.Updated == "" {
+		f.Updated = Timestamp(time.Time{})
+		for _, e := range f.Entries {
+			if e.Updated > f.Updated {
+				f.Updated = e.Updated
+			}
+		}
+	}
+
+	body, err := xml.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), body...), nil
+}
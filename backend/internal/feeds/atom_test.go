@@ -0,0 +1,48 @@
+package feeds_test
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/feeds"
+)
+
+// TestMarshal_ValidAtom verifies the document round-trips and derives Updated.
+func TestMarshal_ValidAtom(t *testing.T) {
+	older := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	newer := time.Date(2025, 3, 2, 8, 30, 0, 0, time.FixedZone("EST", -5*3600))
+
+	feed := &feeds.Feed{
+		ID:    "https://example.org/feeds/bills.atom",
+		Title: "Test feed",
+		Links: []feeds.Link{{Href: "https://example.org/feeds/bills.atom", Rel: "self"}},
+		Entries: []feeds.Entry{
+			{ID: "a", Title: "HR 1 & friends", Updated: feeds.Timestamp(older)},
+			{ID: "b", Title: "S 2", Updated: feeds.Timestamp(newer), Content: &feeds.Text{Type: "text", Body: "+3 / -1"}},
+		},
+	}
+
+	data, err := feed.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if !strings.HasPrefix(string(data), "<?xml") {
+		t.Error("Expected XML declaration")
+	}
+	if !strings.Contains(string(data), `xmlns="http://www.w3.org/2005/Atom"`) {
+		t.Error("Expected Atom namespace")
+	}
+
+	var decoded feeds.Feed
+	if err := xml.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if decoded.Updated != "2025-03-02T13:30:00Z" {
+		t.Errorf("Expected feed updated from newest entry in UTC, got %q", decoded.Updated)
+	}
+	if len(decoded.Entries) != 2 || decoded.Entries[0].Title != "HR 1 & friends" {
+		t.Errorf("Entries did not round-trip: %+v", decoded.Entries)
+	}
+}
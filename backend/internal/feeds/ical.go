@@ -0,0 +1,103 @@
+package feeds
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// CalendarContentType is the media type for iCalendar documents.
+const CalendarContentType = "text/calendar; charset=utf-8"
+
+// Calendar is an iCalendar document of all-day events, published for
+// calendar apps to subscribe to.
+type Calendar struct {
+	Name        string // Shown by calendar apps as the calendar's name
+	Description string
+	Events      []Event
+}
+
+// Event is an all-day event in a Calendar.
+type Event struct {
+	UID         string    // Globally unique and stable, so updates replace the event
+	Date        time.Time // The day of the event; its time is ignored
+	Summary     string
+	Description string
+	URL         string
+	Categories  []string
+}
+
+// Marshal encodes the calendar, stamping its events with the time it was
+// generated.
+func (c *Calendar) Marshal(stamp time.Time) []byte {
+	var b strings.Builder
+	line := func(name, value string) {
+		fold(&b, name+":"+value)
+	}
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//DeltaGov//Bill milestones//EN")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		line("X-WR-CALNAME", escapeText(c.Name))
+	}
+	if c.Description != "" {
+		line("X-WR-CALDESC", escapeText(c.Description))
+	}
+	for _, e := range c.Events {
+		y, m, d := e.Date.Date()
+		day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		line("BEGIN", "VEVENT")
+		line("UID", escapeText(e.UID))
+		line("DTSTAMP", stamp.UTC().Format("20060102T150405Z"))
+		line("DTSTART;VALUE=DATE", day.Format("20060102"))
+		line("DTEND;VALUE=DATE", day.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY", escapeText(e.Summary))
+		if e.Description != "" {
+			line("DESCRIPTION", escapeText(e.Description))
+		}
+		if e.URL != "" {
+			line("URL", e.URL)
+		}
+		if len(e.Categories) > 0 {
+			escaped := make([]string, len(e.Categories))
+			for i, cat := range e.Categories {
+				escaped[i] = escapeText(cat)
+			}
+			line("CATEGORIES", strings.Join(escaped, ","))
+		}
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return []byte(b.String())
+}
+
+// escapeText escapes an iCalendar TEXT value.
+func escapeText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+// maxLineOctets is the longest content line RFC 5545 allows, excluding
+// the line break.
+const maxLineOctets = 75
+
+// fold writes a content line, folded into lines of at most maxLineOctets
+// octets without splitting a UTF-8 character, each continuation starting
+// with a space.
+func fold(b *strings.Builder, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		fmt.Fprintf(b, "%s\r\n ", line[:cut])
+		line = line[cut:]
+		limit = maxLineOctets - 1 // The leading space counts
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+}
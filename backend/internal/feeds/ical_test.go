@@ -0,0 +1,48 @@
+package feeds_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/feeds"
+)
+
+func TestCalendarMarshal(t *testing.T) {
+	cal := &feeds.Calendar{
+		Name: "DeltaGov: my watchlist",
+		Events: []feeds.Event{{
+			UID:         "bill-1-floor-20250305@deltagov",
+			Date:        time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC),
+			Summary:     "HR 1 (119th): Floor consideration",
+			Description: "By unanimous consent agreement, the Senate will vote on passage; see the bill's page for the latest text, diffs, and summaries of each version.\nTitle: Lower Costs, More Transparency Act",
+			Categories:  []string{"Floor", "Watchlist"},
+		}},
+	}
+	data := string(cal.Marshal(time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC)))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"X-WR-CALNAME:DeltaGov: my watchlist\r\n",
+		"DTSTAMP:20250303T120000Z\r\n",
+		"DTSTART;VALUE=DATE:20250305\r\nDTEND;VALUE=DATE:20250306\r\n",
+		"CATEGORIES:Floor,Watchlist\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(data, want) {
+			t.Errorf("calendar is missing %q", want)
+		}
+	}
+
+	// Lines are folded at 75 octets; unfolded, text is escaped
+	for _, line := range strings.Split(strings.TrimSuffix(data, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+	}
+	unfolded := strings.ReplaceAll(data, "\r\n ", "")
+	if !strings.Contains(unfolded, `DESCRIPTION:By unanimous consent agreement\, the Senate will vote on passage\; see`) ||
+		!strings.Contains(unfolded, `summaries of each version.\nTitle: Lower Costs\, More Transparency Act`+"\r\n") {
+		t.Errorf("description not escaped as expected:\n%s", unfolded)
+	}
+}
@@ -0,0 +1,216 @@
+// Package govinfo reads bill text from the GovInfo bulk data repository
+// (https://www.govinfo.gov/bulkdata/BILLS), which publishes every text
+// version of every bill as XML. Unlike the Congress.gov API it needs no key
+// and has no hourly request limit, so it suits full-text backfills.
+package govinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/provenance"
+	"github.com/drewjst/deltagov/internal/versioncode"
+)
+
+const (
+	baseURL        = "https://www.govinfo.gov/bulkdata"
+	defaultTimeout = 60 * time.Second
+
+	// maxTextSize bounds a downloaded text file, matching the ingestor's
+	// limit for Congress.gov text.
+	maxTextSize = 10 * 1024 * 1024
+)
+
+// Errors returned by the client.
+var (
+	ErrInvalidStatus = errors.New("govinfo: unexpected status code")
+	ErrNotFound      = errors.New("govinfo: resource not found")
+)
+
+// Client is a GovInfo bulk data client. It is safe for concurrent use.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// Option is a functional option for configuring the Client.
+type Option func(*Client)
+
+// WithHTTPClient sets a custom HTTP client for bulk data requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		if client != nil {
+			c.httpClient = client
+		}
+	}
+}
+
+// WithBaseURL overrides the default bulk data base URL.
+// Useful for testing with mock servers.
+func WithBaseURL(url string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// NewClient creates a new GovInfo bulk data client with the given options.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		httpClient: &http.Client{
+			Timeout: defaultTimeout,
+		},
+		baseURL: baseURL,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// BillFile is one text version of a bill in the BILLS collection.
+type BillFile struct {
+	Congress    int
+	BillType    string // Lower case, e.g., "hr"
+	BillNumber  int
+	VersionCode string // Upper case GPO code, e.g., "IH"
+	Link        string // Download URL of the XML
+}
+
+// fileNamePattern matches lower-cased bulk data file names such as
+// "bills-119hr1ih.xml": congress, bill type, number, and version code.
+var fileNamePattern = regexp.MustCompile(`^bills-(\d+)([a-z]+?)(\d+)([a-z]+)\.xml$`)
+
+// ParseFileName parses a BILLS bulk data file name. It reports false for
+// names that aren't bill text XML.
+func ParseFileName(name string) (BillFile, bool) {
+	m := fileNamePattern.FindStringSubmatch(strings.ToLower(name))
+	if m == nil {
+		return BillFile{}, false
+	}
+	congressNum, _ := strconv.Atoi(m[1])
+	number, _ := strconv.Atoi(m[3])
+	return BillFile{
+		Congress:    congressNum,
+		BillType:    m[2],
+		BillNumber:  number,
+		VersionCode: strings.ToUpper(m[4]),
+	}, true
+}
+
+// ListBills lists the text files of one congress, session, and bill type,
+// ordered by bill number and then legislative stage. It returns
+// ErrNotFound when the directory doesn't exist yet, e.g., for the second
+// session of a congress still in its first.
+func (c *Client) ListBills(ctx context.Context, congressNum, session int, billType string) ([]BillFile, error) {
+	path := fmt.Sprintf("/json/BILLS/%d/%d/%s", congressNum, session, strings.ToLower(billType))
+	resp, err := c.get(ctx, c.baseURL+path, "application/json")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var listing struct {
+		Files []struct {
+			Name   string `json:"name"`
+			Link   string `json:"link"`
+			Folder bool   `json:"folder"`
+		} `json:"files"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&listing); err != nil {
+		return nil, fmt.Errorf("govinfo: failed to decode %s: %w", path, err)
+	}
+
+	files := make([]BillFile, 0, len(listing.Files))
+	for _, f := range listing.Files {
+		if f.Folder {
+			continue
+		}
+		file, ok := ParseFileName(f.Name)
+		if !ok {
+			continue
+		}
+		file.Link = f.Link
+		files = append(files, file)
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		if files[i].BillNumber != files[j].BillNumber {
+			return files[i].BillNumber < files[j].BillNumber
+		}
+		return versioncode.Less(files[i].VersionCode, files[j].VersionCode)
+	})
+	return files, nil
+}
+
+// FetchText downloads a bill text file, with the provenance of what was
+// retrieved.
+func (c *Client) FetchText(ctx context.Context, link string) (string, *models.VersionProvenance, error) {
+	resp, err := c.get(ctx, link, "application/xml")
+	if err != nil {
+		return "", nil, err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxTextSize))
+	if err != nil {
+		return "", nil, fmt.Errorf("govinfo: failed to read %s: %w", link, err)
+	}
+	source := provenance.Capture(link, resp, content, time.Now())
+	return string(content), &source, nil
+}
+
+// get sends a GET request and checks the response status. The caller
+// closes the body.
+func (c *Client) get(ctx context.Context, url, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("govinfo: failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", accept)
+	if id := logging.RequestID(ctx); id != "" {
+		req.Header.Set(logging.RequestIDHeader, id)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("govinfo: failed to fetch %s: %w", url, err)
+	}
+	logging.FromContext(ctx).Debug("govinfo request",
+		"url", url, "status", resp.StatusCode, "latency_ms", time.Since(start).Milliseconds())
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("%w: %d", ErrInvalidStatus, resp.StatusCode)
+	}
+}
+
+// datePattern matches the Dublin Core date in bill XML metadata.
+var datePattern = regexp.MustCompile(`<dc:date>\s*(\d{4}-\d{2}-\d{2})`)
+
+// TextDate returns the date a text version was issued, from the dc:date
+// element of its XML. It reports false when the XML has none.
+func TextDate(content string) (time.Time, bool) {
+	m := datePattern.FindStringSubmatch(content)
+	if m == nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.DateOnly, m[1])
+	return t, err == nil
+}
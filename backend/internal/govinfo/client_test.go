@@ -0,0 +1,83 @@
+package govinfo_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/govinfo"
+)
+
+func TestParseFileName(t *testing.T) {
+	tests := []struct {
+		name string
+		want govinfo.BillFile
+		ok   bool
+	}{
+		{"BILLS-119hr1ih.xml", govinfo.BillFile{Congress: 119, BillType: "hr", BillNumber: 1, VersionCode: "IH"}, true},
+		{"BILLS-118hconres15enr.xml", govinfo.BillFile{Congress: 118, BillType: "hconres", BillNumber: 15, VersionCode: "ENR"}, true},
+		{"BILLS-118s870eas.xml", govinfo.BillFile{Congress: 118, BillType: "s", BillNumber: 870, VersionCode: "EAS"}, true},
+		{"BILLS-119hr1ih.pdf", govinfo.BillFile{}, false},
+		{"resources", govinfo.BillFile{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := govinfo.ParseFileName(tt.name)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("ParseFileName(%q) = %+v, %v; want %+v, %v", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestListBills(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/json/BILLS/119/1/hr":
+			io.WriteString(w, `{"files":[
+				{"name":"BILLS-119hr2eh.xml","link":"https://example.org/BILLS-119hr2eh.xml","folder":false},
+				{"name":"BILLS-119hr10ih.xml","link":"https://example.org/BILLS-119hr10ih.xml","folder":false},
+				{"name":"BILLS-119hr2ih.xml","link":"https://example.org/BILLS-119hr2ih.xml","folder":false},
+				{"name":"resources","link":"https://example.org/resources","folder":true}
+			]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	c := govinfo.NewClient(govinfo.WithBaseURL(srv.URL), govinfo.WithHTTPClient(srv.Client()))
+	files, err := c.ListBills(context.Background(), 119, 1, "HR")
+	if err != nil {
+		t.Fatalf("ListBills: %v", err)
+	}
+
+	var got []string
+	for _, f := range files {
+		got = append(got, f.VersionCode)
+		if f.Link == "" {
+			t.Errorf("file %+v has no link", f)
+		}
+	}
+	// Ordered by bill number, then stage
+	want := []string{"IH", "EH", "IH"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] || files[2].BillNumber != 10 {
+		t.Errorf("ListBills order = %v (%+v), want %v", got, files, want)
+	}
+
+	if _, err := c.ListBills(context.Background(), 119, 2, "hr"); !errors.Is(err, govinfo.ErrNotFound) {
+		t.Errorf("ListBills of a missing session: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestTextDate(t *testing.T) {
+	got, ok := govinfo.TextDate(`<bill><metadata><dublinCore><dc:date>2025-05-20</dc:date></dublinCore></metadata></bill>`)
+	if !ok || !got.Equal(time.Date(2025, 5, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("TextDate = %v, %v", got, ok)
+	}
+	if _, ok := govinfo.TextDate("<bill/>"); ok {
+		t.Error("TextDate of XML without a date reported ok")
+	}
+}
@@ -0,0 +1,61 @@
+package ingestor
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/drewjst/deltagov/internal/alerts"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// matchKeywordAlerts evaluates every user's keyword alerts against a new
+// version's plain text, recording a match with its snippets for each alert
+// that matches. Failures are logged rather than returned: alerts must not
+// fail ingestion of the version.
+func (s *Service) matchKeywordAlerts(ctx context.Context, version *models.Version) {
+	if version.PlainText == "" {
+		return
+	}
+	log := logging.FromContext(ctx)
+
+	var keywordAlerts []models.KeywordAlert
+	if err := s.db.WithContext(ctx).Order("id ASC").Find(&keywordAlerts).Error; err != nil {
+		log.Warn("failed to list keyword alerts", "version_id", version.ID, "error", err)
+		return
+	}
+
+	matched := 0
+	for _, alert := range keywordAlerts {
+		query, err := alerts.Parse(alert.Query)
+		if err != nil {
+			// Queries are validated when saved; a stricter parser may reject old ones
+			log.Debug("skipping invalid keyword alert", "alert_id", alert.ID, "error", err)
+			continue
+		}
+		snippets, ok := query.Match(version.PlainText)
+		if !ok {
+			continue
+		}
+
+		match := models.KeywordAlertMatch{
+			AlertID:   alert.ID,
+			UserID:    alert.UserID,
+			BillID:    version.BillID,
+			VersionID: version.ID,
+			Snippets:  make([]models.AlertSnippet, len(snippets)),
+		}
+		for i, snippet := range snippets {
+			match.Snippets[i] = models.AlertSnippet(snippet)
+		}
+		if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&match).Error; err != nil {
+			log.Warn("failed to record keyword alert match", "alert_id", alert.ID, "version_id", version.ID, "error", err)
+			continue
+		}
+		matched++
+	}
+	if matched > 0 {
+		log.Info("version matched keyword alerts", "version_id", version.ID, "alerts", matched)
+	}
+}
@@ -0,0 +1,54 @@
+package ingestor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// storeAliases stores a bill's short and popular titles as aliases for
+// search, replacing the ones from earlier syncs. Aliases curated by an
+// operator are kept.
+func (s *Service) storeAliases(ctx context.Context, bill *models.Bill, titles []congress.BillTitle) error {
+	var rows []models.BillAlias
+	seen := map[string]bool{strings.ToLower(bill.Title): true}
+	for _, title := range titles {
+		source := ""
+		switch titleKind(title) {
+		case models.TitleKindPopular:
+			source = models.AliasSourcePopularTitle
+		case models.TitleKindShort:
+			source = models.AliasSourceShortTitle
+		default:
+			continue
+		}
+		alias := strings.Join(strings.Fields(title.Title), " ")
+		if alias == "" || seen[strings.ToLower(alias)] {
+			continue
+		}
+		seen[strings.ToLower(alias)] = true
+		rows = append(rows, models.BillAlias{BillID: bill.ID, Alias: alias, Source: source})
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("bill_id = ? AND source <> ?", bill.ID, models.AliasSourceAdmin).
+			Delete(&models.BillAlias{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		// An operator may have added the same alias already
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store aliases: %w", err)
+	}
+	return nil
+}
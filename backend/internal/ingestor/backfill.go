@@ -0,0 +1,200 @@
+package ingestor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// AllBillTypes lists every bill type served by the Congress.gov bill endpoint.
+var AllBillTypes = congress.BillTypeCodes()
+
+// ParseBillTypes parses a comma-separated list of bill types, such as
+// "hr,s,hjres,sjres", into lower-case codes, rejecting types Congress.gov
+// doesn't serve. An empty list returns nil, for AllBillTypes.
+func ParseBillTypes(spec string) ([]string, error) {
+	var types []string
+	for _, item := range strings.Split(spec, ",") {
+		if item = strings.TrimSpace(item); item == "" {
+			continue
+		}
+		t, ok := congress.LookupBillType(item)
+		if !ok {
+			return nil, fmt.Errorf("ingestor: unknown bill type %q (want one of %s)", item, strings.Join(AllBillTypes, ", "))
+		}
+		types = append(types, t.Code)
+	}
+	return types, nil
+}
+
+const (
+	// DefaultBackfillDelay spaces out bills so a backfill stays well under
+	// the Congress.gov limit of 5,000 requests per hour. Each bill costs
+	// several requests (detail, cosponsors, text list, one per version).
+	DefaultBackfillDelay = 2 * time.Second
+
+	// rateLimitBackoff is how long to wait after a 429 before retrying.
+	rateLimitBackoff = time.Minute
+
+	// maxRateLimitRetries bounds consecutive 429 retries before giving up.
+	maxRateLimitRetries = 5
+)
+
+// BackfillConfig contains configuration for historical backfill.
+type BackfillConfig struct {
+	Congress  int           // Congress to backfill (required)
+	BillTypes []string      // Bill types to walk (default: AllBillTypes)
+	Delay     time.Duration // Pause between bills (default: DefaultBackfillDelay)
+	MaxBills  int           // Stop after this many bills this run; 0 for no limit
+	Restart   bool          // Ignore existing checkpoints and start from the beginning
+}
+
+// Backfill walks every bill of a congress via paginated FetchBills and
+// ingests all of each bill's text versions, not just the latest. Progress is
+// checkpointed per bill type after each page, so a later run resumes where
+// an interrupted one stopped; completed bill types are skipped.
+func (s *Service) Backfill(ctx context.Context, cfg BackfillConfig) (*IngestResult, error) {
+	if cfg.Congress <= 0 {
+		return nil, fmt.Errorf("ingestor: backfill requires a congress")
+	}
+	if len(cfg.BillTypes) == 0 {
+		cfg.BillTypes = AllBillTypes
+	}
+	if cfg.Delay <= 0 {
+		cfg.Delay = DefaultBackfillDelay
+	}
+
+	result := &IngestResult{}
+	logger := logging.FromContext(ctx)
+
+	for _, billType := range cfg.BillTypes {
+		checkpoint, err := s.loadCheckpoint(ctx, cfg.Congress, billType, cfg.Restart)
+		if err != nil {
+			return result, err
+		}
+		if checkpoint.CompletedAt != nil {
+			logger.Info("backfill already complete", "congress", cfg.Congress, "bill_type", billType)
+			continue
+		}
+
+		logger.Info("backfilling bill type", "congress", cfg.Congress, "bill_type", billType, "offset", checkpoint.Offset)
+
+		for {
+			if cfg.MaxBills > 0 && result.BillsFetched >= cfg.MaxBills {
+				logger.Info("backfill bill limit reached, stopping", "max_bills", cfg.MaxBills)
+				return result, nil
+			}
+
+			var page *congress.FetchBillsResult
+			err := withRateLimitRetry(ctx, func() error {
+				var err error
+				page, err = s.congressClient.FetchBills(ctx, cfg.Congress, billType, checkpoint.Offset)
+				return err
+			})
+			if err != nil {
+				return result, fmt.Errorf("ingestor: backfill failed to fetch %s offset %d: %w", billType, checkpoint.Offset, err)
+			}
+
+			bills := page.Bills
+			if cfg.MaxBills > 0 && result.BillsFetched+len(bills) > cfg.MaxBills {
+				// Only count what we process so the checkpoint stays exact
+				bills = bills[:cfg.MaxBills-result.BillsFetched]
+			}
+			result.BillsFetched += len(bills)
+
+			inScope, skipped := s.filterInScope(bills)
+			result.BillsSkipped += skipped
+
+			for i := range inScope {
+				if err := sleepContext(ctx, cfg.Delay); err != nil {
+					return result, err
+				}
+				// Errors are collected so one bad bill doesn't stop the backfill
+				_ = s.ingestOne(ctx, &inScope[i], result)
+			}
+
+			checkpoint.Offset += len(bills)
+			checkpoint.BillsDone += len(bills)
+			checkpoint.TotalCount = page.TotalCount
+			if !page.HasMore && len(bills) == len(page.Bills) {
+				now := time.Now()
+				checkpoint.CompletedAt = &now
+			}
+			if err := s.db.WithContext(context.WithoutCancel(ctx)).Save(checkpoint).Error; err != nil {
+				return result, fmt.Errorf("ingestor: failed to save backfill checkpoint: %w", err)
+			}
+
+			logger.Info("backfill progress",
+				"congress", cfg.Congress, "bill_type", billType,
+				"offset", checkpoint.Offset, "total", checkpoint.TotalCount)
+
+			if checkpoint.CompletedAt != nil || len(page.Bills) == 0 {
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// loadCheckpoint returns the checkpoint for a congress and bill type,
+// creating it (or resetting it when restart is set) as needed.
+func (s *Service) loadCheckpoint(ctx context.Context, congressNum int, billType string, restart bool) (*models.BackfillCheckpoint, error) {
+	checkpoint := &models.BackfillCheckpoint{}
+	if err := s.db.WithContext(ctx).
+		Where(models.BackfillCheckpoint{Congress: congressNum, BillType: billType}).
+		FirstOrCreate(checkpoint).Error; err != nil {
+		return nil, fmt.Errorf("ingestor: failed to load backfill checkpoint: %w", err)
+	}
+
+	if restart {
+		checkpoint.Offset = 0
+		checkpoint.BillsDone = 0
+		checkpoint.CompletedAt = nil
+		if err := s.db.WithContext(ctx).Save(checkpoint).Error; err != nil {
+			return nil, fmt.Errorf("ingestor: failed to reset backfill checkpoint: %w", err)
+		}
+	}
+
+	return checkpoint, nil
+}
+
+// withRateLimitRetry runs fn, waiting and retrying whenever it fails with
+// congress.ErrRateLimited: for as long as a rate-limited text download's
+// server asked, or rateLimitBackoff.
+func withRateLimitRetry(ctx context.Context, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if !errors.Is(err, congress.ErrRateLimited) || attempt >= maxRateLimitRetries {
+			return err
+		}
+		backoff := rateLimitBackoff
+		var textErr *textRateLimitError
+		if errors.As(err, &textErr) {
+			backoff = textErr.retryAfter
+		}
+		logging.FromContext(ctx).Warn("rate limited by Congress.gov, backing off",
+			"backoff", backoff.String(), "attempt", attempt+1)
+		if err := sleepContext(ctx, backoff); err != nil {
+			return err
+		}
+	}
+}
+
+// sleepContext waits for d or until ctx is cancelled.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,175 @@
+package ingestor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// checkpointInterval is how often a run's cursor is saved while it
+// processes bills. A run killed outright (e.g., a Cloud Run timeout)
+// repeats at most this much work; one that's cancelled saves on the way out.
+const checkpointInterval = 5 * time.Second
+
+// runKey is the context key for the *runState of the run being recorded.
+type runKey struct{}
+
+// runState is what RecordRun hands the ingestion it records.
+type runState struct {
+	run    *models.IngestRun
+	resume *models.IngestRun // Interrupted run of the same mode, if any
+}
+
+// billKey identifies a Congress.gov bill in a cursor, e.g., "119-hr-1234".
+func billKey(bill *congress.Bill) string {
+	return fmt.Sprintf("%d-%s-%s", bill.Congress, strings.ToLower(bill.Type), bill.Number)
+}
+
+// lastInterrupted returns the latest run of mode when it didn't succeed and
+// left a cursor, or nil.
+func (s *Service) lastInterrupted(ctx context.Context, mode string) (*models.IngestRun, error) {
+	var runs []models.IngestRun
+	if err := s.db.WithContext(ctx).Where("mode = ?", mode).
+		Order("started_at DESC, id DESC").Limit(1).Find(&runs).Error; err != nil {
+		return nil, err
+	}
+	if len(runs) == 0 || runs[0].Status == models.IngestRunSucceeded || runs[0].CursorBill == "" {
+		return nil, nil
+	}
+	return &runs[0], nil
+}
+
+// resumed reports which of bills, listed from source, the interrupted run
+// already processed, or returns nil when it can't tell. They're the bills
+// up to its cursor bill, provided that bill is listed unchanged, except
+// any updated since the interrupted run listed them: those are newer than
+// anything it listed and are processed again. So are the others sharing
+// the cursor bill's updateDate, as ties may be listed in another order
+// than the interrupted run's, with bills it never reached before its
+// cursor bill.
+func resumed(bills []congress.Bill, source string, resume *models.IngestRun) []bool {
+	if resume == nil || resume.CursorSource != source {
+		return nil
+	}
+	for i := range bills {
+		if billKey(&bills[i]) != resume.CursorBill {
+			continue
+		}
+		if bills[i].UpdateDate != resume.CursorUpdateDate {
+			return nil
+		}
+		done := make([]bool, len(bills))
+		for j := range bills[:i+1] {
+			done[j] = bills[j].UpdateDate <= resume.CursorNewest &&
+				(j == i || bills[j].UpdateDate != resume.CursorUpdateDate)
+		}
+		return done
+	}
+	return nil
+}
+
+// cursor tracks which of a run's bills are done and checkpoints the last
+// bill of the done prefix, since workers finish bills out of order.
+type cursor struct {
+	s      *Service
+	state  *runState
+	source string
+	newest string
+
+	mu      sync.Mutex
+	bills   []congress.Bill
+	done    []bool
+	next    int // bills[:next] are done
+	saved   int // next when last saved
+	savedAt time.Time
+}
+
+// newCursor starts a cursor over bills, listed from source, with the bills
+// the run's interrupted predecessor processed already done. It returns nil
+// when no run is being recorded.
+func (s *Service) newCursor(ctx context.Context, source string, bills []congress.Bill) *cursor {
+	state, _ := ctx.Value(runKey{}).(*runState)
+	if state == nil {
+		return nil
+	}
+
+	newest := ""
+	for i := range bills {
+		newest = max(newest, bills[i].UpdateDate)
+	}
+	done := resumed(bills, source, state.resume)
+	if done == nil {
+		done = make([]bool, len(bills))
+	}
+	c := &cursor{s: s, state: state, source: source, newest: newest, bills: bills, done: done, savedAt: time.Now()}
+	for c.next < len(done) && done[c.next] {
+		c.next++
+	}
+	// Until the first save the run keeps the cursor it resumed from
+	if c.next > 0 {
+		c.saveLocked(ctx)
+	}
+	return c
+}
+
+// markDone records that bills[i] is done, saving the cursor when the done
+// prefix has grown and checkpointInterval has passed.
+func (c *cursor) markDone(ctx context.Context, i int) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.done[i] = true
+	for c.next < len(c.done) && c.done[c.next] {
+		c.next++
+	}
+	if c.next > c.saved && time.Since(c.savedAt) >= checkpointInterval {
+		c.saveLocked(ctx)
+	}
+}
+
+// flush saves the cursor if it has moved since the last save.
+func (c *cursor) flush(ctx context.Context) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.next > c.saved {
+		c.saveLocked(ctx)
+	}
+}
+
+// saveLocked writes the cursor to the run's record. Failures are logged;
+// the run then just resumes from an earlier bill.
+func (c *cursor) saveLocked(ctx context.Context) {
+	run := c.state.run
+	last := &c.bills[c.next-1]
+	run.CursorSource, run.CursorNewest = c.source, c.newest
+	run.CursorBill = billKey(last)
+	run.CursorUpdateDate = last.UpdateDate
+	c.saved, c.savedAt = c.next, time.Now()
+
+	if run.ID == 0 {
+		return // The run's start wasn't recorded
+	}
+	// Saved even when the run is being cancelled, which is when it matters
+	if err := c.s.db.WithContext(context.WithoutCancel(ctx)).Model(&models.IngestRun{}).
+		Where("id = ?", run.ID).
+		Updates(map[string]any{
+			"cursor_source":      run.CursorSource,
+			"cursor_bill":        run.CursorBill,
+			"cursor_update_date": run.CursorUpdateDate,
+			"cursor_newest":      run.CursorNewest,
+		}).Error; err != nil {
+		logging.FromContext(ctx).Warn("failed to checkpoint ingestion run", "run_id", run.ID, "error", err)
+	}
+}
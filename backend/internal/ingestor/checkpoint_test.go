@@ -0,0 +1,169 @@
+package ingestor_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/congresstest"
+	"github.com/drewjst/deltagov/internal/ingestor"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// cancelOnRequest cancels a context once a request for a path containing
+// path is made, interrupting the run using it as if it were stopped.
+type cancelOnRequest struct {
+	path   string
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnRequest) RoundTrip(req *http.Request) (*http.Response, error) {
+	if strings.Contains(req.URL.Path, c.path) {
+		c.cancel()
+		return nil, context.Canceled
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+// interruptedRun records a recent-bills run, one bill at a time, that's
+// cancelled on its first request for the bill at path, and returns its
+// record. The bill being processed is stored, without its detail and text,
+// before the run stops.
+func interruptedRun(t *testing.T, db *gorm.DB, srv *congresstest.Server, path string) models.IngestRun {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, err := congress.NewClient(
+		congress.WithAPIKey(congresstest.APIKey),
+		congress.WithBaseURL(srv.BaseURL()),
+		congress.WithHTTPClient(&http.Client{Transport: &cancelOnRequest{path: path, cancel: cancel}}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	svc := ingestor.NewService(db, client)
+	svc.SetConcurrency(1)
+	if _, err := recordRecent(ctx, svc); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Interrupted run: error = %v, want context.Canceled", err)
+	}
+
+	var run models.IngestRun
+	if err := db.Order("id DESC").First(&run).Error; err != nil {
+		t.Fatalf("Failed to read run: %v", err)
+	}
+	return run
+}
+
+// recordRecent records a recent-bills run of svc.
+func recordRecent(ctx context.Context, svc *ingestor.Service) (*ingestor.IngestResult, error) {
+	return svc.RecordRun(ctx, "test", "recent", func(ctx context.Context) (*ingestor.IngestResult, error) {
+		return svc.IngestRecentBills(ctx, 10)
+	})
+}
+
+// lastRun returns the latest recorded run.
+func lastRun(t *testing.T, db *gorm.DB) models.IngestRun {
+	t.Helper()
+	var run models.IngestRun
+	if err := db.Order("id DESC").First(&run).Error; err != nil {
+		t.Fatalf("Failed to read run: %v", err)
+	}
+	return run
+}
+
+// TestResumeInterruptedRun verifies a run cancelled part way resumes past
+// the bills it processed, and that once a run completes the next one
+// starts over.
+func TestResumeInterruptedRun(t *testing.T) {
+	srv := congresstest.NewServer(t)
+	db := congresstest.OpenDB(t)
+	for i, date := range []string{"2025-03-04", "2025-03-03", "2025-03-02", "2025-03-01"} {
+		srv.AddBill(congress.Bill{Congress: 119, Type: "HR", Number: strconv.Itoa(i + 1), Title: "Test Act",
+			UpdateDate: date, UpdateDateIncludingText: date})
+	}
+
+	interrupted := interruptedRun(t, db, srv, "/bill/119/hr/3")
+	if interrupted.Status != models.IngestRunFailed || interrupted.CursorBill != "119-hr-3" ||
+		interrupted.CursorUpdateDate != "2025-03-02" || interrupted.CursorNewest != "2025-03-04" {
+		t.Fatalf("Interrupted run = %+v, want it failed with its cursor at H.R. 3", interrupted)
+	}
+
+	// Bills processed again would be updated from these
+	stale := func() {
+		t.Helper()
+		if err := db.Model(&models.Bill{}).Where("number IN ?", []string{"1", "2", "3"}).
+			Update("update_date", "2025-01-01").Error; err != nil {
+			t.Fatalf("Failed to age bills: %v", err)
+		}
+	}
+	stale()
+
+	svc := ingestor.NewService(db, srv.Client(t))
+	result, err := recordRecent(context.Background(), svc)
+	if err != nil {
+		t.Fatalf("Resumed run failed: %v", err)
+	}
+	if result.BillsCreated != 1 || result.BillsUpdated != 0 || len(result.Errors) != 0 {
+		t.Errorf("Resumed run: got %+v, want H.R. 4 created and the rest skipped", result)
+	}
+	resumed := lastRun(t, db)
+	if resumed.Status != models.IngestRunSucceeded || resumed.ResumedFrom == nil || *resumed.ResumedFrom != interrupted.ID {
+		t.Errorf("Resumed run = %+v, want it succeeded, resumed from run %d", resumed, interrupted.ID)
+	}
+
+	// The resumed run completed, so this one processes every bill
+	stale()
+	result, err = recordRecent(context.Background(), svc)
+	if err != nil {
+		t.Fatalf("Run after a completed one failed: %v", err)
+	}
+	if result.BillsUpdated != 3 {
+		t.Errorf("Run after a completed one: got %+v, want H.R. 1 to 3 updated", result)
+	}
+	if run := lastRun(t, db); run.ResumedFrom != nil {
+		t.Errorf("Run after a completed one resumed from run %d, want it started over", *run.ResumedFrom)
+	}
+}
+
+// TestResumeTiedBills verifies bills sharing the cursor bill's updateDate
+// aren't skipped as processed when they're listed before it only on
+// resuming, since ties can be listed in any order.
+func TestResumeTiedBills(t *testing.T) {
+	srv := congresstest.NewServer(t)
+	db := congresstest.OpenDB(t)
+	tied := func(number string) congress.Bill {
+		return congress.Bill{Congress: 119, Type: "HR", Number: number, Title: "Test Act",
+			UpdateDate: "2025-03-01", UpdateDateIncludingText: "2025-03-01"}
+	}
+	for _, number := range []string{"1", "2", "3"} {
+		srv.AddBill(tied(number))
+	}
+
+	interrupted := interruptedRun(t, db, srv, "/bill/119/hr/2")
+	if interrupted.CursorBill != "119-hr-2" {
+		t.Fatalf("Interrupted run = %+v, want its cursor at H.R. 2", interrupted)
+	}
+
+	// Re-adding H.R. 1 and 2 lists them after H.R. 3, tied with them
+	srv.AddBill(tied("1"))
+	srv.AddBill(tied("2"))
+	svc := ingestor.NewService(db, srv.Client(t))
+	result, err := recordRecent(context.Background(), svc)
+	if err != nil {
+		t.Fatalf("Resumed run failed: %v", err)
+	}
+	if result.BillsCreated != 1 || len(result.Errors) != 0 {
+		t.Errorf("Resumed run: got %+v, want H.R. 3 created", result)
+	}
+	var count int64
+	db.Model(&models.Bill{}).Count(&count)
+	if count != 3 {
+		t.Errorf("Stored %d bills, want 3", count)
+	}
+}
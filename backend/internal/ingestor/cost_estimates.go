@@ -0,0 +1,46 @@
+package ingestor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// syncCostEstimates upserts the CBO cost estimates listed in a bill's detail.
+func (s *Service) syncCostEstimates(ctx context.Context, bill *models.Bill, detail *congress.BillDetail) error {
+	rows := make([]models.CostEstimate, 0, len(detail.CBOCostEstimates))
+	seen := make(map[string]bool, len(detail.CBOCostEstimates))
+	for _, est := range detail.CBOCostEstimates {
+		url := strings.TrimSpace(est.URL)
+		if url == "" || seen[url] {
+			continue
+		}
+		seen[url] = true
+
+		pubDate, _ := parseTextVersionDate(est.PubDate)
+		rows = append(rows, models.CostEstimate{
+			BillID:      bill.ID,
+			URL:         url,
+			Title:       est.Title,
+			Description: est.Description,
+			PubDate:     pubDate,
+		})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "bill_id"}, {Name: "url"}},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "description", "pub_date", "updated_at"}),
+	}).Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to upsert cost estimates: %w", err)
+	}
+
+	return nil
+}
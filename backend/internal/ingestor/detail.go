@@ -0,0 +1,54 @@
+package ingestor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// syncDetail records the fields of a bill only its detail has: its
+// introduced date, policy area, and cosponsor, action, and amendment
+// counts. The detail replaces the list entry as the bill's metadata, so
+// the rest (committee reports, the constitutional authority statement,
+// and so on) is kept too.
+func (s *Service) syncDetail(ctx context.Context, bill *models.Bill, detail *congress.BillDetail) error {
+	metadata, err := s.billToMetadata(detail)
+	if err != nil {
+		return fmt.Errorf("failed to create metadata: %w", err)
+	}
+
+	bill.Metadata = metadata
+	bill.CosponsorCount, bill.ActionCount, bill.AmendmentCount = 0, 0, 0
+	if detail.Cosponsors != nil {
+		bill.CosponsorCount = detail.Cosponsors.Count
+	}
+	if detail.Actions != nil {
+		bill.ActionCount = detail.Actions.Count
+	}
+	if detail.Amendments != nil {
+		bill.AmendmentCount = detail.Amendments.Count
+	}
+	updates := map[string]any{
+		"metadata":        bill.Metadata,
+		"cosponsor_count": bill.CosponsorCount,
+		"action_count":    bill.ActionCount,
+		"amendment_count": bill.AmendmentCount,
+	}
+	if detail.IntroducedDate != "" {
+		bill.IntroducedDate = detail.IntroducedDate
+		updates["introduced_date"] = bill.IntroducedDate
+	}
+	// Until CRS assigns one, the detail has none
+	if detail.PolicyArea != nil && detail.PolicyArea.Name != "" {
+		bill.PolicyArea = detail.PolicyArea.Name
+		updates["policy_area"] = bill.PolicyArea
+	}
+
+	if err := s.db.WithContext(ctx).Model(&models.Bill{}).Where("id = ?", bill.ID).
+		Updates(updates).Error; err != nil {
+		return fmt.Errorf("failed to update bill detail: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package ingestor
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/datatypes"
+
+	"github.com/drewjst/deltagov/internal/events"
+	"github.com/drewjst/deltagov/internal/live"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// recordEvent appends a BillEvent to the bill's change feed. Failures are
+// logged rather than returned: a missing feed entry must not fail ingestion
+// of the bill itself.
+func (s *Service) recordEvent(ctx context.Context, event models.BillEvent) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	if err := s.db.WithContext(ctx).Create(&event).Error; err != nil {
+		logging.FromContext(ctx).Warn("failed to record bill event",
+			"bill_id", event.BillID, "event_type", event.EventType, "error", err)
+	}
+}
+
+// SetEventPublisher publishes bill and version events to a message broker
+// for downstream services. A nil value disables it.
+func (s *Service) SetEventPublisher(p events.Publisher) {
+	s.publisher = p
+}
+
+// brokerEventTypes maps live event types to their broker counterparts.
+var brokerEventTypes = map[string]string{
+	live.EventBillCreated:    events.TypeBillCreated,
+	live.EventBillUpdated:    events.TypeBillUpdated,
+	live.EventVersionCreated: events.TypeVersionCreated,
+}
+
+// publishEvent notifies API instances, and the event publisher if set, of
+// a change to a bill, filling in the bill's identifying fields. Failures
+// are logged; live updates are best effort and the change feed remains the
+// durable record.
+func (s *Service) publishEvent(ctx context.Context, bill *models.Bill, event live.Event) {
+	event.BillID = bill.ID
+	event.Congress = bill.Congress
+	event.BillType = bill.BillType
+	event.Number = bill.Number
+	event.BillNumber = bill.BillNumber
+	event.Title = bill.Title
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	if err := live.Publish(ctx, s.db, event); err != nil {
+		logging.FromContext(ctx).Warn("failed to publish live event",
+			"bill_id", bill.ID, "event_type", event.Type, "error", err)
+	}
+
+	if s.publisher == nil {
+		return
+	}
+	err := s.publisher.Publish(ctx, events.Event{
+		Type:        brokerEventTypes[event.Type],
+		OccurredAt:  event.OccurredAt,
+		BillID:      event.BillID,
+		Congress:    event.Congress,
+		BillType:    event.BillType,
+		Number:      event.Number,
+		BillNumber:  event.BillNumber,
+		Title:       event.Title,
+		VersionID:   event.VersionID,
+		VersionCode: event.VersionCode,
+	})
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to publish event",
+			"bill_id", bill.ID, "event_type", event.Type, "error", err)
+	}
+}
+
+// recordBillChanges records title and status transitions between the stored
+// bill and its freshly fetched replacement.
+func (s *Service) recordBillChanges(ctx context.Context, previous, current *models.Bill) {
+	if previous.Title != current.Title {
+		s.recordEvent(ctx, models.BillEvent{
+			BillID:        previous.ID,
+			EventType:     models.BillEventTitleChanged,
+			PreviousValue: previous.Title,
+			NewValue:      current.Title,
+		})
+	}
+	if previous.CurrentStatus != current.CurrentStatus {
+		s.recordEvent(ctx, models.BillEvent{
+			BillID:        previous.ID,
+			EventType:     models.BillEventStatusChanged,
+			PreviousValue: previous.CurrentStatus,
+			NewValue:      current.CurrentStatus,
+		})
+	}
+}
+
+// recordSponsorChanges records a sponsor_changed event when the primary
+// sponsor changed or cosponsors were added. known holds the Bioguide IDs
+// linked to the bill before this sync; sponsors are only diffed for bills
+// that already had sponsorships, so a first sync doesn't flood the feed.
+func (s *Service) recordSponsorChanges(ctx context.Context, billID uint, known map[string]bool,
+	previousSponsor, currentSponsor string, sponsorships []models.BillSponsorship, names map[string]string) {
+	if len(known) == 0 {
+		return
+	}
+
+	added := make([]string, 0)
+	for _, sp := range sponsorships {
+		if !known[sp.BioguideID] {
+			added = append(added, names[sp.BioguideID])
+		}
+	}
+
+	sponsorChanged := currentSponsor != "" && previousSponsor != currentSponsor
+	if len(added) == 0 && !sponsorChanged {
+		return
+	}
+
+	event := models.BillEvent{
+		BillID:    billID,
+		EventType: models.BillEventSponsorChanged,
+		Details:   datatypes.JSONMap{"cosponsorsAdded": added},
+	}
+	if sponsorChanged {
+		event.PreviousValue = previousSponsor
+		event.NewValue = currentSponsor
+	}
+	s.recordEvent(ctx, event)
+}
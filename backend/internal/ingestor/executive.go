@@ -0,0 +1,201 @@
+package ingestor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ExecutiveSyncResult counts what SyncExecutive stored.
+type ExecutiveSyncResult struct {
+	Treaties    int // Treaties new or changed since the last sync
+	Nominations int // Nominations new or changed since the last sync
+	Failed      int // Treaties and nominations that couldn't be fetched or stored
+}
+
+// SyncExecutive stores the treaties and nominations of a congress, the
+// Senate's executive business, with their action histories. Only those
+// whose Congress.gov update date changed since the last sync are
+// re-fetched. Only being rate limited stops a sync early; items that fail
+// otherwise are logged, counted, and retried on the next sync.
+func (s *Service) SyncExecutive(ctx context.Context, congressNum int) (*ExecutiveSyncResult, error) {
+	result := &ExecutiveSyncResult{}
+	if err := s.syncTreaties(ctx, congressNum, result); err != nil {
+		return result, err
+	}
+	if err := s.syncNominations(ctx, congressNum, result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// syncTreaties stores the new and changed treaties received by a congress.
+func (s *Service) syncTreaties(ctx context.Context, congressNum int, result *ExecutiveSyncResult) error {
+	logger := logging.FromContext(ctx)
+	listed, err := s.congressClient.GetTreaties(ctx, congressNum)
+	if err != nil {
+		return fmt.Errorf("failed to list treaties: %w", err)
+	}
+
+	var stored []models.Treaty
+	if err := s.db.WithContext(ctx).Select("number", "suffix", "update_date").
+		Where("congress = ?", congressNum).Find(&stored).Error; err != nil {
+		return fmt.Errorf("failed to query stored treaties: %w", err)
+	}
+	updated := make(map[string]string, len(stored))
+	for _, t := range stored {
+		updated[fmt.Sprintf("%d%s", t.Number, t.Suffix)] = t.UpdateDate
+	}
+
+	for _, t := range listed {
+		// Suffixes are stored uppercase
+		if date, seen := updated[fmt.Sprintf("%d%s", t.Number, strings.ToUpper(t.Suffix))]; seen && date == t.UpdateDate {
+			continue
+		}
+		err := s.syncTreaty(ctx, congressNum, t)
+		if errors.Is(err, congress.ErrRateLimited) {
+			return err
+		}
+		if err != nil {
+			result.Failed++
+			logger.Warn("failed to sync treaty", "congress", congressNum, "number", t.Number, "suffix", t.Suffix, "error", err)
+			continue
+		}
+		result.Treaties++
+	}
+	return nil
+}
+
+// syncTreaty fetches a treaty's detail and actions and upserts it.
+func (s *Service) syncTreaty(ctx context.Context, congressNum int, listed congress.Treaty) error {
+	detail, err := s.congressClient.GetTreaty(ctx, congressNum, listed.Number, listed.Suffix)
+	if err != nil {
+		return fmt.Errorf("failed to fetch treaty: %w", err)
+	}
+	actions, err := s.congressClient.GetTreatyActions(ctx, congressNum, listed.Number, listed.Suffix)
+	if err != nil && !errors.Is(err, congress.ErrNotFound) {
+		return fmt.Errorf("failed to fetch treaty actions: %w", err)
+	}
+
+	treaty := models.Treaty{
+		Congress:           congressNum,
+		Number:             listed.Number,
+		Suffix:             strings.ToUpper(listed.Suffix),
+		CongressConsidered: detail.CongressConsidered,
+		Topic:              detail.Topic,
+		Title:              detail.FormalTitle(),
+		TransmittedDate:    dateOnly(detail.TransmittedDate),
+		InForceDate:        dateOnly(detail.InForceDate),
+		UpdateDate:         listed.UpdateDate,
+		Actions:            legislativeActions(actions),
+	}
+	if n := len(treaty.Actions); n > 0 {
+		treaty.CurrentStatus, treaty.StatusDate = treaty.Actions[n-1].Text, treaty.Actions[n-1].Date
+	}
+
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "congress"}, {Name: "number"}, {Name: "suffix"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"congress_considered", "topic", "title", "transmitted_date", "in_force_date",
+			"current_status", "status_date", "update_date", "actions", "updated_at",
+		}),
+	}).Create(&treaty).Error; err != nil {
+		return fmt.Errorf("failed to upsert treaty: %w", err)
+	}
+	return nil
+}
+
+// syncNominations stores the new and changed nominations of a congress.
+func (s *Service) syncNominations(ctx context.Context, congressNum int, result *ExecutiveSyncResult) error {
+	logger := logging.FromContext(ctx)
+	listed, err := s.congressClient.GetNominations(ctx, congressNum)
+	if err != nil {
+		return fmt.Errorf("failed to list nominations: %w", err)
+	}
+
+	var stored []models.Nomination
+	if err := s.db.WithContext(ctx).Select("citation", "update_date").
+		Where("congress = ?", congressNum).Find(&stored).Error; err != nil {
+		return fmt.Errorf("failed to query stored nominations: %w", err)
+	}
+	updated := make(map[string]string, len(stored))
+	for _, n := range stored {
+		updated[n.Citation] = n.UpdateDate
+	}
+
+	for _, n := range listed {
+		if date, seen := updated[n.Citation]; seen && date == n.UpdateDate {
+			continue
+		}
+		err := s.syncNomination(ctx, congressNum, n)
+		if errors.Is(err, congress.ErrRateLimited) {
+			return err
+		}
+		if err != nil {
+			result.Failed++
+			logger.Warn("failed to sync nomination", "congress", congressNum, "citation", n.Citation, "error", err)
+			continue
+		}
+		result.Nominations++
+	}
+	return nil
+}
+
+// syncNomination fetches a nomination's actions and upserts it.
+func (s *Service) syncNomination(ctx context.Context, congressNum int, listed congress.Nomination) error {
+	actions, err := s.congressClient.GetNominationActions(ctx, congressNum, listed.Number)
+	if err != nil && !errors.Is(err, congress.ErrNotFound) {
+		return fmt.Errorf("failed to fetch nomination actions: %w", err)
+	}
+
+	nomination := models.Nomination{
+		Congress:     congressNum,
+		Number:       listed.Number,
+		PartNumber:   listed.PartNumber,
+		Citation:     listed.Citation,
+		Description:  listed.Description,
+		Organization: listed.Organization,
+		IsMilitary:   listed.NominationType != nil && listed.NominationType.IsMilitary,
+		ReceivedDate: dateOnly(listed.ReceivedDate),
+		UpdateDate:   listed.UpdateDate,
+		Actions:      legislativeActions(actions),
+	}
+	if listed.LatestAction != nil {
+		nomination.CurrentStatus, nomination.StatusDate = listed.LatestAction.Text, listed.LatestAction.ActionDate
+	}
+
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "congress"}, {Name: "number"}, {Name: "part_number"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"citation", "description", "organization", "is_military", "received_date",
+			"current_status", "status_date", "update_date", "actions", "updated_at",
+		}),
+	}).Create(&nomination).Error; err != nil {
+		return fmt.Errorf("failed to upsert nomination: %w", err)
+	}
+	return nil
+}
+
+// legislativeActions converts actions, which Congress.gov lists newest
+// first, to the oldest-first form they are stored in.
+func legislativeActions(actions []congress.Action) []models.LegislativeAction {
+	out := make([]models.LegislativeAction, len(actions))
+	for i, a := range actions {
+		out[len(actions)-1-i] = models.LegislativeAction{Date: a.ActionDate, Text: a.Text, Type: a.Type}
+	}
+	return out
+}
+
+// dateOnly trims a Congress.gov timestamp such as "2023-05-01T00:00:00Z" to
+// its date.
+func dateOnly(timestamp string) string {
+	date, _, _ := strings.Cut(timestamp, "T")
+	return date
+}
@@ -0,0 +1,174 @@
+package ingestor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/datatypes"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// MaxFailureAttempts is how many times a bill's ingestion may fail before
+// its failure is exhausted and only retried when an operator requeues it.
+const MaxFailureAttempts = 5
+
+// DefaultRetryBatch is the number of due failures RetryFailures takes on
+// when not given a limit.
+const DefaultRetryBatch = 50
+
+// Retry backoff: the first retry waits failureBackoff, and each further
+// one twice as long, up to maxFailureBackoff.
+const (
+	failureBackoff    = 15 * time.Minute
+	maxFailureBackoff = 24 * time.Hour
+)
+
+// classifyError returns the models.IngestError class of an ingestion error.
+func classifyError(err error) string {
+	var netErr net.Error
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, congress.ErrRateLimited):
+		return models.IngestErrorRateLimited
+	case errors.Is(err, congress.ErrNotFound):
+		return models.IngestErrorNotFound
+	case errors.Is(err, congress.ErrInvalidStatus):
+		return models.IngestErrorUpstream
+	case errors.Is(err, context.DeadlineExceeded):
+		return models.IngestErrorTimeout
+	case errors.As(err, &pgErr):
+		return models.IngestErrorDatabase
+	case errors.As(err, &netErr):
+		if netErr.Timeout() {
+			return models.IngestErrorTimeout
+		}
+		return models.IngestErrorNetwork
+	}
+	return models.IngestErrorOther
+}
+
+// failureDelay returns how long to wait before retrying a bill that has
+// failed attempts times.
+func failureDelay(attempts int) time.Duration {
+	delay := failureBackoff
+	for i := 1; i < attempts && delay < maxFailureBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxFailureBackoff)
+}
+
+// recordFailure dead-letters a bill whose ingestion failed at stage, or
+// counts another attempt on its open failure. Errors from cancelling the
+// run aren't the bill's and aren't recorded. Failing to record is logged.
+func (s *Service) recordFailure(ctx context.Context, stage string, apiBill *congress.Bill, cause error) {
+	if errors.Is(cause, context.Canceled) {
+		return
+	}
+	logger := logging.FromContext(ctx)
+	db := s.db.WithContext(context.WithoutCancel(ctx))
+
+	payload, err := json.Marshal(apiBill)
+	if err != nil {
+		logger.Warn("failed to encode failed bill", "error", err)
+		return
+	}
+
+	var failures []models.IngestFailure
+	if err := db.Where("bill_key = ? AND stage = ? AND status IN ?", billKey(apiBill), stage,
+		[]string{models.IngestFailurePending, models.IngestFailureExhausted}).
+		Limit(1).Find(&failures).Error; err != nil {
+		logger.Warn("failed to look up ingestion failure", "error", err)
+		return
+	}
+	failure := models.IngestFailure{BillKey: billKey(apiBill), Stage: stage}
+	if len(failures) > 0 {
+		failure = failures[0]
+	} else if state, _ := ctx.Value(runKey{}).(*runState); state != nil && state.run.ID != 0 {
+		failure.RunID = &state.run.ID
+	}
+
+	failure.Attempts++
+	failure.ErrorClass = classifyError(cause)
+	failure.LastError = cause.Error()
+	failure.Payload = datatypes.JSON(payload)
+	failure.NextAttemptAt = time.Now().Add(failureDelay(failure.Attempts))
+	failure.Status = models.IngestFailurePending
+	if failure.Attempts >= MaxFailureAttempts {
+		failure.Status = models.IngestFailureExhausted
+	}
+	if err := db.Save(&failure).Error; err != nil {
+		logger.Warn("failed to record ingestion failure", "bill", failure.BillKey, "error", err)
+	}
+}
+
+// DueFailures returns how many pending failures are due for a retry.
+func (s *Service) DueFailures(ctx context.Context) (int64, error) {
+	var due int64
+	if err := s.db.WithContext(ctx).Model(&models.IngestFailure{}).
+		Where("status = ? AND next_attempt_at <= ?", models.IngestFailurePending, time.Now()).
+		Count(&due).Error; err != nil {
+		return 0, fmt.Errorf("ingestor: failed to count due failures: %w", err)
+	}
+	return due, nil
+}
+
+// RetryFailures ingests again up to limit pending failures whose retry is
+// due, oldest due first, resolving those that succeed. A bill that fails
+// again has its failure's attempts counted and its retry pushed back. It
+// stops early when rate limited.
+func (s *Service) RetryFailures(ctx context.Context, limit int) (*IngestResult, error) {
+	if limit <= 0 {
+		limit = DefaultRetryBatch
+	}
+
+	var due []models.IngestFailure
+	if err := s.db.WithContext(ctx).
+		Where("status = ? AND next_attempt_at <= ?", models.IngestFailurePending, time.Now()).
+		Order("next_attempt_at ASC, id ASC").Limit(limit).Find(&due).Error; err != nil {
+		return nil, fmt.Errorf("ingestor: failed to fetch due failures: %w", err)
+	}
+
+	result := &IngestResult{BillsFetched: len(due)}
+	for _, failure := range due {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		var apiBill congress.Bill
+		if err := json.Unmarshal(failure.Payload, &apiBill); err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("failure %d: invalid payload: %w", failure.ID, err))
+			continue
+		}
+
+		err := s.ingestOne(ctx, &apiBill, result)
+		if errors.Is(err, congress.ErrRateLimited) {
+			return result, fmt.Errorf("ingestor: retries stopped: %w", err)
+		}
+		if err != nil {
+			continue
+		}
+
+		// Unless the attempt recorded another failure, such as its versions
+		// failing again, the failure is resolved
+		resolved := s.db.WithContext(ctx).Model(&models.IngestFailure{}).
+			Where("id = ? AND attempts = ?", failure.ID, failure.Attempts).
+			Updates(map[string]any{"status": models.IngestFailureResolved, "resolved_at": time.Now()})
+		if resolved.Error != nil {
+			logging.FromContext(ctx).Warn("failed to resolve ingestion failure", "failure_id", failure.ID, "error", resolved.Error)
+		}
+	}
+
+	if len(due) > 0 {
+		logging.FromContext(ctx).Info("retried failed ingestions",
+			"retried", len(due), "errors", len(result.Errors))
+	}
+	return result, nil
+}
@@ -0,0 +1,120 @@
+package ingestor_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"gorm.io/datatypes"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/congresstest"
+	"github.com/drewjst/deltagov/internal/ingestor"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// TestRetryFailures verifies failed bills are dead-lettered, retried once
+// due with a doubling backoff until exhausted, and resolved when a retry
+// succeeds.
+func TestRetryFailures(t *testing.T) {
+	srv := congresstest.NewServer(t)
+	db := congresstest.OpenDB(t)
+	svc := ingestor.NewService(db, srv.Client(t))
+	ctx := context.Background()
+
+	// A listing without a number fails every time
+	srv.AddBill(congress.Bill{Congress: 119, Type: "HR", Title: "Unnumbered Act",
+		UpdateDate: "2025-03-01", UpdateDateIncludingText: "2025-03-01"})
+	start := time.Now()
+	if _, err := recordRecent(ctx, svc); err != nil {
+		t.Fatalf("IngestRecentBills failed: %v", err)
+	}
+	var failure models.IngestFailure
+	if err := db.First(&failure).Error; err != nil {
+		t.Fatalf("Failed to read failure: %v", err)
+	}
+	if failure.Stage != models.IngestFailureStageBill || failure.Status != models.IngestFailurePending ||
+		failure.ErrorClass != models.IngestErrorOther || failure.Attempts != 1 || failure.RunID == nil {
+		t.Errorf("Failure = %+v, want a pending bill failure of the run", failure)
+	}
+	backoff := func(want time.Duration) {
+		t.Helper()
+		if got := failure.NextAttemptAt.Sub(start); got < want || got > want+time.Minute {
+			t.Errorf("After %d attempts, retried in %s, want %s", failure.Attempts, got, want)
+		}
+	}
+	backoff(15 * time.Minute)
+
+	// Not due yet
+	if due, err := svc.DueFailures(ctx); err != nil || due != 0 {
+		t.Errorf("DueFailures = %d, %v; want 0", due, err)
+	}
+	if result, err := svc.RetryFailures(ctx, 0); err != nil || result.BillsFetched != 0 {
+		t.Errorf("RetryFailures before due = %+v, %v; want nothing retried", result, err)
+	}
+
+	makeDue := func(attempts int) {
+		t.Helper()
+		if err := db.Model(&failure).Updates(map[string]any{
+			"attempts": attempts, "next_attempt_at": time.Now().Add(-time.Minute),
+		}).Error; err != nil {
+			t.Fatalf("Failed to make failure due: %v", err)
+		}
+		if due, err := svc.DueFailures(ctx); err != nil || due != 1 {
+			t.Errorf("DueFailures = %d, %v; want 1", due, err)
+		}
+	}
+	retry := func() {
+		t.Helper()
+		start = time.Now()
+		if _, err := svc.RetryFailures(ctx, 0); err != nil {
+			t.Fatalf("RetryFailures failed: %v", err)
+		}
+		if err := db.First(&failure, failure.ID).Error; err != nil {
+			t.Fatalf("Failed to read failure: %v", err)
+		}
+	}
+
+	// Failing again counts an attempt and doubles the wait
+	makeDue(1)
+	retry()
+	if failure.Attempts != 2 || failure.Status != models.IngestFailurePending {
+		t.Errorf("After a failed retry: %+v, want 2 attempts, pending", failure)
+	}
+	backoff(30 * time.Minute)
+
+	// The last allowed attempt exhausts it
+	makeDue(ingestor.MaxFailureAttempts - 1)
+	retry()
+	if failure.Attempts != ingestor.MaxFailureAttempts || failure.Status != models.IngestFailureExhausted {
+		t.Errorf("After the last attempt: %+v, want exhausted", failure)
+	}
+	if due, err := svc.DueFailures(ctx); err != nil || due != 0 {
+		t.Errorf("DueFailures after exhausting = %d, %v; want 0", due, err)
+	}
+
+	// A bill that ingests on retry resolves its failure
+	bill := congress.Bill{Congress: 119, Type: "S", Number: "5", Title: "Recovered Act",
+		UpdateDate: "2025-03-02", UpdateDateIncludingText: "2025-03-02"}
+	srv.AddBill(bill)
+	payload, err := json.Marshal(bill)
+	if err != nil {
+		t.Fatalf("Failed to encode bill: %v", err)
+	}
+	failure = models.IngestFailure{BillKey: "119-s-5", Stage: models.IngestFailureStageBill,
+		Status: models.IngestFailurePending, Attempts: 2, Payload: datatypes.JSON(payload),
+		NextAttemptAt: time.Now().Add(-time.Minute)}
+	if err := db.Create(&failure).Error; err != nil {
+		t.Fatalf("Failed to create failure: %v", err)
+	}
+	retry()
+	if failure.Status != models.IngestFailureResolved || failure.ResolvedAt == nil {
+		t.Errorf("After a successful retry: %+v, want resolved", failure)
+	}
+	var count int64
+	db.Model(&models.Bill{}).Where("bill_type = ? AND number = ?", "S", "5").Count(&count)
+	if count != 1 {
+		t.Errorf("Stored %d of the retried bill, want 1", count)
+	}
+}
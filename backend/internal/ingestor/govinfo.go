@@ -0,0 +1,235 @@
+package ingestor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/govinfo"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/versioncode"
+)
+
+// sessionsPerCongress is the number of sessions GovInfo files bills under.
+const sessionsPerCongress = 2
+
+// errMetadataFailed reports a bill whose Congress.gov metadata failed to
+// ingest; ingestOne has already recorded the error.
+var errMetadataFailed = errors.New("ingestor: bill metadata failed to ingest")
+
+// SetGovInfo makes GovInfo bulk data the primary text source. Runs against
+// the Congress.gov API then sync bill metadata only, and IngestGovInfo
+// stores the text. A nil client restores Congress.gov text fetching.
+func (s *Service) SetGovInfo(c *govinfo.Client) {
+	s.govinfo = c
+}
+
+// GovInfoConfig contains configuration for GovInfo bulk data ingestion.
+type GovInfoConfig struct {
+	Congress  int      // Congress to ingest (required)
+	BillTypes []string // Bill types to walk (default: AllBillTypes)
+}
+
+// IngestGovInfo walks the GovInfo BILLS bulk data of a congress and stores
+// every text version not already stored for its bill, matching files to
+// bills by congress, type, and number and to versions by version code.
+// Bills not in the database yet are created from Congress.gov metadata,
+// subject to the scope rules. BillsFetched counts bills with new text files.
+func (s *Service) IngestGovInfo(ctx context.Context, cfg GovInfoConfig) (*IngestResult, error) {
+	if s.govinfo == nil {
+		return nil, errors.New("ingestor: GovInfo ingestion requires a GovInfo client")
+	}
+	if cfg.Congress <= 0 {
+		return nil, errors.New("ingestor: GovInfo ingestion requires a congress")
+	}
+	if len(cfg.BillTypes) == 0 {
+		cfg.BillTypes = AllBillTypes
+	}
+
+	result := &IngestResult{}
+	logger := logging.FromContext(ctx)
+
+	for _, billType := range cfg.BillTypes {
+		billType = strings.ToLower(billType)
+		stored, err := s.storedVersionCodes(ctx, cfg.Congress, billType)
+		if err != nil {
+			return result, err
+		}
+
+		for session := 1; session <= sessionsPerCongress; session++ {
+			files, err := s.govinfo.ListBills(ctx, cfg.Congress, session, billType)
+			if errors.Is(err, govinfo.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return result, fmt.Errorf("ingestor: failed to list GovInfo %s session %d: %w", billType, session, err)
+			}
+			logger.Info("walking GovInfo bulk data",
+				"congress", cfg.Congress, "session", session, "bill_type", billType, "files", len(files))
+
+			// Files are grouped by bill number; take each bill's new files at once
+			for start := 0; start < len(files); {
+				end := start
+				for end < len(files) && files[end].BillNumber == files[start].BillNumber {
+					end++
+				}
+				var pending []govinfo.BillFile
+				for _, f := range files[start:end] {
+					if !stored[f.BillNumber][f.VersionCode] {
+						pending = append(pending, f)
+					}
+				}
+				start = end
+
+				if len(pending) == 0 {
+					continue
+				}
+				if err := ctx.Err(); err != nil {
+					return result, err
+				}
+				result.BillsFetched++
+				s.ingestGovInfoBill(ctx, pending, stored, result)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// ingestGovInfoBill stores the new text files of one bill, recording the
+// outcome in result and the stored codes in stored.
+func (s *Service) ingestGovInfoBill(ctx context.Context, files []govinfo.BillFile, stored map[int]map[string]bool, result *IngestResult) {
+	first := files[0]
+	bill, err := s.reconcileGovInfoBill(ctx, first, result)
+	if errors.Is(err, errMetadataFailed) {
+		return
+	}
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("bill %s-%d %d: %w",
+			first.BillType, first.Congress, first.BillNumber, err))
+		return
+	}
+	if bill == nil {
+		result.BillsSkipped++
+		return
+	}
+
+	enacted := false
+	for _, f := range files {
+		created, err := s.storeGovInfoFile(ctx, bill, f)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("bill %s-%d %d version %s: %w",
+				f.BillType, f.Congress, f.BillNumber, f.VersionCode, err))
+			continue
+		}
+		if stored[f.BillNumber] == nil {
+			stored[f.BillNumber] = make(map[string]bool)
+		}
+		stored[f.BillNumber][f.VersionCode] = true
+		if created {
+			result.VersionsCreated++
+			enacted = enacted || versioncode.IsEnacted(f.VersionCode)
+		}
+	}
+
+	if enacted && bill.PublicLawNumber != "" {
+		s.enqueueEnactedDiff(ctx, bill)
+	}
+}
+
+// reconcileGovInfoBill returns the stored bill a GovInfo file belongs to.
+// A bill that isn't stored yet is ingested from Congress.gov metadata
+// first; nil is returned when it is out of scope.
+func (s *Service) reconcileGovInfoBill(ctx context.Context, f govinfo.BillFile, result *IngestResult) (*models.Bill, error) {
+	number := strconv.Itoa(f.BillNumber)
+	bill, err := s.findBill(ctx, f.Congress, f.BillType, number)
+	if err != nil || bill != nil {
+		return bill, err
+	}
+
+	var detail *congress.BillDetail
+	err = withRateLimitRetry(ctx, func() error {
+		var err error
+		detail, err = s.congressClient.GetBillDetail(ctx, f.Congress, f.BillType, number)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch metadata: %w", err)
+	}
+	inScope, _ := s.filterInScope([]congress.Bill{detail.Bill})
+	if len(inScope) == 0 {
+		return nil, nil
+	}
+	if err := s.ingestOne(ctx, &inScope[0], result); err != nil {
+		return nil, errMetadataFailed
+	}
+
+	bill, err = s.findBill(ctx, f.Congress, f.BillType, number)
+	if err == nil && bill == nil {
+		err = errors.New("bill missing after ingesting its metadata")
+	}
+	return bill, err
+}
+
+// storeGovInfoFile downloads one GovInfo text file and stores it as a
+// version, dated by the XML's own date when it has one.
+func (s *Service) storeGovInfoFile(ctx context.Context, bill *models.Bill, f govinfo.BillFile) (bool, error) {
+	content, source, err := s.govinfo.FetchText(ctx, f.Link)
+	if err != nil {
+		return false, err
+	}
+	fetchedAt, ok := govinfo.TextDate(content)
+	if !ok {
+		fetchedAt = time.Now()
+	}
+	return s.storeVersion(ctx, bill, f.VersionCode, content, fetchedAt, source)
+}
+
+// findBill returns the stored bill with the given congress, type, and
+// number as published, or nil. Types are compared case-insensitively
+// because Congress.gov reports them in upper case.
+func (s *Service) findBill(ctx context.Context, congressNum int, billType, number string) (*models.Bill, error) {
+	var bill models.Bill
+	err := s.db.WithContext(ctx).
+		Where("congress = ? AND LOWER(bill_type) = ? AND number = ?", congressNum, strings.ToLower(billType), number).
+		First(&bill).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query bill: %w", err)
+	}
+	return &bill, nil
+}
+
+// storedVersionCodes returns the version codes stored for each bill number
+// of a congress and bill type.
+func (s *Service) storedVersionCodes(ctx context.Context, congressNum int, billType string) (map[int]map[string]bool, error) {
+	var rows []struct {
+		BillNumber  int
+		VersionCode string
+	}
+	if err := s.db.WithContext(ctx).Table("versions").
+		Select("bills.bill_number, versions.version_code").
+		Joins("JOIN bills ON bills.id = versions.bill_id").
+		Where("bills.congress = ? AND LOWER(bills.bill_type) = ?", congressNum, billType).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("ingestor: failed to query stored versions: %w", err)
+	}
+
+	stored := make(map[int]map[string]bool)
+	for _, r := range rows {
+		if stored[r.BillNumber] == nil {
+			stored[r.BillNumber] = make(map[string]bool)
+		}
+		stored[r.BillNumber][r.VersionCode] = true
+	}
+	return stored, nil
+}
@@ -0,0 +1,74 @@
+package ingestor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/versioncode"
+)
+
+// syncLaw records the law a bill became, from the laws listed in its
+// detail. Reports whether the bill became law since the last sync, in
+// which case a became_law event is recorded.
+func (s *Service) syncLaw(ctx context.Context, bill *models.Bill, detail *congress.BillDetail) (bool, error) {
+	if len(detail.Laws) == 0 {
+		return false, nil
+	}
+	law := detail.Laws[0]
+	if law.Number == "" || (law.Number == bill.PublicLawNumber && law.Type == bill.LawType) {
+		return false, nil
+	}
+
+	if err := s.db.WithContext(ctx).Model(&models.Bill{}).Where("id = ?", bill.ID).Updates(map[string]any{
+		"public_law_number": law.Number,
+		"law_type":          law.Type,
+	}).Error; err != nil {
+		return false, fmt.Errorf("failed to update law status: %w", err)
+	}
+
+	becameLaw := bill.PublicLawNumber == ""
+	bill.PublicLawNumber, bill.LawType = law.Number, law.Type
+	if becameLaw {
+		s.recordEvent(ctx, models.BillEvent{
+			BillID:    bill.ID,
+			EventType: models.BillEventBecameLaw,
+			NewValue:  fmt.Sprintf("%s %s", law.Type, law.Number),
+		})
+	}
+	return becameLaw, nil
+}
+
+// enqueueEnactedDiff queues the delta between a bill's first version and
+// its enacted text (public law, else enrolled) for precomputation.
+func (s *Service) enqueueEnactedDiff(ctx context.Context, bill *models.Bill) {
+	if s.diffs == nil {
+		return
+	}
+
+	var versions []models.Version
+	if err := s.db.WithContext(ctx).Select("id", "version_code").
+		Where("bill_id = ?", bill.ID).Order("fetched_at ASC, id ASC").
+		Find(&versions).Error; err != nil {
+		logging.FromContext(ctx).Warn("failed to fetch versions for enacted diff", "bill_id", bill.ID, "error", err)
+		return
+	}
+
+	var enacted *models.Version
+	for i := range versions {
+		v := &versions[i]
+		if versioncode.IsEnacted(v.VersionCode) &&
+			(enacted == nil || versioncode.Stage(v.VersionCode) >= versioncode.Stage(enacted.VersionCode)) {
+			enacted = v
+		}
+	}
+	if enacted == nil || enacted.ID == versions[0].ID {
+		logging.FromContext(ctx).Info("enacted text not yet published",
+			"bill_type", bill.BillType, "bill_number", bill.BillNumber, "law", bill.PublicLawNumber)
+		return
+	}
+
+	s.diffs.Enqueue(ctx, versions[0].ID, enacted.ID)
+}
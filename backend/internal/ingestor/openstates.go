@@ -0,0 +1,251 @@
+package ingestor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/live"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/openstates"
+)
+
+// DefaultOpenStatesLimit is the number of state bills ingested per run when
+// OpenStatesConfig.Limit is unset.
+const DefaultOpenStatesLimit = 100
+
+// SetOpenStates sets the Open States client used by IngestOpenStates.
+func (s *Service) SetOpenStates(c *openstates.Client) {
+	s.openstates = c
+}
+
+// OpenStatesConfig contains configuration for state bill ingestion.
+type OpenStatesConfig struct {
+	State   string // Two-letter state code, e.g., "ca" (required)
+	Session string // Legislative session (empty = all sessions)
+	Query   string // Full-text search, e.g., "budget" (empty = all bills)
+	Limit   int    // Maximum bills to ingest (default: DefaultOpenStatesLimit)
+}
+
+// IngestOpenStates fetches a state's most recently updated bills from Open
+// States, upserts them as state bills, and stores each text version not
+// already stored. Versions go through the same storage and diff queue as
+// federal ones. Scope rules apply to Congress.gov bills only.
+func (s *Service) IngestOpenStates(ctx context.Context, cfg OpenStatesConfig) (*IngestResult, error) {
+	if s.openstates == nil {
+		return nil, errors.New("ingestor: state ingestion requires an Open States client")
+	}
+	if cfg.State == "" {
+		return nil, errors.New("ingestor: state ingestion requires a state")
+	}
+	if cfg.Limit <= 0 {
+		cfg.Limit = DefaultOpenStatesLimit
+	}
+
+	result := &IngestResult{}
+	for page := 1; result.BillsFetched < cfg.Limit; page++ {
+		resp, err := s.openstates.SearchBills(ctx, openstates.BillsQuery{
+			State:   cfg.State,
+			Session: cfg.Session,
+			Query:   cfg.Query,
+			Page:    page,
+			PerPage: min(cfg.Limit-result.BillsFetched, openstates.MaxPerPage),
+		})
+		if err != nil {
+			return result, fmt.Errorf("ingestor: failed to fetch %s bills: %w", cfg.State, err)
+		}
+
+		for i := range resp.Bills {
+			if result.BillsFetched >= cfg.Limit {
+				break
+			}
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+			result.BillsFetched++
+
+			apiBill := &resp.Bills[i]
+			created, updated, versionsCreated, err := s.upsertStateBill(ctx, cfg.State, apiBill)
+			recordBillOutcome(created, updated, versionsCreated, err)
+			if err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("bill %s %s: %w", cfg.State, apiBill.Identifier, err))
+				continue
+			}
+			if created {
+				result.BillsCreated++
+			}
+			if updated {
+				result.BillsUpdated++
+			}
+			result.VersionsCreated += versionsCreated
+		}
+
+		if !resp.HasMore() || len(resp.Bills) == 0 {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// upsertStateBill creates or updates a state bill and stores any new text
+// versions. Returns (created, updated, versionsCreated, error).
+func (s *Service) upsertStateBill(ctx context.Context, state string, apiBill *openstates.Bill) (bool, bool, int, error) {
+	billType, billNumber, ok := openstates.ParseIdentifier(apiBill.Identifier)
+	number := strconv.Itoa(billNumber)
+	if !ok {
+		// As for federal bills, a number that isn't numeric is stored as
+		// published with a BillNumber of 0 rather than dropped
+		billType, number, ok = openstates.SplitIdentifier(apiBill.Identifier)
+		if !ok {
+			return false, false, 0, fmt.Errorf("unrecognized bill identifier %q", apiBill.Identifier)
+		}
+		logging.FromContext(ctx).Warn("storing bill with a non-numeric number",
+			"state", state, "identifier", apiBill.Identifier)
+	}
+
+	metadata, err := stateBillToMetadata(apiBill)
+	if err != nil {
+		return false, false, 0, fmt.Errorf("failed to create metadata: %w", err)
+	}
+
+	bill := models.Bill{
+		Number:         number,
+		BillNumber:     billNumber,
+		BillType:       billType,
+		StateCode:      strings.ToLower(state),
+		Session:        apiBill.Session,
+		Jurisdiction:   models.JurisdictionState,
+		Title:          apiBill.Title,
+		Sponsor:        apiBill.PrimarySponsor(),
+		OriginChamber:  apiBill.FromOrganization.Name,
+		CurrentStatus:  apiBill.LatestActionDescription,
+		UpdateDate:     apiBill.UpdatedAt,
+		IntroducedDate: apiBill.IntroducedDate(),
+		IsSpendingBill: congress.IsSpendingBill(apiBill.Title, apiBill.Subject),
+		Subjects:       datatypes.JSONSlice[string](apiBill.Subject),
+		Metadata:       metadata,
+	}
+
+	// Read for change events only; see upsertBill
+	var existingBill models.Bill
+	err = s.db.WithContext(ctx).
+		Where("state_code = ? AND session = ? AND bill_type = ? AND number = ?",
+			bill.StateCode, bill.Session, bill.BillType, bill.Number).
+		First(&existingBill).Error
+	found := err == nil
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, false, 0, fmt.Errorf("failed to query bill: %w", err)
+	}
+
+	created, changed, err := s.upsertBillRow(ctx, &bill, []string{
+		"title", "sponsor", "update_date", "origin_chamber", "current_status",
+		"is_spending_bill", "subjects", "introduced_date", "metadata", "updated_at",
+	})
+	if err != nil {
+		return false, false, 0, err
+	}
+	if !changed {
+		// No changes since the last run, so no new versions either
+		return false, false, 0, nil
+	}
+	updated := !created
+
+	if created {
+		s.publishEvent(ctx, &bill, live.Event{Type: live.EventBillCreated})
+		logging.FromContext(ctx).Info("created new state bill",
+			"state", bill.StateCode, "session", bill.Session,
+			"bill_type", bill.BillType, "bill_number", bill.BillNumber)
+	} else {
+		if found {
+			s.recordBillChanges(ctx, &existingBill, &bill)
+		}
+		s.publishEvent(ctx, &bill, live.Event{Type: live.EventBillUpdated})
+		logging.FromContext(ctx).Info("updated state bill",
+			"state", bill.StateCode, "session", bill.Session,
+			"bill_type", bill.BillType, "bill_number", bill.BillNumber,
+			"previous_update_date", existingBill.UpdateDate, "update_date", bill.UpdateDate)
+	}
+
+	var versionsCreated int
+	locked, err := s.withBillLock(ctx, bill.ID, func() (err error) {
+		versionsCreated, err = s.storeStateVersions(ctx, &bill, apiBill.Versions)
+		return err
+	})
+	if err != nil {
+		// Log but don't fail the bill; the text is retried when it next changes
+		logging.FromContext(ctx).Warn("failed to store state bill versions",
+			"state", bill.StateCode, "bill_type", bill.BillType, "bill_number", bill.BillNumber, "error", err)
+	} else if !locked {
+		logging.FromContext(ctx).Debug("bill text being fetched by another ingestor, skipping",
+			"state", bill.StateCode, "bill_type", bill.BillType, "bill_number", bill.BillNumber)
+	}
+	return created, updated, versionsCreated, nil
+}
+
+// storeStateVersions stores each of a state bill's versions whose note isn't
+// stored yet as a version of that code, oldest first. Versions published
+// only as PDF are skipped. Returns the number of versions created.
+func (s *Service) storeStateVersions(ctx context.Context, bill *models.Bill, versions []openstates.Version) (int, error) {
+	var storedCodes []string
+	if err := s.db.WithContext(ctx).Model(&models.Version{}).
+		Where("bill_id = ?", bill.ID).
+		Pluck("version_code", &storedCodes).Error; err != nil {
+		return 0, fmt.Errorf("failed to query stored versions: %w", err)
+	}
+	stored := make(map[string]bool, len(storedCodes))
+	for _, code := range storedCodes {
+		stored[code] = true
+	}
+
+	sorted := append([]openstates.Version(nil), versions...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Date < sorted[j].Date })
+
+	created := 0
+	for _, v := range sorted {
+		url := v.TextURL()
+		if v.Note == "" || stored[v.Note] || url == "" {
+			continue
+		}
+		content, source, err := s.fetchTextContent(ctx, url)
+		if err != nil {
+			return created, fmt.Errorf("failed to fetch version %q: %w", v.Note, err)
+		}
+		fetchedAt, ok := v.ParsedDate()
+		if !ok {
+			fetchedAt = time.Now()
+		}
+		ok, err = s.storeVersion(ctx, bill, v.Note, content, fetchedAt, source)
+		if err != nil {
+			return created, err
+		}
+		stored[v.Note] = true
+		if ok {
+			created++
+		}
+	}
+	return created, nil
+}
+
+// stateBillToMetadata converts an Open States bill to a JSONB metadata map.
+func stateBillToMetadata(bill *openstates.Bill) (datatypes.JSONMap, error) {
+	data, err := json.Marshal(bill)
+	if err != nil {
+		return nil, err
+	}
+	var metadata map[string]interface{}
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, err
+	}
+	return datatypes.JSONMap(metadata), nil
+}
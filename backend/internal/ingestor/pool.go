@@ -0,0 +1,140 @@
+package ingestor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// clampConcurrency applies the default and maximum worker counts.
+func clampConcurrency(n int) int {
+	if n <= 0 {
+		return DefaultConcurrency
+	}
+	return min(n, MaxConcurrency)
+}
+
+// merge adds another result's counts and errors to r.
+func (r *IngestResult) merge(other *IngestResult) {
+	r.BillsFetched += other.BillsFetched
+	r.BillsSkipped += other.BillsSkipped
+	r.BillsCreated += other.BillsCreated
+	r.BillsUpdated += other.BillsUpdated
+	r.VersionsCreated += other.VersionsCreated
+	r.Errors = append(r.Errors, other.Errors...)
+}
+
+// runWorkerPool upserts bills using a fixed pool of workers. Each worker
+// keeps its own result, merged once all workers finish, so no locking is
+// needed per bill. Workers run under their own contexts derived from the
+// pool's: cancelling ctx stops every worker after aborting its in-flight
+// requests, and a bill that exhausts its rate-limit retries stops the whole
+// pool, since the remaining bills would only be rate limited too.
+//
+// Request pacing is coordinated through the shared congress client, which
+// spaces API requests and pauses every worker after a 429, and through the
+// service, which pauses every worker's text downloads after one.
+//
+// Bills already done in cur are skipped, and each bill that's processed,
+// rather than abandoned as the pool stops, is marked done in it; cur may
+// be nil.
+func (s *Service) runWorkerPool(ctx context.Context, bills []congress.Bill, workers int, cur *cursor) (*IngestResult, error) {
+	workers = min(clampConcurrency(workers), max(len(bills), 1))
+	defer cur.flush(ctx)
+
+	poolCtx, stop := context.WithCancelCause(ctx)
+	defer stop(nil)
+
+	queue := make(chan int)
+	results := make([]IngestResult, workers)
+
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			wctx, cancel := context.WithCancel(poolCtx)
+			defer cancel()
+
+			for i := range queue {
+				if wctx.Err() != nil {
+					continue // Handed over just as the pool stopped
+				}
+				err := s.ingestOne(wctx, &bills[i], &results[w])
+				if errors.Is(err, congress.ErrRateLimited) {
+					stop(err)
+				}
+				if err == nil || wctx.Err() == nil {
+					cur.markDone(ctx, i)
+				}
+			}
+		}()
+	}
+
+	queued, resumed := 0, 0
+feed:
+	for i := range bills {
+		if cur != nil && cur.done[i] {
+			resumed++
+			continue
+		}
+		select {
+		case <-poolCtx.Done():
+			break feed
+		case queue <- i:
+			queued++
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	result := &IngestResult{}
+	for i := range results {
+		result.merge(&results[i])
+	}
+	if resumed > 0 {
+		logging.FromContext(ctx).Info("skipped bills processed by interrupted run", "count", resumed)
+	}
+
+	if err := context.Cause(poolCtx); err != nil {
+		logging.FromContext(ctx).Warn("worker pool stopped early",
+			"processed", queued, "remaining", len(bills)-queued-resumed, "error", err)
+		return result, fmt.Errorf("ingestor: worker pool stopped: %w", err)
+	}
+	return result, nil
+}
+
+// ingestOne upserts a single bill, retrying on rate limits, and records the
+// outcome in result. The bill's error, if any, is also returned, and the
+// bill dead-lettered for RetryFailures.
+func (s *Service) ingestOne(ctx context.Context, apiBill *congress.Bill, result *IngestResult) error {
+	var created, updated bool
+	var versionsCreated int
+	err := withRateLimitRetry(ctx, func() error {
+		// A retry after a rate-limited text fetch finds the bill stored
+		// already, so outcomes of earlier attempts are kept
+		c, u, v, err := s.upsertBill(ctx, apiBill)
+		created, updated, versionsCreated = created || c, updated || u, versionsCreated+v
+		return err
+	})
+	recordBillOutcome(created, updated, versionsCreated, err)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Errorf("bill %s-%d %s: %w",
+			apiBill.Type, apiBill.Congress, apiBill.Number, err))
+		s.recordFailure(ctx, models.IngestFailureStageBill, apiBill, err)
+		return err
+	}
+	if created {
+		result.BillsCreated++
+	}
+	if updated {
+		result.BillsUpdated++
+	}
+	result.VersionsCreated += versionsCreated
+	return nil
+}
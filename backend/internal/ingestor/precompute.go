@@ -0,0 +1,119 @@
+package ingestor
+
+import (
+	"context"
+
+	"github.com/drewjst/deltagov/internal/deltas"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/versioncode"
+)
+
+// SetDiffQueue enables delta precomputation: each stored version is queued
+// for diffing against its neighbors and its version graph parent so the API
+// serves those diffs from the cache. A nil queue disables it.
+func (s *Service) SetDiffQueue(q *deltas.Queue) {
+	s.diffs = q
+}
+
+// enqueueNeighborDiffs queues the deltas between a new version and the
+// versions immediately before and after it. Versions are ordered by
+// fetched_at then ID, matching the API. The following version only exists
+// when an older version is filled in, e.g., by a backfill.
+func (s *Service) enqueueNeighborDiffs(ctx context.Context, version *models.Version) {
+	if s.diffs == nil {
+		return
+	}
+	db := s.db.WithContext(ctx)
+
+	var prev models.Version
+	err := db.Select("id").
+		Where("bill_id = ? AND (fetched_at < ? OR (fetched_at = ? AND id < ?))",
+			version.BillID, version.FetchedAt, version.FetchedAt, version.ID).
+		Order("fetched_at DESC, id DESC").Limit(1).Find(&prev).Error
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to find previous version", "version_id", version.ID, "error", err)
+		return
+	}
+	if prev.ID != 0 {
+		s.diffs.Enqueue(ctx, prev.ID, version.ID)
+	}
+
+	var next models.Version
+	err = db.Select("id").
+		Where("bill_id = ? AND (fetched_at > ? OR (fetched_at = ? AND id > ?))",
+			version.BillID, version.FetchedAt, version.FetchedAt, version.ID).
+		Order("fetched_at ASC, id ASC").Limit(1).Find(&next).Error
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to find next version", "version_id", version.ID, "error", err)
+		return
+	}
+	if next.ID != 0 {
+		s.diffs.Enqueue(ctx, version.ID, next.ID)
+	} else {
+		s.enqueueLatestDiffs(ctx, version, prev.ID)
+	}
+
+	s.enqueueGraphDiffs(ctx, version, prev.ID, next.ID)
+}
+
+// maxLatestDiffs bounds the earlier versions a new latest version is
+// diffed against by enqueueLatestDiffs.
+const maxLatestDiffs = 10
+
+// enqueueLatestDiffs queues the deltas between a bill's new latest version
+// and each of its earlier versions, newest first, when the bill is popular:
+// someone requested one of its diffs within deltas.PopularWindow. Readers
+// of a bill that changed tend to compare the new text with the version
+// they last read, which needn't be the one just before it.
+func (s *Service) enqueueLatestDiffs(ctx context.Context, version *models.Version, prevID uint) {
+	popular, err := deltas.Popular(ctx, s.db, version.BillID)
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to check bill popularity", "bill_id", version.BillID, "error", err)
+		return
+	}
+	if !popular {
+		return
+	}
+
+	var earlier []models.Version
+	if err := s.db.WithContext(ctx).Select("id").
+		Where("bill_id = ? AND id NOT IN ?", version.BillID, []uint{version.ID, prevID}).
+		Where("fetched_at < ? OR (fetched_at = ? AND id < ?)", version.FetchedAt, version.FetchedAt, version.ID).
+		Order("fetched_at DESC, id DESC").Limit(maxLatestDiffs).Find(&earlier).Error; err != nil {
+		logging.FromContext(ctx).Warn("failed to find earlier versions", "version_id", version.ID, "error", err)
+		return
+	}
+	for _, v := range earlier {
+		s.diffs.Enqueue(ctx, v.ID, version.ID)
+	}
+}
+
+// enqueueGraphDiffs queues the deltas between a new version and its parent
+// in the bill's version graph (see versioncode.Parents), and between it and
+// the versions derived from it, where those aren't its neighbors. The API
+// diffs a version against its parent by default, so those pairs are cached
+// too.
+func (s *Service) enqueueGraphDiffs(ctx context.Context, version *models.Version, prevID, nextID uint) {
+	var versions []models.Version
+	if err := s.db.WithContext(ctx).Select("id", "version_code").
+		Where("bill_id = ?", version.BillID).Order("fetched_at ASC, id ASC").
+		Find(&versions).Error; err != nil {
+		logging.FromContext(ctx).Warn("failed to fetch versions for version graph", "version_id", version.ID, "error", err)
+		return
+	}
+	codes := make([]string, len(versions))
+	for i, v := range versions {
+		codes[i] = v.VersionCode
+	}
+
+	for i, parent := range versioncode.Parents(codes) {
+		if parent < 0 {
+			continue
+		}
+		from, to := versions[parent].ID, versions[i].ID
+		if (to == version.ID && from != prevID) || (from == version.ID && to != nextID) {
+			s.diffs.Enqueue(ctx, from, to)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package ingestor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/metrics"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/textnorm"
+	"github.com/drewjst/deltagov/internal/versioncode"
+)
+
+// Reconciliation defaults.
+const (
+	DefaultReconcileSample    = 50
+	DefaultReconcileThreshold = 0.1
+)
+
+// ReconcileConfig configures a reconciliation run.
+type ReconcileConfig struct {
+	Sample    int     // Bills checked, chosen at random (default: DefaultReconcileSample)
+	Threshold float64 // Share of checked bills with drift above which the run alerts (default: DefaultReconcileThreshold)
+}
+
+// Reconcile checks a random sample of stored federal bills against
+// Congress.gov and records what drifted: bills Congress.gov no longer has,
+// text versions it has that aren't stored, a latest text version whose
+// content matches no stored version, and title, status, update date, or
+// law number changes ingestion missed. Each discrepancy is written to
+// reconciliation_discrepancies under a ReconciliationRun. When the share
+// of checked bills with drift exceeds the threshold, the run is marked
+// alerted and logged at error level, and the drift gauge lets monitoring
+// alert on it too. Nothing is repaired; the next ingestion of a bill does
+// that.
+func (s *Service) Reconcile(ctx context.Context, cfg ReconcileConfig) (*models.ReconciliationRun, error) {
+	if cfg.Sample <= 0 {
+		cfg.Sample = DefaultReconcileSample
+	}
+	if cfg.Threshold <= 0 {
+		cfg.Threshold = DefaultReconcileThreshold
+	}
+	logger := logging.FromContext(ctx)
+
+	run := models.ReconciliationRun{
+		Status:    models.IngestRunRunning,
+		StartedAt: time.Now(),
+		Threshold: cfg.Threshold,
+	}
+	// Use a background context so a cancelled run is still recorded
+	db := s.db.WithContext(context.WithoutCancel(ctx))
+	if err := db.Create(&run).Error; err != nil {
+		return nil, fmt.Errorf("failed to record reconciliation run: %w", err)
+	}
+
+	runErr := s.reconcileSample(ctx, &run, cfg.Sample)
+
+	finished := time.Now()
+	run.FinishedAt = &finished
+	run.Status = models.IngestRunSucceeded
+	if runErr != nil {
+		run.Status = models.IngestRunFailed
+		run.ErrorMessage = runErr.Error()
+	}
+	if run.BillsChecked > 0 {
+		run.DriftRate = float64(run.BillsDrifted) / float64(run.BillsChecked)
+		metrics.ReconcileDriftRate.Set(run.DriftRate)
+	}
+	if run.DriftRate > run.Threshold {
+		run.Alerted = true
+		logger.Error("reconciliation drift exceeds threshold",
+			"run_id", run.ID, "drift_rate", run.DriftRate, "threshold", run.Threshold,
+			"bills_checked", run.BillsChecked, "bills_drifted", run.BillsDrifted)
+	}
+
+	if err := db.Save(&run).Error; err != nil {
+		logger.Warn("failed to record reconciliation run", "run_id", run.ID, "error", err)
+	}
+	return &run, runErr
+}
+
+// reconcileSample checks up to sample random bills, recording what it
+// finds on run. Only being rate limited stops it early; bills that can't
+// be checked otherwise are recorded in run.Errors.
+func (s *Service) reconcileSample(ctx context.Context, run *models.ReconciliationRun, sample int) error {
+	var bills []models.Bill
+	if err := s.db.WithContext(ctx).
+		Where("jurisdiction = ? AND tenant_id = 0", models.JurisdictionFederal).
+		Order("random()").Limit(sample).Find(&bills).Error; err != nil {
+		return fmt.Errorf("failed to sample bills: %w", err)
+	}
+	run.BillsSampled = len(bills)
+
+	for i := range bills {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		found, err := s.reconcileBill(ctx, &bills[i])
+		if errors.Is(err, congress.ErrRateLimited) {
+			return err
+		}
+		if err != nil {
+			if len(run.Errors) < maxRecordedErrors {
+				run.Errors = append(run.Errors, fmt.Sprintf("%d-%s-%d: %v",
+					bills[i].Congress, bills[i].BillType, bills[i].BillNumber, err))
+			}
+			continue
+		}
+		run.BillsChecked++
+		if len(found) == 0 {
+			continue
+		}
+		run.BillsDrifted++
+		run.Discrepancies += len(found)
+		for j := range found {
+			found[j].RunID = run.ID
+			found[j].BillID = bills[i].ID
+		}
+		if err := s.db.WithContext(ctx).Create(&found).Error; err != nil {
+			return fmt.Errorf("failed to record discrepancies: %w", err)
+		}
+	}
+	return nil
+}
+
+// reconcileBill compares a stored bill with Congress.gov and returns the
+// discrepancies, without their run or bill set.
+func (s *Service) reconcileBill(ctx context.Context, bill *models.Bill) ([]models.ReconciliationDiscrepancy, error) {
+	detail, err := s.congressClient.GetBillDetail(ctx, bill.Congress, bill.BillType, bill.Number)
+	if errors.Is(err, congress.ErrNotFound) {
+		return []models.ReconciliationDiscrepancy{{Kind: models.DriftMissingBill}}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bill detail: %w", err)
+	}
+
+	var found []models.ReconciliationDiscrepancy
+	metadata := func(field, stored, upstream string) {
+		if upstream != "" && stored != upstream {
+			found = append(found, models.ReconciliationDiscrepancy{
+				Kind: models.DriftMetadata, Field: field, Stored: stored, Upstream: upstream,
+			})
+		}
+	}
+	metadata("title", bill.Title, detail.Title)
+	metadata("update_date", bill.UpdateDate, detail.UpdateDate)
+	if detail.LatestAction != nil {
+		metadata("current_status", bill.CurrentStatus, detail.LatestAction.Text)
+	}
+	if len(detail.Laws) > 0 {
+		metadata("public_law_number", bill.PublicLawNumber, detail.Laws[0].Number)
+	}
+
+	textVersions, err := s.congressClient.GetBillText(ctx, bill.Congress, bill.BillType, bill.Number)
+	if errors.Is(err, congress.ErrNotFound) || (err == nil && len(textVersions) == 0) {
+		return found, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch text versions: %w", err)
+	}
+
+	var stored []models.Version
+	if err := s.db.WithContext(ctx).Select("version_code", "content_hash").
+		Where("bill_id = ?", bill.ID).Order("fetched_at ASC, id ASC").Find(&stored).Error; err != nil {
+		return nil, fmt.Errorf("failed to query stored versions: %w", err)
+	}
+	hashes := make(map[string][]string, len(stored))
+	for _, v := range stored {
+		hashes[v.VersionCode] = append(hashes[v.VersionCode], v.ContentHash)
+	}
+
+	ordered := sortTextVersions(textVersions)
+	for _, tv := range ordered {
+		if code := versioncode.FromType(tv.Type); len(hashes[code]) == 0 {
+			found = append(found, models.ReconciliationDiscrepancy{Kind: models.DriftMissingVersion, VersionCode: code})
+		}
+	}
+
+	// Only the latest version's text is re-fetched, as ingestion re-checks
+	// only it for revised text
+	latest := ordered[len(ordered)-1]
+	code := versioncode.FromType(latest.Type)
+	url := textURL(latest)
+	if url == "" || len(hashes[code]) == 0 {
+		return found, nil
+	}
+	content, _, err := s.fetchTextContent(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch text from %s: %w", url, err)
+	}
+	upstream := textnorm.Hash(content)
+	for _, hash := range hashes[code] {
+		if hash == upstream {
+			return found, nil
+		}
+	}
+	return append(found, models.ReconciliationDiscrepancy{
+		Kind: models.DriftChangedText, VersionCode: code, Stored: hashes[code][len(hashes[code])-1], Upstream: upstream,
+	}), nil
+}
@@ -0,0 +1,133 @@
+package ingestor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// DefaultReingestBatch is the number of bills ReingestBills refreshes when
+// not given a limit.
+const DefaultReingestBatch = 100
+
+// PendingReingestJobs returns how many re-ingestion jobs are queued or
+// unfinished.
+func (s *Service) PendingReingestJobs(ctx context.Context) (int64, error) {
+	var pending int64
+	if err := s.db.WithContext(ctx).Model(&models.ReingestJob{}).
+		Where("status IN ?", []string{models.ReingestJobQueued, models.ReingestJobRunning}).
+		Count(&pending).Error; err != nil {
+		return 0, fmt.Errorf("ingestor: failed to count reingest jobs: %w", err)
+	}
+	return pending, nil
+}
+
+// ReingestBills refreshes up to limit bills of pending re-ingestion jobs,
+// oldest job first, saving each job's progress as it goes. A refresh
+// re-fetches the bill from Congress.gov as if it had changed: its detail,
+// related data, and text versions. Bills that fail are counted on the job
+// and dead-lettered like any other. It stops early when rate limited,
+// leaving the job to continue next time.
+func (s *Service) ReingestBills(ctx context.Context, limit int) (*IngestResult, error) {
+	if limit <= 0 {
+		limit = DefaultReingestBatch
+	}
+
+	var jobs []models.ReingestJob
+	if err := s.db.WithContext(ctx).
+		Where("status IN ?", []string{models.ReingestJobQueued, models.ReingestJobRunning}).
+		Order("created_at ASC, id ASC").Find(&jobs).Error; err != nil {
+		return nil, fmt.Errorf("ingestor: failed to fetch reingest jobs: %w", err)
+	}
+
+	result := &IngestResult{}
+	for i := range jobs {
+		if result.BillsFetched >= limit {
+			break
+		}
+		if err := s.runReingestJob(ctx, &jobs[i], limit-result.BillsFetched, result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// runReingestJob refreshes up to limit of a job's remaining bills.
+func (s *Service) runReingestJob(ctx context.Context, job *models.ReingestJob, limit int, result *IngestResult) error {
+	logger := logging.FromContext(ctx).With("reingest_job", job.ID)
+	// Progress is saved even when the run is cancelled
+	db := s.db.WithContext(context.WithoutCancel(ctx))
+	save := func() {
+		if err := db.Save(job).Error; err != nil {
+			logger.Warn("failed to save reingest job", "error", err)
+		}
+	}
+
+	if job.Status == models.ReingestJobQueued {
+		now := time.Now()
+		job.Status, job.StartedAt = models.ReingestJobRunning, &now
+		save()
+	}
+
+	var stopErr error
+	for n := 0; n < limit && job.Processed < len(job.BillIDs); n++ {
+		if err := ctx.Err(); err != nil {
+			stopErr = err
+			break
+		}
+		err := s.reingestBill(ctx, job.BillIDs[job.Processed], result)
+		if errors.Is(err, congress.ErrRateLimited) {
+			stopErr = fmt.Errorf("ingestor: reingest stopped: %w", err)
+			break
+		}
+		result.BillsFetched++
+		job.Processed++
+		if err != nil {
+			job.Failed++
+			if len(job.Errors) < maxRecordedErrors {
+				job.Errors = append(job.Errors, fmt.Sprintf("bill %d: %v", job.BillIDs[job.Processed-1], err))
+			}
+		}
+	}
+
+	if job.Processed >= len(job.BillIDs) {
+		now := time.Now()
+		job.Status, job.FinishedAt = models.ReingestJobSucceeded, &now
+		logger.Info("reingest job complete", "total", job.Total, "failed", job.Failed)
+	}
+	save()
+	return stopErr
+}
+
+// reingestBill refreshes one stored bill. Its Congress.gov update dates
+// are cleared first, so the upsert treats the fetched detail as a change
+// and re-fetches the bill's text. Bills deleted since the job was queued
+// are skipped.
+func (s *Service) reingestBill(ctx context.Context, billID uint, result *IngestResult) error {
+	var bill models.Bill
+	if err := s.db.WithContext(ctx).Select("id", "congress", "bill_type", "number", "bill_number").
+		Where("id = ?", billID).Limit(1).Find(&bill).Error; err != nil {
+		return fmt.Errorf("failed to fetch bill: %w", err)
+	}
+	if bill.ID == 0 {
+		return nil
+	}
+
+	detail, err := s.fetchStoredBill(ctx, &bill)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.WithContext(ctx).Model(&models.Bill{}).Where("id = ?", bill.ID).Updates(map[string]any{
+		"update_date":                "",
+		"update_date_including_text": "",
+	}).Error; err != nil {
+		return fmt.Errorf("failed to reset bill update dates: %w", err)
+	}
+	return s.ingestOne(ctx, detail, result)
+}
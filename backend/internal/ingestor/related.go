@@ -0,0 +1,56 @@
+package ingestor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// syncRelatedBills fetches the bills Congress.gov lists as related to a bill
+// and upserts them as RelatedBill rows, flagging House/Senate companions.
+func (s *Service) syncRelatedBills(ctx context.Context, bill *models.Bill) error {
+	related, err := s.congressClient.GetRelatedBills(ctx, bill.Congress, bill.BillType, bill.Number)
+	if errors.Is(err, congress.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch related bills: %w", err)
+	}
+	if len(related) == 0 {
+		return nil
+	}
+
+	rows := make([]models.RelatedBill, 0, len(related))
+	for _, r := range related {
+		relationships := make([]string, 0, len(r.RelationshipDetails))
+		for _, d := range r.RelationshipDetails {
+			relationships = append(relationships, fmt.Sprintf("%s (%s)", d.Type, d.IdentifiedBy))
+		}
+		rows = append(rows, models.RelatedBill{
+			BillID:          bill.ID,
+			RelatedCongress: r.Congress,
+			RelatedType:     strings.ToLower(r.Type),
+			RelatedNumber:   r.Number,
+			Title:           r.Title,
+			Relationships:   relationships,
+			IsCompanion:     r.IsCompanionOf(bill.BillType),
+		})
+	}
+
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{
+			{Name: "bill_id"}, {Name: "related_congress"}, {Name: "related_type"}, {Name: "related_number"},
+		},
+		DoUpdates: clause.AssignmentColumns([]string{"title", "relationships", "is_companion", "updated_at"}),
+	}).Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to upsert related bills: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package ingestor
+
+import (
+	"context"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/metrics"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// maxRecordedErrors caps how many per-bill errors are persisted per run.
+const maxRecordedErrors = 100
+
+// RunFunc performs one ingestion pass and returns its statistics.
+type RunFunc func(ctx context.Context) (*IngestResult, error)
+
+// RecordRun executes fn and persists its outcome as an IngestRun.
+// Failing to persist the record is logged but never fails the run.
+// When the previous run of mode was cut short, fn resumes after the bills
+// it got through: runs checkpoint a cursor into their list of bills as
+// they go, and recent and search ingestion skip the bills before the
+// interrupted run's cursor that are listed again unchanged.
+func (s *Service) RecordRun(ctx context.Context, triggeredBy, mode string, fn RunFunc) (*IngestResult, error) {
+	run := models.IngestRun{
+		TriggeredBy: triggeredBy,
+		Mode:        mode,
+		Status:      models.IngestRunRunning,
+		StartedAt:   time.Now(),
+	}
+	// Use a background context so a cancelled run is still recorded
+	db := s.db.WithContext(context.WithoutCancel(ctx))
+
+	resume, err := s.lastInterrupted(ctx, mode)
+	if err != nil {
+		logging.FromContext(ctx).Warn("failed to look up interrupted ingestion run", "error", err)
+	}
+	if resume != nil {
+		// Carried over so the cursor survives this run failing before it moves
+		run.ResumedFrom = &resume.ID
+		run.CursorSource = resume.CursorSource
+		run.CursorBill = resume.CursorBill
+		run.CursorUpdateDate = resume.CursorUpdateDate
+		run.CursorNewest = resume.CursorNewest
+		logging.FromContext(ctx).Info("resuming interrupted ingestion run",
+			"resumed_run_id", resume.ID, "cursor_bill", resume.CursorBill)
+	}
+
+	if err := db.Create(&run).Error; err != nil {
+		logging.FromContext(ctx).Warn("failed to record ingestion run start", "error", err)
+	}
+
+	result, runErr := fn(context.WithValue(ctx, runKey{}, &runState{run: &run, resume: resume}))
+
+	finished := time.Now()
+	run.FinishedAt = &finished
+	run.DurationMs = finished.Sub(run.StartedAt).Milliseconds()
+	run.Status = models.IngestRunSucceeded
+
+	if result != nil {
+		run.BillsFetched = result.BillsFetched
+		run.BillsSkipped = result.BillsSkipped
+		run.BillsCreated = result.BillsCreated
+		run.BillsUpdated = result.BillsUpdated
+		run.VersionsCreated = result.VersionsCreated
+		run.ErrorCount = len(result.Errors)
+
+		errs := make([]string, 0, min(len(result.Errors), maxRecordedErrors))
+		for _, e := range result.Errors {
+			if len(errs) == maxRecordedErrors {
+				break
+			}
+			errs = append(errs, e.Error())
+		}
+		run.Errors = errs
+	}
+	if runErr != nil {
+		run.Status = models.IngestRunFailed
+		run.ErrorMessage = runErr.Error()
+	}
+
+	metrics.IngestRunDuration.WithLabelValues(mode, run.Status).Observe(finished.Sub(run.StartedAt).Seconds())
+	if runErr == nil {
+		metrics.IngestLastSuccess.Set(float64(finished.Unix()))
+	}
+
+	if err := db.Save(&run).Error; err != nil {
+		logging.FromContext(ctx).Warn("failed to record ingestion run", "run_id", run.ID, "error", err)
+	}
+
+	return result, runErr
+}
@@ -5,28 +5,41 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
-	"golang.org/x/sync/errgroup"
 	"gorm.io/datatypes"
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 
 	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/deltas"
+	"github.com/drewjst/deltagov/internal/events"
+	"github.com/drewjst/deltagov/internal/govinfo"
+	"github.com/drewjst/deltagov/internal/live"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/metrics"
 	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/openstates"
+	"github.com/drewjst/deltagov/internal/provenance"
+	"github.com/drewjst/deltagov/internal/scope"
+	"github.com/drewjst/deltagov/internal/textextract"
+	"github.com/drewjst/deltagov/internal/textnorm"
+	"github.com/drewjst/deltagov/internal/textstats"
+	"github.com/drewjst/deltagov/internal/textstore"
+	"github.com/drewjst/deltagov/internal/versioncode"
 )
 
 const (
-	// DefaultConcurrency is the default number of parallel workers for batch processing
-	DefaultConcurrency = 5
+	// DefaultConcurrency is the default number of bills processed at once
+	DefaultConcurrency = 8
 	// MaxConcurrency prevents overwhelming the Congress.gov API
-	MaxConcurrency = 10
+	MaxConcurrency = 16
 )
 
 // Service handles bill ingestion from Congress.gov API.
@@ -34,6 +47,19 @@ type Service struct {
 	db             *gorm.DB
 	congressClient *congress.Client
 	httpClient     *http.Client
+	scope          *scope.Rules
+	targets        []Target
+	concurrency    int
+	diffs          *deltas.Queue
+	texts          textstore.Store
+	govinfo        *govinfo.Client
+	openstates     *openstates.Client
+	publisher      events.Publisher
+
+	// Text downloads are held back until textPausedUntil after a 429; see
+	// pauseText
+	textMu          sync.Mutex
+	textPausedUntil time.Time
 }
 
 // NewService creates a new ingestor service.
@@ -44,52 +70,80 @@ func NewService(db *gorm.DB, congressClient *congress.Client) *Service {
 		httpClient: &http.Client{
 			Timeout: 60 * time.Second,
 		},
+		concurrency: DefaultConcurrency,
 	}
 }
 
-// IngestResult contains statistics from an ingestion run.
-type IngestResult struct {
-	BillsFetched   int
-	BillsCreated   int
-	BillsUpdated   int
-	VersionsCreated int
-	Errors         []error
+// SetScope restricts ingestion to bills allowed by the given rules.
+// A nil value ingests every bill.
+func (s *Service) SetScope(rules *scope.Rules) {
+	s.scope = rules
 }
 
-// IngestRecentBills fetches recent bills from Congress.gov and upserts them.
-func (s *Service) IngestRecentBills(ctx context.Context, limit int) (*IngestResult, error) {
-	result := &IngestResult{}
+// SetTargets restricts recent-bills ingestion to the given targets.
+// With no targets, the most recently updated bills across Congress are used.
+func (s *Service) SetTargets(targets []Target) {
+	s.targets = targets
+}
 
-	// Fetch recent bills from Congress API
-	fetchResult, err := s.congressClient.FetchRecentBills(ctx, limit)
-	if err != nil {
-		return nil, fmt.Errorf("ingestor: failed to fetch recent bills: %w", err)
-	}
+// SetConcurrency sets how many bills IngestRecentBills processes at once.
+// Values are clamped to MaxConcurrency; zero or less uses DefaultConcurrency.
+func (s *Service) SetConcurrency(n int) {
+	s.concurrency = clampConcurrency(n)
+}
 
-	result.BillsFetched = len(fetchResult.Bills)
-	log.Printf("Fetched %d bills from Congress.gov", result.BillsFetched)
+// SetTextStore makes new versions keep their raw text in store rather than
+// in the versions table. A nil store keeps text inline.
+func (s *Service) SetTextStore(store textstore.Store) {
+	s.texts = store
+}
 
-	// Process each bill
-	for _, apiBill := range fetchResult.Bills {
-		created, updated, versionCreated, err := s.upsertBill(ctx, &apiBill)
-		if err != nil {
-			result.Errors = append(result.Errors, fmt.Errorf("bill %s-%d %s: %w",
-				apiBill.Type, apiBill.Congress, apiBill.Number, err))
-			continue
-		}
+// IngestResult contains statistics from an ingestion run.
+type IngestResult struct {
+	BillsFetched    int
+	BillsSkipped    int // Out of scope per the configured scope rules
+	BillsCreated    int
+	BillsUpdated    int
+	VersionsCreated int
+	Errors          []error
+}
 
-		if created {
-			result.BillsCreated++
-		}
-		if updated {
-			result.BillsUpdated++
-		}
-		if versionCreated {
-			result.VersionsCreated++
+// filterInScope drops bills excluded by the configured scope rules.
+// Returns the remaining bills and the number skipped.
+func (s *Service) filterInScope(bills []congress.Bill) ([]congress.Bill, int) {
+	if s.scope.IsEmpty() {
+		return bills, 0
+	}
+	kept := make([]congress.Bill, 0, len(bills))
+	for _, b := range bills {
+		if s.scope.Allows(b.Congress, b.Type, b.Title) {
+			kept = append(kept, b)
 		}
 	}
+	metrics.IngestBills.WithLabelValues("skipped").Add(float64(len(bills) - len(kept)))
+	return kept, len(bills) - len(kept)
+}
 
-	return result, nil
+// recordBillOutcome updates ingestion metrics for one upsertBill call.
+func recordBillOutcome(created, updated bool, versionsCreated int, err error) {
+	outcome := "unchanged"
+	switch {
+	case err != nil:
+		outcome = "error"
+	case created:
+		outcome = "created"
+	case updated:
+		outcome = "updated"
+	}
+	metrics.IngestBills.WithLabelValues(outcome).Inc()
+	metrics.IngestVersionsCreated.Add(float64(versionsCreated))
+}
+
+// IngestRecentBills fetches recent bills from Congress.gov and upserts them
+// using the service's worker pool (see SetConcurrency).
+// When targets are configured, limit applies to each target without its own limit.
+func (s *Service) IngestRecentBills(ctx context.Context, limit int) (*IngestResult, error) {
+	return s.IngestRecentBillsParallel(ctx, limit, s.concurrency)
 }
 
 // SearchIngestConfig contains configuration for search-based ingestion.
@@ -98,21 +152,14 @@ type SearchIngestConfig struct {
 	BillType         string // Bill type filter (hr, s, hjres, etc.)
 	IsAppropriations bool   // Only fetch appropriations/spending bills
 	Limit            int    // Maximum bills to fetch
-	Concurrency      int    // Number of parallel workers (default: 5, max: 10)
+	Concurrency      int    // Number of parallel workers (default: 8, max: 16)
 }
 
 // IngestFromSearch fetches bills matching search criteria and upserts them in parallel.
-// Uses errgroup for concurrent processing with controlled parallelism.
 func (s *Service) IngestFromSearch(ctx context.Context, config SearchIngestConfig) (*IngestResult, error) {
 	result := &IngestResult{}
 
 	// Set defaults
-	if config.Concurrency <= 0 {
-		config.Concurrency = DefaultConcurrency
-	}
-	if config.Concurrency > MaxConcurrency {
-		config.Concurrency = MaxConcurrency
-	}
 	if config.Limit <= 0 {
 		config.Limit = 250
 	}
@@ -132,15 +179,17 @@ func (s *Service) IngestFromSearch(ctx context.Context, config SearchIngestConfi
 	}
 
 	result.BillsFetched = len(searchResult.Bills)
-	log.Printf("Found %d bills matching search criteria (congress=%d, type=%s, appropriations=%v)",
-		result.BillsFetched, config.Congress, config.BillType, config.IsAppropriations)
+	logging.FromContext(ctx).Info("found bills matching search criteria",
+		"count", result.BillsFetched, "congress", config.Congress,
+		"bill_type", config.BillType, "appropriations", config.IsAppropriations)
 
 	if len(searchResult.Bills) == 0 {
 		return result, nil
 	}
 
-	// Process bills in parallel using errgroup
-	return s.processBillsBatch(ctx, searchResult.Bills, config.Concurrency)
+	source := fmt.Sprintf("search congress=%d type=%s appropriations=%t",
+		config.Congress, config.BillType, config.IsAppropriations)
+	return s.processBillsBatch(ctx, source, searchResult.Bills, config.Concurrency)
 }
 
 // IngestAppropriationsBills is a convenience method to ingest spending/appropriations bills.
@@ -153,94 +202,65 @@ func (s *Service) IngestAppropriationsBills(ctx context.Context, congress int, l
 	})
 }
 
-// processBillsBatch processes a batch of bills in parallel using errgroup.
-// Provides controlled concurrency and aggregated error handling.
-func (s *Service) processBillsBatch(ctx context.Context, bills []congress.Bill, concurrency int) (*IngestResult, error) {
-	result := &IngestResult{
-		BillsFetched: len(bills),
-	}
-
-	// Use mutex to safely update result counters
-	var mu sync.Mutex
-
-	// Create errgroup with limited concurrency
-	g, gctx := errgroup.WithContext(ctx)
-	g.SetLimit(concurrency)
-
-	for _, apiBill := range bills {
-		bill := apiBill // Capture loop variable
-		g.Go(func() error {
-			created, updated, versionCreated, err := s.upsertBill(gctx, &bill)
-
-			mu.Lock()
-			defer mu.Unlock()
-
-			if err != nil {
-				result.Errors = append(result.Errors, fmt.Errorf("bill %s-%d %s: %w",
-					bill.Type, bill.Congress, bill.Number, err))
-				return nil // Don't fail entire batch on single bill error
-			}
-
-			if created {
-				result.BillsCreated++
-			}
-			if updated {
-				result.BillsUpdated++
-			}
-			if versionCreated {
-				result.VersionsCreated++
-			}
-
-			return nil
-		})
-	}
+// processBillsBatch filters a batch of bills by scope and processes the rest
+// on a worker pool of the given size. source describes what the bills were
+// listed from; a recorded run resumes an interrupted one only when both
+// listed bills from the same source.
+func (s *Service) processBillsBatch(ctx context.Context, source string, bills []congress.Bill, concurrency int) (*IngestResult, error) {
+	inScope, skipped := s.filterInScope(bills)
 
-	// Wait for all goroutines to complete
-	if err := g.Wait(); err != nil {
-		return result, fmt.Errorf("ingestor: batch processing failed: %w", err)
+	result, err := s.runWorkerPool(ctx, inScope, concurrency, s.newCursor(ctx, source, inScope))
+	result.BillsFetched = len(bills)
+	result.BillsSkipped = skipped
+	if err != nil {
+		return result, err
 	}
 
-	log.Printf("Batch processing complete: %d created, %d updated, %d versions, %d errors",
-		result.BillsCreated, result.BillsUpdated, result.VersionsCreated, len(result.Errors))
+	logging.FromContext(ctx).Info("batch processing complete",
+		"created", result.BillsCreated, "updated", result.BillsUpdated,
+		"versions", result.VersionsCreated, "errors", len(result.Errors))
 
 	return result, nil
 }
 
-// IngestRecentBillsParallel is like IngestRecentBills but processes bills in parallel.
+// IngestRecentBillsParallel is like IngestRecentBills with an explicit worker count.
 func (s *Service) IngestRecentBillsParallel(ctx context.Context, limit int, concurrency int) (*IngestResult, error) {
-	// Set concurrency defaults
-	if concurrency <= 0 {
-		concurrency = DefaultConcurrency
-	}
-	if concurrency > MaxConcurrency {
-		concurrency = MaxConcurrency
-	}
-
 	// Fetch recent bills from Congress API
-	fetchResult, err := s.congressClient.FetchRecentBills(ctx, limit)
+	fetched, err := s.fetchRecent(ctx, limit)
 	if err != nil {
-		return nil, fmt.Errorf("ingestor: failed to fetch recent bills: %w", err)
+		return nil, err
 	}
 
-	log.Printf("Fetched %d recent bills from Congress.gov", len(fetchResult.Bills))
+	logging.FromContext(ctx).Info("fetched recent bills from Congress.gov", "count", len(fetched))
 
 	// Process in parallel
-	return s.processBillsBatch(ctx, fetchResult.Bills, concurrency)
+	return s.processBillsBatch(ctx, s.recentSource(), fetched, concurrency)
 }
 
-// upsertBill creates or updates a bill and potentially creates a new version.
-// Returns (created, updated, versionCreated, error).
-func (s *Service) upsertBill(ctx context.Context, apiBill *congress.Bill) (bool, bool, bool, error) {
-	// Parse bill number from string
-	billNumber, err := strconv.Atoi(apiBill.Number)
+// upsertBill creates or updates a bill and stores any new text versions.
+// Returns (created, updated, versionsCreated, error).
+func (s *Service) upsertBill(ctx context.Context, apiBill *congress.Bill) (bool, bool, int, error) {
+	// The number is kept as published; one that isn't numeric is stored
+	// with a BillNumber of 0 rather than dropped
+	number := strings.TrimSpace(apiBill.Number)
+	if number == "" {
+		return false, false, 0, fmt.Errorf("bill %s in congress %d has no number", apiBill.Type, apiBill.Congress)
+	}
+	billNumber, err := strconv.Atoi(number)
 	if err != nil {
-		return false, false, false, fmt.Errorf("invalid bill number %q: %w", apiBill.Number, err)
+		billNumber = 0
+		logging.FromContext(ctx).Warn("storing bill with a non-numeric number",
+			"bill_type", apiBill.Type, "number", number, "congress", apiBill.Congress)
+	}
+	if _, ok := congress.LookupBillType(apiBill.Type); !ok {
+		logging.FromContext(ctx).Warn("storing bill of an unknown type",
+			"bill_type", apiBill.Type, "number", number, "congress", apiBill.Congress)
 	}
 
 	// Convert API bill to metadata JSON
 	metadata, err := s.billToMetadata(apiBill)
 	if err != nil {
-		return false, false, false, fmt.Errorf("failed to create metadata: %w", err)
+		return false, false, 0, fmt.Errorf("failed to create metadata: %w", err)
 	}
 
 	// Determine current status from latest action
@@ -252,131 +272,265 @@ func (s *Service) upsertBill(ctx context.Context, apiBill *congress.Bill) (bool,
 	// Build the bill model
 	bill := models.Bill{
 		Congress:       apiBill.Congress,
+		Number:         number,
 		BillNumber:     billNumber,
 		BillType:       apiBill.Type,
 		Title:          apiBill.Title,
 		UpdateDate:     apiBill.UpdateDate,
 		OriginChamber:  apiBill.OriginChamber,
 		CurrentStatus:  currentStatus,
-		IsSpendingBill: congress.IsAppropriation(apiBill.Title),
+		IsSpendingBill: congress.IsSpendingBillOfType(apiBill.Type, apiBill.Title, nil),
 		Metadata:       metadata,
 	}
 
-	// Check if bill exists
+	// The stored row, if any, is read for change events and subjects only;
+	// whether the bill is created or updated is decided by the upsert, so
+	// concurrent ingestors can't both create it
 	var existingBill models.Bill
 	err = s.db.WithContext(ctx).
-		Where("congress = ? AND bill_number = ? AND bill_type = ?",
-			bill.Congress, bill.BillNumber, bill.BillType).
+		Where("congress = ? AND number = ? AND bill_type = ? AND state_code = '' AND session = ''",
+			bill.Congress, bill.Number, bill.BillType).
 		First(&existingBill).Error
+	found := err == nil
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, false, 0, fmt.Errorf("failed to query bill: %w", err)
+	}
+	if found {
+		bill.IsSpendingBill = congress.IsSpendingBillOfType(apiBill.Type, apiBill.Title, existingBill.Subjects)
+	}
 
-	created := false
-	updated := false
-
-	if err == gorm.ErrRecordNotFound {
-		// New bill - create it
-		if err := s.db.WithContext(ctx).Create(&bill).Error; err != nil {
-			return false, false, false, fmt.Errorf("failed to create bill: %w", err)
+	created, changed, err := s.upsertBillRow(ctx, &bill, []string{
+		"title", "update_date", "origin_chamber",
+		"current_status", "is_spending_bill", "metadata", "updated_at",
+	})
+	if err != nil {
+		return false, false, 0, err
+	}
+	updated := changed && !created
+
+	switch {
+	case created:
+		s.publishEvent(ctx, &bill, live.Event{Type: live.EventBillCreated})
+		logging.FromContext(ctx).Info("created new bill",
+			"bill_type", bill.BillType, "bill_number", bill.BillNumber, "congress", bill.Congress)
+	case updated:
+		if found {
+			s.recordBillChanges(ctx, &existingBill, &bill)
 		}
-		created = true
-		log.Printf("Created new bill: %s %d (Congress %d)", bill.BillType, bill.BillNumber, bill.Congress)
-	} else if err != nil {
-		return false, false, false, fmt.Errorf("failed to query bill: %w", err)
-	} else {
-		// Existing bill - check if UpdateDate changed
-		if existingBill.UpdateDate != apiBill.UpdateDate {
-			// Update the bill using upsert (ON CONFLICT DO UPDATE)
-			bill.ID = existingBill.ID
-			if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
-				Columns: []clause.Column{
-					{Name: "congress"},
-					{Name: "bill_number"},
-					{Name: "bill_type"},
-				},
-				DoUpdates: clause.AssignmentColumns([]string{
-					"title", "update_date", "origin_chamber",
-					"current_status", "is_spending_bill", "metadata", "updated_at",
-				}),
-			}).Create(&bill).Error; err != nil {
-				return false, false, false, fmt.Errorf("failed to update bill: %w", err)
-			}
-			updated = true
-			log.Printf("Updated bill: %s %d (Congress %d) - UpdateDate changed from %s to %s",
-				bill.BillType, bill.BillNumber, bill.Congress, existingBill.UpdateDate, apiBill.UpdateDate)
+		s.publishEvent(ctx, &bill, live.Event{Type: live.EventBillUpdated})
+		logging.FromContext(ctx).Info("updated bill",
+			"bill_type", bill.BillType, "bill_number", bill.BillNumber, "congress", bill.Congress,
+			"previous_update_date", existingBill.UpdateDate, "update_date", apiBill.UpdateDate)
+	}
+
+	// Sync detail-derived data (sponsors, cost estimates, policy area,
+	// counts), related bills, summaries, subjects, and titles when the bill
+	// is new or changed
+	becameLaw := false
+	if created || updated {
+		detail, err := s.congressClient.GetBillDetail(ctx, bill.Congress, bill.BillType, bill.Number)
+		if err != nil {
+			logging.FromContext(ctx).Warn("failed to fetch bill detail",
+				"bill_type", bill.BillType, "bill_number", bill.BillNumber, "error", err)
 		} else {
-			// No changes needed
-			bill.ID = existingBill.ID
+			if err := s.syncSponsorships(ctx, &bill, detail); err != nil {
+				logging.FromContext(ctx).Warn("failed to sync sponsors",
+					"bill_type", bill.BillType, "bill_number", bill.BillNumber, "error", err)
+			}
+			if err := s.syncCostEstimates(ctx, &bill, detail); err != nil {
+				logging.FromContext(ctx).Warn("failed to sync cost estimates",
+					"bill_type", bill.BillType, "bill_number", bill.BillNumber, "error", err)
+			}
+			if becameLaw, err = s.syncLaw(ctx, &bill, detail); err != nil {
+				logging.FromContext(ctx).Warn("failed to sync law status",
+					"bill_type", bill.BillType, "bill_number", bill.BillNumber, "error", err)
+			}
+			if err := s.syncDetail(ctx, &bill, detail); err != nil {
+				logging.FromContext(ctx).Warn("failed to record bill detail",
+					"bill_type", bill.BillType, "bill_number", bill.BillNumber, "error", err)
+			}
+		}
+		if err := s.syncRelatedBills(ctx, &bill); err != nil {
+			logging.FromContext(ctx).Warn("failed to sync related bills",
+				"bill_type", bill.BillType, "bill_number", bill.BillNumber, "error", err)
+		}
+		if err := s.syncSummaries(ctx, &bill); err != nil {
+			logging.FromContext(ctx).Warn("failed to sync summaries",
+				"bill_type", bill.BillType, "bill_number", bill.BillNumber, "error", err)
+		}
+		if err := s.syncSubjects(ctx, &bill); err != nil {
+			logging.FromContext(ctx).Warn("failed to sync subjects",
+				"bill_type", bill.BillType, "bill_number", bill.BillNumber, "error", err)
+		}
+		if err := s.syncTitles(ctx, &bill); err != nil {
+			logging.FromContext(ctx).Warn("failed to sync titles",
+				"bill_type", bill.BillType, "bill_number", bill.BillNumber, "error", err)
 		}
 	}
 
-	// Try to fetch and store bill text as a new version
-	versionCreated, err := s.fetchAndStoreVersion(ctx, &bill, apiBill)
-	if err != nil {
-		// Log but don't fail the entire operation
-		log.Printf("Warning: failed to fetch version for %s %d: %v",
-			bill.BillType, bill.BillNumber, err)
+	// Queue the introduced-vs-enacted diff when the bill just became law,
+	// with whatever enacted text is stored, or when the enrolled or public
+	// law text of a law is newly stored, which may be runs after the law
+	// was first seen. Deferred so versions stored before a rate limit fails
+	// the bill are queued too, as its retry won't store them again.
+	enactedStored := false
+	defer func() {
+		if becameLaw || (enactedStored && bill.PublicLawNumber != "") {
+			s.enqueueEnactedDiff(ctx, &bill)
+		}
+	}()
+
+	// Try to fetch and store the bill's text versions, unless its text
+	// hasn't changed since the last successful fetch
+	versionsCreated := 0
+	if s.govinfo != nil {
+		// Text comes from GovInfo bulk data (IngestGovInfo); Congress.gov
+		// supplies metadata only
+	} else if apiBill.UpdateDateIncludingText != "" && apiBill.UpdateDateIncludingText == bill.UpdateDateIncludingText {
+		metrics.IngestTextSkipped.Inc()
+		logging.FromContext(ctx).Debug("text unchanged, skipping text fetch",
+			"bill_type", bill.BillType, "bill_number", bill.BillNumber,
+			"update_date_including_text", apiBill.UpdateDateIncludingText)
+	} else if locked, err := s.withBillLock(ctx, bill.ID, func() (err error) {
+		versionsCreated, enactedStored, err = s.fetchAndStoreVersions(ctx, &bill, apiBill)
+		return err
+	}); errors.Is(err, congress.ErrRateLimited) {
+		// Fail the bill so the worker pool pauses; it's retried whole, and
+		// as its text update date isn't recorded, its text with it
+		return created, updated, versionsCreated, fmt.Errorf("failed to fetch versions: %w", err)
+	} else if err != nil {
+		// Log but don't fail the entire operation; the text is retried next
+		// run, or sooner by RetryFailures
+		logging.FromContext(ctx).Warn("failed to fetch versions",
+			"bill_type", bill.BillType, "bill_number", bill.BillNumber, "error", err)
+		s.recordFailure(ctx, models.IngestFailureStageVersions, apiBill, err)
+	} else if !locked {
+		// Left for the other ingestor, which records the text update date
+		logging.FromContext(ctx).Debug("bill text being fetched by another ingestor, skipping",
+			"bill_type", bill.BillType, "bill_number", bill.BillNumber)
+	} else if apiBill.UpdateDateIncludingText != "" {
+		bill.UpdateDateIncludingText = apiBill.UpdateDateIncludingText
+		if err := s.db.WithContext(ctx).Model(&models.Bill{}).Where("id = ?", bill.ID).
+			Update("update_date_including_text", bill.UpdateDateIncludingText).Error; err != nil {
+			logging.FromContext(ctx).Warn("failed to record text update date",
+				"bill_type", bill.BillType, "bill_number", bill.BillNumber, "error", err)
+		}
 	}
 
-	return created, updated, versionCreated, nil
+	return created, updated, versionsCreated, nil
 }
 
-// fetchAndStoreVersion fetches bill text and creates a version if content is new.
-func (s *Service) fetchAndStoreVersion(ctx context.Context, bill *models.Bill, apiBill *congress.Bill) (bool, error) {
-	// Parse bill number for API call
-	billNumber, _ := strconv.Atoi(apiBill.Number)
-
+// fetchAndStoreVersions fetches a bill's text versions and stores each one
+// whose content is new, oldest first, so intermediate stages (RH, EH, EAS)
+// are kept alongside the latest text. To avoid re-downloading every version
+// on every run, earlier versions are only fetched when no version with their
+// code is stored yet; the latest is always re-checked for revised text.
+// Returns the number of versions created and whether one is enacted text,
+// also when a later version fails.
+func (s *Service) fetchAndStoreVersions(ctx context.Context, bill *models.Bill, apiBill *congress.Bill) (int, bool, error) {
 	// Fetch text versions from Congress API
-	textVersions, err := s.congressClient.GetBillText(ctx, apiBill.Congress, apiBill.Type, billNumber)
+	textVersions, err := s.congressClient.GetBillText(ctx, apiBill.Congress, apiBill.Type, apiBill.Number)
 	if err != nil {
 		// Some bills don't have text yet
 		if err == congress.ErrNotFound {
-			return false, nil
+			return 0, false, nil
 		}
-		return false, err
+		return 0, false, err
 	}
 
 	if len(textVersions) == 0 {
-		return false, nil
+		return 0, false, nil
 	}
 
-	// Get the most recent text version
-	latestVersion := textVersions[0]
+	var storedCodes []string
+	if err := s.db.WithContext(ctx).Model(&models.Version{}).
+		Where("bill_id = ?", bill.ID).Distinct().Pluck("version_code", &storedCodes).Error; err != nil {
+		return 0, false, fmt.Errorf("failed to query stored versions: %w", err)
+	}
+	stored := make(map[string]bool, len(storedCodes))
+	for _, code := range storedCodes {
+		stored[code] = true
+	}
 
-	// Find a text format URL (prefer XML, then HTML, then TXT)
-	textURL := ""
-	versionCode := latestVersion.Type
-	for _, format := range latestVersion.Formats {
-		if format.Type == "Formatted Text" || format.Type == "TXT" {
-			textURL = format.URL
-			break
+	ordered := sortTextVersions(textVersions)
+	created, enacted := 0, false
+	for i, tv := range ordered {
+		latest := i == len(ordered)-1
+		if !latest && stored[versioncode.FromType(tv.Type)] {
+			continue
 		}
-		if format.Type == "Formatted XML" || format.Type == "XML" {
-			textURL = format.URL
+
+		fetchedAt := time.Now()
+		if parsed, ok := parseTextVersionDate(tv.Date); ok && !latest {
+			fetchedAt = parsed
 		}
-		if textURL == "" && format.Type == "PDF" {
-			// Skip PDF for now, can't easily hash
-			continue
+
+		ok, err := s.storeTextVersion(ctx, bill, tv, fetchedAt)
+		if err != nil {
+			return created, enacted, fmt.Errorf("version %q: %w", tv.Type, err)
 		}
-		if textURL == "" {
-			textURL = format.URL
+		if ok {
+			created++
+			enacted = enacted || versioncode.IsEnacted(versioncode.FromType(tv.Type))
 		}
 	}
 
-	if textURL == "" {
+	return created, enacted, nil
+}
+
+// storeTextVersion downloads one text version of a bill and stores it as a
+// Version unless a version with identical content already exists.
+// fetchedAt orders the version among the bill's other versions.
+func (s *Service) storeTextVersion(ctx context.Context, bill *models.Bill, textVersion congress.TextVersion, fetchedAt time.Time) (bool, error) {
+	url := textURL(textVersion)
+	if url == "" {
 		return false, nil
 	}
 
 	// Fetch the actual text content
-	textContent, err := s.fetchTextContent(ctx, textURL)
+	textContent, source, err := s.fetchTextContent(ctx, url)
 	if err != nil {
-		return false, fmt.Errorf("failed to fetch text from %s: %w", textURL, err)
+		return false, fmt.Errorf("failed to fetch text from %s: %w", url, err)
+	}
+
+	return s.storeVersion(ctx, bill, versioncode.FromType(textVersion.Type), textContent, fetchedAt, source)
+}
+
+// textURL returns the URL of the format of a text version that is
+// fetched: formatted text, else XML, else anything but PDF. It returns ""
+// when there is none.
+func textURL(textVersion congress.TextVersion) string {
+	url := ""
+	for _, format := range textVersion.Formats {
+		if format.Type == "Formatted Text" || format.Type == "TXT" {
+			return format.URL
+		}
+		if format.Type == "Formatted XML" || format.Type == "XML" {
+			url = format.URL
+		}
+		if url == "" && format.Type == "PDF" {
+			// Skip PDF for now, can't easily hash
+			continue
+		}
+		if url == "" {
+			url = format.URL
+		}
 	}
+	return url
+}
 
-	// Compute SHA-256 hash
-	contentHash := ComputeHash(textContent)
+// storeVersion stores text as a new Version of bill unless a version with
+// identical content already exists, recording and publishing the change.
+// source, if known, is stored as the new version's provenance. Reports
+// whether a version was created.
+func (s *Service) storeVersion(ctx context.Context, bill *models.Bill, versionCode, textContent string, fetchedAt time.Time, source *models.VersionProvenance) (bool, error) {
+	// Hash the normalized text so a format change (e.g., XML to HTML) with
+	// identical legislative text isn't mistaken for a new version
+	contentHash := textnorm.Hash(textContent)
 
-	// Check if we already have this exact version
+	// Check if we already have this text
 	var existingVersion models.Version
-	err = s.db.WithContext(ctx).
+	err := s.db.WithContext(ctx).
 		Where("bill_id = ? AND content_hash = ?", bill.ID, contentHash).
 		First(&existingVersion).Error
 
@@ -392,49 +546,91 @@ func (s *Service) fetchAndStoreVersion(ctx context.Context, bill *models.Bill, a
 		BillID:      bill.ID,
 		VersionCode: versionCode,
 		ContentHash: contentHash,
+		RawHash:     ComputeHash(textContent),
 		TextContent: textContent,
-		FetchedAt:   time.Now(),
+		PlainText:   textextract.Extract(textContent),
+		FetchedAt:   fetchedAt,
+	}
+	textstats.Fill(&version)
+	if err := textstore.Offload(ctx, s.texts, bill, &version); err != nil {
+		return false, fmt.Errorf("failed to store version text: %w", err)
 	}
 
 	if err := s.db.WithContext(ctx).Create(&version).Error; err != nil {
 		return false, fmt.Errorf("failed to create version: %w", err)
 	}
+	if source != nil {
+		source.VersionID = version.ID
+		if err := s.db.WithContext(ctx).Create(source).Error; err != nil {
+			logging.FromContext(ctx).Warn("failed to record version provenance",
+				"version_id", version.ID, "source_url", source.SourceURL, "error", err)
+		}
+	}
 
-	log.Printf("Created new version for %s %d: %s (hash: %s...)",
-		bill.BillType, bill.BillNumber, versionCode, contentHash[:16])
+	s.recordEvent(ctx, models.BillEvent{
+		BillID:     bill.ID,
+		EventType:  models.BillEventVersionAdded,
+		OccurredAt: version.FetchedAt,
+		VersionID:  &version.ID,
+		NewValue:   versionCode,
+		Details:    datatypes.JSONMap{"contentHash": contentHash},
+	})
+
+	s.enqueueNeighborDiffs(ctx, &version)
+	s.matchKeywordAlerts(ctx, &version)
+	s.publishEvent(ctx, bill, live.Event{
+		Type:        live.EventVersionCreated,
+		VersionID:   version.ID,
+		VersionCode: versionCode,
+	})
+
+	logging.FromContext(ctx).Info("created new version",
+		"bill_type", bill.BillType, "bill_number", bill.BillNumber,
+		"version_code", versionCode, "content_hash", contentHash[:16])
 
 	return true, nil
 }
 
-// fetchTextContent fetches text content from a URL.
-func (s *Service) fetchTextContent(ctx context.Context, url string) (string, error) {
+// fetchTextContent fetches text content from a URL, with the provenance
+// of what was retrieved. A 429 returns an error matching
+// congress.ErrRateLimited and pauses every worker's downloads for as long
+// as the server asks.
+func (s *Service) fetchTextContent(ctx context.Context, url string) (string, *models.VersionProvenance, error) {
+	if err := s.waitText(ctx); err != nil {
+		return "", nil, err
+	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return "", nil, s.pauseText(ctx, url, resp)
+	}
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("unexpected status: %d", resp.StatusCode)
+		return "", nil, fmt.Errorf("unexpected status: %d", resp.StatusCode)
 	}
 
 	// Limit read to 10MB to prevent memory issues
-	limited := io.LimitReader(resp.Body, 10*1024*1024)
+	limited := io.LimitReader(resp.Body, provenance.MaxSize)
 	content, err := io.ReadAll(limited)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	return string(content), nil
+	source := provenance.Capture(url, resp, content, time.Now())
+	return string(content), &source, nil
 }
 
-// billToMetadata converts a Congress API bill to a JSONB metadata map.
-func (s *Service) billToMetadata(bill *congress.Bill) (datatypes.JSONMap, error) {
+// billToMetadata converts a Congress API bill or bill detail to a JSONB
+// metadata map.
+func (s *Service) billToMetadata(bill any) (datatypes.JSONMap, error) {
 	// Marshal to JSON then unmarshal to map for clean conversion
 	data, err := json.Marshal(bill)
 	if err != nil {
@@ -0,0 +1,101 @@
+package ingestor
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// syncSponsorships fetches the sponsor and cosponsors of a bill and upserts
+// them as Members linked through BillSponsorship rows. The bill's denormalized
+// Sponsor name is updated to match the primary sponsor from the bill detail.
+func (s *Service) syncSponsorships(ctx context.Context, bill *models.Bill, detail *congress.BillDetail) error {
+	cosponsors, err := s.congressClient.GetBillCosponsors(ctx, bill.Congress, bill.BillType, bill.Number)
+	if err != nil && err != congress.ErrNotFound {
+		return fmt.Errorf("failed to fetch cosponsors: %w", err)
+	}
+
+	members := make([]models.Member, 0, len(detail.Sponsors)+len(cosponsors))
+	sponsorships := make([]models.BillSponsorship, 0, len(detail.Sponsors)+len(cosponsors))
+
+	for _, sp := range detail.Sponsors {
+		members = append(members, memberFromSponsor(sp))
+		sponsorships = append(sponsorships, models.BillSponsorship{
+			BillID:     bill.ID,
+			BioguideID: sp.BioguideID,
+			Role:       models.SponsorshipRoleSponsor,
+		})
+	}
+	for _, co := range cosponsors {
+		members = append(members, memberFromSponsor(co.Sponsor))
+		sponsorships = append(sponsorships, models.BillSponsorship{
+			BillID:          bill.ID,
+			BioguideID:      co.BioguideID,
+			Role:            models.SponsorshipRoleCosponsor,
+			SponsorshipDate: co.SponsorshipDate,
+		})
+	}
+
+	if len(members) == 0 {
+		return nil
+	}
+
+	db := s.db.WithContext(ctx)
+
+	// Snapshot existing links so additions can be reported in the change feed
+	var existing []string
+	if err := db.Model(&models.BillSponsorship{}).Where("bill_id = ?", bill.ID).
+		Pluck("bioguide_id", &existing).Error; err != nil {
+		return fmt.Errorf("failed to fetch existing sponsorships: %w", err)
+	}
+	known := make(map[string]bool, len(existing))
+	for _, id := range existing {
+		known[id] = true
+	}
+	names := make(map[string]string, len(members))
+	for _, m := range members {
+		names[m.BioguideID] = m.FullName
+	}
+	previousSponsor := bill.Sponsor
+
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "bioguide_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"full_name", "party", "state", "district", "updated_at"}),
+	}).Create(&members).Error; err != nil {
+		return fmt.Errorf("failed to upsert members: %w", err)
+	}
+
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "bill_id"}, {Name: "bioguide_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"role", "sponsorship_date"}),
+	}).Create(&sponsorships).Error; err != nil {
+		return fmt.Errorf("failed to upsert sponsorships: %w", err)
+	}
+
+	if len(detail.Sponsors) > 0 && bill.Sponsor != detail.Sponsors[0].FullName {
+		bill.Sponsor = detail.Sponsors[0].FullName
+		if err := db.Model(&models.Bill{}).Where("id = ?", bill.ID).
+			Update("sponsor", bill.Sponsor).Error; err != nil {
+			return fmt.Errorf("failed to update sponsor: %w", err)
+		}
+	}
+
+	s.recordSponsorChanges(ctx, bill.ID, known, previousSponsor, bill.Sponsor, sponsorships, names)
+
+	return nil
+}
+
+// memberFromSponsor converts a Congress API sponsor to a Member model.
+func memberFromSponsor(sp congress.Sponsor) models.Member {
+	return models.Member{
+		BioguideID: sp.BioguideID,
+		FullName:   sp.FullName,
+		Party:      sp.Party,
+		State:      sp.State,
+		District:   sp.District,
+	}
+}
@@ -0,0 +1,65 @@
+package ingestor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// syncSubjects fetches a bill's CRS policy area and legislative subjects,
+// stores them on the bill and in bill_subjects, and reclassifies the bill
+// as spending or not from its subjects.
+func (s *Service) syncSubjects(ctx context.Context, bill *models.Bill) error {
+	subjects, err := s.congressClient.GetBillSubjects(ctx, bill.Congress, bill.BillType, bill.Number)
+	if errors.Is(err, congress.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch subjects: %w", err)
+	}
+
+	names := subjects.Names()
+	// Kept from the bill detail when the subjects have none
+	policyArea := bill.PolicyArea
+	if subjects.PolicyArea != nil {
+		policyArea = subjects.PolicyArea.Name
+	}
+
+	err = s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Bill{}).Where("id = ?", bill.ID).Updates(map[string]any{
+			"policy_area":      policyArea,
+			"subjects":         datatypes.JSONSlice[string](names),
+			"is_spending_bill": congress.IsSpendingBill(bill.Title, names),
+		}).Error; err != nil {
+			return err
+		}
+
+		// Replace the normalized rows
+		if err := tx.Where("bill_id = ?", bill.ID).Delete(&models.BillSubject{}).Error; err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			return nil
+		}
+		rows := make([]models.BillSubject, len(names))
+		for i, name := range names {
+			rows[i] = models.BillSubject{BillID: bill.ID, Subject: name}
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store subjects: %w", err)
+	}
+
+	bill.PolicyArea = policyArea
+	bill.Subjects = names
+	bill.IsSpendingBill = congress.IsSpendingBillOfType(bill.BillType, bill.Title, names)
+	return nil
+}
@@ -0,0 +1,59 @@
+package ingestor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/textextract"
+)
+
+// syncSummaries fetches a bill's CRS summaries and upserts them, one row per
+// summary version code.
+func (s *Service) syncSummaries(ctx context.Context, bill *models.Bill) error {
+	summaries, err := s.congressClient.GetBillSummaries(ctx, bill.Congress, bill.BillType, bill.Number)
+	if errors.Is(err, congress.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch summaries: %w", err)
+	}
+
+	rows := make([]models.Summary, 0, len(summaries))
+	seen := make(map[string]bool, len(summaries))
+	for _, sum := range summaries {
+		if sum.VersionCode == "" || seen[sum.VersionCode] {
+			continue
+		}
+		seen[sum.VersionCode] = true
+
+		actionDate, _ := parseTextVersionDate(sum.ActionDate)
+		rows = append(rows, models.Summary{
+			BillID:      bill.ID,
+			VersionCode: sum.VersionCode,
+			ActionDate:  actionDate,
+			ActionDesc:  sum.ActionDesc,
+			Text:        sum.Text,
+			PlainText:   textextract.Extract(sum.Text),
+			UpdateDate:  sum.UpdateDate,
+		})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	if err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "bill_id"}, {Name: "version_code"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"action_date", "action_desc", "text", "plain_text", "update_date", "updated_at",
+		}),
+	}).Create(&rows).Error; err != nil {
+		return fmt.Errorf("failed to upsert summaries: %w", err)
+	}
+
+	return nil
+}
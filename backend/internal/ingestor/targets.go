@@ -0,0 +1,204 @@
+package ingestor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/logging"
+)
+
+const (
+	// maxTargetPages bounds how many pages are scanned for a target whose
+	// keyword or appropriations filter discards most of each page.
+	maxTargetPages = 5
+
+	// targetPageSize is the Congress.gov API's maximum page size.
+	targetPageSize = 250
+)
+
+// Target selects a slice of Congress to track in recent-bills mode.
+type Target struct {
+	Congress       int      // Congress number; required
+	BillType       string   // Bill type (hr, s, ...); empty for all types
+	Keywords       []string // Title keywords (case-insensitive, any match); empty for all
+	Appropriations bool     // Only appropriations/spending bills
+	Limit          int      // Maximum bills per run; 0 uses the run's limit
+}
+
+// String returns the target in INGEST_TARGETS syntax.
+func (t Target) String() string {
+	parts := []string{fmt.Sprintf("congress=%d", t.Congress)}
+	if t.BillType != "" {
+		parts = append(parts, "type="+t.BillType)
+	}
+	if len(t.Keywords) > 0 {
+		parts = append(parts, "keywords="+strings.Join(t.Keywords, "|"))
+	}
+	if t.Appropriations {
+		parts = append(parts, "appropriations=true")
+	}
+	if t.Limit > 0 {
+		parts = append(parts, fmt.Sprintf("limit=%d", t.Limit))
+	}
+	return strings.Join(parts, " ")
+}
+
+// matches reports whether a bill passes the target's client-side filters.
+func (t Target) matches(bill *congress.Bill) bool {
+	if len(t.Keywords) == 0 {
+		return true
+	}
+	title := strings.ToLower(bill.Title)
+	for _, kw := range t.Keywords {
+		if strings.Contains(title, kw) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseTargets parses an ingestion target list. Targets are separated by
+// semicolons; each is a space-separated list of key=value pairs:
+//
+//	congress=119 type=hr limit=50; congress=119 keywords=defense|border appropriations=true
+//
+// Keys are congress (required), type, keywords (pipe-separated),
+// appropriations (true/false), and limit. An empty spec returns no targets.
+func ParseTargets(spec string) ([]Target, error) {
+	var targets []Target
+	for _, raw := range strings.Split(spec, ";") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+
+		var t Target
+		for _, field := range strings.Fields(raw) {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, fmt.Errorf("ingestor: invalid target field %q (want key=value)", field)
+			}
+			switch strings.ToLower(key) {
+			case "congress":
+				n, err := strconv.Atoi(value)
+				if err != nil || n <= 0 {
+					return nil, fmt.Errorf("ingestor: invalid target congress %q", value)
+				}
+				t.Congress = n
+			case "type":
+				bt, ok := congress.LookupBillType(value)
+				if !ok {
+					return nil, fmt.Errorf("ingestor: unknown target bill type %q", value)
+				}
+				t.BillType = bt.Code
+			case "keywords":
+				for _, kw := range strings.Split(value, "|") {
+					if kw = strings.TrimSpace(strings.ToLower(kw)); kw != "" {
+						t.Keywords = append(t.Keywords, kw)
+					}
+				}
+			case "appropriations":
+				b, err := strconv.ParseBool(value)
+				if err != nil {
+					return nil, fmt.Errorf("ingestor: invalid target appropriations %q", value)
+				}
+				t.Appropriations = b
+			case "limit":
+				n, err := strconv.Atoi(value)
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("ingestor: invalid target limit %q", value)
+				}
+				t.Limit = n
+			default:
+				return nil, fmt.Errorf("ingestor: unknown target key %q", key)
+			}
+		}
+
+		if t.Congress == 0 {
+			return nil, fmt.Errorf("ingestor: target %q is missing congress", raw)
+		}
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+// fetchTargetBills fetches the most recently updated bills for each target,
+// honoring per-target limits, and removes duplicates across targets.
+func (s *Service) fetchTargetBills(ctx context.Context, defaultLimit int) ([]congress.Bill, error) {
+	seen := make(map[string]bool)
+	var bills []congress.Bill
+
+	for _, target := range s.targets {
+		limit := target.Limit
+		if limit <= 0 {
+			limit = defaultLimit
+		}
+
+		pageSize := min(limit, targetPageSize)
+		matched := 0
+		for page := 0; page < maxTargetPages && matched < limit; page++ {
+			result, err := s.congressClient.SearchBills(ctx, congress.SearchFilters{
+				Congress:         target.Congress,
+				BillType:         target.BillType,
+				IsAppropriations: target.Appropriations,
+				Limit:            pageSize,
+				Offset:           page * pageSize,
+				Sort:             "updateDate+desc",
+			})
+			if err != nil {
+				return nil, fmt.Errorf("ingestor: failed to fetch target %q: %w", target, err)
+			}
+
+			for _, bill := range result.Bills {
+				if matched >= limit || !target.matches(&bill) {
+					continue
+				}
+				key := billKey(&bill)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+				bills = append(bills, bill)
+				matched++
+			}
+
+			if !result.HasMore {
+				break
+			}
+		}
+
+		logging.FromContext(ctx).Info("fetched target bills", "target", target.String(), "count", matched)
+	}
+
+	return bills, nil
+}
+
+// recentSource describes the list fetchRecent returns, for resuming runs.
+func (s *Service) recentSource() string {
+	if len(s.targets) == 0 {
+		return "recent"
+	}
+	specs := make([]string, len(s.targets))
+	for i, t := range s.targets {
+		specs[i] = t.String()
+	}
+	return "recent " + strings.Join(specs, "; ")
+}
+
+// fetchRecent returns the bills to process in recent-bills mode: the
+// configured targets when set, otherwise the most recently updated bills
+// across all of Congress.
+func (s *Service) fetchRecent(ctx context.Context, limit int) ([]congress.Bill, error) {
+	if len(s.targets) > 0 {
+		return s.fetchTargetBills(ctx, limit)
+	}
+
+	result, err := s.congressClient.FetchRecentBills(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ingestor: failed to fetch recent bills: %w", err)
+	}
+	return result.Bills, nil
+}
@@ -0,0 +1,52 @@
+package ingestor_test
+
+import (
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/ingestor"
+)
+
+// TestParseTargets verifies target specs parse and invalid specs are rejected.
+func TestParseTargets(t *testing.T) {
+	targets, err := ingestor.ParseTargets("congress=119 type=HR limit=50; congress=118 keywords=Defense|border appropriations=true;")
+	if err != nil {
+		t.Fatalf("ParseTargets failed: %v", err)
+	}
+	if len(targets) != 2 {
+		t.Fatalf("Expected 2 targets, got %d", len(targets))
+	}
+	if targets[0].Congress != 119 || targets[0].BillType != "hr" || targets[0].Limit != 50 {
+		t.Errorf("Unexpected first target: %+v", targets[0])
+	}
+	if !targets[1].Appropriations || len(targets[1].Keywords) != 2 || targets[1].Keywords[0] != "defense" {
+		t.Errorf("Unexpected second target: %+v", targets[1])
+	}
+
+	if targets, err := ingestor.ParseTargets(""); err != nil || len(targets) != 0 {
+		t.Errorf("Empty spec should yield no targets, got %v, %v", targets, err)
+	}
+
+	for _, bad := range []string{"type=hr", "congress=abc", "congress=119 color=red", "congress=119 limit", "congress=119 type=ab"} {
+		if _, err := ingestor.ParseTargets(bad); err == nil {
+			t.Errorf("Expected error for %q", bad)
+		}
+	}
+}
+
+// TestParseBillTypes verifies bill type lists parse and unknown types are
+// rejected.
+func TestParseBillTypes(t *testing.T) {
+	types, err := ingestor.ParseBillTypes(" HR, hres ,sconres,")
+	if err != nil {
+		t.Fatalf("ParseBillTypes failed: %v", err)
+	}
+	if len(types) != 3 || types[0] != "hr" || types[1] != "hres" || types[2] != "sconres" {
+		t.Errorf("Unexpected types: %v", types)
+	}
+	if types, err := ingestor.ParseBillTypes(""); err != nil || types != nil {
+		t.Errorf("Empty list should yield nil, got %v, %v", types, err)
+	}
+	if _, err := ingestor.ParseBillTypes("hr,pl"); err == nil {
+		t.Error("Expected error for unknown type pl")
+	}
+}
@@ -0,0 +1,72 @@
+package ingestor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/metrics"
+)
+
+// textRateLimitError is a text download answered with 429 Too Many
+// Requests. It matches congress.ErrRateLimited, so a rate-limited download
+// is retried, and stops the worker pool, like a rate-limited API request.
+type textRateLimitError struct {
+	retryAfter time.Duration // How long the server asked callers to wait
+}
+
+func (e *textRateLimitError) Error() string {
+	return fmt.Sprintf("%s (retry after %s)", congress.ErrRateLimited, e.retryAfter)
+}
+
+func (e *textRateLimitError) Unwrap() error {
+	return congress.ErrRateLimited
+}
+
+// retryAfter returns how long a 429 response asks callers to wait, from
+// its Retry-After header in seconds or as an HTTP date, or
+// rateLimitBackoff when it has no usable one.
+func retryAfter(resp *http.Response, now time.Time) time.Duration {
+	value := strings.TrimSpace(resp.Header.Get("Retry-After"))
+	if secs, err := strconv.Atoi(value); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0)
+	}
+	return rateLimitBackoff
+}
+
+// pauseText holds back every worker's text downloads after a 429, for as
+// long as the response asks, and returns the error reporting it.
+func (s *Service) pauseText(ctx context.Context, url string, resp *http.Response) error {
+	now := time.Now()
+	d := retryAfter(resp, now)
+
+	s.textMu.Lock()
+	if until := now.Add(d); until.After(s.textPausedUntil) {
+		s.textPausedUntil = until
+	}
+	s.textMu.Unlock()
+
+	metrics.CongressRateLimited.Inc()
+	logging.FromContext(ctx).Warn("text download rate limited, pausing downloads", "url", url, "pause", d.String())
+	return &textRateLimitError{retryAfter: d}
+}
+
+// waitText blocks until text downloads may resume after a 429.
+func (s *Service) waitText(ctx context.Context) error {
+	s.textMu.Lock()
+	until := s.textPausedUntil
+	s.textMu.Unlock()
+
+	if d := time.Until(until); d > 0 {
+		return sleepContext(ctx, d)
+	}
+	return nil
+}
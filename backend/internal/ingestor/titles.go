@@ -0,0 +1,119 @@
+package ingestor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// syncTitles fetches a bill's titles, stores them as its title history, and
+// stores its short and popular titles as aliases for search.
+func (s *Service) syncTitles(ctx context.Context, bill *models.Bill) error {
+	titles, err := s.congressClient.GetBillTitles(ctx, bill.Congress, bill.BillType, bill.Number)
+	if errors.Is(err, congress.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to fetch titles: %w", err)
+	}
+
+	if err := s.storeTitles(ctx, bill, titles); err != nil {
+		return err
+	}
+	return s.storeAliases(ctx, bill, titles)
+}
+
+// storeTitles replaces a bill's stored titles and records a title_revised
+// event for each official, short, or popular title that wasn't among them.
+// Titles are only diffed for bills that already had titles, so a first sync
+// doesn't flood the feed.
+func (s *Service) storeTitles(ctx context.Context, bill *models.Bill, titles []congress.BillTitle) error {
+	rows := make([]models.BillTitle, 0, len(titles))
+	for i, t := range titles {
+		title := strings.TrimSpace(t.Title)
+		if title == "" {
+			continue
+		}
+		rows = append(rows, models.BillTitle{
+			BillID:      bill.ID,
+			Kind:        titleKind(t),
+			TitleType:   t.TitleType,
+			Title:       title,
+			VersionCode: t.BillTextVersionCode,
+			Chamber:     t.ChamberName,
+			UpdateDate:  t.UpdateDate,
+			Position:    i,
+		})
+	}
+
+	var previous []models.BillTitle
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("bill_id = ?", bill.ID).Order("update_date ASC, position DESC").Find(&previous).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("bill_id = ?", bill.ID).Delete(&models.BillTitle{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		return fmt.Errorf("failed to store titles: %w", err)
+	}
+
+	if len(previous) == 0 {
+		return nil
+	}
+	known := make(map[string]bool, len(previous))
+	latest := make(map[string]string) // Kind to its most recent title
+	for _, t := range previous {
+		known[t.Kind+"|"+t.Title] = true
+		latest[t.Kind] = t.Title
+	}
+	// Congress.gov lists the newest titles first
+	for i := len(rows) - 1; i >= 0; i-- {
+		t := rows[i]
+		if t.Kind == models.TitleKindDisplay || t.Kind == "" || known[t.Kind+"|"+t.Title] {
+			continue
+		}
+		known[t.Kind+"|"+t.Title] = true
+		s.recordEvent(ctx, models.BillEvent{
+			BillID:        bill.ID,
+			EventType:     models.BillEventTitleRevised,
+			PreviousValue: latest[t.Kind],
+			NewValue:      t.Title,
+			Details: datatypes.JSONMap{
+				"kind":        t.Kind,
+				"titleType":   t.TitleType,
+				"versionCode": t.VersionCode,
+			},
+		})
+		latest[t.Kind] = t.Title
+	}
+	return nil
+}
+
+// titleKind classifies a Congress.gov title, or returns "" for title types
+// it doesn't recognize.
+func titleKind(t congress.BillTitle) string {
+	switch {
+	case t.IsOfficialTitle():
+		return models.TitleKindOfficial
+	case t.IsShortTitle():
+		return models.TitleKindShort
+	case t.IsPopularTitle():
+		return models.TitleKindPopular
+	case strings.Contains(strings.ToLower(t.TitleType), "display title"):
+		return models.TitleKindDisplay
+	}
+	return ""
+}
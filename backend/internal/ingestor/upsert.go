@@ -0,0 +1,114 @@
+package ingestor
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// billKeyColumns are the columns of idx_bill_number_key, the unique key of
+// a bill. Ingested bills are public, so their tenant_id is always 0.
+var billKeyColumns = []clause.Column{
+	{Name: "congress"},
+	{Name: "number"},
+	{Name: "bill_type"},
+	{Name: "state_code"},
+	{Name: "session"},
+	{Name: "tenant_id"},
+}
+
+// billLockSpace namespaces the advisory locks taken by withBillLock.
+const billLockSpace = 0x64677631 // "dgv1"
+
+// upsertBillRow inserts bill, or when a bill with its key exists and has
+// a different update date, overwrites the given columns. The insert does
+// nothing when the bill exists and the update only matches a row whose
+// update date differs, so concurrent ingestors can't both create a bill,
+// and only one of them sees a given update. On return bill holds the
+// stored row. created reports whether the row was inserted, and changed
+// whether it was inserted or updated.
+func (s *Service) upsertBillRow(ctx context.Context, bill *models.Bill, updates []string) (created, changed bool, err error) {
+	db := s.db.WithContext(ctx)
+	result := db.Clauses(clause.OnConflict{Columns: billKeyColumns, DoNothing: true}).Create(bill)
+	if result.Error != nil {
+		return false, false, fmt.Errorf("failed to insert bill: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return true, true, nil
+	}
+
+	result = db.Model(bill).Clauses(clause.Returning{}).
+		Where("congress = ? AND number = ? AND bill_type = ? AND state_code = ? AND session = ? AND tenant_id = ?",
+			bill.Congress, bill.Number, bill.BillType, bill.StateCode, bill.Session, bill.TenantID).
+		Where("update_date IS DISTINCT FROM ?", bill.UpdateDate).
+		Select(updates).Updates(bill)
+	if result.Error != nil {
+		return false, false, fmt.Errorf("failed to update bill: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return false, true, nil
+	}
+
+	// Unchanged: nothing was written or returned
+	var stored models.Bill
+	if err := db.
+		Where("congress = ? AND number = ? AND bill_type = ? AND state_code = ? AND session = ? AND tenant_id = ?",
+			bill.Congress, bill.Number, bill.BillType, bill.StateCode, bill.Session, bill.TenantID).
+		First(&stored).Error; err != nil {
+		return false, false, fmt.Errorf("failed to query bill: %w", err)
+	}
+	*bill = stored
+	return false, false, nil
+}
+
+// withBillLock runs fn while holding a PostgreSQL advisory lock on the
+// bill, so ingestors running side by side don't fetch and store the same
+// bill's text twice. When another session holds the lock, fn isn't run and
+// locked is false. Other databases, such as the SQLite tests use, have no
+// advisory locks, so fn always runs.
+//
+// The lock is a session lock on a connection set aside for it, with no
+// transaction left open while fn fetches text; it is released when fn
+// returns, or, should that fail, by closing the connection. Each worker
+// holds at most one such connection, so with at most MaxConcurrency
+// workers, fewer than the pool's connections (database.DefaultConfig's
+// MaxOpenConns), fn's own queries always find a free one.
+func (s *Service) withBillLock(ctx context.Context, billID uint, fn func() error) (locked bool, err error) {
+	if s.db.Dialector.Name() != "postgres" {
+		return true, fn()
+	}
+	sqlDB, err := s.db.DB()
+	if err != nil {
+		return false, fmt.Errorf("failed to lock bill: %w", err)
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to lock bill: %w", err)
+	}
+	defer conn.Close()
+
+	key := int64(billLockSpace)<<32 | int64(billID)
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&locked); err != nil {
+		return false, fmt.Errorf("failed to lock bill: %w", err)
+	}
+	if !locked {
+		return false, nil
+	}
+	defer func() {
+		// Released even when ctx is cancelled; a connection that can't be
+		// unlocked is closed rather than returned to the pool locked
+		unlockCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		if _, unlockErr := conn.ExecContext(unlockCtx, "SELECT pg_advisory_unlock($1)", key); unlockErr != nil {
+			logging.FromContext(ctx).Warn("failed to unlock bill, closing its connection", "bill_id", billID, "error", unlockErr)
+			_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+		}
+	}()
+	return true, fn()
+}
@@ -0,0 +1,46 @@
+package ingestor
+
+import (
+	"sort"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/versioncode"
+)
+
+// parseTextVersionDate parses a text version date, which Congress.gov returns
+// as RFC 3339 or a bare date.
+func parseTextVersionDate(date string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, date); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// sortTextVersions returns text versions oldest first. The API lists them
+// newest first, so undated versions keep that order reversed and are
+// treated as newer than any dated version. Versions sharing a date are
+// ordered by legislative stage.
+func sortTextVersions(versions []congress.TextVersion) []congress.TextVersion {
+	ordered := make([]congress.TextVersion, len(versions))
+	for i, v := range versions {
+		ordered[len(versions)-1-i] = v
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		ti, okI := parseTextVersionDate(ordered[i].Date)
+		tj, okJ := parseTextVersionDate(ordered[j].Date)
+		switch {
+		case okI && okJ && !ti.Equal(tj):
+			return ti.Before(tj)
+		case okI && okJ:
+			return versioncode.Less(versioncode.FromType(ordered[i].Type), versioncode.FromType(ordered[j].Type))
+		case okI:
+			return true
+		default:
+			return false
+		}
+	})
+	return ordered
+}
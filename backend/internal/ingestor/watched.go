@@ -0,0 +1,66 @@
+package ingestor
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/drewjst/deltagov/internal/congress"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// RefreshWatchedBills refreshes the federal bills on any user's watchlist
+// from Congress.gov, least recently updated first, up to limit bills (all
+// of them when limit is 0). Bills whose update dates haven't changed are
+// left as they are, as in any other run.
+func (s *Service) RefreshWatchedBills(ctx context.Context, limit int) (*IngestResult, error) {
+	query := s.db.WithContext(ctx).Model(&models.Bill{}).
+		Select("bills.id", "bills.congress", "bills.bill_type", "bills.bill_number").
+		Where("bills.jurisdiction = ? AND bills.tenant_id = 0", models.JurisdictionFederal).
+		Where("EXISTS (SELECT 1 FROM watched_bills WHERE watched_bills.bill_id = bills.id)").
+		Order("bills.updated_at ASC, bills.id ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var bills []models.Bill
+	if err := query.Find(&bills).Error; err != nil {
+		return nil, fmt.Errorf("ingestor: failed to list watched bills: %w", err)
+	}
+
+	result := &IngestResult{BillsFetched: len(bills)}
+	for _, bill := range bills {
+		if err := ctx.Err(); err != nil {
+			return result, fmt.Errorf("ingestor: watched bill refresh stopped: %w", err)
+		}
+		detail, err := s.fetchStoredBill(ctx, &bill)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("bill %s-%d %d: %w",
+				bill.BillType, bill.Congress, bill.BillNumber, err))
+			continue
+		}
+		// Errors are recorded in result and the bill dead-lettered
+		_ = s.ingestOne(ctx, detail, result)
+	}
+
+	logging.FromContext(ctx).Info("watched bill refresh complete",
+		"bills", len(bills), "updated", result.BillsUpdated,
+		"versions", result.VersionsCreated, "errors", len(result.Errors))
+	return result, nil
+}
+
+// fetchStoredBill fetches the Congress.gov detail of a stored bill, keyed
+// exactly as stored so an upsert updates the same row.
+func (s *Service) fetchStoredBill(ctx context.Context, bill *models.Bill) (*congress.Bill, error) {
+	var detail *congress.BillDetail
+	err := withRateLimitRetry(ctx, func() error {
+		var err error
+		detail, err = s.congressClient.GetBillDetail(ctx, bill.Congress, bill.BillType, bill.Number)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bill detail: %w", err)
+	}
+	detail.Type, detail.Number = bill.BillType, strconv.Itoa(bill.BillNumber)
+	return &detail.Bill, nil
+}
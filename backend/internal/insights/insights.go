@@ -0,0 +1,263 @@
+// Package insights writes short, human-readable summaries of what changed
+// between two versions of a bill. Summaries come from a Summarizer: the
+// template summarizer describes sections added and removed and the largest
+// dollar changes; the LLM summarizer asks a language model to do the same
+// in prose, falling back to the template on failure.
+package insights
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/drewjst/deltagov/internal/analysis"
+	"github.com/drewjst/deltagov/internal/diff_engine"
+)
+
+// maxDollarChanges is how many dollar changes a template summary names.
+const maxDollarChanges = 3
+
+// Input is a computed delta and the texts it was computed over.
+type Input struct {
+	FromCode string // Version code, e.g., "IH"
+	ToCode   string
+	FromText string // Plain text, as diffed
+	ToText   string
+	Delta    *diff_engine.Delta
+}
+
+// Summarizer summarizes a delta. Implementations must be safe for
+// concurrent use.
+type Summarizer interface {
+	// Summarize returns a summary of a few sentences, and the name of the
+	// backend that wrote it (see Name).
+	Summarize(ctx context.Context, in Input) (summary, by string, err error)
+	// Name identifies the backend, e.g., "template" or "llm:gpt-4o-mini".
+	Name() string
+}
+
+// FromEnv returns the summarizer selected by INSIGHTS_SUMMARIZER:
+// "template" (the default), "llm" (configured by the INSIGHTS_LLM_*
+// variables; see NewLLM), or "none", which returns nil.
+func FromEnv() (Summarizer, error) {
+	switch kind := os.Getenv("INSIGHTS_SUMMARIZER"); kind {
+	case "", "template":
+		return Template{}, nil
+	case "none":
+		return nil, nil
+	case "llm":
+		llm, err := NewLLM(LLMConfig{
+			BaseURL: os.Getenv("INSIGHTS_LLM_URL"),
+			APIKey:  os.Getenv("INSIGHTS_LLM_API_KEY"),
+			Model:   os.Getenv("INSIGHTS_LLM_MODEL"),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return llm, nil
+	default:
+		return nil, fmt.Errorf("insights: unknown INSIGHTS_SUMMARIZER %q, want template, llm, or none", kind)
+	}
+}
+
+// Template summarizes deltas from the texts' structure alone: sections
+// added, removed, and amended, the largest dollar changes, and the line
+// counts of the diff.
+type Template struct{}
+
+// Name returns "template".
+func (Template) Name() string {
+	return "template"
+}
+
+// Summarize returns the template summary of a delta.
+func (t Template) Summarize(_ context.Context, in Input) (string, string, error) {
+	return Facts(in).String(), t.Name(), nil
+}
+
+// DeltaFacts are the structural changes a template summary describes.
+type DeltaFacts struct {
+	SectionsAdded   []analysis.Section
+	SectionsRemoved []analysis.Section
+	SectionsAmended []analysis.Section // As they read in the newer version
+	DollarChanges   []analysis.SpendingChange
+	Insertions      int
+	Deletions       int
+}
+
+// Facts extracts the structural changes of a delta. DollarChanges holds
+// the largest changes by absolute amount, at most maxDollarChanges.
+func Facts(in Input) DeltaFacts {
+	facts := DeltaFacts{}
+	if in.Delta != nil {
+		facts.Insertions, facts.Deletions = in.Delta.Insertions, in.Delta.Deletions
+	}
+
+	fromSections := make(map[string]analysis.Section)
+	for _, sec := range analysis.SplitSections(in.FromText) {
+		fromSections[sec.Number] = sec
+	}
+	seen := make(map[string]bool)
+	for _, sec := range analysis.SplitSections(in.ToText) {
+		seen[sec.Number] = true
+		prev, ok := fromSections[sec.Number]
+		switch {
+		case !ok:
+			facts.SectionsAdded = append(facts.SectionsAdded, sec)
+		case analysis.NormalizeForComparison(prev.Body) != analysis.NormalizeForComparison(sec.Body):
+			facts.SectionsAmended = append(facts.SectionsAmended, sec)
+		}
+	}
+	for _, sec := range analysis.SplitSections(in.FromText) {
+		if !seen[sec.Number] {
+			facts.SectionsRemoved = append(facts.SectionsRemoved, sec)
+		}
+	}
+
+	for _, c := range analysis.CompareSpending(analysis.ExtractSpendingItems(in.FromText), analysis.ExtractSpendingItems(in.ToText)) {
+		if c.Status != analysis.SpendingUnchanged && c.Change != 0 {
+			facts.DollarChanges = append(facts.DollarChanges, c)
+		}
+	}
+	sort.SliceStable(facts.DollarChanges, func(i, j int) bool {
+		return abs(facts.DollarChanges[i].Change) > abs(facts.DollarChanges[j].Change)
+	})
+	if len(facts.DollarChanges) > maxDollarChanges {
+		facts.DollarChanges = facts.DollarChanges[:maxDollarChanges]
+	}
+	return facts
+}
+
+// String renders the facts as a summary of a few sentences.
+func (f DeltaFacts) String() string {
+	if f.Insertions == 0 && f.Deletions == 0 {
+		return "No changes to the text."
+	}
+
+	var sentences []string
+	var parts []string
+	if n := len(f.SectionsAdded); n > 0 {
+		parts = append(parts, fmt.Sprintf("adds %s (%s)", plural(n, "section"), sectionList(f.SectionsAdded)))
+	}
+	if n := len(f.SectionsRemoved); n > 0 {
+		parts = append(parts, fmt.Sprintf("removes %s (%s)", plural(n, "section"), sectionList(f.SectionsRemoved)))
+	}
+	if n := len(f.SectionsAmended); n > 0 {
+		parts = append(parts, fmt.Sprintf("amends %s", plural(n, "section")))
+	}
+	if len(parts) > 0 {
+		sentences = append(sentences, capitalize(joinClauses(parts))+".")
+	}
+
+	if len(f.DollarChanges) > 0 {
+		changes := make([]string, len(f.DollarChanges))
+		for i, c := range f.DollarChanges {
+			changes[i] = dollarChange(c)
+		}
+		label := "Largest dollar changes"
+		if len(changes) == 1 {
+			label = "Dollar change"
+		}
+		sentences = append(sentences, label+": "+strings.Join(changes, "; ")+".")
+	}
+
+	sentences = append(sentences, fmt.Sprintf("%s added, %s removed.", plural(f.Insertions, "line"), plural(f.Deletions, "line")))
+	return strings.Join(sentences, " ")
+}
+
+// sectionList names up to three sections, e.g., "SEC. 5. REPORTS; SEC. 6".
+func sectionList(sections []analysis.Section) string {
+	const maxNamed = 3
+	names := make([]string, 0, maxNamed)
+	for _, sec := range sections[:min(len(sections), maxNamed)] {
+		name := "SEC. " + sec.Number
+		if sec.Heading != "" {
+			name += ". " + strings.TrimSuffix(sec.Heading, ".")
+		}
+		names = append(names, name)
+	}
+	if extra := len(sections) - maxNamed; extra > 0 {
+		names = append(names, fmt.Sprintf("%d more", extra))
+	}
+	return strings.Join(names, "; ")
+}
+
+// dollarChange describes one spending change, e.g., "Salaries and
+// Expenses (sec. 101) $1,000,000 to $1,500,000 (+$500,000)".
+func dollarChange(c analysis.SpendingChange) string {
+	where := c.Account
+	if where == "" {
+		where = "unlabeled amount"
+	}
+	if c.Section != "" {
+		where += " (sec. " + c.Section + ")"
+	}
+	switch c.Status {
+	case analysis.SpendingAdded:
+		return fmt.Sprintf("%s added at %s", where, FormatDollars(c.ToAmount))
+	case analysis.SpendingRemoved:
+		return fmt.Sprintf("%s of %s removed", where, FormatDollars(c.FromAmount))
+	default:
+		sign := "+"
+		if c.Change < 0 {
+			sign = "-"
+		}
+		return fmt.Sprintf("%s %s to %s (%s%s)", where,
+			FormatDollars(c.FromAmount), FormatDollars(c.ToAmount), sign, FormatDollars(abs(c.Change)))
+	}
+}
+
+// FormatDollars formats whole dollars with thousands separators, e.g.,
+// "$1,500,000".
+func FormatDollars(amount int64) string {
+	digits := strconv.FormatInt(abs(amount), 10)
+	var b strings.Builder
+	if amount < 0 {
+		b.WriteByte('-')
+	}
+	b.WriteByte('$')
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
+
+// joinClauses joins clauses as "a, b, and c".
+func joinClauses(parts []string) string {
+	switch len(parts) {
+	case 1:
+		return parts[0]
+	case 2:
+		return parts[0] + " and " + parts[1]
+	default:
+		return strings.Join(parts[:len(parts)-1], ", ") + ", and " + parts[len(parts)-1]
+	}
+}
+
+// plural formats a count and noun, e.g., "1 section" or "3 sections".
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return strconv.Itoa(n) + " " + noun + "s"
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+func abs(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
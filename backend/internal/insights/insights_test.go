@@ -0,0 +1,116 @@
+package insights_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/insights"
+)
+
+const fromText = `SECTION 1. SHORT TITLE.
+This Act may be cited as the "Example Act".
+SEC. 2. FUNDING.
+Salaries and Expenses
+For necessary expenses, $1,000,000.
+SEC. 3. REPORTS.
+The Secretary shall report annually.
+`
+
+const toText = `SECTION 1. SHORT TITLE.
+This Act may be cited as the "Example Act".
+SEC. 2. FUNDING.
+Salaries and Expenses
+For necessary expenses, $1,500,000.
+SEC. 4. OVERSIGHT.
+The Inspector General shall audit the program.
+`
+
+func input(t *testing.T) insights.Input {
+	t.Helper()
+	delta, err := diff_engine.ComputeWordLevel(fromText, toText)
+	if err != nil {
+		t.Fatalf("ComputeWordLevel: %v", err)
+	}
+	return insights.Input{FromCode: "IH", ToCode: "EH", FromText: fromText, ToText: toText, Delta: delta}
+}
+
+func TestTemplate(t *testing.T) {
+	summary, by, err := insights.Template{}.Summarize(context.Background(), input(t))
+	if err != nil || by != "template" {
+		t.Fatalf("Summarize = %q, %q, %v", summary, by, err)
+	}
+	for _, want := range []string{
+		"Adds 1 section (SEC. 4. OVERSIGHT)",
+		"removes 1 section (SEC. 3. REPORTS)",
+		"amends 1 section",
+		"Salaries and Expenses (sec. 2) $1,000,000 to $1,500,000 (+$500,000)",
+		"lines added",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary %q does not contain %q", summary, want)
+		}
+	}
+
+	if summary, _, _ := (insights.Template{}).Summarize(context.Background(), insights.Input{Delta: &diff_engine.Delta{}}); summary != "No changes to the text." {
+		t.Errorf("summary of an empty delta = %q", summary)
+	}
+}
+
+func TestFormatDollars(t *testing.T) {
+	for amount, want := range map[int64]string{0: "$0", 999: "$999", 1000: "$1,000", 1500000: "$1,500,000", -25000: "-$25,000"} {
+		if got := insights.FormatDollars(amount); got != want {
+			t.Errorf("FormatDollars(%d) = %q, want %q", amount, got, want)
+		}
+	}
+}
+
+func TestLLM(t *testing.T) {
+	fail := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat/completions" || r.Header.Get("Authorization") != "Bearer test-key" {
+			t.Errorf("unexpected request %s %s", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var req struct {
+			Model    string `json:"model"`
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Model != "test-model" || len(req.Messages) != 2 {
+			t.Errorf("request = %+v, %v", req, err)
+		} else if !strings.Contains(req.Messages[1].Content, "+ ") {
+			t.Errorf("prompt has no changed lines: %q", req.Messages[1].Content)
+		}
+		io.WriteString(w, `{"choices":[{"message":{"role":"assistant","content":" Funding rises by $500,000. "}}]}`)
+	}))
+	defer srv.Close()
+
+	llm, err := insights.NewLLM(insights.LLMConfig{BaseURL: srv.URL, APIKey: "test-key", Model: "test-model", HTTPClient: srv.Client()})
+	if err != nil {
+		t.Fatalf("NewLLM: %v", err)
+	}
+	summary, by, err := llm.Summarize(context.Background(), input(t))
+	if err != nil || summary != "Funding rises by $500,000." || by != "llm:test-model" {
+		t.Errorf("Summarize = %q, %q, %v", summary, by, err)
+	}
+
+	fail = true
+	summary, by, err = llm.Summarize(context.Background(), input(t))
+	if err != nil || by != "template" || !strings.HasPrefix(summary, "Adds 1 section") {
+		t.Errorf("Summarize when the LLM fails = %q, %q, %v; want the template summary", summary, by, err)
+	}
+
+	if _, err := insights.NewLLM(insights.LLMConfig{Model: "test-model"}); err == nil {
+		t.Error("NewLLM without an API key succeeded")
+	}
+}
@@ -0,0 +1,158 @@
+package insights
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/logging"
+)
+
+const (
+	defaultLLMURL     = "https://api.openai.com/v1"
+	defaultLLMTimeout = 60 * time.Second
+
+	// maxPromptChanges bounds the changed lines quoted in a prompt, so
+	// large deltas don't exceed the model's context.
+	maxPromptChanges = 200
+	maxPromptBytes   = 24 * 1024
+)
+
+const systemPrompt = "You summarize changes between two versions of a U.S. bill for a general audience. " +
+	"Write two to four plain sentences covering the most significant changes: sections added or removed, " +
+	"dollar amounts changed, and changes in what the bill requires. Do not speculate beyond the text given."
+
+// LLMConfig configures an LLM summarizer.
+type LLMConfig struct {
+	BaseURL    string       // OpenAI-compatible API base URL (default: OpenAI's)
+	APIKey     string       // Bearer token (required)
+	Model      string       // Model name, e.g., "gpt-4o-mini" (required)
+	HTTPClient *http.Client // Optional; defaults to one with a 60s timeout
+}
+
+// LLM summarizes deltas with a language model behind an OpenAI-compatible
+// chat completions API. The prompt holds the template facts and the
+// changed lines. When the model can't be reached, the template summary is
+// returned instead.
+type LLM struct {
+	cfg LLMConfig
+}
+
+// NewLLM creates an LLM summarizer.
+func NewLLM(cfg LLMConfig) (*LLM, error) {
+	if cfg.APIKey == "" || cfg.Model == "" {
+		return nil, errors.New("insights: the LLM summarizer requires an API key and a model")
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = defaultLLMURL
+	}
+	cfg.BaseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: defaultLLMTimeout}
+	}
+	return &LLM{cfg: cfg}, nil
+}
+
+// Name returns "llm:" and the model name.
+func (l *LLM) Name() string {
+	return "llm:" + l.cfg.Model
+}
+
+// Summarize asks the model for a summary, falling back to the template
+// summary when the request fails.
+func (l *LLM) Summarize(ctx context.Context, in Input) (string, string, error) {
+	facts := Facts(in)
+	summary, err := l.complete(ctx, prompt(in, facts))
+	if err != nil {
+		logging.FromContext(ctx).Warn("LLM summary failed, using template", "model", l.cfg.Model, "error", err)
+		return facts.String(), Template{}.Name(), nil
+	}
+	return summary, l.Name(), nil
+}
+
+// prompt builds the user message: the template facts, then the changed
+// lines as a unified diff body.
+func prompt(in Input, facts DeltaFacts) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Changes from version %s to version %s.\n\nOverview: %s\n\nChanged lines:\n",
+		in.FromCode, in.ToCode, facts.String())
+
+	quoted := 0
+	if in.Delta != nil {
+	hunks:
+		for _, hunk := range in.Delta.Hunks {
+			for _, line := range hunk.Lines {
+				var prefix string
+				switch line.Type {
+				case diff_engine.ChangeInsert:
+					prefix = "+ "
+				case diff_engine.ChangeDelete:
+					prefix = "- "
+				default:
+					continue
+				}
+				if quoted == maxPromptChanges || b.Len()+len(line.Content) > maxPromptBytes {
+					b.WriteString("[further changes omitted]\n")
+					break hunks
+				}
+				b.WriteString(prefix + strings.TrimSpace(line.Content) + "\n")
+				quoted++
+			}
+		}
+	}
+	return b.String()
+}
+
+// complete sends one chat completion request and returns the reply.
+func (l *LLM) complete(ctx context.Context, userPrompt string) (string, error) {
+	type message struct {
+		Role    string `json:"role"`
+		Content string `json:"content"`
+	}
+	body, err := json.Marshal(map[string]any{
+		"model": l.cfg.Model,
+		"messages": []message{
+			{Role: "system", Content: systemPrompt},
+			{Role: "user", Content: userPrompt},
+		},
+		"temperature": 0,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.cfg.BaseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("insights: failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+l.cfg.APIKey)
+
+	resp, err := l.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("insights: LLM request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("insights: LLM returned status %d", resp.StatusCode)
+	}
+
+	var reply struct {
+		Choices []struct {
+			Message message `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&reply); err != nil {
+		return "", fmt.Errorf("insights: failed to decode LLM reply: %w", err)
+	}
+	if len(reply.Choices) == 0 || strings.TrimSpace(reply.Choices[0].Message.Content) == "" {
+		return "", errors.New("insights: LLM returned no summary")
+	}
+	return strings.TrimSpace(reply.Choices[0].Message.Content), nil
+}
@@ -0,0 +1,314 @@
+// Package lineage links bills reintroduced from one Congress to the next.
+// Sponsors reintroduce bills that died each Congress under the same
+// title, often word for word; Match finds, for each federal bill, the
+// bills of the previous Congress it continues and stores the links in
+// bill_lineages, so a bill's history can be followed across Congresses
+// and its text diffed against where the last Congress left it.
+//
+// Bills are matched on three signals: the words their titles share,
+// whether they have the same sponsor, and the share of their sections
+// with the same language, from the fingerprints of package provisions.
+package lineage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/provisions"
+)
+
+const (
+	// DefaultMatchLimit is the number of bills Match matches per call
+	// when no limit is given.
+	DefaultMatchLimit = 200
+
+	// MinScore is the least Score for a bill of the previous Congress to
+	// be linked as a predecessor.
+	MinScore = 0.6
+
+	// maxCandidates bounds the bills of the previous Congress scored per
+	// bill; a prolific sponsor's bills are the largest set.
+	maxCandidates = 100
+
+	// maxPredecessors bounds the predecessors linked per bill.
+	maxPredecessors = 3
+)
+
+// Signals are how closely a bill matches one of the previous Congress.
+type Signals struct {
+	Title       float64  // TitleSimilarity of their titles
+	Text        *float64 // SectionSimilarity of their sections; nil when either has none fingerprinted
+	SameSponsor bool
+}
+
+// Score combines signals into a score from 0 to 1. Text weighs most when
+// both bills' sections are fingerprinted; without it, a bill matches on
+// its title and sponsor alone.
+func (s Signals) Score() float64 {
+	sponsor := 0.0
+	if s.SameSponsor {
+		sponsor = 1
+	}
+	if s.Text == nil {
+		return 0.7*s.Title + 0.3*sponsor
+	}
+	return 0.5**s.Text + 0.35*s.Title + 0.15*sponsor
+}
+
+// TitleSimilarity returns the share of distinct words two titles have in
+// common (their Jaccard similarity), ignoring case, punctuation, years,
+// and words every title has, so "Veterans Housing Act of 2023" and
+// "Veterans Housing Act of 2025" are the same title.
+func TitleSimilarity(a, b string) float64 {
+	wa, wb := titleWords(a), titleWords(b)
+	if len(wa) == 0 || len(wb) == 0 {
+		return 0
+	}
+	shared := 0
+	for w := range wa {
+		if wb[w] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wa)+len(wb)-shared)
+}
+
+// titleWords returns the distinct words of a title that tell it apart.
+func titleWords(title string) map[string]bool {
+	words := map[string]bool{}
+	for _, w := range strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if titleStopWords[w] || isYear(w) {
+			continue
+		}
+		words[w] = true
+	}
+	return words
+}
+
+// titleStopWords are words in too many titles to tell them apart.
+var titleStopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "of": true, "to": true, "and": true, "for": true, "in": true,
+	"on": true, "or": true, "with": true, "by": true, "act": true, "bill": true, "other": true, "purposes": true,
+}
+
+// isYear reports whether w is a year, as in "Act of 2025".
+func isYear(w string) bool {
+	return len(w) == 4 && (strings.HasPrefix(w, "19") || strings.HasPrefix(w, "20")) &&
+		strings.IndexFunc(w, func(r rune) bool { return !unicode.IsDigit(r) }) < 0
+}
+
+// SectionSimilarity returns the share of two texts' sections, given by
+// their fingerprints, that have a counterpart with the same language in
+// the other: 1 when every section of each appears in the other, whatever
+// the order.
+func SectionSimilarity(a, b []provisions.Fingerprint) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	return float64(matched(a, b)+matched(b, a)) / float64(len(a)+len(b))
+}
+
+// matched counts the sections of a with a counterpart in b.
+func matched(a, b []provisions.Fingerprint) int {
+	hashes := make(map[string]bool, len(b))
+	for _, fp := range b {
+		hashes[fp.Hash] = true
+	}
+	n := 0
+	for _, fa := range a {
+		if hashes[fa.Hash] {
+			n++
+			continue
+		}
+		for _, fb := range b {
+			if provisions.Similarity(fa, fb) >= provisions.DefaultThreshold {
+				n++
+				break
+			}
+		}
+	}
+	return n
+}
+
+// Matcher links public federal bills within scope to their predecessors.
+type Matcher struct {
+	db    *gorm.DB
+	scope func(*gorm.DB) *gorm.DB
+}
+
+// NewMatcher creates a Matcher. scope restricts the bills matched and
+// linked, such as scope.Rules.Query; nil matches every public bill.
+func NewMatcher(db *gorm.DB, scope func(*gorm.DB) *gorm.DB) *Matcher {
+	if scope == nil {
+		scope = func(db *gorm.DB) *gorm.DB { return db }
+	}
+	return &Matcher{db: db, scope: scope}
+}
+
+// Match matches up to limit bills (DefaultMatchLimit if zero or less)
+// never matched or updated since, most recently updated first, replacing
+// their links to predecessors. It returns how many bills it matched.
+func (m *Matcher) Match(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		limit = DefaultMatchLimit
+	}
+
+	var bills []models.Bill
+	if err := m.db.WithContext(ctx).Model(&models.Bill{}).
+		Select("bills.id", "bills.congress", "bills.title", "bills.sponsor").
+		Joins("LEFT JOIN lineage_states ON lineage_states.bill_id = bills.id").
+		Scopes(m.scope).
+		Where("bills.tenant_id = 0 AND bills.jurisdiction = 'federal'").
+		Where("lineage_states.bill_id IS NULL OR lineage_states.checked_at < bills.updated_at").
+		Order("bills.updated_at DESC").Limit(limit).Find(&bills).Error; err != nil {
+		return 0, fmt.Errorf("lineage: failed to list bills to match: %w", err)
+	}
+
+	linked := 0
+	for i := range bills {
+		n, err := m.matchBill(ctx, &bills[i])
+		if err != nil {
+			return i, err
+		}
+		linked += n
+	}
+	if len(bills) > 0 {
+		logging.FromContext(ctx).Info("matched bill lineage", "bills", len(bills), "links", linked)
+	}
+	return len(bills), nil
+}
+
+// matchBill replaces a bill's links to predecessors, returning how many
+// it linked.
+func (m *Matcher) matchBill(ctx context.Context, bill *models.Bill) (int, error) {
+	started := time.Now()
+	db := m.db.WithContext(ctx)
+
+	var candidates []models.Bill
+	if bill.Congress > 1 {
+		query := db.Model(&models.Bill{}).Select("bills.id", "bills.title", "bills.sponsor").
+			Scopes(m.scope).
+			Where("bills.tenant_id = 0 AND bills.jurisdiction = 'federal' AND bills.congress = ?", bill.Congress-1)
+		matches := db.Where("bills.id IN (?)", db.Model(&models.BillSponsorship{}).Select("bill_id").
+			Where("role = ? AND bioguide_id IN (?)", models.SponsorshipRoleSponsor,
+				db.Model(&models.BillSponsorship{}).Select("bioguide_id").
+					Where("bill_id = ? AND role = ?", bill.ID, models.SponsorshipRoleSponsor))).
+			Or("LOWER(bills.title) = LOWER(?)", bill.Title).
+			Or("bills.id IN (?)", db.Model(&models.Provision{}).Select("bill_id").
+				Where("text_hash IN (?)", db.Model(&models.Provision{}).Select("text_hash").Where("bill_id = ?", bill.ID)))
+		if bill.Sponsor != "" {
+			matches = matches.Or("bills.sponsor = ?", bill.Sponsor)
+		}
+		if err := query.Where(matches).Limit(maxCandidates).Find(&candidates).Error; err != nil {
+			return 0, fmt.Errorf("lineage: failed to find candidates for bill %d: %w", bill.ID, err)
+		}
+	}
+
+	var links []models.BillLineage
+	if len(candidates) > 0 {
+		scored, err := m.score(ctx, bill, candidates)
+		if err != nil {
+			return 0, err
+		}
+		links = scored
+	}
+
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("bill_id = ?", bill.ID).Delete(&models.BillLineage{}).Error; err != nil {
+			return fmt.Errorf("lineage: failed to clear links of bill %d: %w", bill.ID, err)
+		}
+		if len(links) > 0 {
+			if err := tx.Create(&links).Error; err != nil {
+				return fmt.Errorf("lineage: failed to store links of bill %d: %w", bill.ID, err)
+			}
+		}
+		state := models.LineageState{BillID: bill.ID, CheckedAt: started}
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "bill_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"checked_at"}),
+		}).Create(&state).Error; err != nil {
+			return fmt.Errorf("lineage: failed to record match of bill %d: %w", bill.ID, err)
+		}
+		return nil
+	})
+	return len(links), err
+}
+
+// score scores a bill against candidates of the previous Congress,
+// returning links to the best of those scoring at least MinScore.
+func (m *Matcher) score(ctx context.Context, bill *models.Bill, candidates []models.Bill) ([]models.BillLineage, error) {
+	db := m.db.WithContext(ctx)
+	ids := []uint{bill.ID}
+	for _, c := range candidates {
+		ids = append(ids, c.ID)
+	}
+
+	var sponsorships []models.BillSponsorship
+	if err := db.Select("bill_id", "bioguide_id").
+		Where("bill_id IN ? AND role = ?", ids, models.SponsorshipRoleSponsor).Find(&sponsorships).Error; err != nil {
+		return nil, fmt.Errorf("lineage: failed to load sponsors: %w", err)
+	}
+	sponsors := map[uint]string{}
+	for _, s := range sponsorships {
+		sponsors[s.BillID] = s.BioguideID
+	}
+
+	// The bill's first text, as introduced, against each candidate's last
+	var rows []models.Provision
+	if err := db.Select("bill_id", "version_id", "text_hash", "signature", "fetched_at").
+		Where("bill_id IN ?", ids).Order("fetched_at ASC, version_id ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("lineage: failed to load provisions: %w", err)
+	}
+	sections := map[uint][]provisions.Fingerprint{}
+	versions := map[uint]uint{}
+	for _, p := range rows {
+		if v, seen := versions[p.BillID]; seen && v != p.VersionID {
+			if p.BillID == bill.ID {
+				continue
+			}
+			sections[p.BillID] = nil
+		}
+		versions[p.BillID] = p.VersionID
+		sections[p.BillID] = append(sections[p.BillID], provisions.Fingerprint{Hash: p.TextHash, Signature: provisions.DecodeSignature(p.Signature)})
+	}
+
+	var links []models.BillLineage
+	for _, c := range candidates {
+		signals := Signals{Title: TitleSimilarity(bill.Title, c.Title)}
+		if a, b := sponsors[bill.ID], sponsors[c.ID]; a != "" && b != "" {
+			signals.SameSponsor = a == b
+		} else {
+			signals.SameSponsor = bill.Sponsor != "" && bill.Sponsor == c.Sponsor
+		}
+		if a, b := sections[bill.ID], sections[c.ID]; len(a) > 0 && len(b) > 0 {
+			text := SectionSimilarity(a, b)
+			signals.Text = &text
+		}
+		if score := signals.Score(); score >= MinScore {
+			links = append(links, models.BillLineage{
+				BillID:          bill.ID,
+				PredecessorID:   c.ID,
+				Score:           score,
+				TitleSimilarity: signals.Title,
+				TextSimilarity:  signals.Text,
+				SameSponsor:     signals.SameSponsor,
+			})
+		}
+	}
+	sort.SliceStable(links, func(i, j int) bool { return links[i].Score > links[j].Score })
+	if len(links) > maxPredecessors {
+		links = links[:maxPredecessors]
+	}
+	return links, nil
+}
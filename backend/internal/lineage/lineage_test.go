@@ -0,0 +1,76 @@
+package lineage_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/lineage"
+	"github.com/drewjst/deltagov/internal/provisions"
+)
+
+func TestTitleSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		min, max float64
+	}{
+		{"Veterans Housing Stability Act of 2023", "Veterans Housing Stability Act of 2025", 1, 1},
+		{"To amend title 38, United States Code, to expand housing grants for veterans, and for other purposes.",
+			"To amend title 38, United States Code, to expand housing grants for disabled veterans, and for other purposes.", 0.8, 0.95},
+		{"Veterans Housing Stability Act of 2023", "Rural Broadband Mapping Act of 2025", 0, 0},
+		{"", "Rural Broadband Mapping Act", 0, 0},
+	}
+	for _, tt := range tests {
+		if got := lineage.TitleSimilarity(tt.a, tt.b); got < tt.min || got > tt.max {
+			t.Errorf("TitleSimilarity(%q, %q) = %.2f, want %.2f to %.2f", tt.a, tt.b, got, tt.min, tt.max)
+		}
+	}
+}
+
+func TestSectionSimilarity(t *testing.T) {
+	sections := []string{
+		"The Secretary of Veterans Affairs shall establish a program to award grants to eligible entities to provide housing stability services to veterans at risk of homelessness, including rental assistance and case management.",
+		"Not later than one year after the date of the enactment of this Act, and annually thereafter, the Secretary shall submit to the appropriate congressional committees a report on the grants awarded under this section.",
+		"There is authorized to be appropriated to carry out this Act $50,000,000 for each of fiscal years 2026 through 2030, to remain available until expended for the purposes described in this Act.",
+	}
+	fingerprints := func(texts ...string) []provisions.Fingerprint {
+		out := make([]provisions.Fingerprint, len(texts))
+		for i, text := range texts {
+			out[i] = provisions.NewFingerprint(text)
+		}
+		return out
+	}
+
+	original := fingerprints(sections...)
+	reordered := fingerprints(sections[2], strings.ToUpper(sections[0]), sections[1])
+	if got := lineage.SectionSimilarity(original, reordered); got != 1 {
+		t.Errorf("reordered sections: SectionSimilarity = %.2f, want 1", got)
+	}
+	// One section dropped and another added: two of three in common each way
+	revised := fingerprints(sections[0], sections[1], "The Comptroller General of the United States shall conduct a study on the effectiveness of the grants awarded under this Act in reducing homelessness among veterans, and report the results to Congress.")
+	if got := lineage.SectionSimilarity(original, revised); got < 0.66 || got > 0.67 {
+		t.Errorf("revised sections: SectionSimilarity = %.2f, want 2/3", got)
+	}
+	if got := lineage.SectionSimilarity(original, nil); got != 0 {
+		t.Errorf("no sections: SectionSimilarity = %.2f, want 0", got)
+	}
+}
+
+func TestScore(t *testing.T) {
+	same, unrelated := 1.0, 0.0
+	tests := []struct {
+		name    string
+		signals lineage.Signals
+		linked  bool
+	}{
+		{"reintroduced verbatim", lineage.Signals{Title: 1, Text: &same, SameSponsor: true}, true},
+		{"same title, not yet fingerprinted", lineage.Signals{Title: 1}, true},
+		{"same text, new title and sponsor", lineage.Signals{Title: 0.3, Text: &same}, true},
+		{"same sponsor, different bill", lineage.Signals{Title: 0.1, Text: &unrelated, SameSponsor: true}, false},
+		{"same sponsor, unrelated title", lineage.Signals{Title: 0.2, SameSponsor: true}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.signals.Score() >= lineage.MinScore; got != tt.linked {
+			t.Errorf("%s: Score = %.2f, linked = %v, want %v", tt.name, tt.signals.Score(), got, tt.linked)
+		}
+	}
+}
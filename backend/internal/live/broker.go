@@ -0,0 +1,142 @@
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/drewjst/deltagov/internal/logging"
+)
+
+const (
+	// subscriberBuffer is how many events a subscriber may fall behind
+	// before further events are dropped for it.
+	subscriberBuffer = 64
+
+	// reconnectDelay is the wait before re-establishing a lost LISTEN connection.
+	reconnectDelay = 5 * time.Second
+)
+
+// Broker fans events out to subscribers. It is safe for concurrent use.
+type Broker struct {
+	mu     sync.Mutex
+	subs   map[*subscriber]struct{}
+	closed bool
+}
+
+type subscriber struct {
+	filter Filter
+	events chan Event
+}
+
+// NewBroker creates a Broker with no subscribers.
+func NewBroker() *Broker {
+	return &Broker{subs: make(map[*subscriber]struct{})}
+}
+
+// Subscribe returns a channel of the events matching filter and a function
+// that unsubscribes. The channel is closed on unsubscribe or when the
+// broker closes. A subscriber that falls behind misses events rather than
+// stalling the others.
+func (b *Broker) Subscribe(filter Filter) (<-chan Event, func()) {
+	sub := &subscriber{filter: filter, events: make(chan Event, subscriberBuffer)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(sub.events)
+		return sub.events, func() {}
+	}
+	b.subs[sub] = struct{}{}
+
+	var once sync.Once
+	return sub.events, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if _, ok := b.subs[sub]; ok {
+				delete(b.subs, sub)
+				close(sub.events)
+			}
+		})
+	}
+}
+
+// Broadcast delivers an event to every matching subscriber.
+func (b *Broker) Broadcast(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subs {
+		if !sub.filter.Matches(event) {
+			continue
+		}
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+}
+
+// Close closes every subscriber's channel, ending their streams, and
+// rejects new subscriptions.
+func (b *Broker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for sub := range b.subs {
+		close(sub.events)
+		delete(b.subs, sub)
+	}
+}
+
+// Listen LISTENs on Channel and broadcasts each event published to it,
+// reconnecting after connection failures, until ctx is canceled.
+func (b *Broker) Listen(ctx context.Context, databaseURL string) {
+	log := logging.FromContext(ctx)
+	for {
+		err := b.listen(ctx, databaseURL)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Warn("live event listener disconnected, reconnecting", "error", err, "delay", reconnectDelay.String())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// listen holds one LISTEN connection until it fails or ctx is canceled.
+func (b *Broker) listen(ctx context.Context, databaseURL string) error {
+	conn, err := pgx.Connect(ctx, databaseURL)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{Channel}.Sanitize()); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info("listening for live bill events", "channel", Channel)
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		var event Event
+		if err := json.Unmarshal([]byte(notification.Payload), &event); err != nil {
+			logging.FromContext(ctx).Warn("ignoring malformed live event", "error", err)
+			continue
+		}
+		b.Broadcast(event)
+	}
+}
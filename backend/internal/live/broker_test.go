@@ -0,0 +1,41 @@
+package live_test
+
+import (
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/live"
+)
+
+// TestBroker_Filters verifies subscribers only receive matching events and
+// that unsubscribing and closing end their streams.
+func TestBroker_Filters(t *testing.T) {
+	broker := live.NewBroker()
+	all, unsubscribeAll := broker.Subscribe(live.Filter{})
+	house, _ := broker.Subscribe(live.Filter{Congress: 119, BillType: "hr"})
+
+	broker.Broadcast(live.Event{Type: live.EventBillUpdated, BillID: 1, Congress: 119, BillType: "HR"})
+	broker.Broadcast(live.Event{Type: live.EventBillUpdated, BillID: 2, Congress: 119, BillType: "S"})
+
+	if got := len(all); got != 2 {
+		t.Errorf("unfiltered subscriber got %d events, want 2", got)
+	}
+	if got := len(house); got != 1 {
+		t.Fatalf("filtered subscriber got %d events, want 1", got)
+	}
+	if e := <-house; e.BillID != 1 {
+		t.Errorf("filtered subscriber got bill %d, want 1", e.BillID)
+	}
+
+	unsubscribeAll()
+	<-all
+	<-all
+	if _, ok := <-all; ok {
+		t.Error("expected channel to close on unsubscribe")
+	}
+
+	broker.Close()
+	if _, ok := <-house; ok {
+		t.Error("expected channel to close when the broker closes")
+	}
+	unsubscribeAll() // Safe to call again
+}
@@ -0,0 +1,70 @@
+// Package live carries bill update notifications from the ingestor to API
+// clients. The ingestor publishes events with Postgres NOTIFY; the API's
+// Broker LISTENs for them and fans them out to subscribers, such as the
+// Server-Sent Events endpoint.
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Channel is the Postgres NOTIFY channel events are published on.
+const Channel = "deltagov_bill_updates"
+
+// Event types.
+const (
+	EventBillCreated    = "bill_created"
+	EventBillUpdated    = "bill_updated"
+	EventVersionCreated = "version_created"
+)
+
+// Event is a change the ingestor made to a bill or its versions.
+type Event struct {
+	Type        string    `json:"type"`
+	BillID      uint      `json:"billId"`
+	Congress    int       `json:"congress"`
+	BillType    string    `json:"billType"`
+	Number      string    `json:"number"`
+	BillNumber  int       `json:"billNumber"`
+	Title       string    `json:"title,omitempty"`
+	VersionID   uint      `json:"versionId,omitempty"`   // Set for version_created
+	VersionCode string    `json:"versionCode,omitempty"` // Set for version_created
+	OccurredAt  time.Time `json:"occurredAt"`
+}
+
+// Publish sends an event to listening API instances. Delivery is best
+// effort: events published while no API is listening are lost.
+func Publish(ctx context.Context, db *gorm.DB, event Event) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("live: failed to encode event: %w", err)
+	}
+	if err := db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", Channel, string(payload)).Error; err != nil {
+		return fmt.Errorf("live: failed to publish event: %w", err)
+	}
+	return nil
+}
+
+// Filter selects the events a subscriber receives. Zero fields match any
+// value.
+type Filter struct {
+	Congress int
+	BillType string // Case-insensitive
+	BillID   uint
+}
+
+// Matches reports whether an event passes the filter.
+func (f Filter) Matches(e Event) bool {
+	return (f.Congress == 0 || f.Congress == e.Congress) &&
+		(f.BillType == "" || strings.EqualFold(f.BillType, e.BillType)) &&
+		(f.BillID == 0 || f.BillID == e.BillID)
+}
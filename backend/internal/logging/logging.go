@@ -0,0 +1,103 @@
+// Package logging configures structured slog output and carries request
+// correlation IDs through context.Context so log lines from the API,
+// BillService, and the congress client can be joined on a single ID.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+type contextKey struct{}
+
+// RequestIDKey is the log attribute name used for correlation IDs.
+const RequestIDKey = "request_id"
+
+// Setup installs the default slog logger from environment variables:
+//
+//	LOG_LEVEL   debug, info (default), warn, or error
+//	LOG_FORMAT  json (default, Cloud Logging compatible) or text
+//
+// The standard library log package is redirected to the same handler.
+func Setup() *slog.Logger {
+	logger := New(os.Stdout, os.Getenv("LOG_LEVEL"), os.Getenv("LOG_FORMAT"))
+	slog.SetDefault(logger)
+	return logger
+}
+
+// New builds a logger writing to w at the given level and format.
+func New(w io.Writer, level, format string) *slog.Logger {
+	opts := &slog.HandlerOptions{
+		Level: parseLevel(level),
+	}
+
+	if strings.EqualFold(format, "text") {
+		return slog.New(slog.NewTextHandler(w, opts))
+	}
+
+	// Cloud Logging reads "severity" and "message" from structured JSON
+	opts.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+		if len(groups) > 0 {
+			return a
+		}
+		switch a.Key {
+		case slog.LevelKey:
+			a.Key = "severity"
+		case slog.MessageKey:
+			a.Key = "message"
+		}
+		return a
+	}
+	return slog.New(slog.NewJSONHandler(w, opts))
+}
+
+// parseLevel converts a LOG_LEVEL value to a slog.Level, defaulting to info.
+func parseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// NewRequestID returns a random 16-byte hex correlation ID.
+func NewRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b[:])
+}
+
+// WithRequestID returns a context carrying the given correlation ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// RequestID returns the correlation ID carried by ctx, or "".
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// FromContext returns the default logger annotated with the correlation ID
+// carried by ctx, if any.
+func FromContext(ctx context.Context) *slog.Logger {
+	if id := RequestID(ctx); id != "" {
+		return slog.Default().With(RequestIDKey, id)
+	}
+	return slog.Default()
+}
@@ -0,0 +1,52 @@
+package logging
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// RequestIDHeader is the header used to accept and echo correlation IDs.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds client-supplied IDs so they can't bloat logs.
+const maxRequestIDLength = 128
+
+// Middleware assigns each request a correlation ID (reusing a client-supplied
+// X-Request-ID when present), stores it in the request's user context for
+// handlers, echoes it in the response, and logs one line per request.
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		id := c.Get(RequestIDHeader)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = NewRequestID()
+		}
+		c.Set(RequestIDHeader, id)
+		c.SetUserContext(WithRequestID(c.UserContext(), id))
+
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if fe, ok := err.(*fiber.Error); ok {
+			status = fe.Code
+		}
+
+		level := slog.LevelInfo
+		if status >= 500 {
+			level = slog.LevelError
+		}
+		slog.Default().Log(c.UserContext(), level, "request",
+			RequestIDKey, id,
+			"method", c.Method(),
+			"path", c.Path(),
+			"status", status,
+			"latency_ms", time.Since(start).Milliseconds(),
+			"ip", c.IP(),
+		)
+
+		return err
+	}
+}
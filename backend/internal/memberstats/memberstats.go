@@ -0,0 +1,106 @@
+// Package memberstats aggregates members' records as bill sponsors: bills
+// sponsored and enacted per Congress, how much their bills' text changed
+// between versions, and the policy areas they sponsor in. Aggregates are
+// stored in the member_congress_stats and member_policy_areas tables,
+// which Run rebuilds, so the API can serve them without scanning every
+// sponsorship and delta.
+package memberstats
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// batchSize is the number of rows inserted per statement.
+const batchSize = 500
+
+// Run recomputes every member's aggregates and replaces the stored ones,
+// returning how many members sponsored a bill.
+func Run(ctx context.Context, db *gorm.DB) (int, error) {
+	now := time.Now()
+	db = db.WithContext(ctx)
+
+	// A bill's churn is the lines changed between each pair of consecutive
+	// versions, the pairs the precompute queue caches. Enacted matches
+	// versioncode.IsEnacted: enrolled or public law text.
+	var stats []models.MemberCongressStats
+	if err := db.Raw(`
+		WITH ordered AS (
+			SELECT id, bill_id,
+				LAG(id) OVER (PARTITION BY bill_id ORDER BY fetched_at, id) AS prev_id
+			FROM versions
+			WHERE document_id IS NULL
+		), churn AS (
+			SELECT o.bill_id, SUM(d.lines) AS lines
+			FROM ordered o
+			JOIN LATERAL (
+				SELECT insertions + deletions AS lines FROM deltas
+				WHERE version_a_id = o.prev_id AND version_b_id = o.id
+				ORDER BY computed_at DESC LIMIT 1
+			) d ON true
+			GROUP BY o.bill_id
+		)
+		SELECT s.bioguide_id, b.congress,
+			COUNT(*) AS bills_sponsored,
+			COUNT(*) FILTER (WHERE b.public_law_number <> '' OR EXISTS (
+				SELECT 1 FROM versions v WHERE v.bill_id = b.id AND v.version_code IN ('ENR', 'PL')
+			)) AS bills_enacted,
+			COUNT(c.bill_id) AS bills_with_changes,
+			COALESCE(SUM(c.lines), 0) AS lines_changed
+		FROM bill_sponsorships s
+		JOIN bills b ON b.id = s.bill_id
+		LEFT JOIN churn c ON c.bill_id = b.id
+		WHERE s.role = ?
+		GROUP BY s.bioguide_id, b.congress`, models.SponsorshipRoleSponsor).Scan(&stats).Error; err != nil {
+		return 0, fmt.Errorf("memberstats: failed to aggregate sponsorships: %w", err)
+	}
+
+	var areas []models.MemberPolicyArea
+	if err := db.Model(&models.BillSponsorship{}).
+		Select("bill_sponsorships.bioguide_id, bills.policy_area, COUNT(*) AS bills").
+		Joins("JOIN bills ON bills.id = bill_sponsorships.bill_id").
+		Where("bill_sponsorships.role = ? AND bills.policy_area <> ''", models.SponsorshipRoleSponsor).
+		Group("bill_sponsorships.bioguide_id, bills.policy_area").
+		Scan(&areas).Error; err != nil {
+		return 0, fmt.Errorf("memberstats: failed to aggregate policy areas: %w", err)
+	}
+
+	members := make(map[string]bool, len(stats))
+	for i := range stats {
+		stats[i].ComputedAt = now
+		members[stats[i].BioguideID] = true
+	}
+	for i := range areas {
+		areas[i].ComputedAt = now
+	}
+
+	// Replaced in one transaction so readers never see partial aggregates
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.MemberCongressStats{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("1 = 1").Delete(&models.MemberPolicyArea{}).Error; err != nil {
+			return err
+		}
+		if len(stats) > 0 {
+			if err := tx.CreateInBatches(stats, batchSize).Error; err != nil {
+				return err
+			}
+		}
+		if len(areas) > 0 {
+			return tx.CreateInBatches(areas, batchSize).Error
+		}
+		return nil
+	}); err != nil {
+		return 0, fmt.Errorf("memberstats: failed to store aggregates: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("member stats updated", "members", len(members))
+	return len(members), nil
+}
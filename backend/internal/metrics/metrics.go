@@ -0,0 +1,229 @@
+// Package metrics defines the Prometheus collectors exported by DeltaGov
+// binaries and helpers to instrument the congress client, ingestor, diff
+// engine, and database.
+package metrics
+
+import (
+	"net/http"
+	"regexp"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "deltagov"
+
+var (
+	// CongressRequests counts Congress.gov API requests by endpoint and status code.
+	CongressRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "congress",
+		Name:      "requests_total",
+		Help:      "Congress.gov API requests by endpoint and HTTP status.",
+	}, []string{"endpoint", "status"})
+
+	// CongressRequestDuration tracks Congress.gov API latency by endpoint.
+	CongressRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "congress",
+		Name:      "request_duration_seconds",
+		Help:      "Congress.gov API request latency.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"endpoint"})
+
+	// CongressRateLimited counts 429 responses from Congress.gov.
+	CongressRateLimited = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "congress",
+		Name:      "rate_limited_total",
+		Help:      "Congress.gov API responses with status 429.",
+	})
+
+	// CongressSchemaDrift counts Congress.gov responses with a field the
+	// client doesn't decode ("unknown") or expects but didn't get
+	// ("missing"), by endpoint and field. Only counted in strict mode
+	// (see congress.WithStrictDecoding).
+	CongressSchemaDrift = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "congress",
+		Name:      "schema_drift_total",
+		Help:      "Congress.gov API responses with a field unknown to or missing for the client.",
+	}, []string{"endpoint", "kind", "field"})
+
+	// IngestBills counts bills processed by the ingestor by outcome
+	// ("created", "updated", "unchanged", "skipped", "error").
+	IngestBills = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "ingest",
+		Name:      "bills_total",
+		Help:      "Bills processed by the ingestor by outcome.",
+	}, []string{"outcome"})
+
+	// IngestVersionsCreated counts new versions stored by the ingestor.
+	IngestVersionsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "ingest",
+		Name:      "versions_created_total",
+		Help:      "New bill versions stored by the ingestor.",
+	})
+
+	// IngestTextSkipped counts bills whose text fetch was skipped because
+	// updateDateIncludingText hadn't advanced.
+	IngestTextSkipped = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "ingest",
+		Name:      "text_fetches_skipped_total",
+		Help:      "Bills whose text fetch was skipped because their text was unchanged.",
+	})
+
+	// VersionsArchived counts versions whose text was moved to compressed
+	// archive storage.
+	VersionsArchived = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "archive",
+		Name:      "versions_total",
+		Help:      "Versions whose text was archived.",
+	})
+
+	// IngestRunDuration tracks ingestion run duration by mode and status.
+	IngestRunDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "ingest",
+		Name:      "run_duration_seconds",
+		Help:      "Ingestion run duration.",
+		Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600},
+	}, []string{"mode", "status"})
+
+	// IngestLastSuccess is the Unix time of the last successful ingestion run.
+	// Alert when time() - this exceeds a few poll intervals.
+	IngestLastSuccess = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "ingest",
+		Name:      "last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful ingestion run.",
+	})
+
+	// SearchIndexQueries counts bill searches answered from the in-memory
+	// search index rather than the database.
+	SearchIndexQueries = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "search_index",
+		Name:      "queries_total",
+		Help:      "Bill searches answered from the in-memory search index.",
+	})
+
+	// SearchBackendDocuments counts documents indexed into the search
+	// backend by kind ("bills", "versions", "actions").
+	SearchBackendDocuments = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "search_backend",
+		Name:      "documents_indexed_total",
+		Help:      "Documents indexed into the search backend by kind.",
+	}, []string{"kind"})
+
+	// ScheduleRuns counts scheduled ingestion runs by priority ("watched",
+	// "appropriations", "recent") and status ("success", "error", "skipped").
+	ScheduleRuns = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "schedule",
+		Name:      "runs_total",
+		Help:      "Scheduled ingestion runs by priority and status.",
+	}, []string{"priority", "status"})
+
+	// ScheduleRequests counts Congress.gov API requests spent by scheduled
+	// runs, by priority.
+	ScheduleRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "schedule",
+		Name:      "requests_total",
+		Help:      "Congress.gov API requests spent by scheduled runs by priority.",
+	}, []string{"priority"})
+
+	// ScheduleBudgetRemaining is the number of Congress.gov API requests each
+	// priority may still spend in the current hour.
+	ScheduleBudgetRemaining = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "schedule",
+		Name:      "budget_remaining",
+		Help:      "Congress.gov API requests each priority may still spend this hour.",
+	}, []string{"priority"})
+
+	// ScheduleLag tracks how late scheduled runs start after they fall due,
+	// by priority. Sustained lag on a low priority means it is being starved.
+	ScheduleLag = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "schedule",
+		Name:      "lag_seconds",
+		Help:      "Delay between a scheduled run falling due and starting.",
+		Buckets:   []float64{1, 10, 30, 60, 300, 600, 1800, 3600, 7200},
+	}, []string{"priority"})
+
+	// ReconcileDriftRate is the share of bills the last reconciliation run
+	// checked that had drifted from Congress.gov. Alert when it stays above
+	// the run's threshold.
+	ReconcileDriftRate = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "reconcile",
+		Name:      "drift_ratio",
+		Help:      "Share of bills checked by the last reconciliation run that drifted from Congress.gov.",
+	})
+
+	// DiffComputations counts diffs by source ("computed", "cached", "precomputed", "recomputed", "adhoc", "preprocessed").
+	DiffComputations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "diff",
+		Name:      "computations_total",
+		Help:      "Diffs by result source.",
+	}, []string{"source"})
+
+	// DiffDuration tracks diff engine computation time.
+	DiffDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "diff",
+		Name:      "duration_seconds",
+		Help:      "Diff engine computation time.",
+		Buckets:   []float64{.005, .01, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	})
+
+	// DBQueryDuration tracks database statement latency by operation.
+	DBQueryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "query_duration_seconds",
+		Help:      "Database statement latency by operation.",
+		Buckets:   []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"operation"})
+
+	// DBSlowQueries counts statements slower than the slow query threshold
+	// (see database.SetSlowQueryThreshold).
+	DBSlowQueries = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "slow_queries_total",
+		Help:      "Database statements slower than the slow query threshold.",
+	})
+
+	// DBReconnects counts times the database became reachable again after
+	// a process found it unreachable.
+	DBReconnects = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "reconnects_total",
+		Help:      "Times the database became reachable again after being unreachable.",
+	})
+)
+
+// Handler returns an HTTP handler serving all registered metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// numericSegment matches path segments that are identifiers rather than routes.
+var numericSegment = regexp.MustCompile(`/\d+`)
+
+// EndpointLabel collapses numeric path segments so URL paths can be used as
+// low-cardinality labels, e.g. "/bill/119/hr/1/text" -> "/bill/:n/hr/:n/text".
+func EndpointLabel(path string) string {
+	return numericSegment.ReplaceAllString(path, "/:n")
+}
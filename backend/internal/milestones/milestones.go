@@ -0,0 +1,113 @@
+// Package milestones derives the dates ahead of a bill from what is known
+// about it: committee markups and floor consideration scheduled in its
+// latest actions, the day a continuing resolution's funding runs out, and
+// the start of the fiscal year an appropriations bill funds.
+package milestones
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/appropriations"
+)
+
+// Kind is the kind of a milestone.
+type Kind string
+
+// Kinds of milestones.
+const (
+	Markup   Kind = "markup"   // A committee markup
+	Floor    Kind = "floor"    // Floor consideration or a vote
+	Deadline Kind = "deadline" // Funding running out: a CR expiring or a fiscal year starting
+)
+
+// Milestone is a day something is expected to happen to a bill.
+type Milestone struct {
+	Kind    Kind
+	Date    time.Time // The day, at midnight UTC
+	Summary string    // e.g., "Committee markup"
+	Detail  string    // The action or provision it was derived from
+}
+
+// actionKinds classifies an action by words in its text, first match wins.
+var actionKinds = []struct {
+	kind    Kind
+	summary string
+	words   []string
+}{
+	{Markup, "Committee markup", []string{"mark-up", "markup", "mark up"}},
+	{Floor, "Floor consideration", []string{"consideration", "considered", "vote", "cloture", "floor", "debate", "proceed"}},
+}
+
+// FromAction returns the markups and floor consideration an action taken
+// at the given time schedules: dates after its day in the text of an
+// action about a markup or floor consideration, such as "By unanimous
+// consent agreement, the Senate will vote on passage on March 5, 2025."
+func FromAction(text string, at time.Time) []Milestone {
+	lower := strings.ToLower(text)
+	var kind Kind
+	var summary string
+	for _, k := range actionKinds {
+		for _, w := range k.words {
+			if strings.Contains(lower, w) {
+				kind, summary = k.kind, k.summary
+				break
+			}
+		}
+		if kind != "" {
+			break
+		}
+	}
+	if kind == "" {
+		return nil
+	}
+
+	day := truncateDay(at)
+	var out []Milestone
+	seen := map[time.Time]bool{}
+	for _, m := range appropriations.DateRe.FindAllStringSubmatch(text, -1) {
+		date, ok := appropriations.ParseDate(m[1:])
+		if !ok || !date.After(day) || seen[date] {
+			continue
+		}
+		seen[date] = true
+		out = append(out, Milestone{Kind: kind, Date: date, Summary: summary, Detail: text})
+	}
+	return out
+}
+
+// Funding returns the deadline of an appropriations bill. For a
+// continuing resolution it is the day funding runs out, read from text
+// (the resolution's text); otherwise it is the first day of the fiscal
+// year the title names, when funding lapses if the bill isn't enacted.
+// It reports false when there's neither.
+func Funding(title string, subjects []string, text string) (Milestone, bool) {
+	if appropriations.IsContinuing(title, subjects) {
+		if date, ok := appropriations.Expiration(text); ok {
+			return Milestone{
+				Kind:    Deadline,
+				Date:    date,
+				Summary: "Continuing resolution expires",
+				Detail:  "Funding continues until " + date.Format("January 2, 2006") + ".",
+			}, true
+		}
+	}
+	year := appropriations.FiscalYear(title)
+	if year == 0 {
+		return Milestone{}, false
+	}
+	start := appropriations.FiscalYearStart(year)
+	return Milestone{
+		Kind:    Deadline,
+		Date:    start,
+		Summary: fmt.Sprintf("Fiscal year %d begins", year),
+		Detail:  fmt.Sprintf("Appropriations for fiscal year %d lapse if not enacted by %s.", year, start.Format("January 2, 2006")),
+	}, true
+}
+
+// truncateDay returns the day of t, at midnight UTC.
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
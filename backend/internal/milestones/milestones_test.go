@@ -0,0 +1,67 @@
+package milestones_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/milestones"
+)
+
+func day(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestFromAction(t *testing.T) {
+	at := time.Date(2025, 3, 3, 18, 30, 0, 0, time.UTC)
+	tests := []struct {
+		text string
+		want []milestones.Milestone
+	}{
+		{
+			text: "By unanimous consent agreement, the Senate will vote on passage of the bill on March 5, 2025.",
+			want: []milestones.Milestone{{Kind: milestones.Floor, Date: day(2025, 3, 5), Summary: "Floor consideration"}},
+		},
+		{
+			text: "Committee on Ways and Means. Mark-up session scheduled for Mar. 12, 2025.",
+			want: []milestones.Milestone{{Kind: milestones.Markup, Date: day(2025, 3, 12), Summary: "Committee markup"}},
+		},
+		// Dates on or before the action's day aren't scheduled
+		{text: "Considered under suspension of the rules on March 3, 2025."},
+		// Actions about anything else aren't milestones
+		{text: "Referred to the Committee on Appropriations on March 10, 2025."},
+	}
+	for _, tt := range tests {
+		got := milestones.FromAction(tt.text, at)
+		if len(got) != len(tt.want) {
+			t.Errorf("FromAction(%q) = %+v, want %+v", tt.text, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i].Kind != tt.want[i].Kind || !got[i].Date.Equal(tt.want[i].Date) || got[i].Summary != tt.want[i].Summary {
+				t.Errorf("FromAction(%q)[%d] = %+v, want %+v", tt.text, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestFunding(t *testing.T) {
+	cr := "SEC. 106. Unless otherwise provided for in this Act, appropriations and funds made available shall be available until whichever of the following first occurs: (1) the enactment into law of an appropriation for any project or activity provided for in this Act; (2) the enactment into law of the applicable appropriations Act for fiscal year 2026 without any provision for such project or activity; or (3) November 21, 2025.\n" +
+		"SEC. 120. Amounts made available by section 101 may be apportioned through November 21, 2025, notwithstanding section 1513 of title 31, or until December 31, 2025 for the census."
+	tests := []struct {
+		name, title, text string
+		want              time.Time
+		summary           string
+		ok                bool
+	}{
+		{"continuing resolution", "Continuing Appropriations and Extensions Act, 2026", cr, day(2025, 11, 21), "Continuing resolution expires", true},
+		{"regular bill", "Making appropriations for the Department of Defense for the fiscal year ending September 30, 2026, and for other purposes.", "", day(2025, 10, 1), "Fiscal year 2026 begins", true},
+		{"continuing resolution without text", "Making continuing appropriations for fiscal year 2026", "", day(2025, 10, 1), "Fiscal year 2026 begins", true},
+		{"not appropriations", "Clean Water Act Amendments", "", time.Time{}, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := milestones.Funding(tt.title, nil, tt.text)
+		if ok != tt.ok || !got.Date.Equal(tt.want) || got.Summary != tt.summary {
+			t.Errorf("%s: Funding = %+v, %v; want %v %q, %v", tt.name, got, ok, tt.want, tt.summary, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// AppropriationsAccount is the amount a version of an appropriations bill
+// provides an account, parsed from the bill's structure of titles,
+// agencies, and accounts (see appropriations.ParseAccounts). Accounts are
+// compared across versions, and across fiscal years, by Key. The
+// composite unique key is (VersionID, Key).
+type AppropriationsAccount struct {
+	ID           uint      `json:"id" gorm:"primaryKey"`
+	VersionID    uint      `json:"version_id" gorm:"uniqueIndex:idx_appropriations_account_unique,priority:1"`
+	BillID       uint      `json:"bill_id" gorm:"index"`
+	Key          string    `json:"key" gorm:"uniqueIndex:idx_appropriations_account_unique,priority:2;index;size:512"` // appropriations.Account.Key
+	FiscalYear   int       `json:"fiscal_year" gorm:"index"`                                                           // 0 if the bill names none
+	Subcommittee string    `json:"subcommittee" gorm:"index;size:64"`                                                  // One of appropriations.Subcommittees, "" when unrecognized
+	Title        string    `json:"title" gorm:"size:32"`                                                               // e.g., "TITLE I"
+	Agency       string    `json:"agency"`
+	Bureau       string    `json:"bureau"`
+	Account      string    `json:"account"`
+	Raw          string    `json:"raw" gorm:"size:64"` // As written, e.g., "$63,000,000"
+	Amount       int64     `json:"amount"`             // Whole dollars
+	Offset       int       `json:"offset"`             // Byte offset in the version's plain text
+	FetchedAt    time.Time `json:"fetched_at"`         // The version's FetchedAt
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for AppropriationsAccount
+func (AppropriationsAccount) TableName() string {
+	return "appropriations_accounts"
+}
+
+// AppropriationsAccountState records that a version's accounts have been
+// parsed, including versions in which none were found.
+type AppropriationsAccountState struct {
+	VersionID uint      `json:"version_id" gorm:"primaryKey;autoIncrement:false"`
+	Accounts  int       `json:"accounts"`
+	ParsedAt  time.Time `json:"parsed_at"`
+}
+
+// TableName returns the table name for AppropriationsAccountState
+func (AppropriationsAccountState) TableName() string {
+	return "appropriations_account_states"
+}
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Actor types for AuditLog.ActorType.
+const (
+	AuditActorUser       = "user"        // An API key or session token; UserID is set
+	AuditActorAdminToken = "admin_token" // The API server's ADMIN_TOKEN
+	AuditActorAnonymous  = "anonymous"   // No valid credentials
+)
+
+// AuditLog records a request to a mutating API operation: who made it,
+// what it targeted, and how it turned out. Rows are append-only.
+type AuditLog struct {
+	ID         uint              `json:"id" gorm:"primaryKey"`
+	ActorType  string            `json:"actor_type" gorm:"size:16;index"`
+	UserID     *uint             `json:"user_id,omitempty" gorm:"index"`
+	Operation  string            `json:"operation" gorm:"size:64;index"` // OpenAPI operation ID, e.g., "set-user-role"
+	Method     string            `json:"method" gorm:"size:8"`
+	Route      string            `json:"route" gorm:"size:255"` // Path template, e.g., "/api/v1/admin/users/{id}/role"
+	Path       string            `json:"path" gorm:"size:1024"`
+	TargetType string            `json:"target_type" gorm:"size:32;index:idx_audit_target,priority:1"` // Resource collection, e.g., "users"
+	TargetID   string            `json:"target_id,omitempty" gorm:"size:64;index:idx_audit_target,priority:2"`
+	Status     int               `json:"status"`                             // Response status
+	Before     datatypes.JSONMap `json:"before,omitempty" gorm:"type:jsonb"` // Summary of the target before the change, where recorded
+	After      datatypes.JSONMap `json:"after,omitempty" gorm:"type:jsonb"`  // ...and after
+	CreatedAt  time.Time         `json:"created_at" gorm:"index"`
+}
+
+// TableName returns the table name for AuditLog
+func (AuditLog) TableName() string {
+	return "audit_log"
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// BackfillCheckpoint records how far a historical backfill has progressed
+// through one congress and bill type, so an interrupted run can resume.
+// The composite unique key is (Congress, BillType).
+type BackfillCheckpoint struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	Congress    int        `json:"congress" gorm:"uniqueIndex:idx_backfill_unique,priority:1"`
+	BillType    string     `json:"bill_type" gorm:"uniqueIndex:idx_backfill_unique,priority:2;size:10"`
+	Offset      int        `json:"offset"` // Next FetchBills offset to process
+	TotalCount  int        `json:"total_count"`
+	BillsDone   int        `json:"bills_done"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// TableName returns the table name for BackfillCheckpoint
+func (BackfillCheckpoint) TableName() string {
+	return "backfill_checkpoints"
+}
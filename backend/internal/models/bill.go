@@ -6,47 +6,98 @@ import (
 	"gorm.io/datatypes"
 )
 
+// Jurisdictions for Bill.Jurisdiction.
+const (
+	JurisdictionFederal = "federal" // Congress; Congress is set
+	JurisdictionState   = "state"   // A state legislature; StateCode and Session are set
+	JurisdictionDraft   = "draft"   // A tenant's uploaded working draft; TenantID is set
+)
+
 // Bill represents a legislative bill with GORM ORM mappings.
-// The composite unique key is (Congress, BillNumber, BillType).
+// The composite unique key is (Congress, Number, BillType, StateCode,
+// Session, TenantID). Federal bills leave StateCode and Session empty; state
+// bills leave Congress zero. Number is the number as published, which
+// isn't always numeric; BillNumber is its value, for sorting and lookups,
+// or 0 when it has none. Bills ingested from public sources have a zero
+// TenantID and are visible to everyone; drafts belong to one tenant.
 type Bill struct {
-	ID             uint              `json:"id" gorm:"primaryKey"`
-	Congress       int               `json:"congress" gorm:"uniqueIndex:idx_bill_unique,priority:1"`
-	BillNumber     int               `json:"bill_number" gorm:"uniqueIndex:idx_bill_unique,priority:2"`
-	BillType       string            `json:"bill_type" gorm:"uniqueIndex:idx_bill_unique,priority:3;size:10"`
-	Title          string            `json:"title"`
-	Sponsor        string            `json:"sponsor,omitempty"`
-	OriginChamber  string            `json:"origin_chamber"`
-	CurrentStatus  string            `json:"current_status"`
-	UpdateDate     string            `json:"update_date"` // Congress.gov updateDate string
-	IsSpendingBill bool              `json:"is_spending_bill" gorm:"index"`
-	Metadata       datatypes.JSONMap `json:"metadata" gorm:"type:jsonb"`
-	CreatedAt      time.Time         `json:"created_at"`
-	UpdatedAt      time.Time         `json:"updated_at"`
+	ID                      uint                        `json:"id" gorm:"primaryKey"`
+	Congress                int                         `json:"congress" gorm:"uniqueIndex:idx_bill_number_key,priority:1"`
+	Number                  string                      `json:"number" gorm:"uniqueIndex:idx_bill_number_key,priority:2;size:32;not null;default:''"` // As published, e.g., "1"
+	BillNumber              int                         `json:"bill_number" gorm:"index"`
+	BillType                string                      `json:"bill_type" gorm:"uniqueIndex:idx_bill_number_key,priority:3;size:10"`
+	StateCode               string                      `json:"state_code" gorm:"uniqueIndex:idx_bill_number_key,priority:4;size:2;not null;default:''"` // Lower-case state code, e.g., "ca"
+	Session                 string                      `json:"session" gorm:"uniqueIndex:idx_bill_number_key,priority:5;size:32;not null;default:''"`   // State legislative session, e.g., "20252026"
+	TenantID                uint                        `json:"tenant_id" gorm:"uniqueIndex:idx_bill_number_key,priority:6;not null;default:0"`          // Owning tenant of a draft; 0 for public bills
+	Jurisdiction            string                      `json:"jurisdiction" gorm:"index;size:16;not null;default:'federal'"`
+	Title                   string                      `json:"title"`
+	Sponsor                 string                      `json:"sponsor,omitempty"`
+	OriginChamber           string                      `json:"origin_chamber"`
+	CurrentStatus           string                      `json:"current_status"`
+	UpdateDate              string                      `json:"update_date"`                          // Congress.gov updateDate string
+	UpdateDateIncludingText string                      `json:"update_date_including_text"`           // Congress.gov updateDateIncludingText as of the last successful text fetch
+	IntroducedDate          string                      `json:"introduced_date" gorm:"index;size:10"` // YYYY-MM-DD; empty until the bill detail is fetched
+	IsSpendingBill          bool                        `json:"is_spending_bill" gorm:"index"`
+	PolicyArea              string                      `json:"policy_area" gorm:"index"`               // CRS policy area, e.g., "Taxation"
+	Subjects                datatypes.JSONSlice[string] `json:"subjects" gorm:"type:jsonb"`             // CRS legislative subject terms; see BillSubject
+	PublicLawNumber         string                      `json:"public_law_number" gorm:"index;size:20"` // e.g., "118-5"; empty until enacted
+	LawType                 string                      `json:"law_type" gorm:"size:20"`                // "Public Law" or "Private Law"
+	CosponsorCount          int                         `json:"cosponsor_count"`                        // Excluding withdrawn cosponsors; 0 until the bill detail is fetched
+	ActionCount             int                         `json:"action_count"`
+	AmendmentCount          int                         `json:"amendment_count"`
+	Metadata                datatypes.JSONMap           `json:"metadata" gorm:"type:jsonb"` // Source record: the Congress.gov bill detail (list entry until fetched) or Open States bill
+	CreatedAt               time.Time                   `json:"created_at"`
+	UpdatedAt               time.Time                   `json:"updated_at"`
 }
 
 // Version represents a point-in-time snapshot of bill text.
-// Uses SHA-256 content hash for deduplication.
+// It belongs to either a bill or, with DocumentID set, a Document.
+// Uses SHA-256 content hash for deduplication. Superseded versions may be
+// archived (see package archive): their text columns are emptied and the
+// text kept gzip-compressed, while hashes and metadata stay as they are.
+// With a text store configured (see package textstore), TextContent is
+// kept in object storage instead and StorageRef points at it.
 type Version struct {
-	ID          uint      `json:"id" gorm:"primaryKey"`
-	BillID      uint      `json:"bill_id" gorm:"index"`
-	VersionCode string    `json:"version_code"` // e.g., "IH" (Introduced House), "EH" (Engrossed House)
-	ContentHash string    `json:"content_hash" gorm:"index;size:64"` // SHA-256 hash
-	TextContent string    `json:"text_content" gorm:"type:text"`
-	FetchedAt   time.Time `json:"fetched_at"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID                uint       `json:"id" gorm:"primaryKey"`
+	BillID            uint       `json:"bill_id" gorm:"index"`                  // Owning bill; 0 for a document's version
+	DocumentID        *uint      `json:"document_id,omitempty" gorm:"index"`    // Owning Document; nil for a bill's version
+	VersionCode       string     `json:"version_code"`                          // e.g., "IH" (Introduced House), "EH" (Engrossed House)
+	ContentHash       string     `json:"content_hash" gorm:"index;size:64"`     // SHA-256 of the normalized text (textnorm.Hash)
+	RawHash           string     `json:"raw_hash" gorm:"size:64"`               // SHA-256 of TextContent as fetched
+	TextContent       string     `json:"text_content" gorm:"type:text"`         // Raw text as fetched, markup included
+	PlainText         string     `json:"plain_text" gorm:"type:text"`           // textextract.Extract(TextContent); what diffs are computed over
+	TextSize          int        `json:"text_size"`                             // len(TextContent) when stored; set even once the text is offloaded
+	StorageRef        string     `json:"storage_ref,omitempty" gorm:"size:512"` // Text store object holding TextContent; empty when inline
+	ArchivedText      []byte     `json:"-" gorm:"type:bytea"`                   // gzip of TextContent, set when archived
+	ArchivedPlainText []byte     `json:"-" gorm:"type:bytea"`                   // gzip of PlainText, set when archived
+	ArchivedAt        *time.Time `json:"archived_at,omitempty" gorm:"index"`    // Set once text moved to the archive columns
+	WordCount         int        `json:"word_count"`                            // Readability metrics of PlainText; see package textstats
+	PageEstimate      int        `json:"page_estimate"`                         // Printed pages at textstats.WordsPerPage
+	AvgSentenceLength float64    `json:"avg_sentence_length"`                   // Words per sentence
+	GradeLevel        float64    `json:"grade_level"`                           // Flesch-Kincaid grade level
+	DefinedTerms      int        `json:"defined_terms"`                         // Distinct defined terms
+	FetchedAt         time.Time  `json:"fetched_at"`
+	CreatedAt         time.Time  `json:"created_at"`
 }
 
-// Delta represents a stored diff between two versions.
-// DeltaJSON stores structured diff data as JSONB for querying.
+// Delta represents a stored diff between two versions. Diffs are stored
+// compressed in DeltaData (see deltas.CodecGzipRef); DeltaJSON holds those
+// stored before compression, as JSONB.
 type Delta struct {
-	ID         uint              `json:"id" gorm:"primaryKey"`
-	VersionAID uint              `json:"version_a_id" gorm:"index"`
-	VersionBID uint              `json:"version_b_id" gorm:"index"`
-	Insertions int               `json:"insertions"`
-	Deletions  int               `json:"deletions"`
-	DeltaJSON  datatypes.JSONMap `json:"delta_json" gorm:"type:jsonb"` // Structured diff data
-	ComputedAt time.Time         `json:"computed_at"`
-	CreatedAt  time.Time         `json:"created_at"`
+	ID            uint              `json:"id" gorm:"primaryKey"`
+	VersionAID    uint              `json:"version_a_id" gorm:"index"`
+	VersionBID    uint              `json:"version_b_id" gorm:"index"`
+	Insertions    int               `json:"insertions"`
+	Deletions     int               `json:"deletions"`
+	DeltaJSON     datatypes.JSONMap `json:"delta_json" gorm:"type:jsonb"`  // Structured diff data, as stored before compression
+	DeltaData     []byte            `json:"-" gorm:"type:bytea"`           // Compressed structured diff data
+	Codec         string            `json:"codec" gorm:"size:16"`          // Format of DeltaData, e.g., "gzip+ref/1"
+	EngineVersion string            `json:"engine_version" gorm:"size:32"` // diff_engine.EngineVersion that produced it
+	Fingerprint   string            `json:"fingerprint" gorm:"size:64"`    // diff_engine.Fingerprint of the result
+	Summary       string            `json:"summary" gorm:"type:text"`      // Plain-language summary of the change; see package insights
+	SummaryBy     string            `json:"summary_by" gorm:"size:64"`     // insights.Summarizer that wrote Summary, e.g., "template"
+	ComputedAt    time.Time         `json:"computed_at"`
+	CreatedAt     time.Time         `json:"created_at"`
 }
 
 // TableName returns the table name for Bill
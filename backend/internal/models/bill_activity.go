@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// BillActivity is a bill's activity over the trending window, recomputed
+// by package trending. Bills with no activity in the window have no row.
+type BillActivity struct {
+	BillID       uint      `json:"bill_id" gorm:"primaryKey;autoIncrement:false"`
+	Score        float64   `json:"score" gorm:"index"`
+	Versions     int       `json:"versions"`      // Text versions fetched in the window
+	Events       int       `json:"events"`        // Status, title, sponsor, and enactment events in the window
+	LinesChanged int       `json:"lines_changed"` // Lines inserted and deleted by those versions, from cached diffs
+	WindowStart  time.Time `json:"window_start"`
+	ComputedAt   time.Time `json:"computed_at"`
+}
+
+// TableName returns the table name for BillActivity
+func (BillActivity) TableName() string {
+	return "bill_activity"
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Sources for BillAlias.Source.
+const (
+	AliasSourceShortTitle   = "short_title"   // A short title from Congress.gov
+	AliasSourcePopularTitle = "popular_title" // A CRS popular title from Congress.gov
+	AliasSourceAdmin        = "admin"         // Curated by an operator, e.g., "CR"
+)
+
+// BillAlias is another name a bill is known by, such as its short title
+// or a nickname like "NDAA", matched by bill search alongside the official
+// title. The composite unique key is (BillID, Alias).
+type BillAlias struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	BillID    uint      `json:"bill_id" gorm:"uniqueIndex:idx_bill_alias_unique,priority:1"`
+	Alias     string    `json:"alias" gorm:"uniqueIndex:idx_bill_alias_unique,priority:2;size:512"`
+	Source    string    `json:"source" gorm:"size:16;index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for BillAlias
+func (BillAlias) TableName() string {
+	return "bill_aliases"
+}
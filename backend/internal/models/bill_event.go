@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Event types for BillEvent.EventType.
+const (
+	BillEventVersionAdded   = "version_added"
+	BillEventStatusChanged  = "status_changed"
+	BillEventTitleChanged   = "title_changed"
+	BillEventTitleRevised   = "title_revised" // A new official, short, or popular title; Details has its kind and version
+	BillEventSponsorChanged = "sponsor_changed"
+	BillEventBecameLaw      = "became_law"
+)
+
+// BillEvent records a change the ingestor detected on a bill.
+// Events are append-only and form the bill's change feed.
+type BillEvent struct {
+	ID            uint              `json:"id" gorm:"primaryKey"`
+	BillID        uint              `json:"bill_id" gorm:"index:idx_bill_event_bill_time,priority:1"`
+	EventType     string            `json:"event_type" gorm:"size:32;index"`
+	OccurredAt    time.Time         `json:"occurred_at" gorm:"index:idx_bill_event_bill_time,priority:2"`
+	VersionID     *uint             `json:"version_id,omitempty"` // Set for version_added events
+	PreviousValue string            `json:"previous_value,omitempty" gorm:"type:text"`
+	NewValue      string            `json:"new_value,omitempty" gorm:"type:text"`
+	Details       datatypes.JSONMap `json:"details,omitempty" gorm:"type:jsonb"` // Event-specific extras
+	CreatedAt     time.Time         `json:"created_at"`
+}
+
+// TableName returns the table name for BillEvent
+func (BillEvent) TableName() string {
+	return "bill_events"
+}
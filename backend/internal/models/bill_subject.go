@@ -0,0 +1,15 @@
+package models
+
+// BillSubject is a CRS legislative subject term assigned to a bill. Rows
+// mirror Bill.Subjects in normalized form for filtering.
+// The composite unique key is (BillID, Subject).
+type BillSubject struct {
+	ID      uint   `json:"id" gorm:"primaryKey"`
+	BillID  uint   `json:"bill_id" gorm:"uniqueIndex:idx_bill_subject_unique,priority:1"`
+	Subject string `json:"subject" gorm:"uniqueIndex:idx_bill_subject_unique,priority:2;index"`
+}
+
+// TableName returns the table name for BillSubject
+func (BillSubject) TableName() string {
+	return "bill_subjects"
+}
@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// Kinds of BillTitle.
+const (
+	TitleKindOfficial = "official" // "To provide for..."; one per text version
+	TitleKindShort    = "short"    // e.g., "One Big Beautiful Bill Act"
+	TitleKindPopular  = "popular"  // A CRS popular title, e.g., "NDAA"
+	TitleKindDisplay  = "display"  // The title Congress.gov displays; Bill.Title
+)
+
+// BillTitle is one of a bill's titles as of a text version or chamber
+// action, as Congress.gov lists them. Together they are the bill's title
+// history; rows are replaced on each sync.
+type BillTitle struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	BillID      uint      `json:"bill_id" gorm:"index"`
+	Kind        string    `json:"kind" gorm:"size:16"`
+	TitleType   string    `json:"title_type"` // e.g., "Short Titles as Passed House"
+	Title       string    `json:"title" gorm:"type:text"`
+	VersionCode string    `json:"version_code" gorm:"size:16"` // Text version the title is from, e.g., "EH"; empty when not tied to one
+	Chamber     string    `json:"chamber" gorm:"size:16"`
+	UpdateDate  string    `json:"update_date"` // Congress.gov updateDate
+	Position    int       `json:"position"`    // Order in the Congress.gov listing
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for BillTitle
+func (BillTitle) TableName() string {
+	return "bill_titles"
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// CostEstimate is a CBO (Congressional Budget Office) cost estimate of a
+// bill. The estimate itself lives on cbo.gov; URL links to it.
+// The composite unique key is (BillID, URL).
+type CostEstimate struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	BillID      uint      `json:"bill_id" gorm:"uniqueIndex:idx_cost_estimate_unique,priority:1"`
+	URL         string    `json:"url" gorm:"uniqueIndex:idx_cost_estimate_unique,priority:2"`
+	Title       string    `json:"title"`
+	Description string    `json:"description" gorm:"type:text"` // e.g., "As ordered reported by the House Committee on ..."
+	PubDate     time.Time `json:"pub_date"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for CostEstimate
+func (CostEstimate) TableName() string {
+	return "cost_estimates"
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// DefinedTerm is a term defined in a version's definitions section, with its
+// definition. Terms are compared across versions by Key.
+// The composite unique key is (VersionID, Key).
+type DefinedTerm struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	VersionID  uint      `json:"version_id" gorm:"uniqueIndex:idx_defined_term_unique,priority:1"`
+	Key        string    `json:"key" gorm:"uniqueIndex:idx_defined_term_unique,priority:2;size:512"` // analysis.Definition.Key
+	Term       string    `json:"term"`
+	Definition string    `json:"definition" gorm:"type:text"`
+	Section    string    `json:"section" gorm:"size:16"`
+	Anchor     string    `json:"anchor"`
+	Offset     int       `json:"offset"` // Byte offset in the markup-stripped text
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for DefinedTerm
+func (DefinedTerm) TableName() string {
+	return "defined_terms"
+}
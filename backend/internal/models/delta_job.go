@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Delta job scopes for DeltaJob.Scope.
+const (
+	DeltaJobScopeBill = "bill" // Every cached delta of one bill, plus its neighboring version pairs
+	DeltaJobScopePair = "pair" // One version pair
+	DeltaJobScopeAll  = "all"  // Every cached delta
+)
+
+// Delta job statuses for DeltaJob.Status.
+const (
+	DeltaJobQueued    = "queued"
+	DeltaJobRunning   = "running"
+	DeltaJobSucceeded = "succeeded"
+	DeltaJobFailed    = "failed"
+)
+
+// DeltaJob records an operator-requested recomputation of cached deltas.
+// Per-pair errors are kept in Errors; a job-level failure sets ErrorMessage.
+type DeltaJob struct {
+	ID            uint                        `json:"id" gorm:"primaryKey"`
+	Scope         string                      `json:"scope" gorm:"size:16"`
+	BillID        *uint                       `json:"bill_id,omitempty"`         // Set for bill scope
+	FromVersionID *uint                       `json:"from_version_id,omitempty"` // Set for pair scope
+	ToVersionID   *uint                       `json:"to_version_id,omitempty"`   // Set for pair scope
+	Status        string                      `json:"status" gorm:"size:16;index"`
+	Total         int                         `json:"total"`    // Version pairs to recompute
+	Computed      int                         `json:"computed"` // Pairs recomputed and stored
+	Skipped       int                         `json:"skipped"`  // Pairs over the diff size limit
+	Failed        int                         `json:"failed"`   // Pairs that errored
+	Errors        datatypes.JSONSlice[string] `json:"errors" gorm:"type:jsonb"`
+	ErrorMessage  string                      `json:"error_message,omitempty"`
+	StartedAt     *time.Time                  `json:"started_at,omitempty"`
+	FinishedAt    *time.Time                  `json:"finished_at,omitempty"`
+	CreatedAt     time.Time                   `json:"created_at" gorm:"index"`
+}
+
+// TableName returns the table name for DeltaJob
+func (DeltaJob) TableName() string {
+	return "delta_jobs"
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// DiffHit counts requests for the diff between two versions of a bill, so
+// the most requested diffs can be precomputed before they are asked for
+// again. Only diffs of the texts as they are, without preprocessing
+// options, are counted, since only those are cached.
+type DiffHit struct {
+	ID         uint      `json:"id" gorm:"primaryKey"`
+	BillID     uint      `json:"bill_id" gorm:"index"`
+	VersionAID uint      `json:"version_a_id" gorm:"uniqueIndex:idx_diff_hit_pair,priority:1"`
+	VersionBID uint      `json:"version_b_id" gorm:"uniqueIndex:idx_diff_hit_pair,priority:2"`
+	Hits       int64     `json:"hits" gorm:"not null;default:0"`
+	LastHitAt  time.Time `json:"last_hit_at" gorm:"index"`
+}
+
+// TableName returns the table name for DiffHit
+func (DiffHit) TableName() string {
+	return "diff_hits"
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Document is a custom text a user tracks outside the legislative record,
+// such as an agency guidance memo or a contract. Its versions are stored
+// as Versions with DocumentID set, so they are hashed and diffed like bill
+// versions; only the uploading user's API key can read them.
+type Document struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	UserID      uint      `json:"user_id" gorm:"index;not null"`
+	Title       string    `json:"title" gorm:"size:1000"`
+	Description string    `json:"description,omitempty" gorm:"type:text"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for Document
+func (Document) TableName() string {
+	return "documents"
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// Earmark is a community project funding entry extracted from a version's
+// text: an amount directed to a recipient for a purpose. Earmarks are
+// compared across versions by Key. The composite unique key is
+// (VersionID, Key).
+type Earmark struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	VersionID uint      `json:"version_id" gorm:"uniqueIndex:idx_earmark_unique,priority:1"`
+	Key       string    `json:"key" gorm:"uniqueIndex:idx_earmark_unique,priority:2;size:1024"` // analysis.Earmark.Key
+	Recipient string    `json:"recipient"`
+	Purpose   string    `json:"purpose" gorm:"type:text"`
+	Section   string    `json:"section" gorm:"size:16"`
+	Raw       string    `json:"raw" gorm:"size:64"` // As written, e.g., "$500,000"
+	Amount    int64     `json:"amount"`             // Whole dollars
+	Offset    int       `json:"offset"`             // Byte offset in the markup-stripped text
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for Earmark
+func (Earmark) TableName() string {
+	return "earmarks"
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kinds of text embedded, for Embedding.Kind.
+const (
+	EmbeddingKindSummary = "summary"
+	EmbeddingKindSection = "section"
+)
+
+// Embedding is a vector embedding of a bill's CRS summary or of a section
+// of its latest text version, for semantic search (see package
+// embeddings). Its table needs the pgvector extension, so it is created
+// by embeddings.Migrate when embeddings are enabled rather than by
+// database.Migrate. The composite unique key is (Model, BillID, Kind, Ref).
+type Embedding struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Model       string    `json:"model" gorm:"uniqueIndex:idx_embedding_unique,priority:1;size:128"` // Provider that computed it, e.g., "openai:text-embedding-3-small"
+	BillID      uint      `json:"bill_id" gorm:"uniqueIndex:idx_embedding_unique,priority:2;index"`
+	Kind        string    `json:"kind" gorm:"uniqueIndex:idx_embedding_unique,priority:3;size:16"`
+	Ref         string    `json:"ref" gorm:"uniqueIndex:idx_embedding_unique,priority:4;size:64"` // Summaries: the CRS version code; sections: analysis.Section.Anchor
+	VersionID   *uint     `json:"version_id,omitempty"`                                           // Sections: the version the section is from
+	Section     string    `json:"section,omitempty" gorm:"size:16"`                               // Sections: the section number
+	Heading     string    `json:"heading,omitempty"`                                              // Sections: the heading; summaries: the action summarized
+	Excerpt     string    `json:"excerpt" gorm:"type:text"`                                       // Start of the text embedded, for display
+	ContentHash string    `json:"content_hash" gorm:"size:64"`                                    // SHA-256 of the text embedded, so unchanged text isn't embedded again
+	Vector      Vector    `json:"-" gorm:"type:vector;not null"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for Embedding
+func (Embedding) TableName() string {
+	return "embeddings"
+}
+
+// EmbeddingState records when a bill's embeddings were last brought up to
+// date for a model, so only bills updated since are embedded again.
+type EmbeddingState struct {
+	BillID   uint      `json:"bill_id" gorm:"primaryKey;autoIncrement:false"`
+	Model    string    `json:"model" gorm:"primaryKey;size:128"`
+	SyncedAt time.Time `json:"synced_at"`
+}
+
+// TableName returns the table name for EmbeddingState
+func (EmbeddingState) TableName() string {
+	return "embedding_states"
+}
+
+// Vector is a pgvector vector, stored in its text form, e.g., "[1,2.5,3]".
+type Vector []float32
+
+// Value implements driver.Valuer.
+func (v Vector) Value() (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return v.String(), nil
+}
+
+// String returns the vector in pgvector's text form.
+func (v Vector) String() string {
+	var b strings.Builder
+	b.WriteByte('[')
+	for i, x := range v {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(strconv.FormatFloat(float64(x), 'g', -1, 32))
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// Scan implements sql.Scanner.
+func (v *Vector) Scan(src any) error {
+	var s string
+	switch src := src.(type) {
+	case nil:
+		*v = nil
+		return nil
+	case string:
+		s = src
+	case []byte:
+		s = string(src)
+	default:
+		return fmt.Errorf("models: cannot scan %T into Vector", src)
+	}
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
+		return fmt.Errorf("models: invalid vector %q", s)
+	}
+	s = s[1 : len(s)-1]
+	if s == "" {
+		*v = Vector{}
+		return nil
+	}
+	parts := strings.Split(s, ",")
+	out := make(Vector, len(parts))
+	for i, p := range parts {
+		x, err := strconv.ParseFloat(strings.TrimSpace(p), 32)
+		if err != nil {
+			return fmt.Errorf("models: invalid vector element %q: %w", p, err)
+		}
+		out[i] = float32(x)
+	}
+	*v = out
+	return nil
+}
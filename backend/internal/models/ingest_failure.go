@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Ingestion failure stages for IngestFailure.Stage.
+const (
+	IngestFailureStageBill     = "bill"     // Upserting the bill itself failed
+	IngestFailureStageVersions = "versions" // The bill was stored but fetching its text versions failed
+)
+
+// Ingestion failure statuses for IngestFailure.Status.
+const (
+	IngestFailurePending   = "pending"   // Retried once NextAttemptAt passes
+	IngestFailureExhausted = "exhausted" // Out of retries until an operator requeues it
+	IngestFailureResolved  = "resolved"  // A retry succeeded
+	IngestFailureDismissed = "dismissed" // Dismissed by an operator
+)
+
+// Error classes for IngestFailure.ErrorClass.
+const (
+	IngestErrorRateLimited = "rate_limited" // Congress.gov rate limit, after backing off
+	IngestErrorNotFound    = "not_found"    // Congress.gov has no such resource
+	IngestErrorUpstream    = "upstream"     // Congress.gov returned another error status
+	IngestErrorTimeout     = "timeout"
+	IngestErrorNetwork     = "network"
+	IngestErrorDatabase    = "database"
+	IngestErrorOther       = "other"
+)
+
+// IngestFailure is a dead-lettered bill: one whose ingestion failed, kept
+// with the listing it was ingested from so it can be retried on its own.
+// A bill has at most one open (pending or exhausted) failure per stage;
+// repeated failures count up Attempts.
+type IngestFailure struct {
+	ID            uint           `json:"id" gorm:"primaryKey"`
+	BillKey       string         `json:"bill_key" gorm:"size:64;index"` // e.g., "119-hr-1234"
+	Stage         string         `json:"stage" gorm:"size:16"`
+	Status        string         `json:"status" gorm:"size:16;index"`
+	ErrorClass    string         `json:"error_class" gorm:"size:32;index"`
+	LastError     string         `json:"last_error" gorm:"type:text"`
+	Attempts      int            `json:"attempts"`                     // Failed attempts so far
+	NextAttemptAt time.Time      `json:"next_attempt_at" gorm:"index"` // When a pending failure is retried
+	Payload       datatypes.JSON `json:"payload" gorm:"type:jsonb"`    // The Congress.gov bill listing as ingested
+	RunID         *uint          `json:"run_id,omitempty"`             // IngestRun that first recorded it
+	ResolvedAt    *time.Time     `json:"resolved_at,omitempty"`        // Set when resolved or dismissed
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+}
+
+// TableName returns the table name for IngestFailure
+func (IngestFailure) TableName() string {
+	return "ingest_failures"
+}
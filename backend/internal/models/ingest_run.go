@@ -0,0 +1,47 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Ingestion run statuses for IngestRun.Status.
+const (
+	IngestRunRunning   = "running"
+	IngestRunSucceeded = "succeeded"
+	IngestRunFailed    = "failed"
+)
+
+// IngestRun records a single ingestion run and its outcome.
+// Per-bill errors are kept in Errors; a run-level failure sets ErrorMessage.
+// The cursor fields are checkpointed while the run processes bills, so a
+// run that's cut short is resumed by the next run of the same mode.
+type IngestRun struct {
+	ID               uint                        `json:"id" gorm:"primaryKey"`
+	TriggeredBy      string                      `json:"triggered_by" gorm:"size:32"` // e.g., "schedule", "single-run", "manual"
+	Mode             string                      `json:"mode" gorm:"size:32"`         // e.g., "recent", "search", "backfill", "govinfo", "openstates", "retry", "reingest", "watched"
+	Status           string                      `json:"status" gorm:"size:16;index"`
+	StartedAt        time.Time                   `json:"started_at" gorm:"index"`
+	FinishedAt       *time.Time                  `json:"finished_at,omitempty"`
+	DurationMs       int64                       `json:"duration_ms"`
+	BillsFetched     int                         `json:"bills_fetched"`
+	BillsSkipped     int                         `json:"bills_skipped"`
+	BillsCreated     int                         `json:"bills_created"`
+	BillsUpdated     int                         `json:"bills_updated"`
+	VersionsCreated  int                         `json:"versions_created"`
+	ErrorCount       int                         `json:"error_count"`
+	Errors           datatypes.JSONSlice[string] `json:"errors" gorm:"type:jsonb"`
+	ErrorMessage     string                      `json:"error_message,omitempty"`
+	CursorSource     string                      `json:"cursor_source,omitempty" gorm:"size:512"`     // What the bills were listed from, e.g., the targets
+	CursorBill       string                      `json:"cursor_bill,omitempty" gorm:"size:64"`        // Last bill processed in list order with all before it, e.g., "119-hr-1234"
+	CursorUpdateDate string                      `json:"cursor_update_date,omitempty" gorm:"size:32"` // CursorBill's updateDate when listed
+	CursorNewest     string                      `json:"cursor_newest,omitempty" gorm:"size:32"`      // Newest updateDate in the list
+	ResumedFrom      *uint                       `json:"resumed_from,omitempty"`                      // Interrupted run this one picked up from
+	CreatedAt        time.Time                   `json:"created_at"`
+}
+
+// TableName returns the table name for IngestRun
+func (IngestRun) TableName() string {
+	return "ingest_runs"
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// KeywordAlert is a boolean keyword query a user registered to be told
+// when newly ingested bill text matches it; see package alerts for the
+// query syntax.
+type KeywordAlert struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"index"`
+	Name      string    `json:"name"`
+	Query     string    `json:"query" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for KeywordAlert
+func (KeywordAlert) TableName() string {
+	return "keyword_alerts"
+}
+
+// AlertSnippet is one occurrence of a query term in matched text.
+type AlertSnippet struct {
+	Term    string `json:"term"`
+	Text    string `json:"text"`
+	Section string `json:"section,omitempty"` // Section number
+	Anchor  string `json:"anchor,omitempty"`  // Section anchor, as in diffs
+	Offset  int    `json:"offset"`            // Byte offset in the version's plain text
+}
+
+// KeywordAlertMatch is a notification that a version's text matched a
+// keyword alert. An alert matches a version at most once.
+type KeywordAlertMatch struct {
+	ID        uint                              `json:"id" gorm:"primaryKey"`
+	AlertID   uint                              `json:"alert_id" gorm:"uniqueIndex:idx_alert_match_unique,priority:1"`
+	UserID    uint                              `json:"user_id" gorm:"index"`
+	BillID    uint                              `json:"bill_id" gorm:"index"`
+	VersionID uint                              `json:"version_id" gorm:"uniqueIndex:idx_alert_match_unique,priority:2"`
+	Snippets  datatypes.JSONSlice[AlertSnippet] `json:"snippets" gorm:"type:jsonb"`
+	CreatedAt time.Time                         `json:"created_at" gorm:"index"`
+}
+
+// TableName returns the table name for KeywordAlertMatch
+func (KeywordAlertMatch) TableName() string {
+	return "keyword_alert_matches"
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// BillLineage links a bill to a bill of the previous Congress it
+// reintroduces, matched on title, sponsor, and text (see package
+// lineage). A bill can have more than one predecessor, such as House and
+// Senate companions, and more than one successor. The composite unique
+// key is (BillID, PredecessorID).
+type BillLineage struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	BillID          uint      `json:"bill_id" gorm:"uniqueIndex:idx_lineage_unique,priority:1"`
+	PredecessorID   uint      `json:"predecessor_id" gorm:"uniqueIndex:idx_lineage_unique,priority:2;index"`
+	Score           float64   `json:"score"`                     // Combined match score, from lineage.MinScore to 1
+	TitleSimilarity float64   `json:"title_similarity"`          // Share of title words in common
+	TextSimilarity  *float64  `json:"text_similarity,omitempty"` // Share of sections in common; nil when either bill's sections aren't fingerprinted
+	SameSponsor     bool      `json:"same_sponsor"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for BillLineage
+func (BillLineage) TableName() string {
+	return "bill_lineages"
+}
+
+// LineageState records when a bill was last matched against the previous
+// Congress, so only bills updated since are matched again.
+type LineageState struct {
+	BillID    uint      `json:"bill_id" gorm:"primaryKey;autoIncrement:false"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// TableName returns the table name for LineageState
+func (LineageState) TableName() string {
+	return "lineage_states"
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Sponsorship roles for BillSponsorship.Role.
+const (
+	SponsorshipRoleSponsor   = "sponsor"
+	SponsorshipRoleCosponsor = "cosponsor"
+)
+
+// Member represents a member of Congress, keyed by Bioguide ID.
+type Member struct {
+	BioguideID string    `json:"bioguide_id" gorm:"primaryKey;size:16"`
+	FullName   string    `json:"full_name"`
+	Party      string    `json:"party" gorm:"size:8"`
+	State      string    `json:"state" gorm:"size:4"`
+	District   int       `json:"district,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// BillSponsorship links a Member to a Bill they sponsored or cosponsored.
+// The composite unique key is (BillID, BioguideID).
+type BillSponsorship struct {
+	ID              uint      `json:"id" gorm:"primaryKey"`
+	BillID          uint      `json:"bill_id" gorm:"uniqueIndex:idx_sponsorship_unique,priority:1"`
+	BioguideID      string    `json:"bioguide_id" gorm:"uniqueIndex:idx_sponsorship_unique,priority:2;index;size:16"`
+	Role            string    `json:"role" gorm:"size:16"` // "sponsor" or "cosponsor"
+	SponsorshipDate string    `json:"sponsorship_date,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for Member
+func (Member) TableName() string {
+	return "members"
+}
+
+// TableName returns the table name for BillSponsorship
+func (BillSponsorship) TableName() string {
+	return "bill_sponsorships"
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+// MemberCongressStats is a member's record as sponsor in one Congress.
+// Rows are rebuilt by memberstats.Run rather than updated in place.
+type MemberCongressStats struct {
+	BioguideID       string    `json:"bioguide_id" gorm:"primaryKey;size:16"`
+	Congress         int       `json:"congress" gorm:"primaryKey"`
+	BillsSponsored   int       `json:"bills_sponsored"`
+	BillsEnacted     int       `json:"bills_enacted"`      // Sponsored bills that became law or have enrolled text
+	BillsWithChanges int       `json:"bills_with_changes"` // Sponsored bills with a diff between consecutive versions
+	LinesChanged     int       `json:"lines_changed"`      // Lines inserted and deleted across those diffs
+	ComputedAt       time.Time `json:"computed_at"`
+}
+
+// MemberPolicyArea counts a member's sponsored bills in one CRS policy
+// area. Rows are rebuilt by memberstats.Run rather than updated in place.
+type MemberPolicyArea struct {
+	BioguideID string    `json:"bioguide_id" gorm:"primaryKey;size:16"`
+	PolicyArea string    `json:"policy_area" gorm:"primaryKey"`
+	Bills      int       `json:"bills"`
+	ComputedAt time.Time `json:"computed_at"`
+}
+
+// TableName returns the table name for MemberCongressStats
+func (MemberCongressStats) TableName() string {
+	return "member_congress_stats"
+}
+
+// TableName returns the table name for MemberPolicyArea
+func (MemberPolicyArea) TableName() string {
+	return "member_policy_areas"
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Nomination is a presidential nomination sent to the Senate, keyed by
+// Congress, PN number, and part number, as divided nominations are
+// considered part by part.
+type Nomination struct {
+	ID            uint                                   `json:"id" gorm:"primaryKey"`
+	Congress      int                                    `json:"congress" gorm:"uniqueIndex:idx_nomination_key,priority:1"`
+	Number        int                                    `json:"number" gorm:"uniqueIndex:idx_nomination_key,priority:2"`
+	PartNumber    string                                 `json:"part_number" gorm:"uniqueIndex:idx_nomination_key,priority:3;size:8"` // "00" when undivided
+	Citation      string                                 `json:"citation" gorm:"index;size:32"`                                       // e.g., "PN123" or "PN123-1"
+	Description   string                                 `json:"description" gorm:"type:text"`
+	Organization  string                                 `json:"organization" gorm:"index"`
+	IsMilitary    bool                                   `json:"is_military"`
+	ReceivedDate  string                                 `json:"received_date"`
+	CurrentStatus string                                 `json:"current_status" gorm:"type:text"` // Text of the latest action
+	StatusDate    string                                 `json:"status_date" gorm:"index"`        // Date of the latest action
+	UpdateDate    string                                 `json:"update_date"`                     // Congress.gov's update date, to skip unchanged nominations
+	Actions       datatypes.JSONSlice[LegislativeAction] `json:"actions" gorm:"type:jsonb"`       // Oldest first
+	CreatedAt     time.Time                              `json:"created_at"`
+	UpdatedAt     time.Time                              `json:"updated_at"`
+}
+
+// TableName returns the table name for Nomination
+func (Nomination) TableName() string {
+	return "nominations"
+}
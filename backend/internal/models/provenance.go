@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// VersionProvenance records where a version's text was retrieved from and
+// what the source served, so the stored text can be shown to match it:
+// ContentHash is the SHA-256 of the bytes as retrieved, before any
+// processing, and equals the version's RawHash. Versions stored before
+// provenance was recorded, and uploaded drafts and documents, have none.
+type VersionProvenance struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	VersionID     uint      `json:"version_id" gorm:"uniqueIndex"`
+	SourceURL     string    `json:"source_url" gorm:"type:text"`          // URL requested
+	FinalURL      string    `json:"final_url,omitempty" gorm:"type:text"` // URL that answered, when redirected
+	LastModified  string    `json:"last_modified,omitempty" gorm:"size:64"`
+	ETag          string    `json:"etag,omitempty" gorm:"size:256"`
+	ContentType   string    `json:"content_type,omitempty" gorm:"size:128"`
+	ContentLength int       `json:"content_length"`              // Bytes retrieved
+	ContentHash   string    `json:"content_hash" gorm:"size:64"` // SHA-256 of the bytes retrieved
+	RetrievedAt   time.Time `json:"retrieved_at"`                // When the source answered; Version.FetchedAt may be the text's own date
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for VersionProvenance
+func (VersionProvenance) TableName() string {
+	return "version_provenance"
+}
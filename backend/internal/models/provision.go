@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+// Provision is a section of a bill version's text, fingerprinted so that
+// the same language can be found in other bills and versions (see package
+// provisions). Every version's sections are kept, not just the latest
+// version's, since tracing a provision back means finding where it first
+// appeared.
+type Provision struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	VersionID uint      `json:"version_id" gorm:"index"`
+	BillID    uint      `json:"bill_id" gorm:"index"`
+	Section   string    `json:"section" gorm:"size:16"`         // Section number
+	Anchor    string    `json:"anchor" gorm:"size:64"`          // analysis.Section.Anchor
+	Heading   string    `json:"heading"`                        // Section heading
+	Excerpt   string    `json:"excerpt" gorm:"type:text"`       // Start of the section body, for display
+	TextHash  string    `json:"text_hash" gorm:"index;size:64"` // provisions.Fingerprint.Hash; equal for verbatim copies
+	Words     int       `json:"words"`
+	Signature []byte    `json:"-" gorm:"type:bytea;not null"` // provisions.EncodeSignature of the MinHash signature
+	FetchedAt time.Time `json:"fetched_at" gorm:"index"`      // The version's FetchedAt: when the text is known to have appeared
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for Provision
+func (Provision) TableName() string {
+	return "provisions"
+}
+
+// ProvisionBand indexes a provision under one band of its signature, so
+// provisions sharing a band key are found without scanning every
+// signature. The composite primary key is (Key, ProvisionID).
+type ProvisionBand struct {
+	Key         int64 `json:"key" gorm:"primaryKey;autoIncrement:false"`
+	ProvisionID uint  `json:"provision_id" gorm:"primaryKey;autoIncrement:false;index"`
+}
+
+// TableName returns the table name for ProvisionBand
+func (ProvisionBand) TableName() string {
+	return "provision_bands"
+}
+
+// ProvisionState records that a version's sections have been
+// fingerprinted, including versions with no sections long enough to keep.
+type ProvisionState struct {
+	VersionID  uint      `json:"version_id" gorm:"primaryKey;autoIncrement:false"`
+	Provisions int       `json:"provisions"` // Sections kept
+	IndexedAt  time.Time `json:"indexed_at"`
+}
+
+// TableName returns the table name for ProvisionState
+func (ProvisionState) TableName() string {
+	return "provision_states"
+}
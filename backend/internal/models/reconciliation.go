@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Discrepancy kinds for ReconciliationDiscrepancy.Kind.
+const (
+	DriftMissingBill    = "missing_bill"    // Congress.gov no longer has the bill
+	DriftMissingVersion = "missing_version" // Congress.gov has a text version with a code not stored
+	DriftChangedText    = "changed_text"    // The latest text version's content doesn't match any stored version
+	DriftMetadata       = "metadata"        // A bill field differs from Congress.gov; Field names it
+)
+
+// ReconciliationRun records one pass comparing a sample of stored bills
+// with Congress.gov. DriftRate is BillsDrifted over BillsChecked; the run
+// alerts when it exceeds Threshold.
+type ReconciliationRun struct {
+	ID            uint                        `json:"id" gorm:"primaryKey"`
+	Status        string                      `json:"status" gorm:"size:16;index"` // One of the IngestRun statuses
+	StartedAt     time.Time                   `json:"started_at" gorm:"index"`
+	FinishedAt    *time.Time                  `json:"finished_at,omitempty"`
+	BillsSampled  int                         `json:"bills_sampled"`
+	BillsChecked  int                         `json:"bills_checked"` // Sampled bills Congress.gov answered for
+	BillsDrifted  int                         `json:"bills_drifted"` // Checked bills with any discrepancy
+	Discrepancies int                         `json:"discrepancies"`
+	DriftRate     float64                     `json:"drift_rate"`
+	Threshold     float64                     `json:"threshold"`
+	Alerted       bool                        `json:"alerted"`
+	Errors        datatypes.JSONSlice[string] `json:"errors" gorm:"type:jsonb"` // Bills that couldn't be checked
+	ErrorMessage  string                      `json:"error_message,omitempty"`
+	CreatedAt     time.Time                   `json:"created_at"`
+}
+
+// ReconciliationDiscrepancy is one difference a ReconciliationRun found
+// between a stored bill and Congress.gov.
+type ReconciliationDiscrepancy struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	RunID       uint      `json:"run_id" gorm:"index"`
+	BillID      uint      `json:"bill_id" gorm:"index"`
+	Kind        string    `json:"kind" gorm:"size:32;index"`
+	Field       string    `json:"field,omitempty" gorm:"size:64"`        // Bill field, for DriftMetadata
+	VersionCode string    `json:"version_code,omitempty" gorm:"size:16"` // For DriftMissingVersion and DriftChangedText
+	Stored      string    `json:"stored,omitempty" gorm:"type:text"`     // Value or content hash stored
+	Upstream    string    `json:"upstream,omitempty" gorm:"type:text"`   // Value or content hash Congress.gov has
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for ReconciliationRun
+func (ReconciliationRun) TableName() string {
+	return "reconciliation_runs"
+}
+
+// TableName returns the table name for ReconciliationDiscrepancy
+func (ReconciliationDiscrepancy) TableName() string {
+	return "reconciliation_discrepancies"
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Reingest job statuses for ReingestJob.Status.
+const (
+	ReingestJobQueued    = "queued"
+	ReingestJobRunning   = "running"
+	ReingestJobSucceeded = "succeeded"
+)
+
+// ReingestJob records an operator-requested refresh of stored federal
+// bills from Congress.gov. The bills matching its filter are resolved when
+// it is queued, into BillIDs; the ingestor refreshes them in order, a batch
+// per polling cycle, with Processed as its position. Per-bill errors are
+// kept in Errors.
+type ReingestJob struct {
+	ID           uint                        `json:"id" gorm:"primaryKey"`
+	Congress     int                         `json:"congress,omitempty"`                        // Filter
+	BillType     string                      `json:"bill_type,omitempty" gorm:"size:10"`        // Filter
+	UpdatedSince string                      `json:"updated_since,omitempty" gorm:"size:32"`    // Filter: Congress.gov update date on or after
+	RequestedIDs datatypes.JSONSlice[uint]   `json:"requested_ids,omitempty" gorm:"type:jsonb"` // Filter: these bills only
+	BillIDs      datatypes.JSONSlice[uint]   `json:"bill_ids" gorm:"type:jsonb"`                // Matching bills, in refresh order
+	Status       string                      `json:"status" gorm:"size:16;index"`
+	Total        int                         `json:"total"`     // len(BillIDs)
+	Processed    int                         `json:"processed"` // Bills refreshed or failed so far
+	Failed       int                         `json:"failed"`
+	Errors       datatypes.JSONSlice[string] `json:"errors" gorm:"type:jsonb"`
+	StartedAt    *time.Time                  `json:"started_at,omitempty"`
+	FinishedAt   *time.Time                  `json:"finished_at,omitempty"`
+	CreatedAt    time.Time                   `json:"created_at" gorm:"index"`
+}
+
+// TableName returns the table name for ReingestJob
+func (ReingestJob) TableName() string {
+	return "reingest_jobs"
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// RelatedBill links a bill to another bill Congress.gov reports as related.
+// The related bill is identified by congress, type, and number since it may
+// not be ingested. The composite unique key is (BillID, RelatedCongress,
+// RelatedType, RelatedNumber).
+type RelatedBill struct {
+	ID              uint                        `json:"id" gorm:"primaryKey"`
+	BillID          uint                        `json:"bill_id" gorm:"uniqueIndex:idx_related_bill_unique,priority:1"`
+	RelatedCongress int                         `json:"related_congress" gorm:"uniqueIndex:idx_related_bill_unique,priority:2"`
+	RelatedType     string                      `json:"related_type" gorm:"uniqueIndex:idx_related_bill_unique,priority:3;size:10"` // Lowercase, e.g., "s"
+	RelatedNumber   int                         `json:"related_number" gorm:"uniqueIndex:idx_related_bill_unique,priority:4"`
+	Title           string                      `json:"title"`
+	Relationships   datatypes.JSONSlice[string] `json:"relationships" gorm:"type:jsonb"` // "Type (IdentifiedBy)" pairs
+	IsCompanion     bool                        `json:"is_companion" gorm:"index"`       // Identical bill in the other chamber
+	CreatedAt       time.Time                   `json:"created_at"`
+	UpdatedAt       time.Time                   `json:"updated_at"`
+}
+
+// TableName returns the table name for RelatedBill
+func (RelatedBill) TableName() string {
+	return "related_bills"
+}
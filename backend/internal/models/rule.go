@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// Rule types for Rule.RuleType.
+const (
+	RuleTypeProposed = "proposed" // Notice of proposed rulemaking
+	RuleTypeFinal    = "final"
+)
+
+// Rule is a proposed or final rule published in the Federal Register,
+// with its full text. A final rule is diffed against the proposed rule
+// with the same RIN (Regulation Identifier Number), or failing that the
+// same docket.
+type Rule struct {
+	ID              uint                        `json:"id" gorm:"primaryKey"`
+	DocumentNumber  string                      `json:"document_number" gorm:"uniqueIndex;size:32"` // Federal Register document number, e.g., "2025-01234"
+	RuleType        string                      `json:"rule_type" gorm:"index;size:16"`             // RuleTypeProposed or RuleTypeFinal
+	Title           string                      `json:"title"`
+	Abstract        string                      `json:"abstract" gorm:"type:text"`
+	Agencies        datatypes.JSONSlice[string] `json:"agencies" gorm:"type:jsonb"`     // Issuing agency names
+	AgencySlugs     datatypes.JSONSlice[string] `json:"agency_slugs" gorm:"type:jsonb"` // e.g., "environmental-protection-agency"
+	RIN             string                      `json:"rin" gorm:"index;size:16"`       // First Regulation Identifier Number, e.g., "2060-AV09"
+	DocketID        string                      `json:"docket_id" gorm:"index;size:64"` // First docket ID, e.g., "EPA-HQ-OAR-2023-0234"
+	PublicationDate time.Time                   `json:"publication_date" gorm:"index"`
+	HTMLURL         string                      `json:"html_url"`
+	ContentHash     string                      `json:"content_hash" gorm:"size:64"` // textnorm.Hash of TextContent
+	TextContent     string                      `json:"text_content" gorm:"type:text"`
+	PlainText       string                      `json:"plain_text" gorm:"type:text"` // textextract.Extract(TextContent); what diffs are computed over
+	FetchedAt       time.Time                   `json:"fetched_at"`
+	CreatedAt       time.Time                   `json:"created_at"`
+	UpdatedAt       time.Time                   `json:"updated_at"`
+}
+
+// TableName returns the table name for Rule
+func (Rule) TableName() string {
+	return "rules"
+}
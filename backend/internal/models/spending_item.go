@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// SpendingItem is a dollar amount extracted from a version's text, located
+// by section and account heading. Items are compared across versions by Key.
+// The composite unique key is (VersionID, Key).
+type SpendingItem struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	VersionID uint      `json:"version_id" gorm:"uniqueIndex:idx_spending_item_unique,priority:1"`
+	Key       string    `json:"key" gorm:"uniqueIndex:idx_spending_item_unique,priority:2;size:512"` // analysis.SpendingItem.Key
+	Section   string    `json:"section" gorm:"size:16"`
+	Account   string    `json:"account"`
+	Context   string    `json:"context" gorm:"type:text"`
+	Raw       string    `json:"raw" gorm:"size:64"` // As written, e.g., "$1,500,000"
+	Amount    int64     `json:"amount"`             // Whole dollars
+	Offset    int       `json:"offset"`             // Byte offset in the markup-stripped text
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for SpendingItem
+func (SpendingItem) TableName() string {
+	return "spending_items"
+}
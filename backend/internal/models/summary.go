@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Summary is a CRS summary of a bill as of one legislative action.
+// The composite unique key is (BillID, VersionCode).
+type Summary struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	BillID      uint      `json:"bill_id" gorm:"uniqueIndex:idx_summary_unique,priority:1"`
+	VersionCode string    `json:"version_code" gorm:"uniqueIndex:idx_summary_unique,priority:2;size:10"` // CRS summary code, e.g., "00"
+	ActionDate  time.Time `json:"action_date" gorm:"index"`
+	ActionDesc  string    `json:"action_desc"`                 // e.g., "Introduced in House"
+	Text        string    `json:"text" gorm:"type:text"`       // HTML as fetched
+	PlainText   string    `json:"plain_text" gorm:"type:text"` // textextract.Extract(Text); what diffs are computed over
+	UpdateDate  string    `json:"update_date"`                 // Congress.gov updateDate string
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for Summary
+func (Summary) TableName() string {
+	return "summaries"
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// Tenant is an organization that keeps private working drafts alongside
+// public bills. Its drafts are bills with its TenantID, readable only with
+// the API keys of its users.
+type Tenant struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"uniqueIndex;size:100"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for Tenant
+func (Tenant) TableName() string {
+	return "tenants"
+}
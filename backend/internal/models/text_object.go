@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// TextObject is version text held by the Postgres text store
+// (TEXT_STORE=postgres), keyed by textstore.Key.
+type TextObject struct {
+	Key       string    `json:"key" gorm:"primaryKey;size:512"`
+	Content   string    `json:"content" gorm:"type:text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for TextObject
+func (TextObject) TableName() string {
+	return "text_objects"
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// LegislativeAction is a step in a Treaty's or Nomination's history, as
+// listed by Congress.gov.
+type LegislativeAction struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	Text string `json:"text"`
+	Type string `json:"type,omitempty"` // e.g., "Committee", "Floor"
+}
+
+// Treaty is a treaty transmitted to the Senate, keyed like its Treaty
+// Document number: the Congress that received it, its number, and for a
+// treaty transmitted in parts, the part's suffix.
+type Treaty struct {
+	ID                 uint                                   `json:"id" gorm:"primaryKey"`
+	Congress           int                                    `json:"congress" gorm:"uniqueIndex:idx_treaty_key,priority:1"` // Congress that received it
+	Number             int                                    `json:"number" gorm:"uniqueIndex:idx_treaty_key,priority:2"`
+	Suffix             string                                 `json:"suffix" gorm:"uniqueIndex:idx_treaty_key,priority:3;size:4"` // e.g., "A"; "" when undivided
+	CongressConsidered int                                    `json:"congress_considered"`
+	Topic              string                                 `json:"topic"`
+	Title              string                                 `json:"title" gorm:"type:text"`
+	TransmittedDate    string                                 `json:"transmitted_date"`
+	InForceDate        string                                 `json:"in_force_date,omitempty"`
+	CurrentStatus      string                                 `json:"current_status" gorm:"type:text"` // Text of the latest action
+	StatusDate         string                                 `json:"status_date" gorm:"index"`        // Date of the latest action
+	UpdateDate         string                                 `json:"update_date"`                     // Congress.gov's update date, to skip unchanged treaties
+	Actions            datatypes.JSONSlice[LegislativeAction] `json:"actions" gorm:"type:jsonb"`       // Oldest first
+	CreatedAt          time.Time                              `json:"created_at"`
+	UpdatedAt          time.Time                              `json:"updated_at"`
+}
+
+// TableName returns the table name for Treaty
+func (Treaty) TableName() string {
+	return "treaties"
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// User roles, from least to most privileged. Each role can do everything
+// the roles before it can.
+const (
+	UserRoleReader = "reader" // Reads data and manages its own watchlist
+	UserRoleEditor = "editor" // Also uploads documents and tenant drafts
+	UserRoleAdmin  = "admin"  // Also uses the admin endpoints
+)
+
+// userRoleRanks orders the roles by privilege.
+var userRoleRanks = map[string]int{UserRoleReader: 1, UserRoleEditor: 2, UserRoleAdmin: 3}
+
+// ValidUserRole reports whether role is a known user role.
+func ValidUserRole(role string) bool {
+	return userRoleRanks[role] > 0
+}
+
+// User is an API consumer identified by an API key or, for end users who
+// sign in with an identity provider, by the provider's subject. Only the
+// key's SHA-256 hash is stored; the key itself is shown once, when the user
+// is created. A user in a tenant can upload and read that tenant's drafts.
+type User struct {
+	ID         uint   `json:"id" gorm:"primaryKey"`
+	Name       string `json:"name"`
+	APIKeyHash string `json:"-" gorm:"uniqueIndex;size:64"`
+	// Subject is the issuer and the provider's user ID of users who sign
+	// in, e.g., "https://accounts.google.com|1234"
+	Subject *string `json:"subject,omitempty" gorm:"uniqueIndex;size:255"`
+	Email   string  `json:"email,omitempty" gorm:"size:320"`
+	// Role is set explicitly on creation; the column default gives users
+	// created before roles existed the write access they already had
+	Role          string     `json:"role" gorm:"size:16;not null;default:editor"`
+	TenantID      *uint      `json:"tenant_id,omitempty" gorm:"index"`
+	LastCheckedAt *time.Time `json:"last_checked_at,omitempty"` // Last watchlist updates check
+	// CalendarTokenHash is the SHA-256 of the secret in the user's
+	// watchlist calendar URL; nil until the user asks for one
+	CalendarTokenHash *string   `json:"-" gorm:"uniqueIndex;size:64"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for User
+func (User) TableName() string {
+	return "users"
+}
+
+// HasRole reports whether the user's role grants role's privileges.
+func (u User) HasRole(role string) bool {
+	return userRoleRanks[u.Role] > 0 && userRoleRanks[u.Role] >= userRoleRanks[role]
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// SavedSearch is a bill search a user saved to be told about new matches.
+// Params holds the search filters in the shape of the /api/v1/lex query.
+type SavedSearch struct {
+	ID        uint              `json:"id" gorm:"primaryKey"`
+	UserID    uint              `json:"user_id" gorm:"index"`
+	Name      string            `json:"name"`
+	Params    datatypes.JSONMap `json:"params" gorm:"type:jsonb"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// TableName returns the table name for SavedSearch
+func (SavedSearch) TableName() string {
+	return "saved_searches"
+}
+
+// WatchedBill is a bill on a user's watchlist.
+// The composite unique key is (UserID, BillID).
+type WatchedBill struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	UserID    uint      `json:"user_id" gorm:"uniqueIndex:idx_watched_bill_unique,priority:1"`
+	BillID    uint      `json:"bill_id" gorm:"uniqueIndex:idx_watched_bill_unique,priority:2;index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for WatchedBill
+func (WatchedBill) TableName() string {
+	return "watched_bills"
+}
@@ -0,0 +1,259 @@
+// Package opensearch indexes bills, their text versions, and their actions
+// into an OpenSearch or Elasticsearch cluster and searches them there, for
+// the full-text searches the database and the in-memory index can't
+// answer: phrases, proximity, and highlighted excerpts of bill text.
+//
+// The cluster is optional and holds nothing that isn't in the database, so
+// its indices can be dropped and rebuilt by the next Sync at any time.
+package opensearch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultTimeout = 30 * time.Second
+
+// maxErrorBody bounds how much of an error response is kept in the error.
+const maxErrorBody = 1024
+
+// ErrInvalidStatus is returned when the cluster answers a request with an
+// unexpected status code.
+var ErrInvalidStatus = errors.New("opensearch: unexpected status code")
+
+// Client is an OpenSearch client. It is safe for concurrent use.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	username   string
+	password   string
+	prefix     string
+}
+
+// Option is a functional option for configuring the Client.
+type Option func(*Client)
+
+// WithHTTPClient sets a custom HTTP client for cluster requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		if client != nil {
+			c.httpClient = client
+		}
+	}
+}
+
+// WithBasicAuth authenticates requests with a username and password.
+func WithBasicAuth(username, password string) Option {
+	return func(c *Client) {
+		c.username, c.password = username, password
+	}
+}
+
+// WithIndexPrefix prefixes the names of the indices the client uses, so
+// several deployments can share a cluster. The default is "deltagov-".
+func WithIndexPrefix(prefix string) Option {
+	return func(c *Client) {
+		c.prefix = prefix
+	}
+}
+
+// NewClient creates a client of the cluster at baseURL, e.g.,
+// "http://localhost:9200".
+func NewClient(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		httpClient: &http.Client{
+			Timeout: defaultTimeout,
+		},
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		prefix:  "deltagov-",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// FromEnv returns a client configured by environment variables, or nil
+// when OPENSEARCH_URL is unset:
+//
+//	OPENSEARCH_URL           cluster URL, e.g. "http://localhost:9200"
+//	OPENSEARCH_USERNAME      basic auth username (optional)
+//	OPENSEARCH_PASSWORD      basic auth password (optional)
+//	OPENSEARCH_INDEX_PREFIX  index name prefix (default: "deltagov-")
+func FromEnv() *Client {
+	url := os.Getenv("OPENSEARCH_URL")
+	if url == "" {
+		return nil
+	}
+	opts := []Option{WithBasicAuth(os.Getenv("OPENSEARCH_USERNAME"), os.Getenv("OPENSEARCH_PASSWORD"))}
+	if prefix := os.Getenv("OPENSEARCH_INDEX_PREFIX"); prefix != "" {
+		opts = append(opts, WithIndexPrefix(prefix))
+	}
+	return NewClient(url, opts...)
+}
+
+// Index returns the name of the index holding documents of a kind.
+func (c *Client) Index(kind string) string {
+	return c.prefix + kind
+}
+
+// do sends a request and decodes a successful JSON response into out,
+// when out isn't nil. A 404 is an error unless allowNotFound is set, in
+// which case do reports false.
+func (c *Client) do(ctx context.Context, method, path, contentType string, body []byte, out any, allowNotFound bool) (bool, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return false, fmt.Errorf("opensearch: failed to create request: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("opensearch: %s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound && allowNotFound {
+		return false, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return false, fmt.Errorf("%w: %s %s returned %d: %s", ErrInvalidStatus, method, path, resp.StatusCode, bytes.TrimSpace(msg))
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return false, fmt.Errorf("opensearch: failed to decode %s %s response: %w", method, path, err)
+		}
+	}
+	return true, nil
+}
+
+// doJSON sends a JSON request body, or none when body is nil.
+func (c *Client) doJSON(ctx context.Context, method, path string, body, out any) error {
+	var data []byte
+	if body != nil {
+		var err error
+		if data, err = json.Marshal(body); err != nil {
+			return fmt.Errorf("opensearch: failed to encode request: %w", err)
+		}
+	}
+	_, err := c.do(ctx, method, path, "application/json", data, out, false)
+	return err
+}
+
+// EnsureIndices creates the indices that don't exist yet, with their
+// mappings. Existing indices are left as they are; to change an index's
+// mappings, delete it and let the next Sync rebuild it.
+func (c *Client) EnsureIndices(ctx context.Context) error {
+	for _, kind := range Kinds {
+		exists, err := c.do(ctx, http.MethodHead, "/"+c.Index(kind), "", nil, nil, true)
+		if err != nil {
+			return err
+		}
+		if exists {
+			continue
+		}
+		if err := c.doJSON(ctx, http.MethodPut, "/"+c.Index(kind), indexBody(kind), nil); err != nil {
+			return fmt.Errorf("opensearch: failed to create index %s: %w", c.Index(kind), err)
+		}
+	}
+	return nil
+}
+
+// Document is a document to index: its kind, ID within the kind, and body.
+type Document struct {
+	Kind string
+	ID   uint
+	Body any
+}
+
+// Bulk indexes documents in one request, replacing any with the same kind
+// and ID. It fails if the cluster rejects any of them.
+func (c *Client) Bulk(ctx context.Context, docs []Document) error {
+	if len(docs) == 0 {
+		return nil
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, d := range docs {
+		action := map[string]any{"index": map[string]any{"_index": c.Index(d.Kind), "_id": fmt.Sprint(d.ID)}}
+		if err := enc.Encode(action); err != nil {
+			return fmt.Errorf("opensearch: failed to encode bulk action: %w", err)
+		}
+		if err := enc.Encode(d.Body); err != nil {
+			return fmt.Errorf("opensearch: failed to encode %s %d: %w", d.Kind, d.ID, err)
+		}
+	}
+
+	var resp struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID     string          `json:"_id"`
+			Status int             `json:"status"`
+			Error  json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if _, err := c.do(ctx, http.MethodPost, "/_bulk", "application/x-ndjson", buf.Bytes(), &resp, false); err != nil {
+		return err
+	}
+	if !resp.Errors {
+		return nil
+	}
+	failed, first := 0, ""
+	for _, item := range resp.Items {
+		for _, result := range item {
+			if result.Status > 299 {
+				if failed == 0 {
+					first = fmt.Sprintf("document %s: %s", result.ID, result.Error)
+				}
+				failed++
+			}
+		}
+	}
+	return fmt.Errorf("opensearch: %d of %d documents rejected, first %s", failed, len(docs), first)
+}
+
+// Newest returns the latest value of a date field in a kind's index, or
+// the zero time when the index is empty.
+func (c *Client) Newest(ctx context.Context, kind, field string) (time.Time, error) {
+	var resp struct {
+		Aggregations struct {
+			Newest struct {
+				ValueAsString string `json:"value_as_string"`
+			} `json:"newest"`
+		} `json:"aggregations"`
+	}
+	body := map[string]any{
+		"size": 0,
+		"aggs": map[string]any{"newest": map[string]any{"max": map[string]any{"field": field, "format": "strict_date_time"}}},
+	}
+	if err := c.doJSON(ctx, http.MethodPost, "/"+c.Index(kind)+"/_search", body, &resp); err != nil {
+		return time.Time{}, err
+	}
+	if resp.Aggregations.Newest.ValueAsString == "" {
+		return time.Time{}, nil
+	}
+	newest, err := time.Parse(time.RFC3339Nano, resp.Aggregations.Newest.ValueAsString)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("opensearch: failed to parse newest %s %s: %w", kind, field, err)
+	}
+	return newest, nil
+}
@@ -0,0 +1,141 @@
+package opensearch_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/opensearch"
+)
+
+func TestEnsureIndices(t *testing.T) {
+	var created []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodHead && r.URL.Path == "/test-bills":
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodHead:
+			http.NotFound(w, r)
+		case r.Method == http.MethodPut:
+			var body struct {
+				Mappings struct {
+					Dynamic    string                    `json:"dynamic"`
+					Properties map[string]map[string]any `json:"properties"`
+				} `json:"mappings"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode %s: %v", r.URL.Path, err)
+			}
+			if body.Mappings.Dynamic != "strict" || body.Mappings.Properties["text"]["analyzer"] != "legal" {
+				t.Errorf("%s: unexpected mappings %+v", r.URL.Path, body.Mappings)
+			}
+			created = append(created, r.URL.Path)
+			io.WriteString(w, `{"acknowledged":true}`)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	c := opensearch.NewClient(srv.URL, opensearch.WithHTTPClient(srv.Client()), opensearch.WithIndexPrefix("test-"))
+	if err := c.EnsureIndices(context.Background()); err != nil {
+		t.Fatalf("EnsureIndices: %v", err)
+	}
+	if want := []string{"/test-versions", "/test-actions"}; !reflect.DeepEqual(created, want) {
+		t.Errorf("created %v, want %v", created, want)
+	}
+}
+
+func TestBulk(t *testing.T) {
+	var lines []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_bulk" || r.Header.Get("Content-Type") != "application/x-ndjson" {
+			t.Errorf("unexpected %s %s (%s)", r.Method, r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		if user, pass, _ := r.BasicAuth(); user != "indexer" || pass != "secret" {
+			t.Errorf("basic auth = %q, %q", user, pass)
+		}
+		scanner := bufio.NewScanner(r.Body)
+		for scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		io.WriteString(w, `{"errors":true,"items":[
+			{"index":{"_id":"1","status":201}},
+			{"index":{"_id":"2","status":400,"error":{"type":"strict_dynamic_mapping_exception"}}}
+		]}`)
+	}))
+	defer srv.Close()
+
+	c := opensearch.NewClient(srv.URL, opensearch.WithHTTPClient(srv.Client()), opensearch.WithBasicAuth("indexer", "secret"))
+	err := c.Bulk(context.Background(), []opensearch.Document{
+		{Kind: opensearch.KindBill, ID: 1, Body: opensearch.BillDoc{BillID: 1, Title: "One Big Beautiful Bill Act"}},
+		{Kind: opensearch.KindAction, ID: 2, Body: opensearch.ActionDoc{BillID: 1, EventType: "became_law"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 documents rejected") || !strings.Contains(err.Error(), "strict_dynamic_mapping_exception") {
+		t.Errorf("Bulk error = %v, want the rejected document reported", err)
+	}
+	if len(lines) != 4 {
+		t.Fatalf("got %d bulk lines, want 4: %q", len(lines), lines)
+	}
+	if lines[0] != `{"index":{"_id":"1","_index":"deltagov-bills"}}` || lines[2] != `{"index":{"_id":"2","_index":"deltagov-actions"}}` {
+		t.Errorf("unexpected bulk actions %q, %q", lines[0], lines[2])
+	}
+}
+
+func TestSearch(t *testing.T) {
+	var got map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/deltagov-versions,deltagov-actions/_search" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		io.WriteString(w, `{"hits":{"total":{"value":12},"hits":[{
+			"_index":"deltagov-versions","_id":"77","_score":4.5,
+			"_source":{"bill_id":3,"congress":119,"bill_type":"hr","bill_number":1,"title":"One Big Beautiful Bill Act","version_code":"EH"},
+			"highlight":{
+				"text":["funds <mark>shall</mark> <mark>not</mark> be used"],
+				"text.exact":["funds <mark>shall</mark> <mark>not</mark> be used","no <mark>shall</mark> <mark>not</mark>"]
+			}
+		}]}}`)
+	}))
+	defer srv.Close()
+
+	c := opensearch.NewClient(srv.URL, opensearch.WithHTTPClient(srv.Client()))
+	results, err := c.Search(context.Background(), opensearch.Query{
+		Text:     "shall not",
+		Phrase:   true,
+		Slop:     2,
+		Kinds:    []string{opensearch.KindVersion, opensearch.KindAction},
+		BillType: "HR",
+		Limit:    10,
+	})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+
+	query := got["query"].(map[string]any)["bool"].(map[string]any)
+	match := query["must"].(map[string]any)["multi_match"].(map[string]any)
+	if match["type"] != "phrase" || match["slop"] != float64(2) || match["fields"].([]any)[0] != "title.exact^3" {
+		t.Errorf("unexpected phrase query %v", match)
+	}
+	if filter := query["filter"].([]any); len(filter) != 1 || filter[0].(map[string]any)["term"].(map[string]any)["bill_type"] != "hr" {
+		t.Errorf("unexpected filters %v", query["filter"])
+	}
+
+	want := &opensearch.Results{Total: 12, Hits: []opensearch.Hit{{
+		Kind: opensearch.KindVersion, ID: 77, BillID: 3, Congress: 119, BillType: "hr", BillNumber: 1,
+		Title: "One Big Beautiful Bill Act", VersionCode: "EH", Score: 4.5,
+		Highlights: []string{"funds <mark>shall</mark> <mark>not</mark> be used", "no <mark>shall</mark> <mark>not</mark>"},
+	}}}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("Search = %+v, want %+v", results, want)
+	}
+}
@@ -0,0 +1,271 @@
+package opensearch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/archive"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/metrics"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// DefaultBatchSize is the number of documents sent per bulk request when
+// no batch size is set. Versions are sent in batches a tenth the size, as
+// their text is long.
+const DefaultBatchSize = 500
+
+// Indexer copies bills, versions, and actions from the database into the
+// cluster: public bills (not tenants' drafts) within scope, and their
+// versions and actions.
+type Indexer struct {
+	db        *gorm.DB
+	client    *Client
+	scope     func(*gorm.DB) *gorm.DB
+	batchSize int
+}
+
+// NewIndexer creates an Indexer. scope restricts the bills indexed, such as
+// scope.Rules.Query; nil indexes every public bill.
+func NewIndexer(db *gorm.DB, client *Client, scope func(*gorm.DB) *gorm.DB) *Indexer {
+	if scope == nil {
+		scope = func(db *gorm.DB) *gorm.DB { return db }
+	}
+	return &Indexer{db: db, client: client, scope: scope, batchSize: DefaultBatchSize}
+}
+
+// SetBatchSize sets the number of documents sent per bulk request.
+func (ix *Indexer) SetBatchSize(n int) {
+	if n > 0 {
+		ix.batchSize = n
+	}
+}
+
+// SyncResult counts the documents a Sync indexed, by kind.
+type SyncResult struct {
+	Bills    int
+	Versions int
+	Actions  int
+}
+
+// Sync creates missing indices and indexes everything that changed since
+// the newest document of each kind already indexed, so the first Sync
+// indexes everything and later ones only what is new. Documents at the
+// newest time are indexed again, as others may share it; indexing is
+// idempotent.
+func (ix *Indexer) Sync(ctx context.Context) (*SyncResult, error) {
+	if err := ix.client.EnsureIndices(ctx); err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{}
+	for _, step := range []struct {
+		kind  string
+		count *int
+		sync  func(context.Context, time.Time) (int, error)
+	}{
+		{KindBill, &result.Bills, ix.syncBills},
+		{KindVersion, &result.Versions, ix.syncVersions},
+		{KindAction, &result.Actions, ix.syncActions},
+	} {
+		since, err := ix.client.Newest(ctx, step.kind, syncField[step.kind])
+		if err != nil {
+			return result, err
+		}
+		n, err := step.sync(ctx, since)
+		*step.count = n
+		metrics.SearchBackendDocuments.WithLabelValues(step.kind).Add(float64(n))
+		if err != nil {
+			return result, err
+		}
+	}
+	logging.FromContext(ctx).Info("search backend synced",
+		"bills", result.Bills, "versions", result.Versions, "actions", result.Actions)
+	return result, nil
+}
+
+// after restricts a query on a table to rows changed at or after since,
+// past the (time, ID) cursor of the previous batch, in cursor order.
+func after(db *gorm.DB, column string, since, lastAt time.Time, lastID uint) *gorm.DB {
+	db = db.Where(column+" >= ?", since).Order(column + ", id")
+	if lastID != 0 {
+		db = db.Where("("+column+", id) > (?, ?)", lastAt, lastID)
+	}
+	return db
+}
+
+// syncBills indexes the bills updated since a time.
+func (ix *Indexer) syncBills(ctx context.Context, since time.Time) (int, error) {
+	total, lastAt, lastID := 0, time.Time{}, uint(0)
+	for {
+		var bills []models.Bill
+		if err := after(ix.db.WithContext(ctx).Scopes(ix.scope).Where("tenant_id = 0"), "updated_at", since, lastAt, lastID).
+			Limit(ix.batchSize).Find(&bills).Error; err != nil {
+			return total, fmt.Errorf("opensearch: failed to load bills: %w", err)
+		}
+		if len(bills) == 0 {
+			return total, nil
+		}
+
+		ids := make([]uint, len(bills))
+		for i, b := range bills {
+			ids[i] = b.ID
+		}
+		var aliases []models.BillAlias
+		if err := ix.db.WithContext(ctx).Select("bill_id", "alias").Where("bill_id IN ?", ids).Find(&aliases).Error; err != nil {
+			return total, fmt.Errorf("opensearch: failed to load aliases: %w", err)
+		}
+		byBill := make(map[uint][]string)
+		for _, a := range aliases {
+			byBill[a.BillID] = append(byBill[a.BillID], a.Alias)
+		}
+
+		docs := make([]Document, len(bills))
+		for i, b := range bills {
+			docs[i] = Document{Kind: KindBill, ID: b.ID, Body: BillDoc{
+				BillID:         b.ID,
+				Congress:       b.Congress,
+				BillType:       strings.ToLower(b.BillType),
+				BillNumber:     b.BillNumber,
+				Jurisdiction:   b.Jurisdiction,
+				State:          b.StateCode,
+				Title:          b.Title,
+				Aliases:        byBill[b.ID],
+				Sponsor:        b.Sponsor,
+				CurrentStatus:  b.CurrentStatus,
+				PolicyArea:     b.PolicyArea,
+				Subjects:       b.Subjects,
+				IntroducedDate: b.IntroducedDate,
+				UpdatedAt:      b.UpdatedAt,
+			}}
+		}
+		if err := ix.client.Bulk(ctx, docs); err != nil {
+			return total, err
+		}
+		total += len(docs)
+		last := bills[len(bills)-1]
+		lastAt, lastID = last.UpdatedAt, last.ID
+	}
+}
+
+// syncVersions indexes the bill versions stored since a time.
+func (ix *Indexer) syncVersions(ctx context.Context, since time.Time) (int, error) {
+	batch := max(ix.batchSize/10, 1)
+	columns := append([]string{"id", "bill_id", "version_code", "fetched_at", "created_at"}, archive.TextColumns...)
+	total, lastAt, lastID := 0, time.Time{}, uint(0)
+	for {
+		var versions []models.Version
+		if err := after(ix.db.WithContext(ctx).Select(columns).Where("bill_id <> 0"), "created_at", since, lastAt, lastID).
+			Limit(batch).Find(&versions).Error; err != nil {
+			return total, fmt.Errorf("opensearch: failed to load versions: %w", err)
+		}
+		if len(versions) == 0 {
+			return total, nil
+		}
+
+		billIDs := make([]uint, len(versions))
+		for i, v := range versions {
+			billIDs[i] = v.BillID
+		}
+		bills, err := ix.bills(ctx, billIDs)
+		if err != nil {
+			return total, err
+		}
+
+		docs := make([]Document, 0, len(versions))
+		for i := range versions {
+			v := &versions[i]
+			bill, ok := bills[v.BillID]
+			if !ok {
+				continue // A draft or out of scope
+			}
+			if err := archive.Rehydrate(v); err != nil {
+				return total, err
+			}
+			docs = append(docs, Document{Kind: KindVersion, ID: v.ID, Body: VersionDoc{
+				BillID:      bill.ID,
+				Congress:    bill.Congress,
+				BillType:    strings.ToLower(bill.BillType),
+				BillNumber:  bill.BillNumber,
+				Title:       bill.Title,
+				VersionCode: v.VersionCode,
+				Text:        v.PlainText,
+				FetchedAt:   v.FetchedAt,
+				CreatedAt:   v.CreatedAt,
+			}})
+		}
+		if err := ix.client.Bulk(ctx, docs); err != nil {
+			return total, err
+		}
+		total += len(docs)
+		last := versions[len(versions)-1]
+		lastAt, lastID = last.CreatedAt, last.ID
+	}
+}
+
+// syncActions indexes the bill events recorded since a time.
+func (ix *Indexer) syncActions(ctx context.Context, since time.Time) (int, error) {
+	total, lastAt, lastID := 0, time.Time{}, uint(0)
+	for {
+		var events []models.BillEvent
+		if err := after(ix.db.WithContext(ctx), "created_at", since, lastAt, lastID).
+			Limit(ix.batchSize).Find(&events).Error; err != nil {
+			return total, fmt.Errorf("opensearch: failed to load bill events: %w", err)
+		}
+		if len(events) == 0 {
+			return total, nil
+		}
+
+		billIDs := make([]uint, len(events))
+		for i, e := range events {
+			billIDs[i] = e.BillID
+		}
+		bills, err := ix.bills(ctx, billIDs)
+		if err != nil {
+			return total, err
+		}
+
+		docs := make([]Document, 0, len(events))
+		for _, e := range events {
+			bill, ok := bills[e.BillID]
+			if !ok {
+				continue
+			}
+			docs = append(docs, Document{Kind: KindAction, ID: e.ID, Body: ActionDoc{
+				BillID:     bill.ID,
+				Congress:   bill.Congress,
+				BillType:   strings.ToLower(bill.BillType),
+				BillNumber: bill.BillNumber,
+				Title:      bill.Title,
+				EventType:  e.EventType,
+				Text:       e.NewValue,
+				OccurredAt: e.OccurredAt,
+				CreatedAt:  e.CreatedAt,
+			}})
+		}
+		if err := ix.client.Bulk(ctx, docs); err != nil {
+			return total, err
+		}
+		total += len(docs)
+		last := events[len(events)-1]
+		lastAt, lastID = last.CreatedAt, last.ID
+	}
+}
+
+// bills loads the indexed bills among ids, keyed by ID.
+func (ix *Indexer) bills(ctx context.Context, ids []uint) (map[uint]*models.Bill, error) {
+	var bills []models.Bill
+	if err := ix.db.WithContext(ctx).Select("id", "congress", "bill_type", "number", "bill_number", "title").
+		Scopes(ix.scope).Where("tenant_id = 0 AND id IN ?", ids).Find(&bills).Error; err != nil {
+		return nil, fmt.Errorf("opensearch: failed to load bills: %w", err)
+	}
+	byID := make(map[uint]*models.Bill, len(bills))
+	for i := range bills {
+		byID[bills[i].ID] = &bills[i]
+	}
+	return byID, nil
+}
@@ -0,0 +1,163 @@
+package opensearch
+
+import "time"
+
+// Kinds of documents, each kept in its own index.
+const (
+	KindBill    = "bills"
+	KindVersion = "versions"
+	KindAction  = "actions"
+)
+
+// Kinds lists every kind of document.
+var Kinds = []string{KindBill, KindVersion, KindAction}
+
+// BillDoc is the document indexed for a bill.
+type BillDoc struct {
+	BillID         uint      `json:"bill_id"`
+	Congress       int       `json:"congress"`
+	BillType       string    `json:"bill_type"` // Lower case, e.g., "hr"
+	BillNumber     int       `json:"bill_number"`
+	Jurisdiction   string    `json:"jurisdiction"`
+	State          string    `json:"state,omitempty"`
+	Title          string    `json:"title"`
+	Aliases        []string  `json:"aliases,omitempty"`
+	Sponsor        string    `json:"sponsor,omitempty"`
+	CurrentStatus  string    `json:"current_status,omitempty"`
+	PolicyArea     string    `json:"policy_area,omitempty"`
+	Subjects       []string  `json:"subjects,omitempty"`
+	IntroducedDate string    `json:"introduced_date,omitempty"` // YYYY-MM-DD
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// VersionDoc is the document indexed for a text version of a bill.
+type VersionDoc struct {
+	BillID      uint      `json:"bill_id"`
+	Congress    int       `json:"congress"`
+	BillType    string    `json:"bill_type"`
+	BillNumber  int       `json:"bill_number"`
+	Title       string    `json:"title"` // The bill's
+	VersionCode string    `json:"version_code"`
+	Text        string    `json:"text"` // Plain text, as diffed
+	FetchedAt   time.Time `json:"fetched_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ActionDoc is the document indexed for an action on a bill: a change the
+// ingestor detected, such as a new status or title.
+type ActionDoc struct {
+	BillID     uint      `json:"bill_id"`
+	Congress   int       `json:"congress"`
+	BillType   string    `json:"bill_type"`
+	BillNumber int       `json:"bill_number"`
+	Title      string    `json:"title"` // The bill's
+	EventType  string    `json:"event_type"`
+	Text       string    `json:"text"` // The new value, e.g., the status
+	OccurredAt time.Time `json:"occurred_at"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// syncField is the date field of each kind that Sync resumes from: a
+// document is reindexed whenever it changes after the newest one indexed.
+var syncField = map[string]string{
+	KindBill:    "updated_at",
+	KindVersion: "created_at",
+	KindAction:  "created_at",
+}
+
+// settings define the analyzers for legal text. "legal" stems lightly so
+// "appropriation" finds "appropriations", and spells out section and
+// paragraph signs so "§ 101" finds "section 101". Stop words are kept:
+// in statutes "shall" and "not" carry the meaning. "legal_exact" is the
+// same without stemming, for phrases that must match word for word.
+var settings = map[string]any{
+	"analysis": map[string]any{
+		"char_filter": map[string]any{
+			"legal_signs": map[string]any{
+				"type":     "mapping",
+				"mappings": []string{"§ => section ", "¶ => paragraph "},
+			},
+		},
+		"filter": map[string]any{
+			"legal_possessive": map[string]any{"type": "stemmer", "language": "possessive_english"},
+			"legal_stemmer":    map[string]any{"type": "stemmer", "language": "light_english"},
+		},
+		"analyzer": map[string]any{
+			"legal": map[string]any{
+				"tokenizer":   "standard",
+				"char_filter": []string{"legal_signs"},
+				"filter":      []string{"lowercase", "asciifolding", "legal_possessive", "legal_stemmer"},
+			},
+			"legal_exact": map[string]any{
+				"tokenizer":   "standard",
+				"char_filter": []string{"legal_signs"},
+				"filter":      []string{"lowercase", "asciifolding"},
+			},
+		},
+	},
+}
+
+// legalText maps a field searched as legal text, with an exact subfield.
+// Offsets are stored so long texts highlight without reanalysis.
+var legalText = map[string]any{
+	"type":          "text",
+	"analyzer":      "legal",
+	"index_options": "offsets",
+	"fields": map[string]any{
+		"exact": map[string]any{"type": "text", "analyzer": "legal_exact", "index_options": "offsets"},
+	},
+}
+
+var keyword = map[string]any{"type": "keyword"}
+
+// billFields are the fields that identify a bill, on every kind.
+var billFields = map[string]any{
+	"bill_id":     map[string]any{"type": "long"},
+	"congress":    map[string]any{"type": "integer"},
+	"bill_type":   keyword,
+	"bill_number": map[string]any{"type": "integer"},
+	"title":       legalText,
+}
+
+// properties are the fields of each kind besides billFields.
+var properties = map[string]map[string]any{
+	KindBill: {
+		"jurisdiction":    keyword,
+		"state":           keyword,
+		"aliases":         legalText,
+		"sponsor":         map[string]any{"type": "text"},
+		"current_status":  legalText,
+		"policy_area":     keyword,
+		"subjects":        keyword,
+		"introduced_date": map[string]any{"type": "date", "format": "yyyy-MM-dd"},
+		"updated_at":      map[string]any{"type": "date"},
+	},
+	KindVersion: {
+		"version_code": keyword,
+		"text":         legalText,
+		"fetched_at":   map[string]any{"type": "date"},
+		"created_at":   map[string]any{"type": "date"},
+	},
+	KindAction: {
+		"event_type":  keyword,
+		"text":        legalText,
+		"occurred_at": map[string]any{"type": "date"},
+		"created_at":  map[string]any{"type": "date"},
+	},
+}
+
+// indexBody returns the settings and mappings of a kind's index. Unknown
+// fields are rejected rather than mapped by guesswork.
+func indexBody(kind string) map[string]any {
+	props := make(map[string]any, len(billFields)+len(properties[kind]))
+	for name, field := range billFields {
+		props[name] = field
+	}
+	for name, field := range properties[kind] {
+		props[name] = field
+	}
+	return map[string]any{
+		"settings": settings,
+		"mappings": map[string]any{"dynamic": "strict", "properties": props},
+	}
+}
@@ -0,0 +1,222 @@
+package opensearch
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Highlight tags around matched words in excerpts.
+const (
+	HighlightPre  = "<mark>"
+	HighlightPost = "</mark>"
+)
+
+// excerptSize and maxExcerpts bound the excerpts returned per field.
+const (
+	excerptSize = 200
+	maxExcerpts = 3
+)
+
+// Query is a full-text search of the indexed documents.
+type Query struct {
+	// Text is searched for in titles, aliases, sponsors, statuses, version
+	// text, and actions. Unless Phrase is set, every word must match, and
+	// Text may use the simple query syntax: "quoted phrases" match word
+	// for word, a phrase followed by ~N matches its words up to N
+	// positions apart, -word excludes, a | b matches either, and word*
+	// matches prefixes.
+	Text string
+
+	// Phrase matches Text word for word, as one phrase; Slop allows its
+	// words up to that many positions apart or out of order.
+	Phrase bool
+	Slop   int
+
+	Kinds    []string // Kinds of documents to search (default: all)
+	Congress int      // 0 = any
+	BillType string   // Case-insensitive; "" = any
+	BillID   uint     // 0 = any
+	Limit    int
+	Offset   int
+}
+
+// Hit is a document matching a search.
+type Hit struct {
+	Kind        string
+	ID          uint // Of the bill, version, or bill event
+	BillID      uint
+	Congress    int
+	BillType    string
+	BillNumber  int
+	Title       string // The bill's
+	VersionCode string // Versions only
+	EventType   string // Actions only
+	Score       float64
+	Highlights  []string // Excerpts around the matches, matched words between HighlightPre and HighlightPost
+}
+
+// Results are a page of the documents matching a search, best first.
+type Results struct {
+	Total int // Documents matching in all
+	Hits  []Hit
+}
+
+// searchFields are the fields Text is matched against, with title and
+// alias matches weighted above matches in long text.
+var searchFields = []string{"title^3", "aliases^3", "sponsor^2", "current_status", "text"}
+
+// highlightFields are the fields excerpts are taken from.
+var highlightFields = []string{"title", "aliases", "current_status", "text"}
+
+// body returns the search request body of a query.
+func (q Query) body() map[string]any {
+	var match map[string]any
+	if q.Phrase {
+		fields := make([]string, len(searchFields))
+		for i, f := range searchFields {
+			name, boost, _ := strings.Cut(f, "^")
+			if name != "sponsor" {
+				name += ".exact"
+			}
+			if boost != "" {
+				name += "^" + boost
+			}
+			fields[i] = name
+		}
+		match = map[string]any{"multi_match": map[string]any{
+			"query":  q.Text,
+			"type":   "phrase",
+			"fields": fields,
+			"slop":   q.Slop,
+		}}
+	} else {
+		match = map[string]any{"simple_query_string": map[string]any{
+			"query":              q.Text,
+			"fields":             searchFields,
+			"default_operator":   "and",
+			"quote_field_suffix": ".exact",
+			"flags":              "AND|OR|NOT|PHRASE|SLOP|PREFIX|PRECEDENCE|WHITESPACE|ESCAPE",
+		}}
+	}
+
+	var filters []any
+	if q.Congress > 0 {
+		filters = append(filters, map[string]any{"term": map[string]any{"congress": q.Congress}})
+	}
+	if q.BillType != "" {
+		filters = append(filters, map[string]any{"term": map[string]any{"bill_type": strings.ToLower(q.BillType)}})
+	}
+	if q.BillID > 0 {
+		filters = append(filters, map[string]any{"term": map[string]any{"bill_id": q.BillID}})
+	}
+	boolQuery := map[string]any{"must": match}
+	if len(filters) > 0 {
+		boolQuery["filter"] = filters
+	}
+
+	highlight := map[string]any{}
+	for _, f := range highlightFields {
+		highlight[f] = map[string]any{}
+		highlight[f+".exact"] = map[string]any{}
+	}
+
+	return map[string]any{
+		"query":            map[string]any{"bool": boolQuery},
+		"from":             q.Offset,
+		"size":             q.Limit,
+		"track_total_hits": true,
+		"_source":          map[string]any{"excludes": []string{"text"}},
+		"highlight": map[string]any{
+			"type":                "unified",
+			"require_field_match": false,
+			"fragment_size":       excerptSize,
+			"number_of_fragments": maxExcerpts,
+			"pre_tags":            []string{HighlightPre},
+			"post_tags":           []string{HighlightPost},
+			"fields":              highlight,
+		},
+	}
+}
+
+// Search returns a page of the documents matching q.
+func (c *Client) Search(ctx context.Context, q Query) (*Results, error) {
+	kinds := q.Kinds
+	if len(kinds) == 0 {
+		kinds = Kinds
+	}
+	indices := make([]string, len(kinds))
+	for i, k := range kinds {
+		indices[i] = c.Index(k)
+	}
+
+	var resp struct {
+		Hits struct {
+			Total struct {
+				Value int `json:"value"`
+			} `json:"total"`
+			Hits []struct {
+				Index     string              `json:"_index"`
+				ID        string              `json:"_id"`
+				Score     float64             `json:"_score"`
+				Source    json.RawMessage     `json:"_source"`
+				Highlight map[string][]string `json:"highlight"`
+			} `json:"hits"`
+		} `json:"hits"`
+	}
+	path := "/" + strings.Join(indices, ",") + "/_search?ignore_unavailable=true"
+	if err := c.doJSON(ctx, http.MethodPost, path, q.body(), &resp); err != nil {
+		return nil, err
+	}
+
+	results := &Results{Total: resp.Hits.Total.Value, Hits: make([]Hit, 0, len(resp.Hits.Hits))}
+	for _, h := range resp.Hits.Hits {
+		var source struct {
+			BillID      uint   `json:"bill_id"`
+			Congress    int    `json:"congress"`
+			BillType    string `json:"bill_type"`
+			BillNumber  int    `json:"bill_number"`
+			Title       string `json:"title"`
+			VersionCode string `json:"version_code"`
+			EventType   string `json:"event_type"`
+		}
+		if len(h.Source) > 0 {
+			if err := json.Unmarshal(h.Source, &source); err != nil {
+				return nil, fmt.Errorf("opensearch: failed to decode hit %s: %w", h.ID, err)
+			}
+		}
+		id, _ := strconv.ParseUint(h.ID, 10, 64)
+		results.Hits = append(results.Hits, Hit{
+			Kind:        strings.TrimPrefix(h.Index, c.prefix),
+			ID:          uint(id),
+			BillID:      source.BillID,
+			Congress:    source.Congress,
+			BillType:    source.BillType,
+			BillNumber:  source.BillNumber,
+			Title:       source.Title,
+			VersionCode: source.VersionCode,
+			EventType:   source.EventType,
+			Score:       h.Score,
+			Highlights:  excerpts(h.Highlight),
+		})
+	}
+	return results, nil
+}
+
+// excerpts merges the excerpts of each field and its exact subfield, in
+// the order of highlightFields, without repeats.
+func excerpts(byField map[string][]string) []string {
+	var out []string
+	for _, f := range highlightFields {
+		for _, e := range slices.Concat(byField[f], byField[f+".exact"]) {
+			if !slices.Contains(out, e) {
+				out = append(out, e)
+			}
+		}
+	}
+	return out
+}
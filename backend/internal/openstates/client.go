@@ -0,0 +1,332 @@
+// Package openstates is a client for the Open States API v3
+// (https://v3.openstates.org), which aggregates bills, versions, and
+// sponsors from all fifty state legislatures.
+package openstates
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/logging"
+)
+
+const (
+	baseURL        = "https://v3.openstates.org"
+	defaultTimeout = 30 * time.Second
+
+	// MaxPerPage is the largest page size the bills endpoint accepts.
+	MaxPerPage = 20
+)
+
+// Errors returned by the client.
+var (
+	ErrNoAPIKey      = errors.New("openstates: API key is required")
+	ErrInvalidStatus = errors.New("openstates: unexpected status code")
+	ErrRateLimited   = errors.New("openstates: rate limit exceeded")
+	ErrNotFound      = errors.New("openstates: resource not found")
+)
+
+// Client is an Open States API v3 client. It is safe for concurrent use.
+type Client struct {
+	apiKey     string
+	httpClient *http.Client
+	baseURL    string
+}
+
+// Option is a functional option for configuring the Client.
+type Option func(*Client)
+
+// WithAPIKey sets the Open States API key.
+func WithAPIKey(key string) Option {
+	return func(c *Client) {
+		c.apiKey = key
+	}
+}
+
+// WithHTTPClient sets a custom HTTP client for API requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		if client != nil {
+			c.httpClient = client
+		}
+	}
+}
+
+// WithBaseURL overrides the default API base URL.
+// Useful for testing with mock servers.
+func WithBaseURL(url string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimSuffix(url, "/")
+	}
+}
+
+// NewClient creates a new Open States client with the given options.
+// Returns an error if the API key is not provided.
+func NewClient(opts ...Option) (*Client, error) {
+	c := &Client{
+		httpClient: &http.Client{
+			Timeout: defaultTimeout,
+		},
+		baseURL: baseURL,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.apiKey == "" {
+		return nil, ErrNoAPIKey
+	}
+	return c, nil
+}
+
+// Bill is a state bill from the /bills endpoint, with versions and
+// sponsorships included.
+type Bill struct {
+	ID                      string        `json:"id"` // Open Civic Data ID, e.g., "ocd-bill/..."
+	Session                 string        `json:"session"`
+	Jurisdiction            Jurisdiction  `json:"jurisdiction"`
+	FromOrganization        Organization  `json:"from_organization"`
+	Identifier              string        `json:"identifier"` // e.g., "AB 1"
+	Title                   string        `json:"title"`
+	Classification          []string      `json:"classification"`
+	Subject                 []string      `json:"subject"`
+	UpdatedAt               string        `json:"updated_at"`
+	LatestActionDescription string        `json:"latest_action_description"`
+	LatestActionDate        string        `json:"latest_action_date"`
+	FirstActionDate         string        `json:"first_action_date"`
+	OpenStatesURL           string        `json:"openstates_url"`
+	Versions                []Version     `json:"versions"`
+	Sponsorships            []Sponsorship `json:"sponsorships"`
+}
+
+// Jurisdiction identifies the legislature a bill belongs to.
+type Jurisdiction struct {
+	ID             string `json:"id"` // e.g., "ocd-jurisdiction/country:us/state:ca/government"
+	Name           string `json:"name"`
+	Classification string `json:"classification"` // "state", "municipality", ...
+}
+
+// Organization is a legislative chamber.
+type Organization struct {
+	Name           string `json:"name"`           // e.g., "Assembly"
+	Classification string `json:"classification"` // "upper", "lower", or "legislature"
+}
+
+// Version is one published text of a bill.
+type Version struct {
+	Note  string `json:"note"` // e.g., "Introduced", "Amended Assembly 03/20/25"
+	Date  string `json:"date"`
+	Links []Link `json:"links"`
+}
+
+// Link is one format of a version's text.
+type Link struct {
+	URL       string `json:"url"`
+	MediaType string `json:"media_type"`
+}
+
+// Sponsorship is a legislator's sponsorship of a bill.
+type Sponsorship struct {
+	Name           string `json:"name"`
+	Primary        bool   `json:"primary"`
+	Classification string `json:"classification"` // e.g., "primary", "cosponsor"
+}
+
+// textMediaTypes are the version formats textextract can read, in order
+// of preference. PDF and word processor formats are skipped.
+var textMediaTypes = []string{"text/html", "text/plain", "application/xml", "text/xml"}
+
+// TextURL returns the URL of the version's most readable text format,
+// or "" when it is only published in formats that can't be extracted.
+func (v Version) TextURL() string {
+	for _, mediaType := range textMediaTypes {
+		for _, link := range v.Links {
+			if strings.EqualFold(strings.TrimSpace(strings.Split(link.MediaType, ";")[0]), mediaType) {
+				return link.URL
+			}
+		}
+	}
+	return ""
+}
+
+// ParsedDate returns the version date, which Open States reports as a
+// bare date or an RFC 3339 timestamp.
+func (v Version) ParsedDate() (time.Time, bool) {
+	return parseDate(v.Date)
+}
+
+// IntroducedDate returns the date of the bill's first action as
+// YYYY-MM-DD, or "" when Open States doesn't report one.
+func (b *Bill) IntroducedDate() string {
+	if t, ok := parseDate(b.FirstActionDate); ok {
+		return t.Format(time.DateOnly)
+	}
+	return ""
+}
+
+// parseDate parses a bare date or an RFC 3339 timestamp.
+func parseDate(s string) (time.Time, bool) {
+	for _, layout := range []string{time.DateOnly, time.RFC3339} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// PrimarySponsor returns the name of the bill's first primary sponsor.
+func (b *Bill) PrimarySponsor() string {
+	for _, sp := range b.Sponsorships {
+		if sp.Primary {
+			return sp.Name
+		}
+	}
+	return ""
+}
+
+// identifierPattern matches bill identifiers such as "AB 1" or "HJR 12".
+var identifierPattern = regexp.MustCompile(`^([A-Za-z]+)\s*0*(\d+)$`)
+
+// looseIdentifierPattern matches bill identifiers whose numbers have
+// suffixes, such as "AB 12A".
+var looseIdentifierPattern = regexp.MustCompile(`^([A-Za-z]+)\s*0*(\d\S*)$`)
+
+// ParseIdentifier splits a bill identifier into its lower-case type prefix
+// and number, e.g., "AB 1" into "ab" and 1. It reports false for
+// identifiers that don't have that shape.
+func ParseIdentifier(identifier string) (string, int, bool) {
+	m := identifierPattern.FindStringSubmatch(strings.TrimSpace(identifier))
+	if m == nil {
+		return "", 0, false
+	}
+	number, err := strconv.Atoi(m[2])
+	if err != nil || number == 0 {
+		return "", 0, false
+	}
+	return strings.ToLower(m[1]), number, true
+}
+
+// SplitIdentifier splits a bill identifier whose number isn't only digits
+// into its lower-case type prefix and its number as published, without
+// leading zeros, e.g., "AB 12A" into "ab" and "12A". It reports false for
+// identifiers whose numbers don't start with a digit.
+func SplitIdentifier(identifier string) (string, string, bool) {
+	m := looseIdentifierPattern.FindStringSubmatch(strings.TrimSpace(identifier))
+	if m == nil {
+		return "", "", false
+	}
+	return strings.ToLower(m[1]), m[2], true
+}
+
+// BillsQuery selects bills from the /bills endpoint.
+type BillsQuery struct {
+	State   string // Two-letter state code, e.g., "ca" (required)
+	Session string // Legislative session identifier (empty = all sessions)
+	Query   string // Full-text search, e.g., "budget" (empty = no filter)
+	Page    int    // 1-based page (default: 1)
+	PerPage int    // Bills per page (default and max: MaxPerPage)
+}
+
+// BillsPage is one page of bills.
+type BillsPage struct {
+	Bills   []Bill
+	Page    int
+	MaxPage int
+	Total   int
+}
+
+// HasMore reports whether later pages exist.
+func (p *BillsPage) HasMore() bool {
+	return p.Page < p.MaxPage
+}
+
+// SearchBills fetches a page of bills, most recently updated first, with
+// their versions and sponsorships.
+func (c *Client) SearchBills(ctx context.Context, q BillsQuery) (*BillsPage, error) {
+	if q.State == "" {
+		return nil, errors.New("openstates: a state is required")
+	}
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.PerPage < 1 || q.PerPage > MaxPerPage {
+		q.PerPage = MaxPerPage
+	}
+
+	query := neturl.Values{}
+	query.Set("jurisdiction", strings.ToLower(q.State))
+	if q.Session != "" {
+		query.Set("session", q.Session)
+	}
+	if q.Query != "" {
+		query.Set("q", q.Query)
+	}
+	query.Set("sort", "updated_desc")
+	query.Add("include", "versions")
+	query.Add("include", "sponsorships")
+	query.Set("page", strconv.Itoa(q.Page))
+	query.Set("per_page", strconv.Itoa(q.PerPage))
+
+	var resp struct {
+		Results    []Bill `json:"results"`
+		Pagination struct {
+			Page       int `json:"page"`
+			MaxPage    int `json:"max_page"`
+			TotalItems int `json:"total_items"`
+		} `json:"pagination"`
+	}
+	if err := c.getJSON(ctx, "/bills", query, &resp); err != nil {
+		return nil, err
+	}
+	return &BillsPage{
+		Bills:   resp.Results,
+		Page:    resp.Pagination.Page,
+		MaxPage: resp.Pagination.MaxPage,
+		Total:   resp.Pagination.TotalItems,
+	}, nil
+}
+
+// getJSON performs a GET request against an API path and decodes the JSON
+// response body into out.
+func (c *Client) getJSON(ctx context.Context, path string, query neturl.Values, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return fmt.Errorf("openstates: failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("X-API-KEY", c.apiKey)
+	if id := logging.RequestID(ctx); id != "" {
+		req.Header.Set(logging.RequestIDHeader, id)
+	}
+
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("openstates: failed to fetch %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	logging.FromContext(ctx).Debug("openstates api request",
+		"path", path, "status", resp.StatusCode, "latency_ms", time.Since(start).Milliseconds())
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return ErrNotFound
+	case http.StatusTooManyRequests:
+		return ErrRateLimited
+	default:
+		return fmt.Errorf("%w: %d", ErrInvalidStatus, resp.StatusCode)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("openstates: failed to decode %s: %w", path, err)
+	}
+	return nil
+}
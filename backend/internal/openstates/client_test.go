@@ -0,0 +1,143 @@
+package openstates_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/openstates"
+)
+
+func TestParseIdentifier(t *testing.T) {
+	tests := []struct {
+		identifier string
+		billType   string
+		number     int
+		ok         bool
+	}{
+		{"AB 1", "ab", 1, true},
+		{"HJR 12", "hjr", 12, true},
+		{"SB0042", "sb", 42, true},
+		{"HB 0", "", 0, false},
+		{"Proposition A", "", 0, false},
+		{"", "", 0, false},
+	}
+	for _, tt := range tests {
+		billType, number, ok := openstates.ParseIdentifier(tt.identifier)
+		if billType != tt.billType || number != tt.number || ok != tt.ok {
+			t.Errorf("ParseIdentifier(%q) = %q, %d, %v; want %q, %d, %v",
+				tt.identifier, billType, number, ok, tt.billType, tt.number, tt.ok)
+		}
+	}
+}
+
+func TestSplitIdentifier(t *testing.T) {
+	tests := []struct {
+		identifier string
+		billType   string
+		number     string
+		ok         bool
+	}{
+		{"AB 12A", "ab", "12A", true},
+		{"SB0042-X1", "sb", "42-X1", true},
+		{"HB 7", "hb", "7", true},
+		{"Proposition A", "", "", false},
+		{"12A", "", "", false},
+		{"", "", "", false},
+	}
+	for _, tt := range tests {
+		billType, number, ok := openstates.SplitIdentifier(tt.identifier)
+		if billType != tt.billType || number != tt.number || ok != tt.ok {
+			t.Errorf("SplitIdentifier(%q) = %q, %q, %v; want %q, %q, %v",
+				tt.identifier, billType, number, ok, tt.billType, tt.number, tt.ok)
+		}
+	}
+}
+
+func TestVersionTextURL(t *testing.T) {
+	v := openstates.Version{Links: []openstates.Link{
+		{URL: "https://example.org/ab1.pdf", MediaType: "application/pdf"},
+		{URL: "https://example.org/ab1.txt", MediaType: "text/plain"},
+		{URL: "https://example.org/ab1.html", MediaType: "text/html; charset=utf-8"},
+	}}
+	if got := v.TextURL(); got != "https://example.org/ab1.html" {
+		t.Errorf("TextURL = %q, want the HTML link", got)
+	}
+
+	pdfOnly := openstates.Version{Links: []openstates.Link{{URL: "https://example.org/ab1.pdf", MediaType: "application/pdf"}}}
+	if got := pdfOnly.TextURL(); got != "" {
+		t.Errorf("TextURL of a PDF-only version = %q, want empty", got)
+	}
+}
+
+func TestSearchBills(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-KEY") != "test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		q := r.URL.Query()
+		if r.URL.Path != "/bills" || q.Get("jurisdiction") != "ca" || q.Get("q") != "budget" || len(q["include"]) != 2 {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		if q.Get("page") == "9" {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		io.WriteString(w, `{
+			"results": [{
+				"id": "ocd-bill/1",
+				"session": "20252026",
+				"identifier": "AB 101",
+				"title": "Budget Act of 2025",
+				"from_organization": {"name": "Assembly", "classification": "lower"},
+				"versions": [{"note": "Introduced", "date": "2025-01-10",
+					"links": [{"url": "https://example.org/ab101.html", "media_type": "text/html"}]}],
+				"sponsorships": [
+					{"name": "Smith", "primary": false},
+					{"name": "Gabriel", "primary": true}
+				]
+			}],
+			"pagination": {"page": 1, "max_page": 3, "total_items": 41}
+		}`)
+	}))
+	defer srv.Close()
+
+	c, err := openstates.NewClient(openstates.WithAPIKey("test-key"),
+		openstates.WithBaseURL(srv.URL), openstates.WithHTTPClient(srv.Client()))
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	page, err := c.SearchBills(context.Background(), openstates.BillsQuery{State: "CA", Query: "budget"})
+	if err != nil {
+		t.Fatalf("SearchBills: %v", err)
+	}
+	if len(page.Bills) != 1 || page.Total != 41 || !page.HasMore() {
+		t.Fatalf("SearchBills = %+v", page)
+	}
+	bill := page.Bills[0]
+	if bill.Identifier != "AB 101" || bill.Session != "20252026" || bill.PrimarySponsor() != "Gabriel" {
+		t.Errorf("bill = %+v", bill)
+	}
+	if len(bill.Versions) != 1 || bill.Versions[0].TextURL() != "https://example.org/ab101.html" {
+		t.Errorf("versions = %+v", bill.Versions)
+	}
+	if d, ok := bill.Versions[0].ParsedDate(); !ok || d.Format("2006-01-02") != "2025-01-10" {
+		t.Errorf("ParsedDate = %v, %v", d, ok)
+	}
+
+	_, err = c.SearchBills(context.Background(), openstates.BillsQuery{State: "ca", Query: "budget", Page: 9})
+	if !errors.Is(err, openstates.ErrRateLimited) {
+		t.Errorf("SearchBills when rate limited: err = %v, want ErrRateLimited", err)
+	}
+}
+
+func TestNewClientRequiresAPIKey(t *testing.T) {
+	if _, err := openstates.NewClient(); !errors.Is(err, openstates.ErrNoAPIKey) {
+		t.Errorf("NewClient without a key: err = %v, want ErrNoAPIKey", err)
+	}
+}
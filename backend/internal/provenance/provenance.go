@@ -0,0 +1,96 @@
+// Package provenance records where version text was retrieved from and
+// checks it against the source later. A record holds the URL requested,
+// the response's Last-Modified, ETag, and Content-Type headers, when it
+// was retrieved, and the SHA-256 of the bytes as retrieved; Verify
+// re-fetches the URL and compares hashes, so anyone citing a version can
+// show its text is what the source served.
+package provenance
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// MaxSize is the most text Verify reads, matching what the ingestor
+// stores.
+const MaxSize = 10 << 20
+
+// defaultClient re-fetches sources for Verify when no client is given.
+var defaultClient = &http.Client{Timeout: time.Minute}
+
+// Hash returns the hex SHA-256 of content, as recorded in ContentHash.
+func Hash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// Capture returns the provenance of content read from resp, the response
+// to a GET of url, retrieved at the given time. The VersionID is left for
+// the caller to set once the version is stored.
+func Capture(url string, resp *http.Response, content []byte, at time.Time) models.VersionProvenance {
+	p := models.VersionProvenance{
+		SourceURL:     url,
+		LastModified:  resp.Header.Get("Last-Modified"),
+		ETag:          resp.Header.Get("ETag"),
+		ContentType:   resp.Header.Get("Content-Type"),
+		ContentLength: len(content),
+		ContentHash:   Hash(content),
+		RetrievedAt:   at.UTC(),
+	}
+	if resp.Request != nil && resp.Request.URL != nil && resp.Request.URL.String() != url {
+		p.FinalURL = resp.Request.URL.String()
+	}
+	return p
+}
+
+// Check is the result of re-fetching a version's source.
+type Check struct {
+	Status       int       // HTTP status of the re-fetch
+	ContentHash  string    // SHA-256 of the bytes served now; empty unless Status is 200
+	LastModified string    // Last-Modified served now
+	ETag         string    // ETag served now
+	Matches      bool      // The source serves the recorded bytes
+	CheckedAt    time.Time // When the source answered
+}
+
+// Verify re-fetches a record's source URL and reports whether it still
+// serves the bytes recorded. A source that answers with an error status
+// doesn't match; errors are returned only when it can't be reached.
+func Verify(ctx context.Context, client *http.Client, p *models.VersionProvenance) (*Check, error) {
+	if client == nil {
+		client = defaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.SourceURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("provenance: invalid source URL: %w", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("provenance: failed to fetch %s: %w", p.SourceURL, err)
+	}
+	defer resp.Body.Close()
+
+	check := &Check{
+		Status:       resp.StatusCode,
+		LastModified: resp.Header.Get("Last-Modified"),
+		ETag:         resp.Header.Get("ETag"),
+		CheckedAt:    time.Now().UTC(),
+	}
+	if resp.StatusCode != http.StatusOK {
+		return check, nil
+	}
+	content, err := io.ReadAll(io.LimitReader(resp.Body, MaxSize))
+	if err != nil {
+		return nil, fmt.Errorf("provenance: failed to read %s: %w", p.SourceURL, err)
+	}
+	check.ContentHash = Hash(content)
+	check.Matches = check.ContentHash == p.ContentHash
+	return check, nil
+}
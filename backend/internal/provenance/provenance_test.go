@@ -0,0 +1,70 @@
+package provenance_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/provenance"
+)
+
+func TestCaptureAndVerify(t *testing.T) {
+	text := "SECTION 1. SHORT TITLE."
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/old":
+			http.Redirect(w, r, "/bill.htm", http.StatusMovedPermanently)
+		case "/bill.htm":
+			w.Header().Set("Last-Modified", "Thu, 22 May 2025 14:03:11 GMT")
+			w.Header().Set("ETag", `"abc"`)
+			w.Header().Set("Content-Type", "text/html")
+			io.WriteString(w, text)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/old")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	at := time.Date(2025, 5, 22, 15, 0, 0, 0, time.UTC)
+	record := provenance.Capture(srv.URL+"/old", resp, body, at)
+	if record.SourceURL != srv.URL+"/old" || record.FinalURL != srv.URL+"/bill.htm" {
+		t.Errorf("URLs = %q, %q", record.SourceURL, record.FinalURL)
+	}
+	if record.LastModified != "Thu, 22 May 2025 14:03:11 GMT" || record.ETag != `"abc"` || record.ContentType != "text/html" {
+		t.Errorf("headers = %q, %q, %q", record.LastModified, record.ETag, record.ContentType)
+	}
+	if record.ContentHash != provenance.Hash([]byte(text)) || record.ContentLength != len(text) || !record.RetrievedAt.Equal(at) {
+		t.Errorf("record = %+v", record)
+	}
+
+	check, err := provenance.Verify(context.Background(), srv.Client(), &record)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if !check.Matches || check.Status != http.StatusOK || check.ETag != `"abc"` {
+		t.Errorf("check = %+v, want a match", check)
+	}
+
+	// The source now serves different text
+	record.ContentHash = provenance.Hash([]byte("SECTION 1. OLD TITLE."))
+	if check, err := provenance.Verify(context.Background(), srv.Client(), &record); err != nil || check.Matches {
+		t.Errorf("Verify of revised text = %+v, %v, want no match", check, err)
+	}
+
+	// The source is gone
+	record.SourceURL = srv.URL + "/missing"
+	check, err = provenance.Verify(context.Background(), srv.Client(), &record)
+	if err != nil || check.Matches || check.Status != http.StatusNotFound || check.ContentHash != "" {
+		t.Errorf("Verify of missing source = %+v, %v", check, err)
+	}
+}
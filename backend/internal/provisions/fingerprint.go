@@ -0,0 +1,156 @@
+// Package provisions finds provisions — sections of bill text — that
+// appear again, verbatim or nearly, in other bills and versions, so a
+// provision can be traced back to where its language first appeared:
+// which bill an omnibus section was lifted from, say.
+//
+// Each section is fingerprinted by MinHash over its word shingles. Texts
+// whose fingerprints agree closely share most of their wording, whatever
+// their numbering, headings, or line breaks; the fingerprints' bands are
+// indexed so candidates are found without comparing every section stored.
+package provisions
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash/fnv"
+	"strings"
+	"unicode"
+)
+
+const (
+	// ShingleWords is the number of consecutive words hashed together;
+	// texts are compared by the shingles they share.
+	ShingleWords = 5
+
+	// SignatureSize is the number of MinHash values in a fingerprint.
+	SignatureSize = 64
+
+	// bandRows is the number of MinHash values per band: two texts are
+	// candidates when they agree on every value of any band. With 16
+	// bands of 4, texts 80% similar are nearly always candidates and
+	// texts 30% similar rarely are.
+	bandRows = 4
+
+	// MinWords is the fewest words a section needs to be traced. Shorter
+	// sections — short titles, effective dates, "Emergency designation" —
+	// are boilerplate that every bill shares.
+	MinWords = 25
+
+	// DefaultThreshold is the estimated similarity at or above which a
+	// section counts as the same provision.
+	DefaultThreshold = 0.8
+)
+
+// Fingerprint identifies a text for near-duplicate comparison.
+type Fingerprint struct {
+	Hash      string   // SHA-256 of the text's words; equal for verbatim copies
+	Words     int      // Number of words
+	Signature []uint32 // MinHash of the text's shingles; empty for an empty text
+}
+
+// NewFingerprint fingerprints text. Case, punctuation, and whitespace are
+// ignored, so re-wrapped or re-punctuated copies fingerprint the same.
+func NewFingerprint(text string) Fingerprint {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	joined := strings.Join(words, " ")
+	sum := sha256.Sum256([]byte(joined))
+	fp := Fingerprint{Hash: hex.EncodeToString(sum[:]), Words: len(words)}
+	if len(words) == 0 {
+		return fp
+	}
+
+	fp.Signature = make([]uint32, SignatureSize)
+	for i := range fp.Signature {
+		fp.Signature[i] = ^uint32(0)
+	}
+	n := max(len(words)-ShingleWords+1, 1)
+	for i := range n {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(words[i:min(i+ShingleWords, len(words))], " ")))
+		shingle := h.Sum64()
+		for j := range fp.Signature {
+			if v := uint32((shingle*seeds[j].a + seeds[j].b) >> 32); v < fp.Signature[j] {
+				fp.Signature[j] = v
+			}
+		}
+	}
+	return fp
+}
+
+// Similarity estimates the share of shingles two fingerprinted texts have
+// in common (their Jaccard similarity), from 0 to 1.
+func Similarity(a, b Fingerprint) float64 {
+	if a.Hash == b.Hash {
+		return 1
+	}
+	if len(a.Signature) != SignatureSize || len(b.Signature) != SignatureSize {
+		return 0
+	}
+	same := 0
+	for i := range a.Signature {
+		if a.Signature[i] == b.Signature[i] {
+			same++
+		}
+	}
+	return float64(same) / SignatureSize
+}
+
+// Bands returns the keys under which a fingerprint is indexed, one per
+// band of its signature; texts sharing any key are candidate matches.
+func (fp Fingerprint) Bands() []int64 {
+	if len(fp.Signature) != SignatureSize {
+		return nil
+	}
+	keys := make([]int64, 0, SignatureSize/bandRows)
+	buf := make([]byte, 4)
+	for band := 0; band < SignatureSize; band += bandRows {
+		h := fnv.New64a()
+		h.Write([]byte{byte(band)})
+		for _, v := range fp.Signature[band : band+bandRows] {
+			binary.LittleEndian.PutUint32(buf, v)
+			h.Write(buf)
+		}
+		keys = append(keys, int64(h.Sum64()))
+	}
+	return keys
+}
+
+// EncodeSignature packs a signature for storage.
+func EncodeSignature(sig []uint32) []byte {
+	out := make([]byte, 4*len(sig))
+	for i, v := range sig {
+		binary.LittleEndian.PutUint32(out[4*i:], v)
+	}
+	return out
+}
+
+// DecodeSignature unpacks a signature packed by EncodeSignature.
+func DecodeSignature(b []byte) []uint32 {
+	sig := make([]uint32, len(b)/4)
+	for i := range sig {
+		sig[i] = binary.LittleEndian.Uint32(b[4*i:])
+	}
+	return sig
+}
+
+// seeds are the parameters of the signature's hash functions, derived
+// with splitmix64 so fingerprints stay comparable across builds.
+var seeds = func() [SignatureSize]struct{ a, b uint64 } {
+	var out [SignatureSize]struct{ a, b uint64 }
+	state := uint64(0x6465_6c74_6167_6f76) // "deltagov"
+	next := func() uint64 {
+		state += 0x9e3779b97f4a7c15
+		z := state
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		return z ^ (z >> 31)
+	}
+	for i := range out {
+		out[i].a = next() | 1
+		out[i].b = next()
+	}
+	return out
+}()
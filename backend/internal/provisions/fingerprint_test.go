@@ -0,0 +1,77 @@
+package provisions_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/provisions"
+)
+
+const provision = `(a) In General.--Section 8(o) of the United States Housing Act of 1937
+(42 U.S.C. 1437f(o)) is amended by adding at the end the following: the
+Secretary shall set aside not less than 10 percent of the amounts made
+available for tenant-based assistance for veterans experiencing
+homelessness, and shall report annually to the Committees on
+Appropriations on the use of such amounts.`
+
+func TestFingerprint(t *testing.T) {
+	a := provisions.NewFingerprint(provision)
+	if a.Words < provisions.MinWords || len(a.Signature) != provisions.SignatureSize {
+		t.Fatalf("fingerprint has %d words and %d values", a.Words, len(a.Signature))
+	}
+
+	// Re-wrapped and re-cased, as when reprinted in another bill
+	rewrapped := provisions.NewFingerprint(strings.ToUpper(strings.Join(strings.Fields(provision), "  ")))
+	if rewrapped.Hash != a.Hash || provisions.Similarity(a, rewrapped) != 1 {
+		t.Error("reformatting changed the fingerprint")
+	}
+
+	// A lightly amended copy
+	amended := provisions.NewFingerprint(strings.Replace(provision, "10 percent", "15 percent", 1))
+	if amended.Hash == a.Hash {
+		t.Error("amended copy has the original's hash")
+	}
+	if s := provisions.Similarity(a, amended); s < 0.6 || s == 1 {
+		t.Errorf("Similarity(original, amended) = %.2f, want high but below 1", s)
+	}
+	if !sharesBand(a, amended) {
+		t.Error("amended copy shares no band with the original")
+	}
+
+	unrelated := provisions.NewFingerprint(`Of the funds made available under this heading,
+		$5,000,000 shall be for grants to States to improve rural broadband mapping,
+		to remain available until expended, notwithstanding any other provision of law.`)
+	if s := provisions.Similarity(a, unrelated); s > 0.2 {
+		t.Errorf("Similarity(original, unrelated) = %.2f, want near 0", s)
+	}
+
+	if empty := provisions.NewFingerprint("  "); empty.Words != 0 || empty.Signature != nil || empty.Bands() != nil {
+		t.Errorf("empty text fingerprinted as %+v", empty)
+	}
+}
+
+func TestSignatureEncoding(t *testing.T) {
+	sig := provisions.NewFingerprint(provision).Signature
+	got := provisions.DecodeSignature(provisions.EncodeSignature(sig))
+	if len(got) != len(sig) {
+		t.Fatalf("decoded %d values, want %d", len(got), len(sig))
+	}
+	for i := range sig {
+		if got[i] != sig[i] {
+			t.Fatalf("value %d = %d, want %d", i, got[i], sig[i])
+		}
+	}
+}
+
+func sharesBand(a, b provisions.Fingerprint) bool {
+	keys := map[int64]bool{}
+	for _, k := range a.Bands() {
+		keys[k] = true
+	}
+	for _, k := range b.Bands() {
+		if keys[k] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,209 @@
+package provisions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/analysis"
+	"github.com/drewjst/deltagov/internal/archive"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+const (
+	// DefaultSyncLimit is the number of versions Sync fingerprints per
+	// call when no limit is given.
+	DefaultSyncLimit = 100
+
+	// excerptChars is the length of the excerpt stored for display.
+	excerptChars = 300
+
+	// maxCandidates bounds the provisions compared per trace, so
+	// boilerplate shared by thousands of bills stays cheap to look up.
+	maxCandidates = 5000
+)
+
+// Indexer fingerprints the sections of public bills' versions (not
+// tenants' drafts) within scope.
+type Indexer struct {
+	db    *gorm.DB
+	scope func(*gorm.DB) *gorm.DB
+}
+
+// NewIndexer creates an Indexer. scope restricts the bills indexed, such
+// as scope.Rules.Query; nil indexes every public bill.
+func NewIndexer(db *gorm.DB, scope func(*gorm.DB) *gorm.DB) *Indexer {
+	if scope == nil {
+		scope = func(db *gorm.DB) *gorm.DB { return db }
+	}
+	return &Indexer{db: db, scope: scope}
+}
+
+// Sync fingerprints the sections of up to limit versions
+// (DefaultSyncLimit if zero or less) not yet fingerprinted, oldest first,
+// so a provision's earliest appearance is indexed before its copies. It
+// returns how many versions it indexed.
+func (ix *Indexer) Sync(ctx context.Context, limit int) (int, error) {
+	if limit <= 0 {
+		limit = DefaultSyncLimit
+	}
+	db := ix.db.WithContext(ctx)
+
+	var ids []uint
+	if err := db.Model(&models.Version{}).
+		Joins("JOIN bills ON bills.id = versions.bill_id").
+		Joins("LEFT JOIN provision_states ON provision_states.version_id = versions.id").
+		Scopes(ix.scope).
+		Where("bills.tenant_id = 0 AND provision_states.version_id IS NULL").
+		Order("versions.fetched_at ASC, versions.id ASC").Limit(limit).
+		Pluck("versions.id", &ids).Error; err != nil {
+		return 0, fmt.Errorf("provisions: failed to list versions to index: %w", err)
+	}
+
+	for i, id := range ids {
+		if err := ix.indexVersion(ctx, id); err != nil {
+			return i, err
+		}
+	}
+	if len(ids) > 0 {
+		logging.FromContext(ctx).Info("fingerprinted provisions", "versions", len(ids))
+	}
+	return len(ids), nil
+}
+
+// indexVersion stores the fingerprints of a version's sections and
+// records it as indexed, in one transaction.
+func (ix *Indexer) indexVersion(ctx context.Context, versionID uint) error {
+	var version models.Version
+	if err := ix.db.WithContext(ctx).Select(append([]string{"id", "bill_id", "fetched_at"}, archive.TextColumns...)).
+		First(&version, versionID).Error; err != nil {
+		return fmt.Errorf("provisions: failed to load version %d: %w", versionID, err)
+	}
+	if err := archive.Rehydrate(&version); err != nil {
+		return err
+	}
+
+	var rows []models.Provision
+	var fingerprints []Fingerprint
+	for _, sec := range analysis.SplitSections(version.PlainText) {
+		fp := NewFingerprint(sec.Body)
+		if fp.Words < MinWords {
+			continue
+		}
+		rows = append(rows, models.Provision{
+			VersionID: version.ID,
+			BillID:    version.BillID,
+			Section:   sec.Number,
+			Anchor:    sec.Anchor(),
+			Heading:   truncate(sec.Heading, 255),
+			Excerpt:   truncate(sec.Body, excerptChars),
+			TextHash:  fp.Hash,
+			Words:     fp.Words,
+			Signature: EncodeSignature(fp.Signature),
+			FetchedAt: version.FetchedAt,
+		})
+		fingerprints = append(fingerprints, fp)
+	}
+
+	return ix.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(rows) > 0 {
+			if err := tx.CreateInBatches(&rows, 200).Error; err != nil {
+				return fmt.Errorf("provisions: failed to store provisions of version %d: %w", versionID, err)
+			}
+			bands := make([]models.ProvisionBand, 0, len(rows)*SignatureSize/bandRows)
+			seen := map[models.ProvisionBand]bool{}
+			for i, row := range rows {
+				for _, key := range fingerprints[i].Bands() {
+					band := models.ProvisionBand{Key: key, ProvisionID: row.ID}
+					if !seen[band] {
+						seen[band] = true
+						bands = append(bands, band)
+					}
+				}
+			}
+			if err := tx.CreateInBatches(&bands, 1000).Error; err != nil {
+				return fmt.Errorf("provisions: failed to index provisions of version %d: %w", versionID, err)
+			}
+		}
+		state := models.ProvisionState{VersionID: versionID, Provisions: len(rows), IndexedAt: time.Now()}
+		if err := tx.Create(&state).Error; err != nil {
+			return fmt.Errorf("provisions: failed to record version %d as indexed: %w", versionID, err)
+		}
+		return nil
+	})
+}
+
+// Match is a stored provision similar to a traced text.
+type Match struct {
+	models.Provision
+	Similarity float64 // Estimated share of wording in common, 1 at best
+	Exact      bool    // Same words, in the same order
+}
+
+// TraceOptions narrows a trace.
+type TraceOptions struct {
+	Threshold float64                   // Least Similarity to match (DefaultThreshold if zero or less)
+	Before    *time.Time                // Only provisions fetched before this
+	Limit     int                       // Most matches returned; zero for all
+	Scopes    []func(*gorm.DB) *gorm.DB // Restrict the provisions searched; may refer to the bills table, which is joined
+}
+
+// Trace finds the stored provisions whose text matches fp, earliest
+// appearance first: the first match is where, as far as the stored
+// versions show, the language originated.
+func Trace(ctx context.Context, db *gorm.DB, fp Fingerprint, opts TraceOptions) ([]Match, error) {
+	threshold := opts.Threshold
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+
+	query := db.WithContext(ctx).Model(&models.Provision{}).
+		Joins("JOIN bills ON bills.id = provisions.bill_id").
+		Scopes(opts.Scopes...)
+	if bands := fp.Bands(); len(bands) > 0 {
+		query = query.Where("provisions.text_hash = ? OR provisions.id IN (?)", fp.Hash,
+			db.Model(&models.ProvisionBand{}).Select("provision_id").Where("key IN ?", bands))
+	} else {
+		query = query.Where("provisions.text_hash = ?", fp.Hash)
+	}
+	if opts.Before != nil {
+		query = query.Where("provisions.fetched_at < ?", *opts.Before)
+	}
+
+	var candidates []models.Provision
+	if err := query.Select("provisions.*").
+		Order("provisions.fetched_at ASC, provisions.id ASC").Limit(maxCandidates).
+		Find(&candidates).Error; err != nil {
+		return nil, fmt.Errorf("provisions: failed to search provisions: %w", err)
+	}
+
+	var matches []Match
+	for _, c := range candidates {
+		similarity := Similarity(fp, Fingerprint{Hash: c.TextHash, Signature: DecodeSignature(c.Signature)})
+		if similarity < threshold {
+			continue
+		}
+		matches = append(matches, Match{Provision: c, Similarity: similarity, Exact: c.TextHash == fp.Hash})
+	}
+	if opts.Limit > 0 && len(matches) > opts.Limit {
+		matches = matches[:opts.Limit]
+	}
+	return matches, nil
+}
+
+// truncate cuts s to at most n bytes, at a rune boundary.
+func truncate(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
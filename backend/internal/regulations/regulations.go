@@ -0,0 +1,256 @@
+// Package regulations tracks proposed and final rules published in the
+// Federal Register and diffs each final rule against its proposed rule,
+// using the same diff engine as bill versions.
+package regulations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/deltas"
+	"github.com/drewjst/deltagov/internal/diff_engine"
+	"github.com/drewjst/deltagov/internal/federalregister"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/textextract"
+	"github.com/drewjst/deltagov/internal/textnorm"
+)
+
+// DefaultLimit is the number of documents fetched per search when
+// Config.Limit is unset.
+const DefaultLimit = 100
+
+// Errors returned by Counterpart and Diff.
+var (
+	ErrNoCounterpart = errors.New("regulations: no matching proposed or final rule")
+	ErrTooLarge      = errors.New("regulations: rule text too large to diff")
+)
+
+// Service ingests rules from the Federal Register.
+type Service struct {
+	db     *gorm.DB
+	client *federalregister.Client
+}
+
+// NewService creates a new regulations service.
+func NewService(db *gorm.DB, client *federalregister.Client) *Service {
+	return &Service{db: db, client: client}
+}
+
+// Config selects the rules to ingest. Agencies and keywords combine with
+// AND; each keyword is searched separately, so keywords combine with OR.
+type Config struct {
+	Agencies []string  // Federal Register agency slugs (empty = all agencies)
+	Keywords []string  // Full-text search terms (empty = no filter)
+	Since    time.Time // Earliest publication date (zero = no limit)
+	Limit    int       // Maximum documents per search (default: DefaultLimit)
+}
+
+// IsEmpty reports whether the config selects nothing to narrow the
+// search; ingesting it would walk every rule of every agency.
+func (c Config) IsEmpty() bool {
+	return len(c.Agencies) == 0 && len(c.Keywords) == 0
+}
+
+// Result contains statistics from an ingestion run.
+type Result struct {
+	DocumentsFetched int
+	RulesCreated     int
+	Errors           []error
+}
+
+// Ingest fetches the newest proposed and final rules matching cfg and
+// stores those not already stored, with their full text.
+func (s *Service) Ingest(ctx context.Context, cfg Config) (*Result, error) {
+	if cfg.Limit <= 0 {
+		cfg.Limit = DefaultLimit
+	}
+	terms := cfg.Keywords
+	if len(terms) == 0 {
+		terms = []string{""}
+	}
+
+	result := &Result{}
+	for _, term := range terms {
+		if err := s.ingestSearch(ctx, cfg, term, result); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// ingestSearch ingests up to cfg.Limit documents of one search term.
+func (s *Service) ingestSearch(ctx context.Context, cfg Config, term string, result *Result) error {
+	fetched := 0
+	for page := 1; fetched < cfg.Limit; page++ {
+		resp, err := s.client.SearchRules(ctx, federalregister.DocumentsQuery{
+			Agencies: cfg.Agencies,
+			Term:     term,
+			Since:    cfg.Since,
+			Page:     page,
+			PerPage:  cfg.Limit - fetched,
+		})
+		if err != nil {
+			return fmt.Errorf("regulations: failed to search rules: %w", err)
+		}
+
+		numbers := make([]string, len(resp.Documents))
+		for i, d := range resp.Documents {
+			numbers[i] = d.DocumentNumber
+		}
+		var stored []string
+		if err := s.db.WithContext(ctx).Model(&models.Rule{}).
+			Where("document_number IN ?", numbers).
+			Pluck("document_number", &stored).Error; err != nil {
+			return fmt.Errorf("regulations: failed to query stored rules: %w", err)
+		}
+		seen := make(map[string]bool, len(stored))
+		for _, n := range stored {
+			seen[n] = true
+		}
+
+		for i := range resp.Documents {
+			if fetched >= cfg.Limit {
+				break
+			}
+			fetched++
+			result.DocumentsFetched++
+
+			doc := &resp.Documents[i]
+			if seen[doc.DocumentNumber] {
+				continue
+			}
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := s.storeRule(ctx, doc); err != nil {
+				result.Errors = append(result.Errors, fmt.Errorf("document %s: %w", doc.DocumentNumber, err))
+				continue
+			}
+			seen[doc.DocumentNumber] = true
+			result.RulesCreated++
+		}
+
+		if !resp.HasMore() || len(resp.Documents) == 0 {
+			break
+		}
+	}
+	return nil
+}
+
+// storeRule downloads a document's text and stores it as a Rule.
+func (s *Service) storeRule(ctx context.Context, doc *federalregister.Document) error {
+	var ruleType string
+	switch doc.Type {
+	case federalregister.TypeProposedRule:
+		ruleType = models.RuleTypeProposed
+	case federalregister.TypeRule:
+		ruleType = models.RuleTypeFinal
+	default:
+		return fmt.Errorf("unexpected document type %q", doc.Type)
+	}
+	if doc.RawTextURL == "" {
+		return errors.New("document has no text")
+	}
+
+	content, err := s.client.FetchText(ctx, doc.RawTextURL)
+	if err != nil {
+		return fmt.Errorf("failed to fetch text: %w", err)
+	}
+	published, _ := doc.ParsedPublicationDate()
+
+	slugs := make([]string, 0, len(doc.Agencies))
+	for _, a := range doc.Agencies {
+		if a.Slug != "" {
+			slugs = append(slugs, a.Slug)
+		}
+	}
+
+	rule := models.Rule{
+		DocumentNumber:  doc.DocumentNumber,
+		RuleType:        ruleType,
+		Title:           doc.Title,
+		Abstract:        doc.Abstract,
+		Agencies:        doc.AgencyNames(),
+		AgencySlugs:     slugs,
+		RIN:             first(doc.RegulationIDNumbers),
+		DocketID:        first(doc.DocketIDs),
+		PublicationDate: published,
+		HTMLURL:         doc.HTMLURL,
+		ContentHash:     textnorm.Hash(content),
+		TextContent:     content,
+		PlainText:       textextract.Extract(content),
+		FetchedAt:       time.Now(),
+	}
+	if err := s.db.WithContext(ctx).Create(&rule).Error; err != nil {
+		return fmt.Errorf("failed to create rule: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("created new rule",
+		"document_number", rule.DocumentNumber, "rule_type", rule.RuleType, "rin", rule.RIN)
+	return nil
+}
+
+// first returns the first non-empty string of values, trimmed.
+func first(values []string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// Counterpart returns the rule a rule is diffed against: for a final rule,
+// the latest proposed rule published before it; for a proposed rule, the
+// earliest final rule published after it. Rules are matched by RIN, or by
+// docket when the rule has no RIN. It returns ErrNoCounterpart when there
+// is none.
+func Counterpart(ctx context.Context, db *gorm.DB, rule *models.Rule) (*models.Rule, error) {
+	query := db.WithContext(ctx).Where("id <> ?", rule.ID)
+	switch {
+	case rule.RIN != "":
+		query = query.Where("rin = ?", rule.RIN)
+	case rule.DocketID != "":
+		query = query.Where("docket_id = ?", rule.DocketID)
+	default:
+		return nil, ErrNoCounterpart
+	}
+	if rule.RuleType == models.RuleTypeFinal {
+		query = query.Where("rule_type = ? AND publication_date <= ?", models.RuleTypeProposed, rule.PublicationDate).
+			Order("publication_date DESC, id DESC")
+	} else {
+		query = query.Where("rule_type = ? AND publication_date >= ?", models.RuleTypeFinal, rule.PublicationDate).
+			Order("publication_date ASC, id ASC")
+	}
+
+	var counterpart models.Rule
+	err := query.First(&counterpart).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNoCounterpart
+	}
+	if err != nil {
+		return nil, fmt.Errorf("regulations: failed to query rules: %w", err)
+	}
+	return &counterpart, nil
+}
+
+// Diff computes the word-level diff from a proposed rule's text to a final
+// rule's, as bill versions are diffed. Texts over deltas.MaxTextSize return
+// ErrTooLarge.
+func Diff(proposed, final *models.Rule) (*diff_engine.Delta, error) {
+	if len(proposed.PlainText) > deltas.MaxTextSize || len(final.PlainText) > deltas.MaxTextSize {
+		return nil, ErrTooLarge
+	}
+	delta, err := diff_engine.ComputeWordLevel(proposed.PlainText, final.PlainText)
+	if err != nil {
+		return nil, err
+	}
+	delta.VersionA, delta.VersionB = proposed.DocumentNumber, final.DocumentNumber
+	return delta, nil
+}
@@ -0,0 +1,44 @@
+package regulations_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/deltas"
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/regulations"
+)
+
+func TestDiff(t *testing.T) {
+	proposed := &models.Rule{DocumentNumber: "2023-01234", PlainText: "Sec. 1. Scope.\nThe limit is 10 tons.\n"}
+	final := &models.Rule{DocumentNumber: "2024-05678", PlainText: "Sec. 1. Scope.\nThe limit is 8 tons.\n"}
+
+	delta, err := regulations.Diff(proposed, final)
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if delta.Insertions != 1 || delta.Deletions != 1 {
+		t.Errorf("Diff = +%d -%d, want +1 -1", delta.Insertions, delta.Deletions)
+	}
+	if len(delta.Hunks) == 0 {
+		t.Error("Diff has no hunks")
+	}
+	if delta.VersionA != "2023-01234" || delta.VersionB != "2024-05678" {
+		t.Errorf("Diff versions = %q, %q", delta.VersionA, delta.VersionB)
+	}
+
+	huge := &models.Rule{PlainText: strings.Repeat("x", deltas.MaxTextSize+1)}
+	if _, err := regulations.Diff(proposed, huge); !errors.Is(err, regulations.ErrTooLarge) {
+		t.Errorf("Diff of oversized text: err = %v, want ErrTooLarge", err)
+	}
+}
+
+func TestConfigIsEmpty(t *testing.T) {
+	if !(regulations.Config{Limit: 10}).IsEmpty() {
+		t.Error("config without agencies or keywords is not empty")
+	}
+	if (regulations.Config{Keywords: []string{"methane"}}).IsEmpty() {
+		t.Error("config with a keyword is empty")
+	}
+}
@@ -0,0 +1,292 @@
+// Package schedule decides when the continuous ingestor refreshes each
+// class of bills, sharing an hourly Congress.gov API request budget among
+// them: bills on a watchlist most often, active appropriations bills next,
+// and recently updated bills in general least often.
+package schedule
+
+import (
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/metrics"
+)
+
+// Priority is a class of bills refreshed on its own schedule.
+type Priority string
+
+// Priorities, most urgent first.
+const (
+	Watched        Priority = "watched"        // Bills on any user's watchlist
+	Appropriations Priority = "appropriations" // Appropriations bills of the current Congress
+	Recent         Priority = "recent"         // Recently updated bills, and the rest of the polling cycle
+)
+
+// Priorities lists every priority, most urgent first.
+var Priorities = []Priority{Watched, Appropriations, Recent}
+
+// DefaultHourlyBudget leaves headroom under Congress.gov's limit of 5,000
+// requests per hour for retries and one-off runs.
+const DefaultHourlyBudget = 4500
+
+// Unlimited is the allowance of every priority without a budget.
+const Unlimited = math.MaxInt
+
+// budgetWindow is the period a budget covers.
+const budgetWindow = time.Hour
+
+// Queue configures how one priority is scheduled.
+type Queue struct {
+	// Interval is how often the priority is refreshed.
+	Interval time.Duration
+
+	// Share is the fraction of the hourly budget reserved for the
+	// priority, which others may not spend. The budget left over by all
+	// reservations is shared, first come first served.
+	Share float64
+}
+
+// Config configures a Scheduler.
+type Config struct {
+	// HourlyBudget is the number of Congress.gov API requests scheduled
+	// runs may spend per hour. Zero means no budget.
+	HourlyBudget int
+
+	// Queues configures each priority; priorities without one never run.
+	Queues map[Priority]Queue
+}
+
+// DefaultConfig refreshes recent bills every pollInterval, appropriations
+// bills twice as often, and watched bills four times as often, with
+// DefaultHourlyBudget requests per hour of which a fifth is left unreserved.
+func DefaultConfig(pollInterval time.Duration) Config {
+	return Config{
+		HourlyBudget: DefaultHourlyBudget,
+		Queues: map[Priority]Queue{
+			Watched:        {Interval: pollInterval / 4, Share: 0.3},
+			Appropriations: {Interval: pollInterval / 2, Share: 0.2},
+			Recent:         {Interval: pollInterval, Share: 0.3},
+		},
+	}
+}
+
+// Task is the work of a scheduled run, which should spend no more than
+// budget Congress.gov API requests (Unlimited without a budget).
+type Task func(ctx context.Context, budget int) error
+
+// charge is the requests a run spent, counted against the budget for an
+// hour after it started.
+type charge struct {
+	at       time.Time
+	requests int
+}
+
+// queueState is a priority's schedule and spending.
+type queueState struct {
+	Queue
+	due     time.Time // When the next run falls due
+	charges []charge  // Oldest first
+}
+
+// Scheduler decides which priority runs next. Each priority falls due one
+// interval after its last run, but waits while it has no budget left: its
+// reservation and the shared remainder are both spent. Among due
+// priorities the most urgent runs first, except that a priority gains one
+// level of urgency for each interval it has been kept waiting, so busy
+// higher priorities can't starve it; of equally urgent priorities, the one
+// waiting longest runs first. Runs are expected one at a time.
+type Scheduler struct {
+	budget int
+	spent  func() int64
+
+	mu     sync.Mutex
+	queues map[Priority]*queueState
+}
+
+// New creates a Scheduler whose priorities are all due at start. spent
+// returns the number of Congress.gov API requests sent so far, such as
+// congress.Client.Requests; runs are charged the difference.
+func New(cfg Config, spent func() int64, start time.Time) *Scheduler {
+	s := &Scheduler{budget: cfg.HourlyBudget, spent: spent, queues: map[Priority]*queueState{}}
+	for _, p := range Priorities {
+		if q, ok := cfg.Queues[p]; ok && q.Interval > 0 {
+			s.queues[p] = &queueState{Queue: q, due: start}
+		}
+	}
+	return s
+}
+
+// Next returns the priority to run next and when it may start, which may
+// be before now if it is overdue. It returns an empty priority when none
+// are configured.
+func (s *Scheduler) Next(now time.Time) (Priority, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var next Priority
+	var nextAt, nextDue time.Time
+	bestRank := 0
+	for i, p := range Priorities {
+		q, ok := s.queues[p]
+		if !ok {
+			continue
+		}
+		at := q.due
+		if funded := s.fundedAt(p, now); funded.After(at) {
+			at = funded
+		}
+		if at.After(now) {
+			if next == "" || (nextAt.After(now) && at.Before(nextAt)) {
+				next, nextAt = p, at
+			}
+			continue
+		}
+		// Ready now: outranks anything later, then by urgency, then by
+		// how long it has waited
+		rank := i - int(now.Sub(q.due)/q.Interval)
+		if next == "" || nextAt.After(now) || rank < bestRank || (rank == bestRank && q.due.Before(nextDue)) {
+			next, nextAt, nextDue, bestRank = p, at, q.due, rank
+		}
+	}
+	return next, nextAt
+}
+
+// Allowance returns the number of requests a run of p starting at now may
+// spend: what is left of its reservation plus what is left of the shared
+// remainder. It is Unlimited without a budget.
+func (s *Scheduler) Allowance(p Priority, now time.Time) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.allowance(p, now)
+}
+
+// allowance is Allowance with s.mu held.
+func (s *Scheduler) allowance(p Priority, now time.Time) int {
+	if s.budget <= 0 {
+		return Unlimited
+	}
+	shared := s.budget
+	sharedSpent := 0
+	own := 0
+	for priority, q := range s.queues {
+		reserved := int(q.Share * float64(s.budget))
+		shared -= reserved
+		spent := q.spentSince(now.Add(-budgetWindow))
+		if spent > reserved {
+			sharedSpent += spent - reserved
+		}
+		if priority == p {
+			own = max(reserved-spent, 0)
+		}
+	}
+	return own + max(shared-sharedSpent, 0)
+}
+
+// fundedAt returns the first time from now at which p has budget to
+// spend: now, or when enough of the past hour's charges expire.
+func (s *Scheduler) fundedAt(p Priority, now time.Time) time.Time {
+	if s.allowance(p, now) > 0 {
+		return now
+	}
+	var expiries []time.Time
+	for _, q := range s.queues {
+		for _, c := range q.charges {
+			expiries = append(expiries, c.at.Add(budgetWindow))
+		}
+	}
+	sort.Slice(expiries, func(i, j int) bool { return expiries[i].Before(expiries[j]) })
+	for _, t := range expiries {
+		// Charges at exactly an hour ago no longer count
+		if t.After(now) && s.allowance(p, t) > 0 {
+			return t
+		}
+	}
+	return now.Add(budgetWindow)
+}
+
+// spentSince returns the requests charged to the queue after since.
+func (q *queueState) spentSince(since time.Time) int {
+	total := 0
+	for _, c := range q.charges {
+		if c.at.After(since) {
+			total += c.requests
+		}
+	}
+	return total
+}
+
+// Run runs a task for p now with its allowance, charges p the requests the
+// task spent, and schedules p's next run one interval later.
+func (s *Scheduler) Run(ctx context.Context, p Priority, task Task) error {
+	start := time.Now()
+	budget := s.Allowance(p, start)
+	before := s.spent()
+	err := task(ctx, budget)
+	s.Record(p, start, int(s.spent()-before), err)
+	return err
+}
+
+// Record charges p the requests a run that started at start spent and
+// schedules its next run one interval after start. Run calls it; it is
+// exported for callers that run tasks themselves.
+func (s *Scheduler) Record(p Priority, start time.Time, requests int, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	q, ok := s.queues[p]
+	if !ok {
+		return
+	}
+	if start.After(q.due) {
+		metrics.ScheduleLag.WithLabelValues(string(p)).Observe(start.Sub(q.due).Seconds())
+	}
+	q.due = start.Add(q.Interval)
+	q.charges = append(q.charges, charge{at: start, requests: requests})
+	s.prune(start)
+
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	metrics.ScheduleRuns.WithLabelValues(string(p), status).Inc()
+	metrics.ScheduleRequests.WithLabelValues(string(p)).Add(float64(requests))
+	s.observeBudget(start)
+}
+
+// Skip schedules p's next run one interval after now without running it,
+// as when scheduled runs are paused.
+func (s *Scheduler) Skip(p Priority, now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if q, ok := s.queues[p]; ok {
+		q.due = now.Add(q.Interval)
+		metrics.ScheduleRuns.WithLabelValues(string(p), "skipped").Inc()
+	}
+}
+
+// prune drops charges that no longer count against the budget.
+func (s *Scheduler) prune(now time.Time) {
+	since := now.Add(-budgetWindow)
+	for _, q := range s.queues {
+		kept := q.charges[:0]
+		for _, c := range q.charges {
+			if c.at.After(since) {
+				kept = append(kept, c)
+			}
+		}
+		q.charges = kept
+	}
+}
+
+// observeBudget updates the remaining budget gauges.
+func (s *Scheduler) observeBudget(now time.Time) {
+	if s.budget <= 0 {
+		return
+	}
+	for p := range s.queues {
+		metrics.ScheduleBudgetRemaining.WithLabelValues(string(p)).Set(float64(s.allowance(p, now)))
+	}
+}
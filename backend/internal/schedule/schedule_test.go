@@ -0,0 +1,115 @@
+package schedule_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/drewjst/deltagov/internal/schedule"
+)
+
+var start = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func newScheduler(budget int) *schedule.Scheduler {
+	cfg := schedule.DefaultConfig(time.Hour)
+	cfg.HourlyBudget = budget
+	return schedule.New(cfg, func() int64 { return 0 }, start)
+}
+
+func TestNextOrdersByPriority(t *testing.T) {
+	s := newScheduler(1000)
+
+	// All due at start: most urgent first
+	var order []schedule.Priority
+	for range schedule.Priorities {
+		p, at := s.Next(start)
+		if !at.Equal(start) {
+			t.Fatalf("Next at = %v, want %v", at, start)
+		}
+		order = append(order, p)
+		s.Record(p, start, 10, nil)
+	}
+	want := []schedule.Priority{schedule.Watched, schedule.Appropriations, schedule.Recent}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("run order = %v, want %v", order, want)
+		}
+	}
+
+	// Watched refreshes every 15 minutes, before the others fall due again
+	if p, at := s.Next(start); p != schedule.Watched || !at.Equal(start.Add(15*time.Minute)) {
+		t.Errorf("Next = %s at %v, want watched at +15m", p, at)
+	}
+}
+
+func TestNextPreventsStarvation(t *testing.T) {
+	s := newScheduler(0)
+	for _, p := range schedule.Priorities {
+		s.Record(p, start, 0, nil)
+	}
+
+	// Recent fell due at +1h; by +2h it has waited a full interval and
+	// outranks appropriations, which is only just due
+	s.Record(schedule.Watched, start.Add(2*time.Hour), 0, nil)
+	s.Record(schedule.Appropriations, start.Add(90*time.Minute), 0, nil)
+	now := start.Add(2 * time.Hour)
+	if p, _ := s.Next(now); p != schedule.Recent {
+		t.Errorf("Next = %s, want recent after waiting an interval", p)
+	}
+}
+
+func TestAllowanceReservesShares(t *testing.T) {
+	s := newScheduler(1000)
+	// Reserved: watched 300, appropriations 200, recent 300; shared 200
+	if got := s.Allowance(schedule.Recent, start); got != 500 {
+		t.Errorf("recent allowance = %d, want 500", got)
+	}
+
+	// Watched overspends its reservation and the shared remainder
+	s.Record(schedule.Watched, start, 600, nil)
+	now := start.Add(time.Minute)
+	if got := s.Allowance(schedule.Watched, now); got != 0 {
+		t.Errorf("watched allowance = %d, want 0", got)
+	}
+	if got := s.Allowance(schedule.Recent, now); got != 300 {
+		t.Errorf("recent allowance = %d, want its 300 reservation", got)
+	}
+
+	// Watched waits for its charge to expire, an hour after it started
+	s.Skip(schedule.Appropriations, start.Add(45*time.Minute))
+	s.Skip(schedule.Recent, now)
+	if p, at := s.Next(now); p != schedule.Watched || !at.Equal(start.Add(time.Hour)) {
+		t.Errorf("Next = %s at %v, want watched at +1h", p, at)
+	}
+	if got := s.Allowance(schedule.Watched, start.Add(time.Hour)); got != 500 {
+		t.Errorf("watched allowance after an hour = %d, want 500", got)
+	}
+}
+
+func TestRunChargesSpentRequests(t *testing.T) {
+	var sent int64
+	cfg := schedule.DefaultConfig(time.Hour)
+	cfg.HourlyBudget = 100
+	s := schedule.New(cfg, func() int64 { return sent }, time.Now())
+
+	boom := errors.New("boom")
+	var budget int
+	err := s.Run(context.Background(), schedule.Recent, func(ctx context.Context, b int) error {
+		budget = b
+		sent += 45
+		return boom
+	})
+	if !errors.Is(err, boom) {
+		t.Errorf("Run error = %v, want the task's", err)
+	}
+	if budget != 50 {
+		t.Errorf("budget = %d, want 50", budget)
+	}
+	if got := s.Allowance(schedule.Recent, time.Now()); got != 5 {
+		t.Errorf("allowance after run = %d, want 5", got)
+	}
+	if got := newScheduler(0).Allowance(schedule.Recent, start); got != schedule.Unlimited {
+		t.Errorf("allowance without budget = %d, want Unlimited", got)
+	}
+}
@@ -0,0 +1,175 @@
+// Package scope implements config-driven rules that restrict which bills an
+// instance ingests and lists, so self-hosters can focus on a subset of
+// Congress (e.g., skip simple resolutions or track only one congress).
+package scope
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Rules holds allow/deny lists for bill types, congresses, and title patterns.
+// An empty allow list allows everything; deny lists always take precedence.
+// A nil *Rules allows every bill.
+type Rules struct {
+	AllowBillTypes  []string
+	DenyBillTypes   []string
+	AllowCongresses []int
+	DenyCongresses  []int
+
+	// AllowTitle and DenyTitle are matched case-insensitively against bill titles.
+	// They are also passed to PostgreSQL's ~* operator by Query, so patterns
+	// should stay within the syntax both engines share.
+	AllowTitle *regexp.Regexp
+	DenyTitle  *regexp.Regexp
+}
+
+// FromEnv loads rules from environment variables:
+//
+//	SCOPE_ALLOW_BILL_TYPES   comma-separated bill types, e.g. "hr,s"
+//	SCOPE_DENY_BILL_TYPES    comma-separated bill types, e.g. "hres,sres"
+//	SCOPE_ALLOW_CONGRESSES   comma-separated congress numbers, e.g. "118,119"
+//	SCOPE_DENY_CONGRESSES    comma-separated congress numbers
+//	SCOPE_ALLOW_TITLE        regular expression a title must match
+//	SCOPE_DENY_TITLE         regular expression that excludes a title
+//
+// Returns nil rules when no variables are set.
+func FromEnv() (*Rules, error) {
+	rules := &Rules{
+		AllowBillTypes: splitList(os.Getenv("SCOPE_ALLOW_BILL_TYPES")),
+		DenyBillTypes:  splitList(os.Getenv("SCOPE_DENY_BILL_TYPES")),
+	}
+
+	var err error
+	if rules.AllowCongresses, err = parseInts(os.Getenv("SCOPE_ALLOW_CONGRESSES")); err != nil {
+		return nil, fmt.Errorf("scope: invalid SCOPE_ALLOW_CONGRESSES: %w", err)
+	}
+	if rules.DenyCongresses, err = parseInts(os.Getenv("SCOPE_DENY_CONGRESSES")); err != nil {
+		return nil, fmt.Errorf("scope: invalid SCOPE_DENY_CONGRESSES: %w", err)
+	}
+	if rules.AllowTitle, err = compileTitle(os.Getenv("SCOPE_ALLOW_TITLE")); err != nil {
+		return nil, fmt.Errorf("scope: invalid SCOPE_ALLOW_TITLE: %w", err)
+	}
+	if rules.DenyTitle, err = compileTitle(os.Getenv("SCOPE_DENY_TITLE")); err != nil {
+		return nil, fmt.Errorf("scope: invalid SCOPE_DENY_TITLE: %w", err)
+	}
+
+	if rules.IsEmpty() {
+		return nil, nil
+	}
+	return rules, nil
+}
+
+// IsEmpty reports whether the rules impose no restrictions.
+func (r *Rules) IsEmpty() bool {
+	return r == nil || (len(r.AllowBillTypes) == 0 && len(r.DenyBillTypes) == 0 &&
+		len(r.AllowCongresses) == 0 && len(r.DenyCongresses) == 0 &&
+		r.AllowTitle == nil && r.DenyTitle == nil)
+}
+
+// Allows reports whether a bill with the given attributes is in scope.
+func (r *Rules) Allows(congress int, billType, title string) bool {
+	if r.IsEmpty() {
+		return true
+	}
+
+	billType = strings.ToLower(billType)
+	if slices.Contains(r.DenyBillTypes, billType) {
+		return false
+	}
+	if len(r.AllowBillTypes) > 0 && !slices.Contains(r.AllowBillTypes, billType) {
+		return false
+	}
+	if slices.Contains(r.DenyCongresses, congress) {
+		return false
+	}
+	if len(r.AllowCongresses) > 0 && !slices.Contains(r.AllowCongresses, congress) {
+		return false
+	}
+	if r.DenyTitle != nil && r.DenyTitle.MatchString(title) {
+		return false
+	}
+	if r.AllowTitle != nil && !r.AllowTitle.MatchString(title) {
+		return false
+	}
+
+	return true
+}
+
+// Query is a GORM scope that restricts a query on the bills table to in-scope rows.
+// Use as db.Scopes(rules.Query).
+func (r *Rules) Query(db *gorm.DB) *gorm.DB {
+	if r.IsEmpty() {
+		return db
+	}
+
+	if len(r.DenyBillTypes) > 0 {
+		db = db.Where("LOWER(bill_type) NOT IN ?", r.DenyBillTypes)
+	}
+	if len(r.AllowBillTypes) > 0 {
+		db = db.Where("LOWER(bill_type) IN ?", r.AllowBillTypes)
+	}
+	if len(r.DenyCongresses) > 0 {
+		db = db.Where("congress NOT IN ?", r.DenyCongresses)
+	}
+	if len(r.AllowCongresses) > 0 {
+		db = db.Where("congress IN ?", r.AllowCongresses)
+	}
+	if r.DenyTitle != nil {
+		db = db.Where("title !~* ?", stripCaseFlag(r.DenyTitle.String()))
+	}
+	if r.AllowTitle != nil {
+		db = db.Where("title ~* ?", stripCaseFlag(r.AllowTitle.String()))
+	}
+
+	return db
+}
+
+// splitList splits a comma-separated list, lowercasing and dropping empty entries.
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	parts := strings.Split(value, ",")
+	items := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.ToLower(strings.TrimSpace(p)); p != "" {
+			items = append(items, p)
+		}
+	}
+	return items
+}
+
+// parseInts parses a comma-separated list of integers.
+func parseInts(value string) ([]int, error) {
+	items := splitList(value)
+	ints := make([]int, 0, len(items))
+	for _, item := range items {
+		n, err := strconv.Atoi(item)
+		if err != nil {
+			return nil, err
+		}
+		ints = append(ints, n)
+	}
+	return ints, nil
+}
+
+// compileTitle compiles a case-insensitive title pattern; empty means no pattern.
+func compileTitle(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile("(?i)" + pattern)
+}
+
+// stripCaseFlag removes the (?i) prefix added by compileTitle, since ~* is
+// already case-insensitive in PostgreSQL.
+func stripCaseFlag(pattern string) string {
+	return strings.TrimPrefix(pattern, "(?i)")
+}
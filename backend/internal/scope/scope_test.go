@@ -0,0 +1,52 @@
+package scope_test
+
+import (
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/scope"
+)
+
+// TestRulesAllows verifies allow/deny precedence across all rule kinds.
+func TestRulesAllows(t *testing.T) {
+	t.Setenv("SCOPE_ALLOW_BILL_TYPES", "HR, s")
+	t.Setenv("SCOPE_DENY_CONGRESSES", "117")
+	t.Setenv("SCOPE_DENY_TITLE", "commemorat")
+
+	rules, err := scope.FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv failed: %v", err)
+	}
+
+	cases := []struct {
+		congress int
+		billType string
+		title    string
+		want     bool
+	}{
+		{119, "hr", "Appropriations Act", true},
+		{119, "S", "Budget Act", true},
+		{119, "hres", "Appropriations Act", false},
+		{117, "hr", "Appropriations Act", false},
+		{119, "hr", "Commemorating the anniversary", false},
+	}
+
+	for _, c := range cases {
+		if got := rules.Allows(c.congress, c.billType, c.title); got != c.want {
+			t.Errorf("Allows(%d, %q, %q) = %v, want %v", c.congress, c.billType, c.title, got, c.want)
+		}
+	}
+}
+
+// TestNilRulesAllowEverything verifies unconfigured scopes are permissive.
+func TestNilRulesAllowEverything(t *testing.T) {
+	rules, err := scope.FromEnv()
+	if err != nil {
+		t.Fatalf("FromEnv failed: %v", err)
+	}
+	if rules != nil {
+		t.Fatalf("Expected nil rules with no env, got %+v", rules)
+	}
+	if !rules.Allows(119, "hres", "Anything") {
+		t.Error("Nil rules should allow every bill")
+	}
+}
@@ -0,0 +1,316 @@
+// Package searchindex keeps an in-process inverted index of bills' titles,
+// aliases, and sponsors, so bill search can be answered from memory in
+// deployments without dedicated search infrastructure. An Index is loaded
+// from the database, kept current with the ingestor's live events, and
+// reloaded periodically to pick up whatever the events missed (see Run).
+package searchindex
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// Sort orders of search results, matching the search API's sort values.
+const (
+	SortUpdateDate     = "updateDate"
+	SortIntroducedDate = "introducedDate"
+	SortTitle          = "title"
+)
+
+// Query selects and orders bills. Zero fields match every bill.
+type Query struct {
+	Text         string // Words that must each start a word of the title or an alias
+	Sponsor      string // Words that must each start a word of the sponsor
+	Congress     int
+	BillType     string // Case-insensitive
+	Number       string // As published, case-insensitive
+	Jurisdiction string
+	State        string // Case-insensitive
+	SpendingOnly bool
+	PolicyArea   string // Case-insensitive
+	Sort         string // One of the Sort values (default: SortUpdateDate)
+	Order        string // "asc" or "desc" (default: desc for dates, asc for title)
+	Limit        int
+	Offset       int
+}
+
+// entry is an indexed bill and the terms it is indexed under.
+type entry struct {
+	bill         models.Bill
+	textTerms    []string // Of the title and aliases
+	sponsorTerms []string
+}
+
+// postings maps each term to the bills containing it, with the terms kept
+// sorted so prefixes can be looked up.
+type postings struct {
+	bills  map[string]map[uint]struct{}
+	sorted []string
+}
+
+func newPostings() postings {
+	return postings{bills: map[string]map[uint]struct{}{}}
+}
+
+// add indexes a bill under terms. Terms new to the index are inserted in
+// order unless unsorted is set, for a caller that sorts them afterwards.
+func (p *postings) add(id uint, terms []string, unsorted bool) {
+	for _, t := range terms {
+		set, ok := p.bills[t]
+		if !ok {
+			set = map[uint]struct{}{}
+			p.bills[t] = set
+			if unsorted {
+				p.sorted = append(p.sorted, t)
+			} else {
+				i, _ := slices.BinarySearch(p.sorted, t)
+				p.sorted = slices.Insert(p.sorted, i, t)
+			}
+		}
+		set[id] = struct{}{}
+	}
+}
+
+// remove drops a bill from terms, and terms no bill has left.
+func (p *postings) remove(id uint, terms []string) {
+	for _, t := range terms {
+		set, ok := p.bills[t]
+		if !ok {
+			continue
+		}
+		delete(set, id)
+		if len(set) == 0 {
+			delete(p.bills, t)
+			if i, found := slices.BinarySearch(p.sorted, t); found {
+				p.sorted = slices.Delete(p.sorted, i, i+1)
+			}
+		}
+	}
+}
+
+// match returns the bills with a term starting with each of words, or nil
+// when words is empty.
+func (p *postings) match(words []string) map[uint]struct{} {
+	var result map[uint]struct{}
+	for _, w := range words {
+		found := map[uint]struct{}{}
+		i, _ := slices.BinarySearch(p.sorted, w)
+		for ; i < len(p.sorted) && strings.HasPrefix(p.sorted[i], w); i++ {
+			for id := range p.bills[p.sorted[i]] {
+				if result == nil {
+					found[id] = struct{}{}
+				} else if _, ok := result[id]; ok {
+					found[id] = struct{}{}
+				}
+			}
+		}
+		result = found
+		if len(result) == 0 {
+			break
+		}
+	}
+	return result
+}
+
+// Index is an in-memory index of bills. It is safe for concurrent use.
+type Index struct {
+	mu      sync.RWMutex
+	ready   bool
+	entries map[uint]*entry
+	text    postings
+	sponsor postings
+}
+
+// New creates an empty Index, which isn't Ready until Replace is called.
+func New() *Index {
+	return &Index{entries: map[uint]*entry{}, text: newPostings(), sponsor: newPostings()}
+}
+
+// Ready reports whether the index has been loaded, so its results are
+// complete.
+func (x *Index) Ready() bool {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return x.ready
+}
+
+// Len returns the number of bills indexed.
+func (x *Index) Len() int {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return len(x.entries)
+}
+
+// Replace indexes exactly bills, with their aliases keyed by bill ID, and
+// marks the index Ready.
+func (x *Index) Replace(bills []models.Bill, aliases map[uint][]string) {
+	entries := make(map[uint]*entry, len(bills))
+	text, sponsor := newPostings(), newPostings()
+	for _, b := range bills {
+		e := newEntry(b, aliases[b.ID])
+		entries[b.ID] = e
+		text.add(b.ID, e.textTerms, true)
+		sponsor.add(b.ID, e.sponsorTerms, true)
+	}
+	// Sorted once rather than term by term
+	slices.Sort(text.sorted)
+	slices.Sort(sponsor.sorted)
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.entries, x.text, x.sponsor, x.ready = entries, text, sponsor, true
+}
+
+// Put adds a bill to the index, or replaces it.
+func (x *Index) Put(bill models.Bill, aliases []string) {
+	e := newEntry(bill, aliases)
+
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(bill.ID)
+	x.entries[bill.ID] = e
+	x.text.add(bill.ID, e.textTerms, false)
+	x.sponsor.add(bill.ID, e.sponsorTerms, false)
+}
+
+// Remove drops a bill from the index, if it is there.
+func (x *Index) Remove(id uint) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(id)
+}
+
+// remove is Remove with x.mu held.
+func (x *Index) remove(id uint) {
+	old, ok := x.entries[id]
+	if !ok {
+		return
+	}
+	x.text.remove(id, old.textTerms)
+	x.sponsor.remove(id, old.sponsorTerms)
+	delete(x.entries, id)
+}
+
+// Search returns a page of the bills matching q, in q's order, and the
+// number of bills matching in all.
+func (x *Index) Search(q Query) ([]models.Bill, int) {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+
+	candidates := x.text.match(Terms(q.Text))
+	if sponsors := x.sponsor.match(Terms(q.Sponsor)); sponsors != nil {
+		if candidates == nil {
+			candidates = sponsors
+		} else {
+			for id := range candidates {
+				if _, ok := sponsors[id]; !ok {
+					delete(candidates, id)
+				}
+			}
+		}
+	}
+
+	var matched []*entry
+	if candidates != nil {
+		for id := range candidates {
+			if e := x.entries[id]; q.matches(&e.bill) {
+				matched = append(matched, e)
+			}
+		}
+	} else {
+		for _, e := range x.entries {
+			if q.matches(&e.bill) {
+				matched = append(matched, e)
+			}
+		}
+	}
+
+	slices.SortFunc(matched, q.compare)
+	total := len(matched)
+	start := min(max(q.Offset, 0), total)
+	end := total
+	if q.Limit > 0 {
+		end = min(start+q.Limit, total)
+	}
+	page := make([]models.Bill, end-start)
+	for i, e := range matched[start:end] {
+		page[i] = e.bill
+	}
+	return page, total
+}
+
+// matches reports whether a bill passes the query's filters other than
+// its words.
+func (q *Query) matches(b *models.Bill) bool {
+	return (q.Congress == 0 || b.Congress == q.Congress) &&
+		(q.BillType == "" || strings.EqualFold(b.BillType, q.BillType)) &&
+		(q.Number == "" || strings.EqualFold(b.Number, q.Number)) &&
+		(q.Jurisdiction == "" || b.Jurisdiction == q.Jurisdiction) &&
+		(q.State == "" || b.StateCode == strings.ToLower(q.State)) &&
+		(!q.SpendingOnly || b.IsSpendingBill) &&
+		(q.PolicyArea == "" || strings.EqualFold(b.PolicyArea, q.PolicyArea))
+}
+
+// compare orders two entries as the query sorts them. Bills without an
+// introduced date sort last either way; ties are broken by ID in the same
+// direction, as in the database search.
+func (q *Query) compare(a, b *entry) int {
+	var c int
+	desc := true
+	switch q.Sort {
+	case SortIntroducedDate:
+		ad, bd := a.bill.IntroducedDate, b.bill.IntroducedDate
+		if (ad == "") != (bd == "") {
+			if ad == "" {
+				return 1
+			}
+			return -1
+		}
+		c = strings.Compare(ad, bd)
+	case SortTitle:
+		c, desc = strings.Compare(a.bill.Title, b.bill.Title), false
+	default:
+		c = strings.Compare(a.bill.UpdateDate, b.bill.UpdateDate)
+	}
+	switch q.Order {
+	case "asc":
+		desc = false
+	case "desc":
+		desc = true
+	}
+	if c == 0 {
+		c = cmp.Compare(a.bill.ID, b.bill.ID)
+	}
+	if desc {
+		return -c
+	}
+	return c
+}
+
+// newEntry returns the entry for a bill and its aliases.
+func newEntry(bill models.Bill, aliases []string) *entry {
+	text := Terms(bill.Title)
+	for _, a := range aliases {
+		text = append(text, Terms(a)...)
+	}
+	return &entry{bill: bill, textTerms: dedupe(text), sponsorTerms: dedupe(Terms(bill.Sponsor))}
+}
+
+// Terms splits text into the lower-case words it is indexed and searched
+// by: runs of letters and digits.
+func Terms(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// dedupe returns terms sorted without repeats.
+func dedupe(terms []string) []string {
+	slices.Sort(terms)
+	return slices.Compact(terms)
+}
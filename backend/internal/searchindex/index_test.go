@@ -0,0 +1,97 @@
+package searchindex_test
+
+import (
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/searchindex"
+)
+
+func newIndex() *searchindex.Index {
+	x := searchindex.New()
+	x.Replace([]models.Bill{
+		{ID: 1, Congress: 119, BillType: "HR", Number: "1", BillNumber: 1, Jurisdiction: "federal", Title: "One Big Beautiful Bill Act",
+			Sponsor: "Rep. Arrington, Jodey C. [R-TX-19]", UpdateDate: "2025-07-04", IntroducedDate: "2025-05-20", IsSpendingBill: true},
+		{ID: 2, Congress: 119, BillType: "S", Number: "2296", BillNumber: 2296, Jurisdiction: "federal", Title: "National Defense Authorization Act for Fiscal Year 2026",
+			Sponsor: "Sen. Wicker, Roger F. [R-MS]", UpdateDate: "2025-09-10"},
+		{ID: 3, Congress: 118, BillType: "HR", Number: "2882", BillNumber: 2882, Jurisdiction: "federal", Title: "Further Consolidated Appropriations Act, 2024",
+			Sponsor: "Rep. Arrington, Jodey C. [R-TX-19]", UpdateDate: "2024-03-23", IntroducedDate: "2023-04-26", IsSpendingBill: true},
+	}, map[uint][]string{2: {"NDAA"}})
+	return x
+}
+
+func ids(bills []models.Bill) []uint {
+	out := make([]uint, len(bills))
+	for i, b := range bills {
+		out[i] = b.ID
+	}
+	return out
+}
+
+func equal(a, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSearch(t *testing.T) {
+	x := newIndex()
+	if !x.Ready() {
+		t.Fatal("index not ready after Replace")
+	}
+
+	for name, tc := range map[string]struct {
+		query searchindex.Query
+		want  []uint
+	}{
+		"everything by update date": {searchindex.Query{}, []uint{2, 1, 3}},
+		"word prefixes":             {searchindex.Query{Text: "approp act"}, []uint{3}},
+		"alias":                     {searchindex.Query{Text: "ndaa"}, []uint{2}},
+		"every word must match":     {searchindex.Query{Text: "defense appropriations"}, nil},
+		"sponsor":                   {searchindex.Query{Sponsor: "arrington"}, []uint{1, 3}},
+		"sponsor and filters":       {searchindex.Query{Sponsor: "arrington", Congress: 118, BillType: "hr"}, []uint{3}},
+		"number":                    {searchindex.Query{BillType: "s", Number: "2296"}, []uint{2}},
+		"spending only":             {searchindex.Query{SpendingOnly: true, Sort: searchindex.SortTitle}, []uint{3, 1}},
+		"introduced, missing last":  {searchindex.Query{Sort: searchindex.SortIntroducedDate, Order: "asc"}, []uint{3, 1, 2}},
+		"paged":                     {searchindex.Query{Limit: 1, Offset: 1}, []uint{1}},
+	} {
+		bills, total := x.Search(tc.query)
+		if !equal(ids(bills), tc.want) {
+			t.Errorf("%s: Search = %v, want %v", name, ids(bills), tc.want)
+		}
+		if tc.query.Limit == 0 && total != len(tc.want) {
+			t.Errorf("%s: total = %d, want %d", name, total, len(tc.want))
+		}
+	}
+}
+
+func TestPutRemove(t *testing.T) {
+	x := newIndex()
+
+	x.Put(models.Bill{ID: 2, Congress: 119, BillType: "S", BillNumber: 2296, Title: "Renamed Act", UpdateDate: "2025-10-01"}, nil)
+	if bills, _ := x.Search(searchindex.Query{Text: "ndaa"}); len(bills) != 0 {
+		t.Errorf("old alias still matches: %v", ids(bills))
+	}
+	if bills, _ := x.Search(searchindex.Query{Text: "renamed"}); !equal(ids(bills), []uint{2}) {
+		t.Errorf("new title: Search = %v, want [2]", ids(bills))
+	}
+
+	x.Put(models.Bill{ID: 4, Title: "Renewable Energy Act", UpdateDate: "2025-01-01"}, nil)
+	if bills, _ := x.Search(searchindex.Query{Text: "ren"}); !equal(ids(bills), []uint{2, 4}) {
+		t.Errorf("added bill: Search = %v, want [2 4]", ids(bills))
+	}
+
+	x.Remove(2)
+	if bills, total := x.Search(searchindex.Query{Text: "ren"}); !equal(ids(bills), []uint{4}) || total != 1 {
+		t.Errorf("removed bill: Search = %v (%d), want [4]", ids(bills), total)
+	}
+	if x.Len() != 3 {
+		t.Errorf("Len = %d, want 3", x.Len())
+	}
+}
@@ -0,0 +1,127 @@
+package searchindex
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/live"
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// DefaultReloadInterval is how often Run reloads the whole index when no
+// interval is given.
+const DefaultReloadInterval = 15 * time.Minute
+
+// columns are the bill columns indexed: those search filters on, sorts by,
+// and lists.
+var columns = []string{
+	"id", "jurisdiction", "state_code", "session", "congress", "number", "bill_number", "bill_type",
+	"title", "sponsor", "origin_chamber", "current_status", "update_date", "introduced_date",
+	"is_spending_bill", "policy_area", "public_law_number", "law_type",
+	"cosponsor_count", "action_count", "amendment_count",
+}
+
+// Loader reads the bills an index holds from the database: public bills
+// (not tenants' drafts) within scope.
+type Loader struct {
+	db    *gorm.DB
+	scope func(*gorm.DB) *gorm.DB
+}
+
+// NewLoader creates a Loader. scope restricts the bills indexed, such as
+// scope.Rules.Query; nil indexes every public bill.
+func NewLoader(db *gorm.DB, scope func(*gorm.DB) *gorm.DB) *Loader {
+	if scope == nil {
+		scope = func(db *gorm.DB) *gorm.DB { return db }
+	}
+	return &Loader{db: db, scope: scope}
+}
+
+// bills returns a query for the bills indexed.
+func (l *Loader) bills(ctx context.Context) *gorm.DB {
+	return l.db.WithContext(ctx).Model(&models.Bill{}).Select(columns).Scopes(l.scope).Where("tenant_id = 0")
+}
+
+// Load replaces the index's contents with every bill the loader reads.
+func (l *Loader) Load(ctx context.Context, x *Index) error {
+	var bills []models.Bill
+	if err := l.bills(ctx).Find(&bills).Error; err != nil {
+		return fmt.Errorf("searchindex: failed to load bills: %w", err)
+	}
+	var rows []models.BillAlias
+	if err := l.db.WithContext(ctx).Select("bill_id", "alias").Find(&rows).Error; err != nil {
+		return fmt.Errorf("searchindex: failed to load aliases: %w", err)
+	}
+	aliases := make(map[uint][]string)
+	for _, r := range rows {
+		aliases[r.BillID] = append(aliases[r.BillID], r.Alias)
+	}
+	x.Replace(bills, aliases)
+	return nil
+}
+
+// Refresh re-reads one bill into the index, or drops it when it is gone or
+// out of scope.
+func (l *Loader) Refresh(ctx context.Context, x *Index, id uint) error {
+	var bill models.Bill
+	if err := l.bills(ctx).Where("id = ?", id).Limit(1).Find(&bill).Error; err != nil {
+		return fmt.Errorf("searchindex: failed to load bill %d: %w", id, err)
+	}
+	if bill.ID == 0 {
+		x.Remove(id)
+		return nil
+	}
+	var aliases []string
+	if err := l.db.WithContext(ctx).Model(&models.BillAlias{}).Where("bill_id = ?", id).
+		Pluck("alias", &aliases).Error; err != nil {
+		return fmt.Errorf("searchindex: failed to load aliases of bill %d: %w", id, err)
+	}
+	x.Put(bill, aliases)
+	return nil
+}
+
+// Run keeps an index current until ctx is done: it loads the index,
+// refreshes each bill a live event reports created or updated, and reloads
+// the whole index every interval (DefaultReloadInterval if zero or less),
+// which picks up alias edits and events dropped while it was busy.
+// Failures are logged; the index keeps serving what it has, and until the
+// first load succeeds it isn't Ready.
+func (l *Loader) Run(ctx context.Context, x *Index, events <-chan live.Event, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+	log := logging.FromContext(ctx)
+	load := func() {
+		start := time.Now()
+		if err := l.Load(ctx, x); err != nil {
+			log.Error("failed to load search index", "error", err)
+			return
+		}
+		log.Info("search index loaded", "bills", x.Len(), "duration_ms", time.Since(start).Milliseconds())
+	}
+	load()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			load()
+		case event, ok := <-events:
+			if !ok {
+				// Broker closed; carry on with periodic reloads
+				events = nil
+				continue
+			}
+			if err := l.Refresh(ctx, x, event.BillID); err != nil {
+				log.Warn("failed to refresh search index", "bill_id", event.BillID, "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Package stats maintains the aggregates behind the dashboard as Postgres
+// materialized views: public federal bills per legislative stage per
+// congress, bill versions ingested per day, and diff sizes by bill type.
+// The views are created by database.Migrate and refreshed by Refresh,
+// which the ingestor runs after each polling cycle, so the API reads
+// precomputed rows rather than scanning bills, versions, and deltas.
+package stats
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/versioncode"
+)
+
+// View names.
+const (
+	ViewBillsByStatus  = "stats_bills_by_status"
+	ViewVersionsPerDay = "stats_versions_per_day"
+	ViewDiffSizeByType = "stats_diff_size_by_bill_type"
+)
+
+// view is a materialized view with the unique index REFRESH ...
+// CONCURRENTLY requires.
+type view struct {
+	name   string
+	query  string
+	unique string // Columns of the unique index
+}
+
+// views returns the view definitions. A bill's stage is the furthest
+// stage of its text versions (see versioncode.Stage), enacted once it has
+// a law number, and introduced before any text is published. Every view
+// records when it was last refreshed in computed_at.
+func views() []view {
+	stages := make([]string, 0, len(versioncode.Known()))
+	for _, info := range versioncode.Known() {
+		stages = append(stages, fmt.Sprintf("('%s', %d)", info.Code, info.Stage))
+	}
+
+	return []view{
+		{
+			name:   ViewBillsByStatus,
+			unique: "congress, stage",
+			query: fmt.Sprintf(`
+				WITH bill_stages AS (
+					SELECT b.id, b.congress,
+						CASE WHEN b.public_law_number <> '' THEN %d
+							ELSE COALESCE(MAX(s.stage), %d) END AS stage
+					FROM bills b
+					LEFT JOIN versions v ON v.bill_id = b.id
+					LEFT JOIN (VALUES %s) AS s(code, stage) ON s.code = v.version_code
+					WHERE b.jurisdiction = 'federal' AND b.tenant_id = 0
+					GROUP BY b.id, b.congress, b.public_law_number
+				)
+				SELECT congress, stage, COUNT(*) AS bills, now() AS computed_at
+				FROM bill_stages
+				GROUP BY congress, stage`,
+				versioncode.StageEnacted, versioncode.StageIntroduced, strings.Join(stages, ", ")),
+		},
+		{
+			name:   ViewVersionsPerDay,
+			unique: "day",
+			query: `
+				SELECT v.fetched_at::date AS day, COUNT(*) AS versions,
+					COUNT(DISTINCT v.bill_id) AS bills, now() AS computed_at
+				FROM versions v
+				JOIN bills b ON b.id = v.bill_id
+				WHERE v.document_id IS NULL AND b.tenant_id = 0
+				GROUP BY v.fetched_at::date`,
+		},
+		{
+			name:   ViewDiffSizeByType,
+			unique: "bill_type",
+			query: `
+				SELECT b.bill_type, COUNT(*) AS diffs,
+					AVG(d.insertions + d.deletions)::float8 AS avg_lines_changed,
+					AVG(d.insertions)::float8 AS avg_insertions,
+					AVG(d.deletions)::float8 AS avg_deletions,
+					now() AS computed_at
+				FROM deltas d
+				JOIN versions v ON v.id = d.version_b_id
+				JOIN bills b ON b.id = v.bill_id
+				WHERE b.jurisdiction = 'federal' AND b.tenant_id = 0
+				GROUP BY b.bill_type`,
+		},
+	}
+}
+
+// CreateViews creates the materialized views and their unique indexes
+// where they don't exist, populating new views.
+func CreateViews(db *gorm.DB) error {
+	for _, v := range views() {
+		if err := db.Exec(fmt.Sprintf(`CREATE MATERIALIZED VIEW IF NOT EXISTS %s AS %s`, v.name, v.query)).Error; err != nil {
+			return fmt.Errorf("stats: failed to create %s: %w", v.name, err)
+		}
+		if err := db.Exec(fmt.Sprintf(`CREATE UNIQUE INDEX IF NOT EXISTS idx_%s_key ON %s (%s)`,
+			v.name, v.name, v.unique)).Error; err != nil {
+			return fmt.Errorf("stats: failed to index %s: %w", v.name, err)
+		}
+	}
+	return nil
+}
+
+// Refresh recomputes every view. Each is refreshed CONCURRENTLY, so
+// readers see its previous rows until its refresh completes.
+func Refresh(ctx context.Context, db *gorm.DB) error {
+	db = db.WithContext(ctx)
+	for _, v := range views() {
+		if err := db.Exec(fmt.Sprintf(`REFRESH MATERIALIZED VIEW CONCURRENTLY %s`, v.name)).Error; err != nil {
+			return fmt.Errorf("stats: failed to refresh %s: %w", v.name, err)
+		}
+	}
+	logging.FromContext(ctx).Info("dashboard stats refreshed")
+	return nil
+}
@@ -0,0 +1,115 @@
+// Package textextract converts Congress.gov bill text formats (Formatted
+// XML, Formatted Text HTML, and plain text) into readable plain text that
+// keeps the bill's line structure and nests subdivisions by indentation.
+package textextract
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/drewjst/deltagov/internal/textnorm"
+)
+
+var (
+	prePattern        = regexp.MustCompile(`(?is)<pre[^>]*>(.*?)</pre>`)
+	markupPattern     = regexp.MustCompile(`<[^>]+>`)
+	hiddenPattern     = regexp.MustCompile(`(?is)<(head|script|style)\b[^>]*>.*?</(head|script|style)>`)
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// Extract returns the plain text of bill content in any supported format.
+// Typography is made ASCII (textnorm.Typography), trailing whitespace is
+// trimmed from every line, and runs of blank lines collapse to one.
+func Extract(content string) string {
+	var text string
+	switch detect(content) {
+	case formatXML:
+		var err error
+		if text, err = fromXML(content); err != nil {
+			// Malformed XML still yields its text, just without layout
+			text = fromMarkup(content)
+		}
+	case formatPreHTML:
+		text = fromPreHTML(content)
+	case formatHTML:
+		text = fromMarkup(content)
+	default:
+		text = content
+	}
+	return tidy(textnorm.Typography(text))
+}
+
+type format int
+
+const (
+	formatPlain format = iota
+	formatXML
+	formatPreHTML
+	formatHTML
+)
+
+// Markup kinds reported by Kind.
+const (
+	KindPlain = "plain"
+	KindHTML  = "html"
+	KindXML   = "xml"
+)
+
+// Kind reports the markup of bill content: KindXML for Formatted XML,
+// KindHTML for Formatted Text and other HTML, and KindPlain otherwise.
+func Kind(content string) string {
+	switch detect(content) {
+	case formatXML:
+		return KindXML
+	case formatPreHTML, formatHTML:
+		return KindHTML
+	default:
+		return KindPlain
+	}
+}
+
+// detect identifies the format of bill content.
+func detect(content string) format {
+	head := strings.ToLower(strings.TrimSpace(content[:min(len(content), 2048)]))
+	switch {
+	case !strings.Contains(content, "<"):
+		return formatPlain
+	case strings.HasPrefix(head, "<?xml"), strings.HasPrefix(head, "<bill"),
+		strings.HasPrefix(head, "<resolution"), strings.HasPrefix(head, "<amendment-doc"):
+		return formatXML
+	case prePattern.MatchString(content):
+		return formatPreHTML
+	case strings.HasPrefix(head, "<!doctype html"), strings.HasPrefix(head, "<html"):
+		return formatHTML
+	default:
+		return formatPlain
+	}
+}
+
+// fromPreHTML extracts Formatted Text, which GPO serves as preformatted
+// text wrapped in HTML. The layout inside <pre> is already the bill's.
+func fromPreHTML(content string) string {
+	var b strings.Builder
+	for _, m := range prePattern.FindAllStringSubmatch(content, -1) {
+		b.WriteString(html.UnescapeString(markupPattern.ReplaceAllString(m[1], "")))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// fromMarkup is the layout-free fallback: every tag becomes a line break.
+func fromMarkup(content string) string {
+	content = hiddenPattern.ReplaceAllString(content, "\n")
+	return html.UnescapeString(markupPattern.ReplaceAllString(content, "\n"))
+}
+
+// tidy trims trailing whitespace from lines and collapses blank-line runs.
+func tidy(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t\u00A0")
+	}
+	text = strings.Join(lines, "\n")
+	return strings.Trim(blankLinesPattern.ReplaceAllString(text, "\n\n"), "\n")
+}
@@ -0,0 +1,56 @@
+package textextract_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/textextract"
+)
+
+const billXML = `<?xml version="1.0"?>
+<bill bill-stage="Introduced-in-House">
+<metadata><dublinCore><dc:title>119 HR 1 IH</dc:title></dublinCore></metadata>
+<form><congress>119th CONGRESS</congress><session>1st Session</session>
+<legis-num>H. R. 1</legis-num>
+<official-title>To provide for reconciliation.</official-title></form>
+<legis-body>
+<section id="S1" section-type="section-one"><enum>1.</enum><header>Short title</header>
+<text display-inline="no-display-inline">This Act may be cited as the <quote>Example Act</quote>.</text></section>
+<title id="T1"><enum>I</enum><header>Border Security</header>
+<section id="S101"><enum>101.</enum><header>Funding</header>
+<subsection id="a"><enum>(a)</enum><header>In general</header><text>There is appropriated
+  $1,000,000&#x2014;</text>
+<paragraph id="1"><enum>(1)</enum><text>for barriers; and</text></paragraph>
+<paragraph id="2"><enum>(2)</enum><text>for technology.</text></paragraph></subsection>
+</section></title>
+</legis-body>
+</bill>`
+
+// TestExtract_XMLLayout verifies headings, enumerations, and nesting.
+func TestExtract_XMLLayout(t *testing.T) {
+	got := textextract.Extract(billXML)
+
+	for _, want := range []string{
+		"H. R. 1\n",
+		"To provide for reconciliation.\n",
+		"SEC. 1. SHORT TITLE.\n  This Act may be cited as the \"Example Act\".\n",
+		"TITLE I--BORDER SECURITY\n",
+		"SEC. 101. FUNDING.\n    (a) In general.--There is appropriated $1,000,000--\n      (1) for barriers; and\n      (2) for technology.",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "119 HR 1 IH") {
+		t.Error("Expected metadata to be skipped")
+	}
+}
+
+// TestExtract_PreformattedHTML verifies Formatted Text keeps its layout.
+func TestExtract_PreformattedHTML(t *testing.T) {
+	content := "<html><body><pre>\nSEC. 2. FINDINGS.   \n\n    Congress finds &ldquo;that&rdquo; &lt;all&gt;\n</pre></body></html>"
+	want := "SEC. 2. FINDINGS.\n\n    Congress finds \"that\" <all>"
+	if got := textextract.Extract(content); got != want {
+		t.Errorf("Extract = %q, want %q", got, want)
+	}
+}
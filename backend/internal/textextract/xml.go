@@ -0,0 +1,330 @@
+package textextract
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+)
+
+// node is a parsed XML element. Children are *node or string (character data).
+type node struct {
+	name     string
+	children []any
+}
+
+// subdivisions are the GPO bill DTD levels below the section. Each is
+// indented one step further than the element containing it.
+var subdivisions = map[string]bool{
+	"subsection": true, "paragraph": true, "subparagraph": true, "clause": true,
+	"subclause": true, "item": true, "subitem": true,
+}
+
+// divisions are the units above the section, rendered as headings such as
+// "TITLE I--BORDER SECURITY".
+var divisions = map[string]bool{
+	"division": true, "subdivision": true, "title": true, "subtitle": true,
+	"part": true, "subpart": true, "chapter": true, "subchapter": true,
+}
+
+// blocks are elements that start a new line. Anything else is inline.
+var blocks = map[string]bool{
+	"text": true, "section": true, "quoted-block": true, "after-quoted-block": true,
+	"legis-body": true, "resolution-body": true, "engrossed-amendment-body": true,
+	"form": true, "action": true, "action-desc": true, "action-date": true,
+	"official-title": true, "legis-num": true, "congress": true, "session": true,
+	"current-chamber": true, "distribution-code": true, "legis-type": true,
+	"preamble": true, "whereas": true, "attestation": true, "attestation-group": true,
+	"attestor": true, "role": true, "endorsement": true, "toc": true, "toc-entry": true,
+	"appropriations-major": true, "appropriations-intermediate": true, "appropriations-small": true,
+	"header": true, "enum": true, "row": true, "table": true, "tgroup": true, "tbody": true, "thead": true,
+}
+
+// skipped elements carry no bill text.
+var skipped = map[string]bool{"metadata": true, "dublinCore": true, "pagebreak": true}
+
+const (
+	// indentStep is the indentation added for each nested subdivision.
+	indentStep = 2
+
+	// quoteIndent is the extra indentation applied inside quoted blocks
+	// (text being inserted into existing law).
+	quoteIndent = 4
+)
+
+// fromXML renders GPO bill XML as plain text.
+func fromXML(content string) (string, error) {
+	root, err := parseXML(content)
+	if err != nil {
+		return "", err
+	}
+	r := &renderer{}
+	r.block(root, 0)
+	return r.b.String(), nil
+}
+
+// parseXML builds a node tree from XML, tolerating HTML-style entities.
+func parseXML(content string) (*node, error) {
+	dec := xml.NewDecoder(strings.NewReader(content))
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+	dec.AutoClose = xml.HTMLAutoClose
+
+	root := &node{}
+	stack := []*node{root}
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		top := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			child := &node{name: t.Name.Local}
+			top.children = append(top.children, child)
+			stack = append(stack, child)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			top.children = append(top.children, string(t))
+		}
+	}
+	return root, nil
+}
+
+// renderer accumulates rendered lines.
+type renderer struct {
+	b strings.Builder
+}
+
+// line writes one line at the given indentation.
+func (r *renderer) line(indent int, text string) {
+	text = collapse(text)
+	if text == "" {
+		return
+	}
+	r.b.WriteString(strings.Repeat(" ", indent))
+	r.b.WriteString(text)
+	r.b.WriteString("\n")
+}
+
+// blank writes an empty line (separating sections and divisions).
+func (r *renderer) blank() {
+	r.b.WriteString("\n")
+}
+
+// block renders n's children, starting a new line for each block element.
+// Inline runs between blocks are gathered onto a line of their own.
+func (r *renderer) block(n *node, indent int) {
+	var inline strings.Builder
+	flush := func() {
+		r.line(indent, inline.String())
+		inline.Reset()
+	}
+
+	for _, c := range n.children {
+		child, ok := c.(*node)
+		if !ok {
+			inline.WriteString(c.(string))
+			continue
+		}
+		switch {
+		case skipped[child.name]:
+		case child.name == "section":
+			flush()
+			r.section(child, indent)
+		case divisions[child.name] && hasChild(child, "enum", "header"):
+			flush()
+			r.division(child, indent)
+		case subdivisions[child.name]:
+			flush()
+			r.subdivision(child, indent)
+		case strings.HasPrefix(child.name, "appropriations-"):
+			flush()
+			r.blank()
+			r.block(child, indent)
+		case child.name == "quoted-block":
+			flush()
+			r.block(child, indent+quoteIndent)
+		case child.name == "row":
+			flush()
+			r.line(indent, cells(child))
+		case blocks[child.name] || containsBlock(child):
+			flush()
+			r.block(child, indent)
+		default:
+			inline.WriteString(inlineElement(child))
+		}
+	}
+	flush()
+}
+
+// section renders "SEC. 101. HEADING." followed by its body, indented.
+func (r *renderer) section(n *node, indent int) {
+	enum, header, rest := splitHeading(n)
+	heading := strings.TrimSpace("SEC. " + enum + " " + strings.ToUpper(header))
+	if enum == "" {
+		heading = strings.ToUpper(header)
+	}
+	if heading != "" && !strings.HasSuffix(heading, ".") {
+		heading += "."
+	}
+	r.blank()
+	r.line(indent, heading)
+	r.block(&node{children: rest}, indent+indentStep)
+}
+
+// division renders headings such as "TITLE I--BORDER SECURITY".
+func (r *renderer) division(n *node, indent int) {
+	enum, header, rest := splitHeading(n)
+	heading := strings.ToUpper(n.name)
+	if enum != "" {
+		heading += " " + strings.TrimSuffix(enum, ".")
+	}
+	if header != "" {
+		heading += "--" + strings.ToUpper(header)
+	}
+	r.blank()
+	r.line(indent, heading)
+	r.block(&node{children: rest}, indent)
+}
+
+// subdivision renders "(a) Heading.--Text" with the first text element on
+// the same line and nested subdivisions indented below.
+func (r *renderer) subdivision(n *node, indent int) {
+	indent += indentStep
+	enum, header, rest := splitHeading(n)
+
+	lead := enum
+	if header != "" {
+		lead = strings.TrimSpace(lead + " " + strings.TrimSuffix(header, ".") + ".--")
+	}
+	// Pull the first text element up onto the heading line
+	if len(rest) > 0 {
+		if text, ok := firstNode(rest); ok && text.name == "text" {
+			sep := " "
+			if strings.HasSuffix(lead, "--") || lead == "" {
+				sep = ""
+			}
+			lead += sep + inlineText(text)
+			rest = without(rest, text)
+		}
+	}
+	r.line(indent, lead)
+	r.block(&node{children: rest}, indent)
+}
+
+// splitHeading separates an element's enum and header from its other children.
+func splitHeading(n *node) (enum, header string, rest []any) {
+	for _, c := range n.children {
+		child, ok := c.(*node)
+		switch {
+		case ok && child.name == "enum" && enum == "":
+			enum = collapse(inlineText(child))
+		case ok && child.name == "header" && header == "":
+			header = collapse(inlineText(child))
+		case !ok && strings.TrimSpace(c.(string)) == "":
+			// Formatting whitespace between elements
+		default:
+			rest = append(rest, c)
+		}
+	}
+	return enum, header, rest
+}
+
+// inlineText concatenates all character data under n.
+func inlineText(n *node) string {
+	var b strings.Builder
+	for _, c := range n.children {
+		switch v := c.(type) {
+		case string:
+			b.WriteString(v)
+		case *node:
+			b.WriteString(inlineElement(v))
+		}
+	}
+	return b.String()
+}
+
+// inlineElement renders an inline element, quoting <quote> as GPO does.
+func inlineElement(n *node) string {
+	switch {
+	case skipped[n.name]:
+		return ""
+	case n.name == "quote":
+		return `"` + inlineText(n) + `"`
+	default:
+		return inlineText(n)
+	}
+}
+
+// cells renders a table row's entries separated by two spaces.
+func cells(row *node) string {
+	var parts []string
+	for _, c := range row.children {
+		if child, ok := c.(*node); ok {
+			parts = append(parts, collapse(inlineText(child)))
+		}
+	}
+	return strings.Join(parts, "  ")
+}
+
+// containsBlock reports whether any element below n starts a new line, so
+// that wrappers such as <bill> aren't flattened into a single line.
+func containsBlock(n *node) bool {
+	for _, c := range n.children {
+		child, ok := c.(*node)
+		if !ok {
+			continue
+		}
+		if blocks[child.name] || subdivisions[child.name] || divisions[child.name] || containsBlock(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasChild reports whether n has a direct child element with any of the names.
+func hasChild(n *node, names ...string) bool {
+	for _, c := range n.children {
+		if child, ok := c.(*node); ok {
+			for _, name := range names {
+				if child.name == name {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// firstNode returns the first element among children.
+func firstNode(children []any) (*node, bool) {
+	for _, c := range children {
+		if child, ok := c.(*node); ok {
+			return child, true
+		}
+	}
+	return nil, false
+}
+
+// without returns children minus the given element.
+func without(children []any, n *node) []any {
+	out := make([]any, 0, len(children)-1)
+	for _, c := range children {
+		if child, ok := c.(*node); !ok || child != n {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// collapse folds whitespace runs, including XML formatting newlines, to
+// single spaces.
+func collapse(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,71 @@
+// Package textnorm canonicalizes bill text so that versions differing only
+// in format (XML vs. HTML vs. plain text), typography, or whitespace hash
+// and diff identically.
+package textnorm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	markupPattern = regexp.MustCompile(`<[^>]+>`)
+
+	// Non-content elements whose bodies must be dropped along with the tags
+	hiddenPattern = regexp.MustCompile(`(?is)<(head|script|style)\b[^>]*>.*?</(head|script|style)>`)
+
+	// Within-line whitespace, including non-breaking and other Unicode spaces
+	spacePattern = regexp.MustCompile(`[\t\f\v \x{00A0}\x{2000}-\x{200A}\x{202F}\x{205F}\x{3000}]+`)
+
+	blankLinesPattern = regexp.MustCompile(`\n{3,}`)
+)
+
+// typography maps typographic variants to the ASCII forms used by GPO's
+// plain-text renderings. Em dashes become "--" as in GPO text files.
+var typography = strings.NewReplacer(
+	"“", `"`, "”", `"`, "„", `"`, "‟", `"`, "«", `"`, "»", `"`,
+	"‘", "'", "’", "'", "‚", "'", "‛", "'", "′", "'",
+	"—", "--", "―", "--",
+	"‐", "-", "‑", "-", "‒", "-", "–", "-", "−", "-",
+	"\u00AD", "", "\u200B", "", "\uFEFF", "", // Soft hyphen, zero-width space, BOM
+	"\r\n", "\n", "\r", "\n",
+)
+
+// Normalize converts bill text in any of Congress.gov's formats to
+// canonical plain text: markup is stripped (each tag becomes a line break
+// so block elements stay on their own lines), entities are decoded, quotes
+// and dashes are made ASCII, whitespace runs within a line collapse to a
+// single space, lines are trimmed, and runs of blank lines collapse to one.
+func Normalize(text string) string {
+	if strings.Contains(text, "<") {
+		text = hiddenPattern.ReplaceAllString(text, "\n")
+		text = markupPattern.ReplaceAllString(text, "\n")
+	}
+	text = Typography(html.UnescapeString(text))
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spacePattern.ReplaceAllString(line, " "))
+	}
+	text = strings.Join(lines, "\n")
+
+	return strings.TrimSpace(blankLinesPattern.ReplaceAllString(text, "\n\n"))
+}
+
+// Typography replaces typographic quotes and dashes with their ASCII
+// forms, drops invisible characters, and normalizes line endings, leaving
+// all other whitespace alone.
+func Typography(text string) string {
+	return typography.Replace(text)
+}
+
+// Hash returns the SHA-256 of the normalized text with all line breaks
+// folded into spaces as well, so re-wrapped text hashes the same.
+func Hash(text string) string {
+	canonical := strings.Join(strings.Fields(Normalize(text)), " ")
+	hash := sha256.Sum256([]byte(canonical))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,40 @@
+package textnorm_test
+
+import (
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/textnorm"
+)
+
+// TestHash_FormatIndependent verifies the same text in XML, HTML, and plain
+// text renderings hashes identically.
+func TestHash_FormatIndependent(t *testing.T) {
+	xml := `<bill><section><enum>1.</enum><header>Short title</header>
+<text>This Act may be cited as the &#x201C;Example Act&#x201D;&#x2014;in full.</text></section></bill>`
+	htmlText := `<html><head><title>H.R. 1</title></head><body><pre>
+1.
+Short title
+This Act may be cited as the &ldquo;Example Act&rdquo;&mdash;in   full.
+</pre></body></html>`
+	plain := "1.\r\nShort title\r\nThis Act may be cited as the \"Example Act\"--in\nfull.\r\n"
+
+	want := textnorm.Hash(plain)
+	if got := textnorm.Hash(xml); got != want {
+		t.Errorf("XML hash differs from plain text hash")
+	}
+	if got := textnorm.Hash(htmlText); got != want {
+		t.Errorf("HTML hash differs from plain text hash")
+	}
+	if textnorm.Hash("This Act may be cited as the \"Other Act\".") == want {
+		t.Error("Expected different text to hash differently")
+	}
+}
+
+// TestNormalize_KeepsLines verifies line structure survives for diffing.
+func TestNormalize_KeepsLines(t *testing.T) {
+	got := textnorm.Normalize("  SEC. 2.  FUNDING.\n\n\n\n  (a) In   general’s.  ")
+	want := "SEC. 2. FUNDING.\n\n(a) In general's."
+	if got != want {
+		t.Errorf("Normalize = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,187 @@
+// Package textstats computes readability and complexity metrics of bill
+// text: length, sentence length, reading grade level, and defined terms.
+package textstats
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// WordsPerPage approximates the words on one printed page of a bill, for
+// PageEstimate.
+const WordsPerPage = 250
+
+// Stats are the readability metrics of one text.
+type Stats struct {
+	WordCount         int     `json:"wordCount"`
+	PageEstimate      int     `json:"pageEstimate"`      // Printed pages at WordsPerPage
+	AvgSentenceLength float64 `json:"avgSentenceLength"` // Words per sentence
+	GradeLevel        float64 `json:"gradeLevel"`        // Flesch-Kincaid grade level
+	DefinedTerms      int     `json:"definedTerms"`      // Distinct terms given a definition ("the term `X' means")
+}
+
+// Delta is the change in each metric from one text to another.
+type Delta struct {
+	WordCount         int     `json:"wordCount"`
+	PageEstimate      int     `json:"pageEstimate"`
+	AvgSentenceLength float64 `json:"avgSentenceLength"`
+	GradeLevel        float64 `json:"gradeLevel"`
+	DefinedTerms      int     `json:"definedTerms"`
+}
+
+var (
+	// definitionPattern matches a quoted term followed by a defining verb, in
+	// the quote styles of GPO text (``term'', `term') and plain quotes.
+	definitionPattern = regexp.MustCompile("(?:``|`|\"|')([A-Za-z][^`\"'\\n]{0,99}?)(?:''|'|\")\\s*,?\\s+(?:means|includes|has the meaning)\\b")
+
+	// abbreviations end with a period without ending a sentence.
+	abbreviations = map[string]bool{
+		"sec": true, "secs": true, "no": true, "nos": true, "stat": true, "pub": true,
+		"etc": true, "et": true, "seq": true, "vol": true, "pt": true, "ch": true,
+		"subch": true, "mr": true, "ms": true, "mrs": true, "dr": true, "jr": true,
+		"inc": true, "co": true, "corp": true, "st": true,
+	}
+)
+
+// Compute returns the metrics of plain text, such as Version.PlainText.
+func Compute(text string) Stats {
+	var words, sentences, syllables, scored int
+	inSentence := false
+
+	for _, field := range strings.Fields(text) {
+		word := strings.TrimFunc(field, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+		if word == "" {
+			continue
+		}
+		words++
+		inSentence = true
+		if isAlphabetic(word) {
+			syllables += countSyllables(word)
+			scored++
+		}
+		if endsSentence(field, word) {
+			sentences++
+			inSentence = false
+		}
+	}
+	if inSentence {
+		sentences++
+	}
+
+	stats := Stats{
+		WordCount:    words,
+		PageEstimate: (words + WordsPerPage - 1) / WordsPerPage,
+		DefinedTerms: countDefinedTerms(text),
+	}
+	if sentences > 0 {
+		wordsPerSentence := float64(words) / float64(sentences)
+		stats.AvgSentenceLength = round1(wordsPerSentence)
+		if scored > 0 {
+			grade := 0.39*wordsPerSentence + 11.8*float64(syllables)/float64(scored) - 15.59
+			stats.GradeLevel = round1(math.Max(grade, 0))
+		}
+	}
+	return stats
+}
+
+// Compare returns the change in each metric from one text to another.
+func Compare(from, to Stats) Delta {
+	return Delta{
+		WordCount:         to.WordCount - from.WordCount,
+		PageEstimate:      to.PageEstimate - from.PageEstimate,
+		AvgSentenceLength: round1(to.AvgSentenceLength - from.AvgSentenceLength),
+		GradeLevel:        round1(to.GradeLevel - from.GradeLevel),
+		DefinedTerms:      to.DefinedTerms - from.DefinedTerms,
+	}
+}
+
+// Fill computes the metrics of v.PlainText and stores them on v.
+func Fill(v *models.Version) {
+	s := Compute(v.PlainText)
+	v.WordCount = s.WordCount
+	v.PageEstimate = s.PageEstimate
+	v.AvgSentenceLength = s.AvgSentenceLength
+	v.GradeLevel = s.GradeLevel
+	v.DefinedTerms = s.DefinedTerms
+}
+
+// FromVersion returns the metrics stored on v. ok is false for versions
+// stored before metrics were computed.
+func FromVersion(v *models.Version) (Stats, bool) {
+	return Stats{
+		WordCount:         v.WordCount,
+		PageEstimate:      v.PageEstimate,
+		AvgSentenceLength: v.AvgSentenceLength,
+		GradeLevel:        v.GradeLevel,
+		DefinedTerms:      v.DefinedTerms,
+	}, v.WordCount > 0
+}
+
+// endsSentence reports whether a whitespace-delimited field ends a
+// sentence. word is the field with surrounding punctuation trimmed.
+// Abbreviations ("Sec.", "U.S.C.") and enumerators ("(a).") don't.
+func endsSentence(field, word string) bool {
+	trimmed := strings.TrimRight(field, `"')]`)
+	if trimmed == "" {
+		return false
+	}
+	switch trimmed[len(trimmed)-1] {
+	case '?', '!':
+		return true
+	case '.':
+	default:
+		return false
+	}
+	if strings.Contains(word, ".") || abbreviations[strings.ToLower(word)] {
+		return false
+	}
+	return len(word) > 1 || unicode.IsDigit(rune(word[0]))
+}
+
+// countSyllables estimates a word's syllables as its vowel groups, less a
+// silent final "e".
+func countSyllables(word string) int {
+	word = strings.ToLower(word)
+	count := 0
+	prevVowel := false
+	for _, r := range word {
+		vowel := strings.ContainsRune("aeiouy", r)
+		if vowel && !prevVowel {
+			count++
+		}
+		prevVowel = vowel
+	}
+	if count > 1 && strings.HasSuffix(word, "e") && !strings.HasSuffix(word, "le") {
+		count--
+	}
+	return max(count, 1)
+}
+
+// countDefinedTerms counts the distinct terms the text defines.
+func countDefinedTerms(text string) int {
+	terms := make(map[string]bool)
+	for _, m := range definitionPattern.FindAllStringSubmatch(text, -1) {
+		terms[strings.ToLower(strings.Join(strings.Fields(m[1]), " "))] = true
+	}
+	return len(terms)
+}
+
+// isAlphabetic reports whether a word is made only of letters, so numbers
+// and citations don't skew the grade level.
+func isAlphabetic(word string) bool {
+	for _, r := range word {
+		if !unicode.IsLetter(r) && r != '\'' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// round1 rounds to one decimal place.
+func round1(f float64) float64 {
+	return math.Round(f*10) / 10
+}
@@ -0,0 +1,64 @@
+package textstats_test
+
+import (
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/textstats"
+)
+
+const billText = "SEC. 2. DEFINITIONS.\n" +
+	"In this Act:\n" +
+	"(1) The term ``Secretary'' means the Secretary of Energy.\n" +
+	"(2) The term ``State'' includes the District of Columbia.\n" +
+	"(3) The term ``Secretary'' has the meaning given in section 101 of title 5, U.S.C.\n" +
+	"SEC. 3. FUNDING.\n" +
+	"There is appropriated $10,000,000 for fiscal year 2026 to carry out this Act."
+
+func TestCompute(t *testing.T) {
+	got := textstats.Compute(billText)
+	if got.WordCount != 55 {
+		t.Errorf("WordCount = %d, want 55", got.WordCount)
+	}
+	if got.PageEstimate != 1 {
+		t.Errorf("PageEstimate = %d, want 1", got.PageEstimate)
+	}
+	if got.DefinedTerms != 2 {
+		t.Errorf("DefinedTerms = %d, want 2 (Secretary and State)", got.DefinedTerms)
+	}
+	if got.AvgSentenceLength <= 0 || got.GradeLevel <= 0 {
+		t.Errorf("AvgSentenceLength = %v, GradeLevel = %v; want both positive", got.AvgSentenceLength, got.GradeLevel)
+	}
+
+	if empty := textstats.Compute(""); empty != (textstats.Stats{}) {
+		t.Errorf("Compute(\"\") = %+v, want zero", empty)
+	}
+}
+
+func TestComputeSentences(t *testing.T) {
+	// Abbreviations and enumerators don't end sentences
+	got := textstats.Compute("See 42 U.S.C. 1983 and Sec. 5 of this Act. It applies to (a). paragraph one. Done!")
+	if got.WordCount != 17 || got.AvgSentenceLength != 5.7 {
+		t.Errorf("Compute = %+v, want 17 words in 3 sentences", got)
+	}
+}
+
+func TestCompareAndFill(t *testing.T) {
+	from := textstats.Compute("The term ``agency'' means an executive agency.")
+	to := textstats.Compute(billText)
+
+	delta := textstats.Compare(from, to)
+	if delta.WordCount != to.WordCount-from.WordCount || delta.DefinedTerms != 1 {
+		t.Errorf("Compare = %+v", delta)
+	}
+
+	v := models.Version{PlainText: billText}
+	textstats.Fill(&v)
+	stats, ok := textstats.FromVersion(&v)
+	if !ok || stats != to {
+		t.Errorf("FromVersion after Fill = %+v, %v; want %+v", stats, ok, to)
+	}
+	if _, ok := textstats.FromVersion(&models.Version{}); ok {
+		t.Error("FromVersion of a version without metrics reported ok")
+	}
+}
@@ -0,0 +1,172 @@
+package textstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// gcsScheme prefixes references to Cloud Storage objects.
+const gcsScheme = "gs"
+
+// metadataTokenURL serves the default service account's access token on
+// GCE, GKE, and Cloud Run.
+const metadataTokenURL = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+
+// TokenSource returns an OAuth access token for Cloud Storage requests.
+type TokenSource func(ctx context.Context) (string, error)
+
+// StaticToken returns a TokenSource that always returns token.
+func StaticToken(token string) TokenSource {
+	return func(context.Context) (string, error) { return token, nil }
+}
+
+// MetadataToken returns a TokenSource that fetches the default service
+// account's token from the metadata server, reusing it until shortly
+// before it expires.
+func MetadataToken(client *http.Client) TokenSource {
+	var (
+		mu      sync.Mutex
+		token   string
+		expires time.Time
+	)
+	return func(ctx context.Context) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if token != "" && time.Now().Before(expires) {
+			return token, nil
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, metadataTokenURL, nil)
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Metadata-Flavor", "Google")
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("textstore: failed to fetch metadata token: %w", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("textstore: metadata token request returned status %d", resp.StatusCode)
+		}
+
+		var body struct {
+			AccessToken string `json:"access_token"`
+			ExpiresIn   int    `json:"expires_in"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			return "", fmt.Errorf("textstore: failed to decode metadata token: %w", err)
+		}
+		// Refresh a minute early so a token never expires mid-request
+		token, expires = body.AccessToken, time.Now().Add(time.Duration(body.ExpiresIn)*time.Second-time.Minute)
+		return token, nil
+	}
+}
+
+// GCS stores text in a Google Cloud Storage bucket through the JSON API.
+type GCS struct {
+	Endpoint string // Default: "https://storage.googleapis.com"
+	Bucket   string
+	Token    TokenSource
+	Client   *http.Client
+}
+
+// Put uploads content under key. The body is streamed to Cloud Storage.
+func (g *GCS) Put(ctx context.Context, key string, content io.Reader, size int64) (string, error) {
+	ref := fmt.Sprintf("%s://%s/%s", gcsScheme, g.Bucket, key)
+	u := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s",
+		g.endpoint(), url.PathEscape(g.Bucket), url.QueryEscape(key))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u, content)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+
+	resp, err := g.do(req)
+	if err != nil {
+		return "", fmt.Errorf("textstore: put %s: %w", ref, err)
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp, "put", ref); err != nil {
+		return "", err
+	}
+	return ref, nil
+}
+
+// Get opens the object a reference points to.
+func (g *GCS) Get(ctx context.Context, ref string) (io.ReadCloser, error) {
+	bucket, key, err := splitRef(ref, gcsScheme)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.objectURL(bucket, key)+"?alt=media", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := g.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("textstore: get %s: %w", ref, err)
+	}
+	if err := checkStatus(resp, "get", ref); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete removes the object a reference points to.
+func (g *GCS) Delete(ctx context.Context, ref string) error {
+	bucket, key, err := splitRef(ref, gcsScheme)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, g.objectURL(bucket, key), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := g.do(req)
+	if err != nil {
+		return fmt.Errorf("textstore: delete %s: %w", ref, err)
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp, "delete", ref); err != nil && resp.StatusCode != http.StatusNotFound {
+		return err
+	}
+	return nil
+}
+
+// endpoint returns the API origin.
+func (g *GCS) endpoint() string {
+	if g.Endpoint == "" {
+		return "https://storage.googleapis.com"
+	}
+	return strings.TrimSuffix(g.Endpoint, "/")
+}
+
+// objectURL returns the JSON API URL of an object. Slashes in the object
+// name are escaped, as the API requires.
+func (g *GCS) objectURL(bucket, key string) string {
+	return fmt.Sprintf("%s/storage/v1/b/%s/o/%s", g.endpoint(), url.PathEscape(bucket), url.PathEscape(key))
+}
+
+// do authorizes and sends a request.
+func (g *GCS) do(req *http.Request) (*http.Response, error) {
+	token, err := g.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	client := g.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
@@ -0,0 +1,74 @@
+package textstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// postgresScheme prefixes references to text_objects rows.
+const postgresScheme = "postgres:"
+
+// Postgres stores text in the text_objects table, keeping it out of the
+// versions table without adding another service to the deployment.
+type Postgres struct {
+	db *gorm.DB
+}
+
+// NewPostgres creates a Postgres text store.
+func NewPostgres(db *gorm.DB) *Postgres {
+	return &Postgres{db: db}
+}
+
+// Put stores content under key, replacing any existing object.
+func (p *Postgres) Put(ctx context.Context, key string, content io.Reader, size int64) (string, error) {
+	var b strings.Builder
+	b.Grow(int(size))
+	if _, err := io.Copy(&b, content); err != nil {
+		return "", fmt.Errorf("textstore: failed to read content: %w", err)
+	}
+
+	obj := models.TextObject{Key: key, Content: b.String()}
+	if err := p.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"content"}),
+	}).Create(&obj).Error; err != nil {
+		return "", fmt.Errorf("textstore: failed to store %s: %w", key, err)
+	}
+	return postgresScheme + key, nil
+}
+
+// Get returns the object a reference points to.
+func (p *Postgres) Get(ctx context.Context, ref string) (io.ReadCloser, error) {
+	key, ok := strings.CutPrefix(ref, postgresScheme)
+	if !ok {
+		return nil, fmt.Errorf("textstore: %q is not a postgres reference", ref)
+	}
+	var obj models.TextObject
+	if err := p.db.WithContext(ctx).Where("key = ?", key).First(&obj).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, ref)
+		}
+		return nil, fmt.Errorf("textstore: failed to fetch %s: %w", ref, err)
+	}
+	return io.NopCloser(strings.NewReader(obj.Content)), nil
+}
+
+// Delete removes the object a reference points to.
+func (p *Postgres) Delete(ctx context.Context, ref string) error {
+	key, ok := strings.CutPrefix(ref, postgresScheme)
+	if !ok {
+		return fmt.Errorf("textstore: %q is not a postgres reference", ref)
+	}
+	if err := p.db.WithContext(ctx).Where("key = ?", key).Delete(&models.TextObject{}).Error; err != nil {
+		return fmt.Errorf("textstore: failed to delete %s: %w", ref, err)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package textstore
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// s3Scheme prefixes references to S3 objects.
+const s3Scheme = "s3"
+
+// unsignedPayload lets request bodies stream without hashing them first.
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3 stores text in an S3 bucket, or any service speaking the S3 API
+// (MinIO, Cloudflare R2, GCS interoperability mode). Requests use path-style
+// addressing and Signature Version 4.
+type S3 struct {
+	Endpoint  string // e.g., "https://s3.us-east-1.amazonaws.com" (default derived from Region)
+	Region    string // Signing region (default: "us-east-1")
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	Client    *http.Client
+}
+
+// Put uploads content under key.
+func (s *S3) Put(ctx context.Context, key string, content io.Reader, size int64) (string, error) {
+	ref := fmt.Sprintf("%s://%s/%s", s3Scheme, s.Bucket, key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(s.Bucket, key), content)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+
+	resp, err := s.do(req)
+	if err != nil {
+		return "", fmt.Errorf("textstore: put %s: %w", ref, err)
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp, "put", ref); err != nil {
+		return "", err
+	}
+	return ref, nil
+}
+
+// Get opens the object a reference points to.
+func (s *S3) Get(ctx context.Context, ref string) (io.ReadCloser, error) {
+	bucket, key, err := splitRef(ref, s3Scheme)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(bucket, key), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("textstore: get %s: %w", ref, err)
+	}
+	if err := checkStatus(resp, "get", ref); err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// Delete removes the object a reference points to.
+func (s *S3) Delete(ctx context.Context, ref string) error {
+	bucket, key, err := splitRef(ref, s3Scheme)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(bucket, key), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("textstore: delete %s: %w", ref, err)
+	}
+	defer resp.Body.Close()
+	if err := checkStatus(resp, "delete", ref); err != nil && resp.StatusCode != http.StatusNotFound {
+		return err
+	}
+	return nil
+}
+
+// objectURL returns the path-style URL of an object.
+func (s *S3) objectURL(bucket, key string) string {
+	endpoint := s.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", s.region())
+	}
+	return strings.TrimSuffix(endpoint, "/") + "/" + bucket + "/" + escapePath(key)
+}
+
+// region returns the signing region.
+func (s *S3) region() string {
+	if s.Region == "" {
+		return "us-east-1"
+	}
+	return s.Region
+}
+
+// do signs and sends a request.
+func (s *S3) do(req *http.Request) (*http.Response, error) {
+	s.sign(req, unsignedPayload, time.Now().UTC())
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+// sign adds Signature Version 4 headers to req.
+func (s *S3) sign(req *http.Request, payloadHash string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	// Canonical headers: host plus every header that is set, lowercased and sorted
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.region() + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hexSHA256(canonicalRequest),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.SecretKey), date)
+	key = hmacSHA256(key, s.region())
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKey, scope, signedHeaders, signature))
+}
+
+// canonicalQuery encodes query parameters sorted by name, as SigV4 requires.
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		values := q[k]
+		sort.Strings(values)
+		for _, v := range values {
+			parts = append(parts, awsEscape(k)+"="+awsEscape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// escapePath escapes each segment of an object key, keeping the slashes.
+func escapePath(key string) string {
+	segments := strings.Split(key, "/")
+	for i, seg := range segments {
+		segments[i] = awsEscape(seg)
+	}
+	return strings.Join(segments, "/")
+}
+
+// awsEscape percent-encodes everything except unreserved characters.
+func awsEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// hmacSHA256 returns HMAC-SHA256(key, data).
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// hexSHA256 returns the hex SHA-256 of s.
+func hexSHA256(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,176 @@
+// Package textstore keeps version text outside the versions table, in
+// Postgres, S3 (or an S3-compatible service), or Google Cloud Storage, so
+// deployments can keep the hot tables small. A version whose raw text was
+// offloaded has an empty TextContent and a StorageRef pointing at the
+// object; its PlainText, which diffs are computed over, stays in the row.
+package textstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// ErrNotFound is returned when a referenced object doesn't exist.
+var ErrNotFound = errors.New("textstore: object not found")
+
+// ErrNoStore is returned when a version's text was offloaded but no store
+// is configured to read it back.
+var ErrNoStore = errors.New("textstore: version text is offloaded but no text store is configured")
+
+// Store reads and writes text objects.
+type Store interface {
+	// Put stores size bytes read from content under key and returns the
+	// reference to save in Version.StorageRef.
+	Put(ctx context.Context, key string, content io.Reader, size int64) (string, error)
+
+	// Get opens the object a reference returned by Put points to.
+	// The caller must close it.
+	Get(ctx context.Context, ref string) (io.ReadCloser, error)
+
+	// Delete removes the object a reference points to. Deleting a missing
+	// object is not an error.
+	Delete(ctx context.Context, ref string) error
+}
+
+// Key returns the object key for a bill version's raw text, such as
+// "bills/42/ih-3f2a9c1b4d5e6f70". Bills are keyed by ID, since their
+// numbers identify them only together with their state, session, and
+// tenant, and state bills with suffixed numbers have no BillNumber. The
+// content hash prefix keeps a republished version from overwriting the
+// text of the one it replaced.
+func Key(bill *models.Bill, versionCode, contentHash string) string {
+	if len(contentHash) > 16 {
+		contentHash = contentHash[:16]
+	}
+	return fmt.Sprintf("bills/%d/%s-%s", bill.ID, strings.ToLower(versionCode), contentHash)
+}
+
+// Offload uploads a new version's raw text to the store and clears it from
+// the version, setting StorageRef. With a nil store the text stays inline.
+// TextSize is recorded either way so size limits don't need the text.
+func Offload(ctx context.Context, store Store, bill *models.Bill, v *models.Version) error {
+	v.TextSize = len(v.TextContent)
+	if store == nil || v.TextContent == "" {
+		return nil
+	}
+	ref, err := store.Put(ctx, Key(bill, v.VersionCode, v.ContentHash),
+		strings.NewReader(v.TextContent), int64(len(v.TextContent)))
+	if err != nil {
+		return err
+	}
+	v.StorageRef, v.TextContent = ref, ""
+	return nil
+}
+
+// Load fills an offloaded version's TextContent from the store. Versions
+// stored inline are left unchanged.
+func Load(ctx context.Context, store Store, v *models.Version) error {
+	if v.StorageRef == "" {
+		return nil
+	}
+	if store == nil {
+		return ErrNoStore
+	}
+	r, err := store.Get(ctx, v.StorageRef)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	var b strings.Builder
+	if v.TextSize > 0 {
+		b.Grow(v.TextSize)
+	}
+	if _, err := io.Copy(&b, r); err != nil {
+		return fmt.Errorf("textstore: failed to read %s: %w", v.StorageRef, err)
+	}
+	v.TextContent = b.String()
+	return nil
+}
+
+// FromEnv builds the store configured by environment variables:
+//
+//	TEXT_STORE              "postgres", "s3", or "gcs"; empty keeps text inline
+//	TEXT_STORE_BUCKET       bucket name (s3, gcs)
+//	S3_ENDPOINT             endpoint URL (default: https://s3.<region>.amazonaws.com)
+//	S3_REGION               signing region (default: us-east-1)
+//	AWS_ACCESS_KEY_ID       S3 access key
+//	AWS_SECRET_ACCESS_KEY   S3 secret key
+//	GCS_ACCESS_TOKEN        static OAuth token (default: from the GCE/Cloud Run metadata server)
+//
+// Returns nil when TEXT_STORE is unset.
+func FromEnv(db *gorm.DB) (Store, error) {
+	client := &http.Client{Timeout: 5 * time.Minute}
+
+	switch kind := strings.ToLower(os.Getenv("TEXT_STORE")); kind {
+	case "":
+		return nil, nil
+	case "postgres":
+		return NewPostgres(db), nil
+	case "s3":
+		bucket := os.Getenv("TEXT_STORE_BUCKET")
+		if bucket == "" {
+			return nil, errors.New("textstore: TEXT_STORE_BUCKET is required for s3")
+		}
+		accessKey, secretKey := os.Getenv("AWS_ACCESS_KEY_ID"), os.Getenv("AWS_SECRET_ACCESS_KEY")
+		if accessKey == "" || secretKey == "" {
+			return nil, errors.New("textstore: AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required for s3")
+		}
+		return &S3{
+			Endpoint:  os.Getenv("S3_ENDPOINT"),
+			Region:    os.Getenv("S3_REGION"),
+			Bucket:    bucket,
+			AccessKey: accessKey,
+			SecretKey: secretKey,
+			Client:    client,
+		}, nil
+	case "gcs":
+		bucket := os.Getenv("TEXT_STORE_BUCKET")
+		if bucket == "" {
+			return nil, errors.New("textstore: TEXT_STORE_BUCKET is required for gcs")
+		}
+		token := MetadataToken(client)
+		if static := os.Getenv("GCS_ACCESS_TOKEN"); static != "" {
+			token = StaticToken(static)
+		}
+		return &GCS{Bucket: bucket, Token: token, Client: client}, nil
+	default:
+		return nil, fmt.Errorf("textstore: unknown TEXT_STORE %q (want postgres, s3, or gcs)", kind)
+	}
+}
+
+// splitRef splits a "scheme://bucket/key" reference.
+func splitRef(ref, scheme string) (bucket, key string, err error) {
+	rest, ok := strings.CutPrefix(ref, scheme+"://")
+	if !ok {
+		return "", "", fmt.Errorf("textstore: %q is not a %s reference", ref, scheme)
+	}
+	bucket, key, ok = strings.Cut(rest, "/")
+	if !ok || bucket == "" || key == "" {
+		return "", "", fmt.Errorf("textstore: malformed reference %q", ref)
+	}
+	return bucket, key, nil
+}
+
+// checkStatus converts an unsuccessful object storage response to an error.
+func checkStatus(resp *http.Response, op, ref string) error {
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusNotFound:
+		return fmt.Errorf("%w: %s", ErrNotFound, ref)
+	default:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("textstore: %s %s: unexpected status %d: %s", op, ref, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+}
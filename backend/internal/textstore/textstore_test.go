@@ -0,0 +1,190 @@
+package textstore_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/models"
+	"github.com/drewjst/deltagov/internal/textstore"
+)
+
+// objectServer is a fake object store keyed by request path.
+type objectServer struct {
+	mu      sync.Mutex
+	objects map[string]string
+	auth    []string
+}
+
+func (o *objectServer) handle(key func(*http.Request) string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		o.auth = append(o.auth, r.Header.Get("Authorization"))
+		k := key(r)
+		switch r.Method {
+		case http.MethodPut, http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			o.objects[k] = string(body)
+		case http.MethodGet:
+			body, ok := o.objects[k]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			io.WriteString(w, body)
+		case http.MethodDelete:
+			if _, ok := o.objects[k]; !ok {
+				http.NotFound(w, r)
+				return
+			}
+			delete(o.objects, k)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}
+}
+
+func roundTrip(t *testing.T, store textstore.Store) {
+	t.Helper()
+	ctx := context.Background()
+	content := "<bill>SEC. 1. SHORT TITLE.</bill>"
+
+	ref, err := store.Put(ctx, "bills/119/hr/1/ih-abc", strings.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	r, err := store.Get(ctx, ref)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, _ := io.ReadAll(r)
+	r.Close()
+	if string(got) != content {
+		t.Errorf("Get = %q, want %q", got, content)
+	}
+
+	if err := store.Delete(ctx, ref); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := store.Delete(ctx, ref); err != nil {
+		t.Errorf("Delete of a missing object: %v", err)
+	}
+	if _, err := store.Get(ctx, ref); !errors.Is(err, textstore.ErrNotFound) {
+		t.Errorf("Get after Delete: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestS3RoundTrip(t *testing.T) {
+	fake := &objectServer{objects: map[string]string{}}
+	srv := httptest.NewServer(fake.handle(func(r *http.Request) string { return r.URL.Path }))
+	defer srv.Close()
+
+	store := &textstore.S3{Endpoint: srv.URL, Bucket: "text", AccessKey: "AK", SecretKey: "SK", Client: srv.Client()}
+	roundTrip(t, store)
+
+	if _, ok := fake.objects["/text/bills/119/hr/1/ih-abc"]; ok {
+		t.Error("object not deleted")
+	}
+	for _, auth := range fake.auth {
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AK/") {
+			t.Errorf("Authorization = %q, want a SigV4 signature", auth)
+		}
+	}
+}
+
+func TestGCSRoundTrip(t *testing.T) {
+	fake := &objectServer{objects: map[string]string{}}
+	srv := httptest.NewServer(fake.handle(func(r *http.Request) string {
+		if name := r.URL.Query().Get("name"); name != "" {
+			return name // Upload: name in the query
+		}
+		return strings.TrimPrefix(r.URL.Path, "/storage/v1/b/text/o/")
+	}))
+	defer srv.Close()
+
+	store := &textstore.GCS{Endpoint: srv.URL, Bucket: "text", Token: textstore.StaticToken("tok"), Client: srv.Client()}
+	roundTrip(t, store)
+
+	for _, auth := range fake.auth {
+		if auth != "Bearer tok" {
+			t.Errorf("Authorization = %q, want bearer token", auth)
+		}
+	}
+}
+
+// memStore is an in-memory Store.
+type memStore map[string]string
+
+func (m memStore) Put(_ context.Context, key string, content io.Reader, _ int64) (string, error) {
+	b, err := io.ReadAll(content)
+	m[key] = string(b)
+	return "mem:" + key, err
+}
+
+func (m memStore) Get(_ context.Context, ref string) (io.ReadCloser, error) {
+	body, ok := m[strings.TrimPrefix(ref, "mem:")]
+	if !ok {
+		return nil, textstore.ErrNotFound
+	}
+	return io.NopCloser(strings.NewReader(body)), nil
+}
+
+func (m memStore) Delete(_ context.Context, ref string) error {
+	delete(m, strings.TrimPrefix(ref, "mem:"))
+	return nil
+}
+
+func TestOffloadAndLoad(t *testing.T) {
+	ctx := context.Background()
+	store := memStore{}
+	bill := &models.Bill{ID: 42, Congress: 119, BillType: "HR", BillNumber: 1}
+	v := &models.Version{VersionCode: "IH", ContentHash: "0123456789abcdef0123", TextContent: "raw text", PlainText: "raw text"}
+
+	if err := textstore.Offload(ctx, store, bill, v); err != nil {
+		t.Fatalf("Offload: %v", err)
+	}
+	if v.StorageRef != "mem:bills/42/ih-0123456789abcdef" {
+		t.Errorf("StorageRef = %q", v.StorageRef)
+	}
+	if v.TextContent != "" || v.PlainText != "raw text" || v.TextSize != len("raw text") {
+		t.Errorf("after Offload: TextContent=%q PlainText=%q TextSize=%d", v.TextContent, v.PlainText, v.TextSize)
+	}
+
+	if err := textstore.Load(ctx, store, v); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if v.TextContent != "raw text" {
+		t.Errorf("after Load: TextContent = %q", v.TextContent)
+	}
+
+	if err := textstore.Load(ctx, nil, v); !errors.Is(err, textstore.ErrNoStore) {
+		t.Errorf("Load without a store: err = %v, want ErrNoStore", err)
+	}
+}
+
+// TestKeyDistinguishesBills verifies bills sharing a number, such as state
+// bills whose suffixed numbers leave BillNumber 0, get distinct keys.
+func TestKeyDistinguishesBills(t *testing.T) {
+	ab12a := &models.Bill{ID: 7, BillType: "AB", Number: "12A", StateCode: "ca", Session: "20252026", Jurisdiction: models.JurisdictionState}
+	ab12b := &models.Bill{ID: 8, BillType: "AB", Number: "12B", StateCode: "ca", Session: "20252026", Jurisdiction: models.JurisdictionState}
+	const hash = "0123456789abcdef0123"
+	if a, b := textstore.Key(ab12a, "IH", hash), textstore.Key(ab12b, "IH", hash); a == b {
+		t.Errorf("Key(AB 12A) = Key(AB 12B) = %q, want distinct keys", a)
+	}
+}
+
+func TestOffloadWithoutStoreKeepsTextInline(t *testing.T) {
+	v := &models.Version{TextContent: "inline"}
+	if err := textstore.Offload(context.Background(), nil, &models.Bill{}, v); err != nil {
+		t.Fatalf("Offload: %v", err)
+	}
+	if v.TextContent != "inline" || v.StorageRef != "" || v.TextSize != len("inline") {
+		t.Errorf("TextContent=%q StorageRef=%q TextSize=%d", v.TextContent, v.StorageRef, v.TextSize)
+	}
+}
@@ -0,0 +1,129 @@
+// Package trending scores bills by how actively they are changing: text
+// versions published, legislative events recorded, and lines changed, over
+// a sliding window. Scores are stored in the bill_activity table, which Run
+// rebuilds, for the API to rank bills by.
+package trending
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/drewjst/deltagov/internal/logging"
+	"github.com/drewjst/deltagov/internal/models"
+)
+
+// DefaultWindow is how far back activity counts when no window is set.
+const DefaultWindow = 7 * 24 * time.Hour
+
+// Score weights. A new version outweighs an event, since it's what can be
+// diffed; lines changed count logarithmically, so one very large bill
+// doesn't bury every other change.
+const (
+	VersionWeight = 10.0
+	EventWeight   = 3.0
+	LinesWeight   = 2.0
+)
+
+// batchSize is the number of rows inserted per statement.
+const batchSize = 500
+
+// Score returns the activity score of a bill with the given activity.
+func Score(versions, events, linesChanged int) float64 {
+	score := VersionWeight*float64(versions) +
+		EventWeight*float64(events) +
+		LinesWeight*math.Log2(1+float64(max(linesChanged, 0)))
+	return math.Round(score*100) / 100
+}
+
+// Run recomputes every bill's activity over the window ending now and
+// replaces the stored scores, returning how many bills had activity.
+// A window of zero or less uses DefaultWindow.
+func Run(ctx context.Context, db *gorm.DB, window time.Duration) (int, error) {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	now := time.Now()
+	since := now.Add(-window)
+
+	db = db.WithContext(ctx)
+
+	// Each version's lines changed come from its diff against the bill's
+	// previous version, the pair the precompute queue caches
+	var versions []struct {
+		BillID       uint
+		Versions     int
+		LinesChanged int
+	}
+	if err := db.Raw(`
+		WITH ordered AS (
+			SELECT id, bill_id, fetched_at,
+				LAG(id) OVER (PARTITION BY bill_id ORDER BY fetched_at, id) AS prev_id
+			FROM versions
+			WHERE document_id IS NULL
+		)
+		SELECT o.bill_id, COUNT(*) AS versions, COALESCE(SUM(d.lines), 0) AS lines_changed
+		FROM ordered o
+		LEFT JOIN LATERAL (
+			SELECT insertions + deletions AS lines FROM deltas
+			WHERE version_a_id = o.prev_id AND version_b_id = o.id
+			ORDER BY computed_at DESC LIMIT 1
+		) d ON true
+		WHERE o.fetched_at >= ?
+		GROUP BY o.bill_id`, since).Scan(&versions).Error; err != nil {
+		return 0, fmt.Errorf("trending: failed to count versions: %w", err)
+	}
+
+	var events []struct {
+		BillID uint
+		Events int
+	}
+	if err := db.Model(&models.BillEvent{}).
+		Select("bill_id, COUNT(*) AS events").
+		Where("occurred_at >= ? AND event_type <> ?", since, models.BillEventVersionAdded).
+		Group("bill_id").Scan(&events).Error; err != nil {
+		return 0, fmt.Errorf("trending: failed to count events: %w", err)
+	}
+
+	activity := make(map[uint]*models.BillActivity, len(versions)+len(events))
+	row := func(billID uint) *models.BillActivity {
+		a, ok := activity[billID]
+		if !ok {
+			a = &models.BillActivity{BillID: billID, WindowStart: since, ComputedAt: now}
+			activity[billID] = a
+		}
+		return a
+	}
+	for _, v := range versions {
+		a := row(v.BillID)
+		a.Versions, a.LinesChanged = v.Versions, v.LinesChanged
+	}
+	for _, e := range events {
+		row(e.BillID).Events = e.Events
+	}
+
+	rows := make([]models.BillActivity, 0, len(activity))
+	for _, a := range activity {
+		a.Score = Score(a.Versions, a.Events, a.LinesChanged)
+		rows = append(rows, *a)
+	}
+
+	// Replaced in one transaction so readers never see a partial ranking
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&models.BillActivity{}).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(rows, batchSize).Error
+	}); err != nil {
+		return 0, fmt.Errorf("trending: failed to store scores: %w", err)
+	}
+
+	logging.FromContext(ctx).Info("bill activity scores updated", "bills", len(rows), "window", window.String())
+	return len(rows), nil
+}
@@ -0,0 +1,27 @@
+package trending_test
+
+import (
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/trending"
+)
+
+func TestScore(t *testing.T) {
+	if got := trending.Score(0, 0, 0); got != 0 {
+		t.Errorf("Score of no activity = %v, want 0", got)
+	}
+	if got := trending.Score(1, 2, 3); got != 20 {
+		t.Errorf("Score(1, 2, 3) = %v, want 20", got)
+	}
+
+	// A new version outranks a small diff alone, and more of anything ranks higher
+	if trending.Score(1, 0, 0) <= trending.Score(0, 0, 10) {
+		t.Error("a version should outweigh 10 changed lines")
+	}
+	if trending.Score(2, 1, 50) <= trending.Score(2, 1, 40) {
+		t.Error("more changed lines should score higher")
+	}
+	if trending.Score(0, 0, -5) != 0 {
+		t.Error("negative line counts should be ignored")
+	}
+}
@@ -0,0 +1,235 @@
+// Package versioncode maps Congress.gov bill text version types to the
+// short GPO version codes stored on versions, and orders them by
+// legislative stage.
+package versioncode
+
+import (
+	"slices"
+	"strings"
+)
+
+// Chambers for Info.Chamber.
+const (
+	ChamberHouse  = "House"
+	ChamberSenate = "Senate"
+)
+
+// Legislative stages, in the order a bill moves through them.
+const (
+	StageUnknown    = 0
+	StageIntroduced = 1
+	StageReferred   = 2
+	StageReported   = 3
+	StagePassed     = 4 // Engrossed or agreed to by the originating chamber
+	StageAmended    = 5 // Engrossed amendment from the second chamber
+	StageEnrolled   = 6
+	StageEnacted    = 7
+)
+
+// Info describes a version code.
+type Info struct {
+	Code    string // Short GPO code, e.g., "EH"
+	Label   string // Congress.gov type string, e.g., "Engrossed in House"
+	Stage   int    // One of the Stage constants
+	Chamber string // ChamberHouse, ChamberSenate, or "" for bicameral stages
+}
+
+// known lists every mapped version code. Label is the canonical
+// Congress.gov type string.
+var known = []Info{
+	{"IH", "Introduced in House", StageIntroduced, ChamberHouse},
+	{"IS", "Introduced in Senate", StageIntroduced, ChamberSenate},
+	{"RFH", "Referred in House", StageReferred, ChamberHouse},
+	{"RFS", "Referred in Senate", StageReferred, ChamberSenate},
+	{"RDH", "Received in House", StageReferred, ChamberHouse},
+	{"RDS", "Received in Senate", StageReferred, ChamberSenate},
+	{"RH", "Reported in House", StageReported, ChamberHouse},
+	{"RS", "Reported in Senate", StageReported, ChamberSenate},
+	{"PCH", "Placed on Calendar House", StageReported, ChamberHouse},
+	{"PCS", "Placed on Calendar Senate", StageReported, ChamberSenate},
+	{"EH", "Engrossed in House", StagePassed, ChamberHouse},
+	{"ES", "Engrossed in Senate", StagePassed, ChamberSenate},
+	{"CPH", "Considered and Passed House", StagePassed, ChamberHouse},
+	{"CPS", "Considered and Passed Senate", StagePassed, ChamberSenate},
+	{"ATH", "Agreed to House", StagePassed, ChamberHouse},
+	{"ATS", "Agreed to Senate", StagePassed, ChamberSenate},
+	{"EAH", "Engrossed Amendment House", StageAmended, ChamberHouse},
+	{"EAS", "Engrossed Amendment Senate", StageAmended, ChamberSenate},
+	{"ENR", "Enrolled Bill", StageEnrolled, ""},
+	{"PL", "Public Law", StageEnacted, ""},
+}
+
+var (
+	byCode = make(map[string]Info, len(known))
+	byType = make(map[string]string, len(known)+1)
+)
+
+func init() {
+	for _, info := range known {
+		byCode[info.Code] = info
+		byType[strings.ToLower(info.Label)] = info.Code
+	}
+	// Older API responses and earlier rows use the short form
+	byType["enrolled"] = "ENR"
+}
+
+// FromType returns the short code for a Congress.gov text version type
+// ("Engrossed in House" -> "EH"). Strings that are already codes are
+// returned as-is, and unmapped types are returned trimmed but unchanged
+// so no information is lost.
+func FromType(typeStr string) string {
+	typeStr = strings.TrimSpace(typeStr)
+	if code, ok := byType[strings.ToLower(typeStr)]; ok {
+		return code
+	}
+	if _, ok := byCode[strings.ToUpper(typeStr)]; ok {
+		return strings.ToUpper(typeStr)
+	}
+	return typeStr
+}
+
+// Known returns every mapped version code.
+func Known() []Info {
+	return slices.Clone(known)
+}
+
+// Lookup returns the metadata for a code.
+func Lookup(code string) (Info, bool) {
+	info, ok := byCode[code]
+	return info, ok
+}
+
+// Label returns the human-readable label for a code, or the code itself
+// when it is unknown.
+func Label(code string) string {
+	if info, ok := byCode[code]; ok {
+		return info.Label
+	}
+	return code
+}
+
+// Stage returns the legislative stage of a code, or StageUnknown.
+func Stage(code string) int {
+	return byCode[code].Stage
+}
+
+// Chamber returns the chamber a code belongs to, or "" for bicameral or
+// unknown codes.
+func Chamber(code string) string {
+	return byCode[code].Chamber
+}
+
+// IsEnacted reports whether a code denotes enacted text (enrolled or
+// public law).
+func IsEnacted(code string) bool {
+	return Stage(code) >= StageEnrolled
+}
+
+// Less reports whether code a comes before code b in the legislative
+// process. Unknown codes sort after known ones.
+func Less(a, b string) bool {
+	sa, sb := Stage(a), Stage(b)
+	if sa == StageUnknown {
+		return false
+	}
+	return sb == StageUnknown || sa < sb
+}
+
+// LegacyTypes returns the type strings that may have been stored as a
+// version code before codes were normalized, mapped to their code.
+//
+// The first release also stored types it didn't map by their first two
+// letters. Of those only "Pl" names one code: "Placed on Calendar Senate"
+// was mapped, so it can only be "Placed on Calendar House". The others
+// ("En" for Engrossed Amendment House or Enrolled Bill, "Re" for Referred
+// or Received in either chamber, and "Co" and "Ag" for either chamber)
+// can't be told apart from the stored row and aren't mapped.
+func LegacyTypes() map[string]string {
+	legacy := make(map[string]string, len(known)+2)
+	for _, info := range known {
+		legacy[info.Label] = info.Code
+	}
+	legacy["Enrolled"] = "ENR"
+	legacy["Pl"] = "PCH"
+	return legacy
+}
+
+// Parents returns, for a bill's version codes in the order the versions
+// were published, the index of the version each was derived from, or -1
+// for the first. Versions aren't a straight line: a chamber's committee
+// and floor versions follow its own previous version, or the other
+// chamber's passed text it received; an engrossed amendment branches from
+// the other chamber's passed text, or its latest amendment when amending
+// an amendment; enrolled text follows the latest passed or amended
+// version, and public law the enrolled text. Codes that aren't mapped,
+// such as reserved or star print versions, follow the version published
+// before them.
+func Parents(codes []string) []int {
+	parents := make([]int, len(codes))
+	latest := make(map[string]int)    // Latest committee or floor version per chamber
+	passed := make(map[string]int)    // Latest passed version per chamber
+	amendment := make(map[string]int) // Latest engrossed amendment per chamber
+	enrolled := -1
+	agreed := -1 // Latest passed or amended version of either chamber
+
+	for i, code := range codes {
+		parent := i - 1
+		info, ok := Lookup(code)
+		if ok {
+			other := otherChamber(info.Chamber)
+			switch info.Stage {
+			case StageEnacted:
+				if enrolled >= 0 {
+					parent = enrolled
+				}
+			case StageEnrolled:
+				if agreed >= 0 {
+					parent = agreed
+				}
+			case StageAmended:
+				if j, found := amendment[other]; found {
+					parent = j
+				} else if j, found := passed[other]; found {
+					parent = j
+				}
+			default:
+				if j, found := latest[info.Chamber]; found {
+					parent = j
+				} else if j, found := passed[other]; found {
+					parent = j
+				} else if info.Stage == StageIntroduced {
+					parent = -1
+				}
+			}
+
+			switch info.Stage {
+			case StageEnacted:
+			case StageEnrolled:
+				enrolled = i
+			case StageAmended:
+				amendment[info.Chamber] = i
+				agreed = i
+			default:
+				latest[info.Chamber] = i
+				if info.Stage == StagePassed {
+					passed[info.Chamber] = i
+					agreed = i
+				}
+			}
+		}
+		parents[i] = parent
+	}
+	return parents
+}
+
+// otherChamber returns the chamber other than chamber, or "" for a
+// bicameral one.
+func otherChamber(chamber string) string {
+	switch chamber {
+	case ChamberHouse:
+		return ChamberSenate
+	case ChamberSenate:
+		return ChamberHouse
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package versioncode_test
+
+import (
+	"testing"
+
+	"github.com/drewjst/deltagov/internal/versioncode"
+)
+
+// TestFromType verifies type strings, codes, and unknown types all map sensibly.
+func TestFromType(t *testing.T) {
+	cases := map[string]string{
+		"Engrossed in House":    "EH",
+		" Introduced in Senate": "IS",
+		"Enrolled Bill":         "ENR",
+		"Enrolled":              "ENR",
+		"eh":                    "EH",
+		"PL":                    "PL",
+		"Something New":         "Something New",
+	}
+	for in, want := range cases {
+		if got := versioncode.FromType(in); got != want {
+			t.Errorf("FromType(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+// TestStageOrdering verifies codes order by legislative stage.
+func TestStageOrdering(t *testing.T) {
+	ordered := []string{"IH", "RH", "EH", "EAS", "ENR", "PL"}
+	for i := 1; i < len(ordered); i++ {
+		if !versioncode.Less(ordered[i-1], ordered[i]) {
+			t.Errorf("expected %s before %s", ordered[i-1], ordered[i])
+		}
+	}
+	if versioncode.Less("XYZ", "IH") || !versioncode.Less("IH", "XYZ") {
+		t.Error("expected unknown codes to sort last")
+	}
+	if versioncode.Chamber("RS") != versioncode.ChamberSenate || versioncode.Chamber("ENR") != "" {
+		t.Error("unexpected chamber metadata")
+	}
+	if !versioncode.IsEnacted("ENR") || versioncode.IsEnacted("EH") {
+		t.Error("unexpected IsEnacted result")
+	}
+}
+
+// TestParents verifies versions branch where the chambers' texts diverge.
+func TestParents(t *testing.T) {
+	codes := []string{"IH", "RH", "EH", "RFS", "RS", "EAS", "EAH", "ENR", "PL", "XYZ"}
+	want := []int{-1, 0, 1, 2, 3, 2, 5, 6, 7, 8}
+	got := versioncode.Parents(codes)
+	for i := range codes {
+		if got[i] != want[i] {
+			t.Errorf("parent of %s = %d, want %d", codes[i], got[i], want[i])
+		}
+	}
+}
+
+// TestLegacyTypes verifies stored type strings and unambiguous truncated
+// codes map to their codes, and ambiguous ones are left alone.
+func TestLegacyTypes(t *testing.T) {
+	legacy := versioncode.LegacyTypes()
+	for stored, want := range map[string]string{"Engrossed in House": "EH", "Enrolled": "ENR", "Pl": "PCH"} {
+		if got := legacy[stored]; got != want {
+			t.Errorf("LegacyTypes()[%q] = %q, want %q", stored, got, want)
+		}
+	}
+	for _, stored := range []string{"En", "Re", "Co", "Ag"} {
+		if code, ok := legacy[stored]; ok {
+			t.Errorf("Ambiguous %q mapped to %q", stored, code)
+		}
+	}
+}
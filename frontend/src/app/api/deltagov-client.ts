@@ -0,0 +1,4459 @@
+// Code generated by genclient from the DeltaGov OpenAPI document. DO NOT EDIT.
+
+export interface AccountHistoryResponse {
+  account: string;
+  agency?: string;
+  bureau?: string;
+  /** Funding by fiscal year, as enacted or else as in the latest version. */
+  fiscalYears: FiscalYearLevel[] | null;
+  key: string;
+  subcommittee?: string;
+  /** Every version that funds the account, oldest first. */
+  versions: AccountLevel[] | null;
+}
+
+export interface AccountLevel {
+  account: string;
+  agency?: string;
+  /** Whole dollars. */
+  amount: number;
+  bill: BillResponse;
+  bureau?: string;
+  /** The bill became law. */
+  enacted: boolean;
+  fetchedAt: string;
+  fiscalYear?: number;
+  /** Identifies the account across versions and fiscal years. */
+  key: string;
+  /** As written. */
+  raw: string;
+  /** Which of the twelve bills funds the account. */
+  subcommittee?: string;
+  title?: string;
+  versionCode: string;
+  versionId: number;
+}
+
+export interface ActionResponse {
+  date: string;
+  text: string;
+  type?: string;
+}
+
+export interface AddBillAliasInputBody {
+  /** Name the bill is known by. */
+  alias: string;
+}
+
+export interface AddDraftVersionInputBody {
+  /** Bill text: plain text, HTML, or USLM XML. */
+  text: string;
+  /** Version code, e.g., "V2". */
+  versionCode: string;
+}
+
+export interface AdhocText {
+  /** Name shown as the diff's fromVersion or toVersion (default: "from" or "to"). */
+  label?: string;
+  /** Bill text: plain text, HTML, or USLM XML. */
+  text: string;
+}
+
+export interface AdminUserResponse {
+  createdAt: string;
+  id: number;
+  name: string;
+  /** One of: reader, editor, admin. */
+  role: 'reader' | 'editor' | 'admin';
+  tenantId?: number;
+}
+
+export interface AlertMatchList {
+  limit: number;
+  matches: AlertMatchResponse[] | null;
+  offset: number;
+  total: number;
+}
+
+export interface AlertMatchResponse {
+  alertId: number;
+  bill: BillResponse;
+  id: number;
+  matchedAt: string;
+  snippets: AlertSnippet[] | null;
+  versionCode: string;
+  versionId: number;
+}
+
+export interface AlertSnippet {
+  /** Section anchor, as in diffs. */
+  anchor?: string;
+  /** Byte offset of the match in the version's plain text. */
+  offset: number;
+  /** Number of the section containing the match. */
+  section?: string;
+  /** Query term that matched, as written. */
+  term: string;
+  /** The match with surrounding text. */
+  text: string;
+}
+
+export interface AppropriationsAccountsResponse {
+  accounts: AccountLevel[] | null;
+  fiscalYear: number;
+  limit: number;
+  offset: number;
+  /** Totals of the matching accounts, by bill. */
+  subcommittees: SubcommitteeTotal[] | null;
+  /** Matching accounts. */
+  total: number;
+}
+
+export interface AppropriationsDeadlinesResponse {
+  congress: number;
+  /** Soonest expiration first; resolutions without one last. */
+  deadlines: CRDeadline[] | null;
+  /** Day countdowns are from (UTC). */
+  today: string;
+}
+
+export interface AsOfResponse {
+  bill: BillResponse;
+  current: boolean;
+  date: string;
+  diff?: DiffResponse;
+  latestVersionId: number;
+  text: string;
+  version: VersionResponse;
+}
+
+export interface AuditLogList {
+  entries: AuditLogResponse[] | null;
+  limit: number;
+  offset: number;
+  total: number;
+}
+
+export interface AuditLogResponse {
+  /** One of: user, admin_token, anonymous. */
+  actorType: 'user' | 'admin_token' | 'anonymous';
+  after?: Record<string, unknown>;
+  before?: Record<string, unknown>;
+  createdAt: string;
+  id: number;
+  method: string;
+  /** Operation ID, e.g., set-user-role. */
+  operation: string;
+  path: string;
+  route: string;
+  status: number;
+  targetId?: string;
+  targetType: string;
+  userId?: number;
+}
+
+export interface BillAliasResponse {
+  alias: string;
+  createdAt: string;
+  id: number;
+  /** short_title, popular_title, or admin. */
+  source: string;
+}
+
+export interface BillAliasesResponse {
+  aliases: BillAliasResponse[] | null;
+  billId: number;
+}
+
+export interface BillChangeFeed {
+  billId: number;
+  changes: BillChangeResponse[] | null;
+  limit: number;
+  offset: number;
+  total: number;
+}
+
+export interface BillChangeResponse {
+  details?: Record<string, unknown>;
+  id: number;
+  newValue?: string;
+  occurredAt: string;
+  previousValue?: string;
+  type: string;
+  version?: ChangeVersion;
+}
+
+export interface BillResponse {
+  actionCount?: number;
+  amendmentCount?: number;
+  becameLaw: boolean;
+  billNumber: number;
+  billType: string;
+  congress: number;
+  cosponsorCount?: number;
+  currentStatus: string;
+  id: number;
+  introducedDate?: string;
+  jurisdiction: string;
+  lawNumber?: string;
+  number: string;
+  originChamber: string;
+  policyArea?: string;
+  session?: string;
+  sponsor: string;
+  state?: string;
+  subjects?: string[] | null;
+  title: string;
+  titles?: TitleResponse[] | null;
+  updateDate: string;
+  versions?: VersionResponse[] | null;
+}
+
+export interface BillsByStatusResponse {
+  computedAt?: string;
+  congresses: CongressStatusCounts[] | null;
+}
+
+export interface CRDeadline {
+  bill: BillResponse;
+  /** Days from today to expiresOn; 0 on the last day, negative once expired. */
+  daysRemaining?: number;
+  /** Last day funding continues; absent when the text names none or isn't fetched yet. */
+  expiresOn?: string;
+  /** Fiscal year the resolution funds. */
+  fiscalYear?: number;
+  /**
+   * Appropriations bills of the same fiscal year not yet enacted, other CRs included: what could
+   * replace or extend the resolution.
+   */
+  pendingReplacements: BillResponse[] | null;
+  /** One of: active, expired, pending. */
+  status: 'active' | 'expired' | 'pending';
+  /** Version of the resolution the expiration was read from. */
+  versionId?: number;
+}
+
+export interface CalendarResponse {
+  /** Path of the calendar to subscribe to, relative to the API's public origin. */
+  path: string;
+  /** Secret identifying the calendar; shown only once. */
+  token: string;
+}
+
+export interface ChangeVersion {
+  deletions?: number;
+  id: number;
+  insertions?: number;
+  previousVersionId?: number;
+  versionCode: string;
+}
+
+export interface CompareAdhocInputBody {
+  /** Earlier text. */
+  from: AdhocText;
+  /** Later text. */
+  to: AdhocText;
+}
+
+export interface CongressDiagnostics {
+  checkedAt?: string;
+  error?: string;
+  latencyMs: number;
+  status: string;
+}
+
+export interface CongressStatusCounts {
+  congress: number;
+  statuses: StatusCount[] | null;
+  total: number;
+}
+
+export interface CostEstimateResponse {
+  description: string;
+  pubDate: string;
+  title: string;
+  url: string;
+}
+
+export interface CostEstimatesResponse {
+  billId: number;
+  costEstimates: CostEstimateResponse[] | null;
+}
+
+export interface CreateDocumentInputBody {
+  /** What the document is, for your own reference. */
+  description?: string;
+  /** Document title. */
+  title: string;
+}
+
+export interface CreateDraftInputBody {
+  /** Bill text: plain text, HTML, or USLM XML. */
+  text: string;
+  /** Draft title. */
+  title: string;
+  /** Code of the first version, e.g., "V1". Default: DRAFT. */
+  versionCode?: string;
+}
+
+export interface CreateTenantInputBody {
+  /** Organization name. */
+  name: string;
+}
+
+export interface CreateTenantUserInputBody {
+  /** Display name. */
+  name: string;
+  /** User role; readers can't upload drafts. One of: reader, editor, admin. Default: editor. */
+  role?: 'reader' | 'editor' | 'admin';
+}
+
+export interface CreateUserInputBody {
+  /** Display name. */
+  name: string;
+}
+
+export interface DatabaseDiagnostics {
+  error?: string;
+  idle: number;
+  inUse: number;
+  latencyMs: number;
+  maxOpen: number;
+  openConnections: number;
+  status: string;
+  waitCount: number;
+  waitDurationMs: number;
+}
+
+export interface DatabasePoolResponse {
+  idle: number;
+  inUse: number;
+  /** Connections closed because the idle pool was full. */
+  maxIdleClosed: number;
+  /** Connections closed for being idle too long. */
+  maxIdleTimeClosed: number;
+  /** Connections closed for reaching their maximum lifetime. */
+  maxLifetimeClosed: number;
+  /** Maximum open connections; 0 for no limit. */
+  maxOpen: number;
+  /** primary, or replica 1 and so on. */
+  name: string;
+  /** Open connections, in use or idle. */
+  open: number;
+  /** Queries that waited for a free connection since startup. */
+  waitCount: number;
+  /** Total time queries waited for a free connection since startup. */
+  waitDurationMs: number;
+}
+
+export interface DatabaseStatsResponse {
+  pools: DatabasePoolResponse[] | null;
+  /** Statements slower than this are logged and counted as slow; 0 when disabled. */
+  slowQueryThresholdMs: number;
+}
+
+export interface DefinitionChange {
+  anchor: string;
+  fromText?: string;
+  key: string;
+  section: string;
+  status: string;
+  term: string;
+  toText?: string;
+}
+
+export interface DefinitionChangesResponse {
+  billId: number;
+  changes: DefinitionChange[] | null;
+  fromVersion: string;
+  fromVersionId: number;
+  termsAdded: number;
+  termsRemoved: number;
+  termsReworded: number;
+  termsUnchanged: number;
+  toVersion: string;
+  toVersionId: number;
+}
+
+export interface Delta {
+  avgSentenceLength: number;
+  definedTerms: number;
+  gradeLevel: number;
+  pageEstimate: number;
+  wordCount: number;
+}
+
+export interface DeltaJobResponse {
+  billId?: number;
+  computed: number;
+  createdAt: string;
+  errorMessage?: string;
+  errors: string[] | null;
+  failed: number;
+  finishedAt?: string;
+  fromVersionId?: number;
+  id: number;
+  scope: string;
+  /** Pairs over the diff size limit, left as they were. */
+  skipped: number;
+  startedAt?: string;
+  status: string;
+  toVersionId?: number;
+  total: number;
+}
+
+export interface DeterminismReport {
+  deterministic: boolean;
+  engineVersion: string;
+  fingerprints: string[] | null;
+  fromVersionId: number;
+  hasStored: boolean;
+  matchesStored: boolean;
+  mismatches: string[] | null;
+  storedEngineVersion?: string;
+  storedFingerprint?: string;
+  toVersionId: number;
+}
+
+export interface DiagnosticHealthOutputBody {
+  status: string;
+}
+
+export interface DiagnosticsReport {
+  congress: CongressDiagnostics;
+  database: DatabaseDiagnostics;
+  errors?: string[] | null;
+  ingestion?: IngestionDiagnostics;
+  pending?: PendingJobs;
+  rateLimit?: RateLimitDiagnostics;
+  status: string;
+}
+
+export interface DiffAnchor {
+  heading: string;
+  hunk: number;
+  id: string;
+  lineNumber: number;
+  section: string;
+}
+
+export interface DiffHunk {
+  index: number;
+  lineCount: number;
+  lineNumber: number;
+  startA: number;
+  startB: number;
+}
+
+export interface DiffLine {
+  anchor?: string;
+  lineNumber: number;
+  text: string;
+  type: string;
+}
+
+export interface DiffResponse {
+  anchors?: DiffAnchor[] | null;
+  deletions: number;
+  fromVersion: string;
+  hunks?: DiffHunk[] | null;
+  insertions: number;
+  lines: DiffLine[] | null;
+  nextHunk?: number;
+  rows?: SplitRow[] | null;
+  segments: DiffSegment[] | null;
+  statsDelta?: Delta;
+  summary?: string;
+  toVersion: string;
+  totalHunks: number;
+  view?: string;
+}
+
+export interface DiffSegment {
+  text: string;
+  type: string;
+}
+
+export interface DiffSizeByType {
+  avgDeletions: number;
+  avgInsertions: number;
+  avgLinesChanged: number;
+  billType: string;
+  diffs: number;
+}
+
+export interface DiffSizeByTypeResponse {
+  billTypes: DiffSizeByType[] | null;
+  computedAt?: string;
+}
+
+export interface DiffStreamRecord {
+  anchor?: string;
+  deletions?: number;
+  error?: string;
+  event: string;
+  fromVersion?: string;
+  insertions?: number;
+  lineNumber: number;
+  text: string;
+  toVersion?: string;
+  type: string;
+}
+
+export interface DocumentListResponse {
+  documents: DocumentResponse[] | null;
+}
+
+export interface DocumentResponse {
+  createdAt: string;
+  description?: string;
+  id: number;
+  title: string;
+  updatedAt: string;
+  versions?: VersionResponse[] | null;
+}
+
+export interface DocumentVersionUpload {
+  /** Document text: plain text, HTML, or XML. */
+  text: string;
+  /** Version code, e.g., "V2" (default: V followed by the version's number). */
+  versionCode?: string;
+}
+
+export interface DollarAmount {
+  offset: number;
+  raw: string;
+  value: number;
+}
+
+export interface DraftListResponse {
+  drafts: BillResponse[] | null;
+}
+
+export interface Earmark {
+  amount: DollarAmount;
+  key: string;
+  purpose: string;
+  recipient: string;
+  section: string;
+}
+
+export interface EarmarkChange {
+  change: number;
+  fromAmount: number;
+  fromRaw?: string;
+  key: string;
+  purpose: string;
+  recipient: string;
+  section: string;
+  status: string;
+  toAmount: number;
+  toRaw?: string;
+}
+
+export interface EarmarkChangesResponse {
+  billId: number;
+  changes: EarmarkChange[] | null;
+  earmarksAdded: number;
+  earmarksChanged: number;
+  earmarksRemoved: number;
+  earmarksUnchanged: number;
+  fromTotal: number;
+  fromVersion: string;
+  fromVersionId: number;
+  netChange: number;
+  toTotal: number;
+  toVersion: string;
+  toVersionId: number;
+}
+
+export interface ErrorDetail {
+  /** Where the error occurred, e.g. 'body.items[3].tags' or 'path.thing-id'. */
+  location?: string;
+  /** Error message text. */
+  message?: string;
+  /** The value at the given location. */
+  value?: unknown;
+}
+
+export interface ErrorModel {
+  /** Machine-readable error code. */
+  code: string;
+  /** A human-readable explanation specific to this occurrence of the problem. */
+  detail?: string;
+  /** Optional list of individual error details. */
+  errors?: ErrorDetail[] | null;
+  /** A URI reference that identifies the specific occurrence of the problem. */
+  instance?: string;
+  /** HTTP status code. */
+  status?: number;
+  /**
+   * A short, human-readable summary of the problem type. This value should not change between
+   * occurrences of the error.
+   */
+  title?: string;
+  /** A URI reference to human-readable documentation for the error. Default: about:blank. */
+  type?: string;
+}
+
+export interface FacetCount {
+  count: number;
+  value: string;
+}
+
+export interface FiscalYearLevel {
+  /** Whole dollars. */
+  amount: number;
+  /** Difference from the prior fiscal year; absent when it isn't known. */
+  change?: number;
+  enacted: boolean;
+  fiscalYear: number;
+}
+
+export interface GetBillVersionsOutputBody {
+  billId: number;
+  versions: VersionResponse[] | null;
+}
+
+export interface GetDocumentVersionsOutputBody {
+  documentId: number;
+  versions: VersionResponse[] | null;
+}
+
+export interface GraphVersion {
+  chamber?: string;
+  fetchedAt: string;
+  id: number;
+  label: string;
+  parentId?: number;
+  stage: number;
+  versionCode: string;
+}
+
+export interface HealthOutputBody {
+  service: string;
+  status: string;
+}
+
+export interface IngestFailureList {
+  failures: IngestFailureResponse[] | null;
+  limit: number;
+  offset: number;
+  total: number;
+}
+
+export interface IngestFailureResponse {
+  attempts: number;
+  billKey: string;
+  createdAt: string;
+  errorClass: string;
+  id: number;
+  lastError: string;
+  nextAttemptAt: string;
+  /** The Congress.gov bill listing as ingested. */
+  payload: Record<string, unknown>;
+  resolvedAt?: string;
+  runId?: number;
+  /** bill or versions. */
+  stage: string;
+  /** pending, exhausted, resolved, or dismissed. */
+  status: string;
+  updatedAt: string;
+}
+
+export interface IngestRunList {
+  limit: number;
+  offset: number;
+  runs: IngestRunResponse[] | null;
+  total: number;
+}
+
+export interface IngestRunResponse {
+  billsCreated: number;
+  billsFetched: number;
+  billsSkipped: number;
+  billsUpdated: number;
+  cursorBill?: string;
+  durationMs: number;
+  errorCount: number;
+  errorMessage?: string;
+  errors: string[] | null;
+  finishedAt?: string;
+  id: number;
+  mode: string;
+  resumedFrom?: number;
+  startedAt: string;
+  status: string;
+  triggeredBy: string;
+  versionsCreated: number;
+}
+
+export interface IngestionDiagnostics {
+  lastRunAt?: string;
+  lastRunStatus?: string;
+  lastSuccessAt?: string;
+}
+
+export interface KeywordAlertBody {
+  /** Name of the alert. */
+  name: string;
+  /**
+   * Words and "quoted phrases" combined with AND, OR, NOT, and parentheses; adjacent terms are
+   * ANDed, and a trailing * matches a word prefix.
+   */
+  query: string;
+}
+
+export interface KeywordAlertResponse {
+  createdAt: string;
+  id: number;
+  name: string;
+  query: string;
+  updatedAt: string;
+}
+
+export interface KeywordAlertUpdates {
+  alert: KeywordAlertResponse;
+  matches: AlertMatchResponse[] | null;
+}
+
+export interface KeywordAlertsResponse {
+  alerts: KeywordAlertResponse[] | null;
+}
+
+export interface LexSearchResult {
+  bills: BillResponse[] | null;
+  facets?: SearchFacets;
+  limit: number;
+  offset: number;
+  total: number;
+}
+
+export interface LineageLink {
+  bill: BillResponse;
+  /** The linked bill's latest text version. */
+  latestVersionId?: number;
+  sameSponsor: boolean;
+  /** How closely the bills match, from 0.6 to 1. */
+  score: number;
+  /**
+   * Share of sections with the same language; absent when either bill's sections aren't
+   * fingerprinted yet.
+   */
+  textSimilarity?: number;
+  /** Share of title words in common, ignoring years. */
+  titleSimilarity: number;
+}
+
+export interface LineageResponse {
+  billId: number;
+  /** The line of bills through each Congress's best match, oldest first, this bill included. */
+  chain: BillResponse[] | null;
+  /** Bills of the previous Congress this bill continues, best match first. */
+  predecessors: LineageLink[] | null;
+  /** Bills of the next Congress continuing this one, best match first. */
+  successors: LineageLink[] | null;
+}
+
+export interface ListBillsOutputBody {
+  bills: BillResponse[] | null;
+  total: number;
+}
+
+export interface LivenessOutputBody {
+  status: string;
+}
+
+export interface MatrixVersion {
+  id: number;
+  label: string;
+  lines: number;
+  stage: number;
+  versionCode: string;
+}
+
+export interface MeResponse {
+  createdAt: string;
+  email?: string;
+  id: number;
+  name: string;
+  /** One of: reader, editor, admin. */
+  role: 'reader' | 'editor' | 'admin';
+  /** Identity provider and its user ID, for users who sign in. */
+  subject?: string;
+  tenantId?: number;
+}
+
+export interface MemberBillImpact {
+  billId: number;
+  billNumber: number;
+  billType: string;
+  congress: number;
+  enacted: boolean;
+  enactedAppropriation: number;
+  provisionsIntroduced: number;
+  provisionsSurvived: number;
+  role: string;
+  title: string;
+}
+
+export interface MemberCongressStatsItem {
+  averageChurn: number;
+  billsEnacted: number;
+  billsSponsored: number;
+  congress: number;
+  enactmentRate: number;
+}
+
+export interface MemberImpactResponse {
+  bills: MemberBillImpact[] | null;
+  billsCosponsored: number;
+  billsEnacted: number;
+  billsSponsored: number;
+  bioguideId: string;
+  enactedAppropriation: number;
+  fullName: string;
+  party: string;
+  provisionsIntroduced: number;
+  provisionsSurvived: number;
+  state: string;
+  survivalRate: number;
+}
+
+export interface MemberPolicyAreaItem {
+  bills: number;
+  policyArea: string;
+  share: number;
+}
+
+export interface MemberStatsResponse {
+  averageChurn: number;
+  billsEnacted: number;
+  billsSponsored: number;
+  bioguideId: string;
+  computedAt?: string;
+  congresses: MemberCongressStatsItem[] | null;
+  enactmentRate: number;
+  fullName: string;
+  party: string;
+  policyAreas: MemberPolicyAreaItem[] | null;
+  state: string;
+}
+
+export interface NominationListResponse {
+  limit: number;
+  nominations: NominationResponse[] | null;
+  offset: number;
+  total: number;
+}
+
+export interface NominationResponse {
+  actions?: ActionResponse[] | null;
+  citation: string;
+  congress: number;
+  currentStatus: string;
+  description: string;
+  id: number;
+  isMilitary: boolean;
+  number: number;
+  organization: string;
+  partNumber: string;
+  receivedDate?: string;
+  statusDate?: string;
+  updatedAt: string;
+}
+
+export interface PendingJobs {
+  deltaJobs: number;
+  diffQueue: number;
+  diffQueueEnabled: boolean;
+  ingestExhausted: number;
+  ingestRetries: number;
+  runningIngestions: number;
+}
+
+export interface ProbeCheck {
+  error?: string;
+  latencyMs: number;
+  status: string;
+}
+
+export interface ProvenanceResponse {
+  /** SHA-256 of the bytes retrieved, before any processing. */
+  contentHash: string;
+  /** Bytes retrieved. */
+  contentLength: number;
+  contentType?: string;
+  etag?: string;
+  /** URL that answered, when the source redirected. */
+  finalUrl?: string;
+  lastModified?: string;
+  retrievedAt: string;
+  sourceUrl: string;
+  versionId: number;
+}
+
+export interface ProvenanceVerification {
+  provenance: ProvenanceResponse;
+  /** Absent when the source couldn't be reached. */
+  source?: SourceCheck;
+  sourceError?: string;
+  /** SHA-256 of the raw text stored now. */
+  storedHash: string;
+  /** The stored text is the text retrieved. */
+  storedMatches: boolean;
+  /** Both the stored text and the source match the recorded hash. */
+  verified: boolean;
+}
+
+export interface RateLimitDiagnostics {
+  clientLimit: number;
+  limit: number;
+  observedAt?: string;
+  pausedUntil?: string;
+  remaining: number;
+}
+
+export interface ReadinessReport {
+  checks: Record<string, ProbeCheck>;
+  status: string;
+}
+
+export interface RecomputeDeltasRequest {
+  /** Recompute every cached delta. */
+  all?: boolean;
+  /** Recompute every cached delta of this bill and its neighboring version pairs. */
+  billId?: number;
+  /** With toVersionId, recompute one version pair. */
+  fromVersionId?: number;
+  /** With fromVersionId, recompute one version pair. */
+  toVersionId?: number;
+}
+
+export interface ReconcileResponse {
+  baseVersion: string;
+  baseVersionId: number;
+  billId: number;
+  both: number;
+  dropped: number;
+  house: number;
+  houseVersion: string;
+  houseVersionId: number;
+  neither: number;
+  sections: ReconcileSection[] | null;
+  senate: number;
+  senateVersion: string;
+  senateVersionId: number;
+}
+
+export interface ReconcileSection {
+  anchor: string;
+  followed: string;
+  heading: string;
+  houseChanges: number;
+  section: string;
+  senateChanges: number;
+}
+
+export interface ReingestJobResponse {
+  /** The billIds filter, if given. */
+  billIds?: number[] | null;
+  billType?: string;
+  congress?: number;
+  createdAt: string;
+  errors: string[] | null;
+  failed: number;
+  finishedAt?: string;
+  id: number;
+  /** Bills refreshed or failed so far. */
+  processed: number;
+  /** Processed / total, from 0 to 1. */
+  progress: number;
+  startedAt?: string;
+  /** One of: queued, running, succeeded. */
+  status: 'queued' | 'running' | 'succeeded';
+  /** Bills matching the filter when the job was queued. */
+  total: number;
+  updatedSince?: string;
+}
+
+export interface ReingestRequest {
+  /** Only these bills (database IDs). */
+  billIds?: number[] | null;
+  /** Only bills of this type, e.g., hr. */
+  billType?: string;
+  /** Only bills of this congress. */
+  congress?: number;
+  /** Only bills Congress.gov last updated on or after this date (YYYY-MM-DD). */
+  updatedSince?: string;
+}
+
+export interface RelatedBillResponse {
+  billId?: number;
+  billNumber: number;
+  billType: string;
+  congress: number;
+  isCompanion: boolean;
+  latestVersionId?: number;
+  relationships: string[] | null;
+  title: string;
+}
+
+export interface RelatedBillsResponse {
+  billId: number;
+  latestVersionId?: number;
+  related: RelatedBillResponse[] | null;
+}
+
+export interface RuleListResponse {
+  limit: number;
+  offset: number;
+  rules: RuleResponse[] | null;
+  total: number;
+}
+
+export interface RuleResponse {
+  abstract?: string;
+  agencies: string[] | null;
+  counterpartId?: number;
+  docketId?: string;
+  documentNumber: string;
+  id: number;
+  publicationDate: string;
+  rin?: string;
+  ruleType: string;
+  title: string;
+  url: string;
+}
+
+export interface SaveSearchInputBody {
+  /** Search filters, as accepted by /api/v1/lex. */
+  filters: SearchFilters;
+  /** Name of the saved search. */
+  name: string;
+}
+
+export interface SavedSearchResponse {
+  createdAt: string;
+  filters: SearchFilters;
+  id: number;
+  name: string;
+}
+
+export interface SavedSearchUpdates {
+  bills: BillResponse[] | null;
+  search: SavedSearchResponse;
+}
+
+export interface SearchFacets {
+  billType: FacetCount[] | null;
+  congress: FacetCount[] | null;
+  isSpendingBill: FacetCount[] | null;
+  originChamber: FacetCount[] | null;
+  policyArea: FacetCount[] | null;
+}
+
+export interface SearchFilters {
+  /** Filter by congress number. */
+  congress?: number;
+  /**
+   * Filter to federal or state bills, or the caller's tenant's drafts. One of: federal, state,
+   * draft.
+   */
+  jurisdiction?: 'federal' | 'state' | 'draft';
+  /** Filter by CRS policy area. */
+  policyArea?: string;
+  /** Search text in bill title. */
+  query?: string;
+  /** Only spending bills. */
+  spending?: boolean;
+  /** Filter by sponsor name (partial match). */
+  sponsor?: string;
+  /** Filter by two-letter state code of state bills. */
+  state?: string;
+  /** Filter by CRS legislative subject. */
+  subject?: string;
+  /** Filter by bill type (hr, s, hjres, sjres). */
+  type?: string;
+}
+
+export interface SectionAppearance {
+  /** The section's anchor, as in version text and section diffs. */
+  anchor: string;
+  bill: BillResponse;
+  /** Word for word the same, ignoring case, punctuation, and line breaks. */
+  exact: boolean;
+  excerpt: string;
+  /** When the version was fetched; the earliest the language is known to have appeared in it. */
+  fetchedAt: string;
+  heading: string;
+  section: string;
+  /** Estimated share of wording in common with the provision, at most 1. */
+  similarity: number;
+  versionCode: string;
+  versionId: number;
+}
+
+export interface SectionProvenanceResult {
+  appearances: SectionAppearance[] | null;
+  /** The earliest appearance; absent when there is none. */
+  origin?: SectionAppearance;
+  /** Words in the provision traced. */
+  words: number;
+}
+
+export interface SemanticBill {
+  bill: BillResponse;
+  /** Cosine similarity of the bill's closest summary or section to the query, at most 1. */
+  similarity: number;
+}
+
+export interface SemanticMatch {
+  /** Sections: the section's anchor, as in version text and section diffs. */
+  anchor?: string;
+  billId: number;
+  excerpt: string;
+  /** Sections: the heading; summaries: the action summarized. */
+  heading?: string;
+  /** One of: summary, section. */
+  kind: 'summary' | 'section';
+  /** Sections: the section number. */
+  section?: string;
+  similarity: number;
+  /** Sections: the version the section is from. */
+  versionId?: number;
+}
+
+export interface SemanticSearchResult {
+  bills: SemanticBill[] | null;
+  matches: SemanticMatch[] | null;
+  /** Embedding provider and model that ranked the results. */
+  model: string;
+}
+
+export interface SessionResponse {
+  expiresAt: string;
+  /** Send as 'Authorization: Bearer <token>' in place of an API key. */
+  token: string;
+  user: MeResponse;
+}
+
+export interface SetUserRoleInputBody {
+  /** New role. One of: reader, editor, admin. */
+  role: 'reader' | 'editor' | 'admin';
+}
+
+export interface SlowQueryThresholdRequest {
+  /** New threshold in milliseconds; 0 disables slow query logging. */
+  thresholdMs: number;
+}
+
+export interface SourceCheck {
+  checkedAt: string;
+  /** SHA-256 of the bytes served now; absent unless status is 200. */
+  contentHash?: string;
+  etag?: string;
+  lastModified?: string;
+  /** The source serves the recorded bytes. */
+  matches: boolean;
+  /** HTTP status the source answered with. */
+  status: number;
+}
+
+export interface SpendingChange {
+  account: string;
+  change: number;
+  context: string;
+  fromAmount: number;
+  fromRaw?: string;
+  key: string;
+  section: string;
+  status: string;
+  toAmount: number;
+  toRaw?: string;
+}
+
+export interface SpendingChangesResponse {
+  amountsAdded: number;
+  amountsChanged: number;
+  amountsRemoved: number;
+  amountsUnchanged: number;
+  billId: number;
+  changes: SpendingChange[] | null;
+  fromTotal: number;
+  fromVersion: string;
+  fromVersionId: number;
+  netChange: number;
+  toTotal: number;
+  toVersion: string;
+  toVersionId: number;
+}
+
+export interface SplitCell {
+  anchor?: string;
+  lineNumber: number;
+  text: string;
+}
+
+export interface SplitRow {
+  left: SplitCell;
+  right: SplitCell;
+  type: string;
+}
+
+export interface Stats {
+  avgSentenceLength: number;
+  definedTerms: number;
+  gradeLevel: number;
+  pageEstimate: number;
+  wordCount: number;
+}
+
+export interface StatusCount {
+  bills: number;
+  stage: number;
+  /**
+   * One of: introduced, referred, reported, passed_one_chamber, amended_by_second_chamber,
+   * enrolled, enacted.
+   */
+  status: 'introduced' | 'referred' | 'reported' | 'passed_one_chamber' | 'amended_by_second_chamber' | 'enrolled' | 'enacted';
+}
+
+export interface SubcommitteeTotal {
+  accounts: number;
+  name: string;
+  /** Whole dollars. */
+  total: number;
+}
+
+export interface SummariesResponse {
+  billId: number;
+  summaries: SummaryResponse[] | null;
+}
+
+export interface SummaryResponse {
+  actionDate: string;
+  actionDesc: string;
+  id: number;
+  text: string;
+  versionCode: string;
+}
+
+export interface TenantListResponse {
+  tenants: TenantResponse[] | null;
+}
+
+export interface TenantResponse {
+  createdAt: string;
+  id: number;
+  name: string;
+}
+
+export interface TextSearchHit {
+  billId: number;
+  billNumber: number;
+  billType: string;
+  congress: number;
+  /** Actions: the kind of change, as in the bill's events. */
+  eventType?: string;
+  /** Excerpts around the matches, with matched words wrapped in <mark> tags. */
+  highlights?: string[] | null;
+  /** ID of the bill, version, or bill event. */
+  id: number;
+  /** One of: bills, versions, actions. */
+  kind: 'bills' | 'versions' | 'actions';
+  score: number;
+  /** The bill's title. */
+  title: string;
+  /** Versions: the version's code. */
+  versionCode?: string;
+}
+
+export interface TextSearchResult {
+  hits: TextSearchHit[] | null;
+  limit: number;
+  offset: number;
+  total: number;
+}
+
+export interface TitleResponse {
+  chamber?: string;
+  /** official, short, popular, or display. */
+  kind: string;
+  title: string;
+  titleType: string;
+  updateDate?: string;
+  versionCode?: string;
+}
+
+export interface TreatyListResponse {
+  limit: number;
+  offset: number;
+  total: number;
+  treaties: TreatyResponse[] | null;
+}
+
+export interface TreatyResponse {
+  actions?: ActionResponse[] | null;
+  congress: number;
+  congressConsidered?: number;
+  currentStatus: string;
+  documentNumber: string;
+  id: number;
+  inForceDate?: string;
+  number: number;
+  statusDate?: string;
+  suffix?: string;
+  title: string;
+  topic: string;
+  transmittedDate?: string;
+  updatedAt: string;
+}
+
+export interface TrendingBill {
+  bill: BillResponse;
+  events: number;
+  linesChanged: number;
+  score: number;
+  versions: number;
+}
+
+export interface TrendingResponse {
+  bills: TrendingBill[] | null;
+  computedAt?: string;
+  windowStart?: string;
+}
+
+export interface UserResponse {
+  /** Send as the X-API-Key header; it is not shown again. */
+  apiKey: string;
+  id: number;
+  name: string;
+  /**
+   * What the key may do: readers read and keep a watchlist, editors also upload, admins also use
+   * the admin endpoints. One of: reader, editor, admin.
+   */
+  role: 'reader' | 'editor' | 'admin';
+  /** Tenant whose drafts the key can read and upload. */
+  tenantId?: number;
+}
+
+export interface VersionEarmarksResponse {
+  billId: number;
+  earmarks: Earmark[] | null;
+  total: number;
+  versionCode: string;
+  versionId: number;
+}
+
+export interface VersionGraphEdge {
+  fromVersionId: number;
+  toVersionId: number;
+}
+
+export interface VersionGraphResponse {
+  billId: number;
+  edges: VersionGraphEdge[] | null;
+  versions: GraphVersion[] | null;
+}
+
+export interface VersionMatrixResponse {
+  billId: number;
+  pairs: VersionPairStats[] | null;
+  pending: number;
+  versions: MatrixVersion[] | null;
+}
+
+export interface VersionPairStats {
+  deletions: number;
+  fromVersionId: number;
+  insertions: number;
+  percentChanged?: number;
+  status: string;
+  toVersionId: number;
+}
+
+export interface VersionResponse {
+  chamber?: string;
+  contentHash: string;
+  date: string;
+  id: number;
+  label: string;
+  stage: number;
+  stats?: Stats;
+  versionCode: string;
+}
+
+export interface VersionTextResponse {
+  format: string;
+  length: number;
+  offset: number;
+  text: string;
+  totalLength: number;
+  versionCode: string;
+  versionId: number;
+}
+
+export interface VersionsPerDay {
+  bills: number;
+  date: string;
+  versions: number;
+}
+
+export interface VersionsPerDayResponse {
+  computedAt?: string;
+  days: VersionsPerDay[] | null;
+}
+
+export interface WatchedBillUpdates {
+  bill: BillResponse;
+  changes: BillChangeResponse[] | null;
+}
+
+export interface WatchlistResponse {
+  bills: BillResponse[] | null;
+  searches: SavedSearchResponse[] | null;
+}
+
+export interface WatchlistUpdates {
+  /** Keyword alert matches recorded since the last check. */
+  alerts: KeywordAlertUpdates[] | null;
+  bills: WatchedBillUpdates[] | null;
+  /** Pass as 'since' to resume from this check. */
+  checkedAt: string;
+  searches: SavedSearchUpdates[] | null;
+  since: string;
+}
+
+/** Query and header parameters of addDocumentVersion. */
+export interface AddDocumentVersionParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of addDraftVersion. */
+export interface AddDraftVersionParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of checkDiffDeterminism. */
+export interface CheckDiffDeterminismParams {
+  /** Return 304 Not Modified if the resource ETag matches one of these values. */
+  ifNoneMatch?: string;
+  /** Ignore changes in indentation, spacing, and blank lines. */
+  ignoreWhitespace?: boolean;
+  /** Ignore lines re-wrapped within a paragraph; each paragraph is diffed as one line. */
+  ignoreLineWrap?: boolean;
+  /** Ignore page markers, running headers, and page numbers of printed text. */
+  stripPageArtifacts?: boolean;
+  /** Index of the first hunk to return; use nextHunk from the previous page. Default: 0. */
+  hunkOffset?: number;
+  /** Maximum hunks to return (0 = all). Default: 0. */
+  hunkLimit?: number;
+  /**
+   * Unchanged lines to keep around each change; hunks further apart than twice this are split.
+   * Default: 3.
+   */
+  context?: number;
+  /**
+   * unified returns interleaved lines; split returns aligned left/right rows. One of: unified,
+   * split. Default: unified.
+   */
+  view?: 'unified' | 'split';
+}
+
+/** Query and header parameters of compareAdhoc. */
+export interface CompareAdhocParams {
+  /** Ignore changes in indentation, spacing, and blank lines. */
+  ignoreWhitespace?: boolean;
+  /** Ignore lines re-wrapped within a paragraph; each paragraph is diffed as one line. */
+  ignoreLineWrap?: boolean;
+  /** Ignore page markers, running headers, and page numbers of printed text. */
+  stripPageArtifacts?: boolean;
+  /** Index of the first hunk to return; use nextHunk from the previous page. Default: 0. */
+  hunkOffset?: number;
+  /** Maximum hunks to return (0 = all). Default: 0. */
+  hunkLimit?: number;
+  /**
+   * Unchanged lines to keep around each change; hunks further apart than twice this are split.
+   * Default: 3.
+   */
+  context?: number;
+  /**
+   * unified returns interleaved lines; split returns aligned left/right rows. One of: unified,
+   * split. Default: unified.
+   */
+  view?: 'unified' | 'split';
+}
+
+/** Query and header parameters of compareBillSummaries. */
+export interface CompareBillSummariesParams {
+  /** Action date of the source summary, YYYY-MM-DD (default: second most recent summary). */
+  from?: string;
+  /** Action date of the target summary, YYYY-MM-DD (default: most recent summary). */
+  to?: string;
+  /**
+   * unified returns interleaved lines; split returns aligned left/right rows. One of: unified,
+   * split. Default: unified.
+   */
+  view?: 'unified' | 'split';
+}
+
+/** Query and header parameters of computeDiff. */
+export interface ComputeDiffParams {
+  /** Return 304 Not Modified if the resource ETag matches one of these values. */
+  ifNoneMatch?: string;
+  /** Ignore changes in indentation, spacing, and blank lines. */
+  ignoreWhitespace?: boolean;
+  /** Ignore lines re-wrapped within a paragraph; each paragraph is diffed as one line. */
+  ignoreLineWrap?: boolean;
+  /** Ignore page markers, running headers, and page numbers of printed text. */
+  stripPageArtifacts?: boolean;
+  /** Index of the first hunk to return; use nextHunk from the previous page. Default: 0. */
+  hunkOffset?: number;
+  /** Maximum hunks to return (0 = all). Default: 0. */
+  hunkLimit?: number;
+  /**
+   * Unchanged lines to keep around each change; hunks further apart than twice this are split.
+   * Default: 3.
+   */
+  context?: number;
+  /**
+   * unified returns interleaved lines; split returns aligned left/right rows. One of: unified,
+   * split. Default: unified.
+   */
+  view?: 'unified' | 'split';
+}
+
+/** Query and header parameters of computeLineageDiff. */
+export interface ComputeLineageDiffParams {
+  /** Return 304 Not Modified if the resource ETag matches one of these values. */
+  ifNoneMatch?: string;
+  /** Ignore changes in indentation, spacing, and blank lines. */
+  ignoreWhitespace?: boolean;
+  /** Ignore lines re-wrapped within a paragraph; each paragraph is diffed as one line. */
+  ignoreLineWrap?: boolean;
+  /** Ignore page markers, running headers, and page numbers of printed text. */
+  stripPageArtifacts?: boolean;
+  /** Index of the first hunk to return; use nextHunk from the previous page. Default: 0. */
+  hunkOffset?: number;
+  /** Maximum hunks to return (0 = all). Default: 0. */
+  hunkLimit?: number;
+  /**
+   * Unchanged lines to keep around each change; hunks further apart than twice this are split.
+   * Default: 3.
+   */
+  context?: number;
+  /**
+   * Predecessor to diff against, one of the bill's lineage predecessors. Default: the best match.
+   */
+  predecessorId?: number;
+  /** The bill's version to diff. Default: its first version, as reintroduced. */
+  toVersion?: number;
+  /**
+   * unified returns interleaved lines; split returns aligned left/right rows. One of: unified,
+   * split. Default: unified.
+   */
+  view?: 'unified' | 'split';
+}
+
+/** Query and header parameters of computeParentDiff. */
+export interface ComputeParentDiffParams {
+  /** Return 304 Not Modified if the resource ETag matches one of these values. */
+  ifNoneMatch?: string;
+  /** Ignore changes in indentation, spacing, and blank lines. */
+  ignoreWhitespace?: boolean;
+  /** Ignore lines re-wrapped within a paragraph; each paragraph is diffed as one line. */
+  ignoreLineWrap?: boolean;
+  /** Ignore page markers, running headers, and page numbers of printed text. */
+  stripPageArtifacts?: boolean;
+  /** Index of the first hunk to return; use nextHunk from the previous page. Default: 0. */
+  hunkOffset?: number;
+  /** Maximum hunks to return (0 = all). Default: 0. */
+  hunkLimit?: number;
+  /**
+   * Unchanged lines to keep around each change; hunks further apart than twice this are split.
+   * Default: 3.
+   */
+  context?: number;
+  /**
+   * unified returns interleaved lines; split returns aligned left/right rows. One of: unified,
+   * split. Default: unified.
+   */
+  view?: 'unified' | 'split';
+}
+
+/** Query and header parameters of createDocument. */
+export interface CreateDocumentParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of createDraft. */
+export interface CreateDraftParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of createKeywordAlert. */
+export interface CreateKeywordAlertParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of createWatchlistCalendar. */
+export interface CreateWatchlistCalendarParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of deleteKeywordAlert. */
+export interface DeleteKeywordAlertParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of deleteSavedSearch. */
+export interface DeleteSavedSearchParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of deleteWatchlistCalendar. */
+export interface DeleteWatchlistCalendarParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of diffDocumentVersions. */
+export interface DiffDocumentVersionsParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+  /** Ignore changes in indentation, spacing, and blank lines. */
+  ignoreWhitespace?: boolean;
+  /** Ignore lines re-wrapped within a paragraph; each paragraph is diffed as one line. */
+  ignoreLineWrap?: boolean;
+  /** Ignore page markers, running headers, and page numbers of printed text. */
+  stripPageArtifacts?: boolean;
+  /** Index of the first hunk to return; use nextHunk from the previous page. Default: 0. */
+  hunkOffset?: number;
+  /** Maximum hunks to return (0 = all). Default: 0. */
+  hunkLimit?: number;
+  /**
+   * Unchanged lines to keep around each change; hunks further apart than twice this are split.
+   * Default: 3.
+   */
+  context?: number;
+  /**
+   * unified returns interleaved lines; split returns aligned left/right rows. One of: unified,
+   * split. Default: unified.
+   */
+  view?: 'unified' | 'split';
+}
+
+/** Query and header parameters of exportBills. */
+export interface ExportBillsParams {
+  /**
+   * jsonl writes one JSON object per line; csv writes a header row, then one row per record. One
+   * of: jsonl, csv. Default: jsonl.
+   */
+  format?: 'jsonl' | 'csv';
+  /** Filter by congress number. */
+  congress?: number;
+  /** Earliest date, YYYY-MM-DD: the bill's update date, or the version's date. */
+  from?: string;
+  /** Latest date, YYYY-MM-DD, inclusive. */
+  to?: string;
+  /** Only spending bills. */
+  spending?: boolean;
+}
+
+/** Query and header parameters of exportVersions. */
+export interface ExportVersionsParams {
+  /**
+   * jsonl writes one JSON object per line; csv writes a header row, then one row per record. One
+   * of: jsonl, csv. Default: jsonl.
+   */
+  format?: 'jsonl' | 'csv';
+  /** Filter by congress number. */
+  congress?: number;
+  /** Earliest date, YYYY-MM-DD: the bill's update date, or the version's date. */
+  from?: string;
+  /** Latest date, YYYY-MM-DD, inclusive. */
+  to?: string;
+  /** Only spending bills. */
+  spending?: boolean;
+  /** Include each version's plain text (large). */
+  includeText?: boolean;
+}
+
+/** Query and header parameters of getAppropriationsAccountHistory. */
+export interface GetAppropriationsAccountHistoryParams {
+  /** Required. Account key, from list-appropriations-accounts. */
+  key?: string;
+}
+
+/** Query and header parameters of getAppropriationsDeadlines. */
+export interface GetAppropriationsDeadlinesParams {
+  /** Congress number. Default: the latest with federal bills. */
+  congress?: number;
+  /**
+   * Only resolutions with this status: active (enacted, funding continues), expired, or pending
+   * (not enacted). One of: active, expired, pending.
+   */
+  status?: 'active' | 'expired' | 'pending';
+}
+
+/** Query and header parameters of getBill. */
+export interface GetBillParams {
+  /** Return 304 Not Modified if the resource ETag matches one of these values. */
+  ifNoneMatch?: string;
+}
+
+/** Query and header parameters of getBillAsOf. */
+export interface GetBillAsOfParams {
+  /** Required. Date, YYYY-MM-DD; the bill as it stood at the end of the day (UTC). */
+  date?: string;
+  /** Include the diff from the version current on the date to the most recent version. */
+  diff?: boolean;
+}
+
+/** Query and header parameters of getBillChanges. */
+export interface GetBillChangesParams {
+  /** Number of changes per page (max 200). Default: 50. */
+  limit?: number;
+  /** Pagination offset. Default: 0. */
+  offset?: number;
+}
+
+/** Query and header parameters of getBillDefinitionChanges. */
+export interface GetBillDefinitionChangesParams {
+  /** Source version ID (default: second most recent version). */
+  from?: number;
+  /** Target version ID (default: most recent version). */
+  to?: number;
+  /** Include definitions that did not change. */
+  includeUnchanged?: boolean;
+}
+
+/** Query and header parameters of getBillEarmarkChanges. */
+export interface GetBillEarmarkChangesParams {
+  /** Source version ID (default: second most recent version). */
+  from?: number;
+  /** Target version ID (default: most recent version). */
+  to?: number;
+  /** Include earmarks that did not change. */
+  includeUnchanged?: boolean;
+}
+
+/** Query and header parameters of getBillSpendingChanges. */
+export interface GetBillSpendingChangesParams {
+  /** Source version ID (default: second most recent version). */
+  from?: number;
+  /** Target version ID (default: most recent version). */
+  to?: number;
+  /** Include amounts that did not change. */
+  includeUnchanged?: boolean;
+}
+
+/** Query and header parameters of getBillVersionMatrix. */
+export interface GetBillVersionMatrixParams {
+  /** Return 304 Not Modified if the resource ETag matches one of these values. */
+  ifNoneMatch?: string;
+}
+
+/** Query and header parameters of getBillVersions. */
+export interface GetBillVersionsParams {
+  /** Return 304 Not Modified if the resource ETag matches one of these values. */
+  ifNoneMatch?: string;
+}
+
+/** Query and header parameters of getBillsByStatus. */
+export interface GetBillsByStatusParams {
+  /** Only this congress (default: every congress). */
+  congress?: number;
+}
+
+/** Query and header parameters of getDocument. */
+export interface GetDocumentParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of getDocumentVersions. */
+export interface GetDocumentVersionsParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of getEvents. */
+export interface GetEventsParams {
+  /** Only events for this congress (0 = all). */
+  congress?: number;
+  /** Only events for this bill type, e.g., hr (case-insensitive). */
+  billType?: string;
+  /** Only events for this bill ID (0 = all). */
+  billId?: number;
+}
+
+/** Query and header parameters of getHr1. */
+export interface GetHr1Params {
+  /** Return 304 Not Modified if the resource ETag matches one of these values. */
+  ifNoneMatch?: string;
+}
+
+/** Query and header parameters of getMe. */
+export interface GetMeParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of getTrendingBills. */
+export interface GetTrendingBillsParams {
+  /** Return 304 Not Modified if the resource ETag matches one of these values. */
+  ifNoneMatch?: string;
+  /** Number of bills (max 100). Default: 20. */
+  limit?: number;
+}
+
+/** Query and header parameters of getVersionText. */
+export interface GetVersionTextParams {
+  /** Return 304 Not Modified if the resource ETag matches one of these values. */
+  ifNoneMatch?: string;
+  /**
+   * plain is the extracted text diffs are computed over; xml is the original Formatted XML; html is
+   * the original HTML, or the plain text in a <pre> block. One of: plain, html, xml. Default:
+   * plain.
+   */
+  format?: 'plain' | 'html' | 'xml';
+  /** First section number to return (plain and html only). */
+  fromSection?: string;
+  /** Last section number to return, inclusive (default: fromSection). */
+  toSection?: string;
+  /** Byte offset into the selected text. Default: 0. */
+  offset?: number;
+  /** Maximum bytes to return (0 = to the end). Default: 0. */
+  length?: number;
+}
+
+/** Query and header parameters of getVersionsPerDay. */
+export interface GetVersionsPerDayParams {
+  /** Number of days, ending today (UTC). Default: 30. */
+  days?: number;
+}
+
+/** Query and header parameters of getWatchlist. */
+export interface GetWatchlistParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of getWatchlistUpdates. */
+export interface GetWatchlistUpdatesParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+  /** Report changes after this time (RFC 3339); defaults to the last check. */
+  since?: string;
+}
+
+/** Query and header parameters of listAppropriationsAccounts. */
+export interface ListAppropriationsAccountsParams {
+  /** Fiscal year. Default: the latest with parsed accounts. */
+  fiscalYear?: number;
+  /**
+   * Only accounts of this bill. One of: Agriculture-FDA, Commerce-Justice-Science, Defense,
+   * Energy-Water, Financial Services-General Government, Homeland Security, Interior-Environment,
+   * Labor-HHS-Education, Legislative Branch, Military Construction-VA, State-Foreign Operations,
+   * Transportation-HUD.
+   */
+  subcommittee?: 'Agriculture-FDA' | 'Commerce-Justice-Science' | 'Defense' | 'Energy-Water' | 'Financial Services-General Government' | 'Homeland Security' | 'Interior-Environment' | 'Labor-HHS-Education' | 'Legislative Branch' | 'Military Construction-VA' | 'State-Foreign Operations' | 'Transportation-HUD';
+  /** Search in agency, bureau, and account names (case-insensitive partial match). */
+  query?: string;
+  /** Number of results per page (max 200). Default: 50. */
+  limit?: number;
+  /** Pagination offset. Default: 0. */
+  offset?: number;
+}
+
+/** Query and header parameters of listAuditLog. */
+export interface ListAuditLogParams {
+  /** Only entries of this user. */
+  userId?: number;
+  /** Only entries of this kind of caller. One of: user, admin_token, anonymous. */
+  actorType?: 'user' | 'admin_token' | 'anonymous';
+  /** Only entries of this operation ID, e.g., start-reingest. */
+  operation?: string;
+  /** Only entries targeting this collection, e.g., users. */
+  targetType?: string;
+  /** Only entries targeting this ID. */
+  targetId?: string;
+  /** Only entries at or after this time (RFC 3339). */
+  since?: string;
+  /** Only entries before this time (RFC 3339). */
+  until?: string;
+  /** Number of results per page (max 200). Default: 50. */
+  limit?: number;
+  /** Pagination offset. Default: 0. */
+  offset?: number;
+}
+
+/** Query and header parameters of listBills. */
+export interface ListBillsParams {
+  /** Only return bills that became law. */
+  becameLaw?: boolean;
+}
+
+/** Query and header parameters of listDocuments. */
+export interface ListDocumentsParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of listDrafts. */
+export interface ListDraftsParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of listIngestionFailures. */
+export interface ListIngestionFailuresParams {
+  /**
+   * Only failures with this status (default: pending and exhausted). One of: pending, exhausted,
+   * resolved, dismissed.
+   */
+  status?: 'pending' | 'exhausted' | 'resolved' | 'dismissed';
+  /** Number of results per page (max 100). Default: 20. */
+  limit?: number;
+  /** Pagination offset. Default: 0. */
+  offset?: number;
+}
+
+/** Query and header parameters of listIngestionRuns. */
+export interface ListIngestionRunsParams {
+  /** Number of results per page (max 100). Default: 20. */
+  limit?: number;
+  /** Pagination offset. Default: 0. */
+  offset?: number;
+}
+
+/** Query and header parameters of listKeywordAlertMatches. */
+export interface ListKeywordAlertMatchesParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+  /** Number of matches per page (max 100). Default: 20. */
+  limit?: number;
+  /** Pagination offset. Default: 0. */
+  offset?: number;
+}
+
+/** Query and header parameters of listKeywordAlerts. */
+export interface ListKeywordAlertsParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of listNominations. */
+export interface ListNominationsParams {
+  /** Filter by congress. */
+  congress?: number;
+  /** Filter by nominating organization (case-insensitive partial match). */
+  organization?: string;
+  /** Filter to civilian or military nominations. One of: civilian, military. */
+  type?: 'civilian' | 'military';
+  /** Search in nomination description (case-insensitive partial match). */
+  query?: string;
+  /** Number of results per page (max 100). Default: 20. */
+  limit?: number;
+  /** Pagination offset. Default: 0. */
+  offset?: number;
+}
+
+/** Query and header parameters of listRules. */
+export interface ListRulesParams {
+  /** Filter by Federal Register agency slug. */
+  agency?: string;
+  /** Filter to proposed or final rules. One of: proposed, final. */
+  type?: 'proposed' | 'final';
+  /** Filter by Regulation Identifier Number. */
+  rin?: string;
+  /** Search in rule title (case-insensitive partial match). */
+  query?: string;
+  /** Number of results per page (max 100). Default: 20. */
+  limit?: number;
+  /** Pagination offset. Default: 0. */
+  offset?: number;
+}
+
+/** Query and header parameters of listTreaties. */
+export interface ListTreatiesParams {
+  /** Filter by the Congress that received the treaty. */
+  congress?: number;
+  /** Search in treaty title and topic (case-insensitive partial match). */
+  query?: string;
+  /** Number of results per page (max 100). Default: 20. */
+  limit?: number;
+  /** Pagination offset. Default: 0. */
+  offset?: number;
+}
+
+/** Query and header parameters of loginCallback. */
+export interface LoginCallbackParams {
+  /** Required. Authorization code from the identity provider. */
+  code?: string;
+  /** Required. State passed to the identity provider. */
+  state?: string;
+}
+
+/** Query and header parameters of reconcileBillVersions. */
+export interface ReconcileBillVersionsParams {
+  /**
+   * Version ID of the reconciled text, e.g., the conference or enrolled version (default: most
+   * recent version).
+   */
+  base?: number;
+  /** Version ID of the House text (default: most recent House version). */
+  house?: number;
+  /** Version ID of the Senate text (default: most recent Senate version). */
+  senate?: number;
+}
+
+/** Query and header parameters of saveSearch. */
+export interface SaveSearchParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of searchBills. */
+export interface SearchBillsParams {
+  /** Filter by congress number (e.g., 118, 119). 0 = no filter. */
+  congress?: number;
+  /** Filter by sponsor name (case-insensitive partial match). */
+  sponsor?: string;
+  /**
+   * Search in bill title and aliases, such as short titles and nicknames like NDAA
+   * (case-insensitive partial match).
+   */
+  query?: string;
+  /** Filter by bill type (hr, s, hjres, sjres, hconres, sconres, hres, sres). */
+  type?: string;
+  /**
+   * Filter by bill number as published, such as 1 or a state's 12A (case-insensitive); with
+   * congress or state and type, finds a single bill.
+   */
+  number?: string;
+  /**
+   * Filter to federal bills, state legislature bills, or the caller's tenant's drafts. One of:
+   * federal, state, draft.
+   */
+  jurisdiction?: 'federal' | 'state' | 'draft';
+  /** Filter by two-letter state code of state bills. */
+  state?: string;
+  /** Filter to only spending/appropriations bills (classified by CRS subjects). */
+  spending?: boolean;
+  /** Filter by CRS policy area (case-insensitive exact match). */
+  policyArea?: string;
+  /** Filter by CRS legislative subject term (case-insensitive exact match). */
+  subject?: string;
+  /** Sort field. One of: updateDate, introducedDate, title. Default: updateDate. */
+  sort?: 'updateDate' | 'introducedDate' | 'title';
+  /** Sort order (default: desc for dates, asc for title). One of: asc, desc. */
+  order?: 'asc' | 'desc';
+  /** Number of results per page (max 100). Default: 20. */
+  limit?: number;
+  /** Pagination offset. Default: 0. */
+  offset?: number;
+}
+
+/** Query and header parameters of searchBillsV1. */
+export interface SearchBillsV1Params {
+  /** Filter by congress number. 0 = no filter. */
+  congress?: number;
+  /** Filter by sponsor name (case-insensitive partial match). */
+  sponsor?: string;
+  /**
+   * Search in bill title and aliases, such as short titles and nicknames like NDAA
+   * (case-insensitive partial match).
+   */
+  q?: string;
+  /** Filter by bill type (hr, s, hjres, sjres, hconres, sconres, hres, sres). */
+  billType?: string;
+  /** Only return spending/appropriations bills. */
+  spendingOnly?: boolean;
+  /**
+   * Also return counts of matching bills per congress, bill type, origin chamber, spending
+   * classification, and policy area.
+   */
+  facets?: boolean;
+  /** Sort field. One of: updateDate, introducedDate, title. Default: updateDate. */
+  sort?: 'updateDate' | 'introducedDate' | 'title';
+  /** Sort order (default: desc for dates, asc for title). One of: asc, desc. */
+  order?: 'asc' | 'desc';
+  /** Number of results per page (max 100). Default: 20. */
+  limit?: number;
+  /** Pagination offset. Default: 0. */
+  offset?: number;
+}
+
+/** Query and header parameters of searchSemantic. */
+export interface SearchSemanticParams {
+  /** Text describing what to find, in plain language. */
+  q?: string;
+  /**
+   * Find bills like this one instead: bills whose summaries and sections are closest to its
+   * summary.
+   */
+  billId?: number;
+  /**
+   * Match only CRS summaries or only sections of bill text. Default: both. One of: summary,
+   * section.
+   */
+  kind?: 'summary' | 'section';
+  /** Number of bills, and of matches (max 50). Default: 10. */
+  limit?: number;
+}
+
+/** Query and header parameters of searchText. */
+export interface SearchTextParams {
+  /**
+   * Required. Words to search for, all of which must match. Supports "quoted phrases", "phrase"~N
+   * for words up to N positions apart, -word to exclude, a | b for either, and word* for prefixes.
+   */
+  q?: string;
+  /** Match q word for word as a single phrase, without the query syntax. */
+  phrase?: boolean;
+  /** With phrase, how many positions apart or out of order the phrase's words may be. */
+  slop?: number;
+  /**
+   * Search only bills (titles, aliases, sponsors, statuses), version text, or actions. Default:
+   * all. One of: bills, versions, actions.
+   */
+  kind?: 'bills' | 'versions' | 'actions';
+  /** Filter by congress number. 0 = no filter. */
+  congress?: number;
+  /** Filter by bill type. */
+  type?: string;
+  /** Search within one bill. 0 = all bills. */
+  billId?: number;
+  /** Number of results per page (max 100). Default: 20. */
+  limit?: number;
+  /** Pagination offset. Default: 0. */
+  offset?: number;
+}
+
+/** Query and header parameters of traceSectionProvenance. */
+export interface TraceSectionProvenanceParams {
+  /** Provision text to trace. */
+  text?: string;
+  /** Or trace a section of this version, given by anchor, to versions fetched before it. */
+  versionId?: number;
+  /** Section anchor in versionId, as in version text and diffs. */
+  anchor?: string;
+  /** Least estimated share of wording in common for a section to count. Default: 0.8. */
+  minSimilarity?: number;
+  /** Most appearances returned (max 100). Default: 20. */
+  limit?: number;
+}
+
+/** Query and header parameters of unwatchBill. */
+export interface UnwatchBillParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of updateKeywordAlert. */
+export interface UpdateKeywordAlertParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Query and header parameters of watchBill. */
+export interface WatchBillParams {
+  /** API key returned when the user was created; not needed with a session token. */
+  apiKey?: string;
+}
+
+/** Options of a DeltaGovClient. */
+export interface ClientOptions {
+  /** Origin of the API, e.g., 'https://api.deltagov.org'. */
+  baseUrl: string;
+  /** Sends requests; defaults to the global fetch. */
+  fetch?: typeof fetch;
+  /** Headers sent with every request. */
+  headers?: Record<string, string>;
+  /** Authenticates watchlist requests. */
+  apiKey?: string;
+  /** Authenticates admin requests with the server's ADMIN_TOKEN. */
+  adminToken?: string;
+  /** Authenticates a signed-in user with a session token from the login callback. */
+  sessionToken?: string;
+}
+
+/** Options of a single request. */
+export interface RequestOptions {
+  /** Aborts the request, and a stream being read. */
+  signal?: AbortSignal;
+}
+
+/** An error response from the API. */
+export class ApiError extends Error {
+  constructor(
+    readonly status: number,
+    readonly body: ErrorModel | null,
+  ) {
+    const code = body?.code ? ' ' + body.code : '';
+    super(`deltagov: ${status}${code}: ${body?.detail || body?.title || 'request failed'}`);
+    this.name = 'ApiError';
+  }
+
+  /** The API's error code, e.g., 'BILL_NOT_FOUND'. */
+  get code(): string | undefined {
+    return this.body?.code;
+  }
+}
+
+type QueryValue = string | number | boolean | null | undefined;
+
+interface ApiRequest extends RequestOptions {
+  query?: Record<string, QueryValue>;
+  headers?: Record<string, QueryValue>;
+  body?: unknown;
+  accept?: string;
+}
+
+/** A typed client of the DeltaGov API. */
+export class DeltaGovClient {
+  private readonly baseUrl: string;
+  private readonly fetch: typeof fetch;
+  private readonly headers: Record<string, string>;
+
+  constructor(options: ClientOptions) {
+    this.baseUrl = options.baseUrl.replace(/\/+$/, '');
+    this.fetch = options.fetch ?? globalThis.fetch.bind(globalThis);
+    this.headers = { ...options.headers };
+    if (options.apiKey) {
+      this.headers['X-API-Key'] = options.apiKey;
+    }
+    if (options.adminToken) {
+      this.headers['Authorization'] = `Bearer ${options.adminToken}`;
+    }
+    if (options.sessionToken) {
+      this.headers['Authorization'] = `Bearer ${options.sessionToken}`;
+    }
+  }
+
+  private async send(method: string, path: string, request: ApiRequest): Promise<Response> {
+    const url = new URL(this.baseUrl + path);
+    for (const [key, value] of Object.entries(request.query ?? {})) {
+      if (value !== undefined && value !== null && value !== '') {
+        url.searchParams.set(key, String(value));
+      }
+    }
+    const headers: Record<string, string> = {
+      ...this.headers,
+      Accept: request.accept ?? 'application/json',
+    };
+    for (const [key, value] of Object.entries(request.headers ?? {})) {
+      if (value !== undefined && value !== null && value !== '') {
+        headers[key] = String(value);
+      }
+    }
+    let body: string | undefined;
+    if (request.body !== undefined) {
+      headers['Content-Type'] = 'application/json';
+      body = JSON.stringify(request.body);
+    }
+
+    const response = await this.fetch(url, { method, headers, body, signal: request.signal });
+    if (!response.ok) {
+      const error = (await response.json().catch(() => null)) as ErrorModel | null;
+      throw new ApiError(response.status, error);
+    }
+    return response;
+  }
+
+  private async request<T>(method: string, path: string, request: ApiRequest): Promise<T> {
+    const response = await this.send(method, path, request);
+    return (await response.json()) as T;
+  }
+
+  /**
+   * POST /api/v1/admin/bills/{id}/aliases: Add an alias to a bill.
+   *
+   * Adds a name the bill is known by, such as "CR" or "NDAA", for bill search to match. Curated
+   * aliases are kept when the bill's titles are synced.
+   */
+  async addBillAlias(
+    id: number,
+    body: AddBillAliasInputBody,
+    options: RequestOptions = {},
+  ): Promise<BillAliasResponse> {
+    return this.request('POST', `/api/v1/admin/bills/${path(id)}/aliases`, { body, ...options });
+  }
+
+  /**
+   * POST /api/v1/documents/{id}/versions: Upload a document version.
+   *
+   * Adds a version to one of the caller's documents, sent as JSON or as a multipart form with a
+   * file field and an optional versionCode field. Uploading text identical to an earlier version is
+   * rejected. Requires the editor role.
+   */
+  async addDocumentVersion(
+    id: number,
+    body: DocumentVersionUpload,
+    params: AddDocumentVersionParams = {},
+    options: RequestOptions = {},
+  ): Promise<VersionResponse> {
+    return this.request(
+      'POST',
+      `/api/v1/documents/${path(id)}/versions`,
+      { headers: { 'X-API-Key': params.apiKey }, body, ...options },
+    );
+  }
+
+  /**
+   * POST /api/v1/drafts/{id}/versions: Upload a draft version.
+   *
+   * Adds a version to one of the caller's tenant's drafts, to diff against its earlier versions.
+   * Requires the editor role.
+   */
+  async addDraftVersion(
+    id: number,
+    body: AddDraftVersionInputBody,
+    params: AddDraftVersionParams = {},
+    options: RequestOptions = {},
+  ): Promise<VersionResponse> {
+    return this.request(
+      'POST',
+      `/api/v1/drafts/${path(id)}/versions`,
+      { headers: { 'X-API-Key': params.apiKey }, body, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/bills/{billId}/diff/{fromVersion}/{toVersion}/determinism: Check diff determinism.
+   *
+   * Recomputes the diff between two versions multiple times and reports whether the results agree
+   * with each other and with the stored delta.
+   */
+  async checkDiffDeterminism(
+    billId: number,
+    fromVersion: number,
+    toVersion: number,
+    params: CheckDiffDeterminismParams = {},
+    options: RequestOptions = {},
+  ): Promise<DeterminismReport> {
+    return this.request(
+      'GET',
+      `/api/v1/bills/${path(billId)}/diff/${path(fromVersion)}/${path(toVersion)}/determinism`,
+      {
+        query: {
+          ignoreWhitespace: params.ignoreWhitespace,
+          ignoreLineWrap: params.ignoreLineWrap,
+          stripPageArtifacts: params.stripPageArtifacts,
+          hunkOffset: params.hunkOffset,
+          hunkLimit: params.hunkLimit,
+          context: params.context,
+          view: params.view,
+        },
+        headers: { 'If-None-Match': params.ifNoneMatch },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * POST /api/v1/compare/adhoc: Compare two uploaded texts.
+   *
+   * Diffs two texts sent in the request, such as a discussion draft and the introduced bill, with
+   * the same text extraction and diff engine as stored versions. Nothing is stored. Each text may
+   * be up to 100KB.
+   */
+  async compareAdhoc(
+    body: CompareAdhocInputBody,
+    params: CompareAdhocParams = {},
+    options: RequestOptions = {},
+  ): Promise<DiffResponse> {
+    return this.request(
+      'POST',
+      '/api/v1/compare/adhoc',
+      {
+        query: {
+          ignoreWhitespace: params.ignoreWhitespace,
+          ignoreLineWrap: params.ignoreLineWrap,
+          stripPageArtifacts: params.stripPageArtifacts,
+          hunkOffset: params.hunkOffset,
+          hunkLimit: params.hunkLimit,
+          context: params.context,
+          view: params.view,
+        },
+        body,
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * GET /api/v1/bills/{id}/summaries/diff: Compare CRS summaries between action dates.
+   *
+   * Diffs the CRS summaries written for the actions on two dates. Defaults to the two most recent
+   * summaries. fromVersion and toVersion in the response are the summaries' version codes.
+   */
+  async compareBillSummaries(
+    id: number,
+    params: CompareBillSummariesParams = {},
+    options: RequestOptions = {},
+  ): Promise<DiffResponse> {
+    return this.request(
+      'GET',
+      `/api/v1/bills/${path(id)}/summaries/diff`,
+      { query: { from: params.from, to: params.to, view: params.view }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/bills/{billId}/diff/{fromVersion}/{toVersion}: Compute diff between two bill
+   * versions.
+   *
+   * Returns a structured diff showing insertions, deletions, and unchanged text between two
+   * versions. With view=split, returns aligned left/right rows for side-by-side rendering. Large
+   * diffs can be loaded a page of hunks at a time with hunkOffset and hunkLimit, and context
+   * narrows the unchanged lines around each change.
+   */
+  async computeDiff(
+    billId: number,
+    fromVersion: number,
+    toVersion: number,
+    params: ComputeDiffParams = {},
+    options: RequestOptions = {},
+  ): Promise<DiffResponse> {
+    return this.request(
+      'GET',
+      `/api/v1/bills/${path(billId)}/diff/${path(fromVersion)}/${path(toVersion)}`,
+      {
+        query: {
+          ignoreWhitespace: params.ignoreWhitespace,
+          ignoreLineWrap: params.ignoreLineWrap,
+          stripPageArtifacts: params.stripPageArtifacts,
+          hunkOffset: params.hunkOffset,
+          hunkLimit: params.hunkLimit,
+          context: params.context,
+          view: params.view,
+        },
+        headers: { 'If-None-Match': params.ifNoneMatch },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * GET /api/v1/bills/{billId}/lineage/diff: Diff a bill against its predecessor.
+   *
+   * Returns the diff from the latest text of a bill's predecessor in the previous Congress (see
+   * get-bill-lineage), where that Congress left it, to the bill's text: what changed on
+   * reintroduction. Takes the same options as compute-diff.
+   */
+  async computeLineageDiff(
+    billId: number,
+    params: ComputeLineageDiffParams = {},
+    options: RequestOptions = {},
+  ): Promise<DiffResponse> {
+    return this.request(
+      'GET',
+      `/api/v1/bills/${path(billId)}/lineage/diff`,
+      {
+        query: {
+          ignoreWhitespace: params.ignoreWhitespace,
+          ignoreLineWrap: params.ignoreLineWrap,
+          stripPageArtifacts: params.stripPageArtifacts,
+          hunkOffset: params.hunkOffset,
+          hunkLimit: params.hunkLimit,
+          context: params.context,
+          predecessorId: params.predecessorId,
+          toVersion: params.toVersion,
+          view: params.view,
+        },
+        headers: { 'If-None-Match': params.ifNoneMatch },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * GET /api/v1/bills/{billId}/diff/{toVersion}: Diff a version against its parent.
+   *
+   * Returns the diff from the version toVersion derives from in the bill's version graph (see
+   * get-version-graph) to toVersion, the comparison that shows what that stage changed. Takes the
+   * same options as compute-diff.
+   */
+  async computeParentDiff(
+    billId: number,
+    toVersion: number,
+    params: ComputeParentDiffParams = {},
+    options: RequestOptions = {},
+  ): Promise<DiffResponse> {
+    return this.request(
+      'GET',
+      `/api/v1/bills/${path(billId)}/diff/${path(toVersion)}`,
+      {
+        query: {
+          ignoreWhitespace: params.ignoreWhitespace,
+          ignoreLineWrap: params.ignoreLineWrap,
+          stripPageArtifacts: params.stripPageArtifacts,
+          hunkOffset: params.hunkOffset,
+          hunkLimit: params.hunkLimit,
+          context: params.context,
+          view: params.view,
+        },
+        headers: { 'If-None-Match': params.ifNoneMatch },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * POST /api/v1/documents: Create a tracked document.
+   *
+   * Creates a document to track outside the legislative record, such as agency guidance or a
+   * contract. Upload its versions with the document versions endpoint, then diff them like bill
+   * versions. Requires the editor role.
+   */
+  async createDocument(
+    body: CreateDocumentInputBody,
+    params: CreateDocumentParams = {},
+    options: RequestOptions = {},
+  ): Promise<DocumentResponse> {
+    return this.request(
+      'POST',
+      '/api/v1/documents',
+      { headers: { 'X-API-Key': params.apiKey }, body, ...options },
+    );
+  }
+
+  /**
+   * POST /api/v1/drafts: Upload a draft.
+   *
+   * Uploads a private working draft with its first version. Only API keys of the caller's tenant
+   * can read it; with one, the bill, version, and diff endpoints serve drafts like public bills,
+   * and search includes them. Requires the editor role.
+   */
+  async createDraft(
+    body: CreateDraftInputBody,
+    params: CreateDraftParams = {},
+    options: RequestOptions = {},
+  ): Promise<BillResponse> {
+    return this.request(
+      'POST',
+      '/api/v1/drafts',
+      { headers: { 'X-API-Key': params.apiKey }, body, ...options },
+    );
+  }
+
+  /**
+   * POST /api/v1/watchlist/alerts: Create a keyword alert.
+   *
+   * Registers a boolean keyword query evaluated against the text of every newly ingested version;
+   * matches, with snippets and section anchors, are reported by the alert's matches and the
+   * watchlist updates endpoints.
+   */
+  async createKeywordAlert(
+    body: KeywordAlertBody,
+    params: CreateKeywordAlertParams = {},
+    options: RequestOptions = {},
+  ): Promise<KeywordAlertResponse> {
+    return this.request(
+      'POST',
+      '/api/v1/watchlist/alerts',
+      { headers: { 'X-API-Key': params.apiKey }, body, ...options },
+    );
+  }
+
+  /**
+   * POST /api/v1/admin/tenants: Create a tenant.
+   *
+   * Creates an organization that can upload private drafts. Create its users' API keys with the
+   * tenant users endpoint.
+   */
+  async createTenant(
+    body: CreateTenantInputBody,
+    options: RequestOptions = {},
+  ): Promise<TenantResponse> {
+    return this.request('POST', '/api/v1/admin/tenants', { body, ...options });
+  }
+
+  /**
+   * POST /api/v1/admin/tenants/{id}/users: Create a tenant user.
+   *
+   * Creates a user in a tenant and returns its API key, which is shown only once. The key reads the
+   * tenant's drafts, uploads them unless its role is reader, and works with the watchlist
+   * endpoints.
+   */
+  async createTenantUser(
+    id: number,
+    body: CreateTenantUserInputBody,
+    options: RequestOptions = {},
+  ): Promise<UserResponse> {
+    return this.request('POST', `/api/v1/admin/tenants/${path(id)}/users`, { body, ...options });
+  }
+
+  /**
+   * POST /api/v1/users: Create a user.
+   *
+   * Creates a user with the reader role and returns its API key, which is shown only once. An admin
+   * can grant it another role.
+   */
+  async createUser(body: CreateUserInputBody, options: RequestOptions = {}): Promise<UserResponse> {
+    return this.request('POST', '/api/v1/users', { body, ...options });
+  }
+
+  /**
+   * POST /api/v1/watchlist/calendar: Create a watchlist calendar URL.
+   *
+   * Returns the URL of an iCalendar feed of the milestones of the caller's watched bills, for
+   * calendar apps to subscribe to. The URL carries a secret token shown only once; creating another
+   * replaces it.
+   */
+  async createWatchlistCalendar(
+    params: CreateWatchlistCalendarParams = {},
+    options: RequestOptions = {},
+  ): Promise<CalendarResponse> {
+    return this.request(
+      'POST',
+      '/api/v1/watchlist/calendar',
+      { headers: { 'X-API-Key': params.apiKey }, ...options },
+    );
+  }
+
+  /**
+   * DELETE /api/v1/admin/bills/{id}/aliases/{aliasId}: Remove an alias from a bill.
+   *
+   * Removes one of a bill's aliases. Short and popular titles return on the bill's next sync.
+   */
+  async deleteBillAlias(id: number, aliasId: number, options: RequestOptions = {}): Promise<void> {
+    await this.send('DELETE', `/api/v1/admin/bills/${path(id)}/aliases/${path(aliasId)}`, options);
+  }
+
+  /**
+   * DELETE /api/v1/admin/deltas/{id}: Invalidate a cached delta.
+   *
+   * Deletes a cached delta; the next request for that version pair recomputes it.
+   */
+  async deleteDelta(id: number, options: RequestOptions = {}): Promise<void> {
+    await this.send('DELETE', `/api/v1/admin/deltas/${path(id)}`, options);
+  }
+
+  /**
+   * DELETE /api/v1/watchlist/alerts/{id}: Delete a keyword alert.
+   *
+   * Deletes a keyword alert and its matches.
+   */
+  async deleteKeywordAlert(
+    id: number,
+    params: DeleteKeywordAlertParams = {},
+    options: RequestOptions = {},
+  ): Promise<void> {
+    await this.send(
+      'DELETE',
+      `/api/v1/watchlist/alerts/${path(id)}`,
+      { headers: { 'X-API-Key': params.apiKey }, ...options },
+    );
+  }
+
+  /** DELETE /api/v1/watchlist/searches/{id}: Delete a saved search. */
+  async deleteSavedSearch(
+    id: number,
+    params: DeleteSavedSearchParams = {},
+    options: RequestOptions = {},
+  ): Promise<void> {
+    await this.send(
+      'DELETE',
+      `/api/v1/watchlist/searches/${path(id)}`,
+      { headers: { 'X-API-Key': params.apiKey }, ...options },
+    );
+  }
+
+  /**
+   * DELETE /api/v1/admin/users/{id}/alerts/{alertId}: Delete a user's keyword alert.
+   *
+   * Deletes one of a user's keyword alerts and its matches.
+   */
+  async deleteUserKeywordAlert(
+    id: number,
+    alertId: number,
+    options: RequestOptions = {},
+  ): Promise<void> {
+    await this.send('DELETE', `/api/v1/admin/users/${path(id)}/alerts/${path(alertId)}`, options);
+  }
+
+  /**
+   * DELETE /api/v1/admin/users/{id}/watchlist/searches/{searchId}: Delete a user's saved search.
+   */
+  async deleteUserSavedSearch(
+    id: number,
+    searchId: number,
+    options: RequestOptions = {},
+  ): Promise<void> {
+    await this.send(
+      'DELETE',
+      `/api/v1/admin/users/${path(id)}/watchlist/searches/${path(searchId)}`,
+      options,
+    );
+  }
+
+  /**
+   * DELETE /api/v1/watchlist/calendar: Delete the watchlist calendar URL.
+   *
+   * Stops publishing the caller's watchlist calendar; its URL returns 404.
+   */
+  async deleteWatchlistCalendar(
+    params: DeleteWatchlistCalendarParams = {},
+    options: RequestOptions = {},
+  ): Promise<void> {
+    await this.send(
+      'DELETE',
+      '/api/v1/watchlist/calendar',
+      { headers: { 'X-API-Key': params.apiKey }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/documents/{id}/diff/{fromVersion}/{toVersion}: Compute diff between two document
+   * versions.
+   *
+   * Returns the same structured diff as the bill diff endpoint for two versions of one of the
+   * caller's documents. With view=split, returns aligned left/right rows for side-by-side
+   * rendering.
+   */
+  async diffDocumentVersions(
+    id: number,
+    fromVersion: number,
+    toVersion: number,
+    params: DiffDocumentVersionsParams = {},
+    options: RequestOptions = {},
+  ): Promise<DiffResponse> {
+    return this.request(
+      'GET',
+      `/api/v1/documents/${path(id)}/diff/${path(fromVersion)}/${path(toVersion)}`,
+      {
+        query: {
+          ignoreWhitespace: params.ignoreWhitespace,
+          ignoreLineWrap: params.ignoreLineWrap,
+          stripPageArtifacts: params.stripPageArtifacts,
+          hunkOffset: params.hunkOffset,
+          hunkLimit: params.hunkLimit,
+          context: params.context,
+          view: params.view,
+        },
+        headers: { 'X-API-Key': params.apiKey },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * GET /api/v1/rules/{id}/diff: Diff proposed and final rule.
+   *
+   * Diffs the proposed rule and the final rule sharing a RIN (or docket) with this rule, from
+   * proposed to final. fromVersion and toVersion are document numbers.
+   */
+  async diffRule(id: number, options: RequestOptions = {}): Promise<DiffResponse> {
+    return this.request('GET', `/api/v1/rules/${path(id)}/diff`, options);
+  }
+
+  /**
+   * POST /api/v1/admin/ingest-failures/{id}/dismiss: Dismiss an ingestion failure.
+   *
+   * Closes an open failure so it is no longer retried.
+   */
+  async dismissIngestionFailure(
+    id: number,
+    options: RequestOptions = {},
+  ): Promise<IngestFailureResponse> {
+    return this.request('POST', `/api/v1/admin/ingest-failures/${path(id)}/dismiss`, options);
+  }
+
+  /**
+   * GET /api/v1/export/bills: Export bills.
+   *
+   * Streams every bill matching the filters as JSON Lines or CSV, for loading into tools such as
+   * pandas or BigQuery.
+   */
+  async exportBills(
+    params: ExportBillsParams = {},
+    options: RequestOptions = {},
+  ): Promise<Response> {
+    return this.send(
+      'GET',
+      '/api/v1/export/bills',
+      {
+        query: {
+          format: params.format,
+          congress: params.congress,
+          from: params.from,
+          to: params.to,
+          spending: params.spending,
+        },
+        accept: '*/*',
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * GET /api/v1/export/versions: Export versions.
+   *
+   * Streams every text version of the bills matching the filters as JSON Lines or CSV, optionally
+   * with plain text.
+   */
+  async exportVersions(
+    params: ExportVersionsParams = {},
+    options: RequestOptions = {},
+  ): Promise<Response> {
+    return this.send(
+      'GET',
+      '/api/v1/export/versions',
+      {
+        query: {
+          format: params.format,
+          congress: params.congress,
+          from: params.from,
+          to: params.to,
+          spending: params.spending,
+          includeText: params.includeText,
+        },
+        accept: '*/*',
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * POST /api/v1/bills/hr1/fetch: Fetch H.R. 1 (One Big Beautiful Bill).
+   *
+   * Fetches H.R. 1 (119th Congress) from Congress.gov and stores all versions.
+   */
+  async fetchHr1(options: RequestOptions = {}): Promise<BillResponse> {
+    return this.request('POST', '/api/v1/bills/hr1/fetch', options);
+  }
+
+  /**
+   * GET /api/v1/appropriations/accounts/history: Appropriations account funding history.
+   *
+   * Returns an account's amount in every version of every bill that funds it, to compare across a
+   * bill's versions, and its level in each fiscal year with the change from the year before.
+   */
+  async getAppropriationsAccountHistory(
+    params: GetAppropriationsAccountHistoryParams = {},
+    options: RequestOptions = {},
+  ): Promise<AccountHistoryResponse> {
+    return this.request(
+      'GET',
+      '/api/v1/appropriations/accounts/history',
+      { query: { key: params.key }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/appropriations/deadlines: Continuing resolution deadlines.
+   *
+   * Returns a Congress's continuing resolutions, identified by title or CRS subject, with the day
+   * each one's funding runs out as read from its latest text, the days remaining, whether it was
+   * enacted, and the appropriations bills of the same fiscal year pending that could replace it.
+   */
+  async getAppropriationsDeadlines(
+    params: GetAppropriationsDeadlinesParams = {},
+    options: RequestOptions = {},
+  ): Promise<AppropriationsDeadlinesResponse> {
+    return this.request(
+      'GET',
+      '/api/v1/appropriations/deadlines',
+      { query: { congress: params.congress, status: params.status }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/bills/{id}: Get a bill by ID.
+   *
+   * Returns detailed information about a specific legislative bill.
+   */
+  async getBill(
+    id: number,
+    params: GetBillParams = {},
+    options: RequestOptions = {},
+  ): Promise<BillResponse> {
+    return this.request(
+      'GET',
+      `/api/v1/bills/${path(id)}`,
+      { headers: { 'If-None-Match': params.ifNoneMatch }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/bills/{id}/aliases: List a bill's aliases.
+   *
+   * Returns the names a bill is known by besides its official title: its short and popular titles
+   * from Congress.gov and nicknames curated by operators. Bill search matches aliases as well as
+   * titles.
+   */
+  async getBillAliases(id: number, options: RequestOptions = {}): Promise<BillAliasesResponse> {
+    return this.request('GET', `/api/v1/bills/${path(id)}/aliases`, options);
+  }
+
+  /**
+   * GET /api/v1/bills/{id}/as-of: Get a bill as it stood on a date.
+   *
+   * Returns the version that was current at the end of the date, with its plain text, and the
+   * bill's title, status, sponsor, and law status at the time, reconstructed from its change feed.
+   * With diff=true, also diffs that version against the most recent one.
+   */
+  async getBillAsOf(
+    id: number,
+    params: GetBillAsOfParams = {},
+    options: RequestOptions = {},
+  ): Promise<AsOfResponse> {
+    return this.request(
+      'GET',
+      `/api/v1/bills/${path(id)}/as-of`,
+      { query: { date: params.date, diff: params.diff }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/bills/{id}/changes: Get a bill's change feed.
+   *
+   * Returns detected changes to a bill in chronological order: new versions (with insert/delete
+   * counts from cached diffs), status transitions, title changes, and sponsor changes.
+   */
+  async getBillChanges(
+    id: number,
+    params: GetBillChangesParams = {},
+    options: RequestOptions = {},
+  ): Promise<BillChangeFeed> {
+    return this.request(
+      'GET',
+      `/api/v1/bills/${path(id)}/changes`,
+      { query: { limit: params.limit, offset: params.offset }, ...options },
+    );
+  }
+
+  /**
+   * Yields the changes of every page of getBillChanges, starting at params.offset and fetching
+   * params.limit at a time.
+   */
+  async *getBillChangesAll(
+    id: number,
+    params: GetBillChangesParams = {},
+    options: RequestOptions = {},
+  ): AsyncGenerator<BillChangeResponse> {
+    let offset = params.offset ?? 0;
+    for (;;) {
+      const page = await this.getBillChanges(id, { ...params, offset }, options);
+      const items = page.changes ?? [];
+      yield* items;
+      offset += items.length;
+      if (items.length === 0 || offset >= page.total) {
+        return;
+      }
+    }
+  }
+
+  /**
+   * GET /api/v1/bills/{id}/cost-estimates: Get a bill's CBO cost estimates.
+   *
+   * Returns links to the Congressional Budget Office cost estimates published for a bill, newest
+   * first.
+   */
+  async getBillCostEstimates(
+    id: number,
+    options: RequestOptions = {},
+  ): Promise<CostEstimatesResponse> {
+    return this.request('GET', `/api/v1/bills/${path(id)}/cost-estimates`, options);
+  }
+
+  /**
+   * GET /api/v1/bills/{id}/definition-changes: Compare defined terms between two bill versions.
+   *
+   * Extracts the terms defined in each version's definitions sections (e.g., "SEC. 2.
+   * DEFINITIONS.") and reports which definitions were added, removed, or reworded. Rewording
+   * ignores case and whitespace. Defaults to the two most recent versions.
+   */
+  async getBillDefinitionChanges(
+    id: number,
+    params: GetBillDefinitionChangesParams = {},
+    options: RequestOptions = {},
+  ): Promise<DefinitionChangesResponse> {
+    return this.request(
+      'GET',
+      `/api/v1/bills/${path(id)}/definition-changes`,
+      {
+        query: { from: params.from, to: params.to, includeUnchanged: params.includeUnchanged },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * GET /api/v1/bills/{id}/earmark-changes: Compare earmarks between two bill versions.
+   *
+   * Matches earmarks between two versions by recipient and purpose and reports which were added or
+   * removed and whose amounts changed. Defaults to the two most recent versions.
+   */
+  async getBillEarmarkChanges(
+    id: number,
+    params: GetBillEarmarkChangesParams = {},
+    options: RequestOptions = {},
+  ): Promise<EarmarkChangesResponse> {
+    return this.request(
+      'GET',
+      `/api/v1/bills/${path(id)}/earmark-changes`,
+      {
+        query: { from: params.from, to: params.to, includeUnchanged: params.includeUnchanged },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * GET /feeds/bills/{id}.atom: Atom feed of a bill's changes.
+   *
+   * Returns an Atom feed of the latest changes detected on a single bill, with diff statistics for
+   * new versions.
+   */
+  async getBillFeed(id: number, options: RequestOptions = {}): Promise<Response> {
+    return this.send(
+      'GET',
+      `/feeds/bills/${path(id)}.atom`,
+      { accept: 'application/atom+xml', ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/bills/{id}/lineage: Get a bill's lineage across Congresses.
+   *
+   * Returns the bills of the previous Congress a bill reintroduces and those of the next Congress
+   * that reintroduce it, matched by the ingestor on title, sponsor, and the share of sections with
+   * the same language, and the chain of best matches through every Congress.
+   */
+  async getBillLineage(id: number, options: RequestOptions = {}): Promise<LineageResponse> {
+    return this.request('GET', `/api/v1/bills/${path(id)}/lineage`, options);
+  }
+
+  /**
+   * GET /api/v1/bills/{id}/spending-changes: Compare dollar amounts between two bill versions.
+   *
+   * Extracts dollar amounts with their section and account context from two versions and reports
+   * which amounts were added, removed, or changed and by how much. Defaults to the two most recent
+   * versions.
+   */
+  async getBillSpendingChanges(
+    id: number,
+    params: GetBillSpendingChangesParams = {},
+    options: RequestOptions = {},
+  ): Promise<SpendingChangesResponse> {
+    return this.request(
+      'GET',
+      `/api/v1/bills/${path(id)}/spending-changes`,
+      {
+        query: { from: params.from, to: params.to, includeUnchanged: params.includeUnchanged },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * GET /api/v1/bills/{id}/summaries: Get a bill's CRS summaries.
+   *
+   * Returns the Congressional Research Service summaries of a bill, one per summarized action,
+   * oldest first.
+   */
+  async getBillSummaries(id: number, options: RequestOptions = {}): Promise<SummariesResponse> {
+    return this.request('GET', `/api/v1/bills/${path(id)}/summaries`, options);
+  }
+
+  /**
+   * GET /api/v1/bills/{id}/version-matrix: Get diff statistics for every pair of a bill's versions.
+   *
+   * Returns insertions, deletions, and percent of lines changed from each version to each later
+   * one, from cached diffs. Pairs not diffed yet are pending and queued; request again to pick them
+   * up.
+   */
+  async getBillVersionMatrix(
+    id: number,
+    params: GetBillVersionMatrixParams = {},
+    options: RequestOptions = {},
+  ): Promise<VersionMatrixResponse> {
+    return this.request(
+      'GET',
+      `/api/v1/bills/${path(id)}/version-matrix`,
+      { headers: { 'If-None-Match': params.ifNoneMatch }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/bills/{id}/versions: Get all versions of a bill.
+   *
+   * Returns all tracked versions/snapshots of a bill's text.
+   */
+  async getBillVersions(
+    id: number,
+    params: GetBillVersionsParams = {},
+    options: RequestOptions = {},
+  ): Promise<GetBillVersionsOutputBody> {
+    return this.request(
+      'GET',
+      `/api/v1/bills/${path(id)}/versions`,
+      { headers: { 'If-None-Match': params.ifNoneMatch }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/stats/bills-by-status: Count bills by status per congress.
+   *
+   * Counts public federal bills by the furthest legislative stage their text has reached, per
+   * congress. Bills without published text count as introduced, and bills with a law number as
+   * enacted. Refreshed by the ingestor after each run; computedAt says when.
+   */
+  async getBillsByStatus(
+    params: GetBillsByStatusParams = {},
+    options: RequestOptions = {},
+  ): Promise<BillsByStatusResponse> {
+    return this.request(
+      'GET',
+      '/api/v1/stats/bills-by-status',
+      { query: { congress: params.congress }, ...options },
+    );
+  }
+
+  /**
+   * GET /feeds/bills.atom: Atom feed of recently changed bills.
+   *
+   * Returns an Atom feed of the latest changes detected across all bills, with diff statistics for
+   * new versions.
+   */
+  async getBillsFeed(options: RequestOptions = {}): Promise<Response> {
+    return this.send('GET', '/feeds/bills.atom', { accept: 'application/atom+xml', ...options });
+  }
+
+  /**
+   * GET /api/v1/bills/{billId}/diff/{fromVersion}/{toVersion}/report.docx: Download a change report
+   * as a Word document.
+   *
+   * Returns a Word document (.docx) reporting the changes between two versions: word, page, and
+   * section counts, the stored summary of the changes when there is one, and each added, removed,
+   * and amended section, its changes marked as tracked insertions and deletions that can be
+   * reviewed in Word.
+   */
+  async getChangeReport(
+    billId: number,
+    fromVersion: number,
+    toVersion: number,
+    options: RequestOptions = {},
+  ): Promise<Response> {
+    return this.send(
+      'GET',
+      `/api/v1/bills/${path(billId)}/diff/${path(fromVersion)}/${path(toVersion)}/report.docx`,
+      {
+        accept: 'application/vnd.openxmlformats-officedocument.wordprocessingml.document',
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * GET /api/v1/admin/database: Get database pool statistics.
+   *
+   * Returns the API server's connection pool statistics, for the primary and each read replica, and
+   * its slow query threshold. A growing waitCount means queries are queuing for a free connection.
+   */
+  async getDatabaseStats(options: RequestOptions = {}): Promise<DatabaseStatsResponse> {
+    return this.request('GET', '/api/v1/admin/database', options);
+  }
+
+  /**
+   * GET /api/v1/admin/deltas/jobs/{id}: Get a delta recompute job.
+   *
+   * Returns a delta recompute job's status and progress.
+   */
+  async getDeltaJob(id: number, options: RequestOptions = {}): Promise<DeltaJobResponse> {
+    return this.request('GET', `/api/v1/admin/deltas/jobs/${path(id)}`, options);
+  }
+
+  /**
+   * GET /api/v1/diagnostics: Diagnostics.
+   *
+   * Pings the database (with connection pool statistics) and Congress.gov (with latency, cached for
+   * 30 seconds), and reports the remaining Congress.gov rate limit, the last successful ingestion,
+   * and pending delta jobs, ingestion retries, and background diffs. Status is degraded if a check
+   * fails.
+   */
+  async getDiagnostics(options: RequestOptions = {}): Promise<DiagnosticsReport> {
+    return this.request('GET', '/api/v1/diagnostics', options);
+  }
+
+  /**
+   * GET /api/v1/stats/diff-size-by-type: Average diff size by bill type.
+   *
+   * Returns the number of stored diffs of each federal bill type and their average lines inserted,
+   * deleted, and changed. Refreshed by the ingestor after each run; computedAt says when.
+   */
+  async getDiffSizeByType(options: RequestOptions = {}): Promise<DiffSizeByTypeResponse> {
+    return this.request('GET', '/api/v1/stats/diff-size-by-type', options);
+  }
+
+  /**
+   * GET /api/v1/documents/{id}: Get a tracked document.
+   *
+   * Returns one of the caller's documents with its versions and their content hashes.
+   */
+  async getDocument(
+    id: number,
+    params: GetDocumentParams = {},
+    options: RequestOptions = {},
+  ): Promise<DocumentResponse> {
+    return this.request(
+      'GET',
+      `/api/v1/documents/${path(id)}`,
+      { headers: { 'X-API-Key': params.apiKey }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/documents/{id}/versions: Get all versions of a tracked document.
+   *
+   * Returns the uploaded versions of one of the caller's documents, oldest first.
+   */
+  async getDocumentVersions(
+    id: number,
+    params: GetDocumentVersionsParams = {},
+    options: RequestOptions = {},
+  ): Promise<GetDocumentVersionsOutputBody> {
+    return this.request(
+      'GET',
+      `/api/v1/documents/${path(id)}/versions`,
+      { headers: { 'X-API-Key': params.apiKey }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/events: Stream live bill updates.
+   *
+   * Server-Sent Events stream that pushes an event whenever the ingestor creates or updates a bill
+   * (bill_created, bill_updated) or stores a new version (version_created). Each event's data is a
+   * JSON object identifying the bill. Events published while a client is disconnected are not
+   * replayed; use the change feed to catch up.
+   */
+  async getEvents(params: GetEventsParams = {}, options: RequestOptions = {}): Promise<Response> {
+    return this.send(
+      'GET',
+      '/api/v1/events',
+      {
+        query: { congress: params.congress, billType: params.billType, billId: params.billId },
+        accept: 'text/event-stream',
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * GET /health: Health Check.
+   *
+   * Returns the status of the API and database connection.
+   */
+  async getHealth(options: RequestOptions = {}): Promise<DiagnosticHealthOutputBody> {
+    return this.request('GET', '/health', options);
+  }
+
+  /**
+   * GET /api/v1/bills/hr1: Get H.R. 1 (One Big Beautiful Bill).
+   *
+   * Returns H.R. 1 with all versions. Auto-fetches from Congress.gov if not cached.
+   */
+  async getHr1(params: GetHr1Params = {}, options: RequestOptions = {}): Promise<BillResponse> {
+    return this.request(
+      'GET',
+      '/api/v1/bills/hr1',
+      { headers: { 'If-None-Match': params.ifNoneMatch }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/admin/ingestions/latest: Get the latest ingestion run.
+   *
+   * Returns the most recently started ingestion run, including runs still in progress.
+   */
+  async getLatestIngestionRun(options: RequestOptions = {}): Promise<IngestRunResponse> {
+    return this.request('GET', '/api/v1/admin/ingestions/latest', options);
+  }
+
+  /**
+   * GET /healthz: Liveness probe.
+   *
+   * Returns 200 while the process is running. Does not check dependencies.
+   */
+  async getLiveness(options: RequestOptions = {}): Promise<LivenessOutputBody> {
+    return this.request('GET', '/healthz', options);
+  }
+
+  /**
+   * GET /api/v1/me: Get the current user.
+   *
+   * Returns the user a session token or API key belongs to.
+   */
+  async getMe(params: GetMeParams = {}, options: RequestOptions = {}): Promise<MeResponse> {
+    return this.request(
+      'GET',
+      '/api/v1/me',
+      { headers: { 'X-API-Key': params.apiKey }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/members/{id}/impact: Get a member's text-influence scorecard.
+   *
+   * Returns sponsorship counts, how many provisions from the member's sponsored bills survived into
+   * enacted text, and total enacted appropriations.
+   */
+  async getMemberImpact(id: string, options: RequestOptions = {}): Promise<MemberImpactResponse> {
+    return this.request('GET', `/api/v1/members/${path(id)}/impact`, options);
+  }
+
+  /**
+   * GET /api/v1/members/{id}/stats: Get a member's sponsorship stats.
+   *
+   * Returns bills sponsored and enacted per congress, the enactment rate, the average text churn of
+   * the member's bills (lines changed between consecutive versions), and the policy areas they
+   * sponsor in. Served from aggregates the ingestor refreshes each polling cycle; computedAt says
+   * when.
+   */
+  async getMemberStats(id: string, options: RequestOptions = {}): Promise<MemberStatsResponse> {
+    return this.request('GET', `/api/v1/members/${path(id)}/stats`, options);
+  }
+
+  /**
+   * GET /api/v1/nominations/{id}: Get a nomination.
+   *
+   * Returns a nomination with its actions, from receipt through committee and floor consideration.
+   */
+  async getNomination(id: number, options: RequestOptions = {}): Promise<NominationResponse> {
+    return this.request('GET', `/api/v1/nominations/${path(id)}`, options);
+  }
+
+  /**
+   * GET /readyz: Readiness probe.
+   *
+   * Returns 200 when the database (and optionally Congress.gov) is reachable, 503 otherwise or
+   * while shutting down.
+   */
+  async getReadiness(options: RequestOptions = {}): Promise<ReadinessReport> {
+    return this.request('GET', '/readyz', options);
+  }
+
+  /**
+   * GET /api/v1/admin/reingest/{id}: Get a reingest job.
+   *
+   * Returns a re-ingestion job's status and progress.
+   */
+  async getReingestJob(id: number, options: RequestOptions = {}): Promise<ReingestJobResponse> {
+    return this.request('GET', `/api/v1/admin/reingest/${path(id)}`, options);
+  }
+
+  /**
+   * GET /api/v1/bills/{id}/related: Get a bill's related bills.
+   *
+   * Returns bills Congress.gov lists as related, with House/Senate companions (identical bills in
+   * the other chamber) first. Ingested related bills include their latest version ID for use with
+   * the diff endpoint.
+   */
+  async getRelatedBills(id: number, options: RequestOptions = {}): Promise<RelatedBillsResponse> {
+    return this.request('GET', `/api/v1/bills/${path(id)}/related`, options);
+  }
+
+  /**
+   * GET /api/v1/rules/{id}: Get a rule.
+   *
+   * Returns a rule and the ID of the proposed or final rule it is diffed against.
+   */
+  async getRule(id: number, options: RequestOptions = {}): Promise<RuleResponse> {
+    return this.request('GET', `/api/v1/rules/${path(id)}`, options);
+  }
+
+  /**
+   * GET /api/v1/treaties/{id}: Get a treaty.
+   *
+   * Returns a treaty with its actions, from transmittal through committee and floor consideration.
+   */
+  async getTreaty(id: number, options: RequestOptions = {}): Promise<TreatyResponse> {
+    return this.request('GET', `/api/v1/treaties/${path(id)}`, options);
+  }
+
+  /**
+   * GET /api/v1/bills/trending: List the most actively changing bills.
+   *
+   * Ranks bills by activity over a sliding window (a week by default): text versions published,
+   * legislative events, and lines changed between versions. Scores are recomputed by the ingestor
+   * after each run.
+   */
+  async getTrendingBills(
+    params: GetTrendingBillsParams = {},
+    options: RequestOptions = {},
+  ): Promise<TrendingResponse> {
+    return this.request(
+      'GET',
+      '/api/v1/bills/trending',
+      {
+        query: { limit: params.limit },
+        headers: { 'If-None-Match': params.ifNoneMatch },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * GET /api/v1/admin/users/{id}/watchlist: Get a user's watchlist.
+   *
+   * Returns a user's saved searches and watched bills.
+   */
+  async getUserWatchlist(id: number, options: RequestOptions = {}): Promise<WatchlistResponse> {
+    return this.request('GET', `/api/v1/admin/users/${path(id)}/watchlist`, options);
+  }
+
+  /**
+   * GET /api/v1/versions/{id}/earmarks: List a version's earmarks.
+   *
+   * Returns the community project funding entries in a version's text, each with its recipient,
+   * purpose, amount, and section: clauses directing an amount as a grant to a named recipient, and
+   * rows of community project funding tables.
+   */
+  async getVersionEarmarks(
+    id: number,
+    options: RequestOptions = {},
+  ): Promise<VersionEarmarksResponse> {
+    return this.request('GET', `/api/v1/versions/${path(id)}/earmarks`, options);
+  }
+
+  /**
+   * GET /api/v1/bills/{id}/version-graph: Get a bill's version graph.
+   *
+   * Returns which version each of a bill's versions derives from, as parent edges. Versions branch:
+   * an engrossed amendment derives from the other chamber's passed text rather than the version
+   * fetched before it, and enrolled text from the version the chambers agreed to. Derived from
+   * version codes and fetch order; unmapped codes follow the version fetched before them.
+   */
+  async getVersionGraph(id: number, options: RequestOptions = {}): Promise<VersionGraphResponse> {
+    return this.request('GET', `/api/v1/bills/${path(id)}/version-graph`, options);
+  }
+
+  /**
+   * GET /api/v1/versions/{id}/provenance: Get a version's provenance.
+   *
+   * Returns where a version's text was retrieved from: the source URL, its Last-Modified and ETag
+   * headers, when it was retrieved, and the SHA-256 of the bytes retrieved.
+   */
+  async getVersionProvenance(
+    id: number,
+    options: RequestOptions = {},
+  ): Promise<ProvenanceResponse> {
+    return this.request('GET', `/api/v1/versions/${path(id)}/provenance`, options);
+  }
+
+  /**
+   * GET /api/v1/versions/{id}/text: Get a version's text.
+   *
+   * Returns a version's text as plain text, HTML, or the original XML. fromSection/toSection select
+   * a range of sections, and offset/length a byte range within it, so large bills can be loaded in
+   * portions; totalLength is the size of the whole selection.
+   */
+  async getVersionText(
+    id: number,
+    params: GetVersionTextParams = {},
+    options: RequestOptions = {},
+  ): Promise<VersionTextResponse> {
+    return this.request(
+      'GET',
+      `/api/v1/versions/${path(id)}/text`,
+      {
+        query: {
+          format: params.format,
+          fromSection: params.fromSection,
+          toSection: params.toSection,
+          offset: params.offset,
+          length: params.length,
+        },
+        headers: { 'If-None-Match': params.ifNoneMatch },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * GET /api/v1/stats/versions-per-day: Count versions ingested per day.
+   *
+   * Counts the bill text versions ingested on each recent day, and the bills they belong to.
+   * Refreshed by the ingestor after each run; computedAt says when.
+   */
+  async getVersionsPerDay(
+    params: GetVersionsPerDayParams = {},
+    options: RequestOptions = {},
+  ): Promise<VersionsPerDayResponse> {
+    return this.request(
+      'GET',
+      '/api/v1/stats/versions-per-day',
+      { query: { days: params.days }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/watchlist: Get watchlist.
+   *
+   * Returns the caller's saved searches and watched bills.
+   */
+  async getWatchlist(
+    params: GetWatchlistParams = {},
+    options: RequestOptions = {},
+  ): Promise<WatchlistResponse> {
+    return this.request(
+      'GET',
+      '/api/v1/watchlist',
+      { headers: { 'X-API-Key': params.apiKey }, ...options },
+    );
+  }
+
+  /**
+   * GET /feeds/watchlist/{token}.ics: iCalendar feed of watched bills' milestones.
+   *
+   * Returns an iCalendar feed of all-day events for the bills on a watchlist: committee markups and
+   * floor consideration scheduled in their actions, the day continuing resolutions expire, and the
+   * start of the fiscal year unenacted appropriations bills fund. Events stay for 90 days after
+   * their day.
+   */
+  async getWatchlistCalendar(token: string, options: RequestOptions = {}): Promise<Response> {
+    return this.send(
+      'GET',
+      `/feeds/watchlist/${path(token)}.ics`,
+      { accept: 'text/calendar', ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/watchlist/updates: Get watchlist updates.
+   *
+   * Returns changes to watched bills, bills newly matching saved searches, and keyword alert
+   * matches since the last check (or 'since'), then records the check.
+   */
+  async getWatchlistUpdates(
+    params: GetWatchlistUpdatesParams = {},
+    options: RequestOptions = {},
+  ): Promise<WatchlistUpdates> {
+    return this.request(
+      'GET',
+      '/api/v1/watchlist/updates',
+      { query: { since: params.since }, headers: { 'X-API-Key': params.apiKey }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/appropriations/accounts: Appropriations account funding levels.
+   *
+   * Returns the accounts the twelve annual appropriations bills fund in a fiscal year, parsed from
+   * their titles, agencies, and accounts, with each account's amount as enacted or, until then, as
+   * in the latest version, and totals by bill.
+   */
+  async listAppropriationsAccounts(
+    params: ListAppropriationsAccountsParams = {},
+    options: RequestOptions = {},
+  ): Promise<AppropriationsAccountsResponse> {
+    return this.request(
+      'GET',
+      '/api/v1/appropriations/accounts',
+      {
+        query: {
+          fiscalYear: params.fiscalYear,
+          subcommittee: params.subcommittee,
+          query: params.query,
+          limit: params.limit,
+          offset: params.offset,
+        },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * GET /api/v1/admin/audit: List the audit log.
+   *
+   * Returns recorded requests to mutating operations, newest first: admin actions, watchlist and
+   * alert changes, and uploads, with the caller, target, response status, and before/after
+   * summaries where recorded.
+   */
+  async listAuditLog(
+    params: ListAuditLogParams = {},
+    options: RequestOptions = {},
+  ): Promise<AuditLogList> {
+    return this.request(
+      'GET',
+      '/api/v1/admin/audit',
+      {
+        query: {
+          userId: params.userId,
+          actorType: params.actorType,
+          operation: params.operation,
+          targetType: params.targetType,
+          targetId: params.targetId,
+          since: params.since,
+          until: params.until,
+          limit: params.limit,
+          offset: params.offset,
+        },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * Yields the entries of every page of listAuditLog, starting at params.offset and fetching
+   * params.limit at a time.
+   */
+  async *listAuditLogAll(
+    params: ListAuditLogParams = {},
+    options: RequestOptions = {},
+  ): AsyncGenerator<AuditLogResponse> {
+    let offset = params.offset ?? 0;
+    for (;;) {
+      const page = await this.listAuditLog({ ...params, offset }, options);
+      const items = page.entries ?? [];
+      yield* items;
+      offset += items.length;
+      if (items.length === 0 || offset >= page.total) {
+        return;
+      }
+    }
+  }
+
+  /**
+   * GET /api/v1/bills: List all bills.
+   *
+   * Returns all bills stored in the database. With becameLaw=true, returns only bills enacted as
+   * public or private law.
+   */
+  async listBills(
+    params: ListBillsParams = {},
+    options: RequestOptions = {},
+  ): Promise<ListBillsOutputBody> {
+    return this.request(
+      'GET',
+      '/api/v1/bills',
+      { query: { becameLaw: params.becameLaw }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/documents: List tracked documents.
+   *
+   * Returns the caller's documents, most recently updated first.
+   */
+  async listDocuments(
+    params: ListDocumentsParams = {},
+    options: RequestOptions = {},
+  ): Promise<DocumentListResponse> {
+    return this.request(
+      'GET',
+      '/api/v1/documents',
+      { headers: { 'X-API-Key': params.apiKey }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/drafts: List drafts.
+   *
+   * Returns the drafts of the caller's tenant, most recently updated first.
+   */
+  async listDrafts(
+    params: ListDraftsParams = {},
+    options: RequestOptions = {},
+  ): Promise<DraftListResponse> {
+    return this.request(
+      'GET',
+      '/api/v1/drafts',
+      { headers: { 'X-API-Key': params.apiKey }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/admin/ingest-failures: List ingestion failures.
+   *
+   * Returns dead-lettered bills whose ingestion failed, with the error class, attempts, and the
+   * listing they were ingested from. The ingestor retries pending failures with backoff after each
+   * run.
+   */
+  async listIngestionFailures(
+    params: ListIngestionFailuresParams = {},
+    options: RequestOptions = {},
+  ): Promise<IngestFailureList> {
+    return this.request(
+      'GET',
+      '/api/v1/admin/ingest-failures',
+      { query: { status: params.status, limit: params.limit, offset: params.offset }, ...options },
+    );
+  }
+
+  /**
+   * Yields the failures of every page of listIngestionFailures, starting at params.offset and
+   * fetching params.limit at a time.
+   */
+  async *listIngestionFailuresAll(
+    params: ListIngestionFailuresParams = {},
+    options: RequestOptions = {},
+  ): AsyncGenerator<IngestFailureResponse> {
+    let offset = params.offset ?? 0;
+    for (;;) {
+      const page = await this.listIngestionFailures({ ...params, offset }, options);
+      const items = page.failures ?? [];
+      yield* items;
+      offset += items.length;
+      if (items.length === 0 || offset >= page.total) {
+        return;
+      }
+    }
+  }
+
+  /**
+   * GET /api/v1/admin/ingestions: List ingestion runs.
+   *
+   * Returns recorded ingestion runs with counts, duration, and errors, newest first.
+   */
+  async listIngestionRuns(
+    params: ListIngestionRunsParams = {},
+    options: RequestOptions = {},
+  ): Promise<IngestRunList> {
+    return this.request(
+      'GET',
+      '/api/v1/admin/ingestions',
+      { query: { limit: params.limit, offset: params.offset }, ...options },
+    );
+  }
+
+  /**
+   * Yields the runs of every page of listIngestionRuns, starting at params.offset and fetching
+   * params.limit at a time.
+   */
+  async *listIngestionRunsAll(
+    params: ListIngestionRunsParams = {},
+    options: RequestOptions = {},
+  ): AsyncGenerator<IngestRunResponse> {
+    let offset = params.offset ?? 0;
+    for (;;) {
+      const page = await this.listIngestionRuns({ ...params, offset }, options);
+      const items = page.runs ?? [];
+      yield* items;
+      offset += items.length;
+      if (items.length === 0 || offset >= page.total) {
+        return;
+      }
+    }
+  }
+
+  /**
+   * GET /api/v1/watchlist/alerts/{id}/matches: List keyword alert matches.
+   *
+   * Returns the versions whose text matched a keyword alert, newest first, with the matching
+   * snippets and the anchors of their sections.
+   */
+  async listKeywordAlertMatches(
+    id: number,
+    params: ListKeywordAlertMatchesParams = {},
+    options: RequestOptions = {},
+  ): Promise<AlertMatchList> {
+    return this.request(
+      'GET',
+      `/api/v1/watchlist/alerts/${path(id)}/matches`,
+      {
+        query: { limit: params.limit, offset: params.offset },
+        headers: { 'X-API-Key': params.apiKey },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * Yields the matches of every page of listKeywordAlertMatches, starting at params.offset and
+   * fetching params.limit at a time.
+   */
+  async *listKeywordAlertMatchesAll(
+    id: number,
+    params: ListKeywordAlertMatchesParams = {},
+    options: RequestOptions = {},
+  ): AsyncGenerator<AlertMatchResponse> {
+    let offset = params.offset ?? 0;
+    for (;;) {
+      const page = await this.listKeywordAlertMatches(id, { ...params, offset }, options);
+      const items = page.matches ?? [];
+      yield* items;
+      offset += items.length;
+      if (items.length === 0 || offset >= page.total) {
+        return;
+      }
+    }
+  }
+
+  /**
+   * GET /api/v1/watchlist/alerts: List keyword alerts.
+   *
+   * Returns the caller's keyword alerts.
+   */
+  async listKeywordAlerts(
+    params: ListKeywordAlertsParams = {},
+    options: RequestOptions = {},
+  ): Promise<KeywordAlertsResponse> {
+    return this.request(
+      'GET',
+      '/api/v1/watchlist/alerts',
+      { headers: { 'X-API-Key': params.apiKey }, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/nominations: List nominations.
+   *
+   * Lists presidential nominations sent to the Senate, most recent action first.
+   */
+  async listNominations(
+    params: ListNominationsParams = {},
+    options: RequestOptions = {},
+  ): Promise<NominationListResponse> {
+    return this.request(
+      'GET',
+      '/api/v1/nominations',
+      {
+        query: {
+          congress: params.congress,
+          organization: params.organization,
+          type: params.type,
+          query: params.query,
+          limit: params.limit,
+          offset: params.offset,
+        },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * Yields the nominations of every page of listNominations, starting at params.offset and fetching
+   * params.limit at a time.
+   */
+  async *listNominationsAll(
+    params: ListNominationsParams = {},
+    options: RequestOptions = {},
+  ): AsyncGenerator<NominationResponse> {
+    let offset = params.offset ?? 0;
+    for (;;) {
+      const page = await this.listNominations({ ...params, offset }, options);
+      const items = page.nominations ?? [];
+      yield* items;
+      offset += items.length;
+      if (items.length === 0 || offset >= page.total) {
+        return;
+      }
+    }
+  }
+
+  /**
+   * GET /api/v1/rules: List rules.
+   *
+   * Lists proposed and final Federal Register rules, newest first.
+   */
+  async listRules(
+    params: ListRulesParams = {},
+    options: RequestOptions = {},
+  ): Promise<RuleListResponse> {
+    return this.request(
+      'GET',
+      '/api/v1/rules',
+      {
+        query: {
+          agency: params.agency,
+          type: params.type,
+          rin: params.rin,
+          query: params.query,
+          limit: params.limit,
+          offset: params.offset,
+        },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * Yields the rules of every page of listRules, starting at params.offset and fetching
+   * params.limit at a time.
+   */
+  async *listRulesAll(
+    params: ListRulesParams = {},
+    options: RequestOptions = {},
+  ): AsyncGenerator<RuleResponse> {
+    let offset = params.offset ?? 0;
+    for (;;) {
+      const page = await this.listRules({ ...params, offset }, options);
+      const items = page.rules ?? [];
+      yield* items;
+      offset += items.length;
+      if (items.length === 0 || offset >= page.total) {
+        return;
+      }
+    }
+  }
+
+  /** GET /api/v1/admin/tenants: List tenants. */
+  async listTenants(options: RequestOptions = {}): Promise<TenantListResponse> {
+    return this.request('GET', '/api/v1/admin/tenants', options);
+  }
+
+  /**
+   * GET /api/v1/treaties: List treaties.
+   *
+   * Lists treaties transmitted to the Senate, most recent action first.
+   */
+  async listTreaties(
+    params: ListTreatiesParams = {},
+    options: RequestOptions = {},
+  ): Promise<TreatyListResponse> {
+    return this.request(
+      'GET',
+      '/api/v1/treaties',
+      {
+        query: {
+          congress: params.congress,
+          query: params.query,
+          limit: params.limit,
+          offset: params.offset,
+        },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * Yields the treaties of every page of listTreaties, starting at params.offset and fetching
+   * params.limit at a time.
+   */
+  async *listTreatiesAll(
+    params: ListTreatiesParams = {},
+    options: RequestOptions = {},
+  ): AsyncGenerator<TreatyResponse> {
+    let offset = params.offset ?? 0;
+    for (;;) {
+      const page = await this.listTreaties({ ...params, offset }, options);
+      const items = page.treaties ?? [];
+      yield* items;
+      offset += items.length;
+      if (items.length === 0 || offset >= page.total) {
+        return;
+      }
+    }
+  }
+
+  /** GET /api/v1/admin/users/{id}/alerts: List a user's keyword alerts. */
+  async listUserKeywordAlerts(
+    id: number,
+    options: RequestOptions = {},
+  ): Promise<KeywordAlertsResponse> {
+    return this.request('GET', `/api/v1/admin/users/${path(id)}/alerts`, options);
+  }
+
+  /**
+   * GET /api/v1/auth/login: Sign in.
+   *
+   * Redirects to the configured identity provider (Google, GitHub, or any OpenID Connect issuer),
+   * which redirects back to the callback endpoint.
+   */
+  async login(options: RequestOptions = {}): Promise<void> {
+    await this.send('GET', '/api/v1/auth/login', options);
+  }
+
+  /**
+   * GET /api/v1/auth/callback: Complete sign-in.
+   *
+   * Redeems the identity provider's authorization code and returns a session token. Users are
+   * created on first sign-in with the reader role; their watchlist, saved searches, and documents
+   * are theirs across sessions.
+   */
+  async loginCallback(
+    params: LoginCallbackParams = {},
+    options: RequestOptions = {},
+  ): Promise<SessionResponse> {
+    return this.request(
+      'GET',
+      '/api/v1/auth/callback',
+      { query: { code: params.code, state: params.state }, ...options },
+    );
+  }
+
+  /**
+   * POST /api/v1/admin/deltas/recompute: Recompute cached deltas.
+   *
+   * Starts a background job recomputing the cached deltas of one bill, one version pair, or all
+   * bills. Poll the returned job for progress.
+   */
+  async recomputeDeltas(
+    body: RecomputeDeltasRequest,
+    options: RequestOptions = {},
+  ): Promise<DeltaJobResponse> {
+    return this.request('POST', '/api/v1/admin/deltas/recompute', { body, ...options });
+  }
+
+  /**
+   * GET /api/v1/bills/{id}/reconcile: Compare a bill's final text with its House and Senate
+   * versions.
+   *
+   * Matches sections by number across a base version (typically conference or enrolled text) and
+   * the House and Senate versions, and reports for each whether the base followed the House, the
+   * Senate, both, or neither, or dropped the section.
+   */
+  async reconcileBillVersions(
+    id: number,
+    params: ReconcileBillVersionsParams = {},
+    options: RequestOptions = {},
+  ): Promise<ReconcileResponse> {
+    return this.request(
+      'GET',
+      `/api/v1/bills/${path(id)}/reconcile`,
+      { query: { base: params.base, house: params.house, senate: params.senate }, ...options },
+    );
+  }
+
+  /**
+   * POST /api/v1/admin/ingest-failures/{id}/requeue: Requeue an ingestion failure.
+   *
+   * Makes an open failure due for retry on the ingestor's next run, with its attempts reset.
+   */
+  async requeueIngestionFailure(
+    id: number,
+    options: RequestOptions = {},
+  ): Promise<IngestFailureResponse> {
+    return this.request('POST', `/api/v1/admin/ingest-failures/${path(id)}/requeue`, options);
+  }
+
+  /**
+   * POST /api/v1/watchlist/searches: Save a search.
+   *
+   * Saves a bill search; bills matching it are reported by the watchlist updates endpoint.
+   */
+  async saveSearch(
+    body: SaveSearchInputBody,
+    params: SaveSearchParams = {},
+    options: RequestOptions = {},
+  ): Promise<SavedSearchResponse> {
+    return this.request(
+      'POST',
+      '/api/v1/watchlist/searches',
+      { headers: { 'X-API-Key': params.apiKey }, body, ...options },
+    );
+  }
+
+  /**
+   * GET /api/v1/lex: Search legislative bills.
+   *
+   * Search and filter bills by congress, sponsor, title query, bill type, spending classification,
+   * CRS policy area, legislative subject, and Congress.gov metadata. Supports pagination via
+   * limit/offset.
+   *
+   * Bills can also be filtered by the Congress.gov bill detail stored as their metadata, with
+   * exact-match (case-sensitive) parameters such as metadata.sponsors.party=R. Accepted parameters:
+   * metadata.committeeReports.citation, metadata.latestAction.actionDate,
+   * metadata.latestAction.text, metadata.laws.number, metadata.laws.type, metadata.originChamber,
+   * metadata.originChamberCode, metadata.policyArea, metadata.sponsors.bioguideId,
+   * metadata.sponsors.party, metadata.sponsors.state.
+   */
+  async searchBills(
+    params: SearchBillsParams = {},
+    options: RequestOptions = {},
+  ): Promise<LexSearchResult> {
+    return this.request(
+      'GET',
+      '/api/v1/lex',
+      {
+        query: {
+          congress: params.congress,
+          sponsor: params.sponsor,
+          query: params.query,
+          type: params.type,
+          number: params.number,
+          jurisdiction: params.jurisdiction,
+          state: params.state,
+          spending: params.spending,
+          policyArea: params.policyArea,
+          subject: params.subject,
+          sort: params.sort,
+          order: params.order,
+          limit: params.limit,
+          offset: params.offset,
+        },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * Yields the bills of every page of searchBills, starting at params.offset and fetching
+   * params.limit at a time.
+   */
+  async *searchBillsAll(
+    params: SearchBillsParams = {},
+    options: RequestOptions = {},
+  ): AsyncGenerator<BillResponse> {
+    let offset = params.offset ?? 0;
+    for (;;) {
+      const page = await this.searchBills({ ...params, offset }, options);
+      const items = page.bills ?? [];
+      yield* items;
+      offset += items.length;
+      if (items.length === 0 || offset >= page.total) {
+        return;
+      }
+    }
+  }
+
+  /**
+   * GET /api/v1/bills/search: Search bills.
+   *
+   * Searches bills by congress, sponsor, title, bill type, spending classification, and
+   * Congress.gov metadata, sorted by update date, introduced date, or title. Supports pagination
+   * via limit/offset.
+   *
+   * Bills can also be filtered by the Congress.gov bill detail stored as their metadata, with
+   * exact-match (case-sensitive) parameters such as metadata.sponsors.party=R. Accepted parameters:
+   * metadata.committeeReports.citation, metadata.latestAction.actionDate,
+   * metadata.latestAction.text, metadata.laws.number, metadata.laws.type, metadata.originChamber,
+   * metadata.originChamberCode, metadata.policyArea, metadata.sponsors.bioguideId,
+   * metadata.sponsors.party, metadata.sponsors.state.
+   */
+  async searchBillsV1(
+    params: SearchBillsV1Params = {},
+    options: RequestOptions = {},
+  ): Promise<LexSearchResult> {
+    return this.request(
+      'GET',
+      '/api/v1/bills/search',
+      {
+        query: {
+          congress: params.congress,
+          sponsor: params.sponsor,
+          q: params.q,
+          billType: params.billType,
+          spendingOnly: params.spendingOnly,
+          facets: params.facets,
+          sort: params.sort,
+          order: params.order,
+          limit: params.limit,
+          offset: params.offset,
+        },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * Yields the bills of every page of searchBillsV1, starting at params.offset and fetching
+   * params.limit at a time.
+   */
+  async *searchBillsV1All(
+    params: SearchBillsV1Params = {},
+    options: RequestOptions = {},
+  ): AsyncGenerator<BillResponse> {
+    let offset = params.offset ?? 0;
+    for (;;) {
+      const page = await this.searchBillsV1({ ...params, offset }, options);
+      const items = page.bills ?? [];
+      yield* items;
+      offset += items.length;
+      if (items.length === 0 || offset >= page.total) {
+        return;
+      }
+    }
+  }
+
+  /**
+   * GET /api/v1/search/semantic: Find conceptually similar bills and sections.
+   *
+   * Ranks bills, CRS summaries, and sections of bill text by similarity in meaning to q, or to the
+   * bill given by billId ("find bills like this one"), using the embeddings the ingestor computes
+   * of each bill's latest summary and latest text. Returns 501 when no embedding provider is
+   * configured.
+   */
+  async searchSemantic(
+    params: SearchSemanticParams = {},
+    options: RequestOptions = {},
+  ): Promise<SemanticSearchResult> {
+    return this.request(
+      'GET',
+      '/api/v1/search/semantic',
+      {
+        query: { q: params.q, billId: params.billId, kind: params.kind, limit: params.limit },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * GET /api/v1/lex/text: Search bill text.
+   *
+   * Full-text search of bills, the text of their versions, and their actions, with phrase and
+   * proximity matching and highlighted excerpts. Answered by the configured search backend
+   * (OpenSearch or Elasticsearch); returns 501 when there is none. Drafts are not searched.
+   */
+  async searchText(
+    params: SearchTextParams = {},
+    options: RequestOptions = {},
+  ): Promise<TextSearchResult> {
+    return this.request(
+      'GET',
+      '/api/v1/lex/text',
+      {
+        query: {
+          q: params.q,
+          phrase: params.phrase,
+          slop: params.slop,
+          kind: params.kind,
+          congress: params.congress,
+          type: params.type,
+          billId: params.billId,
+          limit: params.limit,
+          offset: params.offset,
+        },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * Yields the hits of every page of searchText, starting at params.offset and fetching
+   * params.limit at a time.
+   */
+  async *searchTextAll(
+    params: SearchTextParams = {},
+    options: RequestOptions = {},
+  ): AsyncGenerator<TextSearchHit> {
+    let offset = params.offset ?? 0;
+    for (;;) {
+      const page = await this.searchText({ ...params, offset }, options);
+      const items = page.hits ?? [];
+      yield* items;
+      offset += items.length;
+      if (items.length === 0 || offset >= page.total) {
+        return;
+      }
+    }
+  }
+
+  /**
+   * PUT /api/v1/admin/database/slow-query-threshold: Set the slow query threshold.
+   *
+   * Sets how long a statement may take before the API server logs it and counts it in
+   * deltagov_db_slow_queries_total, until it restarts; DB_SLOW_QUERY_THRESHOLD sets the initial
+   * value. Applies to the API server handling the request only, not to other replicas or the
+   * ingestor.
+   */
+  async setSlowQueryThreshold(
+    body: SlowQueryThresholdRequest,
+    options: RequestOptions = {},
+  ): Promise<DatabaseStatsResponse> {
+    return this.request('PUT', '/api/v1/admin/database/slow-query-threshold', { body, ...options });
+  }
+
+  /**
+   * PUT /api/v1/admin/users/{id}/role: Set a user's role.
+   *
+   * Changes what a user's API key may do: readers read and keep a watchlist, editors also upload
+   * documents and drafts, and admins also use the admin endpoints.
+   */
+  async setUserRole(
+    id: number,
+    body: SetUserRoleInputBody,
+    options: RequestOptions = {},
+  ): Promise<AdminUserResponse> {
+    return this.request('PUT', `/api/v1/admin/users/${path(id)}/role`, { body, ...options });
+  }
+
+  /**
+   * POST /api/v1/admin/reingest: Re-ingest bills by filter.
+   *
+   * Queues a job refreshing the stored federal bills matching a filter from Congress.gov: detail,
+   * related data, and every text version, as if each had changed. The ingestor works through it a
+   * batch per polling cycle; poll the returned job for progress.
+   */
+  async startReingest(
+    body: ReingestRequest,
+    options: RequestOptions = {},
+  ): Promise<ReingestJobResponse> {
+    return this.request('POST', '/api/v1/admin/reingest', { body, ...options });
+  }
+
+  /**
+   * GET /api/v1/bills/{billId}/diff/{fromVersion}/{toVersion}/stream: Stream diff between two bill
+   * versions.
+   *
+   * Streams the diff as it is computed: a start record, one record per line (lineNumber, type,
+   * text), then an end record with totals. Records are NDJSON, or Server-Sent Events with
+   * format=sse. A failure mid-stream ends with an error record.
+   */
+  async *streamDiff(
+    billId: number,
+    fromVersion: number,
+    toVersion: number,
+    options: RequestOptions = {},
+  ): AsyncGenerator<DiffStreamRecord> {
+    const response = await this.send(
+      'GET',
+      `/api/v1/bills/${path(billId)}/diff/${path(fromVersion)}/${path(toVersion)}/stream`,
+      { query: { format: 'ndjson' }, accept: 'application/x-ndjson', ...options },
+    );
+    yield* readNdjson<DiffStreamRecord>(response);
+  }
+
+  /**
+   * GET /api/v1/provenance/section: Trace a provision across bills.
+   *
+   * Finds the sections of stored bill versions with the same language as a provision — word for
+   * word or nearly, regardless of numbering and formatting — earliest first, showing where
+   * language in an omnibus or package originated. Give the provision as text, or as versionId and
+   * the section's anchor to trace it to earlier versions. Sections are fingerprinted by the
+   * ingestor, so recently fetched versions may not appear yet.
+   */
+  async traceSectionProvenance(
+    params: TraceSectionProvenanceParams = {},
+    options: RequestOptions = {},
+  ): Promise<SectionProvenanceResult> {
+    return this.request(
+      'GET',
+      '/api/v1/provenance/section',
+      {
+        query: {
+          text: params.text,
+          versionId: params.versionId,
+          anchor: params.anchor,
+          minSimilarity: params.minSimilarity,
+          limit: params.limit,
+        },
+        ...options,
+      },
+    );
+  }
+
+  /**
+   * DELETE /api/v1/watchlist/bills/{id}: Unwatch a bill.
+   *
+   * Removes a bill from the caller's watchlist.
+   */
+  async unwatchBill(
+    id: number,
+    params: UnwatchBillParams = {},
+    options: RequestOptions = {},
+  ): Promise<void> {
+    await this.send(
+      'DELETE',
+      `/api/v1/watchlist/bills/${path(id)}`,
+      { headers: { 'X-API-Key': params.apiKey }, ...options },
+    );
+  }
+
+  /**
+   * DELETE /api/v1/admin/users/{id}/watchlist/bills/{billId}: Remove a bill from a user's
+   * watchlist.
+   */
+  async unwatchUserBill(id: number, billId: number, options: RequestOptions = {}): Promise<void> {
+    await this.send(
+      'DELETE',
+      `/api/v1/admin/users/${path(id)}/watchlist/bills/${path(billId)}`,
+      options,
+    );
+  }
+
+  /**
+   * PUT /api/v1/watchlist/alerts/{id}: Update a keyword alert.
+   *
+   * Renames a keyword alert and replaces its query; earlier matches are kept.
+   */
+  async updateKeywordAlert(
+    id: number,
+    body: KeywordAlertBody,
+    params: UpdateKeywordAlertParams = {},
+    options: RequestOptions = {},
+  ): Promise<KeywordAlertResponse> {
+    return this.request(
+      'PUT',
+      `/api/v1/watchlist/alerts/${path(id)}`,
+      { headers: { 'X-API-Key': params.apiKey }, body, ...options },
+    );
+  }
+
+  /**
+   * POST /api/v1/versions/{id}/provenance/verify: Verify a version's provenance.
+   *
+   * Hashes the version's stored raw text and re-fetches its source URL, comparing both with the
+   * hash recorded when it was retrieved. verified is true when the stored text is unchanged and the
+   * source still serves the same bytes; a source revised since retrieval shows a different hash
+   * and, usually, a newer Last-Modified.
+   */
+  async verifyVersionProvenance(
+    id: number,
+    options: RequestOptions = {},
+  ): Promise<ProvenanceVerification> {
+    return this.request('POST', `/api/v1/versions/${path(id)}/provenance/verify`, options);
+  }
+
+  /**
+   * PUT /api/v1/watchlist/bills/{id}: Watch a bill.
+   *
+   * Adds a bill to the caller's watchlist.
+   */
+  async watchBill(
+    id: number,
+    params: WatchBillParams = {},
+    options: RequestOptions = {},
+  ): Promise<void> {
+    await this.send(
+      'PUT',
+      `/api/v1/watchlist/bills/${path(id)}`,
+      { headers: { 'X-API-Key': params.apiKey }, ...options },
+    );
+  }
+
+}
+
+/** Formats a path parameter. */
+function path(value: string | number): string {
+  return encodeURIComponent(String(value));
+}
+
+/** Yields the records of an NDJSON response as they arrive. */
+async function* readNdjson<T>(response: Response): AsyncGenerator<T> {
+  if (!response.body) {
+    return;
+  }
+  const reader = response.body.pipeThrough(new TextDecoderStream()).getReader();
+  let buffer = '';
+  try {
+    for (;;) {
+      const { done, value } = await reader.read();
+      if (value) {
+        buffer += value;
+      }
+      let newline: number;
+      while ((newline = buffer.indexOf('\n')) >= 0) {
+        const line = buffer.slice(0, newline).trim();
+        buffer = buffer.slice(newline + 1);
+        if (line) {
+          yield JSON.parse(line) as T;
+        }
+      }
+      if (done) {
+        break;
+      }
+    }
+    if (buffer.trim()) {
+      yield JSON.parse(buffer) as T;
+    }
+  } finally {
+    reader.releaseLock();
+  }
+}